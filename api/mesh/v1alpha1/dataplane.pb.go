@@ -209,10 +209,14 @@ type Dataplane_Networking_Outbound struct {
 	// Service name.
 	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
 	// Service port.
-	ServicePort          uint32   `protobuf:"varint,3,opt,name=service_port,json=servicePort,proto3" json:"service_port,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ServicePort uint32 `protobuf:"varint,3,opt,name=service_port,json=servicePort,proto3" json:"service_port,omitempty"`
+	// Tags that narrow down the destination to a subset of the service,
+	// e.g. a particular Pod of a StatefulSet.
+	// +optional
+	Tags                 map[string]string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Dataplane_Networking_Outbound) Reset()         { *m = Dataplane_Networking_Outbound{} }
@@ -261,6 +265,13 @@ func (m *Dataplane_Networking_Outbound) GetServicePort() uint32 {
 	return 0
 }
 
+func (m *Dataplane_Networking_Outbound) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 // Gateway describes a service that ingress should not be proxied.
 type Dataplane_Networking_Gateway struct {
 	// Tags associated with a gateway (e.g., Kong, Contour, etc) this
@@ -307,10 +318,17 @@ func (m *Dataplane_Networking_Gateway) GetTags() map[string]string {
 // TransparentProxying describes configuration for transparent proxying.
 type Dataplane_Networking_TransparentProxying struct {
 	// Port on which all traffic is being transparently redirected.
-	RedirectPort         uint32   `protobuf:"varint,1,opt,name=redirect_port,json=redirectPort,proto3" json:"redirect_port,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	RedirectPort uint32 `protobuf:"varint,1,opt,name=redirect_port,json=redirectPort,proto3" json:"redirect_port,omitempty"`
+	// List of inbound ports that are excluded from being redirected.
+	ExcludedInboundPorts []uint32 `protobuf:"varint,2,rep,packed,name=excluded_inbound_ports,json=excludedInboundPorts,proto3" json:"excluded_inbound_ports,omitempty"`
+	// List of outbound ports that are excluded from being redirected.
+	ExcludedOutboundPorts []uint32 `protobuf:"varint,3,rep,packed,name=excluded_outbound_ports,json=excludedOutboundPorts,proto3" json:"excluded_outbound_ports,omitempty"`
+	// List of outbound IP ranges (in CIDR notation) that are excluded from
+	// being redirected, e.g. 169.254.169.254/32.
+	ExcludedOutboundCidrs []string `protobuf:"bytes,4,rep,name=excluded_outbound_cidrs,json=excludedOutboundCidrs,proto3" json:"excluded_outbound_cidrs,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *Dataplane_Networking_TransparentProxying) Reset() {
@@ -347,12 +365,34 @@ func (m *Dataplane_Networking_TransparentProxying) GetRedirectPort() uint32 {
 	return 0
 }
 
+func (m *Dataplane_Networking_TransparentProxying) GetExcludedInboundPorts() []uint32 {
+	if m != nil {
+		return m.ExcludedInboundPorts
+	}
+	return nil
+}
+
+func (m *Dataplane_Networking_TransparentProxying) GetExcludedOutboundPorts() []uint32 {
+	if m != nil {
+		return m.ExcludedOutboundPorts
+	}
+	return nil
+}
+
+func (m *Dataplane_Networking_TransparentProxying) GetExcludedOutboundCidrs() []string {
+	if m != nil {
+		return m.ExcludedOutboundCidrs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Dataplane)(nil), "kuma.mesh.v1alpha1.Dataplane")
 	proto.RegisterType((*Dataplane_Networking)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking")
 	proto.RegisterType((*Dataplane_Networking_Inbound)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Inbound")
 	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Inbound.TagsEntry")
 	proto.RegisterType((*Dataplane_Networking_Outbound)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Outbound")
+	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Outbound.TagsEntry")
 	proto.RegisterType((*Dataplane_Networking_Gateway)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Gateway")
 	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Gateway.TagsEntry")
 	proto.RegisterType((*Dataplane_Networking_TransparentProxying)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.TransparentProxying")
@@ -361,37 +401,41 @@ func init() {
 func init() { proto.RegisterFile("mesh/v1alpha1/dataplane.proto", fileDescriptor_7608682fd5ea84a4) }
 
 var fileDescriptor_7608682fd5ea84a4 = []byte{
-	// 500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0x4f, 0x6b, 0x13, 0x41,
-	0x18, 0xc6, 0x99, 0x4d, 0x9a, 0xdd, 0x7d, 0xd3, 0x40, 0x99, 0x16, 0x5c, 0xb6, 0x08, 0x45, 0x0f,
-	0x86, 0x1e, 0x36, 0x49, 0x45, 0x94, 0xe2, 0x29, 0x28, 0x55, 0xa1, 0x5a, 0x86, 0x9e, 0x7a, 0x29,
-	0xd3, 0x64, 0x4c, 0x96, 0x24, 0x33, 0xcb, 0xec, 0x24, 0x35, 0x5f, 0xc1, 0x8f, 0xe0, 0xc1, 0x9b,
-	0xdf, 0xc0, 0x93, 0x27, 0x6f, 0x7e, 0x16, 0x3f, 0x45, 0x65, 0xfe, 0x6d, 0x94, 0xf6, 0xb0, 0x3d,
-	0x78, 0x9b, 0xe4, 0x7d, 0x9e, 0xdf, 0xbc, 0xcf, 0xfb, 0x0e, 0x0b, 0x0f, 0x17, 0xac, 0x9c, 0xf6,
-	0x56, 0x03, 0x3a, 0x2f, 0xa6, 0x74, 0xd0, 0x1b, 0x53, 0x45, 0x8b, 0x39, 0xe5, 0x2c, 0x2b, 0xa4,
-	0x50, 0x02, 0xe3, 0xd9, 0x72, 0x41, 0x33, 0xad, 0xc9, 0xbc, 0x26, 0xdd, 0xff, 0xd7, 0xb2, 0x60,
-	0x4a, 0xe6, 0xa3, 0xd2, 0x1a, 0xd2, 0x07, 0x2b, 0x3a, 0xcf, 0xc7, 0x54, 0xb1, 0x9e, 0x3f, 0xd8,
-	0xc2, 0xa3, 0xef, 0x11, 0xc4, 0xaf, 0x3c, 0x1d, 0xbf, 0x01, 0xe0, 0x4c, 0x5d, 0x0b, 0x39, 0xcb,
-	0xf9, 0x24, 0x41, 0x07, 0xa8, 0xdb, 0x3e, 0xea, 0x66, 0xb7, 0x2f, 0xcb, 0x2a, 0x4b, 0xf6, 0xbe,
-	0xd2, 0x93, 0xbf, 0xbc, 0xf8, 0x19, 0x84, 0xae, 0x83, 0x24, 0x30, 0x98, 0xfd, 0xbb, 0x30, 0xa7,
-	0x56, 0x42, 0xbc, 0x36, 0xfd, 0x16, 0x02, 0x6c, 0x88, 0xf8, 0x1d, 0x84, 0x39, 0xbf, 0x12, 0x4b,
-	0x3e, 0x4e, 0xd0, 0x41, 0xa3, 0xdb, 0x3e, 0xea, 0xd7, 0x6d, 0x26, 0x7b, 0x6b, 0x7d, 0xc4, 0x03,
-	0xf0, 0x29, 0x44, 0x62, 0xa9, 0x2c, 0x2c, 0x30, 0xb0, 0x41, 0x6d, 0xd8, 0x07, 0x67, 0x24, 0x15,
-	0x42, 0xb7, 0x36, 0xa1, 0x8a, 0x5d, 0xd3, 0x75, 0xd2, 0x30, 0x01, 0xeb, 0xb7, 0x76, 0x62, 0x7d,
-	0xc4, 0x03, 0xb0, 0x80, 0x3d, 0x25, 0x29, 0x2f, 0x0b, 0x2a, 0x19, 0x57, 0x97, 0x85, 0x14, 0x9f,
-	0xd6, 0x7a, 0x01, 0x4d, 0x03, 0x7e, 0x59, 0x1b, 0x7c, 0xbe, 0x81, 0x9c, 0x39, 0x06, 0xd9, 0x55,
-	0xb7, 0xff, 0x4c, 0x7f, 0x21, 0x08, 0xdd, 0x80, 0xf0, 0x13, 0x88, 0x73, 0xae, 0x98, 0xfc, 0x48,
-	0x47, 0xcc, 0xac, 0x3c, 0x1e, 0xc6, 0x3f, 0x7e, 0xff, 0x6c, 0x34, 0x65, 0xb0, 0x13, 0x90, 0x4d,
-	0x0d, 0x5f, 0x40, 0x53, 0xd1, 0x49, 0xe9, 0x86, 0x77, 0x7c, 0xdf, 0x4d, 0x64, 0xe7, 0x74, 0x52,
-	0xbe, 0xe6, 0x4a, 0xae, 0x87, 0xa0, 0xf9, 0x5b, 0x5f, 0x50, 0x10, 0x21, 0x62, 0x98, 0xe9, 0x73,
-	0x88, 0xab, 0x32, 0xde, 0x81, 0xc6, 0x8c, 0xad, 0x6d, 0x2f, 0x44, 0x1f, 0xf1, 0x1e, 0x6c, 0xad,
-	0xe8, 0x7c, 0xc9, 0xcc, 0x5b, 0x8a, 0x89, 0xfd, 0x71, 0x1c, 0xbc, 0x40, 0xe9, 0x67, 0x04, 0x91,
-	0xdf, 0x4e, 0xfd, 0x28, 0x8f, 0x21, 0x2c, 0x99, 0x5c, 0xe5, 0x23, 0x47, 0xac, 0x64, 0x53, 0x44,
-	0x7c, 0x05, 0xf7, 0x61, 0xdb, 0x1d, 0x2f, 0x0b, 0x21, 0x95, 0x59, 0x73, 0x67, 0xd8, 0xd1, 0xca,
-	0xe8, 0xb0, 0x95, 0xdc, 0xdc, 0x34, 0xba, 0x88, 0xb4, 0x9d, 0xe4, 0x4c, 0x48, 0x95, 0x7e, 0x45,
-	0x10, 0xba, 0xe5, 0x56, 0xd3, 0x42, 0xf7, 0x9c, 0x96, 0xf3, 0xff, 0x9f, 0x69, 0x9d, 0xc0, 0xee,
-	0x1d, 0x6f, 0x04, 0xf7, 0xa1, 0x23, 0xd9, 0x38, 0x97, 0x6c, 0xa4, 0x6c, 0x54, 0x64, 0xa2, 0xb6,
-	0xf5, 0xc5, 0xad, 0xc3, 0xa6, 0x8e, 0x4a, 0xb6, 0xbd, 0x42, 0x27, 0x1d, 0xc2, 0x45, 0xe4, 0x63,
-	0x5c, 0xb5, 0xcc, 0x97, 0xe4, 0xe9, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x34, 0x1f, 0xc2, 0xdc,
-	0xb4, 0x04, 0x00, 0x00,
+	// 567 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xcb, 0x6e, 0xda, 0x4e,
+	0x18, 0xc5, 0x35, 0x36, 0x01, 0xfc, 0x11, 0xa4, 0x68, 0xc2, 0xff, 0x1f, 0xe4, 0xa8, 0x12, 0x6a,
+	0x17, 0x45, 0x59, 0x18, 0x48, 0xaf, 0x4a, 0xbb, 0xa2, 0xad, 0x7a, 0x91, 0xd2, 0x44, 0xa3, 0xac,
+	0xb2, 0x41, 0x13, 0x7b, 0x0a, 0x16, 0x60, 0x5b, 0xe3, 0x81, 0x84, 0xf7, 0xe8, 0xaa, 0x8b, 0x3e,
+	0x48, 0x57, 0xdd, 0xf5, 0x0d, 0xfa, 0x06, 0x5d, 0x74, 0xd1, 0x67, 0x48, 0x35, 0x37, 0xd3, 0x8a,
+	0xa8, 0x02, 0x55, 0xdd, 0x0d, 0x7c, 0xe7, 0xfc, 0x3c, 0x9c, 0xf3, 0x19, 0xb8, 0x35, 0x65, 0xf9,
+	0xa8, 0x33, 0xef, 0xd1, 0x49, 0x36, 0xa2, 0xbd, 0x4e, 0x44, 0x05, 0xcd, 0x26, 0x34, 0x61, 0x41,
+	0xc6, 0x53, 0x91, 0x62, 0x3c, 0x9e, 0x4d, 0x69, 0x20, 0x35, 0x81, 0xd5, 0xf8, 0xfb, 0xbf, 0x5b,
+	0xa6, 0x4c, 0xf0, 0x38, 0xcc, 0xb5, 0xc1, 0xdf, 0x9b, 0xd3, 0x49, 0x1c, 0x51, 0xc1, 0x3a, 0xf6,
+	0xa0, 0x07, 0xb7, 0xbf, 0x01, 0x78, 0xcf, 0x2d, 0x1d, 0xbf, 0x02, 0x48, 0x98, 0xb8, 0x4c, 0xf9,
+	0x38, 0x4e, 0x86, 0x4d, 0xd4, 0x42, 0xed, 0xda, 0x61, 0x3b, 0x58, 0x7d, 0x58, 0x50, 0x58, 0x82,
+	0xb7, 0x85, 0x9e, 0xfc, 0xe2, 0xc5, 0x0f, 0xa0, 0x62, 0x6e, 0xd0, 0x74, 0x14, 0x66, 0xff, 0x26,
+	0xcc, 0xb1, 0x96, 0x10, 0xab, 0xf5, 0xbf, 0x7a, 0x00, 0x4b, 0x22, 0x7e, 0x03, 0x95, 0x38, 0xb9,
+	0x48, 0x67, 0x49, 0xd4, 0x44, 0x2d, 0xb7, 0x5d, 0x3b, 0xec, 0xae, 0x7b, 0x99, 0xe0, 0xb5, 0xf6,
+	0x11, 0x0b, 0xc0, 0xc7, 0x50, 0x4d, 0x67, 0x42, 0xc3, 0x1c, 0x05, 0xeb, 0xad, 0x0d, 0x3b, 0x31,
+	0x46, 0x52, 0x20, 0xe4, 0xd5, 0x86, 0x54, 0xb0, 0x4b, 0xba, 0x68, 0xba, 0x2d, 0xb4, 0xd1, 0xd5,
+	0x5e, 0x6a, 0x1f, 0xb1, 0x00, 0x9c, 0x42, 0x43, 0x70, 0x9a, 0xe4, 0x19, 0xe5, 0x2c, 0x11, 0x83,
+	0x8c, 0xa7, 0x57, 0x0b, 0x59, 0x40, 0x49, 0x81, 0x9f, 0xae, 0x0d, 0x3e, 0x5b, 0x42, 0x4e, 0x0d,
+	0x83, 0xec, 0x8a, 0xd5, 0x2f, 0xfd, 0x2f, 0x08, 0x2a, 0x26, 0x20, 0x7c, 0x17, 0xbc, 0x38, 0x11,
+	0x8c, 0xbf, 0xa3, 0x21, 0x53, 0x95, 0x7b, 0x7d, 0xef, 0xd3, 0xf7, 0xcf, 0x6e, 0x89, 0x3b, 0x3b,
+	0x0e, 0x59, 0xce, 0xf0, 0x39, 0x94, 0x04, 0x1d, 0xe6, 0x26, 0xbc, 0xa3, 0x4d, 0x9b, 0x08, 0xce,
+	0xe8, 0x30, 0x7f, 0x91, 0x08, 0xbe, 0xe8, 0x83, 0xe4, 0x6f, 0x7d, 0x40, 0x4e, 0x15, 0x11, 0xc5,
+	0xf4, 0x1f, 0x81, 0x57, 0x8c, 0xf1, 0x0e, 0xb8, 0x63, 0xb6, 0xd0, 0x77, 0x21, 0xf2, 0x88, 0x1b,
+	0xb0, 0x35, 0xa7, 0x93, 0x19, 0x53, 0xbb, 0xe4, 0x11, 0xfd, 0xe1, 0xc8, 0x79, 0x8c, 0xfc, 0xf7,
+	0x0e, 0x54, 0x6d, 0x3b, 0xeb, 0xff, 0x94, 0x3b, 0x50, 0xc9, 0x19, 0x9f, 0xc7, 0xa1, 0x21, 0x16,
+	0xb2, 0x11, 0x22, 0x76, 0x82, 0xbb, 0xb0, 0x6d, 0x8e, 0x83, 0x2c, 0xe5, 0x42, 0xd5, 0x5c, 0xef,
+	0xd7, 0xa5, 0xb2, 0x7a, 0x50, 0x6e, 0x5e, 0x5f, 0xbb, 0x6d, 0x44, 0x6a, 0x46, 0x72, 0x9a, 0x72,
+	0x81, 0x4f, 0x4c, 0x42, 0x25, 0x95, 0xd0, 0x93, 0x8d, 0xd7, 0x6b, 0x19, 0xd1, 0xdf, 0xc6, 0xf2,
+	0x11, 0x41, 0xc5, 0xac, 0x59, 0xd1, 0x1b, 0xda, 0xb0, 0x37, 0xe3, 0xff, 0x37, 0xbd, 0xfd, 0x40,
+	0xb0, 0x7b, 0xc3, 0xba, 0xe2, 0x2e, 0xd4, 0x39, 0x8b, 0x62, 0xce, 0x42, 0xa1, 0x53, 0x47, 0x2a,
+	0xf5, 0x9a, 0x7c, 0x72, 0xf9, 0xa0, 0x24, 0x53, 0x27, 0xdb, 0x56, 0xa1, 0x42, 0xbf, 0x0f, 0xff,
+	0xb3, 0xab, 0x70, 0x32, 0x8b, 0x58, 0x34, 0x30, 0xef, 0xba, 0x72, 0xea, 0x45, 0xad, 0x93, 0x86,
+	0x9d, 0x9a, 0x3d, 0x94, 0xa6, 0x1c, 0x3f, 0x84, 0xbd, 0xc2, 0x65, 0xdf, 0x69, 0x63, 0x73, 0x95,
+	0xed, 0x3f, 0x3b, 0xb6, 0xe5, 0xfc, 0xc1, 0x17, 0xc6, 0x11, 0xd7, 0xad, 0x7b, 0xab, 0xbe, 0x67,
+	0x72, 0xd8, 0x87, 0xf3, 0xaa, 0x4d, 0xfb, 0xa2, 0xac, 0xfe, 0x7a, 0xef, 0xfd, 0x1c, 0x00, 0xda,
+	0x10, 0x85, 0xb0, 0xe5, 0x05, 0x00, 0x00,
 }
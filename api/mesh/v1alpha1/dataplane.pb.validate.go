@@ -346,6 +346,8 @@ func (m *Dataplane_Networking_Outbound) Validate() error {
 		}
 	}
 
+	// no validation rules for Tags
+
 	return nil
 }
 
@@ -526,6 +528,12 @@ func (m *Dataplane_Networking_TransparentProxying) Validate() error {
 		}
 	}
 
+	// no validation rules for ExcludedInboundPorts
+
+	// no validation rules for ExcludedOutboundPorts
+
+	// no validation rules for ExcludedOutboundCidrs
+
 	return nil
 }
 
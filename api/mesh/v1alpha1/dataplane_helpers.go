@@ -198,6 +198,16 @@ func (d *Dataplane_Networking_Outbound) MatchTags(selector TagSelector) bool {
 	return service == MatchAllTag || service == d.Service
 }
 
+// DestinationTags returns tags of the destination of an outbound, i.e. `service` tag and tags of a subset if any.
+func (d *Dataplane_Networking_Outbound) DestinationTags() map[string]string {
+	tags := map[string]string{}
+	for key, value := range d.Tags {
+		tags[key] = value
+	}
+	tags[ServiceTag] = d.Service
+	return tags
+}
+
 const MatchAllTag = "*"
 
 type TagSelector map[string]string
@@ -25,10 +25,13 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // DataplaneInsight defines the observed state of a Dataplane.
 type DataplaneInsight struct {
 	// List of ADS subscriptions created by a given Dataplane.
-	Subscriptions        []*DiscoverySubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	Subscriptions []*DiscoverySubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	// Registration of a Dataplane by `kuma-dp` itself.
+	// Absent if a Dataplane has been created by other means, e.g. `kumactl apply`.
+	SelfRegistration     *SelfRegistration `protobuf:"bytes,2,opt,name=self_registration,json=selfRegistration,proto3" json:"self_registration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *DataplaneInsight) Reset()         { *m = DataplaneInsight{} }
@@ -63,6 +66,13 @@ func (m *DataplaneInsight) GetSubscriptions() []*DiscoverySubscription {
 	return nil
 }
 
+func (m *DataplaneInsight) GetSelfRegistration() *SelfRegistration {
+	if m != nil {
+		return m.SelfRegistration
+	}
+	return nil
+}
+
 // DiscoverySubscription describes a single ADS subscription
 // created by a Dataplane to the Control Plane.
 // Ideally, there should be only one such subscription per Dataplane lifecycle.
@@ -82,10 +92,13 @@ type DiscoverySubscription struct {
 	// Time when a given Dataplane disconnected from the Control Plane.
 	DisconnectTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=disconnect_time,json=disconnectTime,proto3" json:"disconnect_time,omitempty"`
 	// Status of the ADS subscription.
-	Status               *DiscoverySubscriptionStatus `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	Status *DiscoverySubscriptionStatus `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// Status of the Envoy process, as reported by `kuma-dp` at the time
+	// the Dataplane connected to the Control Plane.
+	Envoy                *EnvoyProcessStatus `protobuf:"bytes,6,opt,name=envoy,proto3" json:"envoy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *DiscoverySubscription) Reset()         { *m = DiscoverySubscription{} }
@@ -148,6 +161,13 @@ func (m *DiscoverySubscription) GetStatus() *DiscoverySubscriptionStatus {
 	return nil
 }
 
+func (m *DiscoverySubscription) GetEnvoy() *EnvoyProcessStatus {
+	if m != nil {
+		return m.Envoy
+	}
+	return nil
+}
+
 // DiscoverySubscriptionStatus defines status of an ADS subscription.
 type DiscoverySubscriptionStatus struct {
 	// Time when status of a given ADS subscription was most recently updated.
@@ -293,11 +313,125 @@ func (m *DiscoveryServiceStats) GetResponsesRejected() uint64 {
 	return 0
 }
 
+// EnvoyProcessStatus describes an Envoy process supervised by `kuma-dp`.
+type EnvoyProcessStatus struct {
+	// Number of times Envoy has crashed since `kuma-dp` started.
+	Crashes uint32 `protobuf:"varint,1,opt,name=crashes,proto3" json:"crashes,omitempty"`
+	// Exit code of the most recent Envoy crash.
+	// -1 indicates that Envoy was terminated by a signal.
+	LastExitCode int32 `protobuf:"varint,2,opt,name=last_exit_code,json=lastExitCode,proto3" json:"last_exit_code,omitempty"`
+	// Hot restart epoch of the Envoy process.
+	RestartEpoch         uint32   `protobuf:"varint,3,opt,name=restart_epoch,json=restartEpoch,proto3" json:"restart_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnvoyProcessStatus) Reset()         { *m = EnvoyProcessStatus{} }
+func (m *EnvoyProcessStatus) String() string { return proto.CompactTextString(m) }
+func (*EnvoyProcessStatus) ProtoMessage()    {}
+func (*EnvoyProcessStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_35794f05b529b342, []int{4}
+}
+
+func (m *EnvoyProcessStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnvoyProcessStatus.Unmarshal(m, b)
+}
+func (m *EnvoyProcessStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnvoyProcessStatus.Marshal(b, m, deterministic)
+}
+func (m *EnvoyProcessStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnvoyProcessStatus.Merge(m, src)
+}
+func (m *EnvoyProcessStatus) XXX_Size() int {
+	return xxx_messageInfo_EnvoyProcessStatus.Size(m)
+}
+func (m *EnvoyProcessStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnvoyProcessStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnvoyProcessStatus proto.InternalMessageInfo
+
+func (m *EnvoyProcessStatus) GetCrashes() uint32 {
+	if m != nil {
+		return m.Crashes
+	}
+	return 0
+}
+
+func (m *EnvoyProcessStatus) GetLastExitCode() int32 {
+	if m != nil {
+		return m.LastExitCode
+	}
+	return 0
+}
+
+func (m *EnvoyProcessStatus) GetRestartEpoch() uint32 {
+	if m != nil {
+		return m.RestartEpoch
+	}
+	return 0
+}
+
+// SelfRegistration marks a Dataplane that has been registered by `kuma-dp` itself.
+type SelfRegistration struct {
+	// Version of the Dataplane resource most recently written on behalf of `kuma-dp`.
+	// Once the Dataplane has been changed by other means, it is no longer
+	// considered self-registered.
+	DataplaneVersion string `protobuf:"bytes,1,opt,name=dataplane_version,json=dataplaneVersion,proto3" json:"dataplane_version,omitempty"`
+	// Time when the Dataplane was most recently registered by `kuma-dp`.
+	RegistrationTime     *timestamp.Timestamp `protobuf:"bytes,2,opt,name=registration_time,json=registrationTime,proto3" json:"registration_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SelfRegistration) Reset()         { *m = SelfRegistration{} }
+func (m *SelfRegistration) String() string { return proto.CompactTextString(m) }
+func (*SelfRegistration) ProtoMessage()    {}
+func (*SelfRegistration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_35794f05b529b342, []int{5}
+}
+
+func (m *SelfRegistration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelfRegistration.Unmarshal(m, b)
+}
+func (m *SelfRegistration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelfRegistration.Marshal(b, m, deterministic)
+}
+func (m *SelfRegistration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelfRegistration.Merge(m, src)
+}
+func (m *SelfRegistration) XXX_Size() int {
+	return xxx_messageInfo_SelfRegistration.Size(m)
+}
+func (m *SelfRegistration) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelfRegistration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelfRegistration proto.InternalMessageInfo
+
+func (m *SelfRegistration) GetDataplaneVersion() string {
+	if m != nil {
+		return m.DataplaneVersion
+	}
+	return ""
+}
+
+func (m *SelfRegistration) GetRegistrationTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.RegistrationTime
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DataplaneInsight)(nil), "kuma.mesh.v1alpha1.DataplaneInsight")
 	proto.RegisterType((*DiscoverySubscription)(nil), "kuma.mesh.v1alpha1.DiscoverySubscription")
 	proto.RegisterType((*DiscoverySubscriptionStatus)(nil), "kuma.mesh.v1alpha1.DiscoverySubscriptionStatus")
 	proto.RegisterType((*DiscoveryServiceStats)(nil), "kuma.mesh.v1alpha1.DiscoveryServiceStats")
+	proto.RegisterType((*EnvoyProcessStatus)(nil), "kuma.mesh.v1alpha1.EnvoyProcessStatus")
+	proto.RegisterType((*SelfRegistration)(nil), "kuma.mesh.v1alpha1.SelfRegistration")
 }
 
 func init() {
@@ -305,37 +439,47 @@ func init() {
 }
 
 var fileDescriptor_35794f05b529b342 = []byte{
-	// 506 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x4d, 0x8e, 0x13, 0x31,
-	0x10, 0x85, 0xe5, 0xce, 0x8f, 0x66, 0x1c, 0x66, 0x08, 0x96, 0x66, 0xe8, 0x09, 0x0b, 0xa2, 0x48,
-	0x23, 0x85, 0x05, 0xdd, 0x9a, 0x41, 0xac, 0x58, 0x20, 0x42, 0x36, 0x59, 0x81, 0x3a, 0xb0, 0x61,
-	0xd3, 0x72, 0xec, 0x22, 0x31, 0xe3, 0xd8, 0x2d, 0xdb, 0x09, 0xe2, 0x0a, 0x9c, 0x80, 0x03, 0x70,
-	0x02, 0x96, 0xac, 0xb8, 0x08, 0x07, 0xe0, 0x16, 0xc8, 0xee, 0xee, 0x24, 0xfc, 0x88, 0x90, 0x5d,
-	0x94, 0xf7, 0xbe, 0x72, 0xd5, 0xab, 0x6a, 0x7c, 0xb9, 0x04, 0xbb, 0x48, 0xd7, 0x57, 0x54, 0x16,
-	0x0b, 0x7a, 0x95, 0x72, 0xea, 0x68, 0x21, 0xa9, 0x82, 0x5c, 0x28, 0x2b, 0xe6, 0x0b, 0x97, 0x14,
-	0x46, 0x3b, 0x4d, 0xc8, 0xcd, 0x6a, 0x49, 0x13, 0xef, 0x4d, 0x6a, 0x6f, 0xef, 0xfe, 0x5c, 0xeb,
-	0xb9, 0x84, 0x34, 0x38, 0x66, 0xab, 0xb7, 0xa9, 0x13, 0x4b, 0xb0, 0x8e, 0x2e, 0x8b, 0x12, 0xea,
-	0xdd, 0x5d, 0x53, 0x29, 0x38, 0x75, 0x90, 0xd6, 0x3f, 0x4a, 0x61, 0xc0, 0x70, 0x77, 0x5c, 0x3f,
-	0x34, 0x29, 0xdf, 0x21, 0x2f, 0xf0, 0x89, 0x5d, 0xcd, 0x2c, 0x33, 0xa2, 0x70, 0x42, 0x2b, 0x1b,
-	0xa3, 0x7e, 0x63, 0xd8, 0xb9, 0x7e, 0x90, 0xfc, 0xf9, 0x72, 0x32, 0x16, 0x96, 0xe9, 0x35, 0x98,
-	0x0f, 0xd3, 0x1d, 0x22, 0xfb, 0x95, 0x1f, 0x7c, 0x8f, 0xf0, 0xd9, 0x5f, 0x8d, 0xe4, 0x02, 0x47,
-	0x82, 0xc7, 0xa8, 0x8f, 0x86, 0xc7, 0xa3, 0xe3, 0xaf, 0x3f, 0xbe, 0x35, 0x9a, 0x26, 0xea, 0xa2,
-	0x2c, 0x12, 0x9c, 0x8c, 0xf1, 0x05, 0xd3, 0xca, 0x19, 0x2d, 0xf3, 0x4d, 0x0c, 0x8e, 0x2a, 0x06,
-	0xb9, 0xe0, 0x71, 0xf4, 0x3b, 0x71, 0x5e, 0x79, 0x5f, 0x56, 0x83, 0x04, 0xe7, 0x84, 0x93, 0x09,
-	0xbe, 0xc5, 0xb4, 0x52, 0xc0, 0x5c, 0xee, 0x33, 0x89, 0x1b, 0x7d, 0x34, 0xec, 0x5c, 0xf7, 0x92,
-	0x32, 0xb0, 0xa4, 0x0e, 0x2c, 0x79, 0x55, 0x07, 0x36, 0xc2, 0xbe, 0x68, 0xeb, 0x0b, 0x8a, 0x8e,
-	0x50, 0xd6, 0xa9, 0x58, 0xaf, 0x92, 0xe7, 0xf8, 0x36, 0xf7, 0x43, 0xec, 0x54, 0x6b, 0xee, 0xab,
-	0x96, 0x9d, 0x6e, 0x91, 0x50, 0x64, 0x8a, 0xdb, 0xd6, 0x51, 0xb7, 0xb2, 0x71, 0x2b, 0xb0, 0xe9,
-	0x7f, 0x87, 0x3a, 0x0d, 0x58, 0xd5, 0xde, 0x47, 0xe4, 0x87, 0xae, 0x4a, 0x0d, 0x3e, 0x35, 0xf0,
-	0xbd, 0x7f, 0x30, 0x64, 0x8c, 0xbb, 0x92, 0x5a, 0x97, 0xaf, 0x0a, 0xbf, 0xf9, 0xb2, 0x75, 0xb4,
-	0xbf, 0x75, 0xcf, 0xbc, 0x0e, 0x48, 0x68, 0xfd, 0x29, 0x6e, 0x39, 0xed, 0xa8, 0x0c, 0xe1, 0xef,
-	0x3d, 0x07, 0x30, 0x6b, 0xc1, 0xc0, 0x37, 0x60, 0xb3, 0x92, 0x23, 0x4f, 0x70, 0x83, 0x71, 0x5b,
-	0xad, 0xe0, 0x00, 0xdc, 0x53, 0x1e, 0x06, 0x6e, 0xab, 0xc4, 0x0f, 0x81, 0xa1, 0x84, 0x25, 0xaf,
-	0x23, 0x3f, 0x04, 0x96, 0x25, 0x6c, 0xb8, 0x8d, 0xdb, 0x07, 0xc3, 0x86, 0xdb, 0xc1, 0x67, 0xb4,
-	0x7b, 0xfa, 0x3b, 0x32, 0xb9, 0xc4, 0xa7, 0x06, 0x6c, 0xa1, 0x95, 0x05, 0x9b, 0x5b, 0x50, 0x2e,
-	0xac, 0xa4, 0x99, 0x9d, 0x6c, 0xfe, 0x9d, 0x82, 0x72, 0xe4, 0x31, 0x3e, 0xdf, 0xda, 0x28, 0xbb,
-	0x51, 0xfa, 0xbd, 0x04, 0x3e, 0x87, 0xf2, 0x1b, 0x68, 0x66, 0x67, 0x1b, 0xf5, 0xd9, 0x8e, 0x48,
-	0x1e, 0x62, 0xb2, 0xc5, 0x0c, 0xbc, 0x03, 0xe6, 0x80, 0x87, 0xe8, 0x9b, 0xd9, 0x9d, 0x8d, 0x92,
-	0x55, 0xc2, 0x08, 0xbf, 0x39, 0xaa, 0xa7, 0x99, 0xb5, 0xc3, 0x2d, 0x3c, 0xfa, 0x19, 0x00, 0x00,
-	0xff, 0xff, 0x8c, 0xbe, 0x8f, 0xad, 0x90, 0x04, 0x00, 0x00,
+	// 670 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xcf, 0x6e, 0x13, 0x3f,
+	0x10, 0xc7, 0xb5, 0xf9, 0xf7, 0x6b, 0xdd, 0xa6, 0xbf, 0xc4, 0x52, 0xcb, 0xb6, 0x1c, 0xa8, 0x42,
+	0x8b, 0x8a, 0x10, 0x1b, 0xb5, 0x88, 0x13, 0x48, 0x88, 0x36, 0x15, 0xea, 0x89, 0xe2, 0x00, 0x07,
+	0x2e, 0x2b, 0x77, 0x3d, 0x4d, 0x4c, 0x37, 0xeb, 0x95, 0xc7, 0x09, 0xed, 0x1b, 0x20, 0x9e, 0x00,
+	0x89, 0x2b, 0x4f, 0xc0, 0x81, 0x03, 0x27, 0x5e, 0x87, 0xb7, 0x40, 0xf6, 0xee, 0x26, 0xa1, 0x8d,
+	0x08, 0xb9, 0x25, 0xdf, 0x99, 0xcf, 0x77, 0x3c, 0x9e, 0x59, 0x93, 0xdd, 0x01, 0x60, 0xbf, 0x3d,
+	0xda, 0xe7, 0x71, 0xda, 0xe7, 0xfb, 0x6d, 0xc1, 0x0d, 0x4f, 0x63, 0x9e, 0x40, 0x28, 0x13, 0x94,
+	0xbd, 0xbe, 0x09, 0x52, 0xad, 0x8c, 0xa2, 0xf4, 0x62, 0x38, 0xe0, 0x81, 0xcd, 0x0d, 0x8a, 0xdc,
+	0xad, 0x3b, 0x3d, 0xa5, 0x7a, 0x31, 0xb4, 0x5d, 0xc6, 0xd9, 0xf0, 0xbc, 0x6d, 0xe4, 0x00, 0xd0,
+	0xf0, 0x41, 0x9a, 0x41, 0x5b, 0xb7, 0x46, 0x3c, 0x96, 0x82, 0x1b, 0x68, 0x17, 0x3f, 0xb2, 0x40,
+	0xeb, 0xbb, 0x47, 0x1a, 0x9d, 0xa2, 0xd2, 0x49, 0x56, 0x88, 0xbe, 0x24, 0x75, 0x1c, 0x9e, 0x61,
+	0xa4, 0x65, 0x6a, 0xa4, 0x4a, 0xd0, 0xf7, 0xb6, 0xcb, 0x7b, 0x2b, 0x07, 0xf7, 0x83, 0x9b, 0xa5,
+	0x83, 0x8e, 0xc4, 0x48, 0x8d, 0x40, 0x5f, 0x75, 0xa7, 0x08, 0xf6, 0x27, 0x4f, 0x5f, 0x91, 0x26,
+	0x42, 0x7c, 0x1e, 0x6a, 0xe8, 0x49, 0x34, 0x9a, 0x5b, 0xd5, 0x2f, 0x6d, 0x7b, 0x7b, 0x2b, 0x07,
+	0x3b, 0xb3, 0x4c, 0xbb, 0x10, 0x9f, 0xb3, 0xa9, 0x5c, 0xd6, 0xc0, 0x6b, 0x4a, 0xeb, 0x4b, 0x99,
+	0xac, 0xcf, 0xac, 0x4d, 0x37, 0x49, 0x49, 0x0a, 0xdf, 0xdb, 0xf6, 0xf6, 0x96, 0x0f, 0x97, 0x7f,
+	0xfc, 0xfa, 0x59, 0xae, 0xe8, 0x52, 0xc3, 0x63, 0x25, 0x29, 0x68, 0x87, 0x6c, 0x46, 0x2a, 0x31,
+	0x5a, 0xc5, 0xe1, 0xf8, 0x6a, 0x0d, 0x4f, 0x22, 0x08, 0xa5, 0xf0, 0x4b, 0xd7, 0x89, 0x8d, 0x3c,
+	0xf7, 0x34, 0xbf, 0x1b, 0x97, 0x79, 0x22, 0xe8, 0x09, 0x59, 0x8d, 0x54, 0x92, 0x40, 0x64, 0x42,
+	0x7b, 0xcf, 0x7e, 0xd9, 0x35, 0xb2, 0x15, 0x64, 0x43, 0x08, 0x8a, 0x21, 0x04, 0xaf, 0x8b, 0x21,
+	0x1c, 0x12, 0x6b, 0x5a, 0xfd, 0xe6, 0x95, 0x96, 0x3c, 0xb6, 0x92, 0xb3, 0x36, 0x4a, 0x8f, 0xc8,
+	0xff, 0x42, 0x62, 0xae, 0x64, 0x6e, 0x95, 0x79, 0x6e, 0x6c, 0x6d, 0x82, 0x38, 0x93, 0x2e, 0xa9,
+	0xa1, 0xe1, 0x66, 0x88, 0x7e, 0xd5, 0xb1, 0xed, 0x7f, 0x9e, 0x53, 0xd7, 0x61, 0xf9, 0xf1, 0x3e,
+	0x79, 0xb6, 0xe9, 0xdc, 0x8a, 0x3e, 0x25, 0x55, 0x48, 0x46, 0xea, 0xca, 0xaf, 0x39, 0xcf, 0x7b,
+	0xb3, 0x3c, 0x8f, 0x6d, 0xc2, 0xa9, 0x56, 0x11, 0x20, 0x66, 0x56, 0x2c, 0x83, 0x5a, 0x9f, 0xcb,
+	0xe4, 0xf6, 0x5f, 0x2a, 0xd2, 0x0e, 0x69, 0xc4, 0x1c, 0x4d, 0x38, 0x4c, 0xed, 0x2e, 0x66, 0x8d,
+	0x7b, 0xf3, 0x1b, 0xb7, 0xcc, 0x1b, 0x87, 0xb8, 0xc6, 0x9f, 0x91, 0xaa, 0x51, 0x86, 0xc7, 0xf9,
+	0x2a, 0xcd, 0xd9, 0x4f, 0xd0, 0x23, 0x19, 0x81, 0x3d, 0x00, 0xb2, 0x8c, 0xa3, 0x4f, 0x48, 0x39,
+	0x12, 0xe8, 0x97, 0x17, 0xc5, 0x2d, 0x65, 0x61, 0x10, 0xe8, 0x57, 0x16, 0x86, 0x21, 0x83, 0x63,
+	0x51, 0x0c, 0x6c, 0x11, 0x38, 0xce, 0x60, 0x2d, 0xd0, 0xaf, 0x2d, 0x0c, 0x6b, 0x81, 0xad, 0xaf,
+	0x1e, 0x59, 0x9f, 0x19, 0xa6, 0xbb, 0x64, 0x4d, 0x03, 0xa6, 0x2a, 0x41, 0xc0, 0x10, 0x21, 0x31,
+	0x6e, 0x24, 0x15, 0x56, 0x1f, 0xab, 0x5d, 0x48, 0x0c, 0x7d, 0x4c, 0x36, 0x26, 0x69, 0x3c, 0xba,
+	0x48, 0xd4, 0x87, 0x18, 0x44, 0x0f, 0xb2, 0x2f, 0xa8, 0xc2, 0xd6, 0xc7, 0xd1, 0xe7, 0x53, 0x41,
+	0xfa, 0x90, 0xd0, 0x09, 0xa6, 0xe1, 0x3d, 0x44, 0x06, 0x84, 0xbb, 0xfa, 0x0a, 0x6b, 0x8e, 0x23,
+	0x2c, 0x0f, 0xb4, 0xae, 0x08, 0xbd, 0xb9, 0x5e, 0xd4, 0x27, 0xff, 0x45, 0x9a, 0x63, 0x1f, 0xd0,
+	0x9d, 0xad, 0xce, 0x8a, 0xbf, 0x74, 0x87, 0xb8, 0xed, 0x08, 0xe1, 0x52, 0x9a, 0x30, 0x52, 0x02,
+	0xdc, 0x69, 0xaa, 0x6c, 0xd5, 0xaa, 0xc7, 0x97, 0xd2, 0x1c, 0x29, 0x01, 0xf4, 0x2e, 0xb1, 0xcd,
+	0x18, 0xae, 0x4d, 0x08, 0xa9, 0x8a, 0xfa, 0xae, 0x7e, 0x9d, 0xad, 0xe6, 0xe2, 0xb1, 0xd5, 0x5a,
+	0x1f, 0x3d, 0xd2, 0xb8, 0xfe, 0x02, 0xd1, 0x07, 0xa4, 0x39, 0x79, 0x91, 0x47, 0xa0, 0xd1, 0x3e,
+	0x61, 0xee, 0x91, 0x61, 0x8d, 0x71, 0xe0, 0x6d, 0xa6, 0xd3, 0x17, 0xa4, 0x39, 0xfd, 0xd4, 0x65,
+	0xfb, 0x5d, 0x9a, 0xbb, 0xdf, 0x8d, 0x69, 0xc8, 0xca, 0x87, 0xe4, 0xdd, 0x52, 0x31, 0xd3, 0xb3,
+	0x9a, 0x23, 0x1e, 0xfd, 0x1e, 0x00, 0x13, 0x27, 0x36, 0x42, 0x28, 0x06, 0x00, 0x00,
 }
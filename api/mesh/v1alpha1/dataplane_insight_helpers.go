@@ -66,6 +66,13 @@ func (ds *DataplaneInsight) GetLatestSubscription() (*DiscoverySubscription, *ti
 	return ds.Subscriptions[idx], latest
 }
 
+// GetEnvoyProcessStatus returns status of the Envoy process as reported
+// within the latest subscription.
+func (ds *DataplaneInsight) GetEnvoyProcessStatus() *EnvoyProcessStatus {
+	subscription, _ := ds.GetLatestSubscription()
+	return subscription.GetEnvoy()
+}
+
 func (ds *DataplaneInsight) Sum(v func(*DiscoverySubscription) uint64) uint64 {
 	var result uint64 = 0
 	for _, s := range ds.GetSubscriptions() {
@@ -103,3 +110,10 @@ func (s *DiscoverySubscriptionStatus) StatsOf(typeUrl string) *DiscoveryServiceS
 		return &DiscoveryServiceStats{}
 	}
 }
+
+// IsSelfRegisteredAt tells whether a given version of a Dataplane
+// has been written on behalf of `kuma-dp`.
+func (ds *DataplaneInsight) IsSelfRegisteredAt(dataplaneVersion string) bool {
+	registration := ds.GetSelfRegistration()
+	return registration != nil && registration.DataplaneVersion == dataplaneVersion
+}
@@ -141,6 +141,64 @@ var _ = Describe("DataplaneHelpers", func() {
 			})
 		})
 
+		Describe("GetEnvoyProcessStatus()", func() {
+
+			It("should return `nil` when there are no subscriptions", func() {
+				// given
+				status.Subscriptions = nil
+
+				// expect
+				Expect(status.GetEnvoyProcessStatus()).To(BeNil())
+			})
+
+			It("should return status reported within the latest subscription", func() {
+				// given
+				status.Subscriptions = []*DiscoverySubscription{
+					{
+						Id:          "1",
+						ConnectTime: util_proto.MustTimestampProto(t1),
+						Envoy: &EnvoyProcessStatus{
+							Crashes: 1,
+						},
+					},
+					{
+						Id:          "2",
+						ConnectTime: util_proto.MustTimestampProto(t2),
+						Envoy: &EnvoyProcessStatus{
+							Crashes:      2,
+							LastExitCode: 1,
+						},
+					},
+				}
+
+				// when
+				envoy := status.GetEnvoyProcessStatus()
+
+				// then
+				Expect(envoy).To(BeIdenticalTo(status.Subscriptions[1].Envoy))
+			})
+		})
+
+		Describe("IsSelfRegisteredAt()", func() {
+
+			It("should return `false` when a Dataplane hasn't been registered by kuma-dp", func() {
+				// expect
+				Expect(status.IsSelfRegisteredAt("1")).To(BeFalse())
+			})
+
+			It("should return `true` only for the version written on behalf of kuma-dp", func() {
+				// given
+				status.SelfRegistration = &SelfRegistration{
+					DataplaneVersion: "2",
+					RegistrationTime: util_proto.MustTimestampProto(t1),
+				}
+
+				// expect
+				Expect(status.IsSelfRegisteredAt("2")).To(BeTrue())
+				Expect(status.IsSelfRegisteredAt("3")).To(BeFalse())
+			})
+		})
+
 		Describe("Sum()", func() {
 
 			It("should return `0` when there are no subscriptions", func() {
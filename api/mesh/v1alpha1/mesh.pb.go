@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	math "math"
 )
 
@@ -460,10 +461,15 @@ type LoggingBackend struct {
 	// Types that are valid to be assigned to Type:
 	//	*LoggingBackend_File_
 	//	*LoggingBackend_Tcp_
-	Type                 isLoggingBackend_Type `protobuf_oneof:"type"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Type isLoggingBackend_Type `protobuf_oneof:"type"`
+	// Structured format of access logs. Every access log entry is rendered
+	// as a single-line JSON object with the given keys, where each value is
+	// a format string with the same placeholders as in `format`.
+	// Cannot be used together with `format`.
+	JsonFormat           map[string]string `protobuf:"bytes,5,rep,name=json_format,json=jsonFormat,proto3" json:"json_format,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *LoggingBackend) Reset()         { *m = LoggingBackend{} }
@@ -517,10 +523,22 @@ type LoggingBackend_Tcp_ struct {
 	Tcp *LoggingBackend_Tcp `protobuf:"bytes,4,opt,name=tcp,proto3,oneof"`
 }
 
+type LoggingBackend_Syslog_ struct {
+	Syslog *LoggingBackend_Syslog `protobuf:"bytes,6,opt,name=syslog,proto3,oneof"`
+}
+
+type LoggingBackend_Http_ struct {
+	Http *LoggingBackend_Http `protobuf:"bytes,7,opt,name=http,proto3,oneof"`
+}
+
 func (*LoggingBackend_File_) isLoggingBackend_Type() {}
 
 func (*LoggingBackend_Tcp_) isLoggingBackend_Type() {}
 
+func (*LoggingBackend_Syslog_) isLoggingBackend_Type() {}
+
+func (*LoggingBackend_Http_) isLoggingBackend_Type() {}
+
 func (m *LoggingBackend) GetType() isLoggingBackend_Type {
 	if m != nil {
 		return m.Type
@@ -542,20 +560,46 @@ func (m *LoggingBackend) GetTcp() *LoggingBackend_Tcp {
 	return nil
 }
 
+func (m *LoggingBackend) GetSyslog() *LoggingBackend_Syslog {
+	if x, ok := m.GetType().(*LoggingBackend_Syslog_); ok {
+		return x.Syslog
+	}
+	return nil
+}
+
+func (m *LoggingBackend) GetHttp() *LoggingBackend_Http {
+	if x, ok := m.GetType().(*LoggingBackend_Http_); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (m *LoggingBackend) GetJsonFormat() map[string]string {
+	if m != nil {
+		return m.JsonFormat
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*LoggingBackend) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*LoggingBackend_File_)(nil),
 		(*LoggingBackend_Tcp_)(nil),
+		(*LoggingBackend_Syslog_)(nil),
+		(*LoggingBackend_Http_)(nil),
 	}
 }
 
 // Simple logging to file
 type LoggingBackend_File struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Rotation of the log file.
+	// +optional
+	Rotation             *LoggingBackend_File_Rotation `protobuf:"bytes,2,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *LoggingBackend_File) Reset()         { *m = LoggingBackend_File{} }
@@ -590,6 +634,73 @@ func (m *LoggingBackend_File) GetPath() string {
 	return ""
 }
 
+func (m *LoggingBackend_File) GetRotation() *LoggingBackend_File_Rotation {
+	if m != nil {
+		return m.Rotation
+	}
+	return nil
+}
+
+// Rotation of a log file. Access logs of a file with rotation are written
+// by kuma-dp instead of Envoy.
+type LoggingBackend_File_Rotation struct {
+	// Size in bytes after which a log file is rotated. 0 means no limit.
+	MaxSize uint64 `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// Age after which a log file is rotated.
+	MaxAge *duration.Duration `protobuf:"bytes,2,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Number of rotated log files to keep. 0 means all of them.
+	MaxBackups           uint32   `protobuf:"varint,3,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoggingBackend_File_Rotation) Reset()         { *m = LoggingBackend_File_Rotation{} }
+func (m *LoggingBackend_File_Rotation) String() string { return proto.CompactTextString(m) }
+func (*LoggingBackend_File_Rotation) ProtoMessage()    {}
+func (*LoggingBackend_File_Rotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{4, 0, 0}
+}
+
+func (m *LoggingBackend_File_Rotation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoggingBackend_File_Rotation.Unmarshal(m, b)
+}
+func (m *LoggingBackend_File_Rotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoggingBackend_File_Rotation.Marshal(b, m, deterministic)
+}
+func (m *LoggingBackend_File_Rotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoggingBackend_File_Rotation.Merge(m, src)
+}
+func (m *LoggingBackend_File_Rotation) XXX_Size() int {
+	return xxx_messageInfo_LoggingBackend_File_Rotation.Size(m)
+}
+func (m *LoggingBackend_File_Rotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoggingBackend_File_Rotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoggingBackend_File_Rotation proto.InternalMessageInfo
+
+func (m *LoggingBackend_File_Rotation) GetMaxSize() uint64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *LoggingBackend_File_Rotation) GetMaxAge() *duration.Duration {
+	if m != nil {
+		return m.MaxAge
+	}
+	return nil
+}
+
+func (m *LoggingBackend_File_Rotation) GetMaxBackups() uint32 {
+	if m != nil {
+		return m.MaxBackups
+	}
+	return 0
+}
+
 type LoggingBackend_Tcp struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -629,6 +740,141 @@ func (m *LoggingBackend_Tcp) GetAddress() string {
 	return ""
 }
 
+// Logging to a syslog server (RFC5424).
+type LoggingBackend_Syslog struct {
+	// Address of the syslog server in the format HOST:PORT.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Transport protocol, either `udp` or `tcp`. Defaults to `udp`.
+	// +optional
+	Protocol string `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Syslog facility, e.g. `local0`. Defaults to `local0`.
+	// +optional
+	Facility             string   `protobuf:"bytes,3,opt,name=facility,proto3" json:"facility,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoggingBackend_Syslog) Reset()         { *m = LoggingBackend_Syslog{} }
+func (m *LoggingBackend_Syslog) String() string { return proto.CompactTextString(m) }
+func (*LoggingBackend_Syslog) ProtoMessage()    {}
+func (*LoggingBackend_Syslog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{4, 3}
+}
+
+func (m *LoggingBackend_Syslog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoggingBackend_Syslog.Unmarshal(m, b)
+}
+func (m *LoggingBackend_Syslog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoggingBackend_Syslog.Marshal(b, m, deterministic)
+}
+func (m *LoggingBackend_Syslog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoggingBackend_Syslog.Merge(m, src)
+}
+func (m *LoggingBackend_Syslog) XXX_Size() int {
+	return xxx_messageInfo_LoggingBackend_Syslog.Size(m)
+}
+func (m *LoggingBackend_Syslog) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoggingBackend_Syslog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoggingBackend_Syslog proto.InternalMessageInfo
+
+func (m *LoggingBackend_Syslog) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LoggingBackend_Syslog) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *LoggingBackend_Syslog) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+// Logging to an HTTP collector that receives batches of entries
+// as JSON arrays.
+type LoggingBackend_Http struct {
+	// URL of the collector.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Maximum number of entries in a single batch. Defaults to 100.
+	// +optional
+	BatchSize uint32 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Interval after which a non-empty batch is sent even if it isn't full.
+	// Defaults to 5s.
+	// +optional
+	FlushInterval *duration.Duration `protobuf:"bytes,3,opt,name=flush_interval,json=flushInterval,proto3" json:"flush_interval,omitempty"`
+	// Number of retries of a failed batch before it is dropped.
+	// Defaults to 3.
+	// +optional
+	MaxRetries           uint32   `protobuf:"varint,4,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoggingBackend_Http) Reset()         { *m = LoggingBackend_Http{} }
+func (m *LoggingBackend_Http) String() string { return proto.CompactTextString(m) }
+func (*LoggingBackend_Http) ProtoMessage()    {}
+func (*LoggingBackend_Http) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{4, 4}
+}
+
+func (m *LoggingBackend_Http) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoggingBackend_Http.Unmarshal(m, b)
+}
+func (m *LoggingBackend_Http) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoggingBackend_Http.Marshal(b, m, deterministic)
+}
+func (m *LoggingBackend_Http) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoggingBackend_Http.Merge(m, src)
+}
+func (m *LoggingBackend_Http) XXX_Size() int {
+	return xxx_messageInfo_LoggingBackend_Http.Size(m)
+}
+func (m *LoggingBackend_Http) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoggingBackend_Http.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoggingBackend_Http proto.InternalMessageInfo
+
+func (m *LoggingBackend_Http) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *LoggingBackend_Http) GetBatchSize() uint32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *LoggingBackend_Http) GetFlushInterval() *duration.Duration {
+	if m != nil {
+		return m.FlushInterval
+	}
+	return nil
+}
+
+func (m *LoggingBackend_Http) GetMaxRetries() uint32 {
+	if m != nil {
+		return m.MaxRetries
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Mesh)(nil), "kuma.mesh.v1alpha1.Mesh")
 	proto.RegisterType((*Mesh_Mtls)(nil), "kuma.mesh.v1alpha1.Mesh.Mtls")
@@ -639,42 +885,66 @@ func init() {
 	proto.RegisterType((*Tracing_Zipkin)(nil), "kuma.mesh.v1alpha1.Tracing.Zipkin")
 	proto.RegisterType((*Logging)(nil), "kuma.mesh.v1alpha1.Logging")
 	proto.RegisterType((*LoggingBackend)(nil), "kuma.mesh.v1alpha1.LoggingBackend")
+	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.LoggingBackend.JsonFormatEntry")
 	proto.RegisterType((*LoggingBackend_File)(nil), "kuma.mesh.v1alpha1.LoggingBackend.File")
+	proto.RegisterType((*LoggingBackend_File_Rotation)(nil), "kuma.mesh.v1alpha1.LoggingBackend.File.Rotation")
 	proto.RegisterType((*LoggingBackend_Tcp)(nil), "kuma.mesh.v1alpha1.LoggingBackend.Tcp")
+	proto.RegisterType((*LoggingBackend_Syslog)(nil), "kuma.mesh.v1alpha1.LoggingBackend.Syslog")
+	proto.RegisterType((*LoggingBackend_Http)(nil), "kuma.mesh.v1alpha1.LoggingBackend.Http")
 }
 
 func init() { proto.RegisterFile("mesh/v1alpha1/mesh.proto", fileDescriptor_ae9b3cd8c92bbf6a) }
 
 var fileDescriptor_ae9b3cd8c92bbf6a = []byte{
-	// 480 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x41, 0x6b, 0xdb, 0x3e,
-	0x18, 0xc6, 0x1d, 0xc7, 0xd8, 0xce, 0x5b, 0xe8, 0x41, 0xfc, 0xf9, 0x63, 0x5c, 0xc6, 0x8a, 0x0f,
-	0x5d, 0x4f, 0xee, 0xd2, 0x31, 0x18, 0x63, 0x1b, 0x2c, 0x83, 0xe1, 0x43, 0xc3, 0x86, 0xc8, 0x29,
-	0x37, 0x45, 0x56, 0x62, 0x11, 0xd9, 0xd6, 0x6c, 0xa5, 0xd0, 0x7d, 0xb4, 0x7d, 0x8e, 0x7d, 0x9c,
-	0x1d, 0x86, 0x64, 0x29, 0xb0, 0x2e, 0x59, 0x73, 0xd3, 0x2b, 0x3f, 0xbf, 0xf7, 0x79, 0x24, 0xbd,
-	0x18, 0x92, 0x9a, 0xf5, 0xd5, 0xcd, 0xfd, 0x94, 0x08, 0x59, 0x91, 0xe9, 0x8d, 0xae, 0x72, 0xd9,
-	0xb5, 0xaa, 0x45, 0x68, 0xbb, 0xab, 0x49, 0x6e, 0x36, 0xdc, 0xe7, 0xf4, 0xe2, 0xb1, 0x5a, 0x75,
-	0x9c, 0xf6, 0x03, 0x90, 0xfd, 0xf0, 0x21, 0x98, 0xb3, 0xbe, 0x42, 0x53, 0x08, 0x6a, 0x25, 0xfa,
-	0x64, 0x74, 0x39, 0xba, 0x3e, 0xbb, 0x7d, 0x96, 0xff, 0xdd, 0x28, 0xd7, 0xba, 0x7c, 0xae, 0x44,
-	0x8f, 0x8d, 0x14, 0xbd, 0x86, 0x48, 0x75, 0x84, 0xf2, 0x66, 0x93, 0xf8, 0x86, 0xba, 0x38, 0x44,
-	0x2d, 0x06, 0x09, 0x76, 0x5a, 0x8d, 0x89, 0x76, 0xb3, 0xd1, 0xd8, 0xf8, 0x38, 0x76, 0x37, 0x48,
-	0xb0, 0xd3, 0x6a, 0xcc, 0x46, 0x4f, 0x82, 0xe3, 0xd8, 0x7c, 0x90, 0x60, 0xa7, 0x4d, 0x97, 0x10,
-	0xe8, 0xc8, 0xe8, 0x0d, 0xf8, 0x94, 0xd8, 0xd3, 0x5d, 0x1f, 0x22, 0x3f, 0xb1, 0x4e, 0xf1, 0x35,
-	0xa7, 0x44, 0xb1, 0x8f, 0x3b, 0x55, 0xb5, 0x1d, 0x57, 0x0f, 0xd8, 0xa7, 0x04, 0x25, 0x10, 0xb1,
-	0x86, 0xac, 0x04, 0x2b, 0xcd, 0x31, 0x63, 0xec, 0xca, 0xec, 0xe7, 0x08, 0xfe, 0x3b, 0x84, 0xa1,
-	0x3b, 0x88, 0x56, 0x3b, 0x2e, 0x14, 0x6f, 0xac, 0xe3, 0xcb, 0x53, 0x1d, 0xf3, 0xd9, 0xc0, 0x15,
-	0x1e, 0x76, 0x2d, 0xd0, 0x17, 0x88, 0x65, 0xd7, 0xde, 0xf3, 0xd2, 0x26, 0x38, 0xbb, 0x9d, 0x9e,
-	0xdc, 0xee, 0xab, 0x05, 0x0b, 0x0f, 0xef, 0x9b, 0xa4, 0x13, 0x88, 0xac, 0x4d, 0x0a, 0x10, 0x3b,
-	0xc9, 0x2c, 0x84, 0x40, 0x3d, 0x48, 0x96, 0xf5, 0x10, 0xd9, 0x47, 0x43, 0xef, 0x20, 0xfc, 0xce,
-	0xe5, 0x76, 0x7f, 0x8e, 0xec, 0x1f, 0x2f, 0x9c, 0x2f, 0x8d, 0xb2, 0xf0, 0xb0, 0x65, 0xd2, 0x0c,
-	0xc2, 0x61, 0x4f, 0xdf, 0x21, 0x29, 0xcb, 0x8e, 0xf5, 0xc3, 0x80, 0x4d, 0xb0, 0x2b, 0xf7, 0xa6,
-	0xdf, 0x20, 0xb2, 0x4f, 0x8e, 0xae, 0xe0, 0xbc, 0x64, 0x6b, 0xb2, 0x13, 0x6a, 0x46, 0xe8, 0x96,
-	0x35, 0xa5, 0x65, 0x1e, 0xed, 0xa2, 0x0f, 0x10, 0xaf, 0x86, 0x65, 0x9f, 0xf8, 0x97, 0xe3, 0x63,
-	0xf1, 0x6c, 0x5b, 0x4b, 0xe1, 0x3d, 0x93, 0xfd, 0x1a, 0xc1, 0xf9, 0x9f, 0x1f, 0x11, 0x82, 0xa0,
-	0x21, 0x35, 0xb3, 0x86, 0x66, 0x8d, 0xfe, 0x87, 0x70, 0xdd, 0x76, 0x35, 0x51, 0xe6, 0xf2, 0x27,
-	0xd8, 0x56, 0xe8, 0x3d, 0x04, 0x6b, 0x2e, 0x98, 0x1d, 0xe2, 0x17, 0x4f, 0x5b, 0xe7, 0x9f, 0xb9,
-	0x60, 0x85, 0x87, 0x0d, 0x86, 0xde, 0xc2, 0x58, 0x51, 0x69, 0x67, 0xf9, 0xea, 0x04, 0x7a, 0x41,
-	0x65, 0xe1, 0x61, 0x0d, 0xa5, 0x29, 0x04, 0xba, 0x97, 0x8e, 0x2b, 0x89, 0xaa, 0x5c, 0x5c, 0xbd,
-	0x4e, 0x9f, 0xc3, 0x78, 0x41, 0xe5, 0xd3, 0x37, 0x3e, 0x83, 0x65, 0xec, 0xac, 0x56, 0xa1, 0xf9,
-	0x1b, 0xbc, 0xfa, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x2d, 0x3d, 0x86, 0x70, 0x5a, 0x04, 0x00, 0x00,
+	// 787 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0x5f, 0x8f, 0xdb, 0x44,
+	0x10, 0xc0, 0x13, 0xc7, 0xb5, 0x9d, 0x89, 0x7a, 0xa0, 0x55, 0x85, 0x5c, 0x57, 0xa5, 0x55, 0x1e,
+	0x4a, 0x79, 0x71, 0x48, 0x10, 0x52, 0x55, 0x51, 0x44, 0x73, 0x70, 0x0a, 0xe8, 0x4e, 0xa0, 0xbd,
+	0x7b, 0xca, 0x4b, 0xb4, 0x71, 0x36, 0xf6, 0x5e, 0xd6, 0x7f, 0xb0, 0xd7, 0x51, 0x92, 0x8f, 0xc2,
+	0x47, 0xe1, 0x73, 0xf0, 0xc0, 0x07, 0xe0, 0x83, 0xa0, 0xfd, 0x63, 0x4b, 0x1c, 0x09, 0x97, 0xb7,
+	0x9d, 0xd9, 0xf9, 0xcd, 0xcc, 0xce, 0x8c, 0xc7, 0xe0, 0xa7, 0xb4, 0x4a, 0x46, 0xdb, 0x31, 0xe1,
+	0x45, 0x42, 0xc6, 0x23, 0x29, 0x85, 0x45, 0x99, 0x8b, 0x1c, 0xa1, 0x4d, 0x9d, 0x92, 0x50, 0x29,
+	0x9a, 0xeb, 0xe0, 0xc5, 0x43, 0x6b, 0x51, 0xb2, 0xa8, 0xd2, 0x40, 0xf0, 0x79, 0x9c, 0xe7, 0x31,
+	0xa7, 0x23, 0x25, 0x2d, 0xeb, 0xf5, 0x68, 0x55, 0x97, 0x44, 0xb0, 0x3c, 0xd3, 0xf7, 0xc3, 0x3f,
+	0x2c, 0xb0, 0x6f, 0x68, 0x95, 0xa0, 0x31, 0xd8, 0xa9, 0xe0, 0x95, 0xdf, 0x7d, 0xdd, 0x7d, 0x3b,
+	0x98, 0xbc, 0x0c, 0xff, 0x1b, 0x28, 0x94, 0x76, 0xe1, 0x8d, 0xe0, 0x15, 0x56, 0xa6, 0xe8, 0x1b,
+	0x70, 0x45, 0x49, 0x22, 0x96, 0xc5, 0xbe, 0xa5, 0xa8, 0x17, 0xc7, 0xa8, 0x3b, 0x6d, 0x82, 0x1b,
+	0x5b, 0x89, 0xf1, 0x3c, 0x8e, 0x25, 0xd6, 0x3b, 0x8d, 0x5d, 0x6b, 0x13, 0xdc, 0xd8, 0x4a, 0xcc,
+	0x3c, 0xcd, 0xb7, 0x4f, 0x63, 0x37, 0xda, 0x04, 0x37, 0xb6, 0xc1, 0x1c, 0x6c, 0x99, 0x32, 0x7a,
+	0x07, 0x56, 0x44, 0xcc, 0xeb, 0xde, 0x1e, 0x23, 0x2f, 0x69, 0x29, 0xd8, 0x9a, 0x45, 0x44, 0xd0,
+	0x8f, 0xb5, 0x48, 0xf2, 0x92, 0x89, 0x3d, 0xb6, 0x22, 0x82, 0x7c, 0x70, 0x69, 0x46, 0x96, 0x9c,
+	0xae, 0xd4, 0x33, 0x3d, 0xdc, 0x88, 0xc3, 0x3f, 0xbb, 0xf0, 0xec, 0x18, 0x86, 0xae, 0xc1, 0x5d,
+	0xd6, 0x8c, 0x0b, 0x96, 0x99, 0x88, 0x5f, 0x9d, 0x1b, 0x31, 0x9c, 0x6a, 0x6e, 0xd6, 0xc1, 0x8d,
+	0x0b, 0xf4, 0x0b, 0x78, 0x45, 0x99, 0x6f, 0xd9, 0xca, 0x64, 0x30, 0x98, 0x8c, 0xcf, 0x76, 0xf7,
+	0xab, 0x01, 0x67, 0x1d, 0xdc, 0x3a, 0x09, 0xfa, 0xe0, 0x9a, 0x30, 0x01, 0x80, 0xd7, 0x98, 0x4c,
+	0x1d, 0xb0, 0xc5, 0xbe, 0xa0, 0xc3, 0x0a, 0x5c, 0xd3, 0x34, 0xf4, 0x2d, 0x38, 0x07, 0x56, 0x6c,
+	0xda, 0x77, 0x0c, 0xff, 0xa7, 0xc3, 0xe1, 0x5c, 0x59, 0xce, 0x3a, 0xd8, 0x30, 0xc1, 0x10, 0x1c,
+	0xad, 0x93, 0x35, 0x24, 0xab, 0x55, 0x49, 0x2b, 0x3d, 0x60, 0x7d, 0xdc, 0x88, 0x6d, 0xd0, 0xdf,
+	0xc0, 0x35, 0x2d, 0x47, 0x6f, 0xe0, 0x62, 0x45, 0xd7, 0xa4, 0xe6, 0x62, 0x4a, 0xa2, 0x0d, 0xcd,
+	0x56, 0x86, 0x79, 0xa0, 0x45, 0xdf, 0x81, 0xb7, 0xd4, 0xc7, 0xca, 0xb7, 0x5e, 0xf7, 0x4e, 0xa5,
+	0x67, 0xdc, 0x1a, 0x0a, 0xb7, 0xcc, 0xf0, 0x2f, 0x17, 0x2e, 0xfe, 0x7d, 0x89, 0x10, 0xd8, 0x19,
+	0x49, 0xa9, 0x09, 0xa8, 0xce, 0xe8, 0x33, 0x70, 0xd6, 0x79, 0x99, 0x12, 0xa1, 0x8a, 0xdf, 0xc7,
+	0x46, 0x42, 0x1f, 0xc0, 0x5e, 0x33, 0x4e, 0xcd, 0x10, 0x7f, 0xf1, 0x78, 0xe8, 0xf0, 0x8a, 0x71,
+	0x3a, 0xeb, 0x60, 0x85, 0xa1, 0xf7, 0xd0, 0x13, 0x51, 0x61, 0x66, 0xf9, 0xcd, 0x19, 0xf4, 0x5d,
+	0x54, 0xcc, 0x3a, 0x58, 0x42, 0xe8, 0x12, 0x9c, 0x6a, 0x5f, 0xf1, 0x3c, 0xf6, 0x1d, 0x85, 0x7f,
+	0x79, 0x06, 0x7e, 0xab, 0x00, 0xd9, 0x1d, 0x8d, 0xca, 0xfc, 0x13, 0x21, 0x0a, 0xdf, 0x3d, 0x3b,
+	0xff, 0x99, 0x10, 0x32, 0x05, 0x85, 0xa1, 0x5b, 0x18, 0xdc, 0x57, 0x79, 0xb6, 0x30, 0xb5, 0x79,
+	0xa2, 0x1a, 0x30, 0x39, 0xc3, 0xcb, 0xcf, 0x55, 0x9e, 0x5d, 0x29, 0xe8, 0xc7, 0x4c, 0x94, 0x7b,
+	0x0c, 0xf7, 0xad, 0x22, 0xf8, 0xbb, 0x0b, 0xb6, 0xac, 0x92, 0x6c, 0x44, 0x41, 0x44, 0xd2, 0x34,
+	0x42, 0x9e, 0xd1, 0x35, 0x78, 0x65, 0x2e, 0xd4, 0xf6, 0xf2, 0xad, 0xd3, 0x9f, 0xd5, 0x91, 0xa2,
+	0x87, 0xd8, 0x70, 0xb8, 0xf5, 0x10, 0x1c, 0xc0, 0x6b, 0xb4, 0xe8, 0x39, 0x78, 0x29, 0xd9, 0x2d,
+	0x2a, 0x76, 0xd0, 0xad, 0xb7, 0xb1, 0x9b, 0x92, 0xdd, 0x2d, 0x3b, 0x50, 0x34, 0x01, 0x79, 0x5c,
+	0x90, 0x98, 0x9a, 0x98, 0xcf, 0x43, 0xbd, 0x52, 0xc3, 0x66, 0xa5, 0x86, 0x3f, 0x98, 0x95, 0x8a,
+	0x9d, 0x94, 0xec, 0x3e, 0xc6, 0x14, 0xbd, 0x82, 0x81, 0x64, 0xe4, 0xa0, 0xd5, 0x45, 0xa5, 0x06,
+	0xe4, 0x29, 0x86, 0x94, 0xec, 0xa6, 0x5a, 0x13, 0xbc, 0x82, 0xde, 0x5d, 0x54, 0x9c, 0xfe, 0x2a,
+	0x82, 0x0f, 0xf0, 0xc9, 0x83, 0x32, 0xa1, 0x4f, 0xa1, 0xb7, 0xa1, 0x7b, 0x63, 0x28, 0x8f, 0xe8,
+	0x19, 0x3c, 0xd9, 0x12, 0x5e, 0x53, 0x33, 0x97, 0x5a, 0x78, 0x6f, 0xbd, 0xeb, 0x06, 0x73, 0x70,
+	0x74, 0xbb, 0x4f, 0x87, 0x40, 0x81, 0xda, 0x2a, 0x22, 0x8f, 0x72, 0x6e, 0x1c, 0xb4, 0xb2, 0xbc,
+	0x5b, 0x93, 0x88, 0x71, 0x26, 0xf6, 0x2a, 0xfb, 0x3e, 0x6e, 0xe5, 0xe0, 0xf7, 0x2e, 0xd8, 0x72,
+	0x10, 0x64, 0x42, 0x75, 0xc9, 0x9b, 0x84, 0xea, 0x92, 0xa3, 0x97, 0x00, 0x4b, 0x22, 0xa2, 0x44,
+	0x17, 0xd2, 0x52, 0xcf, 0xee, 0x2b, 0x8d, 0x2a, 0xe5, 0xf7, 0x70, 0xb1, 0xe6, 0x75, 0x95, 0x2c,
+	0x58, 0x26, 0x68, 0xb9, 0x25, 0xdc, 0xef, 0x3d, 0x56, 0xd1, 0xa7, 0x0a, 0xf8, 0xc9, 0xd8, 0x37,
+	0x85, 0x2d, 0xe5, 0x6e, 0xa7, 0xfa, 0x3f, 0xa0, 0x0b, 0x8b, 0xb5, 0xa6, 0xd9, 0x26, 0x53, 0x98,
+	0x7b, 0xcd, 0x3c, 0x2c, 0x1d, 0xe5, 0xf6, 0xeb, 0x7f, 0x06, 0x00, 0x04, 0x4d, 0xa8, 0xa5, 0x56,
+	0x07, 0x00, 0x00,
 }
@@ -0,0 +1,54 @@
+package v1alpha1
+
+const (
+	SyslogProtocolUdp = "udp"
+	SyslogProtocolTcp = "tcp"
+
+	SyslogDefaultFacility = "local0"
+)
+
+// syslogFacilities maps names of syslog facilities to their numerical codes (RFC5424).
+var syslogFacilities = map[string]uint32{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"ntp":      12,
+	"security": 13,
+	"console":  14,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// GetProtocolOrDefault returns transport protocol of a syslog server, `udp` by default.
+func (s *LoggingBackend_Syslog) GetProtocolOrDefault() string {
+	if s.GetProtocol() == "" {
+		return SyslogProtocolUdp
+	}
+	return s.GetProtocol()
+}
+
+// GetFacilityCode returns numerical code of a syslog facility, `local0` by default.
+// The second return value is false if the facility is unknown.
+func (s *LoggingBackend_Syslog) GetFacilityCode() (uint32, bool) {
+	facility := s.GetFacility()
+	if facility == "" {
+		facility = SyslogDefaultFacility
+	}
+	code, ok := syslogFacilities[facility]
+	return code, ok
+}
@@ -0,0 +1,77 @@
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Kong/kuma/api/mesh/v1alpha1"
+)
+
+var _ = Describe("MeshHelpers", func() {
+
+	Describe("LoggingBackend_Syslog", func() {
+
+		Describe("GetProtocolOrDefault()", func() {
+
+			It("should default to `udp`", func() {
+				// given
+				syslog := &LoggingBackend_Syslog{}
+
+				// expect
+				Expect(syslog.GetProtocolOrDefault()).To(Equal("udp"))
+			})
+
+			It("should return configured protocol", func() {
+				// given
+				syslog := &LoggingBackend_Syslog{Protocol: "tcp"}
+
+				// expect
+				Expect(syslog.GetProtocolOrDefault()).To(Equal("tcp"))
+			})
+		})
+
+		Describe("GetFacilityCode()", func() {
+
+			type testCase struct {
+				facility string
+				code     uint32
+				ok       bool
+			}
+
+			table.DescribeTable("should resolve syslog facilities",
+				func(given testCase) {
+					// given
+					syslog := &LoggingBackend_Syslog{Facility: given.facility}
+
+					// when
+					code, ok := syslog.GetFacilityCode()
+
+					// then
+					Expect(code).To(Equal(given.code))
+					Expect(ok).To(Equal(given.ok))
+				},
+				table.Entry("default", testCase{
+					facility: "",
+					code:     16,
+					ok:       true,
+				}),
+				table.Entry("daemon", testCase{
+					facility: "daemon",
+					code:     3,
+					ok:       true,
+				}),
+				table.Entry("local7", testCase{
+					facility: "local7",
+					code:     23,
+					ok:       true,
+				}),
+				table.Entry("unknown", testCase{
+					facility: "local8",
+					code:     0,
+					ok:       false,
+				}),
+			)
+		})
+	})
+})
@@ -25,10 +25,13 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type Metrics struct {
 	// Prometheus-specific configuration for metrics that should be collected and
 	// exposed by dataplanes.
-	Prometheus           *Metrics_Prometheus `protobuf:"bytes,1,opt,name=prometheus,proto3" json:"prometheus,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Prometheus *Metrics_Prometheus `protobuf:"bytes,1,opt,name=prometheus,proto3" json:"prometheus,omitempty"`
+	// StatsD-specific configuration for metrics that should be pushed by
+	// dataplanes.
+	Statsd               *Metrics_Statsd `protobuf:"bytes,2,opt,name=statsd,proto3" json:"statsd,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *Metrics) Reset()         { *m = Metrics{} }
@@ -63,6 +66,13 @@ func (m *Metrics) GetPrometheus() *Metrics_Prometheus {
 	return nil
 }
 
+func (m *Metrics) GetStatsd() *Metrics_Statsd {
+	if m != nil {
+		return m.Statsd
+	}
+	return nil
+}
+
 // Prometheus defines Prometheus-specific configuration for metrics that
 // should be collected and exposed by dataplanes.
 type Metrics_Prometheus struct {
@@ -71,10 +81,14 @@ type Metrics_Prometheus struct {
 	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
 	// Path on which a dataplane should expose HTTP endpoint with Prometheus
 	// metrics.
-	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Additional endpoints with Prometheus metrics exposed by applications.
+	// Endpoints defined on a Dataplane override endpoints of the same name
+	// defined on a Mesh.
+	Endpoints            []*Metrics_Prometheus_Endpoint `protobuf:"bytes,3,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
 }
 
 func (m *Metrics_Prometheus) Reset()         { *m = Metrics_Prometheus{} }
@@ -116,23 +130,189 @@ func (m *Metrics_Prometheus) GetPath() string {
 	return ""
 }
 
+func (m *Metrics_Prometheus) GetEndpoints() []*Metrics_Prometheus_Endpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+// Endpoint defines an HTTP endpoint with Prometheus metrics exposed by
+// an application next to a dataplane.
+type Metrics_Prometheus_Endpoint struct {
+	// Name of the endpoint, e.g. `app`. It's available to relabelling
+	// rules on Prometheus side as `__meta_kuma_endpoint` label.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Port on which an application exposes HTTP endpoint with Prometheus
+	// metrics.
+	Port uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// Path on which an application exposes HTTP endpoint with Prometheus
+	// metrics. Defaults to `/metrics`.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Scheme of the endpoint, either `http` or `https`. Defaults to `http`.
+	Scheme string `protobuf:"bytes,4,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Extra labels to attach to every metric scraped from the endpoint.
+	Labels               map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Metrics_Prometheus_Endpoint) Reset()         { *m = Metrics_Prometheus_Endpoint{} }
+func (m *Metrics_Prometheus_Endpoint) String() string { return proto.CompactTextString(m) }
+func (*Metrics_Prometheus_Endpoint) ProtoMessage()    {}
+func (*Metrics_Prometheus_Endpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7dd8c7f420ce268c, []int{0, 0, 0}
+}
+
+func (m *Metrics_Prometheus_Endpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Metrics_Prometheus_Endpoint.Unmarshal(m, b)
+}
+func (m *Metrics_Prometheus_Endpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Metrics_Prometheus_Endpoint.Marshal(b, m, deterministic)
+}
+func (m *Metrics_Prometheus_Endpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metrics_Prometheus_Endpoint.Merge(m, src)
+}
+func (m *Metrics_Prometheus_Endpoint) XXX_Size() int {
+	return xxx_messageInfo_Metrics_Prometheus_Endpoint.Size(m)
+}
+func (m *Metrics_Prometheus_Endpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metrics_Prometheus_Endpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metrics_Prometheus_Endpoint proto.InternalMessageInfo
+
+func (m *Metrics_Prometheus_Endpoint) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Metrics_Prometheus_Endpoint) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Metrics_Prometheus_Endpoint) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Metrics_Prometheus_Endpoint) GetScheme() string {
+	if m != nil {
+		return m.Scheme
+	}
+	return ""
+}
+
+func (m *Metrics_Prometheus_Endpoint) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+// Statsd defines configuration of a StatsD server that dataplanes should
+// push metrics to.
+type Metrics_Statsd struct {
+	// Address of a StatsD server in the format `IP:PORT`,
+	// e.g. `127.0.0.1:8125`. Metrics are sent over UDP.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Prefix of names of all metrics. Defaults to `envoy`.
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Format of tags, either `statsd`, where tags are a part of metric names,
+	// or `dogstatsd`, where tags are sent in DogStatsD format.
+	// Defaults to `statsd`.
+	TagFormat            string   `protobuf:"bytes,3,opt,name=tag_format,json=tagFormat,proto3" json:"tag_format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Metrics_Statsd) Reset()         { *m = Metrics_Statsd{} }
+func (m *Metrics_Statsd) String() string { return proto.CompactTextString(m) }
+func (*Metrics_Statsd) ProtoMessage()    {}
+func (*Metrics_Statsd) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7dd8c7f420ce268c, []int{0, 1}
+}
+
+func (m *Metrics_Statsd) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Metrics_Statsd.Unmarshal(m, b)
+}
+func (m *Metrics_Statsd) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Metrics_Statsd.Marshal(b, m, deterministic)
+}
+func (m *Metrics_Statsd) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metrics_Statsd.Merge(m, src)
+}
+func (m *Metrics_Statsd) XXX_Size() int {
+	return xxx_messageInfo_Metrics_Statsd.Size(m)
+}
+func (m *Metrics_Statsd) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metrics_Statsd.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metrics_Statsd proto.InternalMessageInfo
+
+func (m *Metrics_Statsd) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Metrics_Statsd) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *Metrics_Statsd) GetTagFormat() string {
+	if m != nil {
+		return m.TagFormat
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Metrics)(nil), "kuma.mesh.v1alpha1.Metrics")
 	proto.RegisterType((*Metrics_Prometheus)(nil), "kuma.mesh.v1alpha1.Metrics.Prometheus")
+	proto.RegisterType((*Metrics_Prometheus_Endpoint)(nil), "kuma.mesh.v1alpha1.Metrics.Prometheus.Endpoint")
+	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.Metrics.Prometheus.Endpoint.LabelsEntry")
+	proto.RegisterType((*Metrics_Statsd)(nil), "kuma.mesh.v1alpha1.Metrics.Statsd")
 }
 
 func init() { proto.RegisterFile("mesh/v1alpha1/metrics.proto", fileDescriptor_7dd8c7f420ce268c) }
 
 var fileDescriptor_7dd8c7f420ce268c = []byte{
-	// 153 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xce, 0x4d, 0x2d, 0xce,
-	0xd0, 0x2f, 0x33, 0x4c, 0xcc, 0x29, 0xc8, 0x48, 0x34, 0xd4, 0xcf, 0x4d, 0x2d, 0x29, 0xca, 0x4c,
-	0x2e, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0xca, 0x2e, 0xcd, 0x4d, 0xd4, 0x03, 0xa9,
-	0xd0, 0x83, 0xa9, 0x50, 0x6a, 0x67, 0xe4, 0x62, 0xf7, 0x85, 0xa8, 0x12, 0x72, 0xe3, 0xe2, 0x2a,
-	0x28, 0xca, 0xcf, 0x4d, 0x2d, 0xc9, 0x48, 0x2d, 0x2d, 0x96, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x36,
-	0x52, 0xd3, 0xc3, 0xd4, 0xa4, 0x07, 0xd5, 0xa0, 0x17, 0x00, 0x57, 0x1d, 0x84, 0xa4, 0x53, 0xca,
-	0x84, 0x8b, 0x0b, 0x21, 0x23, 0x24, 0xc4, 0xc5, 0x52, 0x90, 0x5f, 0x54, 0x02, 0x36, 0x8f, 0x37,
-	0x08, 0xcc, 0x06, 0x8b, 0x25, 0x96, 0x64, 0x48, 0x30, 0x29, 0x30, 0x6a, 0x70, 0x06, 0x81, 0xd9,
-	0x4e, 0x5c, 0x51, 0x1c, 0x30, 0x0b, 0x92, 0xd8, 0xc0, 0x0e, 0x36, 0x06, 0x04, 0x00, 0x00, 0xff,
-	0xff, 0xbf, 0xe9, 0xed, 0xe9, 0xcf, 0x00, 0x00, 0x00,
+	// 347 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x6a, 0xf2, 0x40,
+	0x14, 0x85, 0x49, 0xa2, 0xd1, 0x5c, 0xf9, 0xe1, 0x67, 0x28, 0x25, 0xa4, 0x14, 0xc4, 0x45, 0x71,
+	0x35, 0xa2, 0xdd, 0xb4, 0x76, 0x57, 0xd0, 0x55, 0x85, 0x32, 0xae, 0xda, 0x4d, 0x19, 0xcd, 0x68,
+	0xc4, 0x4c, 0x12, 0x66, 0x46, 0xa9, 0x4f, 0xdb, 0x37, 0xe8, 0xaa, 0x0f, 0x50, 0x72, 0x9d, 0xa8,
+	0xd0, 0x52, 0xda, 0xdd, 0xbd, 0x87, 0x73, 0x4e, 0xbe, 0x9b, 0x04, 0x2e, 0xa4, 0xd0, 0x49, 0x6f,
+	0xdb, 0xe7, 0x69, 0x91, 0xf0, 0x7e, 0x4f, 0x0a, 0xa3, 0x56, 0x73, 0x4d, 0x0b, 0x95, 0x9b, 0x9c,
+	0x90, 0xf5, 0x46, 0x72, 0x5a, 0x3a, 0x68, 0xe5, 0xe8, 0xbc, 0xd5, 0xa0, 0x31, 0xd9, 0xbb, 0xc8,
+	0x18, 0xa0, 0x50, 0xb9, 0x14, 0x26, 0x11, 0x1b, 0x1d, 0x3a, 0x6d, 0xa7, 0xdb, 0x1a, 0x5c, 0xd1,
+	0xaf, 0x21, 0x6a, 0x03, 0xf4, 0xf1, 0xe0, 0x66, 0x27, 0x49, 0x32, 0x04, 0x5f, 0x1b, 0x6e, 0x74,
+	0x1c, 0xba, 0xd8, 0xd1, 0xf9, 0xa9, 0x63, 0x8a, 0x4e, 0x66, 0x13, 0xd1, 0x87, 0x0b, 0x70, 0xac,
+	0x25, 0x04, 0x6a, 0x45, 0xae, 0x0c, 0xc2, 0xfc, 0x63, 0x38, 0xa3, 0xc6, 0x4d, 0x82, 0xe5, 0x01,
+	0xc3, 0x99, 0x4c, 0x20, 0x10, 0x59, 0x5c, 0xe4, 0xab, 0xcc, 0xe8, 0xd0, 0x6b, 0x7b, 0xdd, 0xd6,
+	0xa0, 0xf7, 0x3b, 0x72, 0x3a, 0xb2, 0x39, 0x76, 0x6c, 0x88, 0xde, 0x1d, 0x68, 0x56, 0x7a, 0xf9,
+	0xbc, 0x8c, 0x4b, 0x81, 0x0c, 0x01, 0xc3, 0xf9, 0xc0, 0xe5, 0x7e, 0xc3, 0xe5, 0x9d, 0x70, 0x9d,
+	0x83, 0xaf, 0xe7, 0x89, 0x90, 0x22, 0xac, 0xa1, 0x6a, 0x37, 0x32, 0x05, 0x3f, 0xe5, 0x33, 0x91,
+	0xea, 0xb0, 0x8e, 0xb0, 0x77, 0x7f, 0x84, 0xa5, 0x0f, 0x98, 0x1e, 0x65, 0x46, 0xed, 0x98, 0xad,
+	0x8a, 0x6e, 0xa1, 0x75, 0x22, 0x93, 0xff, 0xe0, 0xad, 0xc5, 0xce, 0x62, 0x97, 0x23, 0x39, 0x83,
+	0xfa, 0x96, 0xa7, 0x1b, 0x61, 0x5f, 0xdd, 0x7e, 0x19, 0xba, 0x37, 0x4e, 0xf4, 0x04, 0xfe, 0xfe,
+	0x43, 0x90, 0x10, 0x1a, 0x3c, 0x8e, 0x95, 0xd0, 0xda, 0x26, 0xab, 0xb5, 0xbc, 0xa5, 0x50, 0x62,
+	0xb1, 0x7a, 0xb5, 0x71, 0xbb, 0x91, 0x4b, 0x00, 0xc3, 0x97, 0x2f, 0x8b, 0x5c, 0x49, 0x6e, 0xec,
+	0xf5, 0x81, 0xe1, 0xcb, 0x31, 0x0a, 0xf7, 0xf0, 0xdc, 0xac, 0x2e, 0x9a, 0xf9, 0xf8, 0x23, 0x5e,
+	0x7f, 0x0e, 0x00, 0x8d, 0xf8, 0x9e, 0x94, 0xa7, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,24 @@
+package v1alpha1
+
+const (
+	StatsdTagFormatStatsd    = "statsd"
+	StatsdTagFormatDogStatsd = "dogstatsd"
+
+	StatsdDefaultPrefix = "envoy"
+)
+
+// GetTagFormatOrDefault returns format of tags sent to a StatsD server, `statsd` by default.
+func (s *Metrics_Statsd) GetTagFormatOrDefault() string {
+	if s.GetTagFormat() == "" {
+		return StatsdTagFormatStatsd
+	}
+	return s.GetTagFormat()
+}
+
+// GetPrefixOrDefault returns prefix of names of metrics sent to a StatsD server, `envoy` by default.
+func (s *Metrics_Statsd) GetPrefixOrDefault() string {
+	if s.GetPrefix() == "" {
+		return StatsdDefaultPrefix
+	}
+	return s.GetPrefix()
+}
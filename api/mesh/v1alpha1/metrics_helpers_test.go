@@ -0,0 +1,32 @@
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Kong/kuma/api/mesh/v1alpha1"
+)
+
+var _ = Describe("MetricsHelpers", func() {
+
+	Describe("Metrics_Statsd", func() {
+
+		It("should default to `statsd` tag format and `envoy` prefix", func() {
+			// given
+			statsd := &Metrics_Statsd{}
+
+			// expect
+			Expect(statsd.GetTagFormatOrDefault()).To(Equal("statsd"))
+			Expect(statsd.GetPrefixOrDefault()).To(Equal("envoy"))
+		})
+
+		It("should return configured tag format and prefix", func() {
+			// given
+			statsd := &Metrics_Statsd{TagFormat: "dogstatsd", Prefix: "kuma"}
+
+			// expect
+			Expect(statsd.GetTagFormatOrDefault()).To(Equal("dogstatsd"))
+			Expect(statsd.GetPrefixOrDefault()).To(Equal("kuma"))
+		})
+	})
+})
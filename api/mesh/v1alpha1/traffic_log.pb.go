@@ -23,6 +23,8 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // TrafficLog defines log for traffic between dataplanes.
 type TrafficLog struct {
 	// List of selectors to match dataplanes that are sources of traffic.
+	// On inbound interfaces of a destination, only selectors `service: *` and,
+	// when mTLS is enabled, `service: <name>` are supported.
 	Sources []*Selector `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
 	// List of selectors to match services that are destinations of traffic.
 	Destinations []*Selector `protobuf:"bytes,2,rep,name=destinations,proto3" json:"destinations,omitempty"`
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/pkg/config"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	"github.com/Kong/kuma/pkg/config/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/postgres"
+	"github.com/Kong/kuma/pkg/plugins/resources/postgres/migrations"
+)
+
+type migrateContext struct {
+	args struct {
+		configPath string
+	}
+}
+
+func newMigrateCmd() *cobra.Command {
+	ctx := &migrateContext{}
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage the schema of the Postgres store",
+		Long: `Manage the schema of the Postgres store.
+
+Migrations are embedded in kuma-cp. The Control Plane refuses to start against an outdated schema
+unless auto migration is enabled (KUMA_STORE_POSTGRES_AUTO_MIGRATE).`,
+	}
+	// flags
+	cmd.PersistentFlags().StringVarP(&ctx.args.configPath, "config-file", "c", "", "configuration file")
+	// sub-commands
+	cmd.AddCommand(newMigrateUpCmd(ctx))
+	cmd.AddCommand(newMigrateDownCmd(ctx))
+	cmd.AddCommand(newMigrateStatusCmd(ctx))
+	return cmd
+}
+
+func newMigrateUpCmd(ctx *migrateContext) *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Apply all pending migrations",
+		Long:  `Apply all pending migrations.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return ctx.withMigrator(func(migrator *migrations.Migrator) error {
+				applied, err := migrator.Up()
+				if err != nil {
+					return err
+				}
+				if len(applied) == 0 {
+					cmd.Println("schema is up to date")
+				}
+				for _, migration := range applied {
+					cmd.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newMigrateDownCmd(ctx *migrateContext) *cobra.Command {
+	args := struct {
+		steps int
+		force bool
+	}{}
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the most recent migrations",
+		Long: `Revert the most recent migrations.
+
+Reverting the initial migration removes all resources stored by Kuma, so it has to be confirmed with --force.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("steps") {
+				return errors.New("specify a number of migrations to revert with --steps")
+			}
+			if args.steps < 1 {
+				return errors.New("--steps must be a positive number")
+			}
+			return ctx.withMigrator(func(migrator *migrations.Migrator) error {
+				version, err := migrator.CurrentVersion()
+				if err != nil {
+					return err
+				}
+				if version > 0 && version <= args.steps && !args.force {
+					return errors.New("reverting the initial migration removes all resources stored by Kuma. Use --force to proceed")
+				}
+				reverted, err := migrator.Down(args.steps)
+				if err != nil {
+					return err
+				}
+				if len(reverted) == 0 {
+					cmd.Println("there are no migrations to revert")
+				}
+				for _, migration := range reverted {
+					cmd.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+				}
+				return nil
+			})
+		},
+	}
+	// flags
+	cmd.Flags().IntVar(&args.steps, "steps", 0, "number of migrations to revert")
+	cmd.Flags().BoolVar(&args.force, "force", false, "allow reverting the initial migration, which removes all resources stored by Kuma")
+	return cmd
+}
+
+func newMigrateStatusCmd(ctx *migrateContext) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show which migrations are applied",
+		Long:  `Show which migrations are applied.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return ctx.withMigrator(func(migrator *migrations.Migrator) error {
+				statuses, err := migrator.Status()
+				if err != nil {
+					return err
+				}
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+				fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+				for _, status := range statuses {
+					applied := "pending"
+					if status.AppliedAt != nil {
+						applied = status.AppliedAt.Format(time.RFC3339)
+					}
+					fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, applied)
+				}
+				return w.Flush()
+			})
+		},
+	}
+}
+
+func (c *migrateContext) withMigrator(fn func(*migrations.Migrator) error) error {
+	cfg := kuma_cp.DefaultConfig()
+	if err := config.Load(c.args.configPath, &cfg); err != nil {
+		return errors.Wrap(err, "could not load the configuration")
+	}
+	if cfg.Store.Type != store.PostgresStore {
+		return errors.Errorf("migrations are supported only by the %q store, the configured store is %q", store.PostgresStore, cfg.Store.Type)
+	}
+	migrator, err := postgres.NewMigrator(*cfg.Store.Postgres)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+	return fn(migrator)
+}
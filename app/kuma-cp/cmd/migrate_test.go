@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("migrate", func() {
+
+	DescribeTable("should reject invalid usage",
+		func(args []string, expectedErr string) {
+			// given
+			cmd := newRootCmd()
+			cmd.SetArgs(args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			// when
+			err := cmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("store other than postgres", []string{"migrate", "status"}, `migrations are supported only by the "postgres" store, the configured store is "memory"`),
+		Entry("no number of steps", []string{"migrate", "down"}, "specify a number of migrations to revert with --steps"),
+		Entry("non positive number of steps", []string{"migrate", "down", "--steps", "0"}, "--steps must be a positive number"),
+	)
+})
@@ -3,6 +3,7 @@ package cmd
 import (
 	"io/ioutil"
 	"net/http"
+	net_url "net/url"
 	"os"
 	"time"
 
@@ -14,6 +15,10 @@ import (
 	kumadp_config "github.com/Kong/kuma/app/kuma-dp/pkg/config"
 	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/accesslogs"
 	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/envoy"
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/envoyadmin"
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/readiness"
+	dataplane_template "github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/template"
+	kuma_cmd "github.com/Kong/kuma/pkg/cmd"
 	"github.com/Kong/kuma/pkg/config"
 	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
 	config_types "github.com/Kong/kuma/pkg/config/types"
@@ -49,6 +54,10 @@ func newRunCmd() *cobra.Command {
 				return err
 			}
 
+			if err := renderDataplaneResource(&cfg.DataplaneRuntime); err != nil {
+				return err
+			}
+
 			catalogClient, err := catalogClientFactory(cfg.ControlPlane.ApiServer.URL)
 			if err != nil {
 				return errors.Wrap(err, "could not create catalog client")
@@ -66,6 +75,15 @@ func newRunCmd() *cobra.Command {
 					return err
 				}
 			}
+			if cfg.DataplaneRuntime.Resource != "" {
+				if cfg.DataplaneRuntime.TokenPath == "" {
+					return errors.New("Kuma CP registers a Dataplane on behalf of Kuma DP only if it is authorized by the Dataplane Token. " +
+						"Generate token using 'kumactl generate dataplane-token > /path/file' and provide it via --dataplane-token-file=/path/file argument to Kuma DP")
+				}
+				if url, err := net_url.Parse(catalog.Apis.Bootstrap.Url); err == nil && url.Scheme == "http" {
+					runLog.Info("Dataplane Token is sent to Kuma CP over plain HTTP, make sure that Bootstrap Server is only reachable over a trusted network", "url", catalog.Apis.Bootstrap.Url)
+				}
+			}
 
 			if !cfg.Dataplane.AdminPort.Empty() {
 				// unless a user has explicitly opted out of Envoy Admin API, pick a free port from the range
@@ -115,10 +133,48 @@ func newRunCmd() *cobra.Command {
 				runLog.Info("stopped Access Log server")
 			}()
 
+			stop := core.SetupSignalHandler()
+
+			readinessServerErr := make(chan error)
+			switch {
+			case cfg.Dataplane.ReadinessPort == 0:
+				runLog.Info("readiness endpoint is disabled")
+			case cfg.Dataplane.AdminPort.Empty():
+				runLog.Info("readiness endpoint is disabled since Envoy Admin API is not exposed")
+			default:
+				readinessServer := readiness.NewServer(envoy.NewAdminClient(cfg.Dataplane.AdminPort.Lowest()).IsConfigAcked)
+				defer readinessServer.Close()
+
+				go func() {
+					defer close(readinessServerErr)
+					if err := readinessServer.Start(cfg.Dataplane.ReadinessPort, stop); err != nil {
+						runLog.Error(err, "problem running Readiness server")
+						readinessServerErr <- err
+					}
+					runLog.Info("stopped Readiness server")
+				}()
+			}
+
+			switch {
+			case cfg.Dataplane.AdminPort.Empty():
+				runLog.Info("reverse channel to Control Plane is disabled since Envoy Admin API is not exposed")
+			case !catalog.Apis.EnvoyAdmin.Enabled():
+				runLog.Info("reverse channel to Control Plane is disabled since Control Plane does not support it")
+			default:
+				relay, err := envoyadmin.NewRelay(catalog.Apis.EnvoyAdmin.Url, cfg.Dataplane.Mesh, cfg.Dataplane.Name, cfg.DataplaneRuntime.TokenPath, envoy.NewAdminClient(cfg.Dataplane.AdminPort.Lowest()))
+				if err != nil {
+					return errors.Wrap(err, "could not create a reverse channel to Control Plane")
+				}
+				go func() {
+					// a broken reverse channel is reopened, so it doesn't terminate the Dataplane
+					_ = relay.Start(stop)
+				}()
+			}
+
 			dataplaneErr := make(chan error)
 			go func() {
 				defer close(dataplaneErr)
-				if err := dataplane.Run(core.SetupSignalHandler()); err != nil {
+				if err := dataplane.Run(stop); err != nil {
 					runLog.Error(err, "problem running Dataplane (Envoy)")
 					dataplaneErr <- err
 				}
@@ -131,6 +187,11 @@ func newRunCmd() *cobra.Command {
 					return errors.New("Access Log server terminated unexpectedly")
 				}
 				return err
+			case err := <-readinessServerErr:
+				if err == nil {
+					return errors.New("Readiness server terminated unexpectedly")
+				}
+				return err
 			case err := <-dataplaneErr:
 				return err
 			}
@@ -139,10 +200,39 @@ func newRunCmd() *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&cfg.Dataplane.Name, "name", cfg.Dataplane.Name, "Name of the Dataplane")
 	cmd.PersistentFlags().Var(&cfg.Dataplane.AdminPort, "admin-port", `Port (or range of ports to choose from) for Envoy Admin API to listen on. Empty value indicates that Envoy Admin API should not be exposed over TCP. Format: "9901 | 9901-9999 | 9901- | -9901"`)
+	cmd.PersistentFlags().Uint32Var(&cfg.Dataplane.ReadinessPort, "readiness-port", cfg.Dataplane.ReadinessPort, "Port for the readiness endpoint to listen on (only on 127.0.0.1). The endpoint reports ready once Envoy has received its initial configuration from the Control Plane. 0 disables the endpoint")
 	cmd.PersistentFlags().StringVar(&cfg.Dataplane.Mesh, "mesh", cfg.Dataplane.Mesh, "Mesh that Dataplane belongs to")
 	cmd.PersistentFlags().StringVar(&cfg.ControlPlane.ApiServer.URL, "cp-address", cfg.ControlPlane.ApiServer.URL, "URL of the Control Plane API Server")
 	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.BinaryPath, "binary-path", cfg.DataplaneRuntime.BinaryPath, "Binary path of Envoy executable")
 	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.ConfigDir, "config-dir", cfg.DataplaneRuntime.ConfigDir, "Directory in which Envoy config will be generated")
+	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.EnvoyLogLevel, "envoy-log-level", cfg.DataplaneRuntime.EnvoyLogLevel, kuma_cmd.UsageOptions("Envoy log level", "trace", "debug", "info", "warning", "error", "critical", "off"))
+	cmd.PersistentFlags().Uint32Var(&cfg.DataplaneRuntime.EnvoyBaseId, "envoy-base-id", cfg.DataplaneRuntime.EnvoyBaseId, "Base ID of Envoy used for hot restart, must be unique among Envoy instances on the same machine. 0 indicates that it should be derived from mesh and name of the Dataplane")
 	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.TokenPath, "dataplane-token-file", cfg.DataplaneRuntime.TokenPath, "Path to a file with dataplane token (use 'kumactl generate dataplane-token' to get one)")
+	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.ResourcePath, "dataplane-file", cfg.DataplaneRuntime.ResourcePath, "Path to a file with Dataplane resource that Control Plane should create or update on behalf of this dataplane (in the format of 'kumactl apply')")
+	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.Resource, "dataplane", cfg.DataplaneRuntime.Resource, "Dataplane resource that Control Plane should create or update on behalf of this dataplane (in the format of 'kumactl apply')")
+	cmd.PersistentFlags().StringToStringVar(&cfg.DataplaneRuntime.ResourceVars, "dataplane-var", cfg.DataplaneRuntime.ResourceVars, "Value of a placeholder in Dataplane resource, e.g. --dataplane-var address=192.168.0.1 for {{ .address }}. Values of 'address' and 'hostname' are detected automatically unless given explicitly")
 	return cmd
 }
+
+// renderDataplaneResource renders Dataplane resource given as a template with placeholders like `{{ .address }}`,
+// so that Control Plane receives a complete Dataplane definition.
+func renderDataplaneResource(runtime *kuma_dp.DataplaneRuntime) error {
+	template := runtime.Resource
+	if runtime.ResourcePath != "" {
+		content, err := ioutil.ReadFile(runtime.ResourcePath)
+		if err != nil {
+			return errors.Wrapf(err, "could not read Dataplane resource from a file %q", runtime.ResourcePath)
+		}
+		template = string(content)
+	}
+	if template == "" {
+		return nil
+	}
+	resource, err := dataplane_template.Render(template, runtime.ResourceVars)
+	if err != nil {
+		return err
+	}
+	runtime.Resource = resource
+	runtime.ResourcePath = ""
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	kumadp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
 	test_catalog "github.com/Kong/kuma/pkg/test/catalog"
 	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
 	envoy_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v2"
 	"github.com/golang/protobuf/proto"
 
@@ -57,7 +58,7 @@ var _ = Describe("run", func() {
 		backupSetupSignalHandler = core.SetupSignalHandler
 		backupBootstrapGenerator = bootstrapGenerator
 		backupCatalogClientFactory = catalogClientFactory
-		bootstrapGenerator = func(_ string, cfg kumadp.Config) (proto.Message, error) {
+		bootstrapGenerator = func(_ string, cfg kumadp.Config, _ types.EnvoyProcessStatus) (proto.Message, error) {
 			bootstrap := envoy_bootstrap.Bootstrap{}
 			respBytes, err := ioutil.ReadFile(filepath.Join("testdata", "bootstrap-config.golden.yaml"))
 			Expect(err).ToNot(HaveOccurred())
@@ -340,6 +341,26 @@ var _ = Describe("run", func() {
 		Expect(err).To(MatchError("Kuma CP is configured with Dataplane Token Server therefore the Dataplane Token is required. Generate token using 'kumactl generate dataplane-token > /path/file' and provide it via --dataplane-token-file=/path/file argument to Kuma DP"))
 	})
 
+	It("should fail when a Dataplane resource is given but token is not provided", func() {
+		// given
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{
+			"run",
+			"--cp-address", "http://localhost:1234",
+			"--name", "example",
+			"--admin-port", fmt.Sprintf("%d", port),
+			"--binary-path", filepath.Join("testdata", "envoy-mock.sleep.sh"),
+			"--dataplane", `{"type": "Dataplane", "networking": {"inbound": [{"interface": "1.1.1.1:80:8080", "tags": {"service": "web"}}]}}`,
+		})
+
+		// when
+		err := cmd.Execute()
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(MatchError("Kuma CP registers a Dataplane on behalf of Kuma DP only if it is authorized by the Dataplane Token. Generate token using 'kumactl generate dataplane-token > /path/file' and provide it via --dataplane-token-file=/path/file argument to Kuma DP"))
+	})
+
 	It("should fail when there are no free ports in the port range chosen for Envoy Admin API", func() {
 
 		By("simulating another Envoy instance that already uses this port")
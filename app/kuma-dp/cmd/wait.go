@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/readiness"
+	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
+)
+
+var (
+	waitLog = dataplaneLog.WithName("wait")
+)
+
+func newWaitCmd() *cobra.Command {
+	args := struct {
+		readinessPort uint32
+		timeout       time.Duration
+		interval      time.Duration
+	}{
+		readinessPort: kuma_dp.DefaultConfig().Dataplane.ReadinessPort,
+		timeout:       60 * time.Second,
+		interval:      1 * time.Second,
+	}
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until Dataplane (Envoy) is ready",
+		Long: `Wait until Dataplane (Envoy) is ready.
+
+Blocks until Dataplane (Envoy) has received its initial configuration from the Control Plane.
+Use it in the entrypoint of an application container to make sure that the application
+doesn't start before it can reach other services in the Mesh, e.g.
+
+  kuma-dp wait --readiness-port 9902 && exec /app/server
+
+The readiness endpoint has to be enabled with --readiness-port flag of kuma-dp run.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if args.readinessPort == 0 {
+				return errors.New("specify a port of the readiness endpoint of `kuma-dp run` with --readiness-port")
+			}
+			url := fmt.Sprintf("http://127.0.0.1:%d%s", args.readinessPort, readiness.Path)
+			client := &http.Client{Timeout: args.interval}
+			deadline := time.After(args.timeout)
+			ticker := time.NewTicker(args.interval)
+			defer ticker.Stop()
+			for {
+				ready, err := isReady(client, url)
+				if ready {
+					waitLog.Info("Dataplane (Envoy) is ready")
+					return nil
+				}
+				waitLog.V(1).Info("Dataplane (Envoy) is not ready yet", "err", err)
+				select {
+				case <-deadline:
+					return errors.Errorf("Dataplane (Envoy) has not become ready within %s", args.timeout)
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+	cmd.PersistentFlags().Uint32Var(&args.readinessPort, "readiness-port", args.readinessPort, "Port of the readiness endpoint of `kuma-dp run`")
+	cmd.PersistentFlags().DurationVar(&args.timeout, "timeout", args.timeout, "How long to wait for Dataplane (Envoy) to become ready")
+	cmd.PersistentFlags().DurationVar(&args.interval, "interval", args.interval, "How often to check readiness of Dataplane (Envoy)")
+	return cmd
+}
+
+func isReady(client *http.Client, url string) (bool, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return true, nil
+}
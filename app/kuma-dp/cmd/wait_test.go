@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("wait", func() {
+
+	var server *httptest.Server
+	var ready int32
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&ready, 0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.URL.Path).To(Equal("/ready"))
+			if atomic.AddInt32(&ready, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	readinessPort := func() string {
+		u, err := url.Parse(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		return u.Port()
+	}
+
+	It("should wait until Dataplane (Envoy) is ready", func() {
+		// given
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"wait", "--readiness-port", readinessPort(), "--interval", "10ms", "--timeout", "5s"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		// when
+		err := cmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(atomic.LoadInt32(&ready)).To(Equal(int32(3)))
+	})
+
+	It("should fail when readiness port is not specified", func() {
+		// given
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"wait"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		// when
+		err := cmd.Execute()
+
+		// then
+		Expect(err).To(MatchError("specify a port of the readiness endpoint of `kuma-dp run` with --readiness-port"))
+	})
+
+	It("should fail when Dataplane (Envoy) doesn't become ready in time", func() {
+		// given
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"wait", "--readiness-port", readinessPort(), "--interval", "100ms", "--timeout", "50ms"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		// when
+		err := cmd.Execute()
+
+		// then
+		Expect(err).To(MatchError("Dataplane (Envoy) has not become ready within 50ms"))
+	})
+})
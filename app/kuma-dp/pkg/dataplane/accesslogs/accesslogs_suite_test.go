@@ -1,4 +1,4 @@
-package postgres_test
+package accesslogs
 
 import (
 	"testing"
@@ -7,7 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestControlPlane(t *testing.T) {
+func TestAccessLogs(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Postgres Suite")
+	RunSpecs(t, "Access Logs Suite")
 }
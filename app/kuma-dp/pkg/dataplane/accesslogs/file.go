@@ -0,0 +1,145 @@
+package accesslogs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// backupTimeFormat is a suffix of rotated files, e.g. `access.log.2020-01-02T15-04-05.000`.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+var now = time.Now
+
+// rotatingFile writes access log entries to a file which is rotated
+// once it exceeds max size or max age. Rotated files are renamed with a timestamp suffix
+// and only the most recent max backups of them are kept.
+type rotatingFile struct {
+	sync.Mutex
+	path       string
+	maxSize    uint64
+	maxAge     time.Duration
+	maxBackups int
+
+	file     *os.File
+	size     uint64
+	openedAt time.Time
+}
+
+func newRotatingFile(path string, maxSize uint64, maxAge time.Duration, maxBackups int) *rotatingFile {
+	return &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+}
+
+func (f *rotatingFile) Send(entry string) error {
+	f.Lock()
+	defer f.Unlock()
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if f.shouldRotate(len(entry)) {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.WriteString(entry)
+	f.size += uint64(n)
+	return err
+}
+
+func (f *rotatingFile) Close() error {
+	f.Lock()
+	defer f.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return errors.Wrapf(err, "could not create a directory for access logs file %q", f.path)
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "could not open access logs file %q", f.path)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.Wrapf(err, "could not stat access logs file %q", f.path)
+	}
+	f.file = file
+	f.size = uint64(info.Size())
+	f.openedAt = now()
+	return nil
+}
+
+func (f *rotatingFile) shouldRotate(entrySize int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.maxSize > 0 && f.size+uint64(entrySize) > f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && now().Sub(f.openedAt) >= f.maxAge
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return errors.Wrapf(err, "could not close access logs file %q", f.path)
+	}
+	f.file = nil
+	backup := f.path + "." + now().Format(backupTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil {
+		return errors.Wrapf(err, "could not rotate access logs file %q", f.path)
+	}
+	if err := f.removeOldBackups(); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+func (f *rotatingFile) removeOldBackups() error {
+	if f.maxBackups <= 0 {
+		return nil
+	}
+	dir, prefix := filepath.Dir(f.path), filepath.Base(f.path)+"."
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "could not list rotated access logs files in %q", dir)
+	}
+	var backups []string
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), prefix) {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, strings.TrimPrefix(file.Name(), prefix)); err != nil {
+			continue
+		}
+		backups = append(backups, file.Name())
+	}
+	// timestamp suffixes sort chronologically
+	sort.Strings(backups)
+	for len(backups) > f.maxBackups {
+		if err := os.Remove(filepath.Join(dir, backups[0])); err != nil {
+			return errors.Wrapf(err, "could not remove rotated access logs file %q", backups[0])
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package accesslogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_data_accesslog_v2 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
+)
+
+// jsonFormatPrefix marks a structured format of access logs,
+// i.e. a JSON object with format strings as values.
+const jsonFormatPrefix = "json;"
+
+const spiffePrefix = "spiffe://"
+
+// logFormat renders access log entries received from Envoy.
+type logFormat interface {
+	Format(entry *envoy_data_accesslog_v2.HTTPAccessLogEntry) string
+}
+
+// parseFormat parses a format of access logs encoded into a log name by Control Plane.
+func parseFormat(format string) (logFormat, error) {
+	if !strings.HasPrefix(format, jsonFormatPrefix) {
+		return textFormat(format), nil
+	}
+	fields := map[string]string{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(format, jsonFormatPrefix)), &fields); err != nil {
+		return nil, errors.Wrap(err, "JSON format must be a JSON object with string values")
+	}
+	return jsonFormat(fields), nil
+}
+
+// textFormat is a format string with placeholders.
+type textFormat string
+
+func (f textFormat) Format(entry *envoy_data_accesslog_v2.HTTPAccessLogEntry) string {
+	return replacePlaceholders(string(f), placeholdersOf(entry))
+}
+
+// jsonFormat renders every access log entry as a single-line JSON object
+// with values of the given format strings.
+type jsonFormat map[string]string
+
+func (f jsonFormat) Format(entry *envoy_data_accesslog_v2.HTTPAccessLogEntry) string {
+	placeholders := placeholdersOf(entry)
+	fields := map[string]string{}
+	for key, format := range f {
+		fields[key] = replacePlaceholders(format, placeholders)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		// it's not possible to get an error out of marshalling map[string]string
+		panic(err)
+	}
+	return string(data) + "\n"
+}
+
+// replacePlaceholders substitutes all placeholders in a single pass,
+// so that values that look like placeholders, e.g. a request path, are left intact.
+func replacePlaceholders(format string, placeholders map[string]string) string {
+	var oldnew []string
+	for placeholder, value := range placeholders {
+		oldnew = append(oldnew, placeholder, value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(format)
+}
+
+func placeholdersOf(entry *envoy_data_accesslog_v2.HTTPAccessLogEntry) map[string]string {
+	addrToString := func(addr *envoy_core.Address) string {
+		return fmt.Sprintf("%s:%d", addr.GetSocketAddress().GetAddress(), addr.GetSocketAddress().GetPortValue())
+	}
+	connectionTime := int64(0)
+	if entry.GetCommonProperties().GetTimeToLastDownstreamTxByte() != nil {
+		t, err := ptypes.Duration(entry.GetCommonProperties().GetTimeToLastDownstreamTxByte())
+		if err == nil {
+			connectionTime = int64(t / time.Millisecond)
+		}
+	}
+	responseCode := "0"
+	if entry.GetResponse().GetResponseCode() != nil {
+		responseCode = strconv.FormatUint(uint64(entry.GetResponse().GetResponseCode().GetValue()), 10)
+	}
+	return map[string]string{
+		"%START_TIME%":                       util_proto.TimestampString(entry.GetCommonProperties().GetStartTime(), time.RFC3339),
+		"%DOWNSTREAM_REMOTE_ADDRESS%":        addrToString(entry.GetCommonProperties().GetDownstreamRemoteAddress()),
+		"%DOWNSTREAM_LOCAL_ADDRESS%":         addrToString(entry.GetCommonProperties().GetDownstreamLocalAddress()),
+		"%UPSTREAM_HOST%":                    addrToString(entry.GetCommonProperties().GetUpstreamRemoteAddress()),
+		"%UPSTREAM_REMOTE_ADDRESS%":          addrToString(entry.GetCommonProperties().GetUpstreamRemoteAddress()),
+		"%UPSTREAM_LOCAL_ADDRESS%":           addrToString(entry.GetCommonProperties().GetUpstreamLocalAddress()),
+		"%UPSTREAM_CLUSTER%":                 entry.GetCommonProperties().GetUpstreamCluster(),
+		"%BYTES_RECEIVED%":                   strconv.FormatUint(entry.GetRequest().GetRequestBodyBytes(), 10),
+		"%BYTES_SENT%":                       strconv.FormatUint(entry.GetResponse().GetResponseBodyBytes(), 10),
+		"%DURATION%":                         strconv.FormatInt(connectionTime, 10),
+		"%RESPONSE_TX_DURATION%":             strconv.FormatInt(connectionTime, 10),
+		"%RESPONSE_CODE%":                    responseCode,
+		"%RESPONSE_FLAGS%":                   responseFlagsOf(entry.GetCommonProperties().GetResponseFlags()),
+		"%REQ(:METHOD)%":                     methodOf(entry.GetRequest()),
+		"%REQ(:PATH)%":                       entry.GetRequest().GetPath(),
+		"%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%": originalPathOf(entry.GetRequest()),
+		"%REQ(:AUTHORITY)%":                  entry.GetRequest().GetAuthority(),
+		"%REQ(USER-AGENT)%":                  entry.GetRequest().GetUserAgent(),
+		"%REQ(X-REQUEST-ID)%":                entry.GetRequest().GetRequestId(),
+		"%PROTOCOL%":                         protocolOf(entry.GetProtocolVersion()),
+		"%KUMA_SOURCE_SERVICE%":              sourceServiceOf(entry.GetCommonProperties().GetTlsProperties()),
+	}
+}
+
+// sourceServiceOf resolves a service of a peer from a SPIFFE ID of its certificate, i.e. `spiffe://<mesh>/<service>`.
+// Control Plane leaves %KUMA_SOURCE_SERVICE% to kuma-dp only in access logs of inbound listeners that are delivered by kuma-dp,
+// where a source of a request is not known in advance.
+func sourceServiceOf(tls *envoy_data_accesslog_v2.TLSProperties) string {
+	if service := peerServiceOf(tls); service != "" {
+		return service
+	}
+	return "-"
+}
+
+// peerServiceOf returns a service from a SPIFFE ID of the peer certificate or an empty string if there is none.
+func peerServiceOf(tls *envoy_data_accesslog_v2.TLSProperties) string {
+	for _, san := range tls.GetPeerCertificateProperties().GetSubjectAltName() {
+		uri := san.GetUri()
+		if !strings.HasPrefix(uri, spiffePrefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(uri, spiffePrefix), "/", 2)
+		if len(parts) == 2 && parts[1] != "" {
+			return parts[1]
+		}
+	}
+	return ""
+}
+
+func methodOf(request *envoy_data_accesslog_v2.HTTPRequestProperties) string {
+	if request.GetRequestMethod() == envoy_core.RequestMethod_METHOD_UNSPECIFIED {
+		return ""
+	}
+	return request.GetRequestMethod().String()
+}
+
+func originalPathOf(request *envoy_data_accesslog_v2.HTTPRequestProperties) string {
+	if request.GetOriginalPath() != "" {
+		return request.GetOriginalPath()
+	}
+	return request.GetPath()
+}
+
+func protocolOf(version envoy_data_accesslog_v2.HTTPAccessLogEntry_HTTPVersion) string {
+	switch version {
+	case envoy_data_accesslog_v2.HTTPAccessLogEntry_HTTP10:
+		return "HTTP/1.0"
+	case envoy_data_accesslog_v2.HTTPAccessLogEntry_HTTP11:
+		return "HTTP/1.1"
+	case envoy_data_accesslog_v2.HTTPAccessLogEntry_HTTP2:
+		return "HTTP/2"
+	case envoy_data_accesslog_v2.HTTPAccessLogEntry_HTTP3:
+		return "HTTP/3"
+	default:
+		return ""
+	}
+}
+
+// responseFlagsOf renders response flags the same way Envoy does, e.g. `UH,UF` or `-` if there are none.
+func responseFlagsOf(flags *envoy_data_accesslog_v2.ResponseFlags) string {
+	var values []string
+	add := func(set bool, value string) {
+		if set {
+			values = append(values, value)
+		}
+	}
+	add(flags.GetFailedLocalHealthcheck(), "LH")
+	add(flags.GetNoHealthyUpstream(), "UH")
+	add(flags.GetUpstreamRequestTimeout(), "UT")
+	add(flags.GetLocalReset(), "LR")
+	add(flags.GetUpstreamRemoteReset(), "UR")
+	add(flags.GetUpstreamConnectionFailure(), "UF")
+	add(flags.GetUpstreamConnectionTermination(), "UC")
+	add(flags.GetUpstreamOverflow(), "UO")
+	add(flags.GetNoRouteFound(), "NR")
+	add(flags.GetDelayInjected(), "DI")
+	add(flags.GetFaultInjected(), "FI")
+	add(flags.GetRateLimited(), "RL")
+	add(flags.GetUnauthorizedDetails() != nil, "UAEX")
+	add(flags.GetRateLimitServiceError(), "RLSE")
+	add(flags.GetDownstreamConnectionTermination(), "DC")
+	add(flags.GetUpstreamRetryLimitExceeded(), "URX")
+	add(flags.GetStreamIdleTimeout(), "SI")
+	add(flags.GetInvalidEnvoyRequestHeaders(), "IH")
+	add(flags.GetDownstreamProtocolError(), "DPE")
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,144 @@
+package accesslogs
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_data_accesslog_v2 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
+)
+
+var _ = Describe("format", func() {
+
+	var entry *envoy_data_accesslog_v2.HTTPAccessLogEntry
+
+	BeforeEach(func() {
+		startTime, err := time.Parse(time.RFC3339, "2020-01-02T03:04:05Z")
+		Expect(err).ToNot(HaveOccurred())
+
+		entry = &envoy_data_accesslog_v2.HTTPAccessLogEntry{
+			CommonProperties: &envoy_data_accesslog_v2.AccessLogCommon{
+				StartTime: util_proto.MustTimestampProto(startTime),
+				UpstreamRemoteAddress: &envoy_core.Address{
+					Address: &envoy_core.Address_SocketAddress{
+						SocketAddress: &envoy_core.SocketAddress{
+							Address: "10.0.0.2",
+							PortSpecifier: &envoy_core.SocketAddress_PortValue{
+								PortValue: 8080,
+							},
+						},
+					},
+				},
+				UpstreamCluster: "backend",
+				ResponseFlags: &envoy_data_accesslog_v2.ResponseFlags{
+					NoHealthyUpstream:         true,
+					UpstreamConnectionFailure: true,
+				},
+			},
+			ProtocolVersion: envoy_data_accesslog_v2.HTTPAccessLogEntry_HTTP11,
+			Request: &envoy_data_accesslog_v2.HTTPRequestProperties{
+				RequestMethod:    envoy_core.RequestMethod_POST,
+				Path:             "/api/%BYTES_SENT%",
+				UserAgent:        "curl/7.64.1",
+				RequestId:        "a9680ef2-aa57-11e9-85b6-acde48001122",
+				RequestBodyBytes: 123,
+			},
+			Response: &envoy_data_accesslog_v2.HTTPResponseProperties{
+				ResponseCode:      &wrappers.UInt32Value{Value: 503},
+				ResponseBodyBytes: 456,
+			},
+		}
+	})
+
+	type testCase struct {
+		format   string
+		expected string
+	}
+
+	DescribeTable("should render access log entries",
+		func(given testCase) {
+			// given
+			format, err := parseFormat(given.format)
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			actual := format.Format(entry)
+
+			// then
+			Expect(actual).To(Equal(given.expected))
+		},
+		Entry("text format", testCase{
+			format:   "[%START_TIME%] %UPSTREAM_HOST%(%UPSTREAM_CLUSTER%) sent %BYTES_SENT% bytes, received: %BYTES_RECEIVED% bytes\n",
+			expected: "[2020-01-02T03:04:05Z] 10.0.0.2:8080(backend) sent 456 bytes, received: 123 bytes\n",
+		}),
+		Entry("text format with HTTP placeholders", testCase{
+			format:   `"%REQ(:METHOD)% %REQ(:PATH)% %PROTOCOL%" %RESPONSE_CODE% %RESPONSE_FLAGS% "%REQ(USER-AGENT)%" "%REQ(X-REQUEST-ID)%"`,
+			expected: `"POST /api/%BYTES_SENT% HTTP/1.1" 503 UH,UF "curl/7.64.1" "a9680ef2-aa57-11e9-85b6-acde48001122"`,
+		}),
+		Entry("JSON format", testCase{
+			format:   `json;{"method":"%REQ(:METHOD)%","status":"%RESPONSE_CODE%","bytes":"%BYTES_SENT%","upstream":"%UPSTREAM_HOST% (%UPSTREAM_CLUSTER%)"}`,
+			expected: `{"bytes":"456","method":"POST","status":"503","upstream":"10.0.0.2:8080 (backend)"}` + "\n",
+		}),
+	)
+
+	It("should render `-` when there are no response flags", func() {
+		// given
+		entry.CommonProperties.ResponseFlags = nil
+
+		// when
+		actual := textFormat("%RESPONSE_FLAGS%").Format(entry)
+
+		// then
+		Expect(actual).To(Equal("-"))
+	})
+
+	It("should resolve source service from a SPIFFE ID of the peer certificate", func() {
+		// given
+		entry.CommonProperties.TlsProperties = &envoy_data_accesslog_v2.TLSProperties{
+			PeerCertificateProperties: &envoy_data_accesslog_v2.TLSProperties_CertificateProperties{
+				SubjectAltName: []*envoy_data_accesslog_v2.TLSProperties_CertificateProperties_SubjectAltName{
+					{
+						San: &envoy_data_accesslog_v2.TLSProperties_CertificateProperties_SubjectAltName_Dns{
+							Dns: "web.example.com",
+						},
+					},
+					{
+						San: &envoy_data_accesslog_v2.TLSProperties_CertificateProperties_SubjectAltName_Uri{
+							Uri: "spiffe://default/web",
+						},
+					},
+				},
+			},
+		}
+
+		// when
+		actual := textFormat("%KUMA_SOURCE_SERVICE%->backend").Format(entry)
+
+		// then
+		Expect(actual).To(Equal("web->backend"))
+	})
+
+	It("should render `-` as source service without the peer certificate", func() {
+		// when
+		actual := textFormat("%KUMA_SOURCE_SERVICE%->backend").Format(entry)
+
+		// then
+		Expect(actual).To(Equal("-->backend"))
+	})
+
+	It("should reject JSON format that is not a JSON object", func() {
+		// when
+		_, err := parseFormat(`json;["%START_TIME%"]`)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("JSON format must be a JSON object with string values"))
+	})
+})
@@ -0,0 +1,161 @@
+package accesslogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultFlushInterval = 5 * time.Second
+	defaultMaxRetries    = 3
+	// bufferedBatches is a number of batches that can wait to be sent before new entries are dropped.
+	bufferedBatches = 10
+)
+
+var (
+	httpRequestTimeout = 10 * time.Second
+	httpRetryBackoff   = 1 * time.Second
+)
+
+// httpSink sends access log entries to an HTTP collector in batches, i.e. as JSON arrays.
+// Entries in JSON format are embedded as JSON objects, other entries as strings.
+// A batch is sent once it's full or once flush interval passes, failed batches are retried with backoff.
+type httpSink struct {
+	url           string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	log           logr.Logger
+
+	entries chan string
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newHttpSink(url string, batchSize int, flushInterval time.Duration, maxRetries int, log logr.Logger) *httpSink {
+	s := &httpSink{
+		url:           url,
+		client:        &http.Client{Timeout: httpRequestTimeout},
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		maxRetries:    maxRetries,
+		log:           log.WithValues("url", url),
+		entries:       make(chan string, batchSize*bufferedBatches),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Send buffers an entry. Entries are dropped if the collector cannot keep up.
+func (s *httpSink) Send(entry string) error {
+	select {
+	case s.entries <- entry:
+	default:
+		s.log.Info("buffer of HTTP logging backend is full, dropping an entry")
+	}
+	return nil
+}
+
+// Close sends remaining entries and stops the sink.
+func (s *httpSink) Close() error {
+	close(s.stop)
+	<-s.done
+	return nil
+}
+
+func (s *httpSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	var batch []string
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.sendWithRetries(batch); err != nil {
+			s.log.Error(err, "dropping a batch of access log entries", "entries", len(batch))
+		}
+		batch = nil
+	}
+	for {
+		select {
+		case entry := <-s.entries:
+			batch = append(batch, entry)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.stop:
+			for {
+				select {
+				case entry := <-s.entries:
+					batch = append(batch, entry)
+					if len(batch) >= s.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *httpSink) sendWithRetries(batch []string) error {
+	body, err := batchBody(batch)
+	if err != nil {
+		return err
+	}
+	backoff := httpRetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = s.send(body)
+		if err == nil || attempt >= s.maxRetries {
+			return err
+		}
+		s.log.Info("failed to send a batch of access log entries, retrying", "attempt", attempt+1, "err", err.Error())
+		select {
+		case <-time.After(backoff):
+		case <-s.stop:
+			// do not delay shutdown with backoff, but still try once more
+		}
+		backoff *= 2
+	}
+}
+
+func (s *httpSink) send(body []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func batchBody(batch []string) ([]byte, error) {
+	items := make([]interface{}, len(batch))
+	for i, entry := range batch {
+		entry = strings.TrimRight(entry, "\n")
+		if strings.HasPrefix(entry, "{") && json.Valid([]byte(entry)) {
+			items[i] = json.RawMessage(entry)
+		} else {
+			items[i] = entry
+		}
+	}
+	body, err := json.Marshal(items)
+	return body, errors.Wrap(err, "could not marshal a batch of access log entries")
+}
@@ -4,21 +4,18 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"strconv"
-	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	kumadp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
 	"github.com/Kong/kuma/pkg/core"
-	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/accesslog"
 
-	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	envoy_data_accesslog_v2 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
 	v2 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v2"
 )
@@ -32,6 +29,9 @@ const (
 type accessLogServer struct {
 	server *grpc.Server
 
+	// files shared between streams that write to the same file
+	files sharedFiles
+
 	// streamCount for counting streams
 	streamCount int64
 }
@@ -57,9 +57,14 @@ func (s *accessLogServer) StreamAccessLogs(stream v2.AccessLogService_StreamAcce
 		}
 	}()
 
+	// Control Plane passes sources whose requests are logged on inbound listeners in initial metadata of a stream
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	sources := accesslog.ParseSourceFilter(md)
+
 	initialized := false
-	var address, format string
-	var conn net.Conn
+	var address string
+	var format logFormat
+	var sink logSink
 	for {
 		msg, err := stream.Recv()
 		if err != nil {
@@ -74,17 +79,20 @@ func (s *accessLogServer) StreamAccessLogs(stream v2.AccessLogService_StreamAcce
 		if !initialized {
 			initialized = true
 
-			parts := strings.SplitN(msg.Identifier.GetLogName(), ";", 2)
-			if len(parts) != 2 {
-				return errors.Errorf("failed to initialize Access Logs stream: invalid log name %q: expected %d components, got %d", msg.Identifier.GetLogName(), 2, len(parts))
+			var rawFormat string
+			address, rawFormat, err = accesslog.ParseLogName(msg.Identifier.GetLogName())
+			if err != nil {
+				return errors.Wrapf(err, "failed to initialize Access Logs stream: invalid log name %q", msg.Identifier.GetLogName())
+			}
+			format, err = parseFormat(rawFormat)
+			if err != nil {
+				return errors.Wrapf(err, "failed to initialize Access Logs stream: invalid log name %q", msg.Identifier.GetLogName())
 			}
-			address = parts[0]
-			format = parts[1]
-			conn, err = s.connect(address, log)
+			sink, err = s.openSink(address, log)
 			if err != nil {
 				return err
 			}
-			defer conn.Close()
+			defer sink.Close()
 		}
 
 		var httpLogs []*envoy_data_accesslog_v2.HTTPAccessLogEntry
@@ -98,58 +106,25 @@ func (s *accessLogServer) StreamAccessLogs(stream v2.AccessLogService_StreamAcce
 		}
 
 		for _, httpLogEntry := range httpLogs {
-			entry := formatEntry(httpLogEntry, format)
-			if err := s.sendLog(conn, entry); err != nil {
-				return errors.Wrap(err, "could not send log entry to a TCP logging backend")
+			if !sources.Matches(peerServiceOf(httpLogEntry.GetCommonProperties().GetTlsProperties())) {
+				continue
+			}
+			entry := format.Format(httpLogEntry)
+			if err := sink.Send(entry); err != nil {
+				return errors.Wrap(err, "could not send log entry to a logging backend")
 			}
 		}
 	}
 }
 
-func formatEntry(entry *envoy_data_accesslog_v2.HTTPAccessLogEntry, format string) string {
-	addrToString := func(addr *envoy_core.Address) string {
-		return fmt.Sprintf("%s:%d", addr.GetSocketAddress().GetAddress(), addr.GetSocketAddress().GetPortValue())
-	}
-	connectionTime := int64(0)
-	if entry.GetCommonProperties().GetTimeToLastDownstreamTxByte() != nil {
-		t, err := ptypes.Duration(entry.GetCommonProperties().GetTimeToLastDownstreamTxByte())
-		if err == nil {
-			connectionTime = int64(t / time.Millisecond)
-		}
-	}
-	placeholders := map[string]string{
-		"%START_TIME%":                util_proto.TimestampString(entry.GetCommonProperties().GetStartTime(), time.RFC3339),
-		"%DOWNSTREAM_REMOTE_ADDRESS%": addrToString(entry.GetCommonProperties().GetDownstreamRemoteAddress()),
-		"%DOWNSTREAM_LOCAL_ADDRESS%":  addrToString(entry.GetCommonProperties().GetDownstreamLocalAddress()),
-		"%UPSTREAM_HOST%":             addrToString(entry.GetCommonProperties().GetUpstreamRemoteAddress()),
-		"%UPSTREAM_REMOTE_ADDRESS%":   addrToString(entry.GetCommonProperties().GetUpstreamRemoteAddress()),
-		"%UPSTREAM_LOCAL_ADDRESS%":    addrToString(entry.GetCommonProperties().GetUpstreamLocalAddress()),
-		"%UPSTREAM_CLUSTER%":          entry.GetCommonProperties().GetUpstreamCluster(),
-		"%BYTES_RECEIVED%":            strconv.FormatUint(entry.GetResponse().GetResponseBodyBytes(), 10),
-		"%BYTES_SENT%":                strconv.FormatUint(entry.GetRequest().GetRequestBodyBytes(), 10),
-		"%DURATION%":                  strconv.FormatInt(connectionTime, 10),
-		"%RESPONSE_TX_DURATION%":      strconv.FormatInt(connectionTime, 10),
-	}
-	log := format
-	for placeholder, value := range placeholders {
-		log = strings.ReplaceAll(log, placeholder, value)
-	}
-	return log
-}
-
-func (s *accessLogServer) sendLog(conn net.Conn, log string) error {
-	_, err := conn.Write([]byte(log))
-	return err
-}
-
-func (s *accessLogServer) connect(address string, log logr.Logger) (net.Conn, error) {
+func (s *accessLogServer) connect(address string, log logr.Logger) (*tcpSink, error) {
 	conn, err := net.DialTimeout("tcp", address, defaultConnectTimeout)
 	if err != nil {
 		log.Error(err, "failed to connect to TCP logging backend", "address", address)
 		return nil, err
 	}
 	log.Info("connected to TCP logging backend", "address", address)
-	return conn, nil
+	return &tcpSink{conn: conn}, nil
 }
 
 func (s *accessLogServer) Start(dataplane kumadp.Dataplane) error {
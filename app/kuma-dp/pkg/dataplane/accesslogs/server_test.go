@@ -0,0 +1,110 @@
+package accesslogs
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+
+	envoy_data_accesslog_v2 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
+	v2 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v2"
+)
+
+var _ = Describe("accessLogServer", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "access-logs")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	entryFrom := func(peerURI string) *envoy_data_accesslog_v2.HTTPAccessLogEntry {
+		entry := &envoy_data_accesslog_v2.HTTPAccessLogEntry{
+			CommonProperties: &envoy_data_accesslog_v2.AccessLogCommon{},
+		}
+		if peerURI != "" {
+			entry.CommonProperties.TlsProperties = &envoy_data_accesslog_v2.TLSProperties{
+				PeerCertificateProperties: &envoy_data_accesslog_v2.TLSProperties_CertificateProperties{
+					SubjectAltName: []*envoy_data_accesslog_v2.TLSProperties_CertificateProperties_SubjectAltName{
+						{San: &envoy_data_accesslog_v2.TLSProperties_CertificateProperties_SubjectAltName_Uri{Uri: peerURI}},
+					},
+				},
+			}
+		}
+		return entry
+	}
+
+	It("should log only requests from sources passed in metadata of a stream", func() {
+		// given
+		path := filepath.Join(dir, "access.log")
+		stream := &fakeStream{
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.New(accesslog.SourceFilter{
+				ExcludedSources: []string{"mobile"},
+			}.Metadata())),
+			msgs: []*v2.StreamAccessLogsMessage{{
+				Identifier: &v2.StreamAccessLogsMessage_Identifier{
+					LogName: accesslog.LogName(accesslog.FileAddress(path, url.Values{}), "%KUMA_SOURCE_SERVICE%\n"),
+				},
+				LogEntries: &v2.StreamAccessLogsMessage_HttpLogs{
+					HttpLogs: &v2.StreamAccessLogsMessage_HTTPAccessLogEntries{
+						LogEntry: []*envoy_data_accesslog_v2.HTTPAccessLogEntry{
+							entryFrom("spiffe://default/web"),
+							entryFrom("spiffe://default/mobile"),
+							entryFrom(""),
+						},
+					},
+				},
+			}},
+		}
+
+		// when
+		err := NewAccessLogServer().StreamAccessLogs(stream)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		data, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("web\n-\n"))
+	})
+})
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []*v2.StreamAccessLogsMessage
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) Recv() (*v2.StreamAccessLogsMessage, error) {
+	if len(s.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+func (s *fakeStream) SendAndClose(*v2.StreamAccessLogsResponse) error {
+	return nil
+}
+
+var _ v2.AccessLogService_StreamAccessLogsServer = &fakeStream{}
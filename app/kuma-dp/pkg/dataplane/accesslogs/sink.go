@@ -0,0 +1,164 @@
+package accesslogs
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+)
+
+// logSink delivers formatted access log entries to a logging backend.
+type logSink interface {
+	Send(entry string) error
+	Close() error
+}
+
+// openSink opens a sink for a logging backend at the address encoded into a log name by Control Plane:
+//   - `<host>:<port>` - a TCP backend
+//   - `syslog+udp://<host>:<port>?facility=<code>` and `syslog+tcp://...` - a syslog server
+//   - `http(s)://<url>#batchSize=<count>&flushInterval=<duration>&maxRetries=<count>` - an HTTP collector
+//   - `file:<path>?maxSize=<bytes>&maxAge=<duration>&maxBackups=<count>` - a file with rotation
+func (s *accessLogServer) openSink(address string, log logr.Logger) (logSink, error) {
+	if !strings.Contains(address, "://") && !strings.HasPrefix(address, "file:") {
+		return s.connect(address, log)
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address of a logging backend %q", address)
+	}
+	switch u.Scheme {
+	case "syslog+udp", "syslog+tcp":
+		facility, err := uintParam(u.Query(), "facility", defaultSyslogFacility)
+		if err != nil {
+			return nil, err
+		}
+		return newSyslogSink(strings.TrimPrefix(u.Scheme, "syslog+"), u.Host, int(facility), log)
+	case "http", "https":
+		options, err := url.ParseQuery(u.Fragment)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid options of an HTTP logging backend %q", address)
+		}
+		batchSize, err := uintParam(options, "batchSize", defaultBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		flushInterval, err := durationParam(options, "flushInterval", defaultFlushInterval)
+		if err != nil {
+			return nil, err
+		}
+		maxRetries, err := uintParam(options, "maxRetries", defaultMaxRetries)
+		if err != nil {
+			return nil, err
+		}
+		u.Fragment = ""
+		return newHttpSink(u.String(), int(batchSize), flushInterval, int(maxRetries), log), nil
+	case "file":
+		query := u.Query()
+		maxSize, err := uintParam(query, "maxSize", 0)
+		if err != nil {
+			return nil, err
+		}
+		maxAge, err := durationParam(query, "maxAge", 0)
+		if err != nil {
+			return nil, err
+		}
+		maxBackups, err := uintParam(query, "maxBackups", 0)
+		if err != nil {
+			return nil, err
+		}
+		path, err := accesslog.FilePath(u)
+		if err != nil {
+			return nil, err
+		}
+		return s.files.open(address, func() *rotatingFile {
+			return newRotatingFile(path, maxSize, maxAge, int(maxBackups))
+		}), nil
+	default:
+		return nil, errors.Errorf("unsupported logging backend %q", address)
+	}
+}
+
+func uintParam(values url.Values, name string, defaultValue uint64) (uint64, error) {
+	value := values.Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid value of %q parameter", name)
+	}
+	return result, nil
+}
+
+func durationParam(values url.Values, name string, defaultValue time.Duration) (time.Duration, error) {
+	value := values.Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	result, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid value of %q parameter", name)
+	}
+	return result, nil
+}
+
+// tcpSink writes access log entries to a TCP connection as they are.
+type tcpSink struct {
+	conn net.Conn
+}
+
+func (s *tcpSink) Send(entry string) error {
+	_, err := s.conn.Write([]byte(entry))
+	return err
+}
+
+func (s *tcpSink) Close() error {
+	return s.conn.Close()
+}
+
+// sharedFiles keeps a single writer per file, so that multiple Access Logs streams
+// do not rotate the same file independently.
+type sharedFiles struct {
+	sync.Mutex
+	files map[string]*sharedFile
+}
+
+type sharedFile struct {
+	*rotatingFile
+	owner *sharedFiles
+	key   string
+	refs  int
+}
+
+func (f *sharedFiles) open(key string, create func() *rotatingFile) logSink {
+	f.Lock()
+	defer f.Unlock()
+	if f.files == nil {
+		f.files = map[string]*sharedFile{}
+	}
+	file, ok := f.files[key]
+	if !ok {
+		file = &sharedFile{rotatingFile: create(), owner: f, key: key}
+		f.files[key] = file
+	}
+	file.refs++
+	return file
+}
+
+func (f *sharedFile) Close() error {
+	f.owner.Lock()
+	defer f.owner.Unlock()
+	f.refs--
+	if f.refs > 0 {
+		return nil
+	}
+	delete(f.owner.files, f.key)
+	return f.rotatingFile.Close()
+}
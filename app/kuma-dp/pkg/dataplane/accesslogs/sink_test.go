@@ -0,0 +1,303 @@
+package accesslogs
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+)
+
+var _ = Describe("sinks", func() {
+
+	var server *accessLogServer
+
+	BeforeEach(func() {
+		server = NewAccessLogServer()
+	})
+
+	It("should reject unsupported logging backends", func() {
+		// when
+		_, err := server.openSink("ftp://example.com/logs", core.Log)
+
+		// then
+		Expect(err).To(MatchError(`unsupported logging backend "ftp://example.com/logs"`))
+	})
+
+	Describe("syslog", func() {
+
+		It("should send RFC5424 messages over UDP", func() {
+			// setup
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			// given
+			sink, err := server.openSink(fmt.Sprintf("syslog+udp://%s?facility=3", conn.LocalAddr()), core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			defer sink.Close()
+
+			// when
+			Expect(sink.Send("GET /api 200\n")).To(Succeed())
+
+			// then
+			buf := make([]byte, 1024)
+			Expect(conn.SetReadDeadline(time.Now().Add(5 * time.Second))).To(Succeed())
+			n, _, err := conn.ReadFrom(buf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(buf[:n])).To(MatchRegexp(`^<30>1 \S+ \S+ kuma-dp \d+ - - GET /api 200$`))
+		})
+
+		It("should send octet-counted RFC5424 messages over TCP", func() {
+			// setup
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+			received := make(chan string, 1)
+			go func() {
+				defer GinkgoRecover()
+				conn, err := listener.Accept()
+				Expect(err).ToNot(HaveOccurred())
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				var length int
+				_, err = fmt.Fscanf(reader, "%d ", &length)
+				Expect(err).ToNot(HaveOccurred())
+				msg := make([]byte, length)
+				_, err = reader.Read(msg)
+				Expect(err).ToNot(HaveOccurred())
+				received <- string(msg)
+			}()
+
+			// given
+			sink, err := server.openSink(fmt.Sprintf("syslog+tcp://%s", listener.Addr()), core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			defer sink.Close()
+
+			// when
+			Expect(sink.Send("GET /api 200\n")).To(Succeed())
+
+			// then
+			Eventually(received, "5s").Should(Receive(MatchRegexp(`^<134>1 \S+ \S+ kuma-dp \d+ - - GET /api 200$`)))
+		})
+	})
+
+	Describe("http", func() {
+
+		var collector *httptest.Server
+		var mutex sync.Mutex
+		var batches []string
+		var failures int
+
+		BeforeEach(func() {
+			batches = nil
+			failures = 0
+			collector = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				if failures > 0 {
+					failures--
+					writer.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				body, _ := ioutil.ReadAll(request.Body)
+				batches = append(batches, string(body))
+			}))
+		})
+
+		AfterEach(func() {
+			collector.Close()
+		})
+
+		receivedBatches := func() []string {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return append([]string(nil), batches...)
+		}
+
+		It("should send full batches as JSON arrays", func() {
+			// given
+			sink, err := server.openSink(collector.URL+"#batchSize=2&flushInterval=1h", core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			defer sink.Close()
+
+			// when
+			Expect(sink.Send(`{"status":"200"}` + "\n")).To(Succeed())
+			Expect(sink.Send("GET /api 200\n")).To(Succeed())
+
+			// then
+			Eventually(receivedBatches, "5s").Should(HaveLen(1))
+			Expect(receivedBatches()[0]).To(MatchJSON(`[{"status":"200"},"GET /api 200"]`))
+		})
+
+		It("should send incomplete batches after flush interval", func() {
+			// given
+			sink, err := server.openSink(collector.URL+"#batchSize=100&flushInterval=10ms", core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			defer sink.Close()
+
+			// when
+			Expect(sink.Send("GET /api 200\n")).To(Succeed())
+
+			// then
+			Eventually(receivedBatches, "5s").Should(HaveLen(1))
+			Expect(receivedBatches()[0]).To(MatchJSON(`["GET /api 200"]`))
+		})
+
+		It("should send remaining entries on close", func() {
+			// given
+			sink, err := server.openSink(collector.URL+"#flushInterval=1h", core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Send("GET /api 200\n")).To(Succeed())
+
+			// when
+			Expect(sink.Close()).To(Succeed())
+
+			// then
+			Expect(receivedBatches()).To(HaveLen(1))
+			Expect(receivedBatches()[0]).To(MatchJSON(`["GET /api 200"]`))
+		})
+
+		It("should retry failed batches", func() {
+			// setup
+			backoff := httpRetryBackoff
+			httpRetryBackoff = time.Millisecond
+			defer func() {
+				httpRetryBackoff = backoff
+			}()
+
+			// given
+			failures = 2
+			sink, err := server.openSink(collector.URL+"#batchSize=1&maxRetries=2", core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			defer sink.Close()
+
+			// when
+			Expect(sink.Send("GET /api 200\n")).To(Succeed())
+
+			// then
+			Eventually(receivedBatches, "5s").Should(HaveLen(1))
+			Expect(receivedBatches()[0]).To(MatchJSON(`["GET /api 200"]`))
+		})
+	})
+
+	Describe("file", func() {
+
+		var dir string
+		var current time.Time
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "access-logs")
+			Expect(err).ToNot(HaveOccurred())
+			current, err = time.Parse(time.RFC3339, "2020-01-02T03:04:05Z")
+			Expect(err).ToNot(HaveOccurred())
+			now = func() time.Time {
+				return current
+			}
+		})
+
+		AfterEach(func() {
+			now = time.Now
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		readFile := func(name string) string {
+			data, err := ioutil.ReadFile(filepath.Join(dir, name))
+			Expect(err).ToNot(HaveOccurred())
+			return string(data)
+		}
+
+		It("should rotate a file once it exceeds max size", func() {
+			// given
+			sink, err := server.openSink("file://"+filepath.Join(dir, "access.log")+"?maxSize=10&maxBackups=1", core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			defer sink.Close()
+
+			// when
+			Expect(sink.Send("entry-1\n")).To(Succeed())
+			current = current.Add(time.Second)
+			Expect(sink.Send("entry-2\n")).To(Succeed())
+			current = current.Add(time.Second)
+			Expect(sink.Send("entry-3\n")).To(Succeed())
+
+			// then
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(HaveLen(2))
+			Expect(readFile("access.log")).To(Equal("entry-3\n"))
+			Expect(readFile("access.log.2020-01-02T03-04-07.000")).To(Equal("entry-2\n"))
+		})
+
+		It("should rotate a file once it exceeds max age", func() {
+			// given
+			sink, err := server.openSink("file://"+filepath.Join(dir, "access.log")+"?maxAge=1h", core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			defer sink.Close()
+
+			// when
+			Expect(sink.Send("entry-1\n")).To(Succeed())
+			current = current.Add(30 * time.Minute)
+			Expect(sink.Send("entry-2\n")).To(Succeed())
+			current = current.Add(30 * time.Minute)
+			Expect(sink.Send("entry-3\n")).To(Succeed())
+
+			// then
+			Expect(readFile("access.log")).To(Equal("entry-3\n"))
+			Expect(readFile("access.log.2020-01-02T04-04-05.000")).To(Equal("entry-1\nentry-2\n"))
+		})
+
+		It("should write to a file given by a relative path", func() {
+			// given
+			wd, err := os.Getwd()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.Chdir(dir)).To(Succeed())
+			defer func() {
+				Expect(os.Chdir(wd)).To(Succeed())
+			}()
+			// and an address of a file backend as it is encoded by Control Plane
+			address, _, err := accesslog.ParseLogName(accesslog.LogName(accesslog.FileAddress("logs/access.log", url.Values{"maxSize": []string{"1024"}}), "%RESPONSE_CODE%"))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			sink, err := server.openSink(address, core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Send("entry-1\n")).To(Succeed())
+			Expect(sink.Close()).To(Succeed())
+
+			// then
+			Expect(readFile(filepath.Join("logs", "access.log"))).To(Equal("entry-1\n"))
+		})
+
+		It("should share a file between streams", func() {
+			// given
+			address := "file://" + filepath.Join(dir, "access.log") + "?maxSize=1024"
+			sink1, err := server.openSink(address, core.Log)
+			Expect(err).ToNot(HaveOccurred())
+			sink2, err := server.openSink(address, core.Log)
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			Expect(sink1.Send("entry-1\n")).To(Succeed())
+			Expect(sink1.Close()).To(Succeed())
+			Expect(sink2.Send("entry-2\n")).To(Succeed())
+			Expect(sink2.Close()).To(Succeed())
+
+			// then
+			Expect(readFile("access.log")).To(Equal("entry-1\nentry-2\n"))
+			Expect(server.files.files).To(BeEmpty())
+		})
+	})
+})
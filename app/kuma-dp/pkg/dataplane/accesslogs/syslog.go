@@ -0,0 +1,67 @@
+package accesslogs
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// defaultSyslogFacility is `local0`.
+	defaultSyslogFacility = 16
+	// syslogSeverity is `informational`.
+	syslogSeverity = 6
+	syslogAppName  = "kuma-dp"
+)
+
+// syslogSink sends access log entries to a syslog server as RFC5424 messages.
+// Over TCP messages are framed with octet counting (RFC6587).
+type syslogSink struct {
+	conn     net.Conn
+	framed   bool
+	priority int
+	hostname string
+	procID   int
+}
+
+func newSyslogSink(protocol string, address string, facility int, log logr.Logger) (*syslogSink, error) {
+	conn, err := net.DialTimeout(protocol, address, defaultConnectTimeout)
+	if err != nil {
+		log.Error(err, "failed to connect to syslog logging backend", "protocol", protocol, "address", address)
+		return nil, err
+	}
+	log.Info("connected to syslog logging backend", "protocol", protocol, "address", address)
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{
+		conn:     conn,
+		framed:   protocol == "tcp",
+		priority: facility*8 + syslogSeverity,
+		hostname: hostname,
+		procID:   os.Getpid(),
+	}, nil
+}
+
+func (s *syslogSink) Send(entry string) error {
+	msg := s.message(entry)
+	if s.framed {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+	_, err := s.conn.Write([]byte(msg))
+	return err
+}
+
+// message renders `<PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG`.
+func (s *syslogSink) message(entry string) string {
+	timestamp := now().UTC().Format("2006-01-02T15:04:05.000000Z07:00")
+	return fmt.Sprintf("<%d>1 %s %s %s %d - - %s", s.priority, timestamp, s.hostname, syslogAppName, s.procID, strings.TrimRight(entry, "\n"))
+}
+
+func (s *syslogSink) Close() error {
+	return s.conn.Close()
+}
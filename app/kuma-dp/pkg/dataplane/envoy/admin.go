@@ -0,0 +1,115 @@
+package envoy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	cdsUpdateSuccessStat = "cluster_manager.cds.update_success"
+	ldsUpdateSuccessStat = "listener_manager.lds.update_success"
+)
+
+// AdminClient is a client of Envoy Admin API.
+type AdminClient struct {
+	address string
+	client  *http.Client
+}
+
+func NewAdminClient(port uint32) *AdminClient {
+	return &AdminClient{
+		address: fmt.Sprintf("http://127.0.0.1:%d", port),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// IsConfigAcked tells whether Envoy has applied both initial CDS and LDS responses.
+//
+// Envoy ACKs a response of xDS server only after it has been applied successfully,
+// so non-zero `update_success` counters imply that the initial configuration has been ACKed.
+func (c *AdminClient) IsConfigAcked() (bool, error) {
+	resp, err := c.client.Get(c.address + "/stats?filter=" + `^(cluster_manager\.cds|listener_manager\.lds)\.update_success$`)
+	if err != nil {
+		return false, errors.Wrap(err, "request to Envoy Admin API failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	stats, err := parseStats(resp.Body)
+	if err != nil {
+		return false, errors.Wrap(err, "could not parse stats")
+	}
+	return stats[cdsUpdateSuccessStat] > 0 && stats[ldsUpdateSuccessStat] > 0, nil
+}
+
+// FailHealthchecks makes Envoy fail health checks of upstream Envoys,
+// so that they stop sending new requests to this one.
+func (c *AdminClient) FailHealthchecks() error {
+	return c.post("/healthcheck/fail")
+}
+
+// DrainListeners makes Envoy gracefully close listeners,
+// letting in-flight requests complete.
+func (c *AdminClient) DrainListeners() error {
+	return c.post("/drain_listeners?graceful")
+}
+
+// Get returns a response of a given read-only endpoint of Envoy Admin API, e.g. `/config_dump`.
+func (c *AdminClient) Get(path string) ([]byte, error) {
+	resp, err := c.client.Get(c.address + path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "request to Envoy Admin API %q failed", path)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the body of the response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("request to Envoy Admin API %q failed: unexpected status code: %d", path, resp.StatusCode)
+	}
+	return body, nil
+}
+
+func (c *AdminClient) post(path string) error {
+	resp, err := c.client.Post(c.address+path, "text/plain", nil)
+	if err != nil {
+		return errors.Wrapf(err, "request to Envoy Admin API %q failed", path)
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(ioutil.Discard, resp.Body); err != nil {
+		return errors.Wrap(err, "could not read the body of the response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("request to Envoy Admin API %q failed: unexpected status code: %d", path, resp.StatusCode)
+	}
+	return nil
+}
+
+// parseStats parses stats in the plain text format, i.e. `<name>: <value>` per line.
+// Histograms and other non-integer stats are ignored.
+func parseStats(r io.Reader) (map[string]uint64, error) {
+	stats := map[string]uint64{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+		stats[strings.TrimSpace(parts[0])] = value
+	}
+	return stats, scanner.Err()
+}
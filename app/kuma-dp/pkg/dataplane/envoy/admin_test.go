@@ -0,0 +1,120 @@
+package envoy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AdminClient", func() {
+
+	Describe("IsConfigAcked()", func() {
+
+		var stats string
+		var admin *httptest.Server
+		var client *AdminClient
+
+		BeforeEach(func() {
+			admin = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.URL.Path).To(Equal("/stats"))
+				Expect(req.URL.Query().Get("filter")).To(Equal(`^(cluster_manager\.cds|listener_manager\.lds)\.update_success$`))
+				_, err := w.Write([]byte(stats))
+				Expect(err).ToNot(HaveOccurred())
+			}))
+			adminURL, err := url.Parse(admin.URL)
+			Expect(err).ToNot(HaveOccurred())
+			port, err := strconv.ParseUint(adminURL.Port(), 10, 32)
+			Expect(err).ToNot(HaveOccurred())
+			client = NewAdminClient(uint32(port))
+		})
+		AfterEach(func() {
+			admin.Close()
+		})
+
+		type testCase struct {
+			stats    string
+			expected bool
+		}
+
+		DescribeTable("should tell whether both CDS and LDS responses have been ACKed",
+			func(given testCase) {
+				// setup
+				stats = given.stats
+
+				// when
+				acked, err := client.IsConfigAcked()
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acked).To(Equal(given.expected))
+			},
+			Entry("no updates yet", testCase{
+				stats: `cluster_manager.cds.update_success: 0
+listener_manager.lds.update_success: 0
+`,
+				expected: false,
+			}),
+			Entry("only CDS response has been ACKed", testCase{
+				stats: `cluster_manager.cds.update_success: 1
+listener_manager.lds.update_success: 0
+`,
+				expected: false,
+			}),
+			Entry("both CDS and LDS responses have been ACKed", testCase{
+				stats: `cluster_manager.cds.update_success: 2
+listener_manager.lds.update_success: 1
+`,
+				expected: true,
+			}),
+		)
+	})
+
+	Describe("Get()", func() {
+
+		var admin *httptest.Server
+		var client *AdminClient
+
+		BeforeEach(func() {
+			admin = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				if req.URL.Path != "/config_dump" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, err := w.Write([]byte(`{"configs":[]}`))
+				Expect(err).ToNot(HaveOccurred())
+			}))
+			adminURL, err := url.Parse(admin.URL)
+			Expect(err).ToNot(HaveOccurred())
+			port, err := strconv.ParseUint(adminURL.Port(), 10, 32)
+			Expect(err).ToNot(HaveOccurred())
+			client = NewAdminClient(uint32(port))
+		})
+		AfterEach(func() {
+			admin.Close()
+		})
+
+		It("should return a response of Envoy Admin API", func() {
+			// when
+			body, err := client.Get("/config_dump")
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"configs":[]}`))
+		})
+
+		It("should return an error on unexpected status code", func() {
+			// when
+			_, err := client.Get("/unknown")
+
+			// then
+			Expect(err).To(MatchError(`request to Envoy Admin API "/unknown" failed: unexpected status code: 404`))
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package envoy
+
+import (
+	"time"
+)
+
+// backoff computes exponentially growing intervals between restarts of Envoy.
+type backoff struct {
+	initial time.Duration
+	max     time.Duration
+	next    time.Duration
+}
+
+func newBackoff(initial, max time.Duration) *backoff {
+	return &backoff{
+		initial: initial,
+		max:     max,
+		next:    initial,
+	}
+}
+
+// Next returns an interval to wait before the next restart.
+func (b *backoff) Next() time.Duration {
+	current := b.next
+	b.next *= 2
+	if b.next > b.max {
+		b.next = b.max
+	}
+	return current
+}
+
+// Reset makes the next interval equal to the initial one.
+func (b *backoff) Reset() {
+	b.next = b.initial
+}
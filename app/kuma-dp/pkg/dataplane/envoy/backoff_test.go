@@ -0,0 +1,36 @@
+package envoy
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backoff", func() {
+
+	It("should double the interval up to the maximum", func() {
+		// given
+		b := newBackoff(1*time.Second, 5*time.Second)
+
+		// expect
+		Expect(b.Next()).To(Equal(1 * time.Second))
+		Expect(b.Next()).To(Equal(2 * time.Second))
+		Expect(b.Next()).To(Equal(4 * time.Second))
+		Expect(b.Next()).To(Equal(5 * time.Second))
+		Expect(b.Next()).To(Equal(5 * time.Second))
+	})
+
+	It("should start over from the initial interval after reset", func() {
+		// given
+		b := newBackoff(1*time.Second, 5*time.Second)
+		b.Next()
+		b.Next()
+
+		// when
+		b.Reset()
+
+		// then
+		Expect(b.Next()).To(Equal(1 * time.Second))
+	})
+})
@@ -3,6 +3,7 @@ package envoy
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"os"
 	"os/exec"
@@ -18,6 +19,7 @@ import (
 
 	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
 	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
 )
 
 var (
@@ -29,7 +31,8 @@ var (
 	newConfigFile = GenerateBootstrapFile
 )
 
-type BootstrapConfigFactoryFunc func(url string, cfg kuma_dp.Config) (proto.Message, error)
+// BootstrapConfigFactoryFunc generates bootstrap config for an Envoy process with a given status.
+type BootstrapConfigFactoryFunc func(url string, cfg kuma_dp.Config, status types.EnvoyProcessStatus) (proto.Message, error)
 
 type Opts struct {
 	Catalog   catalog.Catalog
@@ -90,60 +93,248 @@ func lookupEnvoyPath(configuredPath string) (string, error) {
 	return path, nil
 }
 
+// Run starts Envoy and supervises it until stop is closed.
+//
+// If Envoy crashes, it gets restarted with exponential backoff.
+// If bootstrap config changes, Envoy gets hot restarted with the new config
+// without dropping connections.
 func (e *Envoy) Run(stop <-chan struct{}) error {
-	bootstrapConfig, err := e.opts.Generator(e.opts.Catalog.Apis.Bootstrap.Url, e.opts.Config)
+	binaryPath, err := lookupEnvoyPath(e.opts.Config.DataplaneRuntime.BinaryPath)
 	if err != nil {
-		return errors.Wrapf(err, "failed to generate Envoy bootstrap config")
+		return err
 	}
-	configFile, err := newConfigFile(e.opts.Config.DataplaneRuntime, bootstrapConfig)
+
+	status := types.EnvoyProcessStatus{}
+	bootstrapConfig, err := e.generateBootstrap(status)
+	if err != nil {
+		return err
+	}
+	current, err := e.start(binaryPath, bootstrapConfig, status.RestartEpoch)
 	if err != nil {
 		return err
 	}
+	// processes that are being replaced by the current one as part of hot restart
+	var parents []*process
+	defer func() {
+		for _, p := range append(parents, current) {
+			p.cancel()
+		}
+	}()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	var refresh <-chan time.Time
+	if e.hotRestartEnabled() {
+		ticker := time.NewTicker(e.opts.Config.DataplaneRuntime.BootstrapRefreshInterval)
+		defer ticker.Stop()
+		refresh = ticker.C
+	}
+	backoff := newBackoff(e.opts.Config.DataplaneRuntime.RestartInitialBackoff, e.opts.Config.DataplaneRuntime.RestartMaxBackoff)
+
+	for {
+		select {
+		case <-stop:
+			e.drain(current.done)
+			return nil
+		case err := <-current.done:
+			if err == nil {
+				runLog.Info("Envoy terminated successfully")
+				return nil
+			}
+			runLog.Error(err, "Envoy terminated with an error")
+			// Envoy cannot be restarted while there are processes with the same base id
+			for _, p := range parents {
+				p.cancel()
+				<-p.done
+			}
+			parents = nil
+			if time.Since(current.startTime) >= e.opts.Config.DataplaneRuntime.RestartMaxBackoff {
+				// Envoy has been running long enough to consider the crash to be unrelated to the previous ones
+				backoff.Reset()
+			}
+			status.Crashes++
+			status.LastExitCode = exitCodeOf(err)
+			status.RestartEpoch = 0
+			current, bootstrapConfig = e.restartAfterCrash(stop, binaryPath, status, backoff)
+			if current == nil {
+				return nil
+			}
+		case <-refresh:
+			changed, err := e.bootstrapChanged(bootstrapConfig, status)
+			if err != nil {
+				runLog.Error(err, "failed to refresh Envoy bootstrap config")
+				continue
+			}
+			if !changed {
+				continue
+			}
+			next := status
+			next.RestartEpoch++
+			nextConfig, err := e.generateBootstrap(next)
+			if err != nil {
+				runLog.Error(err, "failed to refresh Envoy bootstrap config")
+				continue
+			}
+			runLog.Info("Envoy bootstrap config has changed, hot restarting Envoy", "restartEpoch", next.RestartEpoch)
+			child, err := e.start(binaryPath, nextConfig, next.RestartEpoch)
+			if err != nil {
+				runLog.Error(err, "failed to hot restart Envoy")
+				continue
+			}
+			// the previous process shuts itself down once the new one has taken over its listeners
+			parents = append(parents, current)
+			current, bootstrapConfig, status = child, nextConfig, next
+		}
+	}
+}
 
-	binaryPathConfig := e.opts.Config.DataplaneRuntime.BinaryPath
-	resolvedPath, err := lookupEnvoyPath(binaryPathConfig)
+// restartAfterCrash waits according to the backoff and starts a new Envoy process.
+// It keeps retrying until either Envoy gets started or stop is closed, in which case it returns nil.
+func (e *Envoy) restartAfterCrash(stop <-chan struct{}, binaryPath string, status types.EnvoyProcessStatus, backoff *backoff) (*process, proto.Message) {
+	for {
+		delay := backoff.Next()
+		runLog.Info("restarting Envoy", "crashes", status.Crashes, "lastExitCode", status.LastExitCode, "backoff", delay)
+		select {
+		case <-stop:
+			return nil, nil
+		case <-time.After(delay):
+		}
+		bootstrapConfig, err := e.generateBootstrap(status)
+		if err != nil {
+			runLog.Error(err, "failed to restart Envoy")
+			continue
+		}
+		p, err := e.start(binaryPath, bootstrapConfig, status.RestartEpoch)
+		if err != nil {
+			runLog.Error(err, "failed to restart Envoy")
+			continue
+		}
+		return p, bootstrapConfig
+	}
+}
+
+func (e *Envoy) generateBootstrap(status types.EnvoyProcessStatus) (proto.Message, error) {
+	bootstrapConfig, err := e.opts.Generator(e.opts.Catalog.Apis.Bootstrap.Url, e.opts.Config, status)
 	if err != nil {
-		return err
+		return nil, errors.Wrapf(err, "failed to generate Envoy bootstrap config")
+	}
+	return bootstrapConfig, nil
+}
+
+// bootstrapChanged tells whether Control Plane would generate a different bootstrap config for the current Envoy process.
+func (e *Envoy) bootstrapChanged(current proto.Message, status types.EnvoyProcessStatus) (bool, error) {
+	latest, err := e.generateBootstrap(status)
+	if err != nil {
+		return false, err
+	}
+	return !proto.Equal(current, latest), nil
+}
+
+func (e *Envoy) hotRestartEnabled() bool {
+	return e.opts.Config.DataplaneRuntime.BootstrapRefreshInterval > 0
+}
+
+// process represents a single Envoy process.
+type process struct {
+	startTime time.Time
+	cancel    context.CancelFunc
+	// done receives the result of the process once it terminates
+	done chan error
+}
+
+func (e *Envoy) start(binaryPath string, bootstrapConfig proto.Message, restartEpoch uint32) (*process, error) {
+	configFile, err := newConfigFile(e.opts.Config.DataplaneRuntime, bootstrapConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	args := []string{
 		"-c", configFile,
 		"--drain-time-s",
 		fmt.Sprintf("%d", e.opts.Config.Dataplane.DrainTime/time.Second),
+	}
+	if e.hotRestartEnabled() {
+		args = append(args,
+			// every Envoy instance on the same Linux machine must have a unique `--base-id`,
+			// while all processes taking part in a hot restart must share it
+			"--base-id", fmt.Sprintf("%d", baseIdFor(e.opts.Config)),
+			"--restart-epoch", fmt.Sprintf("%d", restartEpoch),
+			// the parent process must not shut down before it has drained its listeners
+			"--parent-shutdown-time-s", fmt.Sprintf("%d", 2*e.opts.Config.Dataplane.DrainTime/time.Second),
+		)
+	} else {
 		// "hot restart" (enabled by default) requires each Envoy instance to have
 		// `--base-id <uint32_t>` argument.
 		// it is not possible to start multiple Envoy instances on the same Linux machine
 		// without `--base-id <uint32_t>` set.
-		// although we could come up with a solution how to generate `--base-id <uint32_t>`
-		// automatically, it is not strictly necessary since we're not using "hot restart"
-		// and we don't expect users to do "hot restart" manually.
+		// since bootstrap config is not going to be refreshed, "hot restart" is not needed,
 		// so, let's turn it off to simplify getting started experience.
-		"--disable-hot-restart",
+		args = append(args, "--disable-hot-restart")
 	}
-	command := exec.CommandContext(ctx, resolvedPath, args...)
+	if e.opts.Config.DataplaneRuntime.EnvoyLogLevel != "" {
+		args = append(args, "--log-level", e.opts.Config.DataplaneRuntime.EnvoyLogLevel)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	command := exec.CommandContext(ctx, binaryPath, args...)
 	command.Stdout = e.opts.Stdout
 	command.Stderr = e.opts.Stderr
 	if err := command.Start(); err != nil {
-		return err
+		cancel()
+		return nil, err
+	}
+	p := &process{
+		startTime: time.Now(),
+		cancel:    cancel,
+		done:      make(chan error, 1),
 	}
-	done := make(chan error, 1)
 	go func() {
-		done <- command.Wait()
+		p.done <- command.Wait()
 	}()
+	return p, nil
+}
 
+// exitCodeOf returns an exit code of a terminated Envoy process.
+// -1 indicates that Envoy was terminated by a signal.
+func exitCodeOf(err error) int32 {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return int32(exitErr.ExitCode())
+	}
+	return -1
+}
+
+// drain makes Envoy fail health checks and gracefully close listeners,
+// and then waits for the drain time to let in-flight requests complete.
+func (e *Envoy) drain(done <-chan error) {
+	if e.opts.Config.Dataplane.AdminPort.Empty() {
+		runLog.Info("Envoy Admin API is not exposed, terminating Envoy without draining")
+		return
+	}
+	admin := NewAdminClient(e.opts.Config.Dataplane.AdminPort.Lowest())
+	if err := admin.FailHealthchecks(); err != nil {
+		runLog.Error(err, "unable to start draining, terminating Envoy right away")
+		return
+	}
+	if err := admin.DrainListeners(); err != nil {
+		// older versions of Envoy don't support draining listeners on demand,
+		// yet failed health checks still make other Envoys stop sending requests
+		runLog.Error(err, "unable to drain listeners")
+	}
+	drainTime := e.opts.Config.Dataplane.DrainTime
+	runLog.Info("draining Envoy", "drainTime", drainTime)
 	select {
-	case <-stop:
-		cancel()
-		return nil
-	case err := <-done:
-		if err != nil {
-			runLog.Error(err, "Envoy terminated with an error")
-		} else {
-			runLog.Info("Envoy terminated successfully")
-		}
-		return err
+	case <-time.After(drainTime):
+	case <-done:
+		runLog.Info("Envoy terminated while draining")
+	}
+}
+
+// baseIdFor returns `--base-id` of Envoy.
+// Unless given explicitly, it is derived from mesh and name of the Dataplane,
+// which are unique per dataplane and don't change between hot restarts.
+func baseIdFor(cfg kuma_dp.Config) uint32 {
+	if cfg.DataplaneRuntime.EnvoyBaseId != 0 {
+		return cfg.DataplaneRuntime.EnvoyBaseId
 	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(cfg.Dataplane.Mesh + "." + cfg.Dataplane.Name))
+	return hash.Sum32()
 }
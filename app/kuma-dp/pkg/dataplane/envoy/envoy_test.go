@@ -3,13 +3,18 @@
 package envoy
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -21,6 +26,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
+	config_types "github.com/Kong/kuma/pkg/config/types"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
 )
 
 var _ = Describe("Envoy", func() {
@@ -72,7 +79,7 @@ var _ = Describe("Envoy", func() {
 					ConfigDir:  configDir,
 				},
 			}
-			sampleConfig := func(string, kuma_dp.Config) (proto.Message, error) {
+			sampleConfig := func(string, kuma_dp.Config, types.EnvoyProcessStatus) (proto.Message, error) {
 				return &envoy_bootstrap.Bootstrap{
 					Node: &envoy_core.Node{
 						Id: "example",
@@ -135,15 +142,77 @@ var _ = Describe("Envoy", func() {
 			close(done)
 		}, 10)
 
-		It("should return an error if Envoy crashes", func(done Done) {
+		It("should pass Envoy log level if configured", func(done Done) {
 			// given
 			cfg := kuma_dp.Config{
+				Dataplane: kuma_dp.Dataplane{
+					DrainTime: 15 * time.Second,
+				},
 				DataplaneRuntime: kuma_dp.DataplaneRuntime{
-					BinaryPath: filepath.Join("testdata", "envoy-mock.exit-1.sh"),
+					BinaryPath:    filepath.Join("testdata", "envoy-mock.exit-0.sh"),
+					ConfigDir:     configDir,
+					EnvoyLogLevel: "debug",
+				},
+			}
+			sampleConfig := func(string, kuma_dp.Config, types.EnvoyProcessStatus) (proto.Message, error) {
+				return &envoy_bootstrap.Bootstrap{}, nil
+			}
+			expectedConfigFile := filepath.Join(configDir, "bootstrap.yaml")
+
+			By("starting a mock dataplane")
+			// when
+			dataplane := New(Opts{
+				Config:    cfg,
+				Generator: sampleConfig,
+				Stdout:    outWriter,
+				Stderr:    errWriter,
+			})
+			// and
+			err := dataplane.Run(stopCh)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			By("verifying the output of mock dataplane")
+			// when
+			err = outWriter.Close()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// when
+			var buf bytes.Buffer
+			_, err = buf.ReadFrom(outReader)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(strings.TrimSpace(buf.String())).To(Equal(fmt.Sprintf("-c %s --drain-time-s 15 --disable-hot-restart --log-level debug", expectedConfigFile)))
+
+			// complete
+			close(done)
+		}, 10)
+
+		It("should drain Envoy before terminating it", func(done Done) {
+			// setup
+			var requests []string
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+			}))
+			defer admin.Close()
+			adminURL, err := url.Parse(admin.URL)
+			Expect(err).ToNot(HaveOccurred())
+			adminPort, err := strconv.ParseUint(adminURL.Port(), 10, 32)
+			Expect(err).ToNot(HaveOccurred())
+
+			// given
+			cfg := kuma_dp.Config{
+				Dataplane: kuma_dp.Dataplane{
+					AdminPort: config_types.MustExactPort(uint32(adminPort)),
+					DrainTime: 100 * time.Millisecond,
+				},
+				DataplaneRuntime: kuma_dp.DataplaneRuntime{
+					BinaryPath: filepath.Join("testdata", "envoy-mock.sleep.sh"),
 					ConfigDir:  configDir,
 				},
 			}
-			sampleConfig := func(string, kuma_dp.Config) (proto.Message, error) {
+			sampleConfig := func(string, kuma_dp.Config, types.EnvoyProcessStatus) (proto.Message, error) {
 				return &envoy_bootstrap.Bootstrap{}, nil
 			}
 
@@ -160,19 +229,193 @@ var _ = Describe("Envoy", func() {
 				errCh <- dataplane.Run(stopCh)
 			}()
 
-			By("waiting for mock dataplane to complete")
+			By("signalling the dataplane to stop")
+			// when
+			start := time.Now()
+			close(stopCh)
+			// then
+			Expect(<-errCh).ToNot(HaveOccurred())
+			// and
+			Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+			// and
+			Expect(requests).To(Equal([]string{
+				"POST /healthcheck/fail",
+				"POST /drain_listeners",
+			}))
+
+			// complete
+			close(done)
+		}, 10)
+
+		It("should restart Envoy with backoff if it crashes", func(done Done) {
+			// given
+			cfg := kuma_dp.Config{
+				DataplaneRuntime: kuma_dp.DataplaneRuntime{
+					BinaryPath:            filepath.Join("testdata", "envoy-mock.exit-1.sh"),
+					ConfigDir:             configDir,
+					RestartInitialBackoff: 10 * time.Millisecond,
+					RestartMaxBackoff:     40 * time.Millisecond,
+				},
+			}
+			statuses := make(chan types.EnvoyProcessStatus, 10)
+			sampleConfig := func(_ string, _ kuma_dp.Config, status types.EnvoyProcessStatus) (proto.Message, error) {
+				select {
+				case statuses <- status:
+				default:
+				}
+				return &envoy_bootstrap.Bootstrap{}, nil
+			}
+
+			By("starting a mock dataplane")
+			// when
+			dataplane := New(Opts{
+				Config:    cfg,
+				Generator: sampleConfig,
+				Stdout:    &bytes.Buffer{},
+				Stderr:    &bytes.Buffer{},
+			})
+			// and
+			go func() {
+				errCh <- dataplane.Run(stopCh)
+			}()
+
+			By("waiting for mock dataplane to crash and get restarted")
+			// then
+			Expect(<-statuses).To(Equal(types.EnvoyProcessStatus{}))
+			Expect(<-statuses).To(Equal(types.EnvoyProcessStatus{Crashes: 1, LastExitCode: 1}))
+			Expect(<-statuses).To(Equal(types.EnvoyProcessStatus{Crashes: 2, LastExitCode: 1}))
+
+			By("signalling the dataplane to stop")
 			// when
-			err := <-errCh
+			close(stopCh)
 			// then
-			Expect(err).To(BeAssignableToTypeOf(&exec.ExitError{}))
+			Expect(<-errCh).ToNot(HaveOccurred())
+
+			// complete
+			close(done)
+		}, 10)
+
+		It("should hot restart Envoy when bootstrap config changes", func(done Done) {
+			// given
+			cfg := kuma_dp.Config{
+				Dataplane: kuma_dp.Dataplane{
+					DrainTime: 15 * time.Second,
+				},
+				DataplaneRuntime: kuma_dp.DataplaneRuntime{
+					BinaryPath:               filepath.Join("testdata", "envoy-mock.hot-restart.sh"),
+					ConfigDir:                configDir,
+					BootstrapRefreshInterval: 10 * time.Millisecond,
+					EnvoyBaseId:              123,
+				},
+			}
+			var mu sync.Mutex
+			version := "v1"
+			var lastStatus types.EnvoyProcessStatus
+			sampleConfig := func(_ string, _ kuma_dp.Config, status types.EnvoyProcessStatus) (proto.Message, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				lastStatus = status
+				return &envoy_bootstrap.Bootstrap{
+					Node: &envoy_core.Node{
+						Id: version,
+					},
+				}, nil
+			}
+			expectedConfigFile := filepath.Join(configDir, "bootstrap.yaml")
 
+			By("starting a mock dataplane")
 			// when
-			exitError := err.(*exec.ExitError)
+			dataplane := New(Opts{
+				Config:    cfg,
+				Generator: sampleConfig,
+				Stdout:    outWriter,
+				Stderr:    errWriter,
+			})
+			// and
+			go func() {
+				errCh <- dataplane.Run(stopCh)
+			}()
+
+			By("verifying the arguments of the initial Envoy process")
+			// when
+			output := bufio.NewReader(outReader)
+			line, err := output.ReadString('\n')
 			// then
-			Expect(exitError.ProcessState.ExitCode()).To(Equal(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.TrimSpace(line)).To(Equal(fmt.Sprintf("-c %s --drain-time-s 15 --base-id 123 --restart-epoch 0 --parent-shutdown-time-s 30", expectedConfigFile)))
+
+			By("changing bootstrap config")
+			// when
+			mu.Lock()
+			version = "v2"
+			mu.Unlock()
+
+			By("verifying the arguments of the new Envoy process")
+			// when
+			line, err = output.ReadString('\n')
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.TrimSpace(line)).To(Equal(fmt.Sprintf("-c %s --drain-time-s 15 --base-id 123 --restart-epoch 1 --parent-shutdown-time-s 30", expectedConfigFile)))
+			// and
+			mu.Lock()
+			Expect(lastStatus).To(Equal(types.EnvoyProcessStatus{RestartEpoch: 1}))
+			mu.Unlock()
+
+			By("verifying the contents Envoy config file")
+			// when
+			actual, err := ioutil.ReadFile(expectedConfigFile)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(actual).To(MatchYAML(`
+            node:
+              id: v2
+`))
+
+			By("signalling the dataplane to stop")
+			// when
+			close(stopCh)
+			// then
+			Expect(<-errCh).ToNot(HaveOccurred())
 
 			// complete
 			close(done)
 		}, 10)
 	})
+
+	Describe("baseIdFor(..)", func() {
+
+		It("should use base ID given explicitly", func() {
+			// given
+			cfg := kuma_dp.Config{
+				Dataplane: kuma_dp.Dataplane{
+					Mesh: "default",
+					Name: "backend-01",
+				},
+				DataplaneRuntime: kuma_dp.DataplaneRuntime{
+					EnvoyBaseId: 17,
+				},
+			}
+
+			// expect
+			Expect(baseIdFor(cfg)).To(Equal(uint32(17)))
+		})
+
+		It("should derive base ID from mesh and name of the Dataplane", func() {
+			// given
+			dataplane := func(mesh, name string) kuma_dp.Config {
+				return kuma_dp.Config{
+					Dataplane: kuma_dp.Dataplane{
+						Mesh: mesh,
+						Name: name,
+					},
+				}
+			}
+
+			// expect
+			Expect(baseIdFor(dataplane("default", "backend-01"))).To(Equal(baseIdFor(dataplane("default", "backend-01"))))
+			Expect(baseIdFor(dataplane("default", "backend-01"))).ToNot(Equal(baseIdFor(dataplane("default", "backend-02"))))
+			Expect(baseIdFor(dataplane("default", "backend-01"))).ToNot(Equal(baseIdFor(dataplane("demo", "backend-01"))))
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	net_url "net/url"
+	"strings"
 
 	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
 
@@ -25,7 +26,7 @@ func NewRemoteBootstrapGenerator(client *http.Client) BootstrapConfigFactoryFunc
 	return rb.Generate
 }
 
-func (b *remoteBootstrap) Generate(url string, cfg kuma_dp.Config) (proto.Message, error) {
+func (b *remoteBootstrap) Generate(url string, cfg kuma_dp.Config, status types.EnvoyProcessStatus) (proto.Message, error) {
 	bootstrapUrl, err := net_url.Parse(url)
 	if err != nil {
 		return nil, err
@@ -38,6 +39,13 @@ func (b *remoteBootstrap) Generate(url string, cfg kuma_dp.Config) (proto.Messag
 		// that is set in the control plane bootstrap params
 		AdminPort:          cfg.Dataplane.AdminPort.Lowest(),
 		DataplaneTokenPath: cfg.DataplaneRuntime.TokenPath,
+		EnvoyProcess:       &status,
+	}
+	if err := withDataplaneResource(&request, cfg.DataplaneRuntime); err != nil {
+		return nil, err
+	}
+	if err := withDataplaneToken(&request, cfg.DataplaneRuntime); err != nil {
+		return nil, err
 	}
 	jsonBytes, err := json.Marshal(request)
 	if err != nil {
@@ -52,6 +60,10 @@ func (b *remoteBootstrap) Generate(url string, cfg kuma_dp.Config) (proto.Messag
 		if resp.StatusCode == 404 {
 			return nil, errors.New("status: 404. Did you first apply a Dataplane resource?")
 		}
+		if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusForbidden {
+			reason, _ := ioutil.ReadAll(resp.Body)
+			return nil, errors.Errorf("status: %d. Could not register a Dataplane resource: %s", resp.StatusCode, reason)
+		}
 		return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
@@ -66,3 +78,33 @@ func (b *remoteBootstrap) Generate(url string, cfg kuma_dp.Config) (proto.Messag
 
 	return &bootstrap, nil
 }
+
+// withDataplaneResource includes a Dataplane resource into the request,
+// so that Control Plane could create or update it on behalf of the dataplane.
+// Dataplane token authorizes the registration.
+func withDataplaneResource(request *types.BootstrapRequest, runtime kuma_dp.DataplaneRuntime) error {
+	resource := runtime.Resource
+	if runtime.ResourcePath != "" {
+		content, err := ioutil.ReadFile(runtime.ResourcePath)
+		if err != nil {
+			return errors.Wrapf(err, "could not read Dataplane resource from a file %q", runtime.ResourcePath)
+		}
+		resource = string(content)
+	}
+	request.DataplaneResource = resource
+	return nil
+}
+
+// withDataplaneToken includes a dataplane token into the request.
+// Besides registration of a Dataplane, Control Plane uses it to let Envoy authenticate a stream of stats.
+func withDataplaneToken(request *types.BootstrapRequest, runtime kuma_dp.DataplaneRuntime) error {
+	if runtime.TokenPath == "" {
+		return nil
+	}
+	token, err := ioutil.ReadFile(runtime.TokenPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not read dataplane token from a file %q", runtime.TokenPath)
+	}
+	request.DataplaneToken = strings.TrimSpace(string(token))
+	return nil
+}
@@ -15,12 +15,14 @@ import (
 
 	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
 	config_types "github.com/Kong/kuma/pkg/config/types"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
 )
 
 var _ = Describe("Remote Bootstrap", func() {
 
 	type testCase struct {
 		config                   kuma_dp.Config
+		status                   types.EnvoyProcessStatus
 		expectedBootstrapRequest string
 	}
 
@@ -47,7 +49,7 @@ var _ = Describe("Remote Bootstrap", func() {
 		generator := NewRemoteBootstrapGenerator(http.DefaultClient)
 
 		// when
-		config, err := generator(fmt.Sprintf("http://localhost:%d", port), given.config)
+		config, err := generator(fmt.Sprintf("http://localhost:%d", port), given.config, given.status)
 
 		// then
 		Expect(err).ToNot(HaveOccurred())
@@ -59,7 +61,7 @@ var _ = Describe("Remote Bootstrap", func() {
 				cfg.Dataplane.Mesh = "demo"
 				cfg.Dataplane.Name = "sample"
 				cfg.Dataplane.AdminPort = config_types.MustExactPort(4321) // exact port
-				cfg.DataplaneRuntime.TokenPath = "/tmp/token"
+				cfg.DataplaneRuntime.TokenPath = filepath.Join("testdata", "token")
 
 				return testCase{
 					config: cfg,
@@ -68,7 +70,9 @@ var _ = Describe("Remote Bootstrap", func() {
                       "mesh": "demo",
                       "name": "sample",
                       "adminPort": 4321,
-                      "dataplaneTokenPath": "/tmp/token"
+                      "dataplaneTokenPath": "testdata/token",
+                      "dataplaneToken": "sample-token",
+                      "envoyProcess": {}
                     }
 `,
 				}
@@ -80,7 +84,7 @@ var _ = Describe("Remote Bootstrap", func() {
 				cfg.Dataplane.Mesh = "demo"
 				cfg.Dataplane.Name = "sample"
 				cfg.Dataplane.AdminPort = config_types.MustPortRange(4321, 8765) // port range
-				cfg.DataplaneRuntime.TokenPath = "/tmp/token"
+				cfg.DataplaneRuntime.TokenPath = filepath.Join("testdata", "token")
 
 				return testCase{
 					config: cfg,
@@ -89,7 +93,9 @@ var _ = Describe("Remote Bootstrap", func() {
                       "mesh": "demo",
                       "name": "sample",
                       "adminPort": 4321,
-                      "dataplaneTokenPath": "/tmp/token"
+                      "dataplaneTokenPath": "testdata/token",
+                      "dataplaneToken": "sample-token",
+                      "envoyProcess": {}
                     }
 `,
 				}
@@ -100,7 +106,7 @@ var _ = Describe("Remote Bootstrap", func() {
 				cfg.Dataplane.Mesh = "demo"
 				cfg.Dataplane.Name = "sample"
 				cfg.Dataplane.AdminPort = config_types.PortRange{} // empty port range
-				cfg.DataplaneRuntime.TokenPath = "/tmp/token"
+				cfg.DataplaneRuntime.TokenPath = filepath.Join("testdata", "token")
 
 				return testCase{
 					config: cfg,
@@ -108,7 +114,90 @@ var _ = Describe("Remote Bootstrap", func() {
                     {
                       "mesh": "demo",
                       "name": "sample",
-                      "dataplaneTokenPath": "/tmp/token"
+                      "dataplaneTokenPath": "testdata/token",
+                      "dataplaneToken": "sample-token",
+                      "envoyProcess": {}
+                    }
+`,
+				}
+			}()),
+		Entry("should include Dataplane resource and dataplane token",
+			func() testCase {
+				cfg := kuma_dp.DefaultConfig()
+				cfg.Dataplane.Mesh = "demo"
+				cfg.Dataplane.Name = "sample"
+				cfg.Dataplane.AdminPort = config_types.PortRange{} // empty port range
+				cfg.DataplaneRuntime.TokenPath = filepath.Join("testdata", "token")
+				cfg.DataplaneRuntime.ResourcePath = filepath.Join("testdata", "dataplane.yaml")
+
+				return testCase{
+					config: cfg,
+					expectedBootstrapRequest: `
+                    {
+                      "mesh": "demo",
+                      "name": "sample",
+                      "dataplaneTokenPath": "testdata/token",
+                      "dataplaneToken": "sample-token",
+                      "dataplaneResource": "type: Dataplane\nmesh: demo\nname: sample\nnetworking:\n  inbound:\n  - interface: 192.168.0.1:80:8080\n    tags:\n      service: backend\n",
+                      "envoyProcess": {}
+                    }
+`,
+				}
+			}()),
+		Entry("should include rendered Dataplane resource",
+			func() testCase {
+				cfg := kuma_dp.DefaultConfig()
+				cfg.Dataplane.Mesh = "demo"
+				cfg.Dataplane.Name = "sample"
+				cfg.Dataplane.AdminPort = config_types.PortRange{} // empty port range
+				cfg.DataplaneRuntime.TokenPath = filepath.Join("testdata", "token")
+				cfg.DataplaneRuntime.Resource = `type: Dataplane
+mesh: demo
+name: sample
+networking:
+  inbound:
+  - interface: 10.0.0.1:80:8080
+    tags:
+      service: backend
+`
+
+				return testCase{
+					config: cfg,
+					expectedBootstrapRequest: `
+                    {
+                      "mesh": "demo",
+                      "name": "sample",
+                      "dataplaneTokenPath": "testdata/token",
+                      "dataplaneToken": "sample-token",
+                      "dataplaneResource": "type: Dataplane\nmesh: demo\nname: sample\nnetworking:\n  inbound:\n  - interface: 10.0.0.1:80:8080\n    tags:\n      service: backend\n",
+                      "envoyProcess": {}
+                    }
+`,
+				}
+			}()),
+		Entry("should include status of Envoy process",
+			func() testCase {
+				cfg := kuma_dp.DefaultConfig()
+				cfg.Dataplane.Mesh = "demo"
+				cfg.Dataplane.Name = "sample"
+				cfg.Dataplane.AdminPort = config_types.PortRange{} // empty port range
+
+				return testCase{
+					config: cfg,
+					status: types.EnvoyProcessStatus{
+						Crashes:      2,
+						LastExitCode: 1,
+						RestartEpoch: 1,
+					},
+					expectedBootstrapRequest: `
+                    {
+                      "mesh": "demo",
+                      "name": "sample",
+                      "envoyProcess": {
+                        "crashes": 2,
+                        "lastExitCode": 1,
+                        "restartEpoch": 1
+                      }
                     }
 `,
 				}
@@ -0,0 +1,7 @@
+#!/bin/sh
+
+# print arguments to verify in the test
+echo $@
+
+# simulate a long-running Envoy process
+exec sleep 86400
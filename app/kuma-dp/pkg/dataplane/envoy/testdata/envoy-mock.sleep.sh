@@ -0,0 +1,4 @@
+#!/bin/sh
+
+# simulate a long-running Envoy process
+sleep 86400
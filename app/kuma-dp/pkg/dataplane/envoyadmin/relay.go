@@ -0,0 +1,147 @@
+package envoyadmin
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	core_admin "github.com/Kong/kuma/pkg/core/admin"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+var logger = core.Log.WithName("envoy-admin-relay")
+
+const defaultReconnectInterval = 5 * time.Second
+
+// AdminApi gives access to Envoy Admin API.
+type AdminApi interface {
+	Get(path string) ([]byte, error)
+}
+
+// Relay keeps a reverse channel to Control Plane open and relays requests
+// of Control Plane to Envoy Admin API, so that Control Plane could inspect Envoy
+// without direct access to it.
+type Relay struct {
+	serverURL         *url.URL
+	proxyId           string
+	tokenPath         string
+	admin             AdminApi
+	reconnectInterval time.Duration
+}
+
+// NewRelay creates a relay of a given Dataplane. A reverse channel is authenticated by a dataplane token
+// read from a given path, which is read again every time a channel is reopened to pick up a rotated token.
+func NewRelay(serverURL string, mesh string, name string, tokenPath string, admin AdminApi) (*Relay, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the server URL")
+	}
+	if u.Scheme != "grpc" && u.Scheme != "grpcs" {
+		return nil, errors.Errorf("unsupported scheme %q. Use one of %s", u.Scheme, []string{"grpc", "grpcs"})
+	}
+	proxyId, err := core_xds.BuildProxyId(mesh, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Relay{
+		serverURL:         u,
+		proxyId:           proxyId.String(),
+		tokenPath:         tokenPath,
+		admin:             admin,
+		reconnectInterval: defaultReconnectInterval,
+	}, nil
+}
+
+// Start relays requests until it's asked to stop. A broken reverse channel is reopened after a while.
+func (r *Relay) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+	logger.Info("starting", "server", r.serverURL.String())
+	for {
+		if err := r.relay(ctx); err != nil && ctx.Err() == nil {
+			logger.Error(err, "reverse channel to Control Plane is broken, reopening", "interval", r.reconnectInterval)
+		}
+		select {
+		case <-ctx.Done():
+			logger.Info("stopped")
+			return nil
+		case <-time.After(r.reconnectInterval):
+		}
+	}
+}
+
+func (r *Relay) relay(ctx context.Context) error {
+	var dialOpts []grpc.DialOption
+	if r.serverURL.Scheme == "grpcs" {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			// certificate of the server is not verified, same as by `kuma-dp` bootstrap client,
+			// so a dataplane token should only be sent over a trusted network
+			InsecureSkipVerify: true,
+		})))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	if r.tokenPath != "" {
+		token, err := ioutil.ReadFile(r.tokenPath)
+		if err != nil {
+			return errors.Wrapf(err, "could not read dataplane token from a file %q", r.tokenPath)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", strings.TrimSpace(string(token)))
+	}
+	conn, err := grpc.DialContext(ctx, r.serverURL.Host, dialOpts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := observability_proto.NewEnvoyAdminServiceClient(conn).StreamEnvoyAdminRequests(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&observability_proto.EnvoyAdminResponse{
+		Node: &envoy_core.Node{Id: r.proxyId},
+	}); err != nil {
+		return err
+	}
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(r.handle(req)); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Relay) handle(req *observability_proto.EnvoyAdminRequest) *observability_proto.EnvoyAdminResponse {
+	resp := &observability_proto.EnvoyAdminResponse{
+		RequestId: req.GetRequestId(),
+	}
+	if !core_admin.IsAllowedPath(req.GetPath()) {
+		resp.Error = "querying endpoint " + req.GetPath() + " is not allowed"
+		return resp
+	}
+	body, err := r.admin.Get(req.GetPath())
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	resp.Body = body
+	return resp
+}
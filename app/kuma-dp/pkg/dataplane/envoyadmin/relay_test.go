@@ -0,0 +1,188 @@
+package envoyadmin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+)
+
+type fakeAdminApi map[string]string
+
+func (f fakeAdminApi) Get(path string) ([]byte, error) {
+	body, ok := f[path]
+	if !ok {
+		return nil, errors.Errorf("request to Envoy Admin API %q failed: unexpected status code: 404", path)
+	}
+	return []byte(body), nil
+}
+
+// fakeEnvoyAdminService sends requests to every Dataplane that opens a reverse channel and records responses.
+type fakeEnvoyAdminService struct {
+	requests       []*observability_proto.EnvoyAdminRequest
+	responses      chan *observability_proto.EnvoyAdminResponse
+	authorizations chan []string
+}
+
+func (s *fakeEnvoyAdminService) StreamEnvoyAdminRequests(stream observability_proto.EnvoyAdminService_StreamEnvoyAdminRequestsServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.authorizations <- md.Get("authorization")
+	for _, req := range s.requests {
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		s.responses <- resp
+	}
+}
+
+var _ = Describe("Relay", func() {
+
+	var service *fakeEnvoyAdminService
+	var server *grpc.Server
+	var address string
+	var stop chan struct{}
+	var done chan struct{}
+
+	start := func(relay *Relay) {
+		done = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			Expect(relay.Start(stop)).To(Succeed())
+		}()
+	}
+
+	BeforeEach(func() {
+		service = &fakeEnvoyAdminService{
+			requests: []*observability_proto.EnvoyAdminRequest{
+				{RequestId: "1", Path: "/config_dump"},
+				{RequestId: "2", Path: "/clusters"},
+				{RequestId: "3", Path: "/quitquitquit"},
+			},
+			responses:      make(chan *observability_proto.EnvoyAdminResponse, 10),
+			authorizations: make(chan []string, 10),
+		}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		address = lis.Addr().String()
+		server = grpc.NewServer()
+		observability_proto.RegisterEnvoyAdminServiceServer(server, service)
+		go func(server *grpc.Server) {
+			_ = server.Serve(lis)
+		}(server)
+		stop = make(chan struct{})
+		done = nil
+	})
+
+	AfterEach(func() {
+		close(stop)
+		if done != nil {
+			Eventually(done, "5s").Should(BeClosed())
+		}
+		server.Stop()
+	})
+
+	It("should relay requests of Control Plane to Envoy Admin API", func() {
+		// given
+		relay, err := NewRelay(fmt.Sprintf("grpc://%s", address), "default", "backend-01", "", fakeAdminApi{
+			"/config_dump": `{"configs":[]}`,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		start(relay)
+
+		// then kuma-dp introduces itself
+		var resp *observability_proto.EnvoyAdminResponse
+		Eventually(service.responses, "5s").Should(Receive(&resp))
+		Expect(resp.GetNode().GetId()).To(Equal("default.backend-01"))
+
+		// and answers requests
+		Eventually(service.responses).Should(Receive(&resp))
+		Expect(resp.RequestId).To(Equal("1"))
+		Expect(string(resp.Body)).To(Equal(`{"configs":[]}`))
+		Expect(resp.Error).To(BeEmpty())
+
+		Eventually(service.responses).Should(Receive(&resp))
+		Expect(resp.RequestId).To(Equal("2"))
+		Expect(resp.Error).To(Equal(`request to Envoy Admin API "/clusters" failed: unexpected status code: 404`))
+
+		Eventually(service.responses).Should(Receive(&resp))
+		Expect(resp.RequestId).To(Equal("3"))
+		Expect(resp.Error).To(Equal("querying endpoint /quitquitquit is not allowed"))
+	})
+
+	It("should reopen a broken reverse channel", func() {
+		// given
+		service.requests = nil
+		relay, err := NewRelay(fmt.Sprintf("grpc://%s", address), "default", "backend-01", "", fakeAdminApi{})
+		Expect(err).ToNot(HaveOccurred())
+		relay.reconnectInterval = 10 * time.Millisecond
+
+		// when
+		start(relay)
+
+		// then
+		Eventually(service.responses, "5s").Should(Receive())
+
+		// when the old server goes away
+		server.Stop()
+		lis, err := net.Listen("tcp", address)
+		Expect(err).ToNot(HaveOccurred())
+		server = grpc.NewServer()
+		observability_proto.RegisterEnvoyAdminServiceServer(server, service)
+		go func(server *grpc.Server) {
+			_ = server.Serve(lis)
+		}(server)
+
+		// then kuma-dp introduces itself again
+		var resp *observability_proto.EnvoyAdminResponse
+		Eventually(service.responses, "5s").Should(Receive(&resp))
+		Expect(resp.GetNode().GetId()).To(Equal("default.backend-01"))
+	})
+
+	It("should authenticate a reverse channel by a dataplane token", func() {
+		// setup
+		file, err := ioutil.TempFile("", "token")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(file.Name())
+		_, err = file.WriteString("sample-token\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		// given
+		service.requests = nil
+		relay, err := NewRelay(fmt.Sprintf("grpc://%s", address), "default", "backend-01", file.Name(), fakeAdminApi{})
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		start(relay)
+
+		// then
+		Eventually(service.authorizations, "5s").Should(Receive(Equal([]string{"sample-token"})))
+	})
+
+	It("should reject unsupported server URL", func() {
+		// when
+		_, err := NewRelay("http://localhost:5678", "default", "backend-01", "", fakeAdminApi{})
+
+		// then
+		Expect(err).To(MatchError(`unsupported scheme "http". Use one of [grpc grpcs]`))
+	})
+})
@@ -0,0 +1,113 @@
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var logger = core.Log.WithName("readiness-server")
+
+const (
+	// Path of the readiness endpoint.
+	Path = "/ready"
+
+	defaultProbeInterval = 1 * time.Second
+)
+
+const (
+	stateNotReady int32 = iota
+	stateReady
+	stateDraining
+)
+
+// Probe tells whether Envoy is ready to serve traffic.
+type Probe func() (bool, error)
+
+// Server exposes readiness of Envoy over HTTP.
+//
+// Once the probe succeeds, Envoy is considered ready until kuma-dp is asked to stop,
+// at which point Envoy is considered draining and never becomes ready again.
+type Server struct {
+	probe         Probe
+	probeInterval time.Duration
+	state         int32
+	server        *http.Server
+}
+
+func NewServer(probe Probe) *Server {
+	s := &Server{
+		probe:         probe,
+		probeInterval: defaultProbeInterval,
+	}
+	mux := http.NewServeMux()
+	mux.Handle(Path, s)
+	s.server = &http.Server{Handler: mux}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	switch atomic.LoadInt32(&s.state) {
+	case stateReady:
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("READY"))
+	case stateDraining:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("DRAINING"))
+	default:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("NOT READY"))
+	}
+}
+
+// Start serves the readiness endpoint on a given port of the loopback interface until the server is closed.
+func (s *Server) Start(port uint32, stop <-chan struct{}) error {
+	address := fmt.Sprintf("127.0.0.1:%d", port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	go s.watch(stop)
+	logger.Info("starting", "address", fmt.Sprintf("http://%s%s", address, Path))
+	if err := s.server.Serve(lis); err != nil && err != http.ErrServerClosed {
+		logger.Error(err, "terminated with an error")
+		return err
+	}
+	return nil
+}
+
+func (s *Server) watch(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			atomic.StoreInt32(&s.state, stateDraining)
+			logger.Info("Envoy is draining")
+			return
+		case <-ticker.C:
+			if atomic.LoadInt32(&s.state) != stateNotReady {
+				continue
+			}
+			ready, err := s.probe()
+			if err != nil {
+				logger.V(1).Info("unable to probe readiness of Envoy", "err", err)
+				continue
+			}
+			if ready && atomic.CompareAndSwapInt32(&s.state, stateNotReady, stateReady) {
+				logger.Info("Envoy is ready")
+			}
+		}
+	}
+}
+
+func (s *Server) Close() {
+	if err := s.server.Shutdown(context.Background()); err != nil {
+		logger.Error(err, "unable to shut down")
+	}
+}
@@ -0,0 +1,68 @@
+package readiness
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/test"
+)
+
+var _ = Describe("Server", func() {
+
+	var acked int32
+	var port uint32
+	var stopCh chan struct{}
+	var server *Server
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&acked, 0)
+		stopCh = make(chan struct{})
+
+		var err error
+		port, err = test.FindFreePort("")
+		Expect(err).ToNot(HaveOccurred())
+
+		server = NewServer(func() (bool, error) {
+			return atomic.LoadInt32(&acked) == 1, nil
+		})
+		server.probeInterval = 10 * time.Millisecond
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Start(port, stopCh)).To(Succeed())
+		}()
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	status := func() int {
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/ready", port))
+		if err != nil {
+			return 0
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	It("should report ready once Envoy has ACKed its initial configuration and not ready while draining", func() {
+		By("waiting for the server to start")
+		Eventually(status, "5s", "10ms").Should(Equal(http.StatusServiceUnavailable))
+
+		By("simulating ACK of the initial configuration")
+		// when
+		atomic.StoreInt32(&acked, 1)
+		// then
+		Eventually(status, "5s", "10ms").Should(Equal(http.StatusOK))
+
+		By("simulating a signal to stop")
+		// when
+		close(stopCh)
+		// then
+		Eventually(status, "5s", "10ms").Should(Equal(http.StatusServiceUnavailable))
+	})
+})
@@ -0,0 +1,139 @@
+package template
+
+import (
+	"bytes"
+	"os"
+	text_template "text/template"
+	"text/template/parse"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	util_net "github.com/Kong/kuma/pkg/util/net"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+const (
+	// AddressVar is a placeholder for an IP address of the machine,
+	// e.g. `{{ .address }}`. By default, it is auto-detected from network interfaces.
+	AddressVar = "address"
+	// HostnameVar is a placeholder for a hostname of the machine, e.g. `{{ .hostname }}`.
+	HostnameVar = "hostname"
+)
+
+var (
+	// overridable by unit tests
+	detectAddress = util_net.DetectAddress
+	hostname      = os.Hostname
+)
+
+// Render renders a Dataplane template, i.e. a Dataplane definition with placeholders like `{{ .address }}`,
+// and validates the resulting Dataplane.
+//
+// Values of `address` and `hostname` placeholders are detected automatically unless given explicitly.
+// Detection only happens for placeholders that are actually used by the template.
+func Render(template string, vars map[string]string) (string, error) {
+	tmpl, err := text_template.New("dataplane").Option("missingkey=error").Parse(template)
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse Dataplane template")
+	}
+	values, err := valuesFor(placeholdersOf(tmpl.Tree.Root), vars)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", errors.Wrap(err, "could not render Dataplane template")
+	}
+	if err := validate(buf.Bytes()); err != nil {
+		return "", errors.Wrap(err, "Dataplane rendered from the template is not valid")
+	}
+	return buf.String(), nil
+}
+
+func valuesFor(placeholders map[string]bool, vars map[string]string) (map[string]string, error) {
+	values := map[string]string{}
+	for name, value := range vars {
+		values[name] = value
+	}
+	if _, ok := values[AddressVar]; !ok && placeholders[AddressVar] {
+		address, err := detectAddress()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not detect a value of %q variable, set it explicitly", AddressVar)
+		}
+		values[AddressVar] = address
+	}
+	if _, ok := values[HostnameVar]; !ok && placeholders[HostnameVar] {
+		name, err := hostname()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not detect a value of %q variable, set it explicitly", HostnameVar)
+		}
+		values[HostnameVar] = name
+	}
+	return values, nil
+}
+
+// placeholdersOf returns names of top-level fields referenced by a template, e.g. `address` for `{{ .address }}`.
+func placeholdersOf(root parse.Node) map[string]bool {
+	placeholders := map[string]bool{}
+	var visit func(node parse.Node)
+	visit = func(node parse.Node) {
+		switch node := node.(type) {
+		case *parse.ListNode:
+			if node == nil {
+				return
+			}
+			for _, child := range node.Nodes {
+				visit(child)
+			}
+		case *parse.ActionNode:
+			visit(node.Pipe)
+		case *parse.IfNode:
+			visit(&node.BranchNode)
+		case *parse.RangeNode:
+			visit(&node.BranchNode)
+		case *parse.WithNode:
+			visit(&node.BranchNode)
+		case *parse.BranchNode:
+			visit(node.Pipe)
+			visit(node.List)
+			visit(node.ElseList)
+		case *parse.TemplateNode:
+			visit(node.Pipe)
+		case *parse.PipeNode:
+			if node == nil {
+				return
+			}
+			for _, cmd := range node.Cmds {
+				visit(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range node.Args {
+				visit(arg)
+			}
+		case *parse.ChainNode:
+			visit(node.Node)
+		case *parse.FieldNode:
+			placeholders[node.Ident[0]] = true
+		}
+	}
+	visit(root)
+	return placeholders
+}
+
+func validate(content []byte) error {
+	meta := rest.ResourceMeta{}
+	if err := yaml.Unmarshal(content, &meta); err != nil {
+		return err
+	}
+	if meta.Type != "" && meta.Type != string(mesh.DataplaneType) {
+		return errors.Errorf("type must be %q", mesh.DataplaneType)
+	}
+	dataplane := &mesh.DataplaneResource{}
+	if err := util_proto.FromYAML(content, &dataplane.Spec); err != nil {
+		return err
+	}
+	return dataplane.Validate()
+}
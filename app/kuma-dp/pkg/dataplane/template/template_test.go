@@ -0,0 +1,201 @@
+package template
+
+import (
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Render()", func() {
+
+	var backupDetectAddress func() (string, error)
+	var backupHostname func() (string, error)
+
+	BeforeEach(func() {
+		backupDetectAddress = detectAddress
+		backupHostname = hostname
+		detectAddress = func() (string, error) {
+			return "10.0.0.1", nil
+		}
+		hostname = func() (string, error) {
+			return "vm-1", nil
+		}
+	})
+	AfterEach(func() {
+		detectAddress = backupDetectAddress
+		hostname = backupHostname
+	})
+
+	template := `
+type: Dataplane
+mesh: default
+name: backend-{{ .hostname }}
+networking:
+  inbound:
+  - interface: {{ .address }}:10000:{{ .port }}
+    tags:
+      service: backend
+`
+
+	type testCase struct {
+		vars     map[string]string
+		expected string
+	}
+
+	DescribeTable("should render Dataplane template",
+		func(given testCase) {
+			// when
+			actual, err := Render(template, given.vars)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(MatchYAML(given.expected))
+		},
+		Entry("with auto-detected address and hostname", testCase{
+			vars: map[string]string{
+				"port": "8080",
+			},
+			expected: `
+type: Dataplane
+mesh: default
+name: backend-vm-1
+networking:
+  inbound:
+  - interface: 10.0.0.1:10000:8080
+    tags:
+      service: backend
+`,
+		}),
+		Entry("with explicit address and hostname", testCase{
+			vars: map[string]string{
+				"address":  "192.168.0.1",
+				"hostname": "vm-2",
+				"port":     "8080",
+			},
+			expected: `
+type: Dataplane
+mesh: default
+name: backend-vm-2
+networking:
+  inbound:
+  - interface: 192.168.0.1:10000:8080
+    tags:
+      service: backend
+`,
+		}),
+	)
+
+	It("should not detect values of placeholders that are not used", func() {
+		// given
+		detectAddress = func() (string, error) {
+			Fail("address should not be detected")
+			return "", nil
+		}
+		hostname = func() (string, error) {
+			Fail("hostname should not be detected")
+			return "", nil
+		}
+		// and
+		template := `
+type: Dataplane
+mesh: default
+name: backend-{{ .name }}
+networking:
+  inbound:
+  - interface: 192.168.0.1:10000:{{ if .port }}{{ .port }}{{ else }}80{{ end }}
+    tags:
+      service: backend
+`
+
+		// when
+		actual, err := Render(template, map[string]string{"name": "1", "port": "8080"})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(`
+type: Dataplane
+mesh: default
+name: backend-1
+networking:
+  inbound:
+  - interface: 192.168.0.1:10000:8080
+    tags:
+      service: backend
+`))
+	})
+
+	It("should detect values of placeholders used within actions", func() {
+		// given
+		hostname = func() (string, error) {
+			Fail("hostname should not be detected")
+			return "", nil
+		}
+		// and
+		template := `
+type: Dataplane
+mesh: default
+name: backend
+networking:
+  inbound:
+  - interface: {{ if .address }}{{ .address }}{{ end }}:10000:8080
+    tags:
+      service: backend
+`
+
+		// when
+		actual, err := Render(template, nil)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(ContainSubstring("10.0.0.1:10000:8080"))
+	})
+
+	It("should fail when a value of a used placeholder cannot be detected", func() {
+		// given
+		detectAddress = func() (string, error) {
+			return "", errors.New("no network interfaces")
+		}
+
+		// when
+		_, err := Render(template, map[string]string{"port": "8080"})
+
+		// then
+		Expect(err).To(MatchError(`could not detect a value of "address" variable, set it explicitly: no network interfaces`))
+	})
+
+	It("should fail on a Dataplane without networking", func() {
+		// when
+		_, err := Render("type: Dataplane", nil)
+
+		// then
+		Expect(err).To(MatchError(`Dataplane rendered from the template is not valid: networking: has to contain at least one inbound interface or gateway`))
+	})
+
+	type errorTestCase struct {
+		vars     map[string]string
+		expected string
+	}
+
+	DescribeTable("should fail on invalid input",
+		func(given errorTestCase) {
+			// when
+			_, err := Render(template, given.vars)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(given.expected))
+		},
+		Entry("missing value of a placeholder", errorTestCase{
+			vars:     map[string]string{},
+			expected: `map has no entry for key "port"`,
+		}),
+		Entry("invalid Dataplane", errorTestCase{
+			vars: map[string]string{
+				"port": "99999",
+			},
+			expected: `Dataplane rendered from the template is not valid: networking.inbound[0].interface: invalid format`,
+		}),
+	)
+})
@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/app/kuma-injector/pkg/injector/metadata"
+	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
 	config "github.com/Kong/kuma/pkg/config/app/kuma-injector"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/validators"
 	k8s_resources "github.com/Kong/kuma/pkg/plugins/resources/k8s"
 	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
 
@@ -44,13 +49,35 @@ type KumaInjector struct {
 }
 
 func (i *KumaInjector) InjectKuma(pod *kube_core.Pod) error {
+	ns, err := i.namespaceFor(pod)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve namespace for pod")
+	}
+	enabled, err := metadata.IsSidecarInjectionEnabled(pod, ns)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	meshName := metadata.GetMesh(pod, ns) // either user-defined value or default
+
 	// sidecar container
 	if pod.Spec.Containers == nil {
 		pod.Spec.Containers = []kube_core.Container{}
 	}
-	pod.Spec.Containers = append(pod.Spec.Containers, i.NewSidecarContainer(pod))
+	sidecar, err := i.NewSidecarContainer(pod, meshName)
+	if err != nil {
+		return err
+	}
+	pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+
+	transparentProxying, err := i.transparentProxyingFor(pod)
+	if err != nil {
+		return err
+	}
 
-	mesh, err := i.meshFor(pod)
+	mesh, err := i.meshFor(meshName)
 	if err != nil {
 		return errors.Wrap(err, "could not retrieve mesh for pod")
 	}
@@ -59,7 +86,7 @@ func (i *KumaInjector) InjectKuma(pod *kube_core.Pod) error {
 	if pod.Annotations == nil {
 		pod.Annotations = map[string]string{}
 	}
-	for key, value := range i.NewAnnotations(pod, mesh) {
+	for key, value := range i.NewAnnotations(pod, mesh, transparentProxying) {
 		pod.Annotations[key] = value
 	}
 
@@ -67,12 +94,61 @@ func (i *KumaInjector) InjectKuma(pod *kube_core.Pod) error {
 	if pod.Spec.InitContainers == nil {
 		pod.Spec.InitContainers = []kube_core.Container{}
 	}
-	pod.Spec.InitContainers = append(pod.Spec.InitContainers, i.NewInitContainer(pod))
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, i.NewInitContainer(pod, transparentProxying))
 	return nil
 }
 
-func (i *KumaInjector) meshFor(pod *kube_core.Pod) (*mesh_core.MeshResource, error) {
-	meshName := metadata.GetMesh(pod) // either user-defined value or default
+// transparentProxyingFor returns settings of the transparent proxying for a given Pod.
+// Exclusions defined by annotations take precedence over the defaults from the configuration.
+func (i *KumaInjector) transparentProxyingFor(pod *kube_core.Pod) (*mesh_proto.Dataplane_Networking_TransparentProxying, error) {
+	transparentProxying := &mesh_proto.Dataplane_Networking_TransparentProxying{
+		RedirectPort:          i.cfg.SidecarContainer.RedirectPort,
+		ExcludedInboundPorts:  i.cfg.InitContainer.ExcludedInboundPorts,
+		ExcludedOutboundPorts: i.cfg.InitContainer.ExcludedOutboundPorts,
+		ExcludedOutboundCidrs: i.cfg.InitContainer.ExcludedOutboundCIDRs,
+	}
+
+	var verr validators.ValidationError
+	annotations := pod.GetAnnotations()
+	path := validators.RootedAt("metadata").Field("annotations")
+
+	if value, exists := annotations[metadata.KumaExcludedInboundPortsAnnotation]; exists {
+		ports, err := metadata.ParsePorts(value)
+		if err != nil {
+			verr.AddViolationAt(path.Key(metadata.KumaExcludedInboundPortsAnnotation), err.Error())
+		}
+		transparentProxying.ExcludedInboundPorts = ports
+	}
+	if value, exists := annotations[metadata.KumaExcludedOutboundPortsAnnotation]; exists {
+		ports, err := metadata.ParsePorts(value)
+		if err != nil {
+			verr.AddViolationAt(path.Key(metadata.KumaExcludedOutboundPortsAnnotation), err.Error())
+		}
+		transparentProxying.ExcludedOutboundPorts = ports
+	}
+	if value, exists := annotations[metadata.KumaExcludedOutboundCIDRsAnnotation]; exists {
+		cidrs, err := metadata.ParseCIDRs(value)
+		if err != nil {
+			verr.AddViolationAt(path.Key(metadata.KumaExcludedOutboundCIDRsAnnotation), err.Error())
+		}
+		transparentProxying.ExcludedOutboundCidrs = cidrs
+	}
+	return transparentProxying, verr.OrNil()
+}
+
+func (i *KumaInjector) namespaceFor(pod *kube_core.Pod) (*kube_core.Namespace, error) {
+	name := pod.Namespace
+	if name == "" {
+		name = kube_core.NamespaceDefault
+	}
+	ns := &kube_core.Namespace{}
+	if err := i.client.Get(context.Background(), kube_types.NamespacedName{Name: name}, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+func (i *KumaInjector) meshFor(meshName string) (*mesh_core.MeshResource, error) {
 	mesh := &mesh_k8s.Mesh{}
 	if err := i.client.Get(context.Background(), kube_types.NamespacedName{Name: meshName}, mesh); err != nil {
 		return nil, err
@@ -84,71 +160,159 @@ func (i *KumaInjector) meshFor(pod *kube_core.Pod) (*mesh_core.MeshResource, err
 	return meshResource, nil
 }
 
-func (i *KumaInjector) NewSidecarContainer(pod *kube_core.Pod) kube_core.Container {
-	mesh := metadata.GetMesh(pod) // either user-defined value or default
-	return kube_core.Container{
-		Name:            KumaSidecarContainerName,
-		Image:           i.cfg.SidecarContainer.Image,
-		ImagePullPolicy: kube_core.PullIfNotPresent,
-		Args: []string{
-			"run",
-			"--log-level=info",
+// sidecarSettings are settings of the Kuma Sidecar container
+// that can be overridden on a per-Pod basis by means of annotations.
+type sidecarSettings struct {
+	image         string
+	drainTime     time.Duration
+	envoyLogLevel string
+	requests      kube_core.ResourceList
+	limits        kube_core.ResourceList
+}
+
+func (i *KumaInjector) sidecarSettingsFor(pod *kube_core.Pod) (sidecarSettings, error) {
+	settings := sidecarSettings{
+		image:     i.cfg.SidecarContainer.Image,
+		drainTime: i.cfg.SidecarContainer.DrainTime,
+		requests: kube_core.ResourceList{
+			kube_core.ResourceCPU:    kube_api.MustParse(i.cfg.SidecarContainer.Resources.Requests.CPU),
+			kube_core.ResourceMemory: kube_api.MustParse(i.cfg.SidecarContainer.Resources.Requests.Memory),
 		},
-		Env: []kube_core.EnvVar{
-			{
-				Name: "POD_NAME",
-				ValueFrom: &kube_core.EnvVarSource{
-					FieldRef: &kube_core.ObjectFieldSelector{
-						APIVersion: "v1",
-						FieldPath:  "metadata.name",
-					},
+		limits: kube_core.ResourceList{
+			kube_core.ResourceCPU:    kube_api.MustParse(i.cfg.SidecarContainer.Resources.Limits.CPU),
+			kube_core.ResourceMemory: kube_api.MustParse(i.cfg.SidecarContainer.Resources.Limits.Memory),
+		},
+	}
+
+	var verr validators.ValidationError
+	annotations := pod.GetAnnotations()
+	path := validators.RootedAt("metadata").Field("annotations")
+
+	if image, exists := annotations[metadata.KumaSidecarImageAnnotation]; exists {
+		if image == "" {
+			verr.AddViolationAt(path.Key(metadata.KumaSidecarImageAnnotation), "must be non-empty")
+		} else {
+			settings.image = image
+		}
+	}
+	quantities := []struct {
+		annotation string
+		resources  kube_core.ResourceList
+		name       kube_core.ResourceName
+	}{
+		{metadata.KumaSidecarCPURequestAnnotation, settings.requests, kube_core.ResourceCPU},
+		{metadata.KumaSidecarCPULimitAnnotation, settings.limits, kube_core.ResourceCPU},
+		{metadata.KumaSidecarMemoryRequestAnnotation, settings.requests, kube_core.ResourceMemory},
+		{metadata.KumaSidecarMemoryLimitAnnotation, settings.limits, kube_core.ResourceMemory},
+	}
+	for _, quantity := range quantities {
+		value, exists := annotations[quantity.annotation]
+		if !exists {
+			continue
+		}
+		parsed, err := kube_api.ParseQuantity(value)
+		if err != nil {
+			verr.AddViolationAt(path.Key(quantity.annotation), fmt.Sprintf("must be a valid resource quantity, e.g. %q", "100m"))
+			continue
+		}
+		quantity.resources[quantity.name] = parsed
+	}
+	if value, exists := annotations[metadata.KumaDrainTimeAnnotation]; exists {
+		drainTime, err := time.ParseDuration(value)
+		switch {
+		case err != nil:
+			verr.AddViolationAt(path.Key(metadata.KumaDrainTimeAnnotation), fmt.Sprintf("must be a valid duration, e.g. %q", "30s"))
+		case drainTime <= 0:
+			verr.AddViolationAt(path.Key(metadata.KumaDrainTimeAnnotation), "must be positive")
+		default:
+			settings.drainTime = drainTime
+		}
+	}
+	if level, exists := annotations[metadata.KumaEnvoyLogLevelAnnotation]; exists {
+		if !kuma_dp.IsValidEnvoyLogLevel(level) {
+			verr.AddViolationAt(path.Key(metadata.KumaEnvoyLogLevelAnnotation), fmt.Sprintf("must be one of %v", kuma_dp.EnvoyLogLevels))
+		} else {
+			settings.envoyLogLevel = level
+		}
+	}
+	return settings, verr.OrNil()
+}
+
+func (i *KumaInjector) NewSidecarContainer(pod *kube_core.Pod, mesh string) (kube_core.Container, error) {
+	settings, err := i.sidecarSettingsFor(pod)
+	if err != nil {
+		return kube_core.Container{}, err
+	}
+	env := []kube_core.EnvVar{
+		{
+			Name: "POD_NAME",
+			ValueFrom: &kube_core.EnvVarSource{
+				FieldRef: &kube_core.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.name",
 				},
 			},
-			{
-				Name: "POD_NAMESPACE",
-				ValueFrom: &kube_core.EnvVarSource{
-					FieldRef: &kube_core.ObjectFieldSelector{
-						APIVersion: "v1",
-						FieldPath:  "metadata.namespace",
-					},
+		},
+		{
+			Name: "POD_NAMESPACE",
+			ValueFrom: &kube_core.EnvVarSource{
+				FieldRef: &kube_core.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.namespace",
 				},
 			},
-			{
-				Name: "INSTANCE_IP",
-				ValueFrom: &kube_core.EnvVarSource{
-					FieldRef: &kube_core.ObjectFieldSelector{
-						APIVersion: "v1",
-						FieldPath:  "status.podIP",
-					},
+		},
+		{
+			Name: "INSTANCE_IP",
+			ValueFrom: &kube_core.EnvVarSource{
+				FieldRef: &kube_core.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "status.podIP",
 				},
 			},
-			{
-				Name:  "KUMA_CONTROL_PLANE_API_SERVER_URL",
-				Value: i.cfg.ControlPlane.ApiServer.URL,
-			},
-			{
-				Name:  "KUMA_DATAPLANE_MESH",
-				Value: mesh,
-			},
-			{
-				Name: "KUMA_DATAPLANE_NAME",
-				// notice that Pod name might not be available at this time (in case of Deployment, ReplicaSet, etc)
-				// that is why we have to use a runtime reference to POD_NAME instead
-				Value: "$(POD_NAME).$(POD_NAMESPACE)", // variable references get expanded by Kubernetes
-			},
-			{
-				Name:  "KUMA_DATAPLANE_ADMIN_PORT",
-				Value: fmt.Sprintf("%d", i.cfg.SidecarContainer.AdminPort),
-			},
-			{
-				Name:  "KUMA_DATAPLANE_DRAIN_TIME",
-				Value: i.cfg.SidecarContainer.DrainTime.String(),
-			},
-			{
-				Name:  "KUMA_DATAPLANE_RUNTIME_TOKEN_PATH",
-				Value: "/var/run/secrets/kubernetes.io/serviceaccount/token",
-			},
 		},
+		{
+			Name:  "KUMA_CONTROL_PLANE_API_SERVER_URL",
+			Value: i.cfg.ControlPlane.ApiServer.URL,
+		},
+		{
+			Name:  "KUMA_DATAPLANE_MESH",
+			Value: mesh,
+		},
+		{
+			Name: "KUMA_DATAPLANE_NAME",
+			// notice that Pod name might not be available at this time (in case of Deployment, ReplicaSet, etc)
+			// that is why we have to use a runtime reference to POD_NAME instead
+			Value: "$(POD_NAME).$(POD_NAMESPACE)", // variable references get expanded by Kubernetes
+		},
+		{
+			Name:  "KUMA_DATAPLANE_ADMIN_PORT",
+			Value: fmt.Sprintf("%d", i.cfg.SidecarContainer.AdminPort),
+		},
+		{
+			Name:  "KUMA_DATAPLANE_DRAIN_TIME",
+			Value: settings.drainTime.String(),
+		},
+		{
+			Name:  "KUMA_DATAPLANE_RUNTIME_TOKEN_PATH",
+			Value: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		},
+	}
+	if settings.envoyLogLevel != "" {
+		env = append(env, kube_core.EnvVar{
+			Name:  "KUMA_DATAPLANE_RUNTIME_ENVOY_LOG_LEVEL",
+			Value: settings.envoyLogLevel,
+		})
+	}
+	return kube_core.Container{
+		Name:            KumaSidecarContainerName,
+		Image:           settings.image,
+		ImagePullPolicy: kube_core.PullIfNotPresent,
+		Args: []string{
+			"run",
+			"--log-level=info",
+		},
+		Env: env,
 		SecurityContext: &kube_core.SecurityContext{
 			RunAsUser:  &i.cfg.SidecarContainer.UID,
 			RunAsGroup: &i.cfg.SidecarContainer.GID,
@@ -186,21 +350,15 @@ func (i *KumaInjector) NewSidecarContainer(pod *kube_core.Pod) kube_core.Contain
 			FailureThreshold:    i.cfg.SidecarContainer.ReadinessProbe.FailureThreshold,
 		},
 		Resources: kube_core.ResourceRequirements{
-			Requests: kube_core.ResourceList{
-				kube_core.ResourceCPU:    kube_api.MustParse(i.cfg.SidecarContainer.Resources.Requests.CPU),
-				kube_core.ResourceMemory: kube_api.MustParse(i.cfg.SidecarContainer.Resources.Requests.Memory),
-			},
-			Limits: kube_core.ResourceList{
-				kube_core.ResourceCPU:    kube_api.MustParse(i.cfg.SidecarContainer.Resources.Limits.CPU),
-				kube_core.ResourceMemory: kube_api.MustParse(i.cfg.SidecarContainer.Resources.Limits.Memory),
-			},
+			Requests: settings.requests,
+			Limits:   settings.limits,
 		},
 		// On versions of Kubernetes prior to v1.15.0
 		// ServiceAccount admission plugin is called only once, prior to any mutating web hook.
 		// That's why it is a responsibility of every mutating web hook to copy
 		// ServiceAccount volume mount into containers it creates.
 		VolumeMounts: i.NewVolumeMounts(pod),
-	}
+	}, nil
 }
 
 func (i *KumaInjector) NewVolumeMounts(pod *kube_core.Pod) []kube_core.VolumeMount {
@@ -225,29 +383,39 @@ func (i *KumaInjector) FindServiceAccountToken(pod *kube_core.Pod) *kube_core.Vo
 	return nil
 }
 
-func (i *KumaInjector) NewInitContainer(pod *kube_core.Pod) kube_core.Container {
+func (i *KumaInjector) NewInitContainer(pod *kube_core.Pod, transparentProxying *mesh_proto.Dataplane_Networking_TransparentProxying) kube_core.Container {
 	inboundPortsToIntercept := "*"
 	if pod.GetAnnotations()[metadata.KumaGatewayAnnotation] == metadata.KumaGatewayEnabled {
 		inboundPortsToIntercept = ""
 	}
+	args := []string{
+		"-p",
+		fmt.Sprintf("%d", transparentProxying.RedirectPort),
+		"-u",
+		fmt.Sprintf("%d", i.cfg.SidecarContainer.UID),
+		"-g",
+		fmt.Sprintf("%d", i.cfg.SidecarContainer.GID),
+		"-m",
+		"REDIRECT",
+		"-i",
+		"*",
+		"-b",
+		inboundPortsToIntercept,
+	}
+	if len(transparentProxying.ExcludedInboundPorts) > 0 {
+		args = append(args, "-d", metadata.FormatPorts(transparentProxying.ExcludedInboundPorts))
+	}
+	if len(transparentProxying.ExcludedOutboundPorts) > 0 {
+		args = append(args, "-o", metadata.FormatPorts(transparentProxying.ExcludedOutboundPorts))
+	}
+	if len(transparentProxying.ExcludedOutboundCidrs) > 0 {
+		args = append(args, "-x", strings.Join(transparentProxying.ExcludedOutboundCidrs, ","))
+	}
 	return kube_core.Container{
 		Name:            KumaInitContainerName,
 		Image:           i.cfg.InitContainer.Image,
 		ImagePullPolicy: kube_core.PullIfNotPresent,
-		Args: []string{
-			"-p",
-			fmt.Sprintf("%d", i.cfg.SidecarContainer.RedirectPort),
-			"-u",
-			fmt.Sprintf("%d", i.cfg.SidecarContainer.UID),
-			"-g",
-			fmt.Sprintf("%d", i.cfg.SidecarContainer.GID),
-			"-m",
-			"REDIRECT",
-			"-i",
-			"*",
-			"-b",
-			inboundPortsToIntercept,
-		},
+		Args:            args,
 		SecurityContext: &kube_core.SecurityContext{
 			Capabilities: &kube_core.Capabilities{
 				Add: []kube_core.Capability{
@@ -268,12 +436,22 @@ func (i *KumaInjector) NewInitContainer(pod *kube_core.Pod) kube_core.Container
 	}
 }
 
-func (i *KumaInjector) NewAnnotations(pod *kube_core.Pod, mesh *mesh_core.MeshResource) map[string]string {
+func (i *KumaInjector) NewAnnotations(pod *kube_core.Pod, mesh *mesh_core.MeshResource, transparentProxying *mesh_proto.Dataplane_Networking_TransparentProxying) map[string]string {
 	annotations := map[string]string{
 		metadata.KumaMeshAnnotation:                    mesh.GetMeta().GetName(), // either user-defined value or default
 		metadata.KumaSidecarInjectedAnnotation:         metadata.KumaSidecarInjected,
 		metadata.KumaTransparentProxyingAnnotation:     metadata.KumaTransparentProxyingEnabled,
-		metadata.KumaTransparentProxyingPortAnnotation: fmt.Sprintf("%d", i.cfg.SidecarContainer.RedirectPort),
+		metadata.KumaTransparentProxyingPortAnnotation: fmt.Sprintf("%d", transparentProxying.RedirectPort),
+	}
+	// effective exclusions are recorded on a Pod to be reflected in the Dataplane definition
+	if len(transparentProxying.ExcludedInboundPorts) > 0 {
+		annotations[metadata.KumaExcludedInboundPortsAnnotation] = metadata.FormatPorts(transparentProxying.ExcludedInboundPorts)
+	}
+	if len(transparentProxying.ExcludedOutboundPorts) > 0 {
+		annotations[metadata.KumaExcludedOutboundPortsAnnotation] = metadata.FormatPorts(transparentProxying.ExcludedOutboundPorts)
+	}
+	if len(transparentProxying.ExcludedOutboundCidrs) > 0 {
+		annotations[metadata.KumaExcludedOutboundCIDRsAnnotation] = strings.Join(transparentProxying.ExcludedOutboundCidrs, ",")
 	}
 	for k, v := range i.prometheusAnnotations(pod, mesh) {
 		annotations[k] = v
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	kube_core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -60,6 +61,8 @@ var _ = BeforeSuite(func(done Done) {
 
 	err = mesh_k8s.AddToScheme(k8sClientScheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = kube_core.AddToScheme(k8sClientScheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
 
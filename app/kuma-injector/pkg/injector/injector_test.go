@@ -18,6 +18,7 @@ import (
 
 	"github.com/ghodss/yaml"
 	kube_core "k8s.io/api/core/v1"
+	kube_types "k8s.io/apimachinery/pkg/types"
 )
 
 var _ = Describe("Injector", func() {
@@ -31,8 +32,9 @@ var _ = Describe("Injector", func() {
 	})
 
 	type testCase struct {
-		num  string
-		mesh string
+		num       string
+		mesh      string
+		namespace string
 	}
 
 	BeforeEach(func() {
@@ -40,6 +42,18 @@ var _ = Describe("Injector", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	BeforeEach(func() {
+		// enable injection in the Namespace of Pods that have no Namespace set
+		ns := &kube_core.Namespace{}
+		err := k8sClient.Get(context.Background(), kube_types.NamespacedName{Name: kube_core.NamespaceDefault}, ns)
+		Expect(err).ToNot(HaveOccurred())
+		ns.Labels = map[string]string{
+			"kuma.io/sidecar-injection": "enabled",
+		}
+		err = k8sClient.Update(context.Background(), ns)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	DescribeTable("should inject Kuma into a Pod",
 		func(given testCase) {
 			// setup
@@ -53,6 +67,14 @@ var _ = Describe("Injector", func() {
 			err = k8sClient.Create(context.Background(), obj)
 			Expect(err).ToNot(HaveOccurred())
 
+			// and create namespace
+			if given.namespace != "" {
+				obj, _, err := decoder.Decode([]byte(given.namespace), nil, nil)
+				Expect(err).ToNot(HaveOccurred())
+				err = k8sClient.Create(context.Background(), obj)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
 			// given
 			pod := &kube_core.Pod{}
 
@@ -173,5 +195,145 @@ var _ = Describe("Injector", func() {
                     port: 1234
                     path: /metrics`,
 		}),
+		Entry("10. Pod with Sidecar overrides", testCase{
+			num: "10",
+			mesh: `
+              apiVersion: kuma.io/v1alpha1
+              kind: Mesh
+              metadata:
+                name: default`,
+		}),
+		Entry("11. Pod with transparent proxying exclusions", testCase{
+			num: "11",
+			mesh: `
+              apiVersion: kuma.io/v1alpha1
+              kind: Mesh
+              metadata:
+                name: default`,
+		}),
+		Entry("12. Pod with disabled injection", testCase{
+			num: "12",
+			mesh: `
+              apiVersion: kuma.io/v1alpha1
+              kind: Mesh
+              metadata:
+                name: default`,
+		}),
+		Entry("13. Pod in a Namespace with enabled injection and a default Mesh", testCase{
+			num: "13",
+			mesh: `
+              apiVersion: kuma.io/v1alpha1
+              kind: Mesh
+              metadata:
+                name: demo`,
+			namespace: `
+              apiVersion: v1
+              kind: Namespace
+              metadata:
+                name: kuma-demo
+                labels:
+                  kuma.io/sidecar-injection: enabled
+                annotations:
+                  kuma.io/mesh: demo`,
+		}),
+		Entry("14. Pod with enabled injection in a Namespace with disabled injection", testCase{
+			num: "14",
+			mesh: `
+              apiVersion: kuma.io/v1alpha1
+              kind: Mesh
+              metadata:
+                name: default`,
+			namespace: `
+              apiVersion: v1
+              kind: Namespace
+              metadata:
+                name: kuma-disabled
+                annotations:
+                  kuma.io/sidecar-injection: disabled`,
+		}),
 	)
+
+	It("should reject a Pod with invalid Sidecar overrides", func() {
+		// given
+		pod := &kube_core.Pod{}
+		err := yaml.Unmarshal([]byte(`
+          apiVersion: v1
+          kind: Pod
+          metadata:
+            name: busybox
+            annotations:
+              kuma.io/sidecar-image: ""
+              kuma.io/sidecar-cpu-limit: lots
+              kuma.io/drain-time: "-5s"
+              kuma.io/envoy-log-level: verbose
+          spec:
+            containers:
+            - name: busybox
+              image: busybox
+`), pod)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = injector.InjectKuma(pod)
+
+		// then
+		Expect(err).To(MatchError(`metadata.annotations["kuma.io/sidecar-image"]: must be non-empty; ` +
+			`metadata.annotations["kuma.io/sidecar-cpu-limit"]: must be a valid resource quantity, e.g. "100m"; ` +
+			`metadata.annotations["kuma.io/drain-time"]: must be positive; ` +
+			`metadata.annotations["kuma.io/envoy-log-level"]: must be one of [trace debug info warning error critical off]`))
+		// and
+		Expect(pod.Spec.Containers).To(HaveLen(1))
+	})
+
+	It("should reject a Pod with invalid value of kuma.io/sidecar-injection", func() {
+		// given
+		pod := &kube_core.Pod{}
+		err := yaml.Unmarshal([]byte(`
+          apiVersion: v1
+          kind: Pod
+          metadata:
+            name: busybox
+            labels:
+              kuma.io/sidecar-injection: "true"
+          spec:
+            containers:
+            - name: busybox
+              image: busybox
+`), pod)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = injector.InjectKuma(pod)
+
+		// then
+		Expect(err).To(MatchError(`metadata.labels["kuma.io/sidecar-injection"]: must be either "enabled" or "disabled"`))
+	})
+
+	It("should reject a Pod with invalid transparent proxying exclusions", func() {
+		// given
+		pod := &kube_core.Pod{}
+		err := yaml.Unmarshal([]byte(`
+          apiVersion: v1
+          kind: Pod
+          metadata:
+            name: busybox
+            annotations:
+              kuma.io/excluded-inbound-ports: "8081,http"
+              kuma.io/excluded-outbound-ports: "70000"
+              kuma.io/excluded-outbound-cidrs: "10.0.0.1"
+          spec:
+            containers:
+            - name: busybox
+              image: busybox
+`), pod)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = injector.InjectKuma(pod)
+
+		// then
+		Expect(err).To(MatchError(`metadata.annotations["kuma.io/excluded-inbound-ports"]: "http" is not a valid port; ` +
+			`metadata.annotations["kuma.io/excluded-outbound-ports"]: "70000" is not a valid port; ` +
+			`metadata.annotations["kuma.io/excluded-outbound-cidrs"]: "10.0.0.1" is not a valid IP range in CIDR notation`))
+	})
 })
@@ -4,8 +4,47 @@ package metadata
 const (
 	// KumaMeshAnnotation defines an annotation that can be put on Pods
 	// in order to associate them with a particular Mesh.
+	// When put on a Namespace, it defines a default Mesh for all Pods in that Namespace.
 	// Annotation value must be a name of a Mesh resource.
 	KumaMeshAnnotation = "kuma.io/mesh"
+
+	// KumaSidecarInjectionAnnotation defines an annotation (or a label) that can be put on Namespaces and Pods
+	// in order to enable or disable injection of the Kuma Sidecar.
+	// A value on a Pod takes precedence over a value on its Namespace.
+	KumaSidecarInjectionAnnotation = "kuma.io/sidecar-injection"
+	KumaSidecarInjectionEnabled    = "enabled"
+	KumaSidecarInjectionDisabled   = "disabled"
+
+	// KumaSidecarImageAnnotation overrides the image of the Kuma Sidecar container.
+	KumaSidecarImageAnnotation = "kuma.io/sidecar-image"
+	// KumaSidecarCPURequestAnnotation overrides CPU request of the Kuma Sidecar container, e.g. "50m".
+	KumaSidecarCPURequestAnnotation = "kuma.io/sidecar-cpu-request"
+	// KumaSidecarCPULimitAnnotation overrides CPU limit of the Kuma Sidecar container, e.g. "1000m".
+	KumaSidecarCPULimitAnnotation = "kuma.io/sidecar-cpu-limit"
+	// KumaSidecarMemoryRequestAnnotation overrides memory request of the Kuma Sidecar container, e.g. "64Mi".
+	KumaSidecarMemoryRequestAnnotation = "kuma.io/sidecar-memory-request"
+	// KumaSidecarMemoryLimitAnnotation overrides memory limit of the Kuma Sidecar container, e.g. "512Mi".
+	KumaSidecarMemoryLimitAnnotation = "kuma.io/sidecar-memory-limit"
+	// KumaDrainTimeAnnotation overrides drain time of Envoy listeners, e.g. "30s".
+	KumaDrainTimeAnnotation = "kuma.io/drain-time"
+	// KumaEnvoyLogLevelAnnotation defines log level of Envoy, e.g. "debug".
+	KumaEnvoyLogLevelAnnotation = "kuma.io/envoy-log-level"
+
+	// KumaExcludedInboundPortsAnnotation defines a comma-separated list of inbound ports
+	// that are not redirected to the Kuma Sidecar, e.g. "8081,8082".
+	KumaExcludedInboundPortsAnnotation = "kuma.io/excluded-inbound-ports"
+	// KumaExcludedOutboundPortsAnnotation defines a comma-separated list of outbound ports
+	// that are not redirected to the Kuma Sidecar, e.g. "5432".
+	KumaExcludedOutboundPortsAnnotation = "kuma.io/excluded-outbound-ports"
+	// KumaExcludedOutboundCIDRsAnnotation defines a comma-separated list of outbound IP ranges
+	// that are not redirected to the Kuma Sidecar, e.g. "169.254.169.254/32".
+	KumaExcludedOutboundCIDRsAnnotation = "kuma.io/excluded-outbound-cidrs"
+
+	// KumaOutboundServicesAnnotation defines a comma-separated list of services a Pod consumes,
+	// e.g. "backend.kuma-demo.svc:8080,redis.kuma-demo.svc".
+	// An entry without a port matches all ports of a service.
+	// If the annotation is not set, a Pod gets an outbound for every service in its Mesh.
+	KumaOutboundServicesAnnotation = "kuma.io/outbound-services"
 )
 
 // Annotations that are being automatically set by the Kuma Sidecar Injector.
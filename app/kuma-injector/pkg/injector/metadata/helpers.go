@@ -1,20 +1,73 @@
 package metadata
 
 import (
+	"fmt"
+	"net"
 	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/validators"
 
 	kube_core "k8s.io/api/core/v1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func GetMesh(pod *kube_core.Pod) string {
+// GetMesh returns a Mesh a given Pod belongs to, that is a value of `kuma.io/mesh` annotation
+// on the Pod or on its Namespace (if known), or the default Mesh otherwise.
+func GetMesh(pod *kube_core.Pod, ns *kube_core.Namespace) string {
 	if mesh := pod.Annotations[KumaMeshAnnotation]; mesh != "" {
 		return mesh
 	}
+	if ns != nil {
+		if mesh := ns.Annotations[KumaMeshAnnotation]; mesh != "" {
+			return mesh
+		}
+	}
 	return core_model.DefaultMesh
 }
 
+// IsSidecarInjectionEnabled tells whether the Kuma Sidecar should be injected into a given Pod
+// according to `kuma.io/sidecar-injection` annotation or label on the Pod or on its Namespace.
+// Unless enabled explicitly, the Kuma Sidecar is not injected.
+func IsSidecarInjectionEnabled(pod *kube_core.Pod, ns *kube_core.Namespace) (bool, error) {
+	var verr validators.ValidationError
+	for _, obj := range []struct {
+		path validators.PathBuilder
+		meta kube_meta.ObjectMeta
+	}{
+		{validators.RootedAt("metadata"), pod.ObjectMeta},
+		{validators.RootedAt("namespace").Field("metadata"), ns.ObjectMeta},
+	} {
+		field, value, exists := getSidecarInjection(obj.meta)
+		if !exists {
+			continue
+		}
+		switch value {
+		case KumaSidecarInjectionEnabled:
+			return true, nil
+		case KumaSidecarInjectionDisabled:
+			return false, nil
+		default:
+			verr.AddViolationAt(obj.path.Field(field).Key(KumaSidecarInjectionAnnotation), fmt.Sprintf("must be either %q or %q", KumaSidecarInjectionEnabled, KumaSidecarInjectionDisabled))
+			return false, verr.OrNil()
+		}
+	}
+	return false, nil
+}
+
+func getSidecarInjection(meta kube_meta.ObjectMeta) (field string, value string, exists bool) {
+	if value, exists := meta.Annotations[KumaSidecarInjectionAnnotation]; exists {
+		return "annotations", value, true
+	}
+	if value, exists := meta.Labels[KumaSidecarInjectionAnnotation]; exists {
+		return "labels", value, true
+	}
+	return "", "", false
+}
+
 func HasKumaSidecar(pod *kube_core.Pod) bool {
 	return pod.Annotations[KumaSidecarInjectedAnnotation] == KumaSidecarInjected
 }
@@ -34,3 +87,57 @@ func GetTransparentProxyingPort(pod *kube_core.Pod) uint32 {
 	}
 	return uint32(port)
 }
+
+// ParsePorts parses a comma-separated list of ports, e.g. "8080,8443".
+func ParsePorts(value string) ([]uint32, error) {
+	var ports []uint32
+	for _, field := range splitList(value) {
+		port, err := strconv.ParseUint(field, 10, 16)
+		if err != nil || port == 0 {
+			return nil, errors.Errorf("%q is not a valid port", field)
+		}
+		ports = append(ports, uint32(port))
+	}
+	return ports, nil
+}
+
+// ParseCIDRs parses a comma-separated list of IP ranges in CIDR notation, e.g. "10.0.0.0/8,169.254.169.254/32".
+func ParseCIDRs(value string) ([]string, error) {
+	var cidrs []string
+	for _, field := range splitList(value) {
+		if _, _, err := net.ParseCIDR(field); err != nil {
+			return nil, errors.Errorf("%q is not a valid IP range in CIDR notation", field)
+		}
+		cidrs = append(cidrs, field)
+	}
+	return cidrs, nil
+}
+
+// FormatPorts is the opposite of ParsePorts.
+func FormatPorts(ports []uint32) string {
+	fields := make([]string, len(ports))
+	for i, port := range ports {
+		fields[i] = strconv.FormatUint(uint64(port), 10)
+	}
+	return strings.Join(fields, ",")
+}
+
+// GetOutboundServices returns a list of services a Pod consumes.
+// The second return value is false if a Pod doesn't limit its outbounds.
+func GetOutboundServices(pod *kube_core.Pod) ([]string, bool) {
+	value, exists := pod.Annotations[KumaOutboundServicesAnnotation]
+	if !exists {
+		return nil, false
+	}
+	return splitList(value), true
+}
+
+func splitList(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/validators"
 
 	kube_core "k8s.io/api/core/v1"
 	kube_webhook "sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -34,7 +35,14 @@ func (h *podMutatingHandler) Handle(ctx context.Context, req kube_webhook.Admiss
 	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
 		return kube_admission.Errored(http.StatusBadRequest, err)
 	}
+	// Pods created by controllers (e.g., ReplicaSet) might not have Namespace set yet
+	if pod.Namespace == "" {
+		pod.Namespace = req.Namespace
+	}
 	if err := h.mutator(&pod); err != nil {
+		if validators.IsValidationError(err) {
+			return kube_admission.Denied(err.Error())
+		}
 		return kube_admission.Errored(http.StatusInternalServerError, err)
 	}
 	mutatedRaw, err := json.Marshal(pod)
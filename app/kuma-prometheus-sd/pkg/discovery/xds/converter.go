@@ -10,6 +10,11 @@ import (
 	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
 )
 
+// Converter converts MonitoringAssignments into Prometheus target groups.
+//
+// Labels of a target override labels of an assignment. All labels are passed through as is,
+// including relabelling hints, e.g. `__meta_kuma_endpoint`, which are available
+// to `relabel_configs` on Prometheus side and dropped afterwards.
 type Converter struct{}
 
 func (c Converter) ConvertAll(assignments []*observability_proto.MonitoringAssignment) []*targetgroup.Group {
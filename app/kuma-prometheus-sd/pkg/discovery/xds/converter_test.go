@@ -177,6 +177,65 @@ var _ = Describe("Converter", func() {
 					},
 				},
 			}),
+			Entry("1 Dataplane with an application endpoint, with relabelling hints", testCase{
+				input: &observability_proto.MonitoringAssignment{
+					Name: "/meshes/default/dataplanes/backend-01",
+					Targets: []*observability_proto.MonitoringAssignment_Target{
+						{
+							Labels: map[string]string{
+								"__address__": "192.168.0.1:8080",
+							},
+						},
+						{
+							Labels: map[string]string{
+								"__address__":          "192.168.0.1:9090",
+								"__scheme__":           "https",
+								"__metrics_path__":     "/stats",
+								"__meta_kuma_endpoint": "app",
+								"endpoint":             "app",
+							},
+						},
+					},
+					Labels: map[string]string{
+						"__scheme__":       "http",
+						"__metrics_path__": "/metrics",
+						"job":              "backend",
+						"instance":         "backend-01",
+					},
+				},
+				expected: []*targetgroup.Group{
+					{
+						Source: "/meshes/default/dataplanes/backend-01/0",
+						Targets: []model.LabelSet{
+							{
+								"__address__": "192.168.0.1:8080",
+							},
+						},
+						Labels: model.LabelSet{
+							"__scheme__":       "http",
+							"__metrics_path__": "/metrics",
+							"job":              "backend",
+							"instance":         "backend-01",
+						},
+					},
+					{
+						Source: "/meshes/default/dataplanes/backend-01/1",
+						Targets: []model.LabelSet{
+							{
+								"__address__": "192.168.0.1:9090",
+							},
+						},
+						Labels: model.LabelSet{
+							"__scheme__":           "https",
+							"__metrics_path__":     "/stats",
+							"__meta_kuma_endpoint": "app",
+							"endpoint":             "app",
+							"job":                  "backend",
+							"instance":             "backend-01",
+						},
+					},
+				},
+			}),
 		)
 	})
 })
@@ -2,6 +2,9 @@ package apply
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+
 	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/model"
@@ -13,6 +16,7 @@ import (
 	"github.com/hoisie/mustache"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -27,80 +31,170 @@ type applyContext struct {
 	*kumactl_cmd.RootContext
 
 	args struct {
-		file string
-		vars map[string]string
+		file      string
+		recursive bool
+		vars      map[string]string
+		dryRun    bool
+		force     bool
 	}
 }
 
+type applyOptions struct {
+	dryRun bool
+	force  bool
+}
+
 func NewApplyCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 	ctx := &applyContext{RootContext: pctx}
 	cmd := &cobra.Command{
 		Use:   "apply",
 		Short: "Create or modify Kuma resources",
-		Long:  `Create or modify Kuma resources.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			var b []byte
-			var err error
+		Long: `Create or modify Kuma resources.
 
-			if ctx.args.file == "" || ctx.args.file == "-" {
-				b, err = ioutil.ReadAll(cmd.InOrStdin())
-				if err != nil {
-					return err
-				}
-			} else {
-				if strings.HasPrefix(ctx.args.file, "http://") || strings.HasPrefix(ctx.args.file, "https://") {
-					client := &http.Client{
-						Timeout: timeout,
-					}
-					req, err := http.NewRequest("GET", ctx.args.file, nil)
-					if err != nil {
-						return errors.Wrap(err, "error creating new http request")
-					}
-					resp, err := client.Do(req)
-					if err != nil {
-						return errors.Wrap(err, "error with GET http request")
-					}
-					if resp.StatusCode != 200 {
-						return errors.Wrap(err, "errpr while retrieving URL")
-					}
-					defer resp.Body.Close()
-					b, err = ioutil.ReadAll(resp.Body)
-					if err != nil {
-						return errors.Wrap(err, "error while reading provided file")
-					}
-				} else {
-					b, err = ioutil.ReadFile(ctx.args.file)
-					if err != nil {
-						return errors.Wrap(err, "error while reading provided file")
-					}
-				}
-			}
+Resources can be read from stdin, a file, a URL or a directory. Every input may contain
+multiple YAML documents separated by "---". Resources are applied in the order that
+satisfies dependencies between them, e.g. Meshes are applied first.
 
-			configBytes, err := processConfigTemplate(string(b), ctx.args.vars)
-			if err != nil {
-				return errors.Wrap(err, "error compiling config from template")
-			}
+If the Control Plane supports it, all resources are applied atomically.
 
-			res, err := parseResource(configBytes)
+If a resource specifies a version, it is applied only if the current version of the resource
+on the Control Plane is the same. Otherwise, resources modified concurrently by someone else
+are applied again up to a few times.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resources, err := readResources(cmd.InOrStdin(), ctx.args.file, ctx.args.recursive, ctx.args.vars)
 			if err != nil {
-				return errors.Wrap(err, "YAML contains invalid resource")
+				return err
 			}
 			rs, err := pctx.CurrentResourceStore()
 			if err != nil {
 				return err
 			}
 
-			if err := upsert(rs, res); err != nil {
+			if err := applyResources(context.Background(), rs, resources, applyOptions{
+				dryRun: ctx.args.dryRun,
+				force:  ctx.args.force,
+			}); err != nil {
 				return err
 			}
+			if ctx.args.dryRun {
+				for _, res := range resources {
+					cmd.Printf("%s %q is valid (dry run)\n", res.GetType(), res.GetMeta().GetName())
+				}
+			}
 			return nil
 		},
 	}
-	cmd.PersistentFlags().StringVarP(&ctx.args.file, "file", "f", "", "Path to file to apply")
+	cmd.PersistentFlags().StringVarP(&ctx.args.file, "file", "f", "", "Path to file or directory to apply")
+	cmd.PersistentFlags().BoolVarP(&ctx.args.recursive, "recursive", "R", false, "Process the directory used in -f recursively")
 	cmd.PersistentFlags().StringToStringVarP(&ctx.args.vars, "var", "v", map[string]string{}, "Variable to replace in configuration")
+	cmd.PersistentFlags().BoolVar(&ctx.args.dryRun, "dry-run", false, "Validate resources on the Control Plane without persisting them")
+	cmd.PersistentFlags().BoolVar(&ctx.args.force, "force", false, "Overwrite resources even if they were modified since the version specified in the input")
 	return cmd
 }
 
+// readResources reads all resources from a given input and sorts them in the order they should be applied.
+func readResources(stdin io.Reader, file string, recursive bool, vars map[string]string) ([]model.Resource, error) {
+	inputs, err := readInputs(stdin, file, recursive)
+	if err != nil {
+		return nil, err
+	}
+	var resources []model.Resource
+	for _, b := range inputs {
+		configBytes, err := processConfigTemplate(string(b), vars)
+		if err != nil {
+			return nil, errors.Wrap(err, "error compiling config from template")
+		}
+		for _, doc := range splitDocuments(configBytes) {
+			res, err := parseResource(doc)
+			if err != nil {
+				return nil, errors.Wrap(err, "YAML contains invalid resource")
+			}
+			resources = append(resources, res)
+		}
+	}
+	sortByType(resources)
+	return resources, nil
+}
+
+// readInputs reads configuration from stdin, a local file, a URL or files in a directory.
+func readInputs(stdin io.Reader, file string, recursive bool) ([][]byte, error) {
+	if file != "" && file != "-" {
+		if info, err := os.Stat(file); err == nil && info.IsDir() {
+			return readDir(file, recursive)
+		}
+	}
+	b, err := readInput(stdin, file)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{b}, nil
+}
+
+// readDir reads all YAML and JSON files in a directory in lexical order.
+func readDir(dir string, recursive bool) ([][]byte, error) {
+	var inputs [][]byte
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "error while reading file %q", path)
+		}
+		inputs = append(inputs, b)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading provided directory")
+	}
+	return inputs, nil
+}
+
+// readInput reads configuration either from stdin, a local file or a URL.
+func readInput(stdin io.Reader, file string) ([]byte, error) {
+	if file == "" || file == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	if strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://") {
+		client := &http.Client{
+			Timeout: timeout,
+		}
+		req, err := http.NewRequest("GET", file, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating new http request")
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, errors.Wrap(err, "error with GET http request")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return nil, errors.Errorf("error while retrieving URL: unexpected status code %d", resp.StatusCode)
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while reading provided file")
+		}
+		return b, nil
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading provided file")
+	}
+	return b, nil
+}
+
 func processConfigTemplate(config string, values map[string]string) ([]byte, error) {
 	// TODO error checking -- match number of placeholders with number of
 	// passed values
@@ -108,23 +202,77 @@ func processConfigTemplate(config string, values map[string]string) ([]byte, err
 	return []byte(data), nil
 }
 
-func upsert(rs store.ResourceStore, res model.Resource) error {
-	newRes, err := registry.Global().NewObject(res.GetType())
+// conflictRetries is a number of attempts to apply resources that are modified concurrently.
+const conflictRetries = 3
+
+// applyResources creates or updates given resources in the order they were given.
+// If the store supports transactions, either all resources are applied or none of them.
+func applyResources(ctx context.Context, rs store.ResourceStore, resources []model.Resource, opts applyOptions) error {
+	// versions given in the input are preconditions of updates
+	versions := make([]string, len(resources))
+	pinned := false
+	if !opts.force {
+		for i, res := range resources {
+			versions[i] = res.GetMeta().GetVersion()
+			pinned = pinned || versions[i] != ""
+		}
+	}
+	applyAll := func(ctx context.Context) error {
+		for i, res := range resources {
+			if err := upsert(ctx, rs, res, versions[i], opts.dryRun); err != nil {
+				return errors.Wrapf(err, "could not apply %s %q", res.GetType(), res.GetMeta().GetName())
+			}
+		}
+		return nil
+	}
+	apply := applyAll
+	if transactional, ok := rs.(store.TransactionalResourceStore); ok && len(resources) > 1 {
+		apply = func(ctx context.Context) error {
+			return transactional.Transactional(ctx, applyAll)
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		err := apply(ctx)
+		if !isConcurrentModification(errors.Cause(err)) {
+			return err
+		}
+		if pinned {
+			return errors.Wrap(err, "resource has been modified since the version specified in the input, fetch it again or use --force to overwrite it")
+		}
+		if attempt == conflictRetries {
+			return err
+		}
+	}
+}
+
+func isConcurrentModification(err error) bool {
+	return store.IsResourceConflict(err) || store.IsResourcePreconditionFailed(err)
+}
+
+// upsert creates or updates a given resource. If a version is given, only this version of the resource is updated.
+// In case of a dry run, the resource is updated with a spec the Control Plane would have persisted.
+func upsert(ctx context.Context, rs store.ResourceStore, res model.Resource, version string, dryRun bool) error {
+	current, err := registry.Global().NewObject(res.GetType())
 	if err != nil {
 		return err
 	}
 	meta := res.GetMeta()
-	if err := rs.Get(context.Background(), newRes, store.GetByKey(meta.GetName(), meta.GetMesh())); err != nil {
-		if store.IsResourceNotFound(err) {
-			return rs.Create(context.Background(), res, store.CreateByKey(meta.GetName(), meta.GetMesh()))
+	getOpts := []store.GetOptionsFunc{store.GetByKey(meta.GetName(), meta.GetMesh())}
+	if version != "" {
+		getOpts = append(getOpts, store.GetByVersion(version))
+	}
+	if err := rs.Get(ctx, current, getOpts...); err != nil {
+		if store.IsResourceNotFound(err) && version != "" {
+			// a version of a resource that does not exist cannot match
+			return store.ErrorResourcePreconditionFailed(res.GetType(), meta.GetName(), meta.GetMesh())
+		} else if store.IsResourceNotFound(err) {
+			return rs.Create(ctx, res, store.CreateByKey(meta.GetName(), meta.GetMesh()), store.CreateWithDryRun(dryRun))
 		} else {
 			return err
 		}
 	}
-	if err := newRes.SetSpec(res.GetSpec()); err != nil {
-		return err
-	}
-	return rs.Update(context.Background(), newRes)
+	res.SetMeta(current.GetMeta())
+	return rs.Update(ctx, res, store.UpdateWithDryRun(dryRun))
 }
 
 func parseResource(bytes []byte) (model.Resource, error) {
@@ -146,8 +294,9 @@ func parseResource(bytes []byte) (model.Resource, error) {
 		return nil, err
 	}
 	resource.SetMeta(meta{
-		Name: resMeta.Name,
-		Mesh: resMeta.Mesh,
+		Name:    resMeta.Name,
+		Mesh:    resMeta.Mesh,
+		Version: resMeta.Version,
 	})
 	return resource, nil
 }
@@ -155,8 +304,9 @@ func parseResource(bytes []byte) (model.Resource, error) {
 var _ model.ResourceMeta = &meta{}
 
 type meta struct {
-	Name string
-	Mesh string
+	Name    string
+	Mesh    string
+	Version string
 }
 
 func (m meta) GetName() string {
@@ -168,7 +318,7 @@ func (m meta) GetNamespace() string {
 }
 
 func (m meta) GetVersion() string {
-	return ""
+	return m.Version
 }
 
 func (m meta) GetMesh() string {
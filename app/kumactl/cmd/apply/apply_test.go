@@ -15,6 +15,7 @@ import (
 	"github.com/Kong/kuma/app/kumactl/cmd"
 	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/rest/errors/types"
 	test_store "github.com/Kong/kuma/pkg/test/store"
 	. "github.com/onsi/ginkgo"
@@ -23,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
 	memory_resources "github.com/Kong/kuma/pkg/plugins/resources/memory"
 )
@@ -183,6 +185,240 @@ var _ = Describe("kumactl apply", func() {
 		Expect(resource.Meta.GetMesh()).To(Equal(""))
 	})
 
+	It("should validate a Dataplane resource without persisting it on --dry-run", func() {
+		// setup
+		rootCtx.Runtime.NewResourceStore = func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
+			// resource manager is used to simulate dry run on the Control Plane
+			return manager.NewResourceManager(store), nil
+		}
+		err := store.Create(context.Background(), &mesh.MeshResource{}, core_store.CreateByKey("default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"apply", "-f", filepath.Join("testdata", "apply-dataplane.yaml"), "--dry-run"},
+		)
+		buf := &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+
+		// when
+		err = rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal("Dataplane \"sample\" is valid (dry run)\n"))
+
+		// and
+		err = store.Get(context.Background(), &mesh.DataplaneResource{}, core_store.GetByKey("sample", "default"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+	})
+
+	Describe("multiple resources", func() {
+
+		var appliedTypes []string
+
+		BeforeEach(func() {
+			appliedTypes = nil
+			rootCtx.Runtime.NewResourceStore = func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
+				return &recordingStore{ResourceStore: store, types: &appliedTypes}, nil
+			}
+		})
+
+		ValidatePersistedResources := func() {
+			Expect(appliedTypes).To(Equal([]string{"Mesh", "Dataplane"}))
+			err := store.Get(context.Background(), &mesh.MeshResource{}, core_store.GetByKey("demo", ""))
+			Expect(err).ToNot(HaveOccurred())
+			dataplane := mesh.DataplaneResource{}
+			err = store.Get(context.Background(), &dataplane, core_store.GetByKey("web", "demo"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dataplane.Spec.Networking.Inbound[0].Tags).To(HaveKeyWithValue("service", "web"))
+		}
+
+		It("should apply a multi-document file with a Mesh applied first", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply", "-f", filepath.Join("testdata", "apply-multi-document.yaml")},
+			)
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			ValidatePersistedResources()
+		})
+
+		It("should apply a multi-document stream from stdin", func() {
+			// setup
+			mockStdin, err := os.Open(filepath.Join("testdata", "apply-multi-document.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply"},
+			)
+			rootCmd.SetIn(mockStdin)
+
+			// when
+			err = rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			ValidatePersistedResources()
+		})
+
+		It("should apply YAML and JSON files from a directory", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply", "-f", filepath.Join("testdata", "apply-dir")},
+			)
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			ValidatePersistedResources()
+			// and subdirectories are skipped
+			err = store.Get(context.Background(), &mesh.TrafficPermissionResource{}, core_store.GetByKey("everyone-to-web", "demo"))
+			Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should apply files from subdirectories with -R", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply", "-f", filepath.Join("testdata", "apply-dir"), "-R"},
+			)
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(appliedTypes).To(Equal([]string{"Mesh", "TrafficPermission", "Dataplane"}))
+			err = store.Get(context.Background(), &mesh.TrafficPermissionResource{}, core_store.GetByKey("everyone-to-web", "demo"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not apply any resource if one of them fails", func() {
+			// setup
+			rootCtx.Runtime.NewResourceStore = func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
+				// resource manager is used to simulate validation on the Control Plane
+				return manager.NewResourceManager(store), nil
+			}
+
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply", "-f", filepath.Join("testdata", "apply-multi-document-invalid.yaml")},
+			)
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix(`could not apply Dataplane "web": `))
+			// and
+			err = store.Get(context.Background(), &mesh.MeshResource{}, core_store.GetByKey("demo", ""))
+			Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("concurrent modifications", func() {
+
+		BeforeEach(func() {
+			existing := mesh.DataplaneResource{
+				Spec: v1alpha1.Dataplane{
+					Networking: &v1alpha1.Dataplane_Networking{
+						Inbound: []*v1alpha1.Dataplane_Networking_Inbound{
+							{
+								Interface: "1.1.1.1:80:8080",
+								Tags: map[string]string{
+									"service": "web",
+									"version": "1.0",
+								},
+							},
+						},
+					},
+				},
+			}
+			err := store.Create(context.Background(), &existing, core_store.CreateByKey("sample", "default"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		currentVersionTag := func() string {
+			resource := mesh.DataplaneResource{}
+			err := store.Get(context.Background(), &resource, core_store.GetByKey("sample", "default"))
+			Expect(err).ToNot(HaveOccurred())
+			return resource.Spec.Networking.Inbound[0].Tags["version"]
+		}
+
+		It("should not apply a resource modified since the version in the file", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply", "-f", filepath.Join("testdata", "apply-dataplane-versioned.yaml")},
+			)
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("use --force to overwrite it"))
+			// and
+			Expect(currentVersionTag()).To(Equal("1.0"))
+		})
+
+		It("should overwrite a resource modified since the version in the file on --force", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply", "-f", filepath.Join("testdata", "apply-dataplane-versioned.yaml"), "--force"},
+			)
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(currentVersionTag()).To(Equal("2.0"))
+		})
+
+		It("should apply a resource again when it was modified concurrently", func() {
+			// setup
+			conflicts := 2
+			rootCtx.Runtime.NewResourceStore = func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
+				return &conflictingStore{ResourceStore: store, conflicts: &conflicts}, nil
+			}
+
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"apply", "-f", filepath.Join("testdata", "apply-dataplane.yaml")},
+			)
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(Equal(0))
+			// and
+			ValidatePersistedResource()
+		})
+	})
+
 	It("should apply a new Dataplane resource from URL", func() {
 		// setup http server
 		mux := http.NewServeMux()
@@ -338,3 +574,28 @@ networking:
 		}),
 	)
 })
+
+// recordingStore records types of resources in the order they were created.
+type recordingStore struct {
+	core_store.ResourceStore
+	types *[]string
+}
+
+func (s *recordingStore) Create(ctx context.Context, res model.Resource, fs ...core_store.CreateOptionsFunc) error {
+	*s.types = append(*s.types, string(res.GetType()))
+	return s.ResourceStore.Create(ctx, res, fs...)
+}
+
+// conflictingStore simulates resources modified concurrently by failing a given number of updates.
+type conflictingStore struct {
+	core_store.ResourceStore
+	conflicts *int
+}
+
+func (s *conflictingStore) Update(ctx context.Context, res model.Resource, fs ...core_store.UpdateOptionsFunc) error {
+	if *s.conflicts > 0 {
+		*s.conflicts--
+		return core_store.ErrorResourceConflict(res.GetType(), res.GetMeta().GetName(), res.GetMeta().GetMesh())
+	}
+	return s.ResourceStore.Update(ctx, res, fs...)
+}
@@ -0,0 +1,162 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	"github.com/Kong/kuma/pkg/core/resources/registry"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+type diffContext struct {
+	*kumactl_cmd.RootContext
+
+	args struct {
+		file      string
+		recursive bool
+		vars      map[string]string
+	}
+}
+
+func NewDiffCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	ctx := &diffContext{RootContext: pctx}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show changes that apply would make to Kuma resources",
+		Long: `Show changes that apply would make to Kuma resources.
+
+Every resource is validated on the Control Plane by a dry run. The output is a unified diff
+between the current and the proposed state of each resource.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resources, err := readResources(cmd.InOrStdin(), ctx.args.file, ctx.args.recursive, ctx.args.vars)
+			if err != nil {
+				return err
+			}
+
+			rs, err := pctx.CurrentResourceStore()
+			if err != nil {
+				return err
+			}
+
+			texts, err := diff(context.Background(), rs, resources)
+			if err != nil {
+				return err
+			}
+			for _, text := range texts {
+				cmd.Print(text)
+			}
+			return nil
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&ctx.args.file, "file", "f", "", "Path to file or directory to diff")
+	cmd.PersistentFlags().BoolVarP(&ctx.args.recursive, "recursive", "R", false, "Process the directory used in -f recursively")
+	cmd.PersistentFlags().StringToStringVarP(&ctx.args.vars, "var", "v", map[string]string{}, "Variable to replace in configuration")
+	return cmd
+}
+
+// diff returns unified diffs between the current state of resources and the state
+// the Control Plane would have persisted. A diff is empty if there are no changes.
+func diff(ctx context.Context, rs store.ResourceStore, resources []model.Resource) ([]string, error) {
+	var currentYAMLs []string
+	for _, res := range resources {
+		current, err := registry.Global().NewObject(res.GetType())
+		if err != nil {
+			return nil, err
+		}
+		meta := res.GetMeta()
+		var currentYAML string
+		if err := rs.Get(ctx, current, store.GetByKey(meta.GetName(), meta.GetMesh())); err != nil {
+			if !store.IsResourceNotFound(err) {
+				return nil, err
+			}
+		} else {
+			if currentYAML, err = toYAML(current); err != nil {
+				return nil, err
+			}
+		}
+		currentYAMLs = append(currentYAMLs, currentYAML)
+	}
+
+	if err := applyResources(ctx, rs, resources, applyOptions{dryRun: true}); err != nil {
+		return nil, err
+	}
+
+	var texts []string
+	for i, res := range resources {
+		proposedYAML, err := toYAML(res)
+		if err != nil {
+			return nil, err
+		}
+		name := resourceLabel(res)
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(currentYAMLs[i]),
+			B:        splitLines(proposedYAML),
+			FromFile: "current/" + name,
+			ToFile:   "proposed/" + name,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, text)
+	}
+	return texts, nil
+}
+
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func resourceLabel(res model.Resource) string {
+	if res.GetType() == mesh.MeshType {
+		return fmt.Sprintf("%s/%s", res.GetType(), res.GetMeta().GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", res.GetType(), res.GetMeta().GetMesh(), res.GetMeta().GetName())
+}
+
+func toYAML(res model.Resource) (string, error) {
+	restRes := rest.From.Resource(res)
+	// version is not a part of the desired state of a resource
+	restRes.Meta.Version = ""
+	b, err := yaml.Marshal(restRes)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// splitDocuments splits a multi-document YAML into separate documents.
+// Empty documents are skipped.
+func splitDocuments(b []byte) [][]byte {
+	var docs [][]byte
+	var current []string
+	flush := func() {
+		doc := strings.Join(current, "\n")
+		if strings.TrimSpace(doc) != "" {
+			docs = append(docs, []byte(doc))
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.TrimRight(line, " \t\r") == "---" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return docs
+}
@@ -0,0 +1,114 @@
+package apply_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	memory_resources "github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("kumactl diff", func() {
+
+	var rootCmd *cobra.Command
+	var store core_store.ResourceStore
+	var buf *bytes.Buffer
+
+	BeforeEach(func() {
+		store = memory_resources.NewStore()
+		rootCtx := &kumactl_cmd.RootContext{
+			Runtime: kumactl_cmd.RootRuntime{
+				NewResourceStore: func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
+					// resource manager is used to simulate dry run on the Control Plane
+					return manager.NewResourceManager(store), nil
+				},
+			},
+		}
+		rootCmd = cmd.NewRootCmd(rootCtx)
+		buf = &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+
+		err := store.Create(context.Background(), &mesh.MeshResource{}, core_store.CreateByKey("default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should show a diff for every resource in a multi-document file", func() {
+		// setup
+		existing := mesh.DataplaneResource{
+			Spec: v1alpha1.Dataplane{
+				Networking: &v1alpha1.Dataplane_Networking{
+					Inbound: []*v1alpha1.Dataplane_Networking_Inbound{
+						{
+							Interface: "1.1.1.1:80:8080",
+							Tags: map[string]string{
+								"service": "web",
+								"version": "1.0",
+							},
+						},
+					},
+				},
+			},
+		}
+		err := store.Create(context.Background(), &existing, core_store.CreateByKey("sample", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"diff", "-f", filepath.Join("testdata", "diff-dataplanes.yaml")},
+		)
+
+		// when
+		err = rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "diff-dataplanes.golden.txt"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(string(expected)))
+
+		// and nothing is persisted
+		resource := mesh.DataplaneResource{}
+		err = store.Get(context.Background(), &resource, core_store.GetByKey("sample", "default"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resource.Spec.Networking.Inbound[0].Tags).To(HaveKeyWithValue("version", "1.0"))
+		err = store.Get(context.Background(), &mesh.DataplaneResource{}, core_store.GetByKey("sample-2", "default"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+	})
+
+	It("should show no diff when nothing changes", func() {
+		// setup
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"apply", "-f", filepath.Join("testdata", "apply-dataplane.yaml")},
+		)
+		Expect(rootCmd.Execute()).To(Succeed())
+
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"diff", "-f", filepath.Join("testdata", "apply-dataplane.yaml")},
+		)
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,40 @@
+package apply
+
+import (
+	"sort"
+
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+// ApplyOrder is the order in which resources should be applied (by type).
+//
+// Those occurring earlier in the list get applied before those occurring later in the list.
+// Resources of types that are not on the list get applied last.
+var ApplyOrder = []model.ResourceType{
+	mesh.MeshType,
+	mesh.ProxyTemplateType,
+	mesh.TrafficPermissionType,
+	mesh.TrafficLogType,
+	mesh.TrafficRouteType,
+	mesh.HealthCheckType,
+	mesh.DataplaneType,
+}
+
+// sortByType sorts resources according to ApplyOrder.
+// Resources of the same type keep the order in which they were given.
+func sortByType(resources []model.Resource) {
+	ordering := map[model.ResourceType]int{}
+	for i, typ := range ApplyOrder {
+		ordering[typ] = i
+	}
+	rank := func(typ model.ResourceType) int {
+		if i, ok := ordering[typ]; ok {
+			return i
+		}
+		return len(ApplyOrder)
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rank(resources[i].GetType()) < rank(resources[j].GetType())
+	})
+}
@@ -0,0 +1,197 @@
+package bundle_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_bundle "github.com/Kong/kuma/app/kumactl/pkg/bundle"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/pkg/catalog"
+	catalog_client "github.com/Kong/kuma/pkg/catalog/client"
+	kumactl_config "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/bundle"
+	test_catalog "github.com/Kong/kuma/pkg/test/catalog"
+)
+
+var _ kumactl_bundle.BundleClient = &staticBundleClient{}
+
+type staticBundleClient struct {
+	exportMesh       string
+	exportPassphrase string
+	exportDataplanes bool
+
+	importBundle     []byte
+	importPassphrase string
+	importOnConflict string
+}
+
+func (s *staticBundleClient) Export(mesh string, passphrase string, dataplanes bool) ([]byte, error) {
+	s.exportMesh = mesh
+	s.exportPassphrase = passphrase
+	s.exportDataplanes = dataplanes
+	return []byte(`{"mesh": "demo"}`), nil
+}
+
+func (s *staticBundleClient) Import(meshBundle []byte, passphrase string, onConflict string) (bundle.ImportSummary, error) {
+	s.importBundle = meshBundle
+	s.importPassphrase = passphrase
+	s.importOnConflict = onConflict
+	return bundle.ImportSummary{
+		Created: []bundle.ResourceRef{{Type: "Mesh", Name: "demo"}},
+		Updated: []bundle.ResourceRef{{Type: "Secret", Name: "builtinca.demo"}},
+		Skipped: []bundle.ResourceRef{{Type: "TrafficLog", Name: "logs"}},
+	}, nil
+}
+
+var _ = Describe("kumactl export and import", func() {
+
+	var rootCmd *cobra.Command
+	var buf *bytes.Buffer
+	var client *staticBundleClient
+
+	BeforeEach(func() {
+		client = &staticBundleClient{}
+		rootCtx := &kumactl_cmd.RootContext{
+			Runtime: kumactl_cmd.RootRuntime{
+				NewBundleClient: func(_ string, _ *kumactl_config.Context_AdminApiCredentials) (kumactl_bundle.BundleClient, error) {
+					return client, nil
+				},
+				NewCatalogClient: func(s string) (catalog_client.CatalogClient, error) {
+					return &test_catalog.StaticCatalogClient{
+						Resp: catalog.Catalog{
+							Apis: catalog.Apis{
+								Admin: catalog.AdminApi{
+									LocalUrl: "http://localhost:1234",
+								},
+							},
+						},
+					}, nil
+				},
+			},
+		}
+
+		rootCmd = cmd.NewRootCmd(rootCtx)
+		buf = &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+	})
+
+	Describe("kumactl export mesh", func() {
+		It("should write the bundle to standard output", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"export", "mesh", "demo",
+				"--passphrase", "s3cret",
+				"--include-dataplanes",
+			})
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.exportMesh).To(Equal("demo"))
+			Expect(client.exportPassphrase).To(Equal("s3cret"))
+			Expect(client.exportDataplanes).To(BeTrue())
+			Expect(buf.String()).To(Equal(`{"mesh": "demo"}`))
+		})
+
+		It("should write the bundle to a file", func() {
+			// given
+			dir, err := ioutil.TempDir("", "kumactl-export")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "bundle.json")
+
+			// and
+			rootCmd.SetArgs([]string{
+				"export", "mesh", "demo",
+				"--passphrase", "s3cret",
+				"--output-file", file,
+			})
+
+			// when
+			err = rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.exportDataplanes).To(BeFalse())
+			Expect(buf.String()).To(BeEmpty())
+			content, err := ioutil.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal(`{"mesh": "demo"}`))
+		})
+
+		It("should require a passphrase", func() {
+			// given
+			rootCmd.SetArgs([]string{"export", "mesh", "demo"})
+			rootCmd.SetErr(&bytes.Buffer{})
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(`required flag(s) "passphrase" not set`))
+		})
+	})
+
+	Describe("kumactl import", func() {
+		It("should import the bundle", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"import",
+				"--file", filepath.Join("testdata", "bundle.json"),
+				"--passphrase", "s3cret",
+				"--on-conflict", "overwrite",
+			})
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", "bundle.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.importBundle).To(Equal(expected))
+			Expect(client.importPassphrase).To(Equal("s3cret"))
+			Expect(client.importOnConflict).To(Equal("overwrite"))
+			Expect(buf.String()).To(Equal(`created Mesh "demo"
+updated Secret "builtinca.demo"
+skipped TrafficLog "logs"
+`))
+		})
+
+		It("should read the bundle from standard input", func() {
+			// given
+			rootCmd.SetArgs([]string{"import", "--file", "-"})
+			rootCmd.SetIn(bytes.NewBufferString(`{"mesh": "demo"}`))
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(client.importBundle)).To(Equal(`{"mesh": "demo"}`))
+			Expect(client.importOnConflict).To(Equal("fail"))
+		})
+
+		It("should reject unknown conflict policy", func() {
+			// given
+			rootCmd.SetArgs([]string{"import", "--file", "-", "--on-conflict", "merge"})
+			rootCmd.SetErr(&bytes.Buffer{})
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(`unsupported conflict policy "merge". Available policies: [skip overwrite fail]`))
+		})
+	})
+})
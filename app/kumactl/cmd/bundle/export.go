@@ -0,0 +1,68 @@
+package bundle
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+)
+
+func NewExportCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export Kuma resources",
+		Long:  `Export Kuma resources.`,
+	}
+	// sub-commands
+	cmd.AddCommand(newExportMeshCmd(pctx))
+	return cmd
+}
+
+type exportMeshContext struct {
+	*kumactl_cmd.RootContext
+
+	args struct {
+		passphrase        string
+		includeDataplanes bool
+		outputFile        string
+	}
+}
+
+func newExportMeshCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	ctx := &exportMeshContext{RootContext: pctx}
+	cmd := &cobra.Command{
+		Use:   "mesh NAME",
+		Short: "Export a Mesh with all its resources into a portable bundle",
+		Long: `Export a Mesh with all its resources into a portable bundle.
+
+The bundle contains the Mesh, all its policies and, optionally, its Dataplanes.
+Secrets of the Mesh, e.g. its Certificate Authority, are encrypted with the given passphrase.
+Use "kumactl import" to re-create the bundle on another Control Plane.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := ctx.CurrentBundleClient()
+			if err != nil {
+				return err
+			}
+			meshBundle, err := client.Export(args[0], ctx.args.passphrase, ctx.args.includeDataplanes)
+			if err != nil {
+				return errors.Wrap(err, "could not export the mesh")
+			}
+			if ctx.args.outputFile == "" {
+				_, err := cmd.OutOrStdout().Write(meshBundle)
+				return err
+			}
+			if err := ioutil.WriteFile(ctx.args.outputFile, meshBundle, 0600); err != nil {
+				return errors.Wrap(err, "could not write the bundle")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&ctx.args.passphrase, "passphrase", "", "passphrase used to encrypt secrets of the mesh")
+	_ = cmd.MarkFlagRequired("passphrase")
+	cmd.Flags().BoolVar(&ctx.args.includeDataplanes, "include-dataplanes", false, "include Dataplanes of the mesh in the bundle")
+	cmd.Flags().StringVar(&ctx.args.outputFile, "output-file", "", "path to a file to write the bundle to. Standard output is used by default")
+	return cmd
+}
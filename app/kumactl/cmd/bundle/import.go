@@ -0,0 +1,72 @@
+package bundle
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/pkg/core/bundle"
+)
+
+type importContext struct {
+	*kumactl_cmd.RootContext
+
+	args struct {
+		file       string
+		passphrase string
+		onConflict string
+	}
+}
+
+func NewImportCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	ctx := &importContext{RootContext: pctx}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a bundle created by kumactl export",
+		Long: `Import a bundle created by kumactl export.
+
+Resources of the bundle are re-created on the Control Plane. Resources that already exist
+are skipped, overwritten or abort the whole import depending on --on-conflict.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := bundle.ParseConflictPolicy(ctx.args.onConflict); err != nil {
+				return err
+			}
+			var meshBundle []byte
+			var err error
+			if ctx.args.file == "-" {
+				meshBundle, err = ioutil.ReadAll(cmd.InOrStdin())
+			} else {
+				meshBundle, err = ioutil.ReadFile(ctx.args.file)
+			}
+			if err != nil {
+				return errors.Wrap(err, "could not read the bundle")
+			}
+
+			client, err := ctx.CurrentBundleClient()
+			if err != nil {
+				return err
+			}
+			summary, err := client.Import(meshBundle, ctx.args.passphrase, ctx.args.onConflict)
+			if err != nil {
+				return errors.Wrap(err, "could not import the bundle")
+			}
+			printRefs(cmd, "created", summary.Created)
+			printRefs(cmd, "updated", summary.Updated)
+			printRefs(cmd, "skipped", summary.Skipped)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&ctx.args.file, "file", "f", "", "path to a file with the bundle. Use - to read it from standard input")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().StringVar(&ctx.args.passphrase, "passphrase", "", "passphrase used to decrypt secrets of the bundle")
+	cmd.Flags().StringVar(&ctx.args.onConflict, "on-conflict", string(bundle.ConflictFail), "what to do with resources that already exist: one of skip|overwrite|fail")
+	return cmd
+}
+
+func printRefs(cmd *cobra.Command, action string, refs []bundle.ResourceRef) {
+	for _, ref := range refs {
+		cmd.Printf("%s %s %q\n", action, ref.Type, ref.Name)
+	}
+}
@@ -0,0 +1,134 @@
+package inspect
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kumactl/pkg/output"
+	"github.com/Kong/kuma/app/kumactl/pkg/output/printers"
+	"github.com/Kong/kuma/app/kumactl/pkg/output/table"
+	inspect_types "github.com/Kong/kuma/pkg/xds/inspect/types"
+)
+
+type inspectDataplaneContext struct {
+	*inspectContext
+
+	args struct {
+		configDump bool
+		xds        bool
+		policies   bool
+	}
+}
+
+func newInspectDataplaneCmd(pctx *inspectContext) *cobra.Command {
+	ctx := inspectDataplaneContext{
+		inspectContext: pctx,
+	}
+	cmd := &cobra.Command{
+		Use:   "dataplane NAME",
+		Short: "Inspect Dataplane",
+		Long:  `Inspect a single Dataplane.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selected := 0
+			for _, flag := range []bool{ctx.args.configDump, ctx.args.xds, ctx.args.policies} {
+				if flag {
+					selected++
+				}
+			}
+			if selected > 1 {
+				return errors.New("specify only one thing to inspect, either --config-dump, --xds or --policies")
+			}
+			if selected == 0 {
+				return errors.New("specify what to inspect, e.g. --config-dump, --xds or --policies")
+			}
+			client, err := pctx.CurrentEnvoyAdminClient()
+			if err != nil {
+				return errors.Wrap(err, "failed to create an envoy admin client")
+			}
+			if ctx.args.policies {
+				policies, err := client.Policies(pctx.CurrentMesh(), args[0])
+				if err != nil {
+					return errors.Wrap(err, "failed to get policies of the dataplane")
+				}
+				switch format := output.Format(pctx.args.outputFormat); format {
+				case output.TableFormat:
+					return printDataplanePolicies(policies, cmd.OutOrStdout())
+				default:
+					printer, err := printers.NewGenericPrinter(format)
+					if err != nil {
+						return err
+					}
+					return printer.Print(policies, cmd.OutOrStdout())
+				}
+			}
+			if ctx.args.xds {
+				generatedXds, err := client.GeneratedXds(pctx.CurrentMesh(), args[0])
+				if err != nil {
+					return errors.Wrap(err, "failed to get xDS resources of the dataplane")
+				}
+				content, err := yaml.JSONToYAML(generatedXds)
+				if err != nil {
+					return errors.Wrap(err, "failed to convert xDS resources of the dataplane into YAML")
+				}
+				_, err = cmd.OutOrStdout().Write(content)
+				return err
+			}
+			configDump, err := client.Inspect(pctx.CurrentMesh(), args[0], "config_dump")
+			if err != nil {
+				return errors.Wrap(err, "failed to get config dump of the dataplane")
+			}
+			_, err = cmd.OutOrStdout().Write(configDump)
+			return err
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&ctx.args.configDump, "config-dump", false, "print config dump of Envoy fetched from the Dataplane through Admin Server of Control Plane")
+	cmd.PersistentFlags().BoolVar(&ctx.args.xds, "xds", false, "print Listeners, Clusters and ClusterLoadAssignments that Control Plane generates for the Dataplane along with policies that match it")
+	cmd.PersistentFlags().BoolVar(&ctx.args.policies, "policies", false, "print policies that match each inbound and outbound of the Dataplane along with their ranks and the ones that have been selected")
+	return cmd
+}
+
+func printDataplanePolicies(policies *inspect_types.DataplanePolicies, out io.Writer) error {
+	type row struct {
+		connection string
+		candidate  inspect_types.PolicyCandidate
+	}
+	var rows []row
+	for _, inbound := range policies.Inbound {
+		for _, candidate := range inbound.Candidates {
+			rows = append(rows, row{connection: fmt.Sprintf("inbound %s", inbound.Interface), candidate: candidate})
+		}
+	}
+	for _, outbound := range policies.Outbound {
+		for _, candidate := range outbound.Candidates {
+			rows = append(rows, row{connection: fmt.Sprintf("outbound %s", outbound.Service), candidate: candidate})
+		}
+	}
+	data := printers.Table{
+		Headers: []string{"CONNECTION", "TYPE", "NAME", "EXACT MATCHES", "WILDCARD MATCHES", "SELECTED"},
+		NextRow: func() func() []string {
+			i := 0
+			return func() []string {
+				defer func() { i++ }()
+				if len(rows) <= i {
+					return nil
+				}
+				candidate := rows[i].candidate
+
+				return []string{
+					rows[i].connection, // CONNECTION
+					candidate.Type,     // TYPE
+					candidate.Name,     // NAME
+					table.Number(candidate.Rank.ExactMatches),    // EXACT MATCHES
+					table.Number(candidate.Rank.WildcardMatches), // WILDCARD MATCHES
+					table.Check(candidate.Selected),              // SELECTED
+				}
+			}
+		}(),
+	}
+	return printers.NewTablePrinter().Print(data, out)
+}
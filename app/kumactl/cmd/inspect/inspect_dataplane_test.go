@@ -0,0 +1,248 @@
+package inspect_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/app/kumactl/pkg/envoyadmin"
+	"github.com/Kong/kuma/pkg/catalog"
+	catalog_client "github.com/Kong/kuma/pkg/catalog/client"
+	config_kumactl "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	test_catalog "github.com/Kong/kuma/pkg/test/catalog"
+	inspect_types "github.com/Kong/kuma/pkg/xds/inspect/types"
+)
+
+type testEnvoyAdminClient struct {
+	mesh     string
+	name     string
+	endpoint string
+	err      error
+}
+
+var _ envoyadmin.EnvoyAdminClient = &testEnvoyAdminClient{}
+
+func (c *testEnvoyAdminClient) Inspect(mesh string, name string, endpoint string) ([]byte, error) {
+	c.mesh, c.name, c.endpoint = mesh, name, endpoint
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []byte(`{"configs":[]}`), nil
+}
+
+func (c *testEnvoyAdminClient) GeneratedXds(mesh string, name string) ([]byte, error) {
+	c.mesh, c.name = mesh, name
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []byte(`{"policies":[{"type":"TrafficRoute","name":"route-all","service":"web"}],"listeners":[],"clusters":[{"name":"web","type":"EDS"}],"clusterLoadAssignments":[]}`), nil
+}
+
+func (c *testEnvoyAdminClient) Policies(mesh string, name string) (*inspect_types.DataplanePolicies, error) {
+	c.mesh, c.name = mesh, name
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &inspect_types.DataplanePolicies{
+		Inbound: []inspect_types.InboundPolicies{
+			{
+				Interface: "192.168.0.2:80:8080",
+				Candidates: []inspect_types.PolicyCandidate{
+					{
+						Type:     "TrafficPermission",
+						Name:     "allow-all",
+						Rank:     inspect_types.Rank{WildcardMatches: 1},
+						Selected: true,
+					},
+				},
+			},
+		},
+		Outbound: []inspect_types.OutboundPolicies{
+			{
+				Service: "web",
+				Candidates: []inspect_types.PolicyCandidate{
+					{
+						Type: "TrafficRoute",
+						Name: "route-all",
+						Rank: inspect_types.Rank{WildcardMatches: 2},
+					},
+					{
+						Type:     "TrafficRoute",
+						Name:     "backend-to-web",
+						Rank:     inspect_types.Rank{ExactMatches: 2},
+						Selected: true,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+var _ = Describe("kumactl inspect dataplane", func() {
+
+	var rootCmd *cobra.Command
+	var buf *bytes.Buffer
+	var client *testEnvoyAdminClient
+
+	BeforeEach(func() {
+		client = &testEnvoyAdminClient{}
+		rootCtx := &kumactl_cmd.RootContext{
+			Runtime: kumactl_cmd.RootRuntime{
+				NewEnvoyAdminClient: func(string, *config_kumactl.Context_AdminApiCredentials) (envoyadmin.EnvoyAdminClient, error) {
+					return client, nil
+				},
+				NewCatalogClient: func(string) (catalog_client.CatalogClient, error) {
+					return &test_catalog.StaticCatalogClient{
+						Resp: catalog.Catalog{
+							Apis: catalog.Apis{
+								Admin: catalog.AdminApi{
+									LocalUrl: "http://localhost:1234",
+								},
+							},
+						},
+					}, nil
+				},
+			},
+		}
+
+		rootCmd = cmd.NewRootCmd(rootCtx)
+		buf = &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+	})
+
+	It("should print config dump of a dataplane", func() {
+		// when
+		rootCmd.SetArgs([]string{"inspect", "dataplane", "backend-01", "--config-dump", "--mesh=demo"})
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(MatchJSON(`{"configs":[]}`))
+		// and
+		Expect(client.mesh).To(Equal("demo"))
+		Expect(client.name).To(Equal("backend-01"))
+		Expect(client.endpoint).To(Equal("config_dump"))
+	})
+
+	It("should write error when fetching config dump fails", func() {
+		// setup
+		client.err = errors.New("could not connect to API")
+
+		// when
+		rootCmd.SetArgs([]string{"inspect", "dataplane", "backend-01", "--config-dump"})
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(buf.String()).To(Equal("Error: failed to get config dump of the dataplane: could not connect to API\n"))
+		// and
+		Expect(client.mesh).To(Equal("default"))
+	})
+
+	It("should print xDS resources generated for a dataplane as YAML", func() {
+		// when
+		rootCmd.SetArgs([]string{"inspect", "dataplane", "backend-01", "--xds", "--mesh=demo"})
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(MatchYAML(`
+        policies:
+        - type: TrafficRoute
+          name: route-all
+          service: web
+        listeners: []
+        clusters:
+        - name: web
+          type: EDS
+        clusterLoadAssignments: []
+`))
+		// and
+		Expect(client.mesh).To(Equal("demo"))
+		Expect(client.name).To(Equal("backend-01"))
+	})
+
+	It("should write error when generating xDS resources fails", func() {
+		// setup
+		client.err = errors.New("could not connect to API")
+
+		// when
+		rootCmd.SetArgs([]string{"inspect", "dataplane", "backend-01", "--xds"})
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(buf.String()).To(Equal("Error: failed to get xDS resources of the dataplane: could not connect to API\n"))
+	})
+
+	type testCase struct {
+		outputFormat string
+		goldenFile   string
+	}
+
+	DescribeTable("should print policies that match a dataplane",
+		func(given testCase) {
+			// when
+			rootCmd.SetArgs(append([]string{"inspect", "dataplane", "backend-01", "--policies", "--mesh=demo"}, given.outputFormat))
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", given.goldenFile))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(string(expected)))
+			// and
+			Expect(client.mesh).To(Equal("demo"))
+			Expect(client.name).To(Equal("backend-01"))
+		},
+		Entry("should support Table output", testCase{
+			outputFormat: "-otable",
+			goldenFile:   "inspect-dataplane-policies.golden.txt",
+		}),
+		Entry("should support YAML output", testCase{
+			outputFormat: "-oyaml",
+			goldenFile:   "inspect-dataplane-policies.golden.yaml",
+		}),
+	)
+
+	It("should write error when fetching policies fails", func() {
+		// setup
+		client.err = errors.New("could not connect to API")
+
+		// when
+		rootCmd.SetArgs([]string{"inspect", "dataplane", "backend-01", "--policies"})
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(buf.String()).To(Equal("Error: failed to get policies of the dataplane: could not connect to API\n"))
+	})
+
+	It("should require to specify what to inspect", func() {
+		// when
+		rootCmd.SetArgs([]string{"inspect", "dataplane", "backend-01"})
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(MatchError("specify what to inspect, e.g. --config-dump, --xds or --policies"))
+	})
+
+	It("should not allow to inspect multiple things at once", func() {
+		// when
+		rootCmd.SetArgs([]string{"inspect", "dataplane", "backend-01", "--xds", "--policies"})
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(MatchError("specify only one thing to inspect, either --config-dump, --xds or --policies"))
+	})
+})
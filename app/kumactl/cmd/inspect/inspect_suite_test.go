@@ -0,0 +1,13 @@
+package inspect_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestInspectCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Inspect Cmd Suite")
+}
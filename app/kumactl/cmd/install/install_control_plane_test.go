@@ -7,6 +7,10 @@ import (
 
 	"github.com/Kong/kuma/app/kumactl/cmd"
 	"github.com/Kong/kuma/app/kumactl/cmd/install"
+	"github.com/ghodss/yaml"
+	kube_admission "k8s.io/api/admissionregistration/v1beta1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_labels "k8s.io/apimachinery/pkg/labels"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -128,4 +132,51 @@ var _ = Describe("kumactl install control-plane", func() {
 			goldenFile: "install-control-plane.overrides.golden.yaml",
 		}),
 	)
+
+	Describe("kuma-injector webhook", func() {
+
+		var webhook kube_admission.Webhook
+
+		BeforeEach(func() {
+			// given
+			rootCmd := cmd.DefaultRootCmd()
+			rootCmd.SetArgs([]string{"install", "control-plane"})
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+
+			// when
+			err := rootCmd.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			var config *kube_admission.MutatingWebhookConfiguration
+			for _, manifest := range data.SplitYAML(stdout.Bytes()) {
+				meta := kube_meta.TypeMeta{}
+				Expect(yaml.Unmarshal(manifest, &meta)).To(Succeed())
+				if meta.Kind == "MutatingWebhookConfiguration" {
+					config = &kube_admission.MutatingWebhookConfiguration{}
+					Expect(yaml.Unmarshal(manifest, config)).To(Succeed())
+				}
+			}
+			// then
+			Expect(config).ToNot(BeNil())
+			Expect(config.Webhooks).To(HaveLen(1))
+			webhook = config.Webhooks[0]
+		})
+
+		DescribeTable("should select Namespaces according to `kuma.io/sidecar-injection` label",
+			func(nsLabels map[string]string, expected bool) {
+				// when
+				selector, err := kube_meta.LabelSelectorAsSelector(webhook.NamespaceSelector)
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				// and
+				Expect(selector.Matches(kube_labels.Set(nsLabels))).To(Equal(expected))
+			},
+			Entry("Namespace without a label, so that Pods can opt in", nil, true),
+			Entry("Namespace with injection enabled", map[string]string{"kuma.io/sidecar-injection": "enabled"}, true),
+			Entry("Namespace with injection disabled", map[string]string{"kuma.io/sidecar-injection": "disabled"}, false),
+		)
+	})
 })
@@ -1,9 +1,8 @@
 package install
 
 import (
-	"github.com/Kong/kuma/app/kumactl/pkg/install/data"
-	postgres_schema "github.com/Kong/kuma/app/kumactl/pkg/install/universal/control-plane/postgres"
 	kuma_cmd "github.com/Kong/kuma/pkg/cmd"
+	"github.com/Kong/kuma/pkg/plugins/resources/postgres/migrations"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -24,11 +23,11 @@ rm $sql_file`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			switch args.target {
 			case "postgres":
-				file, err := data.ReadFile(postgres_schema.Schema, "resource.sql")
+				all, err := migrations.All()
 				if err != nil {
-					return errors.Wrap(err, "could not read schema file")
+					return errors.Wrap(err, "could not read schema migrations")
 				}
-				_, err = cmd.OutOrStdout().Write(file)
+				_, err = cmd.OutOrStdout().Write([]byte(migrations.Script(all)))
 				return err
 			default:
 				return errors.Errorf("unknown target type: %s", args.target)
@@ -1,3 +1,4 @@
+-- 0001_create_resources
 CREATE TABLE IF NOT EXISTS resources (
     name        varchar(100) NOT NULL,
     namespace   varchar(100) NOT NULL,
@@ -6,4 +7,11 @@ CREATE TABLE IF NOT EXISTS resources (
     version     integer NOT NULL,
     spec        text,
     PRIMARY KEY (name, namespace, mesh, type)
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS schema_version (
+    version     integer NOT NULL,
+    applied_at  timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (version)
+);
+INSERT INTO schema_version (version) VALUES (1) ON CONFLICT DO NOTHING;
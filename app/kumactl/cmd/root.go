@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/Kong/kuma/app/kumactl/cmd/apply"
+	"github.com/Kong/kuma/app/kumactl/cmd/bundle"
 	"github.com/Kong/kuma/app/kumactl/cmd/config"
 	"github.com/Kong/kuma/app/kumactl/cmd/delete"
 	"github.com/Kong/kuma/app/kumactl/cmd/generate"
@@ -62,6 +63,9 @@ func NewRootCmd(root *kumactl_cmd.RootContext) *cobra.Command {
 	cmd.AddCommand(delete.NewDeleteCmd(root))
 	cmd.AddCommand(inspect.NewInspectCmd(root))
 	cmd.AddCommand(apply.NewApplyCmd(root))
+	cmd.AddCommand(apply.NewDiffCmd(root))
+	cmd.AddCommand(bundle.NewExportCmd(root))
+	cmd.AddCommand(bundle.NewImportCmd(root))
 	cmd.AddCommand(version.NewVersionCmd())
 	cmd.AddCommand(generate.NewGenerateCmd(root))
 	cmd.AddCommand(manage.NewManageCmd(root))
@@ -0,0 +1,124 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kumactl_config "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/bundle"
+	"github.com/Kong/kuma/pkg/core/bundle/rest/types"
+	error_types "github.com/Kong/kuma/pkg/core/rest/errors/types"
+	util_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+const (
+	// export and import of a big mesh takes longer than other requests to the Admin Server
+	timeout = 60 * time.Second
+)
+
+type BundleClient interface {
+	Export(mesh string, passphrase string, dataplanes bool) ([]byte, error)
+	Import(meshBundle []byte, passphrase string, onConflict string) (bundle.ImportSummary, error)
+}
+
+type httpBundleClient struct {
+	client util_http.Client
+}
+
+func NewBundleClient(address string, config *kumactl_config.Context_AdminApiCredentials) (BundleClient, error) {
+	baseURL, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the server URL")
+	}
+	httpClient := &http.Client{
+		Timeout: timeout,
+	}
+	if baseURL.Scheme == "https" {
+		if !config.HasClientCert() {
+			return nil, errors.New("certificates has to be configured to use https destination")
+		}
+		// There is no way to configure a CA of the Admin Server yet, secrets in a bundle are protected by a passphrase instead.
+		if err := util_http.ConfigureTlsWithoutServerVerification(httpClient, config.ClientCert, config.ClientKey); err != nil {
+			return nil, errors.Wrap(err, "could not configure tls for bundle client")
+		}
+	}
+	client := util_http.ClientWithBaseURL(httpClient, baseURL)
+	return &httpBundleClient{
+		client: client,
+	}, nil
+}
+
+var _ BundleClient = &httpBundleClient{}
+
+func (h *httpBundleClient) Export(mesh string, passphrase string, dataplanes bool) ([]byte, error) {
+	exportReq := types.ExportRequest{
+		Passphrase: passphrase,
+		Dataplanes: dataplanes,
+	}
+	reqBytes, err := json.Marshal(exportReq)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("/meshes/%s/export", mesh), bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, err
+	}
+	return h.doRequest(req)
+}
+
+func (h *httpBundleClient) Import(meshBundle []byte, passphrase string, onConflict string) (bundle.ImportSummary, error) {
+	importReq := types.ImportRequest{
+		Passphrase: passphrase,
+	}
+	if err := json.Unmarshal(meshBundle, &importReq.Bundle); err != nil {
+		return bundle.ImportSummary{}, errors.Wrap(err, "could not parse the bundle")
+	}
+	reqBytes, err := json.Marshal(importReq)
+	if err != nil {
+		return bundle.ImportSummary{}, err
+	}
+	req, err := http.NewRequest("POST", "/import?onConflict="+url.QueryEscape(onConflict), bytes.NewReader(reqBytes))
+	if err != nil {
+		return bundle.ImportSummary{}, err
+	}
+	respBytes, err := h.doRequest(req)
+	if err != nil {
+		return bundle.ImportSummary{}, err
+	}
+	summary := bundle.ImportSummary{}
+	if err := json.Unmarshal(respBytes, &summary); err != nil {
+		return bundle.ImportSummary{}, err
+	}
+	return summary, nil
+}
+
+func (h *httpBundleClient) doRequest(req *http.Request) ([]byte, error) {
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 >= 4 {
+		kumaErr := error_types.Error{}
+		if err := json.Unmarshal(b, &kumaErr); err == nil {
+			if kumaErr.Title != "" && kumaErr.Details != "" {
+				return nil, &kumaErr
+			}
+		}
+		return nil, errors.Errorf("(%d): %s", resp.StatusCode, string(b))
+	}
+	return b, nil
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/Kong/kuma/app/kumactl/pkg/bundle"
 	"github.com/Kong/kuma/app/kumactl/pkg/ca"
+	"github.com/Kong/kuma/app/kumactl/pkg/envoyadmin"
 	"net"
 	"net/url"
 	"time"
@@ -34,6 +36,8 @@ type RootRuntime struct {
 	NewDataplaneTokenClient    func(string, *kumactl_config.Context_AdminApiCredentials) (tokens.DataplaneTokenClient, error)
 	NewCatalogClient           func(string) (catalog_client.CatalogClient, error)
 	NewProvidedCaClient        func(string, *kumactl_config.Context_AdminApiCredentials) (ca.ProvidedCaClient, error)
+	NewBundleClient            func(string, *kumactl_config.Context_AdminApiCredentials) (bundle.BundleClient, error)
+	NewEnvoyAdminClient        func(string, *kumactl_config.Context_AdminApiCredentials) (envoyadmin.EnvoyAdminClient, error)
 }
 
 type RootContext struct {
@@ -50,6 +54,8 @@ func DefaultRootContext() *RootContext {
 			NewDataplaneTokenClient:    tokens.NewDataplaneTokenClient,
 			NewCatalogClient:           catalog_client.NewCatalogClient,
 			NewProvidedCaClient:        ca.NewProvidedCaClient,
+			NewBundleClient:            bundle.NewBundleClient,
+			NewEnvoyAdminClient:        envoyadmin.NewEnvoyAdminClient,
 		},
 	}
 }
@@ -238,3 +244,29 @@ func (rc *RootContext) CurrentProvidedCaClient() (ca.ProvidedCaClient, error) {
 	}
 	return rc.Runtime.NewProvidedCaClient(adminServerUrl, ctx.GetCredentials().GetAdminApi())
 }
+
+func (rc *RootContext) CurrentBundleClient() (bundle.BundleClient, error) {
+	ctx, err := rc.CurrentContext()
+	if err != nil {
+		return nil, err
+	}
+
+	adminServerUrl, err := rc.adminServerUrl()
+	if err != nil {
+		return nil, err
+	}
+	return rc.Runtime.NewBundleClient(adminServerUrl, ctx.GetCredentials().GetAdminApi())
+}
+
+func (rc *RootContext) CurrentEnvoyAdminClient() (envoyadmin.EnvoyAdminClient, error) {
+	ctx, err := rc.CurrentContext()
+	if err != nil {
+		return nil, err
+	}
+
+	adminServerUrl, err := rc.adminServerUrl()
+	if err != nil {
+		return nil, err
+	}
+	return rc.Runtime.NewEnvoyAdminClient(adminServerUrl, ctx.GetCredentials().GetAdminApi())
+}
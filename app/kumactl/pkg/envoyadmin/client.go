@@ -0,0 +1,107 @@
+package envoyadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kumactl_config "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	error_types "github.com/Kong/kuma/pkg/core/rest/errors/types"
+	util_http "github.com/Kong/kuma/pkg/util/http"
+	inspect_types "github.com/Kong/kuma/pkg/xds/inspect/types"
+)
+
+const (
+	// Control Plane waits up to 10s for a response of a Dataplane
+	timeout = 15 * time.Second
+)
+
+// EnvoyAdminClient inspects Envoy configuration of Dataplanes through the Admin Server.
+type EnvoyAdminClient interface {
+	// Inspect returns a response of a given endpoint of Envoy Admin API, e.g. `config_dump`.
+	Inspect(mesh string, name string, endpoint string) ([]byte, error)
+	// GeneratedXds returns xDS resources that Control Plane generates for a Dataplane, in JSON format.
+	GeneratedXds(mesh string, name string) ([]byte, error)
+	// Policies returns candidate policies of each connection of a Dataplane along with the ones that have been selected.
+	Policies(mesh string, name string) (*inspect_types.DataplanePolicies, error)
+}
+
+type httpEnvoyAdminClient struct {
+	client util_http.Client
+}
+
+func NewEnvoyAdminClient(address string, config *kumactl_config.Context_AdminApiCredentials) (EnvoyAdminClient, error) {
+	baseURL, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the server URL")
+	}
+	httpClient := &http.Client{
+		Timeout: timeout,
+	}
+	if baseURL.Scheme == "https" {
+		if !config.HasClientCert() {
+			return nil, errors.New("certificates has to be configured to use https destination")
+		}
+		// Since we're not going to pass any secrets to the server, we can skip validating its identity.
+		if err := util_http.ConfigureTlsWithoutServerVerification(httpClient, config.ClientCert, config.ClientKey); err != nil {
+			return nil, errors.Wrap(err, "could not configure tls for envoy admin client")
+		}
+	}
+	client := util_http.ClientWithBaseURL(httpClient, baseURL)
+	return &httpEnvoyAdminClient{
+		client: client,
+	}, nil
+}
+
+var _ EnvoyAdminClient = &httpEnvoyAdminClient{}
+
+func (h *httpEnvoyAdminClient) Inspect(mesh string, name string, endpoint string) ([]byte, error) {
+	return h.get(fmt.Sprintf("/meshes/%s/dataplanes/%s/xds?endpoint=%s", mesh, name, url.QueryEscape(endpoint)))
+}
+
+func (h *httpEnvoyAdminClient) GeneratedXds(mesh string, name string) ([]byte, error) {
+	return h.get(fmt.Sprintf("/meshes/%s/dataplanes/%s/generated-xds", mesh, name))
+}
+
+func (h *httpEnvoyAdminClient) Policies(mesh string, name string) (*inspect_types.DataplanePolicies, error) {
+	b, err := h.get(fmt.Sprintf("/meshes/%s/dataplanes/%s/policies", mesh, name))
+	if err != nil {
+		return nil, err
+	}
+	policies := &inspect_types.DataplanePolicies{}
+	if err := json.Unmarshal(b, policies); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal policies of the dataplane")
+	}
+	return policies, nil
+}
+
+func (h *httpEnvoyAdminClient) get(path string) ([]byte, error) {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 >= 4 {
+		kumaErr := error_types.Error{}
+		if err := json.Unmarshal(b, &kumaErr); err == nil {
+			if kumaErr.Title != "" && kumaErr.Details != "" {
+				return nil, &kumaErr
+			}
+		}
+		return nil, errors.Errorf("(%d): %s", resp.StatusCode, string(b))
+	}
+	return b, nil
+}
@@ -21,112 +21,112 @@ var Templates = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2019, 9, 9, 21, 11, 18, 646386350, time.UTC),
+			modTime: time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 		},
 		"/control-plane": &vfsgen۰DirInfo{
 			name:    "control-plane",
-			modTime: time.Date(2019, 9, 10, 9, 51, 52, 661530733, time.UTC),
+			modTime: time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 		},
 		"/control-plane/crds": &vfsgen۰DirInfo{
 			name:    "crds",
-			modTime: time.Date(2019, 12, 16, 8, 31, 53, 655564709, time.UTC),
+			modTime: time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 		},
 		"/control-plane/crds/kuma.io_dataplaneinsights.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_dataplaneinsights.yaml",
-			modTime:          time.Date(2019, 9, 10, 9, 59, 52, 149171819, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23763,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\x59\x73\x1b\x39\x92\x7e\xd7\xaf\xc8\xd0\x3c\xc8\x8e\x20\x29\xbb\x7b\x66\x63\x47\x6f\x5a\xd9\x9e\xd5\xb6\xaf\xb0\xe4\xde\xd8\x18\x4f\x6c\x80\x55\x49\x12\xa3\x22\x50\x0d\xa0\x24\xb1\x7f\xfd\x46\x66\x02\x75\xb0\x0e\x52\xb6\xa6\x63\xeb\x8d\x47\x65\x01\x79\x7e\x79\xa0\x4e\xe6\xf3\xf9\x89\x2a\xf5\xaf\xe8\xbc\xb6\xe6\x02\x54\xa9\xf1\x31\xa0\xa1\x4f\x7e\x71\xf7\xef\x7e\xa1\xed\xf9\xfd\xeb\x25\x06\xf5\xfa\xe4\x4e\x9b\xfc\x02\xae\x2a\x1f\xec\xf6\x0b\x7a\x5b\xb9\x0c\xdf\xe0\x4a\x1b\x1d\xb4\x35\x27\x5b\x0c\x2a\x57\x41\x5d\x9c\x00\x64\x0e\x15\x7d\x79\xab\xb7\xe8\x83\xda\x96\x17\x60\xaa\xa2\x38\x01\x30\x6a\x8b\x17\x40\xff\x2b\x0b\x65\x50\x1b\xaf\xd7\x9b\xe0\x17\x77\xd5\x56\x2d\xb4\x3d\xf1\x25\x66\x44\x61\xed\x6c\x55\x5e\x40\xfa\x5a\x6e\xf4\xf4\x0b\x80\x2c\xe4\x4d\xa2\x71\x2d\x34\xf8\xa7\xb2\xa8\x9c\x2a\x06\x1e\x70\x02\xe0\x33\x5b\xe2\x05\x9c\x9e\x9e\x00\xdc\xab\x42\xe7\xbc\x44\x21\x69\x4b\x34\x97\x9f\xaf\x7f\xfd\xf9\x26\xdb\xe0\x56\xc9\x97\x00\x39\xfa\xcc\xe9\x92\xff\xd7\x7b\x20\x68\x0f\x61\x83\x20\xb7\xc0\xca\x3a\xfe\x58\x3f\x1a\xd2\xb3\xe1\xf2\xf3\x75\x24\x58\x3a\x5b\xa2\x0b\x3a\x6d\x85\xae\x96\x00\xea\xef\xf6\x1e\x7d\x46\x6b\x93\xff\x40\x4e\x2c\x47\x79\xf4\xbd\x7c\x87\x39\x78\x59\x84\x5d\x41\xd8\x68\x0f\x0e\x4b\x87\x1e\x4d\xe0\x3d\xb6\xc8\x02\xfd\x45\x19\xb0\xcb\x7f\x62\x16\x16\x70\x83\x8e\x88\x80\xdf\xd8\xaa\xc8\x21\xb3\xe6\x1e\x5d\x00\x87\x99\x5d\x1b\xfd\x7b\x4d\xd9\x43\xb0\xfc\xc8\x42\x05\xf4\xa1\x43\x51\x9b\x80\xce\xa8\x82\xb8\x5a\xe1\x0c\x94\xc9\x61\xab\x76\xe0\x90\x9e\x01\x95\x69\x51\xe3\xbf\xf8\x05\x7c\xb0\x8e\x18\xb4\xb2\x17\xb0\x09\xa1\xf4\x17\xe7\xe7\x6b\x1d\x92\xca\x65\x76\xbb\xad\x8c\x0e\xbb\xf3\xcc\x9a\xe0\xf4\xb2\x0a\xd6\xf9\xf3\x1c\xef\xb1\x38\x57\xa5\x9e\xf3\x3a\x4d\x60\x35\xdd\xe6\x7f\x72\x51\x1d\xfd\x59\x6b\x61\x61\x47\xe2\xf6\xc1\x69\xb3\xae\xbf\x66\xdd\x19\x65\xf3\x2f\xda\xe4\x24\x56\x15\x6f\x93\xe5\x36\xdc\xa4\xaf\x88\x09\x5f\xde\xde\xdc\x42\x7a\x28\x73\xbc\xcb\x62\x66\x6e\x73\x9b\x6f\xf8\x4c\x7c\xd1\x66\x85\x4e\xe4\xb4\x72\x76\xcb\x14\xd1\xe4\xa5\xd5\x26\xf0\x87\xac\xd0\x68\xba\x3c\xf6\xd5\x72\xab\x03\x09\xf6\xb7\x0a\x7d\x20\x71\x2c\xe0\x4a\x19\x63\x03\x2c\x11\xaa\x32\x57\x01\xf3\x05\x5c\x1b\xb8\x52\x5b\x2c\xae\x94\xc7\xe7\xe6\x32\x31\xd4\xcf\x89\x83\x87\xf9\xdc\xf6\x06\xe9\x1a\x52\x7e\x36\x00\xda\x05\x2b\xea\xde\x0f\x00\x2a\xcf\xd9\xbb\xa8\xe2\xf3\xc8\xcd\xa3\x2b\x18\x34\xa3\xe6\x49\x2c\x66\x03\x95\xf1\xc1\x55\x59\xa8\x1c\xe6\x70\x87\xbb\x28\xf1\xad\x2a\xc1\x07\x4b\x5f\x3e\xe8\xb0\xe9\x3d\x51\xb5\xa5\xaf\x02\x8b\x75\x89\xe0\x31\xc0\x72\x07\xe4\x43\xd9\x20\x82\xb5\x05\x5b\x0e\xd3\x62\xc3\x70\x18\x9c\xc6\x7b\xec\x93\x74\x4b\x1d\x9c\x72\xbb\x9a\x77\x0b\xb8\xdd\xe0\x0e\x94\x43\x20\x31\xff\x56\xa1\xdb\xa9\x65\x21\x74\xa2\xc1\x2e\x11\x58\xc9\xdc\x3d\xe6\x3d\x92\x0f\x1b\x34\xb0\xb5\xb9\x5e\xed\x48\x73\x45\x2d\xfb\xc6\x77\x71\x7e\x7e\x57\x2d\xd1\x19\x0c\xc8\x8a\x91\xdb\xcc\x9f\x57\x1e\xdd\x7c\x5d\xe9\x1c\xcf\x5b\x02\x3a\x3b\x19\x62\xbd\x50\xee\xfc\x94\x15\x95\x0f\xe8\x3e\x92\xbf\x9f\x92\xc9\xed\x06\xd9\xb7\x8b\xeb\xc2\x74\x1f\x3c\x6c\x74\xb6\xe1\x6f\xa2\x35\x2d\xb1\xb0\x66\x2d\x8a\x7f\xbb\x6f\x71\x74\x69\x0f\x95\xc7\x9c\xd8\x9d\x6b\x4f\xb6\x5a\x69\xbf\xa9\x05\xe5\x59\x92\xe0\xe9\x59\xfc\x40\xe2\x22\x47\x95\x52\x65\xc4\x0e\xc8\xf5\x6a\x85\x6e\xdf\xf2\x5a\x9b\xf1\xf2\x64\x58\x69\x2c\xd8\x4f\x90\x58\x48\xe6\xca\xec\x1e\x36\xe8\x10\x1c\x07\x06\x63\x1f\x98\xba\x2a\x35\x4b\xc6\xc1\xc0\x72\xd7\x96\xbd\x89\x05\xbd\x36\x2c\x8f\x00\x7a\xc5\xd4\xb4\x91\x00\x8a\x60\x5d\xb4\xec\x64\xf7\x8b\x41\xf6\x0f\x68\x7e\x3f\x02\x4f\x09\xe1\xf4\x6a\xff\xef\xe2\x05\x43\xfd\xb1\xe7\x02\x65\x63\x7d\x53\xd4\x5b\x14\xbd\x63\xff\x16\x65\xf7\xa0\x7c\xdc\x12\xb9\xa8\x90\x58\xb7\xae\x94\x53\x26\xa0\x08\x4d\xec\xa7\x2f\x56\x03\x1b\x55\x96\x68\xfc\x7c\x89\x2b\xe2\x94\x75\x39\x3a\x50\x99\xb3\xde\x83\xc7\x52\x39\xe6\x55\x89\x4e\x74\x74\x01\x57\xec\x40\xc5\xdb\x1a\xdb\xa7\x49\x5c\xe6\xf5\xb1\xb5\xa7\x25\xd5\x7b\xc4\x9c\x9e\xfa\xe5\xdd\xd5\xcf\x3f\xff\xfc\x57\x0a\xec\x5b\x16\xa7\xf6\xf4\xf5\xd7\xdb\xab\x05\x7c\x33\x3d\x9a\x9f\x6d\x59\x51\x70\xcc\xc9\x03\x30\x87\x76\x3e\xe0\x76\x01\x5f\x50\xe5\x73\x6b\x8a\xdd\x02\x3e\x56\x45\xc1\x40\xa1\xd0\x7e\xc0\x10\x7f\xd0\x3f\x27\xbf\x71\xba\xb7\x36\xda\x80\x0a\x0c\x8a\x70\x4e\x02\x3a\x56\x89\x72\x2c\x90\xa8\xff\xcd\xa9\x0c\x3f\xa3\xd3\x36\xbf\xc1\xcc\x9a\xbc\xe7\x83\x3b\xda\xf4\xb1\xda\x2e\xd1\x91\x41\x7b\xf9\x37\xa8\xa2\xb0\x0f\x98\x47\x8c\xd4\xe8\x45\xb0\xb0\x26\xda\xab\xaa\x28\x76\x7d\x5d\x42\xb7\xd5\x86\x64\x1b\x05\xaf\x03\x3c\xe8\xa2\x20\x4d\x71\xb8\xb5\xf7\x44\x31\x05\xd0\xc4\xed\x4f\xa6\xd8\xb1\x7c\x49\x09\x7b\x24\xd3\x8e\xba\x7a\x5e\x78\x4b\xb7\x2c\xe0\x83\xda\x01\x49\x8a\x75\x71\x63\x5d\x40\x43\x1a\xdb\x48\x70\x84\xb3\xda\x84\x7f\xfb\xf3\x20\x57\x09\x1b\xad\xf7\xec\xa4\xb7\x88\x69\xdb\x7c\x33\xb4\xe6\x2f\xef\xae\x80\xb5\x93\xbd\x03\x69\x27\x5b\x9e\x0a\xb5\xe3\x1c\x70\x39\x75\xcc\x4a\x5c\xe4\x95\xd0\x0e\xbb\x6e\x2d\x86\xb1\xc6\xcc\xc5\xa2\x55\x2d\xac\x51\xbe\x8a\x19\xb1\xab\x6a\x0c\x81\x22\xc9\x2c\x59\x10\xd9\x7d\xae\x1d\x66\x41\xe4\x14\x38\xa2\x2d\xfb\xd2\x57\x11\x06\x71\x14\x6c\x96\xae\x3d\xe0\x63\x89\x59\xa8\x9d\x46\xdc\x04\xbc\x30\x16\x28\x44\xa0\x83\x7b\xed\xf5\xb2\xe8\xc7\x58\xd6\x96\x9a\x14\x1b\xa1\x2c\x8c\x56\xe5\x50\x65\x9b\xb8\x1a\x0e\x0c\x2f\x41\xad\x02\x0a\xaa\x67\xee\xea\xbe\x42\x85\x9a\x71\x33\xb0\x86\xe1\x00\xc2\x4a\x1b\x55\xe8\xdf\x09\xef\xd1\x33\x78\xcd\xdb\x32\xec\x16\x70\xe9\x79\x89\xa0\xfc\xde\x1f\x7b\x84\xf9\x46\xb2\x7b\xa5\x09\xac\x04\xdc\xfa\x59\x87\xcd\xcb\xc2\x66\x77\x24\xbb\x4f\xe9\xb1\x3d\xbd\x1a\x0a\x91\x1e\xc3\xac\xe5\xfb\x92\x8b\x64\x10\x69\x48\xf0\xd6\x25\x24\xb3\xaa\x5c\xd8\x50\xf0\x32\x11\xfb\xaf\x2a\xc2\x49\xb3\xbe\xa8\x8a\xb0\xb1\xd5\x7a\x43\x06\x9a\x90\x50\xb2\x1e\x88\x69\x51\xcd\xf5\xf8\x87\x24\xb5\xd2\x69\x3b\x10\x46\xac\xac\x91\xd8\xbe\x80\x77\xd6\x01\x3e\xaa\x6d\x59\x50\x76\xc1\xfa\x14\x13\x0c\xd6\x34\x81\x60\x0a\x4a\xcb\x1a\x16\x29\x0f\x05\x92\x9f\x5f\x25\x97\x24\x5a\xf5\x4b\xb5\xa4\x3f\x8b\x3d\x90\xfc\x59\xef\x3d\x9a\x9c\xc2\x5c\xa3\xef\xb5\x2b\xda\x4f\xa6\xe8\xf2\x7a\x2d\x58\x4f\xf0\x8b\x88\x8c\x64\xaf\x0d\x7f\x53\xda\x7c\x01\x97\x51\x93\x54\x68\x2d\x62\xc6\xbf\xc7\x45\xf4\xd1\x1b\x2d\x8a\xd6\x02\x0a\x36\xca\xe5\xed\x45\xa4\x87\xbe\xb8\xb9\xfe\xdb\x2f\xd7\xef\xdf\xbf\xec\x3d\x9e\xd4\xba\x2f\x28\x5e\x45\x56\xa0\x32\x55\x39\x8b\x4e\x34\x2d\xb2\xf1\xa5\x97\x9f\xaf\x39\x93\xe0\x1f\x38\x24\x66\x8c\xcf\x0c\x86\x07\xeb\xee\x7a\x64\x4b\xe5\x02\xc3\x74\x3f\xeb\xb8\x77\x92\x91\x0f\xb4\x0d\x7c\x24\x75\x4e\xe6\x14\x05\xcb\x3a\x3a\x83\xca\x04\xdd\xf7\x28\xca\x80\xca\xb7\xda\x68\x1f\x9c\x0a\xd6\x91\x1e\xa9\x2a\xd8\xad\x12\xad\xb1\x19\x7a\x0f\x99\xa2\x84\x58\x18\x83\x5d\x3d\x1b\xf0\x7f\x1c\x66\x9a\xb0\x42\x58\x64\x95\x30\xdc\xac\x11\x76\x6d\x65\x11\x92\xc6\xdd\x6c\x54\x9f\xa2\x58\x0e\x9a\xc6\xe9\x11\x36\x18\xc3\x02\xfb\x6e\xb4\x7e\xd2\x90\xa1\xb6\x28\xb6\x10\xc4\xff\x73\xc4\xd0\x38\xb4\xc9\x98\xf6\xa1\xf2\xec\x71\xd8\x2b\xa6\xe8\xde\x62\x75\x63\xc5\x8d\x52\x3a\x5c\x93\x2e\xf4\x62\x30\xc0\x5b\x95\x6d\x00\x4d\x70\xbb\x98\xd4\xe9\x9c\xf6\xb8\xd2\xe8\xea\xaa\x8c\x43\x5f\x5a\xc3\x51\x01\x32\xbb\x2d\xad\x41\x13\x1d\x07\xd9\xd9\x40\xa8\xac\x4d\x43\x28\xd7\xeb\x20\xc7\xcc\x8a\x33\xe8\x72\xbb\x3a\x33\x24\x57\x63\xcd\xdc\xe8\x62\xc6\x74\x35\x46\x37\xa1\x63\xa8\x20\x85\x4e\x08\x24\x62\x9c\xfd\x0d\x73\x2c\x78\x52\x12\x2c\x3f\x29\xe7\x54\x37\xcc\xae\xd1\x10\x66\xc6\x83\x49\xda\xe9\xdf\x5a\xff\x8c\x4c\xb6\xa5\x24\xe6\xe4\x21\x56\xfa\x71\x26\xc9\x57\x07\x36\xf4\x23\x05\x01\xbe\x48\x8a\x1c\xb9\xd1\xbf\x55\x31\x1b\xfb\xf4\xf1\xfd\xff\xc0\xf5\x3b\xbe\x9b\x9f\x22\x68\x64\xa3\x7c\x63\x64\xa5\xb3\xf7\x3a\xef\x73\x04\x44\x1c\x6d\x08\x43\x8b\x11\xf7\xca\xd4\x1d\x86\xca\x19\x81\x0c\x4d\x85\xa5\xc1\x41\xa3\x99\x5f\xd8\x28\xd3\x90\x29\x95\xf7\x35\x5c\x92\xf8\xc9\x24\x18\x41\x2e\x59\xb3\x96\xda\xc4\xa2\x41\xbd\xc1\x7e\xc4\xa8\x56\x2b\xfd\x28\x21\x28\xed\x29\x92\xdb\x44\x64\xc0\x69\x6a\x53\xa5\x04\x57\x15\xe8\x13\x6c\x20\xfe\xf4\x9d\x9b\x80\x90\x54\x7c\x5b\x22\x04\x57\x99\xac\xed\x85\x0a\x34\xeb\xb0\x49\x2a\x2a\xab\x60\x3f\xa3\x1d\xb3\xa6\x47\x73\xab\xee\xc4\x06\x64\x71\x51\x5e\xd6\xb4\x64\xcc\xfe\xae\xc7\x7e\x5f\x62\x46\x06\x38\x10\x82\x08\xaa\x6e\xb0\x56\x03\xc9\xc1\x25\x40\xc4\x80\x98\x30\x27\x71\xf6\xe3\xa7\xdb\x28\x3c\x50\xf0\xe7\x57\x7f\x85\xf9\x40\x5c\xf7\x01\x55\x3e\xab\xd3\x03\xd4\x0c\x5b\xe2\x6d\x3f\xbd\x7a\x0d\x57\x92\x7b\x52\x0c\xf9\xcb\xab\x57\x22\x9d\x2f\xa8\xbc\x35\xb1\x30\x47\xf6\x6b\xab\xa1\xe4\x33\xd7\x99\x0a\x82\x06\xda\xea\x9a\x71\xf5\x25\x02\xa7\x95\xad\x4c\x9e\xc2\xbd\xe0\xf0\xa2\xb0\x21\x60\x3e\x80\x95\xe2\xfe\xa3\x06\xc6\x32\x8e\x43\xf2\x31\x2f\x92\x4d\x15\xbb\x3e\xf4\xe4\x85\x70\x66\x3a\xa0\xa4\x08\x5f\x88\xc2\x5c\x60\xc6\x06\x55\x8e\xee\x25\x8b\xe6\xb2\x2c\x0b\x4d\x5b\x27\xa7\xa2\x57\x90\x2c\x98\xc3\x5e\x92\x52\xdf\xa0\x9e\x37\xce\xe8\x1c\xb7\xa5\x0d\x68\xb2\xdd\x7e\xa8\x19\x75\x5b\x51\x41\xf6\xca\xe2\xb0\xef\x9a\x2e\xc1\x53\xa0\x24\x84\x62\x24\xef\xec\x94\x2a\x54\xda\x64\xd6\x22\x08\x76\x35\xc8\xc3\x1c\x3d\x5b\x82\x0f\x2a\xe0\xe2\x98\x8c\xfe\x59\xf2\x41\xee\xa0\x1c\x13\x36\x4f\x2f\x4d\xfb\xcf\x52\xa3\x61\x09\xd8\xa2\xa8\x6b\x66\x68\x56\x96\xeb\x5d\xde\x6e\xd3\x9a\x07\x14\xfb\x5e\x39\xad\x4c\xa0\x94\x31\x46\xdd\x54\x33\x8a\xa8\xbb\x9b\x13\x2a\x89\x4f\x76\xd5\x59\xee\x90\xbf\x24\xa4\x74\x2f\x25\xcb\x1d\x06\x50\x9c\xaa\xd9\x4e\x41\x48\x80\x97\x2e\xc8\x20\x19\x03\x74\x70\x63\x8f\x28\x39\x45\x0e\x00\x14\xb9\x09\x16\x90\x2a\xd7\xab\xa0\x14\x88\x0c\xfe\x41\x7b\x9c\xed\xa1\x88\x8c\x62\x7e\x8e\x6e\xc0\x11\x55\xa6\x45\x22\x65\xa7\x1b\x9d\xe7\x68\xe0\x85\x36\xbc\xdd\xf3\x07\x15\xb2\x0d\xff\xb8\x46\x0a\xce\x45\xe1\x5f\x0a\x14\x10\xfb\x9d\x60\x80\x39\x0b\x94\xa9\x16\x3a\xd3\x94\xea\x2a\x7f\x27\xe1\xc7\x2e\xd9\xbf\xed\x3d\xbf\xae\xcd\x0e\x54\x96\xfe\x9b\x51\xa3\x69\x6f\x4b\xfc\xd9\xac\x83\x2d\xc9\xf5\x95\x51\x65\x5b\x88\x62\xb0\x7e\xcd\x1e\xa8\x72\x8e\x5d\x10\xf6\xc4\x1a\xcb\x28\xa5\xd3\xf7\xba\xc0\x35\xe6\x9c\x73\x49\x3d\x4d\x72\xc4\x7e\xa8\xe0\x32\x73\xf3\xdc\x98\x97\xea\x26\xfb\x9d\xa5\xf4\x30\x7a\x4d\xbe\x83\x5c\x53\xcc\x33\x7b\x24\x97\x3b\x50\x66\xc7\x8f\x66\x57\xf6\xe6\xed\xe7\x2f\x6f\xaf\x2e\x6f\xdf\xbe\x81\x79\x67\xb9\x5c\x22\xa7\x84\xa1\x28\x37\x2a\xaa\x2c\xc9\x6c\x10\xd9\xb5\x8a\x47\xda\xc0\xfd\xeb\xc5\xeb\xbf\x2c\xf6\x9d\xd2\x58\xa7\x82\x7f\x93\xec\xb0\xff\xc3\x9e\xb1\x7e\x8e\x59\xe4\xa8\xed\xc4\xce\x01\x41\x61\x7c\xc4\xac\x0a\xfd\x98\x0e\x92\xb6\x4a\xc1\xb3\x86\xc9\x4d\x82\x45\x28\x44\x4a\x1d\x0b\xd1\x12\xe9\xd0\xf9\x90\x56\x39\x42\xb1\xe3\x42\x22\x37\x52\x21\x04\x56\x4a\x17\xb4\x70\x87\xbe\x2a\x42\xab\x66\x80\xd3\xa6\x4f\x97\x34\x53\x6a\x5c\xc5\x75\x56\xcb\x96\x9e\xe2\xde\x90\x6d\x12\xae\x69\x19\xc3\x20\x65\xba\x3f\xee\x95\x48\xaa\xa2\x48\x26\xd8\x0f\x5e\xa3\x18\xf9\x90\x6c\xe5\x32\x03\x70\xb8\xb9\x3a\x42\x6e\x77\x2e\x52\x4e\xca\x62\x65\xbe\x36\x29\x07\xa5\x21\xf5\x0e\xc7\xe4\x22\x57\xdb\x4d\x8e\xfe\x6d\x02\xec\xcb\x95\x50\xdd\xf0\x3e\xe6\xbc\xf0\xc1\x9f\x46\x1b\x3a\xed\x9f\xfb\xa9\x84\x3c\x93\x14\xe6\xa0\x61\x5c\xaf\xba\xaa\x25\x70\x8c\x38\xf8\x4e\xe9\xa2\x72\x98\xa0\xec\x44\x1e\x05\xa9\x3e\xb2\x44\x28\xd1\x79\xed\x63\x3d\xd0\x07\xeb\xd4\x1a\x93\xba\x99\x94\x47\x52\xba\xe5\x2b\x27\xdd\x0b\x0a\x79\x83\x1e\x07\xb8\xd7\x23\xbd\x03\xce\xc4\xa2\xaf\x6e\xa7\x7a\x43\x42\x39\xa4\x53\xc3\x2d\xfe\x51\x0e\x3d\xb5\xdd\x3f\xaa\x26\xdd\x31\x80\xa7\xb6\xfe\x47\xc9\x0e\x8e\x04\x3c\x65\x0c\x60\x94\xf2\x1f\x38\x1e\xd0\xbe\x0e\x9a\x53\x66\xf3\x51\x97\xd0\x11\xdd\x4d\xb5\x5e\x4b\xf1\xfb\x3f\x6f\x6f\x3f\xa7\x1c\x84\x6e\x6f\x9a\x1f\x04\x2f\x2b\x3f\x83\x57\xa0\xfb\x38\x34\x5d\xb1\x2c\x35\xe6\x02\x5a\x48\xf3\xe7\x9f\x26\x77\x35\x84\x38\x9b\xa5\x07\xa5\x8b\x51\x47\xd8\xd9\xd9\xdb\xc7\x80\x86\x12\xd5\x5c\x05\x05\xca\x7b\x9b\x69\x06\xc7\xb5\xf9\x3a\xce\xa8\x16\x52\x90\x99\xd0\x49\xce\xbb\x48\x33\x44\xb7\x41\x07\x0f\xf6\xc1\x70\xdb\x5c\x9e\x20\xcb\xda\x83\xa0\xa3\x14\xeb\x4a\x44\x8a\x31\xbc\xc2\x3a\xe5\x1f\x6c\x36\x66\x96\x50\x72\x1f\x17\xd7\xbc\xb3\x8c\x3d\xa2\x9d\xe1\x63\x86\x65\x2c\x17\xc9\xa2\xeb\x9c\x20\x6e\x87\x78\x3d\x26\xab\xc3\x11\x07\x20\x53\x95\x9f\xfa\x7d\xa0\x6b\x7e\xc5\xb7\x88\x2f\x06\x6d\xb2\xa2\xca\xd1\xc3\x96\x2c\x27\x32\xb0\x25\xa5\x09\xc2\xd0\x48\xf0\x86\x35\x33\x66\xc6\x2b\xf1\xc6\x0b\xf8\x68\x03\xc7\xdb\xf6\xaf\x8c\x05\x27\x89\xc6\xc2\x46\x5c\x0b\xe6\x71\x8b\xe3\x31\x6d\x32\x6a\xb7\xa8\x1e\xe4\xa5\x5c\xac\x36\x87\xfe\xb4\x9f\x60\xdd\x6e\x52\xe1\x29\x06\xf5\xee\x98\x07\x25\x22\xbc\x8d\x69\x7e\xca\xc5\xb6\x8e\xce\x59\x37\x23\x80\x43\x11\x97\xb5\x86\xd4\xfd\xbf\x6e\x3e\x7d\x04\x8f\x8e\xf1\x80\x1a\x0b\x2b\xfb\xd7\x87\x46\xd0\x90\x93\x50\x4c\x0e\xa5\xf5\x61\xa5\x1f\x21\x4d\x68\xb0\x9b\x31\xec\x82\x8e\xa0\xa8\x82\xb8\x4f\xf2\xb9\x97\xa4\x48\x82\xa5\x7f\x47\x67\xe7\xda\xe4\xf8\x48\xd9\x15\xbc\x23\x8e\x1c\x96\x78\x24\x59\x96\xa8\x9c\xe8\x21\x57\xcf\xb8\x2d\xa6\x39\x83\x11\x5d\xb5\xab\xa8\x0b\x90\x0f\x14\xc7\x06\x18\x69\x45\x26\x9e\xf2\x2a\x8a\xe0\xdb\xaa\x08\xba\x2c\x50\xb8\x4b\xd9\x4a\xf4\x00\x9c\x26\xbc\x95\x4e\xd1\x41\x05\xa1\xeb\x1b\xc0\xb7\x53\x92\xcc\xb7\x53\x98\xc7\x96\x1c\x49\xbf\xfe\x32\xd6\xba\x62\xae\x74\x04\xc5\x5a\x61\x88\x32\x2b\xf4\xdf\x5f\xfd\x63\x31\xf1\x88\x23\x68\xc6\x45\xac\xb4\xf3\x21\xf2\x30\x96\xbb\x4d\x7a\xc8\xb7\xd3\xc3\x84\x0e\x46\xb9\xe6\xda\xa2\xf7\x6a\x3d\x81\x82\xd3\xb5\x57\x8b\xd9\x54\x5b\x65\xe6\x0e\x55\xce\x8d\xd4\xd6\xaf\xf5\x7c\x0f\x49\xfe\x98\x3d\xcb\xdf\x59\xc2\x0b\x68\x47\x82\x58\xdd\x6c\x66\x35\x94\x9f\x4f\x44\x87\xd6\xfe\x2d\xcf\x6d\xa9\x1c\xdd\x61\x6b\x7b\x02\xb3\x24\x04\x3c\x99\x57\x5b\x95\x6d\xb4\xc1\x29\x6e\x1d\xb1\x29\xe6\xe7\x1e\xb7\x52\x39\x56\xaa\xb6\x29\xff\xa6\x7f\xb8\x63\x48\x72\xc0\x64\xf4\x45\x18\x83\x56\xa3\xee\x95\x2e\x68\x8d\xcf\xc8\xb7\x03\x89\x46\xf7\x6f\xc3\x09\x47\xba\x64\x58\xf8\x29\xb1\x93\xef\x68\xbc\x5f\xcf\xdb\x3f\x35\x70\x0a\xa4\xeb\x44\xc8\x29\x56\x1d\xc5\xa4\xfd\x51\xd5\xc9\x4d\x9d\xd1\xae\xe8\x8e\x7f\xf1\xa6\xe0\x93\x91\xba\x62\x33\x6e\x25\x50\x8e\x3b\x28\x93\x74\x5b\x9d\xbc\x34\x20\x52\x2f\xed\x17\x6d\xf2\x3f\x68\x5c\xf5\xbb\x64\x31\x5d\x12\x18\x1b\x69\xfc\x97\x8a\x02\x5e\xc4\x31\x3b\x74\x18\x67\x96\xb5\x59\x17\x38\x9e\xda\xd7\x54\xb9\x4c\x4c\xf9\xed\x32\x39\x9d\x25\xe6\x2f\x7f\x58\x61\xb9\x89\xc1\x1d\x88\x91\x29\xb1\x51\x8e\x5d\xaf\x9a\x5e\xc4\xac\xdd\xf4\xa8\x27\xc8\x9a\x1e\xf1\xe4\xd6\x6a\xad\x6c\xcd\xc7\xca\xc4\x6d\xbe\x80\x1b\xd2\x5b\x81\x0c\x71\x0e\x5b\x7a\x2a\xd3\x6e\xaa\xe9\xd5\x70\xa9\x2e\xa8\xbb\x58\x6b\xe4\x6c\x37\x20\xa8\x8c\x1f\x38\x8f\x09\x9e\xf5\xe9\x21\x07\xe8\x76\x02\x5a\x5a\x0b\x6c\xec\x83\x8c\x08\x05\x0b\x0f\x4a\x87\x7a\xe7\xea\xee\xa0\x47\xdd\x60\x6f\x59\x53\x42\x3d\x26\x87\x84\xa3\xf2\x48\xba\x2a\xfd\x04\x6f\xf5\xf5\xfa\xcd\xbe\x4d\x2c\xc6\x14\x7a\x72\xcf\xcd\x48\xdb\x88\x52\x3f\x79\xd8\xb9\x19\x1e\xf0\x7f\xaa\xf4\x0f\xfb\x8e\x83\x61\x6e\xca\xcd\x3f\xc3\xe9\x84\xf1\x0c\xb7\x55\x47\xfe\x9e\x93\x0a\x13\x84\x9b\xee\xe6\xf7\x9c\x5a\x18\x25\xfc\x87\x87\x87\x83\xe2\x3d\x00\x93\x9f\x0c\x8e\xa3\x9b\x3f\x54\xd6\xab\xbd\xdc\x18\xaf\x8e\x58\x78\xff\x78\xc6\xe8\xca\xcf\x6e\x82\x32\xb9\x72\xb9\xb4\x31\x9a\xe3\x09\x7f\xb8\x40\x8e\xaa\xa4\x58\xb2\x84\xea\xf8\x70\x9d\x6e\x68\x1f\xe2\xd0\xab\x7a\x72\x55\x06\xfc\xa1\xd0\x5b\x3d\x9d\xff\xc5\x2c\xcd\xd4\xd3\xcf\x9c\x98\xd5\x75\xa8\x38\x01\x1b\xfd\x7c\x6c\x13\x1c\x8a\x67\x71\x14\x62\xa3\x52\x61\x87\x6b\x6f\x35\x1a\x67\xa8\x51\xa3\x7c\x5b\xaa\xdf\x2a\x1c\x1c\xfc\x6b\x5f\x71\x9b\xe9\xac\x84\xf6\x9e\x6f\xb2\x71\x68\x22\x8e\x54\xda\xfd\x63\x49\x6a\x7a\xf7\x72\x04\xa5\xd5\x77\x0c\xb6\x3e\xeb\x22\x7c\xc1\xc7\xba\xd7\x58\xef\x60\x9a\xa1\xa9\x27\x7a\x25\x12\x92\x7e\x3e\xb7\x8d\x7c\x20\xf7\x22\xea\xd8\x74\x14\x4b\xeb\x87\xe7\x7e\xdb\x57\x14\x6d\xe4\x6c\x66\xcd\x4a\xaf\xab\x08\x1a\xb8\xbe\xb3\x51\x66\x2d\xb3\x22\x4d\x0d\x43\x4d\x23\x5b\x7c\x80\xad\x36\x15\x89\x95\x7b\xdf\xcd\x9c\x50\x13\xdf\x52\x41\x5f\x62\x7e\xd2\x8a\x03\x40\x0d\x0d\x54\x5e\xfc\xba\x74\xcc\x44\x53\x5b\xa3\x47\x4b\x8c\xe3\x6e\x59\x3d\x83\x3a\x49\x33\x6a\x4b\xbb\xa2\x10\x1b\x55\x38\x83\xca\x14\xe8\x3d\xec\x6c\x25\xfb\x70\x98\xa1\x1e\x3a\x59\xd4\xbe\x64\x9e\xd3\xde\xa1\x91\x20\xa1\x8c\xe0\x9f\xe4\x1d\x9f\x01\x57\x76\x38\x78\x3c\xca\xb8\x09\x4d\xc3\xa7\x0e\xeb\xbe\x25\xfe\xb3\x33\x5f\xb7\x2d\xa6\xb9\x16\x85\x97\xce\x57\xa6\xf3\x0b\x44\x39\x62\x8e\x34\xfe\x96\xfa\x47\x03\xe3\x54\xdd\x95\xa6\xa9\x55\x96\x72\xd4\x75\x61\x7b\x54\xc1\x05\xfc\x2a\x23\xda\x71\x5a\x32\x48\xd7\x7f\x92\xac\xaa\xdd\x40\x6b\x29\x5c\x27\x64\x95\x84\xca\xd4\x6d\xf7\xa5\xca\xee\x8e\xd1\x98\x34\xe7\x75\xcc\x01\x97\x26\x22\x4c\x92\x7c\x86\x68\x91\x59\x23\x45\xb9\x6c\x37\x8f\x23\x30\x73\x65\xf2\x79\xed\x1e\xb2\xdd\x0f\x67\x7d\x1e\x8b\xd5\x7b\x6d\xee\x8e\xd6\xb8\x74\x83\xa0\xb4\xaf\x5f\xde\xef\x83\xb3\x23\x5a\xbb\x70\xdc\x59\xa2\x7f\x31\x2a\x9d\xae\x69\x3d\xb1\x92\xf5\xb0\x89\x83\x21\x35\x70\x19\x5d\xbd\xae\xc7\xe6\x4f\x63\x37\xf8\x34\xa2\xa2\xe9\xb2\xd6\x54\x7f\x68\xb4\x98\x05\x97\x69\x0a\x30\x2b\x94\x13\xe7\xa0\x8c\x74\xee\xe4\xa1\x13\x28\x23\x47\x58\x56\x01\x72\x8b\xd2\x5f\xb2\xf7\xe8\x9c\xce\x11\xf4\xa8\x70\x0f\x0a\x46\x1e\x7a\x34\x28\xab\xb1\x62\xab\x1c\xb3\x80\x4f\x06\xc1\xae\x2e\xe0\xf4\xa6\xca\x32\xf4\xfe\x74\x68\x5c\x27\x5d\x35\x97\x9f\x1b\xcd\x51\x3e\xcf\x06\x29\x7b\xfa\x4e\x88\x3d\xa1\xa7\x63\x13\x0e\xf3\x91\xd9\x97\x51\x52\x85\x5a\x62\xbf\x07\xfa\xcc\x27\x8f\x3f\x28\x1e\x0d\x8f\x89\xdb\x1d\xee\xc4\x2b\x4b\xbf\xbb\x1f\x47\x82\x05\xeb\xd6\xca\xe8\xdf\x07\x0e\x0a\x9b\x1c\x08\x42\xae\xad\xd3\xbf\x23\xbc\xe0\xf7\x1a\xc8\x99\x60\x2c\x30\x0b\x2f\x5b\x07\x7d\xd5\x0e\xb6\x3c\xc2\x26\x3f\x59\xe7\x87\x66\x1f\x1d\x96\x05\x8f\xb9\x92\x25\xd4\xe3\x84\x3e\xd2\x74\xf7\x3a\x1b\xe8\xc9\x1f\x4c\xa4\x85\xaf\x47\x1f\x18\xde\x2a\xa3\xd6\x98\x4b\xaf\x69\x7a\x0c\xf2\x43\xfb\xaf\xb0\x55\xa5\x87\x07\xeb\xee\x56\x85\x7d\x98\x6b\x19\xfd\x4a\x01\x3b\xe2\xd8\xa1\x83\xa5\x76\x95\xda\x4a\x72\x7e\xc8\x61\x5a\x83\x78\x5d\x15\x6a\xaa\xb1\x13\xad\x09\x85\xfb\x50\xec\xe2\x3c\xcf\x08\x70\xd8\xd8\xca\xe3\x1d\x62\xa9\xcd\x5a\x50\xbf\x4c\xcf\x85\x5d\x49\x28\xad\xd8\xc5\xe2\x94\x39\x0b\x60\x62\x3f\x3a\x9e\xbc\xaa\x4c\x8e\xce\x87\x21\x08\xdf\x14\x8c\xc8\x6f\xa5\x95\x25\xad\x49\xd9\xca\x99\x34\x1a\x67\x9d\xc1\xd0\xf4\x65\x9f\x05\xae\x99\x6d\x27\x58\xde\x0c\xcb\xaa\xb2\x2c\x76\x50\xaa\xb0\x81\x42\xdf\x21\x7c\x3b\xcd\xf4\x3c\xcb\xbf\x9d\x0a\xa8\x8d\x38\x5e\xf8\xd7\x23\xcb\x67\x2a\x1f\xd4\xae\xf6\xe5\xb5\x34\x62\xce\xd3\x2c\x9f\xb5\x7d\xef\x9c\xfa\x10\x20\x49\x43\x2b\xdf\xcc\xfe\x5c\x2a\xcf\xfc\x89\x4d\x30\x27\x5a\xf8\x3d\xcd\xf9\x3d\xe8\xb0\x19\x9a\xee\x36\x36\xe8\x0c\x7b\xd3\x7f\x23\x6d\xe8\xe9\xe4\xf3\xd0\x88\x4f\x37\x64\x4e\xce\xf7\xb4\xde\xe2\xd1\x6a\x3e\x8f\x39\xd0\x86\x1b\x9c\xa8\xf2\xb8\x77\x3a\x25\x8f\xb1\xc6\x47\x8c\x3a\xe5\x9e\xc7\x79\x7c\xc6\x29\xfc\xb3\xf2\x63\x34\x59\xe2\x5c\x85\xb5\xe5\xbc\x20\x0f\xdf\x5e\x71\xd4\xc1\x78\x8c\x1b\x29\xc4\x28\xb7\x63\x4b\x73\x2a\xeb\x9f\x0e\x4b\xeb\xec\xec\x4f\xb5\xd6\xbc\x44\x69\x62\x69\xf6\x81\x31\x97\x8b\x67\xbd\xc4\x60\x46\x68\xc6\x91\xa5\xa1\xf9\x75\x38\x1c\x5b\x56\x83\x9e\x46\xae\x41\xef\x0f\xc1\x8d\xf4\xab\x3b\xc2\x8d\x6e\xa9\x95\x70\xa8\xae\xbd\x4c\xad\x76\x14\x92\x89\x6b\x72\x47\x28\x97\x78\x47\xd7\x3f\x0b\x15\xa1\x42\x6d\x7b\x4c\x72\x02\x23\x6e\xd0\xe3\x11\x4b\x1e\x65\x70\x8d\x49\x8e\x58\xf4\xa7\xba\x70\x1f\xdf\xaa\x43\xb4\x69\xc5\x4d\x45\x5f\x2a\xbc\x05\xaa\xc1\xa3\x2a\x69\xcd\xda\x43\x27\x3c\xbc\xe5\x46\xf9\x12\xc9\xb1\xd4\xaf\x20\x20\xcb\xe0\x03\x11\x7c\xc2\x26\x46\xe1\x11\x92\xf5\xd8\x56\x9c\x2b\x76\x08\x67\x97\xe4\x1d\xcf\xd8\xeb\x9c\x7d\xe5\x22\xe6\xd9\x77\x71\x28\xe8\xb1\xb6\x52\xb7\xa1\xa4\xe5\xcc\x46\x68\x9f\x32\x4b\xc5\xf2\x5a\x46\xf0\x40\x38\x78\x62\x66\xec\xba\x3e\x6e\x12\xbd\x73\x7d\x02\x4f\xaf\xba\x02\x88\x1b\x1c\xa4\x73\xe8\x6c\xe0\x11\x1b\x9f\x50\xf5\xb1\x76\xef\x50\x03\xae\x8b\xb0\xf8\x60\x4b\x4a\x95\xe3\x51\x1d\x72\xfc\xda\x80\x6a\xde\xf3\xb1\x80\x6b\xdf\x1c\x79\x1a\x7c\x47\x80\x1c\x83\x90\x01\x68\x19\x1b\x9c\x35\x27\x9c\xb9\xf7\xd9\xbc\x52\x64\xab\x76\xf2\x72\x83\xfa\xb8\xfa\x90\x6e\x36\xe7\x94\xb1\x7b\x0a\x85\x1b\x49\x25\x05\x16\xa7\x55\x48\x5d\xc3\xb6\xe7\x5b\x0c\x1f\xf6\xd2\x1e\x4a\xa7\xb7\xca\x69\x3e\x0a\x11\xe7\xe6\x48\x55\xeb\x43\x1c\xcd\x99\x1b\x01\x87\xdd\x4a\x57\x5e\xbf\xb8\xab\xaf\x2d\x03\x05\xfa\x1f\x69\xa2\x30\xef\x87\x61\xe0\x80\x7e\xd4\x92\x9a\x86\x80\x1f\xeb\x17\xb7\xb4\x03\xa8\x7c\x13\xa5\x8e\x2a\xdb\x08\x47\xbb\x5a\xd1\xdf\xf0\xa5\x89\x76\xd0\x7a\x1d\x8c\x07\x52\x92\x7b\x55\x88\x4c\x99\xfc\xb7\xd3\x1c\x57\xaa\x2a\xc2\xb7\xd3\xe6\xaf\x33\x4a\x03\x7b\x24\xdb\x7f\x8d\x1e\x2d\x53\xc6\x1a\x2e\xd3\x75\xc7\x72\x9b\x01\xbb\x54\x04\x22\x1f\x93\x74\xb4\x6f\x3c\xf2\xa6\x14\x02\xfd\xb9\x8c\xb4\x34\xab\x9e\xb7\x0e\xeb\xd9\xce\x99\xbc\xa6\x37\x19\x1f\xd2\xa3\x9b\xaa\x89\xf1\x4d\x05\xdf\x4c\x7d\x4a\x57\xc1\x9b\x8f\x37\xff\xfb\xfe\xf2\x3f\xde\xbe\x1f\xec\xde\x4c\x14\x7d\x8e\x52\x96\x7a\xfd\xfe\xe8\xc3\x61\xf6\xc1\xa0\xfb\x82\x7c\x68\x33\xeb\x03\xb2\x8e\xae\xbc\x8f\x67\x2f\x12\x77\x73\x2c\xc5\x5c\x96\xbb\xde\x99\xa4\xcb\xf7\xef\x47\x19\x14\xb1\x2c\x17\x9d\xb9\x4c\xc7\x47\x92\xea\xf9\xf2\xce\xfb\x6e\x22\x2f\xd7\xca\x2d\xd5\x1a\x21\x23\x18\x9e\x0d\x02\x95\xeb\xd5\xfe\x89\x8e\x56\x12\xd2\x06\xf1\x33\x99\x67\x57\xa6\x99\xfd\xaa\x8b\xed\xc3\xc2\x8c\x95\x7b\xdb\x14\x8f\x13\xa5\x7a\xae\xa0\x75\x78\xac\xc1\x63\x8c\xe4\x86\xec\xe4\x96\x2b\x2d\x0d\x46\x6b\xcf\xf8\x61\x0d\x27\x5a\x44\x8f\x3c\xba\xfc\xbc\xc8\xba\x0b\xa3\xc9\x92\xe4\x6c\xef\x77\x45\x68\x7e\xcb\xc6\x27\xd2\xb6\xf4\x1a\x96\x23\x16\x41\x32\x75\x15\xce\xe0\xf2\xe3\x9b\xd4\x6f\x60\x8d\xad\x8f\xf7\x9e\xae\xac\x43\x02\xe4\x26\x4f\x74\xc7\xe6\xf7\xea\x23\xf5\x51\x01\x1a\x62\x8d\x20\x7a\x87\xe5\xef\x70\x37\x67\x37\x30\x42\x54\xde\x47\xc6\x6f\x5e\x48\xa9\x46\xb4\xa5\xd6\x89\xa0\x05\xbc\x11\x1f\xc6\x93\xfe\x2b\x55\x78\x5c\xc0\xed\x18\xf4\xaa\xdf\xa9\x94\x0e\x22\x4b\xf7\x8c\x12\x5c\x0f\xa7\xb2\xc2\x53\x28\xd1\x6d\xb5\x6f\x8b\x87\xf7\xd2\x4f\x4d\xe5\xb2\xe9\x60\x1f\xfc\xf9\xa7\x9f\xe0\xc5\x57\x13\x0f\xd9\x70\x95\xf1\xad\x09\x3a\xec\x5e\xb6\xde\x09\x24\x3d\x95\x29\x41\x2f\xad\x2d\x50\x0d\xd5\x1f\x1b\xad\x7d\x8a\x84\xf7\x98\xc7\x26\x57\x1f\x8c\x38\xc2\x22\x8e\x5b\xdb\xf8\x8c\xc0\xc0\x84\xc0\xbe\xda\xff\xd1\x6d\xda\x03\x16\x35\x3e\x4a\x35\x80\xe7\x0e\xed\xe5\xc7\x81\xc8\x51\x6b\x1e\x9d\x6d\x99\x98\x6a\x79\x8e\x15\x8f\xcf\x9f\x4c\x2e\x78\xfc\xf0\xd7\xbc\xe5\x4d\x07\x7e\x24\xa9\x0e\x7c\x3d\x38\x51\x36\x27\xae\x3c\x07\xb4\x3f\xd0\xde\xeb\x9d\x80\x8e\xfd\x2d\x41\x39\x5c\x51\x6a\xc6\x57\xe2\x29\xc5\x74\x10\xa9\x0e\x04\xc3\xd5\xb4\xa3\xba\x78\x23\x9d\xba\x3e\xd4\xe9\x74\xee\x3e\xb4\x9a\xec\x84\xbd\x6c\x19\xf4\x56\xfb\xa0\x33\x68\x75\xae\x66\xf1\x06\x7e\x06\xcf\x6b\x8d\xbf\x30\x40\x8e\x22\x37\xe9\xb0\x35\xed\xb7\x50\x5a\x97\x6a\x0c\x75\x72\x52\xbf\x06\xaf\x47\x52\x06\xd9\x28\x51\x88\x09\x64\x2c\x43\xab\xf6\x0c\xc1\x13\x3b\x86\xa9\x4b\xc8\xaf\xac\xdc\xb6\xde\xa3\x26\x29\x36\xf1\x40\xc9\x8b\x82\xb2\xaa\x50\x6e\x60\xe5\x03\x6a\x5c\xef\x64\xfc\x9d\x3a\x9d\xf6\xe3\x71\xfd\xd2\xd1\x1e\xe9\x73\xbb\xca\x23\x7a\x94\x47\x23\xde\xb1\x5e\x64\xf7\xf4\xd9\xf1\xfd\xc7\x0e\x3f\x07\xcc\xe3\x88\x9e\xe3\xe8\x5a\x07\xdc\x65\xd7\x8a\xc9\x51\xc6\xac\x28\x66\xea\xda\xc4\x17\x67\x98\x3c\x66\x71\x62\xdf\x7b\x6f\x0c\x1c\xc0\xcf\xf2\x1e\xe6\xba\xb4\xde\xbc\x57\xa4\xfb\x06\x3b\x6b\xc0\x4b\x3f\x6c\x55\x15\x4d\x96\x3c\xa0\x76\x2d\xab\x6a\xbd\xb3\x2e\xbd\xc2\x30\xd8\x64\xb3\xd6\xc0\xe7\xaf\xb7\x9d\xf7\x4e\xb6\xd5\xb4\x47\xf7\x98\xae\xf9\xf7\x85\x88\x23\x95\x68\xd0\x37\xf7\x5b\x8e\x03\x7f\xdb\xfb\x2a\xfa\x55\xbe\x6b\x1e\x5f\xe9\x7d\xff\x9a\x2b\xf1\xaf\x4f\xa0\x1e\x06\x6a\x15\x4c\xe3\xb1\xdc\xf8\x4d\xf3\x4c\x95\x65\x58\x06\xcc\x3f\xee\xbf\xdd\x3b\x1e\x67\x49\xef\xf3\xe6\x8f\x99\x35\x52\x95\xf5\x17\xf0\xf7\x7f\x9c\x44\x14\x9b\xff\x9a\x56\x43\x5f\xfe\x5f\x00\x00\x00\xff\xff\xa9\x8a\x00\xd1\xd3\x5c\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3c\xdb\x72\xdb\xca\x91\xef\xfc\x8a\x2e\xe6\x41\x76\x15\x49\xd9\xc7\xc9\xd6\x46\x6f\x5a\xd9\xce\x6a\x63\xcb\x2e\xcb\xce\xd6\x56\x94\xda\x1a\x02\x4d\x72\x22\x60\x06\x67\x66\x20\x99\xe7\xeb\xb7\xba\xe7\x02\x90\xb8\x10\xb2\x95\x64\x49\x3f\x58\x20\xd0\xd3\xd3\xf7\xdb\x60\xb6\x5c\x2e\x67\xa2\x92\x7f\x41\x63\xa5\x56\x17\x20\x2a\x89\xdf\x1d\x2a\xfa\xcb\xae\xee\xff\xdd\xae\xa4\x3e\x7f\x78\xbd\x46\x27\x5e\xcf\xee\xa5\xca\x2f\xe0\xaa\xb6\x4e\x97\x5f\xd0\xea\xda\x64\xf8\x16\x37\x52\x49\x27\xb5\x9a\x95\xe8\x44\x2e\x9c\xb8\x98\x01\x64\x06\x05\x5d\xfc\x2a\x4b\xb4\x4e\x94\xd5\x05\xa8\xba\x28\x66\x00\x4a\x94\x78\x01\x74\x5f\x55\x08\x85\x52\x59\xb9\xdd\x39\xbb\xba\xaf\x4b\xb1\x92\x7a\x66\x2b\xcc\x08\xc2\xd6\xe8\xba\xba\x80\x78\xd9\x3f\x68\xe9\x17\x00\x8f\xc8\xdb\x08\xe3\xda\xc3\xe0\x9f\xaa\xa2\x36\xa2\xe8\x59\x60\x06\x60\x33\x5d\xe1\x05\xcc\xe7\x33\x80\x07\x51\xc8\x9c\x51\xf4\x20\x75\x85\xea\xf2\xf3\xf5\x5f\xde\xdc\x66\x3b\x2c\x79\x0f\x74\x39\x47\x9b\x19\x59\xf1\x7d\x9d\x05\x41\x5a\x70\x3b\x04\xff\x08\x6c\xb4\xe1\x3f\xd3\xd2\x10\xd7\x86\xcb\xcf\xd7\x01\x60\x65\x74\x85\xc6\xc9\xb8\x15\xfa\xb6\x18\x90\xae\x1d\x2d\x7d\x46\xb8\xf9\x7b\x20\x27\x92\xa3\x5f\xfa\xc1\x5f\xc3\x1c\xac\x47\x42\x6f\xc0\xed\xa4\x05\x83\x95\x41\x8b\xca\xf1\x1e\x5b\x60\x01\xf4\x06\x84\x02\xbd\xfe\x3b\x66\x6e\x05\xb7\x68\x08\x08\xd8\x9d\xae\x8b\x1c\x32\xad\x1e\xd0\x38\x30\x98\xe9\xad\x92\xbf\x25\xc8\x16\x9c\xe6\x25\x0b\xe1\xd0\xba\x03\x88\x52\x39\x34\x4a\x14\x44\xd5\x1a\x17\x20\x54\x0e\xa5\xd8\x83\x41\x5a\x03\x6a\xd5\x82\xc6\xb7\xd8\x15\x7c\xd4\x06\x41\xaa\x8d\xbe\x80\x9d\x73\x95\xbd\x38\x3f\xdf\x4a\x17\x45\x2e\xd3\x65\x59\x2b\xe9\xf6\xe7\x99\x56\xce\xc8\x75\xed\xb4\xb1\xe7\x39\x3e\x60\x71\x2e\x2a\xb9\x64\x3c\x15\xed\xcd\xae\xca\xfc\x77\x26\x88\xa3\x3d\x6b\x21\xe6\xf6\xc4\x6e\xeb\x8c\x54\xdb\x74\x99\x65\x67\x90\xcc\x7f\x96\x2a\x27\xb6\x8a\xf0\x98\xdf\x51\x43\x4d\xba\x44\x44\xf8\xf2\xee\xf6\x2b\xc4\x45\x99\xe2\x2d\x90\x10\x88\xdb\x3c\x66\x1b\x3a\x13\x5d\xa4\xda\x20\xc9\x8a\xb4\xb0\x31\xba\x64\xb2\xa2\xca\x2b\x2d\x95\xe3\x3f\xb2\x42\xa2\x3a\xa4\xb1\xad\xd7\xa5\x74\xc4\xd8\x5f\x6b\xb4\x8e\xd8\xb1\x82\x2b\xa1\x94\x76\xb0\x46\xa8\xab\x5c\x38\xcc\x57\x70\xad\xe0\x4a\x94\x58\x5c\x09\x8b\xcf\x4d\x65\x22\xa8\x5d\x12\x05\x4f\xd3\xb9\x6d\x0d\x00\x86\x85\x9f\xbe\xbc\x0b\x16\xd4\xa3\x1f\x00\x44\x9e\xb3\x75\x11\xc5\xe7\x81\x87\x07\x31\xe8\x55\xa3\x66\x25\x66\xb3\x82\x5a\x59\x67\xea\xcc\xd5\x06\x73\xb8\xc7\x7d\xe0\x78\x29\x2a\xb0\x4e\xd3\xc5\x47\xe9\x76\x9d\x15\x45\x9b\xfb\xc2\xb1\xb8\xaf\x11\x2c\x3a\x58\xef\x81\x6c\x28\x2b\x84\xd3\xba\x20\x56\x79\x58\xac\x18\x06\x9d\x91\xf8\x80\x5d\x90\x66\x2d\x9d\x11\x66\x9f\x68\xb7\x82\xaf\x3b\xdc\x83\x30\x08\xc4\xe6\x5f\x6b\x34\x7b\xb1\x2e\x3c\x9c\xa0\xb0\x6b\x04\xd6\x74\xf3\x80\x79\x07\xe4\xe3\x0e\x15\x94\x3a\x97\x9b\x3d\x49\xae\x17\xcb\xae\xf2\x5d\x9c\x9f\xdf\xd7\x6b\x34\x0a\x1d\xb2\xc5\xcf\x75\x66\xcf\x6b\x8b\x66\xb9\xad\x65\x8e\xe7\x2d\x06\x9d\xcd\xfa\x48\xef\x21\x1f\xfc\x94\x15\xb5\x75\x68\x6e\xc8\xde\x8f\xf1\xe4\xeb\x0e\xd9\xb6\x93\x5d\xf2\xb2\xcf\xcf\xc1\xe3\x4e\x66\x3b\xd6\x86\xa0\x4d\x6b\x2c\xb4\xda\x12\x35\x89\x2e\x47\x1a\x47\xff\xa4\x85\xda\x62\x4e\xe4\xce\xa5\x75\x52\x6d\x6b\x69\x77\x89\x51\x96\x39\x09\x96\xd6\xe2\x05\x89\x8a\xf4\x1f\x5b\x89\x8c\xc8\x01\xb9\xdc\x6c\xd0\x1c\x6b\x5e\x6b\x33\xd6\xaf\x0c\x1b\x89\x05\xdb\x09\x62\x0b\xf1\x5c\xa8\xfd\xe3\x0e\x0d\x82\x21\x67\x03\x4a\x3f\x32\x8f\x44\x25\x2d\xeb\x3d\xf4\xa0\xbb\xd5\xc4\x13\xa7\x41\x6e\x15\xf3\xc3\x81\xdc\xb0\x04\x49\xe5\x1d\x28\x82\x36\x41\xb3\xa3\xde\xaf\x66\x13\x25\xbf\xeb\x81\xc7\x98\x30\xbf\x3a\xbe\x9d\x76\x27\xc0\xa5\x3f\x3b\x26\xd0\x6f\xec\x08\x28\xf0\x13\x5e\xee\xd8\xbe\x05\xde\x3d\x0a\x1b\xb6\x44\x26\xca\x45\xd2\x6d\x6b\x61\x84\x72\xe8\x99\xe6\xf5\xa7\x03\x51\x2a\xd8\x89\xaa\x42\x65\x97\x6b\xdc\x10\xa5\xb4\xc9\xd1\x80\xc8\x8c\xb6\x16\x2c\x56\xc2\x10\x85\xc8\x3c\xf0\x1e\xec\x0a\xae\xd8\x80\x7a\x6b\xab\x74\x17\x26\x51\x99\xf1\x63\x6d\x8f\x28\xa5\x3d\x62\x4e\xe2\xf0\xe5\xfd\xd5\x9b\x37\x6f\xfe\x48\x8e\xbd\x64\x76\x4a\x4b\x97\xbf\x7d\xbd\x5a\xc1\x9d\xea\xc0\xfc\xac\xab\x9a\x9c\x63\x4e\x16\x80\xe4\xd6\xee\xad\xc3\x72\x05\x5f\x50\xe4\x4b\xad\x8a\xfd\x0a\x6e\xea\xa2\x20\x78\x50\x48\xeb\xec\x73\xdb\xe7\x68\x37\xe6\x47\xb8\xd1\x06\x84\xe3\xa0\x08\x97\xc4\xa0\xa9\x42\x94\x63\x81\x44\xd1\x3f\x19\x91\xe1\x67\x34\x52\xe7\xb7\x98\x69\x95\xdb\x51\x69\xba\xa9\xcb\x35\x1a\x52\x68\xeb\xef\x06\x51\x14\xfa\x11\xf3\x10\x23\x35\x72\xe1\x34\x6c\x09\xf6\xa6\x2e\x8a\xfd\x11\x48\x00\x87\xa6\x94\x8a\x78\x1b\x18\x2f\x1d\x3c\xca\xa2\x20\x87\x67\xb0\xd4\x0f\x98\x37\x0e\x34\x52\xfb\x93\x2a\xf6\x24\x47\x2c\x84\x1d\x90\x71\x47\x87\x72\x5e\x58\x4d\x8f\xac\xe0\xa3\xd8\x03\x71\x8a\x56\xb0\x3b\x6d\x1c\x2a\xcc\xdb\x1c\x1c\xa0\xac\x54\xee\xdf\x7e\x7f\xf4\x9b\xb7\x8c\x14\x1b\x6d\x8f\xf4\xa4\x83\xc4\xb8\x6e\xbe\xed\xc3\xf9\xcb\xfb\x2b\x60\xe9\x24\xa6\xb2\x74\x12\x63\x41\xb8\x64\x38\x7b\x4c\x4e\xf2\x59\x91\x8a\x8c\x09\xe6\xc7\x66\x2d\xb8\xb1\x46\xcd\x99\x98\x20\x12\xb3\x06\xe9\x0a\x32\x85\x28\x8d\x22\x90\x27\x59\x44\x0d\x22\xbd\xcf\xa5\xc1\xcc\x79\x3e\x39\xf6\x68\xeb\x2e\xf7\x45\x08\x83\x08\x39\x6c\xdc\xad\xb4\x80\xdf\x2b\xcc\x5c\x32\x1a\x61\x13\xf0\x42\x69\x20\x17\x81\x06\x1e\xa4\x95\xeb\xe2\x58\xce\xc1\x4b\x4b\x02\xc5\x4a\xe8\x11\x23\xac\x0c\x8a\x6c\x17\xb0\x61\x97\xf4\x12\xc4\x86\x5c\x11\xed\x81\xa9\x2b\xbb\x5a\xef\x12\xe1\x16\xa0\x15\x07\x83\x08\x1b\xa9\x44\x21\x7f\xa3\x78\x8f\xd6\x20\xa2\x60\x59\xb9\xfd\x0a\x2e\x2d\xa3\x08\xc2\x1e\xdd\xd8\x01\xcc\x0f\x92\xde\x0b\x49\xc1\x8a\xc3\xd2\x2e\x0e\xc8\xbc\x2e\x74\x76\x4f\xbc\xfb\x14\x97\xcd\x8f\x05\xa5\x03\xd4\xf3\x76\xd1\xb2\x7d\xd1\x44\x12\x21\x6b\x45\x8c\xd7\x26\x58\x62\xd8\xd4\xc6\xed\xc8\x79\xa9\x10\xfb\x6f\x6a\x8a\x93\x16\x1d\xb0\xa2\x70\x3b\x5d\x6f\x77\x20\x9b\x48\x28\x6a\x0f\x84\xb4\x28\x51\x3d\xdc\x10\xb9\x56\x19\xa9\x7b\xdc\x08\x2d\x48\xf9\x95\x2c\x71\x05\xef\xb5\x01\xfc\x2e\xca\xaa\xa0\xec\x82\xbc\xbc\x09\x09\x06\x4b\x9a\x0f\xc1\x04\x54\x9a\x25\x2c\x40\xee\xc0\x94\x0a\xde\xbc\x8a\x26\xc9\x4b\xd5\x9f\xeb\x35\xdd\xec\xad\x0a\xf1\x9f\xe5\xde\xa2\xca\xc9\x37\x37\xf2\x9e\x4c\xd1\x71\x32\x45\x5f\x2b\xb7\x3e\xd6\x63\x1a\x05\x96\x11\xef\xa5\xe2\x2b\x95\xce\x57\x70\x19\x24\x49\xb8\x16\x12\xc4\x88\x84\x44\x07\x2e\x23\x45\xb8\x80\x80\x9d\x30\x79\x1b\x89\xb8\xe8\x8b\xdb\xeb\x3f\xfd\xf9\xfa\xc3\x87\x97\x9d\xe5\x49\xac\x3b\x20\xbd\x3c\x67\x05\x0a\x55\x57\x8b\x60\x44\x23\x92\x8d\x2d\xbd\xfc\x7c\xcd\x99\x04\xfd\xdf\xbb\xc4\x0c\xc9\x9c\x2b\x74\x8f\xda\xdc\x77\xc0\x56\xc2\x38\x0e\xd3\xed\xe2\xc0\xbc\x13\x8f\xac\xa3\x6d\xe0\x77\x12\xe7\xa8\x4e\x81\xb1\x2c\xa3\x0b\xa8\x95\x93\x45\x17\x55\x05\x22\x2f\xa5\x92\xd6\x19\xe1\xb4\x21\x39\x12\xb5\xd3\x25\xbb\xd8\xca\xe8\x0c\xad\x85\x4c\x28\xc8\xd1\x13\x06\x0f\xe5\xac\xc7\xfe\xb1\x9b\x49\x64\x24\xdd\xb9\xde\xc4\x18\x6e\xd1\x30\x3b\x69\x59\x08\x49\xc3\x6e\x76\xa2\x0b\x91\x1e\x5e\x23\xaa\xc6\xe8\x51\x6c\x30\x14\x0b\x1c\x9b\xd1\xb4\x52\x07\x6e\xdb\x8c\x1e\x44\x10\xff\xcf\x23\x86\xc6\xa0\x8d\xfa\xb4\x8f\xb5\x25\xba\x79\xab\x18\xbd\x7b\x8b\xd4\x8d\x16\x37\x42\x69\x70\x4b\xb2\xd0\xf1\xc1\x00\xef\x44\xb6\x03\x54\xce\xec\x43\x52\x27\x73\x0a\x54\x37\x12\x4d\xaa\xca\x18\xb4\x95\x56\xec\x15\x20\xd3\x65\xa5\x15\x72\xb2\x4d\x0e\x53\x16\x5d\xf1\x6b\xa9\x86\x87\x9c\xf0\x20\xc3\xcc\x82\xd3\x6b\x72\x0f\x65\xa6\x03\x96\x1d\xa0\x5a\x2a\x59\x2c\x18\x63\x89\xc1\x4c\xc8\xe0\x2a\x48\xa0\x63\x04\x12\x62\x9c\xe3\x0d\xb3\x2f\x38\x26\xef\x08\x4f\xe2\x4f\xc2\x18\x71\xe8\x66\xb7\xa8\x28\x66\xc6\x93\x49\xda\xfc\x4f\xad\x3b\x03\x91\x35\xff\x26\x0a\xca\x3f\x37\xf2\xfb\x82\xcc\x72\x23\xef\x9c\x1d\x74\x3d\x85\xd3\x69\x51\x32\xe4\x4a\xfe\x5a\x87\x6c\xec\xd3\xcd\x87\xff\x81\xeb\xf7\xfc\x34\xe1\x13\xa2\x91\x9d\xb0\x8d\x92\x55\x46\x3f\xc8\xbc\x4b\x11\xf0\xec\x68\x87\x30\x84\x0c\x19\xa3\x00\xdd\xa0\xab\x8d\xf2\x21\x43\x53\x61\x49\xd1\xe4\x70\xe6\xe7\x76\x42\x35\x60\x2a\x61\x6d\x0a\x97\xbc\xff\x64\x10\x1c\x41\xae\xc9\xfa\x96\x6b\xa9\x42\xd1\x20\x6d\xb0\x03\xd4\xd6\x9b\x8d\xfc\x4e\x60\xc8\xbe\xfa\x3d\x05\x77\xbc\x0b\x91\x01\xa7\xa9\x4d\x95\x12\x4c\x5d\xa0\x8d\x61\x03\xd1\xa7\x03\x34\x04\x21\xb1\xf8\xb6\x46\x70\xa6\x56\x59\xdb\x0a\x15\xa8\xb6\x6e\x17\x45\xd4\x63\xc1\x76\x46\x52\xa1\xc3\xe9\x0e\xcc\x52\xdc\x7b\xbd\xf4\xc8\x05\x7e\x69\xd5\xe2\x31\xdb\xbb\x0e\xf9\xa9\x8c\x2b\x37\xb2\xc7\x0b\x13\x7e\xf4\x74\x14\x03\x9f\x83\x7b\x07\x61\x17\x2d\xc0\x9e\x39\x37\x9f\xa8\xd0\x46\xcc\x03\x01\xbf\x7f\xf5\x47\x58\x76\x20\x4a\x65\x1d\x8a\x7c\x91\xd2\x03\x94\x1c\xb6\x84\xc7\x7e\x79\xf5\x1a\x38\xbd\xf5\xb1\xc8\x1f\x5e\xbd\xf2\x85\x80\x2f\x28\xac\x56\xa1\x30\x47\xfa\xab\xeb\x1e\x7d\x55\xb9\xcc\x04\x27\xbd\x87\xe2\x9a\x71\xf5\x25\x04\x4e\x1b\x5d\x53\x7a\xa8\x9a\x48\x91\x12\x1e\xe7\x30\x5f\x0c\xee\x3f\x48\x60\x28\xe3\x18\x24\x1b\xf3\x22\xea\x54\xb1\xef\x86\x9e\x8c\x08\x67\xa6\x1d\x98\x04\xef\x0b\x41\x58\xfa\x30\x63\x87\x22\x47\xf3\x92\x59\x73\x59\x55\x85\xa4\xad\x93\x51\x91\x1b\x88\x1a\x4c\xa8\x27\x2e\x75\x15\xea\x79\xfd\x8c\xcc\xb1\xac\xb4\x43\x95\xed\xe7\xb3\x89\x66\x2b\x08\xc8\x51\x59\xbc\x63\x9a\x2e\xc1\x92\xa3\xa4\x18\x58\xf9\xbc\xf3\xa0\x54\x21\xe2\x26\xb3\x28\x71\x14\x3e\xeb\xcd\x11\x48\x08\x01\xb4\x65\x4d\xb0\x4e\x38\x5c\x0d\x79\xf1\x67\xcf\x07\xb9\x83\x32\xc5\x6d\xce\x2f\x55\xfb\x66\x62\xa3\xa0\x92\xbd\x33\xba\x28\x52\xcd\x0c\xd5\x46\x73\xbd\xcb\xea\x32\xe2\x7c\x04\x95\x04\xfb\x41\x18\x29\x94\xa3\x94\x31\x78\xdd\x58\x33\x0a\x51\xf7\x61\x4e\x28\xbc\x7f\xd2\x9b\x36\x06\xdd\x80\x88\x43\xf1\x9d\x78\xf0\x25\xcb\x3d\xd5\xc6\x38\x55\xd3\x07\x05\x21\xf6\x9f\x4a\x16\xa4\x90\x1c\x03\x1c\xc4\x8d\x1d\xa0\x64\x14\xd9\x01\x90\xe7\xa6\xe0\xbe\xd8\xb7\xb0\xa0\x14\x88\x14\xfe\x51\x5a\x5c\x1c\x45\x11\x19\xf9\xfc\x1c\x4d\x8f\x21\xaa\x55\x0b\x44\xcc\x4e\x77\x32\xcf\x51\xc1\x0b\xa9\x78\xbb\xe7\x8f\xc2\x65\x3b\xfe\x71\x8b\x0e\x32\x51\x14\xf6\xa5\x0f\x49\xbc\xfe\x8e\x10\x40\x9d\x39\xca\x54\x0b\x99\x49\x4a\x75\x85\xbd\x67\x1b\x0b\x7a\xcd\x86\xf3\x68\xfd\x54\x9b\xed\xa9\x2c\xfd\x37\x47\x8d\xb1\x67\x03\x32\xd5\xd2\x16\x07\xb1\x25\x99\xcb\x2a\x88\x6c\x2b\xa2\xe8\xad\x5f\xd3\x73\x59\x6d\xa8\xd8\x49\xc1\xef\x31\x5b\x43\x19\xa5\x32\xf2\x41\x16\xb8\xc5\x9c\x9c\x7b\xe8\x5e\xf0\xed\xdd\x8c\xcd\x97\x99\x9b\x75\x43\x5e\x2a\x9b\xec\x77\x11\xd3\xc3\x60\x35\xf9\x09\x49\x21\x9e\xcf\x33\x3b\x20\xd7\x7b\x10\x6a\xcf\x4b\xb3\x29\x7b\xfb\xee\xf3\x97\x77\x57\x97\x5f\xdf\xbd\x85\xe5\x01\xba\x5c\x22\x17\x0a\x44\x51\xed\x44\x10\x59\xe2\x59\x6f\x64\xd7\x2a\x1e\x49\x05\x0f\xaf\x57\xaf\xff\xb0\x3a\x36\x4a\x43\x9d\x0a\xfa\x56\x3e\x3b\xec\xfe\x70\xa4\xac\x9f\x43\x16\x39\xa8\x3b\xa1\x73\x40\xa1\x30\x7e\xc7\xac\x76\x5d\x9f\x1e\xd2\x56\x5f\xf0\x4c\x61\x72\x52\x14\x22\x6d\x28\x75\xac\xbc\x94\x10\x5f\x0b\x61\x5d\xc4\x72\x00\x62\x42\x82\x20\x04\x6a\xc4\x42\x08\x6c\x84\x2c\xc8\xe1\x19\xb4\x75\xe1\x42\x3d\xc8\x8b\x5a\x1b\xfd\x5e\xd0\xbe\x99\x92\xe2\x2a\x92\x15\xa7\x59\xd3\xa3\xdf\xeb\xd3\x4d\x8a\x6b\x1a\xd0\x5d\x55\x8d\x7e\x33\xec\x95\xb4\x48\x14\x45\x54\xc1\xae\xf3\x1a\x8c\x91\x4f\xf1\xd6\x7f\x55\x4f\x38\x3c\xc0\xe4\x76\xe7\x22\xe6\xa4\xcc\x56\x69\x0f\x52\x0e\x4a\x43\xd2\x0e\x87\xf8\x12\x55\x33\xf1\x77\x35\x1b\xbc\x69\x38\xd8\x8f\xf9\xcb\xaf\x35\xf9\xb2\xfe\x7d\x2c\x39\x88\xe9\xfd\x69\xb0\xa1\x33\x9e\x4a\x84\xf2\x62\x5d\xb8\x8b\xd9\x09\x9a\x5d\x6f\x0e\x45\xcb\x87\x63\x44\xc1\xf7\x42\x16\xb5\x09\xa1\x7f\xdb\x94\xf7\x80\x0c\xf5\x11\xea\x7f\x51\x13\xdc\x86\x7a\x20\x35\xda\xc4\x36\x54\x44\x49\x23\x42\x1e\x49\xe9\x96\xad\x29\xc8\xf0\x6a\xa7\x7b\x2d\x0e\xfd\x0b\x52\xc5\xa5\x85\x68\xab\xdb\xa9\xde\x6a\xf6\x74\x99\xea\x6f\xf1\x0f\x52\xe8\xa9\xed\xfe\x01\x98\xd0\x84\x42\x31\xec\x79\x52\xeb\x7f\x10\x6c\xef\x48\xc0\x53\xc6\x00\x06\x21\xff\x13\xc7\x03\x26\x05\xa1\xf1\x9b\xe9\x1c\x27\xb1\xee\xb6\xde\x6e\x7d\xf1\xfb\x3f\xbf\x7e\xfd\x1c\x53\x17\x7a\xbc\x69\x7e\x50\x78\x59\xdb\x05\xbc\x02\xd9\x8d\x43\xe3\x27\x94\xa5\x86\x4c\x40\x2b\xd2\x7c\xf3\xcb\xc0\x3d\xc3\x11\x67\xfc\xe4\xe8\x84\x2c\xec\xa4\x9d\xbd\xa3\xc1\xa0\x1c\x73\x6a\x23\x09\x10\xd6\xea\x4c\x72\x70\x9c\xd4\xd7\x70\x46\xb5\xf2\x05\x99\x01\x90\x24\x93\x74\x17\x4b\x86\x97\x6d\xa0\xb9\x06\xfd\xa8\xb8\x6d\xee\x57\xf0\x68\x1d\x85\xa0\x83\x10\x53\x25\x22\xfa\x18\xc6\x30\xa5\xfc\xbd\xcd\xc6\x4c\x53\x94\x5c\xce\x06\x40\x92\x29\xa1\xd8\x23\xe8\x19\x7e\xcf\xb0\x0a\xe5\x22\x8f\x74\xca\x09\xc2\x76\x88\xd6\x43\xbc\x3a\xed\x71\x00\x32\x51\xdb\xb1\xdf\x8f\x98\x41\x95\x83\x2b\x7e\xc4\xdb\x62\x90\x2a\x2b\xea\x1c\x2d\x94\x94\xb8\x05\xbe\xb6\xb8\x34\x02\x18\x1a\x03\x7c\xcb\x92\x19\x32\x63\x8a\x03\x6a\x83\x2b\xb8\xd1\x8e\x3a\x78\x07\xbf\x72\x2c\x38\x0a\x34\x14\x36\x02\x2e\x98\x87\x2d\x0e\x11\xe9\x84\xd7\x7e\x0a\x2d\x83\x86\x90\xd8\x9c\xba\xe9\x88\xac\x73\xa2\x2b\x7b\x9f\xe8\xd4\x53\x39\x39\xc4\xf5\x54\x72\xa6\xda\xd2\x49\xb8\xc1\x91\xa3\x31\xda\x2c\x28\xc0\x21\x8f\xcb\x52\x43\xe2\xfe\x5f\xb7\x9f\x6e\xa8\xce\xc1\xf1\x80\x18\x72\x2b\xc7\xdf\x8f\x0d\xa3\x21\x27\xa6\xa8\x1c\x2a\x6d\xdd\x46\x7e\x87\x38\xa1\xc1\x66\x46\xb1\x09\x9a\x00\x51\x38\x3f\x5d\x45\x36\xf7\x92\x04\xc9\xc7\xd2\xbf\xa1\xd1\x4b\xa9\x72\xfc\x4e\xd5\x2e\x78\x4f\x14\x39\xcd\xf1\xe8\xeb\x2a\x14\xc6\xcb\x21\x57\xcf\xb8\x2d\x26\x39\x83\xf1\xb2\xaa\x37\x41\x16\x20\xef\x29\x8e\x75\xbf\x4e\x7b\x1b\x60\x29\xaf\x22\x0f\x5e\xd6\x85\x93\x55\x81\x9e\xba\x76\x05\x9f\x82\x05\xe0\x34\xe1\x9d\xef\x14\x9d\x14\x10\xfa\x77\x07\x70\x37\x27\xce\xdc\xcd\x61\x19\x5a\x72\xc4\xfd\x74\x51\xab\x76\xae\x34\x01\x62\x12\x18\x82\xcc\x02\xfd\xd7\x57\x7f\x5b\x8d\x2c\x31\x01\x66\x40\x62\x23\x0d\x35\x51\x98\x86\xa1\xdc\xad\xe2\x22\x77\xf3\xf9\x6c\x04\xc2\x34\x2f\xd7\x7c\x4a\xb4\x56\x6c\x47\xa2\xe0\x5e\xf5\xb9\x84\x5d\x5d\x0a\xb5\x34\x28\x72\x6e\xa4\xb6\x7e\x8d\x0a\xc5\x9c\x3f\x09\x16\xe2\xed\xcc\xe1\x15\xb4\x3d\x41\xa8\x6e\x86\xc8\x86\xb3\x87\xe5\x88\x77\x68\xbe\x64\xd3\xc9\xfd\xe4\x68\x56\xcf\x49\x2c\xef\x02\x9e\x4c\xab\x52\x64\x3b\xa9\x70\x8c\x5a\x27\x41\x06\xc7\x71\x44\xad\x58\x8e\xe5\x68\x2a\xe5\xdf\x04\xd0\x4c\x01\xc9\x0e\x93\xa3\x2f\x8a\x31\x08\x1b\xf1\x20\x64\x41\x1c\x7d\x46\xba\x9d\x48\x34\xa6\x24\x1c\xf1\xe3\x87\x85\x67\x13\x29\x4f\x36\x9e\x9f\x68\xac\x5f\xc7\xda\x3f\xd5\x71\xfa\x90\xee\xc0\x43\xae\x66\x3f\x49\xa4\xe3\x51\xd5\xd1\x4d\x9d\xd1\xae\xe8\x89\x7f\xf0\xa6\xe0\x93\xf2\x75\xc5\x66\xdc\x8a\xfc\x42\x98\x9d\x1b\x85\xdb\xea\xe4\x85\xce\x66\x83\x1a\x0d\xde\xfe\x93\xc6\x55\x7f\x88\x17\xe3\x25\x81\x1e\x01\xa3\x07\xfe\xb1\xac\x80\x17\x61\xcc\x0e\x89\x68\x54\x64\xb2\x52\x6d\x0b\x1c\x4e\xed\xe3\xd7\x97\x89\x29\xbf\x5d\x47\xa3\xb3\xc6\xfc\xe5\x4f\x0b\x2c\x37\x31\xb8\x03\x31\x30\x25\x36\x48\xb1\xeb\x4d\xd3\x8b\x58\xb4\x9b\x1e\x71\x52\xa2\xd5\x23\x1e\x81\x09\xcd\x10\x60\xcc\x6a\xb9\xda\x47\x13\xb7\xf9\x0a\x6e\x49\x6e\xd9\x44\xc6\x39\x6c\xdf\x53\x19\x85\xd8\xea\xd5\x70\xa9\xce\x51\x4b\x8c\x42\x99\x82\x67\x7c\x69\xf8\x2a\x23\xbb\x02\xcb\x90\xe0\x69\x1b\x17\x39\x01\xf7\xc0\xa1\x45\x5c\x60\xa7\x1f\xfd\x88\x90\xd3\xf0\x28\xa4\x4b\x3b\x17\xf7\x63\xb4\x8f\xa8\x1e\xa3\x35\xc6\xd4\x29\x39\xe4\xb4\x3c\x92\xbe\xb5\x7c\x82\xb5\xfa\x76\xfd\xf6\x58\x27\x56\x43\x02\x3d\x9b\x14\x6e\x0d\x09\xf5\x93\x87\x9d\x9b\xe1\x01\xfb\xbb\x5a\xfe\xb4\xed\x38\xe9\xe6\xc6\xcc\xfc\x33\x9c\x4e\x98\x8d\x0a\x60\xa8\xc6\xfe\xc8\x49\x85\xd9\x04\x8d\xf9\xa1\x53\x0b\x83\x80\xff\xe9\xee\xe1\x24\x7b\x4f\x84\xc9\x4f\x0e\x8e\x83\x99\x3f\x55\xd6\x4b\x56\x6e\xf5\xe3\x88\x77\x8f\x67\x0c\x62\x7e\x76\xeb\x84\xca\x69\x02\x8d\x1a\x3b\xe9\xd9\x7f\x81\xbf\x9e\x54\x49\xd1\xa4\x09\xf5\x74\x77\x1d\x1f\x88\x89\x05\x35\x2d\xe4\x26\x4d\xae\x72\x89\x9a\xba\x9f\xa5\x74\xb3\x09\x59\x5a\xe8\x42\x53\xb3\x87\x12\xb3\x50\x02\x8c\xfd\x95\x68\xe7\x43\x9b\xe0\x94\x3f\x0b\xa3\x10\xd4\x00\xe5\x84\x9a\x78\xd6\x8a\xc6\x39\xd4\x48\x51\xbe\xae\x04\x8d\xd3\xf4\x0d\xfe\xb5\x3f\x61\x9b\xf1\xac\x84\xb4\x96\x1f\xd2\x61\x68\x22\x8c\x54\xea\x03\x65\x67\x6c\x4f\x63\x9a\xb7\xfa\x8e\x4e\x07\xcf\x1b\xea\xe7\x0a\xbf\xa7\x5e\x63\xda\xc1\x28\xc8\xd4\x13\xbd\xf2\x1c\x22\xfb\xc6\xfd\x6e\x2e\xf7\x2b\x17\xc4\xb1\xe9\x28\x56\xda\xf6\xcf\xfd\xb6\x3f\x72\xd3\x1e\x32\xa1\x3a\xa0\xdc\xd6\x21\x68\x20\x3a\x67\x3b\xa1\xa8\xe3\xa9\xdb\x35\x0c\x31\x0a\x72\x83\x8f\x50\x4a\x45\x65\x14\x2a\x51\xb4\xe7\x84\x1a\xff\x16\x0b\xfa\x3e\x89\x8d\x52\x31\x0a\x97\xfd\x61\x4d\x5e\xd0\xd3\x35\x49\x6a\x6b\xf4\x68\x8d\xe0\x3d\x56\x96\x66\x50\x47\x61\x06\x69\x69\x57\x14\x42\xa3\x0a\x69\x14\xb3\xa0\x0e\xd6\x5e\xd7\x7e\x1f\x06\x33\x94\x7d\x27\x8b\xda\x1f\x46\xcd\xe9\x7b\x54\xde\x49\x08\xe5\xe3\x9f\x68\x1d\xc7\x42\x90\x93\x86\xaa\xed\xe3\x03\x05\x27\x2b\xf6\xd9\xad\x6b\x1a\x3e\xc9\xad\x87\xf9\x2a\x66\xff\xd9\x99\x4d\x6d\x8b\x11\xa8\x10\x3b\x2f\xb1\xe1\x12\xfd\x26\x69\x45\x8c\x39\xe2\xf8\x5b\xec\x1f\xf5\x8c\x53\xb5\xbf\xcd\xd4\x2a\x73\x39\xc8\xba\x27\x7b\x10\xc1\x15\xfc\x85\x99\x55\x86\x69\x49\x47\xf3\x19\x27\x98\x21\x92\x19\x68\xa1\x42\x86\xc7\x8b\x24\xd4\x2a\xb5\xdd\xd7\x22\xbb\x9f\x22\x31\x71\xce\x6b\xc2\x38\x4c\xcb\x23\x8c\x82\x7c\x06\x6f\x91\x69\xe5\x07\x18\xb2\xfd\x32\x8c\xc0\x2c\x85\xca\x97\xc9\x3c\x64\xfb\xb3\x9f\x15\x3c\x8b\xc5\xe6\x83\x54\xf7\x93\x25\x2e\x3e\xe0\xa3\xb4\x6f\x5f\x3e\x1c\x07\x67\x49\x74\xc6\x94\x62\xd2\x59\xa2\x9f\xdb\xdb\xc9\xa8\x74\xbc\xa6\xf5\xc4\x4a\xd6\xe3\x2e\x0c\x86\xa4\xc0\x65\x00\x2e\xd7\x9e\xc2\x1c\xdd\x3c\x74\x83\xe7\x21\xf9\x1d\x2f\x6b\x8d\xf5\x87\x06\x8b\x59\x70\x19\xa7\x00\xb3\x42\x18\x9a\x84\xe3\xc9\x56\xee\xdc\xf9\x45\x07\x61\x72\x47\x6f\x5d\x3b\xc8\x35\x52\xb9\xcc\x81\x7e\x40\x63\xa8\xe1\x21\x3b\xa7\xf4\x26\x33\xc6\x2f\x3a\x89\xea\x67\xb7\xad\x58\xb1\x55\x8e\x59\xc1\x27\x45\xc3\xfa\x17\x30\xbf\xad\x33\x1a\x92\x9f\xf7\x8d\xeb\xc4\x4f\xa2\xf2\x73\x47\x73\x94\xcf\xb3\x42\xfa\x3d\x9d\xfd\x18\x49\x46\xe4\x74\x68\xc2\x61\x39\x30\xfb\x32\x08\xaa\x10\x6b\xec\xf6\x40\x9f\xf9\xe4\xf1\x47\x51\x91\xf3\x08\x89\xdb\x3d\xee\x49\xd2\xe2\x71\xf8\xae\x1f\x71\x1a\xb4\xd9\x0a\x6a\xc3\x77\xd6\xa4\xe7\x28\x84\xdc\x6a\x23\x7f\x43\x78\xc1\xef\x35\x60\x68\x16\x0b\xcc\xdc\xcb\xb0\x49\x3a\x5f\x28\xf6\x50\xf2\x08\x9b\xff\x49\x1b\xdb\x37\xfb\x68\xb0\x2a\xa8\x12\x42\xea\xda\x8c\x13\xda\x00\xd3\x3c\xc8\x0c\xed\xd3\x13\x69\x4f\xd7\xb3\xa9\x6c\x28\x85\x12\x5b\xcc\x7d\xaf\xe9\x62\x8c\x98\xf3\x8f\xed\x5b\xa1\x14\x95\x05\x3a\x97\xb2\x29\xf4\xe3\x52\xe6\x8c\x76\x74\xd8\x61\x44\xa1\xef\x60\xa9\xde\xc4\xb6\x12\x93\x9f\xfa\x5e\x01\x07\x72\xe3\x7c\x2d\x42\x0d\x9d\x68\x49\x51\xb8\xa5\x69\x3e\x2a\xf5\x0c\x06\x0e\x3b\x5d\x5b\xbc\x47\xac\xa4\xda\xfa\xa8\x9f\xf2\x08\x4b\x76\x59\xd2\x08\xe1\x3e\x14\xa7\x68\x42\x50\x85\x7e\x74\x38\x79\x55\xab\x1c\x8d\x75\x7d\x21\x7c\x53\x30\x5a\xc1\x65\xda\x6f\x94\x9a\x98\xad\x9c\xf9\x46\xe3\xe2\x60\x30\x34\x5e\xec\xc0\x0c\x87\x23\xe2\x14\x53\x6b\x58\x56\x54\x15\x0d\x00\x0a\xb7\x83\x42\xde\x23\xdc\xcd\x33\xb9\xcc\xf2\xbb\x39\x91\x02\x63\x1c\xef\xe9\xd7\x01\x4b\xde\xaf\x78\x14\xfb\x64\xcb\x13\x37\x42\xce\xd3\xa0\xcf\x51\xd3\xd1\x39\xf5\xbe\x80\x24\x0e\xad\xdc\xa9\xc3\xa1\x80\x30\xf3\x47\x44\x0e\x94\x68\xc5\xef\x71\xce\x8f\xca\xa8\x7d\xd3\xdd\x4a\x3b\x99\x61\x67\xfa\x6f\xa0\x0d\x3d\x9e\x7c\x9e\x1a\xf1\x39\x90\xe0\xf1\xf9\x9e\x14\x65\xc6\xc0\x77\x2c\xfb\x6a\xd5\x11\x89\x29\xc4\x37\x72\x63\xfe\x94\x3c\x86\x1a\x1f\xb9\xd5\x39\xf7\x3c\xce\xc3\x1a\x73\xf8\x7b\x7d\xf4\x1a\x8f\xe6\xcb\x1c\x27\x36\x39\x5d\x2d\x0b\xb2\xf0\x6d\x8c\x83\x0c\x86\x63\xdc\x48\x2e\x86\xde\x5a\x40\x9a\x66\x44\x76\x3f\x88\xe7\xc1\xfe\xe2\x98\x26\xe1\xbc\x46\x6e\x0a\xd2\x78\x68\x96\x6a\x43\xe1\xac\x97\x57\x98\x01\x98\x61\x64\xa9\x6f\x7e\xfd\x84\x71\x4e\x03\x02\xbd\xbc\x1c\xb0\xfe\xe0\x4c\x8d\xa7\x99\x1b\xcc\x52\x2b\xe1\x10\x87\xfa\x32\x86\x6d\x8f\x61\x6c\x9b\x47\x33\x41\xb8\xbc\x75\x34\xdd\xb3\x50\x7a\x73\xa8\x7b\x0c\xb2\x9f\x36\x81\x63\x16\x27\xa0\x3c\x48\xe0\x14\x93\x4c\x40\xfa\x53\xbc\x37\xbe\x55\x87\x60\x13\xc9\x12\x90\x50\xe1\x2d\x50\xf4\x1e\x55\x89\x38\x4b\x0b\x07\xee\xe1\x1d\x37\xca\xd7\x48\xf1\x77\x7a\x05\x01\x69\x06\x45\xd1\xe4\x7f\x65\xf4\xc2\x03\x20\xd3\xd8\x56\x98\x2b\x36\x08\x67\x74\xa8\x62\x7f\xc6\xa6\xfd\xec\x1b\x17\x31\xcf\x7e\x88\x42\xd4\xe5\x98\x40\x1c\x3a\x9d\x02\xed\x43\x93\x44\x98\x58\x2c\x4f\x3c\x82\x47\xea\x04\x8d\xcc\x8c\x5d\xa7\xe3\x26\xc1\x3a\xa7\x13\x78\x72\x73\xc8\x80\xb0\xc1\xd9\x58\xd7\x60\xe8\x6c\xe0\x84\x8d\x8f\x88\xfa\x50\xbb\xb7\xaf\x01\x77\x40\xa3\x33\x3e\xd8\x12\x53\xe5\x70\x54\x87\x0c\x3f\x4d\x9e\x34\xef\xf9\x58\xc1\xb5\x6d\x8e\x3c\xf5\xbe\x23\x80\xa5\x24\x0c\x40\x73\x09\xdd\x2e\x9a\x13\xce\xdc\xfb\x4c\x3f\x70\xc9\x90\xce\xfa\x3c\xa6\xe3\xea\x7d\xb2\x99\x8a\x6a\xcd\xb9\xa7\x68\x06\x15\x88\x8a\x1c\x8b\xa1\x66\x60\x78\x2f\x49\xdb\xf2\xad\xfa\x0f\x7b\x49\x0b\x95\x91\xa5\x30\x92\x8f\x42\x84\xb9\x39\x12\xd5\x74\x88\xa3\x39\x73\x43\xd5\xbd\xfc\xa8\xd2\x95\xa7\x17\x77\x75\xa5\xa5\xa7\x40\xff\xd4\xd8\xaf\xb1\x3a\xf6\x77\xb4\xa9\x81\x30\xb0\x47\x3e\x12\xa7\x46\xb9\x3d\xbf\x89\xb7\x1d\x38\x50\x7f\x25\x70\x9d\x8e\xf3\x83\xea\x4a\x45\x77\xc3\x97\x2a\xe8\x41\x5a\x9c\xb4\x8d\xf2\x8b\x07\x51\x78\x9e\x32\xf8\xbb\x79\x8e\x1b\x51\x17\xee\x6e\xde\x48\xd4\x02\xd6\x3d\xa1\x45\xfb\xd6\x60\xd1\x32\xa1\xb4\x22\xae\x36\x45\x81\x90\xb1\xc5\x01\xbb\x58\x04\xa2\x50\x34\xca\x68\x07\x72\x78\x53\x0a\x05\xfd\x64\x08\xdb\xc2\x1d\xe6\x8b\xd8\x9c\xa5\x20\xc2\x9b\xad\xa6\x37\x19\x16\x99\x0d\x8d\x53\x87\x37\x15\xdc\xa9\x74\x4a\x57\xc0\xdb\x9b\xdb\xff\xfd\x70\xf9\x1f\xef\x3e\xac\xc6\x85\xa3\x03\x74\x92\xb0\x24\xfc\xed\x7c\xaa\x94\xe8\x47\x85\xe6\x0b\xf2\xeb\x7a\x32\xb4\xa3\xb2\xf2\x21\x9c\xbd\x88\xd4\xcd\x91\x12\xc4\x18\xe5\x37\x15\x19\xaa\x2f\x5c\x7e\xf8\x30\x48\xa0\x10\xcb\x72\xd1\x99\xcb\x74\x6b\x6c\xcf\x97\xb7\x40\x25\x5a\x6e\x85\x59\xd3\x34\x7a\x46\xe7\xb3\xe8\x1c\x54\x57\xf6\xae\x37\x07\x4f\x4a\xdb\x4e\x42\xda\x41\x3c\xad\xe0\xcf\x01\xa5\xd9\xaf\x54\x6c\xef\x40\x0d\x87\x81\x64\x94\x5d\x69\x0f\x20\xa5\xb9\x82\xe6\x62\x2b\x1e\xa3\x27\x4c\x9f\x9e\x7c\xe5\x4a\x4b\x13\xa3\xb5\x67\xfc\x42\xf2\x44\x76\xb3\x01\xba\xfa\x57\x44\xd6\x87\x61\x34\x69\x12\x8b\xc9\x80\x5b\x1c\x14\xb1\x70\x5a\x88\xde\xb2\xf1\x89\xa4\x2d\xbe\x86\x65\x02\x12\xc4\x53\x43\x6f\xc2\xbb\xbc\x79\x1b\xfb\x0d\x2c\xb1\xe9\x78\xef\x9c\x7a\xfa\x14\x90\xab\x3c\xc2\x3d\x96\xfd\xce\x91\xfa\x20\x00\x0d\xb0\x86\x11\x41\x08\x9b\x26\xed\x3d\xee\x97\x6c\x06\x06\x80\xd2\x31\x09\xb2\x87\x4e\x16\x31\xd5\x08\xba\xd4\x3a\x11\xb4\x82\xb7\xde\xdc\x51\x3a\x01\x1b\x51\xd0\x2b\xe5\xbe\x0e\x85\x5e\xe9\x9d\x4a\xf1\x20\x32\x55\x32\x0c\x27\xb8\x16\xe6\x1e\xc3\x39\x1d\xd6\x28\xa5\x6d\xb3\x87\xf7\xd2\x4d\x4d\x83\x9e\xc7\x83\x7d\xf0\xfb\x5f\x7e\x81\x17\xdf\x54\x38\x64\x43\xe5\x3b\x78\xa7\x9c\x74\xfb\x97\x49\xdb\x62\x4f\x65\x8c\xd1\x6b\xad\xe9\xed\x17\x3d\x77\x34\x52\xfb\x14\x0e\x1f\x11\x8f\xdf\xe1\x97\x0e\x46\x4c\xd0\x88\x69\xb8\x0d\xcf\x08\x1c\x60\xe5\x27\x04\x8e\xc5\xfe\x99\x0b\x7b\x27\xdb\xb4\x27\x34\x6a\x78\x94\xea\x70\x2f\x37\xad\xa3\x55\x83\x7b\xf9\xf9\x40\x64\x12\xce\xb5\x9c\x44\xfe\x83\xa9\x96\xe7\xc0\xb8\x96\x3f\x44\xe4\x18\x3b\x74\x71\x5e\xb6\xac\x69\xcf\x8f\xc4\xd5\xd9\xc4\xc3\x62\x4b\xa8\x65\xfe\x1c\xa1\x7d\x8c\xa6\x07\x8c\xfc\x01\x89\xe9\x04\x74\xe8\x6f\xb1\x79\x23\xef\xd3\x1e\x5f\x09\xa7\x14\xe3\x41\xa4\xe4\x08\x66\xbd\x89\xe2\xa4\x2e\xde\x40\xa7\xae\x03\xf1\xb0\x73\xf7\xb1\xd5\x64\xa7\xd8\x4b\x57\x4e\x96\xf4\x56\xc2\x0c\x5a\x9d\xab\x45\x78\x80\xd7\xe0\x31\xb2\xae\x21\x8c\x87\x5a\xfc\x51\xe4\x26\x1d\xa6\x4e\x46\x4a\x51\xe8\x20\x75\xa8\x31\xc4\x4b\xcd\x6b\xf0\x3a\x20\x39\x1e\xe6\x6e\x62\x48\x20\x43\x19\xba\x69\x1e\x3e\xbd\x63\x18\xbb\x84\xfc\xca\xca\xb2\xf5\x1e\x35\x9f\x62\x13\x0d\x84\x7f\x51\x50\x56\x17\xc2\xf4\x60\xde\x01\xd9\xda\xc9\x9d\x9a\xd2\x13\x9b\xd8\x2f\x1d\xec\x91\x3e\xb7\xa9\x9c\xd0\xa3\x9c\x1c\xf1\x0e\xf5\x22\x0f\xd4\xe3\x76\x7a\xff\xf1\x80\x9e\x47\x30\x61\x5a\xcf\x71\x10\xd7\x1e\x73\x79\xa8\xc5\x64\x28\x43\x56\x14\x32\x75\x8a\x66\x65\x78\x29\x27\xe7\x02\xa1\xcb\x97\xaa\x2f\x01\xed\x23\xb0\x10\x5e\xdd\xd8\x94\xd6\x43\x7e\xdd\x12\x13\x16\x4c\xa0\x77\x66\xf9\x7e\x18\xbd\xe2\x29\x65\xc9\x7a\x33\xf6\x6e\xd7\xd6\x3b\xeb\xe2\x2b\x0c\xe9\xec\x98\xd7\x59\xad\xe0\xf3\xb7\xaf\x8d\x46\x1e\x89\x69\x07\xee\x7a\x3f\x40\xd6\x9f\x76\x11\x13\x85\xa8\xd7\x36\x77\x5b\x8e\x3d\xb7\x1d\x5d\x0a\x76\x95\xa3\xf5\x65\x78\xa5\xf7\xc3\x6b\xae\xc4\xbf\x9e\x25\x63\x90\xb7\x0a\xa6\xe1\x58\x6e\xb8\xd2\xac\x29\x32\x3a\x58\x87\xf9\xcd\xf1\xdb\xbd\xe7\xf3\x83\xf7\x79\xf3\x9f\x99\x56\xbe\x2a\x6b\x2f\xe0\xaf\x7f\xa3\x37\x7a\x53\x14\x9b\x07\xbf\x61\x2f\xe0\xaf\x7f\x9b\xfd\xdf\x00\xa9\x8a\x00\xd1\xd3\x5c\x00\x00"),
 		},
 		"/control-plane/crds/kuma.io_dataplanes.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_dataplanes.yaml",
-			modTime:          time.Date(2019, 9, 10, 9, 59, 52, 148765608, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23725,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\xeb\x73\xdb\x38\x92\xff\xee\xbf\xa2\xcb\xfb\xc1\x49\x95\x24\x27\x33\xbb\x57\xb7\xfe\xe6\x73\x92\x3d\xdf\xe4\x55\xb1\x33\x57\x57\x9b\xad\x2b\x88\x6c\x49\x58\x53\x00\x07\x00\x6d\x6b\xfe\xfa\xab\xee\x06\xf8\x10\x1f\x92\x13\xef\xd4\xf1\x9b\x65\xb2\x09\xf4\xf3\xd7\x0f\xf0\x64\x3e\x9f\x9f\xa8\x52\xff\x8a\xce\x6b\x6b\x2e\x40\x95\x1a\x1f\x03\x1a\xfa\xcb\x2f\xee\xfe\xdd\x2f\xb4\x3d\xbf\x7f\xbd\xc4\xa0\x5e\x9f\xdc\x69\x93\x5f\xc0\x55\xe5\x83\xdd\x7e\x41\x6f\x2b\x97\xe1\x1b\x5c\x69\xa3\x83\xb6\xe6\x64\x8b\x41\xe5\x2a\xa8\x8b\x13\x80\xcc\xa1\xa2\x1f\x6f\xf5\x16\x7d\x50\xdb\xf2\x02\x4c\x55\x14\x27\x00\x46\x6d\xf1\x02\xe8\xbe\xb2\x50\x06\xfd\xe2\xae\xda\xaa\x85\xb6\x27\xbe\xc4\x8c\x1e\x5d\x3b\x5b\x95\x17\x90\x7e\x96\x27\x3c\xfd\x07\x40\x56\xf0\x26\x3d\xcc\xbf\x95\x45\xe5\x54\xd1\x26\x79\x02\xe0\x33\x5b\xe2\x05\x9c\x9e\x9e\x00\xdc\xab\x42\xe7\xbc\x1a\x21\x62\x4b\x34\x97\x9f\xaf\x7f\xfd\xf9\x26\xdb\xe0\x56\xc9\x8f\x00\x39\xfa\xcc\xe9\x92\xef\x6b\x5e\x01\xda\x43\xd8\x20\xc8\xbd\xb0\xb2\x8e\xff\x6c\x5e\x06\x97\x9f\xaf\x23\x85\xd2\xd9\x12\x5d\xd0\x69\xb5\x74\xb5\x98\x5b\xff\xb6\xf7\xae\x33\x5a\x8c\xdc\x03\x39\xb1\x13\xe5\x95\xf7\xf2\x1b\xe6\xe0\xe5\xe5\x76\x05\x61\xa3\x3d\x38\x2c\x1d\x7a\x34\x81\x37\xd5\x22\x0b\x74\x8b\x32\x60\x97\xff\xc4\x2c\x2c\xe0\x06\x1d\x11\x01\xbf\xb1\x55\x91\x43\x66\xcd\x3d\xba\x00\x0e\x33\xbb\x36\xfa\xf7\x9a\xb2\x87\x60\xf9\x95\x85\x0a\xe8\x43\x87\xa2\x36\x01\x9d\x51\x05\xb1\xb1\xc2\x19\x28\x93\xc3\x56\xed\xc0\x21\xbd\x03\x2a\xd3\xa2\xc6\xb7\xf8\x05\x7c\xb0\x0e\x41\x9b\x95\xbd\x80\x4d\x08\xa5\xbf\x38\x3f\x5f\xeb\x90\xd4\x29\xb3\xdb\x6d\x65\x74\xd8\x9d\x67\xd6\x04\xa7\x97\x55\xb0\xce\x9f\xe7\x78\x8f\xc5\xb9\x2a\xf5\x9c\xd7\x69\x02\xab\xe0\x36\xff\x93\x8b\xaa\xe6\xcf\x5a\x0b\x0b\x3b\x92\xaf\x0f\x4e\x9b\x75\xfd\x33\xab\xc7\x28\x9b\x7f\xd1\x26\x27\x71\xaa\xf8\x98\x2c\xb7\xe1\x26\xfd\x44\x4c\xf8\xf2\xf6\xe6\x16\xd2\x4b\x99\xe3\x5d\x16\x33\x73\x9b\xc7\x7c\xc3\x67\xe2\x8b\x36\x2b\x74\x22\xa7\x95\xb3\x5b\xa6\x88\x26\x2f\xad\x36\x81\xff\xc8\x0a\x8d\xa6\xcb\x63\x5f\x2d\xb7\x3a\x90\x60\x7f\xab\xd0\x07\x12\xc7\x02\xae\x94\x31\x36\xc0\x12\xa1\x2a\x73\x15\x30\x5f\xc0\xb5\x81\x2b\xb5\xc5\xe2\x4a\x79\x7c\x6e\x2e\x13\x43\xfd\x9c\x38\x78\x98\xcf\x6d\x4b\x4f\xd7\x90\xf2\xb3\x01\xd0\x2e\x58\x51\xf7\xfe\x01\xa0\xf2\x9c\x3d\x87\x2a\x3e\x8f\x3c\x3c\xba\x82\x41\x33\x6a\xde\xc4\x62\x36\x50\x19\x1f\x5c\x95\x85\xca\x61\x0e\x77\xb8\x8b\x12\xdf\xaa\x12\x7c\xb0\xf4\xe3\x83\x0e\x9b\xde\x1b\x55\x5b\xfa\x2a\xb0\x58\x97\x08\x1e\x03\x2c\x77\x40\xfe\x91\x0d\x22\x58\x5b\xb0\xe5\x30\x2d\x36\x0c\x87\xc1\x69\xbc\xc7\x3e\x49\xb7\xd4\xc1\x29\xb7\xab\x79\xb7\x80\xdb\x0d\xee\x40\x39\x04\x12\xf3\x6f\x15\xba\x9d\x5a\x16\x42\x27\x1a\xec\x12\x81\x95\xcc\xdd\x63\xde\x23\xf9\xb0\x41\x03\x5b\x9b\xeb\xd5\x8e\x34\x57\xd4\xb2\x6f\x7c\x17\xe7\xe7\x77\xd5\x12\x9d\xc1\x80\xac\x18\xb9\xcd\xfc\x79\xe5\xd1\xcd\xd7\x95\xce\xf1\xbc\x25\xa0\xb3\x93\x21\xd6\x0b\xe5\xce\xbf\xb2\xa2\xf2\x01\xdd\x47\xf2\xe5\x53\x32\xb9\xdd\x20\xbb\x6f\x71\x5d\x98\x9e\x83\x87\x8d\xce\x36\xfc\x4b\xb4\xa6\x25\x16\xd6\xac\x45\xf1\x6f\xf7\x2d\x8e\x2e\xed\xa1\xf2\x98\x13\xbb\x73\xed\xc9\x56\x2b\xed\x37\xb5\xa0\x3c\x4b\x12\x3c\xbd\x8b\x5f\x48\x5c\xe4\xc0\x51\xaa\x8c\xd8\x01\xb9\x5e\xad\xd0\xed\x5b\x5e\x6b\x33\x5e\xde\x0c\x2b\x8d\x05\xfb\x09\x12\x0b\xc9\x5c\x99\xdd\xc3\x06\x1d\x82\xd3\xeb\x4d\x00\x63\x1f\x98\xba\x2a\x35\x4b\xc6\xc1\xc0\x72\xd7\x96\xbd\x89\x05\xbd\x36\x2c\x8f\x00\x7a\xc5\xd4\xb4\x91\xe0\x88\x60\x5d\xb4\xec\x64\xf7\x8b\x41\xf6\x0f\x68\x7e\x3f\xba\x4e\x09\xe1\xf4\x6a\xff\x76\xf1\x82\xa1\xfe\xb3\xe7\x02\x65\x63\x7d\x53\xd4\x5b\x14\xbd\x63\xff\x16\x65\xf7\xa0\x7c\xdc\x12\xb9\xa8\x90\x58\xb7\xae\x94\x53\x26\xa0\x08\x4d\xec\xa7\x2f\x56\x03\x1b\x55\x96\x68\xfc\x7c\x89\x2b\xe2\x94\x75\x39\x3a\x50\x99\xb3\xde\x83\xc7\x52\x39\xe6\x55\x89\x4e\x74\x74\x01\x57\xec\x40\xc5\xdb\x1a\xdb\xa7\x49\x5c\xe6\xf5\xb1\xb5\xa7\x25\xd5\x7b\xc4\x9c\xde\xfa\xe5\xdd\xd5\xcf\x3f\xff\xfc\x57\x0a\xe8\x5b\x16\xa7\xf6\xf4\xf3\xd7\xdb\xab\x05\x7c\x33\x3d\x9a\x9f\x6d\x59\x51\x70\xcc\xc9\x03\x30\x87\x76\x3e\xe0\x76\x01\x5f\x50\xe5\x73\x6b\x8a\xdd\x02\x3e\x56\x45\xc1\x00\xa1\xd0\x7e\xc0\x10\x7f\xd0\x3f\x27\xbf\x71\xba\xb7\x36\xda\x80\x0a\x0c\x7f\x70\x4e\x02\x3a\x56\x89\x72\x2c\x90\xa8\xff\xcd\xa9\x0c\x3f\xa3\xd3\x36\xbf\xc1\xcc\x9a\xbc\xe7\x83\x3b\xda\xf4\xb1\xda\x2e\xd1\x91\x41\x7b\xb9\x1b\x54\x51\xd8\x07\xcc\x23\x36\x6a\xf4\x22\x58\x58\x13\xed\x55\x55\x14\xbb\xbe\x2e\xa1\xdb\x6a\x43\xb2\x8d\x82\xd7\x01\x1e\x74\x51\x90\xa6\x38\xdc\xda\x7b\xa2\x98\x02\x68\xe2\xf6\x27\x53\xec\x58\xbe\xa4\x84\x3d\x92\x69\x47\x5d\x3d\x2f\xbc\xa5\x47\x16\xf0\x41\xed\x80\x24\xc5\xba\xb8\xb1\x2e\xa0\x21\x8d\x6d\x24\x38\xc2\x59\x6d\xc2\xbf\xfd\x79\x90\xab\x84\x8d\xd6\x7b\x76\xd2\x5b\xc4\xb4\x6d\xbe\x19\x5a\xf3\x97\x77\x57\xc0\xda\xc9\xde\x81\xb4\x93\x2d\x4f\x85\xda\x71\x0e\xb8\x9c\x3a\x66\x25\x2e\xf2\x4a\x68\x87\x5d\xb7\x16\xc3\x58\x63\xe6\x62\xd1\xaa\x16\xd6\x28\x5f\xc5\x8c\xd8\x55\x35\x86\x40\x91\x64\x96\x2c\x88\xec\x3e\xd7\x0e\xb3\x20\x72\x0a\x1c\xd1\x96\x7d\xe9\xab\x08\x83\x38\x0a\x36\x4b\xd7\x1e\xf0\xb1\xc4\x2c\xd4\x4e\x23\x6e\x02\x5e\x18\x0b\x14\x22\xd0\xc1\xbd\xf6\x7a\x59\xf4\x63\x2c\x6b\x4b\x4d\x8a\x8d\x50\x16\x46\xab\x72\xa8\xb2\x4d\x5c\x0d\x07\x86\x97\xa0\x56\x01\x05\xcd\x33\x77\x75\x5f\xa1\x42\xcd\xb8\x19\x58\xc3\x70\x00\x61\xa5\x8d\x2a\xf4\xef\x84\xf7\xe8\x1d\xbc\xe6\x6d\x19\x76\x0b\xb8\xf4\xbc\x44\x50\x7e\xef\xc6\x1e\x61\x7e\x90\xec\x5e\x69\x02\x2b\x01\xb7\x7e\xd6\x61\xf3\xb2\xb0\xd9\x1d\xc9\xee\x53\x7a\x6d\x4f\xaf\x86\x42\xa4\xc7\x30\x6b\xf9\xbe\xe4\x22\x19\x44\x1a\x12\xbc\x75\x09\xc9\xac\x2a\x17\x36\x14\xbc\x4c\xc4\xfe\xab\x8a\x70\xd2\xac\x2f\xaa\x22\x6c\x6c\xb5\xde\x90\x81\x26\x24\x94\xac\x07\x62\x3a\x54\x73\x3d\xde\x90\xa4\x56\x3a\x6d\x07\xc2\x88\x95\x35\x12\xdb\x17\xf0\xce\x3a\xc0\x47\xb5\x2d\x0b\xca\x2e\x58\x9f\x62\x82\xc1\x9a\x26\x10\x4c\x41\x69\x59\xc3\x22\xe5\xa1\x40\xf2\xf3\xab\xe4\x92\x44\xab\x7e\xa9\x96\x74\xb3\xd8\x03\xc9\x9f\xf5\xde\xa3\xc9\x29\xcc\x35\xfa\x5e\xbb\xa2\xfd\x64\x8a\x2e\xaf\xd7\x82\xf5\x04\xbf\x88\xc8\x48\xf6\xda\xf0\x2f\xa5\xcd\x17\x70\x19\x35\x49\x85\xd6\x22\x66\xfc\xff\xb8\x88\x3e\x7a\xa3\x45\xd1\x5a\x40\xc1\x46\xb9\xbc\xbd\x88\xf4\xd2\x17\x37\xd7\x7f\xfb\xe5\xfa\xfd\xfb\x97\xbd\xd7\x93\x5a\xf7\x05\xc5\xab\xc8\x0a\x54\xa6\x2a\x67\xd1\x89\xa6\x45\x36\xbe\xf4\xf2\xf3\x35\x67\x12\xfc\x0f\x0e\x89\x19\xe3\x33\x83\xe1\xc1\xba\xbb\x1e\xd9\x52\xb9\xc0\x30\xdd\xcf\x3a\xee\x9d\x64\xe4\x03\x6d\x03\x1f\x49\x9d\x93\x39\x45\xc1\xb2\x8e\xce\xa0\x32\x41\xf7\x3d\x8a\x32\xa0\xf2\xad\x36\xda\x07\xa7\x82\x75\xa4\x47\xaa\x0a\x76\xab\x44\x6b\x6c\x86\xde\x43\xa6\x28\x21\x16\xc6\x60\x57\xcf\x06\xfc\x1f\x87\x99\x26\xac\x10\x16\x59\x25\x0c\x37\x6b\x84\x5d\x5b\x59\x84\xa4\x71\x37\x1b\xd5\xa7\x28\x96\x83\xa6\x71\x7a\x84\x0d\xc6\xb0\xc0\xbe\x1b\xad\xdf\x34\x64\xa8\x2d\x8a\x2d\x04\xf1\xff\x1c\x31\x34\x0e\x6d\x32\xa6\x7d\xa8\x3c\x7b\x1c\xf6\x8a\x29\xba\xb7\x58\xdd\x58\x71\xa3\x94\x0e\xd7\xa4\x0b\xbd\x18\x0c\xf0\x56\x65\x1b\x40\x13\xdc\x2e\x26\x75\x3a\xa7\x3d\xae\x34\xba\xba\x1a\xe3\xd0\x97\xd6\x70\x54\x80\xcc\x6e\x4b\x6b\xd0\x44\xc7\x41\x76\x36\x10\x2a\x6b\xd3\x10\xca\xf5\x3a\xc8\x31\xb3\xe2\x0c\xba\xdc\xae\xce\x0c\xc9\xd5\x58\x33\x37\xba\x98\x31\x5d\x8d\xd1\x4d\xe8\x18\x2a\x48\xa1\x13\x02\x89\x18\x67\x7f\xc3\x1c\x0b\x9e\x94\x04\xcb\xbf\x94\x73\xaa\x1b\x66\xd7\x68\x08\x33\xe3\xc1\x24\xed\xf4\x6f\xad\x3b\x23\x93\x6d\x29\x89\x39\x79\x88\x95\x7e\x9c\x49\xf2\xd5\x81\x0d\xfd\x48\x41\x80\x2f\x92\x22\x47\x6e\xf4\x6f\x55\xcc\xc6\x3e\x7d\x7c\xff\x3f\x70\xfd\x8e\x9f\xe6\xb7\x08\x1a\xd9\x28\xdf\x18\x59\xe9\xec\xbd\xce\xfb\x1c\x01\x11\x47\x1b\xc2\xd0\x62\xc4\xbd\x32\x75\x87\xa1\x72\x46\x20\x43\x53\x61\x69\x70\xd0\x68\xe6\x17\x36\xca\x34\x64\x4a\xe5\x7d\x0d\x97\x24\x7e\x32\x09\x46\x90\x4b\xd6\xac\xa5\x36\xb1\x68\x50\x6f\xb0\x1f\x31\xaa\xd5\x4a\x3f\x4a\x08\x4a\x7b\x8a\xe4\x36\x11\x19\x70\x9a\xda\x94\x25\xc1\x55\x05\xfa\x04\x1b\x88\x3f\x7d\xe7\x26\x20\x24\x15\xdf\x96\x08\xc1\x55\x26\x6b\x7b\xa1\x02\xcd\x3a\x6c\x92\x8a\xca\x2a\xd8\xcf\x68\xc7\xac\xe9\xd1\xdc\xaa\x3b\xb1\x01\x59\x5c\x94\x97\x35\x2d\x19\xb3\xbf\xeb\xb1\xdf\x97\x98\x91\x01\x0e\x84\x20\x82\xaa\x1b\xac\xd5\x40\x72\x70\x09\x10\x31\x20\x26\xcc\x49\x9c\xfd\xf8\xe9\x36\x0a\x0f\x14\xfc\xf9\xd5\x5f\x61\x3e\x10\xd7\x7d\x40\x95\xcf\xea\xf4\x00\x35\xc3\x96\xf8\xd8\x4f\xaf\x5e\xc3\x95\xe4\x9e\x14\x43\xfe\xf2\xea\x95\x48\xe7\x0b\x2a\x6f\x4d\x2c\xcc\x91\xfd\xda\x6a\x28\xf9\xcc\x75\xa6\x82\xa0\x81\xb6\xba\x66\x5c\x7d\x89\xc0\x69\x65\x2b\x93\xa7\x70\x2f\x38\xbc\x28\x6c\x08\x98\x0f\x60\xa5\xb8\xff\xa8\x81\xb1\x8c\xe3\x90\x7c\xcc\x8b\x64\x53\xc5\xae\x0f\x3d\x79\x21\x9c\x99\x0e\x28\x29\xc2\x17\xa2\x30\x17\x98\xb1\x41\x95\xa3\x7b\xc9\xa2\xb9\x2c\xcb\x42\xd3\xd6\xc9\xa9\xe8\x15\x24\x0b\xe6\xb0\x97\xa4\xd4\x37\xa8\xe7\x8d\x33\x3a\xc7\x6d\x69\x03\x9a\x6c\xb7\x1f\x6a\x46\xdd\x56\x54\x90\xbd\xb2\x38\xec\xbb\xa6\x4b\xf0\x14\x28\x09\xa1\x18\xc9\x3b\x3b\xa5\x0a\x95\x36\x99\xb5\x08\x82\x5d\x0d\xf2\x30\x47\xcf\x96\xe0\x83\x0a\xb8\x38\x26\xa3\x7f\x96\x7c\x90\xbb\x23\xc7\x84\xcd\xd3\x4b\xd3\xbe\x59\x6a\x34\x2c\x01\x5b\x14\x75\xcd\x0c\xcd\xca\x72\xbd\xcb\xdb\x6d\x5a\xf3\x80\x62\xdf\x2b\xa7\x95\x09\x94\x32\xc6\xa8\x9b\x6a\x46\x11\x75\x77\x73\x42\x25\xf1\xc9\xae\x3a\xcb\x1d\xf2\x97\x84\x94\xee\xa5\x64\xb9\xc3\x00\x8a\x53\x35\xdb\x29\x08\x09\xf0\xd2\x05\x19\x24\x63\x80\x0e\x6e\xec\x11\x25\xa7\xc8\x01\x80\x22\x37\xc1\x02\x52\xe5\x7a\x15\x94\x02\x91\xc1\x3f\x68\x8f\xb3\x3d\x14\x91\x51\xcc\xcf\xd1\x0d\x38\xa2\xca\xb4\x48\xa4\xec\x74\xa3\xf3\x1c\x0d\xbc\xd0\x86\xb7\x7b\xfe\xa0\x42\xb6\xe1\x7f\xae\x91\x82\x73\x51\xf8\x97\x02\x05\xc4\x7e\x27\x18\x60\xce\x02\x65\xaa\x85\xce\x34\xa5\xba\xca\xdf\x49\xf8\xb1\x4b\xf6\x6f\x7b\xef\xaf\x6b\xb3\x03\x95\xa5\xff\x66\xd4\x68\xda\xdb\x12\x7f\x36\xeb\x60\x4b\x72\x7d\x65\x54\xd9\x16\xa2\x18\xac\x5f\xb3\x07\xaa\x9c\x63\x17\x84\x3d\xb1\xc6\x32\x4a\xe9\xf4\xbd\x2e\x70\x8d\x39\xe7\x5c\x52\x4f\x93\x1c\xb1\x1f\x2a\xb8\xcc\xdc\xbc\x37\xe6\xa5\xba\xc9\x7e\x67\x29\x3d\x8c\x5e\x93\x9f\x20\xd7\x14\xf3\xcc\x1e\xc9\xe5\x0e\x94\xd9\xf1\xab\xd9\x95\xbd\x79\xfb\xf9\xcb\xdb\xab\xcb\xdb\xb7\x6f\x60\xde\x59\x2e\x97\xc8\x29\x61\x28\xca\x8d\x8a\x2a\x4b\x32\x1b\x44\x76\xad\xe2\x91\x36\x70\xff\x7a\xf1\xfa\x2f\x8b\x7d\xa7\x34\xd6\xa9\xe0\xff\x49\x76\xd8\xff\xc7\x9e\xb1\x7e\x8e\x59\xe4\xa8\xed\xc4\xce\x01\x41\x61\x7c\xc4\xac\x0a\xfd\x98\x0e\x92\xb6\x4a\xc1\xb3\x86\xc9\x4d\x82\x45\x28\x44\x4a\x1d\x0b\xd1\x12\xe9\xd0\xf9\x90\x56\x39\x42\xb1\xe3\x42\x22\x37\x52\x21\x04\x56\x4a\x17\xb4\x70\x87\xbe\x2a\x42\xab\x66\x80\xd3\xa6\x4f\x97\x34\x53\x6a\x5c\xc5\x75\x56\xcb\x96\x9e\xe2\xde\x90\x6d\x12\xae\x69\x19\xc3\x20\x65\x7a\x3e\xee\x95\x48\xaa\xa2\x48\x26\xd8\x0f\x5e\xa3\x18\xf9\x90\x6c\xe5\x32\x03\x70\xb8\xb9\x3a\x42\x6e\x77\x2e\x52\x4e\xca\x62\x65\xbe\x36\x29\x07\xa5\x21\xf5\x0e\xc7\xe4\x22\x57\xdb\x4d\x8e\xde\x36\x01\xf6\xe5\x4a\xa8\x6e\x78\x1f\x73\x5e\xf8\xe0\xbf\x46\x1b\x3a\xed\x7f\xf7\x53\x09\x79\x27\x29\xcc\x41\xc3\xb8\x5e\x75\x55\x4b\xe0\x18\x71\xf0\x9d\xd2\x45\xe5\x30\x41\xd9\x89\x3c\x0a\x52\x7d\x64\x89\x50\xa2\xf3\xda\xc7\x7a\xa0\x0f\xd6\xa9\x35\x26\x75\x33\x29\x8f\xa4\x74\xcb\x57\x4e\xba\x17\x14\xf2\x06\x3d\x0e\x70\xaf\x47\x7a\x07\x9c\x89\x45\x5f\xdd\x4e\xf5\x86\x84\x72\x48\xa7\x86\x5b\xfc\xa3\x1c\x7a\x6a\xbb\x7f\x54\x4d\xba\x63\x00\x4f\x6d\xfd\x8f\x92\x1d\x1c\x09\x78\xca\x18\xc0\x28\xe5\x3f\x70\x3c\xa0\x7d\x1d\x34\xa7\xcc\xe6\xa3\x2e\xa1\x23\xba\x9b\x6a\xbd\x96\xe2\xf7\x7f\xde\xde\x7e\x4e\x39\x08\x3d\xde\x34\x3f\x08\x5e\x56\x7e\x06\xaf\x40\xf7\x71\x68\xba\x62\x59\x6a\xcc\x05\xb4\x90\xe6\xcf\x3f\x4d\xee\x6a\x08\x71\x36\x4b\x0f\x4a\x17\xa3\x8e\xb0\xb3\xb3\xb7\x8f\x01\x0d\x25\xaa\xb9\x0a\x0a\x94\xf7\x36\xd3\x0c\x8e\x6b\xf3\x75\x9c\x51\x2d\xa4\x20\x33\xa1\x93\x9c\x77\x91\x66\x88\x6e\x83\x0e\x1e\xec\x83\xe1\xb6\xb9\xbc\x41\x96\xb5\x07\x41\x47\x29\xd6\x95\x88\x14\x63\x78\x85\x75\xca\x3f\xd8\x6c\xcc\x2c\xa1\xe4\x3e\x2e\xae\x79\x67\x19\x7b\x44\x3b\xc3\xc7\x0c\xcb\x58\x2e\x92\x45\xd7\x39\x41\xdc\x0e\xf1\x7a\x4c\x56\x87\x23\x0e\x40\xa6\x2a\x3f\xf5\xff\x81\xae\xf9\x15\x3f\x22\xbe\x18\xb4\xc9\x8a\x2a\x47\x0f\x5b\xb2\x9c\xc8\xc0\x96\x94\x26\x08\x43\x23\xc1\x1b\xd6\xcc\x98\x19\xaf\xc4\x1b\x2f\xe0\xa3\x0d\x1c\x6f\xdb\xff\x65\x2c\x38\x49\x34\x16\x36\xe2\x5a\x30\x8f\x5b\x1c\x8f\x69\x93\x51\xbb\x45\xf5\x20\x2f\xe5\x62\xb5\x39\x74\xd3\x7e\x82\x75\xbb\x49\x85\xa7\x18\xd4\xbb\x63\x1e\x94\x88\xf0\x36\xa6\xf9\x29\x17\xdb\x3a\x3a\x67\xdd\x8c\x00\x0e\x45\x5c\xd6\x1a\x52\xf7\xff\xba\xf9\xf4\x11\x3c\x3a\xc6\x03\x6a\x2c\xac\xec\x5f\x1f\x1a\x41\x43\x4e\x42\x31\x39\x94\xd6\x87\x95\x7e\x84\x34\xa1\xc1\x6e\xc6\xb0\x0b\x3a\x82\xa2\x0a\xe2\x3e\xc9\xe7\x5e\x92\x22\x09\x96\xfe\x1d\x9d\x9d\x6b\x93\xe3\x23\x65\x57\xf0\x8e\x38\x72\x58\xe2\x91\x64\x59\xa2\x72\xa2\x87\x5c\x3d\xe3\xb6\x98\xe6\x0c\x46\x74\xd5\xae\xa2\x2e\x40\x3e\x50\x1c\x1b\x60\xa4\x15\x99\x78\xca\xab\x28\x82\x6f\xab\x22\xe8\xb2\x40\xe1\x2e\x65\x2b\xd1\x03\x70\x9a\xf0\x56\x3a\x45\x07\x15\x84\xae\x6f\x00\xdf\x4e\x49\x32\xdf\x4e\x61\x1e\x5b\x72\x24\xfd\xfa\xc7\x58\xeb\x8a\xb9\xd2\x11\x14\x6b\x85\x21\xca\xac\xd0\x7f\x7f\xf5\x8f\xc5\xc4\x2b\x8e\xa0\x19\x17\xb1\xd2\xce\x87\xc8\xc3\x58\xee\x36\xe9\x25\xdf\x4e\x0f\x13\x3a\x18\xe5\x9a\x6b\x8b\xde\xab\xf5\x04\x0a\x4e\xd7\x5e\x2d\x66\x53\x6d\x95\x99\x3b\x54\x39\x37\x52\x5b\xff\xad\xe7\x7b\x48\xf2\xc7\xec\x59\x6e\x67\x09\x2f\xa0\x1d\x09\x62\x75\xb3\x99\xd5\x50\x7e\x3e\x11\x1d\x5a\xfb\xb7\x3c\xb7\xa5\x72\x74\x87\xad\xed\x09\xcc\x92\x10\xf0\x64\x5e\x6d\x55\xb6\xd1\x06\xa7\xb8\x75\xc4\xa6\x98\x9f\x7b\xdc\x4a\xe5\x58\xa9\xda\xa6\xfc\x9b\xee\x70\xc7\x90\xe4\x80\xc9\xe8\x8b\x30\x06\xad\x46\xdd\x2b\x5d\xd0\x1a\x9f\x91\x6f\x07\x12\x8d\xee\x6d\xc3\x09\x47\xba\x64\x1e\xf8\x29\xb1\x93\x9f\x68\xbc\x5f\xcf\xdb\x3f\x35\x70\x0a\xa4\xeb\x44\xc8\x29\x56\x1d\xc5\xa4\xfd\x51\xd5\xc9\x4d\x9d\xd1\xae\xe8\x89\x7f\xf1\xa6\xe0\x93\x91\xba\x62\x33\x6e\x25\x50\x8e\x3b\x28\x93\x74\x5b\x9d\xbc\x34\x20\x52\x2f\xed\x17\x6d\xf2\x3f\x68\x5c\xf5\xbb\x64\x31\x5d\x12\x18\x1b\x69\xfc\x97\x8a\x02\x5e\xc4\x31\x3b\x74\x18\x67\x96\xb5\x59\x17\x38\x9e\xda\xd7\x54\xb9\x4c\x4c\xf9\xed\x32\x39\x9d\x25\xe6\x2f\x7f\x58\x61\xb9\x89\xc1\x1d\x88\x91\x29\xb1\x51\x8e\x5d\xaf\x9a\x5e\xc4\xac\xdd\xf4\xa8\x27\xc8\x9a\x1e\xf1\xe4\xd6\x6a\xad\x6c\xcd\xc7\xca\xc4\x6d\xbe\x80\x1b\xd2\x5b\x81\x0c\x71\x0e\x5b\x7a\x2a\xd3\x6e\xaa\xe9\xd5\x70\xa9\x2e\xa8\xbb\x58\x6b\xe4\x6c\x37\x20\xa8\x8c\x5f\x38\x8f\x09\x9e\xf5\xe9\x25\x07\xe8\x76\x02\x5a\x5a\x0b\x6c\xec\x83\x8c\x08\x05\x0b\x0f\x4a\x87\x7a\xe7\xea\xee\xa0\x47\xdd\x60\x6f\x59\x53\x42\x3d\x26\x87\x84\xa3\xf2\x48\xba\x2a\xfd\x04\x6f\xf5\xf5\xfa\xcd\xbe\x4d\x2c\xc6\x14\x7a\x72\xcf\xcd\x48\xdb\x88\x52\x3f\x79\xd8\xb9\x19\x1e\xf0\x7f\xaa\xf4\x0f\xfb\x8e\x83\x61\x6e\xca\xcd\x3f\xc3\xe9\x84\xf1\x0c\xb7\x55\x47\xfe\x9e\x93\x0a\x13\x84\x9b\xee\xe6\xf7\x9c\x5a\x18\x25\xfc\x87\x87\x87\x83\xe2\x3d\x00\x93\x9f\x0c\x8e\xa3\x9b\x3f\x54\xd6\xab\xbd\xdc\x18\xaf\x8e\x58\x78\xff\x78\xc6\xe8\xca\xcf\x6e\x82\x32\xb9\x72\xb9\xb4\x31\x9a\xe3\x09\x7f\xb8\x40\x8e\xaa\xa4\x58\xb2\x84\xea\xf8\x70\x9d\x1e\x68\x1f\xe2\xd0\xab\x7a\x72\x55\x06\xfc\xa1\xd0\x5b\x3d\x9d\xff\xc5\x2c\xcd\xd4\xd3\xcf\x9c\x98\xd5\x75\xa8\x38\x01\x1b\xfd\x7c\x6c\x13\x1c\x8a\x67\x71\x14\x62\xa3\x52\x61\x87\x6b\x6f\x35\x1a\x67\xa8\x51\xa3\x7c\x5b\xaa\xdf\x2a\x1c\x1c\xfc\x6b\x5f\x71\x9b\xe9\xac\x84\xf6\x9e\x1f\xb2\x71\x68\x22\x8e\x54\xda\xfd\x63\x49\x6a\x7a\xf7\x72\x04\xa5\xd5\x77\x0c\xb6\x3e\xeb\x22\x7c\xc1\xc7\xba\xd7\x58\xef\x60\x9a\xa1\xa9\x27\x7a\x25\x12\x92\x7e\x3e\xb7\x8d\x7c\x20\xf7\x22\xea\xd8\x74\x14\x4b\xeb\x87\xe7\x7e\xdb\x57\x14\x6d\xe4\x6c\x66\xcd\x4a\xaf\xab\x08\x1a\xb8\xbe\xb3\x51\x66\x2d\xb3\x22\x4d\x0d\x43\x4d\x23\x5b\x7c\x80\xad\x36\x15\x89\x95\x7b\xdf\xcd\x9c\x50\x13\xdf\x52\x41\x5f\x62\x7e\xd2\x8a\x03\x40\x0d\x0d\x54\x5e\xfc\xba\x74\xcc\x44\x53\x5b\xa3\x47\x4b\x8c\xe3\x6e\x59\x3d\x83\x3a\x49\x33\x6a\x4b\xbb\xa2\x10\x1b\x55\x38\x83\xca\x14\xe8\x3d\xec\x6c\x25\xfb\x70\x98\xa1\x1e\x3a\x59\xd4\xbe\x64\x9e\xd3\xde\xa1\x91\x20\xa1\x8c\xe0\x9f\xe4\x1d\x9f\x01\x57\x76\x38\x78\x3c\xca\xb8\x09\x4d\xc3\xa7\x0e\xeb\xbe\x25\xfe\xb3\x33\x5f\xb7\x2d\xa6\xb9\x16\x85\x97\xce\x57\xa6\xf3\x0b\x44\x39\x62\x8e\x34\xfe\x96\xfa\x47\x03\xe3\x54\xdd\x95\xa6\xa9\x55\x96\x72\xd4\x75\x61\x7b\x54\xc1\x05\xfc\x2a\x23\xda\x71\x5a\x32\x48\xd7\x7f\x92\xac\xaa\xdd\x40\x6b\x29\x5c\x27\x64\x95\x84\xca\xd4\x6d\xf7\xa5\xca\xee\x8e\xd1\x98\x34\xe7\x75\xcc\x01\x97\x26\x22\x4c\x92\x7c\x86\x68\x91\x59\x23\x45\xb9\x6c\x37\x8f\x23\x30\x73\x65\xf2\x79\xed\x1e\xb2\xdd\x0f\x67\x7d\x1e\x8b\xd5\x7b\x6d\xee\x8e\xd6\xb8\xf4\x80\xa0\xb4\xaf\x5f\xde\xef\x83\xb3\x23\x5a\xbb\x70\xdc\x59\xa2\x7f\x31\x2a\x9d\xae\x69\x3d\xb1\x92\xf5\xb0\x89\x83\x21\x35\x70\x19\x5d\xbd\xae\xc7\xe6\x4f\x63\x37\xf8\x34\xa2\xa2\xe9\xb2\xd6\x54\x7f\x68\xb4\x98\x05\x97\x69\x0a\x30\x2b\x94\x13\xe7\xa0\x8c\x74\xee\xe4\xa5\x13\x28\x23\x47\x58\x56\x01\x72\x8b\xd2\x5f\xb2\xf7\xe8\x9c\xce\x11\xf4\xa8\x70\x0f\x0a\x46\x5e\x7a\x34\x28\xab\xb1\x62\xab\x1c\xb3\x80\x4f\x06\xc1\xae\x2e\xe0\xf4\xa6\xca\x32\xf4\xfe\x74\x68\x5c\x27\x5d\x35\x97\x9f\x1b\xcd\x51\x3e\xcf\x06\x29\x7b\xfa\x4e\x88\x3d\xa1\xa7\x63\x13\x0e\xf3\x91\xd9\x97\x51\x52\x85\x5a\x62\xbf\x07\xfa\xcc\x27\x8f\x3f\x28\x1e\x0d\x8f\x89\xdb\x1d\xee\xc4\x2b\x4b\xbf\xbb\x1f\x47\x82\x05\xeb\xd6\xca\xe8\xdf\x07\x0e\x0a\x9b\x1c\x08\x42\xae\xad\xd3\xbf\x23\xbc\xe0\x0f\x19\xc8\x99\x60\x2c\x30\x0b\x2f\x5b\x07\x7d\xd5\x0e\xb6\x3c\xc2\x26\xff\xb2\xce\x0f\xcd\x3e\x3a\x2c\x0b\x1e\x73\x25\x4b\xa8\xc7\x09\x7d\xa4\xe9\xee\x75\x36\xd0\x93\x3f\x98\x48\x0b\x5f\x8f\x3e\x30\xbc\x55\x46\xad\x31\x97\x5e\xd3\xf4\x18\xe4\x87\xf6\xad\xb0\x55\xa5\x87\x07\xeb\xee\x56\x85\x7d\x98\x6b\x19\xfd\x4a\x01\x3b\xe2\xd8\xa1\x83\xa5\x76\x95\xda\x4a\x72\x7e\xc8\x61\x5a\x83\x78\x5d\x15\x6a\xaa\xb1\x13\xad\x09\x85\xfb\x50\xec\xe2\x3c\xcf\x08\x70\xd8\xd8\xca\xe3\x1d\x62\xa9\xcd\x5a\x50\xbf\x4c\xcf\x85\x5d\x49\x28\xad\xd8\xc5\xe2\x94\x39\x0b\x60\x62\x3f\x3a\x9e\xbc\xaa\x4c\x8e\xce\x87\x21\x08\xdf\x14\x8c\xc8\x6f\xa5\x95\x25\xad\x49\xd9\xca\x99\x34\x1a\x67\x9d\xc1\xd0\xf4\x63\x9f\x05\xae\x99\x6d\x27\x58\xde\x0c\xcb\xaa\xb2\x2c\x76\x50\xaa\xb0\x81\x42\xdf\x21\x7c\x3b\xcd\xf4\x3c\xcb\xbf\x9d\x0a\xa8\x8d\x38\x5e\xf8\xd7\x23\xcb\x67\x2a\x1f\xd4\xae\xf6\xe5\xb5\x34\x62\xce\xd3\x2c\x9f\xb5\x7d\xef\x9c\xfa\x10\x20\x49\x43\x2b\xdf\xcc\xfe\x5c\x2a\xcf\xfc\x89\x4d\x30\x27\x5a\xf8\x3d\xcd\xf9\x3d\xe8\xb0\x19\x9a\xee\x36\x36\xe8\x0c\x7b\xd3\x7f\x23\x6d\xe8\xe9\xe4\xf3\xd0\x88\x4f\x37\x64\x4e\xce\xf7\xb4\xbe\xe2\xd1\x6a\x3e\x8f\x39\xd0\x86\x1b\x9c\xa8\xf2\xb8\x77\x3a\x25\x8f\xb1\xc6\x47\x8c\x3a\xe5\x9e\xc7\x79\x7c\xc7\x29\xfc\xb3\xf2\x63\x34\x59\xe2\x5c\x85\xb5\xe5\xbc\x20\x0f\xdf\x5e\x71\xd4\xc1\x78\x8c\x1b\x29\xc4\x28\xb7\x63\x4b\x73\x2a\xeb\x9f\x0e\x4b\xeb\xec\xec\x4f\xb5\xd6\xbc\x44\x69\x62\x69\xf6\x81\x31\x97\x8b\x67\xbd\xc4\x60\x46\x68\xc6\x91\xa5\xa1\xf9\x75\x38\x1c\x5b\x56\x83\x9e\x46\xae\x41\xef\x0f\xc1\x8d\xf4\xab\x3b\xc2\x8d\x6e\xa9\x95\x70\xa8\xae\xbd\x4c\xad\x76\x14\x92\x89\x6b\x72\x47\x28\x97\x78\x47\xd7\x3f\x0b\x15\xa1\x42\x6d\x7b\x4c\x72\x02\x23\x6e\xd0\xe3\x11\x4b\x1e\x65\x70\x8d\x49\x8e\x58\xf4\xa7\xba\x70\x1f\xbf\xa6\x43\xb4\x69\xc5\x4d\x45\x5f\x2a\xbc\x05\xaa\xc1\xa3\x2a\x69\xcd\xda\x43\x27\x3c\xbc\xe5\x46\xf9\x12\xc9\xb1\xd4\x9f\x20\x20\xcb\xe0\x03\x11\x7c\xc2\x26\x46\xe1\x11\x92\xf5\xd8\x56\x9c\x2b\x76\x08\x67\x97\xe4\x1d\xcf\xd8\xeb\x9c\x7d\xe5\x22\xe6\xd9\x77\x71\x28\xe8\xb1\xb6\x52\xb7\xa1\xa4\xe5\xcc\x46\x68\x9f\x32\x4b\xc5\xf2\x5a\x46\xf0\x40\x38\x78\x62\x66\xec\xba\x3e\x6e\x12\xbd\x73\x7d\x02\x4f\xaf\xba\x02\x88\x1b\x1c\xa4\x73\xe8\x6c\xe0\x11\x1b\x9f\x50\xf5\xb1\x76\xef\x50\x03\xae\x8b\xb0\xf8\x60\x4b\x4a\x95\xe3\x51\x1d\x72\xfc\xda\x80\x6a\xbe\xf3\xb1\x80\x6b\xdf\x1c\x79\x1a\xfc\x46\x80\x1c\x83\x90\x01\x68\x19\x1b\x9c\x35\x27\x9c\xb9\xf7\xd9\x7c\x52\x64\xab\x76\xf2\x71\x83\xfa\xb8\xfa\x90\x6e\x36\xe7\x94\xb1\x7b\x0a\x85\x1b\x49\x25\x05\x16\xa7\x55\x48\x5d\xc3\xb6\xe7\x5b\x0c\x1f\xf6\xd2\x1e\x4a\xa7\xb7\xca\x69\x3e\x0a\x11\xe7\xe6\x48\x55\xeb\x43\x1c\xcd\x99\x1b\x01\x87\xdd\x4a\x57\x5e\x7f\x94\xab\xaf\x2d\x03\x05\xfa\x1f\x69\xa2\x30\xef\x87\x61\xe0\x80\x7e\xd4\x92\x9a\x86\x80\x1f\xeb\x0f\xb7\xb4\x03\xa8\xfc\x12\xa5\x8e\x2a\xdb\x08\x47\xbb\x5a\xd1\xdf\xf0\xa5\x89\x76\xd0\xfa\x1c\x8c\x07\x52\x92\x7b\x55\x88\x4c\x99\xfc\xb7\xd3\x1c\x57\xaa\x2a\xc2\xb7\xd3\xe6\xd6\x19\xa5\x81\x3d\x92\xed\x5b\xa3\x47\xcb\x94\xb1\x86\xcb\x74\xdd\xb1\xdc\x66\xc0\x2e\x15\x81\xc8\xc7\x24\x1d\xed\x1b\x8f\x7c\x29\x85\x40\x7f\x2e\x23\x2d\xcd\xaa\xe7\xad\xc3\x7a\xb6\x73\x26\xaf\xe9\x4d\xc6\x97\xf4\xe8\xa6\x6a\x62\xfc\x52\xc1\x37\x53\x9f\xd2\x55\xf0\xe6\xe3\xcd\xff\xbe\xbf\xfc\x8f\xb7\xef\x07\xbb\x37\x13\x45\x9f\xa3\x94\xa5\x5e\xbf\x3f\xfa\x70\x98\x7d\x30\xe8\xbe\x20\x1f\xda\xcc\xfa\x80\xac\xa3\x2b\xef\xe3\xd9\x8b\xc4\xdd\x1c\x4b\x31\x97\xe5\xae\x77\x26\xe9\xf2\xfd\xfb\x51\x06\x45\x2c\xcb\x45\x67\x2e\xd3\xf1\x91\xa4\x7a\xbe\xbc\xf3\xbd\x9b\xc8\xcb\xb5\x72\x4b\xb5\x46\xc8\x08\x86\x67\x83\x40\xe5\x7a\xb5\x7f\xa2\xa3\x95\x84\xb4\x41\xfc\x4c\xe6\xd9\x95\x69\x66\xbf\xea\x62\xfb\xb0\x30\x63\xe5\xde\x36\xc5\xe3\x44\xa9\x9e\x2b\x68\x1d\x1e\x6b\xf0\x18\x23\xb9\x21\x3b\xb9\xe5\x4a\x4b\x83\xd1\xda\x33\x7e\x58\xc3\x89\x16\xd1\x23\x8f\x2e\x3f\x2f\xb2\xee\xc2\x68\xb2\x24\x39\xdb\xfb\x5d\x11\x9a\xbf\xb2\xf1\x89\xb4\x2d\x7d\x86\xe5\x88\x45\x90\x4c\x5d\x85\x33\xb8\xfc\xf8\x26\xf5\x1b\x58\x63\xeb\xe3\xbd\xa7\x2b\xeb\x90\x00\xb9\xc9\x13\xdd\xb1\xf9\xbd\xfa\x48\x7d\x54\x80\x86\x58\x23\x88\xde\x61\xf9\x3b\xdc\xcd\xd9\x0d\x8c\x10\x95\xef\x91\xf1\x97\x17\x52\xaa\x11\x6d\xa9\x75\x22\x68\x01\x6f\xc4\x87\xf1\xa4\xff\x4a\x15\x1e\x17\x70\x3b\x06\xbd\xea\x6f\x2a\xa5\x83\xc8\xd2\x3d\xa3\x04\xd7\xc3\xa9\xac\xf0\x14\x4a\x74\x5b\xed\xdb\xe2\xe1\xbd\xf4\x53\x53\xb9\x6c\x3a\xd8\x07\x7f\xfe\xe9\x27\x78\xf1\xd5\xc4\x43\x36\x5c\x65\x7c\x6b\x82\x0e\xbb\x97\xad\x6f\x02\x49\x4f\x65\x4a\xd0\x4b\x6b\x0b\x54\x43\xf5\xc7\x46\x6b\x9f\x22\xe1\x3d\xe6\xb1\xc9\xd5\x07\x23\x8e\xb0\x88\xe3\xd6\x36\x3e\x23\x30\x30\x21\xb0\xaf\xf6\x7f\x74\x9b\xf6\x80\x45\x8d\x8f\x52\x0d\xe0\xb9\x43\x7b\xf9\x71\x20\x72\xd4\x9a\x47\x67\x5b\x26\xa6\x5a\x9e\x63\xc5\xe3\xf3\x27\x93\x0b\x1e\x3f\xfc\x35\x6f\x79\xd3\x81\x7f\x92\x54\x07\x7e\x1e\x9c\x28\x9b\x13\x57\x9e\x03\xda\x1f\x68\xef\xf5\x4e\x40\xc7\xfe\x96\xa0\x1c\xae\x28\x35\xe3\x2b\xf1\x94\x62\x3a\x88\x54\x07\x82\xe1\x6a\xda\x51\x5d\xbc\x91\x4e\x5d\x1f\xea\x74\x3a\x77\x1f\x5a\x4d\x76\xc2\x5e\xb6\x0c\x7a\xab\x7d\xd0\x19\xb4\x3a\x57\xb3\xf8\x00\xbf\x83\xe7\xb5\xc6\x3f\x18\x20\x47\x91\x9b\x74\xd8\x9a\xf6\x57\x28\xad\x4b\x35\x86\x3a\x39\xa9\x3f\x83\xd7\x23\x29\x83\x6c\x94\x28\xc4\x04\x32\x96\xa1\x55\x7b\x86\xe0\x89\x1d\xc3\xd4\x25\xe4\x4f\x56\x6e\x5b\xdf\x51\x93\x14\x9b\x78\xa0\xe4\x43\x41\x59\x55\x28\x37\xb0\xf2\x01\x35\xae\x77\x32\xfe\x4d\x9d\x4e\xfb\xf1\xb8\x7e\xe9\x68\x8f\xf4\xb9\x5d\xe5\x11\x3d\xca\xa3\x11\xef\x58\x2f\xb2\x7b\xfa\xec\xf8\xfe\x63\x87\x9f\x03\xe6\x71\x44\xcf\x71\x74\xad\x03\xee\xb2\x6b\xc5\xe4\x28\x63\x56\x14\x33\x75\x6d\xe2\x87\x33\x4c\x1e\xb3\x38\xb1\xef\xbd\x2f\x06\x0e\xe0\x67\xc6\xcc\x4d\x69\xbd\xf9\xae\x48\xf7\x0b\x76\xd6\x80\x97\x7e\xd8\xaa\x2a\x9a\x2c\x79\x40\xed\x5a\x56\xd5\xfa\x66\x5d\xfa\x84\x61\xb0\xc9\x66\xad\x81\xcf\x5f\x6f\x3b\xdf\x9d\x6c\xab\x69\x8f\xee\x31\x5d\xf3\xef\x0b\x11\x47\x2a\xd1\xa0\x6f\x4e\xdf\xd4\x9e\xb8\x69\xef\xa7\xe8\x55\x19\xab\xcf\xe3\xc7\xba\xef\x5f\x73\x1d\xfe\xf5\x09\xd4\xa3\x40\xad\x72\x69\x3c\x94\x1b\x7f\x69\x9a\x9c\x2a\xcb\xb0\x0c\x98\x7f\xdc\xff\x7c\x77\x3c\xcc\x92\xbe\xdb\xcd\x7f\x66\xd6\x48\x4d\xd6\x5f\xc0\xdf\xff\x71\x12\x31\x6c\xfe\x6b\x5a\x0d\xfd\xf8\x7f\x01\x00\x00\xff\xff\x96\xa3\x21\x0c\xad\x5c\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3c\xdb\x72\xdb\xca\x91\xef\xfc\x8a\x2e\xe6\x41\x76\x15\x49\xd9\xc7\xc9\xd6\x46\x6f\x5a\xd9\xce\x6a\x63\xcb\x2e\xcb\xce\xd6\x56\x94\xda\x1a\x02\x4d\x72\x22\x60\x06\x67\x66\x20\x99\xe7\xeb\xb7\xba\xe7\x02\x90\xb8\x10\xb2\x95\x64\x49\x3f\x58\x20\xd0\xe8\xe9\xfb\x6d\x66\xb6\x5c\x2e\x67\xa2\x92\x7f\x41\x63\xa5\x56\x17\x20\x2a\x89\xdf\x1d\x2a\xfa\xcb\xae\xee\xff\xdd\xae\xa4\x3e\x7f\x78\xbd\x46\x27\x5e\xcf\xee\xa5\xca\x2f\xe0\xaa\xb6\x4e\x97\x5f\xd0\xea\xda\x64\xf8\x16\x37\x52\x49\x27\xb5\x9a\x95\xe8\x44\x2e\x9c\xb8\x98\x01\x64\x06\x05\x5d\xfc\x2a\x4b\xb4\x4e\x94\xd5\x05\xa8\xba\x28\x66\x00\x4a\x94\x78\x01\x74\x5f\x55\x08\x85\x76\x75\x5f\x97\x62\x25\xf5\xcc\x56\x98\xd1\xa3\x5b\xa3\xeb\xea\x02\xe2\x65\xff\x84\xa5\x5f\x00\x3c\x06\x6f\xe3\xc3\x7c\xad\x2a\x6a\x23\x8a\x36\xc8\x19\x80\xcd\x74\x85\x17\x30\x9f\xcf\x00\x1e\x44\x21\x73\xc6\xc6\x03\xd1\x15\xaa\xcb\xcf\xd7\x7f\x79\x73\x9b\xed\xb0\x64\x74\xe9\x72\x8e\x36\x33\xb2\xe2\xfb\x9a\x57\x80\xb4\xe0\x76\x08\xfe\x5e\xd8\x68\xc3\x7f\x36\x2f\x83\xcb\xcf\xd7\x01\x42\x65\x74\x85\xc6\xc9\x88\x2d\x7d\x5b\xc4\x4d\xd7\x8e\xde\x75\x46\xc8\xf8\x7b\x20\x27\x72\xa2\x7f\xe5\x83\xbf\x86\x39\x58\xff\x72\xbd\x01\xb7\x93\x16\x0c\x56\x06\x2d\x2a\xc7\x8b\x6a\x81\x05\xd0\x1b\x10\x0a\xf4\xfa\xef\x98\xb9\x15\xdc\xa2\x21\x20\x60\x77\xba\x2e\x72\xc8\xb4\x7a\x40\xe3\xc0\x60\xa6\xb7\x4a\xfe\x96\x20\x5b\x70\x9a\x5f\x59\x08\x87\xd6\x1d\x40\x94\xca\xa1\x51\xa2\x20\x32\xd6\xb8\x00\xa1\x72\x28\xc5\x1e\x0c\xd2\x3b\xa0\x56\x2d\x68\x7c\x8b\x5d\xc1\x47\x6d\x10\xa4\xda\xe8\x0b\xd8\x39\x57\xd9\x8b\xf3\xf3\xad\x74\x51\x9c\x32\x5d\x96\xb5\x92\x6e\x7f\x9e\x69\xe5\x8c\x5c\xd7\x4e\x1b\x7b\x9e\xe3\x03\x16\xe7\xa2\x92\x4b\xc6\x53\xd1\xda\xec\xaa\xcc\x7f\x67\x82\xa8\xd9\xb3\x16\x62\x6e\x4f\xfc\xb5\xce\x48\xb5\x4d\x97\x59\x3c\x06\xc9\xfc\x67\xa9\x72\x62\xa7\x08\x8f\xf9\x15\x35\xd4\xa4\x4b\x44\x84\x2f\xef\x6e\xbf\x42\x7c\x29\x53\xbc\x05\x12\x02\x71\x9b\xc7\x6c\x43\x67\xa2\x8b\x54\x1b\x24\x19\x91\x16\x36\x46\x97\x4c\x56\x54\x79\xa5\xa5\x72\xfc\x47\x56\x48\x54\x87\x34\xb6\xf5\xba\x94\x8e\x18\xfb\x6b\x8d\xd6\x11\x3b\x56\x70\x25\x94\xd2\x0e\xd6\x08\x75\x95\x0b\x87\xf9\x0a\xae\x15\x5c\x89\x12\x8b\x2b\x61\xf1\xb9\xa9\x4c\x04\xb5\x4b\xa2\xe0\x69\x3a\xb7\x35\x1d\x60\x58\xf8\xe9\xcb\xab\x60\x41\x3d\xfa\x01\x40\xe4\x39\x5b\x0e\x51\x7c\x1e\x78\x78\x10\x83\x5e\x35\x6a\xde\xc4\x6c\x56\x50\x2b\xeb\x4c\x9d\xb9\xda\x60\x0e\xf7\xb8\x0f\x1c\x2f\x45\x05\xd6\x69\xba\xf8\x28\xdd\xae\xf3\x46\xd1\xe6\xbe\x70\x2c\xee\x6b\x04\x8b\x0e\xd6\x7b\x20\xfb\xc8\x0a\xe1\xb4\x2e\x88\x55\x1e\x16\x2b\x86\x41\x67\x24\x3e\x60\x17\xa4\x59\x4b\x67\x84\xd9\x27\xda\xad\xe0\xeb\x0e\xf7\x20\x0c\x02\xb1\xf9\xd7\x1a\xcd\x5e\xac\x0b\x0f\x27\x28\xec\x1a\x81\x35\xdd\x3c\x60\xde\x01\xf9\xb8\x43\x05\xa5\xce\xe5\x66\x4f\x92\xeb\xc5\xb2\xab\x7c\x17\xe7\xe7\xf7\xf5\x1a\x8d\x42\x87\x6c\xcd\x73\x9d\xd9\xf3\xda\xa2\x59\x6e\x6b\x99\xe3\x79\x8b\x41\x67\xb3\x3e\xd2\x7b\xc8\x07\x3f\x65\x45\x6d\x1d\x9a\x1b\xb2\xe5\x63\x3c\xf9\xba\x43\x36\xdf\x64\x97\xbc\xec\xf3\x73\xf0\xb8\x93\xd9\x8e\xb5\x21\x68\xd3\x1a\x0b\xad\xb6\x44\x4d\xa2\xcb\x91\xc6\xd1\x3f\x69\xa1\xb6\x98\x13\xb9\x73\x69\x9d\x54\xdb\x5a\xda\x5d\x62\x94\x65\x4e\x82\xa5\x77\xf1\x0b\x89\x8a\xf4\x1f\x5b\x89\x8c\xc8\x01\xb9\xdc\x6c\xd0\x1c\x6b\x5e\x6b\x31\xd6\xbf\x19\x36\x12\x0b\xb6\x13\xc4\x16\xe2\xb9\x50\xfb\xc7\x1d\x1a\x04\x23\xb7\x3b\x07\x4a\x3f\x32\x8f\x44\x25\x2d\xeb\x3d\xf4\xa0\xbb\xd5\xc4\x13\xa7\x41\x6e\x15\xf3\xc3\x81\xdc\xb0\x04\x49\xe5\x9d\x23\x82\x36\x41\xb3\xa3\xde\xaf\x66\x13\x25\xbf\xeb\x5d\xc7\x98\x30\xbf\x3a\xbe\x9d\x56\x27\xc0\xa5\x3f\x3b\x26\xd0\x2f\xec\x08\x28\xf0\x13\x5e\xee\xd8\xbe\x05\xde\x3d\x0a\x1b\x96\x44\x26\xca\x45\xd2\x6d\x6b\x61\x84\x72\xe8\x99\xe6\xf5\xa7\x03\x51\x2a\xd8\x89\xaa\x42\x65\x97\x6b\xdc\x10\xa5\xb4\xc9\xd1\x80\xc8\x8c\xb6\x16\x2c\x56\xc2\x10\x85\xc8\x3c\xf0\x1a\xec\x0a\xae\xd8\x80\x7a\x6b\xab\x74\x17\x26\x51\x99\xf1\x63\x6d\x8f\x28\xa5\x35\x62\x4e\xe2\xf0\xe5\xfd\xd5\x9b\x37\x6f\xfe\x48\x0e\xbd\x64\x76\x4a\x4b\x97\xbf\x7d\xbd\x5a\xc1\x9d\xea\xc0\xfc\xac\xab\x9a\x9c\x63\x4e\x16\x80\xe4\xd6\xee\xad\xc3\x72\x05\x5f\x50\xe4\x4b\xad\x8a\xfd\x0a\x6e\xea\xa2\x20\x78\x50\x48\xeb\xec\x73\xdb\xe7\x68\x37\xe6\x47\xb8\xd1\x02\x84\xe3\xf0\x07\x97\xc4\xa0\xa9\x42\x94\x63\x81\x44\xd1\x3f\x19\x91\xe1\x67\x34\x52\xe7\xb7\x98\x69\x95\xdb\x51\x69\xba\xa9\xcb\x35\x1a\x52\x68\xeb\xef\x06\x51\x14\xfa\x11\xf3\x10\x1b\x35\x72\xe1\x34\x6c\x09\xf6\xa6\x2e\x8a\xfd\x11\x48\x00\x87\xa6\x94\x8a\x78\x1b\x18\x2f\x1d\x3c\xca\xa2\x20\x87\x67\xb0\xd4\x0f\x98\x37\x0e\x34\x52\xfb\x93\x2a\xf6\x24\x47\x2c\x84\x1d\x90\x71\x45\x87\x72\x5e\x58\x4d\x8f\xac\xe0\xa3\xd8\x03\x71\x8a\xde\x60\x77\xda\x38\x54\x98\xb7\x39\x38\x40\x59\xa9\xdc\xbf\xfd\xfe\xe8\x37\x6f\x19\x29\x36\xda\x1e\xe9\x49\x07\x89\x71\xdd\x7c\xdb\x87\xf3\x97\xf7\x57\xc0\xd2\x49\x4c\x65\xe9\x24\xc6\x82\x70\xc9\x70\xf6\x98\x9c\xe4\xb3\x22\x15\x19\x13\xcc\x8f\xcd\x5a\x70\x63\x8d\x9a\x33\x31\x41\x24\x66\x0d\xd2\x15\x64\x0a\x51\x1a\x45\x20\x4f\xb2\x88\x1a\x44\x7a\x9f\x4b\x83\x99\xf3\x7c\x72\xec\xd1\xd6\x5d\xee\x8b\x10\x06\x11\x72\xd8\xb8\x5b\x69\x01\xbf\x57\x98\xb9\x64\x34\xc2\x22\xe0\x85\xd2\x40\x2e\x02\x0d\x3c\x48\x2b\xd7\xc5\xb1\x9c\x83\x97\x96\x04\x8a\x95\xd0\x23\x46\x58\x19\x14\xd9\x2e\x60\xc3\x2e\xe9\x25\x88\x0d\xb9\x22\x5a\x03\x53\x57\x76\xb5\xde\x25\xc2\x2d\x40\x2b\x0e\x06\x11\x36\x52\x89\x42\xfe\x46\xf1\x1e\xbd\x83\x88\x82\x65\xe5\xf6\x2b\xb8\xb4\x8c\x22\x08\x7b\x74\x63\x07\x30\x3f\x48\x7a\x2f\x24\x05\x2b\x0e\x4b\xbb\x38\x20\xf3\xba\xd0\xd9\x3d\xf1\xee\x53\x7c\x6d\x7e\x2c\x28\x1d\xa0\x9e\xb7\x8b\x96\xed\x8b\x26\x92\x08\x59\x2b\x62\xbc\x36\xc1\x12\xc3\xa6\x36\x6e\x47\xce\x4b\x85\xd8\x7f\x53\x53\x9c\xb4\xe8\x80\x15\x85\xdb\xe9\x7a\xbb\x03\xd9\x44\x42\x51\x7b\x20\xa4\x43\x89\xea\xe1\x86\xc8\xb5\xca\x48\xdd\xe3\x46\xe8\x85\x94\x57\xc9\x12\x57\xf0\x5e\x1b\xc0\xef\xa2\xac\x0a\xca\x2e\xc8\xcb\x9b\x90\x60\xb0\xa4\xf9\x10\x4c\x40\xa5\x59\xc2\x02\xe4\x0e\x4c\xa9\xe0\xcd\xab\x68\x92\xbc\x54\xfd\xb9\x5e\xd3\xcd\xde\xaa\x10\xff\x59\xee\x2d\xaa\x9c\x7c\x73\x23\xef\xc9\x14\x1d\x27\x53\xf4\xb5\x72\xeb\x63\x3d\xa6\x51\x60\x19\xf1\x5e\x2a\xbe\x52\xe9\x7c\x05\x97\x41\x92\x84\x6b\x21\x41\x8c\x48\x48\x74\xe0\x32\x52\x84\x0b\x08\xd8\x09\x93\xb7\x91\x88\x2f\x7d\x71\x7b\xfd\xa7\x3f\x5f\x7f\xf8\xf0\xb2\xf3\x7a\x12\xeb\x0e\x48\x2f\xcf\x59\x81\x42\xd5\xd5\x22\x18\xd1\x88\x64\x63\x4b\x2f\x3f\x5f\x73\x26\x41\xff\xf7\x2e\x31\x43\x32\xe7\x0a\xdd\xa3\x36\xf7\x1d\xb0\x95\x30\x8e\xc3\x74\xbb\x38\x30\xef\xc4\x23\xeb\x68\x19\xf8\x9d\xc4\x39\xaa\x53\x60\x2c\xcb\xe8\x02\x6a\xe5\x64\xd1\x45\x55\x81\xc8\x4b\xa9\xa4\x75\x46\x38\x6d\x48\x8e\x44\xed\x74\xc9\x2e\xb6\x32\x3a\x43\x6b\x21\x13\x0a\x72\xf4\x84\xc1\x43\x39\xeb\xb1\x7f\xec\x66\x12\x19\x49\x77\xae\x37\x31\x86\x5b\x34\xcc\x4e\x5a\x16\x42\xd2\xb0\x9a\x9d\xe8\x42\xa4\x87\xd7\x88\xaa\x31\x7a\x14\x1b\x0c\xc5\x02\xc7\x66\x34\xbd\xa9\x03\xb7\x6d\x46\x0f\x22\x88\xff\xe7\x11\x43\x63\xd0\x46\x7d\xda\xc7\xda\x12\xdd\xbc\x55\x8c\xde\xbd\x45\xea\x46\x8b\x1b\xa1\x34\xb8\x25\x59\xe8\xf8\x60\x80\x77\x22\xdb\x01\x2a\x67\xf6\x21\xa9\x93\x39\x05\xaa\x1b\x89\x26\x55\x63\x0c\xda\x4a\x2b\xf6\x0a\x90\xe9\xb2\xd2\x0a\x39\xd9\x26\x87\x29\x8b\xae\xf8\xb5\x54\xc3\x43\x4e\x78\x90\x61\x66\xc1\xe9\x35\xb9\x87\x32\xd3\x01\xcb\x0e\x50\x2d\x95\x2c\x16\x8c\xb1\xc4\x60\x26\x64\x70\x15\x24\xd0\x31\x02\x09\x31\xce\xf1\x82\xd9\x17\x1c\x93\x77\x84\x27\xf1\x27\x61\x8c\x38\x74\xb3\x5b\x54\x14\x33\xe3\xc9\x24\x6d\xfe\xa7\xd6\x9d\x81\xc8\x9a\x7f\x13\x05\xe5\x9f\x1b\xf9\x7d\x41\x66\xb9\x91\x77\xce\x0e\xba\x9e\xc2\xe9\xf4\x52\x32\xe4\x4a\xfe\x5a\x87\x6c\xec\xd3\xcd\x87\xff\x81\xeb\xf7\xfc\x34\xe1\x13\xa2\x91\x9d\xb0\x8d\x92\x55\x46\x3f\xc8\xbc\x4b\x11\xf0\xec\x68\x87\x30\x84\x0c\x19\xa3\x00\xdd\xa0\xab\x8d\xf2\x21\x43\x53\x61\x49\xd1\xe4\x70\xe6\xe7\x76\x42\x35\x60\x2a\x61\x6d\x0a\x97\xbc\xff\x64\x10\x1c\x41\xae\xc9\xfa\x96\x6b\xa9\x42\xd1\x20\x2d\xb0\x03\xd4\xd6\x9b\x8d\xfc\x4e\x60\xc8\xbe\xfa\x35\x05\x77\xbc\x0b\x91\x01\xa7\xa9\x4d\x59\x12\x4c\x5d\xa0\x8d\x61\x03\xd1\xa7\x03\x34\x04\x21\xb1\xf8\xb6\x46\x70\xa6\x56\x59\xdb\x0a\x15\xa8\xb6\x6e\x17\x45\xd4\x63\xc1\x76\x46\x52\xa1\xc3\xe9\x0e\xcc\x52\xdc\x7b\xbd\xf4\xc8\x05\x7e\x69\xd5\xe2\x31\xdb\xbb\x0e\xf9\xa9\x52\x2b\x37\xb2\xc7\x0b\x13\x7e\xf4\x74\x14\x03\x9f\x83\x7b\x07\x61\x17\x2d\xc0\x9e\x39\x37\x9f\xa8\xd0\x46\xcc\x03\x01\xbf\x7f\xf5\x47\x58\x76\x20\x4a\x65\x1d\x8a\x7c\x91\xd2\x03\x94\x1c\xb6\x84\xc7\x7e\x79\xf5\x1a\x38\xbd\xf5\xb1\xc8\x1f\x5e\xbd\xf2\x85\x80\x2f\x28\xac\x56\xa1\x30\x47\xfa\xab\xeb\x1e\x7d\x55\xb9\xcc\x04\x27\xbd\x87\xe2\x9a\x71\xf5\x25\x04\x4e\x1b\x5d\x53\x7a\xa8\x9a\x48\x91\x12\x1e\xe7\x30\x5f\x0c\xae\x3f\x48\x60\x28\xe3\x18\x24\x1b\xf3\x22\xea\x54\xb1\xef\x86\x9e\x8c\x08\x67\xa6\x1d\x98\x04\xef\x0b\x41\x58\xfa\x30\x63\x87\x22\x47\xf3\x92\x59\x73\x59\x55\x85\xa4\xa5\x93\x51\x91\x1b\x88\x1a\x4c\xa8\x27\x2e\x75\x15\xea\x79\xfd\x8c\xcc\xb1\xac\xb4\x43\x95\xed\xe7\xb3\x89\x66\x2b\x08\xc8\x51\x59\xbc\x63\x9a\x2e\xc1\x92\xa3\xa4\x18\x58\xf9\xbc\xf3\xa0\x54\x21\xe2\x22\xb3\x28\x71\x14\x3e\xeb\xcd\x11\x48\x08\x01\xb4\x65\x4d\xb0\x4e\x38\x5c\x0d\x79\xf1\x67\xcf\x07\xb9\x3b\x32\xc5\x6d\xce\x2f\x55\xfb\x66\x62\xa3\xa0\x92\xbd\x33\xba\x28\x52\xcd\x0c\xd5\x46\x73\xbd\xcb\xea\x32\xe2\x7c\x04\x95\x04\xfb\x41\x18\x29\x94\xa3\x94\x31\x78\xdd\x58\x33\x0a\x51\xf7\x61\x4e\x28\xbc\x7f\xd2\x9b\x36\x06\xdd\x80\x88\x43\xf1\x9d\x78\xf0\x25\xcb\x3d\xd5\xc6\x38\x55\xd3\x07\x05\x21\xf6\x9f\x4a\x16\xa4\x90\x1c\x03\x1c\xc4\x8d\x1d\xa0\x64\x14\xd9\x01\x90\xe7\xa6\xe0\xbe\xd8\xb7\xb0\xa0\x14\x88\x14\xfe\x51\x5a\x5c\x1c\x45\x11\x19\xf9\xfc\x1c\x4d\x8f\x21\xaa\x55\x0b\x44\xcc\x4e\x77\x32\xcf\x51\xc1\x0b\xa9\x78\xb9\xe7\x8f\xc2\x65\x3b\xfe\x71\x8b\x0e\x32\x51\x14\xf6\xa5\x0f\x49\xbc\xfe\x8e\x10\x40\x9d\x39\xca\x54\x0b\x99\x49\x4a\x75\x85\xbd\x67\x1b\x0b\x7a\xcd\x86\xf3\xe8\xfd\xa9\x36\xdb\x53\x59\xfa\x6f\x8e\x1a\x63\xcf\x06\x64\xaa\xa5\x2d\x0e\x62\x4b\x32\x97\x55\x10\xd9\x56\x44\xd1\x5b\xbf\xa6\xe7\xb2\xda\x50\xb1\x93\x82\xdf\x63\xb6\x86\x32\x4a\x65\xe4\x83\x2c\x70\x8b\x39\x39\xf7\xd0\xbd\xe0\xdb\xbb\x19\x9b\x2f\x33\x37\xef\x0d\x79\xa9\x6c\xb2\xdf\x45\x4c\x0f\x83\xd5\xe4\x27\x24\x85\x78\x3e\xcf\xec\x80\x5c\xef\x41\xa8\x3d\xbf\x9a\x4d\xd9\xdb\x77\x9f\xbf\xbc\xbb\xba\xfc\xfa\xee\x2d\x2c\x0f\xd0\xe5\x12\xb9\x50\x20\x8a\x6a\x27\x82\xc8\x12\xcf\x7a\x23\xbb\x56\xf1\x48\x2a\x78\x78\xbd\x7a\xfd\x87\xd5\xb1\x51\x1a\xea\x54\xd0\xb7\xf2\xd9\x61\xf7\x87\x23\x65\xfd\x1c\xb2\xc8\x41\xdd\x09\x9d\x03\x0a\x85\xf1\x3b\x66\xb5\xeb\xfa\xf4\x90\xb6\xfa\x82\x67\x0a\x93\x93\xa2\x10\x69\x43\xa9\x63\xe5\xa5\x84\xf8\x5a\x08\xeb\x22\x96\x03\x10\x13\x12\x04\x21\x50\x23\x16\x42\x60\x23\x64\x41\x0e\xcf\xa0\xad\x0b\x17\xea\x41\x5e\xd4\xda\xe8\xf7\x82\xf6\xcd\x94\x14\x57\x91\xac\x38\xcd\x9a\x1e\xfd\x5e\x9f\x6e\x52\x5c\xd3\x80\xee\xaa\x6a\xf4\x9b\x61\xad\xa4\x45\xa2\x28\xa2\x0a\x76\x9d\xd7\x60\x8c\x7c\x8a\xb7\xfe\xab\x7a\xc2\xe1\x01\x26\xb7\x3b\x17\x31\x27\x65\xb6\x4a\x7b\x90\x72\x50\x1a\x92\x56\x38\xc4\x97\xa8\x9a\x89\xbf\xab\xd9\xe0\x4d\xc3\xc1\x7e\xcc\x5f\x7e\xad\xc9\x97\xf5\xaf\x63\xc9\x41\x4c\xef\x4f\x83\x0d\x9d\xf1\x54\x22\x94\x17\xeb\xc2\x5d\xcc\x4e\xd0\xec\x7a\x73\x28\x5a\x3e\x1c\x23\x0a\xbe\x17\xb2\xa8\x4d\x08\xfd\xdb\xa6\xbc\x07\x64\xa8\x8f\x50\xff\x8b\x9a\xe0\x36\xd4\x03\xa9\xd1\x26\xb6\xa1\x22\x4a\x1a\x11\xf2\x48\x4a\xb7\x6c\x4d\x41\x86\x57\x3b\xdd\x6b\x71\xe8\x5f\x90\x2a\x2e\x2d\x44\x5b\xdd\x4e\xf5\x56\xb3\xa7\xcb\x54\x7f\x8b\x7f\x90\x42\x4f\x6d\xf7\x0f\xc0\x84\x26\x14\x8a\x61\xcf\x93\x5a\xff\x83\x60\x7b\x47\x02\x9e\x32\x06\x30\x08\xf9\x9f\x38\x1e\x30\x29\x08\x8d\xdf\x4c\xe7\x38\x89\x75\xb7\xf5\x76\xeb\x8b\xdf\xff\xf9\xf5\xeb\xe7\x98\xba\xd0\xe3\x4d\xf3\x83\xc2\xcb\xda\x2e\xe0\x15\xc8\x6e\x1c\x1a\x3f\xa1\x2c\x35\x64\x02\x5a\x91\xe6\x9b\x5f\x06\xee\x19\x8e\x38\xe3\x27\x47\x27\x64\x61\x27\xad\xec\x1d\x0d\xfd\xe4\x98\x53\x1b\x49\x80\xb0\x56\x67\x92\x83\xe3\xa4\xbe\x86\x33\xaa\x95\x2f\xc8\x0c\x80\x24\x99\xa4\xbb\x58\x32\xbc\x6c\x03\xcd\x35\xe8\x47\xc5\x6d\x73\xff\x06\x8f\xd6\x51\x08\x3a\x08\x31\x55\x22\xa2\x8f\x61\x0c\x53\xca\xdf\xdb\x6c\xcc\x34\x45\xc9\xe5\x6c\x00\x24\x99\x12\x8a\x3d\x82\x9e\xe1\xf7\x0c\xab\x50\x2e\xf2\x48\xa7\x9c\x20\x2c\x87\x68\x3d\xc4\xab\xd3\x1e\x07\x20\x13\xb5\x1d\xfb\xfd\x88\x19\x54\x39\xb8\xe2\x47\xbc\x2d\x06\xa9\xb2\xa2\xce\xd1\x42\x49\x89\x5b\xe0\x6b\x8b\x4b\x23\x80\xa1\x31\xc0\xb7\x2c\x99\x21\x33\xa6\x38\xa0\x36\xb8\x82\x1b\xed\xa8\x83\x77\xf0\x2b\xc7\x82\xa3\x40\x43\x61\x23\xe0\x82\x79\x58\xe2\x10\x91\x4e\x78\xed\xa7\xd0\x32\x68\x08\x89\xcd\xa9\x9b\x8e\xc8\x3a\x27\xba\xb2\xf7\x89\x4e\x3d\x95\x93\x43\x5c\x4f\x25\x67\xaa\x2d\x9d\x84\x1b\x1c\x39\x1a\xa3\xcd\x82\x02\x1c\xf2\xb8\x2c\x35\x24\xee\xff\x75\xfb\xe9\x86\xea\x1c\x1c\x0f\x88\x21\xb7\x72\xfc\xfd\xd8\x30\x1a\x72\x62\x8a\xca\xa1\xd2\xd6\x6d\xe4\x77\x88\x13\x1a\x6c\x66\x14\x9b\xa0\x09\x10\x85\xf3\xd3\x55\x64\x73\x2f\x49\x90\x7c\x2c\xfd\x1b\x1a\xbd\x94\x2a\xc7\xef\x54\xed\x82\xf7\x44\x91\xd3\x1c\x8f\xbe\xae\x42\x61\xbc\x1c\x72\xf5\x8c\xdb\x62\x92\x33\x18\x2f\xab\x7a\x13\x64\x01\xf2\x9e\xe2\x58\xf7\xeb\xb4\xb7\x01\x96\xf2\x2a\xf2\xe0\x65\x5d\x38\x59\x15\xe8\xa9\x6b\x57\xf0\x29\x58\x00\x4e\x13\xde\xf9\x4e\xd1\x49\x01\xa1\x7f\x77\x00\x77\x73\xe2\xcc\xdd\x1c\x96\xa1\x25\x47\xdc\x4f\x17\xb5\x6a\xe7\x4a\x13\x20\x26\x81\x21\xc8\x2c\xd0\x7f\x7d\xf5\xb7\xd5\xc8\x2b\x26\xc0\x0c\x48\x6c\xa4\xa1\x26\x0a\xd3\x30\x94\xbb\x55\x7c\xc9\xdd\x7c\x3e\x1b\x81\x30\xcd\xcb\x35\x9f\x12\xad\x15\xdb\x91\x28\xb8\x57\x7d\x2e\x61\x57\x97\x42\x2d\x0d\x8a\x9c\x1b\xa9\xad\x5f\xa3\x42\x31\xe7\x4f\x82\x85\x78\x3b\x73\x78\x05\x6d\x4f\x10\xaa\x9b\x21\xb2\xe1\xec\x61\x39\xe2\x1d\x9a\x2f\xd9\x74\x72\x3f\x39\x9a\xd5\x73\x12\xcb\xbb\x80\x27\xd3\xaa\x14\xd9\x4e\x2a\x1c\xa3\xd6\x49\x90\xc1\x71\x1c\x51\x2b\x96\x63\x39\x9a\x4a\xf9\x37\x01\x34\x53\x40\xb2\xc3\xe4\xe8\x8b\x62\x0c\xc2\x46\x3c\x08\x59\x10\x47\x9f\x91\x6e\x27\x12\x8d\x29\x09\x47\xfc\xf8\x79\xe0\xd9\x44\xca\x93\x8d\xe7\x27\x1a\xeb\xd7\xb1\xf6\x4f\x75\x9c\x3e\xa4\x3b\xf0\x90\xab\xd9\x4f\x12\xe9\x78\x54\x75\x74\x51\x67\xb4\x2a\x7a\xe2\x1f\xbc\x28\xf8\xa4\x7c\x5d\xb1\x19\xb7\x22\xbf\x10\x66\xe7\x46\xe1\xb6\x3a\x79\xa1\xb3\xd9\xa0\x46\x83\xb7\xff\xa4\x71\xd5\x1f\xe2\xc5\x78\x49\xa0\x47\xc0\xe8\x81\x7f\x2c\x2b\xe0\x45\x18\xb3\x43\x22\x1a\x15\x99\xac\x54\xdb\x02\x87\x53\xfb\xf8\xf5\x65\x62\xca\x6f\xd7\xd1\xe8\xac\x31\x7f\xf9\xd3\x02\xcb\x4d\x0c\xee\x40\x0c\x4c\x89\x0d\x52\xec\x7a\xd3\xf4\x22\x16\xed\xa6\x47\x9c\x94\x68\xf5\x88\x47\x60\x42\x33\x04\x18\xb3\x5a\xae\xf6\xd1\xc4\x6d\xbe\x82\x5b\x92\x5b\x36\x91\x71\x0e\xdb\xf7\x54\x46\x21\xb6\x7a\x35\x5c\xaa\x73\xd4\x12\xa3\x50\xa6\xe0\x19\x5f\x1a\xbe\xca\xc8\xae\xc0\x32\x24\x78\xda\xc6\x97\x9c\x80\x7b\xe0\xd0\x22\x2e\xb0\xd3\x8f\x7e\x44\xc8\x69\x78\x14\xd2\xa5\x95\x8b\xfb\x31\xda\x47\x54\x8f\xd1\x1a\x63\xea\x94\x1c\x72\x5a\x1e\x49\xdf\x5a\x3e\xc1\x5a\x7d\xbb\x7e\x7b\xac\x13\xab\x21\x81\x9e\x4d\x0a\xb7\x86\x84\xfa\xc9\xc3\xce\xcd\xf0\x80\xfd\x5d\x2d\x7f\xda\x76\x9c\x74\x73\x63\x66\xfe\x19\x76\x27\xcc\x46\x05\x30\x54\x63\x7f\x64\xa7\xc2\x6c\x82\xc6\xfc\xd0\xae\x85\x41\xc0\xff\x74\xf7\x70\x92\xbd\x27\xc2\xe4\x27\x07\xc7\xc1\xcc\x9f\x2a\xeb\x25\x2b\xb7\xfa\x71\xc4\xbb\xdb\x33\x06\x31\x3f\xbb\x75\x42\xe5\x34\x81\x46\x8d\x9d\xf4\xec\xbf\xc0\x5f\x4f\xaa\xa4\x68\xd2\x84\x7a\xba\xbb\x8e\x0f\xc4\xc4\x82\x9a\x16\x72\x93\x26\x57\xb9\x44\x4d\xdd\xcf\x52\xba\xd9\x84\x2c\x2d\x74\xa1\xa9\xd9\x43\x89\x59\x28\x01\xc6\xfe\x4a\xb4\xf3\xa1\x4d\x70\xca\x9f\x85\x51\x08\x6a\x80\x72\x42\x4d\x3c\x6b\x45\xe3\x1c\x6a\xa4\x28\x5f\x57\x82\xc6\x69\xfa\x06\xff\xda\x9f\xb0\xcc\xb8\x57\x42\x5a\xcb\x0f\xe9\x30\x34\x11\x46\x2a\xf5\x81\xb2\x33\xb6\xa7\x31\xcd\x5b\x7d\x47\xa7\x83\xe7\x0d\xf5\x73\x85\xdf\x53\xaf\x31\xad\x60\x14\x64\xea\x89\x5e\x79\x0e\x91\x7d\xe3\x7e\x37\x97\xfb\x95\x0b\xe2\xd8\x74\x14\x2b\x6d\xfb\xe7\x7e\xdb\x1f\xb9\x69\x0f\x99\x50\x1d\x50\x6e\xeb\x10\x34\x10\x9d\xb3\x9d\x50\xd4\xf1\xd4\xed\x1a\x86\x18\x05\xb9\xc1\x47\x28\xa5\xa2\x32\x0a\x95\x28\xda\x73\x42\x8d\x7f\x8b\x05\x7d\x9f\xc4\x46\xa9\x18\x85\xcb\xfe\xb0\x26\x2f\xe8\xe9\x9a\x24\xb5\x35\x7a\xb4\x46\xf0\x1e\x2b\x4b\x33\xa8\xa3\x30\x83\xb4\xb4\x2b\x0a\xa1\x51\x85\x34\x8a\x59\x50\x07\x6b\xaf\x6b\xbf\x0e\x83\x19\xca\xbe\x9d\x45\xed\x0f\xa3\xe6\xf4\x3d\x2a\xef\x24\x84\xf2\xf1\x4f\xb4\x8e\x63\x21\xc8\x49\x43\xd5\xf6\xf1\x81\x82\x93\x15\xfb\xec\xd6\x35\x0d\x9f\xe4\xd6\xc3\x7c\x15\xb3\xff\xec\xcc\xa6\xb6\xc5\x08\x54\x88\x9d\x97\xd8\x70\x89\x7e\x93\xb4\x22\xc6\x1c\x71\xfc\x2d\xf6\x8f\x7a\xc6\xa9\xda\xdf\x66\x6a\x95\xb9\x1c\x64\xdd\x93\x3d\x88\xe0\x0a\xfe\xc2\xcc\x2a\xc3\xb4\xa4\xa3\xf9\x8c\x13\xcc\x10\xc9\x0c\xb4\x50\x21\xc3\xe3\x45\x12\x6a\x95\xda\xee\x6b\x91\xdd\x4f\x91\x98\x38\xe7\x35\x61\x1c\xa6\xe5\x11\x46\x41\x3e\x83\xb7\xc8\xb4\xf2\x03\x0c\xd9\x7e\x19\x46\x60\x96\x42\xe5\xcb\x64\x1e\xb2\xfd\xd9\xcf\x0a\x9e\xc5\x62\xf3\x41\xaa\xfb\xc9\x12\x17\x1f\xf0\x51\xda\xb7\x2f\x1f\x8e\x83\xb3\x24\x3a\x63\x4a\x31\x69\x2f\xd1\xcf\xad\xed\x64\x54\x3a\x5e\xd3\x7a\x62\x25\xeb\x71\x17\x06\x43\x52\xe0\x32\x00\x97\x6b\x4f\x61\x8e\x6e\x1e\xba\xc1\xf3\x90\xfc\x8e\x97\xb5\xc6\xfa\x43\x83\xc5\x2c\xb8\x8c\x53\x80\x59\x21\x0c\x4d\xc2\xf1\x64\x2b\x77\xee\xfc\x4b\x07\x61\x72\x47\x6f\x5d\x3b\xc8\x35\x52\xb9\xcc\x81\x7e\x40\x63\xa8\xe1\x21\x3b\xbb\xf4\x26\x33\xc6\xbf\x74\x12\xd5\xcf\x6e\x5b\xb1\x62\xab\x1c\xb3\x82\x4f\x8a\x86\xf5\x2f\x60\x7e\x5b\x67\x34\x24\x3f\xef\x1b\xd7\x89\x9f\x44\xe5\xe7\x8e\xe6\x28\x9f\x67\x85\xf4\x6b\x3a\xfb\x31\x92\x8c\xc8\xe9\xd0\x84\xc3\x72\x60\xf6\x65\x10\x54\x21\xd6\xd8\xed\x81\x3e\xf3\xce\xe3\x8f\xa2\x22\xe7\x11\x12\xb7\x7b\xdc\x93\xa4\xc5\xed\xf0\x5d\x3f\xe2\x34\x68\xb3\x15\xd4\x86\xef\xbc\x93\x9e\xa3\x10\x72\xab\x8d\xfc\x0d\xe1\x05\x1f\x64\xc0\xd0\x2c\x16\x98\xb9\x97\x61\x91\xb4\xbf\x50\xec\xa1\xe4\x11\x36\xff\x93\x36\xb6\x6f\xf6\xd1\x60\x55\x50\x25\x84\xd4\xb5\x19\x27\xb4\x01\xa6\x79\x90\x19\xda\xa7\x27\xd2\x9e\xae\x67\x53\xd9\x50\x0a\x25\xb6\x98\xfb\x5e\xd3\xc5\x18\x31\xe7\x1f\xdb\xb7\x42\x29\x2a\x0b\xb4\x2f\x65\x53\xe8\xc7\xa5\xcc\x19\xed\xe8\xb0\xc3\x88\x42\xdf\xc6\x52\xbd\x89\x6d\x25\x26\x3f\xf5\xbd\x02\x0e\xe4\xc6\xf9\x5a\x84\x1a\x3a\xd1\x92\xa2\x70\x4b\xd3\x7c\x54\xea\x19\x0c\x1c\x76\xba\xb6\x78\x8f\x58\x49\xb5\xf5\x51\x3f\xe5\x11\x96\xec\xb2\xa4\x11\xc2\x7d\x28\x4e\xd1\x84\xa0\x0a\xfd\xe8\xb0\xf3\xaa\x56\x39\x1a\xeb\xfa\x42\xf8\xa6\x60\xb4\x82\xcb\xb4\xde\x28\x35\x31\x5b\x39\xf3\x8d\xc6\xc5\xc1\x60\x68\xbc\xd8\x81\x19\x36\x47\xc4\x29\xa6\xd6\xb0\xac\xa8\x2a\x1a\x00\x14\x6e\x07\x85\xbc\x47\xb8\x9b\x67\x72\x99\xe5\x77\x73\x22\x05\xc6\x38\xde\xd3\xaf\x03\x96\xbc\x5f\xf1\x28\xf6\xc9\x96\x27\x6e\x84\x9c\xa7\x41\x9f\xa3\xa6\xa3\x7d\xea\x7d\x01\x49\x1c\x5a\xb9\x53\x87\x43\x01\x61\xe6\x8f\x88\x1c\x28\xd1\x8a\xdf\xe3\x9c\x1f\x95\x51\xfb\xa6\xbb\x95\x76\x32\xc3\xce\xf4\xdf\x40\x1b\x7a\x3c\xf9\x3c\x35\xe2\x73\x20\xc1\xe3\xf3\x3d\x29\xca\x8c\x81\xef\x58\xf6\xd5\xaa\x23\x12\x53\x88\x6f\xe4\xc6\xfc\x2e\x79\x0c\x35\x3e\x72\xab\x73\xee\x79\x9c\x87\x77\xcc\xe1\xef\xf5\xd1\x31\x1e\xcd\x97\x39\x4e\x6c\x72\xba\x5a\x16\x64\xe1\xdb\x18\x07\x19\x0c\xdb\xb8\x91\x5c\x0c\x9d\x5a\x40\x9a\x66\x44\x76\x3f\x88\xe7\xc1\xfa\xe2\x98\x26\xe1\xbc\x46\x6e\x0a\xd2\x78\x68\x96\x6a\x43\x61\xaf\x97\x57\x98\x01\x98\x61\x64\xa9\x6f\x7e\xfd\x84\x71\x4e\x03\x02\xbd\xbc\x1c\xb0\xfe\xe0\x4c\x8d\xa7\x99\x1b\xcc\x52\x2b\xe1\x10\x87\xfa\x32\x86\x6d\x8f\x61\x6c\x9b\x47\x33\x41\xb8\xbc\x75\x34\xdd\xbd\x50\x7a\x73\xa8\x7b\x0c\xb2\x9f\x36\x81\x63\x16\x27\xa0\x3c\x48\xe0\x14\x93\x4c\x40\xfa\x53\xbc\x37\x9e\xa6\x43\xb0\x89\x64\x09\x48\xa8\xf0\x16\x28\x7a\xb7\xaa\x44\x9c\xa5\x85\x03\xf7\xf0\x8e\x1b\xe5\x6b\xa4\xf8\x3b\x1d\x41\x40\x9a\x41\x51\x34\xf9\x5f\x19\xbd\xf0\x00\xc8\x34\xb6\x15\xe6\x8a\x0d\xc2\x19\x6d\xaa\xd8\x9f\xb1\x69\x3f\xfb\xc6\x45\xcc\xb3\x1f\xa2\x10\x75\x39\x26\x10\x87\x76\xa7\x40\x7b\xd3\x24\x11\x26\x16\xcb\x13\x8f\xe0\x91\x3a\x41\x23\x33\x63\xd7\x69\xbb\x49\xb0\xce\x69\x07\x9e\xdc\x1c\x32\x20\x2c\x70\x36\xd6\x35\x18\xda\x1b\x38\x61\xe1\x23\xa2\x3e\xd4\xee\xed\x6b\xc0\x1d\xd0\xe8\x8c\x37\xb6\xc4\x54\x39\x6c\xd5\x21\xc3\x4f\x93\x27\xcd\x39\x1f\x2b\xb8\xb6\xcd\x96\xa7\xde\x33\x02\x58\x4a\xc2\x00\x34\x97\xd0\xed\xa2\xd9\xe1\xcc\xbd\xcf\xf4\x03\x97\x0c\x69\xaf\xcf\x63\xda\xae\xde\x27\x9b\xa9\xa8\xd6\xec\x7b\x8a\x66\x50\x81\xa8\xc8\xb1\x18\x6a\x06\x86\x73\x49\xda\x96\x6f\xd5\xbf\xd9\x4b\x5a\xa8\x8c\x2c\x85\x91\xbc\x15\x22\xcc\xcd\x91\xa8\xa6\x4d\x1c\xcd\x9e\x1b\xaa\xee\xe5\x47\x95\xae\x3c\x1d\xca\xd5\x95\x96\x9e\x02\xfd\x53\x63\xbf\xc6\xea\xd8\xdf\xd1\xa2\x06\xc2\xc0\x1e\xf9\x48\x9c\x1a\xe5\xf6\xfc\x26\xde\x76\xe0\x40\xfd\x95\xc0\x75\xda\xce\x0f\xaa\x2b\x15\xdd\x05\x5f\xaa\xa0\x07\xe9\xe5\xa4\x6d\x94\x5f\x3c\x88\xc2\xf3\x94\xc1\xdf\xcd\x73\xdc\x88\xba\x70\x77\xf3\x46\xa2\x16\xb0\xee\x09\x2d\xda\xb7\x06\x8b\x96\x09\xa5\x15\x71\xb5\x29\x0a\x84\x8c\x2d\x0e\xd8\xc5\x22\x10\x85\xa2\x51\x46\x3b\x90\xc3\x49\x29\x14\xf4\x93\x21\x6c\x0b\x77\x98\x2f\x62\x73\x96\x82\x08\x6f\xb6\x9a\xde\x64\x78\xc9\x6c\x68\x9c\x3a\x9c\x54\x70\xa7\xd2\x2e\x5d\x01\x6f\x6f\x6e\xff\xf7\xc3\xe5\x7f\xbc\xfb\xb0\x1a\x17\x8e\x0e\xd0\x49\xc2\x92\xf0\xb7\xf3\xa9\x52\xa2\x1f\x15\x9a\x2f\xc8\xc7\xf5\x64\x68\x47\x65\xe5\x43\xd8\x7b\x11\xa9\x9b\x23\x25\x88\x31\xca\x6f\x2a\x32\x54\x5f\xb8\xfc\xf0\x61\x90\x40\x21\x96\xe5\xa2\x33\x97\xe9\xd6\xd8\x9e\x2f\x6f\x81\x4a\xb4\xdc\x0a\xb3\xa6\x69\xf4\x8c\xf6\x67\xd1\x3e\xa8\xae\xec\x5d\x6f\x0e\x9e\x94\xb6\x9d\x84\xb4\x83\x78\x7a\x83\xdf\x07\x94\x66\xbf\x52\xb1\xbd\x03\x35\x6c\x06\x92\x51\x76\xa5\x3d\x80\x94\xe6\x0a\x9a\x8b\xad\x78\x8c\x9e\x30\x7d\x7a\xf2\x95\x2b\x2d\x4d\x8c\xd6\x9e\xf1\x0b\xc9\x13\xd9\xcd\x06\xe8\xea\x5f\x11\x59\x1f\x86\xd1\xa4\x49\x2c\x26\x03\x6e\x71\x50\xc4\xc2\x6e\x21\x3a\x65\xe3\x13\x49\x5b\x3c\x86\x65\x02\x12\xc4\x53\x43\x27\xe1\x5d\xde\xbc\x8d\xfd\x06\x96\xd8\xb4\xbd\x77\x4e\x3d\x7d\x0a\xc8\x55\x1e\xe1\x1e\xcb\x7e\x67\x4b\x7d\x10\x80\x06\x58\xc3\x88\x20\x84\x4d\x93\xf6\x1e\xf7\x4b\x36\x03\x03\x40\x69\x9b\x04\xd9\x43\x27\x8b\x98\x6a\x04\x5d\x6a\xed\x08\x5a\xc1\x5b\x6f\xee\x28\x9d\x80\x8d\x28\xe8\x48\xb9\xaf\x43\xa1\x57\x3a\x53\x29\x6e\x44\xa6\x4a\x86\xe1\x04\xd7\xc2\xdc\x63\x38\xa7\xcd\x1a\xa5\xb4\x6d\xf6\xf0\x5a\xba\xa9\x69\xd0\xf3\xb8\xb1\x0f\x7e\xff\xcb\x2f\xf0\xe2\x9b\x0a\x9b\x6c\xa8\x7c\x07\xef\x94\x93\x6e\xff\x32\x69\x5b\xec\xa9\x8c\x31\x7a\xad\x35\x9d\x7e\xd1\x73\x47\x23\xb5\x4f\xe1\xf0\x11\xf1\xf8\x0c\xbf\xb4\x31\x62\x82\x46\x4c\xc3\x6d\x78\x46\xe0\x00\x2b\x3f\x21\x70\x2c\xf6\xcf\x5c\xd8\x3b\xd9\xa6\x3d\xa1\x51\xc3\xa3\x54\x87\x6b\xb9\x69\x6d\xad\x1a\x5c\xcb\xcf\x07\x22\x93\x70\xae\xe5\x24\xf2\x1f\x4c\xb5\x3c\x07\xc6\xb5\xfc\x21\x22\xc7\xd8\xa1\x8b\xf3\xb2\x65\x4d\x7b\x7e\x24\xae\xce\x26\x6e\x16\x5b\x42\x2d\xf3\xe7\x08\xed\x63\x34\x3d\x60\xe4\x0f\x48\x4c\x3b\xa0\x43\x7f\x8b\xcd\x1b\x79\x9f\xf6\xf8\x4a\xd8\xa5\x18\x37\x22\x25\x47\x30\xeb\x4d\x14\x27\x75\xf1\x06\x3a\x75\x1d\x88\x87\x9d\xbb\x8f\xad\x26\x3b\xc5\x5e\xba\x72\xb2\xa4\x53\x09\x33\x68\x75\xae\x16\xe1\x01\x7e\x07\x8f\x91\x75\x0d\x61\xdc\xd4\xe2\xb7\x22\x37\xe9\x30\x75\x32\x52\x8a\x42\x1b\xa9\x43\x8d\x21\x5e\x6a\x8e\xc1\xeb\x80\xe4\x78\x98\xbb\x89\x21\x81\x0c\x65\xe8\xa6\x79\xf8\xf4\x8e\x61\xec\x12\xf2\x91\x95\x65\xeb\x1c\x35\x9f\x62\x13\x0d\x84\x3f\x28\x28\xab\x0b\x61\x7a\x30\xef\x80\x6c\xad\xe4\x4e\x4d\xe9\x89\x4d\xec\x97\x0e\xf6\x48\x9f\xdb\x54\x4e\xe8\x51\x4e\x8e\x78\x87\x7a\x91\x07\xea\x71\x3b\xbd\xff\x78\x40\xcf\x23\x98\x30\xad\xe7\x38\x88\x6b\x8f\xb9\x3c\xd4\x62\x32\x94\x21\x2b\x0a\x99\x3a\x45\xb3\x32\x1c\xca\xc9\xb9\x40\xe8\xf2\xa5\xea\x4b\x40\xfb\x08\x2c\x84\xa3\x1b\x9b\xd2\x7a\xc8\xaf\x5b\x62\xc2\x82\x09\x74\x66\x96\xef\x87\xd1\x11\x4f\x29\x4b\xd6\x9b\xb1\xb3\x5d\x5b\x67\xd6\xc5\x23\x0c\x69\xef\x98\xd7\x59\xad\xe0\xf3\xb7\xaf\x8d\x46\x1e\x89\x69\x07\xee\x7a\x3f\x40\xd6\x9f\x76\x11\x13\x85\xa8\xd7\x36\xc7\x33\xb5\x47\x6e\x3a\xba\x14\xac\x2a\xc7\xea\xcb\x70\x58\xf7\xc3\x6b\xae\xc3\xbf\x9e\x25\x53\x90\xb7\xca\xa5\x61\x53\x6e\xb8\xd2\x34\x39\x45\x46\xdb\xea\x30\xbf\x39\x3e\xbe\x7b\x3e\x3f\x38\xb7\x9b\xff\xcc\xb4\xf2\x35\x59\x7b\x01\x7f\xfd\x1b\x1d\xe0\x4d\x31\x6c\x1e\xbc\x86\xbd\x80\xbf\xfe\x6d\xf6\x7f\x03\x00\x96\xa3\x21\x0c\xad\x5c\x00\x00"),
 		},
 		"/control-plane/crds/kuma.io_healthchecks.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_healthchecks.yaml",
-			modTime:          time.Date(2019, 12, 16, 8, 31, 53, 655744194, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23681,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x7c\x59\x73\xdb\x48\x92\xff\xbb\x3f\x45\x86\xe6\x41\x76\x04\x49\xdb\xdd\x33\xff\xf8\x8f\xde\xb4\xb2\x7b\x46\xdb\xbe\xc2\x52\xcf\xc6\xc6\x7a\x63\xa3\x08\x24\x89\x1a\x81\x55\xe8\xaa\x82\x24\xf6\xa7\xdf\xc8\xcc\x2a\x1c\xc4\x41\xca\xad\xe9\x58\xbc\x89\x02\x12\x55\x79\xfe\xf2\x28\xbc\x58\x2e\x97\x2f\x54\xa5\xff\x81\xce\x6b\x6b\x2e\x40\x55\x1a\x1f\x03\x1a\xfa\xcb\xaf\xee\xfe\xbf\x5f\x69\xfb\xfa\xfe\xed\x1a\x83\x7a\xfb\xe2\x4e\x9b\xfc\x02\xae\x6a\x1f\xec\xee\x2b\x7a\x5b\xbb\x0c\xdf\xe1\x46\x1b\x1d\xb4\x35\x2f\x76\x18\x54\xae\x82\xba\x78\x01\x90\x39\x54\xf4\xe3\xad\xde\xa1\x0f\x6a\x57\x5d\x80\xa9\xcb\xf2\x05\x80\x51\x3b\xbc\x80\x02\x55\x19\x8a\xac\xc0\xec\xce\xaf\xee\xea\x9d\x5a\x69\xfb\xc2\x57\x98\xd1\xc3\x5b\x67\xeb\xea\x02\xd2\xcf\xf2\x8c\xa7\xff\x00\xc8\x1a\xfe\xce\x8f\x5f\xd1\xe3\xfc\x6b\x55\xd6\x4e\x95\x7d\xb2\x2f\x00\x7c\x66\x2b\xbc\x80\xb3\xb3\x17\x00\xf7\xaa\xd4\x39\xaf\x49\x08\xd9\x0a\xcd\xe5\x97\xeb\x7f\xfc\x78\x93\x15\xb8\x53\xf2\x23\x40\x8e\x3e\x73\xba\xe2\xfb\xba\xaf\x01\xed\x21\x14\x08\x72\x37\x6c\xac\xe3\x3f\xbb\x2f\x84\xcb\x2f\xd7\x91\x4a\xe5\x6c\x85\x2e\xe8\xb4\x6a\xba\x3a\x6c\x6e\x7e\x3b\x78\xdf\x39\x2d\x48\xee\x81\x9c\x18\x8b\xf2\xd2\x7b\xf9\x0d\x73\xf0\xf2\x7a\xbb\x81\x50\x68\x0f\x0e\x2b\x87\x1e\x4d\xe0\x8d\x75\xc8\x02\xdd\xa2\x0c\xd8\xf5\x3f\x31\x0b\x2b\xb8\x41\x47\x44\xc0\x17\xb6\x2e\x73\xc8\xac\xb9\x47\x17\xc0\x61\x66\xb7\x46\xff\xd6\x50\xf6\x10\x2c\xbf\xb2\x54\x01\x7d\xe8\x51\xd4\x26\xa0\x33\xaa\x24\x56\xd6\xb8\x00\x65\x72\xd8\xa9\x3d\x38\xa4\x77\x40\x6d\x3a\xd4\xf8\x16\xbf\x82\x8f\xd6\x21\x68\xb3\xb1\x17\x50\x84\x50\xf9\x8b\xd7\xaf\xb7\x3a\x24\xc5\xca\xec\x6e\x57\x1b\x1d\xf6\xaf\x33\x6b\x82\xd3\xeb\x3a\x58\xe7\x5f\xe7\x78\x8f\xe5\x6b\x55\xe9\x25\xaf\xd3\x04\x56\xc6\x5d\xfe\x27\x17\x95\xce\x9f\x77\x16\x16\xf6\x24\x63\x1f\x9c\x36\xdb\xe6\x67\x56\x93\x49\x36\xff\xac\x4d\x4e\x02\x55\xf1\x31\x59\x6e\xcb\x4d\xfa\x89\x98\xf0\xf5\xfd\xcd\x2d\xa4\x97\x32\xc7\xfb\x2c\x66\xe6\xb6\x8f\xf9\x96\xcf\xc4\x17\x6d\x36\xe8\x44\x4e\x1b\x67\x77\x4c\x11\x4d\x5e\x59\x6d\x02\xff\x91\x95\x1a\x4d\x9f\xc7\xbe\x5e\xef\x74\x20\xc1\xfe\x5a\xa3\x0f\x24\x8e\x15\x5c\x29\x63\x6c\x80\x35\x42\x5d\xe5\x2a\x60\xbe\x82\x6b\x03\x57\x6a\x87\xe5\x95\xf2\xf8\xdc\x5c\x26\x86\xfa\x25\x71\xf0\x38\x9f\xbb\x36\x9f\xae\x31\xe5\x67\x03\xa0\x5d\xb0\xa2\x1e\xfc\x03\x40\xe5\x39\xfb\x10\x55\x7e\x99\x78\x78\x72\x05\xa3\x66\xd4\xbe\x89\xc5\x6c\xa0\x36\x3e\xb8\x3a\x0b\xb5\xc3\x1c\xee\x70\x1f\x25\xbe\x53\x15\xf8\x60\xe9\xc7\x07\x1d\x8a\xc1\x1b\x55\x57\xfa\x2a\xb0\x58\xd7\x08\x1e\x03\xac\xf7\x40\x9e\x92\x0d\x22\x58\x5b\xb2\xe5\x30\x2d\x36\x0c\x87\xc1\x69\xbc\xc7\x21\x49\xb7\xd6\xc1\x29\xb7\x6f\x78\xb7\x82\xdb\x02\xf7\xa0\x1c\x02\x89\xf9\xd7\x1a\xdd\x5e\xad\x4b\xa1\x13\x0d\x76\x8d\xc0\x4a\xe6\xee\x31\x1f\x90\x7c\x28\xd0\xc0\xce\xe6\x7a\xb3\x27\xcd\x15\xb5\x1c\x1a\xdf\xc5\xeb\xd7\x77\xf5\x1a\x9d\xc1\x80\xac\x18\xb9\xcd\xfc\xeb\xda\xa3\x5b\x6e\x6b\x9d\xe3\xeb\x8e\x80\xce\x5f\x8c\xb1\x5e\x28\xf7\xfe\x95\x95\xb5\x0f\xe8\x3e\x91\x57\x9f\x93\xc9\x6d\x81\xec\xc6\xc5\x75\x61\x7a\x0e\x1e\x0a\x9d\x15\xfc\x4b\xb4\xa6\x35\x96\xd6\x6c\x45\xf1\x6f\x0f\x2d\x8e\x2e\xed\xa1\xf6\x98\x13\xbb\x73\xed\xc9\x56\x6b\xed\x8b\x46\x50\x9e\x25\x09\x9e\xde\xc5\x2f\x24\x2e\x72\x00\xa9\x54\x46\xec\x80\x5c\x6f\x36\xe8\x0e\x2d\xaf\xb3\x19\x2f\x6f\x86\x8d\xc6\x92\xfd\x04\x89\x85\x64\xae\xcc\xfe\xa1\x40\x87\xe0\xf4\xb6\x08\x60\xec\x03\x53\x57\x95\x66\xc9\x38\x18\x59\xee\xd6\xb2\x37\xb1\xa0\xb7\x86\xe5\x11\x40\x6f\x98\x9a\x36\x12\x26\x11\xac\x8b\x96\x9d\xec\x7e\x35\xca\xfe\x11\xcd\x1f\xc6\xd9\x39\x21\x9c\x5d\x1d\xde\x2e\x5e\x30\x34\x7f\x0e\x5c\xa0\x6c\x6c\x68\x8a\x7a\x87\xa2\x77\xec\xdf\xa2\xec\x1e\x94\x8f\x5b\x22\x17\x15\x12\xeb\xb6\xb5\x72\xca\x04\x14\xa1\x89\xfd\x0c\xc5\x6a\xa0\x50\x55\x85\xc6\x2f\xd7\xb8\x21\x4e\x59\x97\xa3\x03\x95\x39\xeb\x3d\x78\xac\x94\x63\x5e\x55\xe8\x44\x47\x57\x70\xc5\x0e\x54\xbc\xad\xb1\x43\x9a\xc4\x65\x5e\x1f\x5b\x7b\x5a\x52\xb3\x47\xcc\xe9\xad\x5f\x7f\xba\xfa\xf1\xc7\x1f\xff\x4a\x21\x7d\xc7\xe2\xd4\x9e\x7e\xfe\xe5\xf6\x6a\x05\xdf\xcc\x80\xe6\x17\x5b\xd5\x14\x1c\x73\xf2\x00\xcc\xa1\xbd\x0f\xb8\x5b\xc1\x57\x54\xf9\xd2\x9a\x72\xbf\x82\x4f\x75\x59\x32\x44\x28\xb5\x1f\x31\xc4\xdf\xe9\x9f\x93\xdf\x38\x3b\x58\x1b\x6d\x40\x85\x0b\x20\x45\x5a\x92\x80\x4e\x55\xa2\x1c\x4b\x24\xea\x7f\x73\x2a\xc3\x2f\xe8\xb4\xcd\x6f\x30\xb3\x26\x1f\xf8\xe0\x9e\x36\x7d\xaa\x77\x6b\x74\x64\xd0\x5e\xee\x06\x55\x96\xf6\x01\xf3\x88\x8e\x5a\xbd\x08\x16\xb6\x44\x7b\x53\x97\xe5\x7e\xa8\x4b\xe8\x76\xda\x90\x6c\xa3\xe0\x75\x80\x07\x5d\x96\xa4\x29\x0e\x77\xf6\x9e\x28\xa6\x00\x9a\xb8\xfd\xd9\x94\x7b\x96\x2f\x29\xe1\x80\x64\xda\x51\x5f\xcf\x4b\x6f\xe9\x91\x15\x7c\x54\x7b\x20\x49\xb1\x2e\x16\xd6\x05\x34\xa4\xb1\xad\x04\x27\x38\xab\x4d\xf8\x7f\x7f\x1e\xe5\x2a\x61\xa3\xed\x81\x9d\x0c\x16\x31\x6f\x9b\xef\xc6\xd6\xfc\xf5\xa7\x2b\x60\xed\x64\xef\x40\xda\xc9\x96\xa7\x42\xe3\x38\x47\x5c\x4e\x13\xb3\x12\x17\x79\x25\xb4\xc3\xbe\x5b\x8b\x61\xac\x35\x73\xb1\x68\xd5\x08\x6b\x92\xaf\x62\x46\xec\xaa\x5a\x43\xa0\x48\xb2\x48\x16\x44\x76\x9f\x6b\x87\x59\x10\x39\x05\x8e\x68\xeb\xa1\xf4\x55\x84\x41\x1c\x05\xdb\xa5\x6b\x0f\xf8\x58\x61\x16\x1a\xa7\x11\x37\x01\x2f\x8d\x05\x0a\x11\xe8\xe0\x5e\x7b\xbd\x2e\x87\x31\x96\xb5\xa5\x21\xc5\x46\x28\x0b\xa3\x55\x39\x54\x59\x11\x57\xc3\x81\xe1\x15\xa8\x4d\x40\xc1\xf3\xcc\x5d\x3d\x54\xa8\xd0\x30\x6e\x01\xd6\x30\x1c\x40\xd8\x68\xa3\x4a\xfd\x1b\xe1\x3d\x7a\x07\xaf\x79\x57\x85\xfd\x0a\x2e\x3d\x2f\x11\x94\x3f\xb8\x71\x40\x98\x1f\x24\xbb\x57\x9a\xc0\x4a\xc0\x9d\x5f\xf4\xd8\xbc\x2e\x6d\x76\x47\xb2\xfb\x9c\x5e\x3b\xd0\xab\xb1\x10\xe9\x31\x2c\x3a\xbe\x2f\xb9\x48\x06\x91\x86\x04\x6f\x5d\x42\x32\x9b\xda\x85\x82\x82\x97\x89\xd8\x7f\x53\x13\x4e\x5a\x0c\x45\x55\x86\xc2\xd6\xdb\x82\x0c\x34\x21\xa1\x64\x3d\x10\x13\xa2\x86\xeb\xf1\x86\x24\xb5\xca\x69\x3b\x12\x46\xac\xac\x91\xd8\xbe\x82\x9f\xac\x03\x7c\x54\xbb\xaa\xa4\xec\x82\xf5\x29\x26\x18\xac\x69\x02\xc1\x14\x54\x96\x35\x2c\x52\x1e\x0b\x24\x3f\xbe\x49\x2e\x49\xb4\xea\xe7\x7a\x4d\x37\x8b\x3d\x90\xfc\x59\xef\x3d\x9a\x9c\xc2\x5c\xab\xef\x8d\x2b\x3a\x4c\xa6\xe8\xf2\x7a\x2b\x58\x4f\xf0\x8b\x88\x8c\x64\xaf\x0d\xff\x52\xd9\x7c\x05\x97\x51\x93\x54\xe8\x2c\x62\xc1\xff\x8f\x8b\x18\xa2\x37\x5a\x14\xad\x05\x14\x14\xca\xe5\xdd\x45\xa4\x97\xbe\xbc\xb9\xfe\xdb\xcf\xd7\x1f\x3e\xbc\x1a\xbc\x9e\xd4\x7a\x28\x28\x5e\x45\x56\xa2\x32\x75\xb5\x88\x4e\x34\x2d\xb2\xf5\xa5\x97\x5f\xae\x39\x93\xe0\x7f\x70\x48\xcc\x18\x9f\x19\x0c\x0f\xd6\xdd\x0d\xc8\x56\xca\x05\x86\xe9\x7e\xd1\x73\xef\x24\x23\x1f\x68\x1b\xf8\x48\xea\x9c\xcc\x29\x0a\x96\x75\x74\x01\xb5\x09\x7a\xe8\x51\x94\x01\x95\xef\xb4\xd1\x3e\x38\x15\xac\x23\x3d\x52\x75\xb0\x3b\x25\x5a\x63\x33\xf4\x1e\x32\x45\x09\xb1\x30\x06\xfb\x7a\x36\xe2\xff\x38\xcc\xb4\x61\x85\xb0\xc8\x26\x61\xb8\x45\x2b\xec\xc6\xca\x22\x24\x8d\xbb\x29\xd4\x90\xa2\x58\x0e\x9a\xd6\xe9\x11\x36\x98\xc2\x02\x87\x6e\xb4\x79\xd3\x98\xa1\x76\x28\x76\x10\xc4\xff\x71\xc4\xd0\x3a\xb4\xd9\x98\xf6\xb1\xf6\xec\x71\xd8\x2b\xa6\xe8\xde\x61\x75\x6b\xc5\xad\x52\x3a\xdc\x92\x2e\x0c\x62\x30\xc0\x7b\x95\x15\x80\x26\xb8\x7d\x4c\xea\x74\x4e\x7b\xdc\x68\x74\x4d\x3d\xc6\xa1\xaf\xac\xe1\xa8\x00\x99\xdd\x55\xd6\xa0\x89\x8e\x83\xec\x6c\x24\x54\x36\xa6\x21\x94\x9b\x75\x90\x63\x66\xc5\x19\x75\xb9\x7d\x9d\x19\x93\xab\xb1\x66\x69\x74\xb9\x60\xba\x1a\xa3\x9b\xd0\x31\x54\x90\x42\x27\x04\x12\x31\xce\xe1\x86\x39\x16\x3c\x29\x09\x96\x7f\x29\xe7\x54\x3f\xcc\x6e\xd1\x10\x66\xc6\xa3\x49\xda\xd9\xdf\x3a\x77\x46\x26\xdb\x4a\x12\x73\xf2\x10\x1b\xfd\xb8\x90\xe4\xab\x07\x1b\x86\x91\x82\x00\x5f\x24\x45\x8e\xdc\xe8\x5f\xeb\x98\x8d\x7d\xfe\xf4\xe1\x3f\xe1\xfa\x27\x7e\x9a\xdf\x22\x68\xa4\x50\xbe\x35\xb2\xca\xd9\x7b\x9d\x0f\x39\x02\x22\x8e\x2e\x84\xa1\xc5\x88\x7b\x65\xea\x0e\x43\xed\x8c\x40\x86\xb6\xc2\xd2\xe2\xa0\xc9\xcc\x2f\x14\xca\xb4\x64\x2a\xe5\x7d\x03\x97\x24\x7e\x32\x09\x46\x90\x6b\xd6\xac\xb5\x36\xb1\x68\xd0\x6c\x70\x18\x31\xea\xcd\x46\x3f\x4a\x08\x4a\x7b\x8a\xe4\x8a\x88\x0c\x38\x4d\x6d\x4b\x93\xe0\xea\x12\x7d\x82\x0d\xc4\x9f\xa1\x73\x13\x10\x92\x8a\x6f\x6b\x84\xe0\x6a\x93\x75\xbd\x50\x89\x66\x1b\x8a\xa4\xa2\xb2\x0a\xf6\x33\xda\x31\x6b\x06\x34\x77\xea\x4e\x6c\x40\x16\x17\xe5\x65\x4d\x47\xc6\xec\xef\x06\xec\xf7\x15\x66\x64\x80\x23\x21\x88\xa0\x6a\x81\x8d\x1a\x48\x0e\x2e\x01\x22\x06\xc4\x84\x39\x89\xb3\x9f\x3e\xdf\x46\xe1\x81\x82\x3f\xbf\xf9\x2b\x2c\x47\xe2\xba\x0f\xa8\xf2\x45\x93\x1e\xa0\x66\xd8\x12\x1f\xfb\xe1\xcd\x5b\xb8\x92\xdc\x93\x62\xc8\x5f\xde\xbc\x11\xe9\x7c\x45\xe5\xad\x89\x85\x39\xb2\x5f\x5b\x8f\x25\x9f\xb9\xce\x54\x10\x34\xd0\x55\xd7\x8c\xab\x2f\x11\x38\x6d\x6c\x6d\xf2\x14\xee\x05\x87\x97\xa5\x0d\x01\xf3\x11\xac\x14\xf7\x1f\x35\x30\x96\x71\x1c\x92\x8f\x79\x99\x6c\xaa\xdc\x0f\xa1\x27\x2f\x84\x33\xd3\x11\x25\x45\xf8\x4a\x14\x96\x02\x33\x0a\x54\x39\xba\x57\x2c\x9a\xcb\xaa\x2a\x35\x6d\x9d\x9c\x8a\xde\x40\xb2\x60\x0e\x7b\x49\x4a\x43\x83\x7a\xde\x38\xa3\x73\xdc\x55\x36\xa0\xc9\xf6\x87\xa1\x66\xd2\x6d\x45\x05\x39\x28\x8b\xc3\xa1\x6b\xba\x04\x4f\x81\x92\x10\x8a\x91\xbc\xb3\x57\xaa\x50\x69\x93\x59\x87\x20\xd8\xcd\x28\x0f\x73\xf4\x6c\x09\x3e\xa8\x80\xab\x53\x32\xfa\x67\xc9\x07\xb9\x4f\x72\x4a\xd8\x3c\xbb\x34\xdd\x9b\xa5\x46\xc3\x12\xb0\x65\xd9\xd4\xcc\xd0\x6c\x2c\xd7\xbb\xbc\xdd\xa5\x35\x8f\x28\xf6\xbd\x72\x5a\x99\x40\x29\x63\x8c\xba\xa9\x66\x14\x51\x77\x3f\x27\x54\x12\x9f\xec\xa6\xb7\xdc\x31\x7f\x49\x48\xe9\x5e\x4a\x96\x7b\x0c\xa0\x38\x55\xb3\xbd\x82\x90\x00\x2f\x5d\x92\x41\x32\x06\xe8\xe1\xc6\x01\x51\x72\x8a\x1c\x00\x28\x72\x13\x2c\x20\x55\x6e\x56\x41\x29\x10\x19\xfc\x83\xf6\xb8\x38\x40\x11\x19\xc5\xfc\x1c\xdd\x88\x23\xaa\x4d\x87\x44\xca\x4e\x0b\x9d\xe7\x68\xe0\xa5\x36\xbc\xdd\xd7\x0f\x2a\x64\x05\xff\x73\x8b\x14\x9c\xcb\xd2\xbf\x12\x28\x20\xf6\x3b\xc3\x00\x73\x1e\x28\x53\x2d\x75\xa6\x29\xd5\x55\xfe\x4e\xc2\x8f\x5d\xb3\x7f\x3b\x78\x7f\x53\x9b\x1d\xa9\x2c\xfd\x07\xa3\x46\xd3\xdd\x96\xf8\xb3\x45\x0f\x5b\x92\xeb\xab\xa2\xca\x76\x10\xc5\x68\xfd\x9a\x3d\x50\xed\x1c\xbb\x20\x1c\x88\x35\x96\x51\x2a\xa7\xef\x75\x89\x5b\xcc\x39\xe7\x92\x7a\x9a\xe4\x88\xc3\x50\xc1\x65\xe6\xf6\xbd\x31\x2f\xd5\x6d\xf6\xbb\x48\xe9\x61\xf4\x9a\xfc\x04\xb9\xa6\x98\x67\x0e\x48\xae\xf7\xa0\xcc\x9e\x5f\xcd\xae\xec\xdd\xfb\x2f\x5f\xdf\x5f\x5d\xde\xbe\x7f\x07\xcb\xde\x72\xb9\x44\x4e\x09\x43\x59\x15\x2a\xaa\x2c\xc9\x6c\x14\xd9\x75\x8a\x47\xda\xc0\xfd\xdb\xd5\xdb\xbf\xac\x0e\x9d\xd2\x54\xa7\x82\xff\x27\xd9\xe1\xf0\x1f\x07\xc6\xfa\x25\x66\x91\x93\xb6\x13\x3b\x07\x04\x85\xf1\x11\xb3\x3a\x0c\x63\x3a\x48\xda\x2a\x05\xcf\x06\x26\xb7\x09\x16\xa1\x10\x29\x75\xac\x44\x4b\xa4\x43\xe7\x43\x5a\xe5\x04\xc5\x9e\x0b\x89\xdc\x48\x85\x10\xd8\x28\x5d\xd2\xc2\x1d\xfa\xba\x0c\x9d\x9a\x01\xce\x9b\x3e\x5d\xd2\x4c\x69\x70\x15\xd7\x59\x2d\x5b\x7a\x8a\x7b\x63\xb6\x49\xb8\xa6\x63\x0c\xa3\x94\xe9\xf9\xb8\x57\x22\xa9\xca\x32\x99\xe0\x30\x78\x4d\x62\xe4\x63\xb2\x95\xcb\x8c\xc0\xe1\xf6\xea\x09\xb9\xdb\xb9\x48\x39\x29\x8b\x95\xf9\xda\xa6\x1c\x94\x86\x34\x3b\x9c\x92\x8b\x5c\x5d\x37\x39\x79\xdb\x0c\xd8\x97\x2b\xa1\xba\xf1\x7d\x2c\x79\xe1\xa3\xff\x9a\x6c\xe8\x74\xff\x3d\x4c\x25\xe4\x9d\xa4\x30\x47\x0d\xe3\x7a\xd3\x57\x2d\x81\x63\xc4\xc1\x9f\x94\x2e\x6b\x87\x09\xca\xce\xe4\x51\x90\xea\x23\x6b\x84\x0a\x9d\xd7\x3e\xd6\x03\x7d\xb0\x4e\x6d\x31\xa9\x9b\x49\x79\x24\xa5\x5b\xbe\x76\xd2\xbd\xa0\x90\x37\xea\x71\x80\x7b\x3d\xd2\x3b\xe0\x4c\x2c\xfa\xea\x6e\xaa\x37\x26\x94\x63\x3a\x35\xde\xe2\x9f\xe4\xd0\x53\xdb\xfd\x93\x6a\xd2\x1f\x03\x78\x6a\xeb\x7f\x92\xec\xe8\x48\xc0\x53\xc6\x00\x26\x29\xff\x81\xe3\x01\xdd\xeb\xa8\x39\x65\x36\x9f\x74\x09\x3d\xd1\xdd\xd4\xdb\xad\x14\xbf\xff\x7e\x7b\xfb\x25\xe5\x20\xf4\x78\xdb\xfc\x20\x78\x59\xfb\x05\xbc\x01\x3d\xc4\xa1\xe9\x8a\x65\xa9\x29\x17\xd0\x41\x9a\x3f\xfe\x30\xbb\xab\x31\xc4\xd9\x2e\x3d\x28\x5d\x4e\x3a\xc2\xde\xce\xde\x3f\x06\x34\x94\xa8\xe6\x2a\x28\x50\xde\xdb\x4c\x33\x38\x6e\xcc\xd7\x71\x46\xb5\x92\x82\xcc\x8c\x4e\x72\xde\x45\x9a\x21\xba\x0d\x3a\x78\xb0\x0f\x86\xdb\xe6\xf2\x06\x59\xd6\x01\x04\x9d\xa4\xd8\x54\x22\x52\x8c\xe1\x15\x36\x29\xff\x68\xb3\x31\xb3\x84\x92\x87\xb8\xb8\xe1\x9d\x65\xec\x11\xed\x0c\x1f\x33\xac\x62\xb9\x48\x16\xdd\xe4\x04\x71\x3b\xc4\xeb\x29\x59\x1d\x8f\x38\x00\x99\xaa\xfd\xdc\xff\x47\xba\xe6\x57\xfc\x88\xf8\x62\xd0\x26\x2b\xeb\x1c\x3d\xec\xc8\x72\x22\x03\x3b\x52\x9a\x21\x0c\xad\x04\x6f\x58\x33\x63\x66\xbc\x11\x6f\xbc\x82\x4f\x36\x70\xbc\xed\xfe\x97\xb1\xe0\x2c\xd1\x58\xd8\x88\x6b\xc1\x3c\x6e\x71\x3a\xa6\xcd\x46\xed\x0e\xd5\xa3\xbc\x94\x8b\xd5\xe6\xd8\x4d\x87\x09\xd6\x6d\x91\x0a\x4f\x31\xa8\xf7\xc7\x3c\x28\x11\xe1\x6d\xcc\xf3\x53\x2e\xb6\x75\x74\xce\xba\x05\x01\x1c\x8a\xb8\xac\x35\xa4\xee\xff\x7e\xf3\xf9\x13\x78\x74\x8c\x07\xd4\x54\x58\x39\xbc\x3e\xb6\x82\x86\x9c\x84\x62\x72\xa8\xac\x0f\x1b\xfd\x08\x69\x42\x83\xdd\x8c\x61\x17\x74\x02\x45\x15\xc4\x7d\x92\xcf\xbd\x24\x45\x12\x2c\xfd\x1b\x3a\xbb\xd4\x26\xc7\x47\xca\xae\xe0\x27\xe2\xc8\x71\x89\x47\x92\x55\x85\xca\x89\x1e\x72\xf5\x8c\xdb\x62\x9a\x33\x18\xd1\x55\xbb\x89\xba\x00\xf9\x48\x71\x6c\x84\x91\x56\x64\xe2\x29\xaf\xa2\x08\xbe\xab\xcb\xa0\xab\x12\x85\xbb\x94\xad\x44\x0f\xc0\x69\xc2\x7b\xe9\x14\x1d\x55\x10\xba\xbe\x01\x7c\x3b\x23\xc9\x7c\x3b\x83\x65\x6c\xc9\x91\xf4\x9b\x1f\x63\xad\x2b\xe6\x4a\x27\x50\x6c\x14\x86\x28\xb3\x42\xff\xd7\x9b\xff\x5e\xcd\xbc\xe2\x04\x9a\x71\x11\x1b\xed\x7c\x88\x3c\x8c\xe5\x6e\x93\x5e\xf2\xed\xec\x38\xa1\xa3\x51\xae\xbd\x76\xe8\xbd\xda\xce\xa0\xe0\x74\x1d\xd4\x62\x8a\x7a\xa7\xcc\xd2\xa1\xca\xb9\x91\xda\xf9\x6f\x33\xdf\x43\x92\x3f\x65\xcf\x72\x3b\x4b\x78\x05\xdd\x48\x10\xab\x9b\xed\xac\x86\xf2\xcb\x99\xe8\xd0\xd9\xbf\xe5\xb9\x2d\x95\xa3\x3b\x6e\x6d\x4f\x60\x96\x84\x80\x27\xf3\x6a\xa7\xb2\x42\x1b\x9c\xe3\xd6\x09\x9b\x62\x7e\x1e\x70\x2b\x95\x63\xa5\x6a\x9b\xf2\x6f\xba\xc3\x9d\x42\x92\x03\x26\xa3\x2f\xc2\x18\xb4\x1a\x75\xaf\x74\x49\x6b\x7c\x46\xbe\x1d\x49\x34\xfa\xb7\x8d\x27\x1c\xe9\x92\xb9\xe0\xa7\xc4\x4e\x7e\xa2\xf5\x7e\x03\x6f\xff\xd4\xc0\x29\x90\xae\x17\x21\xe7\x58\x75\x12\x93\x0e\x47\x55\x67\x37\x75\x4e\xbb\xa2\x27\xfe\xc5\x9b\x82\xcf\x46\xea\x8a\xed\xb8\x95\x40\x39\xee\xa0\xcc\xd2\xed\x74\xf2\xd2\x80\x48\xb3\xb4\x9f\xb5\xc9\xff\xa0\x71\xd5\xef\x92\xc5\x7c\x49\x60\x6a\xa4\xf1\x5f\x2a\x0a\x78\x19\xc7\xec\xd0\x61\x9c\x59\xd6\x66\x5b\xe2\x74\x6a\xdf\x50\xe5\x32\x31\xe5\xb7\xeb\xe4\x74\xd6\x98\xbf\xfa\xdd\x0a\xcb\x4d\x0c\xee\x40\x4c\x4c\x89\x4d\x72\xec\x7a\xd3\xf6\x22\x16\xdd\xa6\x47\x33\x41\xd6\xf6\x88\x67\xb7\xd6\x68\x65\x67\x3e\x56\x26\x6e\xf3\x15\xdc\x90\xde\x0a\x64\x88\x73\xd8\xd2\x53\x99\x77\x53\x6d\xaf\x86\x4b\x75\x41\xdd\xc5\x5a\x23\x67\xbb\x01\x41\x65\xfc\xc2\x65\x4c\xf0\xac\x4f\x2f\x39\x42\xb7\x17\xd0\xd2\x5a\xa0\xb0\x0f\x32\x22\x14\x2c\x3c\x28\x1d\x9a\x9d\xab\xbb\xa3\x1e\xb5\xc0\xc1\xb2\xe6\x84\x7a\x4a\x0e\x09\x27\xe5\x91\x74\xd5\xfa\x09\xde\xea\x97\xeb\x77\x87\x36\xb1\x9a\x52\xe8\xd9\x3d\xb7\x23\x6d\x13\x4a\xfd\xe4\x61\xe7\x76\x78\xc0\xff\xa9\xd6\xbf\xdb\x77\x1c\x0d\x73\x73\x6e\xfe\x19\x4e\x27\x4c\x67\xb8\x9d\x3a\xf2\xf7\x9c\x54\x98\x21\xdc\x76\x37\xbf\xe7\xd4\xc2\x24\xe1\x3f\x3c\x3c\x1c\x15\xef\x11\x98\xfc\x64\x70\x1c\xdd\xfc\xb1\xb2\x5e\xe3\xe5\xa6\x78\x75\xc2\xc2\x87\xc7\x33\x26\x57\x7e\x7e\x13\x94\xc9\x95\xcb\xa5\x8d\xd1\x1e\x4f\xf8\xc3\x05\x72\x52\x25\xc5\x92\x25\xd4\xa7\x87\xeb\xf4\x40\xf7\x10\x87\xde\x34\x93\xab\x32\xe0\x0f\xa5\xde\xe9\xf9\xfc\x2f\x66\x69\xa6\x99\x7e\xe6\xc4\xac\xa9\x43\xc5\x09\xd8\xe8\xe7\x63\x9b\xe0\x58\x3c\x8b\xa3\x10\x85\x4a\x85\x1d\xae\xbd\x35\x68\x9c\xa1\x46\x83\xf2\x6d\xa5\x7e\xad\x71\x74\xf0\xaf\x7b\xc5\x6d\xa6\xb3\x12\xda\x7b\x7e\xc8\xc6\xa1\x89\x38\x52\x69\x0f\x8f\x25\xa9\xf9\xdd\xcb\x11\x94\x4e\xdf\x31\xd8\xe6\xac\x8b\xf0\x05\x1f\x9b\x5e\x63\xb3\x83\x79\x86\xa6\x9e\xe8\x95\x48\x48\xfa\xf9\xdc\x36\xf2\x81\xdc\x8b\xa8\x63\xdb\x51\xac\xac\x1f\x9f\xfb\xed\x5e\x51\xb4\x91\xb3\x99\x35\x1b\xbd\xad\x23\x68\xe0\xfa\x4e\xa1\xcc\x56\x66\x45\xda\x1a\x86\x9a\x47\xb6\xf8\x00\x3b\x6d\x6a\x12\x2b\xf7\xbe\xdb\x39\xa1\x36\xbe\xa5\x82\xbe\xc4\xfc\xa4\x15\x47\x80\x1a\x1a\xa8\xbd\xf8\x75\xe9\x98\x89\xa6\x76\x46\x8f\xd6\x18\xc7\xdd\xb2\x66\x06\x75\x96\x66\xd4\x96\x6e\x45\x21\x36\xaa\x70\x01\xb5\x29\xd1\x7b\xd8\xdb\x5a\xf6\xe1\x30\x43\x3d\x76\xb2\xa8\x7b\xc9\x3c\xa7\xbd\x43\x23\x41\x42\x19\xc1\x3f\xc9\x3b\x3e\x03\xae\xec\x71\xf0\x74\x94\x71\x13\xda\x86\x4f\x13\xd6\x7d\x47\xfc\xe7\xe7\xbe\x69\x5b\xcc\x73\x2d\x0a\x2f\x9d\xaf\x4c\xe7\x17\x88\x72\xc4\x1c\x69\xfc\x2d\xf5\x8f\x46\xc6\xa9\xfa\x2b\x4d\x53\xab\x2c\xe5\xa8\xeb\xc2\xf6\xa8\x82\x2b\xf8\x87\x8c\x68\xc7\x69\xc9\x20\x5d\xff\x59\xb2\xaa\x71\x03\x9d\xa5\x70\x9d\x90\x55\x12\x6a\xd3\xb4\xdd\xd7\x2a\xbb\x3b\x45\x63\xd2\x9c\xd7\x29\x07\x5c\xda\x88\x30\x4b\xf2\x19\xa2\x45\x66\x8d\x14\xe5\xb2\xfd\x32\x8e\xc0\x2c\x95\xc9\x97\x8d\x7b\xc8\xf6\xbf\x3b\xeb\xf3\x58\x6e\x3e\x68\x73\x77\xb2\xc6\xa5\x07\x04\xa5\xfd\xf2\xf5\xc3\x21\x38\x3b\xa1\xb5\x0b\xa7\x9d\x25\xfa\x17\xa3\xd2\xf9\x9a\xd6\x13\x2b\x59\x0f\x45\x1c\x0c\x69\x80\xcb\xe4\xea\x75\x33\x36\x7f\x16\xbb\xc1\x67\x11\x15\xcd\x97\xb5\xe6\xfa\x43\x93\xc5\x2c\xb8\x4c\x53\x80\x59\xa9\x9c\x38\x07\x65\xa4\x73\x27\x2f\x9d\x41\x19\x39\xc2\xba\x0e\x90\x5b\x94\xfe\x92\xbd\x47\xe7\x74\x8e\xa0\x27\x85\x7b\x54\x30\xf2\xd2\x93\x41\x59\x83\x15\x3b\xe5\x98\x15\x7c\x36\x08\x76\x73\x01\x67\x37\x75\x96\xa1\xf7\x67\x63\xe3\x3a\xe9\x6a\xb8\xfc\xdc\x68\x8e\xf2\x79\x36\x48\xd9\xd3\x77\x42\xec\x19\x3d\x9d\x9a\x70\x58\x4e\xcc\xbe\x4c\x92\x2a\xd5\x1a\x87\x3d\xd0\x67\x3e\x79\xfc\x51\xf1\x68\x78\x4c\xdc\xee\x70\x2f\x5e\x59\xfa\xdd\xc3\x38\x12\x2c\x58\xb7\x55\x46\xff\x36\x72\x50\xd8\xe4\x40\x10\x72\x6b\x9d\xfe\x0d\xe1\x25\x7f\xcc\x40\xce\x04\x63\x89\x59\x78\xd5\x39\xe8\xab\xf6\xb0\xe3\x11\x36\xf9\x97\x75\x7e\x6c\xf6\xd1\x61\x55\xf2\x98\x2b\x59\x42\x33\x4e\xe8\x23\x4d\x77\xaf\xb3\x91\x9e\xfc\xd1\x44\x5a\xf8\x7a\xf2\x81\xe1\x9d\x32\x6a\x8b\xb9\xf4\x9a\xe6\xc7\x20\x3f\x76\x6f\x85\x9d\xaa\x3c\x3c\x58\x77\xb7\x29\xed\xc3\x52\xcb\xe8\x57\x0a\xd8\x11\xc7\x8e\x1d\x2c\xb5\x9b\xd4\x56\x92\xf3\x43\x0e\xd3\x1a\xc4\xeb\xaa\xd0\x50\x8d\x9d\x68\x4d\x28\xdc\x87\x72\x1f\xe7\x79\x26\x80\x43\x61\x6b\x8f\x77\x88\x95\x36\x5b\x41\xfd\x32\x3d\x17\xf6\x15\xa1\xb4\x72\x1f\x8b\x53\xe6\x3c\x80\x89\xfd\xe8\x78\xf2\xaa\x36\x39\x3a\x1f\xc6\x20\x7c\x5b\x30\x22\xbf\x95\x56\x96\xb4\x26\x65\x2b\xe7\xd2\x68\x5c\xf4\x06\x43\xd3\x8f\x43\x16\xb8\x76\xb6\x9d\x60\x79\x3b\x2c\xab\xaa\xaa\xdc\x43\xa5\x42\x01\xa5\xbe\x43\xf8\x76\x96\xe9\x65\x96\x7f\x3b\x13\x50\x1b\x71\xbc\xf0\x6f\x40\x96\xcf\x54\x3e\xa8\x7d\xe3\xcb\x1b\x69\xc4\x9c\xa7\x5d\x3e\x6b\xfb\xc1\x39\xf5\x31\x40\x92\x86\x56\xbe\x99\xc3\xb9\x54\x9e\xf9\x13\x9b\x60\x4e\x74\xf0\x7b\x9a\xf3\x7b\xd0\xa1\x18\x9b\xee\x36\x36\xe8\x0c\x07\xd3\x7f\x13\x6d\xe8\xf9\xe4\xf3\xd8\x88\x4f\x3f\x64\xce\xce\xf7\x74\xbe\xe2\xd1\x69\x3e\x4f\x39\xd0\x96\x1b\x9c\xa8\xf2\xb8\x77\x3a\x25\x8f\xb1\xc6\x47\x8c\x3a\xe3\x9e\xc7\xeb\xf8\x8e\x33\xf8\x67\xed\xa7\x68\xb2\xc4\xb9\x0a\x6b\xab\x65\x49\x1e\xbe\xbb\xe2\xa8\x83\xf1\x18\x37\x52\x88\x51\x6e\xcf\x96\xe6\x54\x36\x3c\x1d\x96\xd6\xd9\xdb\x9f\xea\xac\x79\x8d\xd2\xc4\xd2\xec\x03\x63\x2e\x17\xcf\x7a\x89\xc1\x4c\xd0\x8c\x23\x4b\x63\xf3\xeb\x70\x3c\xb6\x6c\x46\x3d\x8d\x5c\xa3\xde\x1f\x82\x9b\xe8\x57\xf7\x84\x1b\xdd\x52\x27\xe1\x50\x7d\x7b\x99\x5b\xed\x24\x24\x13\xd7\xe4\x4e\x50\x2e\xf1\x8e\x6e\x78\x16\x2a\x42\x85\xc6\xf6\x98\xe4\x0c\x46\x2c\xd0\xe3\x09\x4b\x9e\x64\x70\x83\x49\x4e\x58\xf4\xe7\xa6\x70\x1f\xbf\xa7\x43\xb4\x69\xc5\x6d\x45\x5f\x2a\xbc\x25\xaa\xd1\xa3\x2a\x69\xcd\xda\x43\x2f\x3c\xbc\xe7\x46\xf9\x1a\xc9\xb1\x34\x9f\x20\x20\xcb\xe0\x03\x11\x7c\xc2\x26\x46\xe1\x09\x92\xcd\xd8\x56\x9c\x2b\x76\x08\xe7\x97\xe4\x1d\xcf\xd9\xeb\x9c\xff\xc2\x45\xcc\xf3\xef\xe2\x50\xd0\x53\x6d\xa5\x7e\x43\x49\xcb\x99\x8d\xd0\x3d\x65\x96\x8a\xe5\x8d\x8c\xe0\x81\x70\xf0\xcc\xcc\xd8\x75\x73\xdc\x24\x7a\xe7\xe6\x04\x9e\xde\xf4\x05\x10\x37\x38\x4a\xe7\xd8\xd9\xc0\x13\x36\x3e\xa3\xea\x53\xed\xde\xb1\x06\x5c\x1f\x61\xf1\xc1\x96\x94\x2a\xc7\xa3\x3a\xe4\xf8\xb5\x01\xd5\x7e\xe7\x63\x05\xd7\xbe\x3d\xf2\x34\xfa\x8d\x00\x39\x06\x21\x03\xd0\x32\x36\xb8\x68\x4f\x38\x73\xef\xb3\xfd\xa4\xc8\x4e\xed\xe5\xe3\x06\xcd\x71\xf5\x31\xdd\x6c\xcf\x29\x63\xff\x14\x0a\x37\x92\x2a\x0a\x2c\x4e\xab\x90\xba\x86\x5d\xcf\xb7\x1a\x3f\xec\xa5\x3d\x54\x4e\xef\x94\xd3\x7c\x14\x22\xce\xcd\x91\xaa\x36\x87\x38\xda\x33\x37\x02\x0e\xfb\x95\xae\xbc\xf9\x3c\xd7\x50\x5b\x46\x0a\xf4\xbf\xa7\x89\xc2\xbc\x1f\x87\x81\x23\xfa\xd1\x48\x6a\x1e\x02\x7e\x6a\x3e\xdc\xd2\x0d\xa0\xf2\x4b\x94\x3a\xaa\xac\x10\x8e\xf6\xb5\x62\xb8\xe1\x4b\x13\xed\xa0\xf3\x39\x18\x0f\xa4\x24\xf7\xaa\x14\x99\x32\xf9\x6f\x67\x39\x6e\x54\x5d\x86\x6f\x67\xed\xad\x0b\x4a\x03\x07\x24\xbb\xb7\x46\x8f\x96\x29\x63\x0d\x97\xe9\xfa\x63\xb9\xed\x80\x5d\x2a\x02\x91\x8f\x49\x3a\x3a\x34\x1e\xf9\x52\x0a\x81\xfe\x5c\x46\x5a\xda\x55\x2f\x3b\x87\xf5\x6c\xef\x4c\x5e\xdb\x9b\x8c\x2f\x19\xd0\x4d\xd5\xc4\xf8\xa5\x82\x6f\xa6\x39\xa5\xab\xe0\xdd\xa7\x9b\xff\xf9\x70\xf9\x6f\xef\x3f\x8c\x76\x6f\x66\x8a\x3e\x27\x29\x4b\xb3\x7e\x7f\xf2\xe1\x30\xfb\x60\xd0\x7d\x45\x3e\xb4\x99\x0d\x01\x59\x4f\x57\x3e\xc4\xb3\x17\x89\xbb\x39\x56\x62\x2e\xeb\xfd\xe0\x4c\xd2\xe5\x87\x0f\x93\x0c\x8a\x58\x96\x8b\xce\x5c\xa6\xe3\x23\x49\xcd\x7c\x79\xef\x7b\x37\x91\x97\x5b\xe5\xd6\x6a\x8b\x90\x11\x0c\xcf\x46\x81\xca\xf5\xe6\xf0\x44\x47\x27\x09\xe9\x82\xf8\x85\xcc\xb3\x2b\xd3\xce\x7e\x35\xc5\xf6\x71\x61\xc6\xca\xbd\x6d\x8b\xc7\x89\x52\x33\x57\xd0\x39\x3c\xd6\xe2\x31\x46\x72\x63\x76\x72\xcb\x95\x96\x16\xa3\x75\x67\xfc\xb0\x81\x13\x1d\xa2\x27\x1e\x5d\x7e\x5e\x64\xdd\x87\xd1\x64\x49\x72\xb6\xf7\xbb\x22\x34\x7f\x65\xe3\x33\x69\x5b\xfa\x0c\xcb\x09\x8b\x20\x99\xba\x1a\x17\x70\xf9\xe9\x5d\xea\x37\xb0\xc6\x36\xc7\x7b\xcf\x36\xd6\x21\x01\x72\x93\x27\xba\x53\xf3\x7b\xcd\x91\xfa\xa8\x00\x2d\xb1\x56\x10\x83\xc3\xf2\x77\xb8\x5f\xb2\x1b\x98\x20\x2a\xdf\x23\xe3\x2f\x2f\xa4\x54\x23\xda\x52\xe7\x44\xd0\x0a\xde\x89\x0f\xe3\x49\xff\x8d\x2a\x3d\xae\xe0\x76\x0a\x7a\x35\xdf\x54\x4a\x07\x91\xa5\x7b\x46\x09\xae\x87\x33\x59\xe1\x19\x54\xe8\x76\xda\x77\xc5\xc3\x7b\x19\xa6\xa6\x72\xd9\x74\xb0\x0f\xfe\xfc\xc3\x0f\xf0\xf2\x17\x13\x0f\xd9\x70\x95\xf1\xbd\x09\x3a\xec\x5f\x75\xbe\x09\x24\x3d\x95\x39\x41\xaf\xad\x2d\x51\x8d\xd5\x1f\x5b\xad\x7d\x8a\x84\x0f\x98\xc7\x26\xd7\x1c\x8c\x38\xc1\x22\x4e\x5b\xdb\xf4\x8c\xc0\xc8\x84\xc0\xa1\xda\xff\xd1\x6d\xda\x23\x16\x35\x3d\x4a\x35\x82\xe7\x8e\xed\xe5\xf7\x03\x91\x93\xd6\x3c\x39\xdb\x32\x33\xd5\xf2\x1c\x2b\x9e\x9e\x3f\x99\x5d\xf0\xf4\xe1\xaf\x65\xc7\x9b\x8e\xfc\x93\xa4\x3a\xf2\xf3\xe8\x44\xd9\x92\xb8\xf2\x1c\xd0\xfe\x48\x7b\x6f\x70\x02\x3a\xf6\xb7\x04\xe5\x70\x45\xa9\x1d\x5f\x89\xa7\x14\xd3\x41\xa4\x26\x10\x8c\x57\xd3\x4e\xea\xe2\x4d\x74\xea\x86\x50\xa7\xd7\xb9\xfb\xd8\x69\xb2\x13\xf6\xb2\x55\xd0\x3b\xed\x83\xce\xa0\xd3\xb9\x5a\xc4\x07\xf8\x1d\x3c\xaf\x35\xfd\xc1\x00\x39\x8a\xdc\xa6\xc3\xd6\x74\xbf\x42\x69\x5d\xaa\x31\x34\xc9\x49\xf3\x19\xbc\x01\x49\x19\x64\xa3\x44\x21\x26\x90\xb1\x0c\xad\xba\x33\x04\x4f\xec\x18\xa6\x2e\x21\x7f\xb2\x72\xd7\xf9\x8e\x9a\xa4\xd8\xc4\x03\x25\x1f\x0a\xca\xea\x52\xb9\x91\x95\x8f\xa8\x71\xb3\x93\xe9\x6f\xea\xf4\xda\x8f\xa7\xf5\x4b\x27\x7b\xa4\xcf\xed\x2a\x4f\xe8\x51\x9e\x8c\x78\xa7\x7a\x91\xfd\xd3\x67\xa7\xf7\x1f\x7b\xfc\x1c\x31\x8f\x13\x7a\x8e\x93\x6b\x1d\x71\x97\x7d\x2b\x26\x47\x19\xb3\xa2\x98\xa9\x6b\x13\x3f\x9c\x61\xf2\x98\xc5\x89\x7d\x1f\x7c\x31\x70\x04\x3f\x33\x66\x6e\x4b\xeb\xed\x77\x45\xfa\x5f\xb0\xb3\x06\xbc\xf4\xc3\x36\x75\xd9\x66\xc9\x23\x6a\xd7\xb1\xaa\xce\x37\xeb\xd2\x27\x0c\x83\x4d\x36\x6b\x0d\x7c\xf9\xe5\xb6\xf7\xdd\xc9\xae\x9a\x0e\xe8\x9e\xd2\x35\xff\xbe\x10\x71\xa2\x12\x8d\xfa\xe6\x1d\xfa\xe2\x62\x70\xd3\xc1\xb3\xe9\x03\xdc\x33\x94\x0e\x7e\x8a\xae\x97\x01\xfd\x32\x7e\xdb\xfb\xfe\x2d\x17\xeb\xdf\xf2\x13\x32\x2f\xd4\xa9\xa9\xc6\x93\xbb\xf1\x97\xff\x0d\x00\x00\xff\xff\x2e\xb3\x7c\x6b\x81\x5c\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x7c\x59\x73\xdb\x48\x92\xf0\x3b\x7f\x45\x06\xe7\x41\x76\x04\x49\xd9\xed\x9e\x2f\xbe\xd1\x9b\x56\xb6\x7b\xb4\xed\x2b\x2c\xbb\x37\x36\x56\x1b\x1b\x45\x20\x49\xd6\x08\xa8\x42\x57\x15\x24\xb3\x7f\xfd\x46\x66\x1d\x00\x89\x83\x90\xad\xe9\x59\xd2\x0f\x16\x08\x64\x65\xe5\x7d\x15\x66\xcb\xe5\x72\x26\x2a\xf9\x1b\x1a\x2b\xb5\xba\x00\x51\x49\xfc\xe6\x50\xd1\x5f\x76\x75\xf7\xff\xed\x4a\xea\xf3\xfb\x97\x6b\x74\xe2\xe5\xec\x4e\xaa\xfc\x02\xae\x6a\xeb\x74\xf9\x19\xad\xae\x4d\x86\xaf\x71\x23\x95\x74\x52\xab\x59\x89\x4e\xe4\xc2\x89\x8b\x19\x40\x66\x50\xd0\xc5\x2f\xb2\x44\xeb\x44\x59\x5d\x80\xaa\x8b\x62\x06\xa0\x44\x89\x17\xb0\x43\x51\xb8\x5d\xb6\xc3\xec\xce\xae\xee\xea\x52\xac\xa4\x9e\xd9\x0a\x33\x7a\x78\x6b\x74\x5d\x5d\x40\xbc\xec\x9f\xb1\xf4\x0b\x80\xc7\xe1\xef\xfc\xf8\x15\x3d\xce\x57\xab\xa2\x36\xa2\x38\x04\x3b\x03\xb0\x99\xae\xf0\x02\xe6\xf3\x19\xc0\xbd\x28\x64\xce\x38\x79\x40\xba\x42\x75\xf9\xe9\xfa\xb7\x57\x37\xd9\x0e\x4b\x46\x9a\x2e\xe7\x68\x33\x23\x2b\xbe\xaf\xbd\x0c\x48\x0b\x6e\x87\xe0\xef\x86\x8d\x36\xfc\x67\x7b\x41\xb8\xfc\x74\x1d\xa0\x54\x46\x57\x68\x9c\x8c\x58\xd3\xb7\x45\xe6\x74\xed\x68\xbd\x33\x42\xc8\xdf\x03\x39\x11\x16\xfd\xa2\xf7\xfe\x1a\xe6\x60\xfd\xf2\x7a\x03\x6e\x27\x2d\x18\xac\x0c\x5a\x54\x8e\x37\xd6\x02\x0b\xa0\x37\x20\x14\xe8\xf5\x3f\x30\x73\x2b\xb8\x41\x43\x40\xc0\xee\x74\x5d\xe4\x90\x69\x75\x8f\xc6\x81\xc1\x4c\x6f\x95\xfc\x23\x41\xb6\xe0\x34\x2f\x59\x08\x87\xd6\x1d\x40\x94\xca\xa1\x51\xa2\x20\x52\xd6\xb8\x00\xa1\x72\x28\xc5\x1e\x0c\xd2\x1a\x50\xab\x16\x34\xbe\xc5\xae\xe0\xbd\x36\x08\x52\x6d\xf4\x05\xec\x9c\xab\xec\xc5\xf9\xf9\x56\xba\x28\x58\x99\x2e\xcb\x5a\x49\xb7\x3f\xcf\xb4\x72\x46\xae\x6b\xa7\x8d\x3d\xcf\xf1\x1e\x8b\x73\x51\xc9\x25\xe3\xa9\x68\x6f\x76\x55\xe6\x7f\x31\x41\xe8\xec\x59\x0b\x31\xb7\x27\x1e\x5b\x67\xa4\xda\xa6\xcb\x2c\x26\x83\x64\xfe\x55\xaa\x9c\x18\x2a\xc2\x63\x7e\x47\x0d\x35\xe9\x12\x11\xe1\xf3\x9b\x9b\x2f\x10\x17\x65\x8a\xb7\x40\x42\x20\x6e\xf3\x98\x6d\xe8\x4c\x74\x91\x6a\x83\x24\x25\xd2\xc2\xc6\xe8\x92\xc9\x8a\x2a\xaf\xb4\x54\x8e\xff\xc8\x0a\x89\xea\x90\xc6\xb6\x5e\x97\xd2\x11\x63\x7f\xaf\xd1\x3a\x62\xc7\x0a\xae\x84\x52\xda\xc1\x1a\xa1\xae\x72\xe1\x30\x5f\xc1\xb5\x82\x2b\x51\x62\x71\x25\x2c\x3e\x35\x95\x89\xa0\x76\x49\x14\x3c\x4d\xe7\xb6\xce\x03\x0c\x0b\x3f\x7d\x79\x17\x2c\xa8\x47\x3f\x00\x88\x3c\x67\x1b\x22\x8a\x4f\x03\x0f\x0f\x62\xd0\xab\x46\xcd\x4a\xcc\x66\x05\xb5\xb2\xce\xd4\x99\xab\x0d\xe6\x70\x87\xfb\xc0\xf1\x52\x54\x60\x9d\xa6\x8b\x0f\xd2\xed\x3a\x2b\x8a\x36\xf7\x85\x63\x71\x5f\x23\x58\x74\xb0\xde\x03\x59\x4a\x56\x08\xa7\x75\x41\xac\xf2\xb0\x58\x31\x0c\x3a\x23\xf1\x1e\xbb\x20\xcd\x5a\x3a\x23\xcc\x3e\xd1\x6e\x05\x5f\x76\xb8\x07\x61\x10\x88\xcd\xbf\xd7\x68\xf6\x62\x5d\x78\x38\x41\x61\xd7\x08\xac\xe9\xe6\x1e\xf3\x0e\xc8\x87\x1d\x2a\x28\x75\x2e\x37\x7b\x92\x5c\x2f\x96\x5d\xe5\xbb\x38\x3f\xbf\xab\xd7\x68\x14\x3a\x64\xbb\x9e\xeb\xcc\x9e\xd7\x16\xcd\x72\x5b\xcb\x1c\xcf\x5b\x0c\x3a\x9b\xf5\x91\xde\x43\x3e\xf8\x29\x2b\x6a\xeb\xd0\x7c\x20\xab\x3e\xc6\x93\x2f\x3b\x64\x33\x4e\x76\xc9\xcb\x3e\x3f\x07\x0f\x3b\x99\xed\x58\x1b\x82\x36\xad\xb1\xd0\x6a\x4b\xd4\x24\xba\x1c\x69\x1c\xfd\x93\x16\x6a\x8b\x39\x91\x3b\x97\xd6\x49\xb5\xad\xa5\xdd\x25\x46\x59\xe6\x24\x58\x5a\x8b\x17\x24\x2a\xd2\x7f\x6c\x25\x32\x22\x07\xe4\x72\xb3\x41\x73\xac\x79\xad\xcd\x58\xbf\x32\x6c\x24\x16\x6c\x27\x88\x2d\xc4\x73\xa1\xf6\x0f\x3b\x34\x08\x46\x6e\x77\x0e\x94\x7e\x60\x1e\x89\x4a\x5a\xd6\x7b\xe8\x41\x77\xab\x89\x27\x4e\x83\xdc\x2a\xe6\x87\x03\xb9\x61\x09\x92\xca\xbb\x49\x04\x6d\x82\x66\x47\xbd\x5f\xcd\x26\x4a\x7e\xd7\xcf\x8e\x31\x61\x7e\x75\x7c\x3b\xed\x4e\x80\x4b\x7f\x76\x4c\xa0\xdf\xd8\x11\x50\xe0\x27\xbc\xdc\xb1\x7d\x0b\xbc\x7b\x10\x36\x6c\x89\x4c\x94\x8b\xa4\xdb\xd6\xc2\x08\xe5\xd0\x33\xcd\xeb\x4f\x07\xa2\x54\xb0\x13\x55\x85\xca\x2e\xd7\xb8\x21\x4a\x69\x93\xa3\x01\x91\x19\x6d\x2d\x58\xac\x84\x21\x0a\x91\x79\xe0\x3d\xd8\x15\x5c\xb1\x01\xf5\xd6\x56\xe9\x2e\x4c\xa2\x32\xe3\xc7\xda\x1e\x51\x4a\x7b\xc4\x9c\xc4\xe1\xf3\xdb\xab\x57\xaf\x5e\xfd\x8d\x5c\x7a\xc9\xec\x94\x96\x2e\x7f\xfd\x72\xb5\x82\x5b\xd5\x81\xf9\x49\x57\x35\x39\xc7\x9c\x2c\x00\xc9\xad\xdd\x5b\x87\xe5\x0a\x3e\xa3\xc8\x97\x5a\x15\xfb\x15\x7c\xa8\x8b\x82\xe0\x41\x21\xad\xb3\x4f\x6d\x9f\xa3\xdd\x98\x1f\xe1\x46\x1b\x10\xee\x02\xc8\x45\x2c\x89\x41\x53\x85\x28\xc7\x02\x89\xa2\xbf\x18\x91\xe1\x27\x34\x52\xe7\x37\x98\x69\x95\xdb\x51\x69\xfa\x50\x97\x6b\x34\xa4\xd0\xd6\xdf\x0d\xa2\x28\xf4\x03\xe6\x21\x3a\x6a\xe4\xc2\x69\xd8\x12\xec\x4d\x5d\x14\xfb\x23\x90\x00\x0e\x4d\x29\x15\xf1\x36\x30\x5e\x3a\x78\x90\x45\x41\x0e\xcf\x60\xa9\xef\x31\x6f\x1c\x68\xa4\xf6\x47\x55\xec\x49\x8e\x58\x08\x3b\x20\xe3\x8e\x0e\xe5\xbc\xb0\x9a\x1e\x59\xc1\x7b\xb1\x07\xe2\x14\xad\x60\x77\xda\x38\x54\x98\xb7\x39\x38\x40\x59\xa9\xdc\xff\xfb\xf9\xe8\x37\x6f\x19\x29\x36\xda\x1e\xe9\x49\x07\x89\x71\xdd\x7c\xdd\x87\xf3\xe7\xb7\x57\xc0\xd2\x49\x4c\x65\xe9\x24\xc6\x82\x70\xc9\x70\xf6\x98\x9c\xe4\xb3\x22\x15\x19\x13\xcc\x8f\xcd\x5a\x70\x63\x8d\x9a\x33\x31\x41\x24\x66\x0d\xd2\x15\x64\x0a\x51\x1a\x45\x20\x4f\xb2\x88\x1a\x44\x7a\x9f\x4b\x83\x99\xf3\x7c\x72\xec\xd1\xd6\x5d\xee\x8b\x10\x06\x11\x72\xd8\xb8\x5b\x69\x01\xbf\x55\x98\xb9\x64\x34\xc2\x26\xe0\x99\xd2\x40\x2e\x02\x0d\xdc\x4b\x2b\xd7\xc5\xb1\x9c\x83\x97\x96\x04\x8a\x95\xd0\x23\x46\x58\x19\x14\xd9\x2e\x60\xc3\x2e\xe9\x39\x88\x0d\xb9\x22\xda\x03\x53\x57\x76\xb5\xde\x25\xc2\x2d\x40\x2b\x0e\x06\x11\x36\x52\x89\x42\xfe\x41\xf1\x1e\xad\x41\x44\xc1\xb2\x72\xfb\x15\x5c\x5a\x46\x11\x84\x3d\xba\xb1\x03\x98\x1f\x24\xbd\x17\x92\x82\x15\x87\xa5\x5d\x1c\x90\x79\x5d\xe8\xec\x8e\x78\xf7\x31\x2e\x9b\x1f\x0b\x4a\x07\xa8\xe7\xed\xa2\x65\xfb\xa2\x89\x24\x42\xd6\x8a\x18\xaf\x4d\xb0\xc4\xb0\xa9\x8d\xdb\x91\xf3\x52\x21\xf6\xdf\xd4\x14\x27\x2d\x3a\x60\x29\xd5\xd1\xf5\x76\x07\xb2\x89\x84\xa2\xf6\x40\x48\x88\x12\xd5\xc3\x0d\x91\x6b\x95\x91\xba\xc7\x8d\xd0\x82\x94\x59\xc9\x12\x57\xf0\x56\x1b\xc0\x6f\xa2\xac\x0a\xca\x2e\xc8\xcb\x9b\x90\x60\xb0\xa4\xf9\x10\x4c\x40\xa5\x59\xc2\x02\xe4\x0e\x4c\xa9\xe0\xd5\x8b\x68\x92\xbc\x54\xfd\x5a\xaf\xe9\x66\x6f\x55\x88\xff\x2c\xf7\x16\x55\x4e\xbe\xb9\x91\xf7\x64\x8a\x8e\x93\x29\xfa\x5a\xb9\xf5\xb1\x1e\xd3\x28\xb0\x8c\x78\x2f\x15\x5f\xa9\x74\xbe\x82\xcb\x20\x49\xc2\xb5\x90\x20\x46\x24\x24\x3a\x70\x19\x29\xc2\x05\x04\xec\x84\xc9\xdb\x48\xc4\x45\x9f\xdd\x5c\xff\xf2\xeb\xf5\xbb\x77\xcf\x3b\xcb\x93\x58\x77\x40\x7a\x79\xce\x0a\x14\xaa\xae\x16\xc1\x88\x46\x24\x1b\x5b\x7a\xf9\xe9\x9a\x33\x09\xfa\xbf\x77\x89\x19\x92\x39\x57\xe8\x1e\xb4\xb9\xeb\x80\xad\x84\x71\x1c\xa6\xdb\xc5\x81\x79\x27\x1e\x59\x47\xdb\xc0\x6f\x24\xce\x51\x9d\x02\x63\x59\x46\x17\x50\x2b\x27\x8b\x2e\xaa\x0a\x44\x5e\x4a\x25\xad\x33\xc2\x69\x43\x72\x24\x6a\xa7\x4b\x76\xb1\x95\xd1\x19\x5a\x0b\x99\x50\x90\xa3\x27\x0c\x1e\xca\x59\x8f\xfd\x63\x37\x93\xc8\x48\xba\x73\xbd\x89\x31\xdc\xa2\x61\x76\xd2\xb2\x10\x92\x86\xdd\xec\x44\x17\x22\x3d\xbc\x46\x54\x8d\xd1\xa3\xd8\x60\x28\x16\x38\x36\xa3\x69\xa5\x0e\xdc\xb6\x19\x3d\x88\x20\xfe\x8f\x47\x0c\x8d\x41\x1b\xf5\x69\xef\x6b\x4b\x74\xf3\x56\x31\x7a\xf7\x16\xa9\x1b\x2d\x6e\x84\xd2\xe0\x96\x64\xa1\xe3\x83\x01\xde\x88\x6c\x07\xa8\x9c\xd9\x87\xa4\x4e\xe6\x14\xa8\x6e\x24\x9a\x54\x8f\x31\x68\x2b\xad\xd8\x2b\x40\xa6\xcb\x4a\x2b\xe4\x64\x9b\x1c\xa6\x2c\xba\xe2\xd7\x52\x0d\x0f\x39\xe1\x41\x86\x99\x05\xa7\xd7\xe4\x1e\xca\x4c\x07\x2c\x3b\x40\xb5\x54\xb2\x58\x30\xc6\x12\x83\x99\x90\xc1\x55\x90\x40\xc7\x08\x24\xc4\x38\xc7\x1b\x66\x5f\x70\x4c\xde\x11\x9e\xc4\x9f\x84\x31\xe2\xd0\xcd\x6e\x51\x51\xcc\x8c\x27\x93\xb4\xf9\x2f\xad\x3b\x03\x91\x35\xff\x26\x0a\xca\x3f\x37\xf2\xdb\x82\xcc\x72\x23\xef\x9c\x1d\x74\x3d\x85\xd3\x69\x51\x32\xe4\x4a\xfe\x5e\x87\x6c\xec\xe3\x87\x77\xff\x09\xd7\x6f\xf9\x69\xc2\x27\x44\x23\x3b\x61\x1b\x25\xab\x8c\xbe\x97\x79\x97\x22\xe0\xd9\xd1\x0e\x61\x08\x19\x32\x46\x01\xba\x41\x57\x1b\xe5\x43\x86\xa6\xc2\x92\xa2\xc9\xe1\xcc\xcf\xed\x84\x6a\xc0\x54\xc2\xda\x14\x2e\x79\xff\xc9\x20\x38\x82\x5c\x93\xf5\x2d\xd7\x52\x85\xa2\x41\xda\x60\x07\xa8\xad\x37\x1b\xf9\x8d\xc0\x90\x7d\xf5\x7b\x0a\xee\x78\x17\x22\x03\x4e\x53\x9b\xd2\x24\x98\xba\x40\x1b\xc3\x06\xa2\x4f\x07\x68\x08\x42\x62\xf1\x6d\x8d\xe0\x4c\xad\xb2\xb6\x15\x2a\x50\x6d\xdd\x2e\x8a\xa8\xc7\x82\xed\x8c\xa4\x42\x87\xd3\x1d\x98\xa5\xb8\xf3\x7a\xe9\x91\x0b\xfc\xd2\xaa\xc5\x63\xb6\x77\x1d\xf2\x53\xc5\x56\x6e\x64\x8f\x17\x26\xfc\xe8\xe9\x28\x06\x3e\x07\xf7\x0e\xc2\x2e\x5a\x80\x3d\x73\x3e\x7c\xa4\x42\x1b\x31\x0f\x04\xfc\xfc\xe2\x6f\xb0\xec\x40\x94\xca\x3a\x14\xf9\x22\xa5\x07\x28\x39\x6c\x09\x8f\xfd\xf4\xe2\x25\x70\x7a\xeb\x63\x91\xbf\xbe\x78\xe1\x0b\x01\x9f\x51\x58\xad\x42\x61\x8e\xf4\x57\xd7\x3d\xfa\xaa\x72\x99\x09\x4e\x7a\x0f\xc5\x35\xe3\xea\x4b\x08\x9c\x36\xba\xa6\xf4\x50\x35\x91\x22\x25\x3c\xce\x61\xbe\x18\xdc\x7f\x90\xc0\x50\xc6\x31\x48\x36\xe6\x59\xd4\xa9\x62\xdf\x0d\x3d\x19\x11\xce\x4c\x3b\x30\x09\xde\x67\x82\xb0\xf4\x61\xc6\x0e\x45\x8e\xe6\x39\xb3\xe6\xb2\xaa\x0a\x49\x5b\x27\xa3\x22\x37\x10\x35\x98\x50\x4f\x5c\xea\x2a\xd4\xd3\xfa\x19\x99\x63\x59\x69\x87\x2a\xdb\xcf\x67\x13\xcd\x56\x10\x90\xa3\xb2\x78\xc7\x34\x5d\x82\x25\x47\x49\x31\xb0\xf2\x79\xe7\x41\xa9\x42\xc4\x4d\x66\x51\xe2\x28\x7c\xd6\x9b\x23\x90\x10\x02\x68\xcb\x9a\x60\x9d\x70\xb8\x1a\xf2\xe2\x4f\x9e\x0f\x72\x9f\x64\x8a\xdb\x9c\x5f\xaa\xf6\xcd\xc4\x46\x41\x25\x7b\x67\x74\x51\xa4\x9a\x19\xaa\x8d\xe6\x7a\x97\xd5\x65\xc4\xf9\x08\x2a\x09\xf6\xbd\x30\x52\x28\x47\x29\x63\xf0\xba\xb1\x66\x14\xa2\xee\xc3\x9c\x50\x78\xff\xa4\x37\x6d\x0c\xba\x01\x11\x87\xe2\x3b\x71\xef\x4b\x96\x7b\xaa\x8d\x71\xaa\xa6\x0f\x0a\x42\xec\x3f\x95\x2c\x48\x21\x39\x06\x38\x88\x1b\x3b\x40\xc9\x28\xb2\x03\x20\xcf\x4d\xc1\x7d\xb1\x6f\x61\x41\x29\x10\x29\xfc\x83\xb4\xb8\x38\x8a\x22\x32\xf2\xf9\x39\x9a\x1e\x43\x54\xab\x16\x88\x98\x9d\xee\x64\x9e\xa3\x82\x67\x52\xf1\x76\xcf\x1f\x84\xcb\x76\xfc\xe3\x16\x1d\x64\xa2\x28\xec\x73\x1f\x92\x78\xfd\x1d\x21\x80\x3a\x73\x94\xa9\x16\x32\x93\x94\xea\x0a\x7b\xc7\x36\x16\xf4\x9a\x0d\xe7\xd1\xfa\xa9\x36\xdb\x53\x59\xfa\x0f\x8e\x1a\x63\xcf\x06\x64\xaa\xa5\x2d\x0e\x62\x4b\x32\x97\x55\x10\xd9\x56\x44\xd1\x5b\xbf\xa6\xe7\xb2\xda\x50\xb1\x93\x82\xdf\x63\xb6\x86\x32\x4a\x65\xe4\xbd\x2c\x70\x8b\x39\x39\xf7\xd0\xbd\xe0\xdb\xbb\x19\x9b\x2f\x33\x37\xeb\x86\xbc\x54\x36\xd9\xef\x22\xa6\x87\xc1\x6a\xf2\x13\x92\x42\x3c\x9f\x67\x76\x40\xae\xf7\x20\xd4\x9e\x97\x66\x53\xf6\xfa\xcd\xa7\xcf\x6f\xae\x2e\xbf\xbc\x79\x0d\xcb\x03\x74\xb9\x44\x2e\x14\x88\xa2\xda\x89\x20\xb2\xc4\xb3\xde\xc8\xae\x55\x3c\x92\x0a\xee\x5f\xae\x5e\xfe\x75\x75\x6c\x94\x86\x3a\x15\xf4\xad\x7c\x76\xd8\xfd\xe1\x48\x59\x3f\x85\x2c\x72\x50\x77\x42\xe7\x80\x42\x61\xfc\x86\x59\xed\xba\x3e\x3d\xa4\xad\xbe\xe0\x99\xc2\xe4\xa4\x28\x44\xda\x50\xea\x58\x79\x29\x21\xbe\x16\xc2\xba\x88\xe5\x00\xc4\x84\x04\x41\x08\xd4\x88\x85\x10\xd8\x08\x59\x90\xc3\x33\x68\xeb\xc2\x85\x7a\x90\x17\xb5\x36\xfa\xbd\xa0\x7d\x33\x25\xc5\x55\x24\x2b\x4e\xb3\xa6\x47\xbf\xd7\xa7\x9b\x14\xd7\x34\xa0\xbb\xaa\x1a\xfd\x66\xd8\x2b\x69\x91\x28\x8a\xa8\x82\x5d\xe7\x35\x18\x23\x9f\xe2\xad\xff\xaa\x9e\x70\x78\x80\xc9\xed\xce\x45\xcc\x49\x99\xad\xd2\x1e\xa4\x1c\x94\x86\xa4\x1d\x0e\xf1\x25\xaa\x66\xe2\xef\x6a\x36\x78\xd3\x70\xb0\x1f\xf3\x97\xdf\x6b\xf2\x65\xfd\xfb\x58\x72\x10\xd3\xfb\xd3\x60\x43\x67\x3c\x95\x08\xe5\xc5\xba\x70\x17\xb3\x13\x34\xbb\xde\x1c\x8a\x96\x0f\xc7\x88\x82\x6f\x85\x2c\x6a\x13\x42\xff\xb6\x29\xef\x01\x19\xea\x23\xd4\xff\xa2\x26\xb8\x0d\xf5\x40\x6a\xb4\x89\x6d\xa8\x88\x92\x46\x84\x3c\x92\xd2\x2d\x5b\x53\x90\xe1\xd5\x4e\xf7\x5a\x1c\xfa\x17\xa4\x8a\x4b\x0b\xd1\x56\xb7\x53\xbd\xd5\xec\xf1\x32\xd5\xdf\xe2\x1f\xa4\xd0\x63\xdb\xfd\x03\x30\xa1\x09\x85\x62\xd8\xf3\xa8\xd6\xff\x20\xd8\xde\x91\x80\xc7\x8c\x01\x0c\x42\xfe\x13\xc7\x03\x26\x05\xa1\xf1\x9b\xe9\x1c\x27\xb1\xee\xa6\xde\x6e\x7d\xf1\xfb\xef\x5f\xbe\x7c\x8a\xa9\x0b\x3d\xde\x34\x3f\x28\xbc\xac\xed\x02\x5e\x80\xec\xc6\xa1\xf1\x13\xca\x52\x43\x26\xa0\x15\x69\xbe\xfa\x69\xe0\x9e\xe1\x88\x33\x7e\x72\x74\x42\x16\x76\xd2\xce\xde\xd0\xf8\x4f\x8e\x39\xb5\x91\x04\x08\x6b\x75\x26\x39\x38\x4e\xea\x6b\x38\xa3\x5a\xf9\x82\xcc\x00\x48\x92\x49\xba\x8b\x25\xc3\xcb\x36\xd0\x5c\x83\x7e\x50\xdc\x36\xf7\x2b\x78\xb4\x8e\x42\xd0\x41\x88\xa9\x12\x11\x7d\x0c\x63\x98\x52\xfe\xde\x66\x63\xa6\x29\x4a\x2e\x67\x03\x20\xc9\x94\x50\xec\x11\xf4\x0c\xbf\x65\x58\x85\x72\x91\x47\x3a\xe5\x04\x61\x3b\x44\xeb\x21\x5e\x9d\xf6\x38\x00\x99\xa8\xed\xd8\xef\x47\xcc\xa0\xca\xc1\x15\x3f\xe2\x6d\x31\x48\x95\x15\x75\x8e\x16\x4a\x4a\xdc\x02\x5f\x5b\x5c\x1a\x01\x0c\x8d\x01\xbe\x61\xc9\x0c\x99\x31\xc5\x01\xb5\xc1\x15\x7c\xd0\x8e\x3a\x78\x07\xbf\x72\x2c\x38\x0a\x34\x14\x36\x02\x2e\x98\x87\x2d\x0e\x11\xe9\x84\xd7\x7e\x0c\x2d\x83\x86\x90\xd8\x9c\xba\xe9\x88\xac\x73\xa2\x2b\x7b\x9f\xe8\xd4\x53\x39\x39\xc4\xf5\x54\x72\xa6\xda\xd2\x49\xb8\xc1\x91\xa3\x31\xda\x2c\x28\xc0\x21\x8f\xcb\x52\x43\xe2\xfe\xef\x37\x1f\x3f\x50\x9d\x83\xe3\x01\x31\xe4\x56\x8e\xbf\xef\x1b\x46\x43\x4e\x4c\x51\x39\x54\xda\xba\x8d\xfc\x06\x71\x42\x83\xcd\x8c\x62\x13\x34\x01\xa2\x70\x7e\xba\x8a\x6c\xee\x25\x09\x92\x8f\xa5\xff\x40\xa3\x97\x52\xe5\xf8\x8d\xaa\x5d\xf0\x96\x28\x72\x9a\xe3\xd1\xd7\x55\x28\x8c\x97\x43\xae\x9e\x71\x5b\x4c\x72\x06\xe3\x65\x55\x6f\x82\x2c\x40\xde\x53\x1c\xeb\x7e\x9d\xf6\x36\xc0\x52\x5e\x45\x1e\xbc\xac\x0b\x27\xab\x02\x3d\x75\xed\x0a\x3e\x06\x0b\xc0\x69\xc2\x1b\xdf\x29\x3a\x29\x20\xf4\xef\x16\xe0\x76\x4e\x9c\xb9\x9d\xc3\x32\xb4\xe4\x88\xfb\xe9\xa2\x56\xed\x5c\x69\x02\xc4\x24\x30\x04\x99\x05\xfa\xbf\x5e\xfc\xf7\x6a\x64\x89\x09\x30\x03\x12\x1b\x69\xa8\x89\xc2\x34\x0c\xe5\x6e\x15\x17\xb9\x9d\xcf\x67\x23\x10\xa6\x79\xb9\xe6\x53\xa2\xb5\x62\x3b\x12\x05\xf7\xaa\xcf\x25\xec\xea\x52\xa8\xa5\x41\x91\x73\x23\xb5\xf5\x6b\x54\x28\xe6\xfc\x49\xb0\x10\x6f\x67\x0e\xaf\xa0\xed\x09\x42\x75\x33\x44\x36\x9c\x3d\x2c\x47\xbc\x43\xf3\x25\x9b\x4e\xee\x27\x47\xb3\x7a\x4a\x62\x79\x17\xf0\x68\x5a\x95\x22\xdb\x49\x85\x63\xd4\x3a\x09\x32\x38\x8e\x23\x6a\xc5\x72\x2c\x47\x53\x29\xff\x26\x80\x66\x0a\x48\x76\x98\x1c\x7d\x51\x8c\x41\xd8\x88\x7b\x21\x0b\xe2\xe8\x13\xd2\xed\x44\xa2\x31\x25\xe1\x88\x1f\x3f\x17\x3c\x9b\x48\x79\xb2\xf1\xfc\x44\x63\xfd\x3a\xd6\xfe\xb1\x8e\xd3\x87\x74\x07\x1e\x72\x35\xfb\x41\x22\x1d\x8f\xaa\x8e\x6e\xea\x8c\x76\x45\x4f\xfc\x93\x37\x05\x1f\x95\xaf\x2b\x36\xe3\x56\xe4\x17\xc2\xec\xdc\x28\xdc\x56\x27\x2f\x74\x36\x1b\xd4\x68\xf0\xf6\x4f\x1a\x57\xfd\x2e\x5e\x8c\x97\x04\x7a\x04\x8c\x1e\xf8\xe7\xb2\x02\x9e\x85\x31\x3b\x24\xa2\x51\x91\xc9\x4a\xb5\x2d\x70\x38\xb5\x8f\x5f\x5f\x26\xa6\xfc\x76\x1d\x8d\xce\x1a\xf3\xe7\x3f\x2c\xb0\xdc\xc4\xe0\x0e\xc4\xc0\x94\xd8\x20\xc5\xae\x37\x4d\x2f\x62\xd1\x6e\x7a\xc4\x49\x89\x56\x8f\x78\x04\x26\x34\x43\x80\x31\xab\xe5\x6a\x1f\x4d\xdc\xe6\x2b\xb8\x21\xb9\x65\x13\x19\xe7\xb0\x7d\x4f\x65\x14\x62\xab\x57\xc3\xa5\x3a\x47\x2d\x31\x0a\x65\x0a\x9e\xf1\xa5\xe1\xab\x8c\xec\x0a\x2c\x43\x82\xa7\x6d\x5c\xe4\x04\xdc\x03\x87\x16\x71\x81\x9d\x7e\xf0\x23\x42\x4e\xc3\x83\x90\x2e\xed\x5c\xdc\x8d\xd1\x3e\xa2\x7a\x8c\xd6\x18\x53\xa7\xe4\x90\xd3\xf2\x48\xfa\xd6\xf2\x11\xd6\xea\xeb\xf5\xeb\x63\x9d\x58\x0d\x09\xf4\x6c\x52\xb8\x35\x24\xd4\x8f\x1e\x76\x6e\x86\x07\xec\x5f\x6a\xf9\xc3\xb6\xe3\xa4\x9b\x1b\x33\xf3\x4f\x70\x3a\x61\x36\x2a\x80\xa1\x1a\xfb\x3d\x27\x15\x66\x13\x34\xe6\xbb\x4e\x2d\x0c\x02\xfe\xd3\xdd\xc3\x49\xf6\x9e\x08\x93\x1f\x1d\x1c\x07\x33\x7f\xaa\xac\x97\xac\xdc\xea\xfb\x11\xef\x1e\xcf\x18\xc4\xfc\xec\xc6\x09\x95\xd3\x04\x1a\x35\x76\xd2\xb3\xff\x02\x7f\x3d\xa9\x92\xa2\x49\x13\xea\xe9\xee\x3a\x3e\x10\x13\x0b\x6a\x5a\xc8\x4d\x9a\x5c\xe5\x12\x35\x75\x3f\x4b\xe9\x66\x13\xb2\xb4\xd0\x85\xa6\x66\x0f\x25\x66\xa1\x04\x18\xfb\x2b\xd1\xce\x87\x36\xc1\x29\x7f\x16\x46\x21\xa8\x01\xca\x09\x35\xf1\xac\x15\x8d\x73\xa8\x91\xa2\x7c\x5d\x09\x1a\xa7\xe9\x1b\xfc\x6b\x7f\xc2\x36\xe3\x59\x09\x69\x2d\x3f\xa4\xc3\xd0\x44\x18\xa9\xd4\x07\xca\xce\xd8\x9e\xc6\x34\x6f\xf5\x1d\x9d\x0e\x9e\x37\xd4\xcf\x15\x7e\x4b\xbd\xc6\xb4\x83\x51\x90\xa9\x27\x7a\xe5\x39\x44\xf6\x8d\xfb\xdd\x5c\xee\x57\x2e\x88\x63\xd3\x51\xac\xb4\xed\x9f\xfb\x6d\x7f\xe4\xa6\x3d\x64\x42\x75\x40\xb9\xad\x43\xd0\x40\x74\xce\x76\x42\x51\xc7\x53\xb7\x6b\x18\x62\x14\xe4\x06\x1f\xa0\x94\x8a\xca\x28\x54\xa2\x68\xcf\x09\x35\xfe\x2d\x16\xf4\x7d\x12\x1b\xa5\x62\x14\x2e\xfb\xc3\x9a\xbc\xa0\xa7\x6b\x92\xd4\xd6\xe8\xd1\x1a\xc1\x7b\xac\x2c\xcd\xa0\x8e\xc2\x0c\xd2\xd2\xae\x28\x84\x46\x15\xd2\x28\x66\x41\x1d\xac\xbd\xae\xfd\x3e\x0c\x66\x28\xfb\x4e\x16\xb5\x3f\x8c\x9a\xd3\x77\xa8\xbc\x93\x10\xca\xc7\x3f\xd1\x3a\x8e\x85\x20\x27\x0d\x55\xdb\xc7\x07\x0a\x4e\x56\xec\xb3\x1b\xd7\x34\x7c\x92\x5b\x0f\xf3\x55\xcc\xfe\xb3\x33\x9b\xda\x16\x23\x50\x21\x76\x5e\x62\xc3\x25\xfa\x4d\xd2\x8a\x18\x73\xc4\xf1\xb7\xd8\x3f\xea\x19\xa7\x6a\x7f\x9b\xa9\x55\xe6\x72\x90\x75\x4f\xf6\x20\x82\x2b\xf8\x8d\x99\x55\x86\x69\x49\x47\xf3\x19\x27\x98\x21\x92\x19\x68\xa1\x42\x86\xc7\x8b\x24\xd4\x2a\xb5\xdd\xd7\x22\xbb\x9b\x22\x31\x71\xce\x6b\xc2\x38\x4c\xcb\x23\x8c\x82\x7c\x02\x6f\x91\x69\xe5\x07\x18\xb2\xfd\x32\x8c\xc0\x2c\x85\xca\x97\xc9\x3c\x64\xfb\xb3\x1f\x15\x3c\x8b\xc5\xe6\x9d\x54\x77\x93\x25\x2e\x3e\xe0\xa3\xb4\xaf\x9f\xdf\x1d\x07\x67\x49\x74\xc6\x94\x62\xd2\x59\xa2\x1f\xdb\xdb\xc9\xa8\x74\xbc\xa6\xf5\xc8\x4a\xd6\xc3\x2e\x0c\x86\xa4\xc0\x65\x00\x2e\xd7\x9e\xc2\x1c\xdd\x3c\x74\x83\xe7\x21\xf9\x1d\x2f\x6b\x8d\xf5\x87\x06\x8b\x59\x70\x19\xa7\x00\xb3\x42\x18\x9a\x84\xe3\xc9\x56\xee\xdc\xf9\x45\x07\x61\x72\x47\x6f\x5d\x3b\xc8\x35\x52\xb9\xcc\x81\xbe\x47\x63\xa8\xe1\x21\x3b\xa7\xf4\x26\x33\xc6\x2f\x3a\x89\xea\x67\x37\xad\x58\xb1\x55\x8e\x59\xc1\x47\x45\xc3\xfa\x17\x30\xbf\xa9\x33\x1a\x92\x9f\xf7\x8d\xeb\xc4\x4f\xa2\xf2\x53\x47\x73\x94\xcf\xb3\x42\xfa\x3d\x9d\x7d\x1f\x49\x46\xe4\x74\x68\xc2\x61\x39\x30\xfb\x32\x08\xaa\x10\x6b\xec\xf6\x40\x9f\xf8\xe4\xf1\x7b\x51\x91\xf3\x08\x89\xdb\x1d\xee\x49\xd2\xe2\x71\xf8\xae\x1f\x71\x1a\xb4\xd9\x0a\x6a\xc3\x77\xd6\xa4\xe7\x28\x84\xdc\x6a\x23\xff\x40\x78\xc6\x2f\x33\x60\x68\x16\x0b\xcc\xdc\xf3\xb0\x49\x3a\x5f\x28\xf6\x50\xf2\x08\x9b\xff\x49\x1b\xdb\x37\xfb\x68\xb0\x2a\xa8\x12\x42\xea\xda\x8c\x13\xda\x00\xd3\xdc\xcb\x0c\xed\xe3\x13\x69\x4f\xd7\xb3\xa9\x6c\x28\x85\x12\x5b\xcc\x7d\xaf\xe9\x62\x8c\x98\xf3\xf7\xed\x5b\xa1\x14\x95\x05\x3a\x97\xb2\x29\xf4\xc3\x52\xe6\x8c\x76\x74\xd8\x61\x44\xa1\xef\x60\xa9\xde\xc4\xb6\x12\x93\x9f\xfa\x5e\x01\x07\x72\xe3\x7c\x2d\x42\x0d\x9d\x68\x49\x51\xb8\xa5\x69\x3e\x2a\xf5\x0c\x06\x0e\x3b\x5d\x5b\xbc\x43\xac\xa4\xda\xfa\xa8\x9f\xf2\x08\x4b\x76\x59\xd2\x08\xe1\x3e\x14\xa7\x68\x42\x50\x85\x7e\x74\x38\x79\x55\xab\x1c\x8d\x75\x7d\x21\x7c\x53\x30\x5a\xc1\x65\xda\x6f\x94\x9a\x98\xad\x9c\xf9\x46\xe3\xe2\x60\x30\x34\x5e\xec\xc0\x0c\x87\x23\xe2\x14\x53\x6b\x58\x56\x54\x15\x0d\x00\x0a\xb7\x83\x42\xde\x21\xdc\xce\x33\xb9\xcc\xf2\xdb\x39\x91\x02\x63\x1c\xef\xe9\xd7\x01\x4b\xde\xaf\x78\x10\xfb\x64\xcb\x13\x37\x42\xce\xd3\xa0\xcf\x51\xd3\xd1\x39\xf5\xbe\x80\x24\x0e\xad\xdc\xaa\xc3\xa1\x80\x30\xf3\x47\x44\x0e\x94\x68\xc5\xef\x71\xce\x8f\xca\xa8\x7d\xd3\xdd\x4a\x3b\x99\x61\x67\xfa\x6f\xa0\x0d\x3d\x9e\x7c\x9e\x1a\xf1\x39\x90\xe0\xf1\xf9\x9e\x14\x65\xc6\xc0\x77\x2c\xfb\x6a\xd5\x11\x89\x29\xc4\x37\x72\x63\xfe\x94\x3c\x86\x1a\x1f\xb9\xd5\x39\xf7\x3c\xce\xc3\x1a\x73\xf8\x47\x7d\xf4\x1a\x8f\xe6\xcb\x1c\x27\x36\x39\x5d\x2d\x0b\xb2\xf0\x6d\x8c\x83\x0c\x86\x63\xdc\x48\x2e\x86\xde\x5a\x40\x9a\x66\x44\x78\xef\x4a\xf7\x7b\xb4\xbf\x38\xa6\x49\x38\xaf\x91\x9b\x82\x34\x1e\x9a\xa5\xda\x50\x38\xeb\xe5\x15\x66\x00\x66\x18\x59\xea\x9b\x5f\x3f\x61\x9c\xd3\x80\x40\x2f\x2f\x07\xac\x3f\x38\x53\xe3\x69\xe6\x06\xb3\xd4\x4a\x38\xc4\xa1\xbe\x8c\x61\xdb\x63\x18\xdb\xe6\xd1\x4c\x10\x2e\x6f\x1d\x4d\xf7\x2c\x94\xde\x1c\xea\x1e\x83\xec\xa7\x4d\xe0\x98\xc5\x09\x28\x0f\x12\x38\xc5\x24\x13\x90\xfe\x18\xef\x8d\xef\xd3\x21\xd8\x44\xb2\x04\x24\x54\x78\x0b\x14\xbd\x47\x55\x22\xce\xd2\xc2\x81\x7b\x78\xc3\x8d\xf2\x35\x52\xfc\x9d\x5e\x41\x40\x9a\x41\x51\x34\xf9\x5f\x19\xbd\xf0\x00\xc8\x34\xb6\x15\xe6\x8a\x0d\xc2\x19\x1d\xaa\xd8\x9f\xb1\x69\x3f\xfb\xca\x45\xcc\xb3\xef\xa2\x10\x75\x39\x26\x10\x87\x4e\xa7\x40\xfb\xd0\x24\x11\x26\x16\xcb\x13\x8f\xe0\x81\x3a\x41\x23\x33\x63\xd7\xe9\xb8\x49\xb0\xce\xe9\x04\x9e\xdc\x1c\x32\x20\x6c\x70\x36\xd6\x35\x18\x3a\x1b\x38\x61\xe3\x23\xa2\x3e\xd4\xee\xed\x6b\xc0\x1d\xd0\xe8\x8c\x0f\xb6\xc4\x54\x39\x1c\xd5\x21\xc3\x4f\x93\x27\xcd\x7b\x3e\x56\x70\x6d\x9b\x23\x4f\xbd\xef\x08\x60\x29\x09\x03\xd0\x5c\x42\xb7\x8b\xe6\x84\x33\xf7\x3e\xd3\x0f\x5c\x32\xa4\xb3\x3e\x0f\xe9\xb8\x7a\x9f\x6c\xa6\xa2\x5a\x73\xee\x29\x9a\x41\x05\xa2\x22\xc7\x62\xa8\x19\x18\xde\x4b\xd2\xb6\x7c\xab\xfe\xc3\x5e\xd2\x42\x65\x64\x29\x8c\xe4\xa3\x10\x61\x6e\x8e\x44\x35\x1d\xe2\x68\xce\xdc\x50\x75\x2f\x3f\xaa\x74\xe5\xe9\xf5\x5c\x5d\x69\xe9\x29\xd0\x3f\x36\xf6\x6b\xac\x8e\xfd\x0b\x6d\x6a\x20\x0c\xec\x91\x8f\xc4\xa9\x51\x6e\xcf\x3f\xc4\xdb\x0e\x1c\xa8\xbf\x12\xb8\x4e\xc7\xf9\x41\x75\xa5\xa2\xbb\xe1\x4b\x15\xf4\x20\x2d\x4e\xda\x46\xf9\xc5\xbd\x28\x3c\x4f\x19\xfc\xed\x3c\xc7\x8d\xa8\x0b\x77\x3b\x6f\x24\x6a\x01\xeb\x9e\xd0\xa2\x7d\x6b\xb0\x68\x99\x50\x5a\x11\x57\x9b\xa2\x40\xc8\xd8\xe2\x80\x5d\x2c\x02\x51\x28\x1a\x65\xb4\x03\x39\xbc\x29\x85\x82\x7e\x32\x84\x6d\xe1\x0e\xf3\x45\x6c\xce\x52\x10\xe1\xcd\x56\xd3\x9b\x0c\x8b\xcc\x86\xc6\xa9\xc3\x9b\x0a\x6e\x55\x3a\xa5\x2b\xe0\xf5\x87\x9b\xff\x79\x77\xf9\x6f\x6f\xde\xad\xc6\x85\xa3\x03\x74\x92\xb0\x24\xfc\xed\x7c\xaa\x94\xe8\x07\x85\xe6\x33\xf2\xeb\x7a\x32\xb4\xa3\xb2\xf2\x2e\x9c\xbd\x88\xd4\xcd\x91\x12\xc4\x18\xe5\x37\x15\x19\xaa\x2f\x5c\xbe\x7b\x37\x48\xa0\x10\xcb\x72\xd1\x99\xcb\x74\x6b\x6c\xcf\x97\xb7\x40\x25\x5a\x6e\x85\x59\xd3\x34\x7a\x46\xe7\xb3\xe8\x1c\x54\x57\xf6\xae\x37\x07\x4f\x4a\xdb\x4e\x42\xda\x41\x3c\xad\xe0\xcf\x01\xa5\xd9\xaf\x54\x6c\xef\x40\x0d\x87\x81\x64\x94\x5d\x69\x0f\x20\xa5\xb9\x82\xe6\x62\x2b\x1e\xa3\x27\x4c\x9f\x9e\x7c\xe1\x4a\x4b\x13\xa3\xb5\x67\xfc\x42\xf2\x44\x76\xb3\x01\xba\xfa\x57\x44\xd6\x87\x61\x34\x69\x12\x8b\xc9\x80\x5b\x1c\x14\xb1\x70\x5a\x88\xde\xb2\xf1\x91\xa4\x2d\xbe\x86\x65\x02\x12\xc4\x53\x43\x6f\xc2\xbb\xfc\xf0\x3a\xf6\x1b\x58\x62\xd3\xf1\xde\x39\xf5\xf4\x29\x20\x57\x79\x84\x7b\x2c\xfb\x9d\x23\xf5\x41\x00\x1a\x60\x0d\x23\x82\x10\x36\x4d\xda\x3b\xdc\x2f\xd9\x0c\x0c\x00\xa5\x63\x12\x64\x0f\x9d\x2c\x62\xaa\x11\x74\xa9\x75\x22\x68\x05\xaf\xbd\xb9\xa3\x74\x02\x36\xa2\xa0\x57\xca\x7d\x19\x0a\xbd\xd2\x3b\x95\xe2\x41\x64\xaa\x64\x18\x4e\x70\x2d\xcc\x3d\x86\x73\x3a\xac\x51\x4a\xdb\x66\x0f\xef\xa5\x9b\x9a\x06\x3d\x8f\x07\xfb\xe0\xe7\x9f\x7e\x82\x67\x5f\x55\x38\x64\x43\xe5\x3b\x78\xa3\x9c\x74\xfb\xe7\x49\xdb\x62\x4f\x65\x8c\xd1\x6b\xad\xe9\xed\x17\x3d\x77\x34\x52\xfb\x18\x0e\x1f\x11\x8f\xdf\xe1\x97\x0e\x46\x4c\xd0\x88\x69\xb8\x0d\xcf\x08\x1c\x60\xe5\x27\x04\x8e\xc5\xfe\x89\x0b\x7b\x27\xdb\xb4\x27\x34\x6a\x78\x94\xea\x70\x2f\x1f\x5a\x47\xab\x06\xf7\xf2\xe3\x81\xc8\x24\x9c\x6b\x39\x89\xfc\x07\x53\x2d\x4f\x81\x71\x2d\xbf\x8b\xc8\x31\x76\xe8\xe2\xbc\x6c\x59\xd3\x9e\x1f\x89\xab\xb3\x89\x87\xc5\x96\x50\xcb\xfc\x29\x42\xfb\x18\x4d\x0f\x18\xf9\x03\x12\xd3\x09\xe8\xd0\xdf\x62\xf3\x46\xde\xa7\x3d\xbe\x12\x4e\x29\xc6\x83\x48\xc9\x11\xcc\x7a\x13\xc5\x49\x5d\xbc\x81\x4e\x5d\x07\xe2\x61\xe7\xee\x7d\xab\xc9\x4e\xb1\x97\xae\x9c\x2c\xe9\xad\x84\x19\xb4\x3a\x57\x8b\xf0\x00\xaf\xc1\x63\x64\x5d\x43\x18\x0f\xb5\xf8\xa3\xc8\x4d\x3a\x4c\x9d\x8c\x94\xa2\xd0\x41\xea\x50\x63\x88\x97\x9a\xd7\xe0\x75\x40\x72\x3c\xcc\xdd\xc4\x90\x40\x86\x32\x74\xd3\x3c\x7c\x7c\xc7\x30\x76\x09\xf9\x95\x95\x65\xeb\x3d\x6a\x3e\xc5\x26\x1a\x08\xff\xa2\xa0\xac\x2e\x84\xe9\xc1\xbc\x03\xb2\xb5\x93\x5b\x35\xa5\x27\x36\xb1\x5f\x3a\xd8\x23\x7d\x6a\x53\x39\xa1\x47\x39\x39\xe2\x1d\xea\x45\x1e\xa8\xc7\xcd\xf4\xfe\xe3\x01\x3d\x8f\x60\xc2\xb4\x9e\xe3\x20\xae\x3d\xe6\xf2\x50\x8b\xc9\x50\x86\xac\x28\x64\xea\x14\xcd\xca\xf0\x52\x4e\xce\x05\x42\x97\x2f\x55\x5f\x02\xda\x47\x60\x21\xbc\xba\xb1\x29\xad\x87\xfc\xba\x25\x26\x2c\x98\x40\xef\xcc\xf2\xfd\x30\x7a\xc5\x53\xca\x92\xf5\x66\xec\xdd\xae\xad\x77\xd6\xc5\x57\x18\xd2\xd9\x31\xaf\xb3\x5a\xc1\xa7\xaf\x5f\x1a\x8d\x3c\x12\xd3\x0e\xdc\xf5\x7e\x80\xac\x3f\xec\x22\x26\x0a\x51\xaf\x6d\x2e\xd1\xee\x2e\x66\x27\x9e\x8d\x2f\xe0\x1e\x81\x74\x74\x29\x98\x5e\x0e\xe8\x97\xe1\xdd\xde\xf7\x2f\xb9\x58\xff\x72\x96\xec\x45\xde\xaa\xa9\x86\x93\xbb\x17\xe0\x4c\x8d\xb3\xff\x1d\x00\x2e\xb3\x7c\x6b\x81\x5c\x00\x00"),
 		},
 		"/control-plane/crds/kuma.io_meshes.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_meshes.yaml",
-			modTime:          time.Date(2019, 12, 3, 12, 0, 22, 232161565, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23747,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\xeb\x73\xdb\x38\x92\xff\x9e\xbf\xa2\xcb\xfb\xc1\x49\x95\x24\x27\x93\xdd\xab\x5b\x7f\xf3\x39\xc9\x9c\x6f\xf2\xaa\xd8\x99\xab\xab\xcd\xd6\x15\x44\xb6\x24\xac\x29\x80\x03\x80\xb6\x35\x7f\xfd\x55\x77\x03\x7c\x88\x0f\xc9\x89\x67\xea\xf8\xcd\x32\xd9\x04\xfa\xf9\xeb\x07\xf8\x6c\x3e\x9f\x3f\x53\xa5\xfe\x15\x9d\xd7\xd6\x9c\x83\x2a\x35\x3e\x04\x34\xf4\x97\x5f\xdc\xfe\xbb\x5f\x68\x7b\x76\xf7\x6a\x89\x41\xbd\x7a\x76\xab\x4d\x7e\x0e\x97\x95\x0f\x76\xfb\x05\xbd\xad\x5c\x86\x6f\x70\xa5\x8d\x0e\xda\x9a\x67\x5b\x0c\x2a\x57\x41\x9d\x3f\x03\xc8\x1c\x2a\xfa\xf1\x46\x6f\xd1\x07\xb5\x2d\xcf\xc1\x54\x45\xf1\x0c\xc0\xa8\x2d\x9e\xc3\x16\xfd\x06\xfd\xe2\xb6\xda\xaa\x85\xb6\xcf\x7c\x89\x19\x3d\xb6\x76\xb6\x2a\xcf\x21\xfd\x2c\x77\x7b\xfa\x0f\x80\xbc\xfd\x03\xfa\x0d\xff\x59\x16\x95\x53\x45\xa2\xf4\x0c\xc0\x67\xb6\xc4\x73\xb8\x2c\x2a\x1f\xd0\x3d\x03\xb8\x53\x85\xce\x79\x15\x42\xc0\x96\x68\x2e\x3e\x5f\xfd\xfa\xfa\x3a\xdb\xe0\x56\xc9\x8f\x00\x39\xfa\xcc\xe9\x92\xef\x63\xf2\xa0\x3d\x84\x0d\x82\xdc\x06\x2b\xeb\xf8\x4f\x79\x11\x5c\x7c\xbe\x8a\x0f\x96\xce\x96\xe8\x82\x4e\x0b\xa4\xab\xc5\xcb\xfa\xb7\xbd\x57\x9c\xd2\x1a\xe4\x1e\xc8\x89\x7b\x28\xaf\xbb\x93\xdf\x30\x07\x2f\x2f\xb6\x2b\x08\x1b\xed\xc1\x61\xe9\xd0\xa3\x09\xbc\x97\x16\x59\xa0\x5b\x94\x01\xbb\xfc\x17\x66\x61\x01\xd7\xe8\x88\x08\xf8\x8d\xad\x8a\x1c\x32\x6b\xee\xd0\x05\x70\x98\xd9\xb5\xd1\xbf\xd7\x94\x3d\x04\xcb\xaf\x2c\x54\x40\x1f\x3a\x14\xb5\x09\xe8\x8c\x2a\x88\x7b\x15\xce\x40\x99\x1c\xb6\x6a\x07\x0e\xe9\x1d\x50\x99\x16\x35\xbe\xc5\x2f\xe0\x83\x75\x08\xda\xac\xec\x39\x6c\x42\x28\xfd\xf9\xd9\xd9\x5a\x87\xa4\x3d\x99\xdd\x6e\x2b\xa3\xc3\xee\x2c\xb3\x26\x38\xbd\xac\x82\x75\xfe\x2c\xc7\x3b\x2c\xce\x54\xa9\xe7\xbc\x4e\x13\x58\xe3\xb6\xf9\x5f\x5c\xd4\x2c\x7f\xda\x5a\x58\xd8\x91\x6c\x7d\x70\xda\xac\xeb\x9f\x59\x23\x46\xd9\xfc\x8b\x36\x39\x89\x52\xc5\xc7\x64\xb9\x0d\x37\xe9\x27\x62\xc2\x97\xb7\xd7\x37\x90\x5e\xca\x1c\xef\xb2\x98\x99\xdb\x3c\xe6\x1b\x3e\x13\x5f\xb4\x59\xa1\x13\x39\xad\x9c\xdd\x32\x45\x34\x79\x69\xb5\x09\xfc\x47\x56\x68\x34\x5d\x1e\xfb\x6a\xb9\xd5\x81\x04\xfb\x5b\x85\x3e\x90\x38\x16\x70\xa9\x8c\xb1\x01\x96\x08\x55\x99\xab\x80\xf9\x02\xae\x0c\x5c\xaa\x2d\x16\x97\xca\xe3\x53\x73\x99\x18\xea\xe7\xc4\xc1\xc3\x7c\x6e\x1b\x76\xba\x86\x94\x9f\x0d\x80\x76\xc1\x8a\xba\xf7\x0f\x00\x95\xe7\xec\x28\x54\xf1\x79\xe4\xe1\xd1\x15\x0c\x9a\x51\xf3\x26\x16\xb3\x81\xca\xf8\xe0\xaa\x2c\x54\x0e\x73\xb8\xc5\x5d\x94\xf8\x56\x95\xe0\x83\xa5\x1f\xef\x75\xd8\xf4\xde\xa8\xda\xd2\x57\x81\xc5\xba\x44\xf0\x18\x60\xb9\x03\x72\x87\x6c\x10\xc1\xda\x82\x2d\x87\x69\xb1\x61\x38\x0c\x4e\xe3\x1d\xf6\x49\xba\xa5\x0e\x4e\xb9\x5d\xcd\xbb\x05\xdc\x6c\x70\x07\xca\x21\x90\x98\x7f\xab\xd0\xed\xd4\xb2\x10\x3a\xd1\x60\x97\x08\xac\x64\xee\x0e\xf3\x1e\xc9\xfb\x0d\x1a\xd8\xda\x5c\xaf\x76\xa4\xb9\xa2\x96\x7d\xe3\x3b\x3f\x3b\xbb\xad\x96\xe8\x0c\x06\x64\xc5\xc8\x6d\xe6\xcf\x2a\x8f\x6e\xbe\xae\x74\x8e\x67\x2d\x01\x9d\x3e\x1b\x62\xbd\x50\xee\xfc\x2b\x13\x9f\xfa\x91\x5c\xf7\x94\x4c\x6e\x36\xc8\x1e\x5b\x5c\x17\xa6\xe7\xe0\x7e\xa3\xb3\x0d\xff\x12\xad\x69\x89\x85\x35\x6b\x51\xfc\x9b\x7d\x8b\xa3\x4b\x7b\xa8\x3c\xe6\xc4\xee\x5c\x7b\xb2\xd5\x4a\xfb\x4d\x2d\x28\xcf\x92\x04\x4f\xef\xe2\x17\x12\x17\x39\x56\x94\x2a\x23\x76\x40\xae\x57\x2b\x74\xfb\x96\xd7\xda\x8c\x97\x37\xc3\x4a\x63\xc1\x7e\x82\xc4\x42\x32\x57\x66\x77\xbf\x41\x87\xe0\xf4\x7a\x13\xc0\xd8\x7b\xa6\xae\x4a\xcd\x92\x71\x30\xb0\xdc\xb5\x65\x6f\x62\x41\xaf\x0d\xcb\x23\x80\x5e\x31\x35\x6d\x24\x16\x22\x58\x17\x2d\x3b\xd9\xfd\x62\x90\xfd\x03\x9a\xdf\x0f\xa6\x53\x42\x38\xb9\xdc\xbf\x5d\xbc\x60\xa8\xff\xec\xb9\x40\xd9\x58\xdf\x14\xf5\x16\x45\xef\xd8\xbf\x45\xd9\xdd\x2b\x1f\xb7\x44\x2e\x2a\x24\xd6\xad\x2b\xe5\x94\x09\x28\x42\x13\xfb\xe9\x8b\xd5\xc0\x46\x95\x25\x1a\x3f\x5f\xe2\x8a\x38\x65\x5d\x8e\x0e\x54\xe6\xac\xf7\xe0\xb1\x54\x8e\x79\x55\xa2\x13\x1d\x5d\xc0\x25\x3b\x50\xf1\xb6\xc6\xf6\x69\x12\x97\x79\x7d\x6c\xed\x69\x49\xf5\x1e\x31\xa7\xb7\x7e\x79\x77\xf9\xfa\xf5\xeb\xbf\x53\x30\xdf\xb2\x38\xb5\xa7\x9f\xbf\xde\x5c\x2e\xe0\x9b\xe9\xd1\xfc\x6c\xcb\x8a\x82\x63\x4e\x1e\x80\x39\xb4\xf3\x01\xb7\x0b\xf8\x82\x2a\x9f\x5b\x53\xec\x16\xf0\xb1\x2a\x0a\x06\x07\x85\xf6\x03\x86\xf8\x83\xfe\x39\xf9\x8d\x93\xbd\xb5\xd1\x06\x54\x38\x07\x52\xa4\x39\x09\xe8\x58\x25\xca\xb1\x40\xa2\xfe\xb3\x53\x19\x7e\x46\xa7\x6d\x7e\x8d\x99\x35\x79\xcf\x07\x77\xb4\xe9\x63\xb5\x5d\xa2\x23\x83\xf6\x72\x37\xa8\xa2\xb0\xf7\x98\x47\x5c\xd4\xe8\x45\xb0\xb0\x26\xda\xab\xaa\x28\x76\x7d\x5d\x42\xb7\xd5\x86\x64\x1b\x05\xaf\x03\xdc\xeb\xa2\x20\x4d\x71\xb8\xb5\x77\x44\x31\x05\xd0\xc4\xed\x4f\xa6\xd8\xb1\x7c\x49\x09\x7b\x24\xd3\x8e\xba\x7a\x5e\x78\x4b\x8f\x2c\xe0\x83\xda\x01\x49\x8a\x75\x71\x63\x5d\x40\x43\x1a\xdb\x48\x70\x84\xb3\xda\x84\x7f\xfb\xeb\x20\x57\x09\x1b\xad\xf7\xec\xa4\xb7\x88\x69\xdb\x7c\x33\xb4\xe6\x2f\xef\x2e\x81\xb5\x93\xbd\x03\x69\x27\x5b\x9e\x0a\xb5\xe3\x1c\x70\x39\x75\xcc\x4a\x5c\xe4\x95\xd0\x0e\xbb\x6e\x2d\x86\xb1\xc6\xcc\xc5\xa2\x55\x2d\xac\x51\xbe\x8a\x19\xb1\xab\x6a\x0c\x81\x22\xc9\x2c\x59\x10\xd9\x7d\xae\x1d\x66\x41\xe4\x14\x38\xa2\x2d\xfb\xd2\x57\x11\x06\x71\x14\x6c\x96\xae\x3d\xe0\x43\x89\x59\xa8\x9d\x46\xdc\x04\x3c\x37\x16\x28\x44\xa0\x83\x3b\xed\xf5\xb2\xe8\xc7\x58\xd6\x96\x9a\x14\x1b\xa1\x2c\x8c\x56\xe5\x50\x65\x9b\xb8\x1a\x0e\x0c\x2f\x40\xad\x02\x0a\x92\x67\xee\xea\xbe\x42\x85\x9a\x71\x33\xb0\x86\xe1\x00\xc2\x4a\x1b\x55\xe8\xdf\x09\xef\xd1\x3b\x78\xcd\xdb\x32\xec\x16\x70\xe1\x79\x89\xa0\xfc\xde\x8d\x3d\xc2\xfc\x20\xd9\xbd\xd2\x04\x56\x02\x6e\xfd\xac\xc3\xe6\x65\x61\xb3\x5b\x92\xdd\xa7\xf4\xda\x9e\x5e\x0d\x85\x48\x8f\x61\xd6\xf2\x7d\xc9\x45\x32\x88\x34\x24\x78\xeb\x12\x92\x59\x55\x2e\x6c\x28\x78\x99\x88\xfd\x57\x15\xe1\xa4\x59\x5f\x54\x45\xd8\xd8\x6a\xbd\x21\x03\x4d\x48\x28\x59\x0f\xc4\x54\xa8\xe6\x7a\xbc\x21\x49\xad\x74\xda\x0e\x84\x11\x2b\x6b\x24\xb6\x2f\xe0\x9d\x75\x80\x0f\x6a\x5b\x16\x94\x5d\xb0\x3e\xc5\x04\x83\x35\x4d\x20\x98\x82\xd2\xb2\x86\x45\xca\x43\x81\xe4\xf5\xcb\xe4\x92\x44\xab\x7e\xa9\x96\x74\xb3\xd8\x03\xc9\x9f\xf5\xde\xa3\xc9\x29\xcc\x35\xfa\x5e\xbb\xa2\xfd\x64\x8a\x2e\xaf\xd7\x82\xf5\x04\xbf\x88\xc8\x48\xf6\xda\xf0\x2f\xa5\xcd\x17\x70\x11\x35\x49\x85\xd6\x22\x66\xfc\xff\xb8\x88\x3e\x7a\xa3\x45\xd1\x5a\x40\xc1\x46\xb9\xbc\xbd\x88\xf4\xd2\xe7\xd7\x57\x3f\xff\x72\xf5\xfe\xfd\x8b\xde\xeb\x49\xad\xfb\x82\xe2\x55\x64\x05\x2a\x53\x95\xb3\xe8\x44\xd3\x22\x1b\x5f\x7a\xf1\xf9\x8a\x33\x09\xfe\x07\x87\xc4\x8c\xf1\x99\xc1\x70\x6f\xdd\x6d\x8f\x6c\xa9\x5c\x60\x98\xee\x67\x1d\xf7\x4e\x32\xf2\x81\xb6\x81\x0f\xa4\xce\xc9\x9c\xa2\x60\x59\x47\x67\x50\x99\xa0\xfb\x1e\x45\x19\x50\xf9\x56\x1b\xed\x83\x53\xc1\x3a\xd2\x23\x55\x05\xbb\x55\xa2\x35\x36\x43\xef\x21\x53\x94\x10\x0b\x63\xb0\xab\x67\x03\xfe\x8f\xc3\x4c\x13\x56\x08\x8b\xac\x12\x86\x9b\x35\xc2\xae\xad\x2c\x42\xd2\xb8\x9b\x8d\xea\x53\x14\xcb\x41\xd3\x38\x3d\xc2\x06\x63\x58\x60\xdf\x8d\xd6\x6f\x1a\x32\xd4\x16\xc5\x16\x82\xf8\x7f\x8e\x18\x1a\x87\x36\x19\xd3\x3e\x54\x9e\x3d\x0e\x7b\xc5\x14\xdd\x5b\xac\x6e\xac\xb8\x51\x4a\x87\x6b\xd2\x85\x5e\x0c\x06\x78\xab\xb2\x0d\xa0\x09\x6e\x17\x93\x3a\x9d\xd3\x1e\x57\x1a\x5d\x5d\x89\x71\xe8\x4b\x6b\x38\x2a\x40\x66\xb7\xa5\x35\x68\xa2\xe3\x20\x3b\x1b\x08\x95\xb5\x69\x08\xe5\x7a\x1d\xe4\x98\x59\x71\x06\x5d\x6e\x57\x67\x86\xe4\x6a\xac\x99\x1b\x5d\xcc\x98\xae\xc6\xe8\x26\x74\x0c\x15\xa4\xd0\x09\x81\x44\x8c\xb3\xbf\x61\x8e\x05\x8f\x4a\x82\xe5\x5f\xca\x39\xd5\x0d\xb3\x6b\x34\x84\x99\xf1\x60\x92\x76\xf2\x73\xeb\xce\xc8\x64\x5b\x4a\x62\x4e\x1e\x62\xa5\x1f\x66\x92\x7c\x75\x60\x43\x3f\x52\x10\xe0\x8b\xa4\xc8\x91\x1b\xfd\x5b\x15\xb3\xb1\x4f\x1f\xdf\xff\x0f\x5c\xbd\xe3\xa7\xf9\x2d\x82\x46\x36\xca\x37\x46\x56\x3a\x7b\xa7\xf3\x3e\x47\x40\xc4\xd1\x86\x30\xb4\x18\x71\xaf\x4c\xdd\x61\xa8\x9c\x11\xc8\xd0\x54\x58\x1a\x1c\x34\x9a\xf9\x85\x8d\x32\x0d\x99\x52\x79\x5f\xc3\x25\x89\x9f\x4c\x82\x11\xe4\x92\x35\x6b\xa9\x4d\x2c\x1a\xd4\x1b\xec\x47\x8c\x6a\xb5\xd2\x0f\x12\x82\xd2\x9e\x22\xb9\x4d\x44\x06\x9c\xa6\x36\xd5\x48\x70\x55\x81\x3e\xc1\x06\xe2\x4f\xdf\xb9\x09\x08\x49\xc5\xb7\x25\x42\x70\x95\xc9\xda\x5e\xa8\x40\xb3\x0e\x9b\xa4\xa2\xb2\x0a\xf6\x33\xda\x31\x6b\x7a\x34\xb7\xea\x56\x6c\x40\x16\x17\xe5\x65\x4d\x4b\xc6\xec\xef\x7a\xec\xf7\x25\x66\x64\x80\x03\x21\x88\xa0\xea\x06\x6b\x35\x90\x1c\x5c\x02\x44\x0c\x88\x09\x73\x12\x67\x3f\x7e\xba\x89\xc2\x03\x05\x7f\x7d\xf9\x77\x98\x0f\xc4\x75\x1f\x50\xe5\xb3\x3a\x3d\x40\xcd\xb0\x25\x3e\xf6\xd3\xcb\x57\x70\x29\xb9\x27\xc5\x90\xbf\xbd\x7c\x29\xd2\xf9\x82\xca\x5b\x13\x0b\x73\x64\xbf\xb6\x1a\x4a\x3e\x73\x9d\xa9\x20\x68\xa0\xad\xae\x19\x57\x5f\x22\x70\x5a\xd9\xca\xe4\x29\xdc\x0b\x0e\x2f\x0a\x1b\x02\xe6\x03\x58\x29\xee\x3f\x6a\x60\x2c\xe3\x38\x24\x1f\xf3\x3c\xd9\x54\xb1\xeb\x43\x4f\x5e\x08\x67\xa6\x03\x4a\x8a\xf0\x85\x28\xcc\x05\x66\x6c\x50\xe5\xe8\x5e\xb0\x68\x2e\xca\xb2\xd0\xb4\x75\x72\x2a\x7a\x05\xc9\x82\x39\xec\x25\x29\xf5\x0d\xea\x69\xe3\x8c\xce\x71\x5b\xda\x80\x26\xdb\xed\x87\x9a\x51\xb7\x15\x15\x64\xaf\x2c\x0e\xfb\xae\xe9\x02\x3c\x05\x4a\x42\x28\x46\xf2\xce\x4e\xa9\x42\xa5\x4d\x66\x2d\x82\x60\x57\x83\x3c\xcc\xd1\xb3\x25\xf8\xa0\x02\x2e\x8e\xc9\xe8\x9f\x24\x1f\xe4\x66\xc8\x31\x61\xf3\xe4\xc2\xb4\x6f\x96\x1a\x0d\x4b\xc0\x16\x45\x5d\x33\x43\xb3\xb2\x5c\xef\xf2\x76\x9b\xd6\x3c\xa0\xd8\x77\xca\x69\x65\x02\xa5\x8c\x31\xea\xa6\x9a\x51\x44\xdd\xdd\x9c\x50\x49\x7c\xb2\xab\xce\x72\x87\xfc\x25\x21\xa5\x3b\x29\x59\xee\x30\x80\xe2\x54\xcd\x76\x0a\x42\x02\xbc\x74\x41\x06\xc9\x18\xa0\x83\x1b\x7b\x44\xc9\x29\x72\x00\xa0\xc8\x4d\xb0\x80\x54\xb9\x5e\x05\xa5\x40\x64\xf0\xf7\xda\xe3\x6c\x0f\x45\x64\x14\xf3\x73\x74\x03\x8e\xa8\x32\x2d\x12\x29\x3b\xdd\xe8\x3c\x47\x03\xcf\xb5\xe1\xed\x9e\xdd\xab\x90\x6d\xf8\x9f\x6b\xa4\xe0\x5c\x14\xfe\x85\x40\x01\xb1\xdf\x09\x06\x98\xd3\x40\x99\x6a\xa1\x33\x4d\xa9\xae\xf2\xb7\x12\x7e\xec\x92\xfd\xdb\xde\xfb\xeb\xda\xec\x40\x65\xe9\xbf\x19\x35\x9a\xf6\xb6\xc4\x9f\xcd\x3a\xd8\x92\x5c\x5f\x19\x55\xb6\x85\x28\x06\xeb\xd7\xec\x81\x2a\xe7\xd8\x05\x61\x4f\xac\xb1\x8c\x52\x3a\x7d\xa7\x0b\x5c\x63\xce\x39\x97\xd4\xd3\x24\x47\xec\x87\x0a\x2e\x33\x37\xef\x8d\x79\xa9\x6e\xb2\xdf\x59\x4a\x0f\xa3\xd7\xe4\x27\xc8\x35\xc5\x3c\xb3\x47\x72\xb9\x03\x65\x76\xfc\x6a\x76\x65\x6f\xde\x7e\xfe\xf2\xf6\xf2\xe2\xe6\xed\x1b\x98\x77\x96\xcb\x25\x72\x4a\x18\x8a\x72\xa3\xa2\xca\x92\xcc\x06\x91\x5d\xab\x78\xa4\x0d\xdc\xbd\x5a\xbc\xfa\xdb\x62\xdf\x29\x8d\x75\x2a\xf8\x7f\x92\x1d\xf6\xff\xb1\x67\xac\x9f\x63\x16\x39\x6a\x3b\xb1\x73\x40\x50\x18\x1f\x30\xab\x42\x3f\xa6\x83\xa4\xad\x52\xf0\xac\x61\x72\x93\x60\x11\x0a\x91\x52\xc7\x42\xb4\x44\x3a\x74\x3e\xa4\x55\x8e\x50\xec\xb8\x90\xc8\x8d\x54\x08\x81\x95\xd2\x05\x2d\xdc\xa1\xaf\x8a\xd0\xaa\x19\xe0\xb4\xe9\xd3\x25\xcd\x94\x1a\x57\x71\x9d\xd5\xb2\xa5\xa7\xb8\x37\x64\x9b\x84\x6b\x5a\xc6\x30\x48\x99\x9e\x8f\x7b\x25\x92\xaa\x28\x92\x09\xf6\x83\xd7\x28\x46\x3e\x24\x5b\xb9\xcc\x00\x1c\x6e\xae\x8e\x90\xdb\x9d\x8b\x94\x93\xb2\x58\x99\xaf\x4d\xca\x41\x69\x48\xbd\xc3\x31\xb9\xc8\xd5\x76\x93\xa3\xb7\x4d\x80\x7d\xb9\x12\xaa\x1b\xde\xc7\x9c\x17\x3e\xf8\xaf\xd1\x86\x4e\xfb\xdf\xfd\x54\x42\xde\x49\x0a\x73\xd0\x30\xae\x56\x5d\xd5\x12\x38\x46\x1c\x7c\xa7\x74\x51\x39\x4c\x50\x76\x22\x8f\x82\x54\x1f\x59\x22\x94\xe8\xbc\xf6\xb1\x1e\xe8\x83\x75\x6a\x8d\x49\xdd\x4c\xca\x23\x29\xdd\xf2\x95\x93\xee\x05\x85\xbc\x41\x8f\x03\xdc\xeb\x91\xde\x01\x67\x62\xd1\x57\xb7\x53\xbd\x21\xa1\x1c\xd2\xa9\xe1\x16\xff\x28\x87\x1e\xdb\xee\x1f\x55\x93\xee\x18\xc0\x63\x5b\xff\xa3\x64\x07\x47\x02\x1e\x33\x06\x30\x4a\xf9\x4f\x1c\x0f\x68\x5f\x07\xcd\x29\xb3\xf9\xa8\x4b\xe8\x88\xee\xba\x5a\xaf\xa5\xf8\xfd\x9f\x37\x37\x9f\x53\x0e\x42\x8f\x37\xcd\x0f\x82\x97\x95\x9f\xc1\x4b\xd0\x7d\x1c\x9a\xae\x58\x96\x1a\x73\x01\x2d\xa4\xf9\xfa\xa7\xc9\x5d\x0d\x21\xce\x66\xe9\x41\xe9\x62\xd4\x11\x76\x76\xf6\xf6\x21\xa0\xa1\x44\x35\x57\x41\x81\xf2\xde\x66\x9a\xc1\x71\x6d\xbe\x8e\x33\xaa\x85\x14\x64\x26\x74\x92\xf3\x2e\xd2\x0c\xd1\x6d\xd0\xc1\x83\xbd\x37\xdc\x36\x97\x37\xc8\xb2\xf6\x20\xe8\x28\xc5\xba\x12\x91\x62\x0c\xaf\xb0\x4e\xf9\x07\x9b\x8d\x99\x25\x94\xdc\xc7\xc5\x35\xef\x2c\x63\x8f\x68\x67\xf8\x90\x61\x19\xcb\x45\xb2\xe8\x3a\x27\x88\xdb\x21\x5e\x8f\xc9\xea\x70\xc4\x01\xc8\x54\xe5\xa7\xfe\x3f\xd0\x35\xbf\xe4\x47\xc4\x17\x83\x36\x59\x51\xe5\xe8\x61\x4b\x96\x13\x19\xd8\x92\xd2\x04\x61\x68\x24\x78\xcd\x9a\x19\x33\xe3\x95\x78\xe3\x05\x7c\xb4\x81\xe3\x6d\xfb\xbf\x8c\x05\x27\x89\xc6\xc2\x46\x5c\x0b\xe6\x71\x8b\xe3\x31\x6d\x32\x6a\xb7\xa8\x1e\xe4\xa5\x5c\xac\x36\x87\x6e\xda\x4f\xb0\x6e\x36\xa9\xf0\x14\x83\x7a\x77\xcc\x83\x12\x11\xde\xc6\x34\x3f\xe5\x62\x5b\x47\xe7\xac\x9b\x11\xc0\xa1\x88\xcb\x5a\x43\xea\xfe\x5f\xd7\x9f\x3e\x82\x47\xc7\x78\x40\x8d\x85\x95\xfd\xeb\x43\x23\x68\xc8\x49\x28\x26\x87\xd2\xfa\xb0\xd2\x0f\x90\x26\x34\xd8\xcd\x18\x76\x41\x47\x50\x54\x41\xdc\x27\xf9\xdc\x0b\x52\x24\xc1\xd2\xbf\xa3\xb3\x73\x6d\x72\x7c\xa0\xec\x0a\xde\x11\x47\x0e\x4b\x3c\x92\x2c\x4b\x54\x4e\xf4\x90\xab\x67\xdc\x16\xd3\x9c\xc1\x88\xae\xda\x55\xd4\x05\xc8\x07\x8a\x63\x03\x8c\xb4\x22\x13\x4f\x79\x15\x45\xf0\x6d\x55\x04\x5d\x16\x28\xdc\xa5\x6c\x25\x7a\x00\x4e\x13\xde\x4a\xa7\xe8\xa0\x82\xd0\xf5\x0d\xe0\xdb\x09\x49\xe6\xdb\x09\xcc\x63\x4b\x8e\xa4\x5f\xff\x18\x6b\x5d\x31\x57\x3a\x82\x62\xad\x30\x44\x99\x15\xfa\x1f\x2f\xff\xb9\x98\x78\xc5\x11\x34\xe3\x22\x56\xda\xf9\x10\x79\x18\xcb\xdd\x26\xbd\xe4\xdb\xc9\x61\x42\x07\xa3\x5c\x73\x6d\xd1\x7b\xb5\x9e\x40\xc1\xe9\xda\xab\xc5\x6c\xaa\xad\x32\x73\x87\x2a\xe7\x46\x6a\xeb\xbf\xf5\x7c\x0f\x49\xfe\x98\x3d\xcb\xed\x2c\xe1\x05\xb4\x23\x41\xac\x6e\x36\xb3\x1a\xca\xcf\x27\xa2\x43\x6b\xff\x96\xe7\xb6\x54\x8e\xee\xb0\xb5\x3d\x82\x59\x12\x02\x1e\xcd\xab\xad\xca\x36\xda\xe0\x14\xb7\x8e\xd8\x14\xf3\x73\x8f\x5b\xa9\x1c\x2b\x55\xdb\x94\x7f\xd3\x1d\xee\x18\x92\x1c\x30\x19\x7d\x11\xc6\xa0\xd5\xa8\x3b\xa5\x0b\x5a\xe3\x13\xf2\xed\x40\xa2\xd1\xbd\x6d\x38\xe1\x48\x97\x8c\x00\x3f\x26\x76\xf2\x13\x8d\xf7\xeb\x79\xfb\xc7\x06\x4e\x81\x74\x9d\x08\x39\xc5\xaa\xa3\x98\xb4\x3f\xaa\x3a\xb9\xa9\x53\xda\x15\x3d\xf1\x07\x6f\x0a\x3e\x19\xa9\x2b\x36\xe3\x56\x02\xe5\xb8\x83\x32\x49\xb7\xd5\xc9\x4b\x03\x22\xf5\xd2\x7e\xd1\x26\xff\x93\xc6\x55\xbf\x4b\x16\xd3\x25\x81\xb1\x91\xc6\x3f\x54\x14\xf0\x3c\x8e\xd9\xa1\xc3\x38\xb3\xac\xcd\xba\xc0\xf1\xd4\xbe\xa6\xca\x65\x62\xca\x6f\x97\xc9\xe9\x2c\x31\x7f\xf1\xc3\x0a\xcb\x4d\x0c\xee\x40\x8c\x4c\x89\x8d\x72\xec\x6a\xd5\xf4\x22\x66\xed\xa6\x47\x3d\x41\xd6\xf4\x88\x27\xb7\x56\x6b\x65\x6b\x3e\x56\x26\x6e\xf3\x05\x5c\x93\xde\x0a\x64\x88\x73\xd8\xd2\x53\x99\x76\x53\x4d\xaf\x86\x4b\x75\x41\xdd\xc6\x5a\x23\x67\xbb\x01\x41\x65\xfc\xc2\x79\x4c\xf0\xac\x4f\x2f\x39\x40\xb7\x13\xd0\xd2\x5a\x60\x63\xef\x65\x44\x28\x58\xb8\x57\x3a\xd4\x3b\x57\xb7\x07\x3d\xea\x06\x7b\xcb\x9a\x12\xea\x31\x39\x24\x1c\x95\x47\xd2\x55\xe9\x47\x78\xab\xaf\x57\x6f\xf6\x6d\x62\x31\xa6\xd0\x93\x7b\x6e\x46\xda\x46\x94\xfa\xd1\xc3\xce\xcd\xf0\x80\xff\x4b\xa5\x7f\xd8\x77\x1c\x0c\x73\x53\x6e\xfe\x09\x4e\x27\x8c\x67\xb8\xad\x3a\xf2\xf7\x9c\x54\x98\x20\xdc\x74\x37\xbf\xe7\xd4\xc2\x28\xe1\x3f\x3d\x3c\x1c\x14\xef\x01\x98\xfc\x68\x70\x1c\xdd\xfc\xa1\xb2\x5e\xed\xe5\xc6\x78\x75\xc4\xc2\xfb\xc7\x33\x46\x57\x7e\x7a\x1d\x94\xc9\x95\xcb\xa5\x8d\xd1\x1c\x4f\xf8\xd3\x05\x72\x54\x25\xc5\x92\x25\x54\xc7\x87\xeb\xf4\x40\xfb\x10\x87\x5e\xd5\x93\xab\x32\xe0\x0f\x85\xde\xea\xe9\xfc\x2f\x66\x69\xa6\x9e\x7e\xe6\xc4\xac\xae\x43\xc5\x09\xd8\xe8\xe7\x63\x9b\xe0\x50\x3c\x8b\xa3\x10\x1b\x95\x0a\x3b\x5c\x7b\xab\xd1\x38\x43\x8d\x1a\xe5\xdb\x52\xfd\x56\xe1\xe0\xe0\x5f\xfb\x8a\xdb\x4c\x67\x25\xb4\xf7\xfc\x90\x8d\x43\x13\x71\xa4\xd2\xee\x1f\x4b\x52\xd3\xbb\x97\x23\x28\xad\xbe\x63\xb0\xf5\x59\x17\xe1\x0b\x3e\xd4\xbd\xc6\x7a\x07\xd3\x0c\x4d\x3d\xd1\x4b\x91\x90\xf4\xf3\xb9\x6d\xe4\x03\xb9\x17\x51\xc7\xa6\xa3\x58\x5a\x3f\x3c\xf7\xdb\xbe\xa2\x68\x23\x67\x33\x6b\x56\x7a\x5d\x45\xd0\xc0\xf5\x9d\x8d\x32\x6b\x99\x15\x69\x6a\x18\x6a\x1a\xd9\xe2\x3d\x6c\xb5\xa9\x48\xac\xdc\xfb\x6e\xe6\x84\x9a\xf8\x96\x0a\xfa\x12\xf3\x93\x56\x1c\x00\x6a\x68\xa0\xf2\xe2\xd7\xa5\x63\x26\x9a\xda\x1a\x3d\x5a\x62\x1c\x77\xcb\xea\x19\xd4\x49\x9a\x51\x5b\xda\x15\x85\xd8\xa8\xc2\x19\x54\xa6\x40\xef\x61\x67\x2b\xd9\x87\xc3\x0c\xf5\xd0\xc9\xa2\xf6\x25\xf3\x9c\xf6\x16\x8d\x04\x09\x65\x04\xff\x24\xef\xf8\x04\xb8\xb2\xc3\xc1\xe3\x51\xc6\x75\x68\x1a\x3e\x75\x58\xf7\x2d\xf1\x9f\x9e\xfa\xba\x6d\x31\xcd\xb5\x28\xbc\x74\xbe\x32\x9d\x5f\x20\xca\x11\x73\xa4\xf1\xb7\xd4\x3f\x1a\x18\xa7\xea\xae\x34\x4d\xad\xb2\x94\xa3\xae\x0b\xdb\xa3\x0a\x2e\xe0\x57\x19\xd1\x8e\xd3\x92\x41\xba\xfe\x93\x64\x55\xed\x06\x5a\x4b\xe1\x3a\x21\xab\x24\x54\xa6\x6e\xbb\x2f\x55\x76\x7b\x8c\xc6\xa4\x39\xaf\x63\x0e\xb8\x34\x11\x61\x92\xe4\x13\x44\x8b\xcc\x1a\x29\xca\x65\xbb\x79\x1c\x81\x99\x2b\x93\xcf\x6b\xf7\x90\xed\x7e\x38\xeb\xf3\x58\xac\xde\x6b\x73\x7b\xb4\xc6\xa5\x07\x04\xa5\x7d\xfd\xf2\x7e\x1f\x9c\x1d\xd1\xda\x85\xe3\xce\x12\xfd\xc1\xa8\x74\xba\xa6\xf5\xc8\x4a\xd6\xfd\x26\x0e\x86\xd4\xc0\x65\x74\xf5\xba\x1e\x9b\x3f\x89\xdd\xe0\x93\x88\x8a\xa6\xcb\x5a\x53\xfd\xa1\xd1\x62\x16\x5c\xa4\x29\xc0\xac\x50\x4e\x9c\x83\x32\xd2\xb9\x93\x97\x4e\xa0\x8c\x1c\x61\x59\x05\xc8\x2d\x4a\x7f\xc9\xde\xa1\x73\x3a\x47\xd0\xa3\xc2\x3d\x28\x18\x79\xe9\xd1\xa0\xac\xc6\x8a\xad\x72\xcc\x02\x3e\x19\x04\xbb\x3a\x87\x93\xeb\x2a\xcb\xd0\xfb\x93\xa1\x71\x9d\x74\xd5\x5c\x7e\x6a\x34\x47\xf9\x3c\x1b\xa4\xec\xe9\x3b\x21\xf6\x84\x9e\x8e\x4d\x38\xcc\x47\x66\x5f\x46\x49\x15\x6a\x89\xfd\x1e\xe8\x13\x9f\x3c\xfe\xa0\x78\x34\x3c\x26\x6e\xb7\xb8\x13\xaf\x2c\xfd\xee\x7e\x1c\x09\x16\xac\x5b\x2b\xa3\x7f\x1f\x38\x28\x6c\x72\x20\x08\xb9\xb6\x4e\xff\x8e\xf0\x9c\x3f\x62\x20\x67\x82\xb1\xc0\x2c\xbc\x68\x1d\xf4\x55\x3b\xd8\xf2\x08\x9b\xfc\xcb\x3a\x3f\x34\xfb\xe8\xb0\x2c\x78\xcc\x95\x2c\xa1\x1e\x27\xf4\x91\xa6\xbb\xd3\xd9\x40\x4f\xfe\x60\x22\x2d\x7c\x3d\xfa\xc0\xf0\x56\x19\xb5\xc6\x5c\x7a\x4d\xd3\x63\x90\x1f\xda\xb7\xc2\x56\x95\x1e\xee\xad\xbb\x5d\x15\xf6\x7e\xae\x65\xf4\x2b\x05\xec\x88\x63\x87\x0e\x96\xda\x55\x6a\x2b\xc9\xf9\x21\x87\x69\x0d\xe2\x75\x55\xa8\xa9\xc6\x4e\xb4\x26\x14\xee\x43\xb1\x8b\xf3\x3c\x23\xc0\x61\x63\x2b\x8f\xb7\x88\xa5\x36\x6b\x41\xfd\x32\x3d\x17\x76\x25\xa1\xb4\x62\x17\x8b\x53\xe6\x34\x80\x89\xfd\xe8\x78\xf2\xaa\x32\x39\x3a\x1f\x86\x20\x7c\x53\x30\x22\xbf\x95\x56\x96\xb4\x26\x65\x2b\xa7\xd2\x68\x9c\x75\x06\x43\xd3\x8f\x7d\x16\xb8\x66\xb6\x9d\x60\x79\x33\x2c\xab\xca\xb2\xd8\x41\xa9\xc2\x06\x0a\x7d\x8b\xf0\xed\x24\xd3\xf3\x2c\xff\x76\x22\xa0\x36\xe2\x78\xe1\x5f\x8f\x2c\x9f\xa9\xbc\x57\xbb\xda\x97\xd7\xd2\x88\x39\x4f\xb3\x7c\xd6\xf6\xbd\x73\xea\x43\x80\x24\x0d\xad\x7c\x33\xfb\x73\xa9\x3c\xf3\x27\x36\xc1\x9c\x68\xe1\xf7\x34\xe7\x77\xaf\xc3\x66\x68\xba\xdb\xd8\xa0\x33\xec\x4d\xff\x8d\xb4\xa1\xa7\x93\xcf\x43\x23\x3e\xdd\x90\x39\x39\xdf\xd3\xfa\x8a\x47\xab\xf9\x3c\xe6\x40\x1b\x6e\x70\xa2\xca\xe3\xde\xe9\x94\x3c\xc6\x1a\x1f\x31\xea\x84\x7b\x1e\x67\xf1\x1d\x27\xf0\xaf\xca\x8f\xd1\x64\x89\x73\x15\xd6\x96\xf3\x82\x3c\x7c\x7b\xc5\x51\x07\xe3\x31\x6e\xa4\x10\xa3\xdc\x8e\x2d\xcd\xa9\xac\x7f\x3a\x2c\xad\xb3\xb3\x3f\xd5\x5a\xf3\x12\xa5\x89\xa5\xd9\x07\xc6\x5c\x2e\x9e\xf5\x12\x83\x19\xa1\x19\x47\x96\x86\xe6\xd7\xe1\x70\x6c\x59\x0d\x7a\x1a\xb9\x06\xbd\x3f\x04\x37\xd2\xaf\xee\x08\x37\xba\xa5\x56\xc2\xa1\xba\xf6\x32\xb5\xda\x51\x48\x26\xae\xc9\x1d\xa1\x5c\xe2\x1d\x5d\xff\x2c\x54\x84\x0a\xb5\xed\x31\xc9\x09\x8c\xb8\x41\x8f\x47\x2c\x79\x94\xc1\x35\x26\x39\x62\xd1\x9f\xea\xc2\x7d\xfc\x92\x0e\xd1\xa6\x15\x37\x15\x7d\xa9\xf0\x16\xa8\x06\x8f\xaa\xa4\x35\x6b\x0f\x9d\xf0\xf0\x96\x1b\xe5\x4b\x24\xc7\x52\x7f\x82\x80\x2c\x83\x0f\x44\xf0\x09\x9b\x18\x85\x47\x48\xd6\x63\x5b\x71\xae\xd8\x21\x9c\x5e\x90\x77\x3c\x65\xaf\x73\xfa\x95\x8b\x98\xa7\xdf\xc5\xa1\xa0\xc7\xda\x4a\xdd\x86\x92\x96\x33\x1b\xa1\x7d\xca\x2c\x15\xcb\x6b\x19\xc1\x3d\xe1\xe0\x89\x99\xb1\xab\xfa\xb8\x49\xf4\xce\xf5\x09\x3c\xbd\xea\x0a\x20\x6e\x70\x90\xce\xa1\xb3\x81\x47\x6c\x7c\x42\xd5\xc7\xda\xbd\x43\x0d\xb8\x2e\xc2\xe2\x83\x2d\x29\x55\x8e\x47\x75\xc8\xf1\x6b\x03\xaa\xf9\xce\xc7\x02\xae\x7c\x73\xe4\x69\xf0\x1b\x01\x72\x0c\x42\x06\xa0\x65\x6c\x70\xd6\x9c\x70\xe6\xde\x67\xf3\x49\x91\xad\xda\xc9\xc7\x0d\xea\xe3\xea\x43\xba\xd9\x9c\x53\xc6\xee\x29\x14\x6e\x24\x95\x14\x58\x9c\x56\x21\x75\x0d\xdb\x9e\x6f\x31\x7c\xd8\x4b\x7b\x28\x9d\xde\x2a\xa7\xf9\x28\x44\x9c\x9b\x23\x55\xad\x0f\x71\x34\x67\x6e\x04\x1c\x76\x2b\x5d\x79\xfd\x0d\xae\xbe\xb6\x0c\x14\xe8\x7f\xa4\x89\xc2\xbc\x1f\x86\x81\x03\xfa\x51\x4b\x6a\x1a\x02\x7e\xac\x3f\xdc\xd2\x0e\xa0\xf2\x4b\x94\x3a\xaa\x6c\x23\x1c\xed\x6a\x45\x7f\xc3\x17\x26\xda\x41\xeb\x73\x30\x1e\x48\x49\xee\x54\x21\x32\x65\xf2\xdf\x4e\x72\x5c\xa9\xaa\x08\xdf\x4e\x9a\x5b\x67\x94\x06\xf6\x48\xb6\x6f\x8d\x1e\x2d\x53\xc6\x1a\x2e\xd3\x75\xc7\x72\x9b\x01\xbb\x54\x04\x22\x1f\x93\x74\xb4\x6f\x3c\xf2\xa5\x14\x02\xfd\xb9\x8c\xb4\x34\xab\x9e\xb7\x0e\xeb\xd9\xce\x99\xbc\xa6\x37\x19\x5f\xd2\xa3\x9b\xaa\x89\xf1\x4b\x05\xdf\x4c\x7d\x4a\x57\xc1\x9b\x8f\xd7\xff\xfb\xfe\xe2\x3f\xde\xbe\x1f\xec\xde\x4c\x14\x7d\x8e\x52\x96\x7a\xfd\xfe\xe8\xc3\x61\xf6\xde\xa0\xfb\x82\x7c\x68\x33\xeb\x03\xb2\x8e\xae\xbc\x8f\x67\x2f\x12\x77\x73\x2c\xc5\x5c\x96\xbb\xde\x99\xa4\x8b\xf7\xef\x47\x19\x14\xb1\x2c\x17\x9d\xb9\x4c\xc7\x47\x92\xea\xf9\xf2\xce\xf7\x6e\x22\x2f\xd7\xca\x2d\xd5\x1a\x21\x23\x18\x9e\x0d\x02\x95\xab\xd5\xfe\x89\x8e\x56\x12\xd2\x06\xf1\x33\x99\x67\x57\xa6\x99\xfd\xaa\x8b\xed\xc3\xc2\x8c\x95\x7b\xdb\x14\x8f\x13\xa5\x7a\xae\xa0\x75\x78\xac\xc1\x63\x8c\xe4\x86\xec\xe4\x86\x2b\x2d\x0d\x46\x6b\xcf\xf8\x61\x0d\x27\x5a\x44\x8f\x3c\xba\xfc\xb4\xc8\xba\x0b\xa3\xc9\x92\xe4\x6c\xef\x77\x45\x68\xfe\xca\xc6\x27\xd2\xb6\xf4\x19\x96\x23\x16\x41\x32\x75\x15\xce\xe0\xe2\xe3\x9b\xd4\x6f\x60\x8d\xad\x8f\xf7\x9e\xac\xac\x43\x02\xe4\x26\x4f\x74\xc7\xe6\xf7\xea\x23\xf5\x51\x01\x1a\x62\x8d\x20\x7a\x87\xe5\x6f\x71\x37\x67\x37\x30\x42\x54\xbe\x47\xc6\x5f\x5e\x48\xa9\x46\xb4\xa5\xd6\x89\xa0\x05\xbc\x11\x1f\xc6\x93\xfe\x2b\x55\x78\x5c\xc0\xcd\x18\xf4\xaa\xbf\xa9\x94\x0e\x22\x4b\xf7\x8c\x12\x5c\x0f\x27\xb2\xc2\x13\x28\xd1\x6d\xb5\x6f\x8b\x87\xf7\xd2\x4f\x4d\xe5\xb2\xe9\x60\x1f\xfc\xf5\xa7\x9f\xe0\xf9\x57\x13\x0f\xd9\x70\x95\xf1\xad\x09\x3a\xec\x5e\xb4\xbe\x09\x24\x3d\x95\x29\x41\x2f\xad\x2d\x50\x0d\xd5\x1f\x1b\xad\x7d\x8c\x84\xf7\x98\xc7\x26\x57\x1f\x8c\x38\xc2\x22\x8e\x5b\xdb\xf8\x8c\xc0\xc0\x84\xc0\xbe\xda\xff\xd9\x6d\xda\x03\x16\x35\x3e\x4a\x35\x80\xe7\x0e\xed\xe5\xc7\x81\xc8\x51\x6b\x1e\x9d\x6d\x99\x98\x6a\x79\x8a\x15\x8f\xcf\x9f\x4c\x2e\x78\xfc\xf0\xd7\xbc\xe5\x4d\x07\xfe\x49\x52\x1d\xf8\x79\x70\xa2\x6c\x4e\x5c\x79\x0a\x68\x7f\xa0\xbd\xd7\x3b\x01\x1d\xfb\x5b\x82\x72\xb8\xa2\xd4\x8c\xaf\xc4\x53\x8a\xe9\x20\x52\x1d\x08\x86\xab\x69\x47\x75\xf1\x46\x3a\x75\x7d\xa8\xd3\xe9\xdc\x7d\x68\x35\xd9\x09\x7b\xd9\x32\xe8\xad\xf6\x41\x67\xd0\xea\x5c\xcd\xe2\x03\xfc\x0e\x9e\xd7\x1a\xff\x60\x80\x1c\x45\x6e\xd2\x61\x6b\xda\x5f\xa1\xb4\x2e\xd5\x18\xea\xe4\xa4\xfe\x0c\x5e\x8f\xa4\x0c\xb2\x51\xa2\x10\x13\xc8\x58\x86\x56\xed\x19\x82\x47\x76\x0c\x53\x97\x90\x3f\x59\xb9\x6d\x7d\x47\x4d\x52\x6c\xe2\x81\x92\x0f\x05\x65\x55\xa1\xdc\xc0\xca\x07\xd4\xb8\xde\xc9\xf8\x37\x75\x3a\xed\xc7\xe3\xfa\xa5\xa3\x3d\xd2\xa7\x76\x95\x47\xf4\x28\x8f\x46\xbc\x63\xbd\xc8\xee\xe9\xb3\xe3\xfb\x8f\x1d\x7e\x0e\x98\xc7\x11\x3d\xc7\xd1\xb5\x0e\xb8\xcb\xae\x15\x93\xa3\x8c\x59\x51\xcc\xd4\xb5\x89\x1f\xce\x30\x79\xcc\xe2\xc4\xbe\xf7\xbe\x18\x38\x80\x9f\x19\x33\x37\xa5\xf5\xe6\xbb\x22\xdd\x2f\xd8\x59\x03\x5e\xfa\x61\xab\xaa\x68\xb2\xe4\x01\xb5\x6b\x59\x55\xeb\x9b\x75\xe9\x13\x86\xc1\x26\x9b\xb5\x06\x3e\x7f\xbd\xe9\x7c\x77\xb2\xad\xa6\x3d\xba\xc7\x74\xcd\xbf\x2f\x44\x1c\xa9\x44\x83\xbe\x39\x7d\x46\x7b\xfa\xa6\x5e\x5f\x72\xe0\xb6\xbd\x9f\xa2\xf3\xe5\xa7\xe6\xf1\x13\xde\x77\xaf\xb8\x5c\xff\x8a\x9f\x90\x89\xa1\x56\x55\x35\x9e\xdd\x8d\xbf\x34\xef\x54\x59\x86\x65\xc0\xfc\xe3\xfe\x87\xbd\xe3\x99\x97\xf4\x59\x6f\xfe\x33\xb3\x46\x4a\xb7\xfe\x1c\xfe\xf1\xcf\x67\x11\xea\xe6\xbf\xa6\xd5\xd0\x8f\xff\x17\x00\x00\xff\xff\x83\x48\xc2\x78\xc3\x5c\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3c\xdb\x72\xdb\xca\x91\xef\xfc\x8a\x2e\xe6\x41\x76\x15\x49\xd9\xc7\xc9\xd6\x46\x6f\x5a\xd9\xce\x6a\x63\xcb\x2e\xcb\xce\xd6\x56\x94\xda\x1a\x02\x4d\x72\x22\x60\x06\x67\x66\x20\x99\xe7\xeb\xb7\xba\xe7\x02\x90\xb8\x10\xb2\x95\x64\x49\x3f\x58\x20\xd0\xd3\xd3\xf7\xdb\x60\xb6\x5c\x2e\x67\xa2\x92\x7f\x41\x63\xa5\x56\x17\x20\x2a\x89\xdf\x1d\x2a\xfa\xcb\xae\xee\xff\xdd\xae\xa4\x3e\x7f\x78\xbd\x46\x27\x5e\xcf\xee\xa5\xca\x2f\xe0\xaa\xb6\x4e\x97\x5f\xd0\xea\xda\x64\xf8\x16\x37\x52\x49\x27\xb5\x9a\x95\xe8\x44\x2e\x9c\xb8\x98\x01\x64\x06\x05\x5d\xfc\x2a\x4b\xb4\x4e\x94\xd5\x05\xa8\xba\x28\x66\x00\x4a\x94\x78\x01\x25\xda\x1d\xda\xd5\x7d\x5d\x8a\x95\xd4\x33\x5b\x61\x46\x8f\x6d\x8d\xae\xab\x0b\x88\x97\xfd\xdd\x96\x7e\x01\xf0\xab\x7f\x44\xbb\xe3\x3f\xab\xa2\x36\xa2\x88\x90\x66\x00\x36\xd3\x15\x5e\xc0\x55\x51\x5b\x87\x66\x06\xf0\x20\x0a\x99\x33\x16\x1e\x80\xae\x50\x5d\x7e\xbe\xfe\xcb\x9b\xdb\x6c\x87\x25\xa3\x49\x97\x73\xb4\x99\x91\x15\xdf\xc7\xe0\x41\x5a\x70\x3b\x04\x7f\x1b\x6c\xb4\xe1\x3f\xfd\x42\x70\xf9\xf9\x3a\x3c\x58\x19\x5d\xa1\x71\x32\x22\x48\xdf\x16\x2d\xd3\xb5\xa3\x25\xce\x08\x07\x7f\x0f\xe4\x44\x3d\xf4\xcb\x3d\xf8\x6b\x98\x83\xf5\x0b\xeb\x0d\xb8\x9d\xb4\x60\xb0\x32\x68\x51\x39\xde\x4b\x0b\x2c\x80\xde\x80\x50\xa0\xd7\x7f\xc7\xcc\xad\xe0\x16\x0d\x01\x01\xbb\xd3\x75\x91\x43\xa6\xd5\x03\x1a\x07\x06\x33\xbd\x55\xf2\xb7\x04\xd9\x82\xd3\xbc\x64\x21\x1c\x5a\x77\x00\x51\x2a\x87\x46\x89\x82\xa8\x57\xe3\x02\x84\xca\xa1\x14\x7b\x30\x48\x6b\x40\xad\x5a\xd0\xf8\x16\xbb\x82\x8f\xda\x20\x48\xb5\xd1\x17\xb0\x73\xae\xb2\x17\xe7\xe7\x5b\xe9\xa2\xf4\x64\xba\x2c\x6b\x25\xdd\xfe\x3c\xd3\xca\x19\xb9\xae\x9d\x36\xf6\x3c\xc7\x07\x2c\xce\x45\x25\x97\x8c\xa7\xa2\xbd\xd9\x55\x99\xff\xce\x04\xc9\xb2\x67\x2d\xc4\xdc\x9e\x78\x6b\x9d\x91\x6a\x9b\x2e\xb3\x44\x0c\x92\xf9\xcf\x52\xe5\xc4\x4a\x11\x1e\xf3\x3b\x6a\xa8\x49\x97\x88\x08\x5f\xde\xdd\x7e\x85\xb8\x28\x53\xbc\x05\x12\x02\x71\x9b\xc7\x6c\x43\x67\xa2\x8b\x54\x1b\x24\xf9\x90\x16\x36\x46\x97\x4c\x56\x54\x79\xa5\xa5\x72\xfc\x47\x56\x48\x54\x87\x34\xb6\xf5\xba\x94\x8e\x18\xfb\x6b\x8d\xd6\x11\x3b\x56\x70\x25\x94\xd2\x0e\xd6\x08\x75\x95\x0b\x87\xf9\x0a\xae\x15\x5c\x89\x12\x8b\x2b\x61\xf1\xb9\xa9\x4c\x04\xb5\x4b\xa2\xe0\x69\x3a\xb7\x15\x1b\x60\x58\xf8\xe9\xcb\xbb\x60\x41\x3d\xfa\x01\x40\xe4\x39\x1b\x0a\x51\x7c\x1e\x78\x78\x10\x83\x5e\x35\x6a\x56\x62\x36\x2b\xa8\x95\x75\xa6\xce\x5c\x6d\x30\x87\x7b\xdc\x07\x8e\x97\xa2\x02\xeb\x34\x5d\x7c\x94\x6e\xd7\x59\x51\xb4\xb9\x2f\x1c\x8b\xfb\x1a\xc1\xa2\x83\xf5\x1e\xc8\x1c\xb2\x42\x38\xad\x0b\x62\x95\x87\xc5\x8a\x61\xd0\x19\x89\x0f\xd8\x05\x69\xd6\xd2\x19\x61\xf6\x89\x76\x2b\xf8\xba\xc3\x3d\x08\x83\x40\x6c\xfe\xb5\x46\xb3\x17\xeb\xc2\xc3\x09\x0a\xbb\x46\x60\x4d\x37\x0f\x98\x77\x40\x3e\xee\x50\x41\xa9\x73\xb9\xd9\x93\xe4\x7a\xb1\xec\x2a\xdf\xc5\xf9\xf9\x7d\xbd\x46\xa3\xd0\x21\x1b\xef\x5c\x67\xf6\xbc\xb6\x68\x96\xdb\x5a\xe6\x78\xde\x62\xd0\xd9\xac\x8f\xf4\x1e\xf2\xc1\x4f\x99\xb7\xa9\x37\x64\xba\xc7\x78\xf2\x75\x87\x6c\xb1\xc9\x2e\x79\xd9\xe7\xe7\xe0\x71\x27\xb3\x1d\x6b\x43\xd0\xa6\x35\x16\x5a\x6d\x89\x9a\x44\x97\x23\x8d\xa3\x7f\xd2\x42\x6d\x31\x27\x72\xe7\xd2\x3a\xa9\xb6\xb5\xb4\xbb\xc4\x28\xcb\x9c\x04\x4b\x6b\xf1\x82\x44\x45\xfa\x8f\xad\x44\x46\xe4\x80\x5c\x6e\x36\x68\x8e\x35\xaf\xb5\x19\xeb\x57\x86\x8d\xc4\x82\xed\x04\xb1\x85\x78\x2e\xd4\xfe\x71\x87\x06\xc1\xc8\xed\xce\x81\xd2\x8f\xcc\x23\x51\x49\xcb\x7a\x0f\x3d\xe8\x6e\x35\xf1\xc4\x69\x90\x5b\xc5\xfc\x70\x20\x37\x2c\x41\x52\x79\x5f\x88\xa0\x4d\xd0\xec\xa8\xf7\xab\xd9\x44\xc9\xef\x3a\xd3\x31\x26\xcc\xaf\x8e\x6f\xa7\xdd\x09\x70\xe9\xcf\x8e\x09\xf4\x1b\x3b\x02\x0a\xfc\x84\x97\x3b\xb6\x6f\x81\x77\x8f\xc2\x86\x2d\x91\x89\x72\x91\x74\xdb\x5a\x18\xa1\x1c\x7a\xa6\x79\xfd\xe9\x40\x94\x0a\x76\xa2\xaa\x50\xd9\xe5\x1a\x37\x44\x29\x6d\x72\x34\x20\x32\xa3\xad\x05\x8b\x95\x30\x44\x21\x32\x0f\xbc\x07\xbb\x82\x2b\x36\xa0\xde\xda\x2a\xdd\x85\x49\x54\x66\xfc\x58\xdb\x23\x4a\x69\x8f\x98\x93\x38\x7c\x79\x7f\xf5\xe6\xcd\x9b\x3f\x92\x33\x2f\x99\x9d\xd2\xd2\xe5\x6f\x5f\xaf\x56\x70\xa7\x3a\x30\x3f\xeb\xaa\x26\xe7\x98\x93\x05\x20\xb9\xb5\x7b\xeb\xb0\x5c\xc1\x17\x14\xf9\x52\xab\x62\xbf\x82\x9b\xba\x28\x08\x1e\x14\xd2\x3a\xfb\xdc\xf6\x39\xda\x8d\xf9\x11\x6e\xb4\x01\xe1\x2e\x80\x5c\xc4\x92\x18\x34\x55\x88\x72\x2c\x90\x28\xfa\x27\x23\x32\xfc\x8c\x46\xea\xfc\x16\x33\xad\x72\x3b\x2a\x4d\x37\x75\xb9\x46\x43\x0a\x6d\xfd\xdd\x20\x8a\x42\x3f\x62\x1e\xe2\xa2\x46\x2e\x9c\x86\x2d\xc1\xde\xd4\x45\xb1\x3f\x02\x09\xe0\xd0\x94\x52\x11\x6f\x03\xe3\xa5\x83\x47\x59\x14\xe4\xf0\x0c\x96\xfa\x01\xf3\xc6\x81\x46\x6a\x7f\x52\xc5\x9e\xe4\x88\x85\xb0\x03\x32\xee\xe8\x50\xce\x0b\xab\xe9\x91\x15\x7c\x14\x7b\x20\x4e\xd1\x0a\x76\xa7\x8d\x43\x85\x79\x9b\x83\x03\x94\x95\xca\xfd\xdb\xef\x8f\x7e\xf3\x96\x91\x62\xa3\xed\x91\x9e\x74\x90\x18\xd7\xcd\xb7\x7d\x38\x7f\x79\x7f\x05\x2c\x9d\xc4\x54\x96\x4e\x62\x2c\x08\x97\x0c\x67\x8f\xc9\x49\x3e\x2b\x52\x91\x31\xc1\xfc\xd8\xac\x05\x37\xd6\xa8\x39\x13\x13\x44\x62\xd6\x20\x5d\x41\xa6\x10\xa5\x51\x04\xf2\x24\x8b\xa8\x41\xa4\xf7\xb9\x34\x98\x39\xcf\x27\xc7\x1e\x6d\xdd\xe5\xbe\x08\x61\x10\x21\x87\x8d\xbb\x95\x16\xf0\x7b\x85\x99\x4b\x46\x23\x6c\x02\x5e\x28\x0d\xe4\x22\xd0\xc0\x83\xb4\x72\x5d\x1c\xcb\x39\x78\x69\x49\xa0\x58\x09\x3d\x62\x84\x95\x41\x91\xed\x02\x36\xec\x92\x5e\x82\xd8\x90\x2b\xa2\x3d\x30\x75\x65\x57\xeb\x5d\x22\xdc\x02\xb4\xe2\x60\x10\x61\x23\x95\x28\xe4\x6f\x14\xef\xd1\x1a\x44\x14\x2c\x2b\xb7\x5f\xc1\xa5\x65\x14\x41\xd8\xa3\x1b\x3b\x80\xf9\x41\xd2\x7b\x21\x29\x58\x71\x58\xda\xc5\x01\x99\xd7\x85\xce\xee\x89\x77\x9f\xe2\xb2\xf9\xb1\xa0\x74\x80\x7a\xde\x2e\x5a\xb6\x2f\x9a\x48\x22\x64\xad\x88\xf1\xda\x04\x4b\x0c\x9b\xda\xb8\x1d\x39\x2f\x15\x62\xff\x4d\x4d\x71\xd2\xa2\x03\x56\x14\x6e\xa7\xeb\xed\x0e\x64\x13\x09\x45\xed\x81\x90\x0a\x25\xaa\x87\x1b\x22\xd7\x2a\x23\x75\x8f\x1b\xa1\x05\x29\xa7\x92\x25\xae\xe0\xbd\x36\x80\xdf\x45\x59\x15\x94\x5d\x90\x97\x37\x21\xc1\x60\x49\xf3\x21\x98\x80\x4a\xb3\x84\x05\xc8\x1d\x98\x52\xc1\x9b\x57\xd1\x24\x79\xa9\xfa\x73\xbd\xa6\x9b\xbd\x55\x21\xfe\xb3\xdc\x5b\x54\x39\xf9\xe6\x46\xde\x93\x29\x3a\x4e\xa6\xe8\x6b\xe5\xd6\xc7\x7a\x4c\xa3\xc0\x32\xe2\xbd\x54\x7c\xa5\xd2\xf9\x0a\x2e\x83\x24\x09\xd7\x42\x82\x18\x91\x90\xe8\xc0\x65\xa4\x08\x17\x10\xb0\x13\x26\x6f\x23\x11\x17\x7d\x71\x7b\xfd\xa7\x3f\x5f\x7f\xf8\xf0\xb2\xb3\x3c\x89\x75\x07\xa4\x97\xe7\xac\x40\xa1\xea\x6a\x11\x8c\x68\x44\xb2\xb1\xa5\x97\x9f\xaf\x39\x93\xa0\xff\x7b\x97\x98\x21\x99\x73\x85\xee\x51\x9b\xfb\x0e\xd8\x4a\x18\xc7\x61\xba\x5d\x1c\x98\x77\xe2\x91\x75\xb4\x0d\xfc\x4e\xe2\x1c\xd5\x29\x30\x96\x65\x74\x01\xb5\x72\xb2\xe8\xa2\xaa\x40\xe4\xa5\x54\xd2\x3a\x23\x9c\x36\x24\x47\xa2\x76\xba\x64\x17\x5b\x19\x9d\xa1\xb5\x90\x09\x05\x39\x7a\xc2\xe0\xa1\x9c\xf5\xd8\x3f\x76\x33\x89\x8c\xa4\x3b\xd7\x9b\x18\xc3\x2d\x1a\x66\x27\x2d\x0b\x21\x69\xd8\xcd\x4e\x74\x21\xd2\xc3\x6b\x44\xd5\x18\x3d\x8a\x0d\x86\x62\x81\x63\x33\x9a\x56\xea\xc0\x6d\x9b\xd1\x83\x08\xe2\xff\x79\xc4\xd0\x18\xb4\x51\x9f\xf6\xb1\xb6\x44\x37\x6f\x15\xa3\x77\x6f\x91\xba\xd1\xe2\x46\x28\x0d\x6e\x49\x16\x3a\x3e\x18\xe0\x9d\xc8\x76\x80\xca\x99\x7d\x48\xea\x64\x4e\x81\xea\x46\xa2\x49\x95\x18\x83\xb6\xd2\x8a\xbd\x02\x64\xba\xac\xb4\x42\x4e\xb6\xc9\x61\xca\xa2\x2b\x7e\x2d\xd5\xf0\x90\x13\x1e\x64\x98\x59\x70\x7a\x4d\xee\xa1\xcc\x74\xc0\xb2\x03\x54\x4b\x25\x8b\x05\x63\x2c\x31\x98\x09\x19\x5c\x05\x09\x74\x8c\x40\x42\x8c\x73\xbc\x61\xf6\x05\xc7\xe4\x1d\xe1\x49\xfc\x49\x18\x23\x0e\xdd\xec\x16\x15\xc5\xcc\x78\x32\x49\x9b\xff\xa9\x75\x67\x20\xb2\xe6\xdf\x44\x41\xf9\xe7\x46\x7e\x5f\x90\x59\x6e\xe4\x9d\xb3\x83\xae\xa7\x70\x3a\x2d\x4a\x86\x5c\xc9\x5f\xeb\x90\x8d\x7d\xba\xf9\xf0\x3f\x70\xfd\x9e\x9f\x26\x7c\x42\x34\xb2\x13\xb6\x51\xb2\xca\xe8\x07\x99\x77\x29\x02\x9e\x1d\xed\x10\x86\x90\x21\x63\x14\xa0\x1b\x74\xb5\x51\x3e\x64\x68\x2a\x2c\x29\x9a\x1c\xce\xfc\xdc\x4e\xa8\x06\x4c\x25\xac\x4d\xe1\x92\xf7\x9f\x0c\x82\x23\xc8\x35\x59\xdf\x72\x2d\x55\x28\x1a\xa4\x0d\x76\x80\xda\x7a\xb3\x91\xdf\x09\x0c\xd9\x57\xbf\xa7\xe0\x8e\x77\x21\x32\xe0\x34\xb5\xa9\x46\x82\xa9\x0b\xb4\x31\x6c\x20\xfa\x74\x80\x86\x20\x24\x16\xdf\xd6\x08\xce\xd4\x2a\x6b\x5b\xa1\x02\xd5\xd6\xed\xa2\x88\x7a\x2c\xd8\xce\x48\x2a\x74\x38\xdd\x81\x59\x8a\x7b\xaf\x97\x1e\xb9\xc0\x2f\xad\x5a\x3c\x66\x7b\xd7\x21\x3f\x15\x67\xe5\x46\xf6\x78\x61\xc2\x8f\x9e\x8e\x62\xe0\x73\x70\xef\x20\xec\xa2\x05\xd8\x33\xe7\xe6\x13\x15\xda\x88\x79\x20\xe0\xf7\xaf\xfe\x08\xcb\x0e\x44\xa9\xac\x43\x91\x2f\x52\x7a\x80\x92\xc3\x96\xf0\xd8\x2f\xaf\x5e\x03\xa7\xb7\x3e\x16\xf9\xc3\xab\x57\xbe\x10\xf0\x05\x85\xd5\x2a\x14\xe6\x48\x7f\x75\xdd\xa3\xaf\x2a\x97\x99\xe0\xa4\xf7\x50\x5c\x33\xae\xbe\x84\xc0\x69\xa3\x6b\x4a\x0f\x55\x13\x29\x52\xc2\xe3\x1c\xe6\x8b\xc1\xfd\x07\x09\x0c\x65\x1c\x83\x64\x63\x5e\x44\x9d\x2a\xf6\xdd\xd0\x93\x11\xe1\xcc\xb4\x03\x93\xe0\x7d\x21\x08\x4b\x1f\x66\xec\x50\xe4\x68\x5e\x32\x6b\x2e\xab\xaa\x90\xb4\x75\x32\x2a\x72\x03\x51\x83\x09\xf5\xc4\xa5\xae\x42\x3d\xaf\x9f\x91\x39\x96\x95\x76\xa8\xb2\xfd\x7c\x36\xd1\x6c\x05\x01\x39\x2a\x8b\x77\x4c\xd3\x25\x58\x72\x94\x14\x03\x2b\x9f\x77\x1e\x94\x2a\x44\xdc\x64\x16\x25\x8e\xc2\x67\xbd\x39\x02\x09\x21\x80\xb6\xac\x09\xd6\x09\x87\xab\x21\x2f\xfe\xec\xf9\x20\x37\x43\xa6\xb8\xcd\xf9\xa5\x6a\xdf\x4c\x6c\x14\x54\xb2\x77\x46\x17\x45\xaa\x99\xa1\xda\x68\xae\x77\x59\x5d\x46\x9c\x8f\xa0\x92\x60\x3f\x08\x23\x85\x72\x94\x32\x06\xaf\x1b\x6b\x46\x21\xea\x3e\xcc\x09\x85\xf7\x4f\x7a\xd3\xc6\xa0\x1b\x10\x71\x28\xbe\x13\x0f\xbe\x64\xb9\xa7\xda\x18\xa7\x6a\xfa\xa0\x20\xc4\xfe\x53\xc9\x82\x14\x92\x63\x80\x83\xb8\xb1\x03\x94\x8c\x22\x3b\x00\xf2\xdc\x14\xdc\x17\xfb\x16\x16\x94\x02\x91\xc2\x3f\x4a\x8b\x8b\xa3\x28\x22\x23\x9f\x9f\xa3\xe9\x31\x44\xb5\x6a\x81\x88\xd9\xe9\x4e\xe6\x39\x2a\x78\x21\x15\x6f\xf7\xfc\x51\xb8\x6c\xc7\x3f\x6e\xd1\x41\x26\x8a\xc2\xbe\xf4\x21\x89\xd7\xdf\x11\x02\xa8\x33\x47\x99\x6a\x21\x33\x49\xa9\xae\xb0\xf7\x6c\x63\x41\xaf\xd9\x70\x1e\xad\x9f\x6a\xb3\x3d\x95\xa5\xff\xe6\xa8\x31\xf6\x6c\x40\xa6\x5a\xda\xe2\x20\xb6\x24\x73\x59\x05\x91\x6d\x45\x14\xbd\xf5\x6b\x7a\x2e\xab\x0d\x15\x3b\x29\xf8\x3d\x66\x6b\x28\xa3\x54\x46\x3e\xc8\x02\xb7\x98\x93\x73\x0f\xdd\x0b\xbe\xbd\x9b\xb1\xf9\x32\x73\xb3\x6e\xc8\x4b\x65\x93\xfd\x2e\x62\x7a\x18\xac\x26\x3f\x21\x29\xc4\xf3\x79\x66\x07\xe4\x7a\x0f\x42\xed\x79\x69\x36\x65\x6f\xdf\x7d\xfe\xf2\xee\xea\xf2\xeb\xbb\xb7\xb0\x3c\x40\x97\x4b\xe4\x42\x81\x28\xaa\x9d\x08\x22\x4b\x3c\xeb\x8d\xec\x5a\xc5\x23\xa9\xe0\xe1\xf5\xea\xf5\x1f\x56\xc7\x46\x69\xa8\x53\x41\xdf\xca\x67\x87\xdd\x1f\x8e\x94\xf5\x73\xc8\x22\x07\x75\x27\x74\x0e\x28\x14\xc6\xef\x98\xd5\xae\xeb\xd3\x43\xda\xea\x0b\x9e\x29\x4c\x4e\x8a\x42\xa4\x0d\xa5\x8e\x95\x97\x12\xe2\x6b\x21\xac\x8b\x58\x0e\x40\x4c\x48\x10\x84\x40\x8d\x58\x08\x81\x8d\x90\x05\x39\x3c\x83\xb6\x2e\x5c\xa8\x07\x79\x51\x6b\xa3\xdf\x0b\xda\x37\x53\x52\x5c\x45\xb2\xe2\x34\x6b\x7a\xf4\x7b\x7d\xba\x49\x71\x4d\x03\xba\xab\xaa\xd1\x6f\x86\xbd\x92\x16\x89\xa2\x88\x2a\xd8\x75\x5e\x83\x31\xf2\x29\xde\xfa\xaf\xea\x09\x87\x07\x98\xdc\xee\x5c\xc4\x9c\x94\xd9\x2a\xed\x41\xca\x41\x69\x48\xda\xe1\x10\x5f\xa2\x6a\x26\xfe\xae\x66\x83\x37\x0d\x07\xfb\x31\x7f\xf9\xb5\x26\x5f\xd6\xbf\x8f\x25\x07\x31\xbd\x3f\x0d\x36\x74\xc6\x53\x89\x50\x5e\xac\x0b\x77\x31\x3b\x41\xb3\xeb\xcd\xa1\x68\xf9\x70\x8c\x28\xf8\x5e\xc8\xa2\x36\x21\xf4\x6f\x9b\xf2\x1e\x90\xa1\x3e\x42\xfd\x2f\x6a\x82\xdb\x50\x0f\xa4\x46\x9b\xd8\x86\x8a\x28\x69\x44\xc8\x23\x29\xdd\xb2\x35\x05\x19\x5e\xed\x74\xaf\xc5\xa1\x7f\x41\xaa\xb8\xb4\x10\x6d\x75\x3b\xd5\x5b\xcd\x9e\x2e\x53\xfd\x2d\xfe\x41\x0a\x3d\xb5\xdd\x3f\x00\x13\x9a\x50\x28\x86\x3d\x4f\x6a\xfd\x0f\x82\xed\x1d\x09\x78\xca\x18\xc0\x20\xe4\x7f\xe2\x78\xc0\xa4\x20\x34\x7e\x33\x9d\xe3\x24\xd6\xdd\xd6\xdb\xad\x2f\x7e\xff\xe7\xd7\xaf\x9f\x63\xea\x42\x8f\x37\xcd\x0f\x0a\x2f\x6b\xbb\x80\x57\x20\xbb\x71\x68\xfc\x84\xb2\xd4\x90\x09\x68\x45\x9a\x6f\x7e\x19\xb8\x67\x38\xe2\x8c\x9f\x1c\x9d\x90\x85\x9d\xb4\xb3\x77\x34\xe3\x93\x63\x4e\x6d\x24\x01\xc2\x5a\x9d\x49\x0e\x8e\x93\xfa\x1a\xce\xa8\x56\xbe\x20\x33\x00\x92\x64\x92\xee\x62\xc9\xf0\xb2\x0d\x34\xd7\xa0\x1f\x15\xb7\xcd\xfd\x0a\x1e\xad\xa3\x10\x74\x10\x62\xaa\x44\x44\x1f\xc3\x18\xa6\x94\xbf\xb7\xd9\x98\x69\x8a\x92\xcb\xd9\x00\x48\x32\x25\x14\x7b\x04\x3d\xc3\xef\x19\x56\xa1\x5c\xe4\x91\x4e\x39\x41\xd8\x0e\xd1\x7a\x88\x57\xa7\x3d\x0e\x40\x26\x6a\x3b\xf6\xfb\x11\x33\xa8\x72\x70\xc5\x8f\x78\x5b\x0c\x52\x65\x45\x9d\xa3\x85\x92\x12\xb7\xc0\xd7\x16\x97\x46\x00\x43\x63\x80\x6f\x59\x32\x43\x66\x4c\x71\x40\x6d\x70\x05\x37\xda\x51\x07\xef\xe0\x57\x8e\x05\x47\x81\x86\xc2\x46\xc0\x05\xf3\xb0\xc5\x21\x22\x9d\xf0\xda\x4f\xa1\x65\xd0\x10\x12\x9b\x53\x37\x1d\x91\x75\x4e\x74\x65\xef\x13\x9d\x7a\x2a\x27\x87\xb8\x9e\x4a\xce\x54\x5b\x3a\x09\x37\x38\x72\x34\x46\x9b\x05\x05\x38\xe4\x71\x59\x6a\x48\xdc\xff\xeb\xf6\xd3\x0d\xd5\x39\x38\x1e\x10\x43\x6e\xe5\xf8\xfb\xb1\x61\x34\xe4\xc4\x14\x95\x43\xa5\xad\xdb\xc8\xef\x10\x27\x34\xd8\xcc\x28\x36\x41\x13\x20\x0a\xe7\xa7\xab\xc8\xe6\x5e\x92\x20\xf9\x58\xfa\x37\x34\x7a\x29\x55\x8e\xdf\xa9\xda\x05\xef\x89\x22\xa7\x39\x1e\x7d\x5d\x85\xc2\x78\x39\xe4\xea\x19\xb7\xc5\x24\x67\x30\x5e\x56\xf5\x26\xc8\x02\xe4\x3d\xc5\xb1\xee\xd7\x69\x6f\x03\x2c\xe5\x55\xe4\xc1\xcb\xba\x70\xb2\x2a\xd0\x53\xd7\xae\xe0\x53\xb0\x00\x9c\x26\xbc\xf3\x9d\xa2\x93\x02\x42\xff\xee\x00\xee\xe6\xc4\x99\xbb\x39\x2c\x43\x4b\x8e\xb8\x9f\x2e\x6a\xd5\xce\x95\x26\x40\x4c\x02\x43\x90\x59\xa0\xff\xfa\xea\x6f\xab\x91\x25\x26\xc0\x0c\x48\x6c\xa4\xa1\x26\x0a\xd3\x30\x94\xbb\x55\x5c\xe4\x6e\x3e\x9f\x8d\x40\x98\xe6\xe5\x9a\x4f\x89\xd6\x8a\xed\x48\x14\xdc\xab\x3e\x97\xb0\xab\x4b\xa1\x96\x06\x45\xce\x8d\xd4\xd6\xaf\x51\xa1\x98\xf3\x27\xc1\x42\xbc\x9d\x39\xbc\x82\xb6\x27\x08\xd5\xcd\x10\xd9\x70\xf6\xb0\x1c\xf1\x0e\xcd\x97\x6c\x3a\xb9\x9f\x1c\xcd\xea\x39\x89\xe5\x5d\xc0\x93\x69\x55\x8a\x6c\x27\x15\x8e\x51\xeb\x24\xc8\xe0\x38\x8e\xa8\x15\xcb\xb1\x1c\x4d\xa5\xfc\x9b\x00\x9a\x29\x20\xd9\x61\x72\xf4\x45\x31\x06\x61\x23\x1e\x84\x2c\x88\xa3\xcf\x48\xb7\x13\x89\xc6\x94\x84\x23\x7e\xfc\x08\xf0\x6c\x22\xe5\xc9\xc6\xf3\x13\x8d\xf5\xeb\x58\xfb\xa7\x3a\x4e\x1f\xd2\x1d\x78\xc8\xd5\xec\x27\x89\x74\x3c\xaa\x3a\xba\xa9\x33\xda\x15\x3d\xf1\x0f\xde\x14\x7c\x52\xbe\xae\xd8\x8c\x5b\x91\x5f\x08\xb3\x73\xa3\x70\x5b\x9d\xbc\xd0\xd9\x6c\x50\xa3\xc1\xdb\x7f\xd2\xb8\xea\x0f\xf1\x62\xbc\x24\xd0\x23\x60\xf4\xc0\x3f\x96\x15\xf0\x22\x8c\xd9\x21\x11\x8d\x8a\x4c\x56\xaa\x6d\x81\xc3\xa9\x7d\xfc\xfa\x32\x31\xe5\xb7\xeb\x68\x74\xd6\x98\xbf\xfc\x69\x81\xe5\x26\x06\x77\x20\x06\xa6\xc4\x06\x29\x76\xbd\x69\x7a\x11\x8b\x76\xd3\x23\x4e\x4a\xb4\x7a\xc4\x23\x30\xa1\x19\x02\x8c\x59\x2d\x57\xfb\x68\xe2\x36\x5f\xc1\x2d\xc9\x2d\x9b\xc8\x38\x87\xed\x7b\x2a\xa3\x10\x5b\xbd\x1a\x2e\xd5\x39\x6a\x89\x51\x28\x53\xf0\x8c\x2f\x0d\x5f\x65\x64\x57\x60\x19\x12\x3c\x6d\xe3\x22\x27\xe0\x1e\x38\xb4\x88\x0b\xec\xf4\xa3\x1f\x11\x72\x1a\x1e\x85\x74\x69\xe7\xe2\x7e\x8c\xf6\x11\xd5\x63\xb4\xc6\x98\x3a\x25\x87\x9c\x96\x47\xd2\xb7\x96\x4f\xb0\x56\xdf\xae\xdf\x1e\xeb\xc4\x6a\x48\xa0\x67\x93\xc2\xad\x21\xa1\x7e\xf2\xb0\x73\x33\x3c\x60\x7f\x57\xcb\x9f\xb6\x1d\x27\xdd\xdc\x98\x99\x7f\x86\xd3\x09\xb3\x51\x01\x0c\xd5\xd8\x1f\x39\xa9\x30\x9b\xa0\x31\x3f\x74\x6a\x61\x10\xf0\x3f\xdd\x3d\x9c\x64\xef\x89\x30\xf9\xc9\xc1\x71\x30\xf3\xa7\xca\x7a\xc9\xca\xad\x7e\x1c\xf1\xee\xf1\x8c\x41\xcc\xcf\x6e\x9d\x50\x39\x4d\xa0\x51\x63\x27\x3d\xfb\x2f\xf0\xd7\x93\x2a\x29\x9a\x34\xa1\x9e\xee\xae\xe3\x03\x31\xb1\xa0\xa6\x85\xdc\xa4\xc9\x55\x2e\x51\x53\xf7\xb3\x94\x6e\x36\x21\x4b\x0b\x5d\x68\x6a\xf6\x50\x62\x16\x4a\x80\xb1\xbf\x12\xed\x7c\x68\x13\x9c\xf2\x67\x61\x14\x82\x1a\xa0\x9c\x50\x13\xcf\x5a\xd1\x38\x87\x1a\x29\xca\xd7\x95\xa0\x71\x9a\xbe\xc1\xbf\xf6\x27\x6c\x33\x9e\x95\x90\xd6\xf2\x43\x3a\x0c\x4d\x84\x91\x4a\x7d\xa0\xec\x8c\xed\x69\x4c\xf3\x56\xdf\xd1\xe9\xe0\x79\x43\xfd\x5c\xe1\xf7\xd4\x6b\x4c\x3b\x18\x05\x99\x7a\xa2\x57\x9e\x43\x64\xdf\xb8\xdf\xcd\xe5\x7e\xe5\x82\x38\x36\x1d\xc5\x4a\xdb\xfe\xb9\xdf\xf6\x47\x6e\xda\x43\x26\x54\x07\x94\xdb\x3a\x04\x0d\x44\xe7\x6c\x27\x14\x75\x3c\x75\xbb\x86\x21\x46\x41\x6e\xf0\x11\x4a\xa9\xa8\x8c\x42\x25\x8a\xf6\x9c\x50\xe3\xdf\x62\x41\xdf\x27\xb1\x51\x2a\x46\xe1\xb2\x3f\xac\xc9\x0b\x7a\xba\x26\x49\x6d\x8d\x1e\xad\x11\xbc\xc7\xca\xd2\x0c\xea\x28\xcc\x20\x2d\xed\x8a\x42\x68\x54\x21\x8d\x62\x16\xd4\xc1\xda\xeb\xda\xef\xc3\x60\x86\xb2\xef\x64\x51\xfb\xc3\xa8\x39\x7d\x8f\xca\x3b\x09\xa1\x7c\xfc\x13\xad\xe3\x58\x08\x72\xd2\x50\xb5\x7d\x7c\xa0\xe0\x64\xc5\x3e\xbb\x75\x4d\xc3\x27\xb9\xf5\x30\x5f\xc5\xec\x3f\x3b\xb3\xa9\x6d\x31\x02\x15\x62\xe7\x25\x36\x5c\xa2\xdf\x24\xad\x88\x31\x47\x1c\x7f\x8b\xfd\xa3\x9e\x71\xaa\xf6\xb7\x99\x5a\x65\x2e\x07\x59\xf7\x64\x0f\x22\xb8\x82\xbf\x30\xb3\xca\x30\x2d\xe9\x68\x3e\xe3\x04\x33\x44\x32\x03\x2d\x54\xc8\xf0\x78\x91\x84\x5a\xa5\xb6\xfb\x5a\x64\xf7\x53\x24\x26\xce\x79\x4d\x18\x87\x69\x79\x84\x51\x90\xcf\xe0\x2d\x32\xad\xfc\x00\x43\xb6\x5f\x86\x11\x98\xa5\x50\xf9\x32\x99\x87\x6c\x7f\xf6\xb3\x82\x67\xb1\xd8\x7c\x90\xea\x7e\xb2\xc4\xc5\x07\x7c\x94\xf6\xed\xcb\x87\xe3\xe0\x2c\x89\xce\x98\x52\x4c\x3a\x4b\xf4\x73\x7b\x3b\x19\x95\x8e\xd7\xb4\x9e\x58\xc9\x7a\xdc\x85\xc1\x90\x14\xb8\x0c\xc0\xe5\xda\x53\x98\xa3\x9b\x87\x6e\xf0\x3c\x24\xbf\xe3\x65\xad\xb1\xfe\xd0\x60\x31\x0b\x2e\xe3\x14\x60\x56\x08\x43\x93\x70\x3c\xd9\xca\x9d\x3b\xbf\xe8\x20\x4c\xee\xe8\xad\x6b\x07\xb9\x46\x2a\x97\x39\xd0\x0f\x68\x0c\x35\x3c\x64\xe7\x94\xde\x64\xc6\xf8\x45\x27\x51\xfd\xec\xb6\x15\x2b\xb6\xca\x31\x2b\xf8\xa4\x68\x58\xff\x02\xe6\xb7\x75\x46\x43\xf2\xf3\xbe\x71\x9d\xf8\x49\x54\x7e\xee\x68\x8e\xf2\x79\x56\x48\xbf\xa7\xb3\x1f\x23\xc9\x88\x9c\x0e\x4d\x38\x2c\x07\x66\x5f\x06\x41\x15\x62\x8d\xdd\x1e\xe8\x33\x9f\x3c\xfe\x28\x2a\x72\x1e\x21\x71\xbb\xc7\x3d\x49\x5a\x3c\x0e\xdf\xf5\x23\x4e\x83\x36\x5b\x41\x6d\xf8\xce\x9a\xf4\x1c\x85\x90\x5b\x6d\xe4\x6f\x08\x2f\xf8\x25\x06\x0c\xcd\x62\x81\x99\x7b\x19\x36\x49\xe7\x0b\xc5\x1e\x4a\x1e\x61\xf3\x3f\x69\x63\xfb\x66\x1f\x0d\x56\x05\x55\x42\x48\x5d\x9b\x71\x42\x1b\x60\x9a\x07\x99\xa1\x7d\x7a\x22\xed\xe9\x7a\x36\x95\x0d\xa5\x50\x62\x8b\xb9\xef\x35\x5d\x8c\x11\x73\xfe\xb1\x7d\x2b\x94\xa2\xb2\x40\xe7\x52\x36\x85\x7e\x5c\xca\x9c\xd1\x8e\x0e\x3b\x8c\x28\xf4\x1d\x2c\xd5\x9b\xd8\x56\x62\xf2\x53\xdf\x2b\xe0\x40\x6e\x9c\xaf\x45\xa8\xa1\x13\x2d\x29\x0a\xb7\x34\xcd\x47\xa5\x9e\xc1\xc0\x61\xa7\x6b\x8b\xf7\x88\x95\x54\x5b\x1f\xf5\x53\x1e\x61\xc9\x2e\x4b\x1a\x21\xdc\x87\xe2\x14\x4d\x08\xaa\xd0\x8f\x0e\x27\xaf\x6a\x95\xa3\xb1\xae\x2f\x84\x6f\x0a\x46\x2b\xb8\x4c\xfb\x8d\x52\x13\xb3\x95\x33\xdf\x68\x5c\x1c\x0c\x86\xc6\x8b\x1d\x98\xe1\x70\x44\x9c\x62\x6a\x0d\xcb\x8a\xaa\xa2\x01\x40\xe1\x76\x50\xc8\x7b\x84\xbb\x79\x26\x97\x59\x7e\x37\x27\x52\x60\x8c\xe3\x3d\xfd\x3a\x60\xc9\xfb\x15\x8f\x62\x9f\x6c\x79\xe2\x46\xc8\x79\x1a\xf4\x39\x6a\x3a\x3a\xa7\xde\x17\x90\xc4\xa1\x95\x3b\x75\x38\x14\x10\x66\xfe\x88\xc8\x81\x12\xad\xf8\x3d\xce\xf9\x51\x19\xb5\x6f\xba\x5b\x69\x27\x33\xec\x4c\xff\x0d\xb4\xa1\xc7\x93\xcf\x53\x23\x3e\x07\x12\x3c\x3e\xdf\x93\xa2\xcc\x18\xf8\x8e\x65\x5f\xad\x3a\x22\x31\x85\xf8\x46\x6e\xcc\x9f\x92\xc7\x50\xe3\x23\xb7\x3a\xe7\x9e\xc7\x79\x58\x63\x0e\x7f\xaf\x8f\x5e\xe3\xd1\x7c\x99\xe3\xc4\x26\xa7\xab\x65\x41\x16\xbe\x8d\x71\x90\xc1\x70\x8c\x1b\xc9\xc5\xd0\x5b\x0b\x48\xd3\x8c\xc8\xee\x07\xf1\x3c\xd8\x5f\x1c\xd3\x24\x9c\xd7\xc8\x4d\x41\x1a\x0f\xcd\x52\x6d\x28\x9c\xf5\xf2\x0a\x33\x00\x33\x8c\x2c\xf5\xcd\xaf\x9f\x30\xce\x69\x40\xa0\x97\x97\x03\xd6\x1f\x9c\xa9\xf1\x34\x73\x83\x59\x6a\x25\x1c\xe2\x50\x5f\xc6\xb0\xed\x31\x8c\x6d\xf3\x68\x26\x08\x97\xb7\x8e\xa6\x7b\x16\x4a\x6f\x0e\x75\x8f\x41\xf6\xd3\x26\x70\xcc\xe2\x04\x94\x07\x09\x9c\x62\x92\x09\x48\x7f\x8a\xf7\xc6\x37\xe9\x10\x6c\x22\x59\x02\x12\x2a\xbc\x05\x8a\xde\xa3\x2a\x11\x67\x69\xe1\xc0\x3d\xbc\xe3\x46\xf9\x1a\x29\xfe\x4e\xaf\x20\x20\xcd\xa0\x28\x9a\xfc\xaf\x8c\x5e\x78\x00\x64\x1a\xdb\x0a\x73\xc5\x06\xe1\x8c\x0e\x55\xec\xcf\xd8\xb4\x9f\x7d\xe3\x22\xe6\xd9\x0f\x51\x88\xba\x1c\x13\x88\x43\xa7\x53\xa0\x7d\x68\x92\x08\x13\x8b\xe5\x89\x47\xf0\x48\x9d\xa0\x91\x99\xb1\xeb\x74\xdc\x24\x58\xe7\x74\x02\x4f\x6e\x0e\x19\x10\x36\x38\x1b\xeb\x1a\x0c\x9d\x0d\x9c\xb0\xf1\x11\x51\x1f\x6a\xf7\xf6\x35\xe0\x0e\x68\x74\xc6\x07\x5b\x62\xaa\x1c\x8e\xea\x90\xe1\xa7\xc9\x93\xe6\x3d\x1f\x2b\xb8\xb6\xcd\x91\xa7\xde\x77\x04\xb0\x94\x84\x01\x68\x2e\xa1\xdb\x45\x73\xc2\x99\x7b\x9f\xe9\x07\x2e\x19\xd2\x59\x9f\xc7\x74\x5c\xbd\x4f\x36\x53\x51\xad\x39\xf7\x14\xcd\xa0\x02\x51\x91\x63\x31\xd4\x0c\x0c\xef\x25\x69\x5b\xbe\x55\xff\x61\x2f\x69\xa1\x32\xb2\x14\x46\xf2\x51\x88\x30\x37\x47\xa2\x9a\x0e\x71\x34\x67\x6e\xa8\xba\x97\x1f\x55\xba\xf2\xf4\x0e\xae\xae\xb4\xf4\x14\xe8\x9f\x1a\xfb\x35\x56\xc7\xfe\x8e\x36\x35\x10\x06\xf6\xc8\x47\xe2\xd4\x28\xb7\xe7\x37\xf1\xb6\x03\x07\xea\xaf\x04\xae\xd3\x71\x7e\x50\x5d\xa9\xe8\x6e\xf8\x52\x05\x3d\x48\x8b\x93\xb6\x51\x7e\xf1\x20\x0a\xcf\x53\x06\x7f\x37\xcf\x71\x23\xea\xc2\xdd\xcd\x1b\x89\x5a\xc0\xba\x27\xb4\x68\xdf\x1a\x2c\x5a\x26\x94\x56\xc4\xd5\xa6\x28\x10\x32\xb6\x38\x60\x17\x8b\x40\x14\x8a\x46\x19\xed\x40\x0e\x6f\x4a\xa1\xa0\x9f\x0c\x61\x5b\xb8\xc3\x7c\x11\x9b\xb3\x14\x44\x78\xb3\xd5\xf4\x26\xc3\x22\xb3\xa1\x71\xea\xf0\xa6\x82\x3b\x95\x4e\xe9\x0a\x78\x7b\x73\xfb\xbf\x1f\x2e\xff\xe3\xdd\x87\xd5\xb8\x70\x74\x80\x4e\x12\x96\x84\xbf\x9d\x4f\x95\x12\xfd\xa8\xd0\x7c\x41\x7e\x5d\x4f\x86\x76\x54\x56\x3e\x84\xb3\x17\x91\xba\x39\x52\x82\x18\xa3\xfc\xa6\x22\x43\xf5\x85\xcb\x0f\x1f\x06\x09\x14\x62\x59\x2e\x3a\x73\x99\x6e\x8d\xed\xf9\xf2\x16\xa8\x44\xcb\xad\x30\x6b\x9a\x46\xcf\xe8\x7c\x16\x9d\x83\xea\xca\xde\xf5\xe6\xe0\x49\x69\xdb\x49\x48\x3b\x88\xa7\x15\xfc\x39\xa0\x34\xfb\x95\x8a\xed\x1d\xa8\xe1\x30\x90\x8c\xb2\x2b\xed\x01\xa4\x34\x57\xd0\x5c\x6c\xc5\x63\xf4\x84\xe9\xd3\x93\xaf\x5c\x69\x69\x62\xb4\xf6\x8c\x5f\x48\x9e\xc8\x6e\x36\x40\x57\xff\x8a\xc8\xfa\x30\x8c\x26\x4d\x62\x31\x19\x70\x8b\x83\x22\x16\x4e\x0b\xd1\x5b\x36\x3e\x91\xb4\xc5\xd7\xb0\x4c\x40\x82\x78\x6a\xe8\x4d\x78\x97\x37\x6f\x63\xbf\x81\x25\x36\x1d\xef\x9d\x53\x4f\x9f\x02\x72\x95\x47\xb8\xc7\xb2\xdf\x39\x52\x1f\x04\xa0\x01\xd6\x30\x22\x08\x61\xd3\xa4\xbd\xc7\xfd\x92\xcd\xc0\x00\x50\x3a\x26\x41\xf6\xd0\xc9\x22\xa6\x1a\x41\x97\x5a\x27\x82\x56\xf0\xd6\x9b\x3b\x4a\x27\x60\x23\x0a\x7a\xa5\xdc\xd7\xa1\xd0\x2b\xbd\x53\x29\x1e\x44\xa6\x4a\x86\xe1\x04\xd7\xc2\xdc\x63\x38\xa7\xc3\x1a\xa5\xb4\x6d\xf6\xf0\x5e\xba\xa9\x69\xd0\xf3\x78\xb0\x0f\x7e\xff\xcb\x2f\xf0\xe2\x9b\x0a\x87\x6c\xa8\x7c\x07\xef\x94\x93\x6e\xff\x32\x69\x5b\xec\xa9\x8c\x31\x7a\xad\x35\xbd\xfd\xa2\xe7\x8e\x46\x6a\x9f\xc2\xe1\x23\xe2\xf1\x3b\xfc\xd2\xc1\x88\x09\x1a\x31\x0d\xb7\xe1\x19\x81\x03\xac\xfc\x84\xc0\xb1\xd8\x3f\x73\x61\xef\x64\x9b\xf6\x84\x46\x0d\x8f\x52\x1d\xee\xe5\xa6\x75\xb4\x6a\x70\x2f\x3f\x1f\x88\x4c\xc2\xb9\x96\x93\xc8\x7f\x30\xd5\xf2\x1c\x18\xd7\xf2\x87\x88\x1c\x63\x87\x2e\xce\xcb\x96\x35\xed\xf9\x91\xb8\x3a\x9b\x78\x58\x6c\x09\xb5\xcc\x9f\x23\xb4\x8f\xd1\xf4\x80\x91\x3f\x20\x31\x9d\x80\x0e\xfd\x2d\x36\x6f\xe4\x7d\xda\xe3\x2b\xe1\x94\x62\x3c\x88\x94\x1c\xc1\xac\x37\x51\x9c\xd4\xc5\x1b\xe8\xd4\x75\x20\x1e\x76\xee\x3e\xb6\x9a\xec\x14\x7b\xe9\xca\xc9\x92\xde\x4a\x98\x41\xab\x73\xb5\x08\x0f\xf0\x1a\x3c\x46\xd6\x35\x84\xf1\x50\x8b\x3f\x8a\xdc\xa4\xc3\xd4\xc9\x48\x29\x0a\x1d\xa4\x0e\x35\x86\x78\xa9\x79\x0d\x5e\x07\x24\xc7\xc3\xdc\x4d\x0c\x09\x64\x28\x43\x37\xcd\xc3\xa7\x77\x0c\x63\x97\x90\x5f\x59\x59\xb6\xde\xa3\xe6\x53\x6c\xa2\x81\xf0\x2f\x0a\xca\xea\x42\x98\x1e\xcc\x3b\x20\x5b\x3b\xb9\x53\x53\x7a\x62\x13\xfb\xa5\x83\x3d\xd2\xe7\x36\x95\x13\x7a\x94\x93\x23\xde\xa1\x5e\xe4\x81\x7a\xdc\x4e\xef\x3f\x1e\xd0\xf3\x08\x26\x4c\xeb\x39\x0e\xe2\xda\x63\x2e\x0f\xb5\x98\x0c\x65\xc8\x8a\x42\xa6\x4e\xd1\xac\x0c\x2f\xe5\xe4\x5c\x20\x74\xf9\x52\xf5\x25\xa0\x7d\x04\x16\xc2\xab\x1b\x9b\xd2\x7a\xc8\xaf\x5b\x62\xc2\x82\x09\xf4\xce\x2c\xdf\x0f\xa3\x57\x3c\xa5\x2c\x59\x6f\xc6\xde\xed\xda\x7a\x67\x5d\x7c\x85\x21\x9d\x1d\xf3\x3a\xab\x15\x7c\xfe\xf6\xb5\xd1\xc8\x23\x31\xed\xc0\x5d\xef\x07\xc8\xfa\xd3\x2e\x62\xa2\x10\xf5\xda\xe6\xf8\x1a\xed\xf1\x9b\x3a\x7d\xc9\x9e\xdb\x8e\x2e\x05\xe3\xcb\x4f\x2d\xc3\x2b\xbc\x1f\x5e\x73\xb9\xfe\xf5\x2c\x59\x8c\xbc\x55\x55\x0d\x67\x77\xc3\x95\x66\x4d\x91\xd1\xe9\x3b\xcc\x6f\x8e\x5f\xec\x3d\x9f\x1f\xbc\xd6\x9b\xff\xcc\xb4\xf2\xa5\x5b\x7b\x01\x7f\xfd\x1b\xbd\xe3\x9b\x42\xdd\x3c\x38\x17\x7b\x01\x7f\xfd\xdb\xec\xff\x06\x00\x83\x48\xc2\x78\xc3\x5c\x00\x00"),
 		},
 		"/control-plane/crds/kuma.io_proxytemplates.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_proxytemplates.yaml",
-			modTime:          time.Date(2019, 9, 10, 9, 59, 52, 147704164, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23821,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\xeb\x73\xdb\x38\x92\xff\x9e\xbf\xa2\xcb\xfb\xc1\x49\x95\x24\x27\x93\xdd\xab\x5b\x7f\xf3\x39\xc9\x9c\x6f\xf2\xaa\xd8\x99\xab\xab\xcd\xd6\x15\x44\xb6\x24\xac\x29\x80\x03\x80\xb6\x35\x7f\xfd\x55\x77\x03\x7c\x88\x0f\xc9\x89\x67\xea\xf8\xcd\x32\xd9\x04\xfa\xf9\xeb\x07\xf8\x6c\x3e\x9f\x3f\x53\xa5\xfe\x15\x9d\xd7\xd6\x9c\x83\x2a\x35\x3e\x04\x34\xf4\x97\x5f\xdc\xfe\xbb\x5f\x68\x7b\x76\xf7\x6a\x89\x41\xbd\x7a\x76\xab\x4d\x7e\x0e\x97\x95\x0f\x76\xfb\x05\xbd\xad\x5c\x86\x6f\x70\xa5\x8d\x0e\xda\x9a\x67\x5b\x0c\x2a\x57\x41\x9d\x3f\x03\xc8\x1c\x2a\xfa\xf1\x46\x6f\xd1\x07\xb5\x2d\xcf\xc1\x54\x45\xf1\x0c\xc0\xa8\x2d\x9e\x43\xe9\xec\xc3\x2e\xe0\xb6\x2c\x54\x40\xbf\xb8\xad\xb6\x6a\xa1\xed\x33\x5f\x62\x46\x8f\xaf\x9d\xad\xca\x73\x48\x3f\xcb\x53\x9e\xfe\x03\x20\xab\xf8\x4c\x04\x6e\x22\x01\xfe\xbd\x2c\x2a\xa7\x8a\x7d\xd2\xcf\x00\x7c\x66\x4b\x3c\x87\x93\x93\x67\x00\x77\xaa\xd0\x39\xaf\x4c\x88\xd9\x12\xcd\xc5\xe7\xab\x5f\x5f\x5f\x67\x1b\xdc\x2a\xf9\x11\x20\x47\x9f\x39\x5d\xf2\x7d\xdd\x57\x81\xf6\x10\x36\x08\x72\x3f\xac\xac\xe3\x3f\xbb\x2f\x85\x8b\xcf\x57\x91\x52\xe9\x6c\x89\x2e\xe8\xb4\x7a\xba\x5a\x0c\xaf\x7f\xdb\x7b\xe7\x29\x2d\x4a\xee\x81\x9c\x58\x8c\xf2\xda\x3b\xf9\x0d\x73\xf0\xb2\x00\xbb\x82\xb0\xd1\x1e\x1c\x96\x0e\x3d\x9a\xc0\x9b\x6b\x91\x05\xba\x45\x19\xb0\xcb\x7f\x61\x16\x16\x70\x8d\x8e\x88\x80\xdf\xd8\xaa\xc8\x21\xb3\xe6\x0e\x5d\x00\x87\x99\x5d\x1b\xfd\x7b\x4d\xd9\x43\xb0\xfc\x4a\xde\x51\xe8\x50\xd4\x26\xa0\x33\xaa\x20\x76\x56\x38\x03\x65\x72\xd8\xaa\x1d\x38\xa4\x77\x40\x65\x5a\xd4\xf8\x16\xbf\x80\x0f\xd6\x21\x68\xb3\xb2\xe7\xb0\x09\xa1\xf4\xe7\x67\x67\x6b\x1d\x92\x8a\x65\x76\xbb\xad\x8c\x0e\xbb\xb3\xcc\x9a\xe0\xf4\xb2\x0a\xd6\xf9\xb3\x1c\xef\xb0\x38\x53\xa5\x9e\xf3\x3a\x4d\x60\xb5\xdc\xe6\x7f\x71\x51\xfd\xfc\x69\x6b\x61\x61\x47\x72\xf6\xc1\x69\xb3\xae\x7f\x66\x75\x19\x65\xf3\x2f\xda\xe4\x24\x52\x15\x1f\x93\xe5\x36\xdc\xa4\x9f\x88\x09\x5f\xde\x5e\xdf\x40\x7a\x29\x73\xbc\xcb\x62\x66\x6e\xf3\x98\x6f\xf8\x4c\x7c\xd1\x66\x85\x4e\xe4\xb4\x72\x76\xcb\x14\xd1\xe4\xa5\xd5\x26\xf0\x1f\x59\xa1\xd1\x74\x79\xec\xab\xe5\x56\x07\x12\xec\x6f\x15\xfa\x40\xe2\x58\xc0\xa5\x32\xc6\x06\x58\x22\x54\x65\xae\x02\xe6\x0b\xb8\x32\x70\xa9\xb6\x58\x5c\x2a\x8f\x4f\xcd\x65\x62\xa8\x9f\x13\x07\x0f\xf3\xb9\x6d\xfd\xe9\x1a\x52\x7e\x36\x00\xda\x05\x2b\xea\xde\x3f\x00\x54\x9e\xb3\x37\x51\xc5\xe7\x91\x87\x47\x57\x30\x68\x46\xcd\x9b\x58\xcc\x06\x2a\xe3\x83\xab\xb2\x50\x39\xcc\xe1\x16\x77\x51\xe2\x5b\x55\x82\x0f\x96\x7e\xbc\xd7\x61\xd3\x7b\xa3\x6a\x4b\x5f\x05\x16\xeb\x12\xc1\x63\x80\xe5\x0e\xc8\x67\xb2\x41\x04\x6b\x0b\xb6\x1c\xa6\xc5\x86\xe1\x30\x38\x8d\x77\xd8\x27\xe9\x96\x3a\x38\xe5\x76\x35\xef\x16\x70\xb3\xc1\x1d\x28\x87\x40\x62\xfe\xad\x42\xb7\x53\xcb\x42\xe8\x44\x83\x5d\x92\xb3\x41\x4f\xda\x95\xf7\x48\xde\x6f\xd0\xc0\xd6\xe6\x7a\xb5\x23\xcd\x15\xb5\xec\x1b\xdf\xf9\xd9\xd9\x6d\xb5\x44\x67\x90\x9c\xaf\xb6\x67\xb9\xcd\xfc\x59\xe5\xd1\xcd\xd7\x95\xce\xf1\xac\x25\xa0\xd3\x67\x43\xac\x17\xca\x9d\x7f\x65\x45\xe5\x03\xba\x8f\xe4\xdf\xa7\x64\x72\xb3\x41\x76\xe7\xe2\xba\x30\x3d\x07\xf7\x1b\x9d\x6d\xf8\x97\x68\x4d\x4b\x2c\xac\x59\x8b\xe2\xdf\xec\x5b\x1c\x5d\xda\x43\xe5\x31\x27\x76\xe7\xda\x93\xad\x56\xda\x6f\x6a\x41\x79\x96\x24\x78\x7a\x17\xbf\x90\xb8\xc8\x81\xa4\x54\x19\xb1\x03\x72\xbd\x5a\xa1\xdb\xb7\xbc\xd6\x66\xbc\xbc\x19\x56\x1a\x0b\xf6\x13\x24\x16\x92\xb9\x32\xbb\xfb\x0d\x3a\x04\xa7\xd7\x9b\x00\xc6\xde\x33\x75\x55\x6a\x96\x8c\x83\x81\xe5\xae\x2d\x7b\x13\x0b\x7a\x6d\x58\x1e\x01\xf4\x8a\xa9\x69\x23\x01\x13\xc1\xba\x68\xd9\xc9\xee\x17\x83\xec\x1f\xd0\xfc\x7e\xc4\x9d\x12\xc2\xc9\xe5\xfe\xed\xe2\x05\x43\xfd\x67\xcf\x05\xca\xc6\xfa\xa6\xa8\xb7\x28\x7a\xc7\xfe\x2d\xca\xee\x5e\xf9\xb8\x25\x72\x51\x21\xb1\x6e\x5d\x29\xa7\x4c\x40\x11\x9a\xd8\x4f\x5f\xac\x06\x36\xaa\x2c\xd1\xf8\xf9\x12\x57\xc4\x29\xeb\x72\x74\xa0\x32\x67\xbd\x07\x8f\xa5\x72\xcc\xab\x12\x9d\xe8\xe8\x02\x2e\xd9\x81\x8a\xb7\x35\xb6\x4f\x93\xb8\xcc\xeb\x63\x6b\x4f\x4b\xaa\xf7\x88\x39\xbd\xf5\xcb\xbb\xcb\xd7\xaf\x5f\xff\x9d\x82\xfa\x96\xc5\xa9\x3d\xfd\xfc\xf5\xe6\x72\x01\xdf\x4c\x8f\xe6\x67\x5b\x56\x14\x1c\x73\xf2\x00\xcc\xa1\x9d\x0f\xb8\x5d\xc0\x17\x54\xf9\xdc\x9a\x62\xb7\x80\x8f\x55\x51\x30\x48\x28\xb4\x1f\x30\xc4\x1f\xf4\xcf\xc9\x6f\x9c\xec\xad\x8d\x36\xa0\xc2\x39\x90\x22\xcd\x49\x40\xc7\x2a\x51\x8e\x05\x12\xf5\x9f\x9d\xca\xf0\x33\x3a\x6d\xf3\x6b\xcc\xac\xc9\x7b\x3e\xb8\xa3\x4d\x1f\xab\xed\x12\x1d\x19\xb4\x97\xbb\x41\x15\x85\xbd\xc7\x3c\xe2\xa3\x46\x2f\x82\x85\x35\xd1\x5e\x55\x45\xb1\xeb\xeb\x12\xba\xad\x36\x24\xdb\x28\x78\x1d\xe0\x5e\x17\x05\x69\x8a\xc3\xad\xbd\x23\x8a\x29\x80\x26\x6e\x7f\x32\xc5\x8e\xe5\x4b\x4a\xd8\x23\x99\x76\xd4\xd5\xf3\xc2\x5b\x7a\x64\x01\x1f\xd4\x0e\x48\x52\xac\x8b\x1b\xeb\x02\x1a\xd2\xd8\x46\x82\x23\x9c\xd5\x26\xfc\xdb\x5f\x07\xb9\x4a\xd8\x68\xbd\x67\x27\xbd\x45\x4c\xdb\xe6\x9b\xa1\x35\x7f\x79\x77\x09\xac\x9d\xec\x1d\x48\x3b\xd9\xf2\x54\xa8\x1d\xe7\x80\xcb\xa9\x63\x56\xe2\x22\xaf\x84\x76\xd8\x75\x6b\x31\x8c\x35\x66\x2e\x16\xad\x6a\x61\x8d\xf2\x55\xcc\x88\x5d\x55\x63\x08\x14\x49\x66\xc9\x82\xc8\xee\x73\xed\x30\x0b\x22\xa7\xc0\x11\x6d\xd9\x97\xbe\x8a\x30\x88\xa3\x60\xb3\x74\xed\x01\x1f\x4a\xcc\x42\xed\x34\xe2\x26\xe0\xb9\xb1\x40\x21\x02\x1d\xdc\x69\xaf\x97\x45\x3f\xc6\xb2\xb6\xd4\xa4\xd8\x08\x65\x61\xb4\x2a\x87\x2a\xdb\xc4\xd5\x70\x60\x78\x01\x6a\x15\x50\x10\x3d\x73\x57\xf7\x15\x2a\xd4\x8c\x9b\x81\x35\x0c\x07\x10\x56\xda\xa8\x42\xff\x4e\x78\x8f\xde\xc1\x6b\xde\x96\x61\xb7\x80\x0b\xcf\x4b\x04\xe5\xf7\x6e\xec\x11\xe6\x07\xc9\xee\x95\x26\xb0\x12\x70\xeb\x67\x1d\x36\x2f\x0b\x9b\xdd\x92\xec\x3e\xa5\xd7\xf6\xf4\x6a\x28\x44\x7a\x0c\xb3\x96\xef\x4b\x2e\x92\x41\xa4\x21\xc1\x5b\x97\x90\xcc\xaa\x72\x61\x43\xc1\xcb\x44\xec\xbf\xaa\x08\x27\xcd\xfa\xa2\x2a\xc2\xc6\x56\xeb\x0d\x19\x68\x42\x42\xc9\x7a\x20\xa6\x44\x35\xd7\xe3\x0d\x49\x6a\xa5\xd3\x76\x20\x8c\x58\x59\x23\xb1\x7d\x01\xef\xac\x03\x7c\x50\xdb\xb2\xa0\xec\x82\xf5\x29\x26\x18\xac\x69\x02\xc1\x14\x94\x96\x35\x2c\x52\x1e\x0a\x24\xaf\x5f\x26\x97\x24\x5a\xf5\x4b\xb5\xa4\x9b\xc5\x1e\x48\xfe\xac\xf7\x1e\x4d\x4e\x61\xae\xd1\xf7\xda\x15\xed\x27\x53\x74\x79\xbd\x16\xac\x27\xf8\x45\x44\x46\xb2\xd7\x46\x92\x41\x9b\x2f\xe0\x22\x6a\x92\x0a\xad\x45\xcc\xf8\xff\x71\x11\x7d\xf4\x46\x8b\xa2\xb5\x80\x82\x8d\x72\x79\x7b\x11\xe9\xa5\xcf\xaf\xaf\x7e\xfe\xe5\xea\xfd\xfb\x17\xbd\xd7\x93\x5a\xf7\x05\xc5\xab\xc8\x0a\x54\xa6\x2a\x67\xd1\x89\xa6\x45\x36\xbe\xf4\xe2\xf3\x15\x67\x12\x92\xca\x52\x48\xcc\x18\x9f\x19\x0c\xf7\xd6\xdd\xf6\xc8\x96\xca\x05\x86\xe9\x7e\xd6\x71\xef\x24\x23\x1f\x68\x1b\xf8\x40\xea\x9c\xcc\x29\x0a\x96\x75\x74\x06\x95\x09\xba\xef\x51\x94\x01\x95\x6f\xb5\xd1\x3e\x38\x15\xac\x23\x3d\x52\x55\xb0\x5b\x25\x5a\x63\x33\xf4\x1e\x32\x45\x09\xb1\x30\x06\xbb\x7a\x36\xe0\xff\x38\xcc\x34\x61\x85\xb0\xc8\x2a\x61\xb8\x59\x23\xec\xda\xca\x22\x24\x8d\xbb\xd9\xa8\x3e\x45\xb1\x1c\x34\x8d\xd3\x23\x6c\x30\x86\x05\xf6\xdd\x68\xfd\xa6\x21\x43\x6d\x51\x6c\x21\x88\xff\xe7\x88\xa1\x71\x68\x93\x31\xed\x43\xe5\xd9\xe3\xb0\x57\x4c\xd1\xbd\xc5\xea\xc6\x8a\x1b\xa5\x74\xb8\x26\x5d\xe8\xc5\x60\x80\xb7\x2a\xdb\x00\x9a\xe0\x76\x31\xa9\xd3\x39\xed\x71\xa5\xd1\xd5\x15\x19\x87\xbe\xb4\x86\xa3\x02\x64\x76\x5b\x5a\x83\x26\x3a\x0e\xb2\xb3\x81\x50\x59\x9b\x86\x50\xae\xd7\x41\x8e\x99\x15\x67\xd0\xe5\x76\x75\x66\x48\xae\xc6\x9a\xb9\xd1\xc5\x8c\xe9\x6a\x8c\x6e\x42\xc7\x50\x41\x0a\x9d\x10\x48\xc4\x38\xfb\x1b\xe6\x58\xf0\xa8\x24\x58\xfe\xa5\x9c\x53\xdd\x30\xbb\x46\x43\x98\x19\x0f\x26\x69\x27\x3f\xb7\xee\x8c\x4c\xb6\xa5\x24\xe6\xe4\x21\x56\xfa\x61\x26\xc9\x57\x07\x36\xf4\x23\x05\x01\xbe\x48\x8a\x1c\xb9\xd1\xbf\x55\x31\x1b\xfb\xf4\xf1\xfd\xff\xc0\xd5\x3b\x7e\x9a\xdf\x22\x68\x64\xa3\x7c\x63\x64\xa5\xb3\x77\x3a\xef\x73\x04\x44\x1c\x6d\x08\x43\x8b\x11\xf7\xca\xd4\x1d\x86\xca\x19\x81\x0c\x4d\x85\xa5\xc1\x41\xa3\x99\x5f\xd8\x28\xd3\x90\x29\x95\xf7\x35\x5c\x92\xf8\xc9\x24\x18\x41\x2e\x59\xb3\x96\xda\xc4\xa2\x41\xbd\xc1\x7e\xc4\xa8\x56\x2b\xfd\x20\x21\x28\xed\x29\x92\xdb\x44\x64\xc0\x69\x6a\x53\x9e\x04\x57\x15\xe8\x13\x6c\x20\xfe\xf4\x9d\x9b\x80\x90\x54\x7c\x5b\x22\x04\x57\x99\xac\xed\x85\x0a\x34\xeb\xb0\x49\x2a\x2a\xab\x60\x3f\xa3\x1d\xb3\xa6\x47\x73\xab\x6e\xc5\x06\x64\x71\x51\x5e\xd6\xb4\x64\xcc\xfe\xae\xc7\x7e\x5f\x62\x46\x06\x38\x10\x82\x08\xaa\x6e\xb0\x56\x03\xc9\xc1\x25\x40\xc4\x80\x98\x30\x27\x71\xf6\xe3\xa7\x9b\x28\x3c\x50\xf0\xd7\x97\x7f\x87\xf9\x40\x5c\xf7\x01\x55\x3e\xab\xd3\x03\xd4\x0c\x5b\xe2\x63\x3f\xbd\x7c\x05\x97\x92\x7b\x52\x0c\xf9\xdb\xcb\x97\x22\x9d\x2f\xa8\xbc\x35\xb1\x30\x47\xf6\x6b\xab\xa1\xe4\x33\xd7\x99\x0a\x82\x06\xda\xea\x9a\x71\xf5\x25\x02\xa7\x95\xad\x4c\x9e\xc2\xbd\xe0\xf0\xa2\xb0\x21\x60\x3e\x80\x95\xe2\xfe\xa3\x06\xc6\x32\x8e\x43\xf2\x31\xcf\x93\x4d\x15\xbb\x3e\xf4\xe4\x85\x70\x66\x3a\xa0\xa4\x08\x5f\x88\xc2\x5c\x60\xc6\x06\x55\x8e\xee\x05\x8b\xe6\xa2\x2c\x0b\x4d\x5b\x27\xa7\xa2\x57\x90\x2c\x98\xc3\x5e\x92\x52\xdf\xa0\x9e\x36\xce\xe8\x1c\xb7\xa5\x0d\x68\xb2\xdd\x7e\xa8\x19\x75\x5b\x51\x41\xf6\xca\xe2\xb0\xef\x9a\x2e\xc0\x53\xa0\x24\x84\x62\x24\xef\xec\x94\x2a\x54\xda\x64\xd6\x22\x08\x76\x35\xc8\xc3\x1c\x3d\x5b\x82\x0f\x2a\xe0\xe2\x98\x8c\xfe\x49\xf2\x41\xee\x98\x1c\x13\x36\x4f\x2e\x4c\xfb\x66\xa9\xd1\xb0\x04\x6c\x51\xd4\x35\x33\x34\x2b\xcb\xf5\x2e\x6f\xb7\x69\xcd\x03\x8a\x7d\xa7\x9c\x56\x26\x50\xca\x18\xa3\x6e\xaa\x19\x45\xd4\xdd\xcd\x09\x95\xc4\x27\xbb\xea\x2c\x77\xc8\x5f\x12\x52\xba\x93\x92\xe5\x0e\x03\x28\x4e\xd5\x6c\xa7\x20\x24\xc0\x4b\x17\x64\x90\x8c\x01\x3a\xb8\xb1\x47\x94\x9c\x22\x07\x00\x8a\xdc\x04\x0b\x48\x95\xeb\x55\x50\x0a\x44\x06\x7f\xaf\x3d\xce\xf6\x50\x44\x46\x31\x3f\x47\x37\xe0\x88\x2a\xd3\x22\x91\xb2\xd3\x8d\xce\x73\x34\xf0\x5c\x1b\xde\xee\xd9\xbd\x0a\xd9\x86\xff\xb9\x46\x0a\xce\x45\xe1\x5f\x08\x14\x10\xfb\x9d\x60\x80\x39\x0d\x94\xa9\x16\x3a\xd3\x94\xea\x2a\x7f\x2b\xe1\xc7\x2e\xd9\xbf\xed\xbd\xbf\xae\xcd\x0e\x54\x96\xfe\x9b\x51\xa3\x69\x6f\x4b\xfc\xd9\xac\x83\x2d\xc9\xf5\x95\x51\x65\x5b\x88\x62\xb0\x7e\xcd\x1e\xa8\x72\x8e\x5d\x10\xf6\xc4\x1a\xcb\x28\xa5\xd3\x77\xba\xc0\x35\xe6\x9c\x73\x49\x3d\x4d\x72\xc4\x7e\xa8\xe0\x32\x73\xf3\xde\x98\x97\xea\x26\xfb\x9d\xa5\xf4\x30\x7a\x4d\x7e\x82\x5c\x53\xcc\x33\x7b\x24\x97\x3b\x50\x66\xc7\xaf\x66\x57\xf6\xe6\xed\xe7\x2f\x6f\x2f\x2f\x6e\xde\xbe\x81\x79\x67\xb9\x5c\x22\xa7\x84\xa1\x28\x37\x2a\xaa\x2c\xc9\x6c\x10\xd9\xb5\x8a\x47\xda\xc0\xdd\xab\xc5\xab\xbf\x2d\xf6\x9d\xd2\x58\xa7\x82\xff\x27\xd9\x61\xff\x1f\xfb\x7d\xc2\x98\x45\x8e\xda\x4e\xec\x1c\x10\x14\xc6\x07\xcc\xaa\xd0\x8f\xe9\x20\x69\xab\x14\x3c\x6b\x98\xdc\x24\x58\x84\x42\xa4\xd4\xb1\x10\x2d\x91\x0e\x9d\x0f\x69\x95\x23\x14\x3b\x2e\x24\x72\x23\x15\x42\x60\xa5\x74\x41\x0b\x77\xe8\xab\x22\xb4\x6a\x06\x38\x6d\xfa\x74\x49\x33\xa5\xc6\x55\x5c\x67\xb5\x6c\xe9\x29\xee\x0d\xd9\x26\xe1\x9a\x96\x31\x0c\x52\xa6\xe7\xe3\x5e\x89\xa4\x2a\x8a\x64\x82\xfd\xe0\x35\x8a\x91\x0f\xc9\x56\x2e\x33\x00\x87\x9b\xab\x23\xe4\x76\xe7\x22\xe5\xa4\x2c\x56\xe6\x6b\x93\x72\x50\x1a\x52\xef\x70\x4c\x2e\x72\xb5\xdd\xe4\xe8\x6d\x13\x60\x5f\xae\x84\xea\x86\xf7\x31\xe7\x85\x0f\xfe\x6b\xb4\xa1\xd3\xfe\x77\x3f\x95\x90\x77\x92\xc2\x1c\x34\x8c\xab\x55\x57\xb5\x04\x8e\x11\x07\xdf\x29\x5d\x54\x0e\x13\x94\x9d\xc8\xa3\x20\xd5\x47\x96\x08\x25\x3a\xaf\x7d\xac\x07\xfa\x60\x9d\x5a\x63\x52\x37\x93\xf2\x48\x4a\xb7\x7c\xe5\xa4\x7b\x41\x21\x6f\xd0\xe3\x00\xf7\x7a\xa4\x77\xc0\x99\x58\xf4\xd5\xed\x54\x6f\x48\x28\x87\x74\x6a\xb8\xc5\x3f\xca\xa1\xc7\xb6\xfb\x47\xd5\xa4\x3b\x06\xf0\xd8\xd6\xff\x28\xd9\xc1\x91\x80\xc7\x8c\x01\x8c\x52\xfe\x13\xc7\x03\xda\xd7\x41\x73\xca\x6c\x3e\xea\x12\x3a\xa2\xbb\xae\xd6\x6b\x29\x7e\xff\xe7\xcd\xcd\xe7\x94\x83\xd0\xe3\x4d\xf3\x83\xe0\x65\xe5\x67\xf0\x12\x74\x1f\x87\xa6\x2b\x96\xa5\xc6\x5c\x40\x0b\x69\xbe\xfe\x69\x72\x57\x43\x88\xb3\x59\x7a\x50\xba\x18\x75\x84\x9d\x9d\xbd\x7d\x08\x68\x28\x51\xcd\x55\x50\xa0\xbc\xb7\x99\x66\x70\x5c\x9b\xaf\xe3\x8c\x6a\x21\x05\x99\x09\x9d\xe4\xbc\x8b\x34\x43\x74\x1b\x74\xf0\x60\xef\x0d\xb7\xcd\xe5\x0d\xb2\xac\x3d\x08\x3a\x4a\xb1\xae\x44\xa4\x18\xc3\x2b\xac\x53\xfe\xc1\x66\x63\x66\x09\x25\xf7\x71\x71\xcd\x3b\xcb\xd8\x23\xda\x19\x3e\x64\x58\xc6\x72\x91\x2c\xba\xce\x09\xe2\x76\x88\xd7\x63\xb2\x3a\x1c\x71\x00\x32\x55\xf9\xa9\xff\x0f\x74\xcd\x2f\xf9\x11\xf1\xc5\xa0\x4d\x56\x54\x39\x7a\xd8\x92\xe5\x44\x06\xb6\xa4\x34\x41\x18\x1a\x09\x5e\xb3\x66\xc6\xcc\x78\x25\xde\x78\x01\x1f\x6d\xe0\x78\xdb\xfe\x2f\x63\xc1\x49\xa2\xb1\xb0\x11\xd7\x82\x79\xdc\xe2\x78\x4c\x9b\x8c\xda\x2d\xaa\x07\x79\x29\x17\xab\xcd\xa1\x9b\xf6\x13\xac\x9b\x4d\x2a\x3c\xc5\xa0\xde\x1d\xf3\xa0\x44\x84\xb7\x31\xcd\x4f\xb9\xd8\xd6\xd1\x39\xeb\x66\x04\x70\x28\xe2\xb2\xd6\x90\xba\xff\xd7\xf5\xa7\x8f\xe0\xd1\x31\x1e\x50\x63\x61\x65\xff\xfa\xd0\x08\x1a\x72\x12\x8a\xc9\xa1\xb4\x3e\xac\xf4\x03\xa4\x09\x0d\x76\x33\x86\x5d\xd0\x11\x14\x55\x10\xf7\x49\x3e\xf7\x82\x14\x49\xb0\xf4\xef\xe8\xec\x5c\x9b\x1c\x1f\x28\xbb\x82\x77\xc4\x91\xc3\x12\x8f\x24\xcb\x12\x95\x13\x3d\xe4\xea\x19\xb7\xc5\x34\x67\x30\xa2\xab\x76\x15\x75\x01\xf2\x81\xe2\xd8\x00\x23\xad\xc8\xc4\x53\x5e\x45\x11\x7c\x5b\x15\x41\x97\x05\x0a\x77\x29\x5b\x89\x1e\x80\xd3\x84\xb7\xd2\x29\x3a\xa8\x20\x74\x7d\x03\xf8\x76\x42\x92\xf9\x76\x02\xf3\xd8\x92\x23\xe9\xd7\x3f\xc6\x5a\x57\xcc\x95\x8e\xa0\x58\x2b\x0c\x51\x66\x85\xfe\xc7\xcb\x7f\x2e\x26\x5e\x71\x04\xcd\xb8\x88\x95\x76\x3e\x44\x1e\xc6\x72\xb7\x49\x2f\xf9\x76\x72\x98\xd0\xc1\x28\xd7\x5c\x5b\xf4\x5e\xad\x27\x50\x70\xba\xf6\x6a\x31\x9b\x6a\xab\xcc\xdc\xa1\xca\xb9\x91\xda\xfa\x6f\x3d\xdf\x43\x92\x3f\x66\xcf\x72\x3b\x4b\x78\x01\xed\x48\x10\xab\x9b\xcd\xac\x86\xf2\xf3\x89\xe8\xd0\xda\xbf\xe5\xb9\x2d\x95\xa3\x3b\x6c\x6d\x8f\x60\x96\x84\x80\x47\xf3\x6a\xab\xb2\x8d\x36\x38\xc5\xad\x23\x36\xc5\xfc\xdc\xe3\x56\x2a\xc7\x4a\xd5\x36\xe5\xdf\x74\x87\x3b\x86\x24\x07\x4c\x46\x5f\x84\x31\x68\x35\xea\x4e\xe9\x82\xd6\xf8\x84\x7c\x3b\x90\x68\x74\x6f\x1b\x4e\x38\xd2\x25\xf3\xc1\x8f\x89\x9d\xfc\x44\xe3\xfd\x7a\xde\xfe\xb1\x81\x53\x20\x5d\x27\x42\x4e\xb1\xea\x28\x26\xed\x8f\xaa\x4e\x6e\xea\x94\x76\x45\x4f\xfc\xc1\x9b\x82\x4f\x46\xea\x8a\xcd\xb8\x95\x40\x39\xee\xa0\x4c\xd2\x6d\x75\xf2\xd2\x80\x48\xbd\xb4\x5f\xb4\xc9\xff\xa4\x71\xd5\xef\x92\xc5\x74\x49\x60\x6c\xa4\xf1\x0f\x15\x05\x3c\x8f\x63\x76\xe8\x30\xce\x2c\x6b\xb3\x2e\x70\x3c\xb5\xaf\xa9\x72\x99\x98\xf2\xdb\x65\x72\x3a\x4b\xcc\x5f\xfc\xb0\xc2\x72\x13\x83\x3b\x10\x23\x53\x62\xa3\x1c\xbb\x5a\x35\xbd\x88\x59\xbb\xe9\x51\x4f\x90\x35\x3d\xe2\xc9\xad\xd5\x5a\xd9\x9a\x8f\x95\x89\xdb\x7c\x01\xd7\xa4\xb7\x02\x19\xe2\x1c\xb6\xf4\x54\xa6\xdd\x54\xd3\xab\xe1\x52\x5d\x50\xb7\xb1\xd6\xc8\xd9\x6e\x40\x50\x19\xbf\x70\x1e\x13\x3c\xeb\xd3\x4b\x0e\xd0\xed\x04\xb4\xb4\x16\xd8\xd8\x7b\x19\x11\x0a\x16\xee\x95\x0e\xf5\xce\xd5\xed\x41\x8f\xba\xc1\xde\xb2\xa6\x84\x7a\x4c\x0e\x09\x47\xe5\x91\x74\x55\xfa\x11\xde\xea\xeb\xd5\x9b\x7d\x9b\x58\x8c\x29\xf4\xe4\x9e\x9b\x91\xb6\x11\xa5\x7e\xf4\xb0\x73\x33\x3c\xe0\xff\x52\xe9\x1f\xf6\x1d\x07\xc3\xdc\x94\x9b\x7f\x82\xd3\x09\xe3\x19\x6e\xab\x8e\xfc\x3d\x27\x15\x26\x08\x37\xdd\xcd\xef\x39\xb5\x30\x4a\xf8\x4f\x0f\x0f\x07\xc5\x7b\x00\x26\x3f\x1a\x1c\x47\x37\x7f\xa8\xac\x57\x7b\xb9\x31\x5e\x1d\xb1\xf0\xfe\xf1\x8c\xd1\x95\x9f\x5e\x07\x65\x72\xe5\x72\x69\x63\x34\xc7\x13\xfe\x74\x81\x1c\x55\x49\xb1\x64\x09\xd5\xf1\xe1\x3a\x3d\xd0\x3e\xc4\xa1\x57\xf5\xe4\xaa\x0c\xf8\x43\xa1\xb7\x7a\x3a\xff\x8b\x59\x9a\xa9\xa7\x9f\x39\x31\xab\xeb\x50\x71\x02\x36\xfa\xf9\xd8\x26\x38\x14\xcf\xe2\x28\xc4\x46\xa5\xc2\x0e\xd7\xde\x6a\x34\xce\x50\xa3\x46\xf9\xb6\x54\xbf\x55\x38\x38\xf8\xd7\xbe\xe2\x36\xd3\x59\x09\xed\x3d\x3f\x64\xe3\xd0\x44\x1c\xa9\xb4\xfb\xc7\x92\xd4\xf4\xee\xe5\x08\x4a\xab\xef\x18\x6c\x7d\xd6\x45\xf8\x82\x0f\x75\xaf\xb1\xde\xc1\x34\x43\x53\x4f\xf4\x52\x24\x24\xfd\x7c\x6e\x1b\xf9\x40\xee\x45\xd4\xb1\xe9\x28\x96\xd6\x0f\xcf\xfd\xb6\xaf\x28\xda\xc8\xd9\xcc\x9a\x95\x5e\x57\x11\x34\x70\x7d\x67\xa3\xcc\x5a\x66\x45\x9a\x1a\x86\x9a\x46\xb6\x78\x0f\x5b\x6d\x2a\x12\x2b\xf7\xbe\x9b\x39\xa1\x26\xbe\xa5\x82\xbe\xc4\xfc\xa4\x15\x07\x80\x1a\x1a\xa8\xbc\xf8\x75\xe9\x98\x89\xa6\xb6\x46\x8f\x96\x18\xc7\xdd\xb2\x7a\x06\x75\x92\x66\xd4\x96\x76\x45\x21\x36\xaa\x70\x06\x95\x29\xd0\x7b\xd8\xd9\x4a\xf6\xe1\x30\x43\x3d\x74\xb2\xa8\x7d\xc9\x3c\xa7\xbd\x45\x23\x41\x42\x19\xc1\x3f\xc9\x3b\x3e\x01\xae\xec\x70\xf0\x78\x94\x71\x1d\x9a\x86\x4f\x1d\xd6\x7d\x4b\xfc\xa7\xa7\xbe\x6e\x5b\x4c\x73\x2d\x0a\x2f\x9d\xaf\x4c\xe7\x17\x88\x72\xc4\x1c\x69\xfc\x2d\xf5\x8f\x06\xc6\xa9\xba\x2b\x4d\x53\xab\x2c\xe5\xa8\xeb\xc2\xf6\xa8\x82\x0b\xf8\x55\x46\xb4\xe3\xb4\x64\x90\xae\xff\x24\x59\x55\xbb\x81\xd6\x52\xb8\x4e\xc8\x2a\x09\x95\xa9\xdb\xee\x4b\x95\xdd\x1e\xa3\x31\x69\xce\xeb\x98\x03\x2e\x4d\x44\x98\x24\xf9\x04\xd1\x22\xb3\x46\x8a\x72\xd9\x6e\x1e\x47\x60\xe6\xca\xe4\xf3\xda\x3d\x64\xbb\x1f\xce\xfa\x3c\x16\xab\xf7\xda\xdc\x1e\xad\x71\xe9\x01\x41\x69\x5f\xbf\xbc\xdf\x07\x67\x47\xb4\x76\xe1\xb8\xb3\x44\x7f\x30\x2a\x9d\xae\x69\x3d\xb2\x92\x75\xbf\x89\x83\x21\x35\x70\x19\x5d\xbd\xae\xc7\xe6\x4f\x62\x37\xf8\x24\xa2\xa2\xe9\xb2\xd6\x54\x7f\x68\xb4\x98\x05\x17\x69\x0a\x30\x2b\x94\x13\xe7\xa0\x8c\x74\xee\xe4\xa5\x13\x28\x23\x47\x58\x56\x01\x72\x8b\xd2\x5f\xb2\x77\xe8\x9c\xce\x11\xf4\xa8\x70\x0f\x0a\x46\x5e\x7a\x34\x28\xab\xb1\x62\xab\x1c\xb3\x80\x4f\x06\xc1\xae\xce\xe1\xe4\xba\xca\x32\xf4\xfe\x64\x68\x5c\x27\x5d\x35\x97\x9f\x1a\xcd\x51\x3e\xcf\x06\x29\x7b\xfa\x4e\x88\x3d\xa1\xa7\x63\x13\x0e\xf3\x91\xd9\x97\x51\x52\x85\x5a\x62\xbf\x07\xfa\xc4\x27\x8f\x3f\x28\x1e\x0d\x8f\x89\xdb\x2d\xee\xc4\x2b\x4b\xbf\xbb\x1f\x47\x82\x05\xeb\xd6\xca\xe8\xdf\x07\x0e\x0a\x9b\x1c\x08\x42\xae\xad\xd3\xbf\x23\x3c\xe7\x0f\x1a\xc8\x99\x60\x2c\x30\x0b\x2f\x5a\x07\x7d\xd5\x0e\xb6\x3c\xc2\x26\xff\xb2\xce\x0f\xcd\x3e\x3a\x2c\x0b\x1e\x73\x25\x4b\xa8\xc7\x09\x7d\xa4\xe9\xee\x74\x36\xd0\x93\x3f\x98\x48\x0b\x5f\x8f\x3e\x30\xbc\x55\x46\xad\x31\x97\x5e\xd3\xf4\x18\xe4\x87\xf6\xad\xb0\x55\xa5\x87\x7b\xeb\x6e\x57\x85\xbd\x9f\x6b\x19\xfd\x4a\x01\x3b\xe2\xd8\xa1\x83\xa5\x76\x95\xda\x4a\x72\x7e\xc8\x61\x5a\x83\x78\x5d\x15\x6a\xaa\xb1\x13\xad\x09\x85\xfb\x50\xec\xe2\x3c\xcf\x08\x70\xd8\xd8\xca\xe3\x2d\x62\xa9\xcd\x5a\x50\xbf\x4c\xcf\x85\x5d\x49\x28\xad\xd8\xc5\xe2\x94\x39\x0d\x60\x62\x3f\x3a\x9e\xbc\xaa\x4c\x8e\xce\x87\x21\x08\xdf\x14\x8c\xc8\x6f\xa5\x95\x25\xad\x49\xd9\xca\xa9\x34\x1a\x67\x9d\xc1\xd0\xf4\x63\x9f\x05\xae\x99\x6d\x27\x58\xde\x0c\xcb\xaa\xb2\x2c\x76\x50\xaa\xb0\x81\x42\xdf\x22\x7c\x3b\xc9\xf4\x3c\xcb\xbf\x9d\x08\xa8\x8d\x38\x5e\xf8\xd7\x23\xcb\x67\x2a\xef\xd5\xae\xf6\xe5\xb5\x34\x62\xce\xd3\x2c\x9f\xb5\x7d\xef\x9c\xfa\x10\x20\x49\x43\x2b\xdf\xcc\xfe\x5c\x2a\xcf\xfc\x89\x4d\x30\x27\x5a\xf8\x3d\xcd\xf9\xdd\xeb\xb0\x19\x9a\xee\x36\x36\xe8\x0c\x7b\xd3\x7f\x23\x6d\xe8\xe9\xe4\xf3\xd0\x88\x4f\x37\x64\x4e\xce\xf7\xb4\xbe\xe2\xd1\x6a\x3e\x8f\x39\xd0\x86\x1b\x9c\xa8\xf2\xb8\x77\x3a\x25\x8f\xb1\xc6\x47\x8c\x3a\xe1\x9e\xc7\x59\x7c\xc7\x09\xfc\xab\xf2\x63\x34\x59\xe2\x5c\x85\xb5\xe5\xbc\x20\x0f\xdf\x5e\x71\xd4\xc1\x78\x8c\x1b\x29\xc4\x28\xb7\x63\x4b\x73\x2a\xeb\x9f\x0e\x4b\xeb\xec\xec\x4f\xb5\xd6\xbc\x44\x69\x62\x69\xf6\x81\x31\x97\x8b\x67\xbd\xc4\x60\x46\x68\xc6\x91\xa5\xa1\xf9\x75\x38\x1c\x5b\x56\x83\x9e\x46\xae\x41\xef\x0f\xc1\x8d\xf4\xab\x3b\xc2\x8d\x6e\xa9\x95\x70\xa8\xae\xbd\x4c\xad\x76\x14\x92\x89\x6b\x72\x47\x28\x97\x78\x47\xd7\x3f\x0b\x15\xa1\x42\x6d\x7b\x4c\x72\x02\x23\x6e\xd0\xe3\x11\x4b\x1e\x65\x70\x8d\x49\x8e\x58\xf4\xa7\xba\x70\x1f\xbf\xa8\x43\xb4\x69\xc5\x4d\x45\x5f\x2a\xbc\x05\xaa\xc1\xa3\x2a\x69\xcd\xda\x43\x27\x3c\xbc\xe5\x46\xf9\x12\xc9\xb1\xd4\x9f\x20\x20\xcb\xe0\x03\x11\x7c\xc2\x26\x46\xe1\x11\x92\xf5\xd8\x56\x9c\x2b\x76\x08\xa7\x17\xe4\x1d\x4f\xd9\xeb\x9c\x7e\xe5\x22\xe6\xe9\x77\x71\x28\xe8\xb1\xb6\x52\xb7\xa1\xa4\xe5\xcc\x46\x68\x9f\x32\x4b\xc5\xf2\x5a\x46\x70\x4f\x38\x78\x62\x66\xec\xaa\x3e\x6e\x12\xbd\x73\x7d\x02\x4f\xaf\xba\x02\x88\x1b\x1c\xa4\x73\xe8\x6c\xe0\x11\x1b\x9f\x50\xf5\xb1\x76\xef\x50\x03\xae\x8b\xb0\xf8\x60\x4b\x4a\x95\xe3\x51\x1d\x72\xfc\xda\x80\x6a\xbe\xf3\xb1\x80\x2b\xdf\x1c\x79\x1a\xfc\x46\x80\x1c\x83\x90\x01\x68\x19\x1b\x9c\x35\x27\x9c\xb9\xf7\xd9\x7c\x52\x64\xab\x76\xf2\x71\x83\xfa\xb8\xfa\x90\x6e\x36\xe7\x94\xb1\x7b\x0a\x85\x1b\x49\x25\x05\x16\xa7\x55\x48\x5d\xc3\xb6\xe7\x5b\x0c\x1f\xf6\xd2\x1e\x4a\xa7\xb7\xca\x69\x3e\x0a\x11\xe7\xe6\x48\x55\xeb\x43\x1c\xcd\x99\x1b\x01\x87\xdd\x4a\x57\x5e\x7f\xa8\xab\xaf\x2d\x03\x05\xfa\x1f\x69\xa2\x30\xef\x87\x61\xe0\x80\x7e\xd4\x92\x9a\x86\x80\x1f\xeb\x0f\xb7\xb4\x03\xa8\xfc\x12\xa5\x8e\x2a\xdb\x08\x47\xbb\x5a\xd1\xdf\xf0\x85\x89\x76\xd0\xfa\x1c\x8c\x07\x52\x92\x3b\x55\x88\x4c\x99\xfc\xb7\x93\x1c\x57\xaa\x2a\xc2\xb7\x93\xe6\xd6\x19\xa5\x81\x3d\x92\xed\x5b\xa3\x47\xcb\x94\xb1\x86\xcb\x74\xdd\xb1\xdc\x66\xc0\x2e\x15\x81\xc8\xc7\x24\x1d\xed\x1b\x8f\x7c\x29\x85\x40\x7f\x2e\x23\x2d\xcd\xaa\xe7\xad\xc3\x7a\xb6\x73\x26\xaf\xe9\x4d\xc6\x97\xf4\xe8\xa6\x6a\x62\xfc\x52\xc1\x37\x53\x9f\xd2\x55\xf0\xe6\xe3\xf5\xff\xbe\xbf\xf8\x8f\xb7\xef\x07\xbb\x37\x13\x45\x9f\xa3\x94\xa5\x5e\xbf\x3f\xfa\x70\x98\xbd\x37\xe8\xbe\x20\x1f\xda\xcc\xfa\x80\xac\xa3\x2b\xef\xe3\xd9\x8b\xc4\xdd\x1c\x4b\x31\x97\xe5\xae\x77\x26\xe9\xe2\xfd\xfb\x51\x06\x45\x2c\xcb\x45\x67\x2e\xd3\xf1\x91\xa4\x7a\xbe\xbc\xf3\xbd\x9b\xc8\xcb\xb5\x72\x4b\xb5\x46\xc8\x08\x86\x67\x83\x40\xe5\x6a\xb5\x7f\xa2\xa3\x95\x84\xb4\x41\xfc\x4c\xe6\xd9\x95\x69\x66\xbf\xea\x62\xfb\xb0\x30\x63\xe5\xde\x36\xc5\xe3\x44\xa9\x9e\x2b\x68\x1d\x1e\x6b\xf0\x18\x23\xb9\x21\x3b\xb9\xe1\x4a\x4b\x83\xd1\xda\x33\x7e\x58\xc3\x89\x16\xd1\x23\x8f\x2e\x3f\x2d\xb2\xee\xc2\x68\xb2\x24\x39\xdb\xfb\x5d\x11\x9a\xbf\xb2\xf1\x89\xb4\x2d\x7d\x86\xe5\x88\x45\x90\x4c\x5d\x85\x33\xb8\xf8\xf8\x26\xf5\x1b\x58\x63\xeb\xe3\xbd\x27\x2b\xeb\x90\x00\xb9\xc9\x13\xdd\xb1\xf9\xbd\xfa\x48\x7d\x54\x80\x86\x58\x23\x88\xde\x61\xf9\x5b\xdc\xcd\xd9\x0d\x8c\x10\x95\xef\x91\xf1\x97\x17\x52\xaa\x11\x6d\xa9\x75\x22\x68\x01\x6f\xc4\x87\xf1\xa4\xff\x4a\x15\x1e\x17\x70\x33\x06\xbd\xea\x6f\x2a\xa5\x83\xc8\xd2\x3d\xa3\x04\xd7\xc3\x89\xac\xf0\x04\x4a\x74\x5b\xed\xdb\xe2\xe1\xbd\xf4\x53\x53\xb9\x6c\x3a\xd8\x07\x7f\xfd\xe9\x27\x78\xfe\xd5\xc4\x43\x36\x5c\x65\x7c\x6b\x82\x0e\xbb\x17\xad\x6f\x02\x49\x4f\x65\x4a\xd0\x4b\x6b\x0b\x54\x43\xf5\xc7\x46\x6b\x1f\x23\xe1\x3d\xe6\xb1\xc9\xd5\x07\x23\x8e\xb0\x88\xe3\xd6\x36\x3e\x23\x30\x30\x21\xb0\xaf\xf6\x7f\x76\x9b\xf6\x80\x45\x8d\x8f\x52\x0d\xe0\xb9\x43\x7b\xf9\x71\x20\x72\xd4\x9a\x47\x67\x5b\x26\xa6\x5a\x9e\x62\xc5\xe3\xf3\x27\x93\x0b\x1e\x3f\xfc\x35\x6f\x79\xd3\x81\x7f\x92\x54\x07\x7e\x1e\x9c\x28\x9b\x13\x57\x9e\x02\xda\x1f\x68\xef\xf5\x4e\x40\xc7\xfe\x96\xa0\x1c\xae\x28\x35\xe3\x2b\xf1\x94\x62\x3a\x88\x54\x07\x82\xe1\x6a\xda\x51\x5d\xbc\x91\x4e\x5d\x1f\xea\x74\x3a\x77\x1f\x5a\x4d\x76\xc2\x5e\xb6\x0c\x7a\xab\x7d\xd0\x19\xb4\x3a\x57\xb3\xf8\x00\xbf\x83\xe7\xb5\xc6\x3f\x18\x20\x47\x91\x9b\x74\xd8\x9a\xf6\x57\x28\xad\x4b\x35\x86\x3a\x39\xa9\x3f\x83\xd7\x23\x29\x83\x6c\x94\x28\xc4\x04\x32\x96\xa1\x55\x7b\x86\xe0\x91\x1d\xc3\xd4\x25\xe4\x4f\x56\x6e\x5b\xdf\x51\x93\x14\x9b\x78\xa0\xe4\x43\x41\x59\x55\x28\x37\xb0\xf2\x01\x35\xae\x77\x32\xfe\x4d\x9d\x4e\xfb\xf1\xb8\x7e\xe9\x68\x8f\xf4\xa9\x5d\xe5\x11\x3d\xca\xa3\x11\xef\x58\x2f\xb2\x7b\xfa\xec\xf8\xfe\x63\x87\x9f\x03\xe6\x71\x44\xcf\x71\x74\xad\x03\xee\xb2\x6b\xc5\xe4\x28\x63\x56\x14\x33\x75\x6d\xe2\x87\x33\x4c\x1e\xb3\x38\xb1\xef\xbd\x2f\x06\x0e\xe0\x67\xc6\xcc\x4d\x69\xbd\xf9\xae\x48\xf7\x0b\x76\xd6\x80\x97\x7e\xd8\xaa\x2a\x9a\x2c\x79\x40\xed\x5a\x56\xd5\xfa\x66\x5d\xfa\x84\x61\xb0\xc9\x66\xad\x81\xcf\x5f\x6f\x3a\xdf\x9d\x6c\xab\x69\x8f\xee\x31\x5d\xf3\xef\x0b\x11\x47\x2a\xd1\xa0\x6f\xde\xa2\xdf\x9c\xf7\x6e\xda\x7b\x36\x7d\x88\x7b\x92\x52\xbf\x79\x39\x70\xdb\xde\x4f\xd1\x43\xf3\x53\xf3\xf8\x31\xf0\xbb\x57\x5c\xd3\x7f\xc5\x4f\xc8\x58\x51\xab\xf4\x1a\x0f\xf8\xc6\x5f\x9a\x77\xaa\x2c\xc3\x32\x60\xfe\x71\xff\xd3\xe0\xf1\x60\x4c\xfa\x1e\x38\xff\x99\x59\x23\xf5\x5d\x7f\x0e\xff\xf8\xe7\xb3\x88\x87\xf3\x5f\xd3\x6a\xe8\xc7\xff\x0b\x00\x00\xff\xff\x2f\xcb\x27\x69\x0d\x5d\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3c\xdb\x72\xdb\xca\x91\xef\xfc\x8a\x2e\xe6\x41\x76\x15\x49\xdb\xe7\x24\x5b\x1b\xbd\x69\x6d\x9f\xac\x36\xbe\x95\x65\x67\x6b\x2b\x4e\x6d\x0d\x81\x26\x39\x11\x30\x83\x33\x33\x90\xc4\xf3\xf5\x5b\xdd\x73\x01\x88\x1b\x21\x5b\x49\x96\xf4\x83\x05\x02\x8d\x9e\xbe\xdf\x66\x16\xeb\xf5\x7a\x21\x2a\xf9\x17\x34\x56\x6a\x75\x09\xa2\x92\xf8\xe0\x50\xd1\x5f\x76\x73\xfb\xef\x76\x23\xf5\x8b\xbb\x57\x5b\x74\xe2\xd5\xe2\x56\xaa\xfc\x12\x5e\xd7\xd6\xe9\xf2\x33\x5a\x5d\x9b\x0c\xdf\xe0\x4e\x2a\xe9\xa4\x56\x8b\x12\x9d\xc8\x85\x13\x97\x0b\x80\xcc\xa0\xa0\x8b\x5f\x64\x89\xd6\x89\xb2\xba\x04\x55\x17\xc5\x02\x40\x89\x12\x2f\xa1\x32\xfa\xe1\xe8\xb0\xac\x0a\xe1\xd0\x6e\x6e\xeb\x52\x6c\xa4\x5e\xd8\x0a\x33\x7a\x7c\x6f\x74\x5d\x5d\x42\xbc\xec\x9f\xb2\xf4\x0b\x80\xc7\xe2\x93\xd1\x0f\xc7\x2f\x01\x00\x5f\xaf\x8a\xda\x88\xa2\x0b\x7a\x01\x60\x33\x5d\xe1\x25\x2c\x97\x0b\x80\x3b\x51\xc8\x9c\x31\xf3\xc0\x74\x85\xea\xea\xd3\xf5\x5f\x7e\xbe\xc9\x0e\x58\x32\xea\x74\x39\x47\x9b\x19\x59\xf1\x7d\xa7\xaf\x02\x69\xc1\x1d\x10\xfc\xfd\xb0\xd3\x86\xff\x3c\x7d\x29\x5c\x7d\xba\x0e\x90\x2a\xa3\x2b\x34\x4e\x46\xec\xe9\xdb\x22\x78\xba\xd6\x79\xe7\x05\x21\xe5\xef\x81\x9c\x48\x8c\xfe\xb5\x77\xfe\x1a\xe6\x60\x3d\x02\x7a\x07\xee\x20\x2d\x18\xac\x0c\x5a\x54\x8e\x17\xd7\x02\x0b\xa0\x77\x20\x14\xe8\xed\xdf\x31\x73\x1b\xb8\x41\x43\x40\xc0\x1e\x74\x5d\xe4\x90\x69\x75\x87\xc6\x81\xc1\x4c\xef\x95\xfc\x2d\x41\xb6\xe0\x34\xbf\x92\x56\x6d\xdd\x09\x44\xa9\x1c\x1a\x25\x0a\x22\x67\x8d\x2b\x10\x2a\x87\x52\x1c\xc1\x20\xbd\x03\x6a\xd5\x82\xc6\xb7\xd8\x0d\xbc\xd7\x06\x41\xaa\x9d\xbe\x84\x83\x73\x95\xbd\x7c\xf1\x62\x2f\x5d\x14\xb1\x4c\x97\x65\xad\xa4\x3b\xbe\xc8\xb4\x72\x46\x6e\x6b\xa7\x8d\x7d\x91\xe3\x1d\x16\x2f\x44\x25\xd7\x8c\xa7\xa2\xb5\xd9\x4d\x99\xff\xce\x04\xf1\xb3\x17\x2d\xc4\xdc\x91\xf8\x6c\x9d\x91\x6a\x9f\x2e\xb3\xb8\x8c\x92\xf9\xcf\x52\xe5\xc4\x52\x11\x1e\xf3\x2b\x6a\xa8\x49\x97\x88\x08\x9f\xdf\xde\x7c\x81\xf8\x52\xa6\x78\x0b\x24\x04\xe2\x36\x8f\xd9\x86\xce\x44\x17\xa9\x76\x48\x72\x22\x2d\xec\x8c\x2e\x99\xac\xa8\xf2\x4a\x4b\xe5\xf8\x8f\xac\x90\xa8\x4e\x69\x6c\xeb\x6d\x29\x1d\x31\xf6\xd7\x1a\xad\x23\x76\x6c\xe0\xb5\x50\x4a\x3b\xd8\x22\xd4\x55\x2e\x1c\xe6\x1b\xb8\x56\xf0\x5a\x94\x58\xbc\x16\x16\x9f\x9a\xca\x44\x50\xbb\x26\x0a\x9e\xa7\x73\x5b\xfb\x01\xc6\x85\x9f\xbe\xbc\x0a\x16\xd4\xce\x0f\x00\x22\xcf\xd9\x9a\x88\xe2\xd3\xc8\xc3\xa3\x18\x0c\xaa\x51\xf3\x26\x66\xb3\x82\x5a\x59\x67\xea\xcc\xd5\x06\x73\xb8\xc5\x63\xe0\x78\x29\x2a\xb0\x4e\xd3\xc5\x7b\xe9\x0e\xbd\x37\x8a\x36\xf7\x85\x63\x71\xdf\x22\x58\x74\xb0\x3d\x02\xd9\x4c\x56\x08\xa7\x75\x41\xac\xf2\xb0\x58\x31\x0c\x3a\x23\xf1\x0e\xfb\x20\xcd\x56\x3a\x23\xcc\x31\xd1\x6e\x03\x5f\x0e\x78\x04\x61\x10\x88\xcd\xbf\xd6\x68\x8e\x62\x5b\x78\x38\x41\x61\xb7\x08\xac\xe9\xe6\x0e\xf3\x1e\xc8\xfb\x03\x2a\x28\x75\x2e\x77\x47\x92\x5c\x2f\x96\x7d\xe5\xbb\x7c\xf1\xe2\xb6\xde\xa2\x51\xe8\x90\x2d\x7c\xae\x33\xfb\xa2\xb6\x68\xd6\xfb\x5a\xe6\xf8\xa2\xc5\xa0\x8b\xc5\x10\xe9\x3d\xe4\x93\x9f\xb2\xa2\xb6\x0e\xcd\x07\xb2\xef\x53\x3c\xf9\x72\x40\x36\xe7\x64\x97\xbc\xec\xf3\x73\x70\x7f\x90\xd9\x81\xb5\x21\x68\xd3\x16\x0b\xad\xf6\x44\x4d\xa2\x4b\x47\xe3\xe8\x9f\xb4\x50\x5b\xcc\x89\xdc\xb9\xb4\x4e\xaa\x7d\x2d\xed\x21\x31\xca\x32\x27\xc1\xd2\xbb\xf8\x85\x44\x45\xfa\x8f\xad\x44\x46\xe4\x80\x5c\xee\x76\x68\xba\x9a\xd7\x5a\x8c\xf5\x6f\x86\x9d\xc4\x82\xed\x04\xb1\x85\x78\x2e\xd4\xf1\xfe\x80\x06\xc1\xc8\xfd\xc1\x81\xd2\xf7\xcc\x23\x51\x49\xcb\x7a\x0f\x03\xe8\xee\x35\xf1\xc4\x69\x90\x7b\xc5\xfc\x70\x20\x77\x2c\x41\x52\x79\x87\x89\xa0\x4d\xd0\xec\xa8\xf7\x9b\xc5\x4c\xc9\xef\x7b\xdc\x29\x26\x2c\x5f\x77\x6f\xa7\xd5\x09\x70\xe9\xcf\x9e\x09\xf4\x0b\xeb\x00\x05\x7e\xc2\xcb\x1d\xdb\xb7\xc0\xbb\x7b\x61\xc3\x92\xc8\x44\xb9\x48\xba\x7d\x2d\x8c\x50\x0e\x3d\xd3\xbc\xfe\xf4\x20\x4a\x05\x07\x51\x55\xa8\xec\x7a\x8b\x3b\xa2\x94\x36\x39\x1a\x10\x99\xd1\xd6\x82\xc5\x4a\x18\xa2\x10\x99\x07\x5e\x83\xdd\xc0\x6b\x36\xa0\xde\xda\x2a\xdd\x87\x49\x54\x66\xfc\x58\xdb\x23\x4a\x69\x8d\x98\x93\x38\x7c\xfe\xe5\xf5\xcf\x3f\xff\xfc\x47\x72\xea\x25\xb3\x53\x5a\xba\xfc\xf5\xcb\xeb\x0d\x7c\x53\x3d\x98\x9f\x74\x55\x93\x73\xcc\xc9\x02\x90\xdc\xda\xa3\x75\x58\x6e\xe0\x33\x8a\x7c\xad\x55\x71\xdc\xc0\x87\xba\x28\x08\x1e\x14\xd2\x3a\xfb\xd4\xf6\x39\xda\x8d\x65\x07\x37\x5a\x80\x70\x97\x40\x2e\x62\x4d\x0c\x9a\x2b\x44\x39\x16\x48\x14\xfd\x93\x11\x19\x7e\x42\x23\x75\x7e\x83\x99\x56\xb9\x9d\x94\xa6\x0f\x75\xb9\x45\x43\x0a\x6d\xfd\xdd\x20\x8a\x42\xdf\x63\x1e\xe2\xa3\x46\x2e\x9c\x86\x3d\xc1\xde\xd5\x45\x71\xec\x80\x04\x70\x68\x4a\xa9\x88\xb7\x81\xf1\xd2\xc1\xbd\x2c\x0a\x72\x78\x06\x4b\x7d\x87\x79\xe3\x40\x23\xb5\x3f\xaa\xe2\x48\x72\xc4\x42\xd8\x03\x19\x57\x74\x2a\xe7\x85\xd5\xf4\xc8\x06\xde\x8b\x23\x10\xa7\xe8\x0d\xf6\xa0\x8d\x43\x85\x79\x9b\x83\x23\x94\x95\xca\xfd\xdb\xef\x3b\xbf\x79\xcb\x48\xb1\xd1\xbe\xa3\x27\x3d\x24\xa6\x75\xf3\xcd\x10\xce\x9f\x7f\x79\x0d\x2c\x9d\xc4\x54\x96\x4e\x62\x2c\x08\x97\x0c\xe7\x80\xc9\x49\x3e\x2b\x52\x91\x31\xc1\xbc\x6b\xd6\x82\x1b\x6b\xd4\x9c\x89\x09\x22\x31\x6b\x94\xae\x20\x53\x88\xd2\x28\x02\x79\x92\x55\xd4\x20\xd2\xfb\x5c\x1a\xcc\x9c\xe7\x93\x63\x8f\xb6\xed\x73\x5f\x84\x30\x88\x90\xc3\xc6\xdd\x4a\x0b\xf8\x50\x61\xe6\x92\xd1\x08\x8b\x80\x67\x4a\x03\xb9\x08\x34\x70\x27\xad\xdc\x16\x5d\x39\x07\x2f\x2d\x09\x14\x2b\xa1\x47\x8c\xb0\x32\x28\xb2\x43\xc0\x86\x5d\xd2\x73\x10\x3b\x72\x45\xb4\x06\xa6\xae\xec\x6b\xbd\x4b\x84\x5b\x81\x56\x1c\x0c\x22\xec\xa4\x12\x85\xfc\x8d\xe2\x3d\x7a\x07\x11\x05\xcb\xca\x1d\x37\x70\x65\x19\x45\x10\xb6\x73\x63\x0f\x30\x3f\x48\x7a\x2f\x24\x05\x2b\x0e\x4b\xbb\x3a\x21\xf3\xb6\xd0\xd9\x2d\xf1\xee\x63\x7c\x6d\xde\x15\x94\x1e\x50\xcf\xdb\x55\xcb\xf6\x45\x13\x49\x84\xac\x15\x31\x5e\x9b\x60\x89\x61\x57\x1b\x77\x20\xe7\xa5\x42\xec\xbf\xab\x29\x4e\x5a\xf5\xc0\x8a\xc2\x1d\x74\xbd\x3f\x80\x6c\x22\xa1\xa8\x3d\x10\x52\xa2\x44\xf5\x70\x43\xe4\x5a\x65\xa4\x1e\x70\x23\xf4\x42\xca\xad\x64\x89\x1b\xf8\x45\x1b\xc0\x07\x51\x56\x05\x65\x17\xe4\xe5\x4d\x48\x30\x58\xd2\x7c\x08\x26\xa0\xd2\x2c\x61\x01\x72\x0f\xa6\x54\xf0\xf3\xcb\x68\x92\xbc\x54\xfd\xb9\xde\xd2\xcd\xde\xaa\x10\xff\x59\xee\x2d\xaa\x9c\x7c\x73\x23\xef\xc9\x14\x75\x93\x29\xfa\x5a\xb9\xf7\xb1\x1e\xd3\x28\xb0\x8c\x78\x2f\x15\x5f\xa9\x74\xbe\x81\xab\x20\x49\xc2\xb5\x90\x20\x46\x24\x24\x7a\x70\x19\x29\xc2\x05\x04\x1c\x84\xc9\xdb\x48\xc4\x97\x3e\xbb\xb9\xfe\xd3\x9f\xaf\xdf\xbd\x7b\xde\x7b\x3d\x89\x75\x0f\xa4\x97\xe7\xac\x40\xa1\xea\x6a\x15\x8c\x68\x44\xb2\xb1\xa5\x57\x9f\xae\x39\x93\xa0\xff\x7b\x97\x98\x21\x99\x73\x85\xee\x5e\x9b\xdb\x1e\xd8\x4a\x18\xc7\x61\xba\x5d\x9d\x98\x77\xe2\x91\x75\xb4\x0c\x7c\x20\x71\x8e\xea\x14\x18\xcb\x32\xba\x82\x5a\x39\x59\xf4\x51\x55\x20\xf2\x52\x2a\x69\x9d\x11\x4e\x1b\x92\x23\x51\x3b\x5d\xb2\x8b\xad\x8c\xce\xd0\x5a\xc8\x84\x82\x1c\x3d\x61\xf0\x54\xce\x06\xec\x1f\xbb\x99\x44\x46\xd2\x9d\xeb\x5d\x8c\xe1\x56\x0d\xb3\x93\x96\x85\x90\x34\xac\xe6\x20\xfa\x10\xe9\xe1\x2d\xa2\x6a\x8c\x1e\xc5\x06\x63\xb1\x40\xd7\x8c\xa6\x37\xf5\xe0\xb6\xcd\xe8\x49\x04\xf1\xff\x3c\x62\x68\x0c\xda\xa4\x4f\x7b\x5f\x5b\xa2\x9b\xb7\x8a\xd1\xbb\xb7\x48\xdd\x68\x71\x23\x94\x06\xf7\x24\x0b\x3d\x1f\x0c\xf0\x56\x64\x07\x40\xe5\xcc\x31\x24\x75\x32\xa7\x40\x75\x27\xd1\xa4\x8a\x8c\x41\x5b\x69\xc5\x5e\x01\x32\x5d\x56\x5a\x21\x27\xdb\xe4\x30\x65\xd1\x17\xbf\x96\x6a\x78\xc8\x09\x0f\x32\xcc\x2c\x38\x83\x26\xf7\x54\x66\x7a\x60\xd9\x01\xaa\xb5\x92\xc5\x8a\x31\x96\x18\xcc\x84\x0c\xae\x82\x04\x3a\x46\x20\x21\xc6\xe9\x2e\x98\x7d\x41\x97\xbc\x13\x3c\x89\x3f\x09\x63\xc4\xa9\x9b\xdd\xa3\xa2\x98\x19\xcf\x26\x69\xcb\x3f\xb5\xee\x0c\x44\xd6\xfc\x9b\x28\x28\xff\xdc\xc9\x87\x15\x99\xe5\x46\xde\x39\x3b\xe8\x7b\x0a\xa7\xd3\x4b\xc9\x90\x2b\xf9\x6b\x1d\xb2\xb1\x8f\x1f\xde\xfd\x0f\x5c\xff\xc2\x4f\x13\x3e\x21\x1a\x39\x08\xdb\x28\x59\x65\xf4\x9d\xcc\xfb\x14\x01\xcf\x8e\x76\x08\x43\xc8\x90\x31\x0a\xd0\x0d\xba\xda\x28\x1f\x32\x34\x15\x96\x14\x4d\x8e\x67\x7e\xee\x20\x54\x03\xa6\x12\xd6\xa6\x70\xc9\xfb\x4f\x06\xc1\x11\xe4\x96\xac\x6f\xb9\x95\x2a\x14\x0d\xd2\x02\x7b\x40\x6d\xbd\xdb\xc9\x07\x02\x43\xf6\xd5\xaf\x29\xb8\xe3\x43\x88\x0c\x38\x4d\x6d\xca\x93\x60\xea\x02\x6d\x0c\x1b\x88\x3e\x3d\xa0\x21\x08\x89\xc5\xb7\x2d\x82\x33\xb5\xca\xda\x56\xa8\x40\xb5\x77\x87\x28\xa2\x1e\x0b\xb6\x33\x92\x0a\x1d\x4e\xf7\x60\x96\xe2\xd6\xeb\xa5\x47\x2e\xf0\x4b\xab\x16\x8f\xd9\xde\xf5\xc8\x4f\x95\x5b\xb9\x93\x03\x5e\x98\xf0\xa3\xa7\xa3\x18\xf8\x1c\xdc\x3b\x08\xbb\x6a\x01\xf6\xcc\xf9\xf0\x91\x0a\x6d\xc4\x3c\x10\xf0\xfb\x97\x7f\x84\x75\x0f\xa2\x54\xd6\xa1\xc8\x57\x29\x3d\x40\xc9\x61\x4b\x78\xec\xa7\x97\xaf\x80\xd3\x5b\x1f\x8b\xfc\xe1\xe5\x4b\x5f\x08\xf8\x8c\xc2\x6a\x15\x0a\x73\xa4\xbf\xba\x1e\xd0\x57\x95\xcb\x4c\x70\xd2\x7b\x2a\xae\x19\x57\x5f\x42\xe0\xb4\xd3\x35\xa5\x87\xaa\x89\x14\x29\xe1\x71\x0e\xf3\xd5\xe8\xfa\x83\x04\x86\x32\x8e\x41\xb2\x31\xcf\xa2\x4e\x15\xc7\x7e\xe8\xc9\x88\x70\x66\xda\x83\x49\xf0\x3e\x13\x84\xb5\x0f\x33\x0e\x28\x72\x34\xcf\x99\x35\x57\x55\x55\x48\x5a\x3a\x19\x15\xb9\x83\xa8\xc1\x84\x7a\xe2\x52\x5f\xa1\x9e\xd6\xcf\xc8\x1c\xcb\x4a\x3b\x54\xd9\x71\xb9\x98\x69\xb6\x82\x80\x74\xca\xe2\x3d\xd3\x74\x05\x96\x1c\x25\xc5\xc0\xca\xe7\x9d\x27\xa5\x0a\x11\x17\x99\x45\x89\xa3\xf0\x59\xef\x3a\x20\x21\x04\xd0\x96\x35\xc1\x3a\xe1\x70\x33\xe6\xc5\x9f\x3c\x1f\xe4\x8e\xc9\x1c\xb7\xb9\xbc\x52\xed\x9b\x89\x8d\x82\x4a\xf6\xce\xe8\xa2\x48\x35\x33\x54\x3b\xcd\xf5\x2e\xab\xcb\x88\x73\x07\x2a\x09\xf6\x9d\x30\x52\x28\x47\x29\x63\xf0\xba\xb1\x66\x14\xa2\xee\xd3\x9c\x50\x78\xff\xa4\x77\x6d\x0c\xfa\x01\x11\x87\xe2\x07\x71\xe7\x4b\x96\x47\xaa\x8d\x71\xaa\xa6\x4f\x0a\x42\xec\x3f\x95\x2c\x48\x21\x39\x06\x38\x89\x1b\x7b\x40\xc9\x28\xb2\x03\x20\xcf\x4d\xc1\x7d\x71\x6c\x61\x41\x29\x10\x29\xfc\xbd\xb4\xb8\xea\x44\x11\x19\xf9\xfc\x1c\xcd\x80\x21\xaa\x55\x0b\x44\xcc\x4e\x0f\x32\xcf\x51\xc1\x33\xa9\x78\xb9\x2f\xee\x85\xcb\x0e\xfc\xe3\x1e\x1d\x64\xa2\x28\xec\x73\x1f\x92\x78\xfd\x9d\x20\x80\xba\x70\x94\xa9\x16\x32\x93\x94\xea\x0a\x7b\xcb\x36\x16\xf4\x96\x0d\x67\xe7\xfd\xa9\x36\x3b\x50\x59\xfa\x6f\x8e\x1a\x63\xcf\x06\x64\xaa\xa5\xad\x4e\x62\x4b\x32\x97\x55\x10\xd9\x56\x44\x31\x58\xbf\xa6\xe7\xb2\xda\x50\xb1\x93\x82\xdf\x2e\x5b\x43\x19\xa5\x32\xf2\x4e\x16\xb8\xc7\x9c\x9c\x7b\xe8\x5e\xf0\xed\xfd\x8c\xcd\x97\x99\x9b\xf7\x86\xbc\x54\x36\xd9\xef\x2a\xa6\x87\xc1\x6a\xf2\x13\x92\x42\x3c\x9f\x67\xf6\x40\x6e\x8f\x20\xd4\x91\x5f\xcd\xa6\xec\xcd\xdb\x4f\x9f\xdf\xbe\xbe\xfa\xf2\xf6\x0d\xac\x4f\xd0\xe5\x12\xb9\x50\x20\x8a\xea\x20\x82\xc8\x12\xcf\x06\x23\xbb\x56\xf1\x48\x2a\xb8\x7b\xb5\x79\xf5\x87\x4d\xd7\x28\x8d\x75\x2a\xe8\x5b\xf9\xec\xb0\xff\x43\x47\x59\x3f\x85\x2c\x72\x54\x77\x42\xe7\x80\x42\x61\x7c\xc0\xac\x0e\x8d\xcb\xee\x57\xaa\x50\xf0\x4c\x61\x72\x52\x14\x22\x6d\x28\x75\x6c\xbc\x94\x10\x5f\x0b\x61\x5d\xc4\x72\x04\x62\x42\x82\x20\x04\x6a\xc4\x42\x08\xec\x84\x2c\xc8\xe1\x19\xb4\x75\xe1\x42\x3d\xc8\x8b\x5a\x1b\xfd\x41\xd0\xbe\x99\x92\xe2\x2a\x92\x15\xa7\x59\xd3\xa3\xdf\x1b\xd2\x4d\x8a\x6b\x1a\xd0\x7d\x55\x8d\x7e\x33\xac\x95\xb4\x48\x14\x45\x54\xc1\xbe\xf3\x1a\x8d\x91\xcf\xf1\xd6\x7f\xd5\x40\x38\x3c\xc2\xe4\x76\xe7\x22\xe6\xa4\xcc\x56\x69\x4f\x52\x0e\x4a\x43\xd2\x0a\xc7\xf8\x12\x55\x33\xf1\x77\xb3\x18\xbd\x69\x3c\xd8\x8f\xf9\xcb\xaf\x35\xf9\xb2\xe1\x75\xac\x39\x88\x19\xfc\x69\xb4\xa1\x33\x9d\x4a\x84\xf2\x62\x5d\xb8\xcb\xc5\x19\x9a\x5d\xef\x4e\x45\xcb\x87\x63\x44\xc1\x5f\x84\x2c\x6a\x13\x42\xff\xb6\x29\x1f\x00\x19\xea\x23\xd4\xff\xa2\x26\xb8\x0d\xf5\x40\x6a\xb4\x89\x7d\xa8\x88\x92\x46\x84\x3c\x92\xd2\x2d\x5b\x53\x90\xe1\xd5\x4e\x0f\x5a\x1c\xfa\x17\xa4\x8a\x4b\x0b\xd1\x56\xb7\x53\xbd\xcd\xe2\xf1\x32\x35\xdc\xe2\x1f\xa5\xd0\x63\xdb\xfd\x23\x30\xa1\x09\x85\x62\xd8\xf3\xa8\xd6\xff\x28\xd8\xc1\x91\x80\xc7\x8c\x01\x8c\x42\xfe\x27\x8e\x07\xcc\x0a\x42\xe3\x37\xd3\x39\xce\x62\xdd\x4d\xbd\xdf\xfb\xe2\xf7\x7f\x7e\xf9\xf2\x29\xa6\x2e\xf4\x78\xd3\xfc\xa0\xf0\xb2\xb6\x2b\x78\x09\xb2\x1f\x87\xc6\x4f\x28\x4b\x8d\x99\x80\x56\xa4\xf9\xf3\x4f\x23\xf7\x8c\x47\x9c\xf1\x93\xa3\x13\xb2\xb0\xb3\x56\xf6\x96\x06\x81\x72\xcc\xa9\x8d\x24\x40\x58\xab\x33\xc9\xc1\x71\x52\x5f\xc3\x19\xd5\xc6\x17\x64\x46\x40\x92\x4c\xd2\x5d\x2c\x19\x5e\xb6\x81\xe6\x1a\xf4\xbd\xe2\xb6\xb9\x7f\x83\x47\xab\x13\x82\x8e\x42\x4c\x95\x88\xe8\x63\x18\xc3\x94\xf2\x0f\x36\x1b\x33\x4d\x51\x72\xb9\x18\x01\x49\xa6\x84\x62\x8f\xa0\x67\xf8\x90\x61\x15\xca\x45\x1e\xe9\x94\x13\x84\xe5\x10\xad\xc7\x78\x75\xde\xe3\x00\x64\xa2\xb6\x53\xbf\x77\x98\x41\x95\x83\xd7\xfc\x88\xb7\xc5\x20\x55\x56\xd4\x39\x5a\x28\x29\x71\x0b\x7c\x6d\x71\x69\x02\x30\x34\x06\xf8\x86\x25\x33\x64\xc6\x14\x07\xd4\x06\x37\xf0\x41\x3b\xea\xe0\x9d\xfc\xca\xb1\xe0\x24\xd0\x50\xd8\x08\xb8\x60\x1e\x96\x38\x46\xa4\x33\x5e\xfb\x31\xb4\x0c\x1a\x42\x62\x73\xee\xa6\x0e\x59\x97\x44\x57\xf6\x3e\xd1\xa9\xa7\x72\x72\x88\xeb\xa9\xe4\x4c\xb5\xa5\xb3\x70\x83\x23\x47\x63\xb4\x59\x51\x80\x43\x1e\x97\xa5\x86\xc4\xfd\xbf\x6e\x3e\x7e\xa0\x3a\x07\xc7\x03\x62\xcc\xad\x74\xbf\xef\x1b\x46\x43\x4e\x4c\x51\x39\x54\xda\xba\x9d\x7c\x80\x38\xa1\xc1\x66\x46\xb1\x09\x9a\x01\x51\x38\x3f\x5d\x45\x36\xf7\x8a\x04\xc9\xc7\xd2\xbf\xa1\xd1\x6b\xa9\x72\x7c\xa0\x6a\x17\xfc\x42\x14\x39\xcf\xf1\xe8\xeb\x2a\x14\xc6\xcb\x21\x57\xcf\xb8\x2d\x26\x39\x83\xf1\xb2\xaa\x77\x41\x16\x20\x1f\x28\x8e\xf5\xbf\x4e\x7b\x1b\x60\x29\xaf\x22\x0f\x5e\xd6\x85\x93\x55\x81\x9e\xba\x76\x03\x1f\x83\x05\xe0\x34\xe1\xad\xef\x14\x9d\x15\x10\xfa\xf7\x0d\xe0\xdb\x92\x38\xf3\x6d\x09\xeb\xd0\x92\x23\xee\xa7\x8b\x5a\xb5\x73\xa5\x19\x10\x93\xc0\x10\x64\x16\xe8\xbf\xbe\xfc\xdb\x66\xe2\x15\x33\x60\x06\x24\x76\xd2\x50\x13\x85\x69\x18\xca\xdd\x2a\xbe\xe4\xdb\x72\xb9\x98\x80\x30\xcf\xcb\x35\x9f\x12\xad\x15\xfb\x89\x28\x78\x50\x7d\xae\xe0\x50\x97\x42\xad\x0d\x8a\x9c\x1b\xa9\xad\x5f\xa3\x42\x31\xe7\xcf\x82\x85\x78\x3b\x73\x78\x03\x6d\x4f\x10\xaa\x9b\x21\xb2\xe1\xec\x61\x3d\xe1\x1d\x9a\x2f\xd9\x74\x72\x3f\x39\x9a\xcd\x53\x12\xcb\xbb\x80\x47\xd3\xaa\x14\xd9\x41\x2a\x9c\xa2\xd6\x59\x90\xc1\x71\x74\xa8\x15\xcb\xb1\x1c\x4d\xa5\xfc\x9b\x00\x9a\x39\x20\xd9\x61\x72\xf4\x45\x31\x06\x61\x23\xee\x84\x2c\x88\xa3\x4f\x48\xb7\x33\x89\xc6\x9c\x84\x23\x7e\xfc\x7c\xf0\x62\x26\xe5\xc9\xc6\xf3\x13\x8d\xf5\xeb\x59\xfb\xc7\x3a\x4e\x1f\xd2\x9d\x78\xc8\xcd\xe2\x07\x89\xd4\x1d\x55\x9d\x5c\xd4\x05\xad\x8a\x9e\xf8\x07\x2f\x0a\x3e\x2a\x5f\x57\x6c\xc6\xad\xc8\x2f\x84\xd9\xb9\x49\xb8\xad\x4e\x5e\xe8\x6c\x36\xa8\xd1\xe0\xed\x3f\x69\x5c\xf5\xbb\x78\x31\x5d\x12\x18\x10\x30\x7a\xe0\x1f\xcb\x0a\x78\x16\xc6\xec\x90\x88\x46\x45\x26\x2b\xd5\xbe\xc0\xf1\xd4\x3e\x7e\x7d\x99\x98\xf2\xdb\x6d\x34\x3a\x5b\xcc\x9f\xff\xb0\xc0\x72\x13\x83\x3b\x10\x23\x53\x62\xa3\x14\xbb\xde\x35\xbd\x88\x55\xbb\xe9\x11\x27\x25\x5a\x3d\xe2\x09\x98\xd0\x0c\x01\xc6\xac\x96\xab\x7d\x34\x71\x9b\x6f\xe0\x86\xe4\x96\x4d\x64\x9c\xc3\xf6\x3d\x95\x49\x88\xad\x5e\x0d\x97\xea\x1c\xb5\xc4\x28\x94\x29\x78\xc6\x97\x86\xaf\x32\xb2\x2b\xb0\x0e\x09\x9e\xb6\xf1\x25\x67\xe0\x9e\x38\xb4\x88\x0b\x1c\xf4\xbd\x1f\x11\x72\x1a\xee\x85\x74\x69\xe5\xe2\x76\x8a\xf6\x11\xd5\x2e\x5a\x53\x4c\x9d\x93\x43\xce\xcb\x23\xe9\x5b\xcb\x47\x58\xab\xaf\xd7\x6f\xba\x3a\xb1\x19\x13\xe8\xc5\xac\x70\x6b\x4c\xa8\x1f\x3d\xec\xdc\x0c\x0f\xd8\xdf\xd5\xf2\x87\x6d\xc7\x59\x37\x37\x65\xe6\x9f\x60\x77\xc2\x62\x52\x00\x43\x35\xf6\x7b\x76\x2a\x2c\x66\x68\xcc\x77\xed\x5a\x18\x05\xfc\x4f\x77\x0f\x67\xd9\x7b\x26\x4c\x7e\x74\x70\x1c\xcc\xfc\xb9\xb2\x5e\xb2\x72\x9b\xef\x47\xbc\xbf\x3d\x63\x14\xf3\x8b\x1b\x27\x54\x4e\x13\x68\xd4\xd8\x49\xcf\xfe\x0b\xfc\xf5\xac\x4a\x8a\x26\x4d\xa8\xe7\xbb\xeb\xf8\x40\x4c\x2c\xa8\x69\x21\x77\x69\x72\x95\x4b\xd4\xd4\xfd\x2c\xa5\x5b\xcc\xc8\xd2\x42\x17\x9a\x9a\x3d\x94\x98\x85\x12\x60\xec\xaf\x44\x3b\x1f\xda\x04\xe7\xfc\x59\x18\x85\xa0\x06\x28\x27\xd4\xc4\xb3\x56\x34\xce\xa1\x46\x8a\xf2\x75\x25\x68\x9c\x66\x68\xf0\xaf\xfd\x09\xcb\x8c\x7b\x25\xa4\xb5\xfc\x90\x0e\x43\x13\x61\xa4\x52\x9f\x28\x3b\x63\x7b\x1e\xd3\xbc\xd5\x77\x74\x3a\x78\xde\x50\x3f\x57\xf8\x90\x7a\x8d\x69\x05\x93\x20\x53\x4f\xf4\xb5\xe7\x10\xd9\x37\xee\x77\x73\xb9\x5f\xb9\x20\x8e\x4d\x47\xb1\xd2\x76\x78\xee\xb7\xfd\x91\xbb\xf6\x90\x09\xd5\x01\xe5\xbe\x0e\x41\x03\xd1\x39\x3b\x08\x45\x1d\x4f\xdd\xae\x61\x88\x49\x90\x3b\xbc\x87\x52\x2a\x2a\xa3\x50\x89\xa2\x3d\x27\xd4\xf8\xb7\x58\xd0\xf7\x49\x6c\x94\x8a\x49\xb8\xec\x0f\x6b\xf2\x82\x9e\xae\x49\x52\x5b\xa3\x47\x5b\x04\xef\xb1\xb2\x34\x83\x3a\x09\x33\x48\x4b\xbb\xa2\x10\x1a\x55\x48\xa3\x98\x05\x75\xb0\x8e\xba\xf6\xeb\x30\x98\xa1\x1c\xda\x59\xd4\xfe\x30\x6a\x4e\xdf\xa2\xf2\x4e\x42\x28\x1f\xff\x44\xeb\x38\x15\x82\x9c\x35\x54\x6d\x1f\x1f\x28\x38\x5b\xb1\x2f\x6e\x5c\xd3\xf0\x49\x6e\x3d\xcc\x57\x31\xfb\x2f\x2e\x6c\x6a\x5b\x4c\x40\x85\xd8\x79\x89\x0d\x97\xe8\x37\x49\x2b\x62\xcc\x11\xc7\xdf\x62\xff\x68\x60\x9c\xaa\xfd\x6d\xa6\x56\x99\xcb\x41\xd6\x3d\xd9\x83\x08\x6e\xe0\x2f\xcc\xac\x32\x4c\x4b\x3a\x9a\xcf\x38\xc3\x0c\x91\xcc\x40\x0b\x15\x32\x3c\x5e\x24\xa1\x56\xa9\xed\xbe\x15\xd9\xed\x1c\x89\x89\x73\x5e\x33\xc6\x61\x5a\x1e\x61\x12\xe4\x13\x78\x8b\x4c\x2b\x3f\xc0\x90\x1d\xd7\x61\x04\x66\x2d\x54\xbe\x4e\xe6\x21\x3b\x5e\xfc\xa8\xe0\x59\x2c\x76\xef\xa4\xba\x9d\x2d\x71\xf1\x01\x1f\xa5\x7d\xfd\xfc\xae\x1b\x9c\x25\xd1\x99\x52\x8a\x59\x7b\x89\x7e\x6c\x6d\x67\xa3\xd2\xe9\x9a\xd6\x23\x2b\x59\xf7\x87\x30\x18\x92\x02\x97\x11\xb8\x5c\x7b\x0a\x73\x74\xcb\xd0\x0d\x5e\x86\xe4\x77\xba\xac\x35\xd5\x1f\x1a\x2d\x66\xc1\x55\x9c\x02\xcc\x0a\x61\x68\x12\x8e\x27\x5b\xb9\x73\xe7\x5f\x3a\x0a\x93\x3b\x7a\xdb\xda\x41\xae\x91\xca\x65\x0e\xf4\x1d\x1a\x43\x0d\x0f\xd9\xdb\xa5\x37\x9b\x31\xfe\xa5\xb3\xa8\x7e\x71\xd3\x8a\x15\x5b\xe5\x98\x0d\x7c\x54\x34\xac\x7f\x09\xcb\x9b\x3a\xa3\x21\xf9\xe5\xd0\xb8\x4e\xfc\x24\x2a\x3f\x75\x34\x47\xf9\x3c\x2b\xa4\x5f\xd3\xc5\xf7\x91\x64\x42\x4e\xc7\x26\x1c\xd6\x23\xb3\x2f\xa3\xa0\x0a\xb1\xc5\x7e\x0f\xf4\x89\x77\x1e\xbf\x17\x15\x39\x8f\x90\xb8\xdd\xe2\x91\x24\x2d\x6e\x87\xef\xfb\x11\xa7\x41\x9b\xbd\xa0\x36\x7c\xef\x9d\xf4\x1c\x85\x90\x7b\x6d\xe4\x6f\x08\xcf\xf8\x40\x03\x86\x66\xb1\xc0\xcc\x3d\x0f\x8b\xa4\xfd\x85\xe2\x08\x25\x8f\xb0\xf9\x9f\xb4\xb1\x43\xb3\x8f\x06\xab\x82\x2a\x21\xa4\xae\xcd\x38\xa1\x0d\x30\xcd\x9d\xcc\xd0\x3e\x3e\x91\xf6\x74\xbd\x98\xcb\x86\x52\x28\xb1\xc7\xdc\xf7\x9a\x2e\xa7\x88\xb9\x7c\xdf\xbe\x15\x4a\x51\x59\xa0\x7d\x29\xbb\x42\xdf\xaf\x65\xce\x68\x47\x87\x1d\x46\x14\x86\x36\x96\xea\x5d\x6c\x2b\x31\xf9\xa9\xef\x15\x70\x20\x37\xce\xd7\x22\xd4\xd0\x89\x96\x14\x85\x5b\x9a\xe6\xa3\x52\xcf\x68\xe0\x70\xd0\xb5\xc5\x5b\xc4\x4a\xaa\xbd\x8f\xfa\x29\x8f\xb0\x64\x97\x25\x8d\x10\x1e\x43\x71\x8a\x26\x04\x55\xe8\x47\x87\x9d\x57\xb5\xca\xd1\x58\x37\x14\xc2\x37\x05\xa3\x0d\x5c\xa5\xf5\x46\xa9\x89\xd9\xca\x85\x6f\x34\xae\x4e\x06\x43\xe3\xc5\x1e\xcc\xb0\x39\x22\x4e\x31\xb5\x86\x65\x45\x55\xd1\x00\xa0\x70\x07\x28\xe4\x2d\xc2\xb7\x65\x26\xd7\x59\xfe\x6d\x49\xa4\xc0\x18\xc7\x7b\xfa\xf5\xc0\x92\xf7\x2b\xee\xc5\x31\xd9\xf2\xc4\x8d\x90\xf3\x34\xe8\x73\xd4\xd4\xd9\xa7\x3e\x14\x90\xc4\xa1\x95\x6f\xea\x74\x28\x20\xcc\xfc\x11\x91\x03\x25\x5a\xf1\x7b\x9c\xf3\xa3\x32\xea\xd0\x74\xb7\xd2\x4e\x66\xd8\x9b\xfe\x1b\x69\x43\x4f\x27\x9f\xe7\x46\x7c\x4e\x24\x78\x7a\xbe\x27\x45\x99\x31\xf0\x9d\xca\xbe\x5a\x75\x44\x62\x0a\xf1\x8d\xdc\x98\xdf\x25\x8f\xa1\xc6\x47\x6e\x75\xc9\x3d\x8f\x17\xe1\x1d\x4b\xf8\x7b\xdd\x39\xc6\xa3\xf9\x32\xc7\x89\x4d\x4e\x57\xeb\x82\x2c\x7c\x1b\xe3\x20\x83\x61\x1b\x37\x92\x8b\xa1\x53\x0b\x48\xd3\x8c\xc8\x6e\x47\xf1\x3c\x59\x5f\x1c\xd3\x24\x9c\xb7\xc8\x4d\x41\x1a\x0f\xcd\x52\x6d\x28\xec\xf5\xf2\x0a\x33\x02\x33\x8c\x2c\x0d\xcd\xaf\x9f\x31\xce\x69\x40\x60\x90\x97\x23\xd6\x1f\x9c\xa9\xf1\x3c\x73\x83\x59\x6a\x25\x1c\xe2\x54\x5f\xa6\xb0\x1d\x30\x8c\x6d\xf3\x68\x66\x08\x97\xb7\x8e\xa6\xbf\x17\x4a\xef\x4e\x75\x8f\x41\x0e\xd3\x26\x70\xcc\xe2\x0c\x94\x47\x09\x9c\x62\x92\x19\x48\x7f\x8c\xf7\xc6\x13\x75\x08\x36\x91\x2c\x01\x09\x15\xde\x02\xc5\xe0\x56\x95\x88\xb3\xb4\x70\xe2\x1e\xde\x72\xa3\x7c\x8b\x14\x7f\xa7\x23\x08\x48\x33\x28\x8a\x26\xff\x2b\xa3\x17\x1e\x01\x99\xc6\xb6\xc2\x5c\xb1\x41\xb8\xa0\x4d\x15\xc7\x0b\x36\xed\x17\x5f\xb9\x88\x79\xf1\x5d\x14\xa2\x2e\xc7\x0c\xe2\xd0\xee\x14\x68\x6f\x9a\x24\xc2\xc4\x62\x79\xe2\x11\xdc\x53\x27\x68\x62\x66\xec\x3a\x6d\x37\x09\xd6\x39\xed\xc0\x93\xbb\x53\x06\x84\x05\x2e\xa6\xba\x06\x63\x7b\x03\x67\x2c\x7c\x42\xd4\xc7\xda\xbd\x43\x0d\xb8\x13\x1a\x5d\xf0\xc6\x96\x98\x2a\x87\xad\x3a\x64\xf8\x69\xf2\xa4\x39\xe7\x63\x03\xd7\xb6\xd9\xf2\x34\x78\x46\x00\x4b\x49\x18\x80\xe6\x12\xba\x5d\x35\x3b\x9c\xb9\xf7\x99\x7e\xe0\x92\x21\xed\xf5\xb9\x4f\xdb\xd5\x87\x64\x33\x15\xd5\x9a\x7d\x4f\xd1\x0c\x2a\x10\x15\x39\x16\x43\xcd\xc0\x70\x2e\x49\xdb\xf2\x6d\x86\x37\x7b\x49\x0b\x95\x91\xa5\x30\x92\xb7\x42\x84\xb9\x39\x12\xd5\xb4\x89\xa3\xd9\x73\x43\xd5\xbd\xbc\x53\xe9\xca\xd3\x41\x5d\x7d\x69\x19\x28\xd0\x3f\x36\xf6\x6b\xac\x8e\xfd\x1d\x2d\x6a\x24\x0c\x1c\x90\x8f\xc4\xa9\x49\x6e\x2f\x3f\xc4\xdb\x4e\x1c\xa8\xbf\x12\xb8\x4e\xdb\xf9\x41\xf5\xa5\xa2\xbf\xe0\x2b\x15\xf4\x20\xbd\x9c\xb4\x8d\xf2\x8b\x3b\x51\x78\x9e\x32\xf8\x6f\xcb\x1c\x77\xa2\x2e\xdc\xb7\x65\x23\x51\x2b\xd8\x0e\x84\x16\xed\x5b\x83\x45\xcb\x84\xd2\x8a\xb8\xda\x14\x05\x42\xc6\x16\x07\xec\x62\x11\x88\x42\xd1\x28\xa3\x3d\xc8\xe1\xa4\x14\x0a\xfa\xc9\x10\xb6\x85\x3b\xcc\x17\xb1\x39\x4b\x41\x84\x37\x5b\x4d\x6f\x32\xbc\x64\x31\x36\x4e\x1d\x4e\x2a\xf8\xa6\xd2\x2e\x5d\x01\x6f\x3e\xdc\xfc\xef\xbb\xab\xff\x78\xfb\x6e\x33\x2d\x1c\x3d\xa0\xb3\x84\x25\xe1\x6f\x97\x73\xa5\x44\xdf\x2b\x34\x9f\x91\x8f\xeb\xc9\xd0\x4e\xca\xca\xbb\xb0\xf7\x22\x52\x37\x47\x4a\x10\x63\x94\xdf\x54\x64\xa8\xbe\x70\xf5\xee\xdd\x28\x81\x42\x2c\xcb\x45\x67\x2e\xd3\x6d\xb1\x3d\x5f\xde\x02\x95\x68\xb9\x17\x66\x4b\xd3\xe8\x19\xed\xcf\xa2\x7d\x50\x7d\xd9\xbb\xde\x9d\x3c\x29\x6d\x3b\x09\x69\x07\xf1\xf4\x06\xbf\x0f\x28\xcd\x7e\xa5\x62\x7b\x0f\x6a\xd8\x0c\x24\xa3\xec\x4a\x7b\x02\x29\xcd\x15\x34\x17\x5b\xf1\x18\x3d\x61\x86\xf4\xe4\x0b\x57\x5a\x9a\x18\xad\x3d\xe3\x17\x92\x27\xb2\x9b\x0d\xd0\xcd\xbf\x22\xb2\x3e\x0d\xa3\x49\x93\x58\x4c\x46\xdc\xe2\xa8\x88\x85\xdd\x42\x74\xca\xc6\x47\x92\xb6\x78\x0c\xcb\x0c\x24\x88\xa7\x86\x4e\xc2\xbb\xfa\xf0\x26\xf6\x1b\x58\x62\xd3\xf6\xde\x25\xf5\xf4\x29\x20\x57\x79\x84\xdb\x95\xfd\xde\x96\xfa\x20\x00\x0d\xb0\x86\x11\x41\x08\x9b\x26\xed\x2d\x1e\xd7\x6c\x06\x46\x80\xd2\x36\x09\xb2\x87\x4e\x16\x31\xd5\x08\xba\xd4\xda\x11\xb4\x81\x37\xde\xdc\x51\x3a\x01\x3b\x51\xd0\x91\x72\x5f\xc6\x42\xaf\x74\xa6\x52\xdc\x88\x4c\x95\x0c\xc3\x09\xae\x85\xa5\xc7\x70\x49\x9b\x35\x4a\x69\xdb\xec\xe1\xb5\xf4\x53\xd3\xa0\xe7\x71\x63\x1f\xfc\xfe\xa7\x9f\xe0\xd9\x57\x15\x36\xd9\x50\xf9\x0e\xde\x2a\x27\xdd\xf1\x79\xd2\xb6\xd8\x53\x99\x62\xf4\x56\x6b\x3a\xfd\x62\xe0\x8e\x46\x6a\x1f\xc3\xe1\x0e\xf1\xf8\x0c\xbf\xb4\x31\x62\x86\x46\xcc\xc3\x6d\x7c\x46\xe0\x04\x2b\x3f\x21\xd0\x15\xfb\x27\x2e\xec\x9d\x6d\xd3\x9e\xd1\xa8\xf1\x51\xaa\xd3\xb5\x7c\x68\x6d\xad\x1a\x5d\xcb\x8f\x07\x22\xb3\x70\xae\xe5\x2c\xf2\x9f\x4c\xb5\x3c\x05\xc6\xb5\xfc\x2e\x22\xc7\xd8\xa1\x8f\xf3\xba\x65\x4d\x07\x7e\x24\xae\x2e\x66\x6e\x16\x5b\x43\x2d\xf3\xa7\x08\xed\x63\x34\x3d\x62\xe4\x4f\x48\x4c\x3b\xa0\x43\x7f\x8b\xcd\x1b\x79\x9f\xf6\xf8\x4a\xd8\xa5\x18\x37\x22\x25\x47\xb0\x18\x4c\x14\x67\x75\xf1\x46\x3a\x75\x3d\x88\xa7\x9d\xbb\xf7\xad\x26\x3b\xc5\x5e\xba\x72\xb2\xa4\x53\x09\x33\x68\x75\xae\x56\xe1\x01\x7e\x07\x8f\x91\xf5\x0d\x61\xdc\xd4\xe2\xb7\x22\x37\xe9\x30\x75\x32\x52\x8a\x42\x1b\xa9\x43\x8d\x21\x5e\x6a\x8e\xc1\xeb\x81\xe4\x78\x98\xbb\x89\x21\x81\x0c\x65\xe8\xa6\x79\xf8\xf8\x8e\x61\xec\x12\xf2\x91\x95\x65\xeb\x1c\x35\x9f\x62\x13\x0d\x84\x3f\x28\x28\xab\x0b\x61\x06\x30\xef\x81\x6c\xad\xe4\x9b\x9a\xd3\x13\x9b\xd9\x2f\x1d\xed\x91\x3e\xb5\xa9\x9c\xd1\xa3\x9c\x1d\xf1\x8e\xf5\x22\x4f\xd4\xe3\x66\x7e\xff\xf1\x84\x9e\x1d\x98\x30\xaf\xe7\x38\x8a\xeb\x80\xb9\x3c\xd5\x62\x32\x94\x21\x2b\x0a\x99\x3a\x45\xb3\x32\x1c\xca\xc9\xb9\x40\xe8\xf2\xa5\xea\x4b\x40\xbb\x03\x16\xc2\xd1\x8d\x4d\x69\x3d\xe4\xd7\x2d\x31\x61\xc1\x04\x3a\x33\xcb\xf7\xc3\xe8\x88\xa7\x94\x25\xeb\xdd\xd4\xd9\xae\xad\x33\xeb\xe2\x11\x86\xb4\x77\xcc\xeb\xac\x56\xf0\xe9\xeb\x97\x46\x23\x3b\x62\xda\x83\xbb\x3d\x8e\x90\xf5\x87\x5d\xc4\x4c\x21\x1a\xb4\xcd\x25\xda\xc3\xe5\xe2\xcc\xb3\xf1\x20\xee\x49\x48\xfd\xe6\xe5\xc0\x6d\x9d\x4b\xc1\x42\x73\xdc\xbf\x0e\x87\x81\xdf\xbd\xe2\x9a\xfe\xab\x45\x32\x2b\x79\xab\xf4\x1a\x36\xf8\x86\x2b\xcd\x3b\x45\x46\x5b\xf4\x30\xff\xd0\x3d\x1a\x7c\xb9\x3c\x39\x0f\x9c\xff\xcc\xb4\xf2\xf5\x5d\x7b\x09\x7f\xfd\x1b\x1d\x0a\x4e\xf1\x70\x1e\x3c\x90\xbd\x84\xbf\xfe\x6d\xf1\x7f\x03\x00\x2f\xcb\x27\x69\x0d\x5d\x00\x00"),
 		},
 		"/control-plane/crds/kuma.io_trafficlogs.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_trafficlogs.yaml",
-			modTime:          time.Date(2019, 9, 20, 15, 26, 41, 385855404, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23401,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\xeb\x73\xdb\x48\x72\xff\xee\xbf\xa2\x4b\xf7\x41\x76\x15\x49\xdb\xbb\x77\xa9\x9c\xbe\x29\xb2\xf7\xa2\xac\x5f\x65\x69\x2f\x95\x8a\x53\xa9\x21\xd0\x20\xe7\x04\xce\x60\x67\x06\x92\xb8\x7f\x7d\xaa\xbb\x67\xf0\x20\x1e\xa4\xd6\xba\xad\xe0\x9b\x28\xa0\x31\xd3\xcf\x5f\x3f\x06\x2f\x96\xcb\xe5\x0b\x55\xe9\xbf\xa3\xf3\xda\x9a\x0b\x50\x95\xc6\xc7\x80\x86\xfe\xf2\xab\xbb\x7f\xf5\x2b\x6d\x5f\xdf\xbf\x5d\x63\x50\x6f\x5f\xdc\x69\x93\x5f\xc0\x55\xed\x83\xdd\x7d\x45\x6f\x6b\x97\xe1\x3b\x2c\xb4\xd1\x41\x5b\xf3\x62\x87\x41\xe5\x2a\xa8\x8b\x17\x00\x99\x43\x45\x3f\xde\xea\x1d\xfa\xa0\x76\xd5\x05\x98\xba\x2c\x5f\x00\x18\xb5\xc3\x0b\x08\x4e\x15\x85\xce\x4a\xbb\xf1\xab\xbb\x7a\xa7\x56\xda\xbe\xf0\x15\x66\xf4\xec\xc6\xd9\xba\xba\x80\xf4\xb3\x3c\xe2\xe9\x3f\x00\xb2\x84\x5b\x79\xfa\x83\xdd\xf0\x8f\x55\x59\x3b\x55\xf6\x88\xbe\x00\xf0\x99\xad\xf0\x02\xce\xce\x5e\x00\xdc\xab\x52\xe7\xbc\x20\x21\x63\x2b\x34\x97\x5f\xae\xff\xfe\xe3\x4d\xb6\xc5\x9d\x92\x1f\x01\x72\xf4\x99\xd3\x15\xdf\xd7\x79\x09\x68\x0f\x61\x8b\x20\x37\x43\x61\x1d\xff\xd9\x79\x1d\x5c\x7e\xb9\x8e\x34\x2a\x67\x2b\x74\x41\xa7\x15\xd3\xd5\xe1\x70\xf3\xdb\xc1\xdb\xce\x69\x39\x72\x0f\xe4\xc4\x53\x94\x77\xde\xcb\x6f\x98\x83\x97\xb7\xdb\x02\xc2\x56\x7b\x70\x58\x39\xf4\x68\x02\x6f\xab\x43\x16\xe8\x16\x65\xc0\xae\xff\x81\x59\x58\xc1\x0d\x3a\x22\x02\x7e\x6b\xeb\x32\x87\xcc\x9a\x7b\x74\x01\x1c\x66\x76\x63\xf4\x6f\x0d\x65\x0f\xc1\xf2\x2b\x4b\x15\xd0\x87\x1e\x45\x6d\x02\x3a\xa3\x4a\x62\x64\x8d\x0b\x50\x26\x87\x9d\xda\x83\x43\x7a\x07\xd4\xa6\x43\x8d\x6f\xf1\x2b\xf8\x68\x1d\x82\x36\x85\xbd\x80\x6d\x08\x95\xbf\x78\xfd\x7a\xa3\x43\xd2\xa9\xcc\xee\x76\xb5\xd1\x61\xff\x3a\xb3\x26\x38\xbd\xae\x83\x75\xfe\x75\x8e\xf7\x58\xbe\x56\x95\x5e\xf2\x3a\x4d\x60\x3d\xdc\xe5\x7f\x72\x51\xdf\xfc\x79\x67\x61\x61\x4f\x12\xf6\xc1\x69\xb3\x69\x7e\x66\x15\x99\x64\xf3\xcf\xda\xe4\x24\x4f\x15\x1f\x93\xe5\xb6\xdc\xa4\x9f\x88\x09\x5f\xdf\xdf\xdc\x42\x7a\x29\x73\xbc\xcf\x62\x66\x6e\xfb\x98\x6f\xf9\x4c\x7c\xd1\xa6\x40\x27\x72\x2a\x9c\xdd\x31\x45\x34\x79\x65\xb5\x09\xfc\x47\x56\x6a\x34\x7d\x1e\xfb\x7a\xbd\xd3\x81\x04\xfb\x6b\x8d\x3e\x90\x38\x56\x70\xa5\x8c\xb1\x01\xd6\x08\x75\x95\xab\x80\xf9\x0a\xae\x0d\x5c\xa9\x1d\x96\x57\xca\xe3\x73\x73\x99\x18\xea\x97\xc4\xc1\xe3\x7c\xee\x9a\x7b\xba\xc6\x94\x9f\x0d\x80\x76\xc1\x8a\x7a\xf0\x0f\x00\x95\xe7\xec\x3e\x54\xf9\x65\xe2\xe1\xc9\x15\x8c\x9a\x51\xfb\x26\x16\xb3\x81\xda\xf8\xe0\xea\x2c\xd4\x0e\x73\xb8\xc3\x7d\x94\xf8\x4e\x55\xe0\x83\xa5\x1f\x1f\x74\xd8\x0e\xde\xa8\xba\xd2\x57\x81\xc5\xba\x46\xf0\x18\x60\xbd\x07\x72\x92\x6c\x10\xc1\xda\x92\x2d\x87\x69\xb1\x61\x38\x0c\x4e\xe3\x3d\x0e\x49\xba\xb5\x0e\x4e\xb9\x7d\xc3\xbb\x15\xdc\x6e\x71\x0f\xca\x21\x90\x98\x7f\xad\xd1\xed\xd5\xba\x14\x3a\xd1\x60\xd7\x08\xac\x64\xee\x1e\xf3\x01\xc9\x87\x2d\x1a\xd8\xd9\x5c\x17\x7b\xd2\x5c\x51\xcb\xa1\xf1\x5d\xbc\x7e\x7d\x57\xaf\xd1\x19\x0c\xc8\x8a\x91\xdb\xcc\xbf\xae\x3d\xba\xe5\xa6\xd6\x39\xbe\xee\x08\xe8\xfc\xc5\x18\xeb\x85\x72\xef\x5f\x59\x59\xfb\x80\xee\x13\x39\xf4\x39\x99\xdc\x6e\x91\x5d\xb8\xb8\x2e\x4c\xcf\xc1\xc3\x56\x67\x5b\xfe\x25\x5a\xd3\x1a\x4b\x6b\x36\xa2\xf8\xb7\x87\x16\x47\x97\xf6\x50\x7b\xcc\x89\xdd\xb9\xf6\x64\xab\xb5\xf6\xdb\x46\x50\x9e\x25\x09\x9e\xde\xc5\x2f\x24\x2e\x72\xf0\xa8\x54\x46\xec\x80\x5c\x17\x05\xba\x43\xcb\xeb\x6c\xc6\xcb\x9b\xa1\xd0\x58\xb2\x9f\x20\xb1\x90\xcc\x95\xd9\x3f\x6c\xd1\x21\x38\xbd\xd9\x06\x30\xf6\x81\xa9\xab\x4a\xb3\x64\x1c\x8c\x2c\x77\x63\xd9\x9b\x58\xd0\x1b\xc3\xf2\x08\xa0\x0b\xa6\xa6\x8d\x44\x48\x04\xeb\xa2\x65\x27\xbb\x5f\x8d\xb2\x7f\x44\xf3\x87\x21\x76\x4e\x08\x67\x57\x87\xb7\x8b\x17\x0c\xcd\x9f\x03\x17\x28\x1b\x1b\x9a\xa2\xde\xa1\xe8\x1d\xfb\xb7\x28\xbb\x07\xe5\xe3\x96\xc8\x45\x85\xc4\xba\x4d\xad\x9c\x32\x01\x45\x68\x62\x3f\x43\xb1\x1a\xd8\xaa\xaa\x42\xe3\x97\x6b\x2c\x88\x53\xd6\xe5\xe8\x40\x65\xce\x7a\x0f\x1e\x2b\xe5\x98\x57\x15\x3a\xd1\xd1\x15\x5c\xb1\x03\x15\x6f\x6b\xec\x90\x26\x71\x99\xd7\xc7\xd6\x9e\x96\xd4\xec\x11\x73\x7a\xeb\xd7\x9f\xae\x7e\xfc\xf1\xc7\xbf\x52\x44\xdf\xb1\x38\xb5\xa7\x9f\x7f\xb9\xbd\x5a\xc1\x37\x33\xa0\xf9\xc5\x56\x35\x05\xc7\x9c\x3c\x00\x73\x68\xef\x03\xee\x56\xf0\x15\x55\xbe\xb4\xa6\xdc\xaf\xe0\x53\x5d\x96\x8c\x10\x4a\xed\x47\x0c\xf1\x3b\xfd\x73\xf2\x1b\x67\x07\x6b\xa3\x0d\xa8\x70\x01\xa4\x48\x4b\x12\xd0\xa9\x4a\x94\x63\x89\x44\xfd\x6f\x4e\x65\xf8\x05\x9d\xb6\xf9\x0d\x66\xd6\xe4\x03\x1f\xdc\xd3\xa6\x4f\xf5\x6e\x8d\x8e\x0c\xda\xcb\xdd\xa0\xca\xd2\x3e\x60\x1e\xc1\x51\xab\x17\xc1\xc2\x86\x68\x17\x75\x59\xee\x87\xba\x84\x6e\xa7\x0d\xc9\x36\x0a\x5e\x07\x78\xd0\x65\x49\x9a\xe2\x70\x67\xef\x89\x62\x0a\xa0\x89\xdb\x9f\x4d\xb9\x67\xf9\x92\x12\x0e\x48\xa6\x1d\xf5\xf5\xbc\xf4\x96\x1e\x59\xc1\x47\xb5\x07\x92\x14\xeb\xe2\xd6\xba\x80\x86\x34\xb6\x95\xe0\x04\x67\xb5\x09\xff\xf2\xe7\x51\xae\x12\x36\xda\x1c\xd8\xc9\x60\x11\xf3\xb6\xf9\x6e\x6c\xcd\x5f\x7f\xba\x02\xd6\x4e\xf6\x0e\xa4\x9d\x6c\x79\x2a\x34\x8e\x73\xc4\xe5\x34\x31\x2b\x71\x91\x57\x42\x3b\xec\xbb\xb5\x18\xc6\x5a\x33\x17\x8b\x56\x8d\xb0\x26\xf9\x2a\x66\xc4\xae\xaa\x35\x04\x8a\x24\x8b\x64\x41\x64\xf7\xb9\x76\x98\x05\x91\x53\xe0\x88\xb6\x1e\x4a\x5f\x45\x18\xc4\x51\xb0\x5d\xba\xf6\x80\x8f\x15\x66\xa1\x71\x1a\x71\x13\xf0\xd2\x58\xa0\x10\x81\x0e\xee\xb5\xd7\xeb\x72\x18\x63\x59\x5b\x1a\x52\x6c\x84\xb2\x30\x5a\x95\x43\x95\x6d\xe3\x6a\x38\x30\xbc\x02\x55\x04\x8c\x70\x9e\xb8\xab\x87\x0a\x15\x1a\xc6\x2d\xc0\x1a\x86\x03\x08\x85\x36\xaa\xd4\xbf\x11\xde\xa3\x77\xf0\x9a\x77\x55\xd8\xaf\xe0\xd2\xf3\x12\x41\xf9\x83\x1b\x07\x84\xf9\x41\xb2\x7b\xa5\x09\xac\x04\xdc\xf9\x45\x8f\xcd\xeb\xd2\x66\x77\x24\xbb\xcf\xe9\xb5\x03\xbd\x1a\x0b\x91\x1e\xc3\xa2\xe3\xfb\x92\x8b\x64\x10\x69\x48\xf0\xd6\x25\x24\x53\xd4\x2e\x6c\x29\x78\x99\x88\xfd\x8b\x9a\x70\xd2\x62\x28\xaa\x32\x6c\x6d\xbd\xd9\x92\x81\x26\x24\x94\xac\x07\x62\x3e\xd4\x70\x3d\xde\x90\xa4\x56\x39\x6d\x47\xc2\x88\x95\x35\x12\xdb\x57\xf0\x93\x75\x80\x8f\x6a\x57\x95\x94\x5d\xb0\x3e\xc5\x04\x83\x35\x4d\x20\x98\x82\xca\xb2\x86\x45\xca\x63\x81\xe4\xc7\x37\xc9\x25\x89\x56\xfd\x5c\xaf\xe9\x66\xb1\x07\x92\x3f\xeb\xbd\x47\x93\x53\x98\x6b\xf5\xbd\x71\x45\x87\xc9\x14\x5d\x5e\x6f\x04\xeb\x09\x7e\x11\x91\x91\xec\xb5\xe1\x5f\x2a\x9b\xaf\xe0\x32\x6a\x92\x0a\x9d\x45\x2c\xf8\xff\x71\x11\x43\xf4\x46\x8b\xa2\xb5\x80\x82\xad\x72\x79\x77\x11\xe9\xa5\x2f\x6f\xae\xff\xf6\xf3\xf5\x87\x0f\xaf\x06\xaf\x27\xb5\x1e\x0a\x8a\x57\x91\x95\xa8\x4c\x5d\x2d\xa2\x13\x4d\x8b\x6c\x7d\xe9\xe5\x97\x6b\xce\x24\xf8\x1f\x1c\x12\x33\xc6\x67\x06\xc3\x83\x75\x77\x03\xb2\x95\x72\x81\x61\xba\x5f\xf4\xdc\x3b\xc9\xc8\x07\xda\x06\x3e\x92\x3a\x27\x73\x8a\x82\x65\x1d\x5d\x40\x6d\x82\x1e\x7a\x14\x65\x40\xe5\x3b\x6d\xb4\x0f\x4e\x05\xeb\x48\x8f\x54\x1d\xec\x4e\x89\xd6\xd8\x0c\xbd\x87\x4c\x51\x42\x2c\x8c\xc1\xbe\x9e\x8d\xf8\x3f\x0e\x33\x6d\x58\x21\x2c\x52\x24\x0c\xb7\x68\x85\xdd\x58\x59\x84\xa4\x71\x37\x5b\x35\xa4\x28\x96\x83\xa6\x75\x7a\x84\x0d\xa6\xb0\xc0\xa1\x1b\x6d\xde\x34\x66\xa8\x1d\x8a\x1d\x04\xf1\xff\x1c\x31\xb4\x0e\x6d\x36\xa6\x7d\xac\x3d\x7b\x1c\xf6\x8a\x29\xba\x77\x58\xdd\x5a\x71\xab\x94\x0e\x37\xa4\x0b\x83\x18\x0c\xf0\x5e\x65\x5b\x40\x13\xdc\x3e\x26\x75\x3a\xa7\x3d\x16\x1a\x5d\x53\x8e\x71\xe8\x2b\x6b\x38\x2a\x40\x66\x77\x95\x35\x68\xa2\xe3\x20\x3b\x1b\x09\x95\x8d\x69\x08\xe5\x66\x1d\xe4\x98\x59\x71\x46\x5d\x6e\x5f\x67\xc6\xe4\x6a\xac\x59\x1a\x5d\x2e\x98\xae\xc6\xe8\x26\x74\x0c\x15\xa4\xd0\x09\x81\x44\x8c\x73\xb8\x61\x8e\x05\x4f\x4a\x82\xe5\x5f\xca\x39\xd5\x0f\xb3\x1b\x34\x84\x99\xf1\x68\x92\x76\xf6\xb7\xce\x9d\x91\xc9\xb6\x92\xc4\x9c\x3c\x44\xa1\x1f\x17\x92\x7c\xf5\x60\xc3\x30\x52\x10\xe0\x8b\xa4\xc8\x91\x1b\xfd\x6b\x1d\xb3\xb1\xcf\x9f\x3e\xfc\x17\x5c\xff\xc4\x4f\xf3\x5b\x04\x8d\x6c\x95\x6f\x8d\xac\x72\xf6\x5e\xe7\x43\x8e\x80\x88\xa3\x0b\x61\x68\x31\xe2\x5e\x99\xba\xc3\x50\x3b\x23\x90\xa1\xad\xb0\xb4\x38\x68\x32\xf3\x0b\x5b\x65\x5a\x32\x95\xf2\xbe\x81\x4b\x12\x3f\x99\x04\x23\xc8\x35\x6b\xd6\x5a\x9b\x58\x34\x68\x36\x38\x8c\x18\x75\x51\xe8\x47\x09\x41\x69\x4f\x91\xdc\x36\x22\x03\x4e\x53\xdb\xc2\x24\xb8\xba\x44\x9f\x60\x03\xf1\x67\xe8\xdc\x04\x84\xa4\xe2\xdb\x1a\x21\xb8\xda\x64\x5d\x2f\x54\xa2\xd9\x84\x6d\x52\x51\x59\x05\xfb\x19\xed\x98\x35\x03\x9a\x3b\x75\x27\x36\x20\x8b\x8b\xf2\xb2\xa6\x23\x63\xf6\x77\x03\xf6\xfb\x0a\x33\x32\xc0\x91\x10\x44\x50\x75\x8b\x8d\x1a\x48\x0e\x2e\x01\x22\x06\xc4\x84\x39\x89\xb3\x9f\x3e\xdf\x46\xe1\x81\x82\x3f\xbf\xf9\x2b\x2c\x47\xe2\xba\x0f\xa8\xf2\x45\x93\x1e\xa0\x66\xd8\x12\x1f\xfb\xe1\xcd\x5b\xb8\x92\xdc\x93\x62\xc8\x5f\xde\xbc\x11\xe9\x7c\x45\xe5\xad\x89\x85\x39\xb2\x5f\x5b\x8f\x25\x9f\xb9\xce\x54\x10\x34\xd0\x55\xd7\x8c\xab\x2f\x11\x38\x15\xb6\x36\x79\x0a\xf7\x82\xc3\xcb\xd2\x86\x80\xf9\x08\x56\x8a\xfb\x8f\x1a\x18\xcb\x38\x0e\xc9\xc7\xbc\x4c\x36\x55\xee\x87\xd0\x93\x17\xc2\x99\xe9\x88\x92\x22\x7c\x25\x0a\x4b\x81\x19\x5b\x54\x39\xba\x57\x2c\x9a\xcb\xaa\x2a\x35\x6d\x9d\x9c\x8a\x2e\x20\x59\x30\x87\xbd\x24\xa5\xa1\x41\x3d\x6f\x9c\xd1\x39\xee\x2a\x1b\xd0\x64\xfb\xc3\x50\x33\xe9\xb6\xa2\x82\x1c\x94\xc5\xe1\xd0\x35\x5d\x82\xa7\x40\x49\x08\xc5\x48\xde\xd9\x2b\x55\xa8\xb4\xc9\xac\x43\x10\x6c\x31\xca\xc3\x1c\x3d\x5b\x82\x0f\x2a\xe0\xea\x94\x8c\xfe\x59\xf2\x41\x6e\x91\x9c\x12\x36\xcf\x2e\x4d\xf7\x66\xa9\xd1\xb0\x04\x6c\x59\x36\x35\x33\x34\x85\xe5\x7a\x97\xb7\xbb\xb4\xe6\x11\xc5\xbe\x57\x4e\x2b\x13\x28\x65\x8c\x51\x37\xd5\x8c\x22\xea\xee\xe7\x84\x4a\xe2\x93\x2d\x7a\xcb\x1d\xf3\x97\x84\x94\xee\xa5\x64\xb9\xc7\x00\x8a\x53\x35\xdb\x2b\x08\x09\xf0\xd2\x25\x19\x24\x63\x80\x1e\x6e\x1c\x10\x25\xa7\xc8\x01\x80\x22\x37\xc1\x02\x52\xe5\x66\x15\x94\x02\x91\xc1\x3f\x68\x8f\x8b\x03\x14\x91\x51\xcc\xcf\xd1\x8d\x38\xa2\xda\x74\x48\xa4\xec\x74\xab\xf3\x1c\x0d\xbc\xd4\x86\xb7\xfb\xfa\x41\x85\x6c\xcb\xff\xdc\x20\x05\xe7\xb2\xf4\xaf\x04\x0a\x88\xfd\xce\x30\xc0\x9c\x07\xca\x54\x4b\x9d\x69\x4a\x75\x95\xbf\x93\xf0\x63\xd7\xec\xdf\x0e\xde\xdf\xd4\x66\x47\x2a\x4b\xff\xc9\xa8\xd1\x74\xb7\x25\xfe\x6c\xd1\xc3\x96\xe4\xfa\xaa\xa8\xb2\x1d\x44\x31\x5a\xbf\x66\x0f\x54\x3b\xc7\x2e\x08\x07\x62\x8d\x65\x94\xca\xe9\x7b\x5d\xe2\x06\x73\xce\xb9\xa4\x9e\x26\x39\xe2\x30\x54\x70\x99\xb9\x7d\x6f\xcc\x4b\x75\x9b\xfd\x2e\x52\x7a\x18\xbd\x26\x3f\x41\xae\x29\xe6\x99\x03\x92\xeb\x3d\x28\xb3\xe7\x57\xb3\x2b\x7b\xf7\xfe\xcb\xd7\xf7\x57\x97\xb7\xef\xdf\xc1\xb2\xb7\x5c\x2e\x91\x53\xc2\x50\x56\x5b\x15\x55\x96\x64\x36\x8a\xec\x3a\xc5\x23\x6d\xe0\xfe\xed\xea\xed\x5f\x56\x87\x4e\x69\xaa\x53\xc1\xff\x93\xec\x70\xf8\x8f\x03\x63\xfd\x12\xb3\xc8\x49\xdb\x89\x9d\x03\x82\xc2\xf8\x88\x59\x1d\x86\x31\x1d\x24\x6d\x95\x82\x67\x03\x93\xdb\x04\x8b\x50\x88\x94\x3a\x56\xa2\x25\xd2\xa1\xf3\x21\xad\x72\x82\x62\xcf\x85\x44\x6e\xa4\x42\x08\x14\x4a\x97\xb4\x70\x87\xbe\x2e\x43\xa7\x66\x80\xf3\xa6\x4f\x97\x34\x53\x1a\x5c\xc5\x75\x56\xcb\x96\x9e\xe2\xde\x98\x6d\x12\xae\xe9\x18\xc3\x28\x65\x7a\x3e\xee\x95\x48\xaa\xb2\x4c\x26\x38\x0c\x5e\x93\x18\xf9\x98\x6c\xe5\x32\x23\x70\xb8\xbd\x7a\x42\xee\x76\x2e\x52\x4e\xca\x62\x65\xbe\xb6\x29\x07\xa5\x21\xcd\x0e\xa7\xe4\x22\x57\xd7\x4d\x4e\xde\x36\x03\xf6\xe5\x4a\xa8\x6e\x7c\x1f\x4b\x5e\xf8\xe8\xbf\x26\x1b\x3a\xdd\x7f\x0f\x53\x09\x79\x27\x29\xcc\x51\xc3\xb8\x2e\xfa\xaa\x25\x70\x8c\x38\xf8\x93\xd2\x65\xed\x30\x41\xd9\x99\x3c\x0a\x52\x7d\x64\x8d\x50\xa1\xf3\xda\xc7\x7a\xa0\x0f\xd6\xa9\x0d\x26\x75\x33\x29\x8f\xa4\x74\xcb\xd7\x4e\xba\x17\x14\xf2\x46\x3d\x0e\x70\xaf\x47\x7a\x07\x9c\x89\x45\x5f\xdd\x4d\xf5\xc6\x84\x72\x4c\xa7\xc6\x5b\xfc\x93\x1c\x7a\x6a\xbb\x7f\x52\x4d\xfa\x63\x00\x4f\x6d\xfd\x4f\x92\x1d\x1d\x09\x78\xca\x18\xc0\x24\xe5\x3f\x70\x3c\xa0\x7b\x1d\x35\xa7\xcc\xe6\x93\x2e\xa1\x27\xba\x9b\x7a\xb3\x91\xe2\xf7\xbf\xdf\xde\x7e\x49\x39\x08\x3d\xde\x36\x3f\x08\x5e\xd6\x7e\x01\x6f\x40\x0f\x71\x68\xba\x62\x59\x6a\xca\x05\x74\x90\xe6\x8f\x3f\xcc\xee\x6a\x0c\x71\xb6\x4b\x0f\x4a\x97\x93\x8e\xb0\xb7\xb3\xf7\x8f\x01\x0d\x25\xaa\xb9\x0a\x0a\x94\xf7\x36\xd3\x0c\x8e\x1b\xf3\x75\x9c\x51\xad\xa4\x20\x33\xa3\x93\x9c\x77\x91\x66\x88\x6e\x83\x0e\x1e\xec\x83\xe1\xb6\xb9\xbc\x41\x96\x75\x00\x41\x27\x29\x36\x95\x88\x14\x63\x78\x85\x4d\xca\x3f\xda\x6c\xcc\x2c\xa1\xe4\x21\x2e\x6e\x78\x67\x19\x7b\x44\x3b\xc3\xc7\x0c\xab\x58\x2e\x92\x45\x37\x39\x41\xdc\x0e\xf1\x7a\x4a\x56\xc7\x23\x0e\x40\xa6\x6a\x3f\xf7\xff\x91\xae\xf9\x15\x3f\x22\xbe\x18\xb4\xc9\xca\x3a\x47\x0f\x3b\xb2\x9c\xc8\xc0\x8e\x94\x66\x08\x43\x2b\xc1\x1b\xd6\xcc\x98\x19\x17\xe2\x8d\x57\xf0\xc9\x06\x8e\xb7\xdd\xff\x32\x16\x9c\x25\x1a\x0b\x1b\x71\x2d\x98\xc7\x2d\x4e\xc7\xb4\xd9\xa8\xdd\xa1\x7a\x94\x97\x72\xb1\xda\x1c\xbb\xe9\x30\xc1\xba\xdd\xa6\xc2\x53\x0c\xea\xfd\x31\x0f\x4a\x44\x78\x1b\xf3\xfc\x94\x8b\x6d\x1d\x9d\xb3\x6e\x41\x00\x87\x22\x2e\x6b\x0d\xa9\xfb\x7f\xdc\x7c\xfe\x04\x1e\x1d\xe3\x01\x35\x15\x56\x0e\xaf\x8f\xad\xa0\x21\x27\xa1\x98\x1c\x2a\xeb\x43\xa1\x1f\x21\x4d\x68\xb0\x9b\x31\xec\x82\x4e\xa0\xa8\x82\xb8\x4f\xf2\xb9\x97\xa4\x48\x82\xa5\x7f\x43\x67\x97\xda\xe4\xf8\x48\xd9\x15\xfc\x44\x1c\x39\x2e\xf1\x48\xb2\xaa\x50\x39\xd1\x43\xae\x9e\x71\x5b\x4c\x73\x06\x23\xba\x6a\x8b\xa8\x0b\x90\x8f\x14\xc7\x46\x18\x69\x45\x26\x9e\xf2\x2a\x8a\xe0\xbb\xba\x0c\xba\x2a\x51\xb8\x4b\xd9\x4a\xf4\x00\x9c\x26\xbc\x97\x4e\xd1\x51\x05\xa1\xeb\x1b\xc0\xb7\x33\x92\xcc\xb7\x33\x58\xc6\x96\x1c\x49\xbf\xf9\x31\xd6\xba\x62\xae\x74\x02\xc5\x46\x61\x88\x32\x2b\xf4\x7f\xbf\xf9\x9f\xd5\xcc\x2b\x4e\xa0\x19\x17\x51\x68\xe7\x43\xe4\x61\x2c\x77\x9b\xf4\x92\x6f\x67\xc7\x09\x1d\x8d\x72\xed\xb5\x43\xef\xd5\x66\x06\x05\xa7\xeb\xa0\x16\xb3\xad\x77\xca\x2c\x1d\xaa\x9c\x1b\xa9\x9d\xff\x36\xf3\x3d\x24\xf9\x53\xf6\x2c\xb7\xb3\x84\x57\xd0\x8d\x04\xb1\xba\xd9\xce\x6a\x28\xbf\x9c\x89\x0e\x9d\xfd\x5b\x9e\xdb\x52\x39\xba\xe3\xd6\xf6\x04\x66\x49\x08\x78\x32\xaf\x76\x2a\xdb\x6a\x83\x73\xdc\x3a\x61\x53\xcc\xcf\x03\x6e\xa5\x72\xac\x54\x6d\x53\xfe\x4d\x77\xb8\x53\x48\x72\xc0\x64\xf4\x45\x18\x83\x56\xa3\xee\x95\x2e\x69\x8d\xcf\xc8\xb7\x23\x89\x46\xff\xb6\xf1\x84\x23\x5d\x32\x13\xfc\x94\xd8\xc9\x4f\xb4\xde\x6f\xe0\xed\x9f\x1a\x38\x05\xd2\xf5\x22\xe4\x1c\xab\x4e\x62\xd2\xe1\xa8\xea\xec\xa6\xce\x69\x57\xf4\xc4\x3f\x79\x53\xf0\xd9\x48\x5d\xb1\x1d\xb7\x12\x28\xc7\x1d\x94\x59\xba\x9d\x4e\x5e\x1a\x10\x69\x96\xf6\xb3\x36\xf9\x1f\x34\xae\xfa\xbb\x64\x31\x5f\x12\x98\x1a\x69\xfc\xa7\x8a\x02\x5e\xc6\x31\x3b\x74\x18\x67\x96\xb5\xd9\x94\x38\x9d\xda\x37\x54\xb9\x4c\x4c\xf9\xed\x3a\x39\x9d\x35\xe6\xaf\xbe\x5b\x61\xb9\x89\xc1\x1d\x88\x89\x29\xb1\x49\x8e\x5d\x17\x6d\x2f\x62\xd1\x6d\x7a\x34\x13\x64\x6d\x8f\x78\x76\x6b\x8d\x56\x76\xe6\x63\x65\xe2\x36\x5f\xc1\x0d\xe9\xad\x40\x86\x38\x87\x2d\x3d\x95\x79\x37\xd5\xf6\x6a\xb8\x54\x17\xd4\x5d\xac\x35\x72\xb6\x1b\x10\x54\xc6\x2f\x5c\xc6\x04\xcf\xfa\xf4\x92\x23\x74\x7b\x01\x2d\xad\x05\xb6\xf6\x41\x46\x84\x82\x85\x07\xa5\x43\xb3\x73\x75\x77\xd4\xa3\x6e\x71\xb0\xac\x39\xa1\x9e\x92\x43\xc2\x49\x79\x24\x5d\xb5\x7e\x82\xb7\xfa\xe5\xfa\xdd\xa1\x4d\xac\xa6\x14\x7a\x76\xcf\xed\x48\xdb\x84\x52\x3f\x79\xd8\xb9\x1d\x1e\xf0\x7f\xaa\xf5\x77\xfb\x8e\xa3\x61\x6e\xce\xcd\x3f\xc3\xe9\x84\xe9\x0c\xb7\x53\x47\xfe\x3d\x27\x15\x66\x08\xb7\xdd\xcd\xdf\x73\x6a\x61\x92\xf0\x1f\x1e\x1e\x8e\x8a\xf7\x08\x4c\x7e\x32\x38\x8e\x6e\xfe\x58\x59\xaf\xf1\x72\x53\xbc\x3a\x61\xe1\xc3\xe3\x19\x93\x2b\x3f\xbf\x09\xca\xe4\xca\xe5\xd2\xc6\x68\x8f\x27\xfc\xe1\x02\x39\xa9\x92\x62\xc9\x12\xea\xd3\xc3\x75\x7a\xa0\x7b\x88\x43\x17\xcd\xe4\xaa\x0c\xf8\x43\xa9\x77\x7a\x3e\xff\x8b\x59\x9a\x69\xa6\x9f\x39\x31\x6b\xea\x50\x71\x02\x36\xfa\xf9\xd8\x26\x38\x16\xcf\xe2\x28\xc4\x56\xa5\xc2\x0e\xd7\xde\x1a\x34\xce\x50\xa3\x41\xf9\xb6\x52\xbf\xd6\x38\x3a\xf8\xd7\xbd\xe2\x36\xd3\x59\x09\xed\x3d\x3f\x64\xe3\xd0\x44\x1c\xa9\xb4\x87\xc7\x92\xd4\xfc\xee\xe5\x08\x4a\xa7\xef\x18\x6c\x73\xd6\x45\xf8\x82\x8f\x4d\xaf\xb1\xd9\xc1\x3c\x43\x53\x4f\xf4\x4a\x24\x24\xfd\x7c\x6e\x1b\xf9\x40\xee\x45\xd4\xb1\xed\x28\x56\xd6\x8f\xcf\xfd\x76\xaf\x28\xda\xc8\xd9\xcc\x9a\x42\x6f\xea\x08\x1a\xb8\xbe\xb3\x55\x66\x23\xb3\x22\x6d\x0d\x43\xcd\x23\x5b\x7c\x80\x9d\x36\x35\x89\x95\x7b\xdf\xed\x9c\x50\x1b\xdf\x52\x41\x5f\x62\x7e\xd2\x8a\x23\x40\x0d\x0d\xd4\x5e\xfc\xba\x74\xcc\x44\x53\x3b\xa3\x47\x6b\x8c\xe3\x6e\x59\x33\x83\x3a\x4b\x33\x6a\x4b\xb7\xa2\x10\x1b\x55\xb8\x80\xda\x94\xe8\x3d\xec\x6d\x2d\xfb\x70\x98\xa1\x1e\x3b\x59\xd4\xbd\x64\x9e\xd3\xde\xa1\x91\x20\xa1\x8c\xe0\x9f\xe4\x1d\x9f\x01\x57\xf6\x38\x78\x3a\xca\xb8\x09\x6d\xc3\xa7\x09\xeb\xbe\x23\xfe\xf3\x73\xdf\xb4\x2d\xe6\xb9\x16\x85\x97\xce\x57\xa6\xf3\x0b\x44\x39\x62\x8e\x34\xfe\x96\xfa\x47\x23\xe3\x54\xfd\x95\xa6\xa9\x55\x96\x72\xd4\x75\x61\x7b\x54\xc1\x15\xfc\x5d\x46\xb4\xe3\xb4\x64\x90\xae\xff\x2c\x59\xd5\xb8\x81\xce\x52\xb8\x4e\xc8\x2a\x09\xb5\x69\xda\xee\x6b\x95\xdd\x9d\xa2\x31\x69\xce\xeb\x94\x03\x2e\x6d\x44\x98\x25\xf9\x0c\xd1\x22\xb3\x46\x8a\x72\xd9\x7e\x19\x47\x60\x96\xca\xe4\xcb\xc6\x3d\x64\xfb\xef\xce\xfa\x3c\x96\xc5\x07\x6d\xee\x4e\xd6\xb8\xf4\x80\xa0\xb4\x5f\xbe\x7e\x38\x04\x67\x27\xb4\x76\xe1\xb4\xb3\x44\xff\x64\x54\x3a\x5f\xd3\x7a\x62\x25\xeb\x61\x1b\x07\x43\x1a\xe0\x32\xb9\x7a\xdd\x8c\xcd\x9f\xc5\x6e\xf0\x59\x44\x45\xf3\x65\xad\xb9\xfe\xd0\x64\x31\x0b\x2e\xd3\x14\x60\x56\x2a\x27\xce\x41\x19\xe9\xdc\xc9\x4b\x67\x50\x46\x8e\xb0\xae\x03\xe4\x16\xa5\xbf\x64\xef\xd1\x39\x9d\x23\xe8\x49\xe1\x1e\x11\xcc\x8c\x50\xa6\xda\xf9\xcb\x89\x41\x8f\x49\x52\xa5\x5a\xe3\xb0\xe1\xf7\xcc\xc7\x6c\x3f\x2a\x9e\x83\x8e\x59\xca\x1d\xee\xc5\x05\x49\x73\x77\xe8\x34\x83\x05\xeb\x36\xca\xe8\xdf\x46\x4e\xc5\x9a\x1c\x08\x2f\x6d\xac\xd3\xbf\x21\xbc\xe4\x73\xfb\x72\x00\x16\x4b\xcc\xc2\xab\xce\xa9\x56\xb5\x87\x1d\xcf\x6b\xc9\xbf\x28\xcd\x1f\x19\xf4\x73\x58\x95\x3c\xd3\x49\x62\x6f\x66\xe7\x7c\xa4\xe9\xee\x75\x36\xd2\x80\x3e\x9a\x35\x0a\x5f\x4f\x3e\x1d\xbb\x53\x46\x6d\x30\x97\xc6\xca\xfc\xcc\xdf\xc7\xee\xad\xb0\x53\x95\x87\x07\xeb\xee\x8a\xd2\x3e\x2c\xb5\xcc\x39\xa5\xe8\x14\x41\xdb\xd8\x29\x4a\x5b\xa4\x1e\x8a\x1c\x96\x71\x98\xd6\x20\x2e\x46\x85\x86\x6a\x6c\xbb\x6a\x82\x9c\x3e\x94\xfb\x38\xbc\x32\x11\x25\xb7\xb6\xf6\x78\x87\x58\x69\xb3\x11\x88\x2b\xa3\x62\x61\x5f\x11\x24\x29\xf7\xb1\x12\x63\xce\x03\x98\xd8\x7c\x8d\xc7\x8c\x6a\x93\xa3\xf3\x61\x0c\xaf\xb6\xd5\x11\x32\xd2\xb4\xb2\xa4\x35\x09\x9a\x9f\x4b\x57\x6d\xd1\x9b\x82\x4c\x3f\x0e\x59\xe0\xda\x41\x6e\xc2\xa0\xed\x64\xa8\xaa\xaa\x72\x0f\x95\x0a\x5b\x28\xf5\x1d\xc2\xb7\xb3\x4c\x2f\xb3\xfc\xdb\x99\x20\xb8\x08\x5a\x85\x7f\x03\xb2\x7c\x80\xf0\x41\xed\x1b\xc7\xd5\x48\x23\x02\xfc\x76\xf9\xac\xed\x07\x87\xb2\xc7\xa2\x6f\x9a\xd0\xf8\x66\x0e\x87\x30\x79\xc0\x4d\x6c\x82\x39\xd1\x01\xab\x69\xa8\xed\x41\x87\xed\xd8\x28\xb3\xb1\x41\x67\x38\x18\x75\x9b\xe8\xb9\xce\x67\x5a\xc7\xe6\x59\xfa\xf1\x61\x76\x98\xa5\xf3\xc9\x8a\x4e\xa7\x75\xca\x81\xb6\xdc\xe0\xac\x8c\x67\x9b\xd3\x91\x70\x8c\x05\x2d\x62\xd4\x19\x17\xf8\x5f\xc7\x77\x9c\xc1\x3f\x6a\x3f\x45\x93\x25\xce\x25\x47\x5b\x2d\x4b\x82\x1b\xdd\x15\x47\x1d\x8c\x67\x96\x31\x23\x48\xeb\xf6\x6c\x69\x4e\x65\xc3\xa3\x50\x69\x9d\xbd\xfd\xa9\xce\x9a\xd7\x28\x1d\x1b\xcd\x3e\x30\x26\x2e\xf1\x60\x93\x18\xcc\x04\xcd\x38\x9f\x33\x36\xac\x0d\xc7\x63\x4b\x31\xea\x69\xe4\x1a\xf5\xfe\x10\xdc\x44\x73\xb6\x27\xdc\xe8\x96\x3a\xe8\x5a\xf5\xed\x65\x6e\xb5\x93\xf8\x43\x5c\x93\x3b\x41\xb9\xc4\x3b\xba\xe1\xc1\x9f\x58\x51\x69\x6c\x8f\x49\xce\x00\xa2\x2d\x7a\x3c\x61\xc9\x93\x0c\x6e\xa0\xcd\x09\x8b\xfe\xdc\x54\xa9\xe3\xb7\x63\x88\x36\xad\xb8\x2d\x5f\x4b\x39\xb3\x44\x35\x7a\x2e\x23\xad\x59\x7b\xe8\x85\x87\xf7\xdc\x15\x5e\x23\x39\x96\xe6\xbc\x3d\x59\x06\x4f\xff\xf3\x71\x92\x18\x85\x27\x48\x36\x33\x4a\x71\x88\xd6\x21\x9c\x5f\x92\x77\x3c\x67\xaf\x73\xfe\x0b\x57\xec\xce\x7f\x17\x87\x82\x9e\xea\xa1\xf4\xbb\x27\x5a\x0e\x28\x84\xee\x91\xaa\x54\x19\x6e\x64\x04\x0f\x04\xfa\x66\x06\xa4\xae\x9b\xb3\x15\xd1\x3b\x37\xc7\xcd\x74\xd1\x17\x40\xdc\xe0\x28\x9d\x63\x07\xe1\x4e\xd8\xf8\x8c\xaa\x4f\xf5\x36\xc7\xba\x4d\x7d\x84\xc5\xa7\x38\x52\x5e\x18\xcf\xa5\x90\xe3\xd7\x06\x54\xfb\x51\x8b\x15\x5c\xfb\xf6\x7c\xcf\xe8\x81\x78\x99\xf9\x97\x69\x5f\x99\x91\x5b\xb4\xc7\x79\xb9\xd1\xd7\x7e\x3f\x63\xa7\xf6\x72\x92\xbf\x39\x9b\x3d\xa6\x9b\xed\xa1\x5c\xec\x1f\xb9\xe0\xae\x49\x45\x81\xc5\x69\x15\x52\x8b\xac\xeb\xf9\x56\xe3\x27\x9b\xb4\x87\xca\xe9\x9d\x72\x9a\xe7\xfe\xe3\x90\x18\xa9\x6a\x73\x62\xa1\x3d\x60\x22\xe0\xb0\x5f\xd6\xc9\x9b\xcf\x50\x0d\xb5\x65\xa4\x1a\xfd\x3d\x1d\x03\xe6\xfd\x38\x0c\x1c\xd1\x8f\x46\x52\xf3\x10\xf0\x53\xf3\x95\x92\x6e\x00\x95\x5f\xa2\xd4\x51\x65\x5b\xe1\x68\x5f\x2b\x86\x1b\xbe\x34\xd1\x0e\x3a\xdf\x3e\xf1\x40\x4a\x72\xaf\x4a\x91\x29\x93\xff\x76\x96\x63\xa1\xea\x32\x7c\x3b\x6b\x6f\x5d\x50\xce\x33\x20\xd9\xbd\x35\x7a\xb4\x4c\x19\x6b\xb8\x26\xd5\x9f\x41\x6d\xa7\xc9\x52\xc5\x83\x7c\x4c\xd2\xd1\xa1\xf1\xc8\x67\x41\x08\xf4\xe7\x32\xbf\xd1\xae\x7a\xd9\x39\x99\x66\x7b\x07\xd0\xda\x46\x5c\x7c\xc9\x80\x6e\x2a\x9d\xc5\x63\xf9\xdf\x4c\x73\x24\x55\xc1\xbb\x4f\x37\xff\xfb\xe1\xf2\xdf\xde\x7f\x18\x6d\x55\xcc\x54\x38\x4e\x52\x96\x66\xfd\xfe\xe4\x93\x50\xf6\xc1\xa0\xfb\x8a\x7c\x42\x31\x1b\x02\xb2\x9e\xae\x7c\x88\x07\x0d\x12\x77\x73\xac\xc4\x5c\xd6\xfb\xc1\x01\x9c\xcb\x0f\x1f\x26\x19\x14\xb1\x2c\x57\x58\xb9\x26\xc5\xe7\x6f\x9a\x61\xea\xde\xc7\x5d\x22\x2f\x37\xca\xad\xd5\x06\x21\x23\x18\x9e\x8d\x02\x95\xeb\xe2\xf0\xf8\x42\x27\x09\xe9\x82\xf8\x85\x0c\x6f\x2b\xd3\x0e\x3a\x35\x95\xe5\x71\x61\xc6\x32\xb5\x6d\x2b\xa5\x89\x52\xd3\x44\xef\x9c\x94\x6a\xf1\x18\x23\xb9\x31\x3b\xb9\xe5\xb2\x42\x8b\xd1\xba\x03\x6d\xd8\xc0\x89\x0e\xd1\x13\xcf\xe9\x3e\x2f\xb2\xee\xc3\x68\xb2\x24\x39\xc8\xfa\xbb\x22\x34\x7f\x52\xe2\x33\x69\x5b\xfa\xe6\xc8\x09\x8b\x20\x99\xba\x1a\x17\x70\xf9\xe9\x5d\x2a\xae\xb3\xc6\x36\x67\x59\xcf\x0a\xeb\x90\x00\xb9\xc9\x13\xdd\xa9\x61\xb5\xe6\xfc\x78\x54\x80\x96\x58\x2b\x88\xc1\xc9\xf0\x3b\xdc\x2f\xd9\x0d\x4c\x10\x95\x8f\x6f\xf1\x67\x06\x52\xaa\x11\x6d\xa9\x73\xfc\x65\x05\xef\xc4\x87\xf1\x58\x7b\xa1\x4a\x8f\x2b\xb8\x9d\x82\x5e\xcd\x07\x84\xd2\xa9\x5b\x69\x15\x51\x82\xeb\xe1\x4c\x56\x78\x06\x15\xba\x9d\xf6\x5d\xf1\xf0\x5e\x86\xa9\xa9\x5c\x36\x9d\x62\x83\x3f\xff\xf0\x03\xbc\xfc\xc5\xc4\x13\x25\x5c\x52\x7b\x6f\x82\x0e\xfb\x57\x9d\x0f\xe0\x48\x03\x61\x4e\xd0\x6b\x6b\x4b\x54\x63\xc5\xb6\x56\x6b\x9f\x22\xe1\x03\xe6\xb1\xc9\x35\xa7\x00\x4e\xb0\x88\xd3\xd6\x36\xdd\x10\x1f\x69\x87\x1f\xaa\xfd\x1f\xdd\x93\x3c\x62\x51\xd3\x73\x43\x23\x78\xee\xd8\x5e\xbe\x1f\x88\x9c\xb4\xe6\xc9\x41\x8e\x99\x11\x8e\xe7\x58\xf1\xf4\xb0\xc5\xec\x82\xa7\x4f\x3a\x2d\x3b\xde\x74\xe4\x9f\x24\xd5\x91\x9f\x47\xc7\xa7\x96\xc4\x95\xe7\x80\xf6\x47\x7a\x59\x83\xe3\xbe\xb1\x99\x23\x28\x87\x2b\x4a\xed\xac\x46\x3c\x92\x97\x4e\xdd\x34\x81\x60\xbc\x9a\x76\x52\xcb\x6a\xa2\x2d\x35\x84\x3a\xbd\x36\xd5\xc7\x4e\x47\x99\xb0\x97\xad\x82\xde\x69\x1f\x74\x06\x9d\x36\xcd\x22\x3e\xc0\xef\xe0\xe1\xa4\xe9\xd3\xf1\x72\xee\xb6\x4d\x87\xad\xe9\x7e\x72\xd1\xba\x54\x63\x68\x92\x93\xe6\x9b\x6f\x03\x92\x32\xb5\x45\x89\x42\x4c\x20\x63\x19\x5a\x75\x1b\xe6\x4f\x6c\x8f\xa5\x96\x18\x7f\x9f\x71\xd7\xf9\x68\x98\xa4\xd8\xc4\x03\x25\x5f\xc5\xc9\xea\x52\xb9\x91\x95\x8f\xa8\x71\xb3\x93\xe9\x0f\xc8\xf4\x7a\x6d\xa7\x35\x07\x27\x1b\x82\xcf\xed\x2a\x4f\x68\xc8\x9d\x8c\x78\xa7\x1a\x6f\xfd\xa3\x56\xa7\x37\xdb\x7a\xfc\x1c\x31\x8f\x13\x1a\x6c\x93\x6b\x1d\x71\x97\x7d\x2b\x26\x47\x19\xb3\xa2\x98\xa9\x6b\x13\xbf\x12\x61\xf2\x98\xc5\x89\x7d\x1f\x7c\x1e\x6f\x04\x3f\x33\x66\x6e\x4b\xeb\xed\x47\x34\xfa\x9f\x6b\xb3\x06\x7c\x9d\x11\x76\x28\xea\xb2\xcd\x92\x47\xd4\xae\x63\x55\x9d\x0f\xb4\xa5\xef\xf5\x05\x9b\x6c\xd6\x1a\xf8\xf2\xcb\x6d\xef\x23\x8b\x5d\x35\x1d\xd0\x3d\xa5\x45\xfc\xfb\x42\xc4\x89\x4a\x34\xea\x9b\x77\xe8\xb7\x17\x83\x9b\x0e\x9e\x4d\x5f\x9a\x9e\xa1\x74\xf0\x53\x74\xbd\x0c\xe8\x97\xf1\x1b\xd6\xf7\x6f\xb9\x58\xff\x96\x9f\x90\xe1\x98\x4e\x4d\x35\x1e\x53\x8d\xbf\xfc\x5f\x00\x00\x00\xff\xff\x2e\xae\x3b\x78\x69\x5b\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3c\x6b\x73\xdb\x48\x72\xdf\xf9\x2b\xba\x78\x1f\x64\x57\x91\xb4\xbd\x7b\x97\xca\xe9\x9b\x62\x7b\x2f\xca\xf9\x55\x96\xf6\x52\xa9\x28\x95\x1a\x02\x0d\x72\x4e\xc0\x0c\x76\x66\x20\x99\xfb\xeb\x53\xdd\xf3\x00\x48\x3c\x08\xad\x75\x7b\x11\xf7\xc3\x9a\x04\x1a\x3d\xfd\x7e\x62\xb1\x5e\xaf\x17\xa2\x96\x7f\x43\x63\xa5\x56\x97\x20\x6a\x89\xdf\x1c\x2a\xfa\x97\xdd\xdc\xff\xab\xdd\x48\xfd\xea\xe1\xcd\x16\x9d\x78\xb3\xb8\x97\x2a\xbf\x84\xb7\x8d\x75\xba\xfa\x8a\x56\x37\x26\xc3\x77\x58\x48\x25\x9d\xd4\x6a\x51\xa1\x13\xb9\x70\xe2\x72\x01\x90\x19\x14\xf4\xe5\xad\xac\xd0\x3a\x51\xd5\x97\xa0\x9a\xb2\x5c\x00\x28\x51\xe1\x25\x38\x23\x8a\x42\x66\xa5\xde\xd9\xcd\x7d\x53\x89\x8d\xd4\x0b\x5b\x63\x46\xf7\xee\x8c\x6e\xea\x4b\x88\x5f\xfb\x5b\x2c\xfd\x02\xe0\x51\xb8\xf5\x77\x7f\xd0\x3b\xfe\xb2\x2e\x1b\x23\xca\x23\xa0\x0b\x00\x9b\xe9\x1a\x2f\x61\xb9\x5c\x00\x3c\x88\x52\xe6\x8c\x90\x07\xa3\x6b\x54\x57\x5f\xae\xff\xf6\xe3\x4d\xb6\xc7\x8a\x31\xa6\xaf\x73\xb4\x99\x91\x35\x5f\xd7\x79\x08\x48\x0b\x6e\x8f\xe0\x2f\x86\x42\x1b\xfe\x67\xe7\x71\x70\xf5\xe5\x3a\xc0\xa8\x8d\xae\xd1\x38\x19\x31\xa6\x4f\x87\xc2\xe9\xbb\x93\xa7\x5d\x10\x3a\xfe\x1a\xc8\x89\xa6\xe8\x9f\xf9\xe0\xbf\xc3\x1c\xac\x7f\xba\x2e\xc0\xed\xa5\x05\x83\xb5\x41\x8b\xca\xf1\xb1\x3a\x60\x01\x74\x01\x42\x81\xde\xfe\x1d\x33\xb7\x81\x1b\x34\x04\x04\xec\x5e\x37\x65\x0e\x99\x56\x0f\x68\x1c\x18\xcc\xf4\x4e\xc9\x5f\x13\x64\x0b\x4e\xf3\x23\x4b\xe1\xd0\xba\x23\x88\x52\x39\x34\x4a\x94\x44\xc8\x06\x57\x20\x54\x0e\x95\x38\x80\x41\x7a\x06\x34\xaa\x03\x8d\x2f\xb1\x1b\xf8\xa8\x0d\x82\x54\x85\xbe\x84\xbd\x73\xb5\xbd\x7c\xf5\x6a\x27\x5d\x94\xa9\x4c\x57\x55\xa3\xa4\x3b\xbc\xca\xb4\x72\x46\x6e\x1b\xa7\x8d\x7d\x95\xe3\x03\x96\xaf\x44\x2d\xd7\x8c\xa7\xa2\xb3\xd9\x4d\x95\xff\xc1\x04\x79\xb3\x17\x1d\xc4\xdc\x81\x38\x6c\x9d\x91\x6a\x97\xbe\x66\x11\x19\x25\xf3\x5f\xa5\xca\x89\x9f\x22\xdc\xe6\x4f\xd4\x52\x93\xbe\x22\x22\x7c\x7d\x7f\x73\x0b\xf1\xa1\x4c\xf1\x0e\x48\x08\xc4\x6d\x6f\xb3\x2d\x9d\x89\x2e\x52\x15\x48\x42\x22\x2d\x14\x46\x57\x4c\x56\x54\x79\xad\xa5\x72\xfc\x8f\xac\x94\xa8\x8e\x69\x6c\x9b\x6d\x25\x1d\x31\xf6\x97\x06\xad\x23\x76\x6c\xe0\xad\x50\x4a\x3b\xd8\x22\x34\x75\x2e\x1c\xe6\x1b\xb8\x56\xf0\x56\x54\x58\xbe\x15\x16\x9f\x9b\xca\x44\x50\xbb\x26\x0a\x9e\xa7\x73\x57\xdd\x01\xc6\x85\x9f\x3e\x7c\x0a\x16\xd4\x93\x1f\x00\x44\x9e\xb3\xf9\x10\xe5\x97\x91\x9b\x47\x31\x18\x54\xa3\xf6\x49\xcc\x66\x05\x8d\xb2\xce\x34\x99\x6b\x0c\xe6\x70\x8f\x87\xc0\xf1\x4a\xd4\x60\x9d\xa6\x2f\x1f\xa5\xdb\xf7\x9e\x28\xba\xdc\x17\x8e\xc5\x7d\x8b\x60\xd1\xc1\xf6\x00\x64\x24\x59\x21\x9c\xd6\x25\xb1\xca\xc3\x62\xc5\x30\xe8\x8c\xc4\x07\xec\x83\x34\x5b\xe9\x8c\x30\x87\x44\xbb\x0d\xdc\xee\xf1\x00\xc2\x20\x10\x9b\x7f\x69\xd0\x1c\xc4\xb6\xf4\x70\x82\xc2\x6e\x11\x58\xd3\xcd\x03\xe6\x3d\x90\x8f\x7b\x54\x50\xe9\x5c\x16\x07\x92\x5c\x2f\x96\x7d\xe5\xbb\x7c\xf5\xea\xbe\xd9\xa2\x51\xe8\x90\x4d\x7a\xae\x33\xfb\xaa\xb1\x68\xd6\xbb\x46\xe6\xf8\xaa\xc3\xa0\x8b\xc5\x10\xe9\x3d\xe4\xa3\x9f\xb2\xb2\xb1\x0e\xcd\x27\x32\xe8\x53\x3c\xb9\xdd\x23\x9b\x70\xb2\x4b\x5e\xf6\xf9\x3e\x78\xdc\xcb\x6c\xcf\xda\x10\xb4\x69\x8b\xa5\x56\x3b\xa2\x26\xd1\xe5\x44\xe3\xe8\x3f\x69\xa1\xb1\x98\x13\xb9\x73\x69\x9d\x54\xbb\x46\xda\x7d\x62\x94\x65\x4e\x82\xa5\x67\xf1\x03\x89\x8a\xf4\x3f\xb6\x16\x19\x91\x03\x72\x59\x14\x68\x4e\x35\xaf\x73\x18\xeb\x9f\x0c\x85\xc4\x92\xed\x04\xb1\x85\x78\x2e\xd4\xe1\x71\x8f\x06\xc1\xc8\xdd\xde\x81\xd2\x8f\xcc\x23\x51\x4b\xcb\x7a\x0f\x03\xe8\xee\x34\xf1\xc4\x69\x90\x3b\xc5\xfc\x70\x20\x0b\x96\x20\xa9\xbc\x87\x44\xd0\x26\x68\x76\xd4\xfb\xcd\x62\xa6\xe4\xf7\x5d\xec\x14\x13\x96\x6f\x4f\x2f\xa7\xd3\x09\x70\xe9\x9f\x3d\x13\xe8\x0f\x76\x02\x14\xf8\x0e\x2f\x77\x6c\xdf\x02\xef\x1e\x85\x0d\x47\x22\x13\xe5\x22\xe9\x76\x8d\x30\x42\x39\xf4\x4c\xf3\xfa\xd3\x83\x28\x15\xec\x45\x5d\xa3\xb2\xeb\x2d\x16\x44\x29\x6d\x72\x34\x20\x32\xa3\xad\x05\x8b\xb5\x30\x44\x21\x32\x0f\x7c\x06\xbb\x81\xb7\x6c\x40\xbd\xb5\x55\xba\x0f\x93\xa8\xcc\xf8\xb1\xb6\x47\x94\xd2\x19\x31\x27\x71\xf8\xfa\xd3\xdb\x1f\x7f\xfc\xf1\xcf\xe4\xd1\x2b\x66\xa7\xb4\xf4\xf5\xcf\xb7\x6f\x37\x70\xa7\x7a\x30\xbf\xe8\xba\x21\xe7\x98\x93\x05\x20\xb9\xb5\x07\xeb\xb0\xda\xc0\x57\x14\xf9\x5a\xab\xf2\xb0\x81\x4f\x4d\x59\x12\x3c\x28\xa5\x75\xf6\xb9\xed\x73\xb4\x1b\xcb\x13\xdc\xe8\x00\xc2\x5d\x02\xb9\x88\x35\x31\x68\xae\x10\xe5\x58\x22\x51\xf4\x2f\x46\x64\xf8\x05\x8d\xd4\xf9\x0d\x66\x5a\xe5\x76\x52\x9a\x3e\x35\xd5\x16\x0d\x29\xb4\xf5\x57\x83\x28\x4b\xfd\x88\x79\x08\x8e\x5a\xb9\x70\x1a\x76\x04\xbb\x68\xca\xf2\x70\x02\x12\xc0\xa1\xa9\xa4\x22\xde\x06\xc6\x4b\x07\x8f\xb2\x2c\xc9\xe1\x19\xac\xf4\x03\xe6\xad\x03\x8d\xd4\xfe\xac\xca\x03\xc9\x11\x0b\x61\x0f\x64\x3c\xd1\xb1\x9c\x97\x56\xd3\x2d\x1b\xf8\x28\x0e\x40\x9c\xa2\x27\xd8\xbd\x36\x0e\x15\xe6\x5d\x0e\x8e\x50\x56\x2a\xf7\x2f\x7f\x3c\xf9\xcd\x5b\x46\x8a\x8d\x76\x27\x7a\xd2\x43\x62\x5a\x37\xdf\x0d\xe1\xfc\xf5\xa7\xb7\xc0\xd2\x49\x4c\x65\xe9\x24\xc6\x82\x70\xc9\x70\x0e\x98\x9c\xe4\xb3\x22\x15\x19\x13\xcc\x4f\xcd\x5a\x70\x63\xad\x9a\x33\x31\x41\x24\x66\x8d\xd2\x15\x64\x0a\x51\x5a\x45\x20\x4f\xb2\x8a\x1a\x44\x7a\x9f\x4b\x83\x99\xf3\x7c\x72\xec\xd1\xb6\x7d\xee\x8b\x10\x06\x11\x72\xd8\xba\x5b\x69\x01\xbf\xd5\x98\xb9\x64\x34\xc2\x21\xe0\x85\xd2\x40\x2e\x02\x0d\x3c\x48\x2b\xb7\xe5\xa9\x9c\x83\x97\x96\x04\x8a\x95\xd0\x23\x46\x58\x19\x14\xd9\x3e\x60\xc3\x2e\xe9\x25\x88\x82\x5c\x11\x9d\x81\xa9\x2b\xfb\x5a\xef\x12\xe1\x56\xa0\x15\x07\x83\x08\x85\x54\xa2\x94\xbf\x52\xbc\x47\xcf\x20\xa2\x60\x55\xbb\xc3\x06\xae\x2c\xa3\x08\xc2\x9e\x5c\xd8\x03\xcc\x37\x92\xde\x0b\x49\xc1\x8a\xc3\xca\xae\x8e\xc8\xbc\x2d\x75\x76\x4f\xbc\xfb\x1c\x1f\x9b\x9f\x0a\x4a\x0f\xa8\xe7\xed\xaa\x63\xfb\xa2\x89\x24\x42\x36\x8a\x18\xaf\x4d\xb0\xc4\x50\x34\xc6\xed\xc9\x79\xa9\x10\xfb\x17\x0d\xc5\x49\xab\x1e\x58\x51\xba\xbd\x6e\x76\x7b\x90\x6d\x24\x14\xb5\x07\x42\x3e\x94\xa8\x1e\x2e\x88\x5c\xab\x8d\xd4\x03\x6e\x84\x1e\x48\x89\x95\xac\x70\x03\x3f\x69\x03\xf8\x4d\x54\x75\x49\xd9\x05\x79\x79\x13\x12\x0c\x96\x34\x1f\x82\x09\xa8\x35\x4b\x58\x80\xdc\x83\x29\x15\xfc\xf8\x3a\x9a\x24\x2f\x55\x7f\x6d\xb6\x74\xb1\xb7\x2a\xc4\x7f\x96\x7b\x8b\x2a\x27\xdf\xdc\xca\x7b\x32\x45\xa7\xc9\x14\x7d\xac\xdc\xf9\x58\x8f\x69\x14\x58\x46\xbc\x97\x8a\xbf\xa9\x75\xbe\x81\xab\x20\x49\xc2\x75\x90\x20\x46\x24\x24\x7a\x70\x19\x29\xc2\x05\x04\xec\x85\xc9\xbb\x48\xc4\x87\xbe\xb8\xb9\xfe\xcb\x5f\xaf\x3f\x7c\x78\xd9\x7b\x3c\x89\x75\x0f\xa4\x97\xe7\xac\x44\xa1\x9a\x7a\x15\x8c\x68\x44\xb2\xb5\xa5\x57\x5f\xae\x39\x93\xa0\xff\xf7\x2e\x31\x43\x32\xe7\x0a\xdd\xa3\x36\xf7\x3d\xb0\xb5\x30\x8e\xc3\x74\xbb\x3a\x32\xef\xc4\x23\xeb\xe8\x18\xf8\x8d\xc4\x39\xaa\x53\x60\x2c\xcb\xe8\x0a\x1a\xe5\x64\xd9\x47\x55\x81\xc8\x2b\xa9\xa4\x75\x46\x38\x6d\x48\x8e\x44\xe3\x74\xc5\x2e\xb6\x36\x3a\x43\x6b\x21\x13\x0a\x72\xf4\x84\xc1\x63\x39\x1b\xb0\x7f\xec\x66\x12\x19\x49\x77\xae\x8b\x18\xc3\xad\x5a\x66\x27\x2d\x0b\x21\x69\x38\xcd\x5e\xf4\x21\xd2\xcd\x5b\x44\xd5\x1a\x3d\x8a\x0d\xc6\x62\x81\x53\x33\x9a\x9e\xd4\x83\xdb\x35\xa3\x47\x11\xc4\xff\xf3\x88\xa1\x35\x68\x93\x3e\xed\x63\x63\x89\x6e\xde\x2a\x46\xef\xde\x21\x75\xab\xc5\xad\x50\x1a\xdc\x91\x2c\xf4\x7c\x30\xc0\x7b\x91\xed\x01\x95\x33\x87\x90\xd4\xc9\x9c\x02\xd5\x42\xa2\x49\xe5\x18\x83\xb6\xd6\x8a\xbd\x02\x64\xba\xaa\xb5\x42\x4e\xb6\xc9\x61\xca\xb2\x2f\x7e\x1d\xd5\xf0\x90\x13\x1e\x64\x98\x59\x70\x06\x4d\xee\xb1\xcc\xf4\xc0\xb2\x03\x54\x6b\x25\xcb\x15\x63\x2c\x31\x98\x09\x19\x5c\x05\x09\x74\x8c\x40\x42\x8c\x73\x7a\x60\xf6\x05\xa7\xe4\x9d\xe0\x49\xfc\x49\x18\x23\x8e\xdd\xec\x0e\x15\xc5\xcc\x78\x36\x49\x5b\xfe\xa5\x73\x65\x20\xb2\xe6\xdf\x44\x49\xf9\x67\x21\xbf\xad\xc8\x2c\xb7\xf2\xce\xd9\x41\xdf\x53\x38\x9d\x1e\x4a\x86\x5c\xc9\x5f\x9a\x90\x8d\x7d\xfe\xf4\xe1\xbf\xe0\xfa\x27\xbe\x9b\xf0\x09\xd1\xc8\x5e\xd8\x56\xc9\x6a\xa3\x1f\x64\xde\xa7\x08\x78\x76\x74\x43\x18\x42\x86\x8c\x51\x80\x6e\xd0\x35\x46\xf9\x90\xa1\xad\xb0\xa4\x68\x72\x3c\xf3\x73\x7b\xa1\x5a\x30\xb5\xb0\x36\x85\x4b\xde\x7f\x32\x08\x8e\x20\xb7\x64\x7d\xab\xad\x54\xa1\x68\x90\x0e\xd8\x03\x6a\x9b\xa2\x90\xdf\x08\x0c\xd9\x57\x7f\xa6\xe0\x8e\xf7\x21\x32\xe0\x34\xb5\x2d\x4c\x82\x69\x4a\xb4\x31\x6c\x20\xfa\xf4\x80\x86\x20\x24\x16\xdf\xb6\x08\xce\x34\x2a\xeb\x5a\xa1\x12\xd5\xce\xed\xa3\x88\x7a\x2c\xd8\xce\x48\x2a\x74\x38\xdd\x83\x59\x89\x7b\xaf\x97\x1e\xb9\xc0\x2f\xad\x3a\x3c\x66\x7b\xd7\x23\x3f\x55\x6b\x65\x21\x07\xbc\x30\xe1\x47\x77\x47\x31\xf0\x39\xb8\x77\x10\x76\xd5\x01\xec\x99\xf3\xe9\x33\x15\xda\x88\x79\x20\xe0\x8f\xaf\xff\x0c\xeb\x1e\x44\xa9\xac\x43\x91\xaf\x52\x7a\x80\x92\xc3\x96\x70\xdb\x0f\xaf\xdf\x00\xa7\xb7\x3e\x16\xf9\xd3\xeb\xd7\xbe\x10\xf0\x15\x85\xd5\x2a\x14\xe6\x48\x7f\x75\x33\xa0\xaf\x2a\x97\x99\xe0\xa4\xf7\x58\x5c\x33\xae\xbe\x84\xc0\xa9\xd0\x0d\xa5\x87\xaa\x8d\x14\x29\xe1\x71\x0e\xf3\xd5\xe8\xf9\x83\x04\x86\x32\x8e\x41\xb2\x31\x2f\xa2\x4e\x95\x87\x7e\xe8\xc9\x88\x70\x66\xda\x83\x49\xf0\xbe\x12\x84\xb5\x0f\x33\xf6\x28\x72\x34\x2f\x99\x35\x57\x75\x5d\x4a\x3a\x3a\x19\x15\x59\x40\xd4\x60\x42\x3d\x71\xa9\xaf\x50\xcf\xeb\x67\x64\x8e\x55\xad\x1d\xaa\xec\xb0\x5c\xcc\x34\x5b\x41\x40\x4e\xca\xe2\x3d\xd3\x74\x05\x96\x1c\x25\xc5\xc0\xca\xe7\x9d\x47\xa5\x0a\x11\x0f\x99\x45\x89\xa3\xf0\x59\x17\x27\x20\x21\x04\xd0\x96\x35\xc1\x3a\xe1\x70\x33\xe6\xc5\x9f\x3d\x1f\xe4\x16\xc9\x1c\xb7\xb9\xbc\x52\xdd\x8b\x89\x8d\x82\x4a\xf6\xce\xe8\xb2\x4c\x35\x33\x54\x85\xe6\x7a\x97\xd5\x55\xc4\xf9\x04\x2a\x09\xf6\x83\x30\x52\x28\x47\x29\x63\xf0\xba\xb1\x66\x14\xa2\xee\xe3\x9c\x50\x78\xff\xa4\x8b\x2e\x06\xfd\x80\x88\x43\xf1\xbd\x78\xf0\x25\xcb\x03\xd5\xc6\x38\x55\xd3\x47\x05\x21\xf6\x9f\x4a\x96\xa4\x90\x1c\x03\x1c\xc5\x8d\x3d\xa0\x64\x14\xd9\x01\x90\xe7\xa6\xe0\xbe\x3c\x74\xb0\xa0\x14\x88\x14\xfe\x51\x5a\x5c\x9d\x44\x11\x19\xf9\xfc\x1c\xcd\x80\x21\x6a\x54\x07\x44\xcc\x4e\xf7\x32\xcf\x51\xc1\x0b\xa9\xf8\xb8\xaf\x1e\x85\xcb\xf6\xfc\xe3\x0e\x1d\x64\xa2\x2c\xed\x4b\x1f\x92\x78\xfd\x9d\x20\x80\xba\x70\x94\xa9\x96\x32\x93\x94\xea\x0a\x7b\xcf\x36\x16\xf4\x96\x0d\xe7\xc9\xf3\x53\x6d\x76\xa0\xb2\xf4\x9f\x1c\x35\xc6\x9e\x0d\xc8\x54\x4b\x5b\x1d\xc5\x96\x64\x2e\xeb\x20\xb2\x9d\x88\x62\xb0\x7e\x4d\xf7\x65\x8d\xa1\x62\x27\x05\xbf\xa7\x6c\x0d\x65\x94\xda\xc8\x07\x59\xe2\x0e\x73\x72\xee\xa1\x7b\xc1\x97\xf7\x33\x36\x5f\x66\x6e\x9f\x1b\xf2\x52\xd9\x66\xbf\xab\x98\x1e\x06\xab\xc9\x77\x48\x0a\xf1\x7c\x9e\xd9\x03\xb9\x3d\x80\x50\x07\x7e\x34\x9b\xb2\x77\xef\xbf\x7c\x7d\xff\xf6\xea\xf6\xfd\x3b\x58\x1f\xa1\xcb\x25\x72\xa1\x40\x94\xf5\x5e\x04\x91\x25\x9e\x0d\x46\x76\x9d\xe2\x91\x54\xf0\xf0\x66\xf3\xe6\x4f\x9b\x53\xa3\x34\xd6\xa9\xa0\x4f\xed\xb3\xc3\xfe\x0f\x27\xca\xfa\x25\x64\x91\xa3\xba\x13\x3a\x07\x14\x0a\xe3\x37\xcc\x1a\xd7\xf7\xe9\x21\x6d\xf5\x05\xcf\x14\x26\x27\x45\x21\xd2\x86\x52\xc7\xc6\x4b\x09\xf1\xb5\x14\xd6\x45\x2c\x47\x20\x26\x24\x08\x42\xa0\x46\x2c\x84\x40\x21\x64\x49\x0e\xcf\xa0\x6d\x4a\x17\xea\x41\x5e\xd4\xba\xe8\x0f\x82\xf6\xcd\x94\x14\x57\x91\xac\x38\xcd\x9a\x1e\xfd\xde\x90\x6e\x52\x5c\xd3\x82\xee\xab\x6a\xf4\x9b\xe1\xac\xa4\x45\xa2\x2c\xa3\x0a\xf6\x9d\xd7\x68\x8c\x7c\x8e\xb7\xfe\xa3\x06\xc2\xe1\x11\x26\x77\x3b\x17\x31\x27\x65\xb6\x4a\x7b\x94\x72\x50\x1a\x92\x4e\x38\xc6\x97\xa8\x9a\x89\xbf\x9b\xc5\xe8\x45\xe3\xc1\x7e\xcc\x5f\x7e\x69\xc8\x97\x0d\x9f\x63\xcd\x41\xcc\xe0\x4f\xa3\x0d\x9d\xe9\x54\x22\x94\x17\x9b\xd2\x5d\x2e\xce\xd0\xec\xba\x38\x16\x2d\x1f\x8e\x11\x05\x7f\x12\xb2\x6c\x4c\x08\xfd\xbb\xa6\x7c\x00\x64\xa8\x8f\x50\xff\x8b\x9a\xe0\x36\xd4\x03\xa9\xd1\x26\x76\xa1\x22\x4a\x1a\x11\xf2\x48\x4a\xb7\x6c\x43\x41\x86\x57\x3b\x3d\x68\x71\xe8\xbf\x20\x55\x5c\x5a\x88\xb6\xba\x9b\xea\x6d\x16\x4f\x97\xa9\xe1\x16\xff\x28\x85\x9e\xda\xee\x1f\x81\x09\x6d\x28\x14\xc3\x9e\x27\xb5\xfe\x47\xc1\x0e\x8e\x04\x3c\x65\x0c\x60\x14\xf2\xef\x38\x1e\x30\x2b\x08\x8d\x9f\x4c\xe7\x38\x8b\x75\x37\xcd\x6e\xe7\x8b\xdf\xff\x7e\x7b\xfb\x25\xa6\x2e\x74\x7b\xdb\xfc\xa0\xf0\xb2\xb1\x2b\x78\x0d\xb2\x1f\x87\xc6\xbf\x50\x96\x1a\x33\x01\x9d\x48\xf3\xc7\x1f\x46\xae\x19\x8f\x38\xe3\x5f\x8e\x4e\xc8\xd2\xce\x3a\xd9\x7b\x9a\xfc\xc9\x31\xa7\x36\x92\x00\x61\xad\xce\x24\x07\xc7\x49\x7d\x0d\x67\x54\x1b\x5f\x90\x19\x01\x49\x32\x49\x57\xb1\x64\x78\xd9\x06\x9a\x6b\xd0\x8f\x8a\xdb\xe6\xfe\x09\x1e\xad\x93\x10\x74\x14\x62\xaa\x44\x44\x1f\xc3\x18\xa6\x94\x7f\xb0\xd9\x98\x69\x8a\x92\xab\xc5\x08\x48\x32\x25\x14\x7b\x04\x3d\xc3\x6f\x19\xd6\xa1\x5c\xe4\x91\x4e\x39\x41\x38\x0e\xd1\x7a\x8c\x57\xe7\x3d\x0e\x40\x26\x1a\x3b\xf5\xfb\x09\x33\xa8\x72\xf0\x96\x6f\xf1\xb6\x18\xa4\xca\xca\x26\x47\x0b\x15\x25\x6e\x81\xaf\x1d\x2e\x4d\x00\x86\xd6\x00\xdf\xb0\x64\x86\xcc\x98\xe2\x80\xc6\xe0\x06\x3e\x69\x47\x1d\xbc\xa3\x5f\x39\x16\x9c\x04\x1a\x0a\x1b\x01\x17\xcc\xc3\x11\xc7\x88\x74\xc6\x6b\x3f\x85\x96\x41\x43\x48\x6c\xce\x5d\x74\x42\xd6\x25\xd1\x95\xbd\x4f\x74\xea\xa9\x9c\x1c\xe2\x7a\x2a\x39\x53\x6d\xe9\x2c\xdc\xe0\xc8\xd1\x18\x6d\x56\x14\xe0\x90\xc7\x65\xa9\x21\x71\xff\x8f\x9b\xcf\x9f\xa8\xce\xc1\xf1\x80\x18\x73\x2b\xa7\x9f\x8f\x2d\xa3\x21\x27\xa6\xa8\x1c\x6a\x6d\x5d\x21\xbf\x41\x9c\xd0\x60\x33\xa3\xd8\x04\xcd\x80\x28\x9c\x9f\xae\x22\x9b\x7b\x45\x82\xe4\x63\xe9\x5f\xd1\xe8\xb5\x54\x39\x7e\xa3\x6a\x17\xfc\x44\x14\x39\xcf\xf1\xe8\xeb\x6a\x14\xc6\xcb\x21\x57\xcf\xb8\x2d\x26\x39\x83\xf1\xb2\xaa\x8b\x20\x0b\x90\x0f\x14\xc7\xfa\x1f\xa7\xbd\x0d\xb0\x94\x57\x91\x07\xaf\x9a\xd2\xc9\xba\x44\x4f\x5d\xbb\x81\xcf\xc1\x02\x70\x9a\xf0\xde\x77\x8a\xce\x0a\x08\xfd\x77\x07\x70\xb7\x24\xce\xdc\x2d\x61\x1d\x5a\x72\xc4\xfd\xf4\xa5\x56\xdd\x5c\x69\x06\xc4\x24\x30\x04\x99\x05\xfa\xbf\x5f\xff\xcf\x66\xe2\x11\x33\x60\x06\x24\x0a\x69\xa8\x89\xc2\x34\x0c\xe5\x6e\x15\x1f\x72\xb7\x5c\x2e\x26\x20\xcc\xf3\x72\xed\x5f\x85\xd6\x8a\xdd\x44\x14\x3c\xa8\x3e\x57\xb0\x6f\x2a\xa1\xd6\x06\x45\xce\x8d\xd4\xce\xaf\x51\xa1\x98\xf3\x67\xc1\x42\xbc\x9c\x39\xbc\x81\xae\x27\x08\xd5\xcd\x10\xd9\x70\xf6\xb0\x9e\xf0\x0e\xed\x87\x6c\x3a\xb9\x9f\x1c\xcd\xe6\x39\x89\xe5\x5d\xc0\x93\x69\x55\x89\x6c\x2f\x15\x4e\x51\xeb\x2c\xc8\xe0\x38\x4e\xa8\x15\xcb\xb1\x1c\x4d\xa5\xfc\x9b\x00\x9a\x39\x20\xd9\x61\x72\xf4\x45\x31\x06\x61\x23\x1e\x84\x2c\x89\xa3\xcf\x48\xb7\x33\x89\xc6\x9c\x84\x23\xfe\xf9\x99\xe0\xc5\x4c\xca\x93\x8d\xe7\x3b\x5a\xeb\xd7\xb3\xf6\x4f\x75\x9c\x3e\xa4\x3b\xf2\x90\x9b\xc5\x77\x12\xe9\x74\x54\x75\xf2\x50\x17\x74\x2a\xba\xe3\x1f\x7c\x28\xf8\xac\x7c\x5d\xb1\x1d\xb7\x22\xbf\x10\x66\xe7\x26\xe1\x76\x3a\x79\xa1\xb3\xd9\xa2\x46\x83\xb7\xbf\xd3\xb8\xea\x6f\xe2\xc5\x74\x49\x60\x40\xc0\xe8\x86\x7f\x2c\x2b\xe0\x45\x18\xb3\x43\x22\x1a\x15\x99\xac\x54\xbb\x12\xc7\x53\xfb\xf8\xf1\x65\x62\xca\x6f\xb7\xd1\xe8\x6c\x31\x7f\xf9\xdd\x02\xcb\x4d\x0c\xee\x40\x8c\x4c\x89\x8d\x52\xec\xba\x68\x7b\x11\xab\x6e\xd3\x23\x4e\x4a\x74\x7a\xc4\x13\x30\xa1\x1d\x02\x8c\x59\x2d\x57\xfb\x68\xe2\x36\xdf\xc0\x0d\xc9\x2d\x9b\xc8\x38\x87\xed\x7b\x2a\x93\x10\x3b\xbd\x1a\x2e\xd5\x39\x6a\x89\x51\x28\x53\xf2\x8c\x2f\x0d\x5f\x65\x64\x57\x60\x1d\x12\x3c\x6d\xe3\x43\xce\xc0\x3d\x72\x68\x11\x17\xd8\xeb\x47\x3f\x22\xe4\x34\x3c\x0a\xe9\xd2\xc9\xc5\xfd\x14\xed\x23\xaa\xa7\x68\x4d\x31\x75\x4e\x0e\x39\x2f\x8f\xa4\x4f\x23\x9f\x60\xad\x7e\xbe\x7e\x77\xaa\x13\x9b\x31\x81\x5e\xcc\x0a\xb7\xc6\x84\xfa\xc9\xc3\xce\xed\xf0\x80\xfd\x43\x23\xbf\xdb\x76\x9c\x75\x73\x53\x66\xfe\x19\xb6\x13\x16\x93\x02\x18\xaa\xb1\xbf\x65\x53\x61\x31\x43\x63\x7e\xd3\xd6\xc2\x28\xe0\xdf\xdd\x3d\x9c\x65\xef\x99\x30\xf9\xc9\xc1\x71\x30\xf3\xe7\xca\x7a\xc9\xca\x6d\x7e\x3b\xe2\xfd\xf5\x8c\x51\xcc\x2f\x6e\x9c\x50\x39\x4d\xa0\x51\x63\x27\xdd\xfb\x4f\xf0\xd7\xb3\x2a\x29\x9a\x34\xa1\x99\xef\xae\xe3\x0d\x31\xb1\xa0\xa6\x85\x2c\xd2\xe4\x2a\x97\xa8\xa9\xfb\x59\x49\xb7\x98\x91\xa5\x85\x2e\x34\x35\x7b\x28\x31\x0b\x25\xc0\xd8\x5f\x89\x76\x3e\xb4\x09\xce\xf9\xb3\x30\x0a\x41\x0d\x50\x4e\xa8\x89\x67\x9d\x68\x9c\x43\x8d\x14\xe5\xeb\x5a\xd0\x38\xcd\xd0\xe0\x5f\xf7\x2f\x1c\x33\xee\x4a\x48\x6b\xf9\x26\x1d\x86\x26\xc2\x48\xa5\x3e\x52\x76\xc6\xf6\x3c\xa6\x79\xa7\xef\xe8\x74\xf0\xbc\xa1\x7e\xae\xf0\x5b\xea\x35\xa6\x13\x4c\x82\x4c\x3d\xd1\xb7\x9e\x43\x64\xdf\xb8\xdf\xcd\xe5\x7e\xe5\x82\x38\xb6\x1d\xc5\x5a\xdb\xe1\xb9\xdf\xee\x9f\x2c\xba\x43\x26\x54\x07\x94\xbb\x26\x04\x0d\x44\xe7\x6c\x2f\x14\x75\x3c\x75\xb7\x86\x21\x26\x41\x16\xf8\x08\x95\x54\x54\x46\xa1\x12\x45\x77\x4e\xa8\xf5\x6f\xb1\xa0\xef\x93\xd8\x28\x15\x93\x70\xd9\x1f\x36\xe4\x05\x3d\x5d\x93\xa4\x76\x46\x8f\xb6\x08\xde\x63\x65\x69\x06\x75\x12\x66\x90\x96\x6e\x45\x21\x34\xaa\x90\x46\x31\x4b\xea\x60\x1d\x74\xe3\xcf\x61\x30\x43\x39\xb4\x59\xd4\xfd\x63\xd4\x9c\xbe\x47\xe5\x9d\x84\x50\x3e\xfe\x89\xd6\x71\x2a\x04\x39\x6b\xa8\xba\x3e\x3e\x50\x70\xb6\x62\x5f\xdc\xb8\xb6\xe1\x93\xdc\x7a\x98\xaf\x62\xf6\x5f\x5c\xd8\xd4\xb6\x98\x80\x0a\xb1\xf3\x12\x1b\x2e\xd1\x6f\x92\x56\xc4\x98\x23\x8e\xbf\xc5\xfe\xd1\xc0\x38\x55\xf7\xd3\x4e\xad\x32\x97\x83\xac\x7b\xb2\x07\x11\xdc\xc0\xdf\x98\x59\x55\x98\x96\x74\x34\x9f\x71\x86\x19\x22\x99\x81\x0e\x2a\x64\x78\xbc\x48\x42\xa3\x52\xdb\x7d\x2b\xb2\xfb\x39\x12\x13\xe7\xbc\x66\x8c\xc3\x74\x3c\xc2\x24\xc8\x67\xf0\x16\x99\x56\x7e\x80\x21\x3b\xac\xc3\x08\xcc\x5a\xa8\x7c\x9d\xcc\x43\x76\xb8\xf8\x5e\xc1\xb3\x58\x16\x1f\xa4\xba\x9f\x2d\x71\xf1\x06\x1f\xa5\xfd\xfc\xf5\xc3\x69\x70\x96\x44\x67\x4a\x29\x66\xed\x12\x7d\xdf\xd9\xce\x46\xa5\xd3\x35\xad\x27\x56\xb2\x1e\xf7\x61\x30\x24\x05\x2e\x23\x70\xb9\xf6\x14\xe6\xe8\x96\xa1\x1b\xbc\x0c\xc9\xef\x74\x59\x6b\xaa\x3f\x34\x5a\xcc\x82\xab\x38\x05\x98\x95\xc2\xd0\x24\x1c\x4f\xb6\x72\xe7\xce\x3f\x74\x14\x26\x77\xf4\xb6\x8d\x83\x5c\x23\x95\xcb\x1c\xe8\x07\x34\x86\x1a\x1e\xb2\xb7\xa5\x37\x93\x31\x13\x4c\x19\x6b\xe7\xaf\x47\x06\x3d\x46\x41\x95\x62\x8b\xfd\x86\xdf\x33\xaf\xd9\x7e\x14\x35\x59\xca\x90\xa5\xdc\xe3\x81\xc8\x1a\x77\xbf\xfb\x46\xd3\x69\xd0\x66\x27\xa8\xe7\xdc\x7b\x26\xdd\x47\xf1\xd2\x4e\x1b\xf9\x2b\xc2\x0b\xde\xdb\x67\x68\x16\x4b\xcc\xdc\xcb\x70\x48\x5a\xa6\x13\x07\xa8\x78\x5e\xcb\xff\xa4\x8d\x1d\x1a\xf4\x33\x58\x97\x94\xf6\x93\x6c\xb6\xb3\x73\x36\xc0\x34\x0f\x32\x43\xfb\xf4\xac\xd1\xd3\xf5\x62\x2e\x1b\x2a\xa1\xc4\x0e\x73\xdf\x58\xb9\x9c\x22\xe6\xf2\x63\xf7\x52\xa8\x44\x6d\x81\x96\x30\x8a\x52\x3f\xae\x65\xce\x68\x47\xef\x14\xfa\xf1\x43\x5b\x94\xba\x88\x3d\x14\x26\x3f\x35\x79\x02\x0e\xe4\xb3\xf8\xbb\x08\x35\xb4\x5d\x25\x85\x9c\x96\x46\xd7\xa8\xae\x31\xea\x25\xf7\xba\xb1\x78\x8f\x58\x4b\xb5\xf3\x21\x2e\x05\xcd\x96\x8c\x90\xa4\x79\xb9\x43\xa8\xc4\xd0\x38\x9c\x0a\xcd\xd7\xb0\x66\xd4\xa8\x1c\x8d\x75\x43\xf1\x6a\x5b\x1d\xd9\xc0\x55\x3a\x6f\x94\x9a\x18\x9a\x5f\xf8\xae\xda\xea\x68\x0a\x32\x7e\xd9\x83\x19\x36\x01\xe2\xc8\x4e\x67\x32\x54\xd4\x35\x4d\xbb\x09\xb7\x87\x52\xde\x23\xdc\x2d\x33\xb9\xce\xf2\xbb\x25\x91\x02\x63\xd0\xea\xe9\xd7\x03\x4b\xa6\xbe\x7c\x14\x87\x64\xb8\x12\x37\x42\x80\xdf\xa2\xcf\x21\xc2\xc9\x52\xf6\x90\xf7\x8d\x13\x1a\x77\xea\xb8\x03\x1e\x06\xdc\x88\xc8\x81\x12\x9d\x60\x35\x0e\xb5\x51\xcd\x70\x68\x94\x59\x69\x27\x33\xec\x8d\xba\x8d\xf4\x5c\xa7\x33\xad\x73\xf3\x2c\x47\x12\x3c\x3d\xcc\x92\x42\xaa\x18\xe5\x4d\xa5\x1a\x9d\xa2\x19\x31\x85\xf8\x46\x36\xdb\xaf\x84\x63\x28\x68\x91\x0f\x59\x72\x81\xff\x55\x78\xc6\x12\xfe\xde\x9c\xbc\xb3\xa2\xfd\x30\xc7\x89\x4d\x4e\xd7\xeb\x92\x92\xd3\x2e\xc6\x41\x06\xc3\xce\x32\xd2\xd2\x11\xad\xe8\x93\xa6\x19\x91\xdd\x8f\xe2\x79\x74\xbe\x38\x93\x48\x38\x6f\x91\x3b\x60\x34\x0b\x99\xa5\x42\x48\x58\x6c\xf2\x0a\x33\x02\x33\xcc\xe7\x0c\x0d\x6b\x9f\x31\xce\xa9\x1b\x3e\xc8\xcb\x11\xeb\x0f\xce\x34\x78\x9e\xb9\xc1\x2c\x75\xa2\x6b\x71\xac\x2f\x53\xd8\x0e\x18\xc6\xae\x79\x34\x33\x84\xcb\x5b\x47\xd3\x5f\xfc\xd1\xc5\xb1\xee\x31\xc8\x61\xda\x04\x8e\x59\x9c\x81\xf2\x28\x81\x53\x68\x33\x03\xe9\xcf\xf1\xda\xf8\xee\x18\x82\x4d\x24\x4b\x40\x42\x39\xb3\x44\x31\xb8\x97\x11\x71\x96\x16\x8e\xdc\xc3\x7b\xee\x0a\x6f\x91\x82\xcd\xb4\x6f\x4f\x9a\x41\x21\x23\xf9\x5f\x19\xbd\xf0\x08\xc8\x34\xa3\x14\x86\x68\x0d\xc2\x05\x6d\x10\x1c\x2e\xd8\xb4\x5f\xfc\xcc\x15\xbb\x8b\xdf\x44\x21\x2a\xe9\xcf\x20\x0e\xad\x62\x40\x77\x43\x90\x08\x13\x2b\xc3\x89\x47\xf0\x48\x6d\x8f\x89\x01\xa9\xeb\xb4\x5b\x11\xac\x73\x5a\x37\x93\xc5\x31\x03\xc2\x01\x17\x53\x25\xf2\xb1\x45\xb8\x19\x07\x9f\x10\xf5\xb1\xde\xe6\x50\xb7\xe9\x88\x46\x17\xbc\xc5\x11\xf3\xc2\xb0\x97\x42\x86\x9f\xc6\x2c\xda\x97\x5a\x6c\xe0\xda\xb6\xfb\x3d\x83\x0b\xf1\x2c\x25\x61\xda\x97\xeb\xc5\x76\xd5\xae\xf3\x72\xa3\x2f\xfd\xc0\xf5\x31\x5a\x6c\x79\x4c\xbb\xd9\x43\xb2\x99\x2a\x48\xed\x92\x4f\x34\x83\x0a\x44\x4d\x8e\xc5\x50\xe7\x2b\xbc\x84\xa3\x6b\xf9\x36\xc3\x9b\x4d\xd2\x42\x6d\x64\x25\x8c\xe4\xb9\xff\x30\x24\x46\xa2\x9a\x36\x16\xda\x05\x13\x2a\x65\xe5\x27\x65\x9d\x3c\xbd\x86\xaa\x2f\x2d\x03\xd5\xe8\xa7\xc6\x7e\xad\xd5\xb1\x7f\xa0\x43\x8d\x84\x81\x03\xf2\x91\x38\x35\xc9\xed\xe5\xa7\x78\xd9\x91\x03\xf5\xdf\x04\xae\xd3\xee\x3a\xa8\xbe\x54\xf4\x0f\x7c\xa5\x82\x1e\xa4\x87\x93\xb6\x51\x7e\xf1\x20\x4a\xcf\x53\x06\x7f\xb7\xcc\xb1\x10\x4d\xe9\xee\x96\xad\x44\xad\x60\x3b\x10\x5a\x74\x2f\x0d\x16\x2d\x13\x4a\x2b\xe2\x6a\x9b\x01\x33\x2f\xda\x69\xb2\x58\xf1\xa0\x50\x34\xca\x68\x0f\x72\x78\x2d\x08\x05\xfd\x64\x08\xbb\xc2\x1d\x86\x69\xd8\x9c\xa5\x20\xc2\x9b\xad\xb6\x11\x17\x1e\xb2\x18\x9b\x1d\x0e\x6b\xf9\x77\x2a\xad\xa4\x0a\x78\xf7\xe9\xe6\x7f\x3f\x5c\xfd\xdb\xfb\x0f\x9b\x69\xe1\xe8\x01\x9d\x25\x2c\x09\x7f\xbb\x9c\x2b\x25\xfa\x51\xa1\xf9\x8a\xfc\x6e\x9a\x0c\xed\xa4\xac\x7c\x08\x8b\x06\x91\xba\x39\x52\x82\x18\xa3\xfc\xb6\xfc\x40\xc9\xf4\xd5\x87\x0f\xa3\x04\x0a\xb1\x2c\x57\x58\xb9\x26\xb5\xc5\xee\x30\x75\x07\x54\xa2\xe5\x4e\x98\x2d\x8d\x5e\x67\xb4\x8c\x44\x4b\x3f\x7d\xd9\xbb\x2e\x8e\xee\x94\xb6\x9b\x84\x74\x83\x78\x7a\x82\x5f\x7a\x49\x83\x4e\xa9\xb2\xdc\x83\x1a\x36\x5f\x64\x94\x5d\x69\x8f\x20\xa5\x26\x7a\xfb\x65\x27\x1e\xa3\x3b\xcc\x90\x9e\xdc\x72\x59\xa1\x8d\xd1\xba\x03\x6d\x21\x79\x22\xbb\xd9\x02\xdd\xfc\x33\x22\xeb\xe3\x30\x9a\x34\x89\xc5\x64\xc4\x2d\x8e\x8a\x58\x58\x8d\xa1\x57\x4a\x7c\x26\x69\x8b\xef\x1c\x99\x81\x04\xf1\xd4\xd0\x6b\xdf\xae\x3e\xbd\x8b\xc5\x75\x96\xd8\xb4\xcb\xba\xa4\x06\x36\x05\xe4\x2a\x8f\x70\x4f\x65\xbf\xb7\x3f\x1e\x04\xa0\x05\xd6\x32\x22\x08\x61\xdb\x91\xbc\xc7\xc3\x9a\xcd\xc0\x08\x50\xda\x09\x20\x7b\xe8\x64\x19\x53\x8d\xa0\x4b\x9d\xf5\x97\x0d\xbc\xf3\xe6\x8e\xd2\x09\x28\x44\x49\xef\x4f\xbb\x1d\x0b\xbd\xd2\x0b\x84\xe2\xd6\x2d\x55\x32\x0c\x27\xb8\x16\x96\x1e\xc3\x25\x6d\x26\x54\xd2\x76\xd9\xc3\x67\xe9\xa7\xa6\x41\xcf\xe3\x16\x1b\xfc\xf1\x87\x1f\xe0\xc5\xcf\x2a\x6c\x94\x50\xad\x0a\xde\x2b\x27\xdd\xe1\x65\xd2\xb6\xd8\x40\x98\x62\xf4\x56\x6b\x7a\xd5\xc3\xc0\x15\xad\xd4\x3e\x85\xc3\x27\xc4\xe3\x17\xd6\xa5\x2d\x80\x19\x1a\x31\x0f\xb7\xf1\x86\xf8\x11\x56\xbe\x1d\x7e\x2a\xf6\xbf\x77\x4f\xf2\x8c\x46\x8d\xcf\x0d\x1d\x9f\xe5\x53\x67\x8f\x68\xf4\x2c\xdf\x1f\x88\xcc\xc2\xb9\x91\xb3\xc8\x7f\x34\xc2\xf1\x1c\x18\x37\xf2\x37\x11\x39\xc6\x0e\x7d\x9c\xd7\x1d\x6b\x3a\xf0\x23\xc9\xd9\x62\xe6\x66\xd4\x1a\x1a\x99\x3f\x47\x68\x1f\xa3\xe9\x11\x23\x7f\x44\x62\x5a\xf7\x0d\xcd\x1c\x36\x6f\xe4\x7d\xba\xb3\x1a\x61\x25\x2f\x6e\xdd\x24\x47\xb0\x18\x4c\x14\x67\xb5\xac\x46\xda\x52\x3d\x88\xc7\x6d\xaa\x8f\x9d\x8e\x32\xc5\x5e\xba\x76\xb2\xa2\x57\xf0\x65\xd0\x69\xd3\xac\xc2\x0d\xfc\x0c\x9e\x99\xea\x1b\xc2\xb8\xc1\xe1\xf7\x6e\xdb\x74\x98\xca\xf6\x29\x45\xa1\xad\xe1\x50\x63\x88\x5f\xb5\xef\x7c\xeb\x81\xe4\x78\x98\x5b\x67\x21\x81\x0c\x65\xe8\xb6\x53\xf6\xf4\xf6\x58\x6c\x89\xf1\xfb\x19\xab\xce\x4b\xc3\x7c\x8a\x4d\x34\x10\xfe\xad\x38\x59\x53\x0a\x33\x80\x79\x0f\x64\xe7\x24\x77\x6a\x4e\x03\x68\x66\x73\x70\xb4\x21\xf8\xdc\xa6\x72\x46\x43\x6e\x76\xc4\x3b\xd6\x78\x3b\x52\x8f\x9b\xf9\xcd\xb6\x23\x7a\x9e\xc0\x84\x79\x0d\xb6\x51\x5c\x07\xcc\xe5\xb1\x16\x93\xa1\x0c\x59\x51\xc8\xd4\x29\x9a\x95\xe1\x0d\x94\x9c\x0b\x84\x96\x56\xaa\xbe\x04\xb4\x4f\xc0\x42\x78\x4f\x61\x5b\x5a\x0f\xf9\x75\x47\x4c\x58\x30\x81\x5e\x10\xd5\x64\x54\xac\xa4\xf7\x19\xa5\x2c\x59\x17\x53\x2f\x32\xed\xbc\xa0\x2d\xbe\xaf\x8f\x16\xa5\xbc\xce\x6a\x05\x5f\x7e\xbe\x6d\x35\xf2\x44\x4c\x7b\x70\xb7\x87\x11\xb2\x7e\xb7\x8b\x98\x29\x44\x83\xb6\xb9\x42\xbb\xbf\x5c\x9c\xb9\x37\xbe\x69\x7a\x02\xd2\xc9\x57\xc1\xf4\x72\x40\xbf\x0e\xef\xb0\x7e\x78\xc3\xc5\xfa\x37\x8b\x64\x2f\xf2\x4e\x4d\x35\xac\xa9\x5e\x82\x33\x0d\x2e\xfe\x6f\x00\x2e\xae\x3b\x78\x69\x5b\x00\x00"),
 		},
 		"/control-plane/crds/kuma.io_trafficpermissions.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_trafficpermissions.yaml",
-			modTime:          time.Date(2019, 9, 10, 9, 59, 52, 147143742, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23436,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\x59\x73\x1b\x49\x72\x7e\xd7\xaf\xc8\xe0\x3e\x50\x8a\x00\x20\x69\x66\xd7\xe1\xe5\x1b\x4d\x69\xd6\xf4\xe8\x0a\x91\xb3\x0e\x87\xe5\x70\x14\xba\xb3\x81\x5a\x36\xaa\x7a\xaa\xaa\x49\x62\x7e\xbd\x23\x33\xab\xfa\x40\x1f\x00\x47\xdc\x09\xf7\x1b\x8e\xce\xae\xca\xf3\xcb\xa3\xfa\xc5\x72\xb9\x7c\xa1\x2a\xfd\x77\x74\x5e\x5b\x73\x01\xaa\xd2\xf8\x18\xd0\xd0\x27\xbf\xba\xfb\x57\xbf\xd2\xf6\xf5\xfd\xdb\x35\x06\xf5\xf6\xc5\x9d\x36\xf9\x05\x5c\xd5\x3e\xd8\xdd\x57\xf4\xb6\x76\x19\xbe\xc3\x42\x1b\x1d\xb4\x35\x2f\x76\x18\x54\xae\x82\xba\x78\x01\x90\x39\x54\xf4\xe5\xad\xde\xa1\x0f\x6a\x57\x5d\x80\xa9\xcb\xf2\x05\x80\x51\x3b\xbc\x80\xe0\x54\x51\xe8\xac\x42\xb7\xd3\x3e\x3e\xac\xde\xa9\x95\xb6\x2f\x7c\x85\x19\x91\xd8\x38\x5b\x57\x17\x90\xbe\x96\x3b\x3d\xfd\x02\x20\x2b\xb9\x15\x22\x5f\x1a\x22\xfc\x5b\x55\xd6\x4e\x95\x63\x8f\x78\x01\xe0\x33\x5b\xe1\x05\x9c\x9d\xbd\x00\xb8\x57\xa5\xce\x79\x95\x42\xd4\x56\x68\x2e\xbf\x5c\xff\xfd\xc7\x9b\x6c\x8b\x3b\x25\x5f\x02\xe4\xe8\x33\xa7\x2b\xfe\xdf\xf0\x91\xa0\x3d\x84\x2d\x82\xdc\x03\x85\x75\xfc\x71\xf8\x70\xb8\xfc\x72\x1d\x29\x56\xce\x56\xe8\x82\x4e\xbb\xa1\xab\x23\x84\xe6\xbb\x83\x67\x9f\xd3\xe2\xe4\x3f\x90\x13\xdb\x51\x1e\x7d\x2f\xdf\x61\x0e\x5e\x16\x61\x0b\x08\x5b\xed\xc1\x61\xe5\xd0\xa3\x09\xbc\xc9\x0e\x59\xa0\xbf\x28\x03\x76\xfd\x0f\xcc\xc2\x0a\x6e\xd0\x11\x11\xf0\x5b\x5b\x97\x39\x64\xd6\xdc\xa3\x0b\xe0\x30\xb3\x1b\xa3\x7f\x6b\x28\x7b\x08\x96\x1f\x59\xaa\x80\x3e\xf4\x28\x6a\x13\xd0\x19\x55\x12\x5b\x6b\x5c\x80\x32\x39\xec\xd4\x1e\x1c\xd2\x33\xa0\x36\x1d\x6a\xfc\x17\xbf\x82\x8f\xd6\x21\x68\x53\xd8\x0b\xd8\x86\x50\xf9\x8b\xd7\xaf\x37\x3a\x24\xb5\xcb\xec\x6e\x57\x1b\x1d\xf6\xaf\x33\x6b\x82\xd3\xeb\x3a\x58\xe7\x5f\xe7\x78\x8f\xe5\x6b\x55\xe9\x25\xaf\xd3\x04\xd6\x9e\x5d\xfe\x27\x17\x55\xd2\x9f\x77\x16\x16\xf6\x24\x6f\x1f\x9c\x36\x9b\xe6\x6b\x56\x9f\x49\x36\xff\xac\x4d\x4e\x62\x55\xf1\x36\x59\x6e\xcb\x4d\xfa\x8a\x98\xf0\xf5\xfd\xcd\x2d\xa4\x87\x32\xc7\xfb\x2c\x66\xe6\xb6\xb7\xf9\x96\xcf\xc4\x17\x6d\x0a\x74\x22\xa7\xc2\xd9\x1d\x53\x44\x93\x57\x56\x9b\xc0\x1f\xb2\x52\xa3\xe9\xf3\xd8\xd7\xeb\x9d\x0e\x24\xd8\x5f\x6b\xf4\x81\xc4\xb1\x82\x2b\x65\x8c\x0d\xb0\x46\xa8\xab\x5c\x05\xcc\x57\x70\x6d\xe0\x4a\xed\xb0\xbc\x52\x1e\x9f\x9b\xcb\xc4\x50\xbf\x24\x0e\x1e\xe7\x73\xd7\x23\xa4\x6b\x4c\xf9\xd9\x00\x68\x17\xac\xa8\x07\x3f\x00\xa8\x3c\x67\x0f\xa3\xca\x2f\x13\x37\x4f\xae\x60\xd4\x8c\xda\x27\xb1\x98\x0d\xd4\xc6\x07\x57\x67\xa1\x76\x98\xc3\x1d\xee\xa3\xc4\x77\xaa\x02\x1f\x2c\x7d\xf9\xa0\xc3\x76\xf0\x44\xd5\x95\xbe\x0a\x2c\xd6\x35\x82\xc7\x00\xeb\x3d\x90\x1f\x65\x83\x08\xd6\x96\x6c\x39\x4c\x8b\x0d\xc3\x61\x70\x1a\xef\x71\x48\xd2\xad\x75\x70\xca\xed\x1b\xde\xad\xe0\x76\x8b\x7b\x50\x0e\x81\xc4\xfc\x6b\x8d\x6e\xaf\xd6\xa5\xd0\x89\x06\xbb\x46\x60\x25\x73\xf7\x98\x0f\x48\x3e\x6c\xd1\xc0\xce\xe6\xba\xd8\x93\xe6\x8a\x5a\x0e\x8d\xef\xe2\xf5\xeb\xbb\x7a\x8d\xce\x60\x40\x56\x8c\xdc\x66\xfe\x75\xed\xd1\x2d\x37\xb5\xce\xf1\x75\x47\x40\xe7\x2f\xc6\x58\x2f\x94\x7b\x3f\x65\x65\xed\x03\xba\x4f\xe4\xf3\xe7\x64\x72\xbb\x45\x76\xef\xe2\xba\x30\xdd\x07\x0f\x5b\x9d\x6d\xf9\x9b\x68\x4d\x6b\x2c\xad\xd9\x88\xe2\xdf\x1e\x5a\x1c\x5d\xda\x43\xed\x31\x27\x76\xe7\xda\x93\xad\xd6\xda\x6f\x1b\x41\x79\x96\x24\x78\x7a\x16\x3f\x90\xb8\xc8\x81\xa5\x52\x19\xb1\x03\x72\x5d\x14\xe8\x0e\x2d\xaf\xb3\x19\x2f\x4f\x86\x42\x63\xc9\x7e\x82\xc4\x42\x32\x57\x66\xff\xb0\x45\x87\xe0\xf4\x66\x1b\xc0\xd8\x07\xa6\xae\x2a\xcd\x92\x71\x30\xb2\xdc\x8d\x65\x6f\x62\x41\x6f\x0c\xcb\x23\x80\x2e\x98\x9a\x36\x12\x44\x11\xac\x8b\x96\x9d\xec\x7e\x35\xca\xfe\x11\xcd\x1f\x46\xe1\x39\x21\x9c\x5d\x1d\xfe\x5d\xbc\x60\x68\x3e\x0e\x5c\xa0\x6c\x6c\x68\x8a\x7a\x87\xa2\x77\xec\xdf\xa2\xec\x1e\x94\x8f\x5b\x22\x17\x15\x12\xeb\x36\xb5\x72\xca\x04\x14\xa1\x89\xfd\x0c\xc5\x6a\x60\xab\xaa\x0a\x8d\x5f\xae\xb1\x20\x4e\x59\x97\xa3\x03\x95\x39\xeb\x3d\x78\xac\x94\x63\x5e\x55\xe8\x44\x47\x57\x70\xc5\x0e\x54\xbc\xad\xb1\x43\x9a\xc4\x65\x5e\x1f\x5b\x7b\x5a\x52\xb3\x47\xcc\xe9\xa9\x5f\x7f\xba\xfa\xf1\xc7\x1f\xff\x4a\x81\x7d\xc7\xe2\xd4\x9e\xbe\xfe\xe5\xf6\x6a\x05\xdf\xcc\x80\xe6\x17\x5b\xd5\x14\x1c\x73\xf2\x00\xcc\xa1\xbd\x0f\xb8\x5b\xc1\x57\x54\xf9\xd2\x9a\x72\xbf\x82\x4f\x75\x59\x32\x50\x28\xb5\x1f\x31\xc4\xef\xf4\xcf\xc9\x6f\x9c\x1d\xac\x8d\x36\xa0\xc2\x05\x90\x22\x2d\x49\x40\xa7\x2a\x51\x8e\x25\x12\xf5\xbf\x39\x95\xe1\x17\x74\xda\xe6\x37\x98\x59\x93\x0f\x7c\x70\x4f\x9b\x3e\xd5\xbb\x35\x3a\x32\x68\x2f\xff\x06\x55\x96\xf6\x01\xf3\x88\x91\x5a\xbd\x08\x16\x36\x44\xbb\xa8\xcb\x72\x3f\xd4\x25\x82\x50\x86\x64\x1b\x05\xaf\x03\x3c\xe8\xb2\x24\x4d\x71\xb8\xb3\xf7\x44\x31\x05\xd0\xc4\xed\xcf\xa6\xdc\xb3\x7c\x49\x09\x07\x24\xd3\x8e\xfa\x7a\x5e\x7a\x4b\xb7\xac\xe0\xa3\xda\x03\x49\x8a\x75\x71\x6b\x5d\x40\x43\x1a\xdb\x4a\x70\x82\xb3\xda\x84\x7f\xf9\xf3\x28\x57\x09\x1b\x6d\x0e\xec\x64\xb0\x88\x79\xdb\x7c\x37\xb6\xe6\xaf\x3f\x5d\x01\x6b\x27\x7b\x07\xd2\x4e\xb6\x3c\x15\x1a\xc7\x39\xe2\x72\x9a\x98\x95\xb8\xc8\x2b\xa1\x1d\xf6\xdd\x5a\x0c\x63\xad\x99\x8b\x45\xab\x46\x58\x93\x7c\x15\x33\x62\x57\xd5\x1a\x02\x45\x92\x45\xb2\x20\xb2\xfb\x5c\x3b\xcc\x82\xc8\x29\x70\x44\x5b\x0f\xa5\xaf\x22\x0c\xe2\x28\xd8\x2e\x5d\x7b\xc0\xc7\x0a\xb3\xd0\x38\x8d\xb8\x09\x78\x69\x2c\x50\x88\x40\x07\xf7\xda\xeb\x75\x39\x8c\xb1\xac\x2d\x0d\x29\x36\x42\x59\x18\xad\xca\xa1\xca\xb6\x71\x35\x1c\x18\x5e\x81\x2a\x02\x46\x54\x4f\xdc\xd5\x43\x85\x0a\x0d\xe3\x16\x60\x0d\xc3\x01\x84\x42\x1b\x55\xea\xdf\x08\xef\xd1\x33\x78\xcd\xbb\x2a\xec\x57\x70\xe9\x79\x89\xa0\xfc\xc1\x1f\x07\x84\xf9\x46\xb2\x7b\xa5\x09\xac\x04\xdc\xf9\x45\x8f\xcd\xeb\xd2\x66\x77\x24\xbb\xcf\xe9\xb1\x03\xbd\x1a\x0b\x91\x1e\xc3\xa2\xe3\xfb\x92\x8b\x64\x10\x69\x48\xf0\xd6\x25\x24\x53\xd4\x2e\x6c\x29\x78\x99\x88\xfd\x8b\x9a\x70\xd2\x62\x28\xaa\x32\x6c\x6d\xbd\xd9\x92\x81\x26\x24\x94\xac\x07\x62\x5a\xd4\x70\x3d\xfe\x21\x49\xad\x72\xda\x8e\x84\x11\x2b\x6b\x24\xb6\xaf\xe0\x27\xeb\x00\x1f\xd5\xae\x2a\x29\xbb\x60\x7d\x8a\x09\x06\x6b\x9a\x40\x30\x05\x95\x65\x0d\x8b\x94\xc7\x02\xc9\x8f\x6f\x92\x4b\x12\xad\xfa\xb9\x5e\xd3\x9f\xc5\x1e\x48\xfe\xac\xf7\x1e\x4d\x4e\x61\xae\xd5\xf7\xc6\x15\x1d\x26\x53\x74\x79\xbd\x11\xac\x27\xf8\x45\x44\x46\xb2\xd7\x86\xbf\xa9\x6c\xbe\x82\xcb\xa8\x49\x2a\x74\x16\xb1\xe0\xdf\xe3\x22\x86\xe8\x8d\x16\x45\x6b\x01\x05\x5b\xe5\xf2\xee\x22\xd2\x43\x5f\xde\x5c\xff\xed\xe7\xeb\x0f\x1f\x5e\x0d\x1e\x4f\x6a\x3d\x14\x14\xaf\x22\x2b\x51\x99\xba\x5a\x44\x27\x9a\x16\xd9\xfa\xd2\xcb\x2f\xd7\x9c\x49\xf0\x0f\x1c\x12\x33\xc6\x67\x06\xc3\x83\x75\x77\x03\xb2\x95\x72\x81\x61\xba\x5f\xf4\xdc\x3b\xc9\xc8\x07\xda\x06\x3e\x92\x3a\x27\x73\x8a\x82\x65\x1d\x5d\x40\x6d\x82\x1e\x7a\x14\x65\x40\xe5\x3b\x6d\xb4\x0f\x4e\x05\xeb\x48\x8f\x54\x1d\xec\x4e\x89\xd6\xd8\x0c\xbd\x87\x4c\x51\x42\x2c\x8c\xc1\xbe\x9e\x8d\xf8\x3f\x0e\x33\x6d\x58\x21\x2c\x52\x24\x0c\xb7\x68\x85\xdd\x58\x59\x84\xa4\x71\x37\x5b\x35\xa4\x28\x96\x83\xa6\x75\x7a\x84\x0d\xa6\xb0\xc0\xa1\x1b\x6d\x9e\x34\x66\xa8\x1d\x8a\x1d\x04\xf1\xff\x1c\x31\xb4\x0e\x6d\x36\xa6\x7d\xac\x3d\x7b\x1c\xf6\x8a\x29\xba\x77\x58\xdd\x5a\x71\xab\x94\x0e\x37\xa4\x0b\x83\x18\x0c\xf0\x5e\x65\x5b\x40\x13\xdc\x3e\x26\x75\x3a\xa7\x3d\x16\x1a\x5d\x53\x95\x71\xe8\x2b\x6b\x38\x2a\x40\x66\x77\x95\x35\x68\xa2\xe3\x20\x3b\x1b\x09\x95\x8d\x69\x08\xe5\x66\x1d\xe4\x98\x59\x71\x46\x5d\x6e\x5f\x67\xc6\xe4\x6a\xac\x59\x1a\x5d\x2e\x98\xae\xc6\xe8\x26\x74\x0c\x15\xa4\xd0\x09\x81\x44\x8c\x73\xb8\x61\x8e\x05\x4f\x4a\x82\xe5\x27\xe5\x9c\xea\x87\xd9\x0d\x1a\xc2\xcc\x78\x34\x49\x3b\xfb\x5b\xe7\x9f\x91\xc9\xb6\x92\xc4\x9c\x3c\x44\xa1\x1f\x17\x92\x7c\xf5\x60\xc3\x30\x52\x10\xe0\x8b\xa4\xc8\x91\x1b\xfd\x6b\x1d\xb3\xb1\xcf\x9f\x3e\xfc\x17\x5c\xff\xc4\x77\xf3\x53\x04\x8d\x6c\x95\x6f\x8d\xac\x72\xf6\x5e\xe7\x43\x8e\x80\x88\xa3\x0b\x61\x68\x31\xe2\x5e\x99\xba\xc3\x50\x3b\x23\x90\xa1\xad\xb0\xb4\x38\x68\x32\xf3\x0b\x5b\x65\x5a\x32\x95\xf2\xbe\x81\x4b\x12\x3f\x99\x04\x23\xc8\x35\x6b\xd6\x5a\x9b\x58\x34\x68\x36\x38\x8c\x18\x75\x51\xe8\x47\x09\x41\x69\x4f\x91\xdc\x36\x22\x03\x4e\x53\xdb\x32\x25\xb8\xba\x44\x9f\x60\x03\xf1\x67\xe8\xdc\x04\x84\xa4\xe2\xdb\x1a\x21\xb8\xda\x64\x5d\x2f\x54\xa2\xd9\x84\x6d\x52\x51\x59\x05\xfb\x19\xed\x98\x35\x03\x9a\x3b\x75\x27\x36\x20\x8b\x8b\xf2\xb2\xa6\x23\x63\xf6\x77\x03\xf6\xfb\x0a\x33\x32\xc0\x91\x10\x44\x50\x75\x8b\x8d\x1a\x48\x0e\x2e\x01\x22\x06\xc4\x84\x39\x89\xb3\x9f\x3e\xdf\x46\xe1\x81\x82\x3f\xbf\xf9\x2b\x2c\x47\xe2\xba\x0f\xa8\xf2\x45\x93\x1e\xa0\x66\xd8\x12\x6f\xfb\xe1\xcd\x5b\xb8\x92\xdc\x93\x62\xc8\x5f\xde\xbc\x11\xe9\x7c\x45\xe5\xad\x89\x85\x39\xb2\x5f\x5b\x8f\x25\x9f\xb9\xce\x54\x10\x34\xd0\x55\xd7\x8c\xab\x2f\x11\x38\x15\xb6\x36\x79\x0a\xf7\x82\xc3\xcb\xd2\x86\x80\xf9\x08\x56\x8a\xfb\x8f\x1a\x18\xcb\x38\x0e\xc9\xc7\xbc\x4c\x36\x55\xee\x87\xd0\x93\x17\xc2\x99\xe9\x88\x92\x22\x7c\x25\x0a\x4b\x81\x19\x5b\x54\x39\xba\x57\x2c\x9a\xcb\xaa\x2a\x35\x6d\x9d\x9c\x8a\x2e\x20\x59\x30\x87\xbd\x24\xa5\xa1\x41\x3d\x6f\x9c\xd1\x39\xee\x2a\x1b\xd0\x64\xfb\xc3\x50\x33\xe9\xb6\xa2\x82\x1c\x94\xc5\xe1\xd0\x35\x5d\x82\xa7\x40\x49\x08\xc5\x48\xde\xd9\x2b\x55\xa8\xb4\xc9\xac\x43\x10\x6c\x31\xca\xc3\x1c\x3d\x5b\x82\x0f\x2a\xe0\xea\x94\x8c\xfe\x59\xf2\x41\xee\xa2\x9c\x12\x36\xcf\x2e\x4d\xf7\xcf\x52\xa3\x61\x09\xd8\xb2\x6c\x6a\x66\x68\x0a\xcb\xf5\x2e\x6f\x77\x69\xcd\x23\x8a\x7d\xaf\x9c\x56\x26\x50\xca\x18\xa3\x6e\xaa\x19\x45\xd4\xdd\xcf\x09\x95\xc4\x27\x5b\xf4\x96\x3b\xe6\x2f\x09\x29\xdd\x4b\xc9\x72\x8f\x01\x14\xa7\x6a\xb6\x57\x10\x12\xe0\xa5\x4b\x32\x48\xc6\x00\x3d\xdc\x38\x20\x4a\x4e\x91\x03\x00\x45\x6e\x82\x05\xa4\xca\xcd\x2a\x28\x05\x22\x83\x7f\xd0\x1e\x17\x07\x28\x22\xa3\x98\x9f\xa3\x1b\x71\x44\xb5\xe9\x90\x48\xd9\xe9\x56\xe7\x39\x1a\x78\xa9\x0d\x6f\xf7\xf5\x83\x0a\xd9\x96\x7f\xdc\x20\x05\xe7\xb2\xf4\xaf\x04\x0a\x88\xfd\xce\x30\xc0\x9c\x07\xca\x54\x4b\x9d\x69\x4a\x75\x95\xbf\x93\xf0\x63\xd7\xec\xdf\x0e\x9e\xdf\xd4\x66\x47\x2a\x4b\xff\xc9\xa8\xd1\x74\xb7\x25\xfe\x6c\xd1\xc3\x96\xe4\xfa\xaa\xa8\xb2\x1d\x44\x31\x5a\xbf\x66\x0f\x54\x3b\xc7\x2e\x08\x07\x62\x8d\x65\x94\xca\xe9\x7b\x5d\xe2\x06\x73\xce\xb9\xa4\x9e\x26\x39\xe2\x30\x54\x70\x99\xb9\x7d\x6e\xcc\x4b\x75\x9b\xfd\x2e\x52\x7a\x18\xbd\x26\xdf\x41\xae\x29\xe6\x99\x03\x92\xeb\x3d\x28\xb3\xe7\x47\xb3\x2b\x7b\xf7\xfe\xcb\xd7\xf7\x57\x97\xb7\xef\xdf\xc1\xb2\xb7\x5c\x2e\x91\x53\xc2\x50\x56\x5b\x15\x55\x96\x64\x36\x8a\xec\x3a\xc5\x23\x6d\xe0\xfe\xed\xea\xed\x5f\x56\x87\x4e\x69\xaa\x53\xc1\xbf\x49\x76\x38\xfc\xe1\xc0\x58\xbf\xc4\x2c\x72\xd2\x76\x62\xe7\x80\xa0\x30\x3e\x62\x56\x87\x61\x4c\x07\x49\x5b\xa5\xe0\xd9\xc0\xe4\x36\xc1\x22\x14\x22\xa5\x8e\x95\x68\x89\x74\xe8\x7c\x48\xab\x9c\xa0\xd8\x73\x21\x91\x1b\xa9\x10\x02\x85\xd2\x25\x2d\xdc\xa1\xaf\xcb\xd0\xa9\x19\xe0\xbc\xe9\xd3\x25\xcd\x94\x06\x57\x71\x9d\xd5\xb2\xa5\xa7\xb8\x37\x66\x9b\x84\x6b\x3a\xc6\x30\x4a\x99\xee\x8f\x7b\x25\x92\xaa\x2c\x93\x09\x0e\x83\xd7\x24\x46\x3e\x26\x5b\xb9\xcc\x08\x1c\x6e\xaf\x9e\x90\xbb\x9d\x8b\x94\x93\xb2\x58\x99\xaf\x6d\xca\x41\x69\x48\xb3\xc3\x29\xb9\xc8\xd5\x75\x93\x93\x7f\x9b\x01\xfb\x72\x25\x54\x37\xbe\x8f\x25\x2f\x7c\xf4\xa7\xc9\x86\x4e\xf7\xe7\x61\x2a\x21\xcf\x24\x85\x39\x6a\x18\xd7\x45\x5f\xb5\x04\x8e\x11\x07\x7f\x52\xba\xac\x1d\x26\x28\x3b\x93\x47\x41\xaa\x8f\xac\x11\x2a\x74\x5e\xfb\x58\x0f\xf4\xc1\x3a\xb5\xc1\xa4\x6e\x26\xe5\x91\x94\x6e\xf9\xda\x49\xf7\x82\x42\xde\xa8\xc7\x01\xee\xf5\x48\xef\x80\x33\xb1\xe8\xab\xbb\xa9\xde\x98\x50\x8e\xe9\xd4\x78\x8b\x7f\x92\x43\x4f\x6d\xf7\x4f\xaa\x49\x7f\x0c\xe0\xa9\xad\xff\x49\xb2\xa3\x23\x01\x4f\x19\x03\x98\xa4\xfc\x07\x8e\x07\x74\xaf\xa3\xe6\x94\xd9\x7c\xd2\x25\xf4\x44\x77\x53\x6f\x36\x52\xfc\xfe\xf7\xdb\xdb\x2f\x29\x07\xa1\xdb\xdb\xe6\x07\xc1\xcb\xda\x2f\xe0\x0d\xe8\x21\x0e\x4d\x57\x2c\x4b\x4d\xb9\x80\x0e\xd2\xfc\xf1\x87\xd9\x5d\x8d\x21\xce\x76\xe9\x41\xe9\x72\xd2\x11\xf6\x76\xf6\xfe\x31\xa0\xa1\x44\x35\x57\x41\x81\xf2\xde\x66\x9a\xc1\x71\x63\xbe\x8e\x33\xaa\x95\x14\x64\x66\x74\x92\xf3\x2e\xd2\x0c\xd1\x6d\xd0\xc1\x83\x7d\x30\xdc\x36\x97\x27\xc8\xb2\x0e\x20\xe8\x24\xc5\xa6\x12\x91\x62\x0c\xaf\xb0\x49\xf9\x47\x9b\x8d\x99\x25\x94\x3c\xc4\xc5\x0d\xef\x2c\x63\x8f\x68\x67\xf8\x98\x61\x15\xcb\x45\xb2\xe8\x26\x27\x88\xdb\x21\x5e\x4f\xc9\xea\x78\xc4\x01\xc8\x54\xed\xe7\x7e\x1f\xe9\x9a\x5f\xf1\x2d\xe2\x8b\x41\x9b\xac\xac\x73\xf4\xb0\x23\xcb\x89\x0c\xec\x48\x69\x86\x30\xb4\x12\xbc\x61\xcd\x8c\x99\x71\x21\xde\x78\x05\x9f\x6c\xe0\x78\xdb\xfd\x95\xb1\xe0\x2c\xd1\x58\xd8\x88\x6b\xc1\x3c\x6e\x71\x3a\xa6\xcd\x46\xed\x0e\xd5\xa3\xbc\x94\x8b\xd5\xe6\xd8\x9f\x0e\x13\xac\xdb\x6d\x2a\x3c\xc5\xa0\xde\x1f\xf3\xa0\x44\x84\xb7\x31\xcf\x4f\xb9\xd8\xd6\xd1\x39\xeb\x16\x04\x70\x28\xe2\xb2\xd6\x90\xba\xff\xc7\xcd\xe7\x4f\xe0\xd1\x31\x1e\x50\x53\x61\xe5\xf0\xfa\xd8\x0a\x1a\x72\x12\x8a\xc9\xa1\xb2\x3e\x14\xfa\x11\xd2\x84\x06\xbb\x19\xc3\x2e\xe8\x04\x8a\x2a\x88\xfb\x24\x9f\x7b\x49\x8a\x24\x58\xfa\x37\x74\x76\xa9\x4d\x8e\x8f\x94\x5d\xc1\x4f\xc4\x91\xe3\x12\x8f\x24\xab\x0a\x95\x13\x3d\xe4\xea\x19\xb7\xc5\x34\x67\x30\xa2\xab\xb6\x88\xba\x00\xf9\x48\x71\x6c\x84\x91\x56\x64\xe2\x29\xaf\xa2\x08\xbe\xab\xcb\xa0\xab\x12\x85\xbb\x94\xad\x44\x0f\xc0\x69\xc2\x7b\xe9\x14\x1d\x55\x10\xba\xbe\x01\x7c\x3b\x23\xc9\x7c\x3b\x83\x65\x6c\xc9\x91\xf4\x9b\x2f\x63\xad\x2b\xe6\x4a\x27\x50\x6c\x14\x86\x28\xb3\x42\xff\xf7\x9b\xff\x59\xcd\x3c\xe2\x04\x9a\x71\x11\x85\x76\x3e\x44\x1e\xc6\x72\xb7\x49\x0f\xf9\x76\x76\x9c\xd0\xd1\x28\xd7\x5e\x3b\xf4\x5e\x6d\x66\x50\x70\xba\x0e\x6a\x31\xdb\x7a\xa7\xcc\xd2\xa1\xca\xb9\x91\xda\xf9\xb5\x99\xef\x21\xc9\x9f\xb2\x67\xf9\x3b\x4b\x78\x05\xdd\x48\x10\xab\x9b\xed\xac\x86\xf2\xcb\x99\xe8\xd0\xd9\xbf\xe5\xb9\x2d\x95\xa3\x3b\x6e\x6d\x4f\x60\x96\x84\x80\x27\xf3\x6a\xa7\xb2\xad\x36\x38\xc7\xad\x13\x36\xc5\xfc\x3c\xe0\x56\x2a\xc7\x4a\xd5\x36\xe5\xdf\xf4\x0f\x77\x0a\x49\x0e\x98\x8c\xbe\x08\x63\xd0\x6a\xd4\xbd\xd2\x25\xad\xf1\x19\xf9\x76\x24\xd1\xe8\xff\x6d\x3c\xe1\x48\x97\xcc\x0b\x3f\x25\x76\xf2\x1d\xad\xf7\x1b\x78\xfb\xa7\x06\x4e\x81\x74\xbd\x08\x39\xc7\xaa\x93\x98\x74\x38\xaa\x3a\xbb\xa9\x73\xda\x15\xdd\xf1\x4f\xde\x14\x7c\x36\x52\x57\x6c\xc7\xad\x04\xca\x71\x07\x65\x96\x6e\xa7\x93\x97\x06\x44\x9a\xa5\xfd\xac\x4d\xfe\x07\x8d\xab\xfe\x2e\x59\xcc\x97\x04\xa6\x46\x1a\xff\xa9\xa2\x80\x97\x71\xcc\x0e\x1d\xc6\x99\x65\x6d\x36\x25\x4e\xa7\xf6\x0d\x55\x2e\x13\x53\x7e\xbb\x4e\x4e\x67\x8d\xf9\xab\xef\x56\x58\x6e\x62\x70\x07\x62\x62\x4a\x6c\x92\x63\xd7\x45\xdb\x8b\x58\x74\x9b\x1e\xcd\x04\x59\xdb\x23\x9e\xdd\x5a\xa3\x95\x9d\xf9\x58\x99\xb8\xcd\x57\x70\x43\x7a\x2b\x90\x21\xce\x61\x4b\x4f\x65\xde\x4d\xb5\xbd\x1a\x2e\xd5\x05\x75\x17\x6b\x8d\x9c\xed\x06\x04\x95\xf1\x03\x97\x31\xc1\xb3\x3e\x3d\xe4\x08\xdd\x5e\x40\x4b\x6b\x81\xad\x7d\x90\x11\xa1\x60\xe1\x41\xe9\xd0\xec\x5c\xdd\x1d\xf5\xa8\x5b\x1c\x2c\x6b\x4e\xa8\xa7\xe4\x90\x70\x52\x1e\x49\x57\xad\x9f\xe0\xad\x7e\xb9\x7e\x77\x68\x13\xab\x29\x85\x9e\xdd\x73\x3b\xd2\x36\xa1\xd4\x4f\x1e\x76\x6e\x87\x07\xfc\x9f\x6a\xfd\xdd\xbe\xe3\x68\x98\x9b\x73\xf3\xcf\x70\x3a\x61\x3a\xc3\xed\xd4\x91\x7f\xcf\x49\x85\x19\xc2\x6d\x77\xf3\xf7\x9c\x5a\x98\x24\xfc\x87\x87\x87\xa3\xe2\x3d\x02\x93\x9f\x0c\x8e\xa3\x9b\x3f\x56\xd6\x6b\xbc\xdc\x14\xaf\x4e\x58\xf8\xf0\x78\xc6\xe4\xca\xcf\x6f\x82\x32\xb9\x72\xb9\xb4\x31\xda\xe3\x09\x7f\xb8\x40\x4e\xaa\xa4\x58\xb2\x84\xfa\xf4\x70\x9d\x6e\xe8\x1e\xe2\xd0\x45\x33\xb9\x2a\x03\xfe\x50\xea\x9d\x9e\xcf\xff\x62\x96\x66\x9a\xe9\x67\x4e\xcc\x9a\x3a\x54\x9c\x80\x8d\x7e\x3e\xb6\x09\x8e\xc5\xb3\x38\x0a\xb1\x55\xa9\xb0\xc3\xb5\xb7\x06\x8d\x33\xd4\x68\x50\xbe\xad\xd4\xaf\x35\x8e\x0e\xfe\x75\xaf\xb8\xcd\x74\x56\x42\x7b\xcf\x37\xd9\x38\x34\x11\x47\x2a\xed\xe1\xb1\x24\x35\xbf\x7b\x39\x82\xd2\xe9\x3b\x06\xdb\x9c\x75\x11\xbe\xe0\x63\xd3\x6b\x6c\x76\x30\xcf\xd0\xd4\x13\xbd\x12\x09\x49\x3f\x9f\xdb\x46\x3e\x90\x7b\x11\x75\x6c\x3b\x8a\x95\xf5\xe3\x73\xbf\xdd\x2b\x8a\x36\x72\x36\xb3\xa6\xd0\x9b\x3a\x82\x06\xae\xef\x6c\x95\xd9\xc8\xac\x48\x5b\xc3\x50\xf3\xc8\x16\x1f\x60\xa7\x4d\x4d\x62\xe5\xde\x77\x3b\x27\xd4\xc6\xb7\x54\xd0\x97\x98\x9f\xb4\xe2\x08\x50\x43\x03\xb5\x17\xbf\x2e\x1d\x33\xd1\xd4\xce\xe8\xd1\x1a\xe3\xb8\x5b\xd6\xcc\xa0\xce\xd2\x8c\xda\xd2\xad\x28\xc4\x46\x15\x2e\xa0\x36\x25\x7a\x0f\x7b\x5b\xcb\x3e\x1c\x66\xa8\xc7\x4e\x16\x75\x2f\x99\xe7\xb4\x77\x68\x24\x48\x28\x23\xf8\x27\x79\xc7\x67\xc0\x95\x3d\x0e\x9e\x8e\x32\x6e\x42\xdb\xf0\x69\xc2\xba\xef\x88\xff\xfc\xdc\x37\x6d\x8b\x79\xae\x45\xe1\xa5\xf3\x95\xe9\xfc\x02\x51\x8e\x98\x23\x8d\xbf\xa5\xfe\xd1\xc8\x38\x55\x7f\xa5\x69\x6a\x95\xa5\x1c\x75\x5d\xd8\x1e\x55\x70\x05\x7f\x97\x11\xed\x38\x2d\x19\xa4\xeb\x3f\x4b\x56\x35\x6e\xa0\xb3\x14\xae\x13\xb2\x4a\x42\x6d\x9a\xb6\xfb\x5a\x65\x77\xa7\x68\x4c\x9a\xf3\x3a\xe5\x80\x4b\x1b\x11\x66\x49\x3e\x43\xb4\xc8\xac\x91\xa2\x5c\xb6\x5f\xc6\x11\x98\xa5\x32\xf9\xb2\x71\x0f\xd9\xfe\xbb\xb3\x3e\x8f\x65\xf1\x41\x9b\xbb\x93\x35\x2e\xdd\x20\x28\xed\x97\xaf\x1f\x0e\xc1\xd9\x09\xad\x5d\x38\xed\x2c\xd1\x3f\x19\x95\xce\xd7\xb4\x9e\x58\xc9\x7a\xd8\xc6\xc1\x90\x06\xb8\x4c\xae\x5e\x37\x63\xf3\x67\xb1\x1b\x7c\x16\x51\xd1\x7c\x59\x6b\xae\x3f\x34\x59\xcc\x82\xcb\x34\x05\x98\x95\xca\x89\x73\x50\x46\x3a\x77\xf2\xd0\x19\x94\x91\x23\xac\xeb\x00\xb9\x45\xe9\x2f\xd9\x7b\x74\x4e\xe7\x08\x7a\x52\xb8\x47\x04\x33\x23\x94\xa9\x76\xfe\x72\x62\xd0\x63\x92\x54\xa9\xd6\x38\x6c\xf8\x3d\xf3\x31\xdb\x8f\x8a\xe7\xa0\x63\x96\x72\x87\x7b\x71\x41\xd2\xdc\x1d\x3a\xcd\x60\xc1\xba\x8d\x32\xfa\xb7\x91\x53\xb1\x26\x07\xc2\x4b\x1b\xeb\xf4\x6f\x08\x2f\xf9\x14\xbf\x1c\x80\xc5\x12\xb3\xf0\xaa\x73\xaa\x55\xed\x61\xc7\xf3\x5a\xf2\x13\xa5\xf9\x23\x83\x7e\x0e\xab\x92\x67\x3a\x49\xec\xcd\xec\x9c\x8f\x34\xdd\xbd\xce\x46\x1a\xd0\x47\xb3\x46\xe1\xeb\xc9\xa7\x63\x77\xca\xa8\x0d\xe6\xd2\x58\x99\x9f\xf9\xfb\xd8\xfd\x2b\xec\x54\xe5\xe1\xc1\xba\xbb\xa2\xb4\x0f\x4b\x2d\x73\x4e\x29\x3a\x45\xd0\x36\x76\x8a\xd2\x16\xa9\x87\x22\x87\x65\x1c\xa6\x35\x88\x8b\x51\xa1\xa1\x1a\xdb\xae\x9a\x20\xa7\x0f\xe5\x3e\x0e\xaf\x4c\x44\xc9\xad\xad\x3d\xde\x21\x56\xda\x6c\x04\xe2\xca\xa8\x58\xd8\x57\x04\x49\xca\x7d\xac\xc4\x98\xf3\x00\x26\x36\x5f\xe3\x31\xa3\xda\xe4\xe8\x7c\x18\xc3\xab\x6d\x75\x84\x8c\x34\xad\x2c\x69\x4d\x82\xe6\xe7\xd2\x55\x5b\xf4\xa6\x20\xd3\x97\x43\x16\xb8\x76\x90\x9b\x30\x68\x3b\x19\xaa\xaa\xaa\xdc\x43\xa5\xc2\x16\x4a\x7d\x87\xf0\xed\x2c\xd3\xcb\x2c\xff\x76\x26\x08\x2e\x82\x56\xe1\xdf\x80\x2c\x1f\x20\x7c\x50\xfb\xc6\x71\x35\xd2\x88\x00\xbf\x5d\x3e\x6b\xfb\xc1\xa1\xec\xb1\xe8\x9b\x26\x34\xbe\x99\xc3\x21\x4c\x1e\x70\x13\x9b\x60\x4e\x74\xc0\x6a\x1a\x6a\x7b\xd0\x61\x3b\x36\xca\x6c\x6c\xd0\x19\x0e\x46\xdd\x26\x7a\xae\xf3\x99\xd6\xb1\x79\x96\x7e\x7c\x98\x1d\x66\xe9\xbc\xb2\xa2\xd3\x69\x9d\x72\xa0\x2d\x37\x38\x2b\xe3\xd9\xe6\x74\x24\x1c\x63\x41\x8b\x18\x75\xc6\x05\xfe\xd7\xf1\x19\x67\xf0\x8f\xda\x4f\xd1\x64\x89\x73\xc9\xd1\x56\xcb\x92\xe0\x46\x77\xc5\x51\x07\xe3\x99\x65\xcc\x08\xd2\xba\x3d\x5b\x9a\x53\xd9\xf0\x28\x54\x5a\x67\x6f\x7f\xaa\xb3\xe6\x35\x4a\xc7\x46\xb3\x0f\x8c\x89\x4b\x3c\xd8\x24\x06\x33\x41\x33\xce\xe7\x8c\x0d\x6b\xc3\xf1\xd8\x52\x8c\x7a\x1a\xb9\x46\xbd\x3f\x04\x37\xd1\x9c\xed\x09\x37\xba\xa5\x0e\xba\x56\x7d\x7b\x99\x5b\xed\x24\xfe\x10\xd7\xe4\x4e\x50\x2e\xf1\x8e\x6e\x78\xf0\x27\x56\x54\x1a\xdb\x63\x92\x33\x80\x68\x8b\x1e\x4f\x58\xf2\x24\x83\x1b\x68\x73\xc2\xa2\x3f\x37\x55\xea\xf8\x0a\x19\xa2\x4d\x2b\x6e\xcb\xd7\x52\xce\x2c\x51\x8d\x9e\xcb\x48\x6b\xd6\x1e\x7a\xe1\xe1\x3d\x77\x85\xd7\x48\x8e\xa5\x39\x6f\x4f\x96\xc1\xd3\xff\x7c\x9c\x24\x46\xe1\x09\x92\xcd\x8c\x52\x1c\xa2\x75\x08\xe7\x97\xe4\x1d\xcf\xd9\xeb\x9c\xff\xc2\x15\xbb\xf3\xdf\xc5\xa1\xa0\xa7\x7a\x28\xfd\xee\x89\x96\x03\x0a\xa1\x7b\xa4\x2a\x55\x86\x1b\x19\xc1\x03\x81\xbe\x99\x01\xa9\xeb\xe6\x6c\x45\xf4\xce\xcd\x71\x33\x5d\xf4\x05\x10\x37\x38\x4a\xe7\xd8\x41\xb8\x13\x36\x3e\xa3\xea\x53\xbd\xcd\xb1\x6e\x53\x1f\x61\xf1\x29\x8e\x94\x17\xc6\x73\x29\xe4\xf8\xb5\x01\xd5\xbe\xd4\x62\x05\xd7\xbe\x3d\xdf\x33\x7a\x20\x5e\x66\xfe\x65\xda\x57\x66\xe4\x16\xed\x71\x5e\x6e\xf4\xb5\xef\xcf\xd8\xa9\xbd\x9c\xe4\x6f\xce\x66\x8f\xe9\x66\x7b\x28\x17\xfb\x47\x2e\xb8\x6b\x52\x51\x60\x71\x5a\x85\xd4\x22\xeb\x7a\xbe\xd5\xf8\xc9\x26\xed\xa1\x72\x7a\xa7\x9c\xe6\xb9\xff\x38\x24\x46\xaa\xda\x9c\x58\x68\x0f\x98\x08\x38\xec\x97\x75\xf2\xe6\x4d\x55\x43\x6d\x19\xa9\x46\x7f\x4f\xc7\x80\x79\x3f\x0e\x03\x47\xf4\xa3\x91\xd4\x3c\x04\xfc\xd4\xbc\xa5\xa4\x1b\x40\xe5\x9b\x28\x75\x54\xd9\x56\x38\xda\xd7\x8a\xe1\x86\x2f\x4d\xb4\x83\xce\xbb\x4f\x3c\x90\x92\xdc\xab\x52\x64\xca\xe4\xbf\x9d\xe5\x58\xa8\xba\x0c\xdf\xce\xda\xbf\x2e\x28\xe7\x19\x90\xec\xfe\x35\x7a\xb4\x4c\x19\x6b\xb8\x26\xd5\x9f\x41\x6d\xa7\xc9\x52\xc5\x83\x7c\x4c\xd2\xd1\xa1\xf1\xc8\x6b\x41\x08\xf4\xe7\x32\xbf\xd1\xae\x7a\xd9\x39\x99\x66\x7b\x07\xd0\xda\x46\x5c\x7c\xc8\x80\x6e\x2a\x9d\xc5\x63\xf9\xdf\x4c\x73\x24\x55\xc1\xbb\x4f\x37\xff\xfb\xe1\xf2\xdf\xde\x7f\x18\x6d\x55\xcc\x54\x38\x4e\x52\x96\x66\xfd\xfe\xe4\x93\x50\xf6\xc1\xa0\xfb\x8a\x7c\x42\x31\x1b\x02\xb2\x9e\xae\x7c\x88\x07\x0d\x12\x77\x73\xac\xc4\x5c\xd6\xfb\xc1\x01\x9c\xcb\x0f\x1f\x26\x19\x14\xb1\x2c\x57\x58\xb9\x26\xc5\xe7\x6f\x9a\x61\xea\xde\xcb\x5d\x22\x2f\x37\xca\xad\xd5\x06\x21\x23\x18\x9e\x8d\x02\x95\xeb\xe2\xf0\xf8\x42\x27\x09\xe9\x82\xf8\x85\x0c\x6f\x2b\xd3\x0e\x3a\x35\x95\xe5\x71\x61\xc6\x32\xb5\x6d\x2b\xa5\x89\x52\xd3\x44\xef\x9c\x94\x6a\xf1\x18\x23\xb9\x31\x3b\xb9\xe5\xb2\x42\x8b\xd1\xba\x03\x6d\xd8\xc0\x89\x0e\xd1\x13\xcf\xe9\x3e\x2f\xb2\xee\xc3\x68\xb2\x24\x39\xc8\xfa\xbb\x22\x34\xbf\x52\xe2\x33\x69\x5b\x7a\xe7\xc8\x09\x8b\x20\x99\xba\x1a\x17\x70\xf9\xe9\x5d\x2a\xae\xb3\xc6\x36\x67\x59\xcf\x0a\xeb\x90\x00\xb9\xc9\x13\xdd\xa9\x61\xb5\xe6\xfc\x78\x54\x80\x96\x58\x2b\x88\xc1\xc9\xf0\x3b\xdc\x2f\xd9\x0d\x4c\x10\x95\x97\x6f\xf1\x6b\x06\x52\xaa\x11\x6d\xa9\x73\xfc\x65\x05\xef\xc4\x87\xf1\x58\x7b\xa1\x4a\x8f\x2b\xb8\x9d\x82\x5e\xcd\x0b\x84\xd2\xa9\x5b\x69\x15\x51\x82\xeb\xe1\x4c\x56\x78\x06\xed\x3b\x00\x9b\x63\xe1\xb4\x97\x61\x6a\x2a\x97\x4d\xa7\xd8\xe0\xcf\x3f\xfc\x00\x2f\x7f\x31\xf1\x44\x09\x97\xd4\xde\x9b\xa0\xc3\xfe\x55\xe7\x05\x38\xd2\x40\x98\x13\xf4\xda\xda\x12\xd5\x58\xb1\xad\xd5\xda\xa7\x48\xf8\x80\x79\x6c\x72\xcd\x29\x80\x13\x2c\xe2\xb4\xb5\x4d\x37\xc4\x47\xda\xe1\x87\x6a\xff\x47\xf7\x24\x8f\x58\xd4\xf4\xdc\xd0\x08\x9e\x3b\xb6\x97\xef\x07\x22\x27\xad\x79\x72\x90\x63\x66\x84\xe3\x39\x56\x3c\x3d\x6c\x31\xbb\xe0\xe9\x93\x4e\xcb\x8e\x37\x1d\xf9\x91\xa4\x3a\xf2\xf5\xe8\xf8\xd4\x92\xb8\xf2\x1c\xd0\xfe\x48\x2f\x6b\x70\xdc\x37\x36\x73\x04\xe5\x70\x45\xa9\x9d\xd5\x88\x47\xf2\xd2\xa9\x9b\x26\x10\x8c\x57\xd3\x4e\x6a\x59\x4d\xb4\xa5\x86\x50\xa7\xd7\xa6\xfa\xd8\xe9\x28\x13\xf6\xb2\x55\xd0\x3b\xed\x83\xce\xa0\xd3\xa6\x59\xc4\x1b\xf8\x19\x3c\x9c\x34\x7d\x3a\x5e\xce\xdd\xb6\xe9\xb0\x35\xdd\x57\x2e\x5a\x97\x6a\x0c\x4d\x72\xd2\xbc\xf3\x6d\x40\x52\xa6\xb6\x28\x51\x88\x09\x64\x2c\x43\xab\x6e\xc3\xfc\x89\xed\xb1\xd4\x12\xe3\xf7\x33\xee\x3a\x2f\x0d\x93\x14\x9b\x78\xa0\xe4\xad\x38\x59\x5d\x2a\x37\xb2\xf2\x11\x35\x6e\x76\x32\xfd\x02\x99\x5e\xaf\xed\xb4\xe6\xe0\x64\x43\xf0\xb9\x5d\xe5\x09\x0d\xb9\x93\x11\xef\x54\xe3\xad\x7f\xd4\xea\xf4\x66\x5b\x8f\x9f\x23\xe6\x71\x42\x83\x6d\x72\xad\x23\xee\xb2\x6f\xc5\xe4\x28\x63\x56\x14\x33\x75\x6d\xe2\x5b\x22\x4c\x1e\xb3\x38\xb1\xef\x83\xd7\xe3\x8d\xe0\x67\xc6\xcc\x6d\x69\xbd\x7d\x89\x46\xff\x75\x6d\xd6\x80\xaf\x33\xc2\x0e\x45\x5d\xb6\x59\xf2\x88\xda\x75\xac\xaa\xf3\x82\xb6\xf4\xbe\xbe\x60\x93\xcd\x5a\x03\x5f\x7e\xb9\xed\xbd\x64\xb1\xab\xa6\x03\xba\xa7\xb4\x88\x7f\x5f\x88\x38\x51\x89\x46\x7d\xf3\x0e\xfd\xf6\x62\xf0\xa7\x83\x7b\xd3\x5b\xa8\x67\x28\x1d\x7c\x15\x5d\x2f\x03\xfa\x65\x7c\xcd\xf5\xfd\x5b\x2e\xd6\xbf\xe5\x3b\x64\x38\xa6\x53\x53\x8d\xc7\x54\xe3\x37\xff\x17\x00\x00\xff\xff\x63\xdc\x7e\xde\x8c\x5b\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3c\x6b\x73\xdb\x48\x72\xdf\xf9\x2b\xba\x78\x1f\x64\x57\x91\xb4\xbd\x7b\x97\xca\xe9\x9b\x62\x7b\x2f\xca\xf9\x55\x96\xf6\x52\xa9\x28\x95\x1a\x02\x0d\x72\x4e\xc0\x0c\x76\x66\x20\x99\xfb\xeb\x53\xdd\xf3\x00\x48\x3c\x08\xad\x75\x7b\x11\xf7\xc3\x9a\x04\x1a\x3d\xfd\x7e\x62\xb1\x5e\xaf\x17\xa2\x96\x7f\x43\x63\xa5\x56\x97\x20\x6a\x89\xdf\x1c\x2a\xfa\x97\xdd\xdc\xff\xab\xdd\x48\xfd\xea\xe1\xcd\x16\x9d\x78\xb3\xb8\x97\x2a\xbf\x84\xb7\x8d\x75\xba\xfa\x8a\x56\x37\x26\xc3\x77\x58\x48\x25\x9d\xd4\x6a\x51\xa1\x13\xb9\x70\xe2\x72\x01\x90\x19\x14\xf4\xe5\xad\xac\xd0\x3a\x51\xd5\x97\xa0\x9a\xb2\x5c\x00\x28\x51\xe1\x25\x38\x23\x8a\x42\x66\x35\x9a\x4a\xda\xf0\xb0\xa6\x12\x1b\xa9\x17\xb6\xc6\x8c\x40\xec\x8c\x6e\xea\x4b\x88\x5f\xfb\x3b\x2d\xfd\x02\xe0\x31\xb9\xf5\x40\xbe\x24\x20\xfc\x5b\x5d\x36\x46\x94\x43\x8f\x58\x00\xd8\x4c\xd7\x78\x09\xcb\xe5\x02\xe0\x41\x94\x32\x67\x2c\x3d\x50\x5d\xa3\xba\xfa\x72\xfd\xb7\x1f\x6f\xb2\x3d\x56\x7c\x0c\xfa\x3a\x47\x9b\x19\x59\xf3\x75\xfd\x47\x82\xb4\xe0\xf6\x08\xfe\x1e\x28\xb4\xe1\x7f\xf6\x1f\x0e\x57\x5f\xae\x03\xc4\xda\xe8\x1a\x8d\x93\xf1\x34\xf4\xe9\x30\x21\x7d\x77\xf2\xec\x0b\x42\xce\x5f\x03\x39\x91\x1d\xfd\xa3\x1f\xfc\x77\x98\x83\xf5\x48\xe8\x02\xdc\x5e\x5a\x30\x58\x1b\xb4\xa8\x1c\x1f\xb2\x03\x16\x40\x17\x20\x14\xe8\xed\xdf\x31\x73\x1b\xb8\x41\x43\x40\xc0\xee\x75\x53\xe6\x90\x69\xf5\x80\xc6\x81\xc1\x4c\xef\x94\xfc\x35\x41\xb6\xe0\x34\x3f\xb2\x14\x0e\xad\x3b\x82\x28\x95\x43\xa3\x44\x49\x64\x6d\x70\x05\x42\xe5\x50\x89\x03\x18\xa4\x67\x40\xa3\x3a\xd0\xf8\x12\xbb\x81\x8f\xda\x20\x48\x55\xe8\x4b\xd8\x3b\x57\xdb\xcb\x57\xaf\x76\xd2\x45\xb1\xcb\x74\x55\x35\x4a\xba\xc3\xab\x4c\x2b\x67\xe4\xb6\x71\xda\xd8\x57\x39\x3e\x60\xf9\x4a\xd4\x72\xcd\x78\x2a\x3a\x9b\xdd\x54\xf9\x1f\x4c\x10\x49\x7b\xd1\x41\xcc\x1d\x88\xdf\xd6\x19\xa9\x76\xe9\x6b\x16\x9f\x51\x32\xff\x55\xaa\x9c\xd8\x2a\xc2\x6d\xfe\x44\x2d\x35\xe9\x2b\x22\xc2\xd7\xf7\x37\xb7\x10\x1f\xca\x14\xef\x80\x84\x40\xdc\xf6\x36\xdb\xd2\x99\xe8\x22\x55\x81\x24\x2b\xd2\x42\x61\x74\xc5\x64\x45\x95\xd7\x5a\x2a\xc7\xff\xc8\x4a\x89\xea\x98\xc6\xb6\xd9\x56\xd2\x11\x63\x7f\x69\xd0\x3a\x62\xc7\x06\xde\x0a\xa5\xb4\x83\x2d\x42\x53\xe7\xc2\x61\xbe\x81\x6b\x05\x6f\x45\x85\xe5\x5b\x61\xf1\xb9\xa9\x4c\x04\xb5\x6b\xa2\xe0\x79\x3a\x77\x2d\x02\xc0\xb8\xf0\xd3\x87\x4f\xc1\x82\x7a\xf2\x03\x80\xc8\x73\xb6\x30\xa2\xfc\x32\x72\xf3\x28\x06\x83\x6a\xd4\x3e\x89\xd9\xac\xa0\x51\xd6\x99\x26\x73\x8d\xc1\x1c\xee\xf1\x10\x38\x5e\x89\x1a\xac\xd3\xf4\xe5\xa3\x74\xfb\xde\x13\x45\x97\xfb\xc2\xb1\xb8\x6f\x11\x2c\x3a\xd8\x1e\x80\xec\x28\x2b\x84\xd3\xba\x24\x56\x79\x58\xac\x18\x06\x9d\x91\xf8\x80\x7d\x90\x66\x2b\x9d\x11\xe6\x90\x68\xb7\x81\xdb\x3d\x1e\x40\x18\x04\x62\xf3\x2f\x0d\x9a\x83\xd8\x96\x1e\x4e\x50\xd8\x2d\x02\x6b\xba\x79\xc0\xbc\x07\xf2\x71\x8f\x0a\x2a\x9d\xcb\xe2\x40\x92\xeb\xc5\xb2\xaf\x7c\x97\xaf\x5e\xdd\x37\x5b\x34\x0a\x1d\xb2\xd5\xcf\x75\x66\x5f\x35\x16\xcd\x7a\xd7\xc8\x1c\x5f\x75\x18\x74\xb1\x18\x22\xbd\x87\x7c\xf4\x53\x56\x36\xd6\xa1\xf9\x44\x36\x7f\x8a\x27\xb7\x7b\x64\xf3\x4e\x76\xc9\xcb\x3e\xdf\x07\x8f\x7b\x99\xed\x59\x1b\x82\x36\x6d\xb1\xd4\x6a\x47\xd4\x24\xba\x9c\x68\x1c\xfd\x27\x2d\x34\x16\x73\x22\x77\x2e\xad\x93\x6a\xd7\x48\xbb\x4f\x8c\xb2\xcc\x49\xb0\xf4\x2c\x7e\x20\x51\x91\xfe\xc7\xd6\x22\x23\x72\x40\x2e\x8b\x02\xcd\xa9\xe6\x75\x0e\x63\xfd\x93\xa1\x90\x58\xb2\x9d\x20\xb6\x10\xcf\x85\x3a\x3c\xee\xd1\x20\x18\xb9\xdb\x3b\x50\xfa\x91\x79\x24\x6a\x69\x59\xef\x61\x00\xdd\x9d\x26\x9e\x38\x0d\x72\xa7\x98\x1f\x0e\x64\xc1\x12\x24\x95\x77\xa2\x08\xda\x04\xcd\x8e\x7a\xbf\x59\xcc\x94\xfc\xbe\x17\x9e\x62\xc2\xf2\xed\xe9\xe5\x74\x3a\x01\x2e\xfd\xb3\x67\x02\xfd\xc1\x4e\x80\x02\xdf\xe1\xe5\x8e\xed\x5b\xe0\xdd\xa3\xb0\xe1\x48\x64\xa2\x5c\x24\xdd\xae\x11\x46\x28\x87\x9e\x69\x5e\x7f\x7a\x10\xa5\x82\xbd\xa8\x6b\x54\x76\xbd\xc5\x82\x28\xa5\x4d\x8e\x06\x44\x66\xb4\xb5\x60\xb1\x16\x86\x28\x44\xe6\x81\xcf\x60\x37\xf0\x96\x0d\xa8\xb7\xb6\x4a\xf7\x61\x12\x95\x19\x3f\xd6\xf6\x88\x52\x3a\x23\xe6\x24\x0e\x5f\x7f\x7a\xfb\xe3\x8f\x3f\xfe\x99\x1c\x7b\xc5\xec\x94\x96\xbe\xfe\xf9\xf6\xed\x06\xee\x54\x0f\xe6\x17\x5d\x37\xe4\x1c\x73\xb2\x00\x24\xb7\xf6\x60\x1d\x56\x1b\xf8\x8a\x22\x5f\x6b\x55\x1e\x36\xf0\xa9\x29\x4b\x82\x07\xa5\xb4\xce\x3e\xb7\x7d\x8e\x76\x63\x79\x82\x1b\x1d\x40\xb8\x4b\x20\x17\xb1\x26\x06\xcd\x15\xa2\x1c\x4b\x24\x8a\xfe\xc5\x88\x0c\xbf\xa0\x91\x3a\xbf\xc1\x4c\xab\xdc\x4e\x4a\xd3\xa7\xa6\xda\xa2\x21\x85\xb6\xfe\x6a\x10\x65\xa9\x1f\x31\x0f\x31\x52\x2b\x17\x4e\xc3\x8e\x60\x17\x4d\x59\x1e\x4e\x40\x02\x38\x0a\xa1\x14\xf1\x36\x30\x5e\x3a\x78\x94\x65\x49\x0e\xcf\x60\xa5\x1f\x30\x6f\x1d\x68\xa4\xf6\x67\x55\x1e\x48\x8e\x58\x08\x7b\x20\xe3\x89\x8e\xe5\xbc\xb4\x9a\x6e\xd9\xc0\x47\x71\x00\xe2\x14\x3d\xc1\xee\xb5\x71\xa8\x30\xef\x72\x70\x84\xb2\x52\xb9\x7f\xf9\xe3\xc9\x6f\xde\x32\x52\x6c\xb4\x3b\xd1\x93\x1e\x12\xd3\xba\xf9\x6e\x08\xe7\xaf\x3f\xbd\x05\x96\x4e\x62\x2a\x4b\x27\x31\x16\x84\x4b\x86\x73\xc0\xe4\x24\x9f\x15\xa9\xc8\x98\x60\x7e\x6a\xd6\x82\x1b\x6b\xd5\x9c\x89\x09\x22\x31\x6b\x94\xae\x20\x53\x88\xd2\x2a\x02\x79\x92\x55\xd4\x20\xd2\xfb\x5c\x1a\xcc\x9c\xe7\x93\x63\x8f\xb6\xed\x73\x5f\x84\x30\x88\x90\xc3\xd6\xdd\x4a\x0b\xf8\xad\xc6\xcc\x25\xa3\x11\x0e\x01\x2f\x94\x06\x72\x11\x68\xe0\x41\x5a\xb9\x2d\x4f\xe5\x1c\xbc\xb4\x24\x50\xac\x84\x1e\x31\xc2\xca\xa0\xc8\xf6\x01\x1b\x76\x49\x2f\x41\x14\xe4\x8a\xe8\x0c\x4c\x5d\xd9\xd7\x7a\x97\x08\xb7\x02\xad\x38\x18\x44\x28\xa4\x12\xa5\xfc\x95\xe2\x3d\x7a\x06\x11\x05\xab\xda\x1d\x36\x70\x65\x19\x45\x10\xf6\xe4\xc2\x1e\x60\xbe\x91\xf4\x5e\x48\x0a\x56\x1c\x56\x76\x75\x44\xe6\x6d\xa9\xb3\x7b\xe2\xdd\xe7\xf8\xd8\xfc\x54\x50\x7a\x40\x3d\x6f\x57\x1d\xdb\x17\x4d\x24\x11\xb2\x51\xc4\x78\x6d\x82\x25\x86\xa2\x31\x6e\x4f\xce\x4b\x85\xd8\xbf\x68\x28\x4e\x5a\xf5\xc0\x8a\xd2\xed\x75\xb3\xdb\x83\x6c\x23\xa1\xa8\x3d\x10\xd2\xa2\x44\xf5\x70\x41\xe4\x5a\x6d\xa4\x1e\x70\x23\xf4\x40\xca\xaf\x64\x85\x1b\xf8\x49\x1b\xc0\x6f\xa2\xaa\x4b\xca\x2e\xc8\xcb\x9b\x90\x60\xb0\xa4\xf9\x10\x4c\x40\xad\x59\xc2\x02\xe4\x1e\x4c\xa9\xe0\xc7\xd7\xd1\x24\x79\xa9\xfa\x6b\xb3\xa5\x8b\xbd\x55\x21\xfe\xb3\xdc\x5b\x54\x39\xf9\xe6\x56\xde\x93\x29\x3a\x4d\xa6\xe8\x63\xe5\xce\xc7\x7a\x4c\xa3\xc0\x32\xe2\xbd\x54\xfc\x4d\xad\xf3\x0d\x5c\x05\x49\x12\xae\x83\x04\x31\x22\x21\xd1\x83\xcb\x48\x11\x2e\x20\x60\x2f\x4c\xde\x45\x22\x3e\xf4\xc5\xcd\xf5\x5f\xfe\x7a\xfd\xe1\xc3\xcb\xde\xe3\x49\xac\x7b\x20\xbd\x3c\x67\x25\x0a\xd5\xd4\xab\x60\x44\x23\x92\xad\x2d\xbd\xfa\x72\xcd\x99\x04\xfd\xbf\x77\x89\x19\x92\x39\x57\xe8\x1e\xb5\xb9\xef\x81\xad\x85\x71\x1c\xa6\xdb\xd5\x91\x79\x27\x1e\x59\x47\xc7\xc0\x6f\x24\xce\x51\x9d\x02\x63\x59\x46\x57\xd0\x28\x27\xcb\x3e\xaa\x0a\x44\x5e\x49\x25\xad\x33\xc2\x69\x43\x72\x24\x1a\xa7\x2b\x76\xb1\xb5\xd1\x19\x5a\x0b\x99\x50\x90\xa3\x27\x0c\x1e\xcb\xd9\x80\xfd\x63\x37\x93\xc8\x48\xba\x73\x5d\xc4\x18\x6e\xd5\x32\x3b\x69\x59\x08\x49\xc3\x69\xf6\xa2\x0f\x91\x6e\xde\x22\xaa\xd6\xe8\x51\x6c\x30\x16\x0b\x9c\x9a\xd1\xf4\xa4\x1e\xdc\xae\x19\x3d\x8a\x20\xfe\x9f\x47\x0c\xad\x41\x9b\xf4\x69\x1f\x1b\x4b\x74\xf3\x56\x31\x7a\xf7\x0e\xa9\x5b\x2d\x6e\x85\xd2\xe0\x8e\x64\xa1\xe7\x83\x01\xde\x8b\x6c\x0f\xa8\x9c\x39\x84\xa4\x4e\xe6\x14\xa8\x16\x12\x4d\xaa\xca\x18\xb4\xb5\x56\xec\x15\x20\xd3\x55\xad\x15\x72\xb2\x4d\x0e\x53\x96\x7d\xf1\xeb\xa8\x86\x87\x9c\xf0\x20\xc3\xcc\x82\x33\x68\x72\x8f\x65\xa6\x07\x96\x1d\xa0\x5a\x2b\x59\xae\x18\x63\x89\xc1\x4c\xc8\xe0\x2a\x48\xa0\x63\x04\x12\x62\x9c\xd3\x03\xb3\x2f\x38\x25\xef\x04\x4f\xe2\x4f\xc2\x18\x71\xec\x66\x77\xa8\x28\x66\xc6\xb3\x49\xda\xf2\x2f\x9d\x2b\x03\x91\x35\xff\x26\x4a\xca\x3f\x0b\xf9\x6d\x45\x66\xb9\x95\x77\xce\x0e\xfa\x9e\xc2\xe9\xf4\x50\x32\xe4\x4a\xfe\xd2\x84\x6c\xec\xf3\xa7\x0f\xff\x05\xd7\x3f\xf1\xdd\x84\x4f\x88\x46\xf6\xc2\xb6\x4a\x56\x1b\xfd\x20\xf3\x3e\x45\xc0\xb3\xa3\x1b\xc2\x10\x32\x64\x8c\x02\x74\x83\xae\x31\xca\x87\x0c\x6d\x85\x25\x45\x93\xe3\x99\x9f\xdb\x0b\xd5\x82\xa9\x85\xb5\x29\x5c\xf2\xfe\x93\x41\x70\x04\xb9\x25\xeb\x5b\x6d\xa5\x0a\x45\x83\x74\xc0\x1e\x50\xdb\x14\x85\xfc\x46\x60\xc8\xbe\xfa\x33\x05\x77\xbc\x0f\x91\x01\xa7\xa9\x6d\x99\x12\x4c\x53\xa2\x8d\x61\x03\xd1\xa7\x07\x34\x04\x21\xb1\xf8\xb6\x45\x70\xa6\x51\x59\xd7\x0a\x95\xa8\x76\x6e\x1f\x45\xd4\x63\xc1\x76\x46\x52\xa1\xc3\xe9\x1e\xcc\x4a\xdc\x7b\xbd\xf4\xc8\x05\x7e\x69\xd5\xe1\x31\xdb\xbb\x1e\xf9\xa9\x92\x2b\x0b\x39\xe0\x85\x09\x3f\xba\x3b\x8a\x81\xcf\xc1\xbd\x83\xb0\xab\x0e\x60\xcf\x9c\x4f\x9f\xa9\xd0\x46\xcc\x03\x01\x7f\x7c\xfd\x67\x58\xf7\x20\x4a\x65\x1d\x8a\x7c\x95\xd2\x03\x94\x1c\xb6\x84\xdb\x7e\x78\xfd\x06\x38\xbd\xf5\xb1\xc8\x9f\x5e\xbf\xf6\x85\x80\xaf\x28\xac\x56\xa1\x30\x47\xfa\xab\x9b\x01\x7d\x55\xb9\xcc\x04\x27\xbd\xc7\xe2\x9a\x71\xf5\x25\x04\x4e\x85\x6e\x28\x3d\x54\x6d\xa4\x48\x09\x8f\x73\x98\xaf\x46\xcf\x1f\x24\x30\x94\x71\x0c\x92\x8d\x79\x11\x75\xaa\x3c\xf4\x43\x4f\x46\x84\x33\xd3\x1e\x4c\x82\xf7\x95\x20\xac\x7d\x98\xb1\x47\x91\xa3\x79\xc9\xac\xb9\xaa\xeb\x52\xd2\xd1\xc9\xa8\xc8\x02\xa2\x06\x13\xea\x89\x4b\x7d\x85\x7a\x5e\x3f\x23\x73\xac\x6a\xed\x50\x65\x87\xe5\x62\xa6\xd9\x0a\x02\x72\x52\x16\xef\x99\xa6\x2b\xb0\xe4\x28\x29\x06\x56\x3e\xef\x3c\x2a\x55\x88\x78\xc8\x2c\x4a\x1c\x85\xcf\xba\x38\x01\x09\x21\x80\xb6\xac\x09\xd6\x09\x87\x9b\x31\x2f\xfe\xec\xf9\x20\x77\x51\xe6\xb8\xcd\xe5\x95\xea\x5e\x4c\x6c\x14\x54\xb2\x77\x46\x97\x65\xaa\x99\xa1\x2a\x34\xd7\xbb\xac\xae\x22\xce\x27\x50\x49\xb0\x1f\x84\x91\x42\x39\x4a\x19\x83\xd7\x8d\x35\xa3\x10\x75\x1f\xe7\x84\xc2\xfb\x27\x5d\x74\x31\xe8\x07\x44\x1c\x8a\xef\xc5\x83\x2f\x59\x1e\xa8\x36\xc6\xa9\x9a\x3e\x2a\x08\xb1\xff\x54\xb2\x24\x85\xe4\x18\xe0\x28\x6e\xec\x01\x25\xa3\xc8\x0e\x80\x3c\x37\x05\xf7\xe5\xa1\x83\x05\xa5\x40\xa4\xf0\x8f\xd2\xe2\xea\x24\x8a\xc8\xc8\xe7\xe7\x68\x06\x0c\x51\xa3\x3a\x20\x62\x76\xba\x97\x79\x8e\x0a\x5e\x48\xc5\xc7\x7d\xf5\x28\x5c\xb6\xe7\x1f\x77\xe8\x20\x13\x65\x69\x5f\xfa\x90\xc4\xeb\xef\x04\x01\xd4\x85\xa3\x4c\xb5\x94\x99\xa4\x54\x57\xd8\x7b\xb6\xb1\xa0\xb7\x6c\x38\x4f\x9e\x9f\x6a\xb3\x03\x95\xa5\xff\xe4\xa8\x31\xf6\x6c\x40\xa6\x5a\xda\xea\x28\xb6\x24\x73\x59\x07\x91\xed\x44\x14\x83\xf5\x6b\xba\x2f\x6b\x0c\x15\x3b\x29\xf8\x3d\x65\x6b\x28\xa3\xd4\x46\x3e\xc8\x12\x77\x98\x93\x73\x0f\xdd\x0b\xbe\xbc\x9f\xb1\xf9\x32\x73\xfb\xdc\x90\x97\xca\x36\xfb\x5d\xc5\xf4\x30\x58\x4d\xbe\x43\x52\x88\xe7\xf3\xcc\x1e\xc8\xed\x01\x84\x3a\xf0\xa3\xd9\x94\xbd\x7b\xff\xe5\xeb\xfb\xb7\x57\xb7\xef\xdf\xc1\xfa\x08\x5d\x2e\x91\x0b\x05\xa2\xac\xf7\x22\x88\x2c\xf1\x6c\x30\xb2\xeb\x14\x8f\xa4\x82\x87\x37\x9b\x37\x7f\xda\x9c\x1a\xa5\xb1\x4e\x05\x7d\x6a\x9f\x1d\xf6\x7f\x38\x51\xd6\x2f\x21\x8b\x1c\xd5\x9d\xd0\x39\xa0\x50\x18\xbf\x61\xd6\xb8\xbe\x4f\x0f\x69\xab\x2f\x78\xa6\x30\x39\x29\x0a\x91\x36\x94\x3a\x36\x5e\x4a\x88\xaf\xa5\xb0\x2e\x62\x39\x02\x31\x21\x41\x10\x02\x35\x62\x21\x04\x0a\x21\x4b\x72\x78\x06\x6d\x53\xba\x50\x0f\xf2\xa2\xd6\x45\x7f\x10\xb4\x6f\xa6\xa4\xb8\x8a\x64\xc5\x69\xd6\xf4\xe8\xf7\x86\x74\x93\xe2\x9a\x16\x74\x5f\x55\xa3\xdf\x0c\x67\x25\x2d\x12\x65\x19\x55\xb0\xef\xbc\x46\x63\xe4\x73\xbc\xf5\x1f\x35\x10\x0e\x8f\x30\xb9\xdb\xb9\x88\x39\x29\xb3\x55\xda\xa3\x94\x83\xd2\x90\x74\xc2\x31\xbe\x44\xd5\x4c\xfc\xdd\x2c\x46\x2f\x1a\x0f\xf6\x63\xfe\xf2\x4b\x43\xbe\x6c\xf8\x1c\x6b\x0e\x62\x06\x7f\x1a\x6d\xe8\x4c\xa7\x12\xa1\xbc\xd8\x94\xee\x72\x71\x86\x66\xd7\xc5\xb1\x68\xf9\x70\x8c\x28\xf8\x93\x90\x65\x63\x42\xe8\xdf\x35\xe5\x03\x20\x43\x7d\x84\xfa\x5f\xd4\x04\xb7\xa1\x1e\x48\x8d\x36\xb1\x0b\x15\x51\xd2\x88\x90\x47\x52\xba\x65\x1b\x0a\x32\xbc\xda\xe9\x41\x8b\x43\xff\x05\xa9\xe2\xd2\x42\xb4\xd5\xdd\x54\x6f\xb3\x78\xba\x4c\x0d\xb7\xf8\x47\x29\xf4\xd4\x76\xff\x08\x4c\x68\x43\xa1\x18\xf6\x3c\xa9\xf5\x3f\x0a\x76\x70\x24\xe0\x29\x63\x00\xa3\x90\x7f\xc7\xf1\x80\x59\x41\x68\xfc\x64\x3a\xc7\x59\xac\xbb\x69\x76\x3b\x5f\xfc\xfe\xf7\xdb\xdb\x2f\x31\x75\xa1\xdb\xdb\xe6\x07\x85\x97\x8d\x5d\xc1\x6b\x90\xfd\x38\x34\xfe\x85\xb2\xd4\x98\x09\xe8\x44\x9a\x3f\xfe\x30\x72\xcd\x78\xc4\x19\xff\x72\x74\x42\x96\x76\xd6\xc9\xde\xd3\x70\x50\x8e\x39\xb5\x91\x04\x08\x6b\x75\x26\x39\x38\x4e\xea\x6b\x38\xa3\xda\xf8\x82\xcc\x08\x48\x92\x49\xba\x8a\x25\xc3\xcb\x36\xd0\x5c\x83\x7e\x54\xdc\x36\xf7\x4f\xf0\x68\x9d\x84\xa0\xa3\x10\x53\x25\x22\xfa\x18\xc6\x30\xa5\xfc\x83\xcd\xc6\x4c\x53\x94\x5c\x2d\x46\x40\x92\x29\xa1\xd8\x23\xe8\x19\x7e\xcb\xb0\x0e\xe5\x22\x8f\x74\xca\x09\xc2\x71\x88\xd6\x63\xbc\x3a\xef\x71\x00\x32\xd1\xd8\xa9\xdf\x4f\x98\x41\x95\x83\xb7\x7c\x8b\xb7\xc5\x20\x55\x56\x36\x39\x5a\xa8\x28\x71\x0b\x7c\xed\x70\x69\x02\x30\xb4\x06\xf8\x86\x25\x33\x64\xc6\x14\x07\x34\x06\x37\xf0\x49\x3b\xea\xe0\x1d\xfd\xca\xb1\xe0\x24\xd0\x50\xd8\x08\xb8\x60\x1e\x8e\x38\x46\xa4\x33\x5e\xfb\x29\xb4\x0c\x1a\x42\x62\x73\xee\xa2\x13\xb2\x2e\x89\xae\xec\x7d\xa2\x53\x4f\xe5\xe4\x10\xd7\x53\xc9\x99\x6a\x4b\x67\xe1\x06\x47\x8e\xc6\x68\xb3\xa2\x00\x87\x3c\x2e\x4b\x0d\x89\xfb\x7f\xdc\x7c\xfe\x44\x75\x0e\x8e\x07\xc4\x98\x5b\x39\xfd\x7c\x6c\x19\x0d\x39\x31\x45\xe5\x50\x6b\xeb\x0a\xf9\x0d\xe2\x84\x06\x9b\x19\xc5\x26\x68\x06\x44\xe1\xfc\x74\x15\xd9\xdc\x2b\x12\x24\x1f\x4b\xff\x8a\x46\xaf\xa5\xca\xf1\x1b\x55\xbb\xe0\x27\xa2\xc8\x79\x8e\x47\x5f\x57\xa3\x30\x5e\x0e\xb9\x7a\xc6\x6d\x31\xc9\x19\x8c\x97\x55\x5d\x04\x59\x80\x7c\xa0\x38\xd6\xff\x38\xed\x6d\x80\xa5\xbc\x8a\x3c\x78\xd5\x94\x4e\xd6\x25\x7a\xea\xda\x0d\x7c\x0e\x16\x80\xd3\x84\xf7\xbe\x53\x74\x56\x40\xe8\xbf\x3b\x80\xbb\x25\x71\xe6\x6e\x09\xeb\xd0\x92\x23\xee\xa7\x2f\xb5\xea\xe6\x4a\x33\x20\x26\x81\x21\xc8\x2c\xd0\xff\xfd\xfa\x7f\x36\x13\x8f\x98\x01\x33\x20\x51\x48\x43\x4d\x14\xa6\x61\x28\x77\xab\xf8\x90\xbb\xe5\x72\x31\x01\x61\x9e\x97\x6b\xff\x2a\xb4\x56\xec\x26\xa2\xe0\x41\xf5\xb9\x82\x7d\x53\x09\xb5\x36\x28\x72\x6e\xa4\x76\x7e\x8d\x0a\xc5\x9c\x3f\x0b\x16\xe2\xe5\xcc\xe1\x0d\x74\x3d\x41\xa8\x6e\x86\xc8\x86\xb3\x87\xf5\x84\x77\x68\x3f\x64\xd3\xc9\xfd\xe4\x68\x36\xcf\x49\x2c\xef\x02\x9e\x4c\xab\x4a\x64\x7b\xa9\x70\x8a\x5a\x67\x41\x06\xc7\x71\x42\xad\x58\x8e\xe5\x68\x2a\xe5\xdf\x04\xd0\xcc\x01\xc9\x0e\x93\xa3\x2f\x8a\x31\x08\x1b\xf1\x20\x64\x49\x1c\x7d\x46\xba\x9d\x49\x34\xe6\x24\x1c\xf1\xcf\xcf\x0b\x2f\x66\x52\x9e\x6c\x3c\xdf\xd1\x5a\xbf\x9e\xb5\x7f\xaa\xe3\xf4\x21\xdd\x91\x87\xdc\x2c\xbe\x93\x48\xa7\xa3\xaa\x93\x87\xba\xa0\x53\xd1\x1d\xff\xe0\x43\xc1\x67\xe5\xeb\x8a\xed\xb8\x15\xf9\x85\x30\x3b\x37\x09\xb7\xd3\xc9\x0b\x9d\xcd\x16\x35\x1a\xbc\xfd\x9d\xc6\x55\x7f\x13\x2f\xa6\x4b\x02\x03\x02\x46\x37\xfc\x63\x59\x01\x2f\xc2\x98\x1d\x12\xd1\xa8\xc8\x64\xa5\xda\x95\x38\x9e\xda\xc7\x8f\x2f\x13\x53\x7e\xbb\x8d\x46\x67\x8b\xf9\xcb\xef\x16\x58\x6e\x62\x70\x07\x62\x64\x4a\x6c\x94\x62\xd7\x45\xdb\x8b\x58\x75\x9b\x1e\x71\x52\xa2\xd3\x23\x9e\x80\x09\xed\x10\x60\xcc\x6a\xb9\xda\x47\x13\xb7\xf9\x06\x6e\x48\x6e\xd9\x44\xc6\x39\x6c\xdf\x53\x99\x84\xd8\xe9\xd5\x70\xa9\xce\x51\x4b\x8c\x42\x99\x92\x67\x7c\x69\xf8\x2a\x23\xbb\x02\xeb\x90\xe0\x69\x1b\x1f\x72\x06\xee\x91\x43\x8b\xb8\xc0\x5e\x3f\xfa\x11\x21\xa7\xe1\x51\x48\x97\x4e\x2e\xee\xa7\x68\x1f\x51\x3d\x45\x6b\x8a\xa9\x73\x72\xc8\x79\x79\x24\x7d\x1a\xf9\x04\x6b\xf5\xf3\xf5\xbb\x53\x9d\xd8\x8c\x09\xf4\x62\x56\xb8\x35\x26\xd4\x4f\x1e\x76\x6e\x87\x07\xec\x1f\x1a\xf9\xdd\xb6\xe3\xac\x9b\x9b\x32\xf3\xcf\xb0\x9d\xb0\x98\x14\xc0\x50\x8d\xfd\x2d\x9b\x0a\x8b\x19\x1a\xf3\x9b\xb6\x16\x46\x01\xff\xee\xee\xe1\x2c\x7b\xcf\x84\xc9\x4f\x0e\x8e\x83\x99\x3f\x57\xd6\x4b\x56\x6e\xf3\xdb\x11\xef\xaf\x67\x8c\x62\x7e\x71\xe3\x84\xca\x69\x02\x8d\x1a\x3b\xe9\xde\x7f\x82\xbf\x9e\x55\x49\xd1\xa4\x09\xcd\x7c\x77\x1d\x6f\x88\x89\x05\x35\x2d\x64\x91\x26\x57\xb9\x44\x4d\xdd\xcf\x4a\xba\xc5\x8c\x2c\x2d\x74\xa1\xa9\xd9\x43\x89\x59\x28\x01\xc6\xfe\x4a\xb4\xf3\xa1\x4d\x70\xce\x9f\x85\x51\x08\x6a\x80\x72\x42\x4d\x3c\xeb\x44\xe3\x1c\x6a\xa4\x28\x5f\xd7\x82\xc6\x69\x86\x06\xff\xba\x7f\xe1\x98\x71\x57\x42\x5a\xcb\x37\xe9\x30\x34\x11\x46\x2a\xf5\x91\xb2\x33\xb6\xe7\x31\xcd\x3b\x7d\x47\xa7\x83\xe7\x0d\xf5\x73\x85\xdf\x52\xaf\x31\x9d\x60\x12\x64\xea\x89\xbe\xf5\x1c\x22\xfb\xc6\xfd\x6e\x2e\xf7\x2b\x17\xc4\xb1\xed\x28\xd6\xda\x0e\xcf\xfd\x76\xff\x64\xd1\x1d\x32\xa1\x3a\xa0\xdc\x35\x21\x68\x20\x3a\x67\x7b\xa1\xa8\xe3\xa9\xbb\x35\x0c\x31\x09\xb2\xc0\x47\xa8\xa4\xa2\x32\x0a\x95\x28\xba\x73\x42\xad\x7f\x8b\x05\x7d\x9f\xc4\x46\xa9\x98\x84\xcb\xfe\xb0\x21\x2f\xe8\xe9\x9a\x24\xb5\x33\x7a\xb4\x45\xf0\x1e\x2b\x4b\x33\xa8\x93\x30\x83\xb4\x74\x2b\x0a\xa1\x51\x85\x34\x8a\x59\x52\x07\xeb\xa0\x1b\x7f\x0e\x83\x19\xca\xa1\xcd\xa2\xee\x1f\xa3\xe6\xf4\x3d\x2a\xef\x24\x84\xf2\xf1\x4f\xb4\x8e\x53\x21\xc8\x59\x43\xd5\xf5\xf1\x81\x82\xb3\x15\xfb\xe2\xc6\xb5\x0d\x9f\xe4\xd6\xc3\x7c\x15\xb3\xff\xe2\xc2\xa6\xb6\xc5\x04\x54\x88\x9d\x97\xd8\x70\x89\x7e\x93\xb4\x22\xc6\x1c\x71\xfc\x2d\xf6\x8f\x06\xc6\xa9\xba\x9f\x76\x6a\x95\xb9\x1c\x64\xdd\x93\x3d\x88\xe0\x06\xfe\xc6\xcc\xaa\xc2\xb4\xa4\xa3\xf9\x8c\x33\xcc\x10\xc9\x0c\x74\x50\x21\xc3\xe3\x45\x12\x1a\x95\xda\xee\x5b\x91\xdd\xcf\x91\x98\x38\xe7\x35\x63\x1c\xa6\xe3\x11\x26\x41\x3e\x83\xb7\xc8\xb4\xf2\x03\x0c\xd9\x61\x1d\x46\x60\xd6\x42\xe5\xeb\x64\x1e\xb2\xc3\xc5\xf7\x0a\x9e\xc5\xb2\xf8\x20\xd5\xfd\x6c\x89\x8b\x37\xf8\x28\xed\xe7\xaf\x1f\x4e\x83\xb3\x24\x3a\x53\x4a\x31\x6b\x97\xe8\xfb\xce\x76\x36\x2a\x9d\xae\x69\x3d\xb1\x92\xf5\xb8\x0f\x83\x21\x29\x70\x19\x81\xcb\xb5\xa7\x30\x47\xb7\x0c\xdd\xe0\x65\x48\x7e\xa7\xcb\x5a\x53\xfd\xa1\xd1\x62\x16\x5c\xc5\x29\xc0\xac\x14\x86\x26\xe1\x78\xb2\x95\x3b\x77\xfe\xa1\xa3\x30\xb9\xa3\xb7\x6d\x1c\xe4\x1a\xa9\x5c\xe6\x40\x3f\xa0\x31\xd4\xf0\x90\xbd\x2d\xbd\x99\x8c\x99\x60\xca\x58\x3b\x7f\x3d\x32\xe8\x31\x0a\xaa\x14\x5b\xec\x37\xfc\x9e\x79\xcd\xf6\xa3\xa8\xc9\x52\x86\x2c\xe5\x1e\x0f\x44\xd6\xb8\xfb\xdd\x37\x9a\x4e\x83\x36\x3b\x41\x3d\xe7\xde\x33\xe9\x3e\x8a\x97\x76\xda\xc8\x5f\x11\x5e\xf0\x16\x3f\x43\xb3\x58\x62\xe6\x5e\x86\x43\xd2\x32\x9d\x38\x40\xc5\xf3\x5a\xfe\x27\x6d\xec\xd0\xa0\x9f\xc1\xba\xa4\xb4\x9f\x64\xb3\x9d\x9d\xb3\x01\xa6\x79\x90\x19\xda\xa7\x67\x8d\x9e\xae\x17\x73\xd9\x50\x09\x25\x76\x98\xfb\xc6\xca\xe5\x14\x31\x97\x1f\xbb\x97\x42\x25\x6a\x0b\xb4\x84\x51\x94\xfa\x71\x2d\x73\x46\x3b\x7a\xa7\xd0\x8f\x1f\xda\xa2\xd4\x45\xec\xa1\x30\xf9\xa9\xc9\x13\x70\x20\x9f\xc5\xdf\x45\xa8\xa1\xed\x2a\x29\xe4\xb4\x34\xba\x46\x75\x8d\x51\x2f\xb9\xd7\x8d\xc5\x7b\xc4\x5a\xaa\x9d\x0f\x71\x29\x68\xb6\x64\x84\x24\xcd\xcb\x1d\x42\x25\x86\xc6\xe1\x54\x68\xbe\x86\x35\xa3\x46\xe5\x68\xac\x1b\x8a\x57\xdb\xea\xc8\x06\xae\xd2\x79\xa3\xd4\xc4\xd0\xfc\xc2\x77\xd5\x56\x47\x53\x90\xf1\xcb\x1e\xcc\xb0\x09\x10\x47\x76\x3a\x93\xa1\xa2\xae\x69\xda\x4d\xb8\x3d\x94\xf2\x1e\xe1\x6e\x99\xc9\x75\x96\xdf\x2d\x89\x14\x18\x83\x56\x4f\xbf\x1e\x58\x32\xf5\xe5\xa3\x38\x24\xc3\x95\xb8\x11\x02\xfc\x16\x7d\x0e\x11\x4e\x96\xb2\x87\xbc\x6f\x9c\xd0\xb8\x53\xc7\x1d\xf0\x30\xe0\x46\x44\x0e\x94\xe8\x04\xab\x71\xa8\x8d\x6a\x86\x43\xa3\xcc\x4a\x3b\x99\x61\x6f\xd4\x6d\xa4\xe7\x3a\x9d\x69\x9d\x9b\x67\x39\x92\xe0\xe9\x61\x96\x14\x52\xc5\x28\x6f\x2a\xd5\xe8\x14\xcd\x88\x29\xc4\x37\xb2\xd9\x7e\x25\x1c\x43\x41\x8b\x7c\xc8\x92\x0b\xfc\xaf\xc2\x33\x96\xf0\xf7\xe6\xe4\x9d\x15\xed\x87\x39\x4e\x6c\x72\xba\x5e\x97\x94\x9c\x76\x31\x0e\x32\x18\x76\x96\x91\x96\x8e\x68\x45\x9f\x34\xcd\x88\xec\x7e\x14\xcf\xa3\xf3\xc5\x99\x44\xc2\x79\x8b\xdc\x01\xa3\x59\xc8\x2c\x15\x42\xc2\x62\x93\x57\x98\x11\x98\x61\x3e\x67\x68\x58\xfb\x8c\x71\x4e\xdd\xf0\x41\x5e\x8e\x58\x7f\x70\xa6\xc1\xf3\xcc\x0d\x66\xa9\x13\x5d\x8b\x63\x7d\x99\xc2\x76\xc0\x30\x76\xcd\xa3\x99\x21\x5c\xde\x3a\x9a\xfe\xe2\x8f\x2e\x8e\x75\x8f\x41\x0e\xd3\x26\x70\xcc\xe2\x0c\x94\x47\x09\x9c\x42\x9b\x19\x48\x7f\x8e\xd7\xc6\x57\xc8\x10\x6c\x22\x59\x02\x12\xca\x99\x25\x8a\xc1\xbd\x8c\x88\xb3\xb4\x70\xe4\x1e\xde\x73\x57\x78\x8b\x14\x6c\xa6\x7d\x7b\xd2\x0c\x0a\x19\xc9\xff\xca\xe8\x85\x47\x40\xa6\x19\xa5\x30\x44\x6b\x10\x2e\x68\x83\xe0\x70\xc1\xa6\xfd\xe2\x67\xae\xd8\x5d\xfc\x26\x0a\x51\x49\x7f\x06\x71\x68\x15\x03\xba\x1b\x82\x44\x98\x58\x19\x4e\x3c\x82\x47\x6a\x7b\x4c\x0c\x48\x5d\xa7\xdd\x8a\x60\x9d\xd3\xba\x99\x2c\x8e\x19\x10\x0e\xb8\x98\x2a\x91\x8f\x2d\xc2\xcd\x38\xf8\x84\xa8\x8f\xf5\x36\x87\xba\x4d\x47\x34\xba\xe0\x2d\x8e\x98\x17\x86\xbd\x14\x32\xfc\x34\x66\xd1\xbe\xd4\x62\x03\xd7\xb6\xdd\xef\x19\x5c\x88\x67\x29\x09\xd3\xbe\x5c\x2f\xb6\xab\x76\x9d\x97\x1b\x7d\xe9\x07\xae\x8f\xd1\x62\xcb\x63\xda\xcd\x1e\x92\xcd\x54\x41\x6a\x97\x7c\xa2\x19\x54\x20\x6a\x72\x2c\x86\x3a\x5f\xe1\x25\x1c\x5d\xcb\xb7\x19\xde\x6c\x92\x16\x6a\x23\x2b\x61\x24\xcf\xfd\x87\x21\x31\x12\xd5\xb4\xb1\xd0\x2e\x98\x50\x29\x2b\x3f\x29\xeb\xe4\xe9\x4d\x55\x7d\x69\x19\xa8\x46\x3f\x35\xf6\x6b\xad\x8e\xfd\x03\x1d\x6a\x24\x0c\x1c\x90\x8f\xc4\xa9\x49\x6e\x2f\x3f\xc5\xcb\x8e\x1c\xa8\xff\x26\x70\x9d\x76\xd7\x41\xf5\xa5\xa2\x7f\xe0\x2b\x15\xf4\x20\x3d\x9c\xb4\x8d\xf2\x8b\x07\x51\x7a\x9e\x32\xf8\xbb\x65\x8e\x85\x68\x4a\x77\xb7\x6c\x25\x6a\x05\xdb\x81\xd0\xa2\x7b\x69\xb0\x68\x99\x50\x5a\x11\x57\xdb\x0c\x98\x79\xd1\x4e\x93\xc5\x8a\x07\x85\xa2\x51\x46\x7b\x90\xc3\x6b\x41\x28\xe8\x27\x43\xd8\x15\xee\x30\x4c\xc3\xe6\x2c\x05\x11\xde\x6c\xb5\x8d\xb8\xf0\x90\xc5\xd8\xec\x70\x58\xcb\xbf\x53\x69\x25\x55\xc0\xbb\x4f\x37\xff\xfb\xe1\xea\xdf\xde\x7f\xd8\x4c\x0b\x47\x0f\xe8\x2c\x61\x49\xf8\xdb\xe5\x5c\x29\xd1\x8f\x0a\xcd\x57\xe4\x77\xd3\x64\x68\x27\x65\xe5\x43\x58\x34\x88\xd4\xcd\x91\x12\xc4\x18\xe5\xb7\xe5\x07\x4a\xa6\xaf\x3e\x7c\x18\x25\x50\x88\x65\xb9\xc2\xca\x35\xa9\x2d\x76\x87\xa9\x3b\xa0\x12\x2d\x77\xc2\x6c\x69\xf4\x3a\xa3\x65\x24\x5a\xfa\xe9\xcb\xde\x75\x71\x74\xa7\xb4\xdd\x24\xa4\x1b\xc4\xd3\x13\xfc\xd2\x4b\x1a\x74\x4a\x95\xe5\x1e\xd4\xb0\xf9\x22\xa3\xec\x4a\x7b\x04\x29\x35\xd1\xdb\x2f\x3b\xf1\x18\xdd\x61\x86\xf4\xe4\x96\xcb\x0a\x6d\x8c\xd6\x1d\x68\x0b\xc9\x13\xd9\xcd\x16\xe8\xe6\x9f\x11\x59\x1f\x87\xd1\xa4\x49\x2c\x26\x23\x6e\x71\x54\xc4\xc2\x6a\x0c\xbd\x52\xe2\x33\x49\x5b\x7c\xe7\xc8\x0c\x24\x88\xa7\x86\x5e\xfb\x76\xf5\xe9\x5d\x2c\xae\xb3\xc4\xa6\x5d\xd6\x25\x35\xb0\x29\x20\x57\x79\x84\x7b\x2a\xfb\xbd\xfd\xf1\x20\x00\x2d\xb0\x96\x11\x41\x08\xdb\x8e\xe4\x3d\x1e\xd6\x6c\x06\x46\x80\xd2\x4e\x00\xd9\x43\x27\xcb\x98\x6a\x04\x5d\xea\xac\xbf\x6c\xe0\x9d\x37\x77\x94\x4e\x40\x21\x4a\x7a\x7f\xda\xed\x58\xe8\x95\x5e\x20\x14\xb7\x6e\xa9\x92\x61\x38\xc1\xb5\xb0\xf4\x18\x2e\xa1\x7d\x07\x60\x64\x0f\x9f\xa5\x9f\x9a\x06\x3d\x8f\x5b\x6c\xf0\xc7\x1f\x7e\x80\x17\x3f\xab\xb0\x51\x42\xb5\x2a\x78\xaf\x9c\x74\x87\x97\x49\xdb\x62\x03\x61\x8a\xd1\x5b\xad\xe9\x55\x0f\x03\x57\xb4\x52\xfb\x14\x0e\x9f\x10\x8f\x5f\x58\x97\xb6\x00\x66\x68\xc4\x3c\xdc\xc6\x1b\xe2\x47\x58\xf9\x76\xf8\xa9\xd8\xff\xde\x3d\xc9\x33\x1a\x35\x3e\x37\x74\x7c\x96\x4f\x9d\x3d\xa2\xd1\xb3\x7c\x7f\x20\x32\x0b\xe7\x46\xce\x22\xff\xd1\x08\xc7\x73\x60\xdc\xc8\xdf\x44\xe4\x18\x3b\xf4\x71\x5e\x77\xac\xe9\xc0\x8f\x24\x67\x8b\x99\x9b\x51\x6b\x68\x64\xfe\x1c\xa1\x7d\x8c\xa6\x47\x8c\xfc\x11\x89\x69\xdd\x37\x34\x73\xd8\xbc\x91\xf7\xe9\xce\x6a\x84\x95\xbc\xb8\x75\x93\x1c\xc1\x62\x30\x51\x9c\xd5\xb2\x1a\x69\x4b\xf5\x20\x1e\xb7\xa9\x3e\x76\x3a\xca\x14\x7b\xe9\xda\xc9\x8a\x5e\xc1\x97\x41\xa7\x4d\xb3\x0a\x37\xf0\x33\x78\x66\xaa\x6f\x08\xe3\x06\x87\xdf\xbb\x6d\xd3\x61\x2a\xdb\xa7\x14\x85\xb6\x86\x43\x8d\x21\x7e\xd5\xbe\xf3\xad\x07\x92\xe3\x61\x6e\x9d\x85\x04\x32\x94\xa1\xdb\x4e\xd9\xd3\xdb\x63\xb1\x25\xc6\xef\x67\xac\x3a\x2f\x0d\xf3\x29\x36\xd1\x40\xf8\xb7\xe2\x64\x4d\x29\xcc\x00\xe6\x3d\x90\x9d\x93\xdc\xa9\x39\x0d\xa0\x99\xcd\xc1\xd1\x86\xe0\x73\x9b\xca\x19\x0d\xb9\xd9\x11\xef\x58\xe3\xed\x48\x3d\x6e\xe6\x37\xdb\x8e\xe8\x79\x02\x13\xe6\x35\xd8\x46\x71\x1d\x30\x97\xc7\x5a\x4c\x86\x32\x64\x45\x21\x53\xa7\x68\x56\x86\x37\x50\x72\x2e\x10\x5a\x5a\xa9\xfa\x12\xd0\x3e\x01\x0b\xe1\x3d\x85\x6d\x69\x3d\xe4\xd7\x1d\x31\x61\xc1\x04\x7a\x41\x54\x93\x51\xb1\x92\xde\x67\x94\xb2\x64\x5d\x4c\xbd\xc8\xb4\xf3\x82\xb6\xf8\xbe\x3e\x5a\x94\xf2\x3a\xab\x15\x7c\xf9\xf9\xb6\xd5\xc8\x13\x31\xed\xc1\xdd\x1e\x46\xc8\xfa\xdd\x2e\x62\xa6\x10\x0d\xda\xe6\x0a\xed\xfe\x72\x71\xe6\xde\xf8\x16\xea\x09\x48\x27\x5f\x05\xd3\xcb\x01\xfd\x3a\xbc\xe6\xfa\xe1\x0d\x17\xeb\xdf\x2c\x92\xbd\xc8\x3b\x35\xd5\xb0\xa6\x7a\x09\xce\x34\xb8\xf8\xbf\x01\x00\x63\xdc\x7e\xde\x8c\x5b\x00\x00"),
 		},
 		"/control-plane/crds/kuma.io_trafficroutes.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kuma.io_trafficroutes.yaml",
-			modTime:          time.Date(2019, 11, 13, 8, 14, 51, 55813956, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 23686,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\xeb\x73\xdb\x48\x72\xff\xee\xbf\xa2\x4b\xf7\x41\x76\x15\x49\xd9\xbb\x77\xa9\x9c\xbe\x29\xb2\xf7\xa2\xac\x5f\x65\x69\x2f\x95\x8a\x53\xa9\x21\xd0\x20\xe7\x04\xce\x60\x67\x06\x92\xb9\x7f\x7d\xaa\xbb\x67\xf0\x20\x1e\xa4\xbc\xba\xad\xe0\x9b\x28\xa0\x31\xd3\xcf\x5f\x3f\x06\x2f\x96\xcb\xe5\x0b\x55\xe9\xbf\xa3\xf3\xda\x9a\x4b\x50\x95\xc6\x6f\x01\x0d\xfd\xe5\x57\xf7\xff\xea\x57\xda\x5e\x3c\xbc\x59\x63\x50\x6f\x5e\xdc\x6b\x93\x5f\xc2\x75\xed\x83\xdd\x7d\x41\x6f\x6b\x97\xe1\x5b\x2c\xb4\xd1\x41\x5b\xf3\x62\x87\x41\xe5\x2a\xa8\xcb\x17\x00\x99\x43\x45\x3f\xde\xe9\x1d\xfa\xa0\x76\xd5\x25\x98\xba\x2c\x5f\x00\x18\xb5\xc3\x4b\x08\x4e\x15\x85\xce\x9c\xad\x03\xfa\xd5\x7d\xbd\x53\x2b\x6d\x5f\xf8\x0a\x33\x7a\x7a\xe3\x6c\x5d\x5d\x42\xfa\x59\x1e\xf2\xf4\x1f\x00\x59\xc4\x9d\x3c\xff\x85\x9e\xe7\x9f\xab\xb2\x76\xaa\x3c\x20\xfc\x02\xc0\x67\xb6\xc2\x4b\x38\x3b\x7b\x01\xf0\xa0\x4a\x9d\xf3\xb2\x84\x94\xad\xd0\x5c\x7d\xbe\xf9\xfb\x8f\xb7\xd9\x16\x77\x4a\x7e\x04\xc8\xd1\x67\x4e\x57\x7c\x5f\xef\x45\xa0\x3d\x84\x2d\x82\xdc\x0e\x85\x75\xfc\x67\xef\x95\x70\xf5\xf9\x26\xd2\xa9\x9c\xad\xd0\x05\x9d\x56\x4e\x57\x87\xd7\xcd\x6f\x07\x6f\x3c\xa7\x25\xc9\x3d\x90\x13\x77\x51\xde\xfa\x20\xbf\x61\x0e\x5e\xde\x6f\x0b\x08\x5b\xed\xc1\x61\xe5\xd0\xa3\x09\xbc\xb5\x0e\x59\xa0\x5b\x94\x01\xbb\xfe\x07\x66\x61\x05\xb7\xe8\x88\x08\xf8\xad\xad\xcb\x1c\x32\x6b\x1e\xd0\x05\x70\x98\xd9\x8d\xd1\xbf\x35\x94\x3d\x04\xcb\xaf\x2c\x55\x40\x1f\x7a\x14\xb5\x09\xe8\x8c\x2a\x89\x99\x35\x2e\x40\x99\x1c\x76\x6a\x0f\x0e\xe9\x1d\x50\x9b\x0e\x35\xbe\xc5\xaf\xe0\x83\x75\x08\xda\x14\xf6\x12\xb6\x21\x54\xfe\xf2\xe2\x62\xa3\x43\xd2\xae\xcc\xee\x76\xb5\xd1\x61\x7f\x91\x59\x13\x9c\x5e\xd7\xc1\x3a\x7f\x91\xe3\x03\x96\x17\xaa\xd2\x4b\x5e\xa7\x09\xac\x91\xbb\xfc\x4f\x2e\x6a\x9e\x3f\xef\x2c\x2c\xec\x49\xca\x3e\x38\x6d\x36\xcd\xcf\xac\x2a\x93\x6c\xfe\x59\x9b\x9c\x24\xaa\xe2\x63\xb2\xdc\x96\x9b\xf4\x13\x31\xe1\xcb\xbb\xdb\x3b\x48\x2f\x65\x8e\xf7\x59\xcc\xcc\x6d\x1f\xf3\x2d\x9f\x89\x2f\xda\x14\xe8\x44\x4e\x85\xb3\x3b\xa6\x88\x26\xaf\xac\x36\x81\xff\xc8\x4a\x8d\xa6\xcf\x63\x5f\xaf\x77\x3a\x90\x60\x7f\xad\xd1\x07\x12\xc7\x0a\xae\x95\x31\x36\xc0\x1a\xa1\xae\x72\x15\x30\x5f\xc1\x8d\x81\x6b\xb5\xc3\xf2\x5a\x79\x7c\x6e\x2e\x13\x43\xfd\x92\x38\x78\x9c\xcf\x5d\xc3\x4f\xd7\x98\xf2\xb3\x01\xd0\x2e\x58\x51\x0f\xfe\x01\xa0\xf2\x9c\x1d\x89\x2a\x3f\x4f\x3c\x3c\xb9\x82\x51\x33\x6a\xdf\xc4\x62\x36\x50\x1b\x1f\x5c\x9d\x85\xda\x61\x0e\xf7\xb8\x8f\x12\xdf\xa9\x0a\x7c\xb0\xf4\xe3\xa3\x0e\xdb\xc1\x1b\x55\x57\xfa\x2a\xb0\x58\xd7\x08\x1e\x03\xac\xf7\x40\xee\x92\x0d\x22\x58\x5b\xb2\xe5\x30\x2d\x36\x0c\x87\xc1\x69\x7c\xc0\x21\x49\xb7\xd6\xc1\x29\xb7\x6f\x78\xb7\x82\xbb\x2d\xee\x41\x39\x04\x12\xf3\xaf\x35\xba\xbd\x5a\x97\x42\x27\x1a\xec\x1a\x81\x95\xcc\x3d\x60\x3e\x20\xf9\xb8\x45\x03\x3b\x9b\xeb\x62\x4f\x9a\x2b\x6a\x39\x34\xbe\xcb\x8b\x8b\xfb\x7a\x8d\xce\x20\x39\x5e\x6d\x2f\x72\x9b\xf9\x8b\xda\xa3\x5b\x6e\x6a\x9d\xe3\x45\x47\x40\xe7\x2f\xc6\x58\x2f\x94\x7b\xff\xca\xca\xda\x07\x74\x1f\xc9\xb5\xcf\xc9\xe4\x6e\x8b\xec\xca\xc5\x75\x61\x7a\x0e\x1e\xb7\x3a\xdb\xf2\x2f\xd1\x9a\xd6\x58\x5a\xb3\x11\xc5\xbf\x3b\xb4\x38\xba\xb4\x87\xda\x63\x4e\xec\xce\xb5\x27\x5b\xad\xb5\xdf\x36\x82\xf2\x2c\x49\xf0\xf4\x2e\x7e\x21\x71\x91\x83\x48\xa5\x32\x62\x07\xe4\xba\x28\xd0\x1d\x5a\x5e\x67\x33\x5e\xde\x0c\x85\xc6\x92\xfd\x04\x89\x85\x64\xae\xcc\xfe\x71\x8b\x0e\xc1\xe9\xcd\x36\x80\xb1\x8f\x4c\x5d\x55\x9a\x25\xe3\x60\x64\xb9\x1b\xcb\xde\xc4\x82\xde\x18\x96\x47\x00\x5d\x30\x35\x6d\x24\x56\x22\x58\x17\x2d\x3b\xd9\xfd\x6a\x94\xfd\x23\x9a\x3f\x0c\xb6\x73\x42\x38\xbb\x3e\xbc\x5d\xbc\x60\x68\xfe\x1c\xb8\x40\xd9\xd8\xd0\x14\xf5\x0e\x45\xef\xd8\xbf\x45\xd9\x3d\x2a\x1f\xb7\x44\x2e\x2a\x24\xd6\x6d\x6a\xe5\x94\x09\x28\x42\x13\xfb\x19\x8a\xd5\xc0\x56\x55\x15\x1a\xbf\x5c\x63\x41\x9c\xb2\x2e\x47\x07\x2a\x73\xd6\x7b\xf0\x58\x29\xc7\xbc\xaa\xd0\x89\x8e\xae\xe0\x9a\x1d\xa8\x78\x5b\x63\x87\x34\x89\xcb\xbc\x3e\xb6\xf6\xb4\xa4\x66\x8f\x98\xd3\x5b\xbf\xfc\x74\xfd\xe3\x8f\x3f\xfe\x95\x62\xfa\x8e\xc5\xa9\x3d\xfd\xfc\xcb\xdd\xf5\x0a\xbe\x9a\x01\xcd\xcf\xb6\xaa\x29\x38\xe6\xe4\x01\x98\x43\x7b\x1f\x70\xb7\x82\x2f\xa8\xf2\xa5\x35\xe5\x7e\x05\x1f\xeb\xb2\x64\x8c\x50\x6a\x3f\x62\x88\xbf\xd3\x3f\x27\xbf\x71\x76\xb0\x36\xda\x80\x0a\x97\x40\x8a\xb4\x24\x01\x9d\xaa\x44\x39\x96\x48\xd4\xff\xe6\x54\x86\x9f\xd1\x69\x9b\xdf\x62\x66\x4d\x3e\xf0\xc1\x3d\x6d\xfa\x58\xef\xd6\xe8\xc8\xa0\xbd\xdc\x0d\xaa\x2c\xed\x23\xe6\x11\x1e\xb5\x7a\x11\x2c\x6c\x88\x76\x51\x97\xe5\x7e\xa8\x4b\xe8\x76\xda\x90\x6c\xa3\xe0\x75\x80\x47\x5d\x96\xa4\x29\x0e\x77\xf6\x81\x28\xa6\x00\x9a\xb8\xfd\xc9\x94\x7b\x96\x2f\x29\xe1\x80\x64\xda\x51\x5f\xcf\x4b\x6f\xe9\x91\x15\x7c\x50\x7b\x20\x49\xb1\x2e\x6e\xad\x0b\x68\x48\x63\x5b\x09\x4e\x70\x56\x9b\xf0\x2f\x7f\x1e\xe5\x2a\x61\xa3\xcd\x81\x9d\x0c\x16\x31\x6f\x9b\x6f\xc7\xd6\xfc\xe5\xa7\x6b\x60\xed\x64\xef\x40\xda\xc9\x96\xa7\x42\xe3\x38\x47\x5c\x4e\x13\xb3\x12\x17\x79\x25\xb4\xc3\xbe\x5b\x8b\x61\xac\x35\x73\xb1\x68\xd5\x08\x6b\x92\xaf\x62\x46\xec\xaa\x5a\x43\xa0\x48\xb2\x48\x16\x44\x76\x9f\x6b\x87\x59\x10\x39\x05\x8e\x68\xeb\xa1\xf4\x55\x84\x41\x1c\x05\xdb\xa5\x6b\x0f\xf8\xad\xc2\x2c\x34\x4e\x23\x6e\x02\x5e\x1a\x0b\x14\x22\xd0\xc1\x83\xf6\x7a\x5d\x0e\x63\x2c\x6b\x4b\x43\x8a\x8d\x50\x16\x46\xab\x72\xa8\xb2\x6d\x5c\x0d\x07\x86\x57\xa0\x8a\x80\x11\xd0\x13\x77\xf5\x50\xa1\x42\xc3\xb8\x05\x58\xc3\x70\x00\xa1\xd0\x46\x95\xfa\x37\xc2\x7b\xf4\x0e\x5e\xf3\xae\x0a\xfb\x15\x5c\x79\x5e\x22\x28\x7f\x70\xe3\x80\x30\x3f\x48\x76\xaf\x34\x81\x95\x80\x3b\xbf\xe8\xb1\x79\x5d\xda\xec\x9e\x64\xf7\x29\xbd\x76\xa0\x57\x63\x21\xd2\x63\x58\x74\x7c\x5f\x72\x91\x0c\x22\x0d\x09\xde\xba\x84\x64\x8a\xda\x85\x2d\x05\x2f\x13\xb1\x7f\x51\x13\x4e\x5a\x0c\x45\x55\x86\xad\xad\x37\x5b\x32\xd0\x84\x84\x92\xf5\x40\xcc\x88\x1a\xae\xc7\x1b\x92\xd4\x2a\xa7\xed\x48\x18\xb1\xb2\x46\x62\xfb\x0a\x7e\xb2\x0e\xf0\x9b\xda\x55\x25\x65\x17\xac\x4f\x31\xc1\x60\x4d\x13\x08\xa6\xa0\xb2\xac\x61\x91\xf2\x58\x20\xf9\xf1\x75\x72\x49\xa2\x55\x3f\xd7\x6b\xba\x59\xec\x81\xe4\xcf\x7a\xef\xd1\xe4\x14\xe6\x5a\x7d\x6f\x5c\xd1\x61\x32\x45\x97\xd7\x1b\xc1\x7a\x82\x5f\x44\x64\x24\x7b\x6d\xf8\x97\xca\xe6\x2b\xb8\x8a\x9a\xa4\x42\x67\x11\x0b\xfe\x7f\x5c\xc4\x10\xbd\xd1\xa2\x68\x2d\xa0\x60\xab\x5c\xde\x5d\x44\x7a\xe9\xcb\xdb\x9b\xbf\xfd\x7c\xf3\xfe\xfd\xab\xc1\xeb\x49\xad\x87\x82\xe2\x55\x64\x25\x2a\x53\x57\x8b\xe8\x44\xd3\x22\x5b\x5f\x7a\xf5\xf9\x86\x33\x09\xfe\x07\x87\xc4\x8c\xf1\x99\xc1\xf0\x68\xdd\xfd\x80\x6c\xa5\x5c\x60\x98\xee\x17\x3d\xf7\x4e\x32\xf2\x81\xb6\x81\xdf\x48\x9d\x93\x39\x45\xc1\xb2\x8e\x2e\xa0\x36\x41\x0f\x3d\x8a\x32\xa0\xf2\x9d\x36\xda\x07\xa7\x82\x75\xa4\x47\xaa\x0e\x76\xa7\x44\x6b\x6c\x86\xde\x43\xa6\x28\x21\x16\xc6\x60\x5f\xcf\x46\xfc\x1f\x87\x99\x36\xac\x10\x16\x29\x12\x86\x5b\xb4\xc2\x6e\xac\x2c\x42\xd2\xb8\x9b\xad\x1a\x52\x14\xcb\x41\xd3\x3a\x3d\xc2\x06\x53\x58\xe0\xd0\x8d\x36\x6f\x1a\x33\xd4\x0e\xc5\x0e\x82\xf8\x7f\x8e\x18\x5a\x87\x36\x1b\xd3\x3e\xd4\x9e\x3d\x0e\x7b\xc5\x14\xdd\x3b\xac\x6e\xad\xb8\x55\x4a\x87\x1b\xd2\x85\x41\x0c\x06\x78\xa7\xb2\x2d\xa0\x09\x6e\x1f\x93\x3a\x9d\xd3\x1e\x0b\x8d\xae\x29\xc8\x38\xf4\x95\x35\x1c\x15\x20\xb3\xbb\xca\x1a\x34\xd1\x71\x90\x9d\x8d\x84\xca\xc6\x34\x84\x72\xb3\x0e\x72\xcc\xac\x38\xa3\x2e\xb7\xaf\x33\x63\x72\x35\xd6\x2c\x8d\x2e\x17\x4c\x57\x63\x74\x13\x3a\x86\x0a\x52\xe8\x84\x40\x22\xc6\x39\xdc\x30\xc7\x82\x27\x25\xc1\xf2\x2f\xe5\x9c\xea\x87\xd9\x0d\x1a\xc2\xcc\x78\x34\x49\x3b\xfb\x5b\xe7\xce\xc8\x64\x5b\x49\x62\x4e\x1e\xa2\xd0\xdf\x16\x92\x7c\xf5\x60\xc3\x30\x52\x10\xe0\x8b\xa4\xc8\x91\x1b\xfd\x6b\x1d\xb3\xb1\x4f\x1f\xdf\xff\x17\xdc\xfc\xc4\x4f\xf3\x5b\x04\x8d\x6c\x95\x6f\x8d\xac\x72\xf6\x41\xe7\x43\x8e\x80\x88\xa3\x0b\x61\x68\x31\xe2\x5e\x99\xba\xc3\x50\x3b\x23\x90\xa1\xad\xb0\xb4\x38\x68\x32\xf3\x0b\x5b\x65\x5a\x32\x95\xf2\xbe\x81\x4b\x12\x3f\x99\x04\x23\xc8\x35\x6b\xd6\x5a\x9b\x58\x34\x68\x36\x38\x8c\x18\x75\x51\xe8\x6f\x12\x82\xd2\x9e\x22\xb9\x6d\x44\x06\x9c\xa6\xb6\xc5\x49\x70\x75\x89\x3e\xc1\x06\xe2\xcf\xd0\xb9\x09\x08\x49\xc5\xb7\x35\x42\x70\xb5\xc9\xba\x5e\xa8\x44\xb3\x09\xdb\xa4\xa2\xb2\x0a\xf6\x33\xda\x31\x6b\x06\x34\x77\xea\x5e\x6c\x40\x16\x17\xe5\x65\x4d\x47\xc6\xec\xef\x06\xec\xf7\x15\x66\x64\x80\x23\x21\x88\xa0\xea\x16\x1b\x35\x90\x1c\x5c\x02\x44\x0c\x88\x09\x73\x12\x67\x3f\x7e\xba\x8b\xc2\x03\x05\x7f\x7e\xfd\x57\x58\x8e\xc4\x75\x1f\x50\xe5\x8b\x26\x3d\x40\xcd\xb0\x25\x3e\xf6\xc3\xeb\x37\x70\x2d\xb9\x27\xc5\x90\xbf\xbc\x7e\x2d\xd2\xf9\x82\xca\x5b\x13\x0b\x73\x64\xbf\xb6\x1e\x4b\x3e\x73\x9d\xa9\x20\x68\xa0\xab\xae\x19\x57\x5f\x22\x70\x2a\x6c\x6d\xf2\x14\xee\x05\x87\x97\xa5\x0d\x01\xf3\x11\xac\x14\xf7\x1f\x35\x30\x96\x71\x1c\x92\x8f\x79\x99\x6c\xaa\xdc\x0f\xa1\x27\x2f\x84\x33\xd3\x11\x25\x45\xf8\x42\x14\x96\x02\x33\xb6\xa8\x72\x74\xaf\x58\x34\x57\x55\x55\x6a\xda\x3a\x39\x15\x5d\x40\xb2\x60\x0e\x7b\x49\x4a\x43\x83\x7a\xde\x38\xa3\x73\xdc\x55\x36\xa0\xc9\xf6\x87\xa1\x66\xd2\x6d\x45\x05\x39\x28\x8b\xc3\xa1\x6b\xba\x02\x4f\x81\x92\x10\x8a\x91\xbc\xb3\x57\xaa\x50\x69\x93\x59\x87\x20\xd8\x62\x94\x87\x39\x7a\xb6\x04\x1f\x54\xc0\xd5\x29\x19\xfd\xb3\xe4\x83\xdc\x2c\x39\x25\x6c\x9e\x5d\x99\xee\xcd\x52\xa3\x61\x09\xd8\xb2\x6c\x6a\x66\x68\x0a\xcb\xf5\x2e\x6f\x77\x69\xcd\x23\x8a\xfd\xa0\x9c\x56\x26\x50\xca\x18\xa3\x6e\xaa\x19\x45\xd4\xdd\xcf\x09\x95\xc4\x27\x5b\xf4\x96\x3b\xe6\x2f\x09\x29\x3d\x48\xc9\x72\x8f\x01\x14\xa7\x6a\xb6\x57\x10\x12\xe0\xa5\x4b\x32\x48\xc6\x00\x3d\xdc\x38\x20\x4a\x4e\x91\x03\x00\x45\x6e\x82\x05\xa4\xca\xcd\x2a\x28\x05\x22\x83\x7f\xd4\x1e\x17\x07\x28\x22\xa3\x98\x9f\xa3\x1b\x71\x44\xb5\xe9\x90\x48\xd9\xe9\x56\xe7\x39\x1a\x78\xa9\x0d\x6f\xf7\xe2\x51\x85\x6c\xcb\xff\xdc\x20\x05\xe7\xb2\xf4\xaf\x04\x0a\x88\xfd\xce\x30\xc0\x9c\x07\xca\x54\x4b\x9d\x69\x4a\x75\x95\xbf\x97\xf0\x63\xd7\xec\xdf\x0e\xde\xdf\xd4\x66\x47\x2a\x4b\xff\xc9\xa8\xd1\x74\xb7\x25\xfe\x6c\xd1\xc3\x96\xe4\xfa\xaa\xa8\xb2\x1d\x44\x31\x5a\xbf\x66\x0f\x54\x3b\xc7\x2e\x08\x07\x62\x8d\x65\x94\xca\xe9\x07\x5d\xe2\x06\x73\xce\xb9\xa4\x9e\x26\x39\xe2\x30\x54\x70\x99\xb9\x7d\x6f\xcc\x4b\x75\x9b\xfd\x2e\x52\x7a\x18\xbd\x26\x3f\x41\xae\x29\xe6\x99\x03\x92\xeb\x3d\x28\xb3\xe7\x57\xb3\x2b\x7b\xfb\xee\xf3\x97\x77\xd7\x57\x77\xef\xde\xc2\xb2\xb7\x5c\x2e\x91\x53\xc2\x50\x56\x5b\x15\x55\x96\x64\x36\x8a\xec\x3a\xc5\x23\x6d\xe0\xe1\xcd\xea\xcd\x5f\x56\x87\x4e\x69\xaa\x53\xc1\xff\x93\xec\x70\xf8\x8f\x03\x63\xfd\x1c\xb3\xc8\x49\xdb\x89\x9d\x03\x82\xc2\xf8\x0d\xb3\xd4\xb5\x3c\xbc\xb4\x89\x05\xcf\x06\x26\xb7\x09\x16\xa1\x10\x29\x75\xac\x44\x4b\xa4\x43\xe7\x43\x5a\xe5\x04\xc5\x9e\x0b\x89\xdc\x48\x85\x10\x28\x94\x2e\x69\xe1\x0e\x7d\x5d\x86\x4e\xcd\x00\xe7\x4d\x9f\x2e\x69\xa6\x34\xb8\x8a\xeb\xac\x96\x2d\x3d\xc5\xbd\x31\xdb\x24\x5c\xd3\x31\x86\x51\xca\xf4\x7c\xdc\x2b\x91\x54\x65\x99\x4c\x70\x18\xbc\x26\x31\xf2\x31\xd9\xca\x65\x46\xe0\x70\x7b\xf5\x84\xdc\xed\x5c\xa4\x9c\x94\xc5\xca\x7c\x6d\x53\x0e\x4a\x43\x9a\x1d\x4e\xc9\x45\xae\xae\x9b\x9c\xbc\x6d\x06\xec\xcb\x95\x50\xdd\xf8\x3e\x96\xbc\xf0\xd1\x7f\x4d\x36\x74\xba\xff\x1e\xa6\x12\xf2\x4e\x52\x98\xa3\x86\x71\x53\xf4\x55\x4b\xe0\x18\x71\xf0\x27\xa5\xcb\xda\x61\x82\xb2\x33\x79\x14\xa4\xfa\xc8\x1a\xa1\x42\xe7\xb5\x8f\xf5\x40\x1f\xac\x53\x1b\x4c\xea\x66\x52\x1e\x49\xe9\x96\xaf\x9d\x74\x2f\x28\xe4\x8d\x7a\x1c\xe0\x5e\x8f\xf4\x0e\x38\x13\x8b\xbe\xba\x9b\xea\x8d\x09\xe5\x98\x4e\x8d\xb7\xf8\x27\x39\xf4\xd4\x76\xff\xa4\x9a\xf4\xc7\x00\x9e\xda\xfa\x9f\x24\x3b\x3a\x12\xf0\x94\x31\x80\x49\xca\x7f\xe0\x78\x40\xf7\x3a\x6a\x4e\x99\xcd\x27\x5d\x42\x4f\x74\xb7\xf5\x66\x23\xc5\xef\x7f\xbf\xbb\xfb\x9c\x72\x10\x7a\xbc\x6d\x7e\x10\xbc\xac\xfd\x02\x5e\x83\x1e\xe2\xd0\x74\xc5\xb2\xd4\x94\x0b\xe8\x20\xcd\x1f\x7f\x98\xdd\xd5\x18\xe2\x6c\x97\x1e\x94\x2e\x27\x1d\x61\x6f\x67\xef\xbe\x05\x34\x94\xa8\xe6\x2a\x28\x50\xde\xdb\x4c\x33\x38\x6e\xcc\xd7\x71\x46\xb5\x92\x82\xcc\x8c\x4e\x72\xde\x45\x9a\x21\xba\x0d\x3a\x78\xb0\x8f\x86\xdb\xe6\xf2\x06\x59\xd6\x01\x04\x9d\xa4\xd8\x54\x22\x52\x8c\xe1\x15\x36\x29\xff\x68\xb3\x31\xb3\x84\x92\x87\xb8\xb8\xe1\x9d\x65\xec\x11\xed\x0c\xbf\x65\x58\xc5\x72\x91\x2c\xba\xc9\x09\xe2\x76\x88\xd7\x53\xb2\x3a\x1e\x71\x00\x32\x55\xfb\xb9\xff\x8f\x74\xcd\xaf\xf9\x11\xf1\xc5\xa0\x4d\x56\xd6\x39\x7a\xd8\x91\xe5\x44\x06\x76\xa4\x34\x43\x18\x5a\x09\xde\xb2\x66\xc6\xcc\xb8\x10\x6f\xbc\x82\x8f\x36\x70\xbc\xed\xfe\x97\xb1\xe0\x2c\xd1\x58\xd8\x88\x6b\xc1\x3c\x6e\x71\x3a\xa6\xcd\x46\xed\x0e\xd5\xa3\xbc\x94\x8b\xd5\xe6\xd8\x4d\x87\x09\xd6\xdd\x36\x15\x9e\x62\x50\xef\x8f\x79\x50\x22\xc2\xdb\x98\xe7\xa7\x5c\x6c\xeb\xe8\x9c\x75\x0b\x02\x38\x14\x71\x59\x6b\x48\xdd\xff\xe3\xf6\xd3\x47\xf0\xe8\x18\x0f\xa8\xa9\xb0\x72\x78\x7d\x68\x05\x0d\x39\x09\xc5\xe4\x50\x59\x1f\x0a\xfd\x0d\xd2\x84\x06\xbb\x19\xc3\x2e\xe8\x04\x8a\x2a\x88\xfb\x24\x9f\x7b\x45\x8a\x24\x58\xfa\x37\x74\x76\xa9\x4d\x8e\xdf\x28\xbb\x82\x9f\x88\x23\xc7\x25\x1e\x49\x56\x15\x2a\x27\x7a\xc8\xd5\x33\x6e\x8b\x69\xce\x60\x44\x57\x6d\x11\x75\x01\xf2\x91\xe2\xd8\x08\x23\xad\xc8\xc4\x53\x5e\x45\x11\x7c\x57\x97\x41\x57\x25\x0a\x77\x29\x5b\x89\x1e\x80\xd3\x84\x77\xd2\x29\x3a\xaa\x20\x74\x7d\x05\xf8\x7a\x46\x92\xf9\x7a\x06\xcb\xd8\x92\x23\xe9\x37\x3f\xc6\x5a\x57\xcc\x95\x4e\xa0\xd8\x28\x0c\x51\x66\x85\xfe\xef\xd7\xff\xb3\x9a\x79\xc5\x09\x34\xe3\x22\x0a\xed\x7c\x88\x3c\x8c\xe5\x6e\x93\x5e\xf2\xf5\xec\x38\xa1\xa3\x51\xae\xbd\x76\xe8\xbd\xda\xcc\xa0\xe0\x74\x1d\xd4\x62\xb6\xf5\x4e\x99\xa5\x43\x95\x73\x23\xb5\xf3\xdf\x66\xbe\x87\x24\x7f\xca\x9e\xe5\x76\x96\xf0\x0a\xba\x91\x20\x56\x37\xdb\x59\x0d\xe5\x97\x33\xd1\xa1\xb3\x7f\xcb\x73\x5b\x2a\x47\x77\xdc\xda\x9e\xc0\x2c\x09\x01\x4f\xe6\xd5\x4e\x65\x5b\x6d\x70\x8e\x5b\x27\x6c\x8a\xf9\x79\xc0\xad\x54\x8e\x95\xaa\x6d\xca\xbf\xe9\x0e\x77\x0a\x49\x0e\x98\x8c\xbe\x08\x63\xd0\x6a\xd4\x83\xd2\x25\xad\xf1\x19\xf9\x76\x24\xd1\xe8\xdf\x36\x9e\x70\xa4\x4b\x66\x83\x9f\x12\x3b\xf9\x89\xd6\xfb\x0d\xbc\xfd\x53\x03\xa7\x40\xba\x5e\x84\x9c\x63\xd5\x49\x4c\x3a\x1c\x55\x9d\xdd\xd4\x39\xed\x8a\x9e\xf8\x27\x6f\x0a\x3e\x19\xa9\x2b\xb6\xe3\x56\x02\xe5\xb8\x83\x32\x4b\xb7\xd3\xc9\x4b\x03\x22\xcd\xd2\x7e\xd6\x26\xff\x83\xc6\x55\xbf\x4b\x16\xf3\x25\x81\xa9\x91\xc6\x7f\xaa\x28\xe0\x65\x1c\xb3\x43\x87\x71\x66\x59\x9b\x4d\x89\xd3\xa9\x7d\x43\x95\xcb\xc4\x94\xdf\xae\x93\xd3\x59\x63\xfe\xea\x77\x2b\x2c\x37\x31\xb8\x03\x31\x31\x25\x36\xc9\xb1\x9b\xa2\xed\x45\x2c\xba\x4d\x8f\x66\x82\xac\xed\x11\xcf\x6e\xad\xd1\xca\xce\x7c\xac\x4c\xdc\xe6\x2b\xb8\x25\xbd\x15\xc8\x10\xe7\xb0\xa5\xa7\x32\xef\xa6\xda\x5e\x0d\x97\xea\x82\xba\x8f\xb5\x46\xce\x76\x03\x82\xca\xf8\x85\xcb\x98\xe0\x59\x9f\x5e\x72\x84\x6e\x2f\xa0\xa5\xb5\xc0\xd6\x3e\xca\x88\x50\xb0\xf0\xa8\x74\x68\x76\xae\xee\x8f\x7a\xd4\x2d\x0e\x96\x35\x27\xd4\x53\x72\x48\x38\x29\x8f\xa4\xab\xd6\x4f\xf0\x56\xbf\xdc\xbc\x3d\xb4\x89\xd5\x94\x42\xcf\xee\xb9\x1d\x69\x9b\x50\xea\x27\x0f\x3b\xb7\xc3\x03\xfe\x4f\xb5\xfe\xdd\xbe\xe3\x68\x98\x9b\x73\xf3\xcf\x70\x3a\x61\x3a\xc3\xed\xd4\x91\xbf\xe7\xa4\xc2\x0c\xe1\xb6\xbb\xf9\x3d\xa7\x16\x26\x09\xff\xe1\xe1\xe1\xa8\x78\x8f\xc0\xe4\x27\x83\xe3\xe8\xe6\x8f\x95\xf5\x1a\x2f\x37\xc5\xab\x13\x16\x3e\x3c\x9e\x31\xb9\xf2\xf3\xdb\xa0\x4c\xae\x5c\x2e\x6d\x8c\xf6\x78\xc2\x1f\x2e\x90\x93\x2a\x29\x96\x2c\xa1\x3e\x3d\x5c\xa7\x07\xba\x87\x38\x74\xd1\x4c\xae\xca\x80\x3f\x94\x7a\xa7\xe7\xf3\xbf\x98\xa5\x99\x66\xfa\x99\x13\xb3\xa6\x0e\x15\x27\x60\xa3\x9f\x8f\x6d\x82\x63\xf1\x2c\x8e\x42\x6c\x55\x2a\xec\x70\xed\xad\x41\xe3\x0c\x35\x1a\x94\x6f\x2b\xf5\x6b\x8d\xa3\x83\x7f\xdd\x2b\x6e\x33\x9d\x95\xd0\xde\xf3\x43\x36\x0e\x4d\xc4\x91\x4a\x7b\x78\x2c\x49\xcd\xef\x5e\x8e\xa0\x74\xfa\x8e\xc1\x36\x67\x5d\x84\x2f\xf8\xad\xe9\x35\x36\x3b\x98\x67\x68\xea\x89\x5e\x8b\x84\xa4\x9f\xcf\x6d\x23\x1f\xc8\xbd\x88\x3a\xb6\x1d\xc5\xca\xfa\xf1\xb9\xdf\xee\x15\x45\x1b\x39\x9b\x59\x53\xe8\x4d\x1d\x41\x03\xd7\x77\xb6\xca\x6c\x64\x56\xa4\xad\x61\xa8\x79\x64\x8b\x8f\xb0\xd3\x86\xcf\xfa\x71\xef\xbb\x9d\x13\x6a\xe3\x5b\x2a\xe8\x4b\xcc\x4f\x5a\x71\x04\xa8\xa1\x81\xda\x8b\x5f\x97\x8e\x99\x68\x6a\x67\xf4\x68\x8d\x71\xdc\x2d\x6b\x66\x50\x67\x69\x46\x6d\xe9\x56\x14\x62\xa3\x0a\x17\x50\x9b\x12\xbd\x87\xbd\xad\x65\x1f\x0e\x33\xd4\x63\x27\x8b\xba\x97\xcc\x73\xda\x7b\x34\x12\x24\x94\x11\xfc\x93\xbc\xe3\x33\xe0\xca\x1e\x07\x4f\x47\x19\xb7\xa1\x6d\xf8\x34\x61\xdd\x77\xc4\x7f\x7e\xee\x9b\xb6\xc5\x3c\xd7\xa2\xf0\xd2\xf9\xca\x74\x7e\x81\x28\x47\xcc\x91\xc6\xdf\x52\xff\x68\x64\x9c\xaa\xbf\xd2\x34\xb5\xca\x52\x8e\xba\x2e\x6c\x8f\x2a\xb8\x82\xbf\xcb\x88\x76\x9c\x96\x0c\xd2\xf5\x9f\x25\xab\x1a\x37\xd0\x59\x0a\xd7\x09\x59\x25\xa1\x36\x4d\xdb\x7d\xad\xb2\xfb\x53\x34\x26\xcd\x79\x9d\x72\xc0\xa5\x8d\x08\xb3\x24\x9f\x21\x5a\x64\xd6\x48\x51\x2e\xdb\x2f\xe3\x08\xcc\x52\x99\x7c\xd9\xb8\x87\x6c\xff\xbb\xb3\x3e\x8f\x65\xf1\x5e\x9b\xfb\x93\x35\x2e\x3d\x20\x28\xed\x97\x2f\xef\x0f\xc1\xd9\x09\xad\x5d\x38\xed\x2c\xd1\x3f\x19\x95\xce\xd7\xb4\x9e\x58\xc9\x7a\xdc\xc6\xc1\x90\x06\xb8\x4c\xae\x5e\x37\x63\xf3\x67\xb1\x1b\x7c\x16\x51\xd1\x7c\x59\x6b\xae\x3f\x34\x59\xcc\x82\xab\x34\x05\x98\x95\xca\x89\x73\x50\x46\x3a\x77\xf2\xd2\x19\x94\x91\x23\xac\xeb\x00\xb9\x45\xe9\x2f\xd9\x07\x74\x4e\xe7\x08\x7a\x52\xb8\x47\x05\x23\x2f\x3d\x19\x94\x35\x58\xb1\x53\x8e\x59\xc1\x27\x83\x60\x8b\x4b\x38\xbb\xad\xb3\x0c\xbd\x3f\x1b\x1b\xd7\x49\x57\xc3\xe5\xe7\x46\x73\x94\xcf\xb3\x41\xca\x9e\xbe\x13\x62\xcf\xe8\xe9\xd4\x84\xc3\x72\x62\xf6\x65\x92\x54\xa9\xd6\x38\xec\x81\x3e\xf3\xc9\xe3\x0f\x8a\x47\xc3\x63\xe2\x76\x8f\x7b\xf1\xca\xd2\xef\x1e\xc6\x91\x60\xc1\xba\x8d\x32\xfa\xb7\x91\x83\xc2\x26\x07\x82\x90\x1b\xeb\xf4\x6f\x08\x2f\xf9\x73\x06\x72\x26\x18\x4b\xcc\xc2\xab\xce\x41\x5f\xb5\x87\x1d\x8f\xb0\xc9\xbf\xac\xf3\x63\xb3\x8f\x0e\xab\x92\xc7\x5c\xc9\x12\x9a\x71\x42\x1f\x69\xba\x07\x9d\x8d\xf4\xe4\x8f\x26\xd2\xc2\xd7\x93\x0f\x0c\xef\x94\x51\x1b\xcc\xa5\xd7\x34\x3f\x06\xf9\xa1\x7b\x2b\xec\x54\xe5\xe1\xd1\xba\xfb\xa2\xb4\x8f\x4b\x2d\xa3\x5f\x29\x60\x47\x1c\x3b\x76\xb0\xd4\x16\xa9\xad\x24\xe7\x87\x1c\xa6\x35\x88\xd7\x55\xa1\xa1\x1a\x3b\xd1\x9a\x50\xb8\x0f\xe5\x3e\xce\xf3\x4c\x00\x87\xad\xad\x3d\xde\x23\x56\xda\x6c\x04\xf5\xcb\xf4\x5c\xd8\x57\x84\xd2\xca\x7d\x2c\x4e\x99\xf3\x00\x26\xf6\xa3\xe3\xc9\xab\xda\xe4\xe8\x7c\x18\x83\xf0\x6d\xc1\x88\xfc\x56\x5a\x59\xd2\x9a\x94\xad\x9c\x4b\xa3\x71\xd1\x1b\x0c\x4d\x3f\x0e\x59\xe0\xda\xd9\x76\x82\xe5\xed\xb0\xac\xaa\xaa\x72\x0f\x95\x0a\x5b\x28\xf5\x3d\xc2\xd7\xb3\x4c\x2f\xb3\xfc\xeb\x99\x80\xda\x88\xe3\x85\x7f\x03\xb2\x7c\xa6\xf2\x51\xed\x1b\x5f\xde\x48\x23\xe6\x3c\xed\xf2\x59\xdb\x0f\xce\xa9\x8f\x01\x92\x34\xb4\xf2\xd5\x1c\xce\xa5\xf2\xcc\x9f\xd8\x04\x73\xa2\x83\xdf\xd3\x9c\xdf\xa3\x0e\xdb\xb1\xe9\x6e\x63\x83\xce\x70\x30\xfd\x37\xd1\x86\x9e\x4f\x3e\x8f\x8d\xf8\xf4\x43\xe6\xec\x7c\x4f\xe7\x2b\x1e\x9d\xe6\xf3\x94\x03\x6d\xb9\xc1\x89\x2a\x8f\x7b\xa7\x53\xf2\x18\x6b\x7c\xc4\xa8\x33\xee\x79\x5c\xc4\x77\x9c\xc1\x3f\x6a\x3f\x45\x93\x25\xce\x55\x58\x5b\x2d\x4b\xf2\xf0\xdd\x15\x47\x1d\x8c\xc7\xb8\x91\x42\x8c\x72\x7b\xb6\x34\xa7\xb2\xe1\xe9\xb0\xb4\xce\xde\xfe\x54\x67\xcd\x6b\x94\x26\x96\x66\x1f\x18\x73\xb9\x78\xd6\x4b\x0c\x66\x82\x66\x1c\x59\x1a\x9b\x5f\x87\xe3\xb1\xa5\x18\xf5\x34\x72\x8d\x7a\x7f\x08\x6e\xa2\x5f\xdd\x13\x6e\x74\x4b\x9d\x84\x43\xf5\xed\x65\x6e\xb5\x93\x90\x4c\x5c\x93\x3b\x41\xb9\xc4\x3b\xba\xe1\x59\xa8\x08\x15\x1a\xdb\x63\x92\x33\x18\x71\x8b\x1e\x4f\x58\xf2\x24\x83\x1b\x4c\x72\xc2\xa2\x3f\x35\x85\xfb\xf8\x41\x1d\xa2\x4d\x2b\x6e\x2b\xfa\x52\xe1\x2d\x51\x8d\x1e\x55\x49\x6b\xd6\x1e\x7a\xe1\xe1\x1d\x37\xca\xd7\x48\x8e\xa5\xf9\x04\x01\x59\x06\x1f\x88\xe0\x13\x36\x31\x0a\x4f\x90\x6c\xc6\xb6\xe2\x5c\xb1\x43\x38\xbf\x22\xef\x78\xce\x5e\xe7\xfc\x17\x2e\x62\x9e\x7f\x17\x87\x82\x9e\x6a\x2b\xf5\x1b\x4a\x5a\xce\x6c\x84\xee\x29\xb3\x54\x2c\x6f\x64\x04\x8f\x84\x83\x67\x66\xc6\x6e\x9a\xe3\x26\xd1\x3b\x37\x27\xf0\x74\xd1\x17\x40\xdc\xe0\x28\x9d\x63\x67\x03\x4f\xd8\xf8\x8c\xaa\x4f\xb5\x7b\xc7\x1a\x70\x7d\x84\xc5\x07\x5b\x52\xaa\x1c\x8f\xea\x90\xe3\xd7\x06\x54\xfb\x9d\x8f\x15\xdc\xf8\xf6\xc8\xd3\xe8\x37\x02\xe4\x18\x84\x0c\x40\xcb\xd8\xe0\xa2\x3d\xe1\xcc\xbd\xcf\xf6\x93\x22\x3b\xb5\x97\x8f\x1b\x34\xc7\xd5\xc7\x74\xb3\x3d\xa7\x8c\xfd\x53\x28\xdc\x48\xaa\x28\xb0\x38\xad\x42\xea\x1a\x76\x3d\xdf\x6a\xfc\xb0\x97\xf6\x50\x39\xbd\x53\x4e\xf3\x51\x88\x38\x37\x47\xaa\xda\x1c\xe2\x68\xcf\xdc\x08\x38\xec\x57\xba\xf2\xe6\x1b\x5d\x43\x6d\x19\x29\xd0\xff\x9e\x26\x0a\xf3\x7e\x1c\x06\x8e\xe8\x47\x23\xa9\x79\x08\xf8\xb1\xf9\x70\x4b\x37\x80\xca\x2f\x51\xea\xa8\xb2\xad\x70\xb4\xaf\x15\xc3\x0d\x5f\x99\x68\x07\x9d\xcf\xc1\x78\x20\x25\x79\x50\xa5\xc8\x94\xc9\x7f\x3d\xcb\xb1\x50\x75\x19\xbe\x9e\xb5\xb7\x2e\x28\x0d\x1c\x90\xec\xde\x1a\x3d\x5a\xa6\x8c\x35\x5c\xa6\xeb\x8f\xe5\xb6\x03\x76\xa9\x08\x44\x3e\x26\xe9\xe8\xd0\x78\xe4\x4b\x29\x04\xfa\x73\x19\x69\x69\x57\xbd\xec\x1c\xd6\xb3\xbd\x33\x79\x6d\x6f\x32\xbe\x64\x40\x37\x55\x13\xe3\x97\x0a\xbe\x9a\xe6\x94\xae\x82\xb7\x1f\x6f\xff\xf7\xfd\xd5\xbf\xbd\x7b\x3f\xda\xbd\x99\x29\xfa\x9c\xa4\x2c\xcd\xfa\xfd\xc9\x87\xc3\xec\xa3\x41\xf7\x05\xf9\xd0\x66\x36\x04\x64\x3d\x5d\x79\x1f\xcf\x5e\x24\xee\xe6\x58\x89\xb9\xac\xf7\x83\x33\x49\x57\xef\xdf\x4f\x32\x28\x62\x59\x2e\x3a\x73\x99\x8e\x8f\x24\x35\xf3\xe5\xbd\xef\xdd\x44\x5e\x6e\x94\x5b\xab\x0d\x42\x46\x30\x3c\x1b\x05\x2a\x37\xc5\xe1\x89\x8e\x4e\x12\xd2\x05\xf1\x0b\x99\x67\x57\xa6\x9d\xfd\x6a\x8a\xed\xe3\xc2\x8c\x95\x7b\xdb\x16\x8f\x13\xa5\x66\xae\xa0\x73\x78\xac\xc5\x63\x8c\xe4\xc6\xec\xe4\x8e\x2b\x2d\x2d\x46\xeb\xce\xf8\x61\x03\x27\x3a\x44\x4f\x3c\xba\xfc\xbc\xc8\xba\x0f\xa3\xc9\x92\xe4\x6c\xef\x77\x45\x68\xfe\xca\xc6\x27\xd2\xb6\xf4\x19\x96\x13\x16\x41\x32\x75\x35\x2e\xe0\xea\xe3\xdb\xd4\x6f\x60\x8d\x6d\x8e\xf7\x9e\x15\xd6\x21\x01\x72\x93\x27\xba\x53\xf3\x7b\xcd\x91\xfa\xa8\x00\x2d\xb1\x56\x10\x83\xc3\xf2\xf7\xb8\x5f\xb2\x1b\x98\x20\x2a\xdf\x23\xe3\x2f\x2f\xa4\x54\x23\xda\x52\xe7\x44\xd0\x0a\xde\x8a\x0f\xe3\x49\xff\x42\x95\x1e\x57\x70\x37\x05\xbd\x9a\x6f\x2a\xa5\x83\xc8\xd2\x3d\xa3\x04\xd7\xc3\x99\xac\xf0\x0c\x2a\x74\x3b\xed\xbb\xe2\xe1\xbd\x0c\x53\x53\xb9\x6c\x3a\xd8\x07\x7f\xfe\xe1\x07\x78\xf9\x8b\x89\x87\x6c\xb8\xca\xf8\xce\x04\x1d\xf6\xaf\x3a\xdf\x04\x92\x9e\xca\x9c\xa0\xd7\xd6\x96\xa8\xc6\xea\x8f\xad\xd6\x3e\x45\xc2\x07\xcc\x63\x93\x6b\x0e\x46\x9c\x60\x11\xa7\xad\x6d\x7a\x46\x60\x64\x42\xe0\x50\xed\xff\xe8\x36\xed\x11\x8b\x9a\x1e\xa5\x1a\xc1\x73\xc7\xf6\xf2\xfb\x81\xc8\x49\x6b\x9e\x9c\x6d\x99\x99\x6a\x79\x8e\x15\x4f\xcf\x9f\xcc\x2e\x78\xfa\xf0\xd7\xb2\xe3\x4d\x47\xfe\x49\x52\x1d\xf9\x79\x74\xa2\x6c\x49\x5c\x79\x0e\x68\x7f\xa4\xbd\x37\x38\x01\x1d\xfb\x5b\x82\x72\xb8\xa2\xd4\x8e\xaf\xc4\x53\x8a\xe9\x20\x52\x13\x08\xc6\xab\x69\x27\x75\xf1\x26\x3a\x75\x43\xa8\xd3\xeb\xdc\x7d\xe8\x34\xd9\x09\x7b\xd9\x2a\xe8\x9d\xf6\x41\x67\xd0\xe9\x5c\x2d\xe2\x03\xfc\x0e\x9e\xd7\x9a\xfe\x60\x80\x1c\x45\x6e\xd3\x61\x6b\xba\x5f\xa1\xb4\x2e\xd5\x18\x9a\xe4\xa4\xf9\x0c\xde\x80\xa4\x0c\xb2\x51\xa2\x10\x13\xc8\x58\x86\x56\xdd\x19\x82\x27\x76\x0c\x53\x97\x90\x3f\x59\xb9\xeb\x7c\x47\x4d\x52\x6c\xe2\x81\x92\x0f\x05\x65\x75\xa9\xdc\xc8\xca\x47\xd4\xb8\xd9\xc9\xf4\x37\x75\x7a\xed\xc7\xd3\xfa\xa5\x93\x3d\xd2\xe7\x76\x95\x27\xf4\x28\x4f\x46\xbc\x53\xbd\xc8\xfe\xe9\xb3\xd3\xfb\x8f\x3d\x7e\x8e\x98\xc7\x09\x3d\xc7\xc9\xb5\x8e\xb8\xcb\xbe\x15\x93\xa3\x8c\x59\x51\xcc\xd4\xb5\x89\x1f\xce\x30\x79\xcc\xe2\xc4\xbe\x0f\xbe\x18\x38\x82\x9f\x19\x33\xb7\xa5\xf5\xf6\xbb\x22\xfd\x2f\xd8\x59\x03\x5e\xfa\x61\x45\x5d\xb6\x59\xf2\x88\xda\x75\xac\xaa\xf3\xcd\xba\xf4\x09\xc3\x60\x93\xcd\x5a\x03\x9f\x7f\xb9\xeb\x7d\x77\xb2\xab\xa6\x03\xba\xa7\x74\xcd\xbf\x2f\x44\x9c\xa8\x44\xa3\xbe\x79\x87\x7e\x7b\x39\xb8\xe9\xe0\xd9\xf4\x11\xee\x19\x4a\x07\x3f\x45\xd7\xcb\x80\x7e\x19\x3f\xf0\xfd\xf0\x86\x8b\xf5\x6f\xf8\x09\x99\x17\xea\xd4\x54\xe3\xc9\xdd\xf8\xcb\xff\x05\x00\x00\xff\xff\xa7\x1a\xe9\x4c\x86\x5c\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3c\x6b\x73\xdb\x48\x72\xdf\xf9\x2b\xba\x78\x1f\x64\x57\x91\xb4\xbd\x7b\x97\xca\xe9\x9b\x62\x7b\x2f\xca\xf9\x55\x96\x7d\xa9\x54\x94\x4a\x0d\x81\x06\x39\x27\x60\x06\x3b\x33\x90\xcc\xfd\xf5\xa9\xee\x79\x00\x24\x1e\x84\x6c\xdd\x5e\x48\x7f\xb0\x40\xa0\xa7\xa7\xdf\xaf\xc1\x62\xbd\x5e\x2f\x44\x2d\xff\x86\xc6\x4a\xad\x2e\x41\xd4\x12\xbf\x39\x54\xf4\x97\xdd\xdc\xfd\xab\xdd\x48\xfd\xe2\xfe\xd5\x16\x9d\x78\xb5\xb8\x93\x2a\xbf\x84\xd7\x8d\x75\xba\xfa\x8c\x56\x37\x26\xc3\x37\x58\x48\x25\x9d\xd4\x6a\x51\xa1\x13\xb9\x70\xe2\x72\x01\x90\x19\x14\x74\xf1\x8b\xac\xd0\x3a\x51\xd5\x97\xa0\x9a\xb2\x5c\x00\x28\x51\xe1\x25\x38\x23\x8a\x42\x66\x46\x37\x0e\xed\xe6\xae\xa9\xc4\x46\xea\x85\xad\x31\xa3\xa7\x77\x46\x37\xf5\x25\xc4\xcb\xfe\x21\x4b\xbf\x00\x78\x24\xbe\xf8\xe7\x3f\xd3\xf3\x7c\xb9\x2e\x1b\x23\xca\x13\xc0\x0b\x00\x9b\xe9\x1a\x2f\x61\xb9\x5c\x00\xdc\x8b\x52\xe6\x8c\x96\x07\xa5\x6b\x54\x57\x9f\xae\xff\xf6\xf3\x4d\xb6\xc7\x8a\xf1\xa6\xcb\x39\xda\xcc\xc8\x9a\xef\x3b\x5a\x08\xa4\x05\xb7\x47\xf0\xb7\x43\xa1\x0d\xff\x79\xb4\x24\x5c\x7d\xba\x0e\x70\x6a\xa3\x6b\x34\x4e\x46\xcc\xe9\xdb\xa1\x75\xba\x76\xb2\xe2\x05\xa1\xe4\xef\x81\x9c\xa8\x8b\x7e\xd5\x7b\x7f\x0d\x73\xb0\x7e\x7d\x5d\x80\xdb\x4b\x0b\x06\x6b\x83\x16\x95\xe3\xad\x75\xc0\x02\xe8\x02\x84\x02\xbd\xfd\x3b\x66\x6e\x03\x37\x68\x08\x08\xd8\xbd\x6e\xca\x1c\x32\xad\xee\xd1\x38\x30\x98\xe9\x9d\x92\xbf\x25\xc8\x16\x9c\xe6\x25\x4b\xe1\xd0\xba\x23\x88\x52\x39\x34\x4a\x94\x44\xcc\x06\x57\x20\x54\x0e\x95\x38\x80\x41\x5a\x03\x1a\xd5\x81\xc6\xb7\xd8\x0d\xbc\xd7\x06\x41\xaa\x42\x5f\xc2\xde\xb9\xda\x5e\xbe\x78\xb1\x93\x2e\x4a\x57\xa6\xab\xaa\x51\xd2\x1d\x5e\x64\x5a\x39\x23\xb7\x8d\xd3\xc6\xbe\xc8\xf1\x1e\xcb\x17\xa2\x96\x6b\xc6\x53\xd1\xde\xec\xa6\xca\xff\x60\x82\xe4\xd9\x8b\x0e\x62\xee\x40\x5c\xb6\xce\x48\xb5\x4b\x97\x59\x54\x46\xc9\xfc\x57\xa9\x72\xe2\xa8\x08\x8f\xf9\x1d\xb5\xd4\xa4\x4b\x44\x84\xcf\x6f\x6f\xbe\x40\x5c\x94\x29\xde\x01\x09\x81\xb8\xed\x63\xb6\xa5\x33\xd1\x45\xaa\x02\x49\x4c\xa4\x85\xc2\xe8\x8a\xc9\x8a\x2a\xaf\xb5\x54\x8e\xff\xc8\x4a\x89\xea\x98\xc6\xb6\xd9\x56\xd2\x11\x63\x7f\x6d\xd0\x3a\x62\xc7\x06\x5e\x0b\xa5\xb4\x83\x2d\x42\x53\xe7\xc2\x61\xbe\x81\x6b\x05\xaf\x45\x85\xe5\x6b\x61\xf1\xa9\xa9\x4c\x04\xb5\x6b\xa2\xe0\x79\x3a\x77\x15\x1f\x60\x5c\xf8\xe9\xcb\xbb\x60\x41\x3d\xf9\x01\x40\xe4\x39\x1b\x12\x51\x7e\x1a\x79\x78\x14\x83\x41\x35\x6a\x57\x62\x36\x2b\x68\x94\x75\xa6\xc9\x5c\x63\x30\x87\x3b\x3c\x04\x8e\x57\xa2\x06\xeb\x34\x5d\x7c\x90\x6e\xdf\x5b\x51\x74\xb9\x2f\x1c\x8b\xfb\x16\xc1\xa2\x83\xed\x01\xc8\x5c\xb2\x42\x38\xad\x4b\x62\x95\x87\xc5\x8a\x61\xd0\x19\x89\xf7\xd8\x07\x69\xb6\xd2\x19\x61\x0e\x89\x76\x1b\xf8\xb2\xc7\x03\x08\x83\x40\x6c\xfe\xb5\x41\x73\x10\xdb\xd2\xc3\x09\x0a\xbb\x45\x60\x4d\x37\xf7\x98\xf7\x40\x3e\xec\x51\x41\xa5\x73\x59\x1c\x48\x72\xbd\x58\xf6\x95\xef\xf2\xc5\x8b\xbb\x66\x8b\x46\xa1\x43\x36\xee\xb9\xce\xec\x8b\xc6\xa2\x59\xef\x1a\x99\xe3\x8b\x0e\x83\x2e\x16\x43\xa4\xf7\x90\x8f\x7e\xca\xca\xc6\x3a\x34\x1f\xc8\xb4\x4f\xf1\xe4\xcb\x1e\xd9\x94\x93\x5d\xf2\xb2\xcf\xcf\xc1\xc3\x5e\x66\x7b\xd6\x86\xa0\x4d\x5b\x2c\xb5\xda\x11\x35\x89\x2e\x27\x1a\x47\xff\xa4\x85\xc6\x62\x4e\xe4\xce\xa5\x75\x52\xed\x1a\x69\xf7\x89\x51\x96\x39\x09\x96\xd6\xe2\x05\x89\x8a\xf4\x1f\x5b\x8b\x8c\xc8\x01\xb9\x2c\x0a\x34\xa7\x9a\xd7\xd9\x8c\xf5\x2b\x43\x21\xb1\x64\x3b\x41\x6c\x21\x9e\x0b\x75\x78\xd8\xa3\x41\x30\x72\xb7\x77\xa0\xf4\x03\xf3\x48\xd4\xd2\xb2\xde\xc3\x00\xba\x3b\x4d\x3c\x71\x1a\xe4\x4e\x31\x3f\x1c\xc8\x82\x25\x48\x2a\xef\x2b\x11\xb4\x09\x9a\x1d\xf5\x7e\xb3\x98\x29\xf9\x7d\x67\x3b\xc5\x84\xe5\xeb\xd3\xdb\x69\x77\x02\x5c\xfa\xb3\x67\x02\xfd\xc6\x4e\x80\x02\x3f\xe1\xe5\x8e\xed\x5b\xe0\xdd\x83\xb0\x61\x4b\x64\xa2\x5c\x24\xdd\xae\x11\x46\x28\x87\x9e\x69\x5e\x7f\x7a\x10\xa5\x82\xbd\xa8\x6b\x54\x76\xbd\xc5\x82\x28\xa5\x4d\x8e\x06\x44\x66\xb4\xb5\x60\xb1\x16\x86\x28\x44\xe6\x81\xf7\x60\x37\xf0\x9a\x0d\xa8\xb7\xb6\x4a\xf7\x61\x12\x95\x19\x3f\xd6\xf6\x88\x52\xda\x23\xe6\x24\x0e\x9f\x7f\x79\xfd\xf3\xcf\x3f\xff\x99\x7c\x7a\xc5\xec\x94\x96\x2e\x7f\xfd\xf2\x7a\x03\xb7\xaa\x07\xf3\x93\xae\x1b\x72\x8e\x39\x59\x00\x92\x5b\x7b\xb0\x0e\xab\x0d\x7c\x46\x91\xaf\xb5\x2a\x0f\x1b\xf8\xd0\x94\x25\xc1\x83\x52\x5a\x67\x9f\xda\x3e\x47\xbb\xb1\x3c\xc1\x8d\x36\x20\xdc\x25\x90\x8b\x58\x13\x83\xe6\x0a\x51\x8e\x25\x12\x45\xff\x62\x44\x86\x9f\xd0\x48\x9d\xdf\x60\xa6\x55\x6e\x27\xa5\xe9\x43\x53\x6d\xd1\x90\x42\x5b\x7f\x37\x88\xb2\xd4\x0f\x98\x87\xf0\xa8\x95\x0b\xa7\x61\x47\xb0\x8b\xa6\x2c\x0f\x27\x20\x01\x1c\x9a\x4a\x2a\xe2\x6d\x60\xbc\x74\xf0\x20\xcb\x92\x1c\x9e\xc1\x4a\xdf\x63\xde\x3a\xd0\x48\xed\x8f\xaa\x3c\x90\x1c\xb1\x10\xf6\x40\xc6\x1d\x1d\xcb\x79\x69\x35\x3d\xb2\x81\xf7\xe2\x00\xc4\x29\x5a\xc1\xee\xb5\x71\xa8\x30\xef\x72\x70\x84\xb2\x52\xb9\x7f\xf9\xe3\xc9\x6f\xde\x32\x52\x6c\xb4\x3b\xd1\x93\x1e\x12\xd3\xba\xf9\x66\x08\xe7\xcf\xbf\xbc\x06\x96\x4e\x62\x2a\x4b\x27\x31\x16\x84\x4b\x86\x73\xc0\xe4\x24\x9f\x15\xa9\xc8\x98\x60\x7e\x6a\xd6\x82\x1b\x6b\xd5\x9c\x89\x09\x22\x31\x6b\x94\xae\x20\x53\x88\xd2\x2a\x02\x79\x92\x55\xd4\x20\xd2\xfb\x5c\x1a\xcc\x9c\xe7\x93\x63\x8f\xb6\xed\x73\x5f\x84\x30\x88\x90\xc3\xd6\xdd\x4a\x0b\xf8\xad\xc6\xcc\x25\xa3\x11\x36\x01\xcf\x94\x06\x72\x11\x68\xe0\x5e\x5a\xb9\x2d\x4f\xe5\x1c\xbc\xb4\x24\x50\xac\x84\x1e\x31\xc2\xca\xa0\xc8\xf6\x01\x1b\x76\x49\xcf\x41\x14\xe4\x8a\x68\x0f\x4c\x5d\xd9\xd7\x7a\x97\x08\xb7\x02\xad\x38\x18\x44\x28\xa4\x12\xa5\xfc\x8d\xe2\x3d\x5a\x83\x88\x82\x55\xed\x0e\x1b\xb8\xb2\x8c\x22\x08\x7b\x72\x63\x0f\x30\x3f\x48\x7a\x2f\x24\x05\x2b\x0e\x2b\xbb\x3a\x22\xf3\xb6\xd4\xd9\x1d\xf1\xee\x63\x5c\x36\x3f\x15\x94\x1e\x50\xcf\xdb\x55\xc7\xf6\x45\x13\x49\x84\x6c\x14\x31\x5e\x9b\x60\x89\xa1\x68\x8c\xdb\x93\xf3\x52\x21\xf6\x2f\x1a\x8a\x93\x56\x3d\xb0\xa2\x74\x7b\xdd\xec\xf6\x20\xdb\x48\x28\x6a\x0f\x84\x8c\x28\x51\x3d\xdc\x10\xb9\x56\x1b\xa9\x07\xdc\x08\x2d\x48\xa9\x95\xac\x70\x03\xbf\x68\x03\xf8\x4d\x54\x75\x49\xd9\x05\x79\x79\x13\x12\x0c\x96\x34\x1f\x82\x09\xa8\x35\x4b\x58\x80\xdc\x83\x29\x15\xfc\xfc\x32\x9a\x24\x2f\x55\x7f\x6d\xb6\x74\xb3\xb7\x2a\xc4\x7f\x96\x7b\x8b\x2a\x27\xdf\xdc\xca\x7b\x32\x45\xa7\xc9\x14\x7d\xad\xdc\xf9\x58\x8f\x69\x14\x58\x46\xbc\x97\x8a\xaf\xd4\x3a\xdf\xc0\x55\x90\x24\xe1\x3a\x48\x10\x23\x12\x12\x3d\xb8\x8c\x14\xe1\x02\x02\xf6\xc2\xe4\x5d\x24\xe2\xa2\xcf\x6e\xae\xff\xf2\xd7\xeb\x77\xef\x9e\xf7\x96\x27\xb1\xee\x81\xf4\xf2\x9c\x95\x28\x54\x53\xaf\x82\x11\x8d\x48\xb6\xb6\xf4\xea\xd3\x35\x67\x12\xf4\x7f\xef\x12\x33\x24\x73\xae\xd0\x3d\x68\x73\xd7\x03\x5b\x0b\xe3\x38\x4c\xb7\xab\x23\xf3\x4e\x3c\xb2\x8e\xb6\x81\xdf\x48\x9c\xa3\x3a\x05\xc6\xb2\x8c\xae\xa0\x51\x4e\x96\x7d\x54\x15\x88\xbc\x92\x4a\x5a\x67\x84\xd3\x86\xe4\x48\x34\x4e\x57\xec\x62\x6b\xa3\x33\xb4\x16\x32\xa1\x20\x47\x4f\x18\x3c\x96\xb3\x01\xfb\xc7\x6e\x26\x91\x91\x74\xe7\xba\x88\x31\xdc\xaa\x65\x76\xd2\xb2\x10\x92\x86\xdd\xec\x45\x1f\x22\x3d\xbc\x45\x54\xad\xd1\xa3\xd8\x60\x2c\x16\x38\x35\xa3\x69\xa5\x1e\xdc\xae\x19\x3d\x8a\x20\xfe\x9f\x47\x0c\xad\x41\x9b\xf4\x69\xef\x1b\x4b\x74\xf3\x56\x31\x7a\xf7\x0e\xa9\x5b\x2d\x6e\x85\xd2\xe0\x8e\x64\xa1\xe7\x83\x01\xde\x8a\x6c\x0f\xa8\x9c\x39\x84\xa4\x4e\xe6\x14\xa8\x16\x12\x4d\x2a\xc8\x18\xb4\xb5\x56\xec\x15\x20\xd3\x55\xad\x15\x72\xb2\x4d\x0e\x53\x96\x7d\xf1\xeb\xa8\x86\x87\x9c\xf0\x20\xc3\xcc\x82\x33\x68\x72\x8f\x65\xa6\x07\x96\x1d\xa0\x5a\x2b\x59\xae\x18\x63\x89\xc1\x4c\xc8\xe0\x2a\x48\xa0\x63\x04\x12\x62\x9c\xd3\x0d\xb3\x2f\x38\x25\xef\x04\x4f\xe2\x4f\xc2\x18\x71\xec\x66\x77\xa8\x28\x66\xc6\xb3\x49\xda\xf2\x2f\x9d\x3b\x03\x91\x35\xff\x26\x4a\xca\x3f\x0b\xf9\x6d\x45\x66\xb9\x95\x77\xce\x0e\xfa\x9e\xc2\xe9\xb4\x28\x19\x72\x25\x7f\x6d\x42\x36\xf6\xf1\xc3\xbb\xff\x82\xeb\x5f\xf8\x69\xc2\x27\x44\x23\x7b\x61\x5b\x25\xab\x8d\xbe\x97\x79\x9f\x22\xe0\xd9\xd1\x0d\x61\x08\x19\x32\x46\x01\xba\x41\xd7\x18\xe5\x43\x86\xb6\xc2\x92\xa2\xc9\xf1\xcc\xcf\xed\x85\x6a\xc1\xd4\xc2\xda\x14\x2e\x79\xff\xc9\x20\x38\x82\xdc\x92\xf5\xad\xb6\x52\x85\xa2\x41\xda\x60\x0f\xa8\x6d\x8a\x42\x7e\x23\x30\x64\x5f\xfd\x9e\x82\x3b\xde\x87\xc8\x80\xd3\xd4\xb6\x38\x09\xa6\x29\xd1\xc6\xb0\x81\xe8\xd3\x03\x1a\x82\x90\x58\x7c\xdb\x22\x38\xd3\xa8\xac\x6b\x85\x4a\x54\x3b\xb7\x8f\x22\xea\xb1\x60\x3b\x23\xa9\xd0\xe1\x74\x0f\x66\x25\xee\xbc\x5e\x7a\xe4\x02\xbf\xb4\xea\xf0\x98\xed\x5d\x8f\xfc\x54\xb5\x95\x85\x1c\xf0\xc2\x84\x1f\x3d\x1d\xc5\xc0\xe7\xe0\xde\x41\xd8\x55\x07\xb0\x67\xce\x87\x8f\x54\x68\x23\xe6\x81\x80\x3f\xbe\xfc\x33\xac\x7b\x10\xa5\xb2\x0e\x45\xbe\x4a\xe9\x01\x4a\x0e\x5b\xc2\x63\x3f\xbd\x7c\x05\x9c\xde\xfa\x58\xe4\x4f\x2f\x5f\xfa\x42\xc0\x67\x14\x56\xab\x50\x98\x23\xfd\xd5\xcd\x80\xbe\xaa\x5c\x66\x82\x93\xde\x63\x71\xcd\xb8\xfa\x12\x02\xa7\x42\x37\x94\x1e\xaa\x36\x52\xa4\x84\xc7\x39\xcc\x57\xa3\xfb\x0f\x12\x18\xca\x38\x06\xc9\xc6\x3c\x8b\x3a\x55\x1e\xfa\xa1\x27\x23\xc2\x99\x69\x0f\x26\xc1\xfb\x4c\x10\xd6\x3e\xcc\xd8\xa3\xc8\xd1\x3c\x67\xd6\x5c\xd5\x75\x29\x69\xeb\x64\x54\x64\x01\x51\x83\x09\xf5\xc4\xa5\xbe\x42\x3d\xad\x9f\x91\x39\x56\xb5\x76\xa8\xb2\xc3\x72\x31\xd3\x6c\x05\x01\x39\x29\x8b\xf7\x4c\xd3\x15\x58\x72\x94\x14\x03\x2b\x9f\x77\x1e\x95\x2a\x44\xdc\x64\x16\x25\x8e\xc2\x67\x5d\x9c\x80\x84\x10\x40\x5b\xd6\x04\xeb\x84\xc3\xcd\x98\x17\x7f\xf2\x7c\x90\x9b\x25\x73\xdc\xe6\xf2\x4a\x75\x6f\x26\x36\x0a\x2a\xd9\x3b\xa3\xcb\x32\xd5\xcc\x50\x15\x9a\xeb\x5d\x56\x57\x11\xe7\x13\xa8\x24\xd8\xf7\xc2\x48\xa1\x1c\xa5\x8c\xc1\xeb\xc6\x9a\x51\x88\xba\x8f\x73\x42\xe1\xfd\x93\x2e\xba\x18\xf4\x03\x22\x0e\xc5\xf7\xe2\xde\x97\x2c\x0f\x54\x1b\xe3\x54\x4d\x1f\x15\x84\xd8\x7f\x2a\x59\x92\x42\x72\x0c\x70\x14\x37\xf6\x80\x92\x51\x64\x07\x40\x9e\x9b\x82\xfb\xf2\xd0\xc1\x82\x52\x20\x52\xf8\x07\x69\x71\x75\x12\x45\x64\xe4\xf3\x73\x34\x03\x86\xa8\x51\x1d\x10\x31\x3b\xdd\xcb\x3c\x47\x05\xcf\xa4\xe2\xed\xbe\x78\x10\x2e\xdb\xf3\x8f\x3b\x74\x90\x89\xb2\xb4\xcf\x7d\x48\xe2\xf5\x77\x82\x00\xea\xc2\x51\xa6\x5a\xca\x4c\x52\xaa\x2b\xec\x1d\xdb\x58\xd0\x5b\x36\x9c\x27\xeb\xa7\xda\xec\x40\x65\xe9\x3f\x39\x6a\x8c\x3d\x1b\x90\xa9\x96\xb6\x3a\x8a\x2d\xc9\x5c\xd6\x41\x64\x3b\x11\xc5\x60\xfd\x9a\x9e\xcb\x1a\x43\xc5\x4e\x0a\x7e\x4f\xd9\x1a\xca\x28\xb5\x91\xf7\xb2\xc4\x1d\xe6\xe4\xdc\x43\xf7\x82\x6f\xef\x67\x6c\xbe\xcc\xdc\xae\x1b\xf2\x52\xd9\x66\xbf\xab\x98\x1e\x06\xab\xc9\x4f\x48\x0a\xf1\x7c\x9e\xd9\x03\xb9\x3d\x80\x50\x07\x5e\x9a\x4d\xd9\x9b\xb7\x9f\x3e\xbf\x7d\x7d\xf5\xe5\xed\x1b\x58\x1f\xa1\xcb\x25\x72\xa1\x40\x94\xf5\x5e\x04\x91\x25\x9e\x0d\x46\x76\x9d\xe2\x91\x54\x70\xff\x6a\xf3\xea\x4f\x9b\x53\xa3\x34\xd6\xa9\xa0\x6f\xed\xb3\xc3\xfe\x0f\x27\xca\xfa\x29\x64\x91\xa3\xba\x13\x3a\x07\x14\x0a\xe3\x37\xcc\x62\xd7\xf2\xf4\x23\x55\x28\x78\xa6\x30\x39\x29\x0a\x91\x36\x94\x3a\x36\x5e\x4a\x88\xaf\xa5\xb0\x2e\x62\x39\x02\x31\x21\x41\x10\x02\x35\x62\x21\x04\x0a\x21\x4b\x72\x78\x06\x6d\x53\xba\x50\x0f\xf2\xa2\xd6\x45\x7f\x10\xb4\x6f\xa6\xa4\xb8\x8a\x64\xc5\x69\xd6\xf4\xe8\xf7\x86\x74\x93\xe2\x9a\x16\x74\x5f\x55\xa3\xdf\x0c\x7b\x25\x2d\x12\x65\x19\x55\xb0\xef\xbc\x46\x63\xe4\x73\xbc\xf5\x5f\x35\x10\x0e\x8f\x30\xb9\xdb\xb9\x88\x39\x29\xb3\x55\xda\xa3\x94\x83\xd2\x90\xb4\xc3\x31\xbe\x44\xd5\x4c\xfc\xdd\x2c\x46\x6f\x1a\x0f\xf6\x63\xfe\xf2\x6b\x43\xbe\x6c\x78\x1f\x6b\x0e\x62\x06\x7f\x1a\x6d\xe8\x4c\xa7\x12\xa1\xbc\xd8\x94\xee\x72\x71\x86\x66\xd7\xc5\xb1\x68\xf9\x70\x8c\x28\xf8\x8b\x90\x65\x63\x42\xe8\xdf\x35\xe5\x03\x20\x43\x7d\x84\xfa\x5f\xd4\x04\xb7\xa1\x1e\x48\x8d\x36\xb1\x0b\x15\x51\xd2\x88\x90\x47\x52\xba\x65\x1b\x0a\x32\xbc\xda\xe9\x41\x8b\x43\xff\x82\x54\x71\x69\x21\xda\xea\x6e\xaa\xb7\x59\x3c\x5e\xa6\x86\x5b\xfc\xa3\x14\x7a\x6c\xbb\x7f\x04\x26\xb4\xa1\x50\x0c\x7b\x1e\xd5\xfa\x1f\x05\x3b\x38\x12\xf0\x98\x31\x80\x51\xc8\xbf\xe3\x78\xc0\xac\x20\x34\x7e\x33\x9d\xe3\x2c\xd6\xdd\x34\xbb\x9d\x2f\x7e\xff\xfb\x97\x2f\x9f\x62\xea\x42\x8f\xb7\xcd\x0f\x0a\x2f\x1b\xbb\x82\x97\x20\xfb\x71\x68\xfc\x84\xb2\xd4\x98\x09\xe8\x44\x9a\x3f\xff\x34\x72\xcf\x78\xc4\x19\x3f\x39\x3a\x21\x4b\x3b\x6b\x67\x6f\x69\x06\x28\xc7\x9c\xda\x48\x02\x84\xb5\x3a\x93\x1c\x1c\x27\xf5\x35\x9c\x51\x6d\x7c\x41\x66\x04\x24\xc9\x24\xdd\xc5\x92\xe1\x65\x1b\x68\xae\x41\x3f\x28\x6e\x9b\xfb\x15\x3c\x5a\x27\x21\xe8\x28\xc4\x54\x89\x88\x3e\x86\x31\x4c\x29\xff\x60\xb3\x31\xd3\x14\x25\x57\x8b\x11\x90\x64\x4a\x28\xf6\x08\x7a\x86\xdf\x32\xac\x43\xb9\xc8\x23\x9d\x72\x82\xb0\x1d\xa2\xf5\x18\xaf\xce\x7b\x1c\x80\x4c\x34\x76\xea\xf7\x13\x66\x50\xe5\xe0\x35\x3f\xe2\x6d\x31\x48\x95\x95\x4d\x8e\x16\x2a\x4a\xdc\x02\x5f\x3b\x5c\x9a\x00\x0c\xad\x01\xbe\x61\xc9\x0c\x99\x31\xc5\x01\x8d\xc1\x0d\x7c\xd0\x8e\x3a\x78\x47\xbf\x72\x2c\x38\x09\x34\x14\x36\x02\x2e\x98\x87\x2d\x8e\x11\xe9\x8c\xd7\x7e\x0c\x2d\x83\x86\x90\xd8\x9c\xbb\xe9\x84\xac\x4b\xa2\x2b\x7b\x9f\xe8\xd4\x53\x39\x39\xc4\xf5\x54\x72\xa6\xda\xd2\x59\xb8\xc1\x91\xa3\x31\xda\xac\x28\xc0\x21\x8f\xcb\x52\x43\xe2\xfe\x1f\x37\x1f\x3f\x50\x9d\x83\xe3\x01\x31\xe6\x56\x4e\xbf\xef\x5b\x46\x43\x4e\x4c\x51\x39\xd4\xda\xba\x42\x7e\x83\x38\xa1\xc1\x66\x46\xb1\x09\x9a\x01\x51\x38\x3f\x5d\x45\x36\xf7\x8a\x04\xc9\xc7\xd2\xbf\xa1\xd1\x6b\xa9\x72\xfc\x46\xd5\x2e\xf8\x85\x28\x72\x9e\xe3\xd1\xd7\xd5\x28\x8c\x97\x43\xae\x9e\x71\x5b\x4c\x72\x06\xe3\x65\x55\x17\x41\x16\x20\x1f\x28\x8e\xf5\xbf\x4e\x7b\x1b\x60\x29\xaf\x22\x0f\x5e\x35\xa5\x93\x75\x89\x9e\xba\x76\x03\x1f\x83\x05\xe0\x34\xe1\xad\xef\x14\x9d\x15\x10\xfa\x77\x0b\x70\xbb\x24\xce\xdc\x2e\x61\x1d\x5a\x72\xc4\xfd\x74\x51\xab\x6e\xae\x34\x03\x62\x12\x18\x82\xcc\x02\xfd\xdf\x2f\xff\x67\x33\xb1\xc4\x0c\x98\x01\x89\x42\x1a\x6a\xa2\x30\x0d\x43\xb9\x5b\xc5\x45\x6e\x97\xcb\xc5\x04\x84\x79\x5e\xae\xfd\x54\x68\xad\xd8\x4d\x44\xc1\x83\xea\x73\x05\xfb\xa6\x12\x6a\x6d\x50\xe4\xdc\x48\xed\xfc\x1a\x15\x8a\x39\x7f\x16\x2c\xc4\xdb\x99\xc3\x1b\xe8\x7a\x82\x50\xdd\x0c\x91\x0d\x67\x0f\xeb\x09\xef\xd0\x7e\xc9\xa6\x93\xfb\xc9\xd1\x6c\x9e\x92\x58\xde\x05\x3c\x9a\x56\x95\xc8\xf6\x52\xe1\x14\xb5\xce\x82\x0c\x8e\xe3\x84\x5a\xb1\x1c\xcb\xd1\x54\xca\xbf\x09\xa0\x99\x03\x92\x1d\x26\x47\x5f\x14\x63\x10\x36\xe2\x5e\xc8\x92\x38\xfa\x84\x74\x3b\x93\x68\xcc\x49\x38\xe2\xc7\xcf\x06\x2f\x66\x52\x9e\x6c\x3c\x3f\xd1\x5a\xbf\x9e\xb5\x7f\xac\xe3\xf4\x21\xdd\x91\x87\xdc\x2c\x7e\x90\x48\xa7\xa3\xaa\x93\x9b\xba\xa0\x5d\xd1\x13\xff\xe0\x4d\xc1\x47\xe5\xeb\x8a\xed\xb8\x15\xf9\x85\x30\x3b\x37\x09\xb7\xd3\xc9\x0b\x9d\xcd\x16\x35\x1a\xbc\xfd\x9d\xc6\x55\xbf\x8b\x17\xd3\x25\x81\x01\x01\xa3\x07\xfe\xb1\xac\x80\x67\x61\xcc\x0e\x89\x68\x54\x64\xb2\x52\xed\x4a\x1c\x4f\xed\xe3\xd7\x97\x89\x29\xbf\xdd\x46\xa3\xb3\xc5\xfc\xf9\x0f\x0b\x2c\x37\x31\xb8\x03\x31\x32\x25\x36\x4a\xb1\xeb\xa2\xed\x45\xac\xba\x4d\x8f\x38\x29\xd1\xe9\x11\x4f\xc0\x84\x76\x08\x30\x66\xb5\x5c\xed\xa3\x89\xdb\x7c\x03\x37\x24\xb7\x6c\x22\xe3\x1c\xb6\xef\xa9\x4c\x42\xec\xf4\x6a\xb8\x54\xe7\xa8\x25\x46\xa1\x4c\xc9\x33\xbe\x34\x7c\x95\x91\x5d\x81\x75\x48\xf0\xb4\x8d\x8b\x9c\x81\x7b\xe4\xd0\x22\x2e\xb0\xd7\x0f\x7e\x44\xc8\x69\x78\x10\xd2\xa5\x9d\x8b\xbb\x29\xda\x47\x54\x4f\xd1\x9a\x62\xea\x9c\x1c\x72\x5e\x1e\x49\xdf\x46\x3e\xc2\x5a\x7d\xbd\x7e\x73\xaa\x13\x9b\x31\x81\x5e\xcc\x0a\xb7\xc6\x84\xfa\xd1\xc3\xce\xed\xf0\x80\xfd\x43\x23\x7f\xd8\x76\x9c\x75\x73\x53\x66\xfe\x09\x4e\x27\x2c\x26\x05\x30\x54\x63\xbf\xe7\xa4\xc2\x62\x86\xc6\x7c\xd7\xa9\x85\x51\xc0\xbf\xbb\x7b\x38\xcb\xde\x33\x61\xf2\xa3\x83\xe3\x60\xe6\xcf\x95\xf5\x92\x95\xdb\x7c\x3f\xe2\xfd\xe3\x19\xa3\x98\x5f\xdc\x38\xa1\x72\x9a\x40\xa3\xc6\x4e\x7a\xf6\x9f\xe0\xaf\x67\x55\x52\x34\x69\x42\x33\xdf\x5d\xc7\x07\x62\x62\x41\x4d\x0b\x59\xa4\xc9\x55\x2e\x51\x53\xf7\xb3\x92\x6e\x31\x23\x4b\x0b\x5d\x68\x6a\xf6\x50\x62\x16\x4a\x80\xb1\xbf\x12\xed\x7c\x68\x13\x9c\xf3\x67\x61\x14\x82\x1a\xa0\x9c\x50\x13\xcf\x3a\xd1\x38\x87\x1a\x29\xca\xd7\xb5\xa0\x71\x9a\xa1\xc1\xbf\xee\x27\x6c\x33\x9e\x95\x90\xd6\xf2\x43\x3a\x0c\x4d\x84\x91\x4a\x7d\xa4\xec\x8c\xed\x79\x4c\xf3\x4e\xdf\xd1\xe9\xe0\x79\x43\xfd\x5c\xe1\xb7\xd4\x6b\x4c\x3b\x98\x04\x99\x7a\xa2\xaf\x3d\x87\xc8\xbe\x71\xbf\x9b\xcb\xfd\xca\x05\x71\x6c\x3b\x8a\xb5\xb6\xc3\x73\xbf\xdd\x8f\x2c\xba\x43\x26\x54\x07\x94\xbb\x26\x04\x0d\x44\xe7\x6c\x2f\x14\x75\x3c\x75\xb7\x86\x21\x26\x41\x16\xf8\x00\x95\x54\x54\x46\xa1\x12\x45\x77\x4e\xa8\xf5\x6f\xb1\xa0\xef\x93\xd8\x28\x15\x93\x70\xd9\x1f\x36\xe4\x05\x3d\x5d\x93\xa4\x76\x46\x8f\xb6\x08\xde\x63\x65\x69\x06\x75\x12\x66\x90\x96\x6e\x45\x21\x34\xaa\x90\x46\x31\x4b\xea\x60\x1d\x74\xe3\xf7\x61\x30\x43\x39\x74\xb2\xa8\xfb\x61\xd4\x9c\xbe\x43\xe5\x9d\x84\x50\x3e\xfe\x89\xd6\x71\x2a\x04\x39\x6b\xa8\xba\x3e\x3e\x50\x70\xb6\x62\x5f\xdc\xb8\xb6\xe1\x93\xdc\x7a\x98\xaf\x62\xf6\x5f\x5c\xd8\xd4\xb6\x98\x80\x0a\xb1\xf3\x12\x1b\x2e\xd1\x6f\x92\x56\xc4\x98\x23\x8e\xbf\xc5\xfe\xd1\xc0\x38\x55\xf7\xdb\x4e\xad\x32\x97\x83\xac\x7b\xb2\x07\x11\xdc\xc0\xdf\x98\x59\x55\x98\x96\x74\x34\x9f\x71\x86\x19\x22\x99\x81\x0e\x2a\x64\x78\xbc\x48\x42\xa3\x52\xdb\x7d\x2b\xb2\xbb\x39\x12\x13\xe7\xbc\x66\x8c\xc3\x74\x3c\xc2\x24\xc8\x27\xf0\x16\x99\x56\x7e\x80\x21\x3b\xac\xc3\x08\xcc\x5a\xa8\x7c\x9d\xcc\x43\x76\xb8\xf8\x51\xc1\xb3\x58\x16\xef\xa4\xba\x9b\x2d\x71\xf1\x01\x1f\xa5\x7d\xfd\xfc\xee\x34\x38\x4b\xa2\x33\xa5\x14\xb3\xce\x12\xfd\xd8\xde\xce\x46\xa5\xd3\x35\xad\x47\x56\xb2\x1e\xf6\x61\x30\x24\x05\x2e\x23\x70\xb9\xf6\x14\xe6\xe8\x96\xa1\x1b\xbc\x0c\xc9\xef\x74\x59\x6b\xaa\x3f\x34\x5a\xcc\x82\xab\x38\x05\x98\x95\xc2\xd0\x24\x1c\x4f\xb6\x72\xe7\xce\x2f\x3a\x0a\x93\x3b\x7a\xdb\xc6\x41\xae\x91\xca\x65\x0e\xf4\x3d\x1a\x43\x0d\x0f\xd9\x3b\xa5\x37\x9b\x31\x7e\xd1\x59\x54\xbf\xb8\xe9\xc4\x8a\x9d\x72\xcc\x06\x3e\x2a\x1a\xd6\xbf\x84\xe5\x4d\x93\xd1\x90\xfc\x72\x68\x5c\x27\x7e\x12\x95\x9f\x3a\x9a\xa3\x7c\x9e\x15\xd2\xef\xe9\xe2\xfb\x48\x32\x21\xa7\x63\x13\x0e\xeb\x91\xd9\x97\x51\x50\xa5\xd8\x62\xbf\x07\xfa\xc4\x27\x8f\xdf\x8b\x9a\x9c\x47\x48\xdc\xee\xf0\x40\x92\x16\x8f\xc3\xf7\xfd\x88\xd3\xa0\xcd\x4e\x50\x1b\xbe\xb7\x26\x3d\x47\x21\xe4\x4e\x1b\xf9\x1b\xc2\x33\x7e\x9d\x01\x43\xb3\x58\x62\xe6\x9e\x87\x4d\xd2\xf9\x42\x71\x80\x8a\x47\xd8\xfc\x4f\xda\xd8\xa1\xd9\x47\x83\x75\x49\x95\x10\x52\xd7\x76\x9c\xd0\x06\x98\xe6\x5e\x66\x68\x1f\x9f\x48\x7b\xba\x5e\xcc\x65\x43\x25\x94\xd8\x61\xee\x7b\x4d\x97\x53\xc4\x5c\xbe\xef\xde\x0a\x95\xa8\x2d\xd0\xb9\x94\xa2\xd4\x0f\x6b\x99\x33\xda\xd1\x61\x87\x11\x85\xa1\x83\xa5\xba\x88\x6d\x25\x26\x3f\xf5\xbd\x02\x0e\xe4\xc6\xf9\x5a\x84\x1a\x3a\xd1\x92\xa2\x70\x4b\xd3\x7c\x54\xea\x19\x0d\x1c\xf6\xba\xb1\x78\x87\x58\x4b\xb5\xf3\x51\x3f\xe5\x11\x96\xec\xb2\xa4\x11\xc2\x43\x28\x4e\xd1\x84\xa0\x0a\xfd\xe8\x70\xf2\xaa\x51\x39\x1a\xeb\x86\x42\xf8\xb6\x60\xb4\x81\xab\xb4\xdf\x28\x35\x31\x5b\xb9\xf0\x8d\xc6\xd5\xd1\x60\x68\xbc\xd8\x83\x19\x0e\x47\xc4\x29\xa6\xce\xb0\xac\xa8\x6b\x1a\x00\x14\x6e\x0f\xa5\xbc\x43\xb8\x5d\x66\x72\x9d\xe5\xb7\x4b\x22\x05\xc6\x38\xde\xd3\xaf\x07\x96\xbc\x5f\xf9\x20\x0e\xc9\x96\x27\x6e\x84\x9c\xa7\x45\x9f\xa3\xa6\x93\x73\xea\x43\x01\x49\x1c\x5a\xb9\x55\xc7\x43\x01\x61\xe6\x8f\x88\x1c\x28\xd1\x89\xdf\xe3\x9c\x1f\x95\x51\x87\xa6\xbb\x95\x76\x32\xc3\xde\xf4\xdf\x48\x1b\x7a\x3a\xf9\x3c\x37\xe2\x73\x24\xc1\xd3\xf3\x3d\x29\xca\x8c\x81\xef\x54\xf6\xd5\xa9\x23\x12\x53\x88\x6f\xe4\xc6\xfc\x29\x79\x0c\x35\x3e\x72\xab\x4b\xee\x79\xbc\x08\x6b\x2c\xe1\xef\xcd\xc9\x6b\x3c\xda\x2f\x73\x9c\xd8\xe4\x74\xbd\x2e\xc9\xc2\x77\x31\x0e\x32\x18\x8e\x71\x23\xb9\x18\x7a\x6b\x01\x69\x9a\x11\xd9\xdd\x28\x9e\x47\xfb\x8b\x63\x9a\x84\xf3\x16\xb9\x29\x48\xe3\xa1\x59\xaa\x0d\x85\xb3\x5e\x5e\x61\x46\x60\x86\x91\xa5\xa1\xf9\xf5\x33\xc6\x39\x0d\x08\x0c\xf2\x72\xc4\xfa\x83\x33\x0d\x9e\x67\x6e\x30\x4b\x9d\x84\x43\x1c\xeb\xcb\x14\xb6\x03\x86\xb1\x6b\x1e\xcd\x0c\xe1\xf2\xd6\xd1\xf4\xcf\x42\xe9\xe2\x58\xf7\x18\xe4\x30\x6d\x02\xc7\x2c\xce\x40\x79\x94\xc0\x29\x26\x99\x81\xf4\xc7\x78\x6f\x7c\xa1\x0e\xc1\x26\x92\x25\x20\xa1\xc2\x5b\xa2\x18\x3c\xaa\x12\x71\x96\x16\x8e\xdc\xc3\x5b\x6e\x94\x6f\x91\xe2\xef\xf4\x0a\x02\xd2\x0c\x8a\xa2\xc9\xff\xca\xe8\x85\x47\x40\xa6\xb1\xad\x30\x57\x6c\x10\x2e\xe8\x50\xc5\xe1\x82\x4d\xfb\xc5\x57\x2e\x62\x5e\x7c\x17\x85\xa8\xcb\x31\x83\x38\x74\x3a\x05\xba\x87\x26\x89\x30\xb1\x58\x9e\x78\x04\x0f\xd4\x09\x9a\x98\x19\xbb\x4e\xc7\x4d\x82\x75\x4e\x27\xf0\x64\x71\xcc\x80\xb0\xc1\xc5\x54\xd7\x60\xec\x6c\xe0\x8c\x8d\x4f\x88\xfa\x58\xbb\x77\xa8\x01\x77\x44\xa3\x0b\x3e\xd8\x12\x53\xe5\x70\x54\x87\x0c\x3f\x4d\x9e\xb4\xef\xf9\xd8\xc0\xb5\x6d\x8f\x3c\x0d\xbe\x23\x80\xa5\x24\x0c\x40\x73\x09\xdd\xae\xda\x13\xce\xdc\xfb\x4c\x3f\x70\xc9\x90\xce\xfa\x3c\xa4\xe3\xea\x43\xb2\x99\x8a\x6a\xed\xb9\xa7\x68\x06\x15\x88\x9a\x1c\x8b\xa1\x66\x60\x78\x2f\x49\xd7\xf2\x6d\x86\x0f\x7b\x49\x0b\xb5\x91\x95\x30\x92\x8f\x42\x84\xb9\x39\x12\xd5\x74\x88\xa3\x3d\x73\x43\xd5\xbd\xfc\xa4\xd2\x95\xa7\x77\x74\xf5\xa5\x65\xa0\x40\xff\xd8\xd8\xaf\xb5\x3a\xf6\x0f\xb4\xa9\x91\x30\x70\x40\x3e\x12\xa7\x26\xb9\xbd\xfc\x10\x6f\x3b\x72\xa0\xfe\x4a\xe0\x3a\x1d\xe7\x07\xd5\x97\x8a\xfe\x86\xaf\x54\xd0\x83\xb4\x38\x69\x1b\xe5\x17\xf7\xa2\xf4\x3c\x65\xf0\xb7\xcb\x1c\x0b\xd1\x94\xee\x76\xd9\x4a\xd4\x0a\xb6\x03\xa1\x45\xf7\xd6\x60\xd1\x32\xa1\xb4\x22\xae\xb6\x45\x81\x90\xb1\xc5\x01\xbb\x58\x04\xa2\x50\x34\xca\x68\x0f\x72\x78\x53\x0a\x05\xfd\x64\x08\xbb\xc2\x1d\xe6\x8b\xd8\x9c\xa5\x20\xc2\x9b\xad\xb6\x37\x19\x16\x59\x8c\x8d\x53\x87\x37\x15\xdc\xaa\x74\x4a\x57\xc0\x9b\x0f\x37\xff\xfb\xee\xea\xdf\xde\xbe\xdb\x4c\x0b\x47\x0f\xe8\x2c\x61\x49\xf8\xdb\xe5\x5c\x29\xd1\x0f\x0a\xcd\x67\xe4\xd7\xf5\x64\x68\x27\x65\xe5\x5d\x38\x7b\x11\xa9\x9b\x23\x25\x88\x31\xca\x6f\x2b\x32\x54\x5f\xb8\x7a\xf7\x6e\x94\x40\x21\x96\xe5\xa2\x33\x97\xe9\xb6\xd8\x9d\x2f\xef\x80\x4a\xb4\xdc\x09\xb3\xa5\x69\xf4\x8c\xce\x67\xd1\x39\xa8\xbe\xec\x5d\x17\x47\x4f\x4a\xdb\x4d\x42\xba\x41\x3c\xad\xe0\xcf\x01\xa5\xd9\xaf\x54\x6c\xef\x41\x0d\x87\x81\x64\x94\x5d\x69\x8f\x20\xa5\xb9\x82\xf6\x62\x27\x1e\xa3\x27\xcc\x90\x9e\x7c\xe1\x4a\x4b\x1b\xa3\x75\x67\xfc\x42\xf2\x44\x76\xb3\x05\xba\xf9\x67\x44\xd6\xc7\x61\x34\x69\x12\x8b\xc9\x88\x5b\x1c\x15\xb1\x70\x5a\x88\xde\xb2\xf1\x91\xa4\x2d\xbe\x86\x65\x06\x12\xc4\x53\x43\x6f\xc2\xbb\xfa\xf0\x26\xf6\x1b\x58\x62\xd3\xf1\xde\x25\xf5\xf4\x29\x20\x57\x79\x84\x7b\x2a\xfb\xbd\x23\xf5\x41\x00\x5a\x60\x2d\x23\x82\x10\xb6\x4d\xda\x3b\x3c\xac\xd9\x0c\x8c\x00\xa5\x63\x12\x64\x0f\x9d\x2c\x63\xaa\x11\x74\xa9\x73\x22\x68\x03\x6f\xbc\xb9\xa3\x74\x02\x0a\x51\xd2\x2b\xe5\xbe\x8c\x85\x5e\xe9\x9d\x4a\xf1\x20\x32\x55\x32\x0c\x27\xb8\x16\x96\x1e\xc3\x25\x1d\xd6\xa8\xa4\xed\xb2\x87\xf7\xd2\x4f\x4d\x83\x9e\xc7\x83\x7d\xf0\xc7\x9f\x7e\x82\x67\x5f\x55\x38\x64\x43\xe5\x3b\x78\xab\x9c\x74\x87\xe7\x49\xdb\x62\x4f\x65\x8a\xd1\x5b\xad\xe9\xed\x17\x03\x77\xb4\x52\xfb\x18\x0e\x9f\x10\x8f\xdf\xe1\x97\x0e\x46\xcc\xd0\x88\x79\xb8\x8d\xcf\x08\x1c\x61\xe5\x27\x04\x4e\xc5\xfe\x89\x0b\x7b\x67\xdb\xb4\x67\x34\x6a\x7c\x94\xea\x78\x2f\x1f\x3a\x47\xab\x46\xf7\xf2\xe3\x81\xc8\x2c\x9c\x1b\x39\x8b\xfc\x47\x53\x2d\x4f\x81\x71\x23\xbf\x8b\xc8\x31\x76\xe8\xe3\xbc\xee\x58\xd3\x81\x1f\x89\xab\x8b\x99\x87\xc5\xd6\xd0\xc8\xfc\x29\x42\xfb\x18\x4d\x8f\x18\xf9\x23\x12\xd3\x09\xe8\xd0\xdf\x62\xf3\x46\xde\xa7\x3b\xbe\x12\x4e\x29\xc6\x83\x48\xc9\x11\x2c\x06\x13\xc5\x59\x5d\xbc\x91\x4e\x5d\x0f\xe2\x71\xe7\xee\x7d\xa7\xc9\x4e\xb1\x97\xae\x9d\xac\xe8\xad\x84\x19\x74\x3a\x57\xab\xf0\x00\xaf\xc1\x63\x64\x7d\x43\x18\x0f\xb5\xf8\xa3\xc8\x6d\x3a\x4c\x9d\x8c\x94\xa2\xd0\x41\xea\x50\x63\x88\x97\xda\xd7\xe0\xf5\x40\x72\x3c\xcc\xdd\xc4\x90\x40\x86\x32\x74\xdb\x3c\x7c\x7c\xc7\x30\x76\x09\xf9\x95\x95\x55\xe7\x3d\x6a\x3e\xc5\x26\x1a\x08\xff\xa2\xa0\xac\x29\x85\x19\xc0\xbc\x07\xb2\xb3\x93\x5b\x35\xa7\x27\x36\xb3\x5f\x3a\xda\x23\x7d\x6a\x53\x39\xa3\x47\x39\x3b\xe2\x1d\xeb\x45\x1e\xa9\xc7\xcd\xfc\xfe\xe3\x11\x3d\x4f\x60\xc2\xbc\x9e\xe3\x28\xae\x03\xe6\xf2\x58\x8b\xc9\x50\x86\xac\x28\x64\xea\x14\xcd\xca\xf0\x52\x4e\xce\x05\x42\x97\x2f\x55\x5f\x02\xda\x27\x60\x21\xbc\xba\xb1\x2d\xad\x87\xfc\xba\x23\x26\x2c\x98\x40\xef\xcc\xf2\xfd\x30\x7a\xc5\x53\xca\x92\x75\x31\xf5\x6e\xd7\xce\x3b\xeb\xe2\x2b\x0c\xe9\xec\x98\xd7\x59\xad\xe0\xd3\xd7\x2f\xad\x46\x9e\x88\x69\x0f\xee\xf6\x30\x42\xd6\x1f\x76\x11\x33\x85\x68\xd0\x36\x57\x68\xf7\x97\x8b\x33\xcf\xc6\x97\x70\x4f\x40\x3a\xb9\x14\x4c\x2f\x07\xf4\xeb\xf0\x82\xef\xfb\x57\x5c\xac\x7f\xb5\x48\xf6\x22\xef\xd4\x54\xc3\xc9\xdd\x4b\x70\xa6\xc1\xc5\xff\x0d\x00\xa7\x1a\xe9\x4c\x86\x5c\x00\x00"),
 		},
 		"/control-plane/kuma-cp": &vfsgen۰DirInfo{
 			name:    "kuma-cp",
-			modTime: time.Date(2019, 12, 16, 8, 31, 53, 656406129, time.UTC),
+			modTime: time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 		},
 		"/control-plane/kuma-cp/app.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "app.yaml",
-			modTime:          time.Date(2019, 12, 16, 8, 31, 53, 656228130, time.UTC),
+			modTime:          time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 			uncompressedSize: 5641,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\x5b\x73\xda\x38\x14\x7e\xe7\x57\x68\xba\xcf\x86\x90\xa6\x2d\xf5\x4c\x1f\x28\xb8\x59\x26\xe1\x32\x36\xc9\x6e\x9e\xa8\x30\x07\xa3\x41\xb6\xbc\x92\xec\x2d\xd3\xe6\xbf\xef\xc8\xb6\x8c\x0d\xb6\x31\xdd\xe4\x09\x9f\xcb\xa7\x73\xf9\x24\x1d\xc5\x30\x8c\x0e\x0e\xc9\x33\x70\x41\x58\x60\xa2\xb8\xdf\xd9\x93\x60\x63\x22\x07\x78\x4c\x5c\xe8\xf8\x20\xf1\x06\x4b\x6c\x76\x10\x0a\xb0\x0f\x26\xfa\xf9\x13\x75\x47\x2c\x90\x9c\xd1\x05\xc5\x01\x64\x96\x33\xec\x03\x7a\x7d\xcd\xcc\x44\x88\xdd\xcc\x76\xa6\x3f\x95\x56\x84\xe0\x2a\xa8\x90\x71\x29\xd4\x0f\x23\xf9\x69\xa2\xbb\xbb\xf7\x1d\x84\xf4\x1a\x3b\x29\x43\x61\xe0\x8d\x4f\x84\x8a\xcb\x10\xc0\x63\xe0\x89\x81\xc4\xdc\x03\xb9\x48\x9c\x3e\xa4\x5e\x1a\xe3\xc3\xc7\x4f\x9f\x0a\x20\x1e\x0f\x5d\x43\x6c\x44\xd9\x62\x70\x6a\xf1\xe3\xd4\xe2\xf3\x49\x20\x67\x16\x83\xfe\xa9\x05\x0e\xc9\x31\xc6\x82\xe1\xed\xa9\xe1\x9a\x31\x29\x24\xc7\x61\xa5\x79\xb1\x04\x5e\x54\x80\x14\x40\xc1\x95\x8c\x9b\x89\x01\x0e\x43\x13\xed\x23\x1f\x1b\x6e\xda\x07\x23\x54\x8d\xe8\x5c\x6a\xe6\xd0\x75\x59\x14\xc8\x8a\x9e\x56\x80\x35\xf7\xb1\x61\x29\x97\x83\xec\xc8\x43\x98\xc0\xae\x81\x07\x20\x41\x74\x09\xeb\x49\x2a\xea\x96\x16\x1b\x61\x48\x2a\x0c\x17\xb8\xbc\xb0\xb2\xf6\x96\x54\x74\x5d\x55\x37\x65\xe1\x6c\xc4\x92\x8a\x11\x70\x89\x7e\xa1\xf5\xc7\x3b\x08\xdc\x94\x8b\xca\x6a\x0f\x87\xa2\xd5\x03\x1c\x4a\x46\x6f\x9c\xca\x29\x69\xff\x57\x5e\x43\x0d\xe6\x24\x58\x2d\x72\x3c\xf7\x68\x9d\xef\x88\x05\x5b\xe2\x4d\x71\xd8\x8a\x20\xea\x6b\x4b\xbc\x96\x59\xa5\xc6\xdd\x03\xf6\xa9\x89\x7e\x25\x2c\xfe\x03\x45\x02\x90\xdc\x11\x81\xb6\x84\x02\x92\x0c\xb1\x18\x38\x27\x1b\x40\x1b\xd8\xe2\x88\xca\xcc\x2d\xe2\x58\x12\x16\x20\xb6\x45\xdf\xd3\x40\xc2\xef\x29\x44\x06\x24\x00\x12\xd3\x5e\xa6\xed\xaa\x0f\xb4\x65\x1c\xe1\x18\x13\x8a\xd7\x14\x90\x00\x29\x49\xe0\x89\xb3\xfc\x71\x18\x8a\x5e\x5e\x84\x31\x84\x94\x1d\x7c\x78\x9b\x6d\x82\x10\xc5\x6b\xa0\xa2\x79\xdf\xea\x43\x51\x1d\x0c\x12\xbc\x43\x6a\xcd\x19\xa5\x24\xf0\x9e\xc2\x0d\x96\x90\x8a\x10\xf2\xf1\x0f\x27\xe2\x1e\x98\xa8\x7f\x94\x3c\x05\x79\x9a\x26\xba\x39\x3b\x2e\x7c\x2c\xdd\xdd\x63\x21\x8e\xfa\x48\x10\x92\xe0\x87\x34\x5f\xb0\x58\x02\xf5\x47\x4b\x28\x4d\x38\x08\xe9\xac\x92\xdf\xa5\x03\x68\x56\x5f\x4c\xf5\xa7\x64\x98\x04\xc0\xf3\x85\x8c\xac\xfe\x55\xd6\x08\x11\x1f\x7b\x15\xf7\xd2\x44\x89\xd1\xeb\xab\x79\xaa\xc8\x3a\x9f\xf6\xa7\x00\xb1\x88\x28\x5d\x30\x4a\xdc\x6c\x2b\x4d\xca\xc2\xa2\x3d\x04\xf1\xb1\x08\x3a\xba\x87\xa7\xe9\x70\x65\xcd\x9e\x27\xf6\x7c\x36\xb5\x66\xcb\xdc\x00\xa1\x18\xd3\x08\x4c\xf4\xee\x78\x8a\xbc\xab\x76\x77\x96\x73\xdb\x5a\x2d\x5f\x16\xd6\xef\x7b\x3f\x3c\x7d\xb5\xec\x99\xb5\xb4\x9c\x95\xf3\xe2\x2c\xad\xe9\x6a\x36\x9c\x5a\xce\x62\x38\xaa\x00\xad\xa0\x6c\x05\xf0\xbd\x35\xb3\xec\xe1\xe3\x6a\x38\x7e\xb6\xec\xe5\xc4\xb1\xc6\xab\x3f\xe7\xce\x52\xe1\x56\x43\xd6\x0f\x08\xdd\x76\x2b\x3a\x63\x67\xe5\x58\xf6\xb3\x65\xaf\xee\xed\xc5\x68\xb5\x98\xdb\x55\x05\x55\x57\x7e\x4d\x31\xfe\x6e\x8d\x30\xa8\x41\x18\x2e\x26\x1a\xa1\xd6\x79\xd0\xaf\x71\xfe\x3a\x9f\x2f\x9d\xa5\x3d\x5c\x5c\x86\xb8\xad\x6b\xe7\x31\x83\xe5\xa3\xb3\x1a\x59\xf6\x72\xf5\x6d\xf2\x58\x51\xf2\x5e\x8c\x79\x8f\x47\x41\x4f\x24\x77\x96\x48\x0e\x42\x75\x51\xe9\xdb\xb5\xa7\x6f\xa1\x5e\x76\xbf\xb4\x5a\xf1\xc1\x7a\x79\x9b\x05\xf7\x70\xa8\x5e\xb0\xc0\xd5\xe1\x78\x3a\x71\x9c\xc9\x7c\x76\xa9\x60\x77\x77\xef\x6b\x0a\xd6\x84\x96\x54\x6f\x3c\xb1\xaf\xcd\xe5\xf4\x3e\xef\x15\xee\xf3\x66\xce\xd8\xd6\x70\xbc\x9a\xcf\x1e\x5f\x2a\x92\x90\x3c\x82\x63\x12\x98\x7b\xa2\x78\x9e\xf0\x28\x28\x7c\x19\x06\x65\x9e\x41\x21\x06\xfa\x85\x04\x5b\x56\x52\xa5\x37\xa4\xa1\x6e\xd0\x2f\x3d\x90\x6e\x39\xf8\xd2\x81\xd9\x2b\x5c\xc2\x39\x46\x3e\x88\x6b\xc8\xfc\xf4\x2d\x8d\xd8\x75\x5a\x3d\x71\xd7\x69\x07\x8d\xda\xcf\x4d\xda\x41\xbf\x51\x7b\xdb\xa8\x3d\xc6\x4c\x49\x0c\x01\x08\xb1\xe0\x6c\x0d\x66\xa1\x15\x6a\x1e\xbf\x07\x59\x14\x21\x14\x62\xb9\x33\x51\x6f\x07\x98\xca\xdd\xa1\xac\xd2\xd8\x37\xb9\x98\x03\xde\x90\xab\xc1\x95\x57\x0b\x68\xc1\x22\xee\x82\x28\x42\x70\xf8\x27\x02\x21\x45\x19\xd6\x0d\x23\x13\xf5\x6f\x6e\xfc\x92\xd4\x07\x9f\xf1\x83\x89\x6e\x3f\x7c\x9c\x92\x5c\x13\x33\x1a\xf9\x30\x55\xb7\xb0\x38\xbf\xc1\xaa\x66\xf1\x1c\x4f\xf9\x2c\xd2\x0c\x5a\x6f\xfe\x52\xec\x78\x33\x0f\xe8\xc1\x44\x8a\xfb\xd5\x4b\x37\xcd\xce\x57\xc7\x71\x79\xe3\xb6\x0b\xaa\x66\xea\xad\x8a\xa7\x79\xff\x5d\x5a\x37\xed\xcd\xd9\xd0\x53\xdf\x94\x34\xed\x22\x19\x52\xc9\xac\xd1\xef\xca\x8a\xb7\x58\xe4\x12\x48\xfb\x72\xba\xfa\x09\x52\x5c\xef\x92\xf3\xd9\x40\xaf\xc3\xe1\xe0\x91\x64\xa6\x26\x2c\xe8\xee\x07\xc9\xcb\x2d\xee\xaf\x41\x62\x3d\xed\x4f\x23\x89\xd5\xab\xe0\x2f\x58\xef\x18\xdb\x8f\x8a\xcf\x8d\xcb\x0f\x3c\x3f\xf3\x36\xfe\x4d\xdd\x8d\xd2\x73\xa5\x93\x49\x85\xd9\xd1\x05\xf0\x41\xec\xba\xd9\xdb\x06\x78\xb7\x0c\xd7\xcd\x98\xd3\x41\x68\x8b\x09\x8d\x38\xe8\x61\xf4\x1b\x26\xea\xc0\x76\x29\x81\x40\xa6\x31\xa6\xf5\x71\xf1\xd7\x28\xd8\x50\xb8\xe2\xb1\x98\xcf\xe2\xba\xc2\xcd\xcf\x97\x63\xfd\x2f\xfe\xdb\x07\x1d\x4f\xb8\x2c\x45\x23\x49\x90\x30\x23\xee\x63\x1a\xee\x70\xdf\x50\x05\xe8\x20\xc4\x23\x0a\xd9\x7f\x7f\x70\x48\xee\x39\x8b\xc2\x8c\xf6\x06\x3a\x56\x41\x3d\x2f\x74\x57\x73\xb5\x86\x4a\x3e\x59\x08\x69\xad\x73\xf5\xc8\xb6\x86\x4b\x2b\xfb\x78\x5a\x8c\xf5\xc7\xc9\x71\x6a\x24\xad\x80\x8a\xc7\x60\x7b\xee\x3c\x63\x4a\x36\x57\xb3\x27\xce\xbd\x2e\xb2\xe6\xb8\x71\x32\x27\xd6\x40\x99\x3a\xd2\x54\xd1\xe6\x37\x89\x73\x46\x9d\x36\xe4\xb9\x8a\x3e\x39\x81\xb2\x84\xe1\x8c\x41\x69\x33\x35\x7d\xd2\x56\x9e\x50\x48\x8b\x8b\xb5\xa9\x24\x93\x36\x2c\x61\x57\xd1\x4a\x1b\x16\xc8\xa5\x45\x05\x8a\x55\xd0\x4c\x5b\x49\x8e\xb7\x5b\xe2\x52\xe6\x89\x2a\x79\x08\x3c\x6b\x40\xa5\x9a\xb3\x48\x42\x59\xa3\x88\x95\x9c\x81\x65\x71\x3a\xb6\xb8\x3b\x70\xf7\x65\x45\xc6\xf6\xa2\x28\xe4\xec\xc7\x41\xbf\xf6\x45\xe7\xbf\x00\x00\x00\xff\xff\x39\x63\xa8\x3c\x09\x16\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\x5f\x73\xda\x38\x10\x7f\xe7\x53\xec\xe4\x9e\x0d\x21\x4d\xdb\xd4\x33\x7d\xa0\xe0\xe6\x98\x84\x3f\x83\x49\xee\xf2\x44\x85\x59\x8c\x26\xb2\xa5\x93\x64\xae\x4c\x9b\xef\x7e\x23\x63\x1b\x1b\x6c\x63\x7a\x2d\x7d\xb0\xb4\xff\x77\x7f\xd2\xae\x62\x59\x56\x8b\x08\xfa\x8c\x52\x51\x1e\xda\xb0\xed\xb6\x5e\x69\xb8\xb2\xc1\x45\xb9\xa5\x1e\xb6\x02\xd4\x64\x45\x34\xb1\x5b\x00\x21\x09\xd0\x86\x1f\x3f\xa0\xdd\xe7\xa1\x96\x9c\x4d\x19\x09\x31\xe1\x1c\x93\x00\xe1\xed\x2d\x61\x53\x82\x78\x09\xef\x38\x5d\x1a\xaa\x12\xe8\x19\x55\x82\x4b\xad\xcc\x87\x15\x7f\xda\x70\x7b\xfb\xae\x05\x90\xda\xd8\x68\x2d\x94\x45\x56\x01\x55\xc6\x2f\x4b\xa1\xdc\xa2\x8c\x19\x34\x91\x3e\xea\x69\x2c\xf4\xfe\xf6\xf6\x5d\x4e\xc7\xfb\x0f\x1f\x3f\xe6\x94\xf8\x52\x78\x96\x5a\xa9\x22\xc7\xdd\x31\xc7\xf7\x63\x8e\x4f\x39\x0e\xe3\xc8\x09\xc7\x5d\xf7\x98\x83\x08\x7a\xf0\x31\xc7\x78\x73\xcc\xb8\xe4\x5c\x2b\x2d\x89\x28\x65\xcf\xa7\xc0\x8f\x72\x2a\x15\x32\xf4\x34\x97\x26\x63\x00\x44\x08\x1b\x5e\xa3\x80\x58\xde\xbe\x0e\x96\x30\x85\x68\x9d\x2b\x66\xcf\xf3\x78\x14\xea\x92\x9a\x96\x28\xab\xaf\x63\x8d\x29\x4f\xa2\x6e\xe9\x9d\x88\xd5\x2e\x51\x86\xa8\x51\xb5\x29\xef\x68\xa6\xaa\x4c\xab\x95\xb2\x34\x53\x96\x87\x52\x9f\xb1\x9c\x4a\x6b\xa6\xda\x9e\xc9\x9b\xe1\x70\x57\x6a\xce\x54\x1f\xa5\x86\x9f\xb0\xfc\x70\x8b\xa1\x67\xbc\xdc\x73\xbd\xe2\x2e\xcf\xf5\x80\xbb\x02\xd3\x6f\x0e\xe5\x18\xb4\xff\x2b\xae\x5e\xaa\xcc\x1c\x32\x94\x0d\x62\x3c\x95\x68\x1c\x6f\x9f\x87\x6b\xea\x8f\x88\x68\x04\x10\x03\x97\x35\xf5\x1b\x46\xb5\x67\x6e\xef\x48\xc0\x6c\xf8\x19\xa3\xf8\x0f\x88\x14\x82\xde\x50\x05\x6b\xca\x10\x34\x07\xbe\x45\x29\xe9\x0a\x61\x85\x6b\x12\x31\x9d\x88\x45\x92\x68\xca\x43\xe0\x6b\xf8\x16\xe7\xd8\x13\xdf\xf6\x2a\x12\x45\x0a\x31\x66\xed\x24\xd4\xb6\x59\xc0\x9a\x4b\x20\x5b\x42\x19\x59\x32\x04\x85\x5a\xd3\xd0\x57\x27\xf1\x13\x21\x54\x27\x4b\xc2\x00\x05\xe3\xbb\x00\x7f\xcf\x31\x01\x60\x64\x89\x4c\xd5\x9f\xdb\xf4\x52\x34\x17\x83\x46\x7f\x67\xbe\x01\x24\x67\x8c\x86\xfe\x93\x58\x11\x8d\xfb\x2d\x80\x80\x7c\x77\x23\xe9\xa3\x0d\xdd\xc3\xce\x53\x98\x85\x69\xc3\xf5\xc9\x75\x11\x10\xed\x6d\x1e\x73\x7e\x54\x7b\x02\xa0\x31\x10\x2c\x33\x98\x4f\x01\x40\x31\x9a\x7a\x3d\x00\x69\x54\xe6\xa7\x0a\x17\xd0\xb8\x3a\x99\x86\xd9\xa8\x22\x34\x44\x99\x19\xb2\x92\xfc\x97\x71\x03\xd0\x80\xf8\x25\x7d\x69\x68\xb6\xe1\xed\xcd\x3e\x26\x24\x95\xdf\xd7\x27\xa7\x62\x1a\x31\x36\xe5\x8c\x7a\xc9\x51\x1a\x16\x37\xf3\xfc\x18\x6e\x53\xdf\x0e\xde\x3d\x3c\x8d\x7a\x0b\x67\xfc\x3c\x9c\x4d\xc6\x23\x67\x3c\xcf\x18\x00\xb6\x84\x45\x68\xc3\xd5\xe1\x16\xb9\x2a\x17\x77\xe7\x93\x99\xb3\x98\xbf\x4c\x9d\x5f\x97\x7e\x78\xfa\xe2\xcc\xc6\xce\xdc\x71\x17\xee\x8b\x3b\x77\x46\x8b\x71\x6f\xe4\xb8\xd3\x5e\xbf\x44\x69\x09\x64\x4b\x14\xdf\x3b\x63\x67\xd6\x7b\x5c\xf4\x06\xcf\xce\x6c\x3e\x74\x9d\xc1\xe2\xcf\x89\x3b\x37\x7a\xcb\x55\x56\x0f\x08\xed\x66\x16\xdd\x81\xbb\x70\x9d\xd9\xb3\x33\x5b\xdc\xcf\xa6\xfd\xc5\x74\x32\x2b\x4b\xa8\x69\xf9\x15\xc9\xf8\xbb\xb1\x86\xbb\x0a\x0d\xbd\xe9\x30\xd5\x50\x29\x7c\xd7\xad\x10\xfe\x32\x99\xcc\xdd\xf9\xac\x37\x3d\xaf\xe2\xe6\xea\x6c\x0e\xe6\x8f\xee\xa2\xef\xcc\xe6\x8b\xaf\xc3\xc7\x92\x94\x77\xb6\x44\x76\x64\x14\x76\x54\xdc\xb3\x54\x7c\x11\x9a\x46\x95\x76\xd7\x4e\xda\x5d\x3b\x49\x7f\x69\x64\xf1\xc1\x79\xf9\x3d\x06\x5f\x71\x57\x6e\x30\x87\xd5\xde\x60\x34\x74\xdd\xe1\x64\x7c\x2e\x61\xb7\xb7\xef\xae\x2e\xd7\x16\x67\x6f\x30\x9c\x5d\x1a\xcb\x71\x3f\xcf\x02\x3b\x8b\x99\x99\xd3\x1b\x2c\x26\xe3\xc7\x97\x53\x93\x57\x5a\x46\x78\x08\x82\x48\x3f\x77\xa9\x5a\x20\xa3\x30\xb7\xb2\x2c\xc6\x7d\x8b\xe1\x16\xd9\x67\x1a\xae\x79\x81\x64\x3a\x1d\xf5\x2d\xd3\x41\x3f\x77\x50\x7b\x45\xe7\x0b\x17\x66\x27\xd7\x84\x33\x1d\xd9\x20\x9e\xaa\xcc\x6e\xdf\xc2\x88\x5d\x45\x4d\x27\xee\x2a\xea\x5d\x2d\xf5\x53\x1d\xf5\xae\x5b\x4b\xbd\xa9\xa5\x1e\x7c\x66\x74\x8b\x21\x2a\x35\x95\x7c\x99\xb5\x51\xf3\xdf\x8c\xf6\xf7\xa8\xf3\x5b\x00\x82\xe8\x8d\x0d\x9d\x0d\x12\xa6\x37\x07\xcc\xa6\x99\x8a\x2d\x5f\x67\x96\x25\x92\x15\xbd\x58\xb9\x91\x6a\xa0\x5a\xf1\x48\x7a\x98\xab\x8d\xb1\xf7\x4f\x84\x2a\x5f\x2f\xf3\xf3\x44\x64\x43\xf7\xfa\x3a\x28\xec\x06\x18\x70\xb9\xb3\xe1\xe6\xfd\x87\x11\xcd\x28\x5b\xce\xa2\x00\x47\xa6\x0b\x2b\xfb\x04\xc1\x65\xb3\x78\xfa\x2f\x30\x32\xd3\x7d\x7a\x1a\x1f\xfe\x9c\xbc\x89\x7a\x12\xb2\x9d\x0d\x06\xfb\xe5\xa6\xeb\x66\xe7\x8b\xfd\x38\x7f\x70\x9b\x39\x55\x31\xf5\x96\xf9\x53\x7f\xfe\xce\xd9\xdd\xd7\x26\x2b\xcb\xf9\xa2\xec\xc3\x3e\x94\x31\xdd\x19\xd7\xca\x5d\x98\xf1\x06\x46\xce\x29\x69\x9e\x4e\x2f\x7d\x82\xe4\xed\x9d\x13\x3e\x19\xe8\x53\x77\x24\xfa\x34\x9e\xa9\x29\x0f\xdb\xaf\x77\xf1\x23\x74\xdb\x5d\xa2\x26\xe9\xb4\x3f\x8a\x34\x31\xaf\x82\xbf\x70\xb9\xe1\xfc\xb5\x9f\x7f\x6e\x54\xcd\xff\x87\x68\x83\x44\xda\xfa\x77\x2f\x6e\x79\x05\xf9\x64\x57\xd9\xad\x34\x01\x01\xaa\x4d\x3b\x79\xdb\xa0\x6c\x17\xd5\xb5\x13\xe4\xb4\x00\xd6\x84\xb2\x48\x62\x3a\x8c\x7e\x25\x94\x99\x37\x14\xa3\x18\xea\xbd\x8f\xfb\xfc\x78\xe4\x4b\x14\xae\x18\x56\x3d\xfd\x4a\x1e\x8b\xd9\x2c\x9e\x66\xb8\xfe\xf9\x72\xc8\x7f\xfd\x54\xd7\x2a\xdc\x70\x49\x88\x96\x89\xc8\xa2\xdc\xda\x76\x09\x13\x1b\xd2\xb5\x4c\x02\x5a\x00\x32\x62\x98\xfc\xf5\x87\x08\x7a\x2f\x79\x24\x12\xd8\x5b\x70\xc8\x02\xc0\xa1\xaa\x19\x39\x55\x15\x2f\xb9\xc0\x7d\xae\x33\x72\x7f\xe6\xf4\xe6\x4e\xb2\x78\x9a\x0e\xd2\xc5\xd1\x75\x6a\x81\xf1\x04\x4b\x1e\x83\xcd\xb1\xf3\x4c\x18\x5d\x5d\x8c\x9e\x6d\x26\x75\x16\x35\x87\x83\x93\x08\xf1\x1a\xc8\x54\x81\xa6\x0c\x36\xbf\x08\x9c\x13\xe8\x34\x01\xcf\x45\xf0\xc9\x00\x94\x04\x8c\x27\x08\x8a\x19\x33\xf8\x00\x94\x40\x08\xe0\x14\x48\xa5\x60\x02\x28\x81\x54\x19\xac\x00\x4e\xc0\x05\x70\x02\xb1\xca\xae\x6d\x81\x96\x64\xbd\xa6\x1e\xe3\xbe\x2a\xdb\x17\x28\x93\x02\x94\x92\x25\x8f\x34\x16\x29\x06\x58\x71\x3f\x2a\x6e\xef\xc7\x16\x6f\x83\xde\x6b\x91\x90\xa0\x3d\xdd\x30\x5b\x42\xf2\xef\x3b\x8d\x81\x60\x44\xa3\x6a\xfd\x37\x00\x39\x63\xa8\x3c\x09\x16\x00\x00"),
 		},
 		"/control-plane/kuma-cp/rbac.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "rbac.yaml",
-			modTime:          time.Date(2019, 12, 16, 8, 31, 53, 656484814, time.UTC),
-			uncompressedSize: 1975,
+			modTime:          time.Date(2026, 10, 19, 1, 26, 47, 560800103, time.UTC),
+			uncompressedSize: 2867,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x54\xc1\x8e\xd3\x40\x0c\xbd\xe7\x2b\xac\xe5\x9c\xac\xb8\xad\x72\x03\x0e\x5c\x10\x87\x5d\xc4\x7d\x3a\x71\x13\x93\xc9\xcc\xc8\xf6\x64\x81\xd5\xfe\x3b\x9a\xa4\x05\x4a\xa0\xa4\xa5\xa8\xa7\xd8\x8e\xed\xf7\xec\xb1\x5e\x51\x96\x65\x61\x22\x7d\x44\x16\x0a\xbe\x06\xde\x18\x5b\x99\xa4\x5d\x60\xfa\x6a\x94\x82\xaf\xfa\x3b\xa9\x28\xdc\x8e\x2f\x8b\x9e\x7c\x53\xc3\x1b\x97\x44\x91\xef\x83\xc3\x62\x40\x35\x8d\x51\x53\x17\x00\xde\x0c\x58\x43\x9f\x06\x53\xdb\xe0\x95\x83\x2b\xa3\x33\x1e\x0b\x4e\x0e\xa5\x2e\x4a\x30\x91\xde\x72\x48\x51\x72\x7a\x09\x37\x37\x05\x00\xa3\x84\xc4\x16\x77\xb1\xdc\x44\xa2\xb1\x28\x93\x1b\x43\x33\x1b\x82\x3c\xd2\x1c\x1d\x91\x37\xbb\xec\x16\x75\xfa\x3a\x92\xd9\x78\x34\x6a\xbb\x25\x52\x26\x55\x51\x58\xc2\x65\xee\x13\x49\x39\x74\xc9\x0b\xb5\x9d\xce\xd1\x01\xa5\x5b\x89\x9c\x2d\xcb\x68\x14\x27\x33\xc5\x66\x6f\xc6\xef\xff\x1b\x74\xa8\x78\x02\xc9\x0e\x8d\xd3\xce\x76\x68\xfb\x4b\xcf\x1f\x39\x7c\xfe\xa2\x38\x44\x67\xf4\x9a\x23\x1e\xf2\xb8\x15\x35\x9a\xfe\x40\x67\x01\xb8\x1e\x45\xd9\x6c\xb7\x64\x23\xf2\x40\x92\xef\xfd\xd2\xeb\xdc\x01\xb8\xd0\xfe\xa7\xce\x1c\xd2\xda\x77\x7a\x01\xa3\x71\x94\x77\x05\xfd\x9d\x80\x86\x1e\x3d\x6c\x70\x1b\x18\x81\x44\x12\x92\x6f\x61\xf8\xf0\xee\x01\x2c\xb2\x2e\xa9\x64\x11\x40\xaf\x64\x7f\x56\x81\xdf\x10\xcb\x7d\x19\x47\xc2\xc7\x5f\x78\xed\x8e\xe4\xdf\x14\xe6\x35\xf9\x86\x7c\xbb\x52\x68\x82\xc3\x7b\xdc\xe6\x9c\xfd\x30\x47\xf0\x0a\x80\xa5\xa0\x1d\xe9\x2e\x69\xf3\x09\xad\x4e\x4a\x36\x17\x3e\xcc\xa2\xf4\xca\xda\x90\xbc\x1e\xd4\x96\x87\xb5\xf0\x43\xd8\x6a\x78\x7a\x82\xea\xfd\xde\x85\xe7\xe7\x73\x56\xb4\x5e\x7d\x8f\x43\x9f\xa2\xcd\x82\x96\x51\x2f\xaf\x12\xe7\x4d\x7f\xd2\x65\xfc\x65\x09\xe7\xdd\xcd\xf5\x0e\xe6\x5b\x00\x00\x00\xff\xff\x7b\xff\xff\xd3\xb7\x07\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x96\x41\x6f\xdb\x3a\x0c\xc7\xef\xfe\x14\x44\x73\xad\x53\xbc\x5b\x61\xbc\x3e\xe0\x6d\x03\x86\x01\xc3\x30\xb4\xc1\x4e\x3b\x54\x96\x69\x9b\xb3\x22\x19\x22\x95\x2c\x2b\xfa\xdd\x07\xd9\x4e\xdb\xc4\x5d\xea\xb4\x2b\x7a\x8a\x6c\xc8\x7f\xfe\x48\x2a\x7f\x2a\x49\xd3\x34\x51\x2d\x7d\x43\xcf\xe4\x6c\x06\x3e\x57\x7a\xae\x82\xd4\xce\xd3\x2f\x25\xe4\xec\xbc\x39\xe7\x39\xb9\xb3\xd5\x3f\x49\x43\xb6\xc8\xe0\xbd\x09\x2c\xe8\x2f\x9d\xc1\x64\x89\xa2\x0a\x25\x2a\x4b\x00\xac\x5a\x62\x06\x4d\x58\xaa\x4c\x3b\x2b\xde\x99\xb4\x35\xca\x62\xe2\x83\x41\xce\x92\x14\x54\x4b\x1f\xbd\x0b\x2d\xc7\xed\x29\x9c\x9c\x24\x00\x1e\xd9\x05\xaf\x71\x78\x17\x45\xb8\x55\x1a\xb9\xdb\xd2\xba\xa2\x5f\x30\xfa\x15\xf5\x6f\x57\xe8\xf3\x61\x77\x85\xd2\xfd\x1a\xe2\x7e\xb1\x56\xa2\xeb\x64\x06\xe2\x95\x65\xa3\x04\xe1\x93\xad\x3c\x32\x23\x83\x2b\xe1\x3a\xd2\x5d\x83\x36\x8a\x19\xc8\x8a\x83\xaf\xde\xfd\xdc\x2c\x70\xd9\xc6\xcd\xdd\x9e\x4a\x09\xae\xd5\x86\xc7\xbc\x16\x65\xed\x7c\x43\xb6\x1a\x6a\x32\xc6\xa7\x6d\xb4\x89\x9c\x1e\x5b\xe7\xe5\x01\xa4\xd4\x4a\x40\xbb\x60\x0a\xb0\x4e\x20\x47\x28\x83\x31\x9b\xfb\x84\x8a\x69\x75\xc4\x15\x5a\xd9\xa3\xd0\x1e\x95\x60\xb7\x6c\xbb\xf8\x23\xa5\x58\x9e\x47\xf3\x8a\x3d\xee\x9a\xc9\xbb\x8f\x64\x99\xaa\xba\x0b\x94\xc2\x12\xb9\x9e\x98\xf9\x1e\x4e\x68\x8b\x5d\xb2\xb8\x2a\xd0\xa0\xe0\x11\x90\x35\x2a\x23\xb5\xae\x51\x37\xd3\x28\xa6\x4b\xb7\xf1\x98\xc8\xf6\x98\xbc\x5d\x8a\xbb\x1c\x67\x2c\x4a\xc2\x1f\x70\x46\x01\xa7\x47\x11\xaf\xca\x92\x74\x8b\x7e\x49\x1c\x7d\x61\x5a\xc6\x47\x07\x30\xae\x7a\x25\x65\xef\xc2\xd4\x3e\xcd\x60\xa5\x0c\xc5\x5a\x41\x73\xce\x20\xae\x41\x0b\x39\x96\xce\x23\x10\x73\x40\xb2\x15\x2c\x17\x9f\xaf\x40\xa3\x97\x31\x4a\x34\x4b\xb4\x42\xfa\xa1\x5b\x3e\x02\x16\x75\x3d\xae\x08\xd7\x7b\x5c\xc3\x21\x79\x99\x13\xbf\x23\x5b\x90\xad\x26\x1a\xb2\x33\x78\x89\x65\x04\xdb\x26\x73\x20\x5e\x02\x30\x36\xfe\x03\xea\x1c\xf2\x1f\xa8\xa5\x73\xfc\xfe\xc3\xab\xde\xbc\xff\xd7\xda\x05\x2b\x3b\xdf\xa6\xbb\xdf\xc2\xfd\x00\xc8\xe0\xe6\x06\xe6\x5f\xb6\x8f\x70\x7b\xdb\x0d\xab\x59\xa7\x86\xb0\x6d\x08\x95\xb1\xf2\xc8\xe0\xb1\x44\x8f\x56\x63\x01\xf9\xe6\x80\xed\x8b\xdb\x5a\x3c\x7c\xb8\x73\xb5\x79\x32\x83\x45\x4d\x0c\xde\x99\xd8\xf6\xde\x7c\x5d\xb0\x05\xe8\xbe\xc8\xe9\x9a\x0a\x9c\xc3\xc2\x01\x5a\x95\x9b\x1e\xc0\x59\x50\x5b\xb5\x53\xc8\xc9\x16\x40\x02\x64\x01\x57\xe8\x37\xf7\xb9\x24\x33\x58\x93\xd4\xfb\x3e\x7f\x87\xdc\x89\x5d\xa1\xf6\x28\x7c\x0a\x38\xaf\x22\x0f\x40\x13\x72\xd4\x62\x06\x1b\xe9\xd8\x62\x8c\x78\x20\xc7\x75\xcf\x86\xd9\x93\x8a\x61\x48\x2d\xfc\x7b\x17\xfd\x3f\xf8\xde\xc9\x01\xa4\xe9\x90\x4d\x94\xba\x78\x4a\x23\x1d\x86\xae\xea\xfb\x76\xb1\xdf\x8f\xec\x91\x06\xbe\xf2\x5d\xe2\x21\xe0\x51\xf7\x0a\xee\x8b\x3b\x76\x84\x67\xfc\xed\xa6\xdf\x7c\x0e\x1f\xe7\xbf\xc2\xff\xc2\xc9\xf3\xbc\xec\x8f\x72\x9b\x27\x8a\xf0\x3c\x2f\x7a\x3b\x13\xfa\x3d\x00\x15\xe3\xff\xa0\x33\x0b\x00\x00"),
 		},
 		"/control-plane/kuma-injector": &vfsgen۰DirInfo{
 			name:    "kuma-injector",
-			modTime: time.Date(2020, 1, 3, 12, 9, 55, 809010261, time.UTC),
+			modTime: time.Date(2020, 1, 22, 16, 48, 57, 0, time.UTC),
 		},
 		"/control-plane/kuma-injector/app.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "app.yaml",
-			modTime:          time.Date(2020, 1, 3, 12, 7, 44, 172865235, time.UTC),
-			uncompressedSize: 4073,
+			modTime:          time.Date(2026, 10, 19, 2, 26, 37, 300337351, time.UTC),
+			uncompressedSize: 4544,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x57\xdd\x73\x9b\x38\x10\x7f\xe7\xaf\xd0\xe4\x1d\x7f\xb4\x71\x2e\x65\xa6\x0f\xae\xe3\x4b\x7d\x89\x3f\x06\x93\xf6\xd1\x23\x8b\x0d\xd6\x45\x48\x9c\x24\xb8\x7a\xda\xfc\xef\x37\x42\x40\x20\x18\x3b\xbe\x94\x27\xd8\xcf\xdf\xee\x4a\xbb\x8b\xeb\xba\x0e\x4e\xe8\x37\x90\x8a\x0a\xee\xa1\x6c\xe8\x3c\x51\x1e\x7a\x68\x0d\x32\xa3\x04\xc6\x84\x88\x94\x6b\x27\x06\x8d\x43\xac\xb1\xe7\x20\xc4\x71\x0c\x1e\x7a\x4a\x63\xec\x52\xfe\x37\x10\x2d\x64\x41\x55\x09\x26\xe0\xa1\x9f\x3f\x51\x6f\x51\x7e\xa2\xe7\x67\xe7\x94\x97\x77\x9a\x57\x09\x10\xa3\x99\x08\xa9\x95\x79\x71\xf3\x57\x0f\x5d\x5e\x7e\x74\x10\x2a\x4d\xee\xb4\x4e\x54\xfe\xad\xb1\x8c\x40\xaf\x72\x99\x6b\x2b\xa4\x80\xe5\xae\xbc\x5c\x00\x27\xc9\x6b\x08\x47\x62\x20\x12\xb4\xa3\xf7\x49\x0e\x7b\x0b\x92\x83\x06\xd5\xa3\xa2\xaf\x99\x3a\x15\x9a\xab\x99\x72\x09\x48\x7d\x22\xc6\xd2\x84\x66\xaa\x47\x0c\x6e\x23\x31\x2b\x8c\x04\x4c\x4d\x40\x6a\xf4\x0b\x6d\xaf\x2e\x81\x13\xa3\x60\x45\x9f\x60\xdf\x12\xbd\x83\x7d\x43\xb2\x33\xb2\x89\xe0\x8f\x34\x9a\xe3\xe4\x64\x10\x24\x97\x7c\x63\x08\x56\xb8\xb7\xc7\x31\xf3\xd0\xaf\x3c\xdf\xa5\x21\x9b\x7d\x84\x14\x0d\x81\x60\x39\x11\x5c\x63\xca\xa1\xa2\x23\x24\x21\xa4\x12\x48\x51\xbc\xe1\x68\x30\x18\x56\xbc\x94\x86\x1e\x1a\x5d\xfd\x71\x5d\x51\xa2\x16\x05\x87\x31\xe5\x56\xf9\xd3\xa7\x9a\x6e\x28\x31\xe5\x01\x35\xa1\x7d\x1c\x28\xa7\xe6\x0f\x87\x94\x83\x52\x2b\x29\xb6\xf0\x82\xc3\x60\xa6\x9a\x62\x76\x03\x0c\xef\xd7\x40\x04\x0f\x95\x87\x86\x35\x01\x4d\x63\x10\xa9\xae\x78\xf9\xf3\xb1\x26\x90\x80\xa4\x22\x6c\xf2\xd1\xa8\x26\xa0\x52\x42\x40\xa9\x60\x27\x41\xed\x04\x0b\x73\x99\xba\x8b\x47\x4c\x59\x2a\xe1\x95\xc0\x87\x4a\x82\xd1\x0c\xde\x0e\xfe\x6a\xf0\x5b\xd1\x9f\x02\x27\x41\x89\x54\x12\x50\x75\x60\x12\xfe\x49\x41\xe9\x06\x0d\x21\x92\xa4\x1e\x1a\x0d\xe2\x06\x31\x86\x58\xc8\xbd\x87\xae\x2e\xe7\xb4\xc6\x60\x34\xa6\x07\xf5\x87\x83\x41\x87\x85\xd1\xf0\xc3\x9c\xb6\xee\x01\x4e\x12\xd5\xaf\x2e\xc3\x0d\x24\x4c\xec\x63\x78\x77\x33\x44\x88\xe1\x2d\x30\xd5\xd9\x69\xca\x76\xa6\xb4\xc4\x1a\xa2\xbd\x15\x94\x82\x31\xca\xa3\x87\x24\xc4\xba\x2a\x65\x8c\x7f\xac\x53\x19\xc1\xcb\xc1\x8b\xf1\x8f\x07\x8e\x33\x4c\x19\xde\x32\xf0\xd0\xa0\xd5\xdb\x62\xac\xc9\xee\xbe\x06\xe1\x20\x08\x84\x34\xc4\x09\xab\x7c\xd5\x63\xce\xb3\xdc\x30\xd0\x61\x02\xa1\x32\x96\xfc\xbd\x31\x52\x16\x07\x13\x97\x17\xab\xbc\xf5\x95\x79\xb7\x23\xcd\xc5\x59\x8e\x71\x04\xcd\x2e\x37\x33\x24\xf4\xfc\xec\x19\xa2\xe9\x22\x52\xb0\x15\xc3\x1c\x8a\xe2\xda\x3a\xd4\xd4\x57\x29\x63\x2b\xc1\x28\x29\xdb\x65\x93\x58\x97\x07\x9e\xbd\x84\x5d\x22\xbb\x7b\x98\x8f\x37\xb3\xc5\x5f\xd3\x49\xb0\xf4\x37\xdf\xa7\x5f\xbe\x2e\x97\x77\x9b\xf5\xd4\xff\x36\xf5\x37\xab\xa5\x1f\xd4\xce\x5d\x86\x59\x0a\x1e\xba\x30\x93\xe7\xe2\x3c\x4b\x93\xa9\x1f\x6c\x6e\x66\x7e\xdb\x5a\x3f\xc3\xb2\x2f\x53\xde\x57\xf9\x38\x52\x7d\x93\x2b\x33\x83\x1a\x39\xeb\xd7\xa6\xcd\x31\xb7\x93\xe5\x22\xf0\x97\xf7\x9b\xd5\xfd\x78\x31\xdd\x7c\x59\x2e\x83\x75\xe0\x8f\x57\x25\x8c\x07\xff\xbe\x8d\xc0\x8c\x57\xaf\x6f\xfd\x11\x9b\x72\x37\x31\x39\xef\xbd\xbe\x02\xde\xe8\xea\xfa\xc3\x59\x08\xc6\xab\xd9\x6f\xf4\x3d\x3c\xe1\x7b\x3d\xbb\x99\x4e\xc6\x16\xc3\x78\xb6\x98\xfa\x9b\xd9\x7c\x7c\x3b\x6d\xbb\x35\xc6\x6f\xb0\xc6\xb9\xab\x73\xce\xdc\x61\xbf\xb3\xc5\x2c\x38\xd7\x29\xa7\xfa\x1c\xc7\x58\x46\xaa\x7e\x7a\x65\xca\x6b\x5f\xae\xcb\x44\xe4\x32\xc8\x80\x7d\xa6\xfc\x51\x34\x58\x76\x6c\xbb\x8f\x94\xc1\xe7\x3e\x68\xd2\x71\xc2\x6a\xd3\xbd\x52\xaf\x16\xb3\xd2\x5a\x75\xc5\x1b\x3b\x98\x7d\x3a\x67\x96\x29\xf2\x2d\xe8\x66\x67\x4f\xb0\xde\x79\xa8\xbf\x03\xcc\xf4\x6e\xdf\x64\xb5\x6d\x9b\x47\x91\x1d\x98\xdc\x7f\x0d\x82\xd5\xfa\x0d\x73\xfe\x98\x5b\xa3\xf5\x2e\xa7\xe7\x4d\xc0\x61\xd7\x00\x6b\x8c\xc0\x4c\xb0\x34\x86\xb9\x69\xb2\xaa\xdd\xa9\x3a\xb7\xcf\xca\xa6\x51\x5c\xd9\xf8\xfe\x67\x53\xb1\xe9\x5c\x72\xb6\xf7\x90\x96\x29\x9c\x00\x51\x6d\x8f\x87\x20\x74\x9e\xb4\x53\xde\x6c\x1a\x8e\xcf\x90\x76\xfc\x36\xd2\x7a\xee\x2d\xe5\xc0\xb8\x7a\xad\xfc\xa6\xe0\x48\xb9\x53\xd7\x5d\x1c\xd1\x6b\x2d\x25\x61\x4c\x95\x79\x95\x10\xd1\x7c\x43\xa0\x82\xf7\x9e\xae\xf3\xff\x8d\x6c\xb8\x05\x8d\xcb\x8d\x65\x9e\x6a\xac\x29\x8f\xbe\xc3\x76\x27\xc4\x93\xdd\xe6\x53\xab\x71\x72\xa3\xff\xd7\x2a\x15\x30\x4a\xad\x82\xaa\x3c\xe7\x60\xb0\xbd\xa2\x50\xf5\x0d\x68\x7d\x6a\xf9\x28\x8b\x5b\xec\xfc\x85\xb1\x3c\x58\xe0\x66\x89\x09\x9d\x6a\x97\x6c\x8c\xe8\xc2\xe9\x9f\x75\x96\xed\x75\x84\x51\xe0\xda\x06\x6c\xdd\x10\xfc\x25\xe5\x21\x83\xb7\xfc\x37\x55\x9b\x4a\x89\xf0\xf8\x36\xd7\x51\xc0\x82\x53\x34\x0a\x4b\x75\x8b\x18\x1d\x84\x64\xca\xa0\xf8\x57\xc5\x09\xbd\x95\x22\x4d\x8a\x8c\xb8\xe8\xe2\xa2\x58\x0d\xcb\xb2\x57\x9c\xcc\x8e\x2e\x91\x80\x2d\x48\xc5\x98\xf8\xd3\x71\x60\x87\xc5\xab\xa6\x62\xfe\x85\x43\xe5\xfc\x17\x00\x00\xff\xff\x9e\x8c\x30\xf6\xe9\x0f\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\x4d\x73\xda\x48\x13\xbe\xeb\x57\x74\xf9\x3d\xbc\xbb\x55\x11\x1f\x89\xed\x75\x54\x95\x03\xc1\x6c\xc2\xda\x06\x0a\x70\x72\xc4\xcd\xa8\x8d\x66\x3d\x9a\xd1\xce\x8c\x70\xa8\xc4\xff\x7d\x6b\xf4\x65\xc9\x18\xb0\x37\x29\x5d\x44\x4f\x7f\x3c\x4f\xcf\x4c\x77\x0b\xdf\xf7\x3d\x4c\xf8\x17\xd2\x86\x2b\x19\xc0\xba\xeb\xdd\x71\x19\x06\x30\x23\xbd\xe6\x8c\x7a\x8c\xa9\x54\x5a\x2f\x26\x8b\x21\x5a\x0c\x3c\x00\x89\x31\x05\x70\x97\xc6\xe8\x73\xf9\x37\x31\xab\x74\x21\x35\x09\x32\x0a\xe0\xfb\x77\x68\x8d\xca\x9f\xf0\xf0\xe0\x1d\x8a\xf2\x93\xee\x4d\x42\xcc\x01\x4b\x94\xb6\xc6\xbd\xf8\xd9\x6b\x00\xc7\xc7\xef\x3c\x80\xd2\x65\x64\x6d\x62\xb2\xdf\x16\xf5\x8a\xec\x24\xd3\x39\xcb\x95\x0c\x89\x8c\x89\x33\x07\xc0\x24\x79\x0a\x61\x0f\x07\xa6\xc9\x7a\x76\x93\x64\xb0\x97\xa4\x25\x59\x32\x2d\xae\xda\x56\x98\x43\xd4\x7c\x2b\x8c\xcf\x48\xdb\x03\x1c\x4b\x17\x56\x98\x16\x73\xb8\x9d\xc6\xb0\x70\x32\x17\xa6\x4f\xda\xc2\x0f\x58\x9e\x1e\x93\x64\x2e\xe7\xb9\xea\x1d\x6d\xb6\x54\x2f\x68\xd3\xd0\xdc\xc9\xac\xaf\xe4\x2d\x5f\x5d\x61\x72\x90\x04\xcb\x34\x5f\x48\x21\x57\x6e\x6d\x30\x16\x01\xfc\xc8\xf2\x5d\x3a\x72\x0c\xdd\x63\x78\x48\x0c\x75\x5f\x49\x8b\x5c\x52\x25\x07\xd0\x14\x72\x4d\xac\xd8\xbc\xee\x49\xa7\xd3\xad\xd6\x52\x1e\x06\x70\x72\xfa\xc7\x59\x25\x59\x6d\x49\x30\x8c\xb9\xcc\x8d\xdf\xbf\xaf\xd9\x86\x1a\xb9\x9c\x73\x77\x4e\xde\x75\x8c\x57\xc9\x35\x61\xc8\x25\x19\x33\xd1\x6a\x49\x8f\x38\x00\xb8\xe4\x96\xa3\x38\x27\x81\x9b\x19\x31\x25\x43\x13\xc0\xa3\x43\x00\xcb\x63\x52\xa9\xad\xd6\x9c\x0c\xde\xd5\x14\x12\xd2\x5c\x85\xcd\x75\x38\xa9\x29\x98\x94\x31\x32\x66\x1e\x69\x32\x91\x12\x61\xe0\xa4\xf5\x10\xb7\xc8\x45\xaa\xe9\x89\xc2\xdb\x4a\x43\xf0\x35\xbd\x1c\xfc\x69\xe7\x97\xa2\x3f\x04\x4e\x93\x51\xa9\x66\x64\xea\xc0\x34\xfd\x93\x92\xb1\x0d\x19\x00\x4b\xd2\x00\x4e\x3a\x71\x43\x18\x53\xac\xf4\x26\x80\xd3\xe3\x2b\x5e\x5b\x10\x3c\xe6\xcf\xda\x77\x3b\x9d\x1d\x1e\x4e\xba\x6f\xaf\xf8\xd6\x0d\xc7\x24\x31\xed\xea\x32\x9c\x53\x22\xd4\x26\xa6\x9f\x2e\x86\x00\x02\x97\x24\xcc\xce\x4a\x53\x96\x33\x63\x35\x5a\x5a\x6d\xdc\x3b\x80\x56\x42\x70\xb9\xba\x4e\x42\xb4\xd5\x56\xc6\xf8\x6d\x96\xea\x15\x3d\x1e\xbc\x18\xbf\x5d\x4b\x5c\x23\x17\xb8\x14\x14\x40\x67\xab\xb6\xc5\x68\x59\x74\x59\x83\xf0\x2c\x08\x00\x4b\x71\x22\xaa\x58\x75\xce\x00\x4d\x0e\x3b\x5d\x00\x94\x5c\xdc\x63\x1a\x2d\x65\xf4\x6c\xe2\xdc\xc3\xca\x5b\x5f\xb9\xf7\x77\xa4\xb9\x38\xcb\x31\xae\xa8\x59\xe5\x86\x4e\x04\x0f\x0f\x81\x13\xba\x2a\xa2\x95\x98\x08\x94\x54\x6c\x6e\x5e\x20\x6b\xe6\x93\x54\x88\x89\x12\x9c\x95\xe5\xb2\x29\xac\xeb\x93\x5c\x97\xb8\x1e\x91\x5d\x5c\x5f\xf5\x16\xc3\xd1\x5f\x83\xfe\x7c\x3c\x5d\x7c\x1d\x7c\xfc\x3c\x1e\x5f\x2c\x66\x83\xe9\x97\xc1\x74\x31\x19\x4f\xe7\x95\x05\xc0\x1a\x45\x4a\x01\x1c\xb9\xce\x73\xf4\x3a\x4f\xfd\xc1\x74\xbe\x38\x1f\x4e\xb7\xbd\xb5\xd7\xa8\xdb\x3a\x95\x6d\x93\xb5\x23\xd3\x76\x99\x6d\x71\xd5\x6e\xe4\xac\x5d\xeb\x36\xfb\xc2\xf6\xc7\xa3\xf9\x74\x7c\xb9\x98\x5c\xf6\x46\x83\xc5\xc7\xf1\x78\x3e\x9b\x4f\x7b\x93\x12\xc6\xf5\xf4\x72\x1b\x81\x6b\xaf\x41\x3b\x8f\xe7\xb6\x50\x2b\xe1\x27\x2e\xe7\xad\xa7\x57\x20\x38\x39\x3d\x7b\xfb\x2a\x04\xbd\xc9\xf0\x17\xc6\xee\x1e\x88\x3d\x1b\x9e\x0f\xfa\xbd\x1c\x43\x6f\x38\x1a\x4c\x17\xc3\xab\xde\xa7\xc1\x76\x58\x47\xec\x1c\x2d\x66\x34\x5f\x73\xe6\x9e\x8f\x3b\x1c\x0d\xe7\xaf\x0d\x2a\xb9\x7d\x4d\x60\xd4\xab\xda\xa5\xf5\x41\xa7\xb2\xf6\xcb\xf7\x85\x5a\xf9\x82\xd6\x24\x3e\x70\x79\xab\x1a\x4b\x79\xdb\xf6\x6f\xb9\xa0\x0f\x6d\xb2\x6c\xc7\x09\xab\x75\xf7\xca\xbc\x1a\xcc\x4a\x6f\xd5\x15\x6f\xcc\x60\x07\x7a\x96\x3b\x60\x9f\xc8\xd6\x45\x00\x09\xda\x28\x80\x76\x44\x28\x6c\xb4\x69\x2e\x6d\xfb\x76\x8f\x61\x11\xb9\xdc\x7f\x9e\xcf\x27\xb3\x17\xf4\xf9\x7d\x61\xdd\x74\xf0\x53\x41\x5f\xd7\x01\xbb\x9d\x97\xb4\xc0\xb5\x12\x69\x4c\x57\x6e\x6e\x37\xc1\xd6\x91\xdb\x39\x7d\x96\x4f\xec\x0c\x27\x39\xbf\xff\x58\x54\x5c\x6f\xc7\x70\x2c\xc5\x26\x00\xab\x53\x3a\x00\xa2\x9a\x1e\x9f\x83\xb0\xf3\xa4\x1d\x8a\x96\xa7\xa1\xca\xc0\x0b\xf9\xe7\x4c\x1f\xd3\x56\x4a\x46\x87\x8d\x5f\x44\x8e\x95\x33\x75\x3d\xc4\x1e\xbb\xad\xa1\x24\x8c\xb9\x71\xaf\x9a\x56\x3c\x9b\x10\xb8\x92\xad\xbb\xb3\xec\x7b\x63\xdd\x5d\x92\xc5\x72\x62\xb9\x4a\x2d\x5a\x2e\x57\x5f\x69\x19\x29\x75\x97\x4f\xf3\x69\x6e\x71\x68\x86\xf1\xef\x73\x23\x9f\x35\xac\x0a\xa9\x09\xbc\x67\xc9\xb6\x8a\x8d\xf2\x00\xfe\x07\x17\x69\x8c\x50\x36\x64\x08\x89\xf1\x90\x0c\xdc\x47\x64\x23\xd2\x60\x15\xe4\xb1\x00\x61\xa2\x42\x40\xc6\x94\x0e\xb9\x5c\xb9\x95\x9b\x72\xc3\x8b\xef\x80\x22\x00\x57\xf2\x26\x73\x8d\x52\x2a\xc7\x4d\x49\x50\x3a\x1f\xa7\x40\x49\xb0\x11\xe5\xbe\x64\x08\xdc\x1a\xa8\xea\xfe\x1b\x30\xaa\x88\xc3\x50\x82\x4a\x2c\x70\x09\xb4\x26\x09\xfc\xb6\xa9\x0a\x11\x1a\x90\x2a\x77\xda\xca\xa2\x55\x6b\x26\x97\x52\xb8\x07\x60\x00\x21\x37\x6e\xf4\x0a\x6f\xe0\x37\x6a\xad\x5a\x59\x1e\xfe\x6f\x40\xdd\x4b\x50\x92\x7e\x07\xd4\x04\x92\xd6\xa4\xc1\x90\xb4\x8e\x6e\x23\x53\x6f\xb2\x98\x46\x39\x36\x5c\x3b\xcc\x26\x03\xbd\x24\x60\x9a\xd0\x52\x98\x03\xbf\x8f\x48\x3e\xc9\x31\x37\x10\xaa\x7b\xd9\x82\x6c\xba\x73\x20\x97\xe4\x9b\x8d\xb1\x14\xdf\x38\x77\x60\x30\x26\xb8\xc7\x4d\xab\xd8\xf4\x8c\xd5\x6c\x7b\x3a\x1c\x7c\x4b\x34\x65\xa7\xac\xb8\x3e\x3e\x64\x1f\x92\x3b\x59\x17\x67\x59\x25\xa4\xd1\xb9\x82\x91\xb2\xc3\xb2\xb3\x64\x3d\xba\x76\x0f\xcb\x04\x79\xd5\x27\x42\x63\xf2\x2a\xd8\xfc\x59\x5f\xca\x5b\x18\x13\x9c\xa4\xcd\xcf\x71\xee\x8e\xe1\xc7\x54\x86\xa2\xe8\x8a\xfb\x3f\x87\xab\x01\xb4\x44\xb2\x7f\x48\xdf\x71\x2f\xbd\x46\xfd\xcf\x13\xe0\x17\xf9\xf0\x00\x74\x2a\xa8\xf8\x0b\x02\x13\xfe\x49\xab\x34\xa9\x52\x78\x74\x54\x4c\xfc\xe5\x6d\xae\x56\xd6\xf9\x44\x92\xa7\xaf\xbe\xd0\x9f\x0e\x7a\xf3\x81\xf7\x4c\xaf\xf0\x21\x51\xa1\xf1\xfe\x1d\x00\x64\xef\xd7\x76\xc0\x11\x00\x00"),
 		},
 		"/control-plane/kuma-injector/rbac.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "rbac.yaml",
-			modTime:          time.Date(2020, 1, 3, 12, 9, 55, 808337435, time.UTC),
-			uncompressedSize: 462,
+			modTime:          time.Date(2026, 10, 18, 22, 54, 23, 525492236, time.UTC),
+			uncompressedSize: 546,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x50\xbd\x4e\xc4\x30\x0c\xde\xfd\x14\x7e\x81\x14\xb1\xa1\x6c\xc0\xc0\xc6\x70\x48\xec\xbe\xd4\x5c\x4d\xdb\xb8\xb2\x93\x22\x71\xba\x77\x47\x6d\xef\x90\x10\x08\x06\xa6\x38\xdf\x4f\xe2\xef\x0b\x21\x00\x4d\xf2\xcc\xe6\xa2\x39\xa2\xed\x29\x35\x54\x4b\xa7\x26\xef\x54\x44\x73\xd3\xdf\x78\x23\x7a\x35\x5f\x43\x2f\xb9\x8d\x78\x3f\x54\x2f\x6c\x3b\x1d\x18\x46\x2e\xd4\x52\xa1\x08\x88\x99\x46\x8e\xd8\xd7\x91\xa2\xe4\x57\x4e\x45\x0d\xac\x0e\xec\x11\x02\xd2\x24\x0f\xa6\x75\xf2\x45\x19\x56\x55\x23\x0a\x88\xc6\xae\xd5\x12\x9f\x89\x91\xbd\x63\x07\xc4\x99\x6d\x7f\xc6\x0e\x5c\xd6\x73\x10\xdf\x86\x37\x2a\xa9\x83\xff\xad\x7e\x27\xb9\x95\x7c\xf8\x3b\x81\x0e\xbc\xe3\x97\x85\xbe\x64\xf8\xe5\x2b\x40\xfc\x5e\xd2\xcf\x0f\x7b\xdd\x2f\xd3\xda\xce\xe6\x79\x62\x9b\x25\xf1\x6d\x4a\x5a\x73\xf9\x62\x0b\x9f\xb6\x0d\xf5\x89\x12\x47\x3c\x1e\xb1\x79\xbc\x5c\xf1\x74\xfa\x08\x00\x00\xff\xff\x55\x52\xc0\x67\xce\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x90\xb1\x4e\xc4\x30\x0c\x86\xf7\x3c\x85\x75\x7b\x8a\xd8\x50\x36\x60\x60\x63\x38\x24\x76\x5f\x6a\xae\xa6\x6d\x1c\xd9\x49\x91\x38\xdd\xbb\xa3\xf6\x0e\x10\x2a\x02\x24\xa6\x44\x89\xff\xcf\xf6\xe7\xbd\x77\x98\xf9\x91\xd4\x58\x52\x00\xdd\x61\x6c\xb0\x96\x4e\x94\x5f\xb1\xb0\xa4\xa6\xbf\xb2\x86\xe5\x62\xba\x74\x3d\xa7\x36\xc0\xed\x50\xad\x90\x6e\x65\x20\x37\x52\xc1\x16\x0b\x06\x07\x90\x70\xa4\x00\x7d\x1d\x31\x70\x7a\xa6\x58\x44\x9d\xd6\x81\x2c\x38\x0f\x98\xf9\x4e\xa5\x66\x9b\x2b\xfd\x52\xd5\xb0\x38\x00\x25\x93\xaa\x91\xce\x1f\x23\x59\x47\xe6\x00\x26\xd2\xdd\xf9\x6d\x4f\x65\x39\x07\xb6\xd3\xe5\x05\x4b\xec\xd6\xd0\xcd\x66\xcd\x9b\x87\xb2\x8c\xf1\xaf\xcc\x7f\xe9\xb8\xe1\xd4\x72\xda\xff\x6e\x45\x06\xda\xd2\xd3\x3c\xc9\xfb\x0a\x3f\xb4\x72\x00\x6b\xf1\xdf\x83\xad\xee\xe6\x16\x8b\xf1\x53\xe6\x81\x74\xe2\x48\xd7\x31\x4a\x4d\xe5\x4b\xcc\x7f\xc4\xe0\x53\x53\x80\xc3\x01\x9a\x7b\x1c\xc9\x32\x46\x82\xe3\xf1\x6d\x00\x48\x9a\x05\xc3\x22\x02\x00\x00"),
 		},
 		"/control-plane/namespace.yaml": &vfsgen۰FileInfo{
 			name:    "namespace.yaml",
-			modTime: time.Date(2019, 9, 9, 21, 11, 18, 649339983, time.UTC),
-			content: []byte("\x0a\x2d\x2d\x2d\x0a\x61\x70\x69\x56\x65\x72\x73\x69\x6f\x6e\x3a\x20\x76\x31\x0a\x6b\x69\x6e\x64\x3a\x20\x4e\x61\x6d\x65\x73\x70\x61\x63\x65\x0a\x6d\x65\x74\x61\x64\x61\x74\x61\x3a\x0a\x20\x20\x6e\x61\x6d\x65\x3a\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x73\x70\x61\x63\x65\x20\x7d\x7d\x0a"),
+			modTime: time.Date(2026, 10, 18, 22, 54, 23, 525038068, time.UTC),
+			content: []byte("\x0a\x2d\x2d\x2d\x0a\x61\x70\x69\x56\x65\x72\x73\x69\x6f\x6e\x3a\x20\x76\x31\x0a\x6b\x69\x6e\x64\x3a\x20\x4e\x61\x6d\x65\x73\x70\x61\x63\x65\x0a\x6d\x65\x74\x61\x64\x61\x74\x61\x3a\x0a\x20\x20\x6e\x61\x6d\x65\x3a\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x73\x70\x61\x63\x65\x20\x7d\x7d\x0a\x20\x20\x6c\x61\x62\x65\x6c\x73\x3a\x0a\x20\x20\x20\x20\x6b\x75\x6d\x61\x2e\x69\x6f\x2f\x73\x69\x64\x65\x63\x61\x72\x2d\x69\x6e\x6a\x65\x63\x74\x69\x6f\x6e\x3a\x20\x64\x69\x73\x61\x62\x6c\x65\x64\x0a"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
@@ -8,10 +8,15 @@ import (
 	admin_server "github.com/Kong/kuma/pkg/config/admin-server"
 	config_core "github.com/Kong/kuma/pkg/config/core"
 	"github.com/Kong/kuma/pkg/core"
+	envoy_admin_rest "github.com/Kong/kuma/pkg/core/admin/rest"
+	"github.com/Kong/kuma/pkg/core/bundle"
+	bundle_rest "github.com/Kong/kuma/pkg/core/bundle/rest"
 	ca_provided_rest "github.com/Kong/kuma/pkg/core/ca/provided/rest"
+	"github.com/Kong/kuma/pkg/core/resources/registry"
 	"github.com/Kong/kuma/pkg/core/runtime"
 	"github.com/Kong/kuma/pkg/tokens/builtin"
 	tokens_server "github.com/Kong/kuma/pkg/tokens/builtin/server"
+	xds_inspect "github.com/Kong/kuma/pkg/xds/inspect"
 	"github.com/emicklei/go-restful"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -158,7 +163,21 @@ func SetupServer(rt runtime.Runtime) error {
 	ws := ca_provided_rest.NewWebservice(rt.ProvidedCaManager(), rt.ResourceManager())
 	webservices = append(webservices, ws)
 
-	ws, err := dataplaneTokenWs(rt)
+	bundleManager := bundle.NewBundleManager(rt.ResourceManager(), rt.SecretManager(), rt.BuiltinCaManager(), registry.Global())
+	webservices = append(webservices, bundle_rest.NewWebservice(bundleManager))
+
+	// Envoy Admin API of Dataplanes is exposed only by the Admin Server since it reveals internals of the mesh
+	dataplanesWs := envoy_admin_rest.NewWebservice(rt.EnvoyAdminChannels(), rt.ResourceManager())
+	xdsInspector, err := xds_inspect.NewXdsInspector(rt)
+	if err != nil {
+		return err
+	}
+	// both webservices share the path, so they have to be merged to be routed properly
+	policiesInspector := xds_inspect.NewPoliciesInspector(rt.ResourceManager())
+	xds_inspect.AddRoutes(dataplanesWs, xdsInspector, policiesInspector, rt.ResourceManager())
+	webservices = append(webservices, dataplanesWs)
+
+	ws, err = dataplaneTokenWs(rt)
 	if err != nil {
 		return err
 	}
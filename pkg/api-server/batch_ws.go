@@ -0,0 +1,212 @@
+package api_server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	rest_errors "github.com/Kong/kuma/pkg/core/rest/errors"
+	"github.com/Kong/kuma/pkg/core/validators"
+)
+
+type batchRequest struct {
+	Items []json.RawMessage `json:"items"`
+}
+
+type batchItem struct {
+	key      model.ResourceKey
+	version  string
+	resource model.Resource
+}
+
+type batchResourceWs struct {
+	resManager manager.ResourceManager
+	factories  map[string]func() model.Resource
+}
+
+func batchWs(resManager manager.ResourceManager, defs []definitions.ResourceWsDefinition) *restful.WebService {
+	b := &batchResourceWs{
+		resManager: resManager,
+		factories:  map[string]func() model.Resource{},
+	}
+	for _, def := range defs {
+		b.factories[string(def.ResourceFactory().GetType())] = def.ResourceFactory
+	}
+
+	ws := new(restful.WebService)
+	ws.
+		Path("/batch").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+	ws.Route(ws.POST("").To(b.applyResources).
+		Doc("Creates or updates a list of resources in a single transaction. Resources are applied in the order of the list. A version of a resource, if given, has to match the current one").
+		Param(ws.QueryParameter("dryRun", "Validate the request without persisting resources").DataType("boolean")).
+		Returns(200, "OK", nil).
+		Returns(400, "Bad Request", nil).
+		Returns(409, "Conflict", nil).
+		Returns(412, "Precondition Failed", nil))
+	return ws
+}
+
+func (b *batchResourceWs) applyResources(request *restful.Request, response *restful.Response) {
+	dryRun, err := dryRunFromRequest(request)
+	if err != nil {
+		rest_errors.HandleError(response, err, "Could not process resources")
+		return
+	}
+
+	batch := batchRequest{}
+	if err := request.ReadEntity(&batch); err != nil {
+		rest_errors.HandleError(response, err, "Could not process resources")
+		return
+	}
+
+	// validate all resources before any of them is applied
+	items, err := b.parseItems(batch.Items)
+	if err != nil {
+		rest_errors.HandleError(response, err, "Could not process resources")
+		return
+	}
+
+	transactional, ok := b.resManager.(manager.TransactionalResourceManager)
+	if !ok {
+		rest_errors.HandleError(response, store.ErrorTransactionsNotSupported(), "Could not apply resources")
+		return
+	}
+	err = transactional.Transactional(request.Request.Context(), func(ctx context.Context) error {
+		// Meshes are not persisted on dry run, so resources that depend on them are only validated
+		dryRunMeshes := map[string]bool{}
+		for i, item := range items {
+			if err := b.upsert(ctx, item, dryRun, dryRunMeshes); err != nil {
+				return itemError(i, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		rest_errors.HandleError(response, err, "Could not apply resources")
+		return
+	}
+
+	restList := rest.ResourceList{}
+	for _, item := range items {
+		restList.Items = append(restList.Items, restResource(item))
+	}
+	if err := response.WriteAsJson(restList); err != nil {
+		log.Error(err, "Could not write the response")
+	}
+}
+
+func (b *batchResourceWs) parseItems(rawItems []json.RawMessage) ([]batchItem, error) {
+	var verr validators.ValidationError
+	var items []batchItem
+	for i, rawItem := range rawItems {
+		path := validators.RootedAt("items").Index(i)
+		meta := rest.ResourceMeta{}
+		if err := json.Unmarshal(rawItem, &meta); err != nil {
+			verr.AddViolationAt(path, err.Error())
+			continue
+		}
+		factory, ok := b.factories[meta.Type]
+		if !ok {
+			verr.AddViolationAt(path.Field("type"), "unknown type of a resource")
+			continue
+		}
+		key := model.ResourceKey{
+			Name: meta.Name,
+			Mesh: meta.Mesh,
+		}
+		if meta.Type == string(mesh.MeshType) {
+			key.Mesh = meta.Name
+		}
+		resource := factory()
+		restResource := rest.Resource{
+			Spec: resource.GetSpec(),
+		}
+		if err := json.Unmarshal(rawItem, &restResource); err != nil {
+			verr.AddViolationAt(path, err.Error())
+			continue
+		}
+		verr.AddErrorAt(path, mesh.ValidateMeta(key.Name, key.Mesh))
+		if err := resource.Validate(); err != nil {
+			if resErr, ok := err.(*validators.ValidationError); ok {
+				verr.AddErrorAt(path, *resErr)
+			} else {
+				verr.AddViolationAt(path, err.Error())
+			}
+		}
+		items = append(items, batchItem{
+			key:      key,
+			version:  meta.Version,
+			resource: resource,
+		})
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// upsert creates or updates a resource of a batch. On dry run, managers only validate resources,
+// so none of their side effects (e.g. creation of a Builtin CA of a Mesh) takes place.
+func (b *batchResourceWs) upsert(ctx context.Context, item batchItem, dryRun bool, dryRunMeshes map[string]bool) error {
+	current := b.factories[string(item.resource.GetType())]()
+	if err := b.resManager.Get(ctx, current, getByKeyAndVersion(item.key.Name, item.key.Mesh, item.version)...); err != nil {
+		if store.IsResourceNotFound(err) && item.version != "" {
+			// a version of a resource that does not exist cannot match
+			return store.ErrorResourcePreconditionFailed(current.GetType(), item.key.Name, item.key.Mesh)
+		}
+		if store.IsResourceNotFound(err) {
+			if dryRun && dryRunMeshes[item.key.Mesh] {
+				// the resource has been validated by parseItems and its Mesh would have been created by the batch
+				return nil
+			}
+			if err := b.resManager.Create(ctx, item.resource, store.CreateBy(item.key), store.CreateWithDryRun(dryRun)); err != nil {
+				return err
+			}
+			if dryRun && item.resource.GetType() == mesh.MeshType {
+				dryRunMeshes[item.key.Name] = true
+			}
+			return nil
+		}
+		return err
+	}
+	item.resource.SetMeta(current.GetMeta())
+	return b.resManager.Update(ctx, item.resource, store.UpdateWithDryRun(dryRun))
+}
+
+// restResource converts a resource of a batch into its REST representation.
+// Resources that have not been persisted (e.g. on dry run) have no meta.
+func restResource(item batchItem) *rest.Resource {
+	if item.resource.GetMeta() != nil {
+		return rest.From.Resource(item.resource)
+	}
+	restRes := &rest.Resource{
+		Meta: rest.ResourceMeta{
+			Type: string(item.resource.GetType()),
+			Name: item.key.Name,
+		},
+		Spec: item.resource.GetSpec(),
+	}
+	if item.resource.GetType() != mesh.MeshType {
+		restRes.Meta.Mesh = item.key.Mesh
+	}
+	return restRes
+}
+
+// itemError points validation errors at the item of a batch.
+func itemError(idx int, err error) error {
+	if verr, ok := err.(*validators.ValidationError); ok {
+		rooted := validators.ValidationError{}
+		rooted.AddErrorAt(validators.RootedAt("items").Index(idx), *verr)
+		return &rooted
+	}
+	return err
+}
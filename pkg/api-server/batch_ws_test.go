@@ -0,0 +1,236 @@
+package api_server_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/test"
+	sample_model "github.com/Kong/kuma/pkg/test/resources/apis/sample"
+	test_runtime "github.com/Kong/kuma/pkg/test/runtime"
+)
+
+var _ = Describe("Batch WS", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		resourceStore = memory.NewStore()
+		apiServer = createTestApiServer(resourceStore, config.DefaultApiServerConfig())
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	postBatch := func(query string, json string) *http.Response {
+		url := fmt.Sprintf("http://%s/batch%s", apiServer.Address(), query)
+		response, err := http.Post(url, "application/json", bytes.NewBufferString(json))
+		Expect(err).ToNot(HaveOccurred())
+		return response
+	}
+
+	meshExists := func(name string) bool {
+		err := resourceStore.Get(context.Background(), &mesh_res.MeshResource{}, store.GetByKey(name, name))
+		if store.IsResourceNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+
+	It("should apply a Mesh and resources that depend on it", func() {
+		// when
+		response := postBatch("", `
+		{
+			"items": [
+				{"type": "Mesh", "name": "demo"},
+				{"type": "SampleTrafficRoute", "name": "tr-1", "mesh": "demo", "path": "/demo"}
+			]
+		}`)
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`
+		{
+			"items": [
+				{"type": "Mesh", "name": "demo", "version": "1"},
+				{"type": "SampleTrafficRoute", "name": "tr-1", "mesh": "demo", "path": "/demo", "version": "1"}
+			]
+		}`))
+
+		// and
+		Expect(meshExists("demo")).To(BeTrue())
+		route := sample_model.TrafficRouteResource{}
+		err = resourceStore.Get(context.Background(), &route, store.GetByKey("tr-1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(route.Spec.Path).To(Equal("/demo"))
+	})
+
+	It("should update existing resources", func() {
+		// setup
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		putSampleResourceIntoStore(resourceStore, "tr-1", "demo")
+
+		// when
+		response := postBatch("", `
+		{
+			"items": [
+				{"type": "SampleTrafficRoute", "name": "tr-1", "mesh": "demo", "path": "/updated"}
+			]
+		}`)
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+
+		// and
+		route := sample_model.TrafficRouteResource{}
+		err = resourceStore.Get(context.Background(), &route, store.GetByKey("tr-1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(route.Spec.Path).To(Equal("/updated"))
+	})
+
+	It("should validate all resources before applying any of them", func() {
+		// when
+		response := postBatch("", `
+		{
+			"items": [
+				{"type": "Mesh", "name": "demo"},
+				{"type": "SampleTrafficRoute", "name": "tr-1", "mesh": "demo"},
+				{"type": "Unknown", "name": "unknown", "mesh": "demo"}
+			]
+		}`)
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`
+		{
+			"title": "Could not process resources",
+			"details": "Resource is not valid",
+			"causes": [
+				{
+					"field": "items[1].path",
+					"message": "cannot be empty"
+				},
+				{
+					"field": "items[2].type",
+					"message": "unknown type of a resource"
+				}
+			]
+		}`))
+
+		// and
+		Expect(meshExists("demo")).To(BeFalse())
+	})
+
+	It("should not apply any resource if one of them fails", func() {
+		// when
+		response := postBatch("", `
+		{
+			"items": [
+				{"type": "Mesh", "name": "demo"},
+				{"type": "SampleTrafficRoute", "name": "tr-1", "mesh": "other", "path": "/demo"}
+			]
+		}`)
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+
+		// and
+		Expect(meshExists("demo")).To(BeFalse())
+	})
+
+	It("should validate resources without persisting them on dry run", func() {
+		// when
+		response := postBatch("?dryRun=true", `
+		{
+			"items": [
+				{"type": "Mesh", "name": "demo"},
+				{"type": "SampleTrafficRoute", "name": "tr-1", "mesh": "demo", "path": "/demo"}
+			]
+		}`)
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+
+		// and
+		Expect(meshExists("demo")).To(BeFalse())
+	})
+
+	It("should not create a Builtin CA of a Mesh on dry run", func() {
+		// setup
+		cfg := kuma_cp.DefaultConfig()
+		port, err := test.GetFreePort()
+		Expect(err).ToNot(HaveOccurred())
+		cfg.ApiServer.Port = port
+		rt, err := test_runtime.BuilderFor(cfg).Build()
+		Expect(err).ToNot(HaveOccurred())
+		server, err := api_server.NewApiServer(rt.ResourceManager(), rt.StatsAggregator(), definitions.All, cfg.ApiServer, &cfg)
+		Expect(err).ToNot(HaveOccurred())
+		serverStop := make(chan struct{})
+		defer close(serverStop)
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Start(serverStop)).To(Succeed())
+		}()
+		waitForServer(&resourceApiClient{address: server.Address(), path: "/meshes"})
+
+		// when
+		url := fmt.Sprintf("http://%s/batch?dryRun=true", server.Address())
+		response, err := http.Post(url, "application/json", bytes.NewBufferString(`
+		{
+			"items": [
+				{"type": "Mesh", "name": "demo", "mtls": {"enabled": true, "ca": {"builtin": {}}}},
+				{"type": "TrafficPermission", "name": "allow-all", "mesh": "demo", "sources": [{"match": {"service": "*"}}], "destinations": [{"match": {"service": "*"}}]}
+			]
+		}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`
+		{
+			"items": [
+				{"type": "Mesh", "name": "demo", "mtls": {"enabled": true, "ca": {"builtin": {}}}},
+				{"type": "TrafficPermission", "name": "allow-all", "mesh": "demo", "sources": [{"match": {"service": "*"}}], "destinations": [{"match": {"service": "*"}}]}
+			]
+		}`))
+
+		// and
+		_, err = rt.BuiltinCaManager().GetRootCerts(context.Background(), "demo")
+		Expect(err).To(HaveOccurred())
+		err = rt.ResourceManager().Get(context.Background(), &mesh_res.MeshResource{}, store.GetByKey("demo", "demo"))
+		Expect(store.IsResourceNotFound(err)).To(BeTrue())
+	})
+})
@@ -22,6 +22,7 @@ var _ = Describe("Catalog WS", func() {
 		cfg.Catalog.DataplaneToken.PublicUrl = "https://kuma.internal:2222"
 		cfg.Catalog.Bootstrap.Url = "http://kuma.internal:3333"
 		cfg.Catalog.MonitoringAssignment.Url = "grpc://kuma.internal:4444"
+		cfg.Catalog.EnvoyAdmin.Url = "grpc://kuma.internal:5555"
 
 		// setup
 		resourceStore := memory.NewStore()
@@ -64,6 +65,9 @@ var _ = Describe("Catalog WS", func() {
 				},
 				"monitoringAssignment": {
 					"url": "grpc://kuma.internal:4444"
+				},
+				"envoyAdmin": {
+					"url": "grpc://kuma.internal:5555"
 				}
 			}
 		}
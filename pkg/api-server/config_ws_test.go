@@ -59,6 +59,7 @@ var _ = Describe("Config WS", func() {
               "adminAccessLogPath": "/dev/null",
               "adminAddress": "127.0.0.1",
               "adminPort": 0,
+              "metricsServiceEnabled": false,
               "xdsConnectTimeout": "1s",
               "xdsHost": "",
               "xdsPort": 0
@@ -121,6 +122,10 @@ var _ = Describe("Config WS", func() {
                 "address": "",
                 "certDir": "",
                 "port": 5443
+              },
+              "ingressGateway": {
+                "httpPort": 8080,
+                "httpsPort": 8443
               }
             }
           },
@@ -134,6 +139,7 @@ var _ = Describe("Config WS", func() {
               "systemNamespace": "kuma-system"
             },
             "postgres": {
+              "autoMigrate": false,
               "connectionTimeout": 5,
               "dbName": "kuma",
               "host": "127.0.0.1",
@@ -152,6 +158,7 @@ var _ = Describe("Config WS", func() {
           },
           "xdsServer": {
             "dataplaneConfigurationRefreshInterval": "1s",
+            "dataplaneDeregistrationGracePeriod": "1m0s",
             "dataplaneStatusFlushInterval": "1s",
             "diagnosticsPort": 5680,
             "grpcPort": 5678
@@ -91,6 +91,7 @@ var _ = Describe("Dataplane Overview WS", func() {
 	"type": "DataplaneOverview",
 	"name": "dp1",
 	"mesh": "mesh1",
+	"version": "1",
 	"dataplane": {
 		"networking": {
 			"inbound": [
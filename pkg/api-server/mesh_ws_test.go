@@ -56,7 +56,8 @@ var _ = Describe("Resource WS", func() {
 			json := `
 			{
 				"type": "Mesh",
-				"name": "mesh-1"
+				"name": "mesh-1",
+				"version": "1"
 			}`
 			Expect(body).To(MatchJSON(json))
 		})
@@ -82,12 +83,14 @@ var _ = Describe("Resource WS", func() {
 			json1 := `
 			{
 				"type": "Mesh",
-				"name": "mesh-1"
+				"name": "mesh-1",
+				"version": "1"
 			}`
 			json2 := `
 			{
 				"type": "Mesh",
-				"name": "mesh-2"
+				"name": "mesh-2",
+				"version": "1"
 			}`
 			body, err := ioutil.ReadAll(response.Body)
 			Expect(err).ToNot(HaveOccurred())
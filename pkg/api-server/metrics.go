@@ -0,0 +1,29 @@
+package api_server
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	apiServerRequests = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "kuma_cp_api_server_request_seconds",
+		Help: "Latency of requests handled by the API Server",
+	}, []string{"method", "route", "status_code"})
+)
+
+// metricsFilter exports latency of requests per route, e.g. `/meshes/{mesh}/dataplanes/{name}`,
+// to keep cardinality of metrics independent of the number of resources.
+func metricsFilter(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	start := time.Now()
+	chain.ProcessFilter(request, response)
+	route := request.SelectedRoutePath()
+	if route == "" {
+		route = "unknown"
+	}
+	apiServerRequests.WithLabelValues(request.Request.Method, route, strconv.Itoa(response.StatusCode())).Observe(time.Since(start).Seconds())
+}
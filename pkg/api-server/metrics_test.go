@@ -0,0 +1,67 @@
+package api_server_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Server metrics", func() {
+	var apiServer *api_server.ApiServer
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		apiServer = createTestApiServer(memory.NewStore(), config.DefaultApiServerConfig())
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should export latency of requests per route", func() {
+		// when
+		response, err := http.Get(fmt.Sprintf("http://%s/meshes/mesh-1/dataplanes/dp-1", apiServer.Address()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.Body.Close()).To(Succeed())
+
+		// then
+		families, err := prometheus.DefaultGatherer.Gather()
+		Expect(err).ToNot(HaveOccurred())
+		var labels []map[string]string
+		for _, family := range families {
+			if family.GetName() != "kuma_cp_api_server_request_seconds" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				pairs := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					pairs[label.GetName()] = label.GetValue()
+				}
+				labels = append(labels, pairs)
+			}
+		}
+		Expect(labels).To(ContainElement(map[string]string{
+			"method":      "GET",
+			"route":       "/meshes/{mesh}/dataplanes/{name}",
+			"status_code": "404",
+		}))
+	})
+})
@@ -10,6 +10,7 @@ import (
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/store"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
 	"github.com/Kong/kuma/pkg/test"
 	sample_proto "github.com/Kong/kuma/pkg/test/apis/sample/v1alpha1"
 	sample_model "github.com/Kong/kuma/pkg/test/resources/apis/sample"
@@ -58,16 +59,55 @@ func (r *resourceApiClient) delete(name string) *http.Response {
 	return response
 }
 
+func (r *resourceApiClient) deleteIfMatch(name string, version string) *http.Response {
+	request, err := http.NewRequest(
+		"DELETE",
+		r.fullAddress()+"/"+name,
+		nil,
+	)
+	Expect(err).ToNot(HaveOccurred())
+	request.Header.Add("If-Match", version)
+	response, err := http.DefaultClient.Do(request)
+	Expect(err).ToNot(HaveOccurred())
+	return response
+}
+
+func (r *resourceApiClient) putIfMatch(res rest.Resource, version string) *http.Response {
+	jsonBytes, err := res.MarshalJSON()
+	Expect(err).ToNot(HaveOccurred())
+	request, err := http.NewRequest(
+		"PUT",
+		r.fullAddress()+"/"+res.Meta.Name,
+		bytes.NewBuffer(jsonBytes),
+	)
+	Expect(err).ToNot(HaveOccurred())
+	request.Header.Add("content-type", "application/json")
+	request.Header.Add("If-Match", version)
+	response, err := http.DefaultClient.Do(request)
+	Expect(err).ToNot(HaveOccurred())
+	return response
+}
+
 func (r *resourceApiClient) put(res rest.Resource) *http.Response {
 	jsonBytes, err := res.MarshalJSON()
 	Expect(err).ToNot(HaveOccurred())
 	return r.putJson(res.Meta.Name, jsonBytes)
 }
 
+func (r *resourceApiClient) putDryRun(res rest.Resource) *http.Response {
+	jsonBytes, err := res.MarshalJSON()
+	Expect(err).ToNot(HaveOccurred())
+	return r.putJsonWithQuery(res.Meta.Name, "?dryRun=true", jsonBytes)
+}
+
 func (r *resourceApiClient) putJson(name string, json []byte) *http.Response {
+	return r.putJsonWithQuery(name, "", json)
+}
+
+func (r *resourceApiClient) putJsonWithQuery(name string, query string, json []byte) *http.Response {
 	request, err := http.NewRequest(
 		"PUT",
-		r.fullAddress()+"/"+name,
+		r.fullAddress()+"/"+name+query,
 		bytes.NewBuffer(json),
 	)
 	Expect(err).ToNot(HaveOccurred())
@@ -99,6 +139,10 @@ func putSampleResourceIntoStore(resourceStore store.ResourceStore, name string,
 }
 
 func createTestApiServer(store store.ResourceStore, config *config_api_server.ApiServerConfig) *api_server.ApiServer {
+	return createTestApiServerWithStats(store, config, core_stats.NewAggregator(core_stats.DefaultStaleAfter))
+}
+
+func createTestApiServerWithStats(store store.ResourceStore, config *config_api_server.ApiServerConfig, aggregator core_stats.Aggregator) *api_server.ApiServer {
 	// we have to manually search for port and put it into config. There is no way to retrieve port of running
 	// http.Server and we need it later for the client
 	port, err := test.GetFreePort()
@@ -108,7 +152,7 @@ func createTestApiServer(store store.ResourceStore, config *config_api_server.Ap
 	resources := manager.NewResourceManager(store)
 	cfg := kuma_cp.DefaultConfig()
 	cfg.ApiServer = config
-	apiServer, err := api_server.NewApiServer(resources, defs, cfg.ApiServer, &cfg)
+	apiServer, err := api_server.NewApiServer(resources, aggregator, defs, cfg.ApiServer, &cfg)
 	Expect(err).ToNot(HaveOccurred())
 	return apiServer
 }
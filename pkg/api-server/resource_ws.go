@@ -3,6 +3,9 @@ package api_server
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/Kong/kuma/pkg/api-server/definitions"
 	"github.com/Kong/kuma/pkg/core"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
@@ -43,7 +46,7 @@ func (r *resourceWs) AddToWs(ws *restful.WebService) {
 	}
 
 	ws.Route(ws.GET(pathPrefix+"/{name}").To(r.findResource).
-		Doc(fmt.Sprintf("Get a %s", r.Name)).
+		Doc(fmt.Sprintf("Get a %s. Version of the resource is returned in the ETag header", r.Name)).
 		Param(ws.PathParameter("name", fmt.Sprintf("Name of a %s", r.Name)).DataType("string")).
 		//Writes(r.SpecFactory()).
 		Returns(200, "OK", nil). // todo(jakubdyszkiewicz) figure out how to expose the doc for ResourceReqResp
@@ -58,14 +61,20 @@ func (r *resourceWs) AddToWs(ws *restful.WebService) {
 		ws.Route(ws.PUT(pathPrefix+"/{name}").To(r.createOrUpdateResource).
 			Doc(fmt.Sprintf("Updates a %s", r.Name)).
 			Param(ws.PathParameter("name", fmt.Sprintf("Name of the %s", r.Name)).DataType("string")).
+			Param(ws.QueryParameter("dryRun", "Validate the request without persisting a resource").DataType("boolean")).
+			Param(ws.HeaderParameter("If-Match", "Update the resource only if its current version matches").DataType("string")).
 			//Reads(r.SampleSpec). // todo(jakubdyszkiewicz) figure out how to expose the doc for ResourceReqResp
 			Returns(200, "OK", nil).
-			Returns(201, "Created", nil))
+			Returns(201, "Created", nil).
+			Returns(409, "Conflict", nil).
+			Returns(412, "Precondition Failed", nil))
 
 		ws.Route(ws.DELETE(pathPrefix+"/{name}").To(r.deleteResource).
 			Doc(fmt.Sprintf("Deletes a %s", r.Name)).
 			Param(ws.PathParameter("name", fmt.Sprintf("Name of a %s", r.Name)).DataType("string")).
-			Returns(200, "OK", nil))
+			Param(ws.HeaderParameter("If-Match", "Delete the resource only if its current version matches").DataType("string")).
+			Returns(200, "OK", nil).
+			Returns(412, "Precondition Failed", nil))
 	}
 }
 
@@ -78,6 +87,7 @@ func (r *resourceWs) findResource(request *restful.Request, response *restful.Re
 	if err != nil {
 		rest_errors.HandleError(response, err, "Could not retrieve a resource")
 	} else {
+		writeETag(resource, response)
 		res := rest.From.Resource(resource)
 		if err := response.WriteAsJson(res); err != nil {
 			core.Log.Error(err, "Could not write the response")
@@ -117,18 +127,79 @@ func (r *resourceWs) createOrUpdateResource(request *restful.Request, response *
 		return
 	}
 
+	dryRun, err := dryRunFromRequest(request)
+	if err != nil {
+		rest_errors.HandleError(response, err, "Could not process a resource")
+		return
+	}
+
+	// If-Match header takes precedence over a version in the body
+	version, ifMatch := ifMatchFromRequest(request)
+	if !ifMatch {
+		version = resourceRes.Meta.Version
+	}
+	mustExist := ifMatch || version != ""
+
 	resource := r.ResourceFactory()
-	if err := r.resManager.Get(request.Request.Context(), resource, store.GetByKey(name, meshName)); err != nil {
-		if store.IsResourceNotFound(err) {
-			r.createResource(request.Request.Context(), name, meshName, resourceRes.Spec, response)
+	if err := r.resManager.Get(request.Request.Context(), resource, getByKeyAndVersion(name, meshName, version)...); err != nil {
+		if store.IsResourceNotFound(err) && mustExist {
+			// a precondition on a resource that does not exist cannot be met
+			rest_errors.HandleError(response, store.ErrorResourcePreconditionFailed(resource.GetType(), name, meshName), "Could not update a resource")
+		} else if store.IsResourceNotFound(err) {
+			r.createResource(request.Request.Context(), name, meshName, resourceRes.Spec, dryRun, response)
+		} else if store.IsResourcePreconditionFailed(err) {
+			rest_errors.HandleError(response, err, "Could not update a resource")
 		} else {
 			rest_errors.HandleError(response, err, "Could not find a resource")
 		}
 	} else {
-		r.updateResource(request.Request.Context(), resource, resourceRes, response)
+		r.updateResource(request.Request.Context(), resource, resourceRes, dryRun, response)
+	}
+}
+
+// ifMatchFromRequest returns a version of a resource from the If-Match header and whether the header is present.
+// Wildcard matches any version of an existing resource, therefore the version is empty.
+func ifMatchFromRequest(request *restful.Request) (string, bool) {
+	header := strings.TrimSpace(request.HeaderParameter("If-Match"))
+	if header == "" {
+		return "", false
+	}
+	if header == "*" {
+		return "", true
+	}
+	header = strings.TrimPrefix(header, "W/")
+	return strings.Trim(header, `"`), true
+}
+
+func getByKeyAndVersion(name string, meshName string, version string) []store.GetOptionsFunc {
+	opts := []store.GetOptionsFunc{store.GetByKey(name, meshName)}
+	if version != "" {
+		opts = append(opts, store.GetByVersion(version))
+	}
+	return opts
+}
+
+// writeETag exposes a version of a resource, so it can be used later as a precondition in If-Match header.
+func writeETag(res model.Resource, response *restful.Response) {
+	if version := res.GetMeta().GetVersion(); version != "" {
+		response.AddHeader("ETag", strconv.Quote(version))
 	}
 }
 
+func dryRunFromRequest(request *restful.Request) (bool, error) {
+	param := request.QueryParameter("dryRun")
+	if param == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(param)
+	if err != nil {
+		var verr validators.ValidationError
+		verr.AddViolation("dryRun", "must be a boolean")
+		return false, &verr
+	}
+	return dryRun, nil
+}
+
 func (r *resourceWs) validateResourceRequest(request *restful.Request, resource *rest.Resource) error {
 	var err validators.ValidationError
 	name := r.nameFromRequest(request)
@@ -146,31 +217,60 @@ func (r *resourceWs) validateResourceRequest(request *restful.Request, resource
 	return err.OrNil()
 }
 
-func (r *resourceWs) createResource(ctx context.Context, name string, meshName string, spec model.ResourceSpec, response *restful.Response) {
+func (r *resourceWs) createResource(ctx context.Context, name string, meshName string, spec model.ResourceSpec, dryRun bool, response *restful.Response) {
 	res := r.ResourceFactory()
 	_ = res.SetSpec(spec)
-	if err := r.resManager.Create(ctx, res, store.CreateByKey(name, meshName)); err != nil {
+	if err := r.resManager.Create(ctx, res, store.CreateByKey(name, meshName), store.CreateWithDryRun(dryRun)); err != nil {
 		rest_errors.HandleError(response, err, "Could not create a resource")
+	} else if dryRun {
+		r.writeDryRunResult(201, name, meshName, res, response)
 	} else {
+		writeETag(res, response)
 		response.WriteHeader(201)
 	}
 }
 
-func (r *resourceWs) updateResource(ctx context.Context, res model.Resource, restRes rest.Resource, response *restful.Response) {
+func (r *resourceWs) updateResource(ctx context.Context, res model.Resource, restRes rest.Resource, dryRun bool, response *restful.Response) {
 	_ = res.SetSpec(restRes.Spec)
-	if err := r.resManager.Update(ctx, res); err != nil {
+	if err := r.resManager.Update(ctx, res, store.UpdateWithDryRun(dryRun)); err != nil {
 		rest_errors.HandleError(response, err, "Could not update a resource")
+	} else if dryRun {
+		r.writeDryRunResult(200, res.GetMeta().GetName(), res.GetMeta().GetMesh(), res, response)
 	} else {
+		writeETag(res, response)
 		response.WriteHeader(200)
 	}
 }
 
+// writeDryRunResult responds with a resource in a shape it would have been persisted in,
+// e.g. with defaults applied by a manager.
+func (r *resourceWs) writeDryRunResult(httpStatus int, name string, meshName string, res model.Resource, response *restful.Response) {
+	restRes := rest.Resource{
+		Meta: rest.ResourceMeta{
+			Type: string(res.GetType()),
+			Name: name,
+		},
+		Spec: res.GetSpec(),
+	}
+	if res.GetType() != mesh.MeshType {
+		restRes.Meta.Mesh = meshName
+	}
+	if err := response.WriteHeaderAndJson(httpStatus, &restRes, restful.MIME_JSON); err != nil {
+		core.Log.Error(err, "Could not write the response")
+	}
+}
+
 func (r *resourceWs) deleteResource(request *restful.Request, response *restful.Response) {
 	name := r.nameFromRequest(request)
 	meshName := r.meshFromRequest(request)
 
 	resource := r.ResourceFactory()
-	if err := r.resManager.Delete(request.Request.Context(), resource, store.DeleteByKey(name, meshName)); err != nil {
+	opts := []store.DeleteOptionsFunc{store.DeleteByKey(name, meshName)}
+	if version, _ := ifMatchFromRequest(request); version != "" {
+		// the version is checked by the store together with the deletion
+		opts = append(opts, store.DeleteByVersion(version))
+	}
+	if err := r.resManager.Delete(request.Request.Context(), resource, opts...); err != nil {
 		rest_errors.HandleError(response, err, "Could not delete a resource")
 	}
 }
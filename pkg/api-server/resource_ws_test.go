@@ -70,7 +70,8 @@ var _ = Describe("Resource WS", func() {
 				"type": "SampleTrafficRoute",
 				"name": "tr-1",
 				"mesh": "default",
-				"path": "/sample-path"
+				"path": "/sample-path",
+				"version": "1"
 			}`
 			Expect(body).To(MatchJSON(json))
 		})
@@ -108,14 +109,16 @@ var _ = Describe("Resource WS", func() {
 				"type": "SampleTrafficRoute",
 				"name": "tr-1",
 				"mesh": "default",
-				"path": "/sample-path"
+				"path": "/sample-path",
+				"version": "1"
 			}`
 			json2 := `
 			{
 				"type": "SampleTrafficRoute",
 				"name": "tr-2",
 				"mesh": "default",
-				"path": "/sample-path"
+				"path": "/sample-path",
+				"version": "1"
 			}`
 			body, err := ioutil.ReadAll(response.Body)
 			Expect(err).ToNot(HaveOccurred())
@@ -394,6 +397,109 @@ var _ = Describe("Resource WS", func() {
 		})
 	})
 
+	Describe("On PUT with dryRun", func() {
+		It("should validate a new resource without persisting it", func() {
+			// given
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name: "new-resource",
+					Mesh: mesh,
+					Type: string(sample_model.TrafficRouteType),
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/sample-path",
+				},
+			}
+
+			// when
+			response := client.putDryRun(res)
+
+			// then
+			Expect(response.StatusCode).To(Equal(201))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`
+			{
+				"type": "SampleTrafficRoute",
+				"name": "new-resource",
+				"mesh": "default",
+				"path": "/sample-path"
+			}`))
+
+			// and
+			err = resourceStore.Get(context.Background(), &sample_model.TrafficRouteResource{}, store.GetByKey("new-resource", mesh))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should validate an update without persisting it", func() {
+			// given
+			name := "tr-1"
+			putSampleResourceIntoStore(resourceStore, name, mesh)
+
+			// when
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name: name,
+					Mesh: mesh,
+					Type: string(sample_model.TrafficRouteType),
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/update-sample-path",
+				},
+			}
+			response := client.putDryRun(res)
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+
+			// and
+			resource := sample_model.TrafficRouteResource{}
+			err := resourceStore.Get(context.Background(), &resource, store.GetByKey(name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal("/sample-path"))
+		})
+
+		It("should return 400 on validation error", func() {
+			// given
+			json := `
+			{
+				"type": "SampleTrafficRoute",
+				"name": "tr-1",
+				"mesh": "default",
+				"path": ""
+			}
+			`
+
+			// when
+			response := client.putJsonWithQuery("tr-1", "?dryRun=true", []byte(json))
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+
+		It("should return 400 on invalid dryRun value", func() {
+			// given
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name: "new-resource",
+					Mesh: mesh,
+					Type: string(sample_model.TrafficRouteType),
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/sample-path",
+				},
+			}
+			jsonBytes, err := res.MarshalJSON()
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response := client.putJsonWithQuery("new-resource", "?dryRun=maybe", jsonBytes)
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+	})
+
 	Describe("On DELETE", func() {
 		It("should delete existing resource", func() {
 			// given
@@ -431,6 +537,162 @@ var _ = Describe("Resource WS", func() {
 		})
 	})
 
+	Describe("Optimistic concurrency", func() {
+		updatedResource := func(name string, version string) rest.Resource {
+			return rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name:    name,
+					Mesh:    mesh,
+					Type:    string(sample_model.TrafficRouteType),
+					Version: version,
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/updated-path",
+				},
+			}
+		}
+
+		expectPath := func(name string, path string) {
+			resource := sample_model.TrafficRouteResource{}
+			err := resourceStore.Get(context.Background(), &resource, store.GetByKey(name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal(path))
+		}
+
+		It("should return a version in the ETag header", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.get("tr-1")
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(response.Header.Get("ETag")).To(Equal(`"1"`))
+		})
+
+		It("should update a resource when If-Match matches the current version", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.putIfMatch(updatedResource("tr-1", ""), `"1"`)
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(response.Header.Get("ETag")).To(Equal(`"2"`))
+			// and
+			expectPath("tr-1", "/updated-path")
+		})
+
+		It("should return 412 when If-Match does not match the current version", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.putIfMatch(updatedResource("tr-1", ""), `"7"`)
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			bytes, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(MatchJSON(`
+			{
+				"title": "Could not update a resource",
+				"details": "Precondition Failed"
+			}
+			`))
+			// and
+			expectPath("tr-1", "/sample-path")
+		})
+
+		It("should use a version from the body when If-Match is not given", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.put(updatedResource("tr-1", "7"))
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			// and
+			expectPath("tr-1", "/sample-path")
+		})
+
+		It("should return 412 when If-Match is given for a resource that does not exist", func() {
+			// when
+			response := client.putIfMatch(updatedResource("tr-1", ""), `"1"`)
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			// and
+			err := resourceStore.Get(context.Background(), &sample_model.TrafficRouteResource{}, store.GetByKey("tr-1", mesh))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should update a resource of any version on If-Match wildcard", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.putIfMatch(updatedResource("tr-1", "7"), `*`)
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			// and
+			expectPath("tr-1", "/updated-path")
+
+			// when
+			response = client.putIfMatch(updatedResource("tr-2", ""), `*`)
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+		})
+
+		It("should not delete a resource when If-Match does not match the current version", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.deleteIfMatch("tr-1", `"7"`)
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			// and
+			expectPath("tr-1", "/sample-path")
+
+			// when
+			response = client.deleteIfMatch("tr-1", `"1"`)
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+		})
+
+		It("should delete a given version of a resource only once when deleted concurrently", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			const attempts = 5
+			statuses := make(chan int, attempts)
+			for i := 0; i < attempts; i++ {
+				go func() {
+					defer GinkgoRecover()
+					statuses <- client.deleteIfMatch("tr-1", `"1"`).StatusCode
+				}()
+			}
+
+			// then
+			deleted := 0
+			for i := 0; i < attempts; i++ {
+				if <-statuses == 200 {
+					deleted++
+				}
+			}
+			Expect(deleted).To(Equal(1))
+		})
+	})
+
 	It("should support CORS", func() {
 		// when
 		req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/meshes/%s/sample-traffic-routes", apiServer.Address(), mesh), nil)
@@ -12,6 +12,7 @@ import (
 	"github.com/Kong/kuma/pkg/core"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/runtime"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
 	"github.com/emicklei/go-restful"
 )
 
@@ -27,7 +28,7 @@ func (a *ApiServer) Address() string {
 	return a.server.Addr
 }
 
-func NewApiServer(resManager manager.ResourceManager, defs []definitions.ResourceWsDefinition, serverConfig *api_server_config.ApiServerConfig, cfg config.Config) (*ApiServer, error) {
+func NewApiServer(resManager manager.ResourceManager, aggregator core_stats.Aggregator, defs []definitions.ResourceWsDefinition, serverConfig *api_server_config.ApiServerConfig, cfg config.Config) (*ApiServer, error) {
 	container := restful.NewContainer()
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", serverConfig.Port),
@@ -46,8 +47,11 @@ func NewApiServer(resManager manager.ResourceManager, defs []definitions.Resourc
 		Consumes(restful.MIME_JSON).
 		Produces(restful.MIME_JSON)
 
-	addToWs(ws, defs, resManager, serverConfig)
+	addToWs(ws, defs, resManager, aggregator, serverConfig)
 	container.Add(ws)
+	if !serverConfig.ReadOnly {
+		container.Add(batchWs(resManager, defs))
+	}
 	container.Add(indexWs())
 	container.Add(catalogWs(*serverConfig.Catalog))
 	configWs, err := configWs(cfg)
@@ -57,17 +61,23 @@ func NewApiServer(resManager manager.ResourceManager, defs []definitions.Resourc
 	container.Add(configWs)
 
 	container.Filter(cors.Filter)
+	container.Filter(metricsFilter)
 	return &ApiServer{
 		server: srv,
 	}, nil
 }
 
-func addToWs(ws *restful.WebService, defs []definitions.ResourceWsDefinition, resManager manager.ResourceManager, config *api_server_config.ApiServerConfig) {
+func addToWs(ws *restful.WebService, defs []definitions.ResourceWsDefinition, resManager manager.ResourceManager, aggregator core_stats.Aggregator, config *api_server_config.ApiServerConfig) {
 	overviewWs := overviewWs{
 		resManager: resManager,
 	}
 	overviewWs.AddToWs(ws)
 
+	serviceStatsWs := serviceStatsWs{
+		aggregator: aggregator,
+	}
+	serviceStatsWs.AddToWs(ws)
+
 	for _, definition := range defs {
 		resourceWs := resourceWs{
 			resManager:           resManager,
@@ -104,7 +114,7 @@ func (a *ApiServer) Start(stop <-chan struct{}) error {
 
 func SetupServer(rt runtime.Runtime) error {
 	cfg := rt.Config()
-	apiServer, err := NewApiServer(rt.ResourceManager(), definitions.All, rt.Config().ApiServer, &cfg)
+	apiServer, err := NewApiServer(rt.ResourceManager(), rt.StatsAggregator(), definitions.All, rt.Config().ApiServer, &cfg)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,37 @@
+package api_server
+
+import (
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	rest_errors "github.com/Kong/kuma/pkg/core/rest/errors"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
+)
+
+type serviceStatsWs struct {
+	aggregator core_stats.Aggregator
+}
+
+func (r *serviceStatsWs) AddToWs(ws *restful.WebService) {
+	ws.Route(ws.GET("/{mesh}/services/{service}/stats").To(r.serviceStats).
+		Doc("Get stats of a service aggregated across all dataplanes connected to this instance of Control Plane").
+		Param(ws.PathParameter("service", "Name of a service").DataType("string")).
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Returns(200, "OK", nil).
+		Returns(404, "Not found", nil))
+}
+
+func (r *serviceStatsWs) serviceStats(request *restful.Request, response *restful.Response) {
+	service := request.PathParameter("service")
+	meshName := request.PathParameter("mesh")
+
+	stats, ok := r.aggregator.ServiceStats(meshName, service)
+	if !ok {
+		// there are no stats if none of dataplanes of the service has reported them recently
+		rest_errors.HandleError(response, store.ErrorResourceNotFound("ServiceStats", service, meshName), "Could not retrieve service stats")
+		return
+	}
+	if err := response.WriteAsJson(stats); err != nil {
+		rest_errors.HandleError(response, err, "Could not retrieve service stats")
+	}
+}
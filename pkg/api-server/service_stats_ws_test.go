@@ -0,0 +1,80 @@
+package api_server_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Stats WS", func() {
+	var apiServer *api_server.ApiServer
+	var aggregator core_stats.Aggregator
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		aggregator = core_stats.NewAggregator(core_stats.DefaultStaleAfter)
+		apiServer = createTestApiServerWithStats(memory.NewStore(), config.DefaultApiServerConfig(), aggregator)
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should return stats of a service", func() {
+		// given
+		dataplane := core_model.ResourceKey{Mesh: "mesh1", Name: "dp1"}
+		aggregator.Update(dataplane, 1, map[string]core_stats.Sample{
+			"backend": {Requests: 10},
+		})
+
+		// when
+		response, err := http.Get(fmt.Sprintf("http://%s/meshes/mesh1/services/backend/stats", apiServer.Address()))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`
+		{
+			"mesh": "mesh1",
+			"service": "backend",
+			"dataplanes": 1,
+			"requestRate": 0,
+			"errorRate": 0,
+			"latency": {
+				"p50": 0,
+				"p95": 0,
+				"p99": 0
+			}
+		}`))
+	})
+
+	It("should return 404 when no dataplane has reported stats of a service", func() {
+		// when
+		response, err := http.Get(fmt.Sprintf("http://%s/meshes/mesh1/services/backend/stats", apiServer.Address()))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(response.StatusCode).To(Equal(404))
+	})
+})
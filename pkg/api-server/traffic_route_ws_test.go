@@ -105,8 +105,8 @@ var _ = Describe("TrafficRoute WS", func() {
 			actual, err := yaml.JSONToYAML(body)
 			// then
 			Expect(err).ToNot(HaveOccurred())
-			// and
-			Expect(actual).To(MatchYAML(given))
+			// and a version is assigned to the resource
+			Expect(actual).To(MatchYAML(given + `        version: "1"` + "\n"))
 		})
 	})
 })
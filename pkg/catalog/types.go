@@ -13,6 +13,7 @@ type Apis struct {
 	DataplaneToken       DataplaneTokenApi       `json:"dataplaneToken"` // DEPRECATED: remove in next major version of Kuma
 	Admin                AdminApi                `json:"admin"`
 	MonitoringAssignment MonitoringAssignmentApi `json:"monitoringAssignment"`
+	EnvoyAdmin           EnvoyAdminApi           `json:"envoyAdmin"`
 }
 
 type AdminApi struct {
@@ -33,6 +34,16 @@ type MonitoringAssignmentApi struct {
 	Url string `json:"url"`
 }
 
+// EnvoyAdminApi is a gRPC server that accepts reverse channels of `kuma-dp`
+// used by Control Plane to query Envoy Admin API.
+type EnvoyAdminApi struct {
+	Url string `json:"url"`
+}
+
+func (e *EnvoyAdminApi) Enabled() bool {
+	return e.Url != ""
+}
+
 func (d *DataplaneTokenApi) Enabled() bool {
 	return d.LocalUrl != ""
 }
@@ -54,6 +65,9 @@ func FromConfig(cfg catalog.CatalogConfig) Catalog {
 			MonitoringAssignment: MonitoringAssignmentApi{
 				Url: cfg.MonitoringAssignment.Url,
 			},
+			EnvoyAdmin: EnvoyAdminApi{
+				Url: cfg.EnvoyAdmin.Url,
+			},
 		},
 	}
 }
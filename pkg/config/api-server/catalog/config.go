@@ -6,6 +6,7 @@ type CatalogConfig struct {
 	DataplaneToken       DataplaneTokenApiConfig // DEPRECATED: remove in next major version of Kuma
 	Admin                AdminApiConfig
 	MonitoringAssignment MonitoringAssignmentApiConfig
+	EnvoyAdmin           EnvoyAdminApiConfig
 }
 
 type BootstrapApiConfig struct {
@@ -25,3 +26,7 @@ type AdminApiConfig struct {
 type MonitoringAssignmentApiConfig struct {
 	Url string
 }
+
+type EnvoyAdminApiConfig struct {
+	Url string
+}
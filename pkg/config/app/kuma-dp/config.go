@@ -18,14 +18,18 @@ func DefaultConfig() Config {
 			},
 		},
 		Dataplane: Dataplane{
-			Mesh:      "default",
-			Name:      "",                                                      // Dataplane name must be set explicitly
-			AdminPort: config_types.MustPortRange(30001, config_types.MaxPort), // by default, automatically choose a free port for Envoy Admin interface
-			DrainTime: 30 * time.Second,
+			Mesh:          "default",
+			Name:          "",                                                      // Dataplane name must be set explicitly
+			AdminPort:     config_types.MustPortRange(30001, config_types.MaxPort), // by default, automatically choose a free port for Envoy Admin interface
+			DrainTime:     30 * time.Second,
+			ReadinessPort: 0, // readiness endpoint has to be enabled explicitly
 		},
 		DataplaneRuntime: DataplaneRuntime{
-			BinaryPath: "envoy",
-			ConfigDir:  "", // if left empty, a temporary directory will be generated automatically
+			BinaryPath:               "envoy",
+			ConfigDir:                "", // if left empty, a temporary directory will be generated automatically
+			RestartInitialBackoff:    1 * time.Second,
+			RestartMaxBackoff:        1 * time.Minute,
+			BootstrapRefreshInterval: 0, // refresh of bootstrap config has to be enabled explicitly
 		},
 	}
 }
@@ -69,6 +73,10 @@ type Dataplane struct {
 	AdminPort config_types.PortRange `yaml:"adminPort,omitempty" envconfig:"kuma_dataplane_admin_port"`
 	// Drain time for listeners.
 	DrainTime time.Duration `yaml:"drainTime,omitempty" envconfig:"kuma_dataplane_drain_time"`
+	// Port for kuma-dp to serve the readiness endpoint on (only on 127.0.0.1).
+	// The endpoint reports ready once Envoy has ACKed its initial xDS configuration.
+	// 0 indicates that the readiness endpoint should not be exposed.
+	ReadinessPort uint32 `yaml:"readinessPort,omitempty" envconfig:"kuma_dataplane_readiness_port"`
 }
 
 // DataplaneRuntime defines the context in which dataplane (Envoy) runs.
@@ -79,6 +87,42 @@ type DataplaneRuntime struct {
 	ConfigDir string `yaml:"configDir,omitempty" envconfig:"kuma_dataplane_runtime_config_dir"`
 	// Path to a file with dataplane token (use 'kumactl generate dataplane-token' to get one)
 	TokenPath string `yaml:"dataplaneTokenPath,omitempty" envconfig:"kuma_dataplane_runtime_token_path"`
+	// Log level of Envoy. If left empty, Envoy uses its own default.
+	EnvoyLogLevel string `yaml:"envoyLogLevel,omitempty" envconfig:"kuma_dataplane_runtime_envoy_log_level"`
+	// Path to a file with Dataplane resource that Control Plane should create or update on behalf of the dataplane.
+	ResourcePath string `yaml:"resourcePath,omitempty" envconfig:"kuma_dataplane_runtime_resource_path"`
+	// Dataplane resource that Control Plane should create or update on behalf of the dataplane.
+	Resource string `yaml:"resource,omitempty" envconfig:"kuma_dataplane_runtime_resource"`
+	// Values of placeholders in Dataplane resource, e.g. `{{ .address }}`.
+	// Values of `address` and `hostname` placeholders are detected automatically unless given explicitly.
+	ResourceVars map[string]string `yaml:"resourceVars,omitempty" envconfig:"kuma_dataplane_runtime_resource_vars"`
+	// Interval to wait before restarting Envoy after a crash.
+	// The interval doubles after every subsequent crash up to RestartMaxBackoff.
+	RestartInitialBackoff time.Duration `yaml:"restartInitialBackoff,omitempty" envconfig:"kuma_dataplane_runtime_restart_initial_backoff"`
+	// Maximum interval to wait before restarting Envoy after a crash.
+	RestartMaxBackoff time.Duration `yaml:"restartMaxBackoff,omitempty" envconfig:"kuma_dataplane_runtime_restart_max_backoff"`
+	// How often to re-request bootstrap config from the Control Plane.
+	// Once bootstrap config changes, e.g. because of a new address of the Control Plane,
+	// Envoy gets hot restarted with the new config without dropping connections.
+	// 0 indicates that bootstrap config should not be refreshed and turns off hot restart of Envoy.
+	BootstrapRefreshInterval time.Duration `yaml:"bootstrapRefreshInterval,omitempty" envconfig:"kuma_dataplane_runtime_bootstrap_refresh_interval"`
+	// Base ID of Envoy used for hot restart. Every Envoy instance on the same machine
+	// (or rather, sharing the same IPC namespace) must have a unique base ID.
+	// 0 indicates that base ID should be derived from mesh and name of the Dataplane.
+	EnvoyBaseId uint32 `yaml:"envoyBaseId,omitempty" envconfig:"kuma_dataplane_runtime_envoy_base_id"`
+}
+
+// EnvoyLogLevels are log levels supported by Envoy.
+var EnvoyLogLevels = []string{"trace", "debug", "info", "warning", "error", "critical", "off"}
+
+// IsValidEnvoyLogLevel tells whether a given value is a log level supported by Envoy.
+func IsValidEnvoyLogLevel(level string) bool {
+	for _, supported := range EnvoyLogLevels {
+		if level == supported {
+			return true
+		}
+	}
+	return false
 }
 
 var _ config.Config = &Config{}
@@ -125,6 +169,9 @@ func (d *Dataplane) Validate() (errs error) {
 	if d.DrainTime <= 0 {
 		errs = multierr.Append(errs, errors.Errorf(".DrainTime must be positive"))
 	}
+	if d.ReadinessPort > config_types.MaxPort {
+		errs = multierr.Append(errs, errors.Errorf(".ReadinessPort must be in the range [0, %d]", config_types.MaxPort))
+	}
 	return
 }
 
@@ -137,6 +184,21 @@ func (d *DataplaneRuntime) Validate() (errs error) {
 	if d.BinaryPath == "" {
 		errs = multierr.Append(errs, errors.Errorf(".BinaryPath must be non-empty"))
 	}
+	if d.EnvoyLogLevel != "" && !IsValidEnvoyLogLevel(d.EnvoyLogLevel) {
+		errs = multierr.Append(errs, errors.Errorf(".EnvoyLogLevel must be one of %v", EnvoyLogLevels))
+	}
+	if d.ResourcePath != "" && d.Resource != "" {
+		errs = multierr.Append(errs, errors.Errorf(".ResourcePath and .Resource cannot be set at the same time"))
+	}
+	if d.RestartInitialBackoff <= 0 {
+		errs = multierr.Append(errs, errors.Errorf(".RestartInitialBackoff must be positive"))
+	}
+	if d.RestartMaxBackoff < d.RestartInitialBackoff {
+		errs = multierr.Append(errs, errors.Errorf(".RestartMaxBackoff must not be less than .RestartInitialBackoff"))
+	}
+	if d.BootstrapRefreshInterval < 0 {
+		errs = multierr.Append(errs, errors.Errorf(".BootstrapRefreshInterval must not be negative"))
+	}
 	return
 }
 
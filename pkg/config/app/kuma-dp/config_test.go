@@ -30,6 +30,13 @@ var _ = Describe("Config", func() {
 		Expect(cfg.ControlPlane.ApiServer.URL).To(Equal("https://kuma-control-plane.internal:5682"))
 		Expect(cfg.Dataplane.AdminPort).To(Equal(config_types.MustExactPort(2345)))
 		Expect(cfg.Dataplane.DrainTime).To(Equal(60 * time.Second))
+		Expect(cfg.Dataplane.ReadinessPort).To(Equal(uint32(19902)))
+		Expect(cfg.DataplaneRuntime.ResourcePath).To(Equal("/etc/kuma/dataplane.yaml"))
+		Expect(cfg.DataplaneRuntime.ResourceVars).To(Equal(map[string]string{"address": "192.168.0.1", "version": "v1"}))
+		Expect(cfg.DataplaneRuntime.RestartInitialBackoff).To(Equal(2 * time.Second))
+		Expect(cfg.DataplaneRuntime.RestartMaxBackoff).To(Equal(2 * time.Minute))
+		Expect(cfg.DataplaneRuntime.BootstrapRefreshInterval).To(Equal(1 * time.Minute))
+		Expect(cfg.DataplaneRuntime.EnvoyBaseId).To(Equal(uint32(17)))
 	})
 
 	Context("with modified environment variables", func() {
@@ -51,14 +58,22 @@ var _ = Describe("Config", func() {
 		It("should be loadable from environment variables", func() {
 			// setup
 			env := map[string]string{
-				"KUMA_CONTROL_PLANE_API_SERVER_URL":  "https://kuma-control-plane.internal:5682",
-				"KUMA_DATAPLANE_MESH":                "demo",
-				"KUMA_DATAPLANE_NAME":                "example",
-				"KUMA_DATAPLANE_ADMIN_PORT":          "2345",
-				"KUMA_DATAPLANE_DRAIN_TIME":          "60s",
-				"KUMA_DATAPLANE_RUNTIME_BINARY_PATH": "envoy.sh",
-				"KUMA_DATAPLANE_RUNTIME_CONFIG_DIR":  "/var/run/envoy",
-				"KUMA_DATAPLANE_RUNTIME_TOKEN_PATH":  "/tmp/token",
+				"KUMA_CONTROL_PLANE_API_SERVER_URL":                 "https://kuma-control-plane.internal:5682",
+				"KUMA_DATAPLANE_MESH":                               "demo",
+				"KUMA_DATAPLANE_NAME":                               "example",
+				"KUMA_DATAPLANE_ADMIN_PORT":                         "2345",
+				"KUMA_DATAPLANE_DRAIN_TIME":                         "60s",
+				"KUMA_DATAPLANE_READINESS_PORT":                     "19902",
+				"KUMA_DATAPLANE_RUNTIME_BINARY_PATH":                "envoy.sh",
+				"KUMA_DATAPLANE_RUNTIME_CONFIG_DIR":                 "/var/run/envoy",
+				"KUMA_DATAPLANE_RUNTIME_TOKEN_PATH":                 "/tmp/token",
+				"KUMA_DATAPLANE_RUNTIME_ENVOY_LOG_LEVEL":            "debug",
+				"KUMA_DATAPLANE_RUNTIME_RESOURCE_PATH":              "/etc/kuma/dataplane.yaml",
+				"KUMA_DATAPLANE_RUNTIME_RESOURCE_VARS":              "address:192.168.0.1,version:v1",
+				"KUMA_DATAPLANE_RUNTIME_RESTART_INITIAL_BACKOFF":    "2s",
+				"KUMA_DATAPLANE_RUNTIME_RESTART_MAX_BACKOFF":        "2m",
+				"KUMA_DATAPLANE_RUNTIME_BOOTSTRAP_REFRESH_INTERVAL": "1m",
+				"KUMA_DATAPLANE_RUNTIME_ENVOY_BASE_ID":              "17",
 			}
 			for key, value := range env {
 				os.Setenv(key, value)
@@ -79,9 +94,17 @@ var _ = Describe("Config", func() {
 			Expect(cfg.Dataplane.Name).To(Equal("example"))
 			Expect(cfg.Dataplane.AdminPort).To(Equal(config_types.MustExactPort(2345)))
 			Expect(cfg.Dataplane.DrainTime).To(Equal(60 * time.Second))
+			Expect(cfg.Dataplane.ReadinessPort).To(Equal(uint32(19902)))
 			Expect(cfg.DataplaneRuntime.BinaryPath).To(Equal("envoy.sh"))
 			Expect(cfg.DataplaneRuntime.ConfigDir).To(Equal("/var/run/envoy"))
 			Expect(cfg.DataplaneRuntime.TokenPath).To(Equal("/tmp/token"))
+			Expect(cfg.DataplaneRuntime.EnvoyLogLevel).To(Equal("debug"))
+			Expect(cfg.DataplaneRuntime.ResourcePath).To(Equal("/etc/kuma/dataplane.yaml"))
+			Expect(cfg.DataplaneRuntime.ResourceVars).To(Equal(map[string]string{"address": "192.168.0.1", "version": "v1"}))
+			Expect(cfg.DataplaneRuntime.RestartInitialBackoff).To(Equal(2 * time.Second))
+			Expect(cfg.DataplaneRuntime.RestartMaxBackoff).To(Equal(2 * time.Minute))
+			Expect(cfg.DataplaneRuntime.BootstrapRefreshInterval).To(Equal(1 * time.Minute))
+			Expect(cfg.DataplaneRuntime.EnvoyBaseId).To(Equal(uint32(17)))
 		})
 	})
 
@@ -110,6 +133,6 @@ var _ = Describe("Config", func() {
 		err := config.Load(filepath.Join("testdata", "invalid-config.input.yaml"), &cfg)
 
 		// then
-		Expect(err).To(MatchError(`Invalid configuration: .ControlPlane is not valid: .ApiServer is not valid: .URL must be a valid absolute URI; .Dataplane is not valid: .Mesh must be non-empty; .Name must be non-empty; .DrainTime must be positive; .DataplaneRuntime is not valid: .BinaryPath must be non-empty`))
+		Expect(err).To(MatchError(`Invalid configuration: .ControlPlane is not valid: .ApiServer is not valid: .URL must be a valid absolute URI; .Dataplane is not valid: .Mesh must be non-empty; .Name must be non-empty; .DrainTime must be positive; .ReadinessPort must be in the range [0, 65535]; .DataplaneRuntime is not valid: .BinaryPath must be non-empty; .EnvoyLogLevel must be one of [trace debug info warning error critical off]; .ResourcePath and .Resource cannot be set at the same time; .RestartInitialBackoff must be positive; .RestartMaxBackoff must not be less than .RestartInitialBackoff; .BootstrapRefreshInterval must not be negative`))
 	})
 })
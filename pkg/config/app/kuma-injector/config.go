@@ -189,6 +189,12 @@ type SidecarResourceLimits struct {
 type InitContainer struct {
 	// Image name.
 	Image string `yaml:"image,omitempty" envconfig:"kuma_injector_init_container_image"`
+	// Inbound ports that are excluded from being redirected to the Kuma sidecar.
+	ExcludedInboundPorts []uint32 `yaml:"excludedInboundPorts,omitempty" envconfig:"kuma_injector_init_container_excluded_inbound_ports"`
+	// Outbound ports that are excluded from being redirected to the Kuma sidecar.
+	ExcludedOutboundPorts []uint32 `yaml:"excludedOutboundPorts,omitempty" envconfig:"kuma_injector_init_container_excluded_outbound_ports"`
+	// Outbound IP ranges (in CIDR notation) that are excluded from being redirected to the Kuma sidecar.
+	ExcludedOutboundCIDRs []string `yaml:"excludedOutboundCIDRs,omitempty" envconfig:"kuma_injector_init_container_excluded_outbound_cidrs"`
 }
 
 var _ config.Config = &Config{}
@@ -316,6 +322,24 @@ func (c *InitContainer) Validate() (errs error) {
 	if c.Image == "" {
 		errs = multierr.Append(errs, errors.Errorf(".Image must be non-empty"))
 	}
+	for _, port := range c.ExcludedInboundPorts {
+		if port < 1 || 65535 < port {
+			errs = multierr.Append(errs, errors.Errorf(".ExcludedInboundPorts must contain ports in the range [1, 65535]"))
+			break
+		}
+	}
+	for _, port := range c.ExcludedOutboundPorts {
+		if port < 1 || 65535 < port {
+			errs = multierr.Append(errs, errors.Errorf(".ExcludedOutboundPorts must contain ports in the range [1, 65535]"))
+			break
+		}
+	}
+	for _, cidr := range c.ExcludedOutboundCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = multierr.Append(errs, errors.Errorf(".ExcludedOutboundCIDRs must contain valid IP ranges in CIDR notation"))
+			break
+		}
+	}
 	return
 }
 
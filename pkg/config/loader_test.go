@@ -70,6 +70,7 @@ var _ = Describe("Config loader", func() {
 			Expect(cfg.BootstrapServer.Params.AdminPort).To(Equal(uint32(1234)))
 			Expect(cfg.BootstrapServer.Params.XdsHost).To(Equal("kuma-control-plane"))
 			Expect(cfg.BootstrapServer.Params.XdsPort).To(Equal(uint32(4321)))
+			Expect(cfg.BootstrapServer.Params.MetricsServiceEnabled).To(BeTrue())
 
 			Expect(cfg.Environment).To(Equal(config_core.KubernetesEnvironment))
 
@@ -81,6 +82,7 @@ var _ = Describe("Config loader", func() {
 			Expect(cfg.Store.Postgres.DbName).To(Equal("kuma"))
 			Expect(cfg.Store.Postgres.ConnectionTimeout).To(Equal(10))
 			Expect(cfg.Store.Postgres.MaxOpenConnections).To(Equal(300))
+			Expect(cfg.Store.Postgres.AutoMigrate).To(BeTrue())
 
 			Expect(cfg.Store.Postgres.TLS.Mode).To(Equal(postgres.VerifyFull))
 			Expect(cfg.Store.Postgres.TLS.CertPath).To(Equal("/path/to/cert"))
@@ -115,6 +117,8 @@ var _ = Describe("Config loader", func() {
 			Expect(cfg.Runtime.Kubernetes.AdmissionServer.Address).To(Equal("127.0.0.2"))
 			Expect(cfg.Runtime.Kubernetes.AdmissionServer.Port).To(Equal(uint32(9443)))
 			Expect(cfg.Runtime.Kubernetes.AdmissionServer.CertDir).To(Equal("/var/run/secrets/kuma.io/kuma-admission-server/tls-cert"))
+			Expect(cfg.Runtime.Kubernetes.IngressGateway.HttpPort).To(Equal(uint32(9080)))
+			Expect(cfg.Runtime.Kubernetes.IngressGateway.HttpsPort).To(Equal(uint32(9443)))
 
 			Expect(cfg.Reports.Enabled).To(BeFalse())
 
@@ -137,6 +141,7 @@ store:
     dbName: kuma
     connectionTimeout: 10
     maxOpenConnections: 300
+    autoMigrate: true
     tls:
       mode: verifyFull
       certPath: /path/to/cert
@@ -151,6 +156,7 @@ bootstrapServer:
     adminPort: 1234
     xdsHost: kuma-control-plane
     xdsPort: 4321
+    metricsServiceEnabled: true
 apiServer:
   port: 9090
   readOnly: true
@@ -190,6 +196,9 @@ runtime:
       address: 127.0.0.2
       port: 9443
       certDir: /var/run/secrets/kuma.io/kuma-admission-server/tls-cert
+    ingressGateway:
+      httpPort: 9080
+      httpsPort: 9443
 reports:
   enabled: false
 general:
@@ -207,6 +216,7 @@ guiServer:
 				"KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_PORT":                       "1234",
 				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_HOST":                         "kuma-control-plane",
 				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_PORT":                         "4321",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_METRICS_SERVICE_ENABLED":          "true",
 				"KUMA_ENVIRONMENT":                                              "kubernetes",
 				"KUMA_STORE_TYPE":                                               "postgres",
 				"KUMA_STORE_POSTGRES_HOST":                                      "postgres.host",
@@ -216,6 +226,7 @@ guiServer:
 				"KUMA_STORE_POSTGRES_DB_NAME":                                   "kuma",
 				"KUMA_STORE_POSTGRES_CONNECTION_TIMEOUT":                        "10",
 				"KUMA_STORE_POSTGRES_MAX_OPEN_CONNECTIONS":                      "300",
+				"KUMA_STORE_POSTGRES_AUTO_MIGRATE":                              "true",
 				"KUMA_STORE_POSTGRES_TLS_MODE":                                  "verifyFull",
 				"KUMA_STORE_POSTGRES_TLS_CERT_PATH":                             "/path/to/cert",
 				"KUMA_STORE_POSTGRES_TLS_KEY_PATH":                              "/path/to/key",
@@ -244,6 +255,8 @@ guiServer:
 				"KUMA_KUBERNETES_ADMISSION_SERVER_ADDRESS":                      "127.0.0.2",
 				"KUMA_KUBERNETES_ADMISSION_SERVER_PORT":                         "9443",
 				"KUMA_KUBERNETES_ADMISSION_SERVER_CERT_DIR":                     "/var/run/secrets/kuma.io/kuma-admission-server/tls-cert",
+				"KUMA_KUBERNETES_INGRESS_GATEWAY_HTTP_PORT":                     "9080",
+				"KUMA_KUBERNETES_INGRESS_GATEWAY_HTTPS_PORT":                    "9443",
 				"KUMA_GENERAL_ADVERTISED_HOSTNAME":                              "kuma.internal",
 				"KUMA_API_SERVER_CORS_ALLOWED_DOMAINS":                          "https://kuma,https://someapi",
 				"KUMA_GUI_SERVER_PORT":                                          "8888",
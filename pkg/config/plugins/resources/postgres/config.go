@@ -24,6 +24,8 @@ type PostgresStoreConfig struct {
 	// Maximum number of open connections to the database
 	// `0` value means number of open connections is unlimited
 	MaxOpenConnections int `yaml:"maxOpenConnections" envconfig:"kuma_store_postgres_max_open_connections"`
+	// Apply pending schema migrations on start of the Control Plane
+	AutoMigrate bool `yaml:"autoMigrate" envconfig:"kuma_store_postgres_auto_migrate"`
 	// TLS settings
 	TLS TLSPostgresStoreConfig `yaml:"tls"`
 }
@@ -112,6 +114,7 @@ func DefaultPostgresStoreConfig() *PostgresStoreConfig {
 		DbName:             "kuma",
 		ConnectionTimeout:  5,
 		MaxOpenConnections: 0, // number of open connections is unlimited
+		AutoMigrate:        false,
 		TLS:                DefaultTLSPostgresStoreConfig(),
 	}
 }
@@ -12,6 +12,10 @@ func DefaultKubernetesRuntimeConfig() *KubernetesRuntimeConfig {
 			Address: "", // all addresses
 			Port:    5443,
 		},
+		IngressGateway: IngressGatewayConfig{
+			HttpPort:  8080,
+			HttpsPort: 8443,
+		},
 	}
 }
 
@@ -19,6 +23,8 @@ func DefaultKubernetesRuntimeConfig() *KubernetesRuntimeConfig {
 type KubernetesRuntimeConfig struct {
 	// Admission WebHook Server implemented by the Control Plane.
 	AdmissionServer AdmissionServerConfig `yaml:"admissionServer"`
+	// Gateways that serve Ingresses of `kuma` class.
+	IngressGateway IngressGatewayConfig `yaml:"ingressGateway"`
 }
 
 // Configuration of the Admission WebHook Server implemented by the Control Plane.
@@ -33,6 +39,14 @@ type AdmissionServerConfig struct {
 	CertDir string `yaml:"certDir" envconfig:"kuma_kubernetes_admission_server_cert_dir"`
 }
 
+// Configuration of gateway Dataplanes that serve Ingresses of `kuma` class.
+type IngressGatewayConfig struct {
+	// Port gateway Dataplanes should accept HTTP traffic on.
+	HttpPort uint32 `yaml:"httpPort" envconfig:"kuma_kubernetes_ingress_gateway_http_port"`
+	// Port gateway Dataplanes should accept HTTPS traffic on (only if Ingresses reference TLS Secrets).
+	HttpsPort uint32 `yaml:"httpsPort" envconfig:"kuma_kubernetes_ingress_gateway_https_port"`
+}
+
 var _ config.Config = &KubernetesRuntimeConfig{}
 
 func (c *KubernetesRuntimeConfig) Sanitize() {
@@ -42,6 +56,9 @@ func (c *KubernetesRuntimeConfig) Validate() error {
 	if err := c.AdmissionServer.Validate(); err != nil {
 		return errors.Wrap(err, "Admission Server validation failed")
 	}
+	if err := c.IngressGateway.Validate(); err != nil {
+		return errors.Wrap(err, "Ingress Gateway validation failed")
+	}
 	return nil
 }
 
@@ -59,3 +76,21 @@ func (c *AdmissionServerConfig) Validate() error {
 	}
 	return nil
 }
+
+var _ config.Config = &IngressGatewayConfig{}
+
+func (c *IngressGatewayConfig) Sanitize() {
+}
+
+func (c *IngressGatewayConfig) Validate() error {
+	if c.HttpPort == 0 || 65535 < c.HttpPort {
+		return errors.New("HttpPort must be in the range [1, 65535]")
+	}
+	if c.HttpsPort == 0 || 65535 < c.HttpsPort {
+		return errors.New("HttpsPort must be in the range [1, 65535]")
+	}
+	if c.HttpPort == c.HttpsPort {
+		return errors.New("HttpPort and HttpsPort must be different")
+	}
+	return nil
+}
@@ -54,6 +54,8 @@ type BootstrapParamsConfig struct {
 	XdsPort uint32 `yaml:"xdsPort" envconfig:"kuma_bootstrap_server_params_xds_port"`
 	// Connection timeout to the XDS Server
 	XdsConnectTimeout time.Duration `yaml:"xdsConnectTimeout" envconfig:"kuma_bootstrap_server_params_xds_connect_timeout"`
+	// If true then Envoy streams stats to Control Plane, which aggregates them per service
+	MetricsServiceEnabled bool `yaml:"metricsServiceEnabled" envconfig:"kuma_bootstrap_server_params_metrics_service_enabled"`
 }
 
 func (b *BootstrapParamsConfig) Sanitize() {
@@ -89,5 +91,7 @@ func DefaultBootstrapParamsConfig() *BootstrapParamsConfig {
 		XdsHost:            "", // by default it is autoconfigured from KUMA_GENERAL_ADVERTISED_HOSTNAME
 		XdsPort:            0,  // by default it is autoconfigured from KUMA_XDS_SERVER_GRPC_PORT
 		XdsConnectTimeout:  1 * time.Second,
+		// by default, Envoy doesn't stream stats to Control Plane
+		MetricsServiceEnabled: false,
 	}
 }
@@ -33,6 +33,7 @@ var _ = Describe("BootstrappServerConfig", func() {
 		Expect(cfg.Params.XdsHost).To(Equal("kuma-control-plane.internal"))
 		Expect(cfg.Params.XdsPort).To(Equal(uint32(10101)))
 		Expect(cfg.Params.XdsConnectTimeout).To(Equal(2 * time.Second))
+		Expect(cfg.Params.MetricsServiceEnabled).To(BeTrue())
 	})
 
 	Context("with modified environment variables", func() {
@@ -54,13 +55,14 @@ var _ = Describe("BootstrappServerConfig", func() {
 		It("should be loadable from environment variables", func() {
 			// setup
 			env := map[string]string{
-				"KUMA_BOOTSTRAP_SERVER_PORT":                         "1234",
-				"KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_ADDRESS":         "192.168.0.1",
-				"KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_PORT":            "4321",
-				"KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_ACCESS_LOG_PATH": "/var/log",
-				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_HOST":              "kuma-control-plane.internal",
-				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_PORT":              "10101",
-				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_CONNECT_TIMEOUT":   "2s",
+				"KUMA_BOOTSTRAP_SERVER_PORT":                           "1234",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_ADDRESS":           "192.168.0.1",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_PORT":              "4321",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_ACCESS_LOG_PATH":   "/var/log",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_HOST":                "kuma-control-plane.internal",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_PORT":                "10101",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_CONNECT_TIMEOUT":     "2s",
+				"KUMA_BOOTSTRAP_SERVER_PARAMS_METRICS_SERVICE_ENABLED": "true",
 			}
 			for key, value := range env {
 				os.Setenv(key, value)
@@ -83,6 +85,7 @@ var _ = Describe("BootstrappServerConfig", func() {
 			Expect(cfg.Params.XdsHost).To(Equal("kuma-control-plane.internal"))
 			Expect(cfg.Params.XdsPort).To(Equal(uint32(10101)))
 			Expect(cfg.Params.XdsConnectTimeout).To(Equal(2 * time.Second))
+			Expect(cfg.Params.MetricsServiceEnabled).To(BeTrue())
 		})
 	})
 
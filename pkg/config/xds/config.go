@@ -14,13 +14,15 @@ var _ config.Config = &XdsServerConfig{}
 type XdsServerConfig struct {
 	// Port of GRPC server that Envoy connects to
 	GrpcPort int `yaml:"grpcPort" envconfig:"kuma_xds_server_grpc_port"`
-	// Port of Diagnostic Server for checking health and readiness of the Control Plane
+	// Port of Diagnostic Server for checking health and readiness of the Control Plane and for scraping its Prometheus metrics (/metrics)
 	DiagnosticsPort int `yaml:"diagnosticsPort" envconfig:"kuma_xds_server_diagnostics_port"`
 
 	// Interval for re-genarting configuration for Dataplanes connected to the Control Plane
 	DataplaneConfigurationRefreshInterval time.Duration `yaml:"dataplaneConfigurationRefreshInterval" envconfig:"kuma_xds_server_dataplane_configuration_refresh_interval"`
 	// Interval for flushing status of Dataplanes connected to the Control Plane
 	DataplaneStatusFlushInterval time.Duration `yaml:"dataplaneStatusFlushInterval" envconfig:"kuma_xds_server_dataplane_status_flush_interval"`
+	// Period of time after which a Dataplane registered by `kuma-dp` itself gets removed once the dataplane disconnects
+	DataplaneDeregistrationGracePeriod time.Duration `yaml:"dataplaneDeregistrationGracePeriod" envconfig:"kuma_xds_server_dataplane_deregistration_grace_period"`
 }
 
 func (x *XdsServerConfig) Sanitize() {
@@ -39,6 +41,9 @@ func (x *XdsServerConfig) Validate() error {
 	if x.DataplaneStatusFlushInterval <= 0 {
 		return errors.New("DataplaneStatusFlushInterval must be positive")
 	}
+	if x.DataplaneDeregistrationGracePeriod < 0 {
+		return errors.New("DataplaneDeregistrationGracePeriod cannot be negative")
+	}
 	return nil
 }
 
@@ -48,5 +53,6 @@ func DefaultXdsServerConfig() *XdsServerConfig {
 		DiagnosticsPort:                       5680,
 		DataplaneConfigurationRefreshInterval: 1 * time.Second,
 		DataplaneStatusFlushInterval:          1 * time.Second,
+		DataplaneDeregistrationGracePeriod:    1 * time.Minute,
 	}
 }
@@ -30,6 +30,7 @@ var _ = Describe("XdsServerConfig", func() {
 		Expect(cfg.DiagnosticsPort).To(Equal(3456))
 		Expect(cfg.DataplaneConfigurationRefreshInterval).To(Equal(3 * time.Second))
 		Expect(cfg.DataplaneStatusFlushInterval).To(Equal(5 * time.Second))
+		Expect(cfg.DataplaneDeregistrationGracePeriod).To(Equal(2 * time.Minute))
 	})
 
 	Context("with modified environment variables", func() {
@@ -55,6 +56,7 @@ var _ = Describe("XdsServerConfig", func() {
 				"KUMA_XDS_SERVER_DIAGNOSTICS_PORT":                         "3456",
 				"KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_REFRESH_INTERVAL": "3s",
 				"KUMA_XDS_SERVER_DATAPLANE_STATUS_FLUSH_INTERVAL":          "5s",
+				"KUMA_XDS_SERVER_DATAPLANE_DEREGISTRATION_GRACE_PERIOD":    "2m",
 			}
 			for key, value := range env {
 				os.Setenv(key, value)
@@ -74,6 +76,8 @@ var _ = Describe("XdsServerConfig", func() {
 			Expect(cfg.DiagnosticsPort).To(Equal(3456))
 			Expect(cfg.DataplaneConfigurationRefreshInterval).To(Equal(3 * time.Second))
 			Expect(cfg.DataplaneStatusFlushInterval).To(Equal(5 * time.Second))
+			Expect(cfg.DataplaneDeregistrationGracePeriod).To(Equal(2 * time.Minute))
+			Expect(cfg.DataplaneDeregistrationGracePeriod).To(Equal(2 * time.Minute))
 		})
 	})
 
@@ -0,0 +1,201 @@
+package admin
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+// Endpoints of Envoy Admin API that Control Plane is allowed to query.
+const (
+	ConfigDumpPath = "/config_dump"
+	ClustersPath   = "/clusters"
+	StatsPath      = "/stats"
+)
+
+// IsAllowedPath tells whether a given endpoint of Envoy Admin API can be queried through a reverse channel.
+// Only read-only endpoints are allowed.
+func IsAllowedPath(path string) bool {
+	switch path {
+	case ConfigDumpPath, ClustersPath, StatsPath:
+		return true
+	default:
+		return false
+	}
+}
+
+type notConnectedError struct {
+	key core_model.ResourceKey
+}
+
+func (e *notConnectedError) Error() string {
+	return "Dataplane " + e.key.Name + " in mesh " + e.key.Mesh + " has no reverse channel to this instance of Control Plane"
+}
+
+// IsNotConnected tells whether an error is caused by a Dataplane that has no reverse channel open.
+func IsNotConnected(err error) bool {
+	_, ok := err.(*notConnectedError)
+	return ok
+}
+
+// EnvoyAdminClient queries Envoy Admin API of connected Dataplanes.
+type EnvoyAdminClient interface {
+	// Get returns a response of Envoy Admin API endpoint at a given path, e.g. `/config_dump`.
+	Get(ctx context.Context, dataplane core_model.ResourceKey, path string) ([]byte, error)
+}
+
+// RequestSender sends requests to `kuma-dp` over a reverse channel.
+type RequestSender interface {
+	Send(*observability_proto.EnvoyAdminRequest) error
+}
+
+// Channel is a reverse channel opened by `kuma-dp`.
+type Channel interface {
+	// Deliver passes a response received from `kuma-dp` to the request that awaits it.
+	Deliver(*observability_proto.EnvoyAdminResponse)
+	// Close unregisters the channel and fails requests that await responses.
+	Close()
+}
+
+// EnvoyAdminChannels keeps track of reverse channels opened by `kuma-dp`
+// and routes requests to Envoy Admin API through them.
+//
+// Channels are kept in memory of a Control Plane instance, so with multiple instances
+// a Dataplane can be queried only through the instance it has opened a reverse channel to.
+type EnvoyAdminChannels interface {
+	EnvoyAdminClient
+	// Open registers a reverse channel of a given Dataplane, which has to be authenticated by a caller.
+	// It supersedes and closes a channel previously opened by that Dataplane, e.g. one that is broken
+	// but hasn't been detected as such yet.
+	Open(dataplane core_model.ResourceKey, sender RequestSender) Channel
+}
+
+func NewEnvoyAdminChannels() EnvoyAdminChannels {
+	return &envoyAdminChannels{
+		channels: map[core_model.ResourceKey]*channel{},
+	}
+}
+
+var _ EnvoyAdminChannels = &envoyAdminChannels{}
+
+type envoyAdminChannels struct {
+	sync.Mutex
+	channels map[core_model.ResourceKey]*channel
+}
+
+func (c *envoyAdminChannels) Open(dataplane core_model.ResourceKey, sender RequestSender) Channel {
+	ch := &channel{
+		owner:   c,
+		key:     dataplane,
+		sender:  sender,
+		pending: map[string]chan *observability_proto.EnvoyAdminResponse{},
+		closed:  make(chan struct{}),
+	}
+	c.Lock()
+	superseded := c.channels[dataplane]
+	c.channels[dataplane] = ch
+	c.Unlock()
+	if superseded != nil {
+		// fail requests that await responses over the superseded channel
+		superseded.Close()
+	}
+	return ch
+}
+
+func (c *envoyAdminChannels) Get(ctx context.Context, dataplane core_model.ResourceKey, path string) ([]byte, error) {
+	if !IsAllowedPath(path) {
+		return nil, errors.Errorf("querying Envoy Admin API endpoint %q is not allowed", path)
+	}
+	c.Lock()
+	ch, ok := c.channels[dataplane]
+	c.Unlock()
+	if !ok {
+		return nil, &notConnectedError{key: dataplane}
+	}
+	return ch.request(ctx, path)
+}
+
+func (c *envoyAdminChannels) remove(ch *channel) {
+	c.Lock()
+	defer c.Unlock()
+	// a Dataplane might have reconnected in the meantime
+	if c.channels[ch.key] == ch {
+		delete(c.channels, ch.key)
+	}
+}
+
+var _ Channel = &channel{}
+
+type channel struct {
+	owner  *envoyAdminChannels
+	key    core_model.ResourceKey
+	sender RequestSender
+	// sendMutex serializes requests since gRPC streams don't support concurrent sends
+	sendMutex sync.Mutex
+
+	sync.Mutex
+	pending   map[string]chan *observability_proto.EnvoyAdminResponse
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *channel) request(ctx context.Context, path string) ([]byte, error) {
+	req := &observability_proto.EnvoyAdminRequest{
+		RequestId: core.NewUUID(),
+		Path:      path,
+	}
+	respCh := make(chan *observability_proto.EnvoyAdminResponse, 1)
+	c.Lock()
+	c.pending[req.RequestId] = respCh
+	c.Unlock()
+	defer func() {
+		c.Lock()
+		delete(c.pending, req.RequestId)
+		c.Unlock()
+	}()
+
+	c.sendMutex.Lock()
+	err := c.sender.Send(req)
+	c.sendMutex.Unlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send a request to the Dataplane")
+	}
+
+	select {
+	case resp := <-respCh:
+		if resp.GetError() != "" {
+			return nil, errors.Errorf("Dataplane could not get a response from Envoy Admin API: %s", resp.GetError())
+		}
+		return resp.GetBody(), nil
+	case <-c.closed:
+		return nil, errors.New("reverse channel of the Dataplane has been closed")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *channel) Deliver(resp *observability_proto.EnvoyAdminResponse) {
+	c.Lock()
+	respCh, ok := c.pending[resp.GetRequestId()]
+	c.Unlock()
+	if !ok {
+		// a request has already timed out
+		return
+	}
+	select {
+	case respCh <- resp:
+	default:
+	}
+}
+
+func (c *channel) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.owner.remove(c)
+	})
+}
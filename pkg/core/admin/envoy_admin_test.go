@@ -0,0 +1,176 @@
+package admin_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+	. "github.com/Kong/kuma/pkg/core/admin"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+type senderFunc func(*observability_proto.EnvoyAdminRequest) error
+
+func (f senderFunc) Send(req *observability_proto.EnvoyAdminRequest) error {
+	return f(req)
+}
+
+var _ = Describe("EnvoyAdminChannels", func() {
+
+	key := core_model.ResourceKey{Mesh: "default", Name: "backend-01"}
+
+	var channels EnvoyAdminChannels
+
+	BeforeEach(func() {
+		channels = NewEnvoyAdminChannels()
+	})
+
+	It("should route a request through a reverse channel", func() {
+		// given
+		var ch Channel
+		ch = channels.Open(key, senderFunc(func(req *observability_proto.EnvoyAdminRequest) error {
+			go ch.Deliver(&observability_proto.EnvoyAdminResponse{
+				RequestId: req.RequestId,
+				Body:      []byte(`response of ` + req.Path),
+			})
+			return nil
+		}))
+		defer ch.Close()
+
+		// when
+		body, err := channels.Get(context.Background(), key, ConfigDumpPath)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("response of /config_dump"))
+	})
+
+	It("should return an error reported by kuma-dp", func() {
+		// given
+		var ch Channel
+		ch = channels.Open(key, senderFunc(func(req *observability_proto.EnvoyAdminRequest) error {
+			go ch.Deliver(&observability_proto.EnvoyAdminResponse{
+				RequestId: req.RequestId,
+				Error:     "connection refused",
+			})
+			return nil
+		}))
+		defer ch.Close()
+
+		// when
+		_, err := channels.Get(context.Background(), key, StatsPath)
+
+		// then
+		Expect(err).To(MatchError("Dataplane could not get a response from Envoy Admin API: connection refused"))
+	})
+
+	It("should not allow endpoints other than read-only ones", func() {
+		// given
+		ch := channels.Open(key, senderFunc(func(*observability_proto.EnvoyAdminRequest) error {
+			Fail("request should not be sent")
+			return nil
+		}))
+		defer ch.Close()
+
+		// when
+		_, err := channels.Get(context.Background(), key, "/quitquitquit")
+
+		// then
+		Expect(err).To(MatchError(`querying Envoy Admin API endpoint "/quitquitquit" is not allowed`))
+	})
+
+	It("should return an error when Dataplane is not connected", func() {
+		// when
+		_, err := channels.Get(context.Background(), key, ConfigDumpPath)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(IsNotConnected(err)).To(BeTrue())
+	})
+
+	It("should fail awaiting requests once a channel is closed", func() {
+		// given
+		var ch Channel
+		ch = channels.Open(key, senderFunc(func(*observability_proto.EnvoyAdminRequest) error {
+			go ch.Close()
+			return nil
+		}))
+
+		// when
+		_, err := channels.Get(context.Background(), key, ClustersPath)
+
+		// then
+		Expect(err).To(MatchError("reverse channel of the Dataplane has been closed"))
+
+		// when
+		_, err = channels.Get(context.Background(), key, ClustersPath)
+
+		// then
+		Expect(IsNotConnected(err)).To(BeTrue())
+	})
+
+	It("should respect a deadline of a request", func() {
+		// given
+		ch := channels.Open(key, senderFunc(func(*observability_proto.EnvoyAdminRequest) error {
+			return nil
+		}))
+		defer ch.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// when
+		_, err := channels.Get(ctx, key, ConfigDumpPath)
+
+		// then
+		Expect(err).To(Equal(context.DeadlineExceeded))
+	})
+
+	It("should keep a newer channel when an older one of the same Dataplane is closed", func() {
+		// given
+		older := channels.Open(key, senderFunc(func(*observability_proto.EnvoyAdminRequest) error {
+			return nil
+		}))
+		var newer Channel
+		newer = channels.Open(key, senderFunc(func(req *observability_proto.EnvoyAdminRequest) error {
+			go newer.Deliver(&observability_proto.EnvoyAdminResponse{RequestId: req.RequestId, Body: []byte("newer")})
+			return nil
+		}))
+		defer newer.Close()
+
+		// when
+		older.Close()
+		body, err := channels.Get(context.Background(), key, ConfigDumpPath)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("newer"))
+	})
+
+	It("should close a channel once it's superseded by a newer one of the same Dataplane", func() {
+		// given
+		sent := make(chan struct{})
+		older := channels.Open(key, senderFunc(func(*observability_proto.EnvoyAdminRequest) error {
+			close(sent)
+			return nil
+		}))
+		defer older.Close()
+		errs := make(chan error, 1)
+		go func() {
+			_, err := channels.Get(context.Background(), key, ConfigDumpPath)
+			errs <- err
+		}()
+		<-sent
+
+		// when
+		newer := channels.Open(key, senderFunc(func(*observability_proto.EnvoyAdminRequest) error {
+			return nil
+		}))
+		defer newer.Close()
+
+		// then
+		Eventually(errs).Should(Receive(MatchError("reverse channel of the Dataplane has been closed")))
+	})
+})
@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+	"time"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/admin"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	rest_errors "github.com/Kong/kuma/pkg/core/rest/errors"
+	errors_types "github.com/Kong/kuma/pkg/core/rest/errors/types"
+	"github.com/Kong/kuma/pkg/core/validators"
+)
+
+var logger = core.Log.WithName("envoy-admin-ws")
+
+const (
+	// requestTimeout limits how long a request waits for a response of a Dataplane
+	requestTimeout = 10 * time.Second
+
+	mimeText = "text/plain"
+)
+
+// endpoints maps values of the `endpoint` query parameter to endpoints of Envoy Admin API.
+var endpoints = map[string]string{
+	"config_dump": admin.ConfigDumpPath,
+	"clusters":    admin.ClustersPath,
+	"stats":       admin.StatsPath,
+}
+
+type envoyAdminWebservice struct {
+	client     admin.EnvoyAdminClient
+	resManager manager.ResourceManager
+}
+
+func NewWebservice(client admin.EnvoyAdminClient, resManager manager.ResourceManager) *restful.WebService {
+	envoyAdminWs := envoyAdminWebservice{
+		client:     client,
+		resManager: resManager,
+	}
+	return envoyAdminWs.createWs()
+}
+
+func (e *envoyAdminWebservice) createWs() *restful.WebService {
+	ws := new(restful.WebService).
+		Produces(restful.MIME_JSON, mimeText)
+	ws.Path("/meshes/{mesh}/dataplanes").
+		Route(ws.GET("/{name}/xds").To(e.inspectDataplane).
+			Param(ws.QueryParameter("endpoint", "Endpoint of Envoy Admin API: config_dump (default), clusters or stats").DataType("string")))
+	return ws
+}
+
+func (e *envoyAdminWebservice) inspectDataplane(request *restful.Request, response *restful.Response) {
+	mesh := request.PathParameter("mesh")
+	name := request.PathParameter("name")
+
+	endpoint := request.QueryParameter("endpoint")
+	if endpoint == "" {
+		endpoint = "config_dump"
+	}
+	path, ok := endpoints[endpoint]
+	if !ok {
+		verr := validators.ValidationError{}
+		verr.AddViolation("endpoint", "has to be one of: config_dump, clusters, stats")
+		rest_errors.HandleError(response, verr.OrNil(), "Could not inspect the dataplane")
+		return
+	}
+
+	dataplane := &core_mesh.DataplaneResource{}
+	if err := e.resManager.Get(request.Request.Context(), dataplane, store.GetByKey(name, mesh)); err != nil {
+		rest_errors.HandleError(response, err, "Could not inspect the dataplane")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Request.Context(), requestTimeout)
+	defer cancel()
+	body, err := e.client.Get(ctx, core_model.MetaToResourceKey(dataplane.GetMeta()), path)
+	if err != nil {
+		handleError(response, err, "Could not inspect the dataplane")
+		return
+	}
+
+	contentType := mimeText
+	if path == admin.ConfigDumpPath {
+		contentType = restful.MIME_JSON
+	}
+	response.Header().Set("Content-Type", contentType)
+	if _, err := response.Write(body); err != nil {
+		logger.Error(err, "Could not write the response")
+	}
+}
+
+func handleError(response *restful.Response, err error, title string) {
+	if !admin.IsNotConnected(err) {
+		rest_errors.HandleError(response, err, title)
+		return
+	}
+	kumaErr := errors_types.Error{
+		Title:   title,
+		Details: err.Error(),
+	}
+	if err := response.WriteHeaderAndJson(503, kumaErr, restful.MIME_JSON); err != nil {
+		logger.Error(err, "Could not write the error response")
+	}
+}
@@ -0,0 +1,184 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+	kumactl_envoyadmin "github.com/Kong/kuma/app/kumactl/pkg/envoyadmin"
+	"github.com/Kong/kuma/pkg/core/admin"
+	"github.com/Kong/kuma/pkg/core/admin/rest"
+	"github.com/Kong/kuma/pkg/core/bundle"
+	bundle_rest "github.com/Kong/kuma/pkg/core/bundle/rest"
+	"github.com/Kong/kuma/pkg/core/ca/builtin"
+	"github.com/Kong/kuma/pkg/core/ca/provided"
+	ca_provided_rest "github.com/Kong/kuma/pkg/core/ca/provided/rest"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	resources_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/rest/errors/types"
+	"github.com/Kong/kuma/pkg/core/secrets/cipher"
+	"github.com/Kong/kuma/pkg/core/secrets/manager"
+	"github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	test_resources "github.com/Kong/kuma/pkg/test/resources"
+)
+
+type senderFunc func(*observability_proto.EnvoyAdminRequest) error
+
+func (f senderFunc) Send(req *observability_proto.EnvoyAdminRequest) error {
+	return f(req)
+}
+
+var _ = Describe("Envoy Admin WS", func() {
+
+	var client kumactl_envoyadmin.EnvoyAdminClient
+	var srv *httptest.Server
+	var channels admin.EnvoyAdminChannels
+
+	BeforeEach(func() {
+		memStore := memory.NewStore()
+		resManager := resources_manager.NewResourceManager(memStore)
+		secretManager := manager.NewSecretManager(store.NewSecretStore(memStore), cipher.None())
+		channels = admin.NewEnvoyAdminChannels()
+
+		container := restful.NewContainer()
+		// other webservices of the Admin Server are added in the same order as in the Admin Server,
+		// so that they don't clash with the envoy admin webservice
+		container.Add(ca_provided_rest.NewWebservice(provided.NewProvidedCaManager(secretManager), resManager))
+		container.Add(bundle_rest.NewWebservice(bundle.NewBundleManager(resManager, secretManager, builtin.NewBuiltinCaManager(secretManager), test_resources.Global())))
+		container.Add(rest.NewWebservice(channels, resManager))
+		srv = httptest.NewServer(container)
+
+		// wait for the server
+		Eventually(func() error {
+			_, err := http.DefaultClient.Get(srv.URL)
+			return err
+		}).ShouldNot(HaveOccurred())
+
+		c, err := kumactl_envoyadmin.NewEnvoyAdminClient(srv.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+		client = c
+
+		err = resManager.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		dataplane := &core_mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: "192.168.0.1:80:8080", Tags: map[string]string{"service": "backend"}},
+					},
+				},
+			},
+		}
+		err = resManager.Create(context.Background(), dataplane, core_store.CreateByKey("backend-01", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	connect := func() admin.Channel {
+		var ch admin.Channel
+		ch = channels.Open(core_model.ResourceKey{Mesh: "demo", Name: "backend-01"}, senderFunc(func(req *observability_proto.EnvoyAdminRequest) error {
+			go ch.Deliver(&observability_proto.EnvoyAdminResponse{
+				RequestId: req.RequestId,
+				Body:      []byte(`{"path":"` + req.Path + `"}`),
+			})
+			return nil
+		}))
+		return ch
+	}
+
+	It("should return config dump of a dataplane", func() {
+		// given
+		ch := connect()
+		defer ch.Close()
+
+		// when
+		body, err := client.Inspect("demo", "backend-01", "config_dump")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"path":"/config_dump"}`))
+	})
+
+	It("should return config dump by default", func() {
+		// given
+		ch := connect()
+		defer ch.Close()
+
+		// when
+		resp, err := http.Get(srv.URL + "/meshes/demo/dataplanes/backend-01/xds")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/json"))
+	})
+
+	It("should return stats of a dataplane", func() {
+		// given
+		ch := connect()
+		defer ch.Close()
+
+		// when
+		body, err := client.Inspect("demo", "backend-01", "stats")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"path":"/stats"}`))
+	})
+
+	It("should reject an unknown endpoint", func() {
+		// when
+		_, err := client.Inspect("demo", "backend-01", "quitquitquit")
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not inspect the dataplane",
+			Details: "Resource is not valid",
+			Causes: []types.Cause{
+				{Field: "endpoint", Message: "has to be one of: config_dump, clusters, stats"},
+			},
+		}))
+	})
+
+	It("should return 404 for a dataplane that does not exist", func() {
+		// when
+		_, err := client.Inspect("demo", "web-01", "config_dump")
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not inspect the dataplane",
+			Details: "Not found",
+		}))
+	})
+
+	It("should return 503 for a dataplane that is not connected", func() {
+		// when
+		resp, err := http.Get(srv.URL + "/meshes/demo/dataplanes/backend-01/xds")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(503))
+
+		// when
+		_, err = client.Inspect("demo", "backend-01", "config_dump")
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not inspect the dataplane",
+			Details: "Dataplane backend-01 in mesh demo has no reverse channel to this instance of Control Plane",
+		}))
+	})
+})
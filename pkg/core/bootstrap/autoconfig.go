@@ -23,9 +23,9 @@ var autoconfigureLog = core.Log.WithName("bootstrap").WithName("auto-configure")
 
 func autoconfigure(cfg *kuma_cp.Config) error {
 	autoconfigureAdminServer(cfg)
+	autoconfigBootstrapXdsParams(cfg)
 	autoconfigureCatalog(cfg)
 	autoconfigureGui(cfg)
-	autoconfigBootstrapXdsParams(cfg)
 	return autoconfigureSds(cfg)
 }
 
@@ -40,6 +40,10 @@ func autoconfigureCatalog(cfg *kuma_cp.Config) {
 		MonitoringAssignment: catalog.MonitoringAssignmentApiConfig{
 			Url: fmt.Sprintf("grpc://%s:%d", cfg.General.AdvertisedHostname, cfg.MonitoringAssignmentServer.GrpcPort),
 		},
+		// reverse channels of `kuma-dp` are served by xDS server, which is reachable at the same address as for Envoy
+		EnvoyAdmin: catalog.EnvoyAdminApiConfig{
+			Url: fmt.Sprintf("grpc://%s:%d", cfg.BootstrapServer.Params.XdsHost, cfg.BootstrapServer.Params.XdsPort),
+		},
 	}
 	if cfg.AdminServer.Public.Enabled {
 		cat.Admin.PublicUrl = fmt.Sprintf("https://%s:%d", cfg.General.AdvertisedHostname, cfg.AdminServer.Public.Port)
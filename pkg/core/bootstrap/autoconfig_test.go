@@ -56,6 +56,9 @@ var _ = Describe("Auto configuration", func() {
 				MonitoringAssignment: catalog.MonitoringAssignmentApiConfig{
 					Url: "grpc://kuma.internal:5676",
 				},
+				EnvoyAdmin: catalog.EnvoyAdminApiConfig{
+					Url: "grpc://kuma.internal:5678",
+				},
 			},
 		}),
 		Entry("without public port explicitly defined", testCase{
@@ -83,6 +86,9 @@ var _ = Describe("Auto configuration", func() {
 				MonitoringAssignment: catalog.MonitoringAssignmentApiConfig{
 					Url: "grpc://kuma.internal:5676",
 				},
+				EnvoyAdmin: catalog.EnvoyAdminApiConfig{
+					Url: "grpc://kuma.internal:5678",
+				},
 			},
 		}),
 		Entry("without public settings for dataplane token server", testCase{
@@ -108,6 +114,9 @@ var _ = Describe("Auto configuration", func() {
 				MonitoringAssignment: catalog.MonitoringAssignmentApiConfig{
 					Url: "grpc://kuma.internal:5676",
 				},
+				EnvoyAdmin: catalog.EnvoyAdminApiConfig{
+					Url: "grpc://kuma.internal:5678",
+				},
 			},
 		}),
 		Entry("without dataplane token server", testCase{
@@ -131,6 +140,9 @@ var _ = Describe("Auto configuration", func() {
 				MonitoringAssignment: catalog.MonitoringAssignmentApiConfig{
 					Url: "grpc://localhost:5676",
 				},
+				EnvoyAdmin: catalog.EnvoyAdminApiConfig{
+					Url: "grpc://localhost:5678",
+				},
 			},
 		}),
 	)
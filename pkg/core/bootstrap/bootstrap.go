@@ -4,6 +4,7 @@ import (
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	config_core "github.com/Kong/kuma/pkg/config/core"
 	"github.com/Kong/kuma/pkg/config/core/resources/store"
+	core_admin "github.com/Kong/kuma/pkg/core/admin"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
 	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
@@ -16,6 +17,7 @@ import (
 	runtime_reports "github.com/Kong/kuma/pkg/core/runtime/reports"
 	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
 	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	builtin_issuer "github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 	"github.com/pkg/errors"
@@ -211,6 +213,8 @@ func initializeDiscovery(cfg kuma_cp.Config, builder *core_runtime.Builder) erro
 
 func initializeXds(builder *core_runtime.Builder) {
 	builder.WithXdsContext(core_xds.NewXdsContext())
+	builder.WithStatsAggregator(core_stats.NewAggregator(core_stats.DefaultStaleAfter))
+	builder.WithEnvoyAdminChannels(core_admin.NewEnvoyAdminChannels())
 }
 
 func initializeCaManagers(builder *core_runtime.Builder) {
@@ -224,7 +228,7 @@ func initializeResourceManager(builder *core_runtime.Builder) {
 	customizableManager := core_manager.NewCustomizableResourceManager(defaultManager, customManagers)
 	meshManager := mesh_managers.NewMeshManager(builder.ResourceStore(), builder.BuiltinCaManager(), builder.ProvidedCaManager(), customizableManager, builder.SecretManager(), registry.Global())
 	customManagers[mesh.MeshType] = meshManager
-	builder.WithResourceManager(customizableManager)
+	builder.WithResourceManager(core_manager.NewMeteredResourceManager(customizableManager))
 }
 
 func customizeRuntime(rt core_runtime.Runtime) error {
@@ -0,0 +1,410 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/pkg/errors"
+
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	core_registry "github.com/Kong/kuma/pkg/core/resources/registry"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	"github.com/Kong/kuma/pkg/core/validators"
+)
+
+// Bundle is a portable snapshot of a Mesh that can be moved between Control Planes.
+type Bundle struct {
+	Mesh string `json:"mesh"`
+	// Resources contains the Mesh itself followed by all resources that belong to it.
+	Resources []json.RawMessage `json:"resources"`
+	// Secrets contains secrets of the Mesh, e.g. its CA, encrypted with a passphrase.
+	Secrets []Secret `json:"secrets,omitempty"`
+}
+
+type Secret struct {
+	Name  string `json:"name"`
+	Value []byte `json:"value"`
+}
+
+// ConflictPolicy defines what happens on import when a resource already exists.
+type ConflictPolicy string
+
+const (
+	ConflictSkip      ConflictPolicy = "skip"
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	ConflictFail      ConflictPolicy = "fail"
+)
+
+var ConflictPolicies = []ConflictPolicy{ConflictSkip, ConflictOverwrite, ConflictFail}
+
+func ParseConflictPolicy(value string) (ConflictPolicy, error) {
+	if value == "" {
+		return ConflictFail, nil
+	}
+	for _, policy := range ConflictPolicies {
+		if string(policy) == value {
+			return policy, nil
+		}
+	}
+	return "", errors.Errorf("unsupported conflict policy %q. Available policies: %v", value, ConflictPolicies)
+}
+
+type ExportOptions struct {
+	Passphrase string
+	Dataplanes bool
+}
+
+type ResourceRef struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// ImportSummary lists what happened to every resource of a Bundle during import.
+type ImportSummary struct {
+	Created []ResourceRef `json:"created"`
+	Updated []ResourceRef `json:"updated"`
+	Skipped []ResourceRef `json:"skipped"`
+}
+
+type BundleManager interface {
+	Export(ctx context.Context, mesh string, opts ExportOptions) (*Bundle, error)
+	Import(ctx context.Context, bundle *Bundle, passphrase string, policy ConflictPolicy) (*ImportSummary, error)
+}
+
+func NewBundleManager(
+	resManager core_manager.ResourceManager,
+	secretManager secret_manager.SecretManager,
+	builtinCaManager builtin_ca.BuiltinCaManager,
+	registry core_registry.TypeRegistry,
+) BundleManager {
+	return &bundleManager{
+		resManager:       resManager,
+		secretManager:    secretManager,
+		builtinCaManager: builtinCaManager,
+		registry:         registry,
+	}
+}
+
+type bundleManager struct {
+	resManager       core_manager.ResourceManager
+	secretManager    secret_manager.SecretManager
+	builtinCaManager builtin_ca.BuiltinCaManager
+	registry         core_registry.TypeRegistry
+}
+
+var _ BundleManager = &bundleManager{}
+
+func (b *bundleManager) Export(ctx context.Context, mesh string, opts ExportOptions) (*Bundle, error) {
+	if opts.Passphrase == "" {
+		verr := validators.ValidationError{}
+		verr.AddViolation("passphrase", "must not be empty")
+		return nil, verr.OrNil()
+	}
+	meshRes := &core_mesh.MeshResource{}
+	if err := b.resManager.Get(ctx, meshRes, core_store.GetByKey(mesh, mesh)); err != nil {
+		return nil, err
+	}
+	bundle := &Bundle{
+		Mesh: mesh,
+	}
+	if err := bundle.addResource(meshRes); err != nil {
+		return nil, err
+	}
+	for _, typ := range b.exportedTypes(opts.Dataplanes) {
+		list, err := b.registry.NewList(typ)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.resManager.List(ctx, list, core_store.ListByMesh(mesh)); err != nil {
+			return nil, errors.Wrapf(err, "could not list resources of type %q", typ)
+		}
+		for _, res := range list.GetItems() {
+			if err := bundle.addResource(res); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	secrets := &core_system.SecretResourceList{}
+	if err := b.secretManager.List(ctx, secrets, core_store.ListByMesh(mesh)); err != nil {
+		return nil, errors.Wrap(err, "could not list secrets")
+	}
+	encryptor := cipher.NewPassphraseCipher(opts.Passphrase)
+	for _, secret := range secrets.Items {
+		value, err := encryptor.Encrypt(secret.Spec.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encrypt secret %q", secret.Meta.GetName())
+		}
+		bundle.Secrets = append(bundle.Secrets, Secret{
+			Name:  secret.Meta.GetName(),
+			Value: value,
+		})
+	}
+	return bundle, nil
+}
+
+// exportedTypes returns types of resources that belong to a Mesh, in the order they should be imported.
+// Dataplanes go last since they are the only resources that are not policies.
+func (b *bundleManager) exportedTypes(dataplanes bool) []core_model.ResourceType {
+	var types []core_model.ResourceType
+	for _, typ := range b.registry.ListTypes() {
+		switch typ {
+		case core_mesh.MeshType, core_mesh.DataplaneInsightType, core_mesh.DataplaneType:
+			continue
+		}
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	if dataplanes {
+		types = append(types, core_mesh.DataplaneType)
+	}
+	return types
+}
+
+func (b *Bundle) addResource(res core_model.Resource) error {
+	restRes := &rest.Resource{
+		Meta: rest.ResourceMeta{
+			Type: string(res.GetType()),
+			Name: res.GetMeta().GetName(),
+		},
+		Spec: res.GetSpec(),
+	}
+	if res.GetType() != core_mesh.MeshType {
+		restRes.Meta.Mesh = res.GetMeta().GetMesh()
+	}
+	bytes, err := json.Marshal(restRes)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal %s %q", res.GetType(), res.GetMeta().GetName())
+	}
+	b.Resources = append(b.Resources, bytes)
+	return nil
+}
+
+func (b *bundleManager) Import(ctx context.Context, bundle *Bundle, passphrase string, policy ConflictPolicy) (*ImportSummary, error) {
+	resources, err := b.parseResources(bundle)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := b.decryptSecrets(bundle, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if policy == ConflictFail {
+		if err := b.ensureNoConflicts(ctx, resources, secrets); err != nil {
+			return nil, err
+		}
+	}
+
+	// A new Mesh generates its own Builtin CA, which is then replaced with the one from the bundle.
+	// Other secrets, e.g. Provided CA, have to exist before the Mesh is created.
+	caSecretName := b.builtinCaManager.GetSecretName(bundle.Mesh)
+	var caSecret *item
+	summary := &ImportSummary{}
+	for i, secret := range secrets {
+		if secret.key.Name == caSecretName {
+			caSecret = &secrets[i]
+			continue
+		}
+		if err := b.importSecret(ctx, secret, policy, summary); err != nil {
+			return nil, err
+		}
+	}
+	meshCreated := false
+	for _, res := range resources {
+		created, err := b.importResource(ctx, res, policy, summary)
+		if err != nil {
+			return nil, err
+		}
+		if res.resource.GetType() == core_mesh.MeshType {
+			meshCreated = created
+		}
+	}
+	if caSecret != nil {
+		caPolicy := policy
+		if meshCreated {
+			caPolicy = ConflictOverwrite
+		}
+		if err := b.importSecret(ctx, *caSecret, caPolicy, summary); err != nil {
+			return nil, err
+		}
+	}
+	return summary, nil
+}
+
+// item is a resource of a Bundle together with the key it is imported under.
+type item struct {
+	key      core_model.ResourceKey
+	resource core_model.Resource
+}
+
+func (i item) ref() ResourceRef {
+	return ResourceRef{Type: string(i.resource.GetType()), Name: i.key.Name}
+}
+
+func (b *bundleManager) parseResources(bundle *Bundle) ([]item, error) {
+	verr := validators.ValidationError{}
+	if bundle.Mesh == "" {
+		verr.AddViolation("mesh", "must not be empty")
+	}
+	var resources []item
+	for i, raw := range bundle.Resources {
+		path := validators.RootedAt("resources").Index(i)
+		meta := rest.ResourceMeta{}
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			verr.AddViolationAt(path, err.Error())
+			continue
+		}
+		res, err := b.registry.NewObject(core_model.ResourceType(meta.Type))
+		if err != nil || meta.Type == string(core_mesh.DataplaneInsightType) {
+			verr.AddViolationAt(path.Field("type"), "unsupported type of a resource")
+			continue
+		}
+		if err := json.Unmarshal(raw, &rest.Resource{Spec: res.GetSpec()}); err != nil {
+			verr.AddViolationAt(path, err.Error())
+			continue
+		}
+		expectedMesh := bundle.Mesh
+		if res.GetType() == core_mesh.MeshType {
+			expectedMesh = ""
+			if meta.Name != bundle.Mesh {
+				verr.AddViolationAt(path.Field("name"), "must be equal to the mesh of the bundle")
+			}
+		}
+		if meta.Mesh != expectedMesh {
+			verr.AddViolationAt(path.Field("mesh"), "must be equal to the mesh of the bundle")
+		}
+		resources = append(resources, item{
+			key:      core_model.ResourceKey{Mesh: bundle.Mesh, Name: meta.Name},
+			resource: res,
+		})
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func (b *bundleManager) decryptSecrets(bundle *Bundle, passphrase string) ([]item, error) {
+	if len(bundle.Secrets) == 0 {
+		return nil, nil
+	}
+	if passphrase == "" {
+		verr := validators.ValidationError{}
+		verr.AddViolation("passphrase", "must not be empty")
+		return nil, verr.OrNil()
+	}
+	decryptor := cipher.NewPassphraseCipher(passphrase)
+	var secrets []item
+	for _, secret := range bundle.Secrets {
+		value, err := decryptor.Decrypt(secret.Value)
+		if err != nil {
+			verr := validators.ValidationError{}
+			verr.AddViolation("passphrase", err.Error())
+			return nil, verr.OrNil()
+		}
+		secrets = append(secrets, item{
+			key: core_model.ResourceKey{Mesh: bundle.Mesh, Name: secret.Name},
+			resource: &core_system.SecretResource{
+				Spec: wrappers.BytesValue{Value: value},
+			},
+		})
+	}
+	return secrets, nil
+}
+
+func (b *bundleManager) ensureNoConflicts(ctx context.Context, resources []item, secrets []item) error {
+	for _, res := range resources {
+		existing, _ := b.registry.NewObject(res.resource.GetType())
+		if err := b.ensureNotExists(b.resManager.Get(ctx, existing, core_store.GetBy(res.key)), res); err != nil {
+			return err
+		}
+	}
+	for _, secret := range secrets {
+		if err := b.ensureNotExists(b.secretManager.Get(ctx, &core_system.SecretResource{}, core_store.GetBy(secret.key)), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *bundleManager) ensureNotExists(getErr error, i item) error {
+	switch {
+	case getErr == nil:
+		return core_store.ErrorResourceConflict(i.resource.GetType(), i.key.Name, i.key.Mesh)
+	case core_store.IsResourceNotFound(getErr):
+		return nil
+	default:
+		return getErr
+	}
+}
+
+// importResource returns true if the resource was created.
+func (b *bundleManager) importResource(ctx context.Context, res item, policy ConflictPolicy, summary *ImportSummary) (bool, error) {
+	existing, _ := b.registry.NewObject(res.resource.GetType())
+	err := b.resManager.Get(ctx, existing, core_store.GetBy(res.key))
+	switch {
+	case core_store.IsResourceNotFound(err):
+		if err := b.resManager.Create(ctx, res.resource, core_store.CreateBy(res.key)); err != nil {
+			return false, err
+		}
+		summary.Created = append(summary.Created, res.ref())
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+	switch policy {
+	case ConflictSkip:
+		summary.Skipped = append(summary.Skipped, res.ref())
+		return false, nil
+	case ConflictOverwrite:
+		res.resource.SetMeta(existing.GetMeta())
+		if err := b.resManager.Update(ctx, res.resource); err != nil {
+			return false, err
+		}
+		summary.Updated = append(summary.Updated, res.ref())
+		return false, nil
+	default:
+		return false, core_store.ErrorResourceConflict(res.resource.GetType(), res.key.Name, res.key.Mesh)
+	}
+}
+
+func (b *bundleManager) importSecret(ctx context.Context, secret item, policy ConflictPolicy, summary *ImportSummary) error {
+	resource := secret.resource.(*core_system.SecretResource)
+	existing := &core_system.SecretResource{}
+	err := b.secretManager.Get(ctx, existing, core_store.GetBy(secret.key))
+	switch {
+	case core_store.IsResourceNotFound(err):
+		if err := b.secretManager.Create(ctx, resource, core_store.CreateBy(secret.key)); err != nil {
+			return err
+		}
+		summary.Created = append(summary.Created, secret.ref())
+		return nil
+	case err != nil:
+		return err
+	}
+	switch policy {
+	case ConflictSkip:
+		summary.Skipped = append(summary.Skipped, secret.ref())
+		return nil
+	case ConflictOverwrite:
+		resource.SetMeta(existing.GetMeta())
+		if err := b.secretManager.Update(ctx, resource); err != nil {
+			return err
+		}
+		summary.Updated = append(summary.Updated, secret.ref())
+		return nil
+	default:
+		return core_store.ErrorResourceConflict(resource.GetType(), secret.key.Name, secret.key.Mesh)
+	}
+}
@@ -0,0 +1,298 @@
+package bundle_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/bundle"
+	"github.com/Kong/kuma/pkg/core/ca/builtin"
+	"github.com/Kong/kuma/pkg/core/ca/provided"
+	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secrets_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secrets_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/core/validators"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	test_resources "github.com/Kong/kuma/pkg/test/resources"
+)
+
+type controlPlane struct {
+	resManager       manager.ResourceManager
+	builtinCaManager builtin.BuiltinCaManager
+	bundleManager    bundle.BundleManager
+}
+
+func newControlPlane() *controlPlane {
+	resStore := memory.NewStore()
+	secretManager := secrets_manager.NewSecretManager(secrets_store.NewSecretStore(resStore), cipher.None())
+	builtinCaManager := builtin.NewBuiltinCaManager(secretManager)
+	providedCaManager := provided.NewProvidedCaManager(secretManager)
+	defaultManager := manager.NewResourceManager(resStore)
+	resManager := manager.NewCustomizableResourceManager(defaultManager, map[model.ResourceType]manager.ResourceManager{
+		core_mesh.MeshType: mesh_managers.NewMeshManager(resStore, builtinCaManager, providedCaManager, defaultManager, secretManager, test_resources.Global()),
+	})
+	return &controlPlane{
+		resManager:       resManager,
+		builtinCaManager: builtinCaManager,
+		bundleManager:    bundle.NewBundleManager(resManager, secretManager, builtinCaManager, test_resources.Global()),
+	}
+}
+
+var _ = Describe("Bundle Manager", func() {
+
+	const mesh = "demo"
+	const passphrase = "s3cret"
+
+	var source *controlPlane
+	var target *controlPlane
+
+	permission := func() *core_mesh.TrafficPermissionResource {
+		return &core_mesh.TrafficPermissionResource{
+			Spec: mesh_proto.TrafficPermission{
+				Sources: []*mesh_proto.Selector{
+					{Match: map[string]string{"service": "web"}},
+				},
+				Destinations: []*mesh_proto.Selector{
+					{Match: map[string]string{"service": "backend"}},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		source = newControlPlane()
+		target = newControlPlane()
+
+		ctx := context.Background()
+		meshRes := &core_mesh.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Mtls: &mesh_proto.Mesh_Mtls{
+					Enabled: true,
+				},
+			},
+		}
+		err := source.resManager.Create(ctx, meshRes, store.CreateByKey(mesh, mesh))
+		Expect(err).ToNot(HaveOccurred())
+		err = source.resManager.Create(ctx, permission(), store.CreateByKey("web-to-backend", mesh))
+		Expect(err).ToNot(HaveOccurred())
+		dataplane := &core_mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{
+							Interface: "127.0.0.1:8080:80",
+							Tags:      map[string]string{"service": "web"},
+						},
+					},
+				},
+			},
+		}
+		err = source.resManager.Create(ctx, dataplane, store.CreateByKey("web-01", mesh))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	export := func(dataplanes bool) *bundle.Bundle {
+		meshBundle, err := source.bundleManager.Export(context.Background(), mesh, bundle.ExportOptions{
+			Passphrase: passphrase,
+			Dataplanes: dataplanes,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return meshBundle
+	}
+
+	Describe("Export()", func() {
+		It("should export policies and encrypted CA of the mesh", func() {
+			// when
+			meshBundle := export(false)
+
+			// then
+			Expect(meshBundle.Mesh).To(Equal(mesh))
+			Expect(meshBundle.Resources).To(HaveLen(2))
+			Expect(meshBundle.Resources[0]).To(MatchJSON(`{"type": "Mesh", "name": "demo", "mtls": {"enabled": true, "ca": {"builtin": {}}}}`))
+			Expect(meshBundle.Resources[1]).To(MatchJSON(`
+            {
+              "type": "TrafficPermission",
+              "name": "web-to-backend",
+              "mesh": "demo",
+              "sources": [{"match": {"service": "web"}}],
+              "destinations": [{"match": {"service": "backend"}}]
+            }`))
+
+			// and
+			Expect(meshBundle.Secrets).To(HaveLen(1))
+			Expect(meshBundle.Secrets[0].Name).To(Equal("builtinca.demo"))
+			Expect(string(meshBundle.Secrets[0].Value)).ToNot(ContainSubstring("roots"))
+		})
+
+		It("should export dataplanes on demand", func() {
+			// when
+			meshBundle := export(true)
+
+			// then
+			Expect(meshBundle.Resources).To(HaveLen(3))
+			Expect(meshBundle.Resources[2]).To(MatchJSON(`
+            {
+              "type": "Dataplane",
+              "name": "web-01",
+              "mesh": "demo",
+              "networking": {"inbound": [{"interface": "127.0.0.1:8080:80", "tags": {"service": "web"}}]}
+            }`))
+		})
+
+		It("should require a passphrase", func() {
+			// when
+			_, err := source.bundleManager.Export(context.Background(), mesh, bundle.ExportOptions{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*validators.ValidationError).Violations).To(ConsistOf(validators.Violation{
+				Field:   "passphrase",
+				Message: "must not be empty",
+			}))
+		})
+
+		It("should fail when the mesh does not exist", func() {
+			// when
+			_, err := source.bundleManager.Export(context.Background(), "other", bundle.ExportOptions{Passphrase: passphrase})
+
+			// then
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("Import()", func() {
+
+		It("should re-create the mesh with the same CA", func() {
+			// when
+			summary, err := target.bundleManager.Import(context.Background(), export(true), passphrase, bundle.ConflictFail)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summary.Created).To(Equal([]bundle.ResourceRef{
+				{Type: "Mesh", Name: "demo"},
+				{Type: "TrafficPermission", Name: "web-to-backend"},
+				{Type: "Dataplane", Name: "web-01"},
+			}))
+			Expect(summary.Updated).To(Equal([]bundle.ResourceRef{
+				{Type: "Secret", Name: "builtinca.demo"},
+			}))
+
+			// and
+			tp := &core_mesh.TrafficPermissionResource{}
+			err = target.resManager.Get(context.Background(), tp, store.GetByKey("web-to-backend", mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tp.Spec).To(Equal(permission().Spec))
+
+			// and
+			sourceCerts, err := source.builtinCaManager.GetRootCerts(context.Background(), mesh)
+			Expect(err).ToNot(HaveOccurred())
+			targetCerts, err := target.builtinCaManager.GetRootCerts(context.Background(), mesh)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(targetCerts).To(Equal(sourceCerts))
+		})
+
+		Context("when resources already exist", func() {
+
+			var meshBundle *bundle.Bundle
+
+			BeforeEach(func() {
+				meshBundle = export(false)
+				_, err := target.bundleManager.Import(context.Background(), meshBundle, passphrase, bundle.ConflictFail)
+				Expect(err).ToNot(HaveOccurred())
+
+				// modify the imported policy
+				tp := &core_mesh.TrafficPermissionResource{}
+				err = target.resManager.Get(context.Background(), tp, store.GetByKey("web-to-backend", mesh))
+				Expect(err).ToNot(HaveOccurred())
+				tp.Spec.Sources[0].Match["service"] = "other"
+				err = target.resManager.Update(context.Background(), tp)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			sourcesOfPermission := func() string {
+				tp := &core_mesh.TrafficPermissionResource{}
+				err := target.resManager.Get(context.Background(), tp, store.GetByKey("web-to-backend", mesh))
+				Expect(err).ToNot(HaveOccurred())
+				return tp.Spec.Sources[0].Match["service"]
+			}
+
+			It("should fail on conflict by default", func() {
+				// when
+				_, err := target.bundleManager.Import(context.Background(), meshBundle, passphrase, bundle.ConflictFail)
+
+				// then
+				Expect(store.IsResourceConflict(err)).To(BeTrue())
+				Expect(sourcesOfPermission()).To(Equal("other"))
+			})
+
+			It("should skip existing resources", func() {
+				// when
+				summary, err := target.bundleManager.Import(context.Background(), meshBundle, passphrase, bundle.ConflictSkip)
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(summary.Created).To(BeEmpty())
+				Expect(summary.Skipped).To(HaveLen(3))
+				Expect(sourcesOfPermission()).To(Equal("other"))
+			})
+
+			It("should overwrite existing resources", func() {
+				// when
+				summary, err := target.bundleManager.Import(context.Background(), meshBundle, passphrase, bundle.ConflictOverwrite)
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(summary.Created).To(BeEmpty())
+				Expect(summary.Updated).To(HaveLen(3))
+				Expect(sourcesOfPermission()).To(Equal("web"))
+			})
+		})
+
+		It("should not import secrets with an invalid passphrase", func() {
+			// when
+			_, err := target.bundleManager.Import(context.Background(), export(false), "invalid", bundle.ConflictFail)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*validators.ValidationError).Violations).To(ConsistOf(validators.Violation{
+				Field:   "passphrase",
+				Message: "could not decrypt data, the passphrase is most likely invalid",
+			}))
+
+			// and nothing is imported
+			err = target.resManager.Get(context.Background(), &core_mesh.MeshResource{}, store.GetByKey(mesh, mesh))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should validate resources of the bundle", func() {
+			// given
+			meshBundle := &bundle.Bundle{
+				Mesh: mesh,
+				Resources: []json.RawMessage{
+					json.RawMessage(`{"type": "Mesh", "name": "other"}`),
+					json.RawMessage(`{"type": "TrafficPermission", "name": "tp", "mesh": "other"}`),
+					json.RawMessage(`{"type": "DataplaneInsight", "name": "web-01", "mesh": "demo"}`),
+				},
+			}
+
+			// when
+			_, err := target.bundleManager.Import(context.Background(), meshBundle, passphrase, bundle.ConflictFail)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*validators.ValidationError).Violations).To(ConsistOf(
+				validators.Violation{Field: "resources[0].name", Message: "must be equal to the mesh of the bundle"},
+				validators.Violation{Field: "resources[1].mesh", Message: "must be equal to the mesh of the bundle"},
+				validators.Violation{Field: "resources[2].type", Message: "unsupported type of a resource"},
+			))
+		})
+	})
+})
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"github.com/Kong/kuma/pkg/core/bundle"
+)
+
+type ExportRequest struct {
+	Passphrase string `json:"passphrase"`
+	Dataplanes bool   `json:"dataplanes"`
+}
+
+type ImportRequest struct {
+	Passphrase string        `json:"passphrase"`
+	Bundle     bundle.Bundle `json:"bundle"`
+}
@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/bundle"
+	"github.com/Kong/kuma/pkg/core/bundle/rest/types"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	rest_errors "github.com/Kong/kuma/pkg/core/rest/errors"
+	errors_types "github.com/Kong/kuma/pkg/core/rest/errors/types"
+	"github.com/Kong/kuma/pkg/core/validators"
+)
+
+var logger = core.Log.WithName("bundle-ws")
+
+type bundleWebservice struct {
+	bundleManager bundle.BundleManager
+}
+
+func NewWebservice(bundleManager bundle.BundleManager) *restful.WebService {
+	bundleWs := bundleWebservice{
+		bundleManager: bundleManager,
+	}
+	return bundleWs.createWs()
+}
+
+func (b *bundleWebservice) createWs() *restful.WebService {
+	ws := new(restful.WebService).
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+	ws.Path("/").
+		Route(ws.POST("/meshes/{mesh}/export").To(b.exportMesh)).
+		Route(ws.POST("/import").To(b.importBundle).
+			Param(ws.QueryParameter("onConflict", "What to do with resources that already exist: skip, overwrite or fail").DataType("string")))
+	return ws
+}
+
+func (b *bundleWebservice) exportMesh(request *restful.Request, response *restful.Response) {
+	exportReq := types.ExportRequest{}
+	if err := request.ReadEntity(&exportReq); err != nil {
+		rest_errors.HandleError(response, err, "Could not process the request")
+		return
+	}
+	mesh := request.PathParameter("mesh")
+	opts := bundle.ExportOptions{
+		Passphrase: exportReq.Passphrase,
+		Dataplanes: exportReq.Dataplanes,
+	}
+	meshBundle, err := b.bundleManager.Export(request.Request.Context(), mesh, opts)
+	if err != nil {
+		rest_errors.HandleError(response, err, "Could not export the mesh")
+		return
+	}
+	if err := response.WriteAsJson(meshBundle); err != nil {
+		logger.Error(err, "Could not write the response")
+	}
+}
+
+func (b *bundleWebservice) importBundle(request *restful.Request, response *restful.Response) {
+	policy, err := bundle.ParseConflictPolicy(request.QueryParameter("onConflict"))
+	if err != nil {
+		verr := validators.ValidationError{}
+		verr.AddViolation("onConflict", err.Error())
+		rest_errors.HandleError(response, verr.OrNil(), "Could not import the bundle")
+		return
+	}
+	importReq := types.ImportRequest{}
+	if err := request.ReadEntity(&importReq); err != nil {
+		rest_errors.HandleError(response, err, "Could not process the request")
+		return
+	}
+	summary, err := b.bundleManager.Import(request.Request.Context(), &importReq.Bundle, importReq.Passphrase, policy)
+	if err != nil {
+		handleError(response, err, "Could not import the bundle")
+		return
+	}
+	if err := response.WriteAsJson(summary); err != nil {
+		logger.Error(err, "Could not write the response")
+	}
+}
+
+func handleError(response *restful.Response, err error, title string) {
+	if store.IsResourceConflict(err) {
+		kumaErr := errors_types.Error{
+			Title:   title,
+			Details: err.Error(),
+		}
+		if err := response.WriteHeaderAndJson(409, kumaErr, "application/json"); err != nil {
+			logger.Error(err, "Could not write the error response")
+		}
+		return
+	}
+	rest_errors.HandleError(response, err, title)
+}
@@ -0,0 +1,130 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kumactl_bundle "github.com/Kong/kuma/app/kumactl/pkg/bundle"
+	"github.com/Kong/kuma/pkg/core/bundle"
+	"github.com/Kong/kuma/pkg/core/bundle/rest"
+	"github.com/Kong/kuma/pkg/core/ca/builtin"
+	"github.com/Kong/kuma/pkg/core/ca/provided"
+	ca_provided_rest "github.com/Kong/kuma/pkg/core/ca/provided/rest"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	resources_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/rest/errors/types"
+	"github.com/Kong/kuma/pkg/core/secrets/cipher"
+	"github.com/Kong/kuma/pkg/core/secrets/manager"
+	"github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	test_resources "github.com/Kong/kuma/pkg/test/resources"
+)
+
+var _ = Describe("Bundle WS", func() {
+
+	var client kumactl_bundle.BundleClient
+	var srv *httptest.Server
+	var resManager resources_manager.ResourceManager
+
+	BeforeEach(func() {
+		memStore := memory.NewStore()
+		resManager = resources_manager.NewResourceManager(memStore)
+		secretManager := manager.NewSecretManager(store.NewSecretStore(memStore), cipher.None())
+		bundleManager := bundle.NewBundleManager(resManager, secretManager, builtin.NewBuiltinCaManager(secretManager), test_resources.Global())
+
+		container := restful.NewContainer()
+		container.Add(rest.NewWebservice(bundleManager))
+		// other webservices of the Admin Server must not clash with the bundle webservice
+		container.Add(ca_provided_rest.NewWebservice(provided.NewProvidedCaManager(secretManager), resManager))
+		srv = httptest.NewServer(container)
+
+		// wait for the server
+		Eventually(func() error {
+			_, err := http.DefaultClient.Get(srv.URL)
+			return err
+		}).ShouldNot(HaveOccurred())
+
+		c, err := kumactl_bundle.NewBundleClient(srv.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+		client = c
+
+		err = resManager.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		err = memStore.Create(context.Background(), &core_mesh.TrafficLogResource{}, core_store.CreateByKey("logs", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	It("should export and import a mesh", func() {
+		// when
+		meshBundle, err := client.Export("demo", "s3cret", false)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(meshBundle).To(MatchJSON(`
+        {
+          "mesh": "demo",
+          "resources": [
+            {"type": "Mesh", "name": "demo"},
+            {"type": "TrafficLog", "name": "logs", "mesh": "demo"}
+          ]
+        }`))
+
+		// when
+		summary, err := client.Import(meshBundle, "", "skip")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(Equal(bundle.ImportSummary{
+			Skipped: []bundle.ResourceRef{
+				{Type: "Mesh", Name: "demo"},
+				{Type: "TrafficLog", Name: "logs"},
+			},
+		}))
+	})
+
+	It("should return conflict", func() {
+		// given
+		meshBundle, err := client.Export("demo", "s3cret", false)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		_, err = client.Import(meshBundle, "", "fail")
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not import the bundle",
+			Details: `Resource conflict: type="Mesh" name="demo" mesh="demo"`,
+		}))
+	})
+
+	It("should reject unknown conflict policy", func() {
+		// when
+		_, err := client.Import([]byte(`{"mesh": "demo"}`), "", "merge")
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unsupported conflict policy"))
+	})
+
+	It("should return not found for a missing mesh", func() {
+		// when
+		_, err := client.Export("other", "s3cret", false)
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not export the mesh",
+			Details: "Not found",
+		}))
+	})
+})
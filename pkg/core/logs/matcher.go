@@ -2,6 +2,7 @@ package logs
 
 import (
 	"context"
+	"sort"
 
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core"
@@ -17,51 +18,171 @@ import (
 var logger = core.Log.WithName("logs")
 
 // Current limitations:
-// 1) Right now we only generate and place access logs for outbound listeners
-// 2) We match all tags in source section of TrafficLog but only service tag on destination
-// 3) Let's assume we've got following dataplanes:
-//    Dataplane 1 with services: kong and kong-admin
-//    Dataplane 2 with services: backend
-//    If we define rule kong->backend, it is also applied for kong-admin because there is no way to differentiate
-//    traffic from services that are using one dataplane.
+//  1. On inbound listeners a source of incoming traffic is not known in advance and might not have a dataplane.
+//     TrafficLogs with `service: *` in source section are applied to traffic coming from any source.
+//     TrafficLogs with `service: <name>` in source section are applied only when mTLS is enabled, since a service
+//     of a source is told apart from a SPIFFE ID of its certificate, in which case access logs are delivered by kuma-dp.
+//     TrafficLogs that select sources by other tags, or any TrafficLogs that select particular sources when mTLS
+//     is disabled, are not applied to inbound listeners, which is reported in logs of Control Plane.
+//  2. On outbound listeners we match all tags in source section of TrafficLog but only service tag on destination
+//  3. Let's assume we've got following dataplanes:
+//     Dataplane 1 with services: kong and kong-admin
+//     Dataplane 2 with services: backend
+//     If we define rule kong->backend, it is also applied for kong-admin because there is no way to differentiate
+//     traffic from services that are using one dataplane.
 type TrafficLogsMatcher struct {
 	ResourceManager manager.ResourceManager
 }
 
 func (m *TrafficLogsMatcher) Match(ctx context.Context, dataplane *mesh_core.DataplaneResource) (core_xds.LogMap, error) {
-	logs := &mesh_core.TrafficLogResourceList{}
-	if err := m.ResourceManager.List(ctx, logs, store.ListByMesh(dataplane.GetMeta().GetMesh())); err != nil {
-		return nil, errors.Wrap(err, "could not retrieve traffic logs")
-	}
-	backends, err := m.backendsByName(ctx, dataplane)
+	policies, mesh, err := m.policiesAndMesh(ctx, dataplane)
 	if err != nil {
 		return nil, err
 	}
-
-	policies := make([]policy.ConnectionPolicy, len(logs.Items))
-	for i, log := range logs.Items {
-		policies[i] = log
-	}
+	backends := backendsByName(mesh)
 	policyMap := policy.SelectOutboundConnectionPolicies(dataplane, policies)
 
 	logMap := core_xds.LogMap{}
 	for service, policy := range policyMap {
-		log := policy.(*mesh_core.TrafficLogResource)
-		backend, found := backends[log.Spec.GetConf().GetBackend()]
-		if !found {
-			logger.Info("Logging backend is not found. Ignoring.", "name", log.Spec.GetConf().GetBackend(), "trafficLog", log.GetMeta())
-			continue
+		if backend := backendOf(policy, backends); backend != nil {
+			logMap[service] = backend
 		}
-		logMap[service] = backend
 	}
 	return logMap, nil
 }
 
-func (m *TrafficLogsMatcher) backendsByName(ctx context.Context, dataplane *mesh_core.DataplaneResource) (map[string]*mesh_proto.LoggingBackend, error) {
-	mesh := mesh_core.MeshResource{}
-	if err := m.ResourceManager.Get(ctx, &mesh, store.GetByKey(dataplane.GetMeta().GetMesh(), dataplane.GetMeta().GetMesh())); err != nil {
+// MatchInbound matches TrafficLogs for inbound interfaces of a dataplane.
+// When mTLS is enabled, traffic from particular services is logged according to TrafficLogs that select them,
+// while traffic from the rest of sources is logged according to TrafficLogs that apply to any source.
+func (m *TrafficLogsMatcher) MatchInbound(ctx context.Context, dataplane *mesh_core.DataplaneResource) (core_xds.InboundLogMap, error) {
+	policies, mesh, err := m.policiesAndMesh(ctx, dataplane)
+	if err != nil {
 		return nil, err
 	}
+	backends := backendsByName(mesh)
+	mtlsEnabled := mesh.Spec.GetMtls().GetEnabled()
+	reportSkippedInboundPolicies(dataplane, policies, mtlsEnabled)
+
+	policiesBySource := policy.InboundSourceRankedConnectionPolicyMap{}
+	if mtlsEnabled {
+		policiesBySource = policy.RankInboundConnectionPoliciesBySource(dataplane, policies)
+	}
+
+	logMap := core_xds.InboundLogMap{}
+	for inbound, candidates := range policy.RankInboundConnectionPolicies(dataplane, policies) {
+		if logs := inboundLogsOf(candidates.BestRanked(), policiesBySource[inbound], backends); len(logs) > 0 {
+			logMap[inbound] = logs
+		}
+	}
+	return logMap, nil
+}
+
+// inboundLogsOf picks the most specific TrafficLog for each source service, unless a TrafficLog that applies
+// to any source is more specific, and the most specific TrafficLog for the rest of sources.
+func inboundLogsOf(anySource *policy.RankedConnectionPolicy, candidatesBySource map[core_xds.ServiceName]policy.RankedConnectionPolicies, backends map[string]*mesh_proto.LoggingBackend) []core_xds.InboundLog {
+	services := make([]core_xds.ServiceName, 0, len(candidatesBySource))
+	for service := range candidatesBySource {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	var selected []policy.ConnectionPolicy
+	sourcesOf := map[policy.ConnectionPolicy][]core_xds.ServiceName{}
+	var excludedSources []core_xds.ServiceName
+	for _, service := range services {
+		best := candidatesBySource[service].BestRanked()
+		if best == nil || anySource != nil && best.Rank.CompareTo(anySource.Rank) <= 0 {
+			continue
+		}
+		if _, ok := sourcesOf[best.Policy]; !ok {
+			selected = append(selected, best.Policy)
+		}
+		sourcesOf[best.Policy] = append(sourcesOf[best.Policy], service)
+		excludedSources = append(excludedSources, service)
+	}
+
+	var logs []core_xds.InboundLog
+	for _, policy := range selected {
+		if backend := backendOf(policy, backends); backend != nil {
+			logs = append(logs, core_xds.InboundLog{
+				TrafficLog: policy.(*mesh_core.TrafficLogResource),
+				Backend:    backend,
+				Sources:    sourcesOf[policy],
+			})
+		}
+	}
+	if anySource != nil {
+		if backend := backendOf(anySource.Policy, backends); backend != nil {
+			logs = append(logs, core_xds.InboundLog{
+				TrafficLog:      anySource.Policy.(*mesh_core.TrafficLogResource),
+				Backend:         backend,
+				ExcludedSources: excludedSources,
+			})
+		}
+	}
+	return logs
+}
+
+// reportSkippedInboundPolicies logs TrafficLogs that apply to inbound interfaces of a dataplane but select sources
+// that cannot be told apart there.
+func reportSkippedInboundPolicies(dataplane *mesh_core.DataplaneResource, policies []policy.ConnectionPolicy, mtlsEnabled bool) {
+	for _, p := range policies {
+		if !appliesToInbound(dataplane, p) {
+			continue
+		}
+		for _, source := range p.Sources() {
+			selector := mesh_proto.TagSelector(source.Match)
+			if policy.MatchesAnySource(selector) {
+				continue
+			}
+			if !mtlsEnabled {
+				logger.Info("TrafficLog selects particular sources, which cannot be told apart on inbound interfaces unless mTLS is enabled. Ignoring on inbound interfaces.", "trafficLog", p.GetMeta(), "source", source.Match, "dataplane", dataplane.GetMeta())
+			} else if _, ok := policy.SourceServiceOf(selector); !ok {
+				logger.Info("TrafficLog selects sources by tags other than `service`, which cannot be told apart on inbound interfaces. Ignoring on inbound interfaces.", "trafficLog", p.GetMeta(), "source", source.Match, "dataplane", dataplane.GetMeta())
+			}
+		}
+	}
+}
+
+func appliesToInbound(dataplane *mesh_core.DataplaneResource, policy policy.ConnectionPolicy) bool {
+	for _, inbound := range dataplane.Spec.GetNetworking().GetInbound() {
+		for _, destination := range policy.Destinations() {
+			if inbound.MatchTags(destination.Match) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *TrafficLogsMatcher) policiesAndMesh(ctx context.Context, dataplane *mesh_core.DataplaneResource) ([]policy.ConnectionPolicy, *mesh_core.MeshResource, error) {
+	logs := &mesh_core.TrafficLogResourceList{}
+	if err := m.ResourceManager.List(ctx, logs, store.ListByMesh(dataplane.GetMeta().GetMesh())); err != nil {
+		return nil, nil, errors.Wrap(err, "could not retrieve traffic logs")
+	}
+	mesh := &mesh_core.MeshResource{}
+	if err := m.ResourceManager.Get(ctx, mesh, store.GetByKey(dataplane.GetMeta().GetMesh(), dataplane.GetMeta().GetMesh())); err != nil {
+		return nil, nil, err
+	}
+
+	policies := make([]policy.ConnectionPolicy, len(logs.Items))
+	for i, log := range logs.Items {
+		policies[i] = log
+	}
+	return policies, mesh, nil
+}
+
+func backendOf(policy policy.ConnectionPolicy, backends map[string]*mesh_proto.LoggingBackend) *mesh_proto.LoggingBackend {
+	log := policy.(*mesh_core.TrafficLogResource)
+	backend, found := backends[log.Spec.GetConf().GetBackend()]
+	if !found {
+		logger.Info("Logging backend is not found. Ignoring.", "name", log.Spec.GetConf().GetBackend(), "trafficLog", log.GetMeta())
+		return nil
+	}
+	return backend
+}
+
+func backendsByName(mesh *mesh_core.MeshResource) map[string]*mesh_proto.LoggingBackend {
 	backendsByName := map[string]*mesh_proto.LoggingBackend{}
 	for _, backend := range mesh.Spec.GetLogging().GetBackends() {
 		backendsByName[backend.Name] = backend
@@ -70,5 +191,5 @@ func (m *TrafficLogsMatcher) backendsByName(ctx context.Context, dataplane *mesh
 	if defaultBackend != "" {
 		backendsByName[""] = backendsByName[defaultBackend]
 	}
-	return backendsByName, nil
+	return backendsByName
 }
@@ -7,6 +7,7 @@ import (
 	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	"github.com/Kong/kuma/pkg/plugins/resources/memory"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -208,4 +209,235 @@ var _ = Describe("Matcher", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(log).To(HaveLen(0))
 	})
+
+	It("should match rules for inbound interfaces", func() {
+		// given
+		logRes1 := core_mesh.TrafficLogResource{
+			Spec: mesh_proto.TrafficLog{
+				Sources: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "web",
+						},
+					},
+				},
+				Destinations: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "kong",
+						},
+					},
+				},
+				Conf: &mesh_proto.TrafficLog_Conf{
+					Backend: "file2",
+				},
+			},
+		}
+		err := manager.Create(context.Background(), &logRes1, store.CreateByKey("lr-1", "sample"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		logRes2 := core_mesh.TrafficLogResource{
+			Spec: mesh_proto.TrafficLog{
+				Sources: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "*",
+						},
+					},
+				},
+				Destinations: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "*",
+						},
+					},
+				},
+				Conf: &mesh_proto.TrafficLog_Conf{
+					Backend: "file3",
+				},
+			},
+		}
+		err = manager.Create(context.Background(), &logRes2, store.CreateByKey("lr-2", "sample"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		logRes3 := core_mesh.TrafficLogResource{
+			Spec: mesh_proto.TrafficLog{
+				Sources: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "*",
+						},
+					},
+				},
+				Destinations: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "kong",
+						},
+					},
+				},
+				Conf: &mesh_proto.TrafficLog_Conf{
+					Backend: "file1",
+				},
+			},
+		}
+		err = manager.Create(context.Background(), &logRes3, store.CreateByKey("lr-3", "sample"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		log, err := matcher.MatchInbound(context.Background(), &dpRes)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(log).To(HaveLen(2))
+		// should match because of *->kong rule, web->kong rule is ignored since sources cannot be told apart without mTLS
+		Expect(inboundLogs(log["127.0.0.1:8080:8081"])).To(Equal([]inboundLog{
+			{trafficLog: "lr-3", backend: backendFile1},
+		}))
+		// should match because *->* rule
+		Expect(inboundLogs(log["127.0.0.1:8090:8091"])).To(Equal([]inboundLog{
+			{trafficLog: "lr-2", backend: backendFile3},
+		}))
+	})
+
+	Context("with mTLS enabled", func() {
+
+		BeforeEach(func() {
+			mesh := core_mesh.MeshResource{}
+			err := manager.Get(context.Background(), &mesh, store.GetByKey("sample", "sample"))
+			Expect(err).ToNot(HaveOccurred())
+			mesh.Spec.Mtls = &mesh_proto.Mesh_Mtls{
+				Enabled: true,
+				Ca: &mesh_proto.CertificateAuthority{
+					Type: &mesh_proto.CertificateAuthority_Builtin_{
+						Builtin: &mesh_proto.CertificateAuthority_Builtin{},
+					},
+				},
+			}
+			err = manager.Update(context.Background(), &mesh)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		trafficLog := func(name string, sources []map[string]string, destination string, backend string) {
+			logRes := core_mesh.TrafficLogResource{
+				Spec: mesh_proto.TrafficLog{
+					Destinations: []*mesh_proto.Selector{
+						{
+							Match: map[string]string{
+								"service": destination,
+							},
+						},
+					},
+					Conf: &mesh_proto.TrafficLog_Conf{
+						Backend: backend,
+					},
+				},
+			}
+			for _, source := range sources {
+				logRes.Spec.Sources = append(logRes.Spec.Sources, &mesh_proto.Selector{Match: source})
+			}
+			err := manager.Create(context.Background(), &logRes, store.CreateByKey(name, "sample"))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("should match rules that apply to particular sources for inbound interfaces", func() {
+			// given
+			trafficLog("lr-1", []map[string]string{{"service": "web"}, {"service": "mobile"}}, "kong", "file2")
+			trafficLog("lr-2", []map[string]string{{"service": "*"}}, "*", "file3")
+			trafficLog("lr-3", []map[string]string{{"service": "mobile"}}, "*", "file1")
+			trafficLog("lr-4", []map[string]string{{"service": "web", "version": "v1"}}, "kong", "file3")
+			trafficLog("lr-5", []map[string]string{{"service": "admin"}}, "backend", "file3")
+
+			// when
+			log, err := matcher.MatchInbound(context.Background(), &dpRes)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(log).To(HaveLen(2))
+			// web->kong and mobile->kong rules are more specific than mobile->* rule,
+			// while web(v1)->kong rule is ignored since a version of a source cannot be told apart
+			Expect(inboundLogs(log["127.0.0.1:8080:8081"])).To(Equal([]inboundLog{
+				{trafficLog: "lr-1", backend: backendFile2, sources: []string{"mobile", "web"}},
+				{trafficLog: "lr-2", backend: backendFile3, excludedSources: []string{"mobile", "web"}},
+			}))
+			// should match because of mobile->* rule for mobile service and *->* rule for the rest of sources
+			Expect(inboundLogs(log["127.0.0.1:8090:8091"])).To(Equal([]inboundLog{
+				{trafficLog: "lr-3", backend: backendFile1, sources: []string{"mobile"}},
+				{trafficLog: "lr-2", backend: backendFile3, excludedSources: []string{"mobile"}},
+			}))
+		})
+
+		It("should prefer a more specific rule that applies to any source", func() {
+			// given
+			trafficLog("lr-1", []map[string]string{{"service": "*"}}, "kong", "file2")
+			trafficLog("lr-2", []map[string]string{{"service": "web"}}, "*", "file3")
+
+			// when
+			log, err := matcher.MatchInbound(context.Background(), &dpRes)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// *->kong rule is as specific as web->* rule
+			Expect(inboundLogs(log["127.0.0.1:8080:8081"])).To(Equal([]inboundLog{
+				{trafficLog: "lr-1", backend: backendFile2},
+			}))
+			// should match because of web->* rule only for web service
+			Expect(inboundLogs(log["127.0.0.1:8090:8091"])).To(Equal([]inboundLog{
+				{trafficLog: "lr-2", backend: backendFile3, sources: []string{"web"}},
+			}))
+		})
+	})
+
+	It("should not match inbound interfaces", func() {
+		// given
+		logRes := core_mesh.TrafficLogResource{
+			Spec: mesh_proto.TrafficLog{
+				Sources: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "*",
+						},
+					},
+				},
+				Destinations: []*mesh_proto.Selector{
+					{
+						Match: map[string]string{
+							"service": "backend",
+						},
+					},
+				},
+			},
+		}
+		err := manager.Create(context.Background(), &logRes, store.CreateByKey("lr-1", "sample"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		log, err := matcher.MatchInbound(context.Background(), &dpRes)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(log).To(HaveLen(0))
+	})
 })
+
+type inboundLog struct {
+	trafficLog      string
+	backend         *mesh_proto.LoggingBackend
+	sources         []string
+	excludedSources []string
+}
+
+func inboundLogs(logs []core_xds.InboundLog) []inboundLog {
+	var result []inboundLog
+	for _, log := range logs {
+		result = append(result, inboundLog{
+			trafficLog:      log.TrafficLog.GetMeta().GetName(),
+			backend:         log.Backend,
+			sources:         log.Sources,
+			excludedSources: log.ExcludedSources,
+		})
+	}
+	return result
+}
@@ -78,6 +78,9 @@ func (m *meshManager) Create(ctx context.Context, resource core_model.Resource,
 	if err := m.meshValidator.ValidateCreate(ctx, opts.Name, mesh); err != nil {
 		return err
 	}
+	if opts.DryRun {
+		return nil
+	}
 	// keep creation of Mesh and Built-in CA in sync
 	var rollback func() error
 	defer func() {
@@ -168,6 +171,9 @@ func (m *meshManager) Update(ctx context.Context, resource core_model.Resource,
 	if err := m.meshValidator.ValidateUpdate(ctx, currentMesh, mesh); err != nil {
 		return err
 	}
+	if core_store.NewUpdateOptions(fs...).DryRun {
+		return nil
+	}
 	return m.store.Update(ctx, mesh, fs...)
 }
 
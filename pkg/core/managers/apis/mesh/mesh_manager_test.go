@@ -71,6 +71,32 @@ var _ = Describe("Mesh Manager", func() {
 			Expect(certs).To(HaveLen(1))
 		})
 
+		It("should neither persist a Mesh nor create a built-in CA on a dry run", func() {
+			// given
+			meshName := "mesh-1"
+			resKey := model.ResourceKey{
+				Mesh: meshName,
+				Name: meshName,
+			}
+
+			// when
+			mesh := core_mesh.MeshResource{}
+			err := resManager.Create(context.Background(), &mesh, store.CreateBy(resKey), store.CreateWithDryRun(true))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and defaults are applied
+			Expect(mesh.Spec.GetMtls().GetCa().GetBuiltin()).ToNot(BeNil())
+
+			// and Mesh is not persisted
+			err = resStore.Get(context.Background(), &core_mesh.MeshResource{}, store.GetBy(resKey))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+
+			// and built-in CA is not created
+			_, err = builtinCaManager.GetRootCerts(context.Background(), meshName)
+			Expect(err).To(HaveOccurred())
+		})
+
 		Describe("should set default values for Prometheus settings", func() {
 
 			type testCase struct {
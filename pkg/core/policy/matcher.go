@@ -57,6 +57,18 @@ func SelectOutboundConnectionPolicies(dataplane *mesh_core.DataplaneResource, po
 
 // SelectConnectionPolicies picks a single the most specific policy applicable to a connection between a given dataplane and given destination services.
 func SelectConnectionPolicies(dataplane *mesh_core.DataplaneResource, destinations ServiceIterator, policies []ConnectionPolicy) ConnectionPolicyMap {
+	policyMap := ConnectionPolicyMap{}
+	for service, candidates := range RankConnectionPolicies(dataplane, destinations, policies) {
+		if best := candidates.Best(); best != nil {
+			policyMap[service] = best
+		}
+	}
+	return policyMap
+}
+
+// RankConnectionPolicies finds all policies applicable to a connection between a given dataplane and given destination services
+// and ranks them by how specific their selectors are.
+func RankConnectionPolicies(dataplane *mesh_core.DataplaneResource, destinations ServiceIterator, policies []ConnectionPolicy) RankedConnectionPolicyMap {
 	sort.Stable(ConnectionPolicyByName(policies)) // sort to avoid flakiness
 
 	// First, select only those ConnectionPolicies that have a `source` selector matching a given Dataplane.
@@ -95,45 +107,173 @@ func SelectConnectionPolicies(dataplane *mesh_core.DataplaneResource, destinatio
 	// To choose between them, we need to compute an aggregate rank of the most specific selector by `source`
 	// with the most specific selector by `destination`.
 
-	type candidateByDestination struct {
-		candidateBySource
-		bestAggregateRank mesh_proto.TagSelectorRank
-	}
-
-	candidatesByDestination := map[core_xds.ServiceName]candidateByDestination{}
+	candidatesByDestination := RankedConnectionPolicyMap{}
 	for service, ok := destinations.Next(); ok; service, ok = destinations.Next() {
 		if _, ok := candidatesByDestination[service]; ok {
 			// apparently, multiple outbound interfaces of a given Dataplane refer to the same service
 			continue
 		}
 		outboundTags := mesh_proto.SingleValueTagSet{mesh_proto.ServiceTag: service}
+		candidates := RankedConnectionPolicies{}
 		for _, candidateBySource := range candidatesBySource {
+			var best *RankedConnectionPolicy
 			for _, destination := range candidateBySource.policy.Destinations() {
 				destinationSelector := mesh_proto.TagSelector(destination.Match)
 				if destinationSelector.Matches(outboundTags) {
 					aggregateRank := destinationSelector.Rank().CombinedWith(candidateBySource.bestSourceRank)
+					if best == nil || aggregateRank.CompareTo(best.Rank) > 0 {
+						best = &RankedConnectionPolicy{Policy: candidateBySource.policy, Rank: aggregateRank}
+					}
+				}
+			}
+			if best != nil {
+				candidates = append(candidates, *best)
+			}
+		}
+		candidatesByDestination[service] = candidates
+	}
+	return candidatesByDestination
+}
 
-					candidateByDestination, exists := candidatesByDestination[service]
+// SelectInboundConnectionPolicies picks a single the most specific policy for each inbound interface of a given Dataplane.
+//
+// Sources of incoming connections are not known in advance, e.g. a client might not have a Dataplane at all,
+// so only policies that apply to connections from any source are taken into account.
+func SelectInboundConnectionPolicies(dataplane *mesh_core.DataplaneResource, policies []ConnectionPolicy) InboundConnectionPolicyMap {
+	policyMap := InboundConnectionPolicyMap{}
+	for inbound, candidates := range RankInboundConnectionPolicies(dataplane, policies) {
+		if best := candidates.Best(); best != nil {
+			policyMap[inbound] = best
+		}
+	}
+	return policyMap
+}
 
-					if !exists || aggregateRank.CompareTo(candidateByDestination.bestAggregateRank) > 0 {
-						// TODO(yskopets): use CreationDate to resolve a conflict between 2 equal ranks
-						candidateByDestination.candidateBySource = candidateBySource
-						candidateByDestination.bestAggregateRank = aggregateRank
+// RankInboundConnectionPolicies finds all policies applicable to each inbound interface of a given Dataplane
+// and ranks them by how specific their `destination` selectors are.
+//
+// Only policies with a `source` selector that matches any source, i.e. `service: *`, are applicable.
+// Policies that select particular sources are ranked by RankInboundConnectionPoliciesBySource.
+func RankInboundConnectionPolicies(dataplane *mesh_core.DataplaneResource, policies []ConnectionPolicy) InboundRankedConnectionPolicyMap {
+	sort.Stable(ConnectionPolicyByName(policies)) // sort to avoid flakiness
 
-						candidatesByDestination[service] = candidateByDestination
-					}
-				}
+	policyMap := InboundRankedConnectionPolicyMap{}
+	for _, inbound := range dataplane.Spec.GetNetworking().GetInbound() {
+		candidates := RankedConnectionPolicies{}
+		for _, policy := range policies {
+			sourceRank, ok := anySourceRankOf(policy)
+			if !ok {
+				continue
+			}
+			if best := rankOnInbound(inbound, policy, sourceRank); best != nil {
+				candidates = append(candidates, *best)
 			}
 		}
+		policyMap[inbound.Interface] = candidates
 	}
+	return policyMap
+}
 
-	policyMap := ConnectionPolicyMap{}
-	for service, candidate := range candidatesByDestination {
-		policyMap[service] = candidate.policy
+// RankInboundConnectionPoliciesBySource finds all policies applicable to each inbound interface of a given Dataplane
+// for connections from particular services and ranks them by how specific their selectors are.
+//
+// Only policies with a `source` selector that selects a single service, i.e. `service: <name>`, are applicable,
+// since a service is the only property of a source that can be told apart on an inbound interface,
+// namely by a SPIFFE ID of a client certificate when mTLS is enabled.
+func RankInboundConnectionPoliciesBySource(dataplane *mesh_core.DataplaneResource, policies []ConnectionPolicy) InboundSourceRankedConnectionPolicyMap {
+	sort.Stable(ConnectionPolicyByName(policies)) // sort to avoid flakiness
+
+	policyMap := InboundSourceRankedConnectionPolicyMap{}
+	for _, inbound := range dataplane.Spec.GetNetworking().GetInbound() {
+		candidatesBySource := map[core_xds.ServiceName]RankedConnectionPolicies{}
+		for _, policy := range policies {
+			for _, source := range policy.Sources() {
+				sourceSelector := mesh_proto.TagSelector(source.Match)
+				service, ok := SourceServiceOf(sourceSelector)
+				if !ok {
+					continue
+				}
+				if best := rankOnInbound(inbound, policy, sourceSelector.Rank()); best != nil {
+					candidatesBySource[service] = append(candidatesBySource[service], *best)
+				}
+			}
+		}
+		policyMap[inbound.Interface] = candidatesBySource
 	}
 	return policyMap
 }
 
+// rankOnInbound ranks a policy by the most specific of its `destination` selectors that match a given inbound interface.
+func rankOnInbound(inbound *mesh_proto.Dataplane_Networking_Inbound, policy ConnectionPolicy, sourceRank mesh_proto.TagSelectorRank) *RankedConnectionPolicy {
+	var best *RankedConnectionPolicy
+	for _, destination := range policy.Destinations() {
+		destinationSelector := mesh_proto.TagSelector(destination.Match)
+		if !inbound.MatchTags(destinationSelector) {
+			continue
+		}
+		rank := destinationSelector.Rank().CombinedWith(sourceRank)
+		if best == nil || rank.CompareTo(best.Rank) > 0 {
+			best = &RankedConnectionPolicy{Policy: policy, Rank: rank}
+		}
+	}
+	return best
+}
+
+// anySourceRankOf returns a rank of a `source` selector of a given policy that matches any source.
+func anySourceRankOf(policy ConnectionPolicy) (mesh_proto.TagSelectorRank, bool) {
+	for _, source := range policy.Sources() {
+		sourceSelector := mesh_proto.TagSelector(source.Match)
+		if MatchesAnySource(sourceSelector) {
+			return sourceSelector.Rank(), true
+		}
+	}
+	return mesh_proto.TagSelectorRank{}, false
+}
+
+// MatchesAnySource tells whether a given selector matches every source, i.e. it is either empty
+// or it has only `service: *`. Every Dataplane has a `service` tag, so `service: *` is a wildcard.
+func MatchesAnySource(selector mesh_proto.TagSelector) bool {
+	for tag, value := range selector {
+		if tag != mesh_proto.ServiceTag || value != mesh_proto.MatchAllTag {
+			return false
+		}
+	}
+	return true
+}
+
+// SourceServiceOf returns a service selected by a given selector if it selects a single service and nothing else,
+// i.e. it has only `service: <name>`.
+func SourceServiceOf(selector mesh_proto.TagSelector) (core_xds.ServiceName, bool) {
+	if len(selector) != 1 {
+		return "", false
+	}
+	service, ok := selector[mesh_proto.ServiceTag]
+	return service, ok && service != mesh_proto.MatchAllTag
+}
+
+// Best returns a policy with the highest rank.
+// If there are multiple such policies, the one that comes first wins.
+func (l RankedConnectionPolicies) Best() ConnectionPolicy {
+	best := l.BestRanked()
+	if best == nil {
+		return nil
+	}
+	return best.Policy
+}
+
+// BestRanked returns a policy with the highest rank along with its rank.
+// If there are multiple such policies, the one that comes first wins.
+func (l RankedConnectionPolicies) BestRanked() *RankedConnectionPolicy {
+	var best *RankedConnectionPolicy
+	for i := range l {
+		if best == nil || l[i].Rank.CompareTo(best.Rank) > 0 {
+			// TODO(yskopets): use CreationDate to resolve a conflict between 2 equal ranks
+			best = &l[i]
+		}
+	}
+	return best
+}
+
 type ConnectionPolicyByName []ConnectionPolicy
 
 func (a ConnectionPolicyByName) Len() int      { return len(a) }
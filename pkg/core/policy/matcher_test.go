@@ -0,0 +1,207 @@
+package policy_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	. "github.com/Kong/kuma/pkg/core/policy"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	test_model "github.com/Kong/kuma/pkg/test/resources/model"
+)
+
+var _ = Describe("Matcher", func() {
+
+	dataplane := &mesh_core.DataplaneResource{
+		Meta: &test_model.ResourceMeta{
+			Mesh: "demo",
+			Name: "web-01",
+		},
+		Spec: mesh_proto.Dataplane{
+			Networking: &mesh_proto.Dataplane_Networking{
+				Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+					{Tags: map[string]string{"service": "web", "version": "v1"}, Interface: "192.168.0.1:80:8080"},
+				},
+				Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+					{Service: "backend", Interface: ":10001"},
+					{Service: "redis", Interface: ":10002"},
+				},
+			},
+		},
+	}
+
+	route := func(name string, sources []mesh_proto.TagSelector, destinations []mesh_proto.TagSelector) *mesh_core.TrafficRouteResource {
+		route := &mesh_core.TrafficRouteResource{
+			Meta: &test_model.ResourceMeta{
+				Mesh: "demo",
+				Name: name,
+			},
+		}
+		for _, source := range sources {
+			route.Spec.Sources = append(route.Spec.Sources, &mesh_proto.Selector{Match: source})
+		}
+		for _, destination := range destinations {
+			route.Spec.Destinations = append(route.Spec.Destinations, &mesh_proto.Selector{Match: destination})
+		}
+		return route
+	}
+
+	routeAll := route("route-all",
+		[]mesh_proto.TagSelector{{"service": "*"}},
+		[]mesh_proto.TagSelector{{"service": "*"}},
+	)
+	routeBackend := route("route-backend",
+		[]mesh_proto.TagSelector{{"service": "*"}, {"service": "web", "version": "v1"}},
+		[]mesh_proto.TagSelector{{"service": "backend"}},
+	)
+	routeBackendToo := route("route-backend-too",
+		[]mesh_proto.TagSelector{{"service": "web", "version": "v1"}},
+		[]mesh_proto.TagSelector{{"service": "backend"}},
+	)
+	routeMobile := route("route-mobile",
+		[]mesh_proto.TagSelector{{"service": "mobile"}},
+		[]mesh_proto.TagSelector{{"service": "*"}},
+	)
+
+	Describe("RankConnectionPolicies()", func() {
+		It("should rank every policy that applies to a connection", func() {
+			// given
+			policies := []ConnectionPolicy{routeAll, routeBackend, routeBackendToo, routeMobile}
+
+			// when
+			ranked := RankConnectionPolicies(dataplane, ToOutboundServicesOf(dataplane), policies)
+
+			// then
+			Expect(ranked).To(Equal(RankedConnectionPolicyMap{
+				"backend": {
+					{Policy: routeAll, Rank: mesh_proto.TagSelectorRank{WildcardMatches: 2}},
+					{Policy: routeBackend, Rank: mesh_proto.TagSelectorRank{ExactMatches: 3}},
+					{Policy: routeBackendToo, Rank: mesh_proto.TagSelectorRank{ExactMatches: 3}},
+				},
+				"redis": {
+					{Policy: routeAll, Rank: mesh_proto.TagSelectorRank{WildcardMatches: 2}},
+				},
+			}))
+		})
+
+		It("should include services that have no applicable policies", func() {
+			// when
+			ranked := RankConnectionPolicies(dataplane, ToOutboundServicesOf(dataplane), []ConnectionPolicy{routeMobile})
+
+			// then
+			Expect(ranked).To(Equal(RankedConnectionPolicyMap{
+				"backend": {},
+				"redis":   {},
+			}))
+		})
+	})
+
+	Describe("SelectConnectionPolicies()", func() {
+		It("should pick the first policy with the highest rank", func() {
+			// given
+			policies := []ConnectionPolicy{routeMobile, routeBackendToo, routeAll, routeBackend}
+
+			// when
+			selected := SelectConnectionPolicies(dataplane, ToOutboundServicesOf(dataplane), policies)
+
+			// then
+			Expect(selected).To(Equal(ConnectionPolicyMap{
+				"backend": routeBackend,
+				"redis":   routeAll,
+			}))
+		})
+	})
+
+	Describe("RankInboundConnectionPolicies()", func() {
+		It("should rank policies by their destination selectors", func() {
+			// given
+			policies := []ConnectionPolicy{routeAll, route("route-web",
+				[]mesh_proto.TagSelector{{"service": "*"}},
+				[]mesh_proto.TagSelector{{"service": "web"}, {"service": "web", "version": "v1"}},
+			)}
+
+			// when
+			ranked := RankInboundConnectionPolicies(dataplane, policies)
+
+			// then
+			Expect(ranked).To(HaveKey("192.168.0.1:80:8080"))
+			Expect(ranked["192.168.0.1:80:8080"]).To(HaveLen(2))
+			Expect(ranked["192.168.0.1:80:8080"][1].Policy.GetMeta().GetName()).To(Equal("route-web"))
+			Expect(ranked["192.168.0.1:80:8080"][1].Rank).To(Equal(mesh_proto.TagSelectorRank{ExactMatches: 2, WildcardMatches: 1}))
+
+			// and
+			Expect(ranked["192.168.0.1:80:8080"].Best().GetMeta().GetName()).To(Equal("route-web"))
+		})
+
+		It("should skip policies that apply only to particular sources", func() {
+			// given
+			policies := []ConnectionPolicy{routeMobile, route("route-web",
+				[]mesh_proto.TagSelector{{"service": "*", "version": "*"}, {"service": "mobile"}},
+				[]mesh_proto.TagSelector{{"service": "web"}},
+			)}
+
+			// when
+			ranked := RankInboundConnectionPolicies(dataplane, policies)
+
+			// then
+			Expect(ranked).To(HaveKey("192.168.0.1:80:8080"))
+			Expect(ranked["192.168.0.1:80:8080"]).To(BeEmpty())
+		})
+
+		It("should consider policies that apply to any source among others", func() {
+			// given
+			policies := []ConnectionPolicy{route("route-web",
+				[]mesh_proto.TagSelector{{"service": "mobile"}, {"service": "*"}},
+				[]mesh_proto.TagSelector{{"service": "web"}},
+			)}
+
+			// when
+			ranked := RankInboundConnectionPolicies(dataplane, policies)
+
+			// then
+			Expect(ranked["192.168.0.1:80:8080"]).To(HaveLen(1))
+			Expect(ranked["192.168.0.1:80:8080"][0].Rank).To(Equal(mesh_proto.TagSelectorRank{ExactMatches: 1, WildcardMatches: 1}))
+		})
+	})
+
+	Describe("RankInboundConnectionPoliciesBySource()", func() {
+		It("should rank policies that apply to particular services by their selectors", func() {
+			// given
+			policies := []ConnectionPolicy{routeAll, routeMobile, route("route-mobile-web",
+				[]mesh_proto.TagSelector{{"service": "mobile"}, {"service": "admin"}},
+				[]mesh_proto.TagSelector{{"service": "web", "version": "v1"}},
+			)}
+
+			// when
+			ranked := RankInboundConnectionPoliciesBySource(dataplane, policies)
+
+			// then
+			Expect(ranked).To(HaveKey("192.168.0.1:80:8080"))
+			Expect(ranked["192.168.0.1:80:8080"]).To(HaveLen(2))
+			Expect(ranked["192.168.0.1:80:8080"]["mobile"]).To(HaveLen(2))
+			Expect(ranked["192.168.0.1:80:8080"]["mobile"][0].Policy.GetMeta().GetName()).To(Equal("route-mobile"))
+			Expect(ranked["192.168.0.1:80:8080"]["mobile"][0].Rank).To(Equal(mesh_proto.TagSelectorRank{ExactMatches: 1, WildcardMatches: 1}))
+			Expect(ranked["192.168.0.1:80:8080"]["mobile"][1].Policy.GetMeta().GetName()).To(Equal("route-mobile-web"))
+			Expect(ranked["192.168.0.1:80:8080"]["mobile"][1].Rank).To(Equal(mesh_proto.TagSelectorRank{ExactMatches: 3}))
+			Expect(ranked["192.168.0.1:80:8080"]["admin"]).To(HaveLen(1))
+
+			// and
+			Expect(ranked["192.168.0.1:80:8080"]["mobile"].Best().GetMeta().GetName()).To(Equal("route-mobile-web"))
+		})
+
+		It("should skip policies with sources that cannot be told apart by a service", func() {
+			// given
+			policies := []ConnectionPolicy{routeAll, route("route-web",
+				[]mesh_proto.TagSelector{{"service": "mobile", "version": "v1"}, {"version": "v1"}, {"service": "*", "version": "*"}},
+				[]mesh_proto.TagSelector{{"service": "web"}},
+			)}
+
+			// when
+			ranked := RankInboundConnectionPoliciesBySource(dataplane, policies)
+
+			// then
+			Expect(ranked).To(HaveKey("192.168.0.1:80:8080"))
+			Expect(ranked["192.168.0.1:80:8080"]).To(BeEmpty())
+		})
+	})
+})
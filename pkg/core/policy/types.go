@@ -15,3 +15,25 @@ type ConnectionPolicy interface {
 
 // ConnectionPolicyMap holds the most specific ConnectionPolicy for each outbound interface of a Dataplane.
 type ConnectionPolicyMap map[core_xds.ServiceName]ConnectionPolicy
+
+// InboundConnectionPolicyMap holds the most specific ConnectionPolicy for each inbound interface of a Dataplane.
+type InboundConnectionPolicyMap map[string]ConnectionPolicy
+
+// RankedConnectionPolicy is a ConnectionPolicy along with a rank of its most specific selectors that match a connection.
+type RankedConnectionPolicy struct {
+	Policy ConnectionPolicy
+	Rank   mesh_proto.TagSelectorRank
+}
+
+// RankedConnectionPolicies is a list of ConnectionPolicies that apply to the same connection.
+type RankedConnectionPolicies []RankedConnectionPolicy
+
+// RankedConnectionPolicyMap holds all ConnectionPolicies applicable to each outbound interface of a Dataplane.
+type RankedConnectionPolicyMap map[core_xds.ServiceName]RankedConnectionPolicies
+
+// InboundRankedConnectionPolicyMap holds all ConnectionPolicies applicable to each inbound interface of a Dataplane.
+type InboundRankedConnectionPolicyMap map[string]RankedConnectionPolicies
+
+// InboundSourceRankedConnectionPolicyMap holds all ConnectionPolicies applicable to each inbound interface of a Dataplane
+// for connections from particular services.
+type InboundSourceRankedConnectionPolicyMap map[string]map[core_xds.ServiceName]RankedConnectionPolicies
@@ -68,9 +68,41 @@ func (d *DataplaneResource) GetPrometheusEndpoint(mesh *MeshResource) *mesh_prot
 	result := &mesh_proto.Metrics_Prometheus{}
 	proto.Merge(result, mesh.Spec.GetMetrics().GetPrometheus())
 	proto.Merge(result, d.Spec.GetMetrics().GetPrometheus())
+	result.Endpoints = mergePrometheusEndpoints(mesh.Spec.GetMetrics().GetPrometheus().GetEndpoints(), d.Spec.GetMetrics().GetPrometheus().GetEndpoints())
 	return result
 }
 
+// GetStatsdSink returns effective StatsD configuration of a Dataplane. StatsD has to be enabled on a Mesh,
+// a Dataplane can only override its settings, e.g. to push metrics to a StatsD agent on the same node.
+func (d *DataplaneResource) GetStatsdSink(mesh *MeshResource) *mesh_proto.Metrics_Statsd {
+	if d == nil || mesh == nil || mesh.Meta.GetName() != d.Meta.GetMesh() || !mesh.HasStatsdMetricsEnabled() {
+		return nil
+	}
+	result := &mesh_proto.Metrics_Statsd{}
+	proto.Merge(result, mesh.Spec.GetMetrics().GetStatsd())
+	proto.Merge(result, d.Spec.GetMetrics().GetStatsd())
+	return result
+}
+
+// mergePrometheusEndpoints returns application endpoints of a Mesh that are not overridden by a Dataplane
+// followed by application endpoints of a Dataplane.
+func mergePrometheusEndpoints(meshEndpoints, dataplaneEndpoints []*mesh_proto.Metrics_Prometheus_Endpoint) []*mesh_proto.Metrics_Prometheus_Endpoint {
+	overridden := map[string]bool{}
+	for _, endpoint := range dataplaneEndpoints {
+		overridden[endpoint.GetName()] = true
+	}
+	var endpoints []*mesh_proto.Metrics_Prometheus_Endpoint
+	for _, endpoint := range meshEndpoints {
+		if !overridden[endpoint.GetName()] {
+			endpoints = append(endpoints, proto.Clone(endpoint).(*mesh_proto.Metrics_Prometheus_Endpoint))
+		}
+	}
+	for _, endpoint := range dataplaneEndpoints {
+		endpoints = append(endpoints, proto.Clone(endpoint).(*mesh_proto.Metrics_Prometheus_Endpoint))
+	}
+	return endpoints
+}
+
 func (d *DataplaneResource) GetIP() string {
 	if d == nil {
 		return ""
@@ -341,6 +341,128 @@ var _ = Describe("Dataplane", func() {
 					Path: "/even-more-non-standard-path",
 				},
 			}),
+			Entry("dataplane.mesh == mesh && both define application endpoints", testCase{
+				dataplaneName: "backend-01",
+				dataplaneMesh: "demo",
+				dataplaneSpec: `
+                metrics:
+                  prometheus:
+                    endpoints:
+                    - name: app
+                      port: 9090
+                      path: /stats
+                    - name: jvm
+                      port: 9091
+`,
+				meshName: "demo",
+				meshSpec: `
+                metrics:
+                  prometheus:
+                    port: 1234
+                    path: /non-standard-path
+                    endpoints:
+                    - name: app
+                      port: 8080
+                    - name: sidecar
+                      port: 8081
+                      scheme: https
+`,
+				expected: &mesh_proto.Metrics_Prometheus{
+					Port: 1234,
+					Path: "/non-standard-path",
+					Endpoints: []*mesh_proto.Metrics_Prometheus_Endpoint{
+						{
+							Name:   "sidecar",
+							Port:   8081,
+							Scheme: "https",
+						},
+						{
+							Name: "app",
+							Port: 9090,
+							Path: "/stats",
+						},
+						{
+							Name: "jvm",
+							Port: 9091,
+						},
+					},
+				},
+			}),
+		)
+	})
+
+	Describe("GetStatsdSink()", func() {
+
+		type testCase struct {
+			dataplaneSpec string
+			meshSpec      string
+			expected      *mesh_proto.Metrics_Statsd
+		}
+
+		DescribeTable("should correctly determine effective StatsD config for given Dataplane and Mesh",
+			func(given testCase) {
+				// given
+				dataplane := &DataplaneResource{
+					Meta: &test_model.ResourceMeta{
+						Name: "backend-01",
+						Mesh: "demo",
+					},
+				}
+				Expect(util_proto.FromYAML([]byte(given.dataplaneSpec), &dataplane.Spec)).To(Succeed())
+				mesh := &MeshResource{
+					Meta: &test_model.ResourceMeta{
+						Name: "demo",
+					},
+				}
+				Expect(util_proto.FromYAML([]byte(given.meshSpec), &mesh.Spec)).To(Succeed())
+
+				// then
+				Expect(dataplane.GetStatsdSink(mesh)).To(Equal(given.expected))
+			},
+			Entry("mesh.metrics.statsd == nil", testCase{
+				dataplaneSpec: `
+                metrics:
+                  statsd:
+                    address: 127.0.0.1:8125
+`,
+				meshSpec: `
+                metrics:
+                  prometheus:
+                    port: 1234
+`,
+				expected: nil,
+			}),
+			Entry("dataplane.metrics.statsd == nil && mesh.metrics.statsd != nil", testCase{
+				meshSpec: `
+                metrics:
+                  statsd:
+                    address: 10.0.0.1:8125
+                    tagFormat: dogstatsd
+`,
+				expected: &mesh_proto.Metrics_Statsd{
+					Address:   "10.0.0.1:8125",
+					TagFormat: "dogstatsd",
+				},
+			}),
+			Entry("dataplane.metrics.statsd != nil && mesh.metrics.statsd != nil", testCase{
+				dataplaneSpec: `
+                metrics:
+                  statsd:
+                    address: 127.0.0.1:8125
+                    prefix: backend
+`,
+				meshSpec: `
+                metrics:
+                  statsd:
+                    address: 10.0.0.1:8125
+                    tagFormat: dogstatsd
+`,
+				expected: &mesh_proto.Metrics_Statsd{
+					Address:   "127.0.0.1:8125",
+					Prefix:    "backend",
+					TagFormat: "dogstatsd",
+				},
+			}),
 		)
 	})
 
@@ -1,6 +1,9 @@
 package mesh
 
 import (
+	"net"
+	"sort"
+
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core/validators"
 )
@@ -8,19 +11,20 @@ import (
 func (d *DataplaneResource) Validate() error {
 	var err validators.ValidationError
 	err.Add(validateNetworking(d.Spec.GetNetworking()))
+	err.AddError("metrics", validateMetrics(d.Spec.GetMetrics()))
 	return err.OrNil()
 }
 
 func validateNetworking(networking *mesh_proto.Dataplane_Networking) validators.ValidationError {
 	var err validators.ValidationError
 	path := validators.RootedAt("networking")
-	if len(networking.GetInbound()) == 0 && networking.Gateway == nil {
+	if len(networking.GetInbound()) == 0 && networking.GetGateway() == nil {
 		err.AddViolationAt(path, "has to contain at least one inbound interface or gateway")
 	}
-	if len(networking.GetInbound()) > 0 && networking.Gateway != nil {
+	if len(networking.GetInbound()) > 0 && networking.GetGateway() != nil {
 		err.AddViolationAt(path, "inbound cannot be defined both with gateway")
 	}
-	if networking.Gateway != nil {
+	if networking.GetGateway() != nil {
 		result := validateGateway(networking.Gateway)
 		err.AddErrorAt(path.Field("gateway"), result)
 	}
@@ -32,6 +36,10 @@ func validateNetworking(networking *mesh_proto.Dataplane_Networking) validators.
 		result := validateOutbound(outbound)
 		err.AddErrorAt(path.Field("outbound").Index(i), result)
 	}
+	if networking.GetTransparentProxying() != nil {
+		result := validateTransparentProxying(networking.GetTransparentProxying())
+		err.AddErrorAt(path.Field("transparentProxying"), result)
+	}
 	return err
 }
 
@@ -59,6 +67,20 @@ func validateOutbound(outbound *mesh_proto.Dataplane_Networking_Outbound) valida
 	if outbound.Service == "" {
 		result.AddViolation("service", "cannot be empty")
 	}
+	var names []string
+	for name := range outbound.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := outbound.Tags[name]
+		if name == mesh_proto.ServiceTag {
+			result.AddViolationAt(validators.RootedAt("tags").Key(name), `tag is not allowed, use "service" field instead`)
+		}
+		if value == "" {
+			result.AddViolationAt(validators.RootedAt("tags").Key(name), `tag value cannot be empty`)
+		}
+	}
 	return result
 }
 
@@ -74,3 +96,23 @@ func validateGateway(gateway *mesh_proto.Dataplane_Networking_Gateway) validator
 	}
 	return result
 }
+
+func validateTransparentProxying(transparentProxying *mesh_proto.Dataplane_Networking_TransparentProxying) validators.ValidationError {
+	var result validators.ValidationError
+	for i, port := range transparentProxying.ExcludedInboundPorts {
+		if port < 1 || 65535 < port {
+			result.AddViolationAt(validators.RootedAt("excludedInboundPorts").Index(i), "port must be in the range [1, 65535]")
+		}
+	}
+	for i, port := range transparentProxying.ExcludedOutboundPorts {
+		if port < 1 || 65535 < port {
+			result.AddViolationAt(validators.RootedAt("excludedOutboundPorts").Index(i), "port must be in the range [1, 65535]")
+		}
+	}
+	for i, cidr := range transparentProxying.ExcludedOutboundCidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			result.AddViolationAt(validators.RootedAt("excludedOutboundCidrs").Index(i), "must be a valid IP range in CIDR notation, e.g. 169.254.169.254/32")
+		}
+	}
+	return result
+}
@@ -56,6 +56,15 @@ var _ = Describe("Dataplane", func() {
 		Entry("dataplane with inbounds", func() core_mesh.DataplaneResource {
 			return validDataplane
 		}),
+		Entry("dataplane with transparent proxying exclusions", func() core_mesh.DataplaneResource {
+			validDataplane.Spec.Networking.TransparentProxying = &mesh_proto.Dataplane_Networking_TransparentProxying{
+				RedirectPort:          15001,
+				ExcludedInboundPorts:  []uint32{8081},
+				ExcludedOutboundPorts: []uint32{5432, 6379},
+				ExcludedOutboundCidrs: []string{"169.254.169.254/32"},
+			}
+			return validDataplane
+		}),
 		Entry("dataplane with gateway", func() core_mesh.DataplaneResource {
 			return core_mesh.DataplaneResource{
 				Meta: &model.ResourceMeta{
@@ -220,6 +229,56 @@ var _ = Describe("Dataplane", func() {
 				},
 			},
 		}),
+		Entry("transparent proxying: invalid exclusions", testCase{
+			dataplane: func() core_mesh.DataplaneResource {
+				validDataplane.Spec.Networking.TransparentProxying = &mesh_proto.Dataplane_Networking_TransparentProxying{
+					RedirectPort:          15001,
+					ExcludedInboundPorts:  []uint32{8081, 0},
+					ExcludedOutboundPorts: []uint32{65536},
+					ExcludedOutboundCidrs: []string{"169.254.169.254/32", "10.0.0.1"},
+				}
+				return validDataplane
+			},
+			validationResult: &validators.ValidationError{
+				Violations: []validators.Violation{
+					{
+						Field:   `networking.transparentProxying.excludedInboundPorts[1]`,
+						Message: `port must be in the range [1, 65535]`,
+					},
+					{
+						Field:   `networking.transparentProxying.excludedOutboundPorts[0]`,
+						Message: `port must be in the range [1, 65535]`,
+					},
+					{
+						Field:   `networking.transparentProxying.excludedOutboundCidrs[1]`,
+						Message: `must be a valid IP range in CIDR notation, e.g. 169.254.169.254/32`,
+					},
+				},
+			},
+		}),
+		Entry("metrics: invalid application endpoint", testCase{
+			dataplane: func() core_mesh.DataplaneResource {
+				validDataplane.Spec.Metrics = &mesh_proto.Metrics{
+					Prometheus: &mesh_proto.Metrics_Prometheus{
+						Endpoints: []*mesh_proto.Metrics_Prometheus_Endpoint{
+							{
+								Name: "app",
+								Port: 65536,
+							},
+						},
+					},
+				}
+				return validDataplane
+			},
+			validationResult: &validators.ValidationError{
+				Violations: []validators.Violation{
+					{
+						Field:   `metrics.prometheus.endpoints[0].port`,
+						Message: `port must be in the range [1, 65535]`,
+					},
+				},
+			},
+		}),
 		Entry("outbound: empty service tag", testCase{
 			dataplane: func() core_mesh.DataplaneResource {
 				validDataplane.Spec.Networking.Outbound[0].Service = ""
@@ -234,6 +293,27 @@ var _ = Describe("Dataplane", func() {
 				},
 			},
 		}),
+		Entry("outbound: invalid tags", testCase{
+			dataplane: func() core_mesh.DataplaneResource {
+				validDataplane.Spec.Networking.Outbound[0].Tags = map[string]string{
+					"service":          "redis",
+					"kuma.io/instance": "",
+				}
+				return validDataplane
+			},
+			validationResult: &validators.ValidationError{
+				Violations: []validators.Violation{
+					{
+						Field:   `networking.outbound[0].tags["kuma.io/instance"]`,
+						Message: `tag value cannot be empty`,
+					},
+					{
+						Field:   `networking.outbound[0].tags["service"]`,
+						Message: `tag is not allowed, use "service" field instead`,
+					},
+				},
+			},
+		}),
 		Entry("multiple errors", testCase{
 			dataplane: func() core_mesh.DataplaneResource {
 				validDataplane.Spec = mesh_proto.Dataplane{
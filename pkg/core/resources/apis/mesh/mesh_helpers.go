@@ -25,3 +25,7 @@ func (m *MeshResource) HasProvidedCA() bool {
 func (m *MeshResource) HasPrometheusMetricsEnabled() bool {
 	return m != nil && m.Spec.GetMetrics().GetPrometheus() != nil
 }
+
+func (m *MeshResource) HasStatsdMetricsEnabled() bool {
+	return m != nil && m.Spec.GetMetrics().GetStatsd() != nil
+}
@@ -4,13 +4,19 @@ import (
 	"fmt"
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core/validators"
+	"github.com/golang/protobuf/ptypes"
 	"net"
+	"net/url"
 )
 
 func (m *MeshResource) Validate() error {
 	var verr validators.ValidationError
 	verr.AddError("mtls", validateMtls(m.Spec.Mtls))
 	verr.AddError("logging", validateLogging(m.Spec.Logging))
+	verr.AddError("metrics", validateMetrics(m.Spec.Metrics))
+	if m.Spec.GetMetrics().GetStatsd() != nil && m.Spec.GetMetrics().GetStatsd().Address == "" {
+		verr.AddViolation("metrics.statsd.address", "cannot be empty")
+	}
 	return verr.OrNil()
 }
 
@@ -49,10 +55,61 @@ func validateBackend(backend *mesh_proto.LoggingBackend) validators.ValidationEr
 	if backend.Name == "" {
 		verr.AddViolation("name", "cannot be empty")
 	}
+	if backend.Format != "" && len(backend.JsonFormat) > 0 {
+		verr.AddViolation("jsonFormat", "cannot be used together with format")
+	}
+	for key := range backend.JsonFormat {
+		if key == "" {
+			verr.AddViolation("jsonFormat", "keys cannot be empty")
+		}
+	}
 	if file, ok := backend.GetType().(*mesh_proto.LoggingBackend_File_); ok {
 		verr.AddError("file", validateLoggingFile(file))
 	} else if tcp, ok := backend.GetType().(*mesh_proto.LoggingBackend_Tcp_); ok {
 		verr.AddError("tcp", validateLoggingTcp(tcp))
+	} else if syslog, ok := backend.GetType().(*mesh_proto.LoggingBackend_Syslog_); ok {
+		verr.AddError("syslog", validateLoggingSyslog(syslog))
+	} else if http, ok := backend.GetType().(*mesh_proto.LoggingBackend_Http_); ok {
+		verr.AddError("http", validateLoggingHttp(http))
+	}
+	return verr
+}
+
+func validateLoggingSyslog(syslog *mesh_proto.LoggingBackend_Syslog_) validators.ValidationError {
+	var verr validators.ValidationError
+	if syslog.Syslog.Address == "" {
+		verr.AddViolation("address", "cannot be empty")
+	} else {
+		host, port, err := net.SplitHostPort(syslog.Syslog.Address)
+		if host == "" || port == "" || err != nil {
+			verr.AddViolation("address", "has to be in format of HOST:PORT")
+		}
+	}
+	switch syslog.Syslog.GetProtocolOrDefault() {
+	case mesh_proto.SyslogProtocolUdp, mesh_proto.SyslogProtocolTcp:
+	default:
+		verr.AddViolation("protocol", fmt.Sprintf("has to be either %q or %q", mesh_proto.SyslogProtocolUdp, mesh_proto.SyslogProtocolTcp))
+	}
+	if _, ok := syslog.Syslog.GetFacilityCode(); !ok {
+		verr.AddViolation("facility", "has to be a valid syslog facility, e.g. \"local0\"")
+	}
+	return verr
+}
+
+func validateLoggingHttp(http *mesh_proto.LoggingBackend_Http_) validators.ValidationError {
+	var verr validators.ValidationError
+	if http.Http.Url == "" {
+		verr.AddViolation("url", "cannot be empty")
+	} else {
+		u, err := url.Parse(http.Http.Url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			verr.AddViolation("url", "has to be a valid http or https URL")
+		}
+	}
+	if http.Http.FlushInterval != nil {
+		if interval, err := ptypes.Duration(http.Http.FlushInterval); err != nil || interval <= 0 {
+			verr.AddViolation("flushInterval", "has to be a positive duration")
+		}
 	}
 	return verr
 }
@@ -75,5 +132,15 @@ func validateLoggingFile(file *mesh_proto.LoggingBackend_File_) validators.Valid
 	if file.File.Path == "" {
 		veer.AddViolation("path", "cannot be empty")
 	}
+	if rotation := file.File.Rotation; rotation != nil {
+		if rotation.MaxSize == 0 && rotation.MaxAge == nil {
+			veer.AddViolation("rotation", "either maxSize or maxAge has to be set")
+		}
+		if rotation.MaxAge != nil {
+			if maxAge, err := ptypes.Duration(rotation.MaxAge); err != nil || maxAge <= 0 {
+				veer.AddViolation("rotation.maxAge", "has to be a positive duration")
+			}
+		}
+	}
 	return veer
 }
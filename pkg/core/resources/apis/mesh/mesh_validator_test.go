@@ -28,6 +28,30 @@ var _ = Describe("Mesh", func() {
               - name: tcp-1
                 tcp:
                   address: kibana:1234
+              - name: json-1
+                jsonFormat:
+                  method: '%REQ(:METHOD)%'
+                  status: '%RESPONSE_CODE%'
+                file:
+                  path: /path/to/file.json
+              - name: rotated-file-1
+                file:
+                  path: /path/to/rotated.log
+                  rotation:
+                    maxSize: 10485760
+                    maxAge: 24h
+                    maxBackups: 5
+              - name: syslog-1
+                syslog:
+                  address: syslog:514
+                  protocol: tcp
+                  facility: local3
+              - name: http-1
+                http:
+                  url: https://collector:8080/logs
+                  batchSize: 50
+                  flushInterval: 1s
+                  maxRetries: 5
               defaultBackend: tcp-1
 `
 			mesh := MeshResource{}
@@ -139,6 +163,111 @@ var _ = Describe("Mesh", func() {
                 violations:
                 - field: logging.backends[0].file.path
                   message: cannot be empty`,
+			}),
+			Entry("file rotation without limits", testCase{
+				mesh: `
+                logging:
+                  backends:
+                  - name: backend-1
+                    file:
+                      path: /path/to/file
+                      rotation:
+                        maxBackups: 3
+                  defaultBackend: backend-1`,
+				expected: `
+                violations:
+                - field: logging.backends[0].file.rotation
+                  message: either maxSize or maxAge has to be set`,
+			}),
+			Entry("file rotation with negative max age", testCase{
+				mesh: `
+                logging:
+                  backends:
+                  - name: backend-1
+                    file:
+                      path: /path/to/file
+                      rotation:
+                        maxAge: -1s
+                  defaultBackend: backend-1`,
+				expected: `
+                violations:
+                - field: logging.backends[0].file.rotation.maxAge
+                  message: has to be a positive duration`,
+			}),
+			Entry("syslog logging with invalid fields", testCase{
+				mesh: `
+                logging:
+                  backends:
+                  - name: backend-1
+                    syslog:
+                      address: syslog
+                      protocol: http
+                      facility: local8
+                  defaultBackend: backend-1`,
+				expected: `
+                violations:
+                - field: logging.backends[0].syslog.address
+                  message: has to be in format of HOST:PORT
+                - field: logging.backends[0].syslog.protocol
+                  message: has to be either "udp" or "tcp"
+                - field: logging.backends[0].syslog.facility
+                  message: has to be a valid syslog facility, e.g. "local0"`,
+			}),
+			Entry("syslog logging address is empty", testCase{
+				mesh: `
+                logging:
+                  backends:
+                  - name: backend-1
+                    syslog: {}
+                  defaultBackend: backend-1`,
+				expected: `
+                violations:
+                - field: logging.backends[0].syslog.address
+                  message: cannot be empty`,
+			}),
+			Entry("http logging with invalid fields", testCase{
+				mesh: `
+                logging:
+                  backends:
+                  - name: backend-1
+                    http:
+                      url: tcp://collector:8080
+                      flushInterval: 0s
+                  defaultBackend: backend-1`,
+				expected: `
+                violations:
+                - field: logging.backends[0].http.url
+                  message: has to be a valid http or https URL
+                - field: logging.backends[0].http.flushInterval
+                  message: has to be a positive duration`,
+			}),
+			Entry("http logging url is empty", testCase{
+				mesh: `
+                logging:
+                  backends:
+                  - name: backend-1
+                    http: {}
+                  defaultBackend: backend-1`,
+				expected: `
+                violations:
+                - field: logging.backends[0].http.url
+                  message: cannot be empty`,
+			}),
+			Entry("both format and json format are set", testCase{
+				mesh: `
+                logging:
+                  backends:
+                  - name: backend-1
+                    format: '%START_TIME%'
+                    jsonFormat:
+                      start: '%START_TIME%'
+                    file:
+                      path: /path/to/file
+                  defaultBackend: backend-1`,
+				expected: `
+                violations:
+                - field: logging.backends[0].jsonFormat
+                  message: cannot be used together with format`,
 			}),
 			Entry("default backend has to be set to one of the backends", testCase{
 				mesh: `
@@ -152,6 +281,65 @@ var _ = Describe("Mesh", func() {
                 violations:
                 - field: logging.defaultBackend
                   message: has to be set to one of the logging backend in mesh`,
+			}),
+			Entry("invalid application endpoints of Prometheus", testCase{
+				mesh: `
+                metrics:
+                  prometheus:
+                    port: 5670
+                    path: /metrics
+                    endpoints:
+                    - name: app
+                      port: 0
+                      path: metrics
+                      scheme: tcp
+                      labels:
+                        __scheme__: https
+                        invalid-label: value
+                    - name: app
+                      port: 8080
+                    - port: 8081`,
+				expected: `
+                violations:
+                - field: metrics.prometheus.endpoints[0].port
+                  message: port must be in the range [1, 65535]
+                - field: metrics.prometheus.endpoints[0].path
+                  message: has to start with "/"
+                - field: metrics.prometheus.endpoints[0].scheme
+                  message: has to be either "http" or "https"
+                - field: metrics.prometheus.endpoints[0].labels["__scheme__"]
+                  message: cannot start with "__" since such labels are reserved for internal use
+                - field: metrics.prometheus.endpoints[0].labels["invalid-label"]
+                  message: has to be a valid Prometheus label name
+                - field: metrics.prometheus.endpoints[1].name
+                  message: '"app" name is already used for another endpoint'
+                - field: metrics.prometheus.endpoints[2].name
+                  message: cannot be empty`,
+			}),
+			Entry("invalid statsd backend", testCase{
+				mesh: `
+                metrics:
+                  statsd:
+                    prefix: 'kuma: "envoy"'
+                    tagFormat: influxdb`,
+				expected: `
+                violations:
+                - field: metrics.statsd.prefix
+                  message: has to consist of alphanumeric characters, '.', '_' or '-'
+                - field: metrics.statsd.tagFormat
+                  message: has to be either "statsd" or "dogstatsd"
+                - field: metrics.statsd.address
+                  message: cannot be empty`,
+			}),
+			Entry("statsd address is not an IP address", testCase{
+				mesh: `
+                metrics:
+                  statsd:
+                    address: statsd.local:8125`,
+				expected: `
+                violations:
+                - field: metrics.statsd.address
+                  message: has to be a valid IP address and port, e.g. 127.0.0.1:8125`,
 			}),
 			Entry("multiple errors", testCase{
 				mesh: `
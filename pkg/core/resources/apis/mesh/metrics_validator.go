@@ -0,0 +1,83 @@
+package mesh
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	prom_model "github.com/prometheus/common/model"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/validators"
+)
+
+var statsdPrefixRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]*$`)
+
+func validateMetrics(metrics *mesh_proto.Metrics) validators.ValidationError {
+	var verr validators.ValidationError
+	if metrics.GetStatsd() != nil {
+		verr.AddErrorAt(validators.RootedAt("statsd"), validateStatsd(metrics.GetStatsd()))
+	}
+	if metrics.GetPrometheus() == nil {
+		return verr
+	}
+	usedNames := map[string]bool{}
+	for i, endpoint := range metrics.GetPrometheus().GetEndpoints() {
+		path := validators.RootedAt("prometheus").Field("endpoints").Index(i)
+		verr.AddErrorAt(path, validatePrometheusEndpoint(endpoint))
+		if endpoint.Name != "" && usedNames[endpoint.Name] {
+			verr.AddViolationAt(path.Field("name"), fmt.Sprintf("%q name is already used for another endpoint", endpoint.Name))
+		}
+		usedNames[endpoint.Name] = true
+	}
+	return verr
+}
+
+func validatePrometheusEndpoint(endpoint *mesh_proto.Metrics_Prometheus_Endpoint) validators.ValidationError {
+	var verr validators.ValidationError
+	if endpoint.Name == "" {
+		verr.AddViolation("name", "cannot be empty")
+	}
+	if endpoint.Port == 0 || endpoint.Port > 65535 {
+		verr.AddViolation("port", "port must be in the range [1, 65535]")
+	}
+	if endpoint.Path != "" && !strings.HasPrefix(endpoint.Path, "/") {
+		verr.AddViolation("path", `has to start with "/"`)
+	}
+	if endpoint.Scheme != "" && endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		verr.AddViolation("scheme", `has to be either "http" or "https"`)
+	}
+	var keys []string
+	for key := range endpoint.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !prom_model.LabelName(key).IsValid() {
+			verr.AddViolationAt(validators.RootedAt("labels").Key(key), "has to be a valid Prometheus label name")
+		} else if strings.HasPrefix(key, prom_model.ReservedLabelPrefix) {
+			verr.AddViolationAt(validators.RootedAt("labels").Key(key), fmt.Sprintf("cannot start with %q since such labels are reserved for internal use", prom_model.ReservedLabelPrefix))
+		}
+	}
+	return verr
+}
+
+func validateStatsd(statsd *mesh_proto.Metrics_Statsd) validators.ValidationError {
+	var verr validators.ValidationError
+	// address is optional on a Dataplane, which only overrides StatsD settings of a Mesh
+	if statsd.Address != "" {
+		host, port, err := net.SplitHostPort(statsd.Address)
+		if err != nil || net.ParseIP(host) == nil || port == "" {
+			verr.AddViolation("address", "has to be a valid IP address and port, e.g. 127.0.0.1:8125")
+		}
+	}
+	if !statsdPrefixRegexp.MatchString(statsd.Prefix) {
+		verr.AddViolation("prefix", "has to consist of alphanumeric characters, '.', '_' or '-'")
+	}
+	if statsd.TagFormat != "" && statsd.TagFormat != mesh_proto.StatsdTagFormatStatsd && statsd.TagFormat != mesh_proto.StatsdTagFormatDogStatsd {
+		verr.AddViolation("tagFormat", fmt.Sprintf("has to be either %q or %q", mesh_proto.StatsdTagFormatStatsd, mesh_proto.StatsdTagFormatDogStatsd))
+	}
+	return verr
+}
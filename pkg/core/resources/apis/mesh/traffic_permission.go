@@ -72,3 +72,11 @@ func init() {
 	registry.RegisterType(&TrafficPermissionResource{})
 	registry.RegistryListType(&TrafficPermissionResourceList{})
 }
+
+func (t *TrafficPermissionResource) Sources() []*mesh_proto.Selector {
+	return t.Spec.GetSources()
+}
+
+func (t *TrafficPermissionResource) Destinations() []*mesh_proto.Selector {
+	return t.Spec.GetDestinations()
+}
@@ -14,6 +14,8 @@ func NewCustomizableResourceManager(defaultManager ResourceManager, customManage
 	}
 }
 
+var _ TransactionalResourceManager = &customizableResourceManager{}
+
 type customizableResourceManager struct {
 	defaultManager ResourceManager
 	customManagers map[model.ResourceType]ResourceManager
@@ -43,6 +45,15 @@ func (m *customizableResourceManager) Update(ctx context.Context, resource model
 	return m.resourceManager(resource.GetType()).Update(ctx, resource, fs...)
 }
 
+// Transactional delegates to the default manager, custom managers are expected to share its store.
+func (m *customizableResourceManager) Transactional(ctx context.Context, fn func(context.Context) error) error {
+	transactional, ok := m.defaultManager.(TransactionalResourceManager)
+	if !ok {
+		return store.ErrorTransactionsNotSupported()
+	}
+	return transactional.Transactional(ctx, fn)
+}
+
 func (m *customizableResourceManager) resourceManager(typ model.ResourceType) ResourceManager {
 	if customManager, ok := m.customManagers[typ]; ok {
 		return customManager
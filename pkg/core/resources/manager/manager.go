@@ -17,13 +17,21 @@ type ResourceManager interface {
 	List(context.Context, model.ResourceList, ...store.ListOptionsFunc) error
 }
 
+// TransactionalResourceManager is implemented by managers that can apply a group of changes atomically.
+type TransactionalResourceManager interface {
+	ResourceManager
+	// Transactional executes fn in a transaction of the underlying store.
+	// Changes made with a context passed to fn are either all persisted or, if fn returns an error, none of them.
+	Transactional(context.Context, func(context.Context) error) error
+}
+
 func NewResourceManager(store store.ResourceStore) ResourceManager {
 	return &resourcesManager{
 		Store: store,
 	}
 }
 
-var _ ResourceManager = &resourcesManager{}
+var _ TransactionalResourceManager = &resourcesManager{}
 
 type resourcesManager struct {
 	Store store.ResourceStore
@@ -47,6 +55,9 @@ func (r *resourcesManager) Create(ctx context.Context, resource model.Resource,
 			return err
 		}
 	}
+	if opts.DryRun {
+		return nil
+	}
 	return r.Store.Create(ctx, resource, fs...)
 }
 
@@ -86,9 +97,20 @@ func (r *resourcesManager) Update(ctx context.Context, resource model.Resource,
 	if err := resource.Validate(); err != nil {
 		return err
 	}
+	if store.NewUpdateOptions(fs...).DryRun {
+		return nil
+	}
 	return r.Store.Update(ctx, resource, fs...)
 }
 
+func (r *resourcesManager) Transactional(ctx context.Context, fn func(context.Context) error) error {
+	transactional, ok := r.Store.(store.TransactionalResourceStore)
+	if !ok {
+		return store.ErrorTransactionsNotSupported()
+	}
+	return transactional.Transactional(ctx, fn)
+}
+
 type MeshNotFoundError struct {
 	Mesh string
 }
@@ -63,6 +63,76 @@ var _ = Describe("Resource Manager", func() {
 			// then
 			Expect(err.Error()).To(Equal("mesh of name mesh-1 is not found"))
 		})
+
+		It("should validate but not persist a resource on a dry run", func() {
+			// given
+			Expect(createSampleMesh("mesh-1")).To(Succeed())
+
+			// when
+			trRes := sample.TrafficRouteResource{
+				Spec: v1alpha1.TrafficRoute{
+					Path: "/some",
+				},
+			}
+			err := resManager.Create(context.Background(), &trRes, store.CreateByKey("tr-1", "mesh-1"), store.CreateWithDryRun(true))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			err = resStore.Get(context.Background(), &sample.TrafficRouteResource{}, store.GetByKey("tr-1", "mesh-1"))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should check that mesh exists on a dry run", func() {
+			// when
+			trRes := sample.TrafficRouteResource{
+				Spec: v1alpha1.TrafficRoute{
+					Path: "/some",
+				},
+			}
+			err := resManager.Create(context.Background(), &trRes, store.CreateByKey("tr-1", "mesh-1"), store.CreateWithDryRun(true))
+
+			// then
+			Expect(err).To(MatchError("mesh of name mesh-1 is not found"))
+		})
+	})
+
+	Describe("Transactional()", func() {
+		It("should create a mesh and a resource that depends on it", func() {
+			// when
+			err := resManager.(manager.TransactionalResourceManager).Transactional(context.Background(), func(ctx context.Context) error {
+				meshRes := mesh.MeshResource{}
+				if err := resManager.Create(ctx, &meshRes, store.CreateByKey("mesh-1", "mesh-1")); err != nil {
+					return err
+				}
+				trRes := sample.TrafficRouteResource{
+					Spec: v1alpha1.TrafficRoute{
+						Path: "/some",
+					},
+				}
+				return resManager.Create(ctx, &trRes, store.CreateByKey("tr-1", "mesh-1"))
+			})
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			err = resStore.Get(context.Background(), &sample.TrafficRouteResource{}, store.GetByKey("tr-1", "mesh-1"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not persist a mesh when a resource that depends on it fails", func() {
+			// when
+			err := resManager.(manager.TransactionalResourceManager).Transactional(context.Background(), func(ctx context.Context) error {
+				meshRes := mesh.MeshResource{}
+				if err := resManager.Create(ctx, &meshRes, store.CreateByKey("mesh-1", "mesh-1")); err != nil {
+					return err
+				}
+				return resManager.Create(ctx, &sample.TrafficRouteResource{}, store.CreateByKey("tr-1", "mesh-1"))
+			})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			err = resStore.Get(context.Background(), &mesh.MeshResource{}, store.GetByKey("mesh-1", "mesh-1"))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
 	})
 
 	Describe("DeleteAll()", func() {
@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+var (
+	storeOperations = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "kuma_cp_store_operation_seconds",
+		Help: "Latency of operations on resources",
+	}, []string{"operation", "resource_type"})
+	storeOperationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuma_cp_store_operation_errors_total",
+		Help: "Number of failed operations on resources",
+	}, []string{"operation", "resource_type"})
+)
+
+// NewMeteredResourceManager returns a manager that exports latency and errors of operations of a given manager.
+func NewMeteredResourceManager(delegate ResourceManager) ResourceManager {
+	return &meteredResourceManager{
+		delegate: delegate,
+	}
+}
+
+var _ TransactionalResourceManager = &meteredResourceManager{}
+
+type meteredResourceManager struct {
+	delegate ResourceManager
+}
+
+func (m *meteredResourceManager) Get(ctx context.Context, resource model.Resource, fs ...store.GetOptionsFunc) error {
+	return m.measure("get", resource.GetType(), func() error {
+		return m.delegate.Get(ctx, resource, fs...)
+	})
+}
+
+func (m *meteredResourceManager) List(ctx context.Context, list model.ResourceList, fs ...store.ListOptionsFunc) error {
+	return m.measure("list", list.GetItemType(), func() error {
+		return m.delegate.List(ctx, list, fs...)
+	})
+}
+
+func (m *meteredResourceManager) Create(ctx context.Context, resource model.Resource, fs ...store.CreateOptionsFunc) error {
+	return m.measure("create", resource.GetType(), func() error {
+		return m.delegate.Create(ctx, resource, fs...)
+	})
+}
+
+func (m *meteredResourceManager) Delete(ctx context.Context, resource model.Resource, fs ...store.DeleteOptionsFunc) error {
+	return m.measure("delete", resource.GetType(), func() error {
+		return m.delegate.Delete(ctx, resource, fs...)
+	})
+}
+
+func (m *meteredResourceManager) DeleteAll(ctx context.Context, list model.ResourceList, fs ...store.DeleteAllOptionsFunc) error {
+	return m.measure("delete_all", list.GetItemType(), func() error {
+		return m.delegate.DeleteAll(ctx, list, fs...)
+	})
+}
+
+func (m *meteredResourceManager) Update(ctx context.Context, resource model.Resource, fs ...store.UpdateOptionsFunc) error {
+	return m.measure("update", resource.GetType(), func() error {
+		return m.delegate.Update(ctx, resource, fs...)
+	})
+}
+
+func (m *meteredResourceManager) Transactional(ctx context.Context, fn func(context.Context) error) error {
+	transactional, ok := m.delegate.(TransactionalResourceManager)
+	if !ok {
+		return store.ErrorTransactionsNotSupported()
+	}
+	return transactional.Transactional(ctx, fn)
+}
+
+func (m *meteredResourceManager) measure(operation string, typ model.ResourceType, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	storeOperations.WithLabelValues(operation, string(typ)).Observe(time.Since(start).Seconds())
+	// resources that are not found are not failures of the store
+	if err != nil && !store.IsResourceNotFound(err) {
+		storeOperationErrors.WithLabelValues(operation, string(typ)).Inc()
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package manager_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metered Resource Manager", func() {
+
+	It("should export failures of operations", func() {
+		// given
+		resManager := manager.NewMeteredResourceManager(manager.NewResourceManager(memory.NewStore()))
+		err := resManager.Create(context.Background(), &mesh.MeshResource{}, store.CreateByKey("mesh-1", "mesh-1"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = resManager.Create(context.Background(), &mesh.MeshResource{}, store.CreateByKey("mesh-1", "mesh-1"))
+		// then
+		Expect(err).To(HaveOccurred())
+
+		// when
+		err = resManager.Get(context.Background(), &mesh.MeshResource{}, store.GetByKey("mesh-2", "mesh-2"))
+		// then
+		Expect(store.IsResourceNotFound(err)).To(BeTrue())
+
+		// and
+		expected := `
+# HELP kuma_cp_store_operation_errors_total Number of failed operations on resources
+# TYPE kuma_cp_store_operation_errors_total counter
+kuma_cp_store_operation_errors_total{operation="create",resource_type="Mesh"} 1
+`
+		err = testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected), "kuma_cp_store_operation_errors_total")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should support transactions of the underlying manager", func() {
+		// given
+		resManager := manager.NewMeteredResourceManager(manager.NewResourceManager(memory.NewStore()))
+
+		// when
+		_, ok := resManager.(manager.TransactionalResourceManager)
+
+		// then
+		Expect(ok).To(BeTrue())
+	})
+})
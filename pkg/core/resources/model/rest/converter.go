@@ -16,9 +16,10 @@ func (c *from) Resource(r model.Resource) *Resource {
 	}
 	return &Resource{
 		Meta: ResourceMeta{
-			Mesh: meshName,
-			Type: string(r.GetType()),
-			Name: r.GetMeta().GetName(),
+			Mesh:    meshName,
+			Type:    string(r.GetType()),
+			Name:    r.GetMeta().GetName(),
+			Version: r.GetMeta().GetVersion(),
 		},
 		Spec: r.GetSpec(),
 	}
@@ -11,9 +11,10 @@ import (
 )
 
 type ResourceMeta struct {
-	Type string `json:"type"`
-	Name string `json:"name"`
-	Mesh string `json:"mesh,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Mesh    string `json:"mesh,omitempty"`
+	Version string `json:"version,omitempty"`
 }
 
 type Resource struct {
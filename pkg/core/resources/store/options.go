@@ -5,8 +5,9 @@ import (
 )
 
 type CreateOptions struct {
-	Name string
-	Mesh string
+	Name   string
+	Mesh   string
+	DryRun bool
 }
 
 type CreateOptionsFunc func(*CreateOptions)
@@ -30,7 +31,15 @@ func CreateByKey(name, mesh string) CreateOptionsFunc {
 	}
 }
 
+// CreateWithDryRun makes Create go through validation without persisting a resource.
+func CreateWithDryRun(dryRun bool) CreateOptionsFunc {
+	return func(opts *CreateOptions) {
+		opts.DryRun = dryRun
+	}
+}
+
 type UpdateOptions struct {
+	DryRun bool
 }
 
 type UpdateOptionsFunc func(*UpdateOptions)
@@ -43,9 +52,17 @@ func NewUpdateOptions(fs ...UpdateOptionsFunc) *UpdateOptions {
 	return opts
 }
 
+// UpdateWithDryRun makes Update go through validation without persisting a resource.
+func UpdateWithDryRun(dryRun bool) UpdateOptionsFunc {
+	return func(opts *UpdateOptions) {
+		opts.DryRun = dryRun
+	}
+}
+
 type DeleteOptions struct {
-	Name string
-	Mesh string
+	Name    string
+	Mesh    string
+	Version string
 }
 
 type DeleteOptionsFunc func(*DeleteOptions)
@@ -69,6 +86,13 @@ func DeleteByKey(name, mesh string) DeleteOptionsFunc {
 	}
 }
 
+// DeleteByVersion makes deletion conditional on a current version of a resource.
+func DeleteByVersion(version string) DeleteOptionsFunc {
+	return func(opts *DeleteOptions) {
+		opts.Version = version
+	}
+}
+
 type DeleteAllOptions struct {
 	Mesh string
 }
@@ -22,6 +22,14 @@ type ClosableResourceStore interface {
 	io.Closer
 }
 
+// TransactionalResourceStore is implemented by stores that can apply a group of changes atomically.
+type TransactionalResourceStore interface {
+	ResourceStore
+	// Transactional executes fn in a transaction.
+	// Changes made with a context passed to fn are either all persisted or, if fn returns an error, none of them.
+	Transactional(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 func NewStrictResourceStore(c ResourceStore) ClosableResourceStore {
 	return &strictResourceStore{delegate: c}
 }
@@ -102,6 +110,17 @@ func (s *strictResourceStore) List(ctx context.Context, rs model.ResourceList, f
 	return s.delegate.List(ctx, rs, fs...)
 }
 
+func (s *strictResourceStore) Transactional(ctx context.Context, fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return fmt.Errorf("ResourceStore.Transactional() requires a non-nil function")
+	}
+	transactional, ok := s.delegate.(TransactionalResourceStore)
+	if !ok {
+		return ErrorTransactionsNotSupported()
+	}
+	return transactional.Transactional(ctx, fn)
+}
+
 func (s *strictResourceStore) Close() error {
 	closable, ok := s.delegate.(io.Closer)
 	if ok {
@@ -126,10 +145,22 @@ func ErrorResourcePreconditionFailed(rt model.ResourceType, name, mesh string) e
 	return fmt.Errorf("Resource precondition failed: type=%q name=%q mesh=%q", rt, name, mesh)
 }
 
+func ErrorTransactionsNotSupported() error {
+	return fmt.Errorf("Transactions are not supported by the resource store")
+}
+
 func IsResourceNotFound(err error) bool {
 	return err != nil && strings.HasPrefix(err.Error(), "Resource not found")
 }
 
+func IsResourceAlreadyExists(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "Resource already exists")
+}
+
+func IsResourceConflict(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "Resource conflict")
+}
+
 func IsResourcePreconditionFailed(err error) bool {
 	return err != nil && strings.HasPrefix(err.Error(), "Resource precondition failed")
 }
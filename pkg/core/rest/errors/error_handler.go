@@ -14,6 +14,8 @@ func HandleError(response *restful.Response, err error, title string) {
 	switch {
 	case store.IsResourceNotFound(err):
 		handleNotFound(title, response)
+	case store.IsResourceConflict(err):
+		handleConflict(title, response)
 	case store.IsResourcePreconditionFailed(err):
 		handlePreconditionFailed(title, response)
 	case manager.IsMeshNotFound(err):
@@ -33,6 +35,14 @@ func handleNotFound(title string, response *restful.Response) {
 	writeError(response, 404, kumaErr)
 }
 
+func handleConflict(title string, response *restful.Response) {
+	kumaErr := types.Error{
+		Title:   title,
+		Details: "Conflict",
+	}
+	writeError(response, 409, kumaErr)
+}
+
 func handlePreconditionFailed(title string, response *restful.Response) {
 	kumaErr := types.Error{
 		Title:   title,
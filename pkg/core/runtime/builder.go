@@ -5,11 +5,13 @@ import (
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	"github.com/Kong/kuma/pkg/core"
+	core_admin "github.com/Kong/kuma/pkg/core/admin"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
 	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
 	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	"github.com/pkg/errors"
 )
@@ -35,6 +37,8 @@ type Builder struct {
 	bcm builtin_ca.BuiltinCaManager
 	pcm provided_ca.ProvidedCaManager
 	xds core_xds.XdsContext
+	sa  core_stats.Aggregator
+	eac core_admin.EnvoyAdminChannels
 	ext context.Context
 }
 
@@ -77,6 +81,16 @@ func (b *Builder) WithXdsContext(xds core_xds.XdsContext) *Builder {
 	return b
 }
 
+func (b *Builder) WithStatsAggregator(sa core_stats.Aggregator) *Builder {
+	b.sa = sa
+	return b
+}
+
+func (b *Builder) WithEnvoyAdminChannels(eac core_admin.EnvoyAdminChannels) *Builder {
+	b.eac = eac
+	return b
+}
+
 func (b *Builder) WithExtensions(ext context.Context) *Builder {
 	b.ext = ext
 	return b
@@ -104,6 +118,12 @@ func (b *Builder) Build() (Runtime, error) {
 	if b.xds == nil {
 		return nil, errors.Errorf("xDS Context has not been configured")
 	}
+	if b.sa == nil {
+		return nil, errors.Errorf("Stats Aggregator has not been configured")
+	}
+	if b.eac == nil {
+		return nil, errors.Errorf("Envoy Admin Channels have not been configured")
+	}
 	if b.ext == nil {
 		return nil, errors.Errorf("Extensions have been misconfigured")
 	}
@@ -118,6 +138,8 @@ func (b *Builder) Build() (Runtime, error) {
 			bcm: b.bcm,
 			pcm: b.pcm,
 			xds: b.xds,
+			sa:  b.sa,
+			eac: b.eac,
 			ext: b.ext,
 		},
 		ComponentManager: b.cm,
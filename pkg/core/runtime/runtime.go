@@ -4,10 +4,12 @@ import (
 	"context"
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	core_admin "github.com/Kong/kuma/pkg/core/admin"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
 	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 )
 
@@ -29,6 +31,8 @@ type RuntimeContext interface {
 	SecretManager() secret_manager.SecretManager
 	BuiltinCaManager() builtin_ca.BuiltinCaManager
 	ProvidedCaManager() provided_ca.ProvidedCaManager
+	StatsAggregator() core_stats.Aggregator
+	EnvoyAdminChannels() core_admin.EnvoyAdminChannels
 	Extensions() context.Context
 }
 
@@ -59,6 +63,8 @@ type runtimeContext struct {
 	bcm builtin_ca.BuiltinCaManager
 	pcm provided_ca.ProvidedCaManager
 	xds core_xds.XdsContext
+	sa  core_stats.Aggregator
+	eac core_admin.EnvoyAdminChannels
 	ext context.Context
 }
 
@@ -80,6 +86,12 @@ func (rc *runtimeContext) BuiltinCaManager() builtin_ca.BuiltinCaManager {
 func (rc *runtimeContext) ProvidedCaManager() provided_ca.ProvidedCaManager {
 	return rc.pcm
 }
+func (rc *runtimeContext) StatsAggregator() core_stats.Aggregator {
+	return rc.sa
+}
+func (rc *runtimeContext) EnvoyAdminChannels() core_admin.EnvoyAdminChannels {
+	return rc.eac
+}
 func (rc *runtimeContext) Extensions() context.Context {
 	return rc.ext
 }
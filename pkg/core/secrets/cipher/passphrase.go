@@ -0,0 +1,80 @@
+package cipher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	saltSize = 16
+	keySize  = 32
+)
+
+// NewPassphraseCipher returns a Cipher that encrypts data with AES-256-GCM
+// using a key derived from a given passphrase.
+// Every encrypted message carries its own random salt and nonce.
+func NewPassphraseCipher(passphrase string) Cipher {
+	return &passphraseCipher{
+		passphrase: []byte(passphrase),
+	}
+}
+
+var _ Cipher = &passphraseCipher{}
+
+type passphraseCipher struct {
+	passphrase []byte
+}
+
+func (p *passphraseCipher) Encrypt(data []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrap(err, "could not generate salt")
+	}
+	gcm, err := p.newGCM(salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+	out := append(salt, nonce...)
+	return gcm.Seal(out, nonce, data, nil), nil
+}
+
+func (p *passphraseCipher) Decrypt(data []byte) ([]byte, error) {
+	if len(data) < saltSize {
+		return nil, errors.New("encrypted data is too short")
+	}
+	salt, data := data[:saltSize], data[saltSize:]
+	gcm, err := p.newGCM(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, data := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, errors.New("could not decrypt data, the passphrase is most likely invalid")
+	}
+	return plain, nil
+}
+
+func (p *passphraseCipher) newGCM(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(p.passphrase, salt, 1<<15, 8, 1, keySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not derive a key from the passphrase")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,64 @@
+package cipher_test
+
+import (
+	"github.com/Kong/kuma/pkg/core/secrets/cipher"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PassphraseCipher", func() {
+
+	It("should decrypt what it encrypted", func() {
+		// given
+		c := cipher.NewPassphraseCipher("s3cret")
+
+		// when
+		encrypted, err := c.Encrypt([]byte("top secret"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(encrypted)).ToNot(ContainSubstring("top secret"))
+
+		// when
+		decrypted, err := c.Decrypt(encrypted)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(decrypted)).To(Equal("top secret"))
+	})
+
+	It("should use a random salt and nonce for every message", func() {
+		// given
+		c := cipher.NewPassphraseCipher("s3cret")
+
+		// when
+		first, err := c.Encrypt([]byte("top secret"))
+		Expect(err).ToNot(HaveOccurred())
+		second, err := c.Encrypt([]byte("top secret"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(first).ToNot(Equal(second))
+	})
+
+	It("should not decrypt with a different passphrase", func() {
+		// given
+		encrypted, err := cipher.NewPassphraseCipher("s3cret").Encrypt([]byte("top secret"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		_, err = cipher.NewPassphraseCipher("other").Decrypt(encrypted)
+
+		// then
+		Expect(err).To(MatchError("could not decrypt data, the passphrase is most likely invalid"))
+	})
+
+	It("should reject truncated data", func() {
+		// when
+		_, err := cipher.NewPassphraseCipher("s3cret").Decrypt([]byte("short"))
+
+		// then
+		Expect(err).To(MatchError("encrypted data is too short"))
+	})
+})
@@ -0,0 +1,176 @@
+package stats
+
+import (
+	"sync"
+	"time"
+
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+// DefaultStaleAfter is a period after which stats of a Dataplane are no longer taken into account
+// unless they are updated. Envoy flushes stats every 5s by default.
+const DefaultStaleAfter = 1 * time.Minute
+
+var now = time.Now
+
+// Latency holds quantiles of latency in milliseconds.
+type Latency struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+}
+
+// Sample holds stats of a service reported by a single Dataplane.
+// Requests and Errors are cumulative counters.
+type Sample struct {
+	Requests uint64
+	Errors   uint64
+	Latency  Latency
+}
+
+// ServiceStats holds stats of a service aggregated across all Dataplanes that report them.
+type ServiceStats struct {
+	Mesh       string `json:"mesh"`
+	Service    string `json:"service"`
+	Dataplanes int    `json:"dataplanes"`
+	// RequestRate is a number of requests per second.
+	RequestRate float64 `json:"requestRate"`
+	// ErrorRate is a fraction of requests that failed.
+	ErrorRate float64 `json:"errorRate"`
+	// Latency is an average of latency quantiles of Dataplanes weighted by a number of their requests.
+	Latency Latency `json:"latency"`
+}
+
+// Aggregator aggregates stats reported by Dataplanes per service.
+//
+// Stats are kept in memory of a Control Plane instance and Envoy streams them to the instance
+// it is connected to. With multiple instances of Control Plane, each of them aggregates stats
+// of its own Dataplanes only, i.e. stats of a service are partial.
+type Aggregator interface {
+	// Update records the latest stats of services reported by a Dataplane over a given stream.
+	Update(dataplane core_model.ResourceKey, streamID int64, samples map[string]Sample)
+	// Remove forgets stats of a Dataplane once a given stream is closed, unless the Dataplane
+	// has reported stats over another stream since then, e.g. after it has reconnected.
+	Remove(dataplane core_model.ResourceKey, streamID int64)
+	// ServiceStats returns aggregated stats of a service. The second return value is false
+	// if no Dataplane has reported stats of the service recently.
+	ServiceStats(mesh string, service string) (ServiceStats, bool)
+}
+
+func NewAggregator(staleAfter time.Duration) Aggregator {
+	return &aggregator{
+		staleAfter: staleAfter,
+		dataplanes: map[core_model.ResourceKey]*dataplaneStats{},
+	}
+}
+
+var _ Aggregator = &aggregator{}
+
+type aggregator struct {
+	sync.RWMutex
+	staleAfter time.Duration
+	dataplanes map[core_model.ResourceKey]*dataplaneStats
+}
+
+type snapshot struct {
+	time    time.Time
+	samples map[string]Sample
+}
+
+// dataplaneStats keeps 2 latest snapshots to compute rates.
+type dataplaneStats struct {
+	// streamID identifies a stream that has reported the latest snapshot
+	streamID int64
+	previous *snapshot
+	current  *snapshot
+}
+
+func (a *aggregator) Update(dataplane core_model.ResourceKey, streamID int64, samples map[string]Sample) {
+	a.Lock()
+	defer a.Unlock()
+	stats, ok := a.dataplanes[dataplane]
+	if !ok {
+		stats = &dataplaneStats{}
+		a.dataplanes[dataplane] = stats
+	}
+	stats.streamID = streamID
+	stats.previous = stats.current
+	stats.current = &snapshot{time: now(), samples: samples}
+}
+
+func (a *aggregator) Remove(dataplane core_model.ResourceKey, streamID int64) {
+	a.Lock()
+	defer a.Unlock()
+	if stats, ok := a.dataplanes[dataplane]; ok && stats.streamID == streamID {
+		delete(a.dataplanes, dataplane)
+	}
+}
+
+func (a *aggregator) ServiceStats(mesh string, service string) (ServiceStats, bool) {
+	a.RLock()
+	defer a.RUnlock()
+	result := ServiceStats{
+		Mesh:    mesh,
+		Service: service,
+	}
+	var requests, errors uint64
+	var weightedLatency, latency Latency
+	for key, stats := range a.dataplanes {
+		if key.Mesh != mesh || now().Sub(stats.current.time) > a.staleAfter {
+			continue
+		}
+		current, ok := stats.current.samples[service]
+		if !ok {
+			continue
+		}
+		result.Dataplanes++
+		latency = latency.plus(current.Latency, 1)
+
+		if stats.previous == nil {
+			continue
+		}
+		previous := stats.previous.samples[service]
+		interval := stats.current.time.Sub(stats.previous.time).Seconds()
+		deltaRequests := delta(previous.Requests, current.Requests)
+		if interval > 0 {
+			result.RequestRate += float64(deltaRequests) / interval
+		}
+		requests += deltaRequests
+		errors += delta(previous.Errors, current.Errors)
+		weightedLatency = weightedLatency.plus(current.Latency, float64(deltaRequests))
+	}
+	if result.Dataplanes == 0 {
+		return ServiceStats{}, false
+	}
+	if requests > 0 {
+		result.ErrorRate = float64(errors) / float64(requests)
+		result.Latency = weightedLatency.times(1 / float64(requests))
+	} else {
+		result.Latency = latency.times(1 / float64(result.Dataplanes))
+	}
+	return result, true
+}
+
+// delta of a cumulative counter that is reset when Envoy restarts.
+func delta(previous, current uint64) uint64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
+
+func (l Latency) plus(other Latency, weight float64) Latency {
+	return Latency{
+		P50: l.P50 + other.P50*weight,
+		P95: l.P95 + other.P95*weight,
+		P99: l.P99 + other.P99*weight,
+	}
+}
+
+func (l Latency) times(factor float64) Latency {
+	return Latency{
+		P50: l.P50 * factor,
+		P95: l.P95 * factor,
+		P99: l.P99 * factor,
+	}
+}
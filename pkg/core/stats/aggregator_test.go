@@ -0,0 +1,171 @@
+package stats
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var _ = Describe("Aggregator", func() {
+
+	var aggregator Aggregator
+	var current time.Time
+
+	dp1 := core_model.ResourceKey{Mesh: "default", Name: "dp-1"}
+	dp2 := core_model.ResourceKey{Mesh: "default", Name: "dp-2"}
+	dp3 := core_model.ResourceKey{Mesh: "other", Name: "dp-3"}
+
+	BeforeEach(func() {
+		current = time.Unix(1000, 0)
+		now = func() time.Time {
+			return current
+		}
+		aggregator = NewAggregator(time.Minute)
+	})
+
+	AfterEach(func() {
+		now = time.Now
+	})
+
+	It("should not return stats of unknown services", func() {
+		// when
+		_, ok := aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should return latency of a single sample", func() {
+		// given
+		aggregator.Update(dp1, 1, map[string]Sample{
+			"backend": {Requests: 10, Latency: Latency{P50: 1, P95: 2, P99: 3}},
+		})
+
+		// when
+		stats, ok := aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(stats).To(Equal(ServiceStats{
+			Mesh:       "default",
+			Service:    "backend",
+			Dataplanes: 1,
+			Latency:    Latency{P50: 1, P95: 2, P99: 3},
+		}))
+	})
+
+	It("should aggregate stats across dataplanes of a mesh", func() {
+		// given
+		aggregator.Update(dp1, 1, map[string]Sample{
+			"backend": {Requests: 100, Errors: 10},
+		})
+		aggregator.Update(dp2, 1, map[string]Sample{
+			"backend": {Requests: 50},
+			"web":     {Requests: 1000},
+		})
+		aggregator.Update(dp3, 1, map[string]Sample{
+			"backend": {Requests: 1000},
+		})
+
+		// when
+		current = current.Add(10 * time.Second)
+		aggregator.Update(dp1, 1, map[string]Sample{
+			"backend": {Requests: 200, Errors: 20, Latency: Latency{P50: 10, P95: 20, P99: 30}},
+		})
+		aggregator.Update(dp2, 1, map[string]Sample{
+			"backend": {Requests: 350, Errors: 10, Latency: Latency{P50: 2, P95: 4, P99: 6}},
+			"web":     {Requests: 2000},
+		})
+		aggregator.Update(dp3, 1, map[string]Sample{
+			"backend": {Requests: 5000, Errors: 5000},
+		})
+
+		// and
+		stats, ok := aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(stats).To(Equal(ServiceStats{
+			Mesh:        "default",
+			Service:     "backend",
+			Dataplanes:  2,
+			RequestRate: 40,
+			ErrorRate:   0.05,
+			Latency:     Latency{P50: 4, P95: 8, P99: 12},
+		}))
+	})
+
+	It("should handle counters reset by a restart of Envoy", func() {
+		// given
+		aggregator.Update(dp1, 1, map[string]Sample{
+			"backend": {Requests: 100},
+		})
+
+		// when
+		current = current.Add(10 * time.Second)
+		aggregator.Update(dp1, 1, map[string]Sample{
+			"backend": {Requests: 20},
+		})
+		stats, ok := aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(stats.RequestRate).To(Equal(2.0))
+	})
+
+	It("should ignore stale and removed dataplanes", func() {
+		// given
+		aggregator.Update(dp1, 1, map[string]Sample{
+			"backend": {Requests: 100},
+		})
+		current = current.Add(50 * time.Second)
+		aggregator.Update(dp2, 1, map[string]Sample{
+			"backend": {Requests: 100},
+		})
+
+		// when
+		current = current.Add(20 * time.Second)
+		stats, ok := aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(stats.Dataplanes).To(Equal(1))
+
+		// when
+		aggregator.Remove(dp2, 1)
+		_, ok = aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should keep stats reported over a new stream once an old stream is closed", func() {
+		// given
+		aggregator.Update(dp1, 1, map[string]Sample{
+			"backend": {Requests: 100},
+		})
+		// and a Dataplane reconnects before an old stream is closed
+		current = current.Add(5 * time.Second)
+		aggregator.Update(dp1, 2, map[string]Sample{
+			"backend": {Requests: 200},
+		})
+
+		// when
+		aggregator.Remove(dp1, 1)
+		stats, ok := aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(stats.Dataplanes).To(Equal(1))
+
+		// when
+		aggregator.Remove(dp1, 2)
+		_, ok = aggregator.ServiceStats("default", "backend")
+
+		// then
+		Expect(ok).To(BeFalse())
+	})
+})
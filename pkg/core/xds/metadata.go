@@ -3,9 +3,11 @@ package xds
 import (
 	"strconv"
 
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core"
 
 	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	pstruct "github.com/golang/protobuf/ptypes/struct"
 )
 
 var metadataLog = core.Log.WithName("xds-server").WithName("metadata-tracker")
@@ -15,6 +17,9 @@ const (
 
 	fieldDataplaneTokenPath = "dataplaneTokenPath"
 	fieldDataplaneAdminPort = "dataplane.admin.port"
+	fieldEnvoyCrashes       = "dataplane.envoy.crashes"
+	fieldEnvoyLastExitCode  = "dataplane.envoy.lastExitCode"
+	fieldEnvoyRestartEpoch  = "dataplane.envoy.restartEpoch"
 )
 
 // DataplaneMetadata represents environment-specific part of a dataplane configuration.
@@ -34,6 +39,8 @@ const (
 type DataplaneMetadata struct {
 	DataplaneTokenPath string
 	AdminPort          uint32
+	// EnvoyProcess describes Envoy process as reported by `kuma-dp` that supervises it.
+	EnvoyProcess *mesh_proto.EnvoyProcessStatus
 }
 
 func (m *DataplaneMetadata) GetDataplaneTokenPath() string {
@@ -50,6 +57,13 @@ func (m *DataplaneMetadata) GetAdminPort() uint32 {
 	return m.AdminPort
 }
 
+func (m *DataplaneMetadata) GetEnvoyProcess() *mesh_proto.EnvoyProcessStatus {
+	if m == nil {
+		return nil
+	}
+	return m.EnvoyProcess
+}
+
 func DataplaneMetadataFromNode(node *envoy_core.Node) *DataplaneMetadata {
 	metadata := DataplaneMetadata{}
 	if node.Metadata == nil {
@@ -65,5 +79,24 @@ func DataplaneMetadataFromNode(node *envoy_core.Node) *DataplaneMetadata {
 			metadataLog.Error(err, "invalid value in dataplane metadata", "field", fieldDataplaneAdminPort, "value", value)
 		}
 	}
+	if value := node.Metadata.Fields[fieldEnvoyCrashes]; value != nil {
+		metadata.EnvoyProcess = &mesh_proto.EnvoyProcessStatus{
+			Crashes:      uint32(parseInt(value, fieldEnvoyCrashes)),
+			LastExitCode: int32(parseInt(node.Metadata.Fields[fieldEnvoyLastExitCode], fieldEnvoyLastExitCode)),
+			RestartEpoch: uint32(parseInt(node.Metadata.Fields[fieldEnvoyRestartEpoch], fieldEnvoyRestartEpoch)),
+		}
+	}
 	return &metadata
 }
+
+func parseInt(value *pstruct.Value, field string) int64 {
+	if value == nil {
+		return 0
+	}
+	number, err := strconv.ParseInt(value.GetStringValue(), 10, 32)
+	if err != nil {
+		metadataLog.Error(err, "invalid value in dataplane metadata", "field", field, "value", value)
+		return 0
+	}
+	return number
+}
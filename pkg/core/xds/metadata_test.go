@@ -1,6 +1,7 @@
 package xds_test
 
 import (
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core/xds"
 	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	pstruct "github.com/golang/protobuf/ptypes/struct"
@@ -40,12 +41,32 @@ var _ = DescribeTable("DataplaneMetadataFromNode",
 							StringValue: "1234",
 						},
 					},
+					"dataplane.envoy.crashes": &pstruct.Value{
+						Kind: &pstruct.Value_StringValue{
+							StringValue: "2",
+						},
+					},
+					"dataplane.envoy.lastExitCode": &pstruct.Value{
+						Kind: &pstruct.Value_StringValue{
+							StringValue: "-1",
+						},
+					},
+					"dataplane.envoy.restartEpoch": &pstruct.Value{
+						Kind: &pstruct.Value_StringValue{
+							StringValue: "3",
+						},
+					},
 				},
 			},
 		},
 		expected: xds.DataplaneMetadata{
 			DataplaneTokenPath: "/tmp/token",
 			AdminPort:          1234,
+			EnvoyProcess: &mesh_proto.EnvoyProcessStatus{
+				Crashes:      2,
+				LastExitCode: -1,
+				RestartEpoch: 3,
+			},
 		},
 	}),
 )
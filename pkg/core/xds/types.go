@@ -51,6 +51,20 @@ type EndpointMap map[ServiceName][]Endpoint
 // LogMap holds the most specific TrafficLog for each outbound interface of a Dataplane.
 type LogMap map[ServiceName]*mesh_proto.LoggingBackend
 
+// InboundLog is a logging backend of a TrafficLog applied to traffic of an inbound interface.
+type InboundLog struct {
+	TrafficLog *mesh_core.TrafficLogResource
+	Backend    *mesh_proto.LoggingBackend
+	// Sources are services whose traffic is logged. Traffic from any source is logged if empty.
+	Sources []ServiceName
+	// ExcludedSources are services whose traffic is logged by other, more specific TrafficLogs.
+	ExcludedSources []ServiceName
+}
+
+// InboundLogMap holds TrafficLogs for each inbound interface of a Dataplane, i.e. the most specific TrafficLog
+// for each source told apart by its service followed by the most specific TrafficLog for the rest of sources.
+type InboundLogMap map[string][]InboundLog
+
 // HealthCheckMap holds the most specific HealthCheck for each reachable service.
 type HealthCheckMap map[ServiceName]*mesh_core.HealthCheckResource
 
@@ -59,6 +73,7 @@ type Proxy struct {
 	Dataplane          *mesh_core.DataplaneResource
 	TrafficPermissions permissions.MatchedPermissions
 	Logs               LogMap
+	InboundLogs        InboundLogMap
 	TrafficRoutes      RouteMap
 	OutboundSelectors  DestinationMap
 	OutboundTargets    EndpointMap
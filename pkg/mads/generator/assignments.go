@@ -21,6 +21,15 @@ const (
 	meshLabel = "mesh"
 	// dataplaneLabel is the name of the label that holds the dataplane name.
 	dataplaneLabel = "dataplane"
+	// endpointLabel is the name of the label that holds the name of an application endpoint.
+	// It keeps series scraped from an application apart from series scraped from Envoy, e.g. `up`.
+	endpointLabel = "endpoint"
+	// endpointHintLabel is the name of the label that exposes the name of an application endpoint
+	// to relabelling rules on Prometheus side. Like all `__meta_` labels, it's dropped after relabelling.
+	endpointHintLabel = prom.MetaLabelPrefix + "kuma_endpoint"
+
+	defaultEndpointScheme = "http"
+	defaultEndpointPath   = "/metrics"
 )
 
 // MonitoringAssignmentsGenerator knows how to generate MonitoringAssignment
@@ -87,6 +96,21 @@ const (
 //       service: backend
 //       services: ,backend,
 //
+//  Application endpoints declared in `metrics.prometheus.endpoints` of a Mesh or a Dataplane
+//  become additional targets of the same MonitoringAssignment. Their labels override
+//  labels of the assignment when `kuma-prometheus-sd` converts them into target groups, e.g.
+//
+//     targets:
+//     - labels:
+//         __address__: 192.168.0.1:8080
+//     - labels:
+//         __address__: 192.168.0.1:9090
+//         __scheme__: https
+//         __metrics_path__: /stats
+//         __meta_kuma_endpoint: app
+//         endpoint: app
+//         team: payments
+//
 type MonitoringAssignmentsGenerator struct {
 }
 
@@ -114,6 +138,11 @@ func (g MonitoringAssignmentsGenerator) Generate(args Args) ([]*core_xds.Resourc
 			}},
 			Labels: g.dataplaneLabels(dataplane, prometheusEndpoint),
 		}
+		for _, endpoint := range prometheusEndpoint.GetEndpoints() {
+			assignment.Targets = append(assignment.Targets, &observability_proto.MonitoringAssignment_Target{
+				Labels: g.applicationLabels(dataplane, endpoint),
+			})
+		}
 
 		resources = append(resources, &core_xds.Resource{
 			Name:     assignment.Name,
@@ -145,6 +174,29 @@ func (_ MonitoringAssignmentsGenerator) addressLabel(dataplane *mesh_core.Datapl
 	}
 }
 
+func (_ MonitoringAssignmentsGenerator) applicationLabels(dataplane *mesh_core.DataplaneResource, endpoint *mesh_proto.Metrics_Prometheus_Endpoint) map[string]string {
+	labels := map[string]string{}
+	// first, we copy user-defined labels
+	for key, value := range endpoint.GetLabels() {
+		labels[prom_util.SanitizeLabelName(key)] = value
+	}
+	// then, we apply mandatory labels on top
+	scheme := endpoint.GetScheme()
+	if scheme == "" {
+		scheme = defaultEndpointScheme
+	}
+	path := endpoint.GetPath()
+	if path == "" {
+		path = defaultEndpointPath
+	}
+	labels[prom.AddressLabel] = net.JoinHostPort(dataplane.GetIP(), strconv.FormatUint(uint64(endpoint.GetPort()), 10))
+	labels[prom.SchemeLabel] = scheme
+	labels[prom.MetricsPathLabel] = path
+	labels[endpointHintLabel] = endpoint.GetName()
+	labels[endpointLabel] = endpoint.GetName()
+	return labels
+}
+
 func (g MonitoringAssignmentsGenerator) dataplaneLabels(dataplane *mesh_core.DataplaneResource, endpoint *mesh_proto.Metrics_Prometheus) map[string]string {
 	labels := map[string]string{}
 	// first, we copy user-defined tags
@@ -492,6 +492,104 @@ var _ = Describe("MonitoringAssignmentsGenerator", func() {
 					},
 				},
 			}),
+			Entry("Dataplane with application endpoints", testCase{
+				meshes: []*mesh_core.MeshResource{
+					{
+						Meta: &test_model.ResourceMeta{
+							Name: "default",
+						},
+						Spec: mesh_proto.Mesh{
+							Metrics: &mesh_proto.Metrics{
+								Prometheus: &mesh_proto.Metrics_Prometheus{
+									Port: 1234,
+									Path: "/non-standard-path",
+									Endpoints: []*mesh_proto.Metrics_Prometheus_Endpoint{{
+										Name: "app",
+										Port: 9090,
+									}},
+								},
+							},
+						},
+					},
+				},
+				dataplanes: []*mesh_core.DataplaneResource{
+					{
+						Meta: &test_model.ResourceMeta{
+							Name: "backend-01",
+							Mesh: "default",
+						},
+						Spec: mesh_proto.Dataplane{
+							Networking: &mesh_proto.Dataplane_Networking{
+								Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+									Interface: "192.168.0.1:80:8080",
+									Tags: map[string]string{
+										"service": "backend",
+									},
+								}},
+							},
+							Metrics: &mesh_proto.Metrics{
+								Prometheus: &mesh_proto.Metrics_Prometheus{
+									Endpoints: []*mesh_proto.Metrics_Prometheus_Endpoint{{
+										Name:   "jvm",
+										Port:   9091,
+										Path:   "/jmx",
+										Scheme: "https",
+										Labels: map[string]string{
+											"team":     "payments",
+											"endpoint": "overridden",
+										},
+									}},
+								},
+							},
+						},
+					},
+				},
+				expected: []*core_xds.Resource{
+					{
+						Name:    "/meshes/default/dataplanes/backend-01",
+						Version: "",
+						Resource: &observability_proto.MonitoringAssignment{
+							Name: "/meshes/default/dataplanes/backend-01",
+							Targets: []*observability_proto.MonitoringAssignment_Target{
+								{
+									Labels: map[string]string{
+										"__address__": "192.168.0.1:1234",
+									},
+								},
+								{
+									Labels: map[string]string{
+										"__address__":          "192.168.0.1:9090",
+										"__scheme__":           "http",
+										"__metrics_path__":     "/metrics",
+										"__meta_kuma_endpoint": "app",
+										"endpoint":             "app",
+									},
+								},
+								{
+									Labels: map[string]string{
+										"__address__":          "192.168.0.1:9091",
+										"__scheme__":           "https",
+										"__metrics_path__":     "/jmx",
+										"__meta_kuma_endpoint": "jvm",
+										"endpoint":             "jvm",
+										"team":                 "payments",
+									},
+								},
+							},
+							Labels: map[string]string{
+								"__scheme__":       "http",
+								"__metrics_path__": "/non-standard-path",
+								"job":              "backend",
+								"instance":         "backend-01",
+								"mesh":             "default",
+								"dataplane":        "backend-01",
+								"service":          "backend",
+								"services":         ",backend,",
+							},
+						},
+					},
+				},
+			}),
 		)
 	})
 })
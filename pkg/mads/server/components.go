@@ -40,9 +40,14 @@ func NewSyncTracker(rt core_runtime.Runtime, reconciler mads_reconcile.Reconcile
 			},
 			OnTick: func() error {
 				log.V(1).Info("on tick")
+				start := time.Now()
+				defer func() {
+					madsGeneration.Observe(time.Since(start).Seconds())
+				}()
 				return reconciler.Reconcile(ctx, node)
 			},
 			OnError: func(err error) {
+				madsGenerationErrors.Inc()
 				log.Error(err, "OnTick() failed")
 			},
 		}, nil
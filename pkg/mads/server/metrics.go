@@ -0,0 +1,17 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	madsGeneration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "kuma_cp_mads_generation_seconds",
+		Help: "Time it takes to generate and push Monitoring Assignments",
+	})
+	madsGenerationErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kuma_cp_mads_generation_errors_total",
+		Help: "Number of failures to generate Monitoring Assignments",
+	})
+)
@@ -19,6 +19,7 @@ func SetupServer(rt core_runtime.Runtime) error {
 	syncTracker := NewSyncTracker(rt, reconciler)
 	callbacks := util_xds.CallbacksChain{
 		util_xds.LoggingCallbacks{Log: madsServerLog},
+		util_xds.StatsCallbacks{Server: "mads"},
 		syncTracker,
 	}
 	srv := NewServer(cache, callbacks, madsServerLog)
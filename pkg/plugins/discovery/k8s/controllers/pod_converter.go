@@ -23,6 +23,9 @@ var (
 	converterLog = core.Log.WithName("discovery").WithName("k8s").WithName("pod-to-dataplane-converter")
 )
 
+// InstanceTag identifies a particular Pod behind a headless Service.
+const InstanceTag = "kuma.io/instance"
+
 func PodToDataplane(dataplane *mesh_k8s.Dataplane, pod *kube_core.Pod, services []*kube_core.Service,
 	others []*mesh_k8s.Dataplane, serviceGetter kube_client.Reader) error {
 	// pick a Mesh
@@ -42,7 +45,8 @@ func PodToDataplane(dataplane *mesh_k8s.Dataplane, pod *kube_core.Pod, services
 }
 
 func MeshFor(pod *kube_core.Pod) string {
-	return injector_metadata.GetMesh(pod)
+	// Kuma Injector records the effective Mesh on a Pod, so there is no need to look up its Namespace
+	return injector_metadata.GetMesh(pod, nil)
 }
 
 func DataplaneFor(pod *kube_core.Pod, services []*kube_core.Service, others []*mesh_k8s.Dataplane, serviceGetter kube_client.Reader) (*mesh_proto.Dataplane, error) {
@@ -50,9 +54,11 @@ func DataplaneFor(pod *kube_core.Pod, services []*kube_core.Service, others []*m
 		Networking: &mesh_proto.Dataplane_Networking{},
 	}
 	if injector_metadata.HasTransparentProxyingEnabled(pod) {
-		dataplane.Networking.TransparentProxying = &mesh_proto.Dataplane_Networking_TransparentProxying{
-			RedirectPort: injector_metadata.GetTransparentProxyingPort(pod),
+		transparentProxying, err := TransparentProxyingFor(pod)
+		if err != nil {
+			return nil, err
 		}
+		dataplane.Networking.TransparentProxying = transparentProxying
 	}
 
 	if injector_metadata.HasGatewayEnabled(pod) {
@@ -69,7 +75,7 @@ func DataplaneFor(pod *kube_core.Pod, services []*kube_core.Service, others []*m
 		dataplane.Networking.Inbound = ifaces
 	}
 
-	ofaces, err := OutboundInterfacesFor(others, serviceGetter)
+	ofaces, err := OutboundInterfacesFor(pod, others, serviceGetter)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +84,27 @@ func DataplaneFor(pod *kube_core.Pod, services []*kube_core.Service, others []*m
 	return dataplane, nil
 }
 
+func TransparentProxyingFor(pod *kube_core.Pod) (*mesh_proto.Dataplane_Networking_TransparentProxying, error) {
+	excludedInboundPorts, err := injector_metadata.ParsePorts(pod.Annotations[injector_metadata.KumaExcludedInboundPortsAnnotation])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid value of %q annotation", injector_metadata.KumaExcludedInboundPortsAnnotation)
+	}
+	excludedOutboundPorts, err := injector_metadata.ParsePorts(pod.Annotations[injector_metadata.KumaExcludedOutboundPortsAnnotation])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid value of %q annotation", injector_metadata.KumaExcludedOutboundPortsAnnotation)
+	}
+	excludedOutboundCIDRs, err := injector_metadata.ParseCIDRs(pod.Annotations[injector_metadata.KumaExcludedOutboundCIDRsAnnotation])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid value of %q annotation", injector_metadata.KumaExcludedOutboundCIDRsAnnotation)
+	}
+	return &mesh_proto.Dataplane_Networking_TransparentProxying{
+		RedirectPort:          injector_metadata.GetTransparentProxyingPort(pod),
+		ExcludedInboundPorts:  excludedInboundPorts,
+		ExcludedOutboundPorts: excludedOutboundPorts,
+		ExcludedOutboundCidrs: excludedOutboundCIDRs,
+	}, nil
+}
+
 func GatewayFor(pod *kube_core.Pod, services []*kube_core.Service) (*mesh_proto.Dataplane_Networking_Gateway, error) {
 	interfaces, err := InboundInterfacesFor(pod, services)
 	if err != nil {
@@ -122,10 +149,13 @@ func InboundInterfacesFor(pod *kube_core.Pod, services []*kube_core.Service) ([]
 	return ifaces, nil
 }
 
-func OutboundInterfacesFor(others []*mesh_k8s.Dataplane, serviceGetter kube_client.Reader) ([]*mesh_proto.Dataplane_Networking_Outbound, error) {
+// OutboundInterfacesFor generates an outbound for every service in a Mesh
+// unless a Pod limits them with `kuma.io/outbound-services` annotation.
+func OutboundInterfacesFor(pod *kube_core.Pod, others []*mesh_k8s.Dataplane, serviceGetter kube_client.Reader) ([]*mesh_proto.Dataplane_Networking_Outbound, error) {
 	var ofaces []*mesh_proto.Dataplane_Networking_Outbound
 
-	allServiceTags := make(map[string]bool)
+	consumes := outboundServicesMatcherFor(pod)
+	inboundsByService := make(map[string][]*mesh_proto.Dataplane_Networking_Inbound)
 	for _, other := range others {
 		dataplane := &mesh_proto.Dataplane{}
 		if err := util_proto.FromMap(other.Spec, dataplane); err != nil {
@@ -134,13 +164,13 @@ func OutboundInterfacesFor(others []*mesh_k8s.Dataplane, serviceGetter kube_clie
 		}
 		for _, inbound := range dataplane.Networking.GetInbound() {
 			svc, ok := inbound.GetTags()[mesh_proto.ServiceTag]
-			if !ok {
+			if !ok || !consumes(svc) {
 				continue
 			}
-			allServiceTags[svc] = true
+			inboundsByService[svc] = append(inboundsByService[svc], inbound)
 		}
 	}
-	for _, serviceTag := range stringSetToSortedList(allServiceTags) {
+	for _, serviceTag := range sortedServiceTags(inboundsByService) {
 		host, port, err := mesh_proto.ServiceTagValue(serviceTag).HostAndPort()
 		if err != nil {
 			converterLog.Error(err, "failed to parse `service` tag value", "value", serviceTag)
@@ -158,18 +188,116 @@ func OutboundInterfacesFor(others []*mesh_k8s.Dataplane, serviceGetter kube_clie
 			continue // one invalid Dataplane definition should not break the entire mesh
 		}
 
-		dataplaneIP := svc.Spec.ClusterIP
-		dataplanePort := port
+		switch {
+		case svc.Spec.Type == kube_core.ServiceTypeExternalName:
+			// a name of ExternalName Service resolves into an address outside of the cluster,
+			// which is represented in the mesh by Dataplanes of external services
+			externalOfaces := ExternalNameOutboundInterfacesFor(serviceTag, inboundsByService[serviceTag])
+			if len(externalOfaces) == 0 {
+				converterLog.V(1).Info("skipping ExternalName Service without Dataplanes of an external service", "namespace", ns, "name", name, "externalName", svc.Spec.ExternalName)
+			}
+			ofaces = append(ofaces, externalOfaces...)
+		case svc.Spec.ClusterIP == kube_core.ClusterIPNone:
+			// a name of headless Service resolves into addresses of individual Pods
+			ofaces = append(ofaces, HeadlessOutboundInterfacesFor(pod, serviceTag, inboundsByService[serviceTag])...)
+		default:
+			ofaces = append(ofaces, &mesh_proto.Dataplane_Networking_Outbound{
+				Interface: mesh_proto.OutboundInterface{
+					DataplaneIP:   svc.Spec.ClusterIP,
+					DataplanePort: port,
+				}.String(),
+				Service: serviceTag,
+			})
+		}
+	}
+	return ofaces, nil
+}
 
+// HeadlessOutboundInterfacesFor generates an outbound for every other Pod behind a headless Service,
+// so that an application could address a particular Pod, e.g. a member of a StatefulSet.
+// A Pod doesn't get an outbound on its own address, which would clash with its inbound listener.
+func HeadlessOutboundInterfacesFor(pod *kube_core.Pod, serviceTag string, inbounds []*mesh_proto.Dataplane_Networking_Inbound) []*mesh_proto.Dataplane_Networking_Outbound {
+	var ofaces []*mesh_proto.Dataplane_Networking_Outbound
+	for _, inbound := range inbounds {
+		instance, ok := inbound.GetTags()[InstanceTag]
+		if !ok {
+			continue
+		}
+		iface, err := mesh_proto.ParseInboundInterface(inbound.Interface)
+		if err != nil {
+			converterLog.Error(err, "failed to parse inbound interface", "interface", inbound.Interface)
+			continue // one invalid Dataplane definition should not break the entire mesh
+		}
+		if iface.DataplaneIP == pod.Status.PodIP {
+			continue
+		}
 		ofaces = append(ofaces, &mesh_proto.Dataplane_Networking_Outbound{
 			Interface: mesh_proto.OutboundInterface{
-				DataplaneIP:   dataplaneIP,
-				DataplanePort: dataplanePort,
+				DataplaneIP:   iface.DataplaneIP,
+				DataplanePort: iface.DataplanePort,
 			}.String(),
 			Service: serviceTag,
+			Tags: map[string]string{
+				InstanceTag: instance,
+			},
 		})
 	}
-	return ofaces, nil
+	sort.Slice(ofaces, func(i, j int) bool {
+		return ofaces[i].Tags[InstanceTag] < ofaces[j].Tags[InstanceTag]
+	})
+	return ofaces
+}
+
+// ExternalNameOutboundInterfacesFor generates an outbound for every address of an external service
+// behind an ExternalName Service. The external service is represented in the mesh by Dataplanes
+// with inbounds on its addresses, so that traffic an application sends to the name of ExternalName Service
+// goes through the mesh rather than directly to the original destination.
+func ExternalNameOutboundInterfacesFor(serviceTag string, inbounds []*mesh_proto.Dataplane_Networking_Inbound) []*mesh_proto.Dataplane_Networking_Outbound {
+	var ofaces []*mesh_proto.Dataplane_Networking_Outbound
+	seen := map[string]bool{}
+	for _, inbound := range inbounds {
+		iface, err := mesh_proto.ParseInboundInterface(inbound.Interface)
+		if err != nil {
+			converterLog.Error(err, "failed to parse inbound interface", "interface", inbound.Interface)
+			continue // one invalid Dataplane definition should not break the entire mesh
+		}
+		address := mesh_proto.OutboundInterface{
+			DataplaneIP:   iface.DataplaneIP,
+			DataplanePort: iface.DataplanePort,
+		}.String()
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		ofaces = append(ofaces, &mesh_proto.Dataplane_Networking_Outbound{
+			Interface: address,
+			Service:   serviceTag,
+		})
+	}
+	sort.Slice(ofaces, func(i, j int) bool {
+		return ofaces[i].Interface < ofaces[j].Interface
+	})
+	return ofaces
+}
+
+// outboundServicesMatcherFor returns a predicate that tells whether a Pod consumes a service with a given `service` tag.
+func outboundServicesMatcherFor(pod *kube_core.Pod) func(serviceTag string) bool {
+	entries, scoped := injector_metadata.GetOutboundServices(pod)
+	if !scoped {
+		return func(string) bool { return true }
+	}
+	consumed := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		consumed[entry] = true
+	}
+	return func(serviceTag string) bool {
+		if consumed[serviceTag] {
+			return true
+		}
+		// an entry without a port matches all ports of a service
+		host, _, err := mesh_proto.ServiceTagValue(serviceTag).HostAndPort()
+		return err == nil && consumed[host]
+	}
 }
 
 func InboundTagsFor(pod *kube_core.Pod, svc *kube_core.Service, svcPort *kube_core.ServicePort) map[string]string {
@@ -178,9 +306,21 @@ func InboundTagsFor(pod *kube_core.Pod, svc *kube_core.Service, svcPort *kube_co
 		tags = make(map[string]string)
 	}
 	tags[mesh_proto.ServiceTag] = ServiceTagFor(svc, svcPort)
+	if svc.Spec.ClusterIP == kube_core.ClusterIPNone {
+		tags[InstanceTag] = InstanceTagFor(pod, svc)
+	}
 	return tags
 }
 
+// InstanceTagFor returns a DNS name of a Pod behind a headless Service, e.g. "redis-0.redis.kuma-demo.svc".
+func InstanceTagFor(pod *kube_core.Pod, svc *kube_core.Service) string {
+	hostname := pod.Spec.Hostname
+	if hostname == "" {
+		hostname = pod.Name
+	}
+	return fmt.Sprintf("%s.%s.%s.svc", hostname, svc.Name, svc.Namespace)
+}
+
 func ServiceTagFor(svc *kube_core.Service, svcPort *kube_core.ServicePort) string {
 	return fmt.Sprintf("%s.%s.svc:%d", svc.Name, svc.Namespace, svcPort.Port)
 }
@@ -195,9 +335,9 @@ func ParseServiceFQDN(host string) (name string, namespace string, err error) {
 	return
 }
 
-func stringSetToSortedList(set map[string]bool) []string {
-	list := make([]string, 0, len(set))
-	for key := range set {
+func sortedServiceTags(inboundsByService map[string][]*mesh_proto.Dataplane_Networking_Inbound) []string {
+	list := make([]string, 0, len(inboundsByService))
+	for key := range inboundsByService {
 		list = append(list, key)
 	}
 	sort.Strings(list)
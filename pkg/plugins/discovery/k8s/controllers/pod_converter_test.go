@@ -363,6 +363,396 @@ var _ = Describe("PodToDataplane(..)", func() {
                   service: test-app.playground.svc:443
                 - interface: 10.108.144.24:80
                   service: test-app.playground.svc:80
+`,
+		}),
+		Entry("Pod with `kuma.io/outbound-services` annotation and 2 other Dataplanes", testCase{
+			pod: &kube_core.Pod{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "example",
+					Annotations: map[string]string{
+						"kuma.io/outbound-services": "test-app.playground.svc:443, web.playground.svc",
+					},
+				},
+			},
+			services: nil,
+			others: []string{`
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: test-app-8646b8bbc8-5qbl2
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 10.244.0.25:80:80
+                  tags:
+                    app: test-app
+                    service: test-app.playground.svc:80
+                - interface: 10.244.0.25:443:443
+                  tags:
+                    app: test-app
+                    service: test-app.playground.svc:443
+`, `
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: web-6b5d6dc5d7-9xq2p
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 10.244.0.26:8080:8080
+                  tags:
+                    app: web
+                    service: web.playground.svc:8080
+`, `
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: redis-5c7f8d9b4-k2xvz
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 10.244.0.27:6379:6379
+                  tags:
+                    app: redis
+                    service: redis.playground.svc:6379
+`,
+			},
+			serviceGetter: fakeReader{
+				"playground/test-app": `
+                    apiVersion: v1
+                    kind: Service
+                    metadata:
+                      name: test-app
+                      namespace: playground
+                    spec:
+                      clusterIP: 10.108.144.24
+`,
+				"playground/web": `
+                    apiVersion: v1
+                    kind: Service
+                    metadata:
+                      name: web
+                      namespace: playground
+                    spec:
+                      clusterIP: 10.108.144.25
+`,
+				"playground/redis": `
+                    apiVersion: v1
+                    kind: Service
+                    metadata:
+                      name: redis
+                      namespace: playground
+                    spec:
+                      clusterIP: 10.108.144.26
+`,
+			},
+			expected: `
+            mesh: default
+            metadata:
+              creationTimestamp: null
+            spec:
+              networking:
+                outbound:
+                - interface: 10.108.144.24:443
+                  service: test-app.playground.svc:443
+                - interface: 10.108.144.25:8080
+                  service: web.playground.svc:8080
+`,
+		}),
+		Entry("Pod with empty `kuma.io/outbound-services` annotation and 1 other Dataplane", testCase{
+			pod: &kube_core.Pod{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "example",
+					Annotations: map[string]string{
+						"kuma.io/outbound-services": "",
+					},
+				},
+			},
+			services: nil,
+			others: []string{`
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: web-6b5d6dc5d7-9xq2p
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 10.244.0.26:8080:8080
+                  tags:
+                    app: web
+                    service: web.playground.svc:8080
+`,
+			},
+			expected: `
+            mesh: default
+            metadata:
+              creationTimestamp: null
+            spec:
+              networking: {}
+`,
+		}),
+		Entry("Pod with a headless Service", testCase{
+			pod: pod,
+			services: []*kube_core.Service{
+				{
+					ObjectMeta: kube_meta.ObjectMeta{
+						Namespace: "demo",
+						Name:      "example",
+					},
+					Spec: kube_core.ServiceSpec{
+						ClusterIP: "None",
+						Ports: []kube_core.ServicePort{
+							{
+								Port: 8080,
+								TargetPort: kube_intstr.IntOrString{
+									Type:   kube_intstr.Int,
+									IntVal: 8080,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: `
+            mesh: default
+            metadata:
+              creationTimestamp: null
+            spec:
+              networking:
+                inbound:
+                - interface: 192.168.0.1:8080:8080
+                  tags:
+                    app: example
+                    kuma.io/instance: example.example.demo.svc
+                    service: example.demo.svc:8080
+                    version: "0.1"
+`,
+		}),
+		Entry("Pod with other Dataplanes behind headless and ExternalName Services", testCase{
+			pod:      pod,
+			services: nil,
+			others: []string{`
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: redis-1
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 10.244.0.31:6379:6379
+                  tags:
+                    app: redis
+                    kuma.io/instance: redis-1.redis.playground.svc
+                    service: redis.playground.svc:6379
+`, `
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: redis-0
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 10.244.0.30:6379:6379
+                  tags:
+                    app: redis
+                    kuma.io/instance: redis-0.redis.playground.svc
+                    service: redis.playground.svc:6379
+`, `
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: legacy
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 10.244.0.32:80:80
+                  tags:
+                    app: legacy
+                    service: payments.playground.svc:80
+`,
+			},
+			serviceGetter: fakeReader{
+				"playground/redis": `
+                    apiVersion: v1
+                    kind: Service
+                    metadata:
+                      name: redis
+                      namespace: playground
+                    spec:
+                      clusterIP: None
+`,
+				"playground/payments": `
+                    apiVersion: v1
+                    kind: Service
+                    metadata:
+                      name: payments
+                      namespace: playground
+                    spec:
+                      type: ExternalName
+                      externalName: payments.example.com
+`,
+			},
+			expected: `
+            mesh: default
+            metadata:
+              creationTimestamp: null
+            spec:
+              networking:
+                outbound:
+                - interface: 10.244.0.32:80
+                  service: payments.playground.svc:80
+                - interface: 10.244.0.30:6379
+                  service: redis.playground.svc:6379
+                  tags:
+                    kuma.io/instance: redis-0.redis.playground.svc
+                - interface: 10.244.0.31:6379
+                  service: redis.playground.svc:6379
+                  tags:
+                    kuma.io/instance: redis-1.redis.playground.svc
+`,
+		}),
+		Entry("Pod with its own Dataplane among other Dataplanes behind a headless Service", testCase{
+			pod:      pod,
+			services: nil,
+			others: []string{`
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: example
+              namespace: demo
+            spec:
+              networking:
+                inbound:
+                - interface: 192.168.0.1:8080:8080
+                  tags:
+                    app: example
+                    kuma.io/instance: example.example.demo.svc
+                    service: example.demo.svc:8080
+`, `
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: example-1
+              namespace: demo
+            spec:
+              networking:
+                inbound:
+                - interface: 192.168.0.2:8080:8080
+                  tags:
+                    app: example
+                    kuma.io/instance: example-1.example.demo.svc
+                    service: example.demo.svc:8080
+`,
+			},
+			serviceGetter: fakeReader{
+				"demo/example": `
+                    apiVersion: v1
+                    kind: Service
+                    metadata:
+                      name: example
+                      namespace: demo
+                    spec:
+                      clusterIP: None
+`,
+			},
+			expected: `
+            mesh: default
+            metadata:
+              creationTimestamp: null
+            spec:
+              networking:
+                outbound:
+                - interface: 192.168.0.2:8080
+                  service: example.demo.svc:8080
+                  tags:
+                    kuma.io/instance: example-1.example.demo.svc
+`,
+		}),
+		Entry("Pod with other Dataplanes of an external service behind ExternalName Service", testCase{
+			pod:      pod,
+			services: nil,
+			others: []string{`
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: payments-2
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 203.0.113.11:443:443
+                  tags:
+                    service: payments.playground.svc:443
+`, `
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: payments-1
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 203.0.113.10:443:443
+                  tags:
+                    service: payments.playground.svc:443
+`, `
+            apiVersion: kuma.io/v1alpha1
+            kind: Dataplane
+            mesh: default
+            metadata:
+              name: payments-1-copy
+              namespace: playground
+            spec:
+              networking:
+                inbound:
+                - interface: 203.0.113.10:443:443
+                  tags:
+                    service: payments.playground.svc:443
+`,
+			},
+			serviceGetter: fakeReader{
+				"playground/payments": `
+                    apiVersion: v1
+                    kind: Service
+                    metadata:
+                      name: payments
+                      namespace: playground
+                    spec:
+                      type: ExternalName
+                      externalName: payments.example.com
+`,
+			},
+			expected: `
+            mesh: default
+            metadata:
+              creationTimestamp: null
+            spec:
+              networking:
+                outbound:
+                - interface: 203.0.113.10:443
+                  service: payments.playground.svc:443
+                - interface: 203.0.113.11:443
+                  service: payments.playground.svc:443
 `,
 		}),
 		Entry("pod with gateway annotation and 1 service", testCase{
@@ -417,6 +807,38 @@ var _ = Describe("PodToDataplane(..)", func() {
               creationTimestamp: null
             spec:
               networking: {}
+`,
+		}),
+		Entry("pod with transparent proxying exclusions", testCase{
+			pod: &kube_core.Pod{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "example",
+					Annotations: map[string]string{
+						"kuma.io/transparent-proxying":      "enabled",
+						"kuma.io/transparent-proxying-port": "15001",
+						"kuma.io/excluded-inbound-ports":    "8081",
+						"kuma.io/excluded-outbound-ports":   "5432,6379",
+						"kuma.io/excluded-outbound-cidrs":   "169.254.169.254/32",
+					},
+				},
+			},
+			services: nil,
+			expected: `
+            mesh: default
+            metadata:
+              creationTimestamp: null
+            spec:
+              networking:
+                transparentProxying:
+                  redirectPort: 15001
+                  excludedInboundPorts:
+                  - 8081
+                  excludedOutboundPorts:
+                  - 5432
+                  - 6379
+                  excludedOutboundCidrs:
+                  - 169.254.169.254/32
 `,
 		}),
 	)
@@ -533,6 +955,35 @@ var _ = Describe("InboundTagsFor(..)", func() {
 	)
 })
 
+var _ = Describe("InstanceTagFor(..)", func() {
+	It("should use Pod's hostname and headless Service FQDN", func() {
+		// given
+		pod := &kube_core.Pod{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace: "demo",
+				Name:      "redis-0",
+			},
+		}
+		svc := &kube_core.Service{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace: "demo",
+				Name:      "redis",
+			},
+			Spec: kube_core.ServiceSpec{
+				ClusterIP: "None",
+			},
+		}
+
+		// expect
+		Expect(InstanceTagFor(pod, svc)).To(Equal("redis-0.redis.demo.svc"))
+
+		// when
+		pod.Spec.Hostname = "master"
+		// then
+		Expect(InstanceTagFor(pod, svc)).To(Equal("master.redis.demo.svc"))
+	})
+})
+
 var _ = Describe("ServiceTagFor(..)", func() {
 	It("should use Service FQDN", func() {
 		// given
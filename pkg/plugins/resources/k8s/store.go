@@ -76,7 +76,7 @@ func (s *KubernetesStore) Delete(ctx context.Context, r core_model.Resource, fs
 	opts := store.NewDeleteOptions(fs...)
 
 	// get object and validate mesh
-	if err := s.Get(ctx, r, store.GetByKey(opts.Name, opts.Mesh)); err != nil {
+	if err := s.Get(ctx, r, store.GetByKey(opts.Name, opts.Mesh), store.GetByVersion(opts.Version)); err != nil {
 		return err
 	}
 
@@ -90,10 +90,18 @@ func (s *KubernetesStore) Delete(ctx context.Context, r core_model.Resource, fs
 	}
 	obj.GetObjectMeta().SetName(name)
 	obj.GetObjectMeta().SetNamespace(namespace)
-	if err := s.Client.Delete(ctx, obj); err != nil {
+	var deleteOpts []kube_client.DeleteOption
+	if opts.Version != "" {
+		// let API Server check the version, so the resource cannot be modified in between
+		deleteOpts = append(deleteOpts, kube_client.Preconditions(kube_meta.Preconditions{ResourceVersion: &opts.Version}))
+	}
+	if err := s.Client.Delete(ctx, obj, deleteOpts...); err != nil {
 		if kube_apierrs.IsNotFound(err) {
 			return nil
 		}
+		if kube_apierrs.IsConflict(err) {
+			return store.ErrorResourcePreconditionFailed(r.GetType(), opts.Name, opts.Mesh)
+		}
 		return errors.Wrap(err, "failed to delete k8s resource")
 	}
 	return nil
@@ -52,7 +52,7 @@ func (v memoryVersion) String() string {
 	return strconv.FormatUint(uint64(v), 10)
 }
 
-var _ store.ResourceStore = &memoryStore{}
+var _ store.TransactionalResourceStore = &memoryStore{}
 
 type memoryStore struct {
 	records memoryStoreRecords
@@ -63,10 +63,52 @@ func NewStore() store.ResourceStore {
 	return &memoryStore{}
 }
 
-func (c *memoryStore) Create(_ context.Context, r model.Resource, fs ...store.CreateOptionsFunc) error {
+// transactionKey marks a context of a transaction in progress on a given store.
+type transactionKey struct {
+	store *memoryStore
+}
+
+// Transactional holds the lock of the store for the whole transaction,
+// so operations made with a context of the transaction don't acquire it again.
+func (c *memoryStore) Transactional(ctx context.Context, fn func(ctx context.Context) error) error {
+	if c.inTransaction(ctx) {
+		return fn(ctx)
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	// records are never modified in place, so a shallow copy is enough to roll back
+	snapshot := append(memoryStoreRecords(nil), c.records...)
+	if err := fn(context.WithValue(ctx, transactionKey{store: c}, true)); err != nil {
+		c.records = snapshot
+		return err
+	}
+	return nil
+}
+
+func (c *memoryStore) inTransaction(ctx context.Context) bool {
+	return ctx.Value(transactionKey{store: c}) != nil
+}
+
+func (c *memoryStore) lock(ctx context.Context) func() {
+	if c.inTransaction(ctx) {
+		return func() {}
+	}
+	c.mu.Lock()
+	return c.mu.Unlock
+}
+
+func (c *memoryStore) rlock(ctx context.Context) func() {
+	if c.inTransaction(ctx) {
+		return func() {}
+	}
+	c.mu.RLock()
+	return c.mu.RUnlock
+}
+
+func (c *memoryStore) Create(ctx context.Context, r model.Resource, fs ...store.CreateOptionsFunc) error {
+	defer c.lock(ctx)()
+
 	opts := store.NewCreateOptions(fs...)
 
 	// Name must be provided via CreateOptions
@@ -96,9 +138,8 @@ func (c *memoryStore) Create(_ context.Context, r model.Resource, fs ...store.Cr
 	c.records = append(c.records, record)
 	return nil
 }
-func (c *memoryStore) Update(_ context.Context, r model.Resource, fs ...store.UpdateOptionsFunc) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *memoryStore) Update(ctx context.Context, r model.Resource, fs ...store.UpdateOptionsFunc) error {
+	defer c.lock(ctx)()
 
 	_ = store.NewUpdateOptions(fs...)
 
@@ -125,11 +166,11 @@ func (c *memoryStore) Update(_ context.Context, r model.Resource, fs ...store.Up
 
 	// persist
 	c.records[idx] = record
+	r.SetMeta(meta)
 	return nil
 }
-func (c *memoryStore) Delete(_ context.Context, r model.Resource, fs ...store.DeleteOptionsFunc) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *memoryStore) Delete(ctx context.Context, r model.Resource, fs ...store.DeleteOptionsFunc) error {
+	defer c.lock(ctx)()
 
 	opts := store.NewDeleteOptions(fs...)
 
@@ -143,13 +184,15 @@ func (c *memoryStore) Delete(_ context.Context, r model.Resource, fs ...store.De
 	if record == nil {
 		return store.ErrorResourceNotFound(r.GetType(), opts.Name, opts.Mesh)
 	}
+	if opts.Version != "" && opts.Version != record.Version.String() {
+		return store.ErrorResourcePreconditionFailed(r.GetType(), opts.Name, opts.Mesh)
+	}
 	c.records = append(c.records[:idx], c.records[idx+1:]...)
 	return nil
 }
 
-func (c *memoryStore) Get(_ context.Context, r model.Resource, fs ...store.GetOptionsFunc) error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+func (c *memoryStore) Get(ctx context.Context, r model.Resource, fs ...store.GetOptionsFunc) error {
+	defer c.rlock(ctx)()
 
 	opts := store.NewGetOptions(fs...)
 
@@ -163,9 +206,8 @@ func (c *memoryStore) Get(_ context.Context, r model.Resource, fs ...store.GetOp
 	}
 	return c.unmarshalRecord(record, r)
 }
-func (c *memoryStore) List(_ context.Context, rs model.ResourceList, fs ...store.ListOptionsFunc) error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+func (c *memoryStore) List(ctx context.Context, rs model.ResourceList, fs ...store.ListOptionsFunc) error {
+	defer c.rlock(ctx)()
 
 	opts := store.NewListOptions(fs...)
 
@@ -8,4 +8,5 @@ import (
 
 var _ = Describe("MemoryStore", func() {
 	test_store.ExecuteStoreTests(memory.NewStore)
+	test_store.ExecuteTransactionalStoreTests(memory.NewStore)
 })
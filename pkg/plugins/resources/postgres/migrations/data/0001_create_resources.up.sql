@@ -6,4 +6,4 @@ CREATE TABLE IF NOT EXISTS resources (
     version     integer NOT NULL,
     spec        text,
     PRIMARY KEY (name, namespace, mesh, type)
-);
\ No newline at end of file
+);
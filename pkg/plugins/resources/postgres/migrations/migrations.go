@@ -0,0 +1,109 @@
+package migrations
+
+//go:generate go run github.com/shurcooL/vfsgen/cmd/vfsgendev -source="github.com/Kong/kuma/pkg/plugins/resources/postgres/migrations".Migrations
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shurcooL/httpfs/vfsutil"
+)
+
+func MigrationsDir(srcDir string) string {
+	return filepath.Join(srcDir, "data")
+}
+
+// Migration is a single versioned change of the database schema.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// All returns migrations embedded in the binary ordered by version.
+func All() ([]Migration, error) {
+	return Load(Migrations)
+}
+
+// Load reads migrations from files named "<version>_<name>.<up|down>.sql".
+// Versions have to start at 1 and must not have gaps.
+func Load(fs http.FileSystem) ([]Migration, error) {
+	byVersion := map[int]*Migration{}
+	walkFn := func(path string, fi os.FileInfo, r io.ReadSeeker, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		matches := fileNameRegexp.FindStringSubmatch(fi.Name())
+		if matches == nil {
+			return errors.Errorf("invalid name of a migration file %q", path)
+		}
+		version, _ := strconv.Atoi(matches[1])
+		content, err := ioutil.ReadAll(r)
+		if err != nil {
+			return errors.Wrapf(err, "could not read migration file %q", path)
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != matches[2] {
+			return errors.Errorf("migration %d has different names: %q and %q", version, migration.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+		return nil
+	}
+	if err := vfsutil.WalkFiles(fs, "/", walkFn); err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, errors.Errorf("migration %d has to have both up and down files", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, errors.Errorf("migration %d is missing", i+1)
+		}
+	}
+	return migrations, nil
+}
+
+// Script renders all migrations as a single SQL script that also records them in the schema_version table.
+// It lets users install the schema without running "kuma-cp migrate up".
+func Script(migrations []Migration) string {
+	var buf bytes.Buffer
+	for _, migration := range migrations {
+		fmt.Fprintf(&buf, "-- %04d_%s\n%s\n", migration.Version, migration.Name, migration.Up)
+	}
+	fmt.Fprintf(&buf, "%s;\n", createSchemaVersionTable)
+	for _, migration := range migrations {
+		fmt.Fprintf(&buf, "INSERT INTO schema_version (version) VALUES (%d) ON CONFLICT DO NOTHING;\n", migration.Version)
+	}
+	return buf.String()
+}
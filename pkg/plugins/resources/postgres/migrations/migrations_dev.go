@@ -0,0 +1,17 @@
+// +build dev
+
+package migrations
+
+import (
+	"net/http"
+	"path/filepath"
+	"runtime"
+)
+
+var Migrations http.FileSystem = http.Dir(MigrationsDir(srcDir()))
+
+func srcDir() string {
+	_, thisFile, _, _ := runtime.Caller(1)
+
+	return filepath.Dir(thisFile)
+}
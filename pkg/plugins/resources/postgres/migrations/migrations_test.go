@@ -0,0 +1,58 @@
+package migrations_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/plugins/resources/postgres/migrations"
+)
+
+var _ = Describe("Migrations", func() {
+
+	It("should load embedded migrations", func() {
+		// when
+		all, err := migrations.All()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(all).ToNot(BeEmpty())
+		Expect(all[0].Version).To(Equal(1))
+		Expect(all[0].Name).To(Equal("create_resources"))
+		Expect(all[0].Up).To(ContainSubstring("CREATE TABLE IF NOT EXISTS resources"))
+		Expect(all[0].Down).To(ContainSubstring("DROP TABLE IF EXISTS resources"))
+	})
+
+	DescribeTable("should reject invalid migrations",
+		func(dir string, expectedErr string) {
+			// when
+			_, err := migrations.Load(http.Dir(filepath.Join("testdata", dir)))
+
+			// then
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("missing down file", "missing-down", "migration 1 has to have both up and down files"),
+		Entry("gap between versions", "gap", "migration 2 is missing"),
+		Entry("invalid file name", "invalid-name", `invalid name of a migration file "/create.sql"`),
+	)
+
+	It("should render migrations as a single script", func() {
+		// given
+		all := []migrations.Migration{
+			{Version: 1, Name: "first", Up: "CREATE TABLE a (id integer);", Down: "DROP TABLE a;"},
+			{Version: 2, Name: "second", Up: "CREATE TABLE b (id integer);", Down: "DROP TABLE b;"},
+		}
+
+		// when
+		script := migrations.Script(all)
+
+		// then
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "script.golden.sql"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(script).To(Equal(string(expected)))
+	})
+})
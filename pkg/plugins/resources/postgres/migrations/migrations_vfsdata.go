@@ -2,7 +2,7 @@
 
 // +build !dev
 
-package postgres
+package migrations
 
 import (
 	"bytes"
@@ -16,23 +16,29 @@ import (
 	"time"
 )
 
-// Schema statically implements the virtual filesystem provided to vfsgen.
-var Schema = func() http.FileSystem {
+// Migrations statically implements the virtual filesystem provided to vfsgen.
+var Migrations = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2019, 9, 20, 15, 29, 39, 301325344, time.UTC),
+			modTime: time.Date(2026, 10, 18, 22, 33, 53, 856396497, time.UTC),
 		},
-		"/resource.sql": &vfsgen۰CompressedFileInfo{
-			name:             "resource.sql",
-			modTime:          time.Date(2019, 9, 20, 15, 29, 39, 301364769, time.UTC),
-			uncompressedSize: 299,
+		"/0001_create_resources.down.sql": &vfsgen۰FileInfo{
+			name:    "0001_create_resources.down.sql",
+			modTime: time.Date(2026, 10, 18, 22, 33, 53, 860992773, time.UTC),
+			content: []byte("\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x73\x6f\x75\x72\x63\x65\x73\x3b\x0a"),
+		},
+		"/0001_create_resources.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "0001_create_resources.up.sql",
+			modTime:          time.Date(2026, 10, 18, 22, 33, 53, 856396497, time.UTC),
+			uncompressedSize: 300,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8e\x4f\x0b\x82\x40\x10\x47\xef\x7e\x8a\xdf\x51\x61\x0f\x76\xee\x64\xb1\x81\x64\x16\xba\x41\x1e\x97\x65\x48\x0f\xea\xb2\xb3\x49\x7d\xfb\x68\xfb\x07\x1d\xc2\x39\x0e\x6f\xde\xbc\x75\x25\x33\x25\xa1\xb2\x55\x21\x91\x6f\x50\xee\x15\xe4\x29\xaf\x55\x0d\x47\x3c\x5e\x9c\x21\x46\x1c\x01\xc0\xa0\x7b\xc2\x6b\x26\xed\x4c\xab\x5d\xbc\x48\xd3\x24\xdc\x94\xc7\xa2\x10\x1f\x8c\xad\x36\xf4\x1f\xeb\x89\xdb\x19\x36\x7f\xb3\x73\x9e\x4e\xe4\xb8\x1b\x87\x80\x75\x83\xa7\x33\xb9\x1f\x82\x2d\x99\xb7\xc8\xd3\xd5\x3f\xb7\x87\x2a\xdf\x65\x55\x83\xad\x6c\x10\x3f\xca\xc5\xb7\x5f\x84\x46\x11\x12\x92\x28\x59\xde\x03\x00\x00\xff\xff\x88\x1c\x8d\x52\x2b\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\x4f\x0b\x82\x40\x10\xc5\xef\x7e\x8a\x77\x54\xd8\x83\x9d\x3b\x59\x6c\x20\x99\x85\x6e\x90\xc7\x65\x19\xd2\x83\xba\xec\x6c\x92\xdf\x3e\xb4\x7f\xd0\x21\x9c\xd3\x1c\x7e\xef\xcd\x6f\xb6\x85\x4c\x94\x84\x4a\x36\x99\x44\xba\x43\x7e\x54\x90\x97\xb4\x54\x25\x1c\x71\x7f\x73\x86\x18\x61\x00\x00\x9d\x6e\x09\xaf\x19\xb4\x33\xb5\x76\xe1\x2a\x8e\xa3\x39\x93\x9f\xb3\x4c\x7c\x30\xb6\xda\xd0\x7f\xac\x25\xae\x17\xb4\xf9\xd1\x2e\x39\x3a\x90\xe3\xa6\xef\xa6\x15\x4d\xe7\xe9\x4a\xee\x87\x60\x4b\xe6\x5d\xe4\xe9\xee\x9f\xb9\x53\x91\x1e\x92\xa2\xc2\x5e\x56\x08\xa7\x07\xc5\xd7\x5f\xcc\x8e\x02\x7e\xb4\x14\x05\xd1\x3a\x78\x0c\x00\x9d\x80\xe6\xd1\x2c\x01\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
-		fs["/resource.sql"].(os.FileInfo),
+		fs["/0001_create_resources.down.sql"].(os.FileInfo),
+		fs["/0001_create_resources.up.sql"].(os.FileInfo),
 	}
 
 	return fs
@@ -58,6 +64,11 @@ func (fs vfsgen۰FS) Open(path string) (http.File, error) {
 			vfsgen۰CompressedFileInfo: f,
 			gr:                        gr,
 		}, nil
+	case *vfsgen۰FileInfo:
+		return &vfsgen۰File{
+			vfsgen۰FileInfo: f,
+			Reader:          bytes.NewReader(f.content),
+		}, nil
 	case *vfsgen۰DirInfo:
 		return &vfsgen۰Dir{
 			vfsgen۰DirInfo: f,
@@ -139,6 +150,37 @@ func (f *vfsgen۰CompressedFile) Close() error {
 	return f.gr.Close()
 }
 
+// vfsgen۰FileInfo is a static definition of an uncompressed file (because it's not worth gzip compressing).
+type vfsgen۰FileInfo struct {
+	name    string
+	modTime time.Time
+	content []byte
+}
+
+func (f *vfsgen۰FileInfo) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, fmt.Errorf("cannot Readdir from file %s", f.name)
+}
+func (f *vfsgen۰FileInfo) Stat() (os.FileInfo, error) { return f, nil }
+
+func (f *vfsgen۰FileInfo) NotWorthGzipCompressing() {}
+
+func (f *vfsgen۰FileInfo) Name() string       { return f.name }
+func (f *vfsgen۰FileInfo) Size() int64        { return int64(len(f.content)) }
+func (f *vfsgen۰FileInfo) Mode() os.FileMode  { return 0444 }
+func (f *vfsgen۰FileInfo) ModTime() time.Time { return f.modTime }
+func (f *vfsgen۰FileInfo) IsDir() bool        { return false }
+func (f *vfsgen۰FileInfo) Sys() interface{}   { return nil }
+
+// vfsgen۰File is an opened file instance.
+type vfsgen۰File struct {
+	*vfsgen۰FileInfo
+	*bytes.Reader
+}
+
+func (f *vfsgen۰File) Close() error {
+	return nil
+}
+
 // vfsgen۰DirInfo is a static definition of a directory.
 type vfsgen۰DirInfo struct {
 	name    string
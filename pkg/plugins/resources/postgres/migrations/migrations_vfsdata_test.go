@@ -0,0 +1,28 @@
+// +build !dev
+
+package migrations_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/plugins/resources/postgres/migrations"
+)
+
+var _ = Describe("Migration files", func() {
+
+	It("generated Go code must be in sync with the original migration files", func() {
+		// given compiled files
+		expected, err := migrations.Load(migrations.Migrations)
+		Expect(err).ToNot(HaveOccurred())
+
+		// and actual files
+		actual, err := migrations.Load(http.Dir(migrations.MigrationsDir(".")))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then both are identical
+		Expect(actual).To(Equal(expected), "generated Go code is no longer in sync with the original migration files. To re-generate it, run `make generate/kumactl/install/control-plane`")
+	})
+})
@@ -0,0 +1,223 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+const createSchemaVersionTable = `CREATE TABLE IF NOT EXISTS schema_version (
+    version     integer NOT NULL,
+    applied_at  timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (version)
+)`
+
+// lockId identifies an advisory lock that prevents concurrent migrations, e.g. by many instances of the Control Plane.
+const lockId = 1804170101
+
+// MigrationStatus tells whether a migration was applied to the database.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator returns a Migrator that takes ownership of a given connection to the database.
+func NewMigrator(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// LatestVersion is a version of the schema expected by this binary.
+func (m *Migrator) LatestVersion() int {
+	return len(m.migrations)
+}
+
+// CurrentVersion returns the version of the schema in the database.
+// A database without the schema of Kuma has version 0.
+func (m *Migrator) CurrentVersion() (int, error) {
+	var version int
+	err := m.inTransaction(func(tx *sql.Tx) error {
+		current, err := currentVersion(tx)
+		version = current
+		return err
+	})
+	return version, err
+}
+
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied := map[int]time.Time{}
+	err := m.inTransaction(func(tx *sql.Tx) error {
+		rows, err := tx.Query("SELECT version, applied_at FROM schema_version")
+		if err != nil {
+			return errors.Wrap(err, "could not read the schema version")
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var version int
+			var appliedAt time.Time
+			if err := rows.Scan(&version, &appliedAt); err != nil {
+				return err
+			}
+			applied[version] = appliedAt
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	var statuses []MigrationStatus
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Up applies all pending migrations and returns them.
+func (m *Migrator) Up() ([]Migration, error) {
+	var applied []Migration
+	err := m.inTransaction(func(tx *sql.Tx) error {
+		version, err := currentVersion(tx)
+		if err != nil {
+			return err
+		}
+		if version > m.LatestVersion() {
+			return newerSchemaError(version, m.LatestVersion())
+		}
+		for _, migration := range m.migrations[version:] {
+			if _, err := tx.Exec(migration.Up); err != nil {
+				return errors.Wrapf(err, "could not apply migration %d %q", migration.Version, migration.Name)
+			}
+			if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES ($1)", migration.Version); err != nil {
+				return errors.Wrapf(err, "could not record migration %d %q", migration.Version, migration.Name)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// Down reverts given number of the most recent migrations and returns them.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.inTransaction(func(tx *sql.Tx) error {
+		version, err := currentVersion(tx)
+		if err != nil {
+			return err
+		}
+		if version > m.LatestVersion() {
+			return newerSchemaError(version, m.LatestVersion())
+		}
+		for ; steps > 0 && version > 0; steps, version = steps-1, version-1 {
+			migration := m.migrations[version-1]
+			if _, err := tx.Exec(migration.Down); err != nil {
+				return errors.Wrapf(err, "could not revert migration %d %q", migration.Version, migration.Name)
+			}
+			if _, err := tx.Exec("DELETE FROM schema_version WHERE version = $1", migration.Version); err != nil {
+				return errors.Wrapf(err, "could not record migration %d %q", migration.Version, migration.Name)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// Check verifies that the schema in the database is exactly the one expected by this binary.
+func (m *Migrator) Check() error {
+	version, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	switch {
+	case version < m.LatestVersion():
+		return errors.Errorf("database schema is outdated: current version is %d, required version is %d. Run `kuma-cp migrate up` or enable auto migration (KUMA_STORE_POSTGRES_AUTO_MIGRATE)", version, m.LatestVersion())
+	case version > m.LatestVersion():
+		return newerSchemaError(version, m.LatestVersion())
+	}
+	return nil
+}
+
+func newerSchemaError(current, latest int) error {
+	return errors.Errorf("database schema is newer than supported: current version is %d, the latest known version is %d. Upgrade Kuma or run `kuma-cp migrate down` of the newer version", current, latest)
+}
+
+func (m *Migrator) inTransaction(fn func(tx *sql.Tx) error) (errs error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "could not begin a transaction")
+	}
+	defer func() {
+		if errs != nil {
+			errs = multierr.Append(errs, tx.Rollback())
+		}
+	}()
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", lockId); err != nil {
+		return errors.Wrap(err, "could not acquire a lock")
+	}
+	if _, err := tx.Exec(createSchemaVersionTable); err != nil {
+		return errors.Wrap(err, "could not create the schema_version table")
+	}
+	if err := adoptLegacySchema(tx); err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// adoptLegacySchema records the initial migration as applied to a schema that was created
+// before versioned migrations were introduced, i.e. the one with the resources table but without any version.
+func adoptLegacySchema(tx *sql.Tx) error {
+	version, err := currentVersion(tx)
+	if err != nil || version > 0 {
+		return err
+	}
+	var exists bool
+	if err := tx.QueryRow("SELECT to_regclass('resources') IS NOT NULL").Scan(&exists); err != nil {
+		return errors.Wrap(err, "could not check the legacy schema")
+	}
+	if !exists {
+		return nil
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (1)"); err != nil {
+		return errors.Wrap(err, "could not adopt the legacy schema")
+	}
+	return nil
+}
+
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func currentVersion(q queryRower) (int, error) {
+	var version int
+	if err := q.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+		return 0, errors.Wrap(err, "could not read the schema version")
+	}
+	return version, nil
+}
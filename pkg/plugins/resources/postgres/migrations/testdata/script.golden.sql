@@ -0,0 +1,11 @@
+-- 0001_first
+CREATE TABLE a (id integer);
+-- 0002_second
+CREATE TABLE b (id integer);
+CREATE TABLE IF NOT EXISTS schema_version (
+    version     integer NOT NULL,
+    applied_at  timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (version)
+);
+INSERT INTO schema_version (version) VALUES (1) ON CONFLICT DO NOTHING;
+INSERT INTO schema_version (version) VALUES (2) ON CONFLICT DO NOTHING;
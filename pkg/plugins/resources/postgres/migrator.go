@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	config "github.com/Kong/kuma/pkg/config/plugins/resources/postgres"
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/plugins/resources/postgres/migrations"
+)
+
+var log = core.Log.WithName("postgres")
+
+// NewMigrator connects to the database and returns a Migrator of its schema.
+// The caller is responsible for closing the Migrator.
+func NewMigrator(cfg config.PostgresStoreConfig) (*migrations.Migrator, error) {
+	all, err := migrations.All()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load schema migrations")
+	}
+	db, err := connectToDb(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return migrations.NewMigrator(db, all), nil
+}
+
+// ensureSchema applies pending migrations when auto migration is enabled.
+// Otherwise it refuses to work with a schema different than the one expected by this version of Kuma.
+func ensureSchema(cfg config.PostgresStoreConfig) (errs error) {
+	migrator, err := NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := migrator.Close(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}()
+	if !cfg.AutoMigrate {
+		return migrator.Check()
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		return errors.Wrap(err, "could not migrate the database schema")
+	}
+	for _, migration := range applied {
+		log.Info("applied schema migration", "version", migration.Version, "name", migration.Name)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// +build integration
+
+package postgres
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/config"
+	"github.com/Kong/kuma/pkg/config/plugins/resources/postgres"
+	"github.com/Kong/kuma/pkg/plugins/resources/postgres/migrations"
+)
+
+var _ = Describe("Migrator", func() {
+
+	var cfg postgres.PostgresStoreConfig
+	var migrator *migrations.Migrator
+
+	BeforeEach(func() {
+		err := config.Load("", &cfg)
+		Expect(err).ToNot(HaveOccurred())
+
+		dbName, err := createRandomDb(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		cfg.DbName = dbName
+
+		migrator, err = NewMigrator(cfg)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(migrator.Close()).To(Succeed())
+	})
+
+	tableExists := func(name string) bool {
+		db, err := connectToDb(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		defer db.Close()
+		var exists bool
+		err = db.QueryRow("SELECT to_regclass($1) IS NOT NULL", name).Scan(&exists)
+		Expect(err).ToNot(HaveOccurred())
+		return exists
+	}
+
+	It("should report an empty database as outdated", func() {
+		// when
+		version, err := migrator.CurrentVersion()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal(0))
+		Expect(migrator.Check()).To(MatchError(ContainSubstring("database schema is outdated")))
+	})
+
+	It("should apply and revert migrations", func() {
+		// when
+		applied, err := migrator.Up()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applied).To(HaveLen(migrator.LatestVersion()))
+		Expect(migrator.Check()).To(Succeed())
+		Expect(tableExists("resources")).To(BeTrue())
+
+		// and
+		statuses, err := migrator.Status()
+		Expect(err).ToNot(HaveOccurred())
+		for _, status := range statuses {
+			Expect(status.AppliedAt).ToNot(BeNil())
+		}
+
+		// when applied again
+		applied, err = migrator.Up()
+
+		// then nothing changes
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applied).To(BeEmpty())
+
+		// when
+		reverted, err := migrator.Down(migrator.LatestVersion())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reverted).To(HaveLen(migrator.LatestVersion()))
+		Expect(tableExists("resources")).To(BeFalse())
+		version, err := migrator.CurrentVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal(0))
+	})
+
+	It("should adopt a schema installed before migrations were introduced", func() {
+		// given
+		db, err := connectToDb(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		defer db.Close()
+		all, err := migrations.All()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = db.Exec(all[0].Up)
+		Expect(err).ToNot(HaveOccurred())
+
+		// expect
+		Expect(migrator.Check()).To(Succeed())
+		version, err := migrator.CurrentVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal(1))
+
+		// when
+		applied, err := migrator.Up()
+
+		// then the initial migration is not applied again
+		Expect(err).ToNot(HaveOccurred())
+		for _, migration := range applied {
+			Expect(migration.Version).ToNot(Equal(1))
+		}
+	})
+
+	It("should accept a schema installed by kumactl", func() {
+		// given
+		db, err := connectToDb(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		defer db.Close()
+		all, err := migrations.All()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = db.Exec(migrations.Script(all))
+		Expect(err).ToNot(HaveOccurred())
+
+		// expect
+		Expect(migrator.Check()).To(Succeed())
+	})
+
+	It("should refuse to work with a newer schema", func() {
+		// given
+		_, err := migrator.Up()
+		Expect(err).ToNot(HaveOccurred())
+		db, err := connectToDb(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		defer db.Close()
+		_, err = db.Exec("INSERT INTO schema_version (version) VALUES ($1)", migrator.LatestVersion()+1)
+		Expect(err).ToNot(HaveOccurred())
+
+		// expect
+		Expect(migrator.Check()).To(MatchError(ContainSubstring("database schema is newer than supported")))
+		_, err = migrator.Up()
+		Expect(err).To(MatchError(ContainSubstring("database schema is newer than supported")))
+	})
+})
+
@@ -20,5 +20,8 @@ func (p *plugin) NewResourceStore(pc core_plugins.PluginContext, config core_plu
 	if !ok {
 		return nil, errors.New("invalid type of the config. Passed config should be a PostgresStoreConfig")
 	}
+	if err := ensureSchema(*cfg); err != nil {
+		return nil, err
+	}
 	return NewStore(*cfg)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/Kong/kuma/pkg/util/proto"
 	_ "github.com/lib/pq"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 )
 
 const duplicateKeyErrorMsg = "duplicate key value violates unique constraint"
@@ -21,7 +22,19 @@ type postgresResourceStore struct {
 	db *sql.DB
 }
 
-var _ store.ResourceStore = &postgresResourceStore{}
+var _ store.TransactionalResourceStore = &postgresResourceStore{}
+
+// querier is a common subset of sql.DB and sql.Tx
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// transactionKey marks a context of a transaction in progress on a given store.
+type transactionKey struct {
+	store *postgresResourceStore
+}
 
 func NewStore(config config.PostgresStoreConfig) (store.ResourceStore, error) {
 	db, err := connectToDb(config)
@@ -71,7 +84,7 @@ func postgresMode(mode config.TLSMode) (string, error) {
 	}
 }
 
-func (r *postgresResourceStore) Create(_ context.Context, resource model.Resource, fs ...store.CreateOptionsFunc) error {
+func (r *postgresResourceStore) Create(ctx context.Context, resource model.Resource, fs ...store.CreateOptionsFunc) error {
 	opts := store.NewCreateOptions(fs...)
 
 	bytes, err := proto.ToJSON(resource.GetSpec())
@@ -81,7 +94,7 @@ func (r *postgresResourceStore) Create(_ context.Context, resource model.Resourc
 
 	version := 0
 	statement := `INSERT INTO resources VALUES ($1, $2, $3, $4, $5, $6);`
-	_, err = r.db.Exec(statement, opts.Name, "", opts.Mesh, resource.GetType(), version, string(bytes)) // todo(jakubdyszkiewicz) solve db migration
+	_, err = r.querier(ctx).Exec(statement, opts.Name, "", opts.Mesh, resource.GetType(), version, string(bytes)) // todo(jakubdyszkiewicz) solve db migration
 	if err != nil {
 		if strings.Contains(err.Error(), duplicateKeyErrorMsg) {
 			return store.ErrorResourceAlreadyExists(resource.GetType(), opts.Name, opts.Mesh)
@@ -97,7 +110,7 @@ func (r *postgresResourceStore) Create(_ context.Context, resource model.Resourc
 	return nil
 }
 
-func (r *postgresResourceStore) Update(_ context.Context, resource model.Resource, fs ...store.UpdateOptionsFunc) error {
+func (r *postgresResourceStore) Update(ctx context.Context, resource model.Resource, fs ...store.UpdateOptionsFunc) error {
 	bytes, err := proto.ToJSON(resource.GetSpec())
 	if err != nil {
 		return err
@@ -108,7 +121,7 @@ func (r *postgresResourceStore) Update(_ context.Context, resource model.Resourc
 		return errors.Wrap(err, "failed to convert meta version to int")
 	}
 	statement := `UPDATE resources SET spec=$1, version=$2 WHERE name=$3 AND mesh=$4 AND type=$5 AND version=$6;`
-	result, err := r.db.Exec(
+	result, err := r.querier(ctx).Exec(
 		statement,
 		string(bytes),
 		version+1,
@@ -128,17 +141,20 @@ func (r *postgresResourceStore) Update(_ context.Context, resource model.Resourc
 	resource.SetMeta(&resourceMetaObject{
 		Name:    resource.GetMeta().GetName(),
 		Mesh:    resource.GetMeta().GetMesh(),
-		Version: strconv.Itoa(version),
+		Version: strconv.Itoa(version + 1),
 	})
 
 	return nil
 }
 
-func (r *postgresResourceStore) Delete(_ context.Context, resource model.Resource, fs ...store.DeleteOptionsFunc) error {
+func (r *postgresResourceStore) Delete(ctx context.Context, resource model.Resource, fs ...store.DeleteOptionsFunc) error {
 	opts := store.NewDeleteOptions(fs...)
 
+	if opts.Version != "" {
+		return r.deleteVersion(ctx, resource, opts)
+	}
 	statement := `DELETE FROM resources WHERE name=$1 AND type=$2 AND mesh=$3`
-	result, err := r.db.Exec(statement, opts.Name, resource.GetType(), opts.Mesh)
+	result, err := r.querier(ctx).Exec(statement, opts.Name, resource.GetType(), opts.Mesh)
 	if err != nil {
 		return errors.Wrapf(err, "failed to execute query: %s", statement)
 	}
@@ -149,11 +165,31 @@ func (r *postgresResourceStore) Delete(_ context.Context, resource model.Resourc
 	return nil
 }
 
-func (r *postgresResourceStore) Get(_ context.Context, resource model.Resource, fs ...store.GetOptionsFunc) error {
+// deleteVersion deletes a resource only if its version matches, so the precondition is checked by the same statement.
+func (r *postgresResourceStore) deleteVersion(ctx context.Context, resource model.Resource, opts *store.DeleteOptions) error {
+	version, err := strconv.Atoi(opts.Version)
+	if err != nil {
+		return store.ErrorResourcePreconditionFailed(resource.GetType(), opts.Name, opts.Mesh)
+	}
+	statement := `DELETE FROM resources WHERE name=$1 AND type=$2 AND mesh=$3 AND version=$4`
+	result, err := r.querier(ctx).Exec(statement, opts.Name, resource.GetType(), opts.Mesh, version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute query: %s", statement)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 { // error ignored, postgres supports RowsAffected()
+		if err := r.Get(ctx, resource, store.GetByKey(opts.Name, opts.Mesh)); err != nil {
+			return err
+		}
+		return store.ErrorResourcePreconditionFailed(resource.GetType(), opts.Name, opts.Mesh)
+	}
+	return nil
+}
+
+func (r *postgresResourceStore) Get(ctx context.Context, resource model.Resource, fs ...store.GetOptionsFunc) error {
 	opts := store.NewGetOptions(fs...)
 
 	statement := `SELECT spec, version FROM resources WHERE name=$1 AND mesh=$2 AND type=$3;`
-	row := r.db.QueryRow(statement, opts.Name, opts.Mesh, resource.GetType())
+	row := r.querier(ctx).QueryRow(statement, opts.Name, opts.Mesh, resource.GetType())
 
 	var spec string
 	var version int
@@ -182,7 +218,7 @@ func (r *postgresResourceStore) Get(_ context.Context, resource model.Resource,
 	return nil
 }
 
-func (r *postgresResourceStore) List(_ context.Context, resources model.ResourceList, args ...store.ListOptionsFunc) error {
+func (r *postgresResourceStore) List(ctx context.Context, resources model.ResourceList, args ...store.ListOptionsFunc) error {
 	opts := store.NewListOptions(args...)
 
 	statement := `SELECT name, mesh, spec, version FROM resources WHERE type=$1`
@@ -194,7 +230,7 @@ func (r *postgresResourceStore) List(_ context.Context, resources model.Resource
 		statement += fmt.Sprintf(" AND mesh=$%d", argsIndex)
 		statementArgs = append(statementArgs, opts.Mesh)
 	}
-	rows, err := r.db.Query(statement, statementArgs...)
+	rows, err := r.querier(ctx).Query(statement, statementArgs...)
 	if err != nil {
 		return errors.Wrapf(err, "failed to execute query: %s", statement)
 	}
@@ -234,6 +270,34 @@ func rowToItem(resources model.ResourceList, rows *sql.Rows) (model.Resource, er
 	return item, nil
 }
 
+func (r *postgresResourceStore) Transactional(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{store: r}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin a transaction")
+	}
+	if err := fn(context.WithValue(ctx, transactionKey{store: r}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return multierr.Append(err, errors.Wrap(rollbackErr, "failed to rollback a transaction"))
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit a transaction")
+	}
+	return nil
+}
+
+// querier returns a transaction in progress or the database otherwise.
+func (r *postgresResourceStore) querier(ctx context.Context) querier {
+	if tx, ok := ctx.Value(transactionKey{store: r}).(*sql.Tx); ok {
+		return tx
+	}
+	return r.db
+}
+
 func (r *postgresResourceStore) Close() error {
 	return r.db.Close()
 }
@@ -34,6 +34,7 @@ var _ = Describe("postgresResourceStore", func() {
 	}
 
 	test_store.ExecuteStoreTests(createStore)
+	test_store.ExecuteTransactionalStoreTests(createStore)
 })
 
 func createRandomDb(cfg postgres.PostgresStoreConfig) (string, error) {
@@ -53,26 +54,12 @@ func createRandomDb(cfg postgres.PostgresStoreConfig) (string, error) {
 }
 
 func prepareDb(cfg postgres.PostgresStoreConfig) error {
-	db, err := connectToDb(cfg)
+	migrator, err := NewMigrator(cfg)
 	if err != nil {
 		return err
 	}
-	statement := ` 
-			CREATE TABLE IF NOT EXISTS resources (
-			   name        varchar(100) NOT NULL,
-			   namespace   varchar(100) NOT NULL,
-			   mesh        varchar(100) NOT NULL,
-			   type        varchar(100) NOT NULL,
-			   version     integer NOT NULL,
-			   spec        text,
-			   PRIMARY KEY (name, namespace, mesh, type)
-			);
-			DELETE FROM resources;
-		`
-	_, err = db.Exec(statement)
-	if err != nil {
+	if _, err := migrator.Up(); err != nil {
 		return err
 	}
-	err = db.Close()
-	return err
+	return migrator.Close()
 }
@@ -11,6 +11,7 @@ import (
 	util_http "github.com/Kong/kuma/pkg/util/http"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/pkg/errors"
 )
@@ -22,13 +23,109 @@ func NewStore(client util_http.Client, api rest.Api) store.ResourceStore {
 	}
 }
 
-var _ store.ResourceStore = &remoteStore{}
+var _ store.TransactionalResourceStore = &remoteStore{}
 
 type remoteStore struct {
 	client util_http.Client
 	api    rest.Api
 }
 
+const batchPath = "/batch"
+
+// transactionKey marks a context of a transaction in progress on a given store.
+type transactionKey struct {
+	store *remoteStore
+}
+
+// remoteTransaction collects changes that are sent to the API Server in a single batch.
+type remoteTransaction struct {
+	items     []*rest.Resource
+	resources []model.Resource
+	dryRun    bool
+}
+
+func (t *remoteTransaction) add(res model.Resource, meta rest.ResourceMeta, dryRun bool) error {
+	if len(t.items) > 0 && t.dryRun != dryRun {
+		return errors.New("dry run and regular changes cannot be mixed in a single transaction")
+	}
+	t.dryRun = dryRun
+	t.items = append(t.items, &rest.Resource{
+		Meta: meta,
+		Spec: res.GetSpec(),
+	})
+	t.resources = append(t.resources, res)
+	res.SetMeta(remoteMeta{
+		Name:    meta.Name,
+		Mesh:    meta.Mesh,
+		Version: "",
+	})
+	return nil
+}
+
+// Transactional collects all changes made with a context passed to fn and applies them
+// in a single batch request once fn succeeds. Reads are not part of the transaction.
+func (s *remoteStore) Transactional(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transaction(ctx) != nil {
+		return fn(ctx)
+	}
+	tx := &remoteTransaction{}
+	if err := fn(context.WithValue(ctx, transactionKey{store: s}, tx)); err != nil {
+		return err
+	}
+	if len(tx.items) == 0 {
+		return nil
+	}
+	return s.applyBatch(ctx, tx)
+}
+
+func (s *remoteStore) transaction(ctx context.Context) *remoteTransaction {
+	tx, _ := ctx.Value(transactionKey{store: s}).(*remoteTransaction)
+	return tx
+}
+
+func (s *remoteStore) applyBatch(ctx context.Context, tx *remoteTransaction) error {
+	b, err := json.Marshal(&rest.ResourceList{Items: tx.items})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", batchPath, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	if tx.dryRun {
+		query := req.URL.Query()
+		query.Set("dryRun", "true")
+		req.URL.RawQuery = query.Encode()
+	}
+	req.Header.Set("content-type", "application/json")
+	statusCode, b, err := s.doRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return errors.Errorf("(%d): %s", statusCode, string(b))
+	}
+	// API Server responds with resources the way they were persisted
+	list := struct {
+		Items []json.RawMessage `json:"items"`
+	}{}
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	if len(list.Items) != len(tx.resources) {
+		return errors.Errorf("expected %d resources in the response, got %d", len(tx.resources), len(list.Items))
+	}
+	for i, item := range list.Items {
+		restRes := rest.Resource{
+			Spec: tx.resources[i].GetSpec(),
+		}
+		if err := json.Unmarshal(item, &restRes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *remoteStore) Create(ctx context.Context, res model.Resource, fs ...store.CreateOptionsFunc) error {
 	opts := store.NewCreateOptions(fs...)
 	meta := rest.ResourceMeta{
@@ -36,29 +133,36 @@ func (s *remoteStore) Create(ctx context.Context, res model.Resource, fs ...stor
 		Name: opts.Name,
 		Mesh: opts.Mesh,
 	}
-	if err := s.upsert(ctx, res, meta); err != nil {
+	if err := s.upsert(ctx, res, meta, opts.DryRun); err != nil {
 		return err
 	}
 	return nil
 }
 func (s *remoteStore) Update(ctx context.Context, res model.Resource, fs ...store.UpdateOptionsFunc) error {
-	_ = store.NewUpdateOptions(fs...)
+	opts := store.NewUpdateOptions(fs...)
 	meta := rest.ResourceMeta{
-		Type: string(res.GetType()),
-		Name: res.GetMeta().GetName(),
-		Mesh: res.GetMeta().GetMesh(),
+		Type:    string(res.GetType()),
+		Name:    res.GetMeta().GetName(),
+		Mesh:    res.GetMeta().GetMesh(),
+		Version: res.GetMeta().GetVersion(),
 	}
-	if err := s.upsert(ctx, res, meta); err != nil {
+	if err := s.upsert(ctx, res, meta, opts.DryRun); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *remoteStore) upsert(ctx context.Context, res model.Resource, meta rest.ResourceMeta) error {
+func (s *remoteStore) upsert(ctx context.Context, res model.Resource, meta rest.ResourceMeta, dryRun bool) error {
+	if tx := s.transaction(ctx); tx != nil {
+		return tx.add(res, meta, dryRun)
+	}
 	resourceApi, err := s.api.GetResourceApi(res.GetType())
 	if err != nil {
 		return errors.Wrapf(err, "failed to construct URI to update a %q", res.GetType())
 	}
+	// version is sent as a precondition in If-Match header
+	version := meta.Version
+	meta.Version = ""
 	restRes := rest.Resource{
 		Meta: meta,
 		Spec: res.GetSpec(),
@@ -71,14 +175,34 @@ func (s *remoteStore) upsert(ctx context.Context, res model.Resource, meta rest.
 	if err != nil {
 		return err
 	}
+	if dryRun {
+		query := req.URL.Query()
+		query.Set("dryRun", "true")
+		req.URL.RawQuery = query.Encode()
+	}
 	req.Header.Set("content-type", "application/json")
+	if version != "" {
+		req.Header.Set("If-Match", strconv.Quote(version))
+	}
 	statusCode, b, err := s.doRequest(ctx, req)
 	if err != nil {
+		if concurrencyErr := concurrencyError(statusCode, res.GetType(), meta.Name, meta.Mesh); concurrencyErr != nil {
+			return concurrencyErr
+		}
 		return err
 	}
 	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
 		return errors.Errorf("(%d): %s", statusCode, string(b))
 	}
+	if dryRun {
+		// API Server responds with a resource the way it would have been persisted
+		restRes := rest.Resource{
+			Spec: res.GetSpec(),
+		}
+		if err := json.Unmarshal(b, &restRes); err != nil {
+			return err
+		}
+	}
 	res.SetMeta(remoteMeta{
 		Name:    meta.Name,
 		Mesh:    meta.Mesh,
@@ -87,6 +211,9 @@ func (s *remoteStore) upsert(ctx context.Context, res model.Resource, meta rest.
 	return nil
 }
 func (s *remoteStore) Delete(ctx context.Context, res model.Resource, fs ...store.DeleteOptionsFunc) error {
+	if s.transaction(ctx) != nil {
+		return errors.New("deletion of resources is not supported in a transaction")
+	}
 	opts := store.NewDeleteOptions(fs...)
 	resourceApi, err := s.api.GetResourceApi(res.GetType())
 	if err != nil {
@@ -96,11 +223,17 @@ func (s *remoteStore) Delete(ctx context.Context, res model.Resource, fs ...stor
 	if err != nil {
 		return err
 	}
+	if opts.Version != "" {
+		req.Header.Set("If-Match", strconv.Quote(opts.Version))
+	}
 	statusCode, b, err := s.doRequest(ctx, req)
 	if err != nil {
 		if statusCode == 404 {
 			return store.ErrorResourceNotFound(res.GetType(), opts.Name, opts.Mesh)
 		}
+		if concurrencyErr := concurrencyError(statusCode, res.GetType(), opts.Name, opts.Mesh); concurrencyErr != nil {
+			return concurrencyErr
+		}
 		return err
 	}
 	if statusCode != http.StatusOK {
@@ -128,7 +261,13 @@ func (s *remoteStore) Get(ctx context.Context, res model.Resource, fs ...store.G
 	if statusCode != 200 {
 		return errors.Errorf("(%d): %s", statusCode, string(b))
 	}
-	return Unmarshal(b, res)
+	if err := Unmarshal(b, res); err != nil {
+		return err
+	}
+	if opts.Version != "" && res.GetMeta().GetVersion() != opts.Version {
+		return store.ErrorResourcePreconditionFailed(res.GetType(), opts.Name, opts.Mesh)
+	}
+	return nil
 }
 
 func (s *remoteStore) List(ctx context.Context, rs model.ResourceList, fs ...store.ListOptionsFunc) error {
@@ -151,6 +290,18 @@ func (s *remoteStore) List(ctx context.Context, rs model.ResourceList, fs ...sto
 	return UnmarshalList(b, rs)
 }
 
+// concurrencyError translates responses of API Server about a resource modified concurrently.
+func concurrencyError(statusCode int, resType model.ResourceType, name, mesh string) error {
+	switch statusCode {
+	case http.StatusConflict:
+		return store.ErrorResourceConflict(resType, name, mesh)
+	case http.StatusPreconditionFailed:
+		return store.ErrorResourcePreconditionFailed(resType, name, mesh)
+	default:
+		return nil
+	}
+}
+
 // execute a request. Returns status code, body, error
 func (s *remoteStore) doRequest(ctx context.Context, req *http.Request) (int, []byte, error) {
 	req.Header.Set("Accept", "application/json")
@@ -3,6 +3,7 @@ package remote_test
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -17,6 +18,7 @@ import (
 	"github.com/Kong/kuma/pkg/test/resources/model"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
@@ -91,6 +93,19 @@ var _ = Describe("RemoteStore", func() {
 
 			Expect(resource.GetMeta().GetName()).To(Equal("res-1"))
 			Expect(resource.GetMeta().GetMesh()).To(Equal("default"))
+			Expect(resource.GetMeta().GetVersion()).To(Equal("3"))
+		})
+
+		It("should return an error when the resource is of a different version", func() {
+			// setup
+			store := setupStore("get.json", func(req *http.Request) {})
+
+			// when
+			resource := sample_core.TrafficRouteResource{}
+			err := store.Get(context.Background(), &resource, core_store.GetByKey("res-1", "default"), core_store.GetByVersion("2"))
+
+			// then
+			Expect(err).To(MatchError(core_store.ErrorResourcePreconditionFailed(resource.GetType(), "res-1", "default")))
 		})
 
 		It("should get mesh resource", func() {
@@ -173,6 +188,27 @@ var _ = Describe("RemoteStore", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should request a dry run and read back the resource", func() {
+			// setup
+			name := "res-1"
+			store := setupStore("create_dry_run.json", func(req *http.Request) {
+				Expect(req.URL.Path).To(Equal(fmt.Sprintf("/meshes/default/traffic-routes/%s", name)))
+				Expect(req.URL.Query().Get("dryRun")).To(Equal("true"))
+			})
+
+			// when
+			resource := sample_core.TrafficRouteResource{
+				Spec: sample_api.TrafficRoute{
+					Path: "/some-path",
+				},
+			}
+			err := store.Create(context.Background(), &resource, core_store.CreateByKey(name, "default"), core_store.CreateWithDryRun(true))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal("/defaulted-path"))
+		})
+
 		It("should send proper mesh json", func() {
 			// setup
 			meshName := "someMesh"
@@ -253,6 +289,54 @@ var _ = Describe("RemoteStore", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should send a version as a precondition in If-Match header", func() {
+			// setup
+			store := setupStore("create_update.json", func(req *http.Request) {
+				Expect(req.Header.Get("If-Match")).To(Equal(`"3"`))
+				bytes, err := ioutil.ReadAll(req.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(bytes)).To(Equal(`{"mesh":"default","name":"res-1","path":"/some-path","type":"SampleTrafficRoute"}`))
+			})
+
+			// when
+			resource := sample_core.TrafficRouteResource{
+				Spec: sample_api.TrafficRoute{
+					Path: "/some-path",
+				},
+				Meta: &model.ResourceMeta{
+					Mesh:    "default",
+					Name:    "res-1",
+					Version: "3",
+				},
+			}
+			err := store.Update(context.Background(), &resource)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		DescribeTable("should map responses about concurrent modifications",
+			func(statusCode int, expectedErr func(core_model.ResourceType, string, string) error) {
+				// given
+				store := setupErrorStore(statusCode, `{"title": "Could not update a resource", "details": "Conflict"}`)
+
+				// when
+				resource := sample_core.TrafficRouteResource{
+					Meta: &model.ResourceMeta{
+						Mesh:    "default",
+						Name:    "res-1",
+						Version: "3",
+					},
+				}
+				err := store.Update(context.Background(), &resource)
+
+				// then
+				Expect(err).To(MatchError(expectedErr(resource.GetType(), "res-1", "default")))
+			},
+			Entry("409", 409, core_store.ErrorResourceConflict),
+			Entry("412", 412, core_store.ErrorResourcePreconditionFailed),
+		)
+
 		It("should send proper mesh json", func() {
 			// setup
 			meshName := "someMesh"
@@ -450,6 +534,32 @@ var _ = Describe("RemoteStore", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should send a version as a precondition in If-Match header", func() {
+			// given
+			store := setupStore("delete.json", func(req *http.Request) {
+				Expect(req.Header.Get("If-Match")).To(Equal(`"3"`))
+			})
+
+			// when
+			resource := sample_core.TrafficRouteResource{}
+			err := store.Delete(context.Background(), &resource, core_store.DeleteByKey("tr-1", "mesh-1"), core_store.DeleteByVersion("3"))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should map 412 error to ResourcePreconditionFailed", func() {
+			// given
+			store := setupErrorStore(412, `{"title": "Could not delete a resource", "details": "Precondition Failed"}`)
+
+			// when
+			resource := sample_core.TrafficRouteResource{}
+			err := store.Delete(context.Background(), &resource, core_store.DeleteByKey("tr-1", "mesh-1"), core_store.DeleteByVersion("3"))
+
+			// then
+			Expect(core_store.IsResourcePreconditionFailed(err)).To(BeTrue())
+		})
+
 		It("should delete mesh resource", func() {
 			// given
 			meshName := "mesh-1"
@@ -516,6 +626,80 @@ var _ = Describe("RemoteStore", func() {
 		})
 	})
 
+	Describe("Transactional()", func() {
+		It("should send all changes in a single batch", func() {
+			// setup
+			requests := 0
+			store := setupStore("batch.json", func(req *http.Request) {
+				requests++
+				Expect(req.Method).To(Equal("POST"))
+				Expect(req.URL.Path).To(Equal("/batch"))
+				Expect(req.URL.Query().Get("dryRun")).To(Equal("true"))
+				bytes, err := ioutil.ReadAll(req.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(bytes).To(MatchJSON(`
+				{
+					"items": [
+						{"type": "Mesh", "mesh": "demo", "name": "demo"},
+						{"type": "SampleTrafficRoute", "mesh": "demo", "name": "res-1", "path": "/some-path"}
+					]
+				}`))
+			})
+			meshRes := mesh.MeshResource{}
+			route := sample_core.TrafficRouteResource{
+				Spec: sample_api.TrafficRoute{
+					Path: "/some-path",
+				},
+			}
+
+			// when
+			err := store.(core_store.TransactionalResourceStore).Transactional(context.Background(), func(ctx context.Context) error {
+				if err := store.Create(ctx, &meshRes, core_store.CreateByKey("demo", "demo"), core_store.CreateWithDryRun(true)); err != nil {
+					return err
+				}
+				return store.Create(ctx, &route, core_store.CreateByKey("res-1", "demo"), core_store.CreateWithDryRun(true))
+			})
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(1))
+			// and
+			Expect(route.Spec.Path).To(Equal("/defaulted-path"))
+		})
+
+		It("should not send anything when function fails", func() {
+			// setup
+			store := setupStore("batch.json", func(req *http.Request) {
+				Fail("no request expected")
+			})
+
+			// when
+			err := store.(core_store.TransactionalResourceStore).Transactional(context.Background(), func(ctx context.Context) error {
+				if err := store.Create(ctx, &mesh.MeshResource{}, core_store.CreateByKey("demo", "demo")); err != nil {
+					return err
+				}
+				return errors.New("failed")
+			})
+
+			// then
+			Expect(err).To(MatchError("failed"))
+		})
+
+		It("should not allow deletion in a transaction", func() {
+			// setup
+			store := setupStore("delete.json", func(req *http.Request) {
+				Fail("no request expected")
+			})
+
+			// when
+			err := store.(core_store.TransactionalResourceStore).Transactional(context.Background(), func(ctx context.Context) error {
+				return store.Delete(ctx, &sample_core.TrafficRouteResource{}, core_store.DeleteByKey("res-1", "demo"))
+			})
+
+			// then
+			Expect(err).To(MatchError("deletion of resources is not supported in a transaction"))
+		})
+	})
 })
 
 type RoundTripperFunc func(*http.Request) (*http.Response, error)
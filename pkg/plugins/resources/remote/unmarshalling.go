@@ -32,7 +32,7 @@ func Unmarshal(b []byte, res model.Resource) error {
 	res.SetMeta(remoteMeta{
 		Name:    restResource.Meta.Name,
 		Mesh:    restResource.Meta.Mesh,
-		Version: "",
+		Version: restResource.Meta.Version,
 	})
 	return nil
 }
@@ -52,7 +52,7 @@ func UnmarshalList(b []byte, rs model.ResourceList) error {
 		r.SetMeta(&remoteMeta{
 			Name:    ri.Meta.Name,
 			Mesh:    ri.Meta.Mesh,
-			Version: "",
+			Version: ri.Meta.Version,
 		})
 		_ = rs.AddItem(r)
 	}
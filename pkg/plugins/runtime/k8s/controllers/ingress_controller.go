@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
+	k8s_metadata "github.com/Kong/kuma/pkg/plugins/runtime/k8s/metadata"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_networking "k8s.io/api/networking/v1beta1"
+	kube_apierrs "k8s.io/apimachinery/pkg/api/errors"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	kube_record "k8s.io/client-go/tools/record"
+	kube_ctrl "sigs.k8s.io/controller-runtime"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_controllerutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	kube_handler "sigs.k8s.io/controller-runtime/pkg/handler"
+	kube_reconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+	kube_source "sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ManagedByLabel marks ProxyTemplates generated by the Ingress controller.
+	ManagedByLabel             = "kuma.io/managed-by"
+	ManagedByIngressController = "ingress-controller"
+)
+
+// IngressReconciler translates Ingresses of `kuma` class into ProxyTemplates of gateway Dataplanes.
+//
+// Since all Ingresses served by the same gateway are merged into a single ProxyTemplate,
+// a change to any Ingress triggers re-generation of all ProxyTemplates.
+type IngressReconciler struct {
+	kube_client.Client
+	Log      logr.Logger
+	Recorder kube_record.EventRecorder
+
+	SystemNamespace string
+	GatewayPorts    GatewayPorts
+}
+
+type gatewayKey struct {
+	mesh    string
+	service string
+}
+
+func (r *IngressReconciler) Reconcile(req kube_ctrl.Request) (kube_ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("ingress", req.NamespacedName)
+
+	ingresses := &kube_networking.IngressList{}
+	if err := r.List(ctx, ingresses); err != nil {
+		log.Error(err, "unable to list Ingresses")
+		return kube_ctrl.Result{}, err
+	}
+	byGateway := map[gatewayKey][]*kube_networking.Ingress{}
+	for i := range ingresses.Items {
+		ingress := &ingresses.Items[i]
+		key, ok := gatewayKeyFor(ingress)
+		if !ok {
+			continue
+		}
+		byGateway[key] = append(byGateway[key], ingress)
+	}
+
+	var errs error
+	desired := map[string]bool{}
+	for key, ingresses := range byGateway {
+		name := ProxyTemplateNameFor(key.mesh, key.service)
+		desired[name] = true
+		sort.Slice(ingresses, func(i, j int) bool {
+			return ingresses[i].Namespace+"/"+ingresses[i].Name < ingresses[j].Namespace+"/"+ingresses[j].Name
+		})
+		if err := r.createOrUpdateProxyTemplate(ctx, log, name, key, ingresses); err != nil {
+			log.Error(err, "unable to create/update ProxyTemplate", "name", name)
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	// delete ProxyTemplates of gateways that no longer have Ingresses
+	templates := &mesh_k8s.ProxyTemplateList{}
+	if err := r.List(ctx, templates, kube_client.InNamespace(r.SystemNamespace), kube_client.MatchingLabels{ManagedByLabel: ManagedByIngressController}); err != nil {
+		log.Error(err, "unable to list ProxyTemplates")
+		return kube_ctrl.Result{}, multierr.Append(errs, err)
+	}
+	for i := range templates.Items {
+		template := &templates.Items[i]
+		if desired[template.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, template); err != nil && !kube_apierrs.IsNotFound(err) {
+			log.Error(err, "unable to delete ProxyTemplate", "name", template.Name)
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return kube_ctrl.Result{}, errs
+}
+
+func (r *IngressReconciler) createOrUpdateProxyTemplate(ctx context.Context, log logr.Logger, name string, key gatewayKey, ingresses []*kube_networking.Ingress) error {
+	proxyTemplate, warnings, err := IngressesToProxyTemplate(key.service, ingresses, r.GatewayPorts, r.Client)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		log.Info("part of Ingress has been left out of ProxyTemplate", "name", name,
+			"ingress", kube_types.NamespacedName{Namespace: warning.Ingress.Namespace, Name: warning.Ingress.Name}, "reason", warning.Reason, "message", warning.Message)
+		r.Recorder.Event(warning.Ingress, kube_core.EventTypeWarning, warning.Reason, warning.Message)
+	}
+	spec, err := util_proto.ToMap(proxyTemplate)
+	if err != nil {
+		return err
+	}
+	template := &mesh_k8s.ProxyTemplate{
+		ObjectMeta: kube_meta.ObjectMeta{
+			Namespace: r.SystemNamespace,
+			Name:      name,
+		},
+	}
+	_, err = kube_controllerutil.CreateOrUpdate(ctx, r.Client, template, func() error {
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[ManagedByLabel] = ManagedByIngressController
+		template.Mesh = key.mesh
+		template.Spec = spec
+		return nil
+	})
+	return err
+}
+
+func gatewayKeyFor(ingress *kube_networking.Ingress) (gatewayKey, bool) {
+	mesh, service, ok := k8s_metadata.IngressGateway(ingress)
+	return gatewayKey{mesh: mesh, service: service}, ok
+}
+
+func (r *IngressReconciler) SetupWithManager(mgr kube_ctrl.Manager) error {
+	if err := kube_core.AddToScheme(mgr.GetScheme()); err != nil {
+		return errors.Wrapf(err, "could not add %q to scheme", kube_core.SchemeGroupVersion)
+	}
+	if err := kube_networking.AddToScheme(mgr.GetScheme()); err != nil {
+		return errors.Wrapf(err, "could not add %q to scheme", kube_networking.SchemeGroupVersion)
+	}
+	if err := mesh_k8s.AddToScheme(mgr.GetScheme()); err != nil {
+		return errors.Wrapf(err, "could not add %q to scheme", mesh_k8s.GroupVersion)
+	}
+	// TLS Secrets are not watched since gateway Dataplanes fetch certificates through SDS
+	return kube_ctrl.NewControllerManagedBy(mgr).
+		For(&kube_networking.Ingress{}).
+		// on Service update reconcile Ingresses that refer to it (named ports of backends are resolved through Services)
+		Watches(&kube_source.Kind{Type: &kube_core.Service{}}, &kube_handler.EnqueueRequestsFromMapFunc{
+			ToRequests: &ServiceToIngressesMapper{Client: mgr.GetClient(), Log: r.Log.WithName("service-to-ingresses-mapper")},
+		}).
+		Complete(r)
+}
+
+type ServiceToIngressesMapper struct {
+	kube_client.Client
+	Log logr.Logger
+}
+
+func (m *ServiceToIngressesMapper) Map(obj kube_handler.MapObject) []kube_reconcile.Request {
+	// Ingresses can refer only to Services in the same namespace
+	ingresses := &kube_networking.IngressList{}
+	if err := m.Client.List(context.Background(), ingresses, kube_client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		m.Log.WithValues("service", obj.Meta).Error(err, "failed to fetch Ingresses")
+		return nil
+	}
+
+	var req []kube_reconcile.Request
+	for _, ingress := range ingresses.Items {
+		if _, ok := gatewayKeyFor(&ingress); !ok {
+			continue
+		}
+		if !refersToService(&ingress, obj.Meta.GetName()) {
+			continue
+		}
+		req = append(req, kube_reconcile.Request{
+			NamespacedName: kube_types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name},
+		})
+	}
+	return req
+}
+
+func refersToService(ingress *kube_networking.Ingress, service string) bool {
+	if ingress.Spec.Backend != nil && ingress.Spec.Backend.ServiceName == service {
+		return true
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.ServiceName == service {
+				return true
+			}
+		}
+	}
+	return false
+}
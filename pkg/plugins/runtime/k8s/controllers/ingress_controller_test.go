@@ -0,0 +1,168 @@
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Kong/kuma/pkg/plugins/runtime/k8s/controllers"
+
+	"github.com/Kong/kuma/pkg/core"
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_networking "k8s.io/api/networking/v1beta1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_runtime "k8s.io/apimachinery/pkg/runtime"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	kube_intstr "k8s.io/apimachinery/pkg/util/intstr"
+	kube_record "k8s.io/client-go/tools/record"
+	kube_ctrl "sigs.k8s.io/controller-runtime"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_client_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	kube_handler "sigs.k8s.io/controller-runtime/pkg/handler"
+	kube_reconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("IngressReconciler", func() {
+
+	var kubeClient kube_client.Client
+	var reconciler kube_reconcile.Reconciler
+
+	ingress := func(name string, annotations map[string]string) *kube_networking.Ingress {
+		return &kube_networking.Ingress{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace:   "kuma-demo",
+				Name:        name,
+				Annotations: annotations,
+			},
+			Spec: kube_networking.IngressSpec{
+				Backend: &kube_networking.IngressBackend{
+					ServiceName: name,
+					ServicePort: kube_intstr.FromInt(80),
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := kube_runtime.NewScheme()
+		Expect(kube_core.AddToScheme(scheme)).To(Succeed())
+		Expect(kube_networking.AddToScheme(scheme)).To(Succeed())
+		Expect(mesh_k8s.AddToScheme(scheme)).To(Succeed())
+
+		kubeClient = kube_client_fake.NewFakeClientWithScheme(
+			scheme,
+			ingress("frontend", map[string]string{
+				"kubernetes.io/ingress.class": "kuma",
+				"kuma.io/gateway-service":     "kuma-gateway.kuma-gateway.svc:80",
+				"kuma.io/mesh":                "demo",
+			}),
+			ingress("other-class", map[string]string{
+				"kubernetes.io/ingress.class": "nginx",
+				"kuma.io/gateway-service":     "kuma-gateway.kuma-gateway.svc:80",
+			}),
+			ingress("no-gateway", map[string]string{
+				"kubernetes.io/ingress.class": "kuma",
+			}),
+		)
+
+		reconciler = &IngressReconciler{
+			Client:          kubeClient,
+			Log:             core.Log.WithName("test"),
+			Recorder:        kube_record.NewFakeRecorder(10),
+			SystemNamespace: "kuma-system",
+			GatewayPorts:    GatewayPorts{HTTP: 8080, HTTPS: 8443},
+		}
+	})
+
+	listProxyTemplates := func() []mesh_k8s.ProxyTemplate {
+		templates := &mesh_k8s.ProxyTemplateList{}
+		Expect(kubeClient.List(context.Background(), templates)).To(Succeed())
+		return templates.Items
+	}
+
+	It("should generate a ProxyTemplate for a gateway of Ingresses of `kuma` class", func() {
+		// given
+		req := kube_ctrl.Request{
+			NamespacedName: kube_types.NamespacedName{Namespace: "kuma-demo", Name: "frontend"},
+		}
+
+		// when
+		result, err := reconciler.Reconcile(req)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		// when
+		templates := listProxyTemplates()
+		// then
+		Expect(templates).To(HaveLen(1))
+		Expect(templates[0].Namespace).To(Equal("kuma-system"))
+		Expect(templates[0].Name).To(Equal("ingress-demo-kuma-gateway-kuma-gateway-svc-80"))
+		Expect(templates[0].Labels).To(Equal(map[string]string{"kuma.io/managed-by": "ingress-controller"}))
+		Expect(templates[0].Mesh).To(Equal("demo"))
+		Expect(templates[0].Spec).To(HaveKeyWithValue("selectors", ConsistOf(map[string]interface{}{
+			"match": map[string]interface{}{
+				"service": "kuma-gateway.kuma-gateway.svc:80",
+			},
+		})))
+	})
+
+	It("should delete a ProxyTemplate of a gateway without Ingresses", func() {
+		// given
+		req := kube_ctrl.Request{
+			NamespacedName: kube_types.NamespacedName{Namespace: "kuma-demo", Name: "frontend"},
+		}
+		_, err := reconciler.Reconcile(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(listProxyTemplates()).To(HaveLen(1))
+
+		// when
+		Expect(kubeClient.Delete(context.Background(), ingress("frontend", nil))).To(Succeed())
+		_, err = reconciler.Reconcile(req)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(listProxyTemplates()).To(BeEmpty())
+	})
+
+	Describe("ServiceToIngressesMapper", func() {
+		It("should map a Service to Ingresses of `kuma` class that refer to it", func() {
+			// given
+			mapper := &ServiceToIngressesMapper{Client: kubeClient, Log: core.Log.WithName("test")}
+			service := &kube_core.Service{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "frontend",
+				},
+			}
+
+			// when
+			requests := mapper.Map(kube_handler.MapObject{Meta: service, Object: service})
+
+			// then
+			Expect(requests).To(Equal([]kube_reconcile.Request{{
+				NamespacedName: kube_types.NamespacedName{Namespace: "kuma-demo", Name: "frontend"},
+			}}))
+		})
+
+		It("should not map a Service no Ingress refers to", func() {
+			// given
+			mapper := &ServiceToIngressesMapper{Client: kubeClient, Log: core.Log.WithName("test")}
+			service := &kube_core.Service{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "other-class",
+				},
+			}
+
+			// when
+			requests := mapper.Map(kube_handler.MapObject{Meta: service, Object: service})
+
+			// then
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
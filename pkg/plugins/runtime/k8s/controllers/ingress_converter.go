@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	gateway_sds_provider "github.com/Kong/kuma/pkg/sds/provider/gateway"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	xds_envoy "github.com/Kong/kuma/pkg/xds/envoy"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_networking "k8s.io/api/networking/v1beta1"
+	kube_intstr "k8s.io/apimachinery/pkg/util/intstr"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	gatewayHttpListenerName  = "kuma:gateway:http"
+	gatewayHttpsListenerName = "kuma:gateway:https"
+	gatewayCatchAllDomain    = "*"
+
+	// IngressInvalidBackendReason is a reason of an Event about a backend of an Ingress that has been skipped.
+	IngressInvalidBackendReason = "InvalidBackend"
+	// IngressConflictingDefaultBackendReason is a reason of an Event about a default backend of an Ingress
+	// that has been ignored in favour of a default backend of another Ingress served by the same gateway.
+	IngressConflictingDefaultBackendReason = "ConflictingDefaultBackend"
+	// IngressConflictingTLSHostReason is a reason of an Event about a TLS host of an Ingress that has been ignored
+	// since another TLS Secret is already served for the same host by the same gateway.
+	IngressConflictingTLSHostReason = "ConflictingTLSHost"
+	// IngressInvalidTLSHostReason is a reason of an Event about a TLS host of an Ingress that has been skipped.
+	IngressInvalidTLSHostReason = "InvalidTLSHost"
+)
+
+// GatewayPorts are ports gateway Dataplanes accept external traffic on.
+type GatewayPorts struct {
+	HTTP  uint32
+	HTTPS uint32
+}
+
+// IngressWarning describes a part of an Ingress that has been left out of a ProxyTemplate.
+type IngressWarning struct {
+	Ingress *kube_networking.Ingress
+	Reason  string
+	Message string
+}
+
+// IngressesToProxyTemplate translates Ingresses served by the same gateway into a ProxyTemplate.
+// Rules of Ingresses become routes of HTTP (and HTTPS if Ingresses reference TLS Secrets) listeners
+// that forward requests to the clusters of mesh services.
+//
+// Backends that cannot be translated don't prevent other Ingresses from being served,
+// they are skipped and returned as warnings instead.
+// TLS certificates are referenced by SDS resource names, so ProxyTemplates never contain private keys.
+func IngressesToProxyTemplate(gatewayService string, ingresses []*kube_networking.Ingress, ports GatewayPorts, reader kube_client.Reader) (*mesh_proto.ProxyTemplate, []IngressWarning, error) {
+	virtualHosts, warnings := gatewayVirtualHostsFor(ingresses, reader)
+	certificates, certificateWarnings := gatewayCertificatesFor(ingresses)
+	warnings = append(warnings, certificateWarnings...)
+
+	httpListener, err := rawGatewayListener(gatewayHttpListenerName, ports.HTTP, virtualHosts, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &mesh_proto.ProxyTemplate{
+		Selectors: []*mesh_proto.Selector{{
+			Match: map[string]string{
+				mesh_proto.ServiceTag: gatewayService,
+			},
+		}},
+		Conf: &mesh_proto.ProxyTemplate_Conf{
+			Imports:   []string{mesh_core.ProfileDefaultProxy},
+			Resources: []*mesh_proto.ProxyTemplateRawResource{httpListener},
+		},
+	}
+	if len(certificates) > 0 {
+		httpsListener, err := rawGatewayListener(gatewayHttpsListenerName, ports.HTTPS, virtualHosts, certificates)
+		if err != nil {
+			return nil, nil, err
+		}
+		template.Conf.Resources = append(template.Conf.Resources, httpsListener)
+	}
+	return template, warnings, nil
+}
+
+func rawGatewayListener(name string, port uint32, virtualHosts []xds_envoy.GatewayVirtualHost, certificates []xds_envoy.GatewayCertificate) (*mesh_proto.ProxyTemplateRawResource, error) {
+	listener := xds_envoy.CreateGatewayListener(name, "0.0.0.0", port, virtualHosts, certificates)
+	any, err := ptypes.MarshalAny(listener)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := util_proto.ToYAML(any)
+	if err != nil {
+		return nil, err
+	}
+	return &mesh_proto.ProxyTemplateRawResource{
+		Name:     name,
+		Version:  "1",
+		Resource: string(resource),
+	}, nil
+}
+
+func gatewayVirtualHostsFor(ingresses []*kube_networking.Ingress, reader kube_client.Reader) ([]xds_envoy.GatewayVirtualHost, []IngressWarning) {
+	var warnings []IngressWarning
+	routesByHost := map[string][]xds_envoy.GatewayRoute{}
+	var defaultRoute *xds_envoy.GatewayRoute
+	var defaultRouteIngress *kube_networking.Ingress
+	for _, ingress := range ingresses {
+		for i, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			host := rule.Host
+			if host == "" {
+				host = gatewayCatchAllDomain
+			}
+			for j, path := range rule.HTTP.Paths {
+				cluster, err := clusterFor(ingress.Namespace, path.Backend, reader)
+				if err != nil {
+					warnings = append(warnings, IngressWarning{
+						Ingress: ingress,
+						Reason:  IngressInvalidBackendReason,
+						Message: fmt.Sprintf("spec.rules[%d].http.paths[%d].backend has been skipped: %s", i, j, err),
+					})
+					continue
+				}
+				prefix := path.Path
+				if prefix == "" {
+					prefix = "/"
+				}
+				routesByHost[host] = append(routesByHost[host], xds_envoy.GatewayRoute{Prefix: prefix, Cluster: cluster})
+			}
+		}
+		if ingress.Spec.Backend != nil {
+			if defaultRouteIngress != nil {
+				warnings = append(warnings, IngressWarning{
+					Ingress: ingress,
+					Reason:  IngressConflictingDefaultBackendReason,
+					Message: fmt.Sprintf("spec.backend has been ignored: gateway already uses a default backend of Ingress %q in namespace %q", defaultRouteIngress.Name, defaultRouteIngress.Namespace),
+				})
+				continue
+			}
+			cluster, err := clusterFor(ingress.Namespace, *ingress.Spec.Backend, reader)
+			if err != nil {
+				warnings = append(warnings, IngressWarning{
+					Ingress: ingress,
+					Reason:  IngressInvalidBackendReason,
+					Message: fmt.Sprintf("spec.backend has been skipped: %s", err),
+				})
+				continue
+			}
+			defaultRoute = &xds_envoy.GatewayRoute{Prefix: "/", Cluster: cluster}
+			defaultRouteIngress = ingress
+		}
+	}
+
+	hosts := make([]string, 0, len(routesByHost))
+	for host := range routesByHost {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var virtualHosts []xds_envoy.GatewayVirtualHost
+	for _, host := range hosts {
+		routes := routesByHost[host]
+		// Envoy picks the first matching route, so the longest prefix has to go first
+		sort.SliceStable(routes, func(i, j int) bool {
+			return len(routes[i].Prefix) > len(routes[j].Prefix)
+		})
+		if host == gatewayCatchAllDomain && defaultRoute != nil {
+			routes = append(routes, *defaultRoute)
+			defaultRoute = nil
+		}
+		virtualHosts = append(virtualHosts, xds_envoy.GatewayVirtualHost{
+			Name:    host,
+			Domains: []string{host},
+			Routes:  routes,
+		})
+	}
+	if defaultRoute != nil {
+		// Ingress without rules for all hosts
+		virtualHosts = append(virtualHosts, xds_envoy.GatewayVirtualHost{
+			Name:    gatewayCatchAllDomain,
+			Domains: []string{gatewayCatchAllDomain},
+			Routes:  []xds_envoy.GatewayRoute{*defaultRoute},
+		})
+	}
+	return virtualHosts, warnings
+}
+
+// gatewayCertificatesFor returns certificates of TLS Secrets referenced by Ingresses.
+// Certificates are served to gateway Dataplanes by SDS server of Control Plane.
+//
+// Envoy rejects a listener with several filter chains for the same server name,
+// so every host (including a TLS section without hosts, which serves all of them) gets a certificate
+// of the first TLS Secret only, the other ones are reported as warnings.
+func gatewayCertificatesFor(ingresses []*kube_networking.Ingress) ([]xds_envoy.GatewayCertificate, []IngressWarning) {
+	var certificates []xds_envoy.GatewayCertificate
+	var warnings []IngressWarning
+	owners := map[string]*kube_networking.Ingress{}
+	claim := func(ingress *kube_networking.Ingress, i int, host string) bool {
+		if owner, taken := owners[host]; taken {
+			warnings = append(warnings, IngressWarning{
+				Ingress: ingress,
+				Reason:  IngressConflictingTLSHostReason,
+				Message: fmt.Sprintf("spec.tls[%d] has been ignored for %s: gateway already uses a TLS Secret of Ingress %q in namespace %q", i, describeTLSHost(host), owner.Name, owner.Namespace),
+			})
+			return false
+		}
+		owners[host] = ingress
+		return true
+	}
+	for _, ingress := range ingresses {
+		for i, tls := range ingress.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+			var serverNames []string
+			if len(tls.Hosts) == 0 {
+				if !claim(ingress, i, "") {
+					continue
+				}
+			} else {
+				for _, host := range tls.Hosts {
+					if host == "" {
+						warnings = append(warnings, IngressWarning{
+							Ingress: ingress,
+							Reason:  IngressInvalidTLSHostReason,
+							Message: fmt.Sprintf("spec.tls[%d] has been ignored for an empty host: omit hosts to serve the TLS Secret for all of them", i),
+						})
+						continue
+					}
+					if claim(ingress, i, host) {
+						serverNames = append(serverNames, host)
+					}
+				}
+				if len(serverNames) == 0 {
+					continue
+				}
+			}
+			certificates = append(certificates, xds_envoy.GatewayCertificate{
+				ServerNames: serverNames,
+				SecretName:  gateway_sds_provider.ResourceName(ingress.Namespace, tls.SecretName),
+			})
+		}
+	}
+	return certificates, warnings
+}
+
+func describeTLSHost(host string) string {
+	if host == "" {
+		return "all hosts"
+	}
+	return fmt.Sprintf("host %q", host)
+}
+
+// clusterFor returns a name of the cluster of a mesh service, which is a value of its `service` tag.
+func clusterFor(namespace string, backend kube_networking.IngressBackend, reader kube_client.Reader) (string, error) {
+	port := backend.ServicePort.IntVal
+	if backend.ServicePort.Type == kube_intstr.String {
+		svc := &kube_core.Service{}
+		if err := reader.Get(context.Background(), kube_client.ObjectKey{Namespace: namespace, Name: backend.ServiceName}, svc); err != nil {
+			return "", errors.Wrapf(err, "could not get Service %q", backend.ServiceName)
+		}
+		port = 0
+		for _, svcPort := range svc.Spec.Ports {
+			if svcPort.Name == backend.ServicePort.StrVal {
+				port = svcPort.Port
+			}
+		}
+		if port == 0 {
+			return "", errors.Errorf("Service %q has no port %q", backend.ServiceName, backend.ServicePort.StrVal)
+		}
+	}
+	return fmt.Sprintf("%s.%s.svc:%d", backend.ServiceName, namespace, port), nil
+}
+
+// ProxyTemplateNameFor returns a name of the ProxyTemplate generated for a gateway.
+func ProxyTemplateNameFor(mesh string, gatewayService string) string {
+	name := strings.NewReplacer(".", "-", ":", "-").Replace(gatewayService)
+	return fmt.Sprintf("ingress-%s-%s", mesh, strings.ToLower(name))
+}
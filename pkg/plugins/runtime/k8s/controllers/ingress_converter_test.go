@@ -0,0 +1,273 @@
+package controllers_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Kong/kuma/pkg/plugins/runtime/k8s/controllers"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	util_envoy "github.com/Kong/kuma/pkg/util/envoy"
+
+	envoy_api "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_networking "k8s.io/api/networking/v1beta1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_runtime "k8s.io/apimachinery/pkg/runtime"
+	kube_intstr "k8s.io/apimachinery/pkg/util/intstr"
+	kube_client_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("IngressesToProxyTemplate(..)", func() {
+
+	var scheme *kube_runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = kube_runtime.NewScheme()
+		Expect(kube_core.AddToScheme(scheme)).To(Succeed())
+	})
+
+	ingresses := []*kube_networking.Ingress{
+		{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace: "kuma-demo",
+				Name:      "shop",
+			},
+			Spec: kube_networking.IngressSpec{
+				TLS: []kube_networking.IngressTLS{
+					{
+						Hosts:      []string{"shop.example.com"},
+						SecretName: "shop-tls",
+					},
+				},
+				Rules: []kube_networking.IngressRule{
+					{
+						Host: "shop.example.com",
+						IngressRuleValue: kube_networking.IngressRuleValue{
+							HTTP: &kube_networking.HTTPIngressRuleValue{
+								Paths: []kube_networking.HTTPIngressPath{
+									{
+										Path: "/",
+										Backend: kube_networking.IngressBackend{
+											ServiceName: "frontend",
+											ServicePort: kube_intstr.FromInt(80),
+										},
+									},
+									{
+										Path: "/api",
+										Backend: kube_networking.IngressBackend{
+											ServiceName: "backend",
+											ServicePort: kube_intstr.FromString("http"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace: "kuma-demo",
+				Name:      "default-backend",
+			},
+			Spec: kube_networking.IngressSpec{
+				Backend: &kube_networking.IngressBackend{
+					ServiceName: "frontend",
+					ServicePort: kube_intstr.FromInt(80),
+				},
+			},
+		},
+	}
+
+	ports := GatewayPorts{HTTP: 8080, HTTPS: 8443}
+
+	It("should translate rules of Ingresses into routes of gateway listeners", func() {
+		// given
+		reader := kube_client_fake.NewFakeClientWithScheme(scheme,
+			&kube_core.Service{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "backend",
+				},
+				Spec: kube_core.ServiceSpec{
+					Ports: []kube_core.ServicePort{
+						{Name: "http", Port: 3001},
+					},
+				},
+			},
+		)
+
+		// when
+		template, warnings, err := IngressesToProxyTemplate("kuma-gateway.kuma-gateway.svc:80", ingresses, ports, reader)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		// and
+		Expect(template.Selectors).To(Equal([]*mesh_proto.Selector{{
+			Match: map[string]string{
+				"service": "kuma-gateway.kuma-gateway.svc:80",
+			},
+		}}))
+		Expect(template.Conf.Imports).To(Equal([]string{"default-proxy"}))
+		Expect(template.Conf.Resources).To(HaveLen(2))
+		for _, resource := range template.Conf.Resources {
+			_, err := util_envoy.ResourceFromYaml(resource.Resource)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		// and
+		Expect(template.Conf.Resources[0].Name).To(Equal("kuma:gateway:http"))
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "ingress.http-listener.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(template.Conf.Resources[0].Resource).To(MatchYAML(expected))
+
+		// and
+		Expect(template.Conf.Resources[1].Name).To(Equal("kuma:gateway:https"))
+		expected, err = ioutil.ReadFile(filepath.Join("testdata", "ingress.https-listener.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(template.Conf.Resources[1].Resource).To(MatchYAML(expected))
+	})
+
+	It("should skip backends that cannot be translated and report them", func() {
+		// given Service "backend" is missing
+		reader := kube_client_fake.NewFakeClientWithScheme(scheme)
+
+		// when
+		template, warnings, err := IngressesToProxyTemplate("kuma-gateway.kuma-gateway.svc:80", ingresses, ports, reader)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].Ingress.Name).To(Equal("shop"))
+		Expect(warnings[0].Reason).To(Equal("InvalidBackend"))
+		Expect(warnings[0].Message).To(HavePrefix(`spec.rules[0].http.paths[1].backend has been skipped: could not get Service "backend"`))
+
+		// and other backends are still served
+		Expect(template.Conf.Resources).To(HaveLen(2))
+		Expect(template.Conf.Resources[0].Resource).To(ContainSubstring("frontend.kuma-demo.svc:80"))
+		Expect(template.Conf.Resources[0].Resource).ToNot(ContainSubstring("backend.kuma-demo.svc"))
+	})
+
+	It("should use the first default backend and report conflicting ones", func() {
+		// given
+		reader := kube_client_fake.NewFakeClientWithScheme(scheme)
+		conflicting := []*kube_networking.Ingress{
+			{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "first",
+				},
+				Spec: kube_networking.IngressSpec{
+					Backend: &kube_networking.IngressBackend{
+						ServiceName: "frontend",
+						ServicePort: kube_intstr.FromInt(80),
+					},
+				},
+			},
+			{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "second",
+				},
+				Spec: kube_networking.IngressSpec{
+					Backend: &kube_networking.IngressBackend{
+						ServiceName: "web",
+						ServicePort: kube_intstr.FromInt(80),
+					},
+				},
+			},
+		}
+
+		// when
+		template, warnings, err := IngressesToProxyTemplate("kuma-gateway.kuma-gateway.svc:80", conflicting, ports, reader)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(Equal([]IngressWarning{{
+			Ingress: conflicting[1],
+			Reason:  "ConflictingDefaultBackend",
+			Message: `spec.backend has been ignored: gateway already uses a default backend of Ingress "first" in namespace "kuma-demo"`,
+		}}))
+
+		// and
+		Expect(template.Conf.Resources).To(HaveLen(1))
+		Expect(template.Conf.Resources[0].Resource).To(ContainSubstring("frontend.kuma-demo.svc:80"))
+		Expect(template.Conf.Resources[0].Resource).ToNot(ContainSubstring("web.kuma-demo.svc:80"))
+	})
+
+	It("should serve a single TLS Secret per host and report conflicting ones", func() {
+		// given
+		reader := kube_client_fake.NewFakeClientWithScheme(scheme)
+		tlsIngress := func(name string, tls ...kube_networking.IngressTLS) *kube_networking.Ingress {
+			return &kube_networking.Ingress{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      name,
+				},
+				Spec: kube_networking.IngressSpec{
+					TLS: tls,
+					Backend: &kube_networking.IngressBackend{
+						ServiceName: "frontend",
+						ServicePort: kube_intstr.FromInt(80),
+					},
+				},
+			}
+		}
+		conflicting := []*kube_networking.Ingress{
+			tlsIngress("first",
+				kube_networking.IngressTLS{Hosts: []string{"shop.example.com"}, SecretName: "shop-tls"},
+				kube_networking.IngressTLS{SecretName: "default-tls"},
+			),
+			tlsIngress("second",
+				kube_networking.IngressTLS{Hosts: []string{"shop.example.com", "api.example.com", ""}, SecretName: "other-tls"},
+				kube_networking.IngressTLS{SecretName: "other-default-tls"},
+			),
+		}
+
+		// when
+		template, warnings, err := IngressesToProxyTemplate("kuma-gateway.kuma-gateway.svc:80", conflicting, ports, reader)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(Equal([]IngressWarning{
+			{
+				Ingress: conflicting[1],
+				Reason:  "ConflictingDefaultBackend",
+				Message: `spec.backend has been ignored: gateway already uses a default backend of Ingress "first" in namespace "kuma-demo"`,
+			},
+			{
+				Ingress: conflicting[1],
+				Reason:  "ConflictingTLSHost",
+				Message: `spec.tls[0] has been ignored for host "shop.example.com": gateway already uses a TLS Secret of Ingress "first" in namespace "kuma-demo"`,
+			},
+			{
+				Ingress: conflicting[1],
+				Reason:  "InvalidTLSHost",
+				Message: `spec.tls[0] has been ignored for an empty host: omit hosts to serve the TLS Secret for all of them`,
+			},
+			{
+				Ingress: conflicting[1],
+				Reason:  "ConflictingTLSHost",
+				Message: `spec.tls[1] has been ignored for all hosts: gateway already uses a TLS Secret of Ingress "first" in namespace "kuma-demo"`,
+			},
+		}))
+
+		// and every server name is matched by a single filter chain
+		Expect(template.Conf.Resources).To(HaveLen(2))
+		resource, err := util_envoy.ResourceFromYaml(template.Conf.Resources[1].Resource)
+		Expect(err).ToNot(HaveOccurred())
+		listener := resource.(*envoy_api.Listener)
+		var serverNames [][]string
+		for _, chain := range listener.FilterChains {
+			serverNames = append(serverNames, chain.FilterChainMatch.GetServerNames())
+		}
+		Expect(serverNames).To(Equal([][]string{{"shop.example.com"}, nil, {"api.example.com"}}))
+	})
+})
@@ -0,0 +1,36 @@
+package metadata
+
+import (
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+
+	kube_networking "k8s.io/api/networking/v1beta1"
+)
+
+const (
+	// IngressClassAnnotation selects a controller that serves an Ingress.
+	IngressClassAnnotation = "kubernetes.io/ingress.class"
+	// IngressClassKuma is a class of Ingresses served by Kuma gateways.
+	IngressClassKuma = "kuma"
+	// IngressGatewayServiceAnnotation defines a value of `service` tag of gateway Dataplanes that serve an Ingress,
+	// e.g. "kuma-gateway.kuma-gateway.svc:80".
+	IngressGatewayServiceAnnotation = "kuma.io/gateway-service"
+	// IngressMeshAnnotation defines a Mesh of gateway Dataplanes that serve an Ingress.
+	IngressMeshAnnotation = "kuma.io/mesh"
+)
+
+// IngressGateway returns a Mesh and a value of `service` tag of gateway Dataplanes that serve a given Ingress.
+// It returns false if the Ingress is not served by Kuma gateways.
+func IngressGateway(ingress *kube_networking.Ingress) (mesh string, service string, ok bool) {
+	if ingress.Annotations[IngressClassAnnotation] != IngressClassKuma {
+		return "", "", false
+	}
+	service = ingress.Annotations[IngressGatewayServiceAnnotation]
+	if service == "" {
+		return "", "", false
+	}
+	mesh = ingress.Annotations[IngressMeshAnnotation]
+	if mesh == "" {
+		mesh = core_model.DefaultMesh
+	}
+	return mesh, service, true
+}
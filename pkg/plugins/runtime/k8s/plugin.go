@@ -57,6 +57,9 @@ func addControllers(mgr kube_ctrl.Manager, rt core_runtime.Runtime) error {
 	if err := addNamespaceReconciler(mgr, rt); err != nil {
 		return err
 	}
+	if err := addIngressReconciler(mgr, rt); err != nil {
+		return err
+	}
 	return addMeshReconciler(mgr, rt)
 }
 
@@ -71,6 +74,20 @@ func addNamespaceReconciler(mgr kube_ctrl.Manager, rt core_runtime.Runtime) erro
 	return reconciler.SetupWithManager(mgr)
 }
 
+func addIngressReconciler(mgr kube_ctrl.Manager, rt core_runtime.Runtime) error {
+	reconciler := &k8s_controllers.IngressReconciler{
+		Client:          mgr.GetClient(),
+		Log:             core.Log.WithName("controllers").WithName("Ingress"),
+		Recorder:        mgr.GetEventRecorderFor("kuma-ingress-controller"),
+		SystemNamespace: rt.Config().Store.Kubernetes.SystemNamespace,
+		GatewayPorts: k8s_controllers.GatewayPorts{
+			HTTP:  rt.Config().Runtime.Kubernetes.IngressGateway.HttpPort,
+			HTTPS: rt.Config().Runtime.Kubernetes.IngressGateway.HttpsPort,
+		},
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
 func addMeshReconciler(mgr kube_ctrl.Manager, rt core_runtime.Runtime) error {
 	reconciler := &k8s_controllers.MeshReconciler{
 		Client:           mgr.GetClient(),
@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	k8s_metadata "github.com/Kong/kuma/pkg/plugins/runtime/k8s/metadata"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	sds_provider "github.com/Kong/kuma/pkg/sds/provider"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_networking "k8s.io/api/networking/v1beta1"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResourcePrefix is a prefix of SDS resources with TLS certificates of gateways.
+const ResourcePrefix = "gateway_cert:"
+
+// ResourceName returns a name of the SDS resource with a TLS certificate stored in a Kubernetes Secret.
+func ResourceName(namespace string, secretName string) string {
+	return ResourcePrefix + namespace + "/" + secretName
+}
+
+func parseResourceName(name string) (namespace string, secretName string, err error) {
+	parts := strings.Split(strings.TrimPrefix(name, ResourcePrefix), "/")
+	if !strings.HasPrefix(name, ResourcePrefix) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("SDS resource name %q must have format %q", name, ResourcePrefix+"<namespace>/<secret>")
+	}
+	return parts[0], parts[1], nil
+}
+
+// New returns a provider of TLS certificates that gateway Dataplanes serve to external clients.
+//
+// A gateway Dataplane gets a certificate only if the Secret is referenced by an Ingress
+// that is served by that gateway and that belongs to the same namespace as the Secret.
+// Secrets are read on demand through secretReader, so Control Plane doesn't need to list or watch them.
+func New(ingressReader kube_client.Reader, secretReader kube_client.Reader) sds_provider.SecretProvider {
+	return &gatewayCertProvider{
+		ingressReader: ingressReader,
+		secretReader:  secretReader,
+	}
+}
+
+type gatewayCertProvider struct {
+	ingressReader kube_client.Reader
+	secretReader  kube_client.Reader
+}
+
+func (p *gatewayCertProvider) RequiresIdentity() bool {
+	return true
+}
+
+func (p *gatewayCertProvider) Get(ctx context.Context, name string, requestor sds_auth.Identity) (sds_provider.Secret, error) {
+	namespace, secretName, err := parseResourceName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.authorize(ctx, namespace, secretName, requestor); err != nil {
+		return nil, err
+	}
+	secret := &kube_core.Secret{}
+	if err := p.secretReader.Get(ctx, kube_client.ObjectKey{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return nil, errors.Wrapf(err, "could not get Secret %q in namespace %q", secretName, namespace)
+	}
+	certificateChain, privateKey := secret.Data[kube_core.TLSCertKey], secret.Data[kube_core.TLSPrivateKeyKey]
+	if len(certificateChain) == 0 || len(privateKey) == 0 {
+		return nil, errors.Errorf("Secret %q in namespace %q must have both %q and %q keys", secretName, namespace, kube_core.TLSCertKey, kube_core.TLSPrivateKeyKey)
+	}
+	return &GatewayCertSecret{
+		PemCertificateChain: certificateChain,
+		PemKey:              privateKey,
+	}, nil
+}
+
+func (p *gatewayCertProvider) authorize(ctx context.Context, namespace string, secretName string, requestor sds_auth.Identity) error {
+	ingresses := &kube_networking.IngressList{}
+	if err := p.ingressReader.List(ctx, ingresses, kube_client.InNamespace(namespace)); err != nil {
+		return errors.Wrapf(err, "could not list Ingresses in namespace %q", namespace)
+	}
+	for i := range ingresses.Items {
+		ingress := &ingresses.Items[i]
+		mesh, service, ok := k8s_metadata.IngressGateway(ingress)
+		if !ok || mesh != requestor.Mesh || service != requestor.Service {
+			continue
+		}
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName == secretName {
+				return nil
+			}
+		}
+	}
+	return errors.Errorf("access denied: Secret %q in namespace %q is not referenced by Ingresses served by gateway %q of Mesh %q", secretName, namespace, requestor.Service, requestor.Mesh)
+}
@@ -0,0 +1,122 @@
+package gateway_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	sds_provider "github.com/Kong/kuma/pkg/sds/provider"
+	"github.com/Kong/kuma/pkg/sds/provider/gateway"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_networking "k8s.io/api/networking/v1beta1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_runtime "k8s.io/apimachinery/pkg/runtime"
+	kube_client_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Gateway Cert Provider", func() {
+
+	var provider sds_provider.SecretProvider
+
+	BeforeEach(func() {
+		scheme := kube_runtime.NewScheme()
+		Expect(kube_core.AddToScheme(scheme)).To(Succeed())
+		Expect(kube_networking.AddToScheme(scheme)).To(Succeed())
+
+		client := kube_client_fake.NewFakeClientWithScheme(scheme,
+			&kube_networking.Ingress{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "shop",
+					Annotations: map[string]string{
+						"kubernetes.io/ingress.class": "kuma",
+						"kuma.io/gateway-service":     "kuma-gateway.kuma-gateway.svc:80",
+						"kuma.io/mesh":                "demo",
+					},
+				},
+				Spec: kube_networking.IngressSpec{
+					TLS: []kube_networking.IngressTLS{
+						{SecretName: "shop-tls"},
+					},
+				},
+			},
+			&kube_core.Secret{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "shop-tls",
+				},
+				Data: map[string][]byte{
+					"tls.crt": []byte("CERTIFICATE"),
+					"tls.key": []byte("PRIVATE KEY"),
+				},
+			},
+			&kube_core.Secret{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "kuma-demo",
+					Name:      "db-credentials",
+				},
+				Data: map[string][]byte{
+					"tls.crt": []byte("CERTIFICATE"),
+					"tls.key": []byte("PRIVATE KEY"),
+				},
+			},
+		)
+		provider = gateway.New(client, client)
+	})
+
+	It("should return a certificate referenced by an Ingress of the gateway", func() {
+		// when
+		secret, err := provider.Get(context.Background(), "gateway_cert:kuma-demo/shop-tls", sds_auth.Identity{
+			Mesh:    "demo",
+			Service: "kuma-gateway.kuma-gateway.svc:80",
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret).To(Equal(&gateway.GatewayCertSecret{
+			PemCertificateChain: []byte("CERTIFICATE"),
+			PemKey:              []byte("PRIVATE KEY"),
+		}))
+	})
+
+	type testCase struct {
+		name      string
+		requestor sds_auth.Identity
+		expected  string
+	}
+
+	DescribeTable("should refuse to return a certificate",
+		func(given testCase) {
+			// when
+			_, err := provider.Get(context.Background(), given.name, given.requestor)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(given.expected))
+		},
+		Entry("to a Dataplane that is not a gateway of the Ingress", testCase{
+			name:      "gateway_cert:kuma-demo/shop-tls",
+			requestor: sds_auth.Identity{Mesh: "demo", Service: "backend"},
+			expected:  `access denied: Secret "shop-tls" in namespace "kuma-demo" is not referenced by Ingresses served by gateway "backend" of Mesh "demo"`,
+		}),
+		Entry("to a gateway of another Mesh", testCase{
+			name:      "gateway_cert:kuma-demo/shop-tls",
+			requestor: sds_auth.Identity{Mesh: "default", Service: "kuma-gateway.kuma-gateway.svc:80"},
+			expected:  `access denied: Secret "shop-tls" in namespace "kuma-demo" is not referenced by Ingresses served by gateway "kuma-gateway.kuma-gateway.svc:80" of Mesh "default"`,
+		}),
+		Entry("of a Secret that is not referenced by Ingresses", testCase{
+			name:      "gateway_cert:kuma-demo/db-credentials",
+			requestor: sds_auth.Identity{Mesh: "demo", Service: "kuma-gateway.kuma-gateway.svc:80"},
+			expected:  `access denied: Secret "db-credentials" in namespace "kuma-demo" is not referenced by Ingresses served by gateway "kuma-gateway.kuma-gateway.svc:80" of Mesh "demo"`,
+		}),
+		Entry("for a malformed resource name", testCase{
+			name:      "gateway_cert:shop-tls",
+			requestor: sds_auth.Identity{Mesh: "demo", Service: "kuma-gateway.kuma-gateway.svc:80"},
+			expected:  `SDS resource name "gateway_cert:shop-tls" must have format "gateway_cert:<namespace>/<secret>"`,
+		}),
+	)
+})
@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+
+	sds_provider "github.com/Kong/kuma/pkg/sds/provider"
+)
+
+type GatewayCertSecret struct {
+	PemCertificateChain []byte
+	PemKey              []byte
+}
+
+var _ sds_provider.Secret = &GatewayCertSecret{}
+
+func (s *GatewayCertSecret) ToResource(name string) *envoy_auth.Secret {
+	return &envoy_auth.Secret{
+		Name: name,
+		Type: &envoy_auth.Secret_TlsCertificate{
+			TlsCertificate: &envoy_auth.TlsCertificate{
+				CertificateChain: &envoy_core.DataSource{
+					Specifier: &envoy_core.DataSource_InlineBytes{
+						InlineBytes: s.PemCertificateChain,
+					},
+				},
+				PrivateKey: &envoy_core.DataSource{
+					Specifier: &envoy_core.DataSource_InlineBytes{
+						InlineBytes: s.PemKey,
+					},
+				},
+			},
+		},
+	}
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	config_core "github.com/Kong/kuma/pkg/config/core"
@@ -16,6 +18,7 @@ import (
 	universal_sds_auth "github.com/Kong/kuma/pkg/sds/auth/universal"
 	sds_provider "github.com/Kong/kuma/pkg/sds/provider"
 	ca_sds_provider "github.com/Kong/kuma/pkg/sds/provider/ca"
+	gateway_sds_provider "github.com/Kong/kuma/pkg/sds/provider/gateway"
 	identity_sds_provider "github.com/Kong/kuma/pkg/sds/provider/identity"
 	"github.com/Kong/kuma/pkg/tokens/builtin"
 
@@ -23,6 +26,8 @@ import (
 	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
 
 	kube_auth "k8s.io/api/authentication/v1"
+	kube_core "k8s.io/api/core/v1"
+	kube_networking "k8s.io/api/networking/v1beta1"
 )
 
 const (
@@ -82,19 +87,45 @@ func DefaultIdentityCertProvider(rt core_runtime.Runtime) sds_provider.SecretPro
 	return identity_sds_provider.New(rt.ResourceManager(), rt.BuiltinCaManager(), rt.ProvidedCaManager())
 }
 
-func DefaultSecretProviderSelector(rt core_runtime.Runtime) func(string) (sds_provider.SecretProvider, error) {
+// DefaultGatewayCertProvider returns a provider of TLS certificates referenced by Ingresses.
+// It is available only on Kubernetes, where certificates are stored in Secrets.
+func DefaultGatewayCertProvider(rt core_runtime.Runtime) (sds_provider.SecretProvider, error) {
+	if rt.Config().Environment != config_core.KubernetesEnvironment {
+		return nil, nil
+	}
+	mgr, ok := k8s_runtime.FromManagerContext(rt.Extensions())
+	if !ok {
+		return nil, errors.Errorf("k8s controller runtime Manager hasn't been configured")
+	}
+	if err := kube_core.AddToScheme(mgr.GetScheme()); err != nil {
+		return nil, errors.Wrapf(err, "could not add %q to scheme", kube_core.SchemeGroupVersion)
+	}
+	if err := kube_networking.AddToScheme(mgr.GetScheme()); err != nil {
+		return nil, errors.Wrapf(err, "could not add %q to scheme", kube_networking.SchemeGroupVersion)
+	}
+	// Secrets are read without a cache, so Control Plane doesn't need permissions to list and watch them
+	return gateway_sds_provider.New(mgr.GetClient(), mgr.GetAPIReader()), nil
+}
+
+func DefaultSecretProviderSelector(rt core_runtime.Runtime) (func(string) (sds_provider.SecretProvider, error), error) {
 	meshCaProvider := DefaultMeshCaProvider(rt)
 	identityCertProvider := DefaultIdentityCertProvider(rt)
+	gatewayCertProvider, err := DefaultGatewayCertProvider(rt)
+	if err != nil {
+		return nil, err
+	}
 	return func(resource string) (sds_provider.SecretProvider, error) {
-		switch resource {
-		case MeshCaResource:
+		switch {
+		case resource == MeshCaResource:
 			return meshCaProvider, nil
-		case IdentityCertResource:
+		case resource == IdentityCertResource:
 			return identityCertProvider, nil
+		case strings.HasPrefix(resource, gateway_sds_provider.ResourcePrefix) && gatewayCertProvider != nil:
+			return gatewayCertProvider, nil
 		default:
 			return nil, errors.Errorf("SDS request for %q resource is not supported", resource)
 		}
-	}
+	}, nil
 }
 
 func DefaultSecretDiscoveryHandler(rt core_runtime.Runtime) (SecretDiscoveryHandler, error) {
@@ -102,8 +133,11 @@ func DefaultSecretDiscoveryHandler(rt core_runtime.Runtime) (SecretDiscoveryHand
 	if err != nil {
 		return nil, err
 	}
-	secretProviderSelector := DefaultSecretProviderSelector(rt)
-	return SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
+	secretProviderSelector, err := DefaultSecretProviderSelector(rt)
+	if err != nil {
+		return nil, err
+	}
+	return NewMeteredSecretDiscoveryHandler(SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
 		resource := req.ResourceNames[0]
 		provider, err := secretProviderSelector(resource)
 		if err != nil {
@@ -129,7 +163,7 @@ func DefaultSecretDiscoveryHandler(rt core_runtime.Runtime) (SecretDiscoveryHand
 			return nil, err
 		}
 		return secret.ToResource(resource), nil
-	}), nil
+	})), nil
 }
 
 type SecretDiscoveryHandlerFunc func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error)
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+)
+
+var (
+	certsIssued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuma_cp_sds_certs_issued_total",
+		Help: "Number of secrets issued by the SDS server",
+	}, []string{"resource"})
+	certGenerationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuma_cp_sds_cert_generation_errors_total",
+		Help: "Number of failures to issue a secret by the SDS server",
+	}, []string{"resource"})
+	certGeneration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "kuma_cp_sds_cert_generation_seconds",
+		Help: "Time it takes to issue a secret by the SDS server",
+	}, []string{"resource"})
+)
+
+// NewMeteredSecretDiscoveryHandler returns a handler that exports metrics of secrets issued by a given handler.
+func NewMeteredSecretDiscoveryHandler(delegate SecretDiscoveryHandler) SecretDiscoveryHandler {
+	return SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
+		resource := req.ResourceNames[0]
+		start := time.Now()
+		secret, err := delegate.Handle(ctx, req)
+		certGeneration.WithLabelValues(resource).Observe(time.Since(start).Seconds())
+		if err != nil {
+			certGenerationErrors.WithLabelValues(resource).Inc()
+			return nil, err
+		}
+		certsIssued.WithLabelValues(resource).Inc()
+		return secret, nil
+	})
+}
@@ -0,0 +1,56 @@
+package server_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Kong/kuma/pkg/sds/server"
+)
+
+var _ = Describe("NewMeteredSecretDiscoveryHandler()", func() {
+
+	It("should count issued secrets and failures", func() {
+		// given
+		handler := NewMeteredSecretDiscoveryHandler(SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
+			if req.ResourceNames[0] == "unknown" {
+				return nil, errors.New("unsupported resource")
+			}
+			return &envoy_auth.Secret{Name: req.ResourceNames[0]}, nil
+		}))
+
+		// when
+		secret, err := handler.Handle(context.Background(), envoy.DiscoveryRequest{ResourceNames: []string{"mesh_ca"}})
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Name).To(Equal("mesh_ca"))
+
+		// when
+		_, err = handler.Handle(context.Background(), envoy.DiscoveryRequest{ResourceNames: []string{"unknown"}})
+		// then
+		Expect(err).To(MatchError("unsupported resource"))
+
+		// and
+		expected := `
+# HELP kuma_cp_sds_certs_issued_total Number of secrets issued by the SDS server
+# TYPE kuma_cp_sds_certs_issued_total counter
+kuma_cp_sds_certs_issued_total{resource="mesh_ca"} 1
+# HELP kuma_cp_sds_cert_generation_errors_total Number of failures to issue a secret by the SDS server
+# TYPE kuma_cp_sds_cert_generation_errors_total counter
+kuma_cp_sds_cert_generation_errors_total{resource="unknown"} 1
+`
+		err = testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected),
+			"kuma_cp_sds_certs_issued_total", "kuma_cp_sds_cert_generation_errors_total")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
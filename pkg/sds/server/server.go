@@ -17,6 +17,7 @@ func SetupServer(rt core_runtime.Runtime) error {
 	}
 	callbacks := util_xds.CallbacksChain{
 		util_xds.LoggingCallbacks{Log: sdsServerLog},
+		util_xds.StatsCallbacks{Server: "sds"},
 	}
 	srv := NewServer(handler, callbacks, sdsServerLog)
 	return core_runtime.Add(
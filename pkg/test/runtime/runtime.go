@@ -1,6 +1,7 @@
 package runtime
 
 import (
+	core_admin "github.com/Kong/kuma/pkg/core/admin"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
 	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
@@ -14,6 +15,7 @@ import (
 	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	bootstrap_universal "github.com/Kong/kuma/pkg/plugins/bootstrap/universal"
 	resources_memory "github.com/Kong/kuma/pkg/plugins/resources/memory"
@@ -33,7 +35,9 @@ func BuilderFor(cfg kuma_cp.Config) *core_runtime.Builder {
 	builder := core_runtime.BuilderFor(cfg).
 		WithComponentManager(bootstrap_universal.NewComponentManager()).
 		WithResourceStore(resources_memory.NewStore()).
-		WithXdsContext(core_xds.NewXdsContext())
+		WithXdsContext(core_xds.NewXdsContext()).
+		WithStatsAggregator(core_stats.NewAggregator(core_stats.DefaultStaleAfter)).
+		WithEnvoyAdminChannels(core_admin.NewEnvoyAdminChannels())
 
 	builder.
 		WithSecretManager(newSecretManager(builder)).
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"errors"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	sample_proto "github.com/Kong/kuma/pkg/test/apis/sample/v1alpha1"
 	sample_model "github.com/Kong/kuma/pkg/test/resources/apis/sample"
@@ -127,7 +128,57 @@ func ExecuteStoreTests(
 			Expect(res.Spec.Path).To(Equal("new-path"))
 		})
 
-		//todo(jakubdyszkiewicz) write tests for optimistic locking
+		It("should update a version of the resource", func() {
+			// given a resources in storage
+			name := "to-be-updated.demo"
+			resource := createResource(name)
+			versionBefore := resource.GetMeta().GetVersion()
+
+			// when
+			resource.Spec.Path = "new-path"
+			err := s.Update(context.Background(), resource)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when retrieve the resource
+			res := sample_model.TrafficRouteResource{}
+			err = s.Get(context.Background(), &res, store.GetByKey(name, mesh))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.GetMeta().GetVersion()).ToNot(Equal(versionBefore))
+			// and updated resource carries the new version
+			Expect(resource.GetMeta().GetVersion()).To(Equal(res.GetMeta().GetVersion()))
+		})
+
+		It("should not update a resource that was modified concurrently", func() {
+			// given a resources in storage
+			name := "to-be-updated.demo"
+			createResource(name)
+
+			// and two copies of the same version
+			first := sample_model.TrafficRouteResource{}
+			err := s.Get(context.Background(), &first, store.GetByKey(name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+			second := sample_model.TrafficRouteResource{}
+			err = s.Get(context.Background(), &second, store.GetByKey(name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when first copy is updated
+			first.Spec.Path = "first"
+			err = s.Update(context.Background(), &first)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when second copy is updated
+			second.Spec.Path = "second"
+			err = s.Update(context.Background(), &second)
+
+			// then
+			Expect(err).To(MatchError(store.ErrorResourceConflict(second.GetType(), name, mesh)))
+		})
 	})
 
 	Describe("Delete()", func() {
@@ -184,6 +235,59 @@ func ExecuteStoreTests(
 			// then resource cannot be found
 			Expect(err).To(Equal(store.ErrorResourceNotFound(resource.GetType(), name, mesh)))
 		})
+
+		It("should not delete a resource that was modified concurrently", func() {
+			// given a resources in storage
+			name := "to-be-deleted.demo"
+			created := createResource(name)
+			version := created.GetMeta().GetVersion()
+
+			// and the resource is modified
+			updated := sample_model.TrafficRouteResource{}
+			err := s.Get(context.Background(), &updated, store.GetByKey(name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+			updated.Spec.Path = "updated"
+			err = s.Update(context.Background(), &updated)
+			Expect(err).ToNot(HaveOccurred())
+
+			// when deleting the previous version
+			err = s.Delete(context.TODO(), &sample_model.TrafficRouteResource{}, store.DeleteByKey(name, mesh), store.DeleteByVersion(version))
+
+			// then
+			Expect(store.IsResourcePreconditionFailed(err)).To(BeTrue())
+
+			// when deleting the current version
+			err = s.Delete(context.TODO(), &sample_model.TrafficRouteResource{}, store.DeleteByKey(name, mesh), store.DeleteByVersion(updated.GetMeta().GetVersion()))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete a given version of a resource only once", func() {
+			// given a resources in storage
+			name := "to-be-deleted.demo"
+			version := createResource(name).GetMeta().GetVersion()
+
+			// when the same version is deleted concurrently
+			const attempts = 5
+			errs := make(chan error, attempts)
+			for i := 0; i < attempts; i++ {
+				go func() {
+					errs <- s.Delete(context.TODO(), &sample_model.TrafficRouteResource{}, store.DeleteByKey(name, mesh), store.DeleteByVersion(version))
+				}()
+			}
+
+			// then exactly one deletion succeeds
+			succeeded := 0
+			for i := 0; i < attempts; i++ {
+				if err := <-errs; err == nil {
+					succeeded++
+				} else {
+					Expect(store.IsResourceNotFound(err) || store.IsResourcePreconditionFailed(err)).To(BeTrue())
+				}
+			}
+			Expect(succeeded).To(Equal(1))
+		})
 	})
 
 	Describe("Get()", func() {
@@ -304,3 +408,108 @@ func ExecuteStoreTests(
 		})
 	})
 }
+
+func ExecuteTransactionalStoreTests(
+	createStore func() store.ResourceStore,
+) {
+	const mesh = "default-mesh"
+	var s store.TransactionalResourceStore
+
+	BeforeEach(func() {
+		s = store.NewStrictResourceStore(createStore()).(store.TransactionalResourceStore)
+	})
+
+	AfterEach(func() {
+		err := s.(store.ClosableResourceStore).Close()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	createResource := func(ctx context.Context, name string) error {
+		res := sample_model.TrafficRouteResource{
+			Spec: sample_proto.TrafficRoute{
+				Path: "demo",
+			},
+		}
+		return s.Create(ctx, &res, store.CreateByKey(name, mesh))
+	}
+
+	exists := func(name string) bool {
+		res := sample_model.TrafficRouteResource{}
+		err := s.Get(context.Background(), &res, store.GetByKey(name, mesh))
+		if store.IsResourceNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+
+	Describe("Transactional()", func() {
+		It("should persist all changes when function succeeds", func() {
+			// when
+			err := s.Transactional(context.Background(), func(ctx context.Context) error {
+				if err := createResource(ctx, "tx-1.demo"); err != nil {
+					return err
+				}
+				return createResource(ctx, "tx-2.demo")
+			})
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists("tx-1.demo")).To(BeTrue())
+			Expect(exists("tx-2.demo")).To(BeTrue())
+		})
+
+		It("should see own changes within a transaction", func() {
+			// when
+			err := s.Transactional(context.Background(), func(ctx context.Context) error {
+				if err := createResource(ctx, "tx-own.demo"); err != nil {
+					return err
+				}
+				res := sample_model.TrafficRouteResource{}
+				return s.Get(ctx, &res, store.GetByKey("tx-own.demo", mesh))
+			})
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should discard all changes when function fails", func() {
+			// given
+			Expect(createResource(context.Background(), "tx-existing.demo")).To(Succeed())
+
+			// when
+			err := s.Transactional(context.Background(), func(ctx context.Context) error {
+				if err := createResource(ctx, "tx-rollback.demo"); err != nil {
+					return err
+				}
+				// duplicate
+				return createResource(ctx, "tx-existing.demo")
+			})
+
+			// then
+			Expect(err).To(MatchError(store.ErrorResourceAlreadyExists(sample_model.TrafficRouteType, "tx-existing.demo", mesh)))
+			Expect(exists("tx-rollback.demo")).To(BeFalse())
+			Expect(exists("tx-existing.demo")).To(BeTrue())
+		})
+
+		It("should join a transaction in progress", func() {
+			// when
+			err := s.Transactional(context.Background(), func(ctx context.Context) error {
+				if err := createResource(ctx, "tx-outer.demo"); err != nil {
+					return err
+				}
+				if err := s.Transactional(ctx, func(ctx context.Context) error {
+					return createResource(ctx, "tx-inner.demo")
+				}); err != nil {
+					return err
+				}
+				return errors.New("outer failed")
+			})
+
+			// then
+			Expect(err).To(MatchError("outer failed"))
+			Expect(exists("tx-outer.demo")).To(BeFalse())
+			Expect(exists("tx-inner.demo")).To(BeFalse())
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package net
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// DetectAddress returns the first IPv4 address of a network interface that is up and is not a loopback.
+func DetectAddress() (string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return "", errors.Wrap(err, "could not list network interfaces")
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return "", errors.Wrapf(err, "could not list addresses of a network interface %q", iface.Name)
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.To4() == nil {
+				continue
+			}
+			return ipNet.IP.String(), nil
+		}
+	}
+	return "", errors.New("could not find a non-loopback IPv4 address")
+}
@@ -0,0 +1,26 @@
+package net_test
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Kong/kuma/pkg/util/net"
+)
+
+var _ = Describe("DetectAddress()", func() {
+	It("should return a non-loopback IPv4 address", func() {
+		// when
+		address, err := DetectAddress()
+		if err != nil {
+			Skip("there is no non-loopback network interface in this environment")
+		}
+
+		// then
+		ip := net.ParseIP(address)
+		Expect(ip).ToNot(BeNil())
+		Expect(ip.To4()).ToNot(BeNil())
+		Expect(ip.IsLoopback()).To(BeFalse())
+	})
+})
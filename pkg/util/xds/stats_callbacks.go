@@ -0,0 +1,77 @@
+package xds
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+)
+
+var (
+	streamsActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kuma_cp_discovery_streams_active",
+		Help: "Number of open streams of a discovery server",
+	}, []string{"server"})
+	requestsReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuma_cp_discovery_requests_total",
+		Help: "Number of discovery requests received by a discovery server",
+	}, []string{"server", "type_url"})
+	requestsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuma_cp_discovery_nacks_total",
+		Help: "Number of discovery requests that reject a previous response (NACK)",
+	}, []string{"server", "type_url"})
+	responsesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuma_cp_discovery_responses_total",
+		Help: "Number of discovery responses sent by a discovery server",
+	}, []string{"server", "type_url"})
+)
+
+// StatsCallbacks exports Prometheus metrics of streams, requests and responses of a discovery server.
+type StatsCallbacks struct {
+	// Server is a name of the discovery server, e.g. "ads", "sds" or "mads".
+	Server string
+}
+
+var _ envoy_xds.Callbacks = StatsCallbacks{}
+
+// OnStreamOpen is called once an xDS stream is open with a stream ID and the type URL (or "" for ADS).
+// Returning an error will end processing and close the stream. OnStreamClosed will still be called.
+func (cb StatsCallbacks) OnStreamOpen(ctx context.Context, streamID int64, typ string) error {
+	streamsActive.WithLabelValues(cb.Server).Inc()
+	return nil
+}
+
+// OnStreamClosed is called immediately prior to closing an xDS stream with a stream ID.
+func (cb StatsCallbacks) OnStreamClosed(streamID int64) {
+	streamsActive.WithLabelValues(cb.Server).Dec()
+}
+
+// OnStreamRequest is called once a request is received on a stream.
+// Returning an error will end processing and close the stream. OnStreamClosed will still be called.
+func (cb StatsCallbacks) OnStreamRequest(streamID int64, req *envoy.DiscoveryRequest) error {
+	requestsReceived.WithLabelValues(cb.Server, req.GetTypeUrl()).Inc()
+	if req.GetErrorDetail() != nil {
+		requestsRejected.WithLabelValues(cb.Server, req.GetTypeUrl()).Inc()
+	}
+	return nil
+}
+
+// OnStreamResponse is called immediately prior to sending a response on a stream.
+func (cb StatsCallbacks) OnStreamResponse(streamID int64, req *envoy.DiscoveryRequest, resp *envoy.DiscoveryResponse) {
+	responsesSent.WithLabelValues(cb.Server, resp.GetTypeUrl()).Inc()
+}
+
+// OnFetchRequest is called for each Fetch request. Returning an error will end processing of the
+// request and respond with an error.
+func (cb StatsCallbacks) OnFetchRequest(ctx context.Context, req *envoy.DiscoveryRequest) error {
+	requestsReceived.WithLabelValues(cb.Server, req.GetTypeUrl()).Inc()
+	return nil
+}
+
+// OnFetchResponse is called immediately prior to sending a response.
+func (cb StatsCallbacks) OnFetchResponse(req *envoy.DiscoveryRequest, resp *envoy.DiscoveryResponse) {
+	responsesSent.WithLabelValues(cb.Server, resp.GetTypeUrl()).Inc()
+}
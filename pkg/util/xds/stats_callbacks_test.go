@@ -0,0 +1,53 @@
+package xds_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"google.golang.org/genproto/googleapis/rpc/status"
+
+	. "github.com/Kong/kuma/pkg/util/xds"
+)
+
+var _ = Describe("StatsCallbacks", func() {
+
+	It("should export metrics of streams, requests and responses", func() {
+		// given
+		callbacks := StatsCallbacks{Server: "test"}
+		typeUrl := "type.googleapis.com/envoy.api.v2.Cluster"
+
+		// when
+		Expect(callbacks.OnStreamOpen(context.Background(), 1, "")).To(Succeed())
+		Expect(callbacks.OnStreamOpen(context.Background(), 2, "")).To(Succeed())
+		Expect(callbacks.OnStreamRequest(1, &envoy.DiscoveryRequest{TypeUrl: typeUrl})).To(Succeed())
+		callbacks.OnStreamResponse(1, &envoy.DiscoveryRequest{TypeUrl: typeUrl}, &envoy.DiscoveryResponse{TypeUrl: typeUrl})
+		Expect(callbacks.OnStreamRequest(1, &envoy.DiscoveryRequest{TypeUrl: typeUrl, ErrorDetail: &status.Status{Message: "invalid"}})).To(Succeed())
+		callbacks.OnStreamClosed(2)
+
+		// then
+		expected := `
+# HELP kuma_cp_discovery_streams_active Number of open streams of a discovery server
+# TYPE kuma_cp_discovery_streams_active gauge
+kuma_cp_discovery_streams_active{server="test"} 1
+# HELP kuma_cp_discovery_requests_total Number of discovery requests received by a discovery server
+# TYPE kuma_cp_discovery_requests_total counter
+kuma_cp_discovery_requests_total{server="test",type_url="type.googleapis.com/envoy.api.v2.Cluster"} 2
+# HELP kuma_cp_discovery_nacks_total Number of discovery requests that reject a previous response (NACK)
+# TYPE kuma_cp_discovery_nacks_total counter
+kuma_cp_discovery_nacks_total{server="test",type_url="type.googleapis.com/envoy.api.v2.Cluster"} 1
+# HELP kuma_cp_discovery_responses_total Number of discovery responses sent by a discovery server
+# TYPE kuma_cp_discovery_responses_total counter
+kuma_cp_discovery_responses_total{server="test",type_url="type.googleapis.com/envoy.api.v2.Cluster"} 1
+`
+		err := testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected),
+			"kuma_cp_discovery_streams_active", "kuma_cp_discovery_requests_total", "kuma_cp_discovery_nacks_total", "kuma_cp_discovery_responses_total")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,55 @@
+package accesslog
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Control Plane configures Envoy to stream access logs to `kuma-dp`, which delivers them to a logging backend.
+// Both an address of the backend and a format of entries are passed in a log name of gRPC Access Log
+// in the form of `<address>;<format>`.
+
+const logNameSeparator = ";"
+
+// logNameEscaper percent-encodes characters of an address that would break parsing of a log name.
+var logNameEscaper = strings.NewReplacer("%", "%25", logNameSeparator, "%3B")
+
+// LogName encodes an address of a logging backend and a format of entries into a log name.
+func LogName(address string, format string) string {
+	return logNameEscaper.Replace(address) + logNameSeparator + format
+}
+
+// ParseLogName decodes an address of a logging backend and a format of entries from a log name.
+func ParseLogName(logName string) (address string, format string, err error) {
+	parts := strings.SplitN(logName, logNameSeparator, 2)
+	if len(parts) != 2 {
+		return "", "", errors.Errorf("expected %d components, got %d", 2, len(parts))
+	}
+	address, err = url.PathUnescape(parts[0])
+	if err != nil {
+		return "", "", errors.Wrap(err, "invalid address")
+	}
+	return address, parts[1], nil
+}
+
+// FileAddress encodes a file backend as `file:<path>?<options>`.
+// A path is kept opaque, so that a relative path isn't mistaken for a host.
+func FileAddress(filePath string, options url.Values) string {
+	return (&url.URL{Scheme: "file", Opaque: (&url.URL{Path: path.Clean(filePath)}).EscapedPath(), RawQuery: options.Encode()}).String()
+}
+
+// FilePath returns a path of a file backend given by its parsed address.
+func FilePath(address *url.URL) (string, error) {
+	if address.Opaque == "" {
+		// `file:///<path>` or `file:/<path>`
+		return address.Path, nil
+	}
+	filePath, err := url.PathUnescape(address.Opaque)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid path of a file %q", address.Opaque)
+	}
+	return filePath, nil
+}
@@ -0,0 +1,84 @@
+package accesslog_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+)
+
+var _ = Describe("LogName(..)", func() {
+
+	DescribeTable("should encode an address and a format so that they can be decoded back",
+		func(address string, format string) {
+			// when
+			logName := accesslog.LogName(address, format)
+			actualAddress, actualFormat, err := accesslog.ParseLogName(logName)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualAddress).To(Equal(address))
+			Expect(actualFormat).To(Equal(format))
+		},
+		Entry("TCP address", "127.0.0.1:1234", "%START_TIME% %KUMA_SOURCE_SERVICE%"),
+		Entry("address with a semicolon", "file:/tmp/access;log?maxSize=1024", "json;{}"),
+		Entry("address with a literal `%3B`", "http://logs.example.com/a%3Bb#batchSize=10", "%RESPONSE_CODE%;"),
+	)
+
+	It("should reject a log name without a format", func() {
+		// when
+		_, _, err := accesslog.ParseLogName("127.0.0.1:1234")
+
+		// then
+		Expect(err).To(MatchError("expected 2 components, got 1"))
+	})
+})
+
+var _ = Describe("FileAddress(..)", func() {
+
+	DescribeTable("should encode a path so that it can be decoded back",
+		func(path string, expectedAddress string) {
+			// given
+			options := url.Values{}
+			options.Set("maxSize", "1024")
+
+			// when
+			address := accesslog.FileAddress(path, options)
+			// then
+			Expect(address).To(Equal(expectedAddress))
+
+			// when
+			u, err := url.Parse(address)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(u.Scheme).To(Equal("file"))
+			Expect(u.Host).To(BeEmpty())
+			Expect(u.Query().Get("maxSize")).To(Equal("1024"))
+
+			// when
+			actualPath, err := accesslog.FilePath(u)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualPath).To(Equal(path))
+		},
+		Entry("absolute path", "/var/log/access.log", "file:/var/log/access.log?maxSize=1024"),
+		Entry("relative path", "logs/access.log", "file:logs/access.log?maxSize=1024"),
+		Entry("path with special characters", "logs/a b?c#d%3B.log", "file:logs/a%20b%3Fc%23d%253B.log?maxSize=1024"),
+	)
+
+	It("should decode a path of `file:///<path>` address", func() {
+		// given
+		u, err := url.Parse("file:///var/log/access.log?maxSize=1024")
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		path, err := accesslog.FilePath(u)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/var/log/access.log"))
+	})
+})
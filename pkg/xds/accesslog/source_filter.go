@@ -0,0 +1,81 @@
+package accesslog
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// A source of a request on an inbound listener is not known in advance and can only be told apart by `kuma-dp`
+// from a SPIFFE ID of the peer certificate. That is why Control Plane passes services whose traffic is logged
+// in initial metadata of a gRPC Access Log stream and leaves filtering of log entries to `kuma-dp`.
+
+const (
+	SourcesMetadataKey         = "x-kuma-log-sources"
+	ExcludedSourcesMetadataKey = "x-kuma-log-excluded-sources"
+
+	sourcesSeparator = ","
+)
+
+// SourceFilter tells whether a request from a given service should be logged.
+type SourceFilter struct {
+	// Sources are services whose requests are logged. Requests from any service are logged if empty.
+	Sources []string
+	// ExcludedSources are services whose requests are not logged, e.g. because they are logged by another TrafficLog.
+	ExcludedSources []string
+}
+
+// IsEmpty tells whether a filter lets requests from any source through.
+func (f SourceFilter) IsEmpty() bool {
+	return len(f.Sources) == 0 && len(f.ExcludedSources) == 0
+}
+
+// Matches tells whether a request from a given service should be logged.
+// An empty service stands for a source that cannot be identified, e.g. a client without a certificate.
+func (f SourceFilter) Matches(service string) bool {
+	if len(f.Sources) > 0 && !contains(f.Sources, service) {
+		return false
+	}
+	return !contains(f.ExcludedSources, service)
+}
+
+// Metadata encodes a filter into gRPC metadata.
+func (f SourceFilter) Metadata() map[string]string {
+	md := map[string]string{}
+	if len(f.Sources) > 0 {
+		md[SourcesMetadataKey] = strings.Join(f.Sources, sourcesSeparator)
+	}
+	if len(f.ExcludedSources) > 0 {
+		md[ExcludedSourcesMetadataKey] = strings.Join(f.ExcludedSources, sourcesSeparator)
+	}
+	return md
+}
+
+// ParseSourceFilter decodes a filter from gRPC metadata.
+func ParseSourceFilter(md metadata.MD) SourceFilter {
+	return SourceFilter{
+		Sources:         sourcesOf(md, SourcesMetadataKey),
+		ExcludedSources: sourcesOf(md, ExcludedSourcesMetadataKey),
+	}
+}
+
+func sourcesOf(md metadata.MD, key string) []string {
+	var sources []string
+	for _, value := range md.Get(key) {
+		for _, source := range strings.Split(value, sourcesSeparator) {
+			if source != "" {
+				sources = append(sources, source)
+			}
+		}
+	}
+	return sources
+}
+
+func contains(services []string, service string) bool {
+	for _, s := range services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
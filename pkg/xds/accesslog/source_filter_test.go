@@ -0,0 +1,45 @@
+package accesslog_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+)
+
+var _ = Describe("SourceFilter", func() {
+
+	DescribeTable("should tell whether a request from a given service should be logged",
+		func(filter accesslog.SourceFilter, service string, expected bool) {
+			Expect(filter.Matches(service)).To(Equal(expected))
+		},
+		Entry("empty filter", accesslog.SourceFilter{}, "web", true),
+		Entry("empty filter and unknown source", accesslog.SourceFilter{}, "", true),
+		Entry("one of sources", accesslog.SourceFilter{Sources: []string{"web", "mobile"}}, "mobile", true),
+		Entry("none of sources", accesslog.SourceFilter{Sources: []string{"web", "mobile"}}, "backend", false),
+		Entry("unknown source and sources", accesslog.SourceFilter{Sources: []string{"web"}}, "", false),
+		Entry("one of excluded sources", accesslog.SourceFilter{ExcludedSources: []string{"web"}}, "web", false),
+		Entry("none of excluded sources", accesslog.SourceFilter{ExcludedSources: []string{"web"}}, "backend", true),
+		Entry("unknown source and excluded sources", accesslog.SourceFilter{ExcludedSources: []string{"web"}}, "", true),
+	)
+
+	DescribeTable("should encode a filter into gRPC metadata so that it can be decoded back",
+		func(filter accesslog.SourceFilter) {
+			// when
+			actual := accesslog.ParseSourceFilter(metadata.New(filter.Metadata()))
+
+			// then
+			Expect(actual).To(Equal(filter))
+		},
+		Entry("empty filter", accesslog.SourceFilter{}),
+		Entry("sources", accesslog.SourceFilter{Sources: []string{"web", "mobile"}}),
+		Entry("excluded sources", accesslog.SourceFilter{ExcludedSources: []string{"web"}}),
+	)
+
+	It("should not encode an empty filter", func() {
+		Expect(accesslog.SourceFilter{}.Metadata()).To(BeEmpty())
+	})
+})
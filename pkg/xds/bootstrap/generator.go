@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"text/template"
 
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	bootstrap_config "github.com/Kong/kuma/pkg/config/xds/bootstrap"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
@@ -45,10 +48,14 @@ func (b *bootstrapGenerator) Generate(ctx context.Context, request types.Bootstr
 	if err != nil {
 		return nil, err
 	}
-	return b.GenerateFor(*proxyId, dataplane, request)
+	meshRes, err := b.fetchMesh(ctx, proxyId.Mesh)
+	if err != nil {
+		return nil, err
+	}
+	return b.GenerateFor(*proxyId, dataplane, meshRes, request)
 }
 
-func (b *bootstrapGenerator) GenerateFor(proxyId xds.ProxyId, dataplane *mesh.DataplaneResource, request types.BootstrapRequest) (proto.Message, error) {
+func (b *bootstrapGenerator) GenerateFor(proxyId xds.ProxyId, dataplane *mesh.DataplaneResource, meshRes *mesh.MeshResource, request types.BootstrapRequest) (proto.Message, error) {
 	// if dataplane has no service - fill this with placeholder. Otherwise take the first service
 	service := dataplane.Spec.GetIdentifyingService()
 
@@ -68,11 +75,36 @@ func (b *bootstrapGenerator) GenerateFor(proxyId xds.ProxyId, dataplane *mesh.Da
 		XdsConnectTimeout:  b.config.XdsConnectTimeout,
 		AccessLogPipe:      accessLogPipe,
 		DataplaneTokenPath: request.DataplaneTokenPath,
+		EnvoyProcess:       request.EnvoyProcess,
 	}
-	log.WithValues("params", params).Info("Generating bootstrap config")
+	// stats sinks are a part of bootstrap config, so changes of StatsD settings require a restart of Envoy
+	if statsd := dataplane.GetStatsdSink(meshRes); statsd != nil {
+		sink, err := statsdSinkParametersFor(statsd)
+		if err != nil {
+			return nil, err
+		}
+		params.StatsdSink = sink
+	}
+	if b.config.MetricsServiceEnabled {
+		params.MetricsService = &metricsServiceParameters{
+			DataplaneToken:    request.DataplaneToken,
+			LocalClustersOnly: params.StatsdSink == nil && dataplane.GetPrometheusEndpoint(meshRes) == nil,
+		}
+	}
+	log.WithValues("params", withoutCredentials(params)).Info("Generating bootstrap config")
 	return b.ConfigForParameters(params)
 }
 
+// withoutCredentials returns a copy of parameters that is safe to log.
+func withoutCredentials(params configParameters) configParameters {
+	if params.MetricsService != nil && params.MetricsService.DataplaneToken != "" {
+		metricsService := *params.MetricsService
+		metricsService.DataplaneToken = "***"
+		params.MetricsService = &metricsService
+	}
+	return params
+}
+
 func (b *bootstrapGenerator) fetchDataplane(ctx context.Context, proxyId *xds.ProxyId) (*mesh.DataplaneResource, error) {
 	res := mesh.DataplaneResource{}
 	if err := b.resManager.Get(ctx, &res, store.GetBy(proxyId.ToResourceKey())); err != nil {
@@ -81,6 +113,41 @@ func (b *bootstrapGenerator) fetchDataplane(ctx context.Context, proxyId *xds.Pr
 	return &res, nil
 }
 
+// fetchMesh returns nil if a Mesh doesn't exist, e.g. when it's in the process of being deleted.
+func (b *bootstrapGenerator) fetchMesh(ctx context.Context, meshName string) (*mesh.MeshResource, error) {
+	res := mesh.MeshResource{}
+	if err := b.resManager.Get(ctx, &res, store.GetByKey(meshName, meshName)); err != nil {
+		if store.IsResourceNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &res, nil
+}
+
+func statsdSinkParametersFor(statsd *mesh_proto.Metrics_Statsd) (*statsdSinkParameters, error) {
+	host, port, err := net.SplitHostPort(statsd.GetAddress())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address of a StatsD server %q", statsd.GetAddress())
+	}
+	portValue, err := strconv.ParseUint(port, 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address of a StatsD server %q", statsd.GetAddress())
+	}
+	sink := &statsdSinkParameters{
+		Name:    "envoy.statsd",
+		Type:    "StatsdSink",
+		Address: host,
+		Port:    uint32(portValue),
+		Prefix:  statsd.GetPrefixOrDefault(),
+	}
+	if statsd.GetTagFormatOrDefault() == mesh_proto.StatsdTagFormatDogStatsd {
+		sink.Name = "envoy.dog_statsd"
+		sink.Type = "DogStatsdSink"
+	}
+	return sink, nil
+}
+
 func (b *bootstrapGenerator) ConfigForParameters(params configParameters) (proto.Message, error) {
 	tmpl, err := template.New("bootstrap").Parse(configTemplate)
 	if err != nil {
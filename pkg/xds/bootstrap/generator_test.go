@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -20,6 +21,8 @@ import (
 	"github.com/Kong/kuma/pkg/plugins/resources/memory"
 	util_proto "github.com/Kong/kuma/pkg/util/proto"
 	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
+
+	envoy_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v2"
 )
 
 var _ = Describe("bootstrapGenerator", func() {
@@ -60,11 +63,23 @@ var _ = Describe("bootstrapGenerator", func() {
 
 	type testCase struct {
 		config             func() *bootstrap_config.BootstrapParamsConfig
+		meshMetrics        *mesh_proto.Metrics
+		dataplaneMetrics   *mesh_proto.Metrics
 		request            types.BootstrapRequest
 		expectedConfigFile string
 	}
 	DescribeTable("should generate bootstrap configuration",
 		func(given testCase) {
+			// given
+			meshRes := mesh.MeshResource{}
+			Expect(resManager.Get(context.Background(), &meshRes, store.GetByKey("mesh", "mesh"))).To(Succeed())
+			meshRes.Spec.Metrics = given.meshMetrics
+			Expect(resManager.Update(context.Background(), &meshRes)).To(Succeed())
+			dataplane := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &dataplane, store.GetByKey("name.namespace", "mesh"))).To(Succeed())
+			dataplane.Spec.Metrics = given.dataplaneMetrics
+			Expect(resManager.Update(context.Background(), &dataplane)).To(Succeed())
+
 			// setup
 			generator := NewDefaultBootstrapGenerator(resManager, given.config())
 
@@ -114,6 +129,26 @@ var _ = Describe("bootstrapGenerator", func() {
 			},
 			expectedConfigFile: "generator.default-config.golden.yaml",
 		}),
+		Entry("default config with status of Envoy process", testCase{
+			config: func() *bootstrap_config.BootstrapParamsConfig {
+				cfg := bootstrap_config.DefaultBootstrapParamsConfig()
+				cfg.XdsHost = "127.0.0.1"
+				cfg.XdsPort = 5678
+				return cfg
+			},
+			request: types.BootstrapRequest{
+				Mesh:               "mesh",
+				Name:               "name.namespace",
+				AdminPort:          1234,
+				DataplaneTokenPath: "/tmp/token",
+				EnvoyProcess: &types.EnvoyProcessStatus{
+					Crashes:      2,
+					LastExitCode: 1,
+					RestartEpoch: 3,
+				},
+			},
+			expectedConfigFile: "generator.default-config-envoy-process.golden.yaml",
+		}),
 		Entry("custom config with minimal request", testCase{
 			config: func() *bootstrap_config.BootstrapParamsConfig {
 				return &bootstrap_config.BootstrapParamsConfig{
@@ -150,5 +185,122 @@ var _ = Describe("bootstrapGenerator", func() {
 			},
 			expectedConfigFile: "generator.custom-config.golden.yaml",
 		}),
+		Entry("default config with StatsD sink", testCase{
+			config: func() *bootstrap_config.BootstrapParamsConfig {
+				cfg := bootstrap_config.DefaultBootstrapParamsConfig()
+				cfg.XdsHost = "127.0.0.1"
+				cfg.XdsPort = 5678
+				return cfg
+			},
+			meshMetrics: &mesh_proto.Metrics{
+				Statsd: &mesh_proto.Metrics_Statsd{
+					Address: "10.0.0.1:8125",
+				},
+			},
+			request: types.BootstrapRequest{
+				Mesh: "mesh",
+				Name: "name.namespace",
+			},
+			expectedConfigFile: "generator.statsd.golden.yaml",
+		}),
+		Entry("default config with DogStatsD sink overridden by Dataplane", testCase{
+			config: func() *bootstrap_config.BootstrapParamsConfig {
+				cfg := bootstrap_config.DefaultBootstrapParamsConfig()
+				cfg.XdsHost = "127.0.0.1"
+				cfg.XdsPort = 5678
+				return cfg
+			},
+			meshMetrics: &mesh_proto.Metrics{
+				Statsd: &mesh_proto.Metrics_Statsd{
+					Address:   "10.0.0.1:8125",
+					TagFormat: "dogstatsd",
+				},
+			},
+			dataplaneMetrics: &mesh_proto.Metrics{
+				Statsd: &mesh_proto.Metrics_Statsd{
+					Address: "127.0.0.1:8125",
+					Prefix:  "kuma",
+				},
+			},
+			request: types.BootstrapRequest{
+				Mesh: "mesh",
+				Name: "name.namespace",
+			},
+			expectedConfigFile: "generator.dogstatsd.golden.yaml",
+		}),
+		Entry("config with metrics service", testCase{
+			config: func() *bootstrap_config.BootstrapParamsConfig {
+				cfg := bootstrap_config.DefaultBootstrapParamsConfig()
+				cfg.XdsHost = "127.0.0.1"
+				cfg.XdsPort = 5678
+				cfg.MetricsServiceEnabled = true
+				return cfg
+			},
+			request: types.BootstrapRequest{
+				Mesh:               "mesh",
+				Name:               "name.namespace",
+				DataplaneTokenPath: "/tmp/token",
+				DataplaneToken:     "sample-token",
+			},
+			expectedConfigFile: "generator.metrics-service.golden.yaml",
+		}),
+		Entry("config with metrics service and StatsD sink", testCase{
+			config: func() *bootstrap_config.BootstrapParamsConfig {
+				cfg := bootstrap_config.DefaultBootstrapParamsConfig()
+				cfg.XdsHost = "127.0.0.1"
+				cfg.XdsPort = 5678
+				cfg.MetricsServiceEnabled = true
+				return cfg
+			},
+			meshMetrics: &mesh_proto.Metrics{
+				Statsd: &mesh_proto.Metrics_Statsd{
+					Address: "10.0.0.1:8125",
+				},
+			},
+			request: types.BootstrapRequest{
+				Mesh: "mesh",
+				Name: "name.namespace",
+			},
+			expectedConfigFile: "generator.metrics-service-statsd.golden.yaml",
+		}),
 	)
+
+	It("should keep stats that kuma-dp reads to tell whether Envoy is ready", func() {
+		// given
+		cfg := bootstrap_config.DefaultBootstrapParamsConfig()
+		cfg.XdsHost = "127.0.0.1"
+		cfg.XdsPort = 5678
+		cfg.MetricsServiceEnabled = true
+		generator := NewDefaultBootstrapGenerator(resManager, cfg)
+
+		// when
+		bootstrapConfig, err := generator.Generate(context.Background(), types.BootstrapRequest{
+			Mesh: "mesh",
+			Name: "name.namespace",
+		})
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		Expect(bootstrapConfig).To(BeAssignableToTypeOf(&envoy_bootstrap.Bootstrap{}))
+
+		// when
+		patterns := bootstrapConfig.(*envoy_bootstrap.Bootstrap).GetStatsConfig().GetStatsMatcher().GetInclusionList().GetPatterns()
+		// then
+		Expect(patterns).ToNot(BeEmpty())
+
+		// and
+		included := func(stat string) bool {
+			for _, pattern := range patterns {
+				if pattern.GetExact() == stat || pattern.GetPrefix() != "" && strings.HasPrefix(stat, pattern.GetPrefix()) {
+					return true
+				}
+			}
+			return false
+		}
+		Expect(included("cluster_manager.cds.update_success")).To(BeTrue())
+		Expect(included("listener_manager.lds.update_success")).To(BeTrue())
+		Expect(included("cluster.localhost:8080.upstream_rq_total")).To(BeTrue())
+		Expect(included("cluster.backend.upstream_rq_total")).To(BeFalse())
+	})
 })
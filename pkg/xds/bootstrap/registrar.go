@@ -0,0 +1,189 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/validators"
+	"github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/sds/auth"
+	builtin_issuer "github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
+)
+
+// DataplaneRegistrar creates or updates a Dataplane on behalf of `kuma-dp`
+// that has included a Dataplane definition into its bootstrap request.
+//
+// Self-registered Dataplanes are marked in their DataplaneInsight.
+// A Dataplane created or changed by other means (e.g., `kumactl apply`)
+// is never overwritten.
+type DataplaneRegistrar interface {
+	Register(ctx context.Context, request types.BootstrapRequest) error
+}
+
+// NewDataplaneRegistrar returns a registrar that authorizes requests by a dataplane token.
+func NewDataplaneRegistrar(resManager manager.ResourceManager, issuer builtin_issuer.DataplaneTokenIssuer) DataplaneRegistrar {
+	return &dataplaneRegistrar{
+		resManager: resManager,
+		issuer:     issuer,
+	}
+}
+
+// maxMarkAttempts limits retries of conflicting updates of a DataplaneInsight.
+const maxMarkAttempts = 3
+
+type dataplaneRegistrar struct {
+	resManager manager.ResourceManager
+	issuer     builtin_issuer.DataplaneTokenIssuer
+}
+
+func (r *dataplaneRegistrar) Register(ctx context.Context, request types.BootstrapRequest) error {
+	proxyId, err := xds.BuildProxyId(request.Mesh, request.Name)
+	if err != nil {
+		return err
+	}
+	if err := r.authorize(*proxyId, request.DataplaneToken); err != nil {
+		return err
+	}
+	dataplane, err := parseDataplane(*proxyId, request.DataplaneResource)
+	if err != nil {
+		return err
+	}
+
+	key := proxyId.ToResourceKey()
+	current := &mesh.DataplaneResource{}
+	if err := r.resManager.Get(ctx, current, store.GetBy(key)); err != nil {
+		if !store.IsResourceNotFound(err) {
+			return err
+		}
+		log.Info("registering Dataplane", "mesh", key.Mesh, "name", key.Name)
+		if err := r.resManager.Create(ctx, dataplane, store.CreateBy(key)); err != nil {
+			return err
+		}
+		return r.markSelfRegistered(ctx, key, dataplane.Meta.GetVersion())
+	}
+	selfRegistered, err := r.isSelfRegistered(ctx, current)
+	if err != nil {
+		return err
+	}
+	if !selfRegistered {
+		return &UnauthorizedError{Reason: "Dataplane already exists and has not been registered by kuma-dp"}
+	}
+	if proto.Equal(&current.Spec, &dataplane.Spec) {
+		// kuma-dp periodically re-requests bootstrap config, avoid needless updates
+		return nil
+	}
+	log.Info("updating Dataplane", "mesh", key.Mesh, "name", key.Name)
+	current.Spec = dataplane.Spec
+	if err := r.resManager.Update(ctx, current); err != nil {
+		return err
+	}
+	return r.markSelfRegistered(ctx, key, current.Meta.GetVersion())
+}
+
+func (r *dataplaneRegistrar) isSelfRegistered(ctx context.Context, dataplane *mesh.DataplaneResource) (bool, error) {
+	insight := &mesh.DataplaneInsightResource{}
+	if err := r.resManager.Get(ctx, insight, store.GetBy(model.MetaToResourceKey(dataplane.Meta))); err != nil {
+		if store.IsResourceNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return insight.Spec.IsSelfRegisteredAt(dataplane.Meta.GetVersion()), nil
+}
+
+// markSelfRegistered records a version of the Dataplane written on behalf of `kuma-dp`.
+// DataplaneInsight is also updated by xDS server, so conflicting updates are retried.
+func (r *dataplaneRegistrar) markSelfRegistered(ctx context.Context, key model.ResourceKey, version string) error {
+	var err error
+	for attempt := 0; attempt < maxMarkAttempts; attempt++ {
+		insight := &mesh.DataplaneInsightResource{}
+		create := false
+		if err = r.resManager.Get(ctx, insight, store.GetBy(key)); err != nil {
+			if !store.IsResourceNotFound(err) {
+				return err
+			}
+			create = true
+		}
+		insight.Spec.SelfRegistration = &mesh_proto.SelfRegistration{
+			DataplaneVersion: version,
+			RegistrationTime: util_proto.MustTimestampProto(time.Now()),
+		}
+		if create {
+			err = r.resManager.Create(ctx, insight, store.CreateBy(key))
+		} else {
+			err = r.resManager.Update(ctx, insight)
+		}
+		if err == nil || !(store.IsResourceConflict(err) || store.IsResourceAlreadyExists(err)) {
+			return err
+		}
+	}
+	return err
+}
+
+func (r *dataplaneRegistrar) authorize(proxyId xds.ProxyId, token string) error {
+	if token == "" {
+		return &UnauthorizedError{Reason: "dataplane token is required to register a Dataplane"}
+	}
+	tokenProxyId, err := r.issuer.Validate(auth.Credential(token))
+	if err != nil {
+		return &UnauthorizedError{Reason: err.Error()}
+	}
+	if tokenProxyId.Mesh != proxyId.Mesh || tokenProxyId.Name != proxyId.Name {
+		return &UnauthorizedError{Reason: "dataplane token has been issued for another Dataplane"}
+	}
+	return nil
+}
+
+// parseDataplane parses a Dataplane in the same format as `kumactl apply` does.
+// Name and mesh of the Dataplane default to the ones of the requestor.
+func parseDataplane(proxyId xds.ProxyId, resource string) (*mesh.DataplaneResource, error) {
+	var verr validators.ValidationError
+	meta := rest.ResourceMeta{}
+	if err := yaml.Unmarshal([]byte(resource), &meta); err != nil {
+		verr.AddViolation("dataplaneResource", err.Error())
+		return nil, &verr
+	}
+	if meta.Type != "" && meta.Type != string(mesh.DataplaneType) {
+		verr.AddViolation("dataplaneResource.type", "must be Dataplane")
+	}
+	if meta.Mesh != "" && meta.Mesh != proxyId.Mesh {
+		verr.AddViolation("dataplaneResource.mesh", "must be the same as the mesh of the requestor")
+	}
+	if meta.Name != "" && meta.Name != proxyId.Name {
+		verr.AddViolation("dataplaneResource.name", "must be the same as the name of the requestor")
+	}
+	dataplane := &mesh.DataplaneResource{}
+	if err := util_proto.FromYAML([]byte(resource), &dataplane.Spec); err != nil {
+		verr.AddViolation("dataplaneResource", err.Error())
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+	return dataplane, nil
+}
+
+// UnauthorizedError is returned when a requestor is not allowed to register a Dataplane.
+type UnauthorizedError struct {
+	Reason string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return "not allowed to register a Dataplane: " + e.Reason
+}
+
+func IsUnauthorized(err error) bool {
+	_, ok := errors.Cause(err).(*UnauthorizedError)
+	return ok
+}
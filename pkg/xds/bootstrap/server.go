@@ -8,8 +8,10 @@ import (
 	"net/http"
 
 	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	"github.com/Kong/kuma/pkg/core/validators"
 	"github.com/Kong/kuma/pkg/util/proto"
 	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
 )
@@ -19,6 +21,12 @@ var log = core.Log.WithName("bootstrap-server")
 type BootstrapServer struct {
 	Port      uint32
 	Generator BootstrapGenerator
+	// Registrar creates Dataplanes included into bootstrap requests.
+	// If nil, registration of Dataplanes by `kuma-dp` is not supported.
+	//
+	// Notice that a dataplane token is sent along with a bootstrap request,
+	// so Bootstrap Server must only be exposed over a trusted network.
+	Registrar DataplaneRegistrar
 }
 
 var _ core_runtime.Component = &BootstrapServer{}
@@ -70,7 +78,30 @@ func (b *BootstrapServer) handleBootstrapRequest(resp http.ResponseWriter, req *
 		return
 	}
 
+	if reqParams.DataplaneResource != "" {
+		if b.Registrar == nil {
+			resp.WriteHeader(http.StatusBadRequest)
+			_, _ = resp.Write([]byte("registration of Dataplanes by kuma-dp is not supported by this Control Plane"))
+			return
+		}
+		if err := b.Registrar.Register(req.Context(), reqParams); err != nil {
+			switch {
+			case IsUnauthorized(err):
+				resp.WriteHeader(http.StatusForbidden)
+			case validators.IsValidationError(err), manager.IsMeshNotFound(err):
+				resp.WriteHeader(http.StatusBadRequest)
+			default:
+				log.WithValues("mesh", reqParams.Mesh, "name", reqParams.Name).Error(err, "Could not register a Dataplane")
+				resp.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = resp.Write([]byte(err.Error()))
+			return
+		}
+	}
 	config, err := b.Generator.Generate(req.Context(), reqParams)
+	// dataplane token is no longer needed, make sure it doesn't end up in logs
+	reqParams.DataplaneToken = ""
 	if err != nil {
 		if store.IsResourceNotFound(err) {
 			resp.WriteHeader(http.StatusNotFound)
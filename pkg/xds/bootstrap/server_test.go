@@ -1,7 +1,9 @@
 package bootstrap
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
@@ -13,8 +15,11 @@ import (
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/xds"
 	"github.com/Kong/kuma/pkg/plugins/resources/memory"
 	"github.com/Kong/kuma/pkg/test"
+	builtin_issuer "github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -26,8 +31,10 @@ var _ = Describe("Bootstrap Server", func() {
 	var resManager manager.ResourceManager
 	var config *bootstrap_config.BootstrapParamsConfig
 	var baseUrl string
+	var issuer builtin_issuer.DataplaneTokenIssuer
 
 	BeforeEach(func() {
+		issuer = builtin_issuer.NewDataplaneTokenIssuer([]byte("signing-key"))
 		resManager = manager.NewResourceManager(memory.NewStore())
 		config = bootstrap_config.DefaultBootstrapParamsConfig()
 		config.XdsHost = "127.0.0.1"
@@ -39,6 +46,7 @@ var _ = Describe("Bootstrap Server", func() {
 		server := BootstrapServer{
 			Port:      uint32(port),
 			Generator: NewDefaultBootstrapGenerator(resManager, config),
+			Registrar: NewDataplaneRegistrar(resManager, issuer),
 		}
 		stop = make(chan struct{})
 		go func() {
@@ -138,4 +146,228 @@ var _ = Describe("Bootstrap Server", func() {
 		Expect(resp.Body.Close()).To(Succeed())
 		Expect(resp.StatusCode).To(Equal(404))
 	})
+
+	Describe("registration of Dataplanes", func() {
+
+		dataplaneResource := `
+type: Dataplane
+mesh: default
+name: dp-1
+networking:
+  inbound:
+  - interface: 8.8.8.8:443:8443
+    tags:
+      service: backend
+`
+
+		tokenFor := func(name string) string {
+			token, err := issuer.Generate(xds.ProxyId{Mesh: "default", Name: name})
+			Expect(err).ToNot(HaveOccurred())
+			return string(token)
+		}
+
+		post := func(request types.BootstrapRequest) (int, string) {
+			body, err := json.Marshal(request)
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := http.Post(baseUrl+"/bootstrap", "application/json", bytes.NewReader(body))
+			Expect(err).ToNot(HaveOccurred())
+			received, err := ioutil.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			return resp.StatusCode, string(received)
+		}
+
+		It("should create a Dataplane authorized by a dataplane token", func() {
+			// when
+			status, body := post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-1"),
+				DataplaneResource: dataplaneResource,
+			})
+
+			// then
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).ToNot(BeEmpty())
+
+			// and
+			dataplane := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &dataplane, store.GetByKey("dp-1", "default"))).To(Succeed())
+			Expect(dataplane.Spec.Networking.Inbound).To(HaveLen(1))
+			Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("8.8.8.8:443:8443"))
+
+			// and
+			insight := mesh.DataplaneInsightResource{}
+			Expect(resManager.Get(context.Background(), &insight, store.GetByKey("dp-1", "default"))).To(Succeed())
+			Expect(insight.Spec.IsSelfRegisteredAt(dataplane.Meta.GetVersion())).To(BeTrue())
+		})
+
+		It("should update a self-registered Dataplane", func() {
+			// given
+			status, _ := post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-1"),
+				DataplaneResource: dataplaneResource,
+			})
+			Expect(status).To(Equal(http.StatusOK))
+
+			// when
+			status, _ = post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-1"),
+				DataplaneResource: strings.Replace(dataplaneResource, "8.8.8.8", "8.8.4.4", 1),
+			})
+
+			// then
+			Expect(status).To(Equal(http.StatusOK))
+
+			// and
+			dataplane := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &dataplane, store.GetByKey("dp-1", "default"))).To(Succeed())
+			Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("8.8.4.4:443:8443"))
+
+			// and
+			insight := mesh.DataplaneInsightResource{}
+			Expect(resManager.Get(context.Background(), &insight, store.GetByKey("dp-1", "default"))).To(Succeed())
+			Expect(insight.Spec.IsSelfRegisteredAt(dataplane.Meta.GetVersion())).To(BeTrue())
+		})
+
+		It("should not overwrite a Dataplane that hasn't been registered by kuma-dp", func() {
+			// given
+			err := resManager.Create(context.Background(), &mesh.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+							Interface: "1.1.1.1:80:8080",
+							Tags: map[string]string{
+								"service": "web",
+							},
+						}},
+					},
+				},
+			}, store.CreateByKey("dp-1", "default"))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			status, body := post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-1"),
+				DataplaneResource: dataplaneResource,
+			})
+
+			// then
+			Expect(status).To(Equal(http.StatusForbidden))
+			Expect(body).To(Equal("not allowed to register a Dataplane: Dataplane already exists and has not been registered by kuma-dp"))
+
+			// and
+			dataplane := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &dataplane, store.GetByKey("dp-1", "default"))).To(Succeed())
+			Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("1.1.1.1:80:8080"))
+		})
+
+		It("should not overwrite a self-registered Dataplane that has been changed by other means", func() {
+			// given
+			status, _ := post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-1"),
+				DataplaneResource: dataplaneResource,
+			})
+			Expect(status).To(Equal(http.StatusOK))
+			// and
+			dataplane := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &dataplane, store.GetByKey("dp-1", "default"))).To(Succeed())
+			dataplane.Spec.Networking.Inbound[0].Interface = "1.1.1.1:80:8080"
+			Expect(resManager.Update(context.Background(), &dataplane)).To(Succeed())
+
+			// when
+			status, _ = post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-1"),
+				DataplaneResource: dataplaneResource,
+			})
+
+			// then
+			Expect(status).To(Equal(http.StatusForbidden))
+		})
+
+		It("should reject a request without a dataplane token", func() {
+			// when
+			status, body := post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneResource: dataplaneResource,
+			})
+
+			// then
+			Expect(status).To(Equal(http.StatusForbidden))
+			Expect(body).To(Equal("not allowed to register a Dataplane: dataplane token is required to register a Dataplane"))
+
+			// and
+			err := resManager.Get(context.Background(), &mesh.DataplaneResource{}, store.GetByKey("dp-1", "default"))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should not update a Dataplane that hasn't changed", func() {
+			// given
+			request := types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-1"),
+				DataplaneResource: dataplaneResource,
+			}
+			status, _ := post(request)
+			Expect(status).To(Equal(http.StatusOK))
+			// and
+			before := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &before, store.GetByKey("dp-1", "default"))).To(Succeed())
+
+			// when
+			status, _ = post(request)
+
+			// then
+			Expect(status).To(Equal(http.StatusOK))
+
+			// and
+			after := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &after, store.GetByKey("dp-1", "default"))).To(Succeed())
+			Expect(after.Meta.GetVersion()).To(Equal(before.Meta.GetVersion()))
+		})
+
+		It("should reject a request with a token of another Dataplane", func() {
+			// when
+			status, body := post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-1",
+				DataplaneToken:    tokenFor("dp-2"),
+				DataplaneResource: dataplaneResource,
+			})
+
+			// then
+			Expect(status).To(Equal(http.StatusForbidden))
+			Expect(body).To(Equal("not allowed to register a Dataplane: dataplane token has been issued for another Dataplane"))
+
+			// and
+			err := resManager.Get(context.Background(), &mesh.DataplaneResource{}, store.GetByKey("dp-1", "default"))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should reject a Dataplane with a name different from the name of the requestor", func() {
+			// when
+			status, body := post(types.BootstrapRequest{
+				Mesh:              "default",
+				Name:              "dp-2",
+				DataplaneToken:    tokenFor("dp-2"),
+				DataplaneResource: dataplaneResource,
+			})
+
+			// then
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(body).To(Equal("dataplaneResource.name: must be the same as the name of the requestor"))
+		})
+	})
 })
@@ -1,6 +1,10 @@
 package bootstrap
 
-import "time"
+import (
+	"time"
+
+	"github.com/Kong/kuma/pkg/xds/bootstrap/types"
+)
 
 type configParameters struct {
 	Id                 string
@@ -13,6 +17,27 @@ type configParameters struct {
 	XdsConnectTimeout  time.Duration
 	AccessLogPipe      string
 	DataplaneTokenPath string
+	EnvoyProcess       *types.EnvoyProcessStatus
+	StatsdSink         *statsdSinkParameters
+	MetricsService     *metricsServiceParameters
+}
+
+type metricsServiceParameters struct {
+	// DataplaneToken authenticates a stream of stats
+	DataplaneToken string
+	// LocalClustersOnly restricts stats that Envoy keeps to those of local clusters,
+	// which is all Control Plane needs unless stats are consumed by other means, e.g. StatsD or Prometheus.
+	LocalClustersOnly bool
+}
+
+type statsdSinkParameters struct {
+	// Name of a stats sink, either `envoy.statsd` or `envoy.dog_statsd`
+	Name string
+	// Type of a stats sink config, either `StatsdSink` or `DogStatsdSink`
+	Type    string
+	Address string
+	Port    uint32
+	Prefix  string
 }
 
 const configTemplate string = `
@@ -26,6 +51,11 @@ node:
 {{if .AdminPort }}
     dataplane.admin.port: "{{ .AdminPort }}"
 {{ end }}
+{{if .EnvoyProcess }}
+    dataplane.envoy.crashes: "{{ .EnvoyProcess.Crashes }}"
+    dataplane.envoy.lastExitCode: "{{ .EnvoyProcess.LastExitCode }}"
+    dataplane.envoy.restartEpoch: "{{ .EnvoyProcess.RestartEpoch }}"
+{{ end }}
 
 {{if .AdminPort }}
 admin:
@@ -46,6 +76,51 @@ dynamic_resources:
     - envoy_grpc:
         cluster_name: ads_cluster
 
+{{if or .MetricsService .StatsdSink }}
+stats_sinks:
+{{if .MetricsService }}
+# stream stats to Control Plane, which aggregates them per service
+- name: envoy.metrics_service
+  typed_config:
+    '@type': type.googleapis.com/envoy.config.metrics.v2.MetricsServiceConfig
+    grpc_service:
+      envoy_grpc:
+        cluster_name: ads_cluster
+{{if .MetricsService.DataplaneToken }}
+      initial_metadata:
+      - key: authorization
+        value: "{{ .MetricsService.DataplaneToken }}"
+{{ end }}
+{{ end }}
+{{if .StatsdSink }}
+# push stats to a StatsD server
+- name: {{ .StatsdSink.Name }}
+  typed_config:
+    '@type': type.googleapis.com/envoy.config.metrics.v2.{{ .StatsdSink.Type }}
+    address:
+      socket_address:
+        protocol: UDP
+        address: {{ .StatsdSink.Address }}
+        port_value: {{ .StatsdSink.Port }}
+    prefix: {{ .StatsdSink.Prefix }}
+{{ end }}
+{{ end }}
+
+{{if .MetricsService }}
+{{if .MetricsService.LocalClustersOnly }}
+stats_config:
+  stats_matcher:
+    # names of local clusters are ` + "`localhost:<port>`" + `, but stat names might be sanitized
+    inclusion_list:
+      patterns:
+      - prefix: "cluster.localhost:"
+      - prefix: "cluster.localhost_"
+      # kuma-dp tells whether Envoy is ready by these stats
+      - exact: "cluster_manager.cds.update_success"
+      - exact: "listener_manager.lds.update_success"
+{{ end }}
+{{ end }}
+
 static_resources:
   clusters:
   - name: ads_cluster
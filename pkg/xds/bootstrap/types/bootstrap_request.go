@@ -5,4 +5,21 @@ type BootstrapRequest struct {
 	Name               string `json:"name"`
 	AdminPort          uint32 `json:"adminPort,omitempty"`
 	DataplaneTokenPath string `json:"dataplaneTokenPath,omitempty"`
+	// DataplaneToken authorizes registration of a Dataplane given by DataplaneResource.
+	DataplaneToken string `json:"dataplaneToken,omitempty"`
+	// DataplaneResource is a Dataplane (in the format of `kumactl apply`)
+	// that Control Plane should create or update on behalf of `kuma-dp`.
+	DataplaneResource string `json:"dataplaneResource,omitempty"`
+	// EnvoyProcess describes Envoy process that is going to use the bootstrap config.
+	EnvoyProcess *EnvoyProcessStatus `json:"envoyProcess,omitempty"`
+}
+
+// EnvoyProcessStatus describes Envoy process supervised by `kuma-dp`.
+type EnvoyProcessStatus struct {
+	// Crashes is a number of times Envoy has crashed since `kuma-dp` started.
+	Crashes uint32 `json:"crashes,omitempty"`
+	// LastExitCode is an exit code of the most recent Envoy crash.
+	LastExitCode int32 `json:"lastExitCode,omitempty"`
+	// RestartEpoch is a hot restart epoch of Envoy process.
+	RestartEpoch uint32 `json:"restartEpoch,omitempty"`
 }
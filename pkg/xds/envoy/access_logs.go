@@ -1,16 +1,22 @@
 package envoy
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Kong/kuma/api/mesh/v1alpha1"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	xds_accesslog "github.com/Kong/kuma/pkg/xds/accesslog"
 	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v2"
 	filter_accesslog "github.com/envoyproxy/go-control-plane/envoy/config/filter/accesslog/v2"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/pkg/errors"
 )
 
@@ -18,42 +24,151 @@ const AccessLogDefaultFormat = "[%START_TIME%] %KUMA_SOURCE_ADDRESS%(%KUMA_SOURC
 
 const AccessLogSink = "access_log_sink"
 
+// AccessLogJsonFormatPrefix marks a log name of a logging backend served by kuma-dp with structured format,
+// i.e. `<address>;json;<JSON object with format strings>`.
+const AccessLogJsonFormatPrefix = "json;"
+
 func convertLoggingBackend(sourceService string, destinationService string, backend *v1alpha1.LoggingBackend, proxy *core_xds.Proxy) (*filter_accesslog.AccessLog, error) {
 	if backend == nil {
 		return nil, nil
 	}
-	format := AccessLogDefaultFormat
-	if backend.Format != "" {
-		format = backend.Format
-	}
 	iface, _ := proxy.Dataplane.Spec.Networking.GetInboundInterface(sourceService)
 	sourceAddress := ""
 	if iface != nil {
 		sourceAddress = iface.DataplaneIP
 	}
-	format = strings.ReplaceAll(format, "%KUMA_SOURCE_ADDRESS%", fmt.Sprintf("%s:0", sourceAddress))
-	format = strings.ReplaceAll(format, "%KUMA_SOURCE_SERVICE%", sourceService)
-	format = strings.ReplaceAll(format, "%KUMA_DESTINATION_SERVICE%", destinationService)
+	replaceKumaPlaceholders := func(format string) string {
+		format = strings.ReplaceAll(format, "%KUMA_SOURCE_ADDRESS%", fmt.Sprintf("%s:0", sourceAddress))
+		format = strings.ReplaceAll(format, "%KUMA_SOURCE_SERVICE%", sourceService)
+		format = strings.ReplaceAll(format, "%KUMA_DESTINATION_SERVICE%", destinationService)
+		return format
+	}
+	return convertLoggingBackendWith(replaceKumaPlaceholders, backend, xds_accesslog.SourceFilter{})
+}
+
+// convertInboundLoggingBackend converts a backend for access logs of an inbound listener.
+// A source of incoming traffic is not known in advance, so %KUMA_SOURCE_ADDRESS% is resolved by Envoy.
+// %KUMA_SOURCE_SERVICE% is resolved by kuma-dp from a SPIFFE ID of the peer certificate, unless access logs
+// are written to a file by Envoy itself, in which case it is substituted with the SPIFFE ID as is.
+// Access logs of particular sources are always delivered by kuma-dp, since only kuma-dp filters them by a source.
+func convertInboundLoggingBackend(destinationService string, log core_xds.InboundLog) (*filter_accesslog.AccessLog, error) {
+	backend := log.Backend
+	if backend == nil {
+		return nil, nil
+	}
+	sources := xds_accesslog.SourceFilter{Sources: log.Sources, ExcludedSources: log.ExcludedSources}
+	replaceKumaPlaceholders := func(format string) string {
+		format = strings.ReplaceAll(format, "%KUMA_SOURCE_ADDRESS%", "%DOWNSTREAM_REMOTE_ADDRESS%")
+		if file := backend.GetFile(); file != nil && file.Rotation == nil && sources.IsEmpty() {
+			format = strings.ReplaceAll(format, "%KUMA_SOURCE_SERVICE%", "%DOWNSTREAM_PEER_URI_SAN%")
+		}
+		format = strings.ReplaceAll(format, "%KUMA_DESTINATION_SERVICE%", destinationService)
+		return format
+	}
+	return convertLoggingBackendWith(replaceKumaPlaceholders, backend, sources)
+}
+
+func convertLoggingBackendWith(replaceKumaPlaceholders func(string) string, backend *v1alpha1.LoggingBackend, sources xds_accesslog.SourceFilter) (*filter_accesslog.AccessLog, error) {
 
-	if file, ok := backend.GetType().(*v1alpha1.LoggingBackend_File_); ok {
-		return fileAccessLog(format, file)
-	} else if tcp, ok := backend.GetType().(*v1alpha1.LoggingBackend_Tcp_); ok {
-		return tcpAccessLog(format, tcp)
+	var format string
+	var jsonFormat map[string]string
+	if len(backend.JsonFormat) > 0 {
+		jsonFormat = map[string]string{}
+		for key, value := range backend.JsonFormat {
+			jsonFormat[key] = replaceKumaPlaceholders(value)
+		}
+		data, err := json.Marshal(jsonFormat)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshall JSON format of access logs")
+		}
+		format = AccessLogJsonFormatPrefix + string(data)
 	} else {
+		format = AccessLogDefaultFormat
+		if backend.Format != "" {
+			format = backend.Format
+		}
+		format = replaceKumaPlaceholders(format)
+	}
+
+	switch backendType := backend.GetType().(type) {
+	case *v1alpha1.LoggingBackend_File_:
+		if backendType.File.Rotation != nil || !sources.IsEmpty() {
+			return sinkAccessLog(fileSinkAddress(backendType.File), format, sources)
+		}
+		if jsonFormat != nil {
+			return fileJsonAccessLog(jsonFormat, backendType)
+		}
+		return fileAccessLog(format, backendType)
+	case *v1alpha1.LoggingBackend_Tcp_:
+		return sinkAccessLog(backendType.Tcp.Address, format, sources)
+	case *v1alpha1.LoggingBackend_Syslog_:
+		return sinkAccessLog(syslogSinkAddress(backendType.Syslog), format, sources)
+	case *v1alpha1.LoggingBackend_Http_:
+		return sinkAccessLog(httpSinkAddress(backendType.Http), format, sources)
+	default:
 		return nil, errors.Errorf("could not convert LoggingBackend of type %T to AccessLog", backend.GetType())
 	}
 }
 
-func tcpAccessLog(format string, tcp *v1alpha1.LoggingBackend_Tcp_) (*filter_accesslog.AccessLog, error) {
+// fileSinkAddress encodes a file backend as `file:<path>?maxSize=<bytes>&maxAge=<duration>&maxBackups=<count>`.
+func fileSinkAddress(file *v1alpha1.LoggingBackend_File) string {
+	query := url.Values{}
+	rotation := file.GetRotation()
+	if rotation.GetMaxSize() > 0 {
+		query.Set("maxSize", strconv.FormatUint(rotation.GetMaxSize(), 10))
+	}
+	if maxAge, err := ptypes.Duration(rotation.GetMaxAge()); rotation.GetMaxAge() != nil && err == nil {
+		query.Set("maxAge", maxAge.String())
+	}
+	if rotation.GetMaxBackups() > 0 {
+		query.Set("maxBackups", strconv.FormatUint(uint64(rotation.GetMaxBackups()), 10))
+	}
+	return xds_accesslog.FileAddress(file.Path, query)
+}
+
+// syslogSinkAddress encodes a syslog backend as `syslog+<protocol>://<address>?facility=<code>`.
+func syslogSinkAddress(syslog *v1alpha1.LoggingBackend_Syslog) string {
+	facility, _ := syslog.GetFacilityCode()
+	query := url.Values{}
+	query.Set("facility", strconv.FormatUint(uint64(facility), 10))
+	return (&url.URL{Scheme: "syslog+" + syslog.GetProtocolOrDefault(), Host: syslog.Address, RawQuery: query.Encode()}).String()
+}
+
+// httpSinkAddress encodes an HTTP backend as a URL of the collector with batching options in the fragment,
+// i.e. `<url>#batchSize=<count>&flushInterval=<duration>&maxRetries=<count>`.
+func httpSinkAddress(http *v1alpha1.LoggingBackend_Http) string {
+	options := url.Values{}
+	if http.BatchSize > 0 {
+		options.Set("batchSize", strconv.FormatUint(uint64(http.BatchSize), 10))
+	}
+	if flushInterval, err := ptypes.Duration(http.FlushInterval); http.FlushInterval != nil && err == nil {
+		options.Set("flushInterval", flushInterval.String())
+	}
+	if http.MaxRetries > 0 {
+		options.Set("maxRetries", strconv.FormatUint(uint64(http.MaxRetries), 10))
+	}
+	address := http.Url
+	if i := strings.Index(address, "#"); i >= 0 {
+		address = address[:i]
+	}
+	if len(options) > 0 {
+		address += "#" + options.Encode()
+	}
+	return address
+}
+
+// sinkAccessLog streams access logs to kuma-dp which delivers them to a logging backend at a given address.
+func sinkAccessLog(address string, format string, sources xds_accesslog.SourceFilter) (*filter_accesslog.AccessLog, error) {
 	fileAccessLog := &accesslog.HttpGrpcAccessLogConfig{
 		CommonConfig: &accesslog.CommonGrpcAccessLogConfig{
-			LogName: fmt.Sprintf("%s;%s", tcp.Tcp.Address, format),
+			LogName: xds_accesslog.LogName(address, format),
 			GrpcService: &envoy_core.GrpcService{
 				TargetSpecifier: &envoy_core.GrpcService_EnvoyGrpc_{
 					EnvoyGrpc: &envoy_core.GrpcService_EnvoyGrpc{
 						ClusterName: AccessLogSink,
 					},
 				},
+				InitialMetadata: sourceFilterMetadata(sources),
 			},
 		},
 	}
@@ -69,6 +184,21 @@ func tcpAccessLog(format string, tcp *v1alpha1.LoggingBackend_Tcp_) (*filter_acc
 	}, nil
 }
 
+// sourceFilterMetadata passes sources whose requests are logged to kuma-dp in initial metadata of a stream.
+func sourceFilterMetadata(sources xds_accesslog.SourceFilter) []*envoy_core.HeaderValue {
+	md := sources.Metadata()
+	keys := make([]string, 0, len(md))
+	for key := range md {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var headers []*envoy_core.HeaderValue
+	for _, key := range keys {
+		headers = append(headers, &envoy_core.HeaderValue{Key: key, Value: md[key]})
+	}
+	return headers
+}
+
 func fileAccessLog(format string, file *v1alpha1.LoggingBackend_File_) (*filter_accesslog.AccessLog, error) {
 	fileAccessLog := &accesslog.FileAccessLog{
 		AccessLogFormat: &accesslog.FileAccessLog_Format{
@@ -87,3 +217,26 @@ func fileAccessLog(format string, file *v1alpha1.LoggingBackend_File_) (*filter_
 		},
 	}, nil
 }
+
+func fileJsonAccessLog(jsonFormat map[string]string, file *v1alpha1.LoggingBackend_File_) (*filter_accesslog.AccessLog, error) {
+	fields := map[string]*structpb.Value{}
+	for key, value := range jsonFormat {
+		fields[key] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: value}}
+	}
+	fileAccessLog := &accesslog.FileAccessLog{
+		AccessLogFormat: &accesslog.FileAccessLog_JsonFormat{
+			JsonFormat: &structpb.Struct{Fields: fields},
+		},
+		Path: file.File.Path,
+	}
+	marshalled, err := ptypes.MarshalAny(fileAccessLog)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshall FileAccessLog")
+	}
+	return &filter_accesslog.AccessLog{
+		Name: wellknown.FileAccessLog,
+		ConfigType: &filter_accesslog.AccessLog_TypedConfig{
+			TypedConfig: marshalled,
+		},
+	}, nil
+}
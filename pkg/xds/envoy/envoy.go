@@ -239,12 +239,24 @@ func CreateOutboundListener(ctx xds_context.Context, listenerName string, addres
 	return listener, nil
 }
 
-func CreateInboundListener(ctx xds_context.Context, listenerName string, address string, port uint32, clusterName string, virtual bool, permissions *mesh_core.TrafficPermissionResourceList, metadata *core_xds.DataplaneMetadata) *v2.Listener {
+func CreateInboundListener(ctx xds_context.Context, listenerName string, address string, port uint32, clusterName string, virtual bool, permissions *mesh_core.TrafficPermissionResourceList, service string, logs []core_xds.InboundLog, metadata *core_xds.DataplaneMetadata) (*v2.Listener, error) {
+	var accessLogs []*filter_accesslog.AccessLog
+	for _, log := range logs {
+		accessLog, err := convertInboundLoggingBackend(service, log)
+		if err != nil {
+			return nil, err
+		}
+		if accessLog != nil {
+			accessLogs = append(accessLogs, accessLog)
+		}
+	}
+
 	config := &envoy_tcp.TcpProxy{
 		StatPrefix: clusterName,
 		ClusterSpecifier: &envoy_tcp.TcpProxy_Cluster{
 			Cluster: clusterName,
 		},
+		AccessLog: accessLogs,
 	}
 	pbst, err := ptypes.MarshalAny(config)
 	util_error.MustNot(err)
@@ -284,7 +296,7 @@ func CreateInboundListener(ctx xds_context.Context, listenerName string, address
 			BindToPort: &wrappers.BoolValue{Value: false},
 		}
 	}
-	return listener
+	return listener, nil
 }
 
 func CreatePrometheusListener(ctx xds_context.Context, listenerName string, address string, port uint32, path string, clusterName string, virtual bool, metadata *core_xds.DataplaneMetadata) *v2.Listener {
@@ -382,6 +394,25 @@ func CreateCommonTlsContext(ctx xds_context.Context, metadata *core_xds.Dataplan
 	}
 }
 
+// CompleteSdsSecretConfigs makes TLS contexts of a Listener fetch secrets that are referenced by name only
+// (without a source of SDS resources) from SDS server of Control Plane.
+func CompleteSdsSecretConfigs(ctx xds_context.Context, listener *v2.Listener, metadata *core_xds.DataplaneMetadata) {
+	for _, chain := range listener.GetFilterChains() {
+		tlsContext := chain.GetTlsContext().GetCommonTlsContext()
+		if tlsContext == nil {
+			continue
+		}
+		for i, config := range tlsContext.TlsCertificateSdsSecretConfigs {
+			if config.GetSdsConfig() == nil {
+				tlsContext.TlsCertificateSdsSecretConfigs[i] = sdsSecretConfig(ctx, config.Name, metadata)
+			}
+		}
+		if config, ok := tlsContext.ValidationContextType.(*envoy_auth.CommonTlsContext_ValidationContextSdsSecretConfig); ok && config.ValidationContextSdsSecretConfig.GetSdsConfig() == nil {
+			config.ValidationContextSdsSecretConfig = sdsSecretConfig(ctx, config.ValidationContextSdsSecretConfig.GetName(), metadata)
+		}
+	}
+}
+
 func sdsSecretConfig(context xds_context.Context, name string, metadata *core_xds.DataplaneMetadata) *envoy_auth.SdsSecretConfig {
 	withCallCredentials := func(grpc *envoy_core.GrpcService_GoogleGrpc) *envoy_core.GrpcService_GoogleGrpc {
 		if metadata.GetDataplaneTokenPath() == "" {
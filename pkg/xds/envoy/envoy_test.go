@@ -18,6 +18,7 @@ import (
 
 	envoy_v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 )
 
 var _ = Describe("Envoy", func() {
@@ -515,6 +516,7 @@ var _ = Describe("Envoy", func() {
 			virtual  bool
 			expected string
 			metadata xds.DataplaneMetadata
+			logs     []xds.InboundLog
 		}
 
 		DescribeTable("should generate 'inbound' Listener",
@@ -550,7 +552,8 @@ var _ = Describe("Envoy", func() {
 				}
 
 				// when
-				resource := envoy.CreateInboundListener(given.ctx, "inbound:192.168.0.1:8080", "192.168.0.1", 8080, "localhost:8080", given.virtual, permissions, &given.metadata)
+				resource, err := envoy.CreateInboundListener(given.ctx, "inbound:192.168.0.1:8080", "192.168.0.1", 8080, "localhost:8080", given.virtual, permissions, "backend1", given.logs, &given.metadata)
+				Expect(err).ToNot(HaveOccurred())
 
 				// then
 				actual, err := util_proto.ToYAML(resource)
@@ -604,6 +607,164 @@ var _ = Describe("Envoy", func() {
                       statPrefix: localhost:8080
                 deprecatedV1:
                   bindToPort: false
+`,
+			}),
+			Entry("with file traffic logs", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				virtual: false,
+				logs: []xds.InboundLog{{
+					Backend: &mesh_proto.LoggingBackend{
+						Name:   "file",
+						Format: "%KUMA_SOURCE_ADDRESS%(%KUMA_SOURCE_SERVICE%)->%KUMA_DESTINATION_SERVICE%",
+						Type: &mesh_proto.LoggingBackend_File_{
+							File: &mesh_proto.LoggingBackend_File{
+								Path: "/tmp/log",
+							},
+						},
+					},
+				}},
+				expected: `
+                name: inbound:192.168.0.1:8080
+                address:
+                  socketAddress:
+                    address: 192.168.0.1
+                    portValue: 8080
+                filterChains:
+                - filters:
+                  - name: envoy.tcp_proxy
+                    typedConfig:
+                      '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                      accessLog:
+                      - name: envoy.file_access_log
+                        typedConfig:
+                          '@type': type.googleapis.com/envoy.config.accesslog.v2.FileAccessLog
+                          format: '%DOWNSTREAM_REMOTE_ADDRESS%(%DOWNSTREAM_PEER_URI_SAN%)->backend1'
+                          path: /tmp/log
+                      cluster: localhost:8080
+                      statPrefix: localhost:8080
+`,
+			}),
+			Entry("with tcp traffic logs in JSON format", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				virtual: false,
+				logs: []xds.InboundLog{{
+					Backend: &mesh_proto.LoggingBackend{
+						Name: "tcp",
+						JsonFormat: map[string]string{
+							"source":      "%KUMA_SOURCE_SERVICE%",
+							"destination": "%KUMA_DESTINATION_SERVICE%",
+						},
+						Type: &mesh_proto.LoggingBackend_Tcp_{
+							Tcp: &mesh_proto.LoggingBackend_Tcp{
+								Address: "127.0.0.1:1234",
+							},
+						},
+					},
+				}},
+				expected: `
+                name: inbound:192.168.0.1:8080
+                address:
+                  socketAddress:
+                    address: 192.168.0.1
+                    portValue: 8080
+                filterChains:
+                - filters:
+                  - name: envoy.tcp_proxy
+                    typedConfig:
+                      '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                      accessLog:
+                      - name: envoy.http_grpc_access_log
+                        typedConfig:
+                          '@type': type.googleapis.com/envoy.config.accesslog.v2.HttpGrpcAccessLogConfig
+                          commonConfig:
+                            grpcService:
+                              envoyGrpc:
+                                clusterName: access_log_sink
+                            logName: '127.0.0.1:1234;json;{"destination":"backend1","source":"%KUMA_SOURCE_SERVICE%"}'
+                      cluster: localhost:8080
+                      statPrefix: localhost:8080
+`,
+			}),
+			Entry("with traffic logs of particular sources", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				virtual: false,
+				logs: []xds.InboundLog{
+					{
+						Backend: &mesh_proto.LoggingBackend{
+							Name:   "file",
+							Format: "%KUMA_SOURCE_ADDRESS%(%KUMA_SOURCE_SERVICE%)->%KUMA_DESTINATION_SERVICE%",
+							Type: &mesh_proto.LoggingBackend_File_{
+								File: &mesh_proto.LoggingBackend_File{
+									Path: "/tmp/log",
+								},
+							},
+						},
+						Sources: []string{"mobile", "web"},
+					},
+					{
+						Backend: &mesh_proto.LoggingBackend{
+							Name:   "tcp",
+							Format: "%KUMA_SOURCE_SERVICE%->%KUMA_DESTINATION_SERVICE%",
+							Type: &mesh_proto.LoggingBackend_Tcp_{
+								Tcp: &mesh_proto.LoggingBackend_Tcp{
+									Address: "127.0.0.1:1234",
+								},
+							},
+						},
+						ExcludedSources: []string{"mobile", "web"},
+					},
+				},
+				expected: `
+                name: inbound:192.168.0.1:8080
+                address:
+                  socketAddress:
+                    address: 192.168.0.1
+                    portValue: 8080
+                filterChains:
+                - filters:
+                  - name: envoy.tcp_proxy
+                    typedConfig:
+                      '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                      accessLog:
+                      - name: envoy.http_grpc_access_log
+                        typedConfig:
+                          '@type': type.googleapis.com/envoy.config.accesslog.v2.HttpGrpcAccessLogConfig
+                          commonConfig:
+                            grpcService:
+                              envoyGrpc:
+                                clusterName: access_log_sink
+                              initialMetadata:
+                              - key: x-kuma-log-sources
+                                value: mobile,web
+                            logName: 'file:/tmp/log;%DOWNSTREAM_REMOTE_ADDRESS%(%KUMA_SOURCE_SERVICE%)->backend1'
+                      - name: envoy.http_grpc_access_log
+                        typedConfig:
+                          '@type': type.googleapis.com/envoy.config.accesslog.v2.HttpGrpcAccessLogConfig
+                          commonConfig:
+                            grpcService:
+                              envoyGrpc:
+                                clusterName: access_log_sink
+                              initialMetadata:
+                              - key: x-kuma-log-excluded-sources
+                                value: mobile,web
+                            logName: '127.0.0.1:1234;%KUMA_SOURCE_SERVICE%->backend1'
+                      cluster: localhost:8080
+                      statPrefix: localhost:8080
 `,
 			}),
 			Entry("with mTLS", testCase{
@@ -1022,6 +1183,228 @@ var _ = Describe("Envoy", func() {
                 cluster: db
                 statPrefix: db
           name: outbound:127.0.0.1:18080
+`,
+			}),
+			Entry("with file traffic logs in JSON format", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				clusters: singleCluster,
+				log: &mesh_proto.LoggingBackend{
+					Name: "file",
+					JsonFormat: map[string]string{
+						"source":      "%KUMA_SOURCE_SERVICE%",
+						"destination": "%KUMA_DESTINATION_SERVICE%",
+						"bytes":       "%BYTES_SENT%",
+					},
+					Type: &mesh_proto.LoggingBackend_File_{
+						File: &mesh_proto.LoggingBackend_File{
+							Path: "/tmp/log",
+						},
+					},
+				},
+				expected: `
+          address:
+            socketAddress:
+              address: 127.0.0.1
+              portValue: 18080
+          filterChains:
+          - filters:
+            - name: envoy.tcp_proxy
+              typedConfig:
+                '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                accessLog:
+                - name: envoy.file_access_log
+                  typedConfig:
+                    '@type': type.googleapis.com/envoy.config.accesslog.v2.FileAccessLog
+                    jsonFormat:
+                      bytes: '%BYTES_SENT%'
+                      destination: db
+                      source: backend
+                    path: /tmp/log
+                cluster: db
+                statPrefix: db
+          name: outbound:127.0.0.1:18080
+`,
+			}),
+			Entry("with tcp traffic logs in JSON format", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				clusters: singleCluster,
+				log: &mesh_proto.LoggingBackend{
+					Name: "tcp",
+					JsonFormat: map[string]string{
+						"source": "%KUMA_SOURCE_SERVICE%",
+						"status": "%RESPONSE_CODE%",
+					},
+					Type: &mesh_proto.LoggingBackend_Tcp_{
+						Tcp: &mesh_proto.LoggingBackend_Tcp{
+							Address: "127.0.0.1:1234",
+						},
+					},
+				},
+				expected: `
+          address:
+            socketAddress:
+              address: 127.0.0.1
+              portValue: 18080
+          filterChains:
+          - filters:
+            - name: envoy.tcp_proxy
+              typedConfig:
+                '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                accessLog:
+                - name: envoy.http_grpc_access_log
+                  typedConfig:
+                    '@type': type.googleapis.com/envoy.config.accesslog.v2.HttpGrpcAccessLogConfig
+                    commonConfig:
+                      grpcService:
+                        envoyGrpc:
+                          clusterName: access_log_sink
+                      logName: '127.0.0.1:1234;json;{"source":"backend","status":"%RESPONSE_CODE%"}'
+                cluster: db
+                statPrefix: db
+          name: outbound:127.0.0.1:18080
+`,
+			}),
+			Entry("with file traffic logs with rotation", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				clusters: singleCluster,
+				log: &mesh_proto.LoggingBackend{
+					Name:   "file",
+					Format: "custom format",
+					Type: &mesh_proto.LoggingBackend_File_{
+						File: &mesh_proto.LoggingBackend_File{
+							Path: "/tmp/access;log",
+							Rotation: &mesh_proto.LoggingBackend_File_Rotation{
+								MaxSize:    1024,
+								MaxAge:     &duration.Duration{Seconds: 3600},
+								MaxBackups: 3,
+							},
+						},
+					},
+				},
+				expected: `
+          address:
+            socketAddress:
+              address: 127.0.0.1
+              portValue: 18080
+          filterChains:
+          - filters:
+            - name: envoy.tcp_proxy
+              typedConfig:
+                '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                accessLog:
+                - name: envoy.http_grpc_access_log
+                  typedConfig:
+                    '@type': type.googleapis.com/envoy.config.accesslog.v2.HttpGrpcAccessLogConfig
+                    commonConfig:
+                      grpcService:
+                        envoyGrpc:
+                          clusterName: access_log_sink
+                      logName: 'file:/tmp/access%3Blog?maxAge=1h0m0s&maxBackups=3&maxSize=1024;custom format'
+                cluster: db
+                statPrefix: db
+          name: outbound:127.0.0.1:18080
+`,
+			}),
+			Entry("with syslog traffic logs", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				clusters: singleCluster,
+				log: &mesh_proto.LoggingBackend{
+					Name:   "syslog",
+					Format: "custom format",
+					Type: &mesh_proto.LoggingBackend_Syslog_{
+						Syslog: &mesh_proto.LoggingBackend_Syslog{
+							Address:  "127.0.0.1:514",
+							Facility: "daemon",
+						},
+					},
+				},
+				expected: `
+          address:
+            socketAddress:
+              address: 127.0.0.1
+              portValue: 18080
+          filterChains:
+          - filters:
+            - name: envoy.tcp_proxy
+              typedConfig:
+                '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                accessLog:
+                - name: envoy.http_grpc_access_log
+                  typedConfig:
+                    '@type': type.googleapis.com/envoy.config.accesslog.v2.HttpGrpcAccessLogConfig
+                    commonConfig:
+                      grpcService:
+                        envoyGrpc:
+                          clusterName: access_log_sink
+                      logName: syslog+udp://127.0.0.1:514?facility=3;custom format
+                cluster: db
+                statPrefix: db
+          name: outbound:127.0.0.1:18080
+`,
+			}),
+			Entry("with http traffic logs", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Resource: &mesh_core.MeshResource{},
+					},
+				},
+				clusters: singleCluster,
+				log: &mesh_proto.LoggingBackend{
+					Name: "http",
+					JsonFormat: map[string]string{
+						"status": "%RESPONSE_CODE%",
+					},
+					Type: &mesh_proto.LoggingBackend_Http_{
+						Http: &mesh_proto.LoggingBackend_Http{
+							Url:           "https://collector:8080/logs",
+							BatchSize:     10,
+							FlushInterval: &duration.Duration{Seconds: 1},
+						},
+					},
+				},
+				expected: `
+          address:
+            socketAddress:
+              address: 127.0.0.1
+              portValue: 18080
+          filterChains:
+          - filters:
+            - name: envoy.tcp_proxy
+              typedConfig:
+                '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                accessLog:
+                - name: envoy.http_grpc_access_log
+                  typedConfig:
+                    '@type': type.googleapis.com/envoy.config.accesslog.v2.HttpGrpcAccessLogConfig
+                    commonConfig:
+                      grpcService:
+                        envoyGrpc:
+                          clusterName: access_log_sink
+                      logName: 'https://collector:8080/logs#batchSize=10&flushInterval=1s;json;{"status":"%RESPONSE_CODE%"}'
+                cluster: db
+                statPrefix: db
+          name: outbound:127.0.0.1:18080
 `,
 			}),
 			Entry("with multiple weighted clusters", testCase{
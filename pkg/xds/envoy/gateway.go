@@ -0,0 +1,127 @@
+package envoy
+
+import (
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_listener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	envoy_route "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	util_error "github.com/Kong/kuma/pkg/util/error"
+)
+
+// GatewayVirtualHost describes HTTP routes of a gateway for a set of domains.
+type GatewayVirtualHost struct {
+	Name    string
+	Domains []string
+	Routes  []GatewayRoute
+}
+
+// GatewayRoute forwards requests with a given path prefix to a cluster.
+type GatewayRoute struct {
+	Prefix  string
+	Cluster string
+}
+
+// GatewayCertificate is a certificate served by a gateway for a set of server names (SNI).
+type GatewayCertificate struct {
+	ServerNames []string
+	// SecretName is a name of the SDS resource with a certificate and a private key.
+	SecretName string
+}
+
+// CreateGatewayListener generates an HTTP listener that routes external traffic into the mesh.
+// If certificates are given, the listener terminates TLS and picks a certificate by SNI.
+//
+// Certificates are referenced by name only, without a source of SDS resources,
+// so a listener can be stored in a ProxyTemplate without exposing private keys.
+// Sources are filled in by CompleteSdsSecretConfigs when xDS resources are generated for a Dataplane.
+func CreateGatewayListener(listenerName string, address string, port uint32, virtualHosts []GatewayVirtualHost, certificates []GatewayCertificate) *v2.Listener {
+	filter := createGatewayHttpFilter(listenerName, virtualHosts)
+	listener := &v2.Listener{
+		Name: listenerName,
+		Address: &envoy_core.Address{
+			Address: &envoy_core.Address_SocketAddress{
+				SocketAddress: &envoy_core.SocketAddress{
+					Protocol: envoy_core.SocketAddress_TCP,
+					Address:  address,
+					PortSpecifier: &envoy_core.SocketAddress_PortValue{
+						PortValue: port,
+					},
+				},
+			},
+		},
+	}
+	if len(certificates) == 0 {
+		listener.FilterChains = []*envoy_listener.FilterChain{{
+			Filters: []*envoy_listener.Filter{filter},
+		}}
+		return listener
+	}
+	for _, certificate := range certificates {
+		listener.FilterChains = append(listener.FilterChains, &envoy_listener.FilterChain{
+			FilterChainMatch: &envoy_listener.FilterChainMatch{
+				ServerNames: certificate.ServerNames,
+			},
+			TlsContext: &envoy_auth.DownstreamTlsContext{
+				CommonTlsContext: &envoy_auth.CommonTlsContext{
+					TlsCertificateSdsSecretConfigs: []*envoy_auth.SdsSecretConfig{{
+						Name: certificate.SecretName,
+					}},
+				},
+			},
+			Filters: []*envoy_listener.Filter{filter},
+		})
+	}
+	return listener
+}
+
+func createGatewayHttpFilter(listenerName string, virtualHosts []GatewayVirtualHost) *envoy_listener.Filter {
+	routeConfig := &v2.RouteConfiguration{
+		Name: listenerName,
+	}
+	for _, virtualHost := range virtualHosts {
+		vh := &envoy_route.VirtualHost{
+			Name:    virtualHost.Name,
+			Domains: virtualHost.Domains,
+		}
+		for _, route := range virtualHost.Routes {
+			vh.Routes = append(vh.Routes, &envoy_route.Route{
+				Match: &envoy_route.RouteMatch{
+					PathSpecifier: &envoy_route.RouteMatch_Prefix{
+						Prefix: route.Prefix,
+					},
+				},
+				Action: &envoy_route.Route_Route{
+					Route: &envoy_route.RouteAction{
+						ClusterSpecifier: &envoy_route.RouteAction_Cluster{
+							Cluster: route.Cluster,
+						},
+					},
+				},
+			})
+		}
+		routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, vh)
+	}
+	config := &envoy_hcm.HttpConnectionManager{
+		StatPrefix: listenerName,
+		CodecType:  envoy_hcm.HttpConnectionManager_AUTO,
+		HttpFilters: []*envoy_hcm.HttpFilter{{
+			Name: wellknown.Router,
+		}},
+		RouteSpecifier: &envoy_hcm.HttpConnectionManager_RouteConfig{
+			RouteConfig: routeConfig,
+		},
+	}
+	pbst, err := ptypes.MarshalAny(config)
+	util_error.MustNot(err)
+	return &envoy_listener.Filter{
+		Name: wellknown.HTTPConnectionManager,
+		ConfigType: &envoy_listener.Filter_TypedConfig{
+			TypedConfig: pbst,
+		},
+	}
+}
@@ -211,6 +211,20 @@ var _ = Describe("OutboundProxyGenerator", func() {
 `,
 			expected: "08.envoy.golden.yaml",
 		}),
+		Entry("09. transparent_proxying=true, mtls=false, outbound=1 to a subset of a service", testCase{
+			ctx: plainCtx,
+			dataplane: `
+            networking:
+              outbound:
+              - interface: 192.168.0.3:5432
+                service: db
+                tags:
+                  role: master
+              transparentProxying:
+                redirectPort: 15001
+`,
+			expected: "09.envoy.golden.yaml",
+		}),
 	)
 
 	Describe("fail when a user-defined configuration (Dataplane, TrafficRoute, etc) is not valid", func() {
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	"github.com/pkg/errors"
 
 	kuma_mesh "github.com/Kong/kuma/api/mesh/v1alpha1"
@@ -43,13 +44,17 @@ type ProxyTemplateRawSource struct {
 	Resources []*kuma_mesh.ProxyTemplateRawResource
 }
 
-func (s *ProxyTemplateRawSource) Generate(_ xds_context.Context, proxy *model.Proxy) ([]*model.Resource, error) {
+func (s *ProxyTemplateRawSource) Generate(ctx xds_context.Context, proxy *model.Proxy) ([]*model.Resource, error) {
 	resources := make([]*model.Resource, 0, len(s.Resources))
 	for i, r := range s.Resources {
 		res, err := util_envoy.ResourceFromYaml(r.Resource)
 		if err != nil {
 			return nil, fmt.Errorf("raw.resources[%d]{name=%q}.resource: %s", i, r.Name, err)
 		}
+		// secrets referenced by name only are served by SDS server of Control Plane
+		if listener, ok := res.(*v2.Listener); ok {
+			envoy.CompleteSdsSecretConfigs(ctx, listener, proxy.Metadata)
+		}
 
 		resources = append(resources, &model.Resource{
 			Name:     r.Name,
@@ -95,7 +100,9 @@ func (_ InboundProxyGenerator) Generate(ctx xds_context.Context, proxy *model.Pr
 	}
 	virtual := proxy.Dataplane.Spec.Networking.GetTransparentProxying().GetRedirectPort() != 0
 	resources := &model.ResourceSet{}
-	for _, endpoint := range endpoints {
+	for i, endpoint := range endpoints {
+		service := proxy.Dataplane.Spec.Networking.Inbound[i].Tags[kuma_mesh.ServiceTag]
+
 		// generate CDS resource
 		localClusterName := localClusterName(endpoint.WorkloadPort)
 		resources.Add(&model.Resource{
@@ -106,10 +113,14 @@ func (_ InboundProxyGenerator) Generate(ctx xds_context.Context, proxy *model.Pr
 
 		// generate LDS resource
 		inboundListenerName := localListenerName(endpoint.DataplaneIP, endpoint.DataplanePort)
+		listener, err := envoy.CreateInboundListener(ctx, inboundListenerName, endpoint.DataplaneIP, endpoint.DataplanePort, localClusterName, virtual, proxy.TrafficPermissions.Get(endpoint.String()), service, proxy.InboundLogs[endpoint.String()], proxy.Metadata)
+		if err != nil {
+			return nil, err
+		}
 		resources.Add(&model.Resource{
 			Name:     inboundListenerName,
 			Version:  "",
-			Resource: envoy.CreateInboundListener(ctx, inboundListenerName, endpoint.DataplaneIP, endpoint.DataplanePort, localClusterName, virtual, proxy.TrafficPermissions.Get(endpoint.String()), proxy.Metadata),
+			Resource: listener,
 		})
 	}
 	return resources.List(), nil
@@ -132,16 +143,27 @@ func (g OutboundProxyGenerator) Generate(ctx xds_context.Context, proxy *model.P
 			return nil, errors.Wrapf(err, "%s: value is not valid: %q", validators.RootedAt("dataplane").Field("networking").Field("outbound").Index(i).Field("interface"), oface.Interface)
 		}
 
-		// pick a route
-		route := proxy.TrafficRoutes[oface.Service]
-		if route == nil {
-			return nil, errors.Errorf("%s{service=%q}: has no TrafficRoute", validators.RootedAt("dataplane").Field("networking").Field("outbound").Index(i), oface.Service)
-		}
+		var clusters []envoy.ClusterInfo
+		if len(oface.Tags) > 0 {
+			// outbound to a subset of a service, e.g. a particular Pod of a StatefulSet, is not subject to TrafficRoutes
+			tags := oface.DestinationTags()
+			clusters = []envoy.ClusterInfo{{
+				Name:   destinationClusterName(oface.Service, tags),
+				Weight: 100,
+				Tags:   tags,
+			}}
+		} else {
+			// pick a route
+			route := proxy.TrafficRoutes[oface.Service]
+			if route == nil {
+				return nil, errors.Errorf("%s{service=%q}: has no TrafficRoute", validators.RootedAt("dataplane").Field("networking").Field("outbound").Index(i), oface.Service)
+			}
 
-		// determine the list of destination clusters
-		clusters, err := g.determineClusters(ctx, proxy, route)
-		if err != nil {
-			return nil, err
+			// determine the list of destination clusters
+			clusters, err = g.determineClusters(ctx, proxy, route)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		// generate CDS and EDS resources
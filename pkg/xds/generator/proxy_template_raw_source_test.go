@@ -191,6 +191,7 @@ var _ = Describe("ProxyTemplateRawSource", func() {
 	Context("Manually-defined xDS resources are valid", func() {
 
 		type testCase struct {
+			ctx      xds_context.Context
 			proxy    *model.Proxy
 			raw      []*mesh_proto.ProxyTemplateRawResource
 			expected string
@@ -201,10 +202,9 @@ var _ = Describe("ProxyTemplateRawSource", func() {
 			gen := &generator.ProxyTemplateRawSource{
 				Resources: given.raw,
 			}
-			ctx := xds_context.Context{}
 
 			// when
-			rs, err := gen.Generate(ctx, given.proxy)
+			rs, err := gen.Generate(given.ctx, given.proxy)
 
 			// then
 			Expect(err).ToNot(HaveOccurred())
@@ -290,6 +290,99 @@ var _ = Describe("ProxyTemplateRawSource", func() {
                 name: catch_all
                 useOriginalDst: true
               version: raw-version
+`,
+			}),
+			Entry("should fetch secrets referenced by name only from SDS server of Control Plane", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{
+						SdsLocation: "kuma-control-plane:5677",
+						SdsTlsCert:  []byte("CERTIFICATE"),
+					},
+				},
+				proxy: &model.Proxy{
+					Id: model.ProxyId{Name: "gateway"},
+					Dataplane: &mesh_core.DataplaneResource{
+						Meta: &test_model.ResourceMeta{
+							Version: "v1",
+						},
+						Spec: mesh_proto.Dataplane{
+							Networking: &mesh_proto.Dataplane_Networking{
+								Gateway: &mesh_proto.Dataplane_Networking_Gateway{
+									Tags: map[string]string{
+										"service": "gateway",
+									},
+								},
+							},
+						},
+					},
+					Metadata: &model.DataplaneMetadata{
+						DataplaneTokenPath: "/var/secret/token",
+					},
+				},
+				raw: []*mesh_proto.ProxyTemplateRawResource{{
+					Name:    "raw-name",
+					Version: "raw-version",
+					Resource: `
+          '@type': type.googleapis.com/envoy.api.v2.Listener
+          address:
+            socketAddress:
+              address: 0.0.0.0
+              portValue: 8443
+          filterChains:
+          - filters:
+            - name: envoy.tcp_proxy
+              typedConfig:
+                '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                cluster: backend
+                statPrefix: backend
+            tlsContext:
+              commonTlsContext:
+                tlsCertificateSdsSecretConfigs:
+                - name: gateway_cert:kuma-demo/shop-tls
+          name: gateway
+`,
+				}},
+				expected: `
+          resources:
+            - name: raw-name
+              resource:
+                '@type': type.googleapis.com/envoy.api.v2.Listener
+                address:
+                  socketAddress:
+                    address: 0.0.0.0
+                    portValue: 8443
+                filterChains:
+                - filters:
+                  - name: envoy.tcp_proxy
+                    typedConfig:
+                      '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                      cluster: backend
+                      statPrefix: backend
+                  tlsContext:
+                    commonTlsContext:
+                      tlsCertificateSdsSecretConfigs:
+                      - name: gateway_cert:kuma-demo/shop-tls
+                        sdsConfig:
+                          apiConfigSource:
+                            apiType: GRPC
+                            grpcServices:
+                            - googleGrpc:
+                                callCredentials:
+                                - fromPlugin:
+                                    name: envoy.grpc_credentials.file_based_metadata
+                                    typedConfig:
+                                      '@type': type.googleapis.com/envoy.config.grpc_credential.v2alpha.FileBasedMetadataConfig
+                                      secretData:
+                                        filename: /var/secret/token
+                                channelCredentials:
+                                  sslCredentials:
+                                    rootCerts:
+                                      inlineBytes: Q0VSVElGSUNBVEU=
+                                credentialsFactoryName: envoy.grpc_credentials.file_based_metadata
+                                statPrefix: sds_gateway_cert:kuma-demo/shop-tls
+                                targetUri: kuma-control-plane:5677
+                name: gateway
+              version: raw-version
 `,
 			}),
 			Entry("should support Cluster resource as YAML", testCase{
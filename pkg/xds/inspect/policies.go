@@ -0,0 +1,184 @@
+package inspect
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/permissions"
+	"github.com/Kong/kuma/pkg/core/policy"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/xds/inspect/types"
+	xds_topology "github.com/Kong/kuma/pkg/xds/topology"
+)
+
+// PoliciesInspector explains which TrafficPermissions, TrafficLogs, TrafficRoutes and HealthChecks
+// apply to connections of a Dataplane by listing all candidates along with their ranks.
+type PoliciesInspector struct {
+	ResourceManager manager.ResourceManager
+}
+
+func NewPoliciesInspector(resManager manager.ResourceManager) *PoliciesInspector {
+	return &PoliciesInspector{
+		ResourceManager: resManager,
+	}
+}
+
+// Inspect returns candidate policies of every inbound interface and every reachable service of a given Dataplane.
+func (i *PoliciesInspector) Inspect(ctx context.Context, dataplane *mesh_core.DataplaneResource) (*types.DataplanePolicies, error) {
+	mesh := dataplane.GetMeta().GetMesh()
+
+	permissionList := &mesh_core.TrafficPermissionResourceList{}
+	if err := i.ResourceManager.List(ctx, permissionList, core_store.ListByMesh(mesh)); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve traffic permissions")
+	}
+	logList := &mesh_core.TrafficLogResourceList{}
+	if err := i.ResourceManager.List(ctx, logList, core_store.ListByMesh(mesh)); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve traffic logs")
+	}
+	routeList := &mesh_core.TrafficRouteResourceList{}
+	if err := i.ResourceManager.List(ctx, routeList, core_store.ListByMesh(mesh)); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve traffic routes")
+	}
+	healthCheckList := &mesh_core.HealthCheckResourceList{}
+	if err := i.ResourceManager.List(ctx, healthCheckList, core_store.ListByMesh(mesh)); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve health checks")
+	}
+
+	inbound := map[string][]types.PolicyCandidate{}
+	outbound := map[core_xds.ServiceName][]types.PolicyCandidate{}
+
+	// all matching TrafficPermissions apply to an inbound interface rather than only the most specific one
+	permissionPolicies := make([]policy.ConnectionPolicy, len(permissionList.Items))
+	for idx, permission := range permissionList.Items {
+		permissionPolicies[idx] = permission
+	}
+	matchedPermissions := permissions.MatchDataplaneTrafficPermissions(&dataplane.Spec, permissionList)
+	for iface, candidates := range rankByDestination(dataplane, permissionPolicies) {
+		selected := map[policy.ConnectionPolicy]bool{}
+		for _, permission := range matchedPermissions.Get(iface).Items {
+			selected[permission] = true
+		}
+		for _, candidate := range candidates {
+			inbound[iface] = append(inbound[iface], toPolicyCandidate(candidate, selected[candidate.Policy]))
+		}
+	}
+
+	logPolicies := make([]policy.ConnectionPolicy, len(logList.Items))
+	for idx, log := range logList.Items {
+		logPolicies[idx] = log
+	}
+	addCandidates(inbound,
+		policy.RankInboundConnectionPolicies(dataplane, logPolicies),
+		policy.SelectInboundConnectionPolicies(dataplane, logPolicies))
+	addCandidates(outbound,
+		policy.RankConnectionPolicies(dataplane, policy.ToOutboundServicesOf(dataplane), logPolicies),
+		policy.SelectOutboundConnectionPolicies(dataplane, logPolicies))
+
+	routePolicies := make([]policy.ConnectionPolicy, len(routeList.Items))
+	for idx, route := range routeList.Items {
+		routePolicies[idx] = route
+	}
+	addCandidates(outbound,
+		policy.RankConnectionPolicies(dataplane, policy.ToOutboundServicesOf(dataplane), routePolicies),
+		policy.SelectOutboundConnectionPolicies(dataplane, routePolicies))
+
+	// HealthChecks apply to services reachable via TrafficRoutes, which are not necessarily the ones of outbound interfaces
+	destinations := xds_topology.BuildDestinationMap(dataplane, xds_topology.BuildRouteMap(dataplane, routeList.Items))
+	healthCheckPolicies := make([]policy.ConnectionPolicy, len(healthCheckList.Items))
+	for idx, healthCheck := range healthCheckList.Items {
+		healthCheckPolicies[idx] = healthCheck
+	}
+	addCandidates(outbound,
+		policy.RankConnectionPolicies(dataplane, policy.ToServicesOf(destinations), healthCheckPolicies),
+		policy.SelectConnectionPolicies(dataplane, policy.ToServicesOf(destinations), healthCheckPolicies))
+
+	result := &types.DataplanePolicies{
+		Inbound:  []types.InboundPolicies{},
+		Outbound: []types.OutboundPolicies{},
+	}
+	for _, iface := range dataplane.Spec.GetNetworking().GetInbound() {
+		result.Inbound = append(result.Inbound, types.InboundPolicies{
+			Interface:  iface.Interface,
+			Candidates: nonNil(inbound[iface.Interface]),
+		})
+	}
+	services := make([]string, 0, len(outbound))
+	for service := range outbound {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		result.Outbound = append(result.Outbound, types.OutboundPolicies{
+			Service:    service,
+			Candidates: nonNil(outbound[service]),
+		})
+	}
+	return result, nil
+}
+
+// rankByDestination ranks policies applicable to each inbound interface of a given Dataplane by their `destination` selectors only.
+// Unlike policy.RankInboundConnectionPolicies, it doesn't skip policies that select particular sources,
+// since TrafficPermissions are enforced per source.
+func rankByDestination(dataplane *mesh_core.DataplaneResource, policies []policy.ConnectionPolicy) map[string]policy.RankedConnectionPolicies {
+	sort.Stable(policy.ConnectionPolicyByName(policies)) // sort to avoid flakiness
+
+	ranked := map[string]policy.RankedConnectionPolicies{}
+	for _, inbound := range dataplane.Spec.GetNetworking().GetInbound() {
+		candidates := policy.RankedConnectionPolicies{}
+		for _, candidate := range policies {
+			var best *policy.RankedConnectionPolicy
+			for _, destination := range candidate.Destinations() {
+				destinationSelector := mesh_proto.TagSelector(destination.Match)
+				if !inbound.MatchTags(destinationSelector) {
+					continue
+				}
+				rank := destinationSelector.Rank()
+				if best == nil || rank.CompareTo(best.Rank) > 0 {
+					best = &policy.RankedConnectionPolicy{Policy: candidate, Rank: rank}
+				}
+			}
+			if best != nil {
+				candidates = append(candidates, *best)
+			}
+		}
+		ranked[inbound.Interface] = candidates
+	}
+	return ranked
+}
+
+// addCandidates adds ranked policies to candidates of each connection and marks the ones that have been selected.
+func addCandidates(candidates map[string][]types.PolicyCandidate, ranked map[string]policy.RankedConnectionPolicies, selected map[string]policy.ConnectionPolicy) {
+	for connection, rankedPolicies := range ranked {
+		if _, ok := candidates[connection]; !ok {
+			candidates[connection] = []types.PolicyCandidate{}
+		}
+		for _, rankedPolicy := range rankedPolicies {
+			candidates[connection] = append(candidates[connection], toPolicyCandidate(rankedPolicy, selected[connection] == rankedPolicy.Policy))
+		}
+	}
+}
+
+func toPolicyCandidate(rankedPolicy policy.RankedConnectionPolicy, selected bool) types.PolicyCandidate {
+	return types.PolicyCandidate{
+		Type: string(rankedPolicy.Policy.GetType()),
+		Name: rankedPolicy.Policy.GetMeta().GetName(),
+		Rank: types.Rank{
+			ExactMatches:    rankedPolicy.Rank.ExactMatches,
+			WildcardMatches: rankedPolicy.Rank.WildcardMatches,
+		},
+		Selected: selected,
+	}
+}
+
+func nonNil(candidates []types.PolicyCandidate) []types.PolicyCandidate {
+	if candidates == nil {
+		return []types.PolicyCandidate{}
+	}
+	return candidates
+}
@@ -0,0 +1,121 @@
+package inspect_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	test_runtime "github.com/Kong/kuma/pkg/test/runtime"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/inspect"
+)
+
+var _ = Describe("PoliciesInspector", func() {
+
+	var inspector *inspect.PoliciesInspector
+	var rt core_runtime.Runtime
+
+	BeforeEach(func() {
+		runtime, err := test_runtime.BuilderFor(kuma_cp.DefaultConfig()).Build()
+		Expect(err).ToNot(HaveOccurred())
+		rt = runtime
+
+		inspector = inspect.NewPoliciesInspector(rt.ResourceManager())
+
+		createResources(rt)
+	})
+
+	It("should list candidate policies of each connection of a Dataplane along with their ranks", func() {
+		// given less specific policies that lose to the ones of the `demo` mesh
+		route := &core_mesh.TrafficRouteResource{}
+		Expect(util_proto.FromYAML([]byte(`
+          sources:
+          - match:
+              service: '*'
+          destinations:
+          - match:
+              service: '*'
+          conf:
+          - weight: 100
+            destination:
+              service: '*'
+`), &route.Spec)).To(Succeed())
+		err := rt.ResourceManager().Create(context.Background(), route, core_store.CreateByKey("route-all", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		permission := &core_mesh.TrafficPermissionResource{}
+		Expect(util_proto.FromYAML([]byte(`
+          sources:
+          - match:
+              service: backend
+          destinations:
+          - match:
+              service: web
+`), &permission.Spec)).To(Succeed())
+		err = rt.ResourceManager().Create(context.Background(), permission, core_store.CreateByKey("backend-to-web", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		dataplane := &core_mesh.DataplaneResource{}
+		err = rt.ResourceManager().Get(context.Background(), dataplane, core_store.GetByKey("web-01", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		result, err := inspector.Inspect(context.Background(), dataplane)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		content, err := json.Marshal(result)
+		Expect(err).ToNot(HaveOccurred())
+		actual, err := yaml.JSONToYAML(content)
+		Expect(err).ToNot(HaveOccurred())
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "web-01.policies.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(expected))
+	})
+
+	It("should list no candidates when there are no policies", func() {
+		// given
+		err := rt.ResourceManager().Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("empty", "empty"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		dataplane := &core_mesh.DataplaneResource{}
+		Expect(util_proto.FromYAML([]byte(`
+          networking:
+            inbound:
+            - interface: 192.168.0.2:80:8080
+              tags:
+                service: backend
+            outbound:
+            - interface: :54321
+              service: web
+`), &dataplane.Spec)).To(Succeed())
+		err = rt.ResourceManager().Create(context.Background(), dataplane, core_store.CreateByKey("backend-01", "empty"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		result, err := inspector.Inspect(context.Background(), dataplane)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Inbound).To(HaveLen(1))
+		Expect(result.Inbound[0].Interface).To(Equal("192.168.0.2:80:8080"))
+		Expect(result.Inbound[0].Candidates).To(BeEmpty())
+		Expect(result.Outbound).To(HaveLen(1))
+		Expect(result.Outbound[0].Service).To(Equal("web"))
+		Expect(result.Outbound[0].Candidates).To(BeEmpty())
+	})
+})
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/json"
+)
+
+// DataplaneXds is Envoy configuration that Control Plane generates for a Dataplane.
+type DataplaneXds struct {
+	// Policies that apply to the Dataplane.
+	Policies []MatchedPolicy `json:"policies"`
+	// Listeners, Clusters and ClusterLoadAssignments in the JSON format of Envoy API.
+	Listeners              []json.RawMessage `json:"listeners"`
+	Clusters               []json.RawMessage `json:"clusters"`
+	ClusterLoadAssignments []json.RawMessage `json:"clusterLoadAssignments"`
+}
+
+// MatchedPolicy is a policy that applies to a Dataplane.
+type MatchedPolicy struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Inbound interface the policy applies to, if any.
+	Inbound string `json:"inbound,omitempty"`
+	// Outbound service the policy applies to, if any.
+	Service string `json:"service,omitempty"`
+}
+
+// DataplanePolicies explains which policies apply to connections of a Dataplane and why.
+type DataplanePolicies struct {
+	Inbound  []InboundPolicies  `json:"inbound"`
+	Outbound []OutboundPolicies `json:"outbound"`
+}
+
+// InboundPolicies lists policies that match an inbound interface of a Dataplane.
+type InboundPolicies struct {
+	Interface  string            `json:"interface"`
+	Candidates []PolicyCandidate `json:"candidates"`
+}
+
+// OutboundPolicies lists policies that match connections of a Dataplane to a service.
+type OutboundPolicies struct {
+	Service    string            `json:"service"`
+	Candidates []PolicyCandidate `json:"candidates"`
+}
+
+// PolicyCandidate is a policy that matches a connection.
+type PolicyCandidate struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Rank of the most specific selectors of the policy that match the connection.
+	Rank Rank `json:"rank"`
+	// Selected tells whether the policy is the one that applies to the connection.
+	Selected bool `json:"selected"`
+}
+
+// Rank tells how specific selectors are. Exact matches take precedence over wildcard ones.
+type Rank struct {
+	ExactMatches    int `json:"exactMatches"`
+	WildcardMatches int `json:"wildcardMatches"`
+}
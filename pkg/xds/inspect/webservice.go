@@ -0,0 +1,76 @@
+package inspect
+
+import (
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	rest_errors "github.com/Kong/kuma/pkg/core/rest/errors"
+	"github.com/Kong/kuma/pkg/xds/inspect/types"
+)
+
+var logger = core.Log.WithName("xds-inspect-ws")
+
+type inspectWebservice struct {
+	xdsInspector      *XdsInspector
+	policiesInspector *PoliciesInspector
+	resManager        manager.ResourceManager
+}
+
+// AddRoutes adds endpoints that let inspect Dataplanes from the perspective of Control Plane
+// to a webservice at `/meshes/{mesh}/dataplanes`.
+func AddRoutes(ws *restful.WebService, xdsInspector *XdsInspector, policiesInspector *PoliciesInspector, resManager manager.ResourceManager) {
+	inspectWs := inspectWebservice{
+		xdsInspector:      xdsInspector,
+		policiesInspector: policiesInspector,
+		resManager:        resManager,
+	}
+	ws.Route(ws.GET("/{name}/generated-xds").To(inspectWs.inspectXds).
+		Doc("Envoy configuration that Control Plane generates for a Dataplane").
+		Produces(restful.MIME_JSON).
+		Writes(types.DataplaneXds{}))
+	ws.Route(ws.GET("/{name}/policies").To(inspectWs.inspectPolicies).
+		Doc("Policies that match connections of a Dataplane along with their ranks").
+		Produces(restful.MIME_JSON).
+		Writes(types.DataplanePolicies{}))
+}
+
+func (i *inspectWebservice) inspectXds(request *restful.Request, response *restful.Response) {
+	mesh := request.PathParameter("mesh")
+	name := request.PathParameter("name")
+
+	dataplane := &core_mesh.DataplaneResource{}
+	if err := i.resManager.Get(request.Request.Context(), dataplane, store.GetByKey(name, mesh)); err != nil {
+		rest_errors.HandleError(response, err, "Could not generate xDS resources of the dataplane")
+		return
+	}
+	result, err := i.xdsInspector.Inspect(request.Request.Context(), dataplane)
+	if err != nil {
+		rest_errors.HandleError(response, err, "Could not generate xDS resources of the dataplane")
+		return
+	}
+	if err := response.WriteAsJson(result); err != nil {
+		logger.Error(err, "Could not write the response")
+	}
+}
+
+func (i *inspectWebservice) inspectPolicies(request *restful.Request, response *restful.Response) {
+	mesh := request.PathParameter("mesh")
+	name := request.PathParameter("name")
+
+	dataplane := &core_mesh.DataplaneResource{}
+	if err := i.resManager.Get(request.Request.Context(), dataplane, store.GetByKey(name, mesh)); err != nil {
+		rest_errors.HandleError(response, err, "Could not inspect policies of the dataplane")
+		return
+	}
+	result, err := i.policiesInspector.Inspect(request.Request.Context(), dataplane)
+	if err != nil {
+		rest_errors.HandleError(response, err, "Could not inspect policies of the dataplane")
+		return
+	}
+	if err := response.WriteAsJson(result); err != nil {
+		logger.Error(err, "Could not write the response")
+	}
+}
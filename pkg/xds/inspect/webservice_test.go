@@ -0,0 +1,115 @@
+package inspect_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	"github.com/emicklei/go-restful"
+	"github.com/ghodss/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kumactl_envoyadmin "github.com/Kong/kuma/app/kumactl/pkg/envoyadmin"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	envoy_admin_rest "github.com/Kong/kuma/pkg/core/admin/rest"
+	"github.com/Kong/kuma/pkg/core/rest/errors/types"
+	test_runtime "github.com/Kong/kuma/pkg/test/runtime"
+	"github.com/Kong/kuma/pkg/xds/inspect"
+)
+
+var _ = Describe("Inspect WS", func() {
+
+	var client kumactl_envoyadmin.EnvoyAdminClient
+	var srv *httptest.Server
+
+	BeforeEach(func() {
+		rt, err := test_runtime.BuilderFor(kuma_cp.DefaultConfig()).Build()
+		Expect(err).ToNot(HaveOccurred())
+		xdsInspector, err := inspect.NewXdsInspector(rt)
+		Expect(err).ToNot(HaveOccurred())
+
+		ws := envoy_admin_rest.NewWebservice(rt.EnvoyAdminChannels(), rt.ResourceManager())
+		policiesInspector := inspect.NewPoliciesInspector(rt.ResourceManager())
+		inspect.AddRoutes(ws, xdsInspector, policiesInspector, rt.ResourceManager())
+		container := restful.NewContainer()
+		container.Add(ws)
+		srv = httptest.NewServer(container)
+
+		// wait for the server
+		Eventually(func() error {
+			_, err := http.DefaultClient.Get(srv.URL)
+			return err
+		}).ShouldNot(HaveOccurred())
+
+		client, err = kumactl_envoyadmin.NewEnvoyAdminClient(srv.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		createResources(rt)
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	It("should return xDS resources generated for a dataplane", func() {
+		// when
+		body, err := client.GeneratedXds("demo", "web-01")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		actual, err := yaml.JSONToYAML(body)
+		Expect(err).ToNot(HaveOccurred())
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "web-01.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(expected))
+	})
+
+	It("should return 404 for a dataplane that does not exist", func() {
+		// when
+		_, err := client.GeneratedXds("demo", "mobile-01")
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not generate xDS resources of the dataplane",
+			Details: "Not found",
+		}))
+	})
+
+	It("should return candidate policies of a dataplane", func() {
+		// when
+		policies, err := client.Policies("demo", "web-01")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policies.Inbound).To(HaveLen(1))
+		Expect(policies.Outbound).To(HaveLen(1))
+		Expect(policies.Outbound[0].Service).To(Equal("backend"))
+	})
+
+	It("should return 404 when inspecting policies of a dataplane that does not exist", func() {
+		// when
+		_, err := client.Policies("demo", "mobile-01")
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not inspect policies of the dataplane",
+			Details: "Not found",
+		}))
+	})
+
+	It("should keep routes of the webservice it's added to", func() {
+		// when
+		_, err := client.Inspect("demo", "web-01", "config_dump")
+
+		// then
+		Expect(err).To(Equal(&types.Error{
+			Title:   "Could not inspect the dataplane",
+			Details: "Dataplane web-01 in mesh demo has no reverse channel to this instance of Control Plane",
+		}))
+	})
+})
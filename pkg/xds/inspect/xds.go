@@ -0,0 +1,177 @@
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+
+	"github.com/Kong/kuma/pkg/core/policy"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/inspect/types"
+	xds_server "github.com/Kong/kuma/pkg/xds/server"
+)
+
+// XdsInspector generates Envoy configuration of a Dataplane the same way xDS server does,
+// but without pushing it to the Dataplane.
+type XdsInspector struct {
+	ResourceManager manager.ResourceManager
+	ProxyBuilder    *xds_server.DataplaneProxyBuilder
+	Generator       xds_server.SnapshotGenerator
+}
+
+func NewXdsInspector(rt core_runtime.Runtime) (*XdsInspector, error) {
+	proxyBuilder, err := xds_server.NewDataplaneProxyBuilder(rt)
+	if err != nil {
+		return nil, err
+	}
+	return &XdsInspector{
+		ResourceManager: rt.ResourceManager(),
+		ProxyBuilder:    proxyBuilder,
+		Generator:       xds_server.DefaultSnapshotGenerator(rt),
+	}, nil
+}
+
+// Inspect returns Envoy configuration of a given Dataplane.
+//
+// Metadata that `kuma-dp` reports on connect (e.g. a port of Envoy Admin API) is not taken into account,
+// so resources that depend on it are not generated.
+func (i *XdsInspector) Inspect(ctx context.Context, dataplane *mesh_core.DataplaneResource) (*types.DataplaneXds, error) {
+	envoyCtx, proxy, err := i.ProxyBuilder.Build(ctx, dataplane, nil)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := i.Generator.GenerateSnapshot(envoyCtx, proxy)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := i.matchedPolicies(ctx, proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.DataplaneXds{
+		Policies: policies,
+	}
+	if result.Listeners, err = toJSON(snapshot.Listeners); err != nil {
+		return nil, err
+	}
+	if result.Clusters, err = toJSON(snapshot.Clusters); err != nil {
+		return nil, err
+	}
+	if result.ClusterLoadAssignments, err = toJSON(snapshot.Endpoints); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (i *XdsInspector) matchedPolicies(ctx context.Context, proxy *core_xds.Proxy) ([]types.MatchedPolicy, error) {
+	policies := []types.MatchedPolicy{}
+
+	templates := &mesh_core.ProxyTemplateResourceList{}
+	if err := i.ResourceManager.List(ctx, templates, core_store.ListByMesh(proxy.Id.Mesh)); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve proxy templates")
+	}
+	if template := xds_server.FindBestMatch(proxy, templates.Items); template != nil {
+		policies = append(policies, types.MatchedPolicy{
+			Type: string(template.GetType()),
+			Name: template.GetMeta().GetName(),
+		})
+	}
+
+	for inbound, permissions := range proxy.TrafficPermissions {
+		for _, permission := range permissions.Items {
+			policies = append(policies, types.MatchedPolicy{
+				Type:    string(permission.GetType()),
+				Name:    permission.GetMeta().GetName(),
+				Inbound: inbound,
+			})
+		}
+	}
+	for service, route := range proxy.TrafficRoutes {
+		policies = append(policies, types.MatchedPolicy{
+			Type:    string(route.GetType()),
+			Name:    route.GetMeta().GetName(),
+			Service: service,
+		})
+	}
+	for service, healthCheck := range proxy.HealthChecks {
+		policies = append(policies, types.MatchedPolicy{
+			Type:    string(healthCheck.GetType()),
+			Name:    healthCheck.GetMeta().GetName(),
+			Service: service,
+		})
+	}
+
+	for inbound, inboundLogs := range proxy.InboundLogs {
+		for _, log := range inboundLogs {
+			policies = append(policies, types.MatchedPolicy{
+				Type:    string(log.TrafficLog.GetType()),
+				Name:    log.TrafficLog.GetMeta().GetName(),
+				Inbound: inbound,
+			})
+		}
+	}
+
+	// Proxy keeps only logging backends of outbound interfaces, so TrafficLogs are selected again the same way TrafficLogsMatcher does it
+	logs := &mesh_core.TrafficLogResourceList{}
+	if err := i.ResourceManager.List(ctx, logs, core_store.ListByMesh(proxy.Id.Mesh)); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve traffic logs")
+	}
+	connectionPolicies := make([]policy.ConnectionPolicy, len(logs.Items))
+	for idx, log := range logs.Items {
+		connectionPolicies[idx] = log
+	}
+	for service, log := range policy.SelectOutboundConnectionPolicies(proxy.Dataplane, connectionPolicies) {
+		if _, ok := proxy.Logs[service]; !ok {
+			continue // logging backend is not found
+		}
+		policies = append(policies, types.MatchedPolicy{
+			Type:    string(log.GetType()),
+			Name:    log.GetMeta().GetName(),
+			Service: service,
+		})
+	}
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		a, b := policies[i], policies[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Inbound != b.Inbound {
+			return a.Inbound < b.Inbound
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.Name < b.Name
+	})
+	return policies, nil
+}
+
+// toJSON converts xDS resources into JSON sorted by name to make the output stable.
+func toJSON(resources envoy_cache.Resources) ([]json.RawMessage, error) {
+	names := make([]string, 0, len(resources.Items))
+	for name := range resources.Items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]json.RawMessage, 0, len(names))
+	for _, name := range names {
+		content, err := util_proto.ToJSON(resources.Items[name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not marshal xDS resource %q", name)
+		}
+		result = append(result, content)
+	}
+	return result, nil
+}
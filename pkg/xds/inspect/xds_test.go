@@ -0,0 +1,162 @@
+package inspect_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	test_runtime "github.com/Kong/kuma/pkg/test/runtime"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/inspect"
+)
+
+// createResources creates resources of a mesh `demo` where `web-01` can reach `backend-01`.
+func createResources(rt core_runtime.Runtime) {
+	create := func(res core_model.Resource, name string, spec string) {
+		Expect(util_proto.FromYAML([]byte(spec), res.GetSpec())).To(Succeed())
+		err := rt.ResourceManager().Create(context.Background(), res, core_store.CreateByKey(name, "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	create(&core_mesh.MeshResource{}, "demo", `
+      logging:
+        backends:
+        - name: file
+          file:
+            path: /var/log/access.log
+`)
+	create(&core_mesh.DataplaneResource{}, "web-01", `
+      networking:
+        inbound:
+        - interface: 192.168.0.1:80:8080
+          tags:
+            service: web
+        outbound:
+        - interface: :54321
+          service: backend
+`)
+	create(&core_mesh.DataplaneResource{}, "backend-01", `
+      networking:
+        inbound:
+        - interface: 192.168.0.2:80:8080
+          tags:
+            service: backend
+`)
+	create(&core_mesh.ProxyTemplateResource{}, "web-template", `
+      selectors:
+      - match:
+          service: web
+      conf:
+        imports:
+        - default-proxy
+`)
+	create(&core_mesh.TrafficPermissionResource{}, "allow-all", `
+      sources:
+      - match:
+          service: '*'
+      destinations:
+      - match:
+          service: '*'
+`)
+	create(&core_mesh.TrafficRouteResource{}, "web-to-backend", `
+      sources:
+      - match:
+          service: web
+      destinations:
+      - match:
+          service: backend
+      conf:
+      - weight: 100
+        destination:
+          service: backend
+`)
+	create(&core_mesh.HealthCheckResource{}, "backend-health", `
+      sources:
+      - match:
+          service: '*'
+      destinations:
+      - match:
+          service: backend
+      conf:
+        activeChecks:
+          interval: 10s
+          timeout: 2s
+          unhealthyThreshold: 3
+          healthyThreshold: 1
+`)
+	create(&core_mesh.TrafficLogResource{}, "log-all", `
+      sources:
+      - match:
+          service: '*'
+      destinations:
+      - match:
+          service: '*'
+      conf:
+        backend: file
+`)
+}
+
+var _ = Describe("XdsInspector", func() {
+
+	var inspector *inspect.XdsInspector
+	var rt core_runtime.Runtime
+
+	BeforeEach(func() {
+		runtime, err := test_runtime.BuilderFor(kuma_cp.DefaultConfig()).Build()
+		Expect(err).ToNot(HaveOccurred())
+		rt = runtime
+
+		inspector, err = inspect.NewXdsInspector(rt)
+		Expect(err).ToNot(HaveOccurred())
+
+		createResources(rt)
+	})
+
+	It("should generate xDS resources of a Dataplane along with matched policies", func() {
+		// given
+		dataplane := &core_mesh.DataplaneResource{}
+		err := rt.ResourceManager().Get(context.Background(), dataplane, core_store.GetByKey("web-01", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		result, err := inspector.Inspect(context.Background(), dataplane)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		content, err := json.Marshal(result)
+		Expect(err).ToNot(HaveOccurred())
+		actual, err := yaml.JSONToYAML(content)
+		Expect(err).ToNot(HaveOccurred())
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "web-01.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(expected))
+	})
+
+	It("should fail when a Mesh of a Dataplane doesn't exist", func() {
+		// given
+		dataplane := &core_mesh.DataplaneResource{}
+		err := rt.ResourceManager().Get(context.Background(), dataplane, core_store.GetByKey("web-01", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		err = rt.ResourceManager().Delete(context.Background(), &core_mesh.MeshResource{}, core_store.DeleteByKey("demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		_, err = inspector.Inspect(context.Background(), dataplane)
+
+		// then
+		Expect(err).To(MatchError("there should be a mesh of name demo. Found 0 meshes of given name"))
+	})
+})
@@ -4,22 +4,19 @@ import (
 	"context"
 	"time"
 
-	"github.com/pkg/errors"
-
+	config_core "github.com/Kong/kuma/pkg/config/core"
 	"github.com/Kong/kuma/pkg/core"
-	"github.com/Kong/kuma/pkg/core/logs"
-	"github.com/Kong/kuma/pkg/core/permissions"
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
 	"github.com/Kong/kuma/pkg/core/xds"
+	sds_server "github.com/Kong/kuma/pkg/sds/server"
+	"github.com/Kong/kuma/pkg/tokens/builtin"
 	util_watchdog "github.com/Kong/kuma/pkg/util/watchdog"
 	util_xds "github.com/Kong/kuma/pkg/util/xds"
 	xds_bootstrap "github.com/Kong/kuma/pkg/xds/bootstrap"
-	xds_context "github.com/Kong/kuma/pkg/xds/context"
 	xds_sync "github.com/Kong/kuma/pkg/xds/sync"
 	xds_template "github.com/Kong/kuma/pkg/xds/template"
-	xds_topology "github.com/Kong/kuma/pkg/xds/topology"
 
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 
@@ -40,42 +37,99 @@ func SetupServer(rt core_runtime.Runtime) error {
 		return err
 	}
 	callbacks := util_xds.CallbacksChain{
+		util_xds.StatsCallbacks{Server: "ads"},
+		NewDataplaneConnectionsTracker(),
 		tracker,
 		metadataTracker,
 		DefaultDataplaneStatusTracker(rt),
 	}
 
+	registrar, err := DefaultDataplaneRegistrar(rt)
+	if err != nil {
+		return err
+	}
+
+	authenticator, err := sds_server.DefaultAuthenticator(rt)
+	if err != nil {
+		return err
+	}
+
 	srv := NewServer(rt.XDS().Cache(), callbacks)
+	if registrar != nil {
+		if err := rt.Add(DefaultDataplaneLifecycle(rt)); err != nil {
+			return err
+		}
+	}
 	return core_runtime.Add(
 		rt,
 		// xDS gRPC API
-		&grpcServer{srv, rt.Config().XdsServer.GrpcPort},
+		&grpcServer{
+			server:     srv,
+			metrics:    NewMetricsService(rt.ResourceManager(), rt.StatsAggregator(), authenticator),
+			envoyAdmin: NewEnvoyAdminService(rt.EnvoyAdminChannels(), authenticator),
+			port:       rt.Config().XdsServer.GrpcPort,
+		},
 		// diagnostics server
 		&diagnosticsServer{rt.Config().XdsServer.DiagnosticsPort},
 		// bootstrap server
 		&xds_bootstrap.BootstrapServer{
 			Port:      rt.Config().BootstrapServer.Port,
 			Generator: xds_bootstrap.NewDefaultBootstrapGenerator(rt.ResourceManager(), rt.Config().BootstrapServer.Params),
+			Registrar: registrar,
 		},
 	)
 }
 
+// DefaultDataplaneRegistrar returns a registrar of Dataplanes included into bootstrap requests.
+// On Kubernetes, Dataplanes are generated from Pods, so `kuma-dp` is not allowed to register them.
+// Registration is authorized by a dataplane token, so it is not supported
+// unless Dataplane Token Server is enabled.
+func DefaultDataplaneRegistrar(rt core_runtime.Runtime) (xds_bootstrap.DataplaneRegistrar, error) {
+	if rt.Config().Environment != config_core.UniversalEnvironment {
+		return nil, nil
+	}
+	if !rt.Config().DataplaneTokenServer.Enabled {
+		return nil, nil
+	}
+	issuer, err := builtin.NewDataplaneTokenIssuer(rt)
+	if err != nil {
+		return nil, err
+	}
+	return xds_bootstrap.NewDataplaneRegistrar(rt.ResourceManager(), issuer), nil
+}
+
+// DefaultDataplaneLifecycle returns a component that removes self-registered Dataplanes that are gone.
+func DefaultDataplaneLifecycle(rt core_runtime.Runtime) *DataplaneLifecycle {
+	gracePeriod := rt.Config().XdsServer.DataplaneDeregistrationGracePeriod
+	return NewDataplaneLifecycle(rt.ResourceManager(), gracePeriod, func() *time.Ticker {
+		// there is no point in checking more often than DataplaneInsights get updated
+		interval := gracePeriod / 2
+		if interval < rt.Config().XdsServer.DataplaneStatusFlushInterval {
+			interval = rt.Config().XdsServer.DataplaneStatusFlushInterval
+		}
+		return time.NewTicker(interval)
+	})
+}
+
 func DefaultReconciler(rt core_runtime.Runtime) SnapshotReconciler {
 	return &reconciler{
-		&templateSnapshotGenerator{
-			ProxyTemplateResolver: &simpleProxyTemplateResolver{
-				ResourceManager:      rt.ResourceManager(),
-				DefaultProxyTemplate: xds_template.DefaultProxyTemplate,
-			},
-		},
+		DefaultSnapshotGenerator(rt),
 		&simpleSnapshotCacher{rt.XDS().Hasher(), rt.XDS().Cache()},
 	}
 }
 
+// DefaultSnapshotGenerator returns a generator of Envoy configuration that is used by the xDS server.
+func DefaultSnapshotGenerator(rt core_runtime.Runtime) SnapshotGenerator {
+	return &templateSnapshotGenerator{
+		ProxyTemplateResolver: &simpleProxyTemplateResolver{
+			ResourceManager:      rt.ResourceManager(),
+			DefaultProxyTemplate: xds_template.DefaultProxyTemplate,
+		},
+	}
+}
+
 func DefaultDataplaneSyncTracker(rt core_runtime.Runtime, reconciler SnapshotReconciler, metadataTracker *DataplaneMetadataTracker) (envoy_xds.Callbacks, error) {
-	permissionsMatcher := permissions.TrafficPermissionsMatcher{ResourceManager: rt.ResourceManager()}
-	logsMatcher := logs.TrafficLogsMatcher{ResourceManager: rt.ResourceManager()}
-	envoyCpCtx, err := xds_context.BuildControlPlaneContext(rt.Config())
+	proxyBuilder, err := NewDataplaneProxyBuilder(rt)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +139,7 @@ func DefaultDataplaneSyncTracker(rt core_runtime.Runtime, reconciler SnapshotRec
 			NewTicker: func() *time.Ticker {
 				return time.NewTicker(rt.Config().XdsServer.DataplaneConfigurationRefreshInterval)
 			},
-			OnTick: func() error {
+			OnTick: measureGeneration(func() error {
 				ctx := context.Background()
 				dataplane := &mesh_core.DataplaneResource{}
 				proxyID := xds.FromResourceKey(key)
@@ -97,64 +151,14 @@ func DefaultDataplaneSyncTracker(rt core_runtime.Runtime, reconciler SnapshotRec
 					return err
 				}
 
-				meshList := mesh_core.MeshResourceList{}
-				if err := rt.ResourceManager().List(ctx, &meshList, core_store.ListByMesh(proxyID.Mesh)); err != nil {
-					return err
-				}
-				if len(meshList.Items) != 1 {
-					return errors.Errorf("there should be a mesh of name %s. Found %d meshes of given name", proxyID.Mesh, len(meshList.Items))
-				}
-				envoyCtx := xds_context.Context{
-					ControlPlane: envoyCpCtx,
-					Mesh: xds_context.MeshContext{
-						Resource: meshList.Items[0],
-					},
-				}
-
-				// pick a single the most specific route for each outbound interface
-				routes, err := xds_topology.GetRoutes(ctx, dataplane, rt.ResourceManager())
-				if err != nil {
-					return err
-				}
-
-				// create creates a map of selectors to match other dataplanes reachable via given routes
-				destinations := xds_topology.BuildDestinationMap(dataplane, routes)
-
-				// resolve all endpoints that match given selectors
-				outbound, err := xds_topology.GetOutboundTargets(ctx, dataplane, destinations, rt.ResourceManager())
+				envoyCtx, proxy, err := proxyBuilder.Build(ctx, dataplane, metadataTracker.Metadata(streamId))
 				if err != nil {
 					return err
 				}
-
-				healthChecks, err := xds_topology.GetHealthChecks(ctx, dataplane, destinations, rt.ResourceManager())
-				if err != nil {
-					return err
-				}
-
-				matchedPermissions, err := permissionsMatcher.Match(ctx, dataplane)
-				if err != nil {
-					return err
-				}
-
-				matchedLogs, err := logsMatcher.Match(ctx, dataplane)
-				if err != nil {
-					return err
-				}
-
-				proxy := xds.Proxy{
-					Id:                 proxyID,
-					Dataplane:          dataplane,
-					TrafficPermissions: matchedPermissions,
-					TrafficRoutes:      routes,
-					OutboundSelectors:  destinations,
-					OutboundTargets:    outbound,
-					HealthChecks:       healthChecks,
-					Logs:               matchedLogs,
-					Metadata:           metadataTracker.Metadata(streamId),
-				}
-				return reconciler.Reconcile(envoyCtx, &proxy)
-			},
+				return reconciler.Reconcile(envoyCtx, proxy)
+			}),
 			OnError: func(err error) {
+				xdsGenerationErrors.Inc()
 				log.Error(err, "OnTick() failed")
 			},
 		}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+)
+
+var (
+	lifecycleLog = xdsServerLog.WithName("dataplane-lifecycle")
+)
+
+// DataplaneLifecycle removes Dataplanes registered by `kuma-dp` itself
+// once a dataplane disconnects and doesn't reconnect within a grace period.
+//
+// Dataplanes created or changed by other means (e.g., `kumactl apply`) are left intact.
+//
+// Both the mark of a self-registered Dataplane and the time of disconnect are taken
+// from a DataplaneInsight, so a Dataplane is removed regardless of which
+// Control Plane instance it was connected to.
+// A Dataplane whose latest xDS stream has never been closed (e.g., because
+// Control Plane instance has crashed) is not removed.
+type DataplaneLifecycle struct {
+	resManager  core_manager.ResourceManager
+	gracePeriod time.Duration
+	newTicker   func() *time.Ticker
+}
+
+func NewDataplaneLifecycle(resManager core_manager.ResourceManager, gracePeriod time.Duration, newTicker func() *time.Ticker) *DataplaneLifecycle {
+	return &DataplaneLifecycle{
+		resManager:  resManager,
+		gracePeriod: gracePeriod,
+		newTicker:   newTicker,
+	}
+}
+
+var _ core_runtime.Component = &DataplaneLifecycle{}
+
+func (d *DataplaneLifecycle) Start(stop <-chan struct{}) error {
+	ticker := d.newTicker()
+	defer ticker.Stop()
+
+	lifecycleLog.Info("starting", "gracePeriod", d.gracePeriod)
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.cleanup(context.Background()); err != nil {
+				lifecycleLog.Error(err, "unable to remove self-registered Dataplanes that are gone")
+			}
+		case <-stop:
+			lifecycleLog.Info("stopping")
+			return nil
+		}
+	}
+}
+
+func (d *DataplaneLifecycle) cleanup(ctx context.Context) error {
+	meshes := &core_mesh.MeshResourceList{}
+	if err := d.resManager.List(ctx, meshes); err != nil {
+		return err
+	}
+	for _, mesh := range meshes.Items {
+		insights := &core_mesh.DataplaneInsightResourceList{}
+		if err := d.resManager.List(ctx, insights, core_store.ListByMesh(mesh.Meta.GetName())); err != nil {
+			return err
+		}
+		for _, insight := range insights.Items {
+			if insight.Spec.GetSelfRegistration() == nil || !d.isGone(insight) {
+				continue
+			}
+			d.deregister(ctx, insight)
+		}
+	}
+	return nil
+}
+
+// isGone tells whether a dataplane has disconnected more than a grace period ago.
+func (d *DataplaneLifecycle) isGone(insight *core_mesh.DataplaneInsightResource) bool {
+	lastSeen, err := ptypes.Timestamp(insight.Spec.GetSelfRegistration().GetRegistrationTime())
+	if err != nil {
+		return false
+	}
+	if subscription, _ := insight.Spec.GetLatestSubscription(); subscription != nil {
+		if subscription.DisconnectTime == nil {
+			return false
+		}
+		disconnectTime, err := ptypes.Timestamp(subscription.DisconnectTime)
+		if err != nil {
+			return false
+		}
+		if disconnectTime.After(lastSeen) {
+			lastSeen = disconnectTime
+		}
+	}
+	return now().Sub(lastSeen) > d.gracePeriod
+}
+
+func (d *DataplaneLifecycle) deregister(ctx context.Context, insight *core_mesh.DataplaneInsightResource) {
+	key := core_model.MetaToResourceKey(insight.Meta)
+	log := lifecycleLog.WithValues("dataplaneKey", key)
+
+	dataplane := &core_mesh.DataplaneResource{}
+	err := d.resManager.Get(ctx, dataplane, core_store.GetBy(key))
+	switch {
+	case core_store.IsResourceNotFound(err):
+	case err != nil:
+		log.Error(err, "unable to get a self-registered Dataplane")
+		return
+	case !insight.Spec.IsSelfRegisteredAt(dataplane.Meta.GetVersion()):
+		// Dataplane has been changed by other means, it's up to a user to remove it
+		return
+	default:
+		if err := d.resManager.Delete(ctx, dataplane, core_store.DeleteBy(key)); err != nil && !core_store.IsResourceNotFound(err) {
+			log.Error(err, "unable to remove a self-registered Dataplane")
+			return
+		}
+	}
+	if err := d.resManager.Delete(ctx, &core_mesh.DataplaneInsightResource{}, core_store.DeleteBy(key)); err != nil && !core_store.IsResourceNotFound(err) {
+		log.Error(err, "unable to remove DataplaneInsight of a self-registered Dataplane")
+		return
+	}
+	log.Info("removed a self-registered Dataplane")
+}
@@ -0,0 +1,179 @@
+package server_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/server"
+)
+
+var _ = Describe("Dataplane Lifecycle", func() {
+
+	gracePeriod := 1 * time.Minute
+
+	var resManager core_manager.ResourceManager
+	var stop chan struct{}
+	var dataplane *core_mesh.DataplaneResource
+
+	BeforeEach(func() {
+		resManager = core_manager.NewResourceManager(memory.NewStore())
+
+		err := resManager.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		dataplane = &core_mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+						Interface: "192.168.0.1:80:8080",
+						Tags: map[string]string{
+							"service": "backend",
+						},
+					}},
+				},
+			},
+		}
+		err = resManager.Create(context.Background(), dataplane, core_store.CreateByKey("example", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		stop = make(chan struct{})
+		lifecycle := server.NewDataplaneLifecycle(resManager, gracePeriod, func() *time.Ticker {
+			return time.NewTicker(10 * time.Millisecond)
+		})
+		go func() {
+			defer GinkgoRecover()
+			Expect(lifecycle.Start(stop)).To(Succeed())
+		}()
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	createInsight := func(insight mesh_proto.DataplaneInsight) {
+		err := resManager.Create(context.Background(), &core_mesh.DataplaneInsightResource{Spec: insight}, core_store.CreateByKey("example", "default"))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	selfRegistration := func(version string) *mesh_proto.SelfRegistration {
+		return &mesh_proto.SelfRegistration{
+			DataplaneVersion: version,
+			RegistrationTime: util_proto.MustTimestampProto(time.Now().Add(-10 * time.Minute)),
+		}
+	}
+
+	subscription := func(disconnectedAgo *time.Duration) *mesh_proto.DiscoverySubscription {
+		s := &mesh_proto.DiscoverySubscription{
+			Id:                     "1",
+			ControlPlaneInstanceId: "cp-1",
+			ConnectTime:            util_proto.MustTimestampProto(time.Now().Add(-5 * time.Minute)),
+			Status:                 mesh_proto.NewSubscriptionStatus(),
+		}
+		if disconnectedAgo != nil {
+			s.DisconnectTime = util_proto.MustTimestampProto(time.Now().Add(-*disconnectedAgo))
+		}
+		return s
+	}
+
+	durationOf := func(d time.Duration) *time.Duration {
+		return &d
+	}
+
+	dataplaneExists := func() bool {
+		err := resManager.Get(context.Background(), &core_mesh.DataplaneResource{}, core_store.GetByKey("example", "default"))
+		if core_store.IsResourceNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+
+	insightExists := func() bool {
+		err := resManager.Get(context.Background(), &core_mesh.DataplaneInsightResource{}, core_store.GetByKey("example", "default"))
+		if core_store.IsResourceNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+
+	It("should remove a self-registered Dataplane after the grace period", func() {
+		// when
+		createInsight(mesh_proto.DataplaneInsight{
+			SelfRegistration: selfRegistration(dataplane.Meta.GetVersion()),
+			Subscriptions: []*mesh_proto.DiscoverySubscription{
+				subscription(durationOf(2 * gracePeriod)),
+			},
+		})
+
+		// then
+		Eventually(dataplaneExists, "1s", "10ms").Should(BeFalse())
+		// and
+		Eventually(insightExists, "1s", "10ms").Should(BeFalse())
+	})
+
+	It("should not remove a self-registered Dataplane within the grace period", func() {
+		// when
+		createInsight(mesh_proto.DataplaneInsight{
+			SelfRegistration: selfRegistration(dataplane.Meta.GetVersion()),
+			Subscriptions: []*mesh_proto.DiscoverySubscription{
+				subscription(durationOf(gracePeriod / 2)),
+			},
+		})
+
+		// then
+		Consistently(dataplaneExists, "200ms", "10ms").Should(BeTrue())
+	})
+
+	It("should not remove a self-registered Dataplane that is connected", func() {
+		// when
+		createInsight(mesh_proto.DataplaneInsight{
+			SelfRegistration: selfRegistration(dataplane.Meta.GetVersion()),
+			Subscriptions: []*mesh_proto.DiscoverySubscription{
+				subscription(nil),
+			},
+		})
+
+		// then
+		Consistently(dataplaneExists, "200ms", "10ms").Should(BeTrue())
+	})
+
+	It("should not remove a Dataplane that hasn't been registered by kuma-dp", func() {
+		// when
+		createInsight(mesh_proto.DataplaneInsight{
+			Subscriptions: []*mesh_proto.DiscoverySubscription{
+				subscription(durationOf(2 * gracePeriod)),
+			},
+		})
+
+		// then
+		Consistently(dataplaneExists, "200ms", "10ms").Should(BeTrue())
+	})
+
+	It("should not remove a self-registered Dataplane that has been changed by other means", func() {
+		// given
+		registeredVersion := dataplane.Meta.GetVersion()
+		dataplane.Spec.Networking.Inbound[0].Interface = "192.168.0.2:80:8080"
+		Expect(resManager.Update(context.Background(), dataplane)).To(Succeed())
+
+		// when
+		createInsight(mesh_proto.DataplaneInsight{
+			SelfRegistration: selfRegistration(registeredVersion),
+			Subscriptions: []*mesh_proto.DiscoverySubscription{
+				subscription(durationOf(2 * gracePeriod)),
+			},
+		})
+
+		// then
+		Consistently(dataplaneExists, "200ms", "10ms").Should(BeTrue())
+	})
+})
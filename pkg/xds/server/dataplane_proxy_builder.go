@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core/logs"
+	"github.com/Kong/kuma/pkg/core/permissions"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	"github.com/Kong/kuma/pkg/core/xds"
+	xds_context "github.com/Kong/kuma/pkg/xds/context"
+	xds_topology "github.com/Kong/kuma/pkg/xds/topology"
+)
+
+// DataplaneProxyBuilder resolves everything that is needed to generate Envoy configuration of a Dataplane,
+// i.e. its Mesh, policies that apply to it and endpoints of services it can reach.
+type DataplaneProxyBuilder struct {
+	ResourceManager     manager.ResourceManager
+	PermissionsMatcher  permissions.TrafficPermissionsMatcher
+	LogsMatcher         logs.TrafficLogsMatcher
+	ControlPlaneContext *xds_context.ControlPlaneContext
+}
+
+func NewDataplaneProxyBuilder(rt core_runtime.Runtime) (*DataplaneProxyBuilder, error) {
+	envoyCpCtx, err := xds_context.BuildControlPlaneContext(rt.Config())
+	if err != nil {
+		return nil, err
+	}
+	return &DataplaneProxyBuilder{
+		ResourceManager:     rt.ResourceManager(),
+		PermissionsMatcher:  permissions.TrafficPermissionsMatcher{ResourceManager: rt.ResourceManager()},
+		LogsMatcher:         logs.TrafficLogsMatcher{ResourceManager: rt.ResourceManager()},
+		ControlPlaneContext: envoyCpCtx,
+	}, nil
+}
+
+// Build returns a Proxy of a given Dataplane along with the context it should be generated in.
+// Metadata is optional, it is known only for Dataplanes that are connected to the xDS server.
+func (b *DataplaneProxyBuilder) Build(ctx context.Context, dataplane *mesh_core.DataplaneResource, metadata *xds.DataplaneMetadata) (xds_context.Context, *xds.Proxy, error) {
+	proxyID := xds.FromResourceKey(core_model.MetaToResourceKey(dataplane.GetMeta()))
+
+	meshList := mesh_core.MeshResourceList{}
+	if err := b.ResourceManager.List(ctx, &meshList, core_store.ListByMesh(proxyID.Mesh)); err != nil {
+		return xds_context.Context{}, nil, err
+	}
+	if len(meshList.Items) != 1 {
+		return xds_context.Context{}, nil, errors.Errorf("there should be a mesh of name %s. Found %d meshes of given name", proxyID.Mesh, len(meshList.Items))
+	}
+	envoyCtx := xds_context.Context{
+		ControlPlane: b.ControlPlaneContext,
+		Mesh: xds_context.MeshContext{
+			Resource: meshList.Items[0],
+		},
+	}
+
+	// pick a single the most specific route for each outbound interface
+	routes, err := xds_topology.GetRoutes(ctx, dataplane, b.ResourceManager)
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	// create creates a map of selectors to match other dataplanes reachable via given routes
+	destinations := xds_topology.BuildDestinationMap(dataplane, routes)
+
+	// resolve all endpoints that match given selectors
+	outbound, err := xds_topology.GetOutboundTargets(ctx, dataplane, destinations, b.ResourceManager)
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	healthChecks, err := xds_topology.GetHealthChecks(ctx, dataplane, destinations, b.ResourceManager)
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	matchedPermissions, err := b.PermissionsMatcher.Match(ctx, dataplane)
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	matchedLogs, err := b.LogsMatcher.Match(ctx, dataplane)
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	matchedInboundLogs, err := b.LogsMatcher.MatchInbound(ctx, dataplane)
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	proxy := &xds.Proxy{
+		Id:                 proxyID,
+		Dataplane:          dataplane,
+		TrafficPermissions: matchedPermissions,
+		TrafficRoutes:      routes,
+		OutboundSelectors:  destinations,
+		OutboundTargets:    outbound,
+		HealthChecks:       healthChecks,
+		Logs:               matchedLogs,
+		InboundLogs:        matchedInboundLogs,
+		Metadata:           metadata,
+	}
+	return envoyCtx, proxy, nil
+}
@@ -121,6 +121,8 @@ func (c *dataplaneStatusTracker) OnStreamRequest(streamID int64, req *envoy.Disc
 	if state.dataplaneId == (core_model.ResourceKey{}) {
 		if id, err := core_xds.ParseProxyId(req.Node); err == nil {
 			state.dataplaneId = core_model.ResourceKey{Mesh: id.Mesh, Name: id.Name}
+			// status of Envoy process is reported by kuma-dp via node metadata
+			state.subscription.Envoy = core_xds.DataplaneMetadataFromNode(req.Node).GetEnvoyProcess()
 			// kick off async Dataplane status flusher
 			go c.createStatusSink(state).Start(state.stop)
 		} else {
@@ -15,6 +15,7 @@ import (
 
 	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	pstruct "github.com/golang/protobuf/ptypes/struct"
 
 	test_runtime "github.com/Kong/kuma/pkg/test/runtime"
 )
@@ -157,6 +158,65 @@ var _ = Describe("DataplaneStatusTracker", func() {
 `))
 	})
 
+	It("should record status of Envoy process reported by kuma-dp", func() {
+		// given
+		streamID := int64(1)
+
+		By("simulating start of ADS subscription")
+		// when
+		err := tracker.OnStreamOpen(ctx, streamID, "")
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		accessor, _ := tracker.GetStatusAccessor(streamID)
+		// then
+		Expect(accessor).ToNot(BeNil())
+
+		By("simulating initial LDS request")
+		// when
+		discoveryRequest := &envoy.DiscoveryRequest{
+			Node: &envoy_core.Node{
+				Id: "default.example-001",
+				Metadata: &pstruct.Struct{
+					Fields: map[string]*pstruct.Value{
+						"dataplane.envoy.crashes": {
+							Kind: &pstruct.Value_StringValue{StringValue: "2"},
+						},
+						"dataplane.envoy.lastExitCode": {
+							Kind: &pstruct.Value_StringValue{StringValue: "1"},
+						},
+						"dataplane.envoy.restartEpoch": {
+							Kind: &pstruct.Value_StringValue{StringValue: "0"},
+						},
+					},
+				},
+			},
+			TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+		}
+		err = tracker.OnStreamRequest(streamID, discoveryRequest)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		_, subscription := accessor.GetStatus()
+		// then
+		Expect(util_proto.ToYAML(subscription)).To(MatchYAML(`
+        connectTime: "2019-07-01T00:00:00Z"
+        controlPlaneInstanceId: test
+        envoy:
+          crashes: 2
+          lastExitCode: 1
+        id: a9680ef2-aa57-11e9-85b6-acde48001122
+        status:
+          cds: {}
+          eds: {}
+          lds: {}
+          rds: {}
+          total: {}
+`))
+	})
+
 	type testCase struct {
 		TypeUrl                    string
 		ExpectedStatsAfterResponse string
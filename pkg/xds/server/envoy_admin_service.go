@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+	core_admin "github.com/Kong/kuma/pkg/core/admin"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+)
+
+var (
+	envoyAdminServiceLog = xdsServerLog.WithName("envoy-admin-service")
+)
+
+// envoyAdminService accepts reverse channels opened by `kuma-dp`,
+// so that Control Plane could query Envoy Admin API of connected Dataplanes.
+//
+// A reverse channel is authenticated the same way as SDS requests, i.e. by a dataplane token
+// that `kuma-dp` sends in `authorization` metadata.
+type envoyAdminService struct {
+	channels      core_admin.EnvoyAdminChannels
+	authenticator sds_auth.Authenticator
+}
+
+var _ observability_proto.EnvoyAdminServiceServer = &envoyAdminService{}
+
+func NewEnvoyAdminService(channels core_admin.EnvoyAdminChannels, authenticator sds_auth.Authenticator) observability_proto.EnvoyAdminServiceServer {
+	return &envoyAdminService{
+		channels:      channels,
+		authenticator: authenticator,
+	}
+}
+
+func (s *envoyAdminService) StreamEnvoyAdminRequests(stream observability_proto.EnvoyAdminService_StreamEnvoyAdminRequestsServer) error {
+	hello, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	proxyId, err := core_xds.ParseProxyId(hello.GetNode())
+	if err != nil {
+		return errors.Wrap(err, "could not identify a Dataplane that opened a reverse channel")
+	}
+	key := proxyId.ToResourceKey()
+
+	log := envoyAdminServiceLog.WithValues("dataplane", key)
+	if err := s.authenticate(stream.Context(), *proxyId); err != nil {
+		log.Info("rejected a reverse channel", "reason", err.Error())
+		return err
+	}
+	log.V(1).Info("reverse channel opened")
+	channel := s.channels.Open(key, stream)
+	defer func() {
+		channel.Close()
+		log.V(1).Info("reverse channel closed")
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		channel.Deliver(resp)
+	}
+}
+
+func (s *envoyAdminService) authenticate(ctx context.Context, proxyId core_xds.ProxyId) error {
+	credential, err := sds_auth.ExtractCredential(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not authenticate a Dataplane that opened a reverse channel")
+	}
+	if _, err := s.authenticator.Authenticate(ctx, proxyId, credential); err != nil {
+		return errors.Wrap(err, "could not authenticate a Dataplane that opened a reverse channel")
+	}
+	return nil
+}
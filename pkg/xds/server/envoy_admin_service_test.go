@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc"
+
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+	core_admin "github.com/Kong/kuma/pkg/core/admin"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+)
+
+type envoyAdminStream struct {
+	ctx       context.Context
+	responses chan *observability_proto.EnvoyAdminResponse
+	requests  chan *observability_proto.EnvoyAdminRequest
+	grpc.ServerStream
+}
+
+func (s *envoyAdminStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *envoyAdminStream) Recv() (*observability_proto.EnvoyAdminResponse, error) {
+	resp, ok := <-s.responses
+	if !ok {
+		return nil, io.EOF
+	}
+	return resp, nil
+}
+
+func (s *envoyAdminStream) Send(req *observability_proto.EnvoyAdminRequest) error {
+	s.requests <- req
+	return nil
+}
+
+var _ = Describe("EnvoyAdminService", func() {
+
+	key := core_model.ResourceKey{Mesh: "default", Name: "backend-01"}
+
+	var channels core_admin.EnvoyAdminChannels
+	var done chan error
+
+	BeforeEach(func() {
+		channels = core_admin.NewEnvoyAdminChannels()
+		done = make(chan error, 1)
+	})
+
+	open := func(token string) *envoyAdminStream {
+		stream := &envoyAdminStream{
+			ctx:       authenticatedContext(token),
+			responses: make(chan *observability_proto.EnvoyAdminResponse),
+			requests:  make(chan *observability_proto.EnvoyAdminRequest),
+		}
+		go func() {
+			done <- NewEnvoyAdminService(channels, &staticAuthenticator{credential: "token"}).StreamEnvoyAdminRequests(stream)
+		}()
+		return stream
+	}
+
+	It("should route requests to Envoy Admin API through a reverse channel", func() {
+		// given
+		stream := open("token")
+
+		// when kuma-dp introduces itself
+		stream.responses <- &observability_proto.EnvoyAdminResponse{
+			Node: &envoy_core.Node{Id: "default.backend-01"},
+		}
+
+		// and kuma-dp answers a request
+		go func() {
+			defer GinkgoRecover()
+			req := <-stream.requests
+			Expect(req.Path).To(Equal("/config_dump"))
+			stream.responses <- &observability_proto.EnvoyAdminResponse{
+				RequestId: req.RequestId,
+				Body:      []byte(`{"configs":[]}`),
+			}
+		}()
+
+		// then
+		var body []byte
+		Eventually(func() error {
+			var err error
+			body, err = channels.Get(context.Background(), key, core_admin.ConfigDumpPath)
+			return err
+		}).ShouldNot(HaveOccurred())
+		Expect(string(body)).To(Equal(`{"configs":[]}`))
+
+		// when kuma-dp closes the stream
+		close(stream.responses)
+
+		// then
+		Eventually(done).Should(Receive(BeNil()))
+		_, err := channels.Get(context.Background(), key, core_admin.ConfigDumpPath)
+		Expect(core_admin.IsNotConnected(err)).To(BeTrue())
+	})
+
+	It("should reject a stream of an unidentified Dataplane", func() {
+		// given
+		stream := open("token")
+
+		// when
+		stream.responses <- &observability_proto.EnvoyAdminResponse{}
+
+		// then
+		var err error
+		Eventually(done).Should(Receive(&err))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("could not identify a Dataplane that opened a reverse channel"))
+	})
+
+	It("should reject a stream that is not authenticated", func() {
+		// given
+		stream := open("forged-token")
+
+		// when
+		stream.responses <- &observability_proto.EnvoyAdminResponse{
+			Node: &envoy_core.Node{Id: "default.backend-01"},
+		}
+
+		// then
+		var err error
+		Eventually(done).Should(Receive(&err))
+		Expect(err).To(MatchError("could not authenticate a Dataplane that opened a reverse channel: invalid credential"))
+		// and
+		_, err = channels.Get(context.Background(), key, core_admin.ConfigDumpPath)
+		Expect(core_admin.IsNotConnected(err)).To(BeTrue())
+	})
+})
@@ -5,22 +5,31 @@ import (
 	"net"
 
 	envoy_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	envoy_metrics "github.com/envoyproxy/go-control-plane/envoy/service/metrics/v2"
 	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
 	"google.golang.org/grpc"
 
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
 	"github.com/Kong/kuma/pkg/core"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
 )
 
-const grpcMaxConcurrentStreams = 1000000
+const (
+	grpcMaxConcurrentStreams = 1000000
+	// grpcMaxRecvMsgSize allows for responses of Envoy Admin API, e.g. config dumps of large meshes,
+	// that exceed the default limit of 4MB.
+	grpcMaxRecvMsgSize = 64 * 1024 * 1024
+)
 
 var (
 	grpcServerLog = core.Log.WithName("xds-server").WithName("grpc")
 )
 
 type grpcServer struct {
-	server envoy_xds.Server
-	port   int
+	server     envoy_xds.Server
+	metrics    envoy_metrics.MetricsServiceServer
+	envoyAdmin observability_proto.EnvoyAdminServiceServer
+	port       int
 }
 
 // Make sure that grpcServer implements all relevant interfaces
@@ -31,6 +40,7 @@ var (
 func (s *grpcServer) Start(stop <-chan struct{}) error {
 	var grpcOptions []grpc.ServerOption
 	grpcOptions = append(grpcOptions, grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams))
+	grpcOptions = append(grpcOptions, grpc.MaxRecvMsgSize(grpcMaxRecvMsgSize))
 	grpcServer := grpc.NewServer(grpcOptions...)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
@@ -40,6 +50,8 @@ func (s *grpcServer) Start(stop <-chan struct{}) error {
 
 	// register services
 	envoy_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, s.server)
+	envoy_metrics.RegisterMetricsServiceServer(grpcServer, s.metrics)
+	observability_proto.RegisterEnvoyAdminServiceServer(grpcServer, s.envoyAdmin)
 
 	errChan := make(chan error)
 	go func() {
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc"
+
+	observability_proto "github.com/Kong/kuma/api/observability/v1alpha1"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/test"
+
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+)
+
+// recordingEnvoyAdminService records responses of a Dataplane that opens a reverse channel.
+type recordingEnvoyAdminService struct {
+	responses chan *observability_proto.EnvoyAdminResponse
+}
+
+func (s *recordingEnvoyAdminService) StreamEnvoyAdminRequests(stream observability_proto.EnvoyAdminService_StreamEnvoyAdminRequestsServer) error {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		s.responses <- resp
+	}
+}
+
+var _ = Describe("grpcServer", func() {
+
+	var service *recordingEnvoyAdminService
+	var address string
+	var stop chan struct{}
+	var done chan error
+
+	BeforeEach(func() {
+		port, err := test.GetFreePort()
+		Expect(err).ToNot(HaveOccurred())
+		address = fmt.Sprintf("127.0.0.1:%d", port)
+
+		service = &recordingEnvoyAdminService{
+			responses: make(chan *observability_proto.EnvoyAdminResponse, 1),
+		}
+		server := &grpcServer{
+			server:     envoy_xds.NewServer(context.Background(), envoy_cache.NewSnapshotCache(true, envoy_cache.IDHash{}, nil), nil),
+			metrics:    NewMetricsService(core_manager.NewResourceManager(memory.NewStore()), core_stats.NewAggregator(core_stats.DefaultStaleAfter), &staticAuthenticator{}),
+			envoyAdmin: service,
+			port:       port,
+		}
+		stop = make(chan struct{})
+		done = make(chan error, 1)
+		go func() {
+			done <- server.Start(stop)
+		}()
+	})
+
+	AfterEach(func() {
+		close(stop)
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("should accept responses of Envoy Admin API larger than 4MB", func() {
+		// given
+		var conn *grpc.ClientConn
+		Eventually(func() error {
+			var err error
+			conn, err = grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
+			return err
+		}).Should(Succeed())
+		defer conn.Close()
+
+		// and
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := observability_proto.NewEnvoyAdminServiceClient(conn).StreamEnvoyAdminRequests(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		// and a config dump of a large mesh
+		body := bytes.Repeat([]byte("x"), 5*1024*1024)
+
+		// when
+		err = stream.Send(&observability_proto.EnvoyAdminResponse{
+			RequestId: "1",
+			Body:      body,
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		var resp *observability_proto.EnvoyAdminResponse
+		Eventually(service.responses).Should(Receive(&resp))
+		Expect(resp.RequestId).To(Equal("1"))
+		Expect(resp.Body).To(HaveLen(len(body)))
+	})
+})
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	go_cp_server "github.com/envoyproxy/go-control-plane/pkg/server"
+
+	"github.com/Kong/kuma/pkg/core/xds"
+)
+
+var (
+	dataplanesConnected = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kuma_cp_xds_dataplanes_connected",
+		Help: "Number of Dataplanes connected to the xDS server",
+	}, []string{"mesh"})
+	xdsGeneration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "kuma_cp_xds_generation_seconds",
+		Help: "Time it takes to generate and push Envoy configuration of a Dataplane",
+	})
+	xdsGenerationErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kuma_cp_xds_generation_errors_total",
+		Help: "Number of failures to generate Envoy configuration of a Dataplane",
+	})
+)
+
+// measureGeneration wraps OnTick() of a Dataplane watchdog to export its latency.
+func measureGeneration(onTick func() error) func() error {
+	return func() error {
+		start := time.Now()
+		defer func() {
+			xdsGeneration.Observe(time.Since(start).Seconds())
+		}()
+		return onTick()
+	}
+}
+
+// DataplaneConnectionsTracker keeps track of the number of Dataplanes connected to the xDS server in each Mesh.
+type DataplaneConnectionsTracker struct {
+	mutex         sync.Mutex
+	meshForStream map[int64]string
+}
+
+func NewDataplaneConnectionsTracker() *DataplaneConnectionsTracker {
+	return &DataplaneConnectionsTracker{
+		meshForStream: map[int64]string{},
+	}
+}
+
+var _ go_cp_server.Callbacks = &DataplaneConnectionsTracker{}
+
+func (d *DataplaneConnectionsTracker) OnStreamOpen(context.Context, int64, string) error {
+	return nil
+}
+
+func (d *DataplaneConnectionsTracker) OnStreamClosed(stream int64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if mesh, ok := d.meshForStream[stream]; ok {
+		dataplanesConnected.WithLabelValues(mesh).Dec()
+		delete(d.meshForStream, stream)
+	}
+}
+
+func (d *DataplaneConnectionsTracker) OnStreamRequest(stream int64, req *envoy.DiscoveryRequest) error {
+	if req.Node == nil {
+		// only the first request on a stream is guaranteed to carry the node identifier
+		return nil
+	}
+	proxyId, err := xds.ParseProxyId(req.Node)
+	if err != nil {
+		// a stream of an invalid Dataplane is going to be closed by other callbacks
+		return nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, ok := d.meshForStream[stream]; ok {
+		return nil
+	}
+	d.meshForStream[stream] = proxyId.Mesh
+	dataplanesConnected.WithLabelValues(proxyId.Mesh).Inc()
+	return nil
+}
+
+func (d *DataplaneConnectionsTracker) OnStreamResponse(int64, *envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
+
+func (d *DataplaneConnectionsTracker) OnFetchRequest(context.Context, *envoy.DiscoveryRequest) error {
+	return nil
+}
+
+func (d *DataplaneConnectionsTracker) OnFetchResponse(*envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+
+	envoy_metrics "github.com/envoyproxy/go-control-plane/envoy/service/metrics/v2"
+	prometheus_client "github.com/prometheus/client_model/go"
+)
+
+var (
+	metricsServiceLog = xdsServerLog.WithName("metrics-service")
+)
+
+// dataplaneRefreshInterval defines how often a Dataplane is reloaded to pick up changes of its inbound interfaces.
+const dataplaneRefreshInterval = 1 * time.Minute
+
+// metricsService receives stats that Envoy flushes periodically and passes stats of inbound interfaces
+// to the Stats Aggregator.
+//
+// A stream is authenticated the same way as SDS requests, i.e. by a dataplane token that Envoy sends
+// in `authorization` metadata.
+type metricsService struct {
+	resManager    core_manager.ResourceManager
+	aggregator    core_stats.Aggregator
+	authenticator sds_auth.Authenticator
+
+	// streamCount for identifying streams, since a Dataplane might open a new stream before an old one is closed
+	streamCount int64
+}
+
+var _ envoy_metrics.MetricsServiceServer = &metricsService{}
+
+func NewMetricsService(resManager core_manager.ResourceManager, aggregator core_stats.Aggregator, authenticator sds_auth.Authenticator) envoy_metrics.MetricsServiceServer {
+	return &metricsService{
+		resManager:    resManager,
+		aggregator:    aggregator,
+		authenticator: authenticator,
+	}
+}
+
+func (s *metricsService) StreamMetrics(stream envoy_metrics.MetricsService_StreamMetricsServer) error {
+	streamID := atomic.AddInt64(&s.streamCount, 1)
+	var key core_model.ResourceKey
+	var dataplane *mesh_core.DataplaneResource
+	var loadedAt time.Time
+	defer func() {
+		if dataplane != nil {
+			s.aggregator.Remove(key, streamID)
+		}
+	}()
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&envoy_metrics.StreamMetricsResponse{})
+		}
+		if err != nil {
+			return err
+		}
+		if dataplane == nil {
+			proxyId, err := core_xds.ParseProxyId(msg.GetIdentifier().GetNode())
+			if err != nil {
+				return errors.Wrap(err, "could not identify a Dataplane that streams metrics")
+			}
+			if err := s.authenticate(stream.Context(), *proxyId); err != nil {
+				metricsServiceLog.Info("rejected a stream of metrics", "proxyId", proxyId, "reason", err.Error())
+				return err
+			}
+			key = proxyId.ToResourceKey()
+		}
+		if dataplane == nil || time.Since(loadedAt) > dataplaneRefreshInterval {
+			loaded := &mesh_core.DataplaneResource{}
+			if err := s.resManager.Get(stream.Context(), loaded, core_store.GetBy(key)); err != nil {
+				metricsServiceLog.Error(err, "could not retrieve a Dataplane", "dataplane", key)
+				return err
+			}
+			dataplane = loaded
+			loadedAt = time.Now()
+		}
+		s.aggregator.Update(key, streamID, InboundSamplesOf(dataplane, msg.GetEnvoyMetrics()))
+	}
+}
+
+func (s *metricsService) authenticate(ctx context.Context, proxyId core_xds.ProxyId) error {
+	credential, err := sds_auth.ExtractCredential(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not authenticate a Dataplane that streams metrics")
+	}
+	if _, err := s.authenticator.Authenticate(ctx, proxyId, credential); err != nil {
+		return errors.Wrap(err, "could not authenticate a Dataplane that streams metrics")
+	}
+	return nil
+}
+
+// InboundSamplesOf extracts stats of services behind inbound interfaces of a Dataplane out of stats of its local clusters.
+// HTTP stats are used if there were any requests, otherwise TCP stats, i.e. connections are counted as requests,
+// failed connection attempts as errors and connect time as latency.
+func InboundSamplesOf(dataplane *mesh_core.DataplaneResource, families []*prometheus_client.MetricFamily) map[string]core_stats.Sample {
+	metrics := map[string]*prometheus_client.Metric{}
+	for _, family := range families {
+		if len(family.GetMetric()) > 0 {
+			metrics[family.GetName()] = family.GetMetric()[0]
+		}
+	}
+	ifaces, err := dataplane.Spec.Networking.GetInboundInterfaces()
+	if err != nil {
+		return nil
+	}
+	samples := map[string]core_stats.Sample{}
+	for i, iface := range ifaces {
+		service := dataplane.Spec.Networking.Inbound[i].Tags[mesh_proto.ServiceTag]
+		sample, ok := localClusterSampleOf(metrics, iface.WorkloadPort)
+		if !ok {
+			continue
+		}
+		if existing, ok := samples[service]; ok {
+			// multiple inbound interfaces of the same service
+			if existing.Requests > sample.Requests {
+				sample.Latency = existing.Latency
+			}
+			sample.Requests += existing.Requests
+			sample.Errors += existing.Errors
+		}
+		samples[service] = sample
+	}
+	return samples
+}
+
+func localClusterSampleOf(metrics map[string]*prometheus_client.Metric, port uint32) (core_stats.Sample, bool) {
+	// names of local clusters are `localhost:<port>`, but stat names might be sanitized
+	for _, prefix := range []string{fmt.Sprintf("cluster.localhost:%d.", port), fmt.Sprintf("cluster.localhost_%d.", port)} {
+		if requests, ok := metrics[prefix+"upstream_rq_total"]; ok && requests.GetCounter().GetValue() > 0 {
+			return core_stats.Sample{
+				Requests: uint64(requests.GetCounter().GetValue()),
+				Errors:   uint64(metrics[prefix+"upstream_rq_5xx"].GetCounter().GetValue()),
+				Latency:  latencyOf(metrics[prefix+"upstream_rq_time"]),
+			}, true
+		}
+		if connections, ok := metrics[prefix+"upstream_cx_total"]; ok {
+			return core_stats.Sample{
+				Requests: uint64(connections.GetCounter().GetValue()),
+				Errors:   uint64(metrics[prefix+"upstream_cx_connect_fail"].GetCounter().GetValue()),
+				Latency:  latencyOf(metrics[prefix+"upstream_cx_connect_ms"]),
+			}, true
+		}
+	}
+	return core_stats.Sample{}, false
+}
+
+func latencyOf(histogram *prometheus_client.Metric) core_stats.Latency {
+	latency := core_stats.Latency{}
+	for _, quantile := range histogram.GetSummary().GetQuantile() {
+		switch quantile.GetQuantile() {
+		case 0.5:
+			latency.P50 = quantile.GetValue()
+		case 0.95:
+			latency.P95 = quantile.GetValue()
+		case 0.99:
+			latency.P99 = quantile.GetValue()
+		}
+	}
+	return latency
+}
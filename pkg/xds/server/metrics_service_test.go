@@ -0,0 +1,328 @@
+package server
+
+import (
+	"context"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_stats "github.com/Kong/kuma/pkg/core/stats"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_metrics "github.com/envoyproxy/go-control-plane/envoy/service/metrics/v2"
+	"github.com/golang/protobuf/proto"
+	prometheus_client "github.com/prometheus/client_model/go"
+)
+
+type metricsStream struct {
+	ctx      context.Context
+	messages chan *envoy_metrics.StreamMetricsMessage
+	closed   bool
+	grpc.ServerStream
+}
+
+func (s *metricsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *metricsStream) Recv() (*envoy_metrics.StreamMetricsMessage, error) {
+	msg, ok := <-s.messages
+	if !ok {
+		return nil, io.EOF
+	}
+	return msg, nil
+}
+
+func (s *metricsStream) SendAndClose(*envoy_metrics.StreamMetricsResponse) error {
+	s.closed = true
+	return nil
+}
+
+// staticAuthenticator accepts only a given credential.
+type staticAuthenticator struct {
+	credential sds_auth.Credential
+}
+
+func (a *staticAuthenticator) Authenticate(_ context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) (sds_auth.Identity, error) {
+	if credential != a.credential {
+		return sds_auth.Identity{}, errors.New("invalid credential")
+	}
+	return sds_auth.Identity{Mesh: proxyId.Mesh}, nil
+}
+
+func authenticatedContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", token))
+}
+
+func counter(name string, value float64) *prometheus_client.MetricFamily {
+	return &prometheus_client.MetricFamily{
+		Name: proto.String(name),
+		Type: prometheus_client.MetricType_COUNTER.Enum(),
+		Metric: []*prometheus_client.Metric{{
+			Counter: &prometheus_client.Counter{Value: proto.Float64(value)},
+		}},
+	}
+}
+
+func summary(name string, p50, p95, p99 float64) *prometheus_client.MetricFamily {
+	return &prometheus_client.MetricFamily{
+		Name: proto.String(name),
+		Type: prometheus_client.MetricType_SUMMARY.Enum(),
+		Metric: []*prometheus_client.Metric{{
+			Summary: &prometheus_client.Summary{
+				Quantile: []*prometheus_client.Quantile{
+					{Quantile: proto.Float64(0.5), Value: proto.Float64(p50)},
+					{Quantile: proto.Float64(0.95), Value: proto.Float64(p95)},
+					{Quantile: proto.Float64(0.99), Value: proto.Float64(p99)},
+				},
+			},
+		}},
+	}
+}
+
+var _ = Describe("MetricsService", func() {
+
+	var dataplane *mesh_core.DataplaneResource
+
+	BeforeEach(func() {
+		dataplane = &mesh_core.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{
+							Interface: "192.168.0.1:80:8080",
+							Tags:      map[string]string{"service": "web"},
+						},
+						{
+							Interface: "192.168.0.1:81:8081",
+							Tags:      map[string]string{"service": "web-api"},
+						},
+						{
+							Interface: "192.168.0.1:82:8082",
+							Tags:      map[string]string{"service": "db"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("InboundSamplesOf()", func() {
+
+		It("should extract HTTP and TCP stats of local clusters", func() {
+			// given
+			families := []*prometheus_client.MetricFamily{
+				counter("cluster.localhost:8080.upstream_rq_total", 100),
+				counter("cluster.localhost:8080.upstream_rq_5xx", 5),
+				counter("cluster.localhost:8080.upstream_cx_total", 10),
+				summary("cluster.localhost:8080.upstream_rq_time", 1, 2, 3),
+				counter("cluster.localhost_8081.upstream_cx_total", 20),
+				counter("cluster.localhost_8081.upstream_cx_connect_fail", 1),
+				summary("cluster.localhost_8081.upstream_cx_connect_ms", 4, 5, 6),
+				counter("cluster.backend.upstream_rq_total", 1000),
+			}
+
+			// when
+			samples := InboundSamplesOf(dataplane, families)
+
+			// then
+			Expect(samples).To(Equal(map[string]core_stats.Sample{
+				"web": {
+					Requests: 100,
+					Errors:   5,
+					Latency:  core_stats.Latency{P50: 1, P95: 2, P99: 3},
+				},
+				"web-api": {
+					Requests: 20,
+					Errors:   1,
+					Latency:  core_stats.Latency{P50: 4, P95: 5, P99: 6},
+				},
+			}))
+		})
+	})
+
+	Describe("StreamMetrics()", func() {
+
+		It("should pass stats to the Stats Aggregator until the stream is closed", func() {
+			// setup
+			resManager := core_manager.NewResourceManager(memory.NewStore())
+			err := resManager.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey("default", "default"))
+			Expect(err).ToNot(HaveOccurred())
+			err = resManager.Create(context.Background(), dataplane, core_store.CreateByKey("dp-1", "default"))
+			Expect(err).ToNot(HaveOccurred())
+			aggregator := core_stats.NewAggregator(core_stats.DefaultStaleAfter)
+			service := NewMetricsService(resManager, aggregator, &staticAuthenticator{credential: "token"})
+
+			// given
+			stream := &metricsStream{
+				ctx:      authenticatedContext("token"),
+				messages: make(chan *envoy_metrics.StreamMetricsMessage),
+			}
+			done := make(chan error)
+			go func() {
+				done <- service.StreamMetrics(stream)
+			}()
+
+			// when
+			stream.messages <- &envoy_metrics.StreamMetricsMessage{
+				Identifier: &envoy_metrics.StreamMetricsMessage_Identifier{
+					Node: &envoy_core.Node{Id: "default.dp-1"},
+				},
+				EnvoyMetrics: []*prometheus_client.MetricFamily{
+					counter("cluster.localhost:8080.upstream_rq_total", 100),
+				},
+			}
+			stream.messages <- &envoy_metrics.StreamMetricsMessage{
+				EnvoyMetrics: []*prometheus_client.MetricFamily{
+					counter("cluster.localhost:8080.upstream_rq_total", 200),
+				},
+			}
+
+			// then
+			Eventually(func() int {
+				stats, _ := aggregator.ServiceStats("default", "web")
+				return stats.Dataplanes
+			}).Should(Equal(1))
+
+			// when
+			close(stream.messages)
+
+			// then
+			Eventually(done).Should(Receive(BeNil()))
+			Expect(stream.closed).To(BeTrue())
+			_, ok := aggregator.ServiceStats("default", "web")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should keep stats of a new stream once an old stream of the same Dataplane is closed", func() {
+			// setup
+			resManager := core_manager.NewResourceManager(memory.NewStore())
+			err := resManager.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey("default", "default"))
+			Expect(err).ToNot(HaveOccurred())
+			err = resManager.Create(context.Background(), dataplane, core_store.CreateByKey("dp-1", "default"))
+			Expect(err).ToNot(HaveOccurred())
+			aggregator := core_stats.NewAggregator(core_stats.DefaultStaleAfter)
+			service := NewMetricsService(resManager, aggregator, &staticAuthenticator{credential: "token"})
+
+			// given
+			open := func() (*metricsStream, chan error) {
+				stream := &metricsStream{
+					ctx:      authenticatedContext("token"),
+					messages: make(chan *envoy_metrics.StreamMetricsMessage),
+				}
+				done := make(chan error)
+				go func() {
+					done <- service.StreamMetrics(stream)
+				}()
+				stream.messages <- &envoy_metrics.StreamMetricsMessage{
+					Identifier: &envoy_metrics.StreamMetricsMessage_Identifier{
+						Node: &envoy_core.Node{Id: "default.dp-1"},
+					},
+					EnvoyMetrics: []*prometheus_client.MetricFamily{
+						counter("cluster.localhost:8080.upstream_rq_total", 100),
+					},
+				}
+				return stream, done
+			}
+			oldStream, oldDone := open()
+			Eventually(func() bool {
+				_, ok := aggregator.ServiceStats("default", "web")
+				return ok
+			}).Should(BeTrue())
+			// and Envoy reconnects before the old stream is closed
+			newStream, newDone := open()
+			// and a stream receives the next message only once stats of the previous one have been passed on
+			newStream.messages <- &envoy_metrics.StreamMetricsMessage{
+				EnvoyMetrics: []*prometheus_client.MetricFamily{
+					counter("cluster.localhost:8080.upstream_rq_total", 200),
+				},
+			}
+
+			// when
+			close(oldStream.messages)
+
+			// then
+			Eventually(oldDone).Should(Receive(BeNil()))
+			stats, ok := aggregator.ServiceStats("default", "web")
+			Expect(ok).To(BeTrue())
+			Expect(stats.Dataplanes).To(Equal(1))
+
+			// when
+			close(newStream.messages)
+
+			// then
+			Eventually(newDone).Should(Receive(BeNil()))
+			_, ok = aggregator.ServiceStats("default", "web")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should reject streams of unknown Dataplanes", func() {
+			// setup
+			resManager := core_manager.NewResourceManager(memory.NewStore())
+			service := NewMetricsService(resManager, core_stats.NewAggregator(core_stats.DefaultStaleAfter), &staticAuthenticator{credential: "token"})
+
+			// given
+			stream := &metricsStream{
+				ctx:      authenticatedContext("token"),
+				messages: make(chan *envoy_metrics.StreamMetricsMessage, 1),
+			}
+			stream.messages <- &envoy_metrics.StreamMetricsMessage{
+				Identifier: &envoy_metrics.StreamMetricsMessage_Identifier{
+					Node: &envoy_core.Node{Id: "default.dp-1"},
+				},
+			}
+
+			// when
+			err := service.StreamMetrics(stream)
+
+			// then
+			Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should reject streams that are not authenticated", func() {
+			// setup
+			resManager := core_manager.NewResourceManager(memory.NewStore())
+			err := resManager.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey("default", "default"))
+			Expect(err).ToNot(HaveOccurred())
+			err = resManager.Create(context.Background(), dataplane, core_store.CreateByKey("dp-1", "default"))
+			Expect(err).ToNot(HaveOccurred())
+			aggregator := core_stats.NewAggregator(core_stats.DefaultStaleAfter)
+			service := NewMetricsService(resManager, aggregator, &staticAuthenticator{credential: "token"})
+
+			// given
+			stream := &metricsStream{
+				ctx:      authenticatedContext("forged-token"),
+				messages: make(chan *envoy_metrics.StreamMetricsMessage, 1),
+			}
+			stream.messages <- &envoy_metrics.StreamMetricsMessage{
+				Identifier: &envoy_metrics.StreamMetricsMessage_Identifier{
+					Node: &envoy_core.Node{Id: "default.dp-1"},
+				},
+				EnvoyMetrics: []*prometheus_client.MetricFamily{
+					counter("cluster.localhost:8080.upstream_rq_total", 100),
+				},
+			}
+
+			// when
+			err = service.StreamMetrics(stream)
+
+			// then
+			Expect(err).To(MatchError("could not authenticate a Dataplane that streams metrics: invalid credential"))
+			// and
+			_, ok := aggregator.ServiceStats("default", "web")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
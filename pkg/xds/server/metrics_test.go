@@ -0,0 +1,46 @@
+package server_test
+
+import (
+	"strings"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/Kong/kuma/pkg/xds/server"
+)
+
+var _ = Describe("Dataplane Connections Tracker", func() {
+
+	It("should count connected Dataplanes per Mesh", func() {
+		// given
+		tracker := server.NewDataplaneConnectionsTracker()
+		request := func(node string) *envoy.DiscoveryRequest {
+			return &envoy.DiscoveryRequest{
+				Node: &envoy_core.Node{Id: node},
+			}
+		}
+
+		// when
+		Expect(tracker.OnStreamRequest(1, request("mesh-1.dp-1"))).To(Succeed())
+		Expect(tracker.OnStreamRequest(1, request("mesh-1.dp-1"))).To(Succeed())
+		Expect(tracker.OnStreamRequest(1, &envoy.DiscoveryRequest{})).To(Succeed())
+		Expect(tracker.OnStreamRequest(2, request("mesh-1.dp-2"))).To(Succeed())
+		Expect(tracker.OnStreamRequest(3, request("mesh-2.dp-1"))).To(Succeed())
+		tracker.OnStreamClosed(3)
+
+		// then
+		expected := `
+# HELP kuma_cp_xds_dataplanes_connected Number of Dataplanes connected to the xDS server
+# TYPE kuma_cp_xds_dataplanes_connected gauge
+kuma_cp_xds_dataplanes_connected{mesh="mesh-1"} 2
+kuma_cp_xds_dataplanes_connected{mesh="mesh-2"} 0
+`
+		err := testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected), "kuma_cp_xds_dataplanes_connected")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
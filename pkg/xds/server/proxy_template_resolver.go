@@ -42,7 +42,7 @@ func (r *simpleProxyTemplateResolver) GetTemplate(proxy *model.Proxy) *mesh_prot
 }
 
 // FindBestMatch given a Dataplane definition and a list of ProxyTemplates returns the "best matching" ProxyTemplate.
-// A ProxyTemplate is considered a match if one of the inbound interfaces of a Dataplane has all tags of ProxyTemplate's selector.
+// A ProxyTemplate is considered a match if one of the inbound interfaces (or the gateway) of a Dataplane has all tags of ProxyTemplate's selector.
 // Every matching ProxyTemplate gets a rank (score) defined as a maximum number of tags in a matching selector.
 // ProxyTemplate with an empty list of selectors is considered a match with a rank (score) of 0.
 // ProxyTemplate with an empty selector (one that has no tags) is considered a match with a rank (score) of 0.
@@ -67,8 +67,8 @@ func FindBestMatch(proxy *model.Proxy, templates []*mesh_core.ProxyTemplateResou
 				}
 				continue
 			}
-			for _, inbound := range proxy.Dataplane.Spec.Networking.GetInbound() {
-				if matches, score := ScoreMatch(selector.Match, inbound.Tags); matches && bestScore < score {
+			for _, tags := range dataplaneTags(proxy.Dataplane) {
+				if matches, score := ScoreMatch(selector.Match, tags); matches && bestScore < score {
 					bestMatch = template
 					bestScore = score
 				}
@@ -78,6 +78,17 @@ func FindBestMatch(proxy *model.Proxy, templates []*mesh_core.ProxyTemplateResou
 	return bestMatch
 }
 
+func dataplaneTags(dataplane *mesh_core.DataplaneResource) []map[string]string {
+	var tags []map[string]string
+	for _, inbound := range dataplane.Spec.Networking.GetInbound() {
+		tags = append(tags, inbound.Tags)
+	}
+	if gateway := dataplane.Spec.Networking.GetGateway(); gateway != nil {
+		tags = append(tags, gateway.Tags)
+	}
+	return tags
+}
+
 func ScoreMatch(selector map[string]string, target map[string]string) (bool, int) {
 	for key, requiredValue := range selector {
 		if actualValue, hasKey := target[key]; !hasKey || actualValue != requiredValue {
@@ -432,6 +432,57 @@ var _ = Describe("Reconcile", func() {
 					},
 				},
 			}),
+			Entry("gateway Dataplane should match templates by gateway tags", testCase{
+				proxy: &model.Proxy{Dataplane: &mesh_core.DataplaneResource{
+					Spec: mesh_proto.Dataplane{
+						Networking: &mesh_proto.Dataplane_Networking{
+							Gateway: &mesh_proto.Dataplane_Networking_Gateway{
+								Tags: map[string]string{
+									"service": "kuma-gateway.kuma-gateway.svc:80",
+								},
+							},
+						},
+					},
+				}},
+				templates: []*mesh_core.ProxyTemplateResource{
+					{
+						Meta: &test_model.ResourceMeta{
+							Mesh: "demo",
+							Name: "first",
+						},
+					},
+					{
+						Meta: &test_model.ResourceMeta{
+							Mesh: "demo",
+							Name: "gateway",
+						},
+						Spec: mesh_proto.ProxyTemplate{
+							Selectors: []*mesh_proto.Selector{
+								{
+									Match: map[string]string{
+										"service": "kuma-gateway.kuma-gateway.svc:80",
+									},
+								},
+							},
+						},
+					},
+				},
+				expected: &mesh_core.ProxyTemplateResource{
+					Meta: &test_model.ResourceMeta{
+						Mesh: "demo",
+						Name: "gateway",
+					},
+					Spec: mesh_proto.ProxyTemplate{
+						Selectors: []*mesh_proto.Selector{
+							{
+								Match: map[string]string{
+									"service": "kuma-gateway.kuma-gateway.svc:80",
+								},
+							},
+						},
+					},
+				},
+			}),
 			Entry("none of templates have matching selectors", testCase{
 				proxy: &model.Proxy{Dataplane: &mesh_core.DataplaneResource{}},
 				templates: []*mesh_core.ProxyTemplateResource{
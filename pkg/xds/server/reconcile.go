@@ -26,7 +26,7 @@ type SnapshotReconciler interface {
 var _ SnapshotReconciler = &reconciler{}
 
 type reconciler struct {
-	generator snapshotGenerator
+	generator SnapshotGenerator
 	cacher    snapshotCacher
 }
 
@@ -90,7 +90,8 @@ func equalSnapshots(old, new map[string]envoy_cache.Resource) bool {
 	return true
 }
 
-type snapshotGenerator interface {
+// SnapshotGenerator generates Envoy configuration of a Dataplane.
+type SnapshotGenerator interface {
 	GenerateSnapshot(ctx xds_context.Context, proxy *model.Proxy) (envoy_cache.Snapshot, error)
 }
 
@@ -77,6 +77,11 @@ func BuildRouteMap(dataplane *mesh_core.DataplaneResource, routes []*mesh_core.T
 func BuildDestinationMap(dataplane *mesh_core.DataplaneResource, routes core_xds.RouteMap) core_xds.DestinationMap {
 	destinations := core_xds.DestinationMap{}
 	for _, oface := range dataplane.Spec.Networking.GetOutbound() {
+		if len(oface.Tags) > 0 {
+			// outbound to a subset of a service is not subject to TrafficRoutes
+			destinations[oface.Service] = destinations[oface.Service].Add(mesh_proto.MatchTags(oface.DestinationTags()))
+			continue
+		}
 		route, ok := routes[oface.Service]
 		if ok {
 			for _, destination := range route.Spec.Conf {
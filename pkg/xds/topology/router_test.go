@@ -659,6 +659,36 @@ var _ = Describe("TrafficRoute", func() {
 					},
 				},
 			}),
+			Entry("Dataplane with outbound interfaces to subsets of a service", testCase{
+				dataplane: &mesh_core.DataplaneResource{
+					Spec: mesh_proto.Dataplane{
+						Networking: &mesh_proto.Dataplane_Networking{
+							Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+								{Service: "redis", Interface: "10.0.0.1:6379", Tags: map[string]string{"kuma.io/instance": "redis-0"}},
+								{Service: "redis", Interface: "10.0.0.2:6379", Tags: map[string]string{"kuma.io/instance": "redis-1"}},
+							},
+						},
+					},
+				},
+				routes: core_xds.RouteMap{
+					"redis": &mesh_core.TrafficRouteResource{
+						Spec: mesh_proto.TrafficRoute{
+							Conf: []*mesh_proto.TrafficRoute_WeightedDestination{
+								{
+									Weight:      100,
+									Destination: mesh_proto.TagSelector{"service": "redis", "role": "master"},
+								},
+							},
+						},
+					},
+				},
+				expected: core_xds.DestinationMap{
+					"redis": []mesh_proto.TagSelector{
+						{"service": "redis", "kuma.io/instance": "redis-0"},
+						{"service": "redis", "kuma.io/instance": "redis-1"},
+					},
+				},
+			}),
 			Entry("Dataplane with outbound interfaces and TrafficRoutes", testCase{
 				dataplane: &mesh_core.DataplaneResource{
 					Spec: mesh_proto.Dataplane{
@@ -26,6 +26,10 @@ function gen_help() {
 
 gen_help kumactl
 gen_help kumactl apply
+gen_help kumactl diff
+gen_help kumactl export
+gen_help kumactl export mesh
+gen_help kumactl import
 gen_help kumactl config
 gen_help kumactl config view
 gen_help kumactl config control-planes